@@ -12,6 +12,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -28,6 +29,7 @@ import (
 	"github.com/sensu/sensu-go/transport"
 	"github.com/sensu/sensu-go/util/retry"
 	utilstrings "github.com/sensu/sensu-go/util/strings"
+	"github.com/sensu/sensu-go/util/systemd"
 	"github.com/sirupsen/logrus"
 )
 
@@ -47,20 +49,28 @@ type Agent struct {
 	api             *http.Server
 	assetGetter     asset.Getter
 	backendSelector BackendSelector
+	commandFilter   *commandFilter
 	config          *Config
 	connected       bool
 	connectedMu     sync.RWMutex
 	contentType     string
+	resultVersion   int
+	signingKey      *transport.SigningKey
+	signatureNonce  string
+	signatureSeq    uint64
 	entity          *corev2.Entity
 	executor        command.Executor
 	handler         *handler.MessageHandler
 	header          http.Header
 	inProgress      map[string]*corev2.CheckConfig
 	inProgressMu    *sync.Mutex
+	cancelFuncs     map[string]context.CancelFunc
+	lastIssued      map[string]int64
 	statsdServer    StatsdServer
 	sendq           chan *transport.Message
 	systemInfo      *corev2.System
 	systemInfoMu    sync.RWMutex
+	cloudMetadata   map[string]string
 	wg              sync.WaitGroup
 	apiQueue        queue
 	marshal         agentd.MarshalFunc
@@ -84,14 +94,22 @@ func NewAgentContext(ctx context.Context, config *Config) (*Agent, error) {
 		handler:         handler.NewMessageHandler(),
 		inProgress:      make(map[string]*corev2.CheckConfig),
 		inProgressMu:    &sync.Mutex{},
+		cancelFuncs:     make(map[string]context.CancelFunc),
+		lastIssued:      make(map[string]int64),
 		sendq:           make(chan *transport.Message, 10),
 		systemInfo:      &corev2.System{},
 		unmarshal:       agentd.UnmarshalJSON,
 		marshal:         agentd.MarshalJSON,
 	}
 
+	if config.Kubernetes != nil && config.Kubernetes.Sidecar {
+		applySidecarConfig(config)
+	}
+
 	agent.statsdServer = NewStatsdServer(agent)
 	agent.handler.AddHandler(corev2.CheckRequestType, agent.handleCheck)
+	agent.handler.AddHandler(transport.MessageTypeRejection, agent.handleRejection)
+	agent.handler.AddHandler(corev2.CheckCancellationType, agent.handleCheckCancellation)
 
 	// We don't check for errors here and let the agent get created regardless
 	// of system info status.
@@ -113,6 +131,18 @@ func NewAgentContext(ctx context.Context, config *Config) (*Agent, error) {
 	}
 	agent.allowList = allowList
 
+	agent.commandFilter, err = newCommandFilter(config.CommandAllowPatterns, config.CommandDenyPatterns, config.AssetCommandsOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.SigningKeyFile != "" {
+		agent.signingKey, err = transport.LoadSigningKey(config.SigningKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error creating agent: %s", err)
+		}
+	}
+
 	return agent, nil
 }
 
@@ -122,6 +152,16 @@ func (a *Agent) sendMessage(msg *transport.Message) {
 		"content_type": a.contentType,
 		"payload_size": len(msg.Payload),
 	}).Info("sending message")
+
+	// Agents with local checks buffer their events while disconnected, so
+	// that they're sent once the agent is connected, even after a restart.
+	if a.config.LocalChecksDir != "" && msg.Type == transport.MessageTypeEvent && msg.SendCallback == nil && !a.Connected() {
+		_, err := a.apiQueue.Send(compressMessage(msg.Payload))
+		if err == nil {
+			return
+		}
+		logger.WithError(err).Error("error buffering message")
+	}
 	a.sendq <- msg
 }
 
@@ -131,12 +171,30 @@ func (a *Agent) refreshSystemInfo(ctx context.Context) error {
 		return err
 	}
 
-	if a.config.DetectCloudProvider {
+	if a.config.DetectCloudProvider || a.config.CloudMetadata {
 		info.CloudProvider = system.GetCloudProvider(ctx)
 	}
 
+	var cloudMetadata map[string]string
+	if a.config.CloudMetadata && info.CloudProvider != "" {
+		cloudMetadata, err = system.GetCloudMetadata(ctx, info.CloudProvider)
+		if err != nil {
+			logger.WithError(err).Warn("couldn't retrieve cloud metadata")
+		}
+	}
+
 	a.systemInfoMu.Lock()
 	a.systemInfo = &info
+	if cloudMetadata != nil {
+		a.cloudMetadata = cloudMetadata
+		if a.entity != nil {
+			// The entity may be in use by other goroutines, swap in a copy
+			// with the new labels rather than updating it
+			entity := *a.entity
+			entity.Labels = a.entityLabels()
+			a.entity = &entity
+		}
+	}
 	a.systemInfoMu.Unlock()
 
 	return nil
@@ -175,6 +233,10 @@ func (a *Agent) buildTransportHeaderMap() http.Header {
 		logger.Info("using tls client auth")
 	}
 	header.Set(transport.HeaderKeySubscriptions, strings.Join(a.config.Subscriptions, ","))
+	if a.config.EntityClass != "" {
+		header.Set(transport.HeaderKeyEntityClass, a.config.EntityClass)
+	}
+	header.Set(transport.HeaderKeyCheckResultVersions, transport.FormatCheckResultVersions(transport.SupportedCheckResultVersions))
 
 	return header
 }
@@ -190,6 +252,8 @@ func (a *Agent) buildTransportHeaderMap() http.Header {
 // 7. Start refreshing system info periodically.
 // 8. Start sending periodic keepalives.
 // 9. Start the API server, shutdown the agent if doing so fails.
+// 10. Start watching kubernetes events, if enabled.
+// 11. Start scheduling the local checks, if any.
 func (a *Agent) Run(ctx context.Context) error {
 	defer func() {
 		if err := a.apiQueue.Close(); err != nil {
@@ -202,6 +266,11 @@ func (a *Agent) Run(ctx context.Context) error {
 	if err := corev2.ValidateName(a.config.AgentName); err != nil {
 		return fmt.Errorf("invalid agent name: %v", err)
 	}
+	if class := a.config.EntityClass; class != "" {
+		if err := corev2.ValidateName(class); err != nil {
+			return fmt.Errorf("invalid entity class: %v", err)
+		}
+	}
 	if timeout := a.config.KeepaliveWarningTimeout; timeout < 5 {
 		return fmt.Errorf("bad keepalive timeout: %d (minimum value is 5 seconds)", timeout)
 	}
@@ -209,9 +278,22 @@ func (a *Agent) Run(ctx context.Context) error {
 		return fmt.Errorf("bad keepalive critical timeout: %d (minimum value is 5 seconds)", timeout)
 	}
 
+	var localChecks []*corev2.CheckConfig
+	if dir := a.config.LocalChecksDir; dir != "" {
+		var err error
+		localChecks, err = loadLocalChecks(dir, a.config.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+
 	if !a.config.DisableAssets {
 		assetManager := asset.NewManager(a.config.CacheDir, a.getAgentEntity(), &a.wg)
-		var err error
+		client, err := a.config.AssetHTTP.NewClient(0)
+		if err != nil {
+			return fmt.Errorf("bad asset HTTP configuration: %s", err)
+		}
+		assetManager.Fetcher = asset.NewHTTPFetcher(client)
 		a.assetGetter, err = assetManager.StartAssetManager(ctx)
 		if err != nil {
 			return err
@@ -227,6 +309,21 @@ func (a *Agent) Run(ctx context.Context) error {
 	go a.refreshSystemInfoPeriodically(ctx)
 	go a.handleAPIQueue(ctx)
 
+	if a.config.Kubernetes != nil && a.config.Kubernetes.Events {
+		go a.watchKubernetesEvents(ctx)
+	}
+
+	a.scheduleLocalChecks(ctx, localChecks)
+
+	// The agent API and sockets are started beforehand, so the agent is
+	// serving, even though it may not be connected to a backend yet
+	systemd.Notify(systemd.Ready)
+	go systemd.Watchdog(ctx, nil)
+	go func() {
+		<-ctx.Done()
+		systemd.Notify(systemd.Stopping)
+	}()
+
 	a.wg.Wait()
 	return nil
 }
@@ -246,17 +343,17 @@ func (a *Agent) connectionManager(ctx context.Context) {
 			log.Fatal(err)
 		}
 
-		ctx, cancel := context.WithCancel(ctx)
+		connCtx, cancel := context.WithCancel(ctx)
 
 		// Start sending hearbeats to the backend
-		conn.Heartbeat(ctx, a.config.BackendHeartbeatInterval, a.config.BackendHeartbeatTimeout)
+		conn.Heartbeat(connCtx, a.config.BackendHeartbeatInterval, a.config.BackendHeartbeatTimeout)
 
 		a.connectedMu.Lock()
 		a.connected = true
 		a.connectedMu.Unlock()
 
-		go a.receiveLoop(ctx, cancel, conn)
-		if err := a.sendLoop(ctx, cancel, conn); err != nil && err != ctx.Err() {
+		go a.receiveLoop(connCtx, cancel, conn)
+		if err := a.sendLoop(ctx, connCtx, cancel, conn); err != nil && err != connCtx.Err() {
 			logger.WithError(err).Error("error sending messages")
 		}
 	}
@@ -299,29 +396,39 @@ func logEvent(e *corev2.Event) {
 	logger.WithFields(fields).Info("sending event to backend")
 }
 
-func (a *Agent) sendLoop(ctx context.Context, cancel context.CancelFunc, conn transport.Transport) error {
+// sendLoop sends the queued messages and the keepalives over conn until
+// connCtx is canceled. ctx is canceled when the agent shuts down.
+func (a *Agent) sendLoop(ctx, connCtx context.Context, cancel context.CancelFunc, conn transport.Transport) error {
 	defer cancel()
 	keepalive := time.NewTicker(time.Duration(a.config.KeepaliveInterval) * time.Second)
 	defer keepalive.Stop()
-	if err := conn.Send(a.newKeepalive()); err != nil {
+	if err := conn.Send(a.sign(a.newKeepalive())); err != nil {
 		logger.WithError(err).Error("error sending message over websocket")
 		return err
 	}
 	for {
 		select {
-		case <-ctx.Done():
+		case <-connCtx.Done():
+			if ctx.Err() != nil && a.deregistersOnShutdown() {
+				// Deregister the entity of the agent right away, it won't outlive it
+				msg := a.newKeepalive()
+				msg.Type = transport.MessageTypeDeregistration
+				if err := conn.Send(a.sign(msg)); err != nil {
+					logger.WithError(err).Error("error sending deregistration message")
+				}
+			}
 			if err := conn.Close(); err != nil {
 				logger.WithError(err).Error("error closing websocket connection")
 				return err
 			}
 			return nil
 		case msg := <-a.sendq:
-			if err := conn.Send(msg); err != nil {
+			if err := conn.Send(a.sign(a.versionCheckResult(msg))); err != nil {
 				logger.WithError(err).Error("error sending message over websocket")
 				return err
 			}
 		case <-keepalive.C:
-			if err := conn.Send(a.newKeepalive()); err != nil {
+			if err := conn.Send(a.sign(a.newKeepalive())); err != nil {
 				logger.WithError(err).Error("error sending message over websocket")
 				return err
 			}
@@ -329,6 +436,31 @@ func (a *Agent) sendLoop(ctx context.Context, cancel context.CancelFunc, conn tr
 	}
 }
 
+// versionCheckResult encodes the event messages with the check result schema
+// version negotiated with the backend. Backends predating the negotiation
+// only receive event messages.
+func (a *Agent) versionCheckResult(msg *transport.Message) *transport.Message {
+	if msg.Type != transport.MessageTypeEvent || a.resultVersion < transport.CheckResultV2 {
+		return msg
+	}
+	return &transport.Message{
+		Type:         transport.MessageTypeCheckResult,
+		Payload:      transport.EncodeCheckResult(a.resultVersion, msg.Payload),
+		SendCallback: msg.SendCallback,
+	}
+}
+
+// sign signs the message if the agent has a signing key, bound to the nonce
+// of the session and to the next sequence number. Backends predating the
+// signed messages receive unsigned messages.
+func (a *Agent) sign(msg *transport.Message) *transport.Message {
+	if a.signingKey == nil || a.signatureNonce == "" {
+		return msg
+	}
+	a.signatureSeq++
+	return a.signingKey.Sign(msg, a.signatureNonce, a.signatureSeq)
+}
+
 func (a *Agent) newKeepalive() *transport.Message {
 	msg := &transport.Message{
 		Type: transport.MessageTypeKeepalive,
@@ -348,6 +480,7 @@ func (a *Agent) newKeepalive() *transport.Message {
 		Timeout:    a.config.KeepaliveWarningTimeout,
 		Ttl:        int64(a.config.KeepaliveCriticalTimeout),
 	}
+	keepalive.Check.Annotations[corev2.KeepaliveTimeoutSetAnnotation] = strconv.FormatBool(a.config.KeepaliveWarningTimeoutSet)
 	keepalive.Entity = a.getAgentEntity()
 	keepalive.Timestamp = time.Now().Unix()
 
@@ -470,6 +603,17 @@ func (a *Agent) connectWithBackoff(ctx context.Context) (transport.Transport, er
 		a.header.Set("Content-Type", a.contentType)
 		logger.WithField("header", fmt.Sprintf("Content-Type: %s", a.contentType)).Debug("setting header")
 
+		a.resultVersion = transport.ParseCheckResultVersion(respHeader.Get(transport.HeaderKeyCheckResultVersion), transport.SupportedCheckResultVersions)
+		logger.WithField("version", a.resultVersion).Debug("negotiated check result schema version")
+
+		// The sequence numbers of the signed messages start over with each
+		// session
+		a.signatureNonce = respHeader.Get(transport.HeaderKeySignedMessages)
+		a.signatureSeq = 0
+		if a.signingKey != nil && a.signatureNonce == "" {
+			logger.Warn("the backend doesn't accept signed messages, sending unsigned messages")
+		}
+
 		return true, nil
 	})
 
@@ -11,6 +11,8 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sensu/lasr"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/agentd"
 	"github.com/sensu/sensu-go/transport"
 	"github.com/sensu/sensu-go/types"
 	"github.com/sensu/sensu-go/version"
@@ -117,7 +119,7 @@ func (a *Agent) handleAPIQueue(ctx context.Context) {
 			}
 			msg := &transport.Message{
 				Type:    transport.MessageTypeEvent,
-				Payload: decompressMessage(message.Body),
+				Payload: a.queuedPayload(decompressMessage(message.Body)),
 				SendCallback: func(err error) {
 					if err != nil {
 						logger.WithError(err).Error("couldn't send queued message, retrying")
@@ -135,6 +137,25 @@ func (a *Agent) handleAPIQueue(ctx context.Context) {
 	}
 }
 
+// queuedPayload returns the payload of a queued event, marshaled for the
+// current connection: the events queued before the agent negotiated protobuf
+// serialization with the backend are marshaled as JSON.
+func (a *Agent) queuedPayload(payload []byte) []byte {
+	if a.contentType != agentd.ProtobufSerializationHeader || len(payload) == 0 || payload[0] != '{' {
+		return payload
+	}
+	event := &corev2.Event{}
+	if err := agentd.UnmarshalJSON(payload, event); err != nil {
+		return payload
+	}
+	b, err := a.marshal(event)
+	if err != nil {
+		logger.WithError(err).Error("error marshaling queued event")
+		return payload
+	}
+	return b
+}
+
 // addEvent accepts an event and send it to the backend over the event channel
 func addEvent(a *Agent) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// handleCheckCancellation is the check cancellation message handler. The
+// backend cancels the executions of a check in flight once the check is
+// unpublished or deleted, so that their results don't produce late events.
+func (a *Agent) handleCheckCancellation(ctx context.Context, payload []byte) error {
+	var cancellation corev2.CheckCancellation
+	if err := json.Unmarshal(payload, &cancellation); err != nil {
+		return err
+	}
+
+	cancelled := 0
+	a.inProgressMu.Lock()
+	for key, check := range a.inProgress {
+		if check.Name != cancellation.Name || check.Namespace != cancellation.Namespace {
+			continue
+		}
+		if cancel, ok := a.cancelFuncs[key]; ok {
+			cancel()
+			cancelled++
+		}
+	}
+	for key := range a.lastIssued {
+		if key == cancellation.Name || strings.HasPrefix(key, cancellation.Name+"/") {
+			delete(a.lastIssued, key)
+		}
+	}
+	a.inProgressMu.Unlock()
+
+	if cancelled > 0 {
+		logger.WithFields(logrus.Fields{
+			"check":     cancellation.Name,
+			"namespace": cancellation.Namespace,
+			"reason":    cancellation.Reason,
+		}).Infof("cancelled %d check execution(s)", cancelled)
+	}
+	return nil
+}
+
+// cancellableExecution returns the context of the execution of the check
+// request, cancelled by the check cancellations of the backend, and the
+// function releasing it once the execution is over.
+func (a *Agent) cancellableExecution(request *corev2.CheckRequest) (context.Context, func()) {
+	key := checkKey(request)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	a.inProgressMu.Lock()
+	a.cancelFuncs[key] = cancel
+	a.inProgressMu.Unlock()
+
+	return ctx, func() {
+		a.inProgressMu.Lock()
+		delete(a.cancelFuncs, key)
+		a.inProgressMu.Unlock()
+		cancel()
+	}
+}
@@ -0,0 +1,60 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/command"
+	"github.com/sensu/sensu-go/testing/mockexecutor"
+	"github.com/sensu/sensu-go/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleCheckCancellation(t *testing.T) {
+	config, cleanup := FixtureConfig()
+	defer cleanup()
+	agent, err := NewAgent(config)
+	require.NoError(t, err)
+
+	ex := &mockexecutor.MockExecutor{}
+	ex.Return(command.FixtureExecutionResponse(0, "late result"), nil)
+	ex.SetRequestFunc(func(ctx context.Context, _ command.ExecutionRequest) {
+		<-ctx.Done()
+	})
+	agent.executor = ex
+	ch := make(chan *transport.Message, 5)
+	agent.sendq = ch
+
+	request := &corev2.CheckRequest{Config: corev2.FixtureCheckConfig("check-cpu"), Issued: time.Now().Unix()}
+	payload, err := json.Marshal(request)
+	require.NoError(t, err)
+	require.NoError(t, agent.handleCheck(context.TODO(), payload))
+
+	executing := func() bool {
+		agent.inProgressMu.Lock()
+		defer agent.inProgressMu.Unlock()
+		_, ok := agent.cancelFuncs[checkKey(request)]
+		return ok
+	}
+	require.Eventually(t, executing, time.Second, 10*time.Millisecond)
+
+	// The cancellations of other checks are ignored
+	other, err := json.Marshal(corev2.CheckCancellation{Name: "check-disk", Namespace: "default"})
+	require.NoError(t, err)
+	require.NoError(t, agent.handleCheckCancellation(context.TODO(), other))
+	assert.True(t, executing())
+
+	cancellation, err := json.Marshal(corev2.CheckCancellation{Name: "check-cpu", Namespace: "default", Reason: "check deleted"})
+	require.NoError(t, err)
+	require.NoError(t, agent.handleCheckCancellation(context.TODO(), cancellation))
+	assert.Eventually(t, func() bool {
+		return !agent.checkInProgress(request)
+	}, time.Second, 10*time.Millisecond)
+
+	// The result of the cancelled execution is discarded
+	assert.Empty(t, ch)
+}
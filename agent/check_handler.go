@@ -34,6 +34,13 @@ func (a *Agent) handleCheck(ctx context.Context, payload []byte) error {
 		return errors.New("given check configuration appears invalid")
 	}
 
+	// The backend may replay the requests the agent received right before a
+	// disconnection
+	if a.duplicateReplay(request) {
+		logger.WithField("check", request.Config.Name).Info("discarding replayed check request already received")
+		return nil
+	}
+
 	checkConfig := request.Config
 	sendFailure := func(err error) {
 		check := corev2.NewCheck(checkConfig)
@@ -65,7 +72,12 @@ func (a *Agent) handleCheck(ctx context.Context, payload []byte) error {
 
 	logger.Info("scheduling check execution: ", checkConfig.Name)
 
+	// Proxy checks are executed for their proxy entity, so its attributes are
+	// used for token substitution rather than the ones of the agent entity
 	entity := a.getAgentEntity()
+	if request.ProxyEntity != nil {
+		entity = request.ProxyEntity
+	}
 	go a.executeCheck(ctx, request, entity)
 
 	return nil
@@ -78,6 +90,23 @@ func (a *Agent) checkInProgress(req *corev2.CheckRequest) bool {
 	return ok
 }
 
+// duplicateReplay records the issue time of the check request, and returns
+// true if it is a request replayed by the backend that the agent already
+// received.
+func (a *Agent) duplicateReplay(request *corev2.CheckRequest) bool {
+	key := checkKey(request)
+	a.inProgressMu.Lock()
+	defer a.inProgressMu.Unlock()
+	last := a.lastIssued[key]
+	if _, ok := request.Config.Annotations[corev2.ReplayedAnnotation]; ok && request.Issued <= last {
+		return true
+	}
+	if request.Issued > last {
+		a.lastIssued[key] = request.Issued
+	}
+	return false
+}
+
 func checkKey(request *corev2.CheckRequest) string {
 	parts := []string{request.Config.Name}
 	if len(request.Config.ProxyEntityName) > 0 {
@@ -102,6 +131,11 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 	a.addInProgress(request)
 	defer a.removeInProgress(request)
 
+	// The backend cancels the execution if the check is unpublished or
+	// deleted in the meantime
+	execCtx, release := a.cancellableExecution(request)
+	defer release()
+
 	checkAssets := request.Assets
 	checkConfig := request.Config
 	checkHooks := request.Hooks
@@ -164,6 +198,13 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 		return
 	}
 
+	// Match check command against the command filter
+	if err := a.commandFilter.check(checkConfig.Command, assets); err != nil {
+		logger.WithFields(fields).WithError(err).Warn("check command denied by the agent command filter")
+		a.sendFailure(event, fmt.Errorf("%s: %s", commandFilterOnDenyOutput, err))
+		return
+	}
+
 	// Prepare environment variables
 	var env []string
 	if match && !matchedEntry.EnableEnv {
@@ -205,10 +246,13 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 		InProgress:   a.inProgress,
 		InProgressMu: a.inProgressMu,
 		Name:         checkConfig.Name,
+		Sandbox:      checkSandbox(a.config, checkConfig),
 	}
 
-	// If stdin is true, add JSON event data to command execution.
+	// If stdin is true, add JSON event data to command execution, with the
+	// proxy entity of proxy checks.
 	if checkConfig.Stdin {
+		event.Entity = request.ProxyEntity
 		input, err := json.Marshal(event)
 		if err != nil {
 			a.sendFailure(event, fmt.Errorf("error marshaling json from event: %s", err))
@@ -217,7 +261,11 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 		ex.Input = string(input)
 	}
 
-	checkExec, err := a.executor.Execute(context.Background(), ex)
+	checkExec, err := a.executor.Execute(execCtx, ex)
+	if execCtx.Err() != nil {
+		logger.WithFields(fields).Info("check execution cancelled by the backend, discarding its result")
+		return
+	}
 	if err != nil {
 		event.Check.Output = err.Error()
 		checkExec.Status = 3
@@ -235,8 +283,13 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 		event.ID = id[:]
 	}
 
+	var perfdata []*corev2.MetricPoint
+	if check.StructuredOutput {
+		perfdata = parseStructuredOutput(event)
+	}
+
 	// Instantiate metrics in the event if the check is attempting to extract metrics
-	if check.OutputMetricFormat != "" || len(check.OutputMetricHandlers) != 0 {
+	if check.OutputMetricFormat != "" || len(check.OutputMetricHandlers) != 0 || len(perfdata) != 0 {
 		event.Metrics = &corev2.Metrics{}
 	}
 
@@ -244,6 +297,12 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 		event.Metrics.Points = extractMetrics(event)
 	}
 
+	if len(perfdata) != 0 {
+		event.Metrics.Points = append(event.Metrics.Points, perfdata...)
+	}
+
+	addOutputMetricTags(event)
+
 	if len(check.OutputMetricHandlers) != 0 {
 		event.Metrics.Handlers = check.OutputMetricHandlers
 	}
@@ -259,6 +318,10 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 		event.Check.Output = ""
 	}
 
+	// Let the post-processing command rewrite the result last, once the
+	// outputs of the hooks are known, so that nothing is sent unscrubbed
+	a.postProcess(event)
+
 	msg, err := a.marshal(event)
 	if err != nil {
 		logger.WithError(err).Error("error marshaling check result")
@@ -275,6 +338,27 @@ func (a *Agent) executeCheck(ctx context.Context, request *corev2.CheckRequest,
 	a.sendMessage(tm)
 }
 
+// checkSandbox returns the sandbox limiting the resources of a check command
+// with a cgroup, using the defaults of the agent for the limits the check
+// doesn't set, or nil if the check command isn't limited.
+func checkSandbox(cfg *Config, check *corev2.CheckConfig) *command.Sandbox {
+	sandbox := &command.Sandbox{
+		CgroupParent:      cfg.CgroupParent,
+		CgroupCPULimit:    int64(check.CgroupCPULimit),
+		CgroupMemoryLimit: check.CgroupMemoryLimit,
+	}
+	if sandbox.CgroupCPULimit == 0 {
+		sandbox.CgroupCPULimit = cfg.CheckCgroupCPULimit
+	}
+	if sandbox.CgroupMemoryLimit == 0 {
+		sandbox.CgroupMemoryLimit = cfg.CheckCgroupMemoryLimit
+	}
+	if sandbox.CgroupCPULimit == 0 && sandbox.CgroupMemoryLimit == 0 {
+		return nil
+	}
+	return sandbox
+}
+
 // prepareCheck prepares a check before its execution by performing token
 // substitution.
 func prepareCheck(cfg *corev2.CheckConfig, entity *corev2.Entity) error {
@@ -282,6 +366,11 @@ func prepareCheck(cfg *corev2.CheckConfig, entity *corev2.Entity) error {
 	// top-level so they can be easily accessed using token substitution
 	synthesizedEntity := dynamic.Synthesize(entity)
 
+	// The output metric tags can also refer to the check, so they are
+	// substituted once the check itself is
+	tags := cfg.OutputMetricTags
+	cfg.OutputMetricTags = nil
+
 	// Substitute tokens within the check configuration with the synthesized
 	// entity
 	checkBytes, err := TokenSubstitution(synthesizedEntity, cfg)
@@ -296,9 +385,52 @@ func prepareCheck(cfg *corev2.CheckConfig, entity *corev2.Entity) error {
 		return fmt.Errorf("could not unmarshal the check: %s", err)
 	}
 
+	if len(tags) == 0 {
+		return nil
+	}
+	tagsBytes, err := TokenSubstitution(metricTagsTokens(synthesizedEntity, cfg), tags)
+	if err != nil {
+		return fmt.Errorf("could not substitute the output metric tags: %s", err)
+	}
+	if err := json.Unmarshal(tagsBytes, &cfg.OutputMetricTags); err != nil {
+		return fmt.Errorf("could not unmarshal the output metric tags: %s", err)
+	}
+
 	return nil
 }
 
+// metricTagsTokens returns the data of the tokens of the output metric tags:
+// the synthesized entity, along with the check under the check key.
+func metricTagsTokens(synthesizedEntity interface{}, cfg *corev2.CheckConfig) map[string]interface{} {
+	data := map[string]interface{}{}
+	if entity, ok := synthesizedEntity.(map[string]interface{}); ok {
+		for k, v := range entity {
+			data[k] = v
+		}
+	}
+	data["check"] = dynamic.Synthesize(cfg)
+	return data
+}
+
+// addOutputMetricTags adds the output metric tags of the check to every
+// metric point of the event, overriding the tags of the same name extracted
+// from the check output.
+func addOutputMetricTags(event *corev2.Event) {
+	if !event.HasMetrics() || len(event.Check.OutputMetricTags) == 0 {
+		return
+	}
+	for _, t := range event.Check.OutputMetricTags {
+		tag, err := corev2.ParseMetricTag(t)
+		if err != nil {
+			logger.WithField("check", event.Check.Name).WithError(err).Warn("skipping invalid output metric tag")
+			continue
+		}
+		for _, point := range event.Metrics.Points {
+			point.SetTag(tag.Name, tag.Value)
+		}
+	}
+}
+
 func (a *Agent) sendFailure(event *corev2.Event, err error) {
 	event.Check.Output = err.Error()
 	event.Check.Status = 3
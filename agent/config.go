@@ -6,6 +6,7 @@ import (
 	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/util/httpclient"
 	"golang.org/x/time/rate"
 )
 
@@ -71,6 +72,14 @@ const (
 	DefaultUser = "agent"
 )
 
+// DefaultKubernetesPodInfoDir specifies the default directory where the pod
+// metadata is mounted with the downward API in sidecar mode
+const DefaultKubernetesPodInfoDir = "/etc/podinfo"
+
+// DefaultKubernetesKinds specifies the default kinds of kubernetes objects
+// whose events are sent to the backend
+var DefaultKubernetesKinds = []string{"Pod", "Node"}
+
 // A Config specifies Agent configuration.
 type Config struct {
 	// AgentName is the entity name for the running agent. Default is hostname.
@@ -82,6 +91,14 @@ type Config struct {
 	// API contains the Sensu client HTTP API configuration
 	API *APIConfig
 
+	// AssetHTTP configures the proxy and the certificate authorities used to
+	// download assets.
+	AssetHTTP httpclient.Config
+
+	// AssetCommandsOnly restricts the check commands executed by the agent to
+	// the executables provided by the assets of the checks.
+	AssetCommandsOnly bool
+
 	// BackendURLs is a list of URLs for the Sensu Backend. Default:
 	// ws://127.0.0.1:8081
 	BackendURLs []string
@@ -89,18 +106,55 @@ type Config struct {
 	// CacheDir path where cached data is stored
 	CacheDir string
 
+	// CgroupParent is the cgroup, relative to the root of the cgroup
+	// hierarchy, under which the agent creates the cgroups of the check
+	// commands with cgroup limits.
+	CgroupParent string
+
+	// CheckCgroupCPULimit is the default CPU limit of the check commands, in
+	// thousandths of a CPU core, enforced with cgroups on Linux. 0 means
+	// unlimited.
+	CheckCgroupCPULimit int64
+
+	// CheckCgroupMemoryLimit is the default memory limit of the check
+	// commands, in bytes, enforced with cgroups on Linux. 0 means unlimited.
+	CheckCgroupMemoryLimit int64
+
+	// CheckPostProcessCommands maps check names to the commands
+	// post-processing their results, overriding PostProcessCommand.
+	CheckPostProcessCommands map[string]string
+
+	// CommandAllowPatterns are glob patterns of the check commands executed by
+	// the agent. When set, the other commands are rejected.
+	CommandAllowPatterns []string
+
+	// CommandDenyPatterns are glob patterns of the check commands rejected by
+	// the agent, even if they match CommandAllowPatterns.
+	CommandDenyPatterns []string
+
 	// Deregister indicates whether the entity is ephemeral
 	Deregister bool
 
 	// DeregistrationHandler specifies a single deregistration handler
 	DeregistrationHandler string
 
+	// EntityClass is the class of the entity of the agent, EntityAgentClass by
+	// default. A custom class must expect keepalives in the cluster
+	// configuration, or the keepalives of the agent are ignored.
+	EntityClass string
+
 	// DetectCloudProvider enables cloud provider detection mechanisms.
 	// When enabled, the agent will attempt to read files, resolve hostnames,
 	// and make HTTP requests to determine what cloud environment it is running
 	// in.
 	DetectCloudProvider bool
 
+	// CloudMetadata enables the collection of the cloud provider instance
+	// metadata (e.g. instance ID, type and availability zone), which is
+	// stored in the labels of the agent entity. It implies cloud provider
+	// detection.
+	CloudMetadata bool
+
 	// DisableAPI disables the events API
 	DisableAPI bool
 
@@ -131,6 +185,11 @@ type Config struct {
 	// corev2 package for default value.
 	KeepaliveWarningTimeout uint32
 
+	// KeepaliveWarningTimeoutSet tells whether KeepaliveWarningTimeout was
+	// configured. The backend uses the default keepalive timeout of the cluster
+	// otherwise.
+	KeepaliveWarningTimeoutSet bool
+
 	// KeepaliveCriticalTimeout is the time after which a sensu-agent is considered dead
 	// by the backend to create a critical event.
 	KeepaliveCriticalTimeout uint32
@@ -141,18 +200,40 @@ type Config struct {
 	// Annotations are key-value pairs that users can provide to agent entities
 	Annotations map[string]string
 
+	// LocalChecksDir is the directory of the check definitions, in YAML or
+	// JSON, scheduled and executed by the agent itself, even without a
+	// backend connection. Their results are buffered until the agent is
+	// connected.
+	LocalChecksDir string
+
 	// Namespace sets the Agent's RBAC namespace identifier
 	Namespace string
 
 	// Password sets Agent's password
 	Password string
 
+	// PostProcessCommand is the command post-processing the results of the
+	// checks before they are sent, which can rewrite their output and status.
+	PostProcessCommand string
+
+	// PostProcessTimeout is the timeout of the post-processing commands, in
+	// seconds.
+	PostProcessTimeout int
+
 	// Redact contains the fields to redact when marshalling the agent's entity
 	Redact []string
 
+	// SigningKeyFile is the file of the key signing the messages sent to the
+	// backend: an ed25519 private key in PEM format, or an HMAC secret.
+	// Messages are not signed if it's empty.
+	SigningKeyFile string
+
 	// Socket contains the Sensu client socket configuration
 	Socket *SocketConfig
 
+	// Kubernetes contains the kubernetes configuration
+	Kubernetes *KubernetesConfig
+
 	// StatsdServer contains the statsd server configuration
 	StatsdServer *StatsdServerConfig
 
@@ -191,6 +272,34 @@ type StatsdServerConfig struct {
 	Disable       bool
 }
 
+// KubernetesConfig contains the kubernetes configuration
+type KubernetesConfig struct {
+	// Sidecar indicates that the agent runs as a sidecar container, in which
+	// case its entity is scoped to its pod
+	Sidecar bool
+
+	// PodInfoDir is the directory where the pod metadata is mounted with the
+	// downward API in sidecar mode
+	PodInfoDir string
+
+	// Events enables watching the kubernetes events
+	Events bool
+
+	// APIURL is the URL of the kubernetes API. The in-cluster API server is
+	// used if empty.
+	APIURL string
+
+	// Namespaces are the kubernetes namespaces to watch. All namespaces are
+	// watched if empty.
+	Namespaces []string
+
+	// Kinds are the kinds of objects whose events are sent to the backend.
+	Kinds []string
+
+	// Handlers are the handlers of the events created from kubernetes events.
+	Handlers []string
+}
+
 // SocketConfig contains the Socket configuration
 type SocketConfig struct {
 	Host string
@@ -219,10 +328,15 @@ func FixtureConfig() (*Config, func()) {
 		KeepaliveWarningTimeout: corev2.DefaultKeepaliveTimeout,
 		Namespace:               DefaultNamespace,
 		Password:                DefaultPassword,
+		PostProcessTimeout:      DefaultPostProcessTimeout,
 		Socket: &SocketConfig{
 			Host: DefaultSocketHost,
 			Port: DefaultSocketPort,
 		},
+		Kubernetes: &KubernetesConfig{
+			Kinds:      DefaultKubernetesKinds,
+			PodInfoDir: DefaultKubernetesPodInfoDir,
+		},
 		StatsdServer: &StatsdServerConfig{
 			Host:          DefaultStatsdMetricsHost,
 			Port:          DefaultStatsdMetricsPort,
@@ -244,6 +358,7 @@ func NewConfig() *Config {
 	c := &Config{
 		API:          &APIConfig{},
 		Socket:       &SocketConfig{},
+		Kubernetes:   &KubernetesConfig{},
 		StatsdServer: &StatsdServerConfig{},
 	}
 	return c
@@ -7,13 +7,27 @@ import (
 	"github.com/sensu/sensu-go/version"
 )
 
+// getAgentEntity returns the entity of the agent. The entity is never mutated
+// once returned: refreshSystemInfo swaps in a new one when its labels change.
 func (a *Agent) getAgentEntity() *corev2.Entity {
+	a.systemInfoMu.RLock()
+	entity := a.entity
+	a.systemInfoMu.RUnlock()
+	if entity != nil {
+		return entity
+	}
+
+	a.systemInfoMu.Lock()
+	defer a.systemInfoMu.Unlock()
 	if a.entity == nil {
 		meta := corev2.NewObjectMeta(a.config.AgentName, a.config.Namespace)
-		meta.Labels = a.config.Labels
 		meta.Annotations = a.config.Annotations
+		class := a.config.EntityClass
+		if class == "" {
+			class = corev2.EntityAgentClass
+		}
 		e := &corev2.Entity{
-			EntityClass:       corev2.EntityAgentClass,
+			EntityClass:       class,
 			Deregister:        a.config.Deregister,
 			LastSeen:          time.Now().Unix(),
 			Redact:            a.config.Redact,
@@ -31,9 +45,8 @@ func (a *Agent) getAgentEntity() *corev2.Entity {
 		}
 
 		// Retrieve the system info from the agent's cached value
-		a.systemInfoMu.RLock()
 		e.System = *a.systemInfo
-		a.systemInfoMu.RUnlock()
+		e.Labels = a.entityLabels()
 
 		a.entity = e
 	}
@@ -57,3 +70,20 @@ func (a *Agent) getEntities(event *corev2.Event) {
 	// so we provide details like the namespace to the backend
 	event.Entity = a.getAgentEntity()
 }
+
+// entityLabels returns the labels of the agent entity, i.e. the configured
+// labels merged with the cloud metadata. Configured labels take precedence.
+// The caller must hold systemInfoMu.
+func (a *Agent) entityLabels() map[string]string {
+	if len(a.cloudMetadata) == 0 {
+		return a.config.Labels
+	}
+	labels := make(map[string]string, len(a.cloudMetadata)+len(a.config.Labels))
+	for k, v := range a.cloudMetadata {
+		labels[k] = v
+	}
+	for k, v := range a.config.Labels {
+		labels[k] = v
+	}
+	return labels
+}
@@ -0,0 +1,75 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"text/template"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/system"
+)
+
+// Keys of the values available to the templates of entity names.
+const (
+	NameTemplateHostname        = "Hostname"
+	NameTemplateCloudInstanceID = "CloudInstanceID"
+	NameTemplateMACAddress      = "MACAddress"
+)
+
+// GetNameTemplateValues returns the values available to the templates of
+// entity names: the hostname, the ID of the cloud instance and the MAC address
+// of the first network interface that has one. The values that can't be
+// determined are omitted, so that the templates using them fail to render.
+func GetNameTemplateValues(ctx context.Context) map[string]string {
+	values := map[string]string{}
+
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		values[NameTemplateHostname] = hostname
+	}
+
+	if provider := system.GetCloudProvider(ctx); provider != "" {
+		metadata, err := system.GetCloudMetadata(ctx, provider)
+		if err != nil {
+			logger.WithError(err).Warn("couldn't retrieve cloud metadata for the entity name")
+		} else if id := metadata[system.CloudInstanceIDKey]; id != "" {
+			values[NameTemplateCloudInstanceID] = id
+		}
+	}
+
+	network, err := system.NetworkInfo()
+	if err != nil {
+		logger.WithError(err).Warn("couldn't retrieve network interfaces for the entity name")
+	}
+	for _, iface := range network.Interfaces {
+		if iface.MAC != "" {
+			values[NameTemplateMACAddress] = iface.MAC
+			break
+		}
+	}
+
+	return values
+}
+
+// RenderEntityName renders the template of an entity name, such as
+// "{{ .Hostname }}-{{ .CloudInstanceID }}", with the given values. An error is
+// returned if the template uses a missing value or if the rendered name is
+// not a valid entity name.
+func RenderEntityName(text string, values map[string]string) (string, error) {
+	tmpl, err := template.New("name").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid entity name template: %s", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, values); err != nil {
+		return "", fmt.Errorf("could not render the entity name template: %s", err)
+	}
+
+	name := buf.String()
+	if err := corev2.ValidateName(name); err != nil {
+		return "", fmt.Errorf("invalid entity name %q rendered from template: %s", name, err)
+	}
+	return name, nil
+}
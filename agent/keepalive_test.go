@@ -0,0 +1,38 @@
+package agent
+
+import (
+	"encoding/json"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeepaliveTimeoutSet(t *testing.T) {
+	tests := []struct {
+		name string
+		set  bool
+		want string
+	}{
+		{name: "default timeout", set: false, want: "false"},
+		{name: "configured timeout", set: true, want: "true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, cleanup := FixtureConfig()
+			defer cleanup()
+			// An explicit timeout equal to the default must be told apart from
+			// the default
+			cfg.KeepaliveWarningTimeout = corev2.DefaultKeepaliveTimeout
+			cfg.KeepaliveWarningTimeoutSet = tt.set
+			ta, err := NewAgent(cfg)
+			require.NoError(t, err)
+
+			var event corev2.Event
+			require.NoError(t, json.Unmarshal(ta.newKeepalive().Payload, &event))
+			assert.Equal(t, uint32(corev2.DefaultKeepaliveTimeout), event.Check.Timeout)
+			assert.Equal(t, tt.want, event.Check.Annotations[corev2.KeepaliveTimeoutSetAnnotation])
+		})
+	}
+}
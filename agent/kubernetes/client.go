@@ -0,0 +1,218 @@
+// Package kubernetes provides a minimal client of the Kubernetes API, used by
+// the agent to watch cluster events.
+package kubernetes
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ServiceAccountDir is the directory where Kubernetes mounts the credentials
+// of the pod service account.
+var ServiceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// ErrResourceVersionExpired is returned by Watch when the requested resource
+// version is too old, in which case the watch must be restarted from a fresh
+// resource version.
+var ErrResourceVersionExpired = errors.New("resource version expired")
+
+// ObjectReference references a Kubernetes object.
+type ObjectReference struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	UID       string `json:"uid"`
+}
+
+// EventSource identifies the component that emitted an event.
+type EventSource struct {
+	Component string `json:"component"`
+	Host      string `json:"host"`
+}
+
+// ObjectMeta is the subset of the Kubernetes object metadata used by the
+// agent.
+type ObjectMeta struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+// Event is a Kubernetes core/v1 event.
+type Event struct {
+	Metadata       ObjectMeta      `json:"metadata"`
+	InvolvedObject ObjectReference `json:"involvedObject"`
+	Reason         string          `json:"reason"`
+	Message        string          `json:"message"`
+	Source         EventSource     `json:"source"`
+	Type           string          `json:"type"`
+	Count          int32           `json:"count"`
+	FirstTimestamp time.Time       `json:"firstTimestamp"`
+	LastTimestamp  time.Time       `json:"lastTimestamp"`
+}
+
+type eventList struct {
+	Metadata struct {
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+}
+
+type watchEvent struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+type status struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Client is a Kubernetes API client.
+type Client struct {
+	url        string
+	token      string
+	httpClient *http.Client
+}
+
+// NewInClusterClient creates a client authenticated with the service account
+// of the pod the agent runs in. If apiURL is empty, the API server is located
+// with the environment variables Kubernetes injects in every pod.
+func NewInClusterClient(apiURL string) (*Client, error) {
+	if apiURL == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" || port == "" {
+			return nil, errors.New("not running in a kubernetes cluster and no API URL configured")
+		}
+		apiURL = "https://" + net.JoinHostPort(host, port)
+	}
+
+	token, err := ioutil.ReadFile(filepath.Join(ServiceAccountDir, "token"))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read service account token: %s", err)
+	}
+
+	tlsConfig := &tls.Config{}
+	if ca, err := ioutil.ReadFile(filepath.Join(ServiceAccountDir, "ca.crt")); err == nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("couldn't parse service account CA certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return &Client{
+		url:   strings.TrimSuffix(apiURL, "/"),
+		token: strings.TrimSpace(string(token)),
+		httpClient: &http.Client{
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}, nil
+}
+
+func eventsPath(namespace string) string {
+	if namespace == "" {
+		return "/api/v1/events"
+	}
+	return "/api/v1/namespaces/" + url.PathEscape(namespace) + "/events"
+}
+
+func (c *Client) get(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.url+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		var s status
+		_ = json.NewDecoder(resp.Body).Decode(&s)
+		if resp.StatusCode == http.StatusGone {
+			return nil, ErrResourceVersionExpired
+		}
+		return nil, fmt.Errorf("kubernetes API returned status %d: %s", resp.StatusCode, s.Message)
+	}
+	return resp, nil
+}
+
+// EventsResourceVersion returns the current resource version of the events
+// of the given namespace, or of all namespaces if namespace is empty.
+// Watching from this version only returns new events.
+func (c *Client) EventsResourceVersion(ctx context.Context, namespace string) (string, error) {
+	resp, err := c.get(ctx, eventsPath(namespace), url.Values{"limit": []string{"1"}})
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var list eventList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return "", err
+	}
+	return list.Metadata.ResourceVersion, nil
+}
+
+// WatchEvents watches the events of the given namespace, or of all
+// namespaces if namespace is empty, starting after resourceVersion. fn is
+// called for every added or modified event. WatchEvents blocks until the
+// watch ends, and returns the resource version to resume watching from.
+func (c *Client) WatchEvents(ctx context.Context, namespace, resourceVersion string, fn func(*Event)) (string, error) {
+	query := url.Values{}
+	query.Set("watch", "1")
+	query.Set("allowWatchBookmarks", "true")
+	if resourceVersion != "" {
+		query.Set("resourceVersion", resourceVersion)
+	}
+	resp, err := c.get(ctx, eventsPath(namespace), query)
+	if err != nil {
+		return resourceVersion, err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var we watchEvent
+		if err := decoder.Decode(&we); err != nil {
+			if ctx.Err() != nil {
+				return resourceVersion, ctx.Err()
+			}
+			// The API server closes watches periodically
+			return resourceVersion, nil
+		}
+		switch we.Type {
+		case "ERROR":
+			var s status
+			if err := json.Unmarshal(we.Object, &s); err == nil && s.Code == http.StatusGone {
+				return "", ErrResourceVersionExpired
+			}
+			return resourceVersion, fmt.Errorf("watch error: %s", s.Message)
+		case "ADDED", "MODIFIED", "BOOKMARK":
+			var event Event
+			if err := json.Unmarshal(we.Object, &event); err != nil {
+				logger.WithError(err).Warn("couldn't decode kubernetes event")
+				continue
+			}
+			resourceVersion = event.Metadata.ResourceVersion
+			if we.Type != "BOOKMARK" {
+				fn(&event)
+			}
+		}
+	}
+}
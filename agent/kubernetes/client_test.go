@@ -0,0 +1,54 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/namespaces/default/events", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		if r.URL.Query().Get("watch") == "" {
+			fmt.Fprint(w, `{"metadata": {"resourceVersion": "10"}}`)
+			return
+		}
+		assert.Equal(t, "10", r.URL.Query().Get("resourceVersion"))
+		fmt.Fprintln(w, `{"type": "ADDED", "object": {"metadata": {"name": "foo.1", "resourceVersion": "11"}, "involvedObject": {"kind": "Pod", "name": "foo"}, "reason": "BackOff", "type": "Warning"}}`)
+		fmt.Fprintln(w, `{"type": "BOOKMARK", "object": {"metadata": {"resourceVersion": "12"}}}`)
+	}))
+	defer server.Close()
+
+	client := &Client{url: server.URL, token: "token", httpClient: server.Client()}
+
+	version, err := client.EventsResourceVersion(context.Background(), "default")
+	require.NoError(t, err)
+	assert.Equal(t, "10", version)
+
+	var events []*Event
+	version, err = client.WatchEvents(context.Background(), "default", version, func(e *Event) {
+		events = append(events, e)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "12", version)
+	require.Len(t, events, 1)
+	assert.Equal(t, "foo", events[0].InvolvedObject.Name)
+	assert.Equal(t, "BackOff", events[0].Reason)
+}
+
+func TestWatchEventsExpired(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"type": "ERROR", "object": {"code": 410, "message": "too old resource version"}}`)
+	}))
+	defer server.Close()
+
+	client := &Client{url: server.URL, httpClient: server.Client()}
+	_, err := client.WatchEvents(context.Background(), "", "1", func(*Event) {})
+	assert.Equal(t, ErrResourceVersionExpired, err)
+}
@@ -0,0 +1,176 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+
+	"github.com/sensu/sensu-go/agent/kubernetes"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/transport"
+)
+
+const (
+	// kubernetesCheckPrefix prefixes the name of the checks created from
+	// kubernetes events.
+	kubernetesCheckPrefix = "kubernetes-"
+
+	// kubernetesRetryInterval is the time to wait before watching again after
+	// an error.
+	kubernetesRetryInterval = 10 * time.Second
+)
+
+// watchKubernetesEvents watches the kubernetes events of the configured
+// namespaces and sends them to the backend as Sensu events.
+func (a *Agent) watchKubernetesEvents(ctx context.Context) {
+	defer logger.Debug("shutting down kubernetes events watcher")
+
+	client, err := kubernetes.NewInClusterClient(a.config.Kubernetes.APIURL)
+	if err != nil {
+		logger.WithError(err).Error("couldn't create kubernetes client, kubernetes events are disabled")
+		return
+	}
+
+	namespaces := a.config.Kubernetes.Namespaces
+	if len(namespaces) == 0 {
+		// Watch all namespaces
+		namespaces = []string{""}
+	}
+	for _, namespace := range namespaces {
+		go a.watchKubernetesNamespace(ctx, client, namespace)
+	}
+	<-ctx.Done()
+}
+
+func (a *Agent) watchKubernetesNamespace(ctx context.Context, client *kubernetes.Client, namespace string) {
+	fields := logrus.Fields{"kubernetes_namespace": namespace}
+	var resourceVersion string
+	for {
+		var err error
+		if resourceVersion == "" {
+			resourceVersion, err = client.EventsResourceVersion(ctx, namespace)
+		}
+		if err == nil {
+			resourceVersion, err = client.WatchEvents(ctx, namespace, resourceVersion, a.handleKubernetesEvent)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err == kubernetes.ErrResourceVersionExpired {
+			resourceVersion = ""
+			continue
+		}
+		if err != nil {
+			logger.WithFields(fields).WithError(err).Error("error watching kubernetes events")
+			select {
+			case <-time.After(kubernetesRetryInterval):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// handleKubernetesEvent converts the kubernetes event and sends it to the
+// backend, if it concerns one of the configured kinds of objects.
+func (a *Agent) handleKubernetesEvent(k8sEvent *kubernetes.Event) {
+	if !a.kubernetesKindEnabled(k8sEvent.InvolvedObject.Kind) {
+		return
+	}
+
+	event, err := a.kubernetesEventToEvent(k8sEvent)
+	if err != nil {
+		logger.WithError(err).Warn("couldn't convert kubernetes event")
+		return
+	}
+	a.postProcess(event)
+
+	msg, err := a.marshal(event)
+	if err != nil {
+		logger.WithError(err).Error("error marshaling kubernetes event")
+		return
+	}
+
+	logEvent(event)
+
+	a.sendMessage(&transport.Message{
+		Type:    transport.MessageTypeEvent,
+		Payload: msg,
+	})
+}
+
+func (a *Agent) kubernetesKindEnabled(kind string) bool {
+	for _, k := range a.config.Kubernetes.Kinds {
+		if strings.EqualFold(k, kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// kubernetesEventToEvent converts a kubernetes event into a Sensu event. The
+// involved object (e.g. a pod or a node) is the proxy entity of the event, and
+// the reason of the event determines the name of the check.
+func (a *Agent) kubernetesEventToEvent(k8sEvent *kubernetes.Event) (*corev2.Event, error) {
+	object := k8sEvent.InvolvedObject
+	if object.Name == "" || k8sEvent.Reason == "" {
+		return nil, fmt.Errorf("kubernetes event %q has no involved object or reason", k8sEvent.Metadata.Name)
+	}
+
+	checkMeta := corev2.NewObjectMeta(kubernetesCheckPrefix+strings.ToLower(k8sEvent.Reason), a.config.Namespace)
+	checkMeta.Labels = map[string]string{
+		"kubernetes_kind": strings.ToLower(object.Kind),
+	}
+	if object.Namespace != "" {
+		checkMeta.Labels["kubernetes_namespace"] = object.Namespace
+	}
+	checkMeta.Annotations = map[string]string{
+		"kubernetes_event":  k8sEvent.Metadata.Name,
+		"kubernetes_source": k8sEvent.Source.Component,
+		"kubernetes_count":  strconv.Itoa(int(k8sEvent.Count)),
+	}
+	check := corev2.NewCheck(&corev2.CheckConfig{
+		ObjectMeta:      checkMeta,
+		Interval:        1,
+		Handlers:        a.config.Kubernetes.Handlers,
+		ProxyEntityName: kubernetesEntityName(object),
+	})
+	check.Output = k8sEvent.Message
+	check.Executed = k8sEvent.LastTimestamp.Unix()
+	if k8sEvent.LastTimestamp.IsZero() {
+		check.Executed = time.Now().Unix()
+	}
+	if k8sEvent.Type == "Warning" {
+		check.Status = 1
+	}
+
+	event := &corev2.Event{
+		ObjectMeta: corev2.NewObjectMeta("", a.config.Namespace),
+		Timestamp:  time.Now().Unix(),
+		Entity:     a.getAgentEntity(),
+		Check:      check,
+	}
+	if id, err := uuid.NewRandom(); err == nil {
+		event.ID = id[:]
+	}
+
+	return event, event.Validate()
+}
+
+// kubernetesEntityName returns the name of the proxy entity of a kubernetes
+// object, <namespace>.<kind>.<name>, or <kind>.<name> for the objects that
+// don't belong to a namespace such as nodes, so that the objects of different
+// namespaces and kinds don't share an entity. The characters not allowed in
+// entity names are replaced.
+func kubernetesEntityName(object kubernetes.ObjectReference) string {
+	parts := []string{strings.ToLower(object.Kind), object.Name}
+	if object.Namespace != "" {
+		parts = append([]string{object.Namespace}, parts...)
+	}
+	return corev2.ResourceNameSpec.Migrate(strings.Join(parts, "."))
+}
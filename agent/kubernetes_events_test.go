@@ -0,0 +1,59 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sensu/sensu-go/agent/kubernetes"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKubernetesEventToEvent(t *testing.T) {
+	cfg, cleanup := FixtureConfig()
+	defer cleanup()
+	cfg.Kubernetes.Handlers = []string{"slack"}
+	agent := &Agent{config: cfg, systemInfo: &corev2.System{}}
+
+	k8sEvent := &kubernetes.Event{
+		Metadata:       kubernetes.ObjectMeta{Name: "web-1.15f"},
+		InvolvedObject: kubernetes.ObjectReference{Kind: "Pod", Namespace: "prod", Name: "web-1"},
+		Reason:         "BackOff",
+		Message:        "Back-off restarting failed container",
+		Type:           "Warning",
+		Count:          3,
+		LastTimestamp:  time.Unix(42, 0),
+	}
+
+	assert.True(t, agent.kubernetesKindEnabled(k8sEvent.InvolvedObject.Kind))
+	assert.False(t, agent.kubernetesKindEnabled("Deployment"))
+
+	event, err := agent.kubernetesEventToEvent(k8sEvent)
+	require.NoError(t, err)
+	assert.Equal(t, "kubernetes-backoff", event.Check.Name)
+	assert.Equal(t, "prod.pod.web-1", event.Check.ProxyEntityName)
+	assert.Equal(t, uint32(1), event.Check.Status)
+	assert.Equal(t, int64(42), event.Check.Executed)
+	assert.Equal(t, k8sEvent.Message, event.Check.Output)
+	assert.Equal(t, []string{"slack"}, event.Check.Handlers)
+	assert.Equal(t, "prod", event.Check.Labels["kubernetes_namespace"])
+	assert.Equal(t, "3", event.Check.Annotations["kubernetes_count"])
+
+	k8sEvent.Reason = ""
+	_, err = agent.kubernetesEventToEvent(k8sEvent)
+	assert.Error(t, err)
+}
+
+func TestKubernetesEntityName(t *testing.T) {
+	// The objects of different namespaces and kinds have their own entity
+	assert.Equal(t, "prod.pod.web-1", kubernetesEntityName(kubernetes.ObjectReference{Kind: "Pod", Namespace: "prod", Name: "web-1"}))
+	assert.Equal(t, "dev.pod.web-1", kubernetesEntityName(kubernetes.ObjectReference{Kind: "Pod", Namespace: "dev", Name: "web-1"}))
+	assert.Equal(t, "prod.service.web-1", kubernetesEntityName(kubernetes.ObjectReference{Kind: "Service", Namespace: "prod", Name: "web-1"}))
+	assert.Equal(t, "node.ip-10-0-0-1.ec2.internal", kubernetesEntityName(kubernetes.ObjectReference{Kind: "Node", Name: "ip-10-0-0-1.ec2.internal"}))
+
+	// The characters not allowed in entity names are replaced
+	name := kubernetesEntityName(kubernetes.ObjectReference{Kind: "Pod", Namespace: "prod", Name: "web/1"})
+	assert.Equal(t, "prod.pod.web_1", name)
+	assert.NoError(t, corev2.ValidateName(name))
+}
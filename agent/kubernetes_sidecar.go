@@ -0,0 +1,89 @@
+package agent
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// Environment variables used to expose the pod metadata to sidecar agents with
+// the downward API.
+const (
+	podNameEnv      = "POD_NAME"
+	podNamespaceEnv = "POD_NAMESPACE"
+)
+
+// GetKubernetesPodName returns the name of the pod the agent runs in, as
+// exposed by the downward API in the POD_NAME environment variable. It falls
+// back to the hostname, which kubernetes sets to the pod name.
+func GetKubernetesPodName() string {
+	if name := os.Getenv(podNameEnv); name != "" {
+		return name
+	}
+	return GetDefaultAgentName()
+}
+
+// readPodLabels reads the pod labels from a file mounted with the downward API,
+// which contains one key="value" line per label.
+func readPodLabels(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	labels := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		i := strings.IndexByte(line, '=')
+		if i <= 0 {
+			continue
+		}
+		value, err := strconv.Unquote(line[i+1:])
+		if err != nil {
+			value = line[i+1:]
+		}
+		labels[line[:i]] = value
+	}
+	return labels, scanner.Err()
+}
+
+// applySidecarConfig scopes the agent entity to its pod: the pod labels and
+// namespace are added to the entity labels, and the entity is marked as
+// ephemeral. Configured labels take precedence over the pod labels.
+func applySidecarConfig(config *Config) {
+	labels := make(map[string]string)
+	path := filepath.Join(config.Kubernetes.PodInfoDir, "labels")
+	podLabels, err := readPodLabels(path)
+	if err != nil {
+		logger.WithError(err).Warn("couldn't read the pod labels from the downward API")
+	}
+	for k, v := range podLabels {
+		labels[k] = v
+	}
+	if namespace := os.Getenv(podNamespaceEnv); namespace != "" {
+		labels["kubernetes_namespace"] = namespace
+	}
+	for k, v := range config.Labels {
+		labels[k] = v
+	}
+	config.Labels = labels
+
+	annotations := make(map[string]string, len(config.Annotations)+1)
+	for k, v := range config.Annotations {
+		annotations[k] = v
+	}
+	annotations[corev2.EphemeralAnnotation] = "true"
+	config.Annotations = annotations
+}
+
+// deregistersOnShutdown returns true if the agent entity must be deregistered
+// as soon as the agent shuts down.
+func (a *Agent) deregistersOnShutdown() bool {
+	return a.config.Deregister && a.config.Kubernetes != nil && a.config.Kubernetes.Sidecar
+}
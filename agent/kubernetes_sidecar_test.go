@@ -0,0 +1,44 @@
+package agent
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySidecarConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "podinfo")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	podLabels := "app=\"web\"\npod-template-hash=\"7f9c\"\ntier=\"frontend\"\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "labels"), []byte(podLabels), 0644))
+	require.NoError(t, os.Setenv(podNamespaceEnv, "prod"))
+	defer os.Unsetenv(podNamespaceEnv)
+
+	cfg, cleanup := FixtureConfig()
+	defer cleanup()
+	cfg.Kubernetes.Sidecar = true
+	cfg.Kubernetes.PodInfoDir = dir
+	cfg.Labels = map[string]string{"tier": "backend"}
+
+	applySidecarConfig(cfg)
+
+	assert.Equal(t, map[string]string{
+		"app":                  "web",
+		"pod-template-hash":    "7f9c",
+		"tier":                 "backend",
+		"kubernetes_namespace": "prod",
+	}, cfg.Labels)
+	assert.Equal(t, "true", cfg.Annotations[corev2.EphemeralAnnotation])
+
+	agent := &Agent{config: cfg}
+	assert.False(t, agent.deregistersOnShutdown())
+	cfg.Deregister = true
+	assert.True(t, agent.deregistersOnShutdown())
+}
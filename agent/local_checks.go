@@ -0,0 +1,108 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// loadLocalChecks reads the check definitions, in YAML or JSON, of the files
+// of dir. The checks belong to the namespace of the agent, and must be
+// scheduled with an interval.
+func loadLocalChecks(dir, namespace string) ([]*corev2.CheckConfig, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the local checks directory: %s", err)
+	}
+
+	names := []string{}
+	for _, file := range files {
+		switch strings.ToLower(filepath.Ext(file.Name())) {
+		case ".yml", ".yaml", ".json":
+			if !file.IsDir() {
+				names = append(names, file.Name())
+			}
+		}
+	}
+	sort.Strings(names)
+
+	checks := make([]*corev2.CheckConfig, 0, len(names))
+	seen := map[string]string{}
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read local check %q: %s", path, err)
+		}
+		check := &corev2.CheckConfig{}
+		if err := yaml.Unmarshal(b, check); err != nil {
+			return nil, fmt.Errorf("invalid local check %q: %s", path, err)
+		}
+		check.Namespace = namespace
+		if err := check.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid local check %q: %s", path, err)
+		}
+		if check.Cron != "" {
+			return nil, fmt.Errorf("invalid local check %q: local checks must be scheduled with an interval", path)
+		}
+		if other, ok := seen[check.Name]; ok {
+			return nil, fmt.Errorf("local checks %q and %q have the same name %q", other, path, check.Name)
+		}
+		seen[check.Name] = path
+		checks = append(checks, check)
+	}
+	return checks, nil
+}
+
+// scheduleLocalChecks executes the local checks at their interval until ctx
+// is canceled, whether the agent is connected to a backend or not. Their
+// results are buffered while the agent is disconnected.
+func (a *Agent) scheduleLocalChecks(ctx context.Context, checks []*corev2.CheckConfig) {
+	for _, check := range checks {
+		logger.WithField("check", check.Name).Info("scheduling local check")
+		go a.scheduleLocalCheck(ctx, check)
+	}
+}
+
+func (a *Agent) scheduleLocalCheck(ctx context.Context, check *corev2.CheckConfig) {
+	ticker := time.NewTicker(time.Duration(check.Interval) * time.Second)
+	defer ticker.Stop()
+	for {
+		request := &corev2.CheckRequest{
+			Config: check,
+			Issued: time.Now().Unix(),
+		}
+		if a.checkInProgress(request) {
+			logger.WithField("check", check.Name).Warn("local check execution still in progress")
+		} else if config, err := copyCheckConfig(check); err != nil {
+			logger.WithField("check", check.Name).WithError(err).Error("couldn't execute local check")
+		} else {
+			// executeCheck substitutes tokens in its own copy of the check
+			request.Config = config
+			go a.executeCheck(ctx, request, a.getAgentEntity())
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// copyCheckConfig returns a deep copy of the check configuration.
+func copyCheckConfig(check *corev2.CheckConfig) (*corev2.CheckConfig, error) {
+	b, err := check.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	config := &corev2.CheckConfig{}
+	return config, config.Unmarshal(b)
+}
@@ -0,0 +1,82 @@
+package agent
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeLocalCheck(t *testing.T, dir, name, content string) {
+	t.Helper()
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+}
+
+func TestLoadLocalChecks(t *testing.T) {
+	dir, err := ioutil.TempDir("", "local-checks")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeLocalCheck(t, dir, "disk.yml", `
+metadata:
+  name: disk
+command: check-disk -w 80
+interval: 60
+`)
+	writeLocalCheck(t, dir, "cpu.json", `{"metadata": {"name": "cpu"}, "command": "check-cpu", "interval": 30}`)
+	writeLocalCheck(t, dir, "README.md", "not a check")
+
+	checks, err := loadLocalChecks(dir, "edge")
+	require.NoError(t, err)
+	require.Len(t, checks, 2)
+	assert.Equal(t, "cpu", checks[0].Name)
+	assert.Equal(t, uint32(30), checks[0].Interval)
+	assert.Equal(t, "disk", checks[1].Name)
+	assert.Equal(t, "check-disk -w 80", checks[1].Command)
+	assert.Equal(t, "edge", checks[1].Namespace)
+}
+
+func TestLoadLocalChecksErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+	}{
+		{
+			name:  "invalid yaml",
+			files: map[string]string{"disk.yml": "metadata: [name"},
+		},
+		{
+			name:  "invalid check",
+			files: map[string]string{"disk.yml": "metadata:\n  name: disk\ncommand: check-disk\n"},
+		},
+		{
+			name:  "cron schedule",
+			files: map[string]string{"disk.yml": "metadata:\n  name: disk\ncommand: check-disk\ncron: '* * * * *'\n"},
+		},
+		{
+			name: "duplicate names",
+			files: map[string]string{
+				"disk.yml":  "metadata:\n  name: disk\ncommand: check-disk\ninterval: 60\n",
+				"disk2.yml": "metadata:\n  name: disk\ncommand: check-disk\ninterval: 60\n",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "local-checks")
+			require.NoError(t, err)
+			defer os.RemoveAll(dir)
+			for name, content := range tt.files {
+				writeLocalCheck(t, dir, name, content)
+			}
+			_, err = loadLocalChecks(dir, "default")
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := loadLocalChecks(filepath.Join(os.TempDir(), "does-not-exist"), "default")
+	assert.Error(t, err)
+}
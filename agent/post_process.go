@@ -0,0 +1,121 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/command"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultPostProcessTimeout is the default timeout of the post-processing
+	// commands, in seconds.
+	DefaultPostProcessTimeout = 10
+
+	// postProcessFailureOutput is the output of the checks whose
+	// post-processing failed, since their raw output must not be sent.
+	postProcessFailureOutput = "check result post-processing failed"
+)
+
+// postProcessResult is what a post-processing command writes to stdout. The
+// fields it omits are left unchanged, as well as the outputs of the hooks it
+// omits.
+type postProcessResult struct {
+	Output *string            `json:"output"`
+	Status *uint32            `json:"status"`
+	Hooks  map[string]*string `json:"hooks"`
+}
+
+// postProcessCommand returns the command post-processing the results of the
+// check, or an empty string if they aren't post-processed. The command
+// configured for the check takes precedence over the global one.
+func (a *Agent) postProcessCommand(check string) string {
+	if command, ok := a.config.CheckPostProcessCommands[check]; ok {
+		return command
+	}
+	return a.config.PostProcessCommand
+}
+
+// postProcess executes the post-processing command of the check of the event,
+// if any. It must be called last, right before the event is sent. The command
+// receives the event in JSON on stdin, and can rewrite the output and the
+// status of the check, and the outputs of its hooks by name, by writing them in
+// JSON to stdout, e.g. to scrub secrets from the outputs. If the command fails,
+// times out or writes anything else, the raw outputs are discarded and the
+// status is set to 3.
+func (a *Agent) postProcess(event *corev2.Event) {
+	cmd := a.postProcessCommand(event.Check.Name)
+	if cmd == "" {
+		return
+	}
+	fields := logrus.Fields{
+		"namespace": event.Check.Namespace,
+		"check":     event.Check.Name,
+	}
+
+	if err := a.executePostProcess(cmd, event); err != nil {
+		logger.WithFields(fields).WithError(err).Error("check result post-processing failed")
+		event.Check.Output = fmt.Sprintf("%s: %s", postProcessFailureOutput, err)
+		event.Check.Status = 3
+		for _, hook := range event.Check.Hooks {
+			if hook != nil {
+				hook.Output = postProcessFailureOutput
+			}
+		}
+	}
+}
+
+func (a *Agent) executePostProcess(cmd string, event *corev2.Event) error {
+	input, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error marshaling json from event: %s", err)
+	}
+
+	timeout := a.config.PostProcessTimeout
+	if timeout <= 0 {
+		timeout = DefaultPostProcessTimeout
+	}
+	ex := command.ExecutionRequest{
+		Env:          os.Environ(),
+		Command:      cmd,
+		Input:        string(input),
+		Timeout:      timeout,
+		InProgress:   a.inProgress,
+		InProgressMu: a.inProgressMu,
+		Name:         event.Check.Name,
+	}
+	exec, err := a.executor.Execute(context.Background(), ex)
+	if err != nil {
+		return err
+	}
+	if exec.Output == command.TimeoutOutput {
+		return fmt.Errorf("timed out after %d seconds", timeout)
+	}
+	if exec.Status != 0 {
+		return fmt.Errorf("exited with status %d", exec.Status)
+	}
+
+	var result postProcessResult
+	if err := json.Unmarshal([]byte(exec.Output), &result); err != nil {
+		return fmt.Errorf("invalid result: %s", err)
+	}
+	if result.Output != nil {
+		event.Check.Output = *result.Output
+	}
+	if result.Status != nil {
+		event.Check.Status = *result.Status
+	}
+	for _, hook := range event.Check.Hooks {
+		if hook == nil {
+			continue
+		}
+		if output := result.Hooks[hook.Name]; output != nil {
+			hook.Output = *output
+		}
+	}
+	return nil
+}
@@ -0,0 +1,202 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/command"
+	"github.com/sensu/sensu-go/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postProcessExecutor returns the result of the check, or executes the
+// post-processing command.
+type postProcessExecutor struct {
+	check       *command.ExecutionResponse
+	postProcess func(command.ExecutionRequest) (*command.ExecutionResponse, error)
+}
+
+func (e *postProcessExecutor) Execute(ctx context.Context, ex command.ExecutionRequest) (*command.ExecutionResponse, error) {
+	if ex.Command == "post-process" {
+		return e.postProcess(ex)
+	}
+	return e.check, nil
+}
+
+func TestPostProcessCommand(t *testing.T) {
+	config, cleanup := FixtureConfig()
+	defer cleanup()
+	agent, err := NewAgent(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, "", agent.postProcessCommand("check"))
+
+	config.PostProcessCommand = "scrub"
+	config.CheckPostProcessCommands = map[string]string{"check": "scrub-check", "other": ""}
+	assert.Equal(t, "scrub-check", agent.postProcessCommand("check"))
+	assert.Equal(t, "", agent.postProcessCommand("other"))
+	assert.Equal(t, "scrub", agent.postProcessCommand("disk"))
+}
+
+func TestExecuteCheckPostProcess(t *testing.T) {
+	tests := []struct {
+		name        string
+		postProcess func(command.ExecutionRequest) (*command.ExecutionResponse, error)
+		wantOutput  string
+		wantStatus  uint32
+	}{
+		{
+			name: "output rewritten",
+			postProcess: func(ex command.ExecutionRequest) (*command.ExecutionResponse, error) {
+				return &command.ExecutionResponse{Output: `{"output": "password=<redacted>"}`}, nil
+			},
+			wantOutput: "password=<redacted>",
+			wantStatus: 1,
+		},
+		{
+			name: "status rewritten",
+			postProcess: func(ex command.ExecutionRequest) (*command.ExecutionResponse, error) {
+				return &command.ExecutionResponse{Output: `{"status": 0}`}, nil
+			},
+			wantOutput: "password=hunter2",
+			wantStatus: 0,
+		},
+		{
+			name: "invalid result",
+			postProcess: func(ex command.ExecutionRequest) (*command.ExecutionResponse, error) {
+				return &command.ExecutionResponse{Output: "password=hunter2"}, nil
+			},
+			wantOutput: "check result post-processing failed: invalid result",
+			wantStatus: 3,
+		},
+		{
+			name: "command failed",
+			postProcess: func(ex command.ExecutionRequest) (*command.ExecutionResponse, error) {
+				return &command.ExecutionResponse{Output: "password=hunter2", Status: 1}, nil
+			},
+			wantOutput: "check result post-processing failed: exited with status 1",
+			wantStatus: 3,
+		},
+		{
+			name: "command timed out",
+			postProcess: func(ex command.ExecutionRequest) (*command.ExecutionResponse, error) {
+				return &command.ExecutionResponse{Output: command.TimeoutOutput, Status: command.TimeoutExitStatus}, nil
+			},
+			wantOutput: "check result post-processing failed: timed out after 5 seconds",
+			wantStatus: 3,
+		},
+		{
+			name: "command not executed",
+			postProcess: func(ex command.ExecutionRequest) (*command.ExecutionResponse, error) {
+				return &command.ExecutionResponse{}, errors.New("no such file")
+			},
+			wantOutput: "check result post-processing failed: no such file",
+			wantStatus: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkConfig := corev2.FixtureCheckConfig("check")
+			request := &corev2.CheckRequest{Config: checkConfig, Issued: time.Now().Unix()}
+
+			config, cleanup := FixtureConfig()
+			defer cleanup()
+			config.CheckPostProcessCommands = map[string]string{"check": "post-process"}
+			config.PostProcessTimeout = 5
+			agent, err := NewAgent(config)
+			require.NoError(t, err)
+			ch := make(chan *transport.Message, 1)
+			agent.sendq = ch
+
+			agent.executor = &postProcessExecutor{
+				check: &command.ExecutionResponse{Output: "password=hunter2", Status: 1},
+				postProcess: func(ex command.ExecutionRequest) (*command.ExecutionResponse, error) {
+					assert.Equal(t, 5, ex.Timeout)
+					event := &corev2.Event{}
+					require.NoError(t, json.Unmarshal([]byte(ex.Input), event))
+					assert.Equal(t, "password=hunter2", event.Check.Output)
+					assert.Equal(t, uint32(1), event.Check.Status)
+					return tt.postProcess(ex)
+				},
+			}
+
+			agent.executeCheck(context.TODO(), request, agent.getAgentEntity())
+			msg := <-ch
+
+			event := &corev2.Event{}
+			require.NoError(t, json.Unmarshal(msg.Payload, event))
+			assert.Contains(t, event.Check.Output, tt.wantOutput)
+			assert.Equal(t, tt.wantStatus, event.Check.Status)
+		})
+	}
+}
+
+func TestPostProcessHooks(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      string
+		status      int
+		wantOutputs []string
+	}{
+		{
+			name:        "hook outputs rewritten",
+			result:      `{"hooks": {"hook1": "password=<redacted>"}}`,
+			wantOutputs: []string{"password=<redacted>", "ok"},
+		},
+		{
+			name:        "hook outputs discarded",
+			status:      1,
+			wantOutputs: []string{postProcessFailureOutput, postProcessFailureOutput},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, cleanup := FixtureConfig()
+			defer cleanup()
+			config.PostProcessCommand = "post-process"
+			agent, err := NewAgent(config)
+			require.NoError(t, err)
+			agent.executor = &postProcessExecutor{
+				postProcess: func(ex command.ExecutionRequest) (*command.ExecutionResponse, error) {
+					event := &corev2.Event{}
+					require.NoError(t, json.Unmarshal([]byte(ex.Input), event))
+					require.Len(t, event.Check.Hooks, 2)
+					assert.Equal(t, "password=hunter2", event.Check.Hooks[0].Output)
+					return &command.ExecutionResponse{Output: tt.result, Status: tt.status}, nil
+				},
+			}
+
+			event := corev2.FixtureEvent("entity", "check")
+			hook1, hook2 := corev2.FixtureHook("hook1"), corev2.FixtureHook("hook2")
+			hook1.Output, hook2.Output = "password=hunter2", "ok"
+			event.Check.Hooks = []*corev2.Hook{hook1, hook2}
+
+			agent.postProcess(event)
+			assert.Equal(t, tt.wantOutputs, []string{hook1.Output, hook2.Output})
+		})
+	}
+}
+
+func TestPrepareEventPostProcess(t *testing.T) {
+	config, cleanup := FixtureConfig()
+	defer cleanup()
+	config.PostProcessCommand = "post-process"
+	agent, err := NewAgent(config)
+	require.NoError(t, err)
+	agent.executor = &postProcessExecutor{
+		postProcess: func(ex command.ExecutionRequest) (*command.ExecutionResponse, error) {
+			return &command.ExecutionResponse{Output: `{"output": "password=<redacted>"}`}, nil
+		},
+	}
+
+	// The events received through the API and the sockets are post-processed
+	event := corev2.FixtureEvent("entity", "check")
+	event.Check.Output = "password=hunter2"
+	require.NoError(t, prepareEvent(agent, event))
+	assert.Equal(t, "password=<redacted>", event.Check.Output)
+}
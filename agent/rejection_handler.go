@@ -0,0 +1,47 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sensu/sensu-go/backend/agentd"
+	"github.com/sensu/sensu-go/transport"
+	"github.com/sirupsen/logrus"
+)
+
+// handleRejection is the rejection message handler. The backend rejects
+// messages that exceed its limits, and may provide a truncated event to emit
+// in place of the rejected one.
+func (a *Agent) handleRejection(ctx context.Context, payload []byte) error {
+	var rejection agentd.Rejection
+	if err := json.Unmarshal(payload, &rejection); err != nil {
+		return err
+	}
+
+	fields := logrus.Fields{
+		"type": rejection.MessageType,
+		"size": rejection.Size,
+	}
+	if rejection.Event != nil && rejection.Event.HasCheck() {
+		fields["check"] = rejection.Event.Check.Name
+	}
+	logger.WithFields(fields).Error("backend rejected message: ", rejection.Reason)
+
+	if rejection.Event == nil {
+		return nil
+	}
+
+	msg, err := a.marshal(rejection.Event)
+	if err != nil {
+		return err
+	}
+
+	logEvent(rejection.Event)
+
+	a.sendMessage(&transport.Message{
+		Type:    transport.MessageTypeEvent,
+		Payload: msg,
+	})
+
+	return nil
+}
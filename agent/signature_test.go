@@ -0,0 +1,46 @@
+package agent
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sensu/sensu-go/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	dir, err := ioutil.TempDir("", "agent")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "secret")
+	require.NoError(t, ioutil.WriteFile(path, []byte("s3cr3t"), 0600))
+	signingKey, err := transport.LoadSigningKey(path)
+	require.NoError(t, err)
+	verificationKey, err := transport.LoadVerificationKey("agent1", path)
+	require.NoError(t, err)
+	keys := []*transport.VerificationKey{verificationKey}
+
+	msg := transport.NewMessage(transport.MessageTypeKeepalive, []byte("keepalive"))
+
+	// Messages are not signed without a key, or for backends predating the
+	// signed messages
+	a := &Agent{signatureNonce: "nonce"}
+	assert.Equal(t, msg, a.sign(msg))
+	a = &Agent{signingKey: signingKey}
+	assert.Equal(t, msg, a.sign(msg))
+
+	// The sequence numbers of the session increase with each message
+	a.signatureNonce = "nonce"
+	for i := uint64(1); i <= 2; i++ {
+		signed := a.sign(msg)
+		assert.Equal(t, transport.MessageTypeSigned, signed.Type)
+		msgType, payload, sequence, err := transport.VerifySignedMessage(signed.Payload, "agent1", "nonce", keys)
+		require.NoError(t, err)
+		assert.Equal(t, transport.MessageTypeKeepalive, msgType)
+		assert.Equal(t, "keepalive", string(payload))
+		assert.Equal(t, i, sequence)
+	}
+}
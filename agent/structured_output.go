@@ -0,0 +1,70 @@
+package agent
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/sensu/sensu-go/agent/transformers"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// structuredOutput is the output of the checks with structured output.
+type structuredOutput struct {
+	// Status overrides the exit status of the check command.
+	Status *uint32 `json:"status"`
+
+	// Output is the human-readable output of the check.
+	Output string `json:"output"`
+
+	// Perfdata is performance data in the Nagios format, extracted as metrics.
+	Perfdata string `json:"perfdata"`
+
+	// Metadata is added to the annotations of the check.
+	Metadata map[string]string `json:"metadata"`
+}
+
+// parseStructuredOutput parses the output of a check with structured output
+// and sets its fields in the event, returning the metric points of its
+// perfdata. The output is left as is if it isn't a JSON object, e.g. when the
+// check command failed before writing it.
+func parseStructuredOutput(event *corev2.Event) []*corev2.MetricPoint {
+	var out structuredOutput
+	if err := json.Unmarshal([]byte(strings.TrimSpace(event.Check.Output)), &out); err != nil {
+		logger.WithFields(logrus.Fields{
+			"namespace": event.Check.Namespace,
+			"check":     event.Check.Name,
+		}).WithError(err).Warn("check output is not structured, leaving it as is")
+		return nil
+	}
+
+	event.Check.Output = out.Output
+	if out.Status != nil {
+		event.Check.Status = *out.Status
+	}
+	if len(out.Metadata) > 0 {
+		// The annotations are shared with the check configuration
+		annotations := make(map[string]string, len(event.Check.Annotations)+len(out.Metadata))
+		for key, value := range event.Check.Annotations {
+			annotations[key] = value
+		}
+		for key, value := range out.Metadata {
+			annotations[key] = value
+		}
+		event.Check.Annotations = annotations
+	}
+
+	if out.Perfdata == "" {
+		return nil
+	}
+	// The perfdata is parsed like the perfdata of Nagios plugins, which
+	// follows the human-readable output after a |
+	perfdata := &corev2.Event{
+		Check: &corev2.Check{
+			ObjectMeta: event.Check.ObjectMeta,
+			Output:     "|" + out.Perfdata,
+			Executed:   event.Check.Executed,
+		},
+	}
+	return transformers.ParseNagios(perfdata).Transform()
+}
@@ -0,0 +1,100 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/command"
+	"github.com/sensu/sensu-go/testing/mockexecutor"
+	"github.com/sensu/sensu-go/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStructuredOutput(t *testing.T) {
+	tests := []struct {
+		name            string
+		output          string
+		wantOutput      string
+		wantStatus      uint32
+		wantAnnotations map[string]string
+		wantPoints      int
+	}{
+		{
+			name:            "not structured",
+			output:          "CRITICAL: connection refused\n",
+			wantOutput:      "CRITICAL: connection refused\n",
+			wantStatus:      1,
+			wantAnnotations: map[string]string{"team": "ops"},
+		},
+		{
+			name:            "output only",
+			output:          `{"output": "OK: 3 processes"}`,
+			wantOutput:      "OK: 3 processes",
+			wantStatus:      1,
+			wantAnnotations: map[string]string{"team": "ops"},
+		},
+		{
+			name:            "all fields",
+			output:          "\n" + `{"status": 2, "output": "CRITICAL: disk full", "perfdata": "used=95%;80;90 free=5%", "metadata": {"team": "storage", "disk": "/"}}` + "\n",
+			wantOutput:      "CRITICAL: disk full",
+			wantStatus:      2,
+			wantAnnotations: map[string]string{"team": "storage", "disk": "/"},
+			wantPoints:      2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := corev2.FixtureCheckConfig("check")
+			config.Annotations = map[string]string{"team": "ops"}
+			event := corev2.FixtureEvent("entity", "check")
+			event.Check = corev2.NewCheck(config)
+			event.Check.Output = tt.output
+			event.Check.Status = 1
+			event.Check.Executed = 42
+
+			points := parseStructuredOutput(event)
+			assert.Equal(t, tt.wantOutput, event.Check.Output)
+			assert.Equal(t, tt.wantStatus, event.Check.Status)
+			assert.Equal(t, tt.wantAnnotations, event.Check.Annotations)
+			require.Len(t, points, tt.wantPoints)
+			for _, point := range points {
+				assert.Equal(t, int64(42), point.Timestamp)
+			}
+
+			// The annotations of the check configuration are left untouched
+			assert.Equal(t, map[string]string{"team": "ops"}, config.Annotations)
+		})
+	}
+}
+
+func TestExecuteCheckStructuredOutput(t *testing.T) {
+	checkConfig := corev2.FixtureCheckConfig("check")
+	checkConfig.StructuredOutput = true
+	request := &corev2.CheckRequest{Config: checkConfig, Issued: time.Now().Unix()}
+
+	config, cleanup := FixtureConfig()
+	defer cleanup()
+	agent, err := NewAgent(config)
+	require.NoError(t, err)
+	ch := make(chan *transport.Message, 1)
+	agent.sendq = ch
+	ex := &mockexecutor.MockExecutor{}
+	agent.executor = ex
+	ex.Return(command.FixtureExecutionResponse(0, `{"status": 1, "output": "WARNING: load is high", "perfdata": "load1=4.2"}`), nil)
+
+	agent.executeCheck(context.TODO(), request, agent.getAgentEntity())
+	msg := <-ch
+
+	event := &corev2.Event{}
+	require.NoError(t, json.Unmarshal(msg.Payload, event))
+	assert.Equal(t, "WARNING: load is high", event.Check.Output)
+	assert.Equal(t, uint32(1), event.Check.Status)
+	require.True(t, event.HasMetrics())
+	require.Len(t, event.Metrics.Points, 1)
+	assert.Equal(t, "load1", event.Metrics.Points[0].Name)
+	assert.Equal(t, 4.2, event.Metrics.Points[0].Value)
+}
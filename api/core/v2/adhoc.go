@@ -1,7 +1,6 @@
 package v2
 
 import (
-	"errors"
 	"fmt"
 	"net/url"
 )
@@ -25,8 +24,8 @@ func (a *AdhocRequest) StorePrefix() string {
 
 // Validate returns an error if the name is not provided.
 func (a *AdhocRequest) Validate() error {
-	if a.Name == "" {
-		return errors.New("must provide check name")
+	if err := ValidateName(a.Name); err != nil {
+		return NewFieldError("metadata.name", "check name "+err.Error())
 	}
 	return nil
 }
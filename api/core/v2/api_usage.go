@@ -0,0 +1,35 @@
+package v2
+
+// APIUsage is the usage of an API route by a user, summed over the running
+// backends of the cluster since they started.
+type APIUsage struct {
+	// User is the name of the user sending the requests, or "anonymous".
+	User string `json:"user"`
+
+	// APIKey is true if the requests were authenticated with an API key
+	// rather than an access token.
+	APIKey bool `json:"api_key"`
+
+	// Method is the HTTP method of the requests.
+	Method string `json:"method"`
+
+	// Route is the path template of the route, e.g.
+	// /api/{group:core}/{version:v2}/namespaces/{namespace}/{resource:checks}
+	Route string `json:"route"`
+
+	// Requests is the number of requests.
+	Requests int64 `json:"requests"`
+
+	// Errors is the number of requests that failed with a 4xx or 5xx status.
+	Errors int64 `json:"errors"`
+
+	// AverageDuration is the average duration of the requests, in seconds.
+	AverageDuration float64 `json:"average_duration"`
+
+	// MaxDuration is the duration of the longest request, in seconds.
+	MaxDuration float64 `json:"max_duration"`
+
+	// LastRequest is the time at which the last request was received, in
+	// seconds since the Unix epoch.
+	LastRequest int64 `json:"last_request"`
+}
@@ -27,15 +27,15 @@ func (a *APIKey) URIPath() string {
 // provided.
 func (a *APIKey) Validate() error {
 	if a.Namespace != "" {
-		return fmt.Errorf("api key cannot have a namespace")
+		return NewFieldError("metadata.namespace", "api key cannot have a namespace")
 	}
 
 	if a.Username == "" {
-		return fmt.Errorf("api key must have a username")
+		return NewFieldError("spec.username", "api key must have a username")
 	}
 
 	if _, err := uuid.Parse(a.Name); err != nil {
-		return fmt.Errorf("api key name: %s", err)
+		return NewFieldError("metadata.name", fmt.Sprintf("api key name: %s", err))
 	}
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"net/url"
 	"path"
 	"regexp"
@@ -19,7 +20,7 @@ const (
 
 var (
 	// AssetNameRegexStr used to validate name of asset
-	AssetNameRegexStr = `[\w\/\_\.\-\:]+`
+	AssetNameRegexStr = `[\p{L}\p{M}\p{N}\/\_\.\-\:]+`
 
 	// AssetNameRegex used to validate name of asset
 	AssetNameRegex = regexp.MustCompile("^" + AssetNameRegexStr + "$")
@@ -41,42 +42,22 @@ func (a *Asset) URIPath() string {
 // Validate returns an error if the asset contains invalid values.
 func (a *Asset) Validate() error {
 	if err := ValidateAssetName(a.Name); err != nil {
-		return err
+		return NewFieldError("metadata.name", err.Error())
 	}
 
 	if a.Namespace == "" {
-		return errors.New("namespace cannot be empty")
+		return NewFieldError("metadata.namespace", "namespace cannot be empty")
 	}
 
 	if len(a.Builds) == 0 {
-		if a.Sha512 == "" {
-			return errors.New("SHA-512 checksum cannot be empty")
+		if err := validateAssetBuild(a.Sha512, a.URL, a.Filters); err != nil {
+			return nestedFieldError("spec", "", err)
 		}
-
-		if len(a.Sha512) < 128 {
-			return errors.New("SHA-512 checksum must be at least 128 characters")
-		}
-
-		if a.URL == "" {
-			return errors.New("URL cannot be empty")
-		}
-
-		if a.URL != "" {
-			u, err := url.Parse(a.URL)
-			if err != nil {
-				return errors.New("invalid URL provided")
-			}
-
-			if u.Scheme != "https" && u.Scheme != "http" {
-				return errors.New("URL must be HTTP or HTTPS")
-			}
-		}
-
-		return js.ParseExpressions(a.Filters)
+		return nil
 	}
-	for _, build := range a.Builds {
+	for i, build := range a.Builds {
 		if err := build.Validate(); err != nil {
-			return err
+			return nestedFieldError(fmt.Sprintf("spec.builds[%d]", i), "", err)
 		}
 	}
 
@@ -85,42 +66,43 @@ func (a *Asset) Validate() error {
 
 // Validate returns an error if the asset contains invalid values.
 func (a *AssetBuild) Validate() error {
-	if a.Sha512 == "" {
-		return errors.New("SHA-512 checksum cannot be empty")
+	return validateAssetBuild(a.Sha512, a.URL, a.Filters)
+}
+
+// validateAssetBuild validates the checksum, URL and filters of an asset or
+// of one of its builds.
+func validateAssetBuild(sha512, assetURL string, filters []string) error {
+	if sha512 == "" {
+		return NewFieldError("sha512", "SHA-512 checksum cannot be empty")
 	}
 
-	if len(a.Sha512) < 128 {
-		return errors.New("SHA-512 checksum must be at least 128 characters")
+	if len(sha512) < 128 {
+		return NewFieldError("sha512", "SHA-512 checksum must be at least 128 characters")
 	}
 
-	if a.URL == "" {
-		return errors.New("URL cannot be empty")
+	if assetURL == "" {
+		return NewFieldError("url", "URL cannot be empty")
 	}
 
-	if a.URL != "" {
-		u, err := url.Parse(a.URL)
-		if err != nil {
-			return errors.New("invalid URL provided")
-		}
+	u, err := url.Parse(assetURL)
+	if err != nil {
+		return NewFieldError("url", "invalid URL provided")
+	}
 
-		if u.Scheme != "https" && u.Scheme != "http" {
-			return errors.New("URL must be HTTP or HTTPS")
-		}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return NewFieldError("url", "URL must be HTTP or HTTPS")
 	}
 
-	return js.ParseExpressions(a.Filters)
+	if err := js.ParseExpressions(filters); err != nil {
+		return NewFieldError("filters", err.Error())
+	}
+	return nil
 }
 
 // ValidateAssetName validates that asset's name is valid
 func ValidateAssetName(name string) error {
-	if name == "" {
-		return errors.New("name cannot be empty")
-	}
-
-	if !AssetNameRegex.MatchString(name) {
-		return errors.New(
-			"name may only contain letters, forward slashes, underscores, dashes and numbers",
-		)
+	if err := AssetNameSpec.Validate(name); err != nil {
+		return errors.New("name " + err.Error())
 	}
 
 	return nil
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFixtureCreatesValidAsset(t *testing.T) {
@@ -64,6 +65,22 @@ func TestValidator(t *testing.T) {
 	assert.NoError(asset.Validate())
 }
 
+func TestAssetValidateFieldError(t *testing.T) {
+	asset := FixtureAsset("name")
+	asset.URL = ""
+	fieldErr, ok := asset.Validate().(*FieldError)
+	require.True(t, ok)
+	assert.Equal(t, "spec.url", fieldErr.Field)
+
+	asset = FixtureAsset("name")
+	build := &AssetBuild{Sha512: asset.Sha512, URL: "file:///root/my_script.sh"}
+	asset.Builds = []*AssetBuild{{Sha512: asset.Sha512, URL: asset.URL}, build}
+	fieldErr, ok = asset.Validate().(*FieldError)
+	require.True(t, ok)
+	assert.Equal(t, "spec.builds[1].url", fieldErr.Field)
+	assert.Equal(t, "URL must be HTTP or HTTPS", fieldErr.Message)
+}
+
 func TestValidateName_GH3344(t *testing.T) {
 	assert := assert.New(t)
 	asset := FixtureAsset("my-asset:1.0.2")
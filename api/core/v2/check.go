@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"strings"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
@@ -22,6 +23,11 @@ const (
 	// DefaultSplayCoverage is the default splay coverage for proxy check requests
 	DefaultSplayCoverage = 90.0
 
+	// HighResolutionInterval is the interval, in seconds, below which the
+	// executions of a check are budgeted: they can't run longer than the
+	// interval of the check, so that they don't pile up on the agents.
+	HighResolutionInterval = 60
+
 	// NagiosOutputMetricFormat is the accepted string to represent the output metric format of
 	// Nagios Perf Data
 	NagiosOutputMetricFormat = "nagios_perfdata"
@@ -45,6 +51,10 @@ const (
 	// RegistrationCheckName is the name of the check that is created when an
 	// entity sends a keepalive and the entity does not yet exist in the store.
 	RegistrationCheckName = "registration"
+
+	// EntityConflictCheckName is the name of the check that is created when
+	// several agents send keepalives for the same entity.
+	EntityConflictCheckName = "entity_conflict"
 )
 
 // OutputMetricFormats represents all the accepted output_metric_format's a check can have
@@ -85,28 +95,32 @@ func NewCheck(c *CheckConfig) *Check {
 			Labels:      c.Labels,
 			Annotations: c.Annotations,
 		},
-		Command:              c.Command,
-		Handlers:             c.Handlers,
-		HighFlapThreshold:    c.HighFlapThreshold,
-		Interval:             c.Interval,
-		LowFlapThreshold:     c.LowFlapThreshold,
-		Publish:              c.Publish,
-		RuntimeAssets:        c.RuntimeAssets,
-		Subscriptions:        c.Subscriptions,
-		ProxyEntityName:      c.ProxyEntityName,
-		CheckHooks:           c.CheckHooks,
-		Stdin:                c.Stdin,
-		Subdue:               c.Subdue,
-		Cron:                 c.Cron,
-		Ttl:                  c.Ttl,
-		Timeout:              c.Timeout,
-		ProxyRequests:        c.ProxyRequests,
-		RoundRobin:           c.RoundRobin,
-		OutputMetricFormat:   c.OutputMetricFormat,
-		OutputMetricHandlers: c.OutputMetricHandlers,
-		EnvVars:              c.EnvVars,
-		DiscardOutput:        c.DiscardOutput,
-		MaxOutputSize:        c.MaxOutputSize,
+		Command:                c.Command,
+		Handlers:               c.Handlers,
+		HighFlapThreshold:      c.HighFlapThreshold,
+		Interval:               c.Interval,
+		LowFlapThreshold:       c.LowFlapThreshold,
+		Publish:                c.Publish,
+		RuntimeAssets:          c.RuntimeAssets,
+		Subscriptions:          c.Subscriptions,
+		ProxyEntityName:        c.ProxyEntityName,
+		CheckHooks:             c.CheckHooks,
+		Stdin:                  c.Stdin,
+		Subdue:                 c.Subdue,
+		Cron:                   c.Cron,
+		Ttl:                    c.Ttl,
+		Timeout:                c.Timeout,
+		ProxyRequests:          c.ProxyRequests,
+		RoundRobin:             c.RoundRobin,
+		OutputMetricFormat:     c.OutputMetricFormat,
+		OutputMetricHandlers:   c.OutputMetricHandlers,
+		OutputMetricThresholds: c.OutputMetricThresholds,
+		OutputMetricTags:       c.OutputMetricTags,
+		EnvVars:                c.EnvVars,
+		DiscardOutput:          c.DiscardOutput,
+		MaxOutputSize:          c.MaxOutputSize,
+		Redact:                 c.Redact,
+		StructuredOutput:       c.StructuredOutput,
 	}
 	if check.Labels == nil {
 		check.Labels = make(map[string]string)
@@ -148,40 +162,40 @@ func (c *Check) URIPath() string {
 // Validate returns an error if the check does not pass validation tests.
 func (c *Check) Validate() error {
 	if err := ValidateName(c.Name); err != nil {
-		return errors.New("check name " + err.Error())
+		return NewFieldError("metadata.name", "check name "+err.Error())
 	}
 	if c.Publish {
 		if c.Cron != "" {
 			if c.Interval > 0 {
-				return errors.New("must only specify either an interval or a cron schedule")
+				return NewFieldError("spec.cron", "must only specify either an interval or a cron schedule")
 			}
 
 			if _, err := cron.ParseStandard(c.Cron); err != nil {
-				return errors.New("check cron string is invalid")
+				return NewFieldError("spec.cron", "check cron string is invalid")
 			}
 		} else {
 			if c.Interval < 1 {
-				return errors.New("check interval must be greater than or equal to 1")
+				return NewFieldError("spec.interval", "check interval must be greater than or equal to 1")
 			}
 		}
 	}
 
 	if c.Ttl > 0 && c.Ttl <= int64(c.Interval) {
-		return errors.New("ttl must be greater than check interval")
+		return NewFieldError("spec.ttl", "ttl must be greater than check interval")
 	}
 	if c.Ttl > 0 && c.Ttl < 5 {
-		return errors.New("minimum ttl is 5 seconds")
+		return NewFieldError("spec.ttl", "minimum ttl is 5 seconds")
 	}
 
-	for _, assetName := range c.RuntimeAssets {
+	for i, assetName := range c.RuntimeAssets {
 		if err := ValidateAssetName(assetName); err != nil {
-			return fmt.Errorf("asset's %s", err)
+			return NewFieldError(fmt.Sprintf("spec.runtime_assets[%d]", i), fmt.Sprintf("asset's %s", err))
 		}
 	}
 
-	for _, subscription := range c.Subscriptions {
+	for i, subscription := range c.Subscriptions {
 		if subscription == "" {
-			return fmt.Errorf("subscriptions cannot be empty strings")
+			return NewFieldError(fmt.Sprintf("spec.subscriptions[%d]", i), "subscriptions cannot be empty strings")
 		}
 	}
 
@@ -189,35 +203,85 @@ func (c *Check) Validate() error {
 	// alphanumeric string)
 	if c.ProxyEntityName != "" {
 		if err := ValidateName(c.ProxyEntityName); err != nil {
-			return errors.New("proxy entity name " + err.Error())
+			return NewFieldError("spec.proxy_entity_name", "proxy entity name "+err.Error())
 		}
 	}
 
 	if c.ProxyRequests != nil {
 		if err := c.ProxyRequests.Validate(); err != nil {
-			return err
+			return nestedFieldError("spec.proxy_requests", "", err)
 		}
 	}
 
 	if c.OutputMetricFormat != "" {
 		if err := ValidateOutputMetricFormat(c.OutputMetricFormat); err != nil {
-			return err
+			return NewFieldError("spec.output_metric_format", err.Error(), OutputMetricFormats...)
 		}
 	}
 
 	if c.LowFlapThreshold != 0 && c.HighFlapThreshold != 0 && c.LowFlapThreshold >= c.HighFlapThreshold {
-		return errors.New("invalid flap thresholds")
+		return NewFieldError("spec.low_flap_threshold", "invalid flap thresholds")
+	}
+
+	if err := ValidateMetricThresholds(c.OutputMetricThresholds); err != nil {
+		return NewFieldError("spec.output_metric_thresholds", err.Error())
+	}
+
+	if err := ValidateMetricTags(c.OutputMetricTags); err != nil {
+		return NewFieldError("spec.output_metric_tags", err.Error())
 	}
 
 	if err := ValidateEnvVars(c.EnvVars); err != nil {
-		return err
+		return NewFieldError("spec.env_vars", err.Error())
 	}
 
 	if c.MaxOutputSize < 0 {
-		return fmt.Errorf("MaxOutputSize must be >= 0")
+		return NewFieldError("spec.max_output_size", "MaxOutputSize must be >= 0")
+	}
+
+	if err := c.Subdue.Validate(); err != nil {
+		return nestedFieldError("spec.subdue", "", err)
+	}
+	return nil
+}
+
+// GetRedactedCheck redacts the check according to the check's Redact fields.
+// A redacted copy is returned. The copy contains pointers to the original's
+// memory, with different Labels, Annotations and EnvVars.
+func (c *Check) GetRedactedCheck() *Check {
+	if c == nil {
+		return nil
+	}
+	if c.Labels == nil && c.Annotations == nil && c.EnvVars == nil {
+		return c
 	}
+	check := &Check{}
+	*check = *c
+	check.Annotations = redactMap(c.Annotations, c.Redact)
+	check.Labels = redactMap(c.Labels, c.Redact)
+	check.EnvVars = redactEnvVars(c.EnvVars, c.Redact)
+	return check
+}
 
-	return c.Subdue.Validate()
+// redactEnvVars redacts the values of the environment variables, in the
+// KEY=value form, whose keys are in the redact fields.
+func redactEnvVars(envVars []string, redact []string) []string {
+	if envVars == nil {
+		return nil
+	}
+	if len(redact) == 0 {
+		redact = DefaultRedactFields
+	}
+	result := make([]string, len(envVars))
+	for i, envVar := range envVars {
+		kv := strings.SplitN(envVar, "=", 2)
+		if len(kv) == 2 && utilstrings.FoundInArray(kv[0], redact) {
+			result[i] = kv[0] + "=" + Redacted
+		} else {
+			result[i] = envVar
+		}
+	}
+	return result
 }
 
 // MarshalJSON implements the json.Marshaler interface.
@@ -225,6 +289,9 @@ func (c *Check) MarshalJSON() ([]byte, error) {
 	if c == nil {
 		return []byte("null"), nil
 	}
+	// Redact the check before marshalling it so we don't leak any sensitive
+	// information
+	c = c.GetRedactedCheck()
 	if c.Subscriptions == nil {
 		c.Subscriptions = []string{}
 	}
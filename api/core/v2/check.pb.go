@@ -39,7 +39,11 @@ type CheckRequest struct {
 	// HookAssets is a map of assets required to execute hooks.
 	HookAssets map[string]*AssetList `protobuf:"bytes,5,rep,name=hook_assets,json=hookAssets,proto3" json:"hook_assets" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	// Secrets is a list of kv to be added to the env vars of a check.
-	Secrets              []string `protobuf:"bytes,6,rep,name=secrets,proto3" json:"secrets,omitempty"`
+	Secrets []string `protobuf:"bytes,6,rep,name=secrets,proto3" json:"secrets,omitempty"`
+	// ProxyEntity is the entity the check is executed for, when it is a proxy
+	// check, so that agents can substitute its tokens and pass it to the
+	// check command.
+	ProxyEntity          *Entity  `protobuf:"bytes,7,opt,name=proxy_entity,json=proxyEntity,proto3" json:"proxy_entity,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -120,6 +124,13 @@ func (m *CheckRequest) GetSecrets() []string {
 	return nil
 }
 
+func (m *CheckRequest) GetProxyEntity() *Entity {
+	if m != nil {
+		return m.ProxyEntity
+	}
+	return nil
+}
+
 // An AssetList represents a list of assets for a CheckRequest.
 type AssetList struct {
 	// Assets are a list of assets required to execute check or hook.
@@ -305,10 +316,37 @@ type CheckConfig struct {
 	DiscardOutput bool `protobuf:"varint,28,opt,name=discard_output,json=discardOutput,proto3" json:"discard_output,omitempty"`
 	// Secrets is the list of Sensu secrets to set for the check's
 	// execution environment.
-	Secrets              []*Secret `protobuf:"bytes,29,rep,name=secrets,proto3" json:"secrets"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Secrets []*Secret `protobuf:"bytes,29,rep,name=secrets,proto3" json:"secrets"`
+	// Template is the name of the check template the check inherits its
+	// unset attributes from.
+	Template string `protobuf:"bytes,30,opt,name=template,proto3" json:"template,omitempty"`
+	// CgroupCPULimit is the maximum CPU usage of the check command, in
+	// thousandths of a CPU core, enforced with a cgroup by Linux agents. The
+	// default of the agent is used if 0.
+	CgroupCPULimit uint32 `protobuf:"varint,31,opt,name=cgroup_cpu_limit,json=cgroupCpuLimit,proto3" json:"cgroup_cpu_limit,omitempty"`
+	// CgroupMemoryLimit is the maximum memory usage of the check command, in
+	// bytes, enforced with a cgroup by Linux agents. The default of the agent
+	// is used if 0.
+	CgroupMemoryLimit int64 `protobuf:"varint,32,opt,name=cgroup_memory_limit,json=cgroupMemoryLimit,proto3" json:"cgroup_memory_limit,omitempty"`
+	// OutputMetricThresholds are the rules evaluated against the metrics
+	// extracted from the check output, rewriting the status of the check
+	// when they are breached, e.g. critical metric "cpu.idle" < 10.
+	OutputMetricThresholds []string `protobuf:"bytes,33,rep,name=output_metric_thresholds,json=outputMetricThresholds,proto3" json:"output_metric_thresholds,omitempty"`
+	// Redact contains the fields of the labels, annotations and environment
+	// variables of the check to redact in its events.
+	Redact []string `protobuf:"bytes,34,rep,name=redact,proto3" json:"redact,omitempty"`
+	// StructuredOutput enables the parsing of the check output as a JSON
+	// object, whose status, output, perfdata and metadata fields are set in
+	// the event instead of the raw output.
+	StructuredOutput bool `protobuf:"varint,35,opt,name=structured_output,json=structuredOutput,proto3" json:"structured_output,omitempty"`
+	// OutputMetricTags are the tags added to every metric point extracted
+	// from the check output, in the name=value format. Their values can refer
+	// to the entity and to the check with tokens, e.g.
+	// region={{ .labels.region }} or check={{ .check.name }}.
+	OutputMetricTags     []string `protobuf:"bytes,36,rep,name=output_metric_tags,json=outputMetricTags,proto3" json:"output_metric_tags,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CheckConfig) Reset()         { *m = CheckConfig{} }
@@ -441,6 +479,22 @@ type Check struct {
 	// Secrets is the list of Sensu secrets to set for the check's
 	// execution environment.
 	Secrets []*Secret `protobuf:"bytes,41,rep,name=secrets,proto3" json:"secrets"`
+	// OutputMetricThresholds are the rules evaluated against the metrics
+	// extracted from the check output, rewriting the status of the check
+	// when they are breached, e.g. critical metric "cpu.idle" < 10.
+	OutputMetricThresholds []string `protobuf:"bytes,42,rep,name=output_metric_thresholds,json=outputMetricThresholds,proto3" json:"output_metric_thresholds,omitempty"`
+	// Redact contains the fields of the labels, annotations and environment
+	// variables of the check to redact in its events.
+	Redact []string `protobuf:"bytes,43,rep,name=redact,proto3" json:"redact,omitempty"`
+	// StructuredOutput enables the parsing of the check output as a JSON
+	// object, whose status, output, perfdata and metadata fields are set in
+	// the event instead of the raw output.
+	StructuredOutput bool `protobuf:"varint,44,opt,name=structured_output,json=structuredOutput,proto3" json:"structured_output,omitempty"`
+	// OutputMetricTags are the tags added to every metric point extracted
+	// from the check output, in the name=value format. Their values can refer
+	// to the entity and to the check with tokens, e.g.
+	// region={{ .labels.region }} or check={{ .check.name }}.
+	OutputMetricTags []string `protobuf:"bytes,45,rep,name=output_metric_tags,json=outputMetricTags,proto3" json:"output_metric_tags,omitempty"`
 	// ExtendedAttributes store serialized arbitrary JSON-encoded data
 	ExtendedAttributes   []byte   `protobuf:"bytes,99,opt,name=ExtendedAttributes,proto3" json:"-"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -564,100 +618,112 @@ func init() {
 func init() { proto.RegisterFile("check.proto", fileDescriptor_d8d3c606fb107336) }
 
 var fileDescriptor_d8d3c606fb107336 = []byte{
-	// 1480 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0x4f, 0x73, 0x13, 0x47,
-	0x16, 0xf7, 0x58, 0x58, 0x96, 0x5a, 0x96, 0x65, 0xb7, 0x6d, 0xdc, 0x16, 0xa0, 0x11, 0xde, 0x05,
-	0xb4, 0xb5, 0xbb, 0x62, 0x31, 0x4b, 0x2d, 0x4b, 0x71, 0x58, 0xe4, 0x85, 0x98, 0x04, 0x30, 0xd5,
-	0x90, 0xb8, 0x2a, 0x55, 0xa9, 0xa9, 0xd6, 0xa8, 0x2d, 0x4d, 0x2c, 0x4d, 0x2b, 0xd3, 0x3d, 0xb2,
-	0xcd, 0x25, 0xd7, 0x7c, 0x84, 0x1c, 0x39, 0x92, 0x53, 0xae, 0xb9, 0xe4, 0xce, 0x91, 0x4f, 0x30,
-	0x95, 0x38, 0x87, 0x54, 0xe9, 0x13, 0xe4, 0x98, 0xea, 0x37, 0x3d, 0xb2, 0x24, 0xcb, 0x40, 0xaa,
-	0x48, 0x55, 0x2a, 0xc5, 0xc5, 0xfd, 0xde, 0xaf, 0xdf, 0xeb, 0x3f, 0xaf, 0xdf, 0xfb, 0xbd, 0x91,
-	0x51, 0xce, 0x6d, 0x71, 0x77, 0xaf, 0xda, 0x0d, 0x84, 0x12, 0x38, 0x2f, 0xb9, 0x2f, 0xc3, 0xaa,
-	0x2b, 0x02, 0x5e, 0xed, 0x6d, 0x14, 0xff, 0xdd, 0xf4, 0x54, 0x2b, 0xac, 0x57, 0x5d, 0xd1, 0xb9,
-	0xda, 0x14, 0x4d, 0x71, 0x15, 0xac, 0xea, 0xe1, 0xee, 0xff, 0x7a, 0xd7, 0xaa, 0xd7, 0xab, 0xd7,
-	0x00, 0x04, 0x0c, 0xa4, 0x78, 0x91, 0x62, 0x8e, 0x49, 0xc9, 0x95, 0x51, 0x50, 0x4b, 0x88, 0xbd,
-	0x44, 0xee, 0x70, 0xc5, 0x8c, 0xbc, 0xa8, 0xbc, 0x0e, 0x77, 0xf6, 0x3d, 0xbf, 0x21, 0xf6, 0x0d,
-	0x34, 0x27, 0xb9, 0x1b, 0x24, 0x8e, 0xeb, 0xdf, 0xa4, 0xd0, 0xdc, 0xa6, 0x3e, 0x1a, 0xe5, 0x5f,
-	0x84, 0x5c, 0x2a, 0x7c, 0x13, 0xa5, 0x5d, 0xe1, 0xef, 0x7a, 0x4d, 0x62, 0x95, 0xad, 0x4a, 0x6e,
-	0xa3, 0x58, 0x1d, 0x39, 0x6c, 0x15, 0x8c, 0x37, 0xc1, 0xa2, 0x76, 0xe6, 0x65, 0x64, 0x5b, 0xd4,
-	0xd8, 0xe3, 0x0d, 0x94, 0x86, 0x23, 0x49, 0x32, 0x5d, 0x4e, 0x55, 0x72, 0x1b, 0xcb, 0x63, 0x9e,
-	0x77, 0xf4, 0x24, 0xf8, 0x4c, 0x51, 0x63, 0x89, 0x6f, 0xa0, 0x19, 0x7d, 0x72, 0x49, 0x52, 0xe0,
-	0xb2, 0x36, 0xe6, 0xb2, 0x25, 0xc4, 0xf0, 0x5e, 0x53, 0x34, 0xb6, 0xc6, 0xeb, 0x28, 0x7d, 0x5f,
-	0xca, 0x90, 0x37, 0xc8, 0x99, 0xb2, 0x55, 0x49, 0xd5, 0x50, 0x3f, 0xb2, 0xd3, 0x1e, 0x20, 0xd4,
-	0xcc, 0xe0, 0xcf, 0x50, 0x4e, 0x1b, 0x3b, 0xe6, 0x4c, 0x33, 0xb0, 0xc1, 0xdf, 0x27, 0xdd, 0xc6,
-	0x5c, 0x1d, 0x76, 0x83, 0x43, 0xca, 0xbb, 0xbe, 0x0a, 0x0e, 0x6b, 0x85, 0x7e, 0x64, 0x0f, 0xaf,
-	0x41, 0x21, 0xca, 0xb1, 0x05, 0x26, 0x68, 0x36, 0x0e, 0xa4, 0x24, 0xe9, 0x72, 0xaa, 0x92, 0xa5,
-	0x89, 0x5a, 0xdc, 0x41, 0x85, 0xb1, 0x95, 0xf0, 0x02, 0x4a, 0xed, 0xf1, 0x43, 0x88, 0x68, 0x96,
-	0x6a, 0x11, 0x57, 0xd1, 0x4c, 0x8f, 0xb5, 0x43, 0x4e, 0xa6, 0x21, 0xca, 0x64, 0x52, 0xac, 0x1e,
-	0x78, 0x52, 0xd1, 0xd8, 0xec, 0xd6, 0xf4, 0x4d, 0x6b, 0xfd, 0x3e, 0xca, 0x0e, 0x70, 0x7c, 0x7b,
-	0x10, 0x6d, 0xeb, 0x35, 0xd1, 0x9e, 0xd7, 0x51, 0xd3, 0xc1, 0x31, 0x37, 0x30, 0xe3, 0xfa, 0xb7,
-	0x16, 0xca, 0x3f, 0x0e, 0xc4, 0xc1, 0xa1, 0xb9, 0xbb, 0xc4, 0x35, 0xb4, 0xc8, 0x7d, 0xe5, 0xa9,
-	0x43, 0x87, 0x29, 0x15, 0x78, 0xf5, 0x50, 0xf1, 0x78, 0xe9, 0x6c, 0x6d, 0xa5, 0x1f, 0xd9, 0x27,
-	0x27, 0xe9, 0x42, 0x0c, 0xdd, 0x19, 0x20, 0xd8, 0x46, 0x33, 0xb2, 0xdb, 0x66, 0x87, 0x70, 0xa9,
-	0x4c, 0x2d, 0xdb, 0x8f, 0xec, 0x18, 0xa0, 0xf1, 0x80, 0xff, 0x8b, 0xe6, 0x41, 0x70, 0x5c, 0xd1,
-	0xe3, 0x01, 0x6b, 0x72, 0x92, 0x2a, 0x5b, 0x95, 0x7c, 0x0d, 0xf7, 0x23, 0x7b, 0x6c, 0x86, 0xe6,
-	0x41, 0xdf, 0x34, 0xea, 0xfa, 0xcf, 0x08, 0xe5, 0x86, 0x72, 0x4f, 0xc7, 0xdf, 0x15, 0x9d, 0x0e,
-	0xf3, 0x1b, 0x26, 0xac, 0x89, 0x8a, 0x2b, 0x28, 0xd3, 0x62, 0x7e, 0xa3, 0xcd, 0x83, 0x38, 0xad,
-	0xb2, 0xb5, 0xb9, 0x7e, 0x64, 0x0f, 0x30, 0x3a, 0x90, 0xf0, 0x07, 0x68, 0xa9, 0xe5, 0x35, 0x5b,
-	0xce, 0x6e, 0x9b, 0x75, 0x1d, 0xd5, 0x0a, 0xb8, 0x6c, 0x89, 0x76, 0x9c, 0x53, 0xf9, 0xda, 0x6a,
-	0x3f, 0xb2, 0x27, 0x4d, 0xd3, 0x45, 0x0d, 0xde, 0x6b, 0xb3, 0xee, 0xd3, 0x04, 0xd2, 0x5b, 0x7a,
-	0xbe, 0xe2, 0x41, 0x8f, 0xb5, 0xc9, 0x0c, 0x78, 0xc3, 0x96, 0x09, 0x46, 0x07, 0x12, 0xfe, 0x3f,
-	0xc2, 0x6d, 0xb1, 0x3f, 0xbe, 0x63, 0x1a, 0x7c, 0xce, 0xf6, 0x23, 0x7b, 0xc2, 0x2c, 0x5d, 0x68,
-	0x8b, 0xfd, 0xd1, 0xfd, 0x2e, 0xa1, 0xd9, 0x6e, 0x58, 0x6f, 0x7b, 0xb2, 0x45, 0xb2, 0x10, 0xea,
-	0x5c, 0x3f, 0xb2, 0x13, 0x88, 0x26, 0x82, 0x0e, 0x77, 0x10, 0xfa, 0x40, 0x01, 0x26, 0x57, 0x10,
-	0xc4, 0x03, 0xc2, 0x3d, 0x3a, 0x43, 0xf3, 0x46, 0x37, 0xe9, 0xfd, 0x1f, 0x94, 0x97, 0x61, 0x5d,
-	0xba, 0x81, 0xd7, 0x55, 0x9e, 0xf0, 0x25, 0xc9, 0x81, 0xe7, 0x62, 0x3f, 0xb2, 0x47, 0x27, 0xe8,
-	0xa8, 0x8a, 0x6f, 0x20, 0x7c, 0xf7, 0x40, 0x71, 0xbf, 0xc1, 0x1b, 0xc7, 0x99, 0x41, 0xe6, 0xca,
-	0x56, 0x65, 0xae, 0x36, 0xd3, 0x8f, 0x6c, 0xeb, 0x9f, 0x74, 0x82, 0x01, 0x7e, 0x8a, 0x16, 0xbb,
-	0x3a, 0x1f, 0x1d, 0x93, 0x67, 0x3e, 0xeb, 0x70, 0x92, 0xd7, 0x0f, 0x5b, 0xab, 0x1c, 0x45, 0x76,
-	0x01, 0x92, 0xf5, 0x2e, 0xcc, 0x3d, 0x62, 0x1d, 0xae, 0x33, 0xf2, 0x84, 0x3d, 0x2d, 0x74, 0x47,
-	0xad, 0xf0, 0x43, 0xc3, 0xbb, 0x4e, 0x4c, 0x32, 0xf3, 0x50, 0x29, 0xab, 0x13, 0x48, 0x46, 0x97,
-	0x54, 0x6d, 0xc9, 0x14, 0xcb, 0xb0, 0x0f, 0x45, 0xa0, 0x6c, 0x01, 0xed, 0xe8, 0xfc, 0x56, 0x0d,
-	0xcf, 0x27, 0x85, 0xa1, 0xfc, 0xd6, 0x00, 0x8d, 0x07, 0x7c, 0x07, 0xa5, 0x65, 0x58, 0x6f, 0x84,
-	0x9c, 0x2c, 0x40, 0x59, 0x5f, 0x18, 0xdb, 0xea, 0xa9, 0xd7, 0xe1, 0x3b, 0x40, 0xc6, 0x3b, 0x2d,
-	0xee, 0xc7, 0xb4, 0x15, 0x3b, 0x50, 0x33, 0x62, 0x8c, 0xce, 0xb8, 0x81, 0xf0, 0xc9, 0x22, 0x24,
-	0x35, 0xc8, 0x78, 0x0d, 0xa5, 0x94, 0x6a, 0x13, 0x0c, 0x5c, 0x37, 0xdb, 0x8f, 0x6c, 0xad, 0x52,
-	0xfd, 0x47, 0x67, 0x82, 0x7e, 0x35, 0x11, 0x2a, 0xb2, 0x04, 0x49, 0x04, 0x99, 0x60, 0x20, 0x9a,
-	0x08, 0x78, 0x13, 0xcd, 0xc7, 0xe1, 0x0a, 0x4c, 0xbd, 0x93, 0x65, 0x38, 0xe0, 0xf9, 0xb1, 0x03,
-	0x8e, 0x70, 0x02, 0xcd, 0x77, 0x47, 0x28, 0xe2, 0x5f, 0x28, 0x17, 0x88, 0xd0, 0x6f, 0x38, 0x81,
-	0xa8, 0x7b, 0x3e, 0x59, 0x81, 0x20, 0x00, 0x49, 0x0e, 0xc1, 0x14, 0x81, 0x42, 0xb5, 0x8c, 0x3f,
-	0x44, 0xcb, 0x22, 0x54, 0xdd, 0x50, 0x39, 0x1d, 0xae, 0x02, 0xcf, 0x75, 0x76, 0x45, 0xd0, 0x61,
-	0x8a, 0x9c, 0x85, 0x87, 0x25, 0xfd, 0xc8, 0x9e, 0x38, 0x4f, 0x71, 0x8c, 0x3e, 0x04, 0xf0, 0x1e,
-	0x60, 0xf8, 0x31, 0x3a, 0x3b, 0x6a, 0x3b, 0x28, 0xf2, 0x55, 0x48, 0xcd, 0x62, 0x3f, 0xb2, 0x4f,
-	0xb1, 0xa0, 0xcb, 0xc3, 0xeb, 0x6d, 0x25, 0xe5, 0x7f, 0x05, 0x65, 0xb8, 0xdf, 0x73, 0x7a, 0x2c,
-	0x90, 0x84, 0x1c, 0x13, 0x45, 0x82, 0xd1, 0x59, 0xee, 0xf7, 0x3e, 0x61, 0x81, 0xc4, 0x1f, 0xa3,
-	0x8c, 0xee, 0xa9, 0x0d, 0xa6, 0x18, 0x29, 0x42, 0xdc, 0xc6, 0x1b, 0xd5, 0x76, 0xfd, 0x73, 0xee,
-	0xea, 0xf5, 0x59, 0xad, 0xa4, 0xb3, 0xe8, 0x55, 0x64, 0x5b, 0xba, 0x9a, 0x13, 0xb7, 0x7f, 0x88,
-	0x8e, 0xa7, 0x78, 0xa7, 0xab, 0x0e, 0xe9, 0x60, 0x29, 0x7c, 0x19, 0x15, 0x3a, 0xec, 0xc0, 0x31,
-	0x67, 0x96, 0xde, 0x33, 0x4e, 0xce, 0xe9, 0x27, 0xa6, 0xf9, 0x0e, 0x3b, 0xd8, 0x06, 0xf4, 0x89,
-	0xf7, 0x8c, 0xe3, 0x4b, 0x68, 0xbe, 0xe1, 0x49, 0x97, 0x05, 0x0d, 0x63, 0x4b, 0xce, 0xeb, 0xd0,
-	0xd3, 0xbc, 0x41, 0x63, 0x53, 0x7c, 0xfb, 0xb8, 0x23, 0x5d, 0x80, 0x44, 0x5f, 0x19, 0x3b, 0xe4,
-	0x13, 0x98, 0x8d, 0x33, 0xc4, 0x58, 0x0e, 0xba, 0xd6, 0xad, 0xcc, 0x57, 0xcf, 0xed, 0xa9, 0x17,
-	0xcf, 0x6d, 0x6b, 0xfd, 0xfb, 0x02, 0x9a, 0x01, 0xa6, 0x7d, 0xcf, 0xb1, 0x7f, 0x50, 0x8e, 0x7d,
-	0x4f, 0x96, 0x7f, 0x46, 0xb2, 0x2c, 0xa2, 0x4c, 0x23, 0x0c, 0x98, 0x7e, 0x62, 0x20, 0x48, 0x8b,
-	0x0e, 0x74, 0x9d, 0xfc, 0xfc, 0x80, 0xbb, 0xa1, 0xe2, 0x0d, 0xb2, 0x0a, 0x37, 0x8b, 0xa9, 0xca,
-	0x60, 0x74, 0x20, 0xe1, 0x7b, 0x68, 0xb6, 0xe5, 0x49, 0x25, 0x82, 0x43, 0xe0, 0xb4, 0xdc, 0xc6,
-	0xb9, 0x49, 0x9f, 0xbc, 0x5b, 0xb1, 0x49, 0xad, 0x60, 0x5e, 0x31, 0xf1, 0xa1, 0x89, 0xa0, 0x3f,
-	0xb1, 0xe3, 0x0f, 0x6a, 0xb2, 0x76, 0xf2, 0x13, 0x3b, 0x1e, 0xb5, 0x8d, 0x21, 0xa4, 0x22, 0x24,
-	0x1f, 0xd8, 0xc4, 0x08, 0x35, 0x23, 0x5e, 0xd6, 0x69, 0xc0, 0x54, 0x4c, 0x6d, 0x59, 0x1a, 0x2b,
-	0xda, 0x53, 0x0b, 0xa1, 0x04, 0x2a, 0xcb, 0x9b, 0xc7, 0x05, 0x84, 0x9a, 0x51, 0x97, 0xb1, 0x12,
-	0x8a, 0xb5, 0x1d, 0x70, 0x71, 0xdc, 0x16, 0xf3, 0x9b, 0x9c, 0x5c, 0x38, 0x2e, 0xe3, 0x93, 0xb3,
-	0x74, 0x01, 0xb0, 0x27, 0x1a, 0xda, 0x04, 0x04, 0x57, 0xd1, 0x6c, 0x9b, 0x49, 0xe5, 0x88, 0x3d,
-	0x52, 0x82, 0x8b, 0xac, 0x1c, 0x45, 0x76, 0xfa, 0x01, 0x93, 0x6a, 0xfb, 0x23, 0x7d, 0x71, 0x33,
-	0x49, 0xd3, 0x5a, 0xd8, 0xde, 0xc3, 0xd7, 0x50, 0x4e, 0xb8, 0x6e, 0x18, 0x04, 0xdc, 0x77, 0xb9,
-	0x24, 0x36, 0xf8, 0xc0, 0xbb, 0x0d, 0xc1, 0x74, 0x58, 0xc1, 0x8f, 0xd0, 0xca, 0x90, 0xea, 0xec,
-	0x33, 0xc5, 0x83, 0x0e, 0x0b, 0xf6, 0x48, 0x19, 0x9c, 0xd7, 0xfa, 0x91, 0x3d, 0xd9, 0x80, 0x2e,
-	0x0f, 0xc1, 0x3b, 0x09, 0x8a, 0xcb, 0x28, 0x23, 0xbd, 0xb6, 0x06, 0x1b, 0xe4, 0x22, 0x50, 0x42,
-	0xfc, 0x43, 0x6b, 0x80, 0xe2, 0xab, 0xc9, 0xcf, 0xa6, 0x75, 0x78, 0xe2, 0xa5, 0x09, 0x45, 0x6a,
-	0x7c, 0xcc, 0x0f, 0xa6, 0xd3, 0x1a, 0xf1, 0x5f, 0xde, 0x69, 0x23, 0xfe, 0xeb, 0x3b, 0x68, 0xc4,
-	0x97, 0xde, 0xb6, 0x11, 0x5f, 0xfe, 0x5d, 0x1b, 0xf1, 0x95, 0xb7, 0x6b, 0xc4, 0x95, 0x37, 0x34,
-	0xe2, 0xbf, 0xfd, 0xe6, 0x46, 0x7c, 0xca, 0x07, 0xb4, 0xfb, 0x86, 0x0f, 0xe8, 0xa1, 0xfe, 0xfd,
-	0xa5, 0xf9, 0x45, 0xbf, 0x75, 0x5c, 0xc9, 0xa6, 0xd6, 0xac, 0x53, 0x6b, 0x6d, 0x98, 0x5f, 0xa6,
-	0x5f, 0xcb, 0x2f, 0x17, 0x51, 0x46, 0xb7, 0xce, 0xae, 0xe7, 0x37, 0xe1, 0xc7, 0x5b, 0x26, 0x39,
-	0xd4, 0x00, 0xae, 0x95, 0x7f, 0xf9, 0xb1, 0x64, 0xbd, 0x38, 0x2a, 0x59, 0xdf, 0x1d, 0x95, 0xac,
-	0x97, 0x47, 0x25, 0xeb, 0xd5, 0x51, 0xc9, 0xfa, 0xe1, 0xa8, 0x64, 0x7d, 0xfd, 0x53, 0x69, 0xea,
-	0xd3, 0xe9, 0xde, 0x46, 0x3d, 0x0d, 0xff, 0x7c, 0xb8, 0xfe, 0x6b, 0x00, 0x00, 0x00, 0xff, 0xff,
-	0x1d, 0x5d, 0xc1, 0x63, 0x16, 0x11, 0x00, 0x00,
+	// 1666 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xff, 0xed, 0x58, 0x4b, 0x73, 0x1b, 0x45,
+	0x10, 0xb6, 0xe2, 0x58, 0x96, 0x47, 0x96, 0x2d, 0x8d, 0x1f, 0x59, 0xdb, 0x89, 0xe5, 0x28, 0x2f,
+	0x43, 0x12, 0x87, 0x38, 0x50, 0x84, 0x14, 0x07, 0xb2, 0x22, 0xc1, 0x01, 0x27, 0x76, 0x4d, 0x12,
+	0x5c, 0x45, 0x15, 0xb5, 0xac, 0x56, 0x63, 0x69, 0xb1, 0xb4, 0x2b, 0x76, 0x67, 0xfd, 0xe0, 0xc2,
+	0x95, 0x9f, 0xc0, 0x8d, 0x1c, 0x73, 0xe3, 0xca, 0x4f, 0xc8, 0x31, 0xbf, 0x20, 0x05, 0xe1, 0x06,
+	0x7f, 0x80, 0x1b, 0xf4, 0xf4, 0xcc, 0xae, 0x57, 0xb2, 0x9c, 0x84, 0x94, 0xa9, 0xa2, 0xa8, 0x1c,
+	0xe4, 0x9d, 0xf9, 0xba, 0x7b, 0x1e, 0xfd, 0x1e, 0x93, 0xbc, 0xd3, 0xe4, 0xce, 0xd6, 0x52, 0x27,
+	0xf0, 0x85, 0x4f, 0x0b, 0x21, 0xf7, 0xc2, 0x68, 0xc9, 0xf1, 0x03, 0xbe, 0xb4, 0xbd, 0x3c, 0xfb,
+	0x6e, 0xc3, 0x15, 0xcd, 0xa8, 0x06, 0xf3, 0xf6, 0x95, 0x86, 0xdf, 0xf0, 0xaf, 0x20, 0x57, 0x2d,
+	0xda, 0xfc, 0x68, 0xfb, 0xea, 0xd2, 0xb5, 0xa5, 0xab, 0x08, 0x22, 0x86, 0x23, 0xb5, 0xc8, 0x6c,
+	0xde, 0x0e, 0x43, 0x2e, 0xf4, 0x84, 0x34, 0x7d, 0x7f, 0x2b, 0x1e, 0xb7, 0xb9, 0xb0, 0xf5, 0xb8,
+	0x24, 0xdc, 0x36, 0xb7, 0x76, 0x5c, 0xaf, 0xee, 0xef, 0x68, 0x68, 0x34, 0xe4, 0x4e, 0x90, 0x08,
+	0x8e, 0x72, 0x4f, 0xb8, 0x62, 0x4f, 0xcd, 0x2a, 0x7f, 0x0d, 0x92, 0xd1, 0xaa, 0x3c, 0x28, 0xe3,
+	0xdf, 0x44, 0x3c, 0x14, 0xf4, 0x3a, 0xc9, 0x3a, 0xbe, 0xb7, 0xe9, 0x36, 0x8c, 0xcc, 0x42, 0x66,
+	0x31, 0xbf, 0x3c, 0xbb, 0xd4, 0x75, 0xf4, 0x25, 0x64, 0xae, 0x22, 0x87, 0x79, 0xfc, 0xc9, 0xb3,
+	0x72, 0x86, 0x69, 0x7e, 0xba, 0x4c, 0xb2, 0x78, 0xc0, 0xd0, 0x38, 0xb6, 0x30, 0x08, 0x92, 0x93,
+	0x3d, 0x92, 0x37, 0x25, 0x11, 0x65, 0x06, 0x98, 0xe6, 0xa4, 0xef, 0x91, 0x21, 0x79, 0x8f, 0xd0,
+	0x18, 0x44, 0x91, 0x99, 0x1e, 0x91, 0x15, 0xa0, 0xa5, 0xf6, 0x1a, 0x60, 0x8a, 0x9b, 0x56, 0x48,
+	0xf6, 0x4e, 0x18, 0x46, 0xbc, 0x6e, 0x1c, 0x87, 0x43, 0x0e, 0x9a, 0xe4, 0xf7, 0x67, 0xe5, 0xac,
+	0x8b, 0x08, 0xd3, 0x14, 0xfa, 0x25, 0xc9, 0x4b, 0x66, 0x4b, 0x9f, 0x69, 0x08, 0x37, 0xb8, 0xd8,
+	0xef, 0x36, 0xfa, 0xea, 0xb8, 0x1b, 0x1e, 0x32, 0xbc, 0xe5, 0x89, 0x60, 0xcf, 0x1c, 0x87, 0x55,
+	0xd3, 0x6b, 0x30, 0xd4, 0xb9, 0xe2, 0xa0, 0x06, 0x19, 0x56, 0x6a, 0x0d, 0x8d, 0x2c, 0x2c, 0x3d,
+	0xc2, 0xe2, 0x29, 0x7d, 0x40, 0x46, 0x41, 0xb7, 0xbb, 0x7b, 0x96, 0x52, 0xb4, 0x31, 0x8c, 0x7a,
+	0x9c, 0xea, 0xd9, 0xf9, 0x16, 0x12, 0xcd, 0x59, 0xd8, 0x63, 0x3a, 0xcd, 0x7e, 0xc9, 0x6f, 0xbb,
+	0x82, 0xb7, 0x3b, 0x62, 0x8f, 0xe5, 0x11, 0x57, 0x8c, 0xb3, 0x1b, 0x64, 0xbc, 0xe7, 0x7c, 0xb4,
+	0x48, 0x06, 0xb7, 0xf8, 0x1e, 0xda, 0x69, 0x84, 0xc9, 0x21, 0x5d, 0x22, 0x43, 0xdb, 0x76, 0x2b,
+	0xe2, 0x60, 0x01, 0xb9, 0xa7, 0xd1, 0xcf, 0x02, 0xab, 0x6e, 0x28, 0x98, 0x62, 0xbb, 0x71, 0xec,
+	0x7a, 0xa6, 0x72, 0x87, 0x8c, 0x24, 0x38, 0xfd, 0x30, 0xb1, 0x61, 0xe6, 0x05, 0x36, 0x1c, 0x93,
+	0xb6, 0x90, 0x2a, 0xd7, 0x7a, 0xd1, 0xdf, 0xca, 0x4f, 0x19, 0x52, 0x58, 0x97, 0x67, 0xd6, 0x1a,
+	0x0d, 0xa9, 0x49, 0x4a, 0xea, 0x5a, 0x96, 0x2d, 0x44, 0xe0, 0xd6, 0x22, 0xc1, 0xd5, 0xd2, 0x23,
+	0xe6, 0x14, 0x2c, 0x70, 0x90, 0xc8, 0x8a, 0x0a, 0xba, 0x99, 0x20, 0xb4, 0x4c, 0x86, 0xc2, 0x4e,
+	0xcb, 0xde, 0xc3, 0x4b, 0xe5, 0xcc, 0x11, 0x90, 0x53, 0x00, 0x53, 0x1f, 0xfa, 0x01, 0x19, 0xc3,
+	0x81, 0xe5, 0xf8, 0xdb, 0x3c, 0xb0, 0x1b, 0x1c, 0xbc, 0x29, 0xb3, 0x58, 0x30, 0x29, 0x70, 0xf6,
+	0x50, 0x58, 0x01, 0xe7, 0x55, 0x3d, 0xad, 0xfc, 0x38, 0x46, 0xf2, 0x29, 0x8f, 0x96, 0x56, 0x85,
+	0x98, 0x6c, 0xdb, 0x5e, 0x5d, 0xab, 0x35, 0x9e, 0xd2, 0x45, 0x92, 0x6b, 0xc2, 0xb7, 0xc5, 0x03,
+	0xe5, 0xac, 0x23, 0xe6, 0x28, 0x2c, 0x9f, 0x60, 0x2c, 0x19, 0xd1, 0x4f, 0xc8, 0x44, 0xd3, 0x6d,
+	0x34, 0xad, 0xcd, 0x96, 0xdd, 0xb1, 0x44, 0x33, 0xe0, 0x61, 0xd3, 0x6f, 0x29, 0x4f, 0x2d, 0x98,
+	0x27, 0x40, 0xa8, 0x1f, 0x99, 0x95, 0x24, 0x78, 0x1b, 0xb0, 0x07, 0x31, 0x24, 0xb7, 0x74, 0x3d,
+	0xc1, 0x03, 0xb0, 0x15, 0xb8, 0xaf, 0x94, 0xc6, 0x2d, 0x63, 0x8c, 0x25, 0x23, 0xfa, 0x31, 0xa1,
+	0x2d, 0x7f, 0xa7, 0x77, 0xc7, 0x2c, 0xca, 0x4c, 0x83, 0x4c, 0x1f, 0x2a, 0x2b, 0x02, 0xd6, 0xbd,
+	0xdf, 0x39, 0x32, 0xdc, 0x89, 0x6a, 0x2d, 0x37, 0x6c, 0x1a, 0x23, 0xa8, 0xea, 0x3c, 0x88, 0xc6,
+	0x10, 0x8b, 0x07, 0x52, 0xdd, 0x41, 0xe4, 0x61, 0x9a, 0xd1, 0xbe, 0x42, 0x50, 0x1f, 0xa8, 0xee,
+	0x6e, 0x0a, 0x2b, 0xe8, 0xb9, 0x0e, 0x9a, 0xf7, 0x49, 0x21, 0x8c, 0x6a, 0xa1, 0x13, 0xb8, 0x1d,
+	0xe1, 0xfa, 0x5e, 0x68, 0xe4, 0x51, 0xb2, 0x04, 0x92, 0xdd, 0x04, 0xd6, 0x3d, 0x85, 0x3c, 0x41,
+	0x6f, 0xed, 0x0a, 0xee, 0xd5, 0x79, 0x7d, 0xdf, 0x33, 0x8c, 0x51, 0x38, 0xe5, 0xa8, 0x39, 0x04,
+	0xd2, 0x99, 0xcb, 0xac, 0x0f, 0x03, 0x84, 0x62, 0x29, 0x1d, 0x5b, 0x96, 0x67, 0xb7, 0xb9, 0x51,
+	0x90, 0x86, 0x35, 0x17, 0x9f, 0x3f, 0x2b, 0x8f, 0xaf, 0xef, 0x07, 0xd8, 0x3d, 0x20, 0x49, 0x8f,
+	0x3c, 0xc0, 0xcf, 0xc6, 0x3b, 0xdd, 0x5c, 0xf4, 0x2e, 0x51, 0xb9, 0xdd, 0x52, 0xa9, 0x6b, 0x0c,
+	0x23, 0xe5, 0x44, 0x9f, 0xd4, 0x25, 0x43, 0xca, 0x9c, 0xd0, 0xc1, 0x92, 0x96, 0x61, 0x04, 0x27,
+	0x2b, 0x98, 0xcc, 0xa4, 0x7f, 0x8b, 0xba, 0xeb, 0x19, 0xe3, 0x29, 0xff, 0x96, 0x00, 0x53, 0x1f,
+	0x7a, 0x93, 0x64, 0x41, 0x1b, 0x75, 0x08, 0xeb, 0x22, 0x86, 0xf5, 0xa9, 0x9e, 0xad, 0x1e, 0x80,
+	0x82, 0x37, 0x30, 0xe1, 0x6f, 0x34, 0xb9, 0xa7, 0x92, 0xa1, 0x12, 0x60, 0xfa, 0x4b, 0x29, 0x39,
+	0xee, 0x04, 0xbe, 0x67, 0x94, 0xd0, 0xa9, 0x71, 0x4c, 0x67, 0xc8, 0xa0, 0x10, 0x2d, 0x83, 0x62,
+	0x06, 0x1d, 0x06, 0x21, 0x39, 0x65, 0xf2, 0x8f, 0xf4, 0x04, 0x69, 0x35, 0x3f, 0x12, 0xc6, 0x04,
+	0x3a, 0x11, 0x7a, 0x82, 0x86, 0x58, 0x3c, 0xa0, 0x55, 0x32, 0xa6, 0xd4, 0x15, 0xe8, 0x78, 0x37,
+	0x26, 0xf1, 0x80, 0x27, 0x7b, 0x0e, 0xd8, 0x95, 0x13, 0x58, 0xa1, 0xd3, 0x95, 0x22, 0xde, 0x21,
+	0xf9, 0xc0, 0x8f, 0xbc, 0xba, 0x15, 0xf8, 0x35, 0x50, 0xc2, 0x14, 0x2a, 0x01, 0x53, 0x6f, 0x0a,
+	0x66, 0x04, 0x27, 0x4c, 0x8e, 0xe9, 0xa7, 0x64, 0x12, 0x76, 0xef, 0x44, 0xc2, 0x82, 0xba, 0x17,
+	0xb8, 0x8e, 0xb5, 0xe9, 0x07, 0x6d, 0x5b, 0x18, 0xd3, 0x68, 0x58, 0x03, 0x44, 0xfb, 0xd2, 0x19,
+	0x55, 0xe8, 0x5d, 0x04, 0x6f, 0x23, 0x46, 0xd7, 0xc9, 0x74, 0x37, 0x6f, 0x12, 0xe4, 0x27, 0xd0,
+	0x35, 0x31, 0x3f, 0xf7, 0xe7, 0x60, 0x93, 0xe9, 0xf5, 0x56, 0xe2, 0xf0, 0xbf, 0x40, 0x72, 0xdc,
+	0xdb, 0xb6, 0xb6, 0x6d, 0x58, 0xc3, 0xd8, 0x4f, 0x14, 0x31, 0xc6, 0x86, 0x61, 0xf4, 0x39, 0x0c,
+	0xe8, 0x43, 0x92, 0x93, 0x75, 0xbb, 0x6e, 0x0b, 0xdb, 0x98, 0x45, 0xbd, 0xf5, 0x96, 0xbf, 0xb5,
+	0xda, 0xd7, 0xdc, 0x91, 0xeb, 0xdb, 0xe6, 0xbc, 0xf4, 0xa2, 0xa7, 0xe0, 0xe8, 0x32, 0x9a, 0x63,
+	0xb1, 0x54, 0xad, 0x48, 0x96, 0xa2, 0xe7, 0xc9, 0x78, 0xdb, 0xde, 0xb5, 0xf4, 0x99, 0x43, 0xf7,
+	0x5b, 0x6e, 0xcc, 0x49, 0x13, 0xb3, 0x02, 0xc0, 0x6b, 0x88, 0xde, 0x07, 0x10, 0x6c, 0x3c, 0x56,
+	0x77, 0x43, 0xc7, 0x0e, 0xea, 0x9a, 0xd7, 0x38, 0x29, 0x55, 0xcf, 0x0a, 0x1a, 0x55, 0xac, 0x50,
+	0x11, 0x92, 0x3a, 0x77, 0x0a, 0x1d, 0xbd, 0xb7, 0x90, 0xdd, 0x47, 0xaa, 0xf2, 0x10, 0xcd, 0xb9,
+	0x5f, 0x0b, 0x67, 0x49, 0x4e, 0x1e, 0xb0, 0x65, 0x0b, 0x6e, 0xcc, 0xa3, 0xef, 0x25, 0x73, 0x58,
+	0xb9, 0xe8, 0x34, 0xc0, 0xac, 0x1d, 0xcb, 0xe9, 0x44, 0x56, 0xcb, 0x85, 0xbb, 0x18, 0x65, 0x95,
+	0xb8, 0x21, 0x36, 0xc7, 0xaa, 0x48, 0xab, 0xae, 0x3f, 0x5c, 0x95, 0x14, 0x36, 0xa6, 0x78, 0xab,
+	0x9d, 0x08, 0xe7, 0x50, 0xea, 0x26, 0xb4, 0x74, 0x9b, 0xb7, 0xfd, 0x60, 0x4f, 0x2f, 0xb0, 0x80,
+	0x57, 0x2d, 0x29, 0xd2, 0x5d, 0xa4, 0x28, 0xfe, 0xaf, 0x88, 0xd1, 0x6d, 0xc6, 0x24, 0x13, 0x86,
+	0xc6, 0x69, 0x34, 0xd3, 0x79, 0xb8, 0x41, 0xe5, 0x30, 0x9e, 0x94, 0xaa, 0xa7, 0xd3, 0x66, 0x4f,
+	0xb2, 0x67, 0x48, 0x2f, 0x91, 0x6c, 0xc0, 0xeb, 0xb6, 0x23, 0x8c, 0x0a, 0xae, 0x37, 0x09, 0xeb,
+	0x15, 0x15, 0x92, 0x92, 0xd6, 0x3c, 0x74, 0x95, 0x94, 0x42, 0x11, 0x44, 0x8e, 0x88, 0x00, 0x88,
+	0x2d, 0x70, 0x06, 0x9d, 0xbf, 0x0c, 0x82, 0x73, 0x07, 0x88, 0xa9, 0x35, 0x8a, 0xfb, 0x44, 0x6d,
+	0xa5, 0x7b, 0x84, 0xf6, 0x9c, 0xdc, 0x6e, 0x84, 0xc6, 0x59, 0x3c, 0xc7, 0x02, 0x2c, 0x77, 0xf2,
+	0x20, 0x35, 0xbd, 0x5e, 0xd7, 0x8d, 0x80, 0x76, 0x23, 0xf7, 0xfd, 0xa3, 0xf2, 0xc0, 0xe3, 0x47,
+	0xe5, 0x4c, 0xe5, 0x8f, 0x12, 0x19, 0xc2, 0x0a, 0xf9, 0xa6, 0x36, 0xfe, 0x47, 0x6b, 0xe3, 0x9b,
+	0x22, 0xf7, 0x7f, 0x2c, 0x72, 0x90, 0x39, 0xeb, 0x51, 0x60, 0x4b, 0x13, 0x63, 0x61, 0xcb, 0xb0,
+	0x64, 0x2e, 0x9d, 0x9f, 0xef, 0x72, 0x07, 0x5a, 0x9c, 0x3a, 0x94, 0x29, 0x79, 0x33, 0x55, 0x62,
+	0x34, 0xc6, 0x92, 0x11, 0xbd, 0x4d, 0x86, 0x9b, 0x60, 0x1f, 0xc8, 0x82, 0x58, 0x8b, 0xf2, 0xcb,
+	0x73, 0xfd, 0x1e, 0x40, 0x2b, 0x8a, 0xc5, 0x1c, 0xd7, 0x56, 0x8c, 0x65, 0x58, 0x3c, 0x90, 0x0f,
+	0x2e, 0xf5, 0xbc, 0x32, 0x66, 0x0e, 0x3e, 0xb8, 0xd4, 0x57, 0xf2, 0xe8, 0x34, 0x36, 0x8b, 0xce,
+	0x87, 0x3c, 0x0a, 0x61, 0xfa, 0x4b, 0x27, 0xa5, 0x1b, 0xc8, 0x62, 0x30, 0x87, 0x36, 0x52, 0x13,
+	0x29, 0x29, 0x07, 0x51, 0x88, 0x25, 0xa8, 0xa0, 0x8d, 0x8b, 0x08, 0xd3, 0x5f, 0x19, 0xc6, 0xc2,
+	0x17, 0x76, 0xcb, 0x42, 0x11, 0xcb, 0x81, 0x94, 0x02, 0x8d, 0xfe, 0xa9, 0xfd, 0x30, 0x3e, 0x48,
+	0x65, 0x45, 0xc4, 0xee, 0x4b, 0xa8, 0x8a, 0x08, 0x54, 0x8d, 0xe1, 0x96, 0x1d, 0x0a, 0xcb, 0xdf,
+	0xc2, 0x72, 0x34, 0x68, 0x4e, 0x41, 0x84, 0x64, 0x57, 0x01, 0x5a, 0xfb, 0x4c, 0x5e, 0x5c, 0x13,
+	0x59, 0x56, 0x0e, 0xd6, 0xb6, 0xe8, 0x55, 0x92, 0xf7, 0x1d, 0x27, 0x0a, 0x02, 0xee, 0x39, 0xd0,
+	0x70, 0x96, 0x51, 0x06, 0xed, 0x96, 0x82, 0x59, 0x7a, 0x02, 0xa9, 0x78, 0x2a, 0x35, 0xb5, 0x76,
+	0x60, 0x73, 0xe8, 0x34, 0x82, 0x2d, 0x55, 0x9a, 0xcc, 0x19, 0x10, 0xee, 0xcf, 0x00, 0xfd, 0xc4,
+	0x3e, 0xbc, 0x11, 0xa3, 0x74, 0x81, 0xe4, 0x42, 0xb7, 0x25, 0xc1, 0xba, 0x2e, 0x54, 0xea, 0xd9,
+	0x9d, 0xa0, 0xf4, 0x4a, 0xfc, 0x88, 0xae, 0xa0, 0x89, 0x27, 0xfa, 0x04, 0xa9, 0x96, 0xd1, 0xcf,
+	0xe7, 0xc3, 0x1a, 0xa8, 0x33, 0x47, 0xda, 0x40, 0x9d, 0x3d, 0x82, 0x06, 0xea, 0xdc, 0xab, 0x36,
+	0x50, 0xe7, 0xff, 0xd5, 0x06, 0xea, 0xc2, 0xab, 0x35, 0x50, 0x8b, 0x2f, 0x69, 0xa0, 0xde, 0xfa,
+	0xe7, 0x0d, 0xd4, 0x8b, 0xda, 0x96, 0xb7, 0x8f, 0xb8, 0x6d, 0xb9, 0xf8, 0xba, 0x6d, 0xcb, 0xa5,
+	0xa3, 0x6d, 0x5b, 0x2e, 0xbf, 0x6e, 0xdb, 0x72, 0xc8, 0x33, 0xd1, 0x79, 0xc9, 0x33, 0x31, 0xd5,
+	0xed, 0x7c, 0xa7, 0xff, 0x1b, 0xb6, 0xb2, 0x9f, 0xf7, 0x74, 0x66, 0xca, 0x1c, 0x9a, 0x99, 0xd2,
+	0xd9, 0xf8, 0xd8, 0x0b, 0xb3, 0xf1, 0x69, 0x92, 0x93, 0x8d, 0x46, 0xc7, 0xf5, 0x1a, 0xf8, 0x2f,
+	0x8a, 0x5c, 0x7c, 0xa8, 0x04, 0x36, 0x17, 0xfe, 0xfc, 0x75, 0x3e, 0xf3, 0xf8, 0xf9, 0x7c, 0xe6,
+	0x67, 0xf8, 0x3d, 0x81, 0xdf, 0x53, 0xf8, 0xfd, 0x02, 0xbf, 0x1f, 0x7e, 0x9b, 0x1f, 0xf8, 0xe2,
+	0xd8, 0xf6, 0x72, 0x2d, 0x8b, 0xff, 0xb8, 0xbb, 0xf6, 0x37, 0x09, 0xd5, 0x2f, 0xf7, 0x60, 0x14,
+	0x00, 0x00,
 }
 
 func (this *CheckRequest) Equal(that interface{}) bool {
@@ -717,6 +783,9 @@ func (this *CheckRequest) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if !this.ProxyEntity.Equal(that1.ProxyEntity) {
+		return false
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -921,6 +990,42 @@ func (this *CheckConfig) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.Template != that1.Template {
+		return false
+	}
+	if this.CgroupCPULimit != that1.CgroupCPULimit {
+		return false
+	}
+	if this.CgroupMemoryLimit != that1.CgroupMemoryLimit {
+		return false
+	}
+	if len(this.OutputMetricThresholds) != len(that1.OutputMetricThresholds) {
+		return false
+	}
+	for i := range this.OutputMetricThresholds {
+		if this.OutputMetricThresholds[i] != that1.OutputMetricThresholds[i] {
+			return false
+		}
+	}
+	if len(this.Redact) != len(that1.Redact) {
+		return false
+	}
+	for i := range this.Redact {
+		if this.Redact[i] != that1.Redact[i] {
+			return false
+		}
+	}
+	if this.StructuredOutput != that1.StructuredOutput {
+		return false
+	}
+	if len(this.OutputMetricTags) != len(that1.OutputMetricTags) {
+		return false
+	}
+	for i := range this.OutputMetricTags {
+		if this.OutputMetricTags[i] != that1.OutputMetricTags[i] {
+			return false
+		}
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -1106,6 +1211,33 @@ func (this *Check) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if len(this.OutputMetricThresholds) != len(that1.OutputMetricThresholds) {
+		return false
+	}
+	for i := range this.OutputMetricThresholds {
+		if this.OutputMetricThresholds[i] != that1.OutputMetricThresholds[i] {
+			return false
+		}
+	}
+	if len(this.Redact) != len(that1.Redact) {
+		return false
+	}
+	for i := range this.Redact {
+		if this.Redact[i] != that1.Redact[i] {
+			return false
+		}
+	}
+	if this.StructuredOutput != that1.StructuredOutput {
+		return false
+	}
+	if len(this.OutputMetricTags) != len(that1.OutputMetricTags) {
+		return false
+	}
+	for i := range this.OutputMetricTags {
+		if this.OutputMetricTags[i] != that1.OutputMetricTags[i] {
+			return false
+		}
+	}
 	if !bytes.Equal(this.ExtendedAttributes, that1.ExtendedAttributes) {
 		return false
 	}
@@ -1175,6 +1307,13 @@ type CheckConfigFace interface {
 	GetMaxOutputSize() int64
 	GetDiscardOutput() bool
 	GetSecrets() []*Secret
+	GetTemplate() string
+	GetCgroupCPULimit() uint32
+	GetCgroupMemoryLimit() int64
+	GetOutputMetricThresholds() []string
+	GetRedact() []string
+	GetStructuredOutput() bool
+	GetOutputMetricTags() []string
 }
 
 func (this *CheckConfig) Proto() github_com_golang_protobuf_proto.Message {
@@ -1285,6 +1424,34 @@ func (this *CheckConfig) GetSecrets() []*Secret {
 	return this.Secrets
 }
 
+func (this *CheckConfig) GetTemplate() string {
+	return this.Template
+}
+
+func (this *CheckConfig) GetCgroupCPULimit() uint32 {
+	return this.CgroupCPULimit
+}
+
+func (this *CheckConfig) GetCgroupMemoryLimit() int64 {
+	return this.CgroupMemoryLimit
+}
+
+func (this *CheckConfig) GetOutputMetricThresholds() []string {
+	return this.OutputMetricThresholds
+}
+
+func (this *CheckConfig) GetRedact() []string {
+	return this.Redact
+}
+
+func (this *CheckConfig) GetStructuredOutput() bool {
+	return this.StructuredOutput
+}
+
+func (this *CheckConfig) GetOutputMetricTags() []string {
+	return this.OutputMetricTags
+}
+
 func NewCheckConfigFromFace(that CheckConfigFace) *CheckConfig {
 	this := &CheckConfig{}
 	this.Command = that.GetCommand()
@@ -1312,6 +1479,13 @@ func NewCheckConfigFromFace(that CheckConfigFace) *CheckConfig {
 	this.MaxOutputSize = that.GetMaxOutputSize()
 	this.DiscardOutput = that.GetDiscardOutput()
 	this.Secrets = that.GetSecrets()
+	this.Template = that.GetTemplate()
+	this.CgroupCPULimit = that.GetCgroupCPULimit()
+	this.CgroupMemoryLimit = that.GetCgroupMemoryLimit()
+	this.OutputMetricThresholds = that.GetOutputMetricThresholds()
+	this.Redact = that.GetRedact()
+	this.StructuredOutput = that.GetStructuredOutput()
+	this.OutputMetricTags = that.GetOutputMetricTags()
 	return this
 }
 
@@ -1354,6 +1528,10 @@ type CheckFace interface {
 	GetMaxOutputSize() int64
 	GetDiscardOutput() bool
 	GetSecrets() []*Secret
+	GetOutputMetricThresholds() []string
+	GetRedact() []string
+	GetStructuredOutput() bool
+	GetOutputMetricTags() []string
 	GetExtendedAttributes() []byte
 }
 
@@ -1513,6 +1691,22 @@ func (this *Check) GetSecrets() []*Secret {
 	return this.Secrets
 }
 
+func (this *Check) GetOutputMetricThresholds() []string {
+	return this.OutputMetricThresholds
+}
+
+func (this *Check) GetRedact() []string {
+	return this.Redact
+}
+
+func (this *Check) GetStructuredOutput() bool {
+	return this.StructuredOutput
+}
+
+func (this *Check) GetOutputMetricTags() []string {
+	return this.OutputMetricTags
+}
+
 func (this *Check) GetExtendedAttributes() []byte {
 	return this.ExtendedAttributes
 }
@@ -1556,6 +1750,10 @@ func NewCheckFromFace(that CheckFace) *Check {
 	this.MaxOutputSize = that.GetMaxOutputSize()
 	this.DiscardOutput = that.GetDiscardOutput()
 	this.Secrets = that.GetSecrets()
+	this.OutputMetricThresholds = that.GetOutputMetricThresholds()
+	this.Redact = that.GetRedact()
+	this.StructuredOutput = that.GetStructuredOutput()
+	this.OutputMetricTags = that.GetOutputMetricTags()
 	this.ExtendedAttributes = that.GetExtendedAttributes()
 	return this
 }
@@ -1584,6 +1782,18 @@ func (m *CheckRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.ProxyEntity != nil {
+		{
+			size, err := m.ProxyEntity.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintCheck(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
 	if len(m.Secrets) > 0 {
 		for iNdEx := len(m.Secrets) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.Secrets[iNdEx])
@@ -1783,6 +1993,74 @@ func (m *CheckConfig) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.OutputMetricTags) > 0 {
+		for iNdEx := len(m.OutputMetricTags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.OutputMetricTags[iNdEx])
+			copy(dAtA[i:], m.OutputMetricTags[iNdEx])
+			i = encodeVarintCheck(dAtA, i, uint64(len(m.OutputMetricTags[iNdEx])))
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0xa2
+		}
+	}
+	if m.StructuredOutput {
+		i--
+		if m.StructuredOutput {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x98
+	}
+	if len(m.Redact) > 0 {
+		for iNdEx := len(m.Redact) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Redact[iNdEx])
+			copy(dAtA[i:], m.Redact[iNdEx])
+			i = encodeVarintCheck(dAtA, i, uint64(len(m.Redact[iNdEx])))
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0x92
+		}
+	}
+	if len(m.OutputMetricThresholds) > 0 {
+		for iNdEx := len(m.OutputMetricThresholds) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.OutputMetricThresholds[iNdEx])
+			copy(dAtA[i:], m.OutputMetricThresholds[iNdEx])
+			i = encodeVarintCheck(dAtA, i, uint64(len(m.OutputMetricThresholds[iNdEx])))
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0x8a
+		}
+	}
+	if m.CgroupMemoryLimit != 0 {
+		i = encodeVarintCheck(dAtA, i, uint64(m.CgroupMemoryLimit))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x80
+	}
+	if m.CgroupCPULimit != 0 {
+		i = encodeVarintCheck(dAtA, i, uint64(m.CgroupCPULimit))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf8
+	}
+	if len(m.Template) > 0 {
+		i -= len(m.Template)
+		copy(dAtA[i:], m.Template)
+		i = encodeVarintCheck(dAtA, i, uint64(len(m.Template)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf2
+	}
 	if len(m.Secrets) > 0 {
 		for iNdEx := len(m.Secrets) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -2057,6 +2335,51 @@ func (m *Check) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x9a
 	}
+	if len(m.OutputMetricTags) > 0 {
+		for iNdEx := len(m.OutputMetricTags) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.OutputMetricTags[iNdEx])
+			copy(dAtA[i:], m.OutputMetricTags[iNdEx])
+			i = encodeVarintCheck(dAtA, i, uint64(len(m.OutputMetricTags[iNdEx])))
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0xea
+		}
+	}
+	if m.StructuredOutput {
+		i--
+		if m.StructuredOutput {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xe0
+	}
+	if len(m.Redact) > 0 {
+		for iNdEx := len(m.Redact) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Redact[iNdEx])
+			copy(dAtA[i:], m.Redact[iNdEx])
+			i = encodeVarintCheck(dAtA, i, uint64(len(m.Redact[iNdEx])))
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0xda
+		}
+	}
+	if len(m.OutputMetricThresholds) > 0 {
+		for iNdEx := len(m.OutputMetricThresholds) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.OutputMetricThresholds[iNdEx])
+			copy(dAtA[i:], m.OutputMetricThresholds[iNdEx])
+			i = encodeVarintCheck(dAtA, i, uint64(len(m.OutputMetricThresholds[iNdEx])))
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0xd2
+		}
+	}
 	if len(m.Secrets) > 0 {
 		for iNdEx := len(m.Secrets) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -2504,8 +2827,11 @@ func NewPopulatedCheckRequest(r randyCheck, easy bool) *CheckRequest {
 	for i := 0; i < v6; i++ {
 		this.Secrets[i] = string(randStringCheck(r))
 	}
+	if r.Intn(5) != 0 {
+		this.ProxyEntity = NewPopulatedEntity(r, easy)
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedCheck(r, 7)
+		this.XXX_unrecognized = randUnrecognizedCheck(r, 8)
 	}
 	return this
 }
@@ -2616,8 +2942,30 @@ func NewPopulatedCheckConfig(r randyCheck, easy bool) *CheckConfig {
 			this.Secrets[i] = NewPopulatedSecret(r, easy)
 		}
 	}
+	this.Template = string(randStringCheck(r))
+	this.CgroupCPULimit = uint32(r.Uint32())
+	this.CgroupMemoryLimit = int64(r.Int63())
+	if r.Intn(2) == 0 {
+		this.CgroupMemoryLimit *= -1
+	}
+	v20 := r.Intn(10)
+	this.OutputMetricThresholds = make([]string, v20)
+	for i := 0; i < v20; i++ {
+		this.OutputMetricThresholds[i] = string(randStringCheck(r))
+	}
+	v21 := r.Intn(10)
+	this.Redact = make([]string, v21)
+	for i := 0; i < v21; i++ {
+		this.Redact[i] = string(randStringCheck(r))
+	}
+	this.StructuredOutput = bool(bool(r.Intn(2) == 0))
+	v22 := r.Intn(10)
+	this.OutputMetricTags = make([]string, v22)
+	for i := 0; i < v22; i++ {
+		this.OutputMetricTags[i] = string(randStringCheck(r))
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedCheck(r, 30)
+		this.XXX_unrecognized = randUnrecognizedCheck(r, 37)
 	}
 	return this
 }
@@ -2625,32 +2973,32 @@ func NewPopulatedCheckConfig(r randyCheck, easy bool) *CheckConfig {
 func NewPopulatedCheck(r randyCheck, easy bool) *Check {
 	this := &Check{}
 	this.Command = string(randStringCheck(r))
-	v20 := r.Intn(10)
-	this.Handlers = make([]string, v20)
-	for i := 0; i < v20; i++ {
+	v23 := r.Intn(10)
+	this.Handlers = make([]string, v23)
+	for i := 0; i < v23; i++ {
 		this.Handlers[i] = string(randStringCheck(r))
 	}
 	this.HighFlapThreshold = uint32(r.Uint32())
 	this.Interval = uint32(r.Uint32())
 	this.LowFlapThreshold = uint32(r.Uint32())
 	this.Publish = bool(bool(r.Intn(2) == 0))
-	v21 := r.Intn(10)
-	this.RuntimeAssets = make([]string, v21)
-	for i := 0; i < v21; i++ {
+	v24 := r.Intn(10)
+	this.RuntimeAssets = make([]string, v24)
+	for i := 0; i < v24; i++ {
 		this.RuntimeAssets[i] = string(randStringCheck(r))
 	}
-	v22 := r.Intn(10)
-	this.Subscriptions = make([]string, v22)
-	for i := 0; i < v22; i++ {
+	v25 := r.Intn(10)
+	this.Subscriptions = make([]string, v25)
+	for i := 0; i < v25; i++ {
 		this.Subscriptions[i] = string(randStringCheck(r))
 	}
 	this.ProxyEntityName = string(randStringCheck(r))
 	if r.Intn(5) != 0 {
-		v23 := r.Intn(5)
-		this.CheckHooks = make([]HookList, v23)
-		for i := 0; i < v23; i++ {
-			v24 := NewPopulatedHookList(r, easy)
-			this.CheckHooks[i] = *v24
+		v26 := r.Intn(5)
+		this.CheckHooks = make([]HookList, v26)
+		for i := 0; i < v26; i++ {
+			v27 := NewPopulatedHookList(r, easy)
+			this.CheckHooks[i] = *v27
 		}
 	}
 	this.Stdin = bool(bool(r.Intn(2) == 0))
@@ -2676,11 +3024,11 @@ func NewPopulatedCheck(r randyCheck, easy bool) *Check {
 		this.Executed *= -1
 	}
 	if r.Intn(5) != 0 {
-		v25 := r.Intn(5)
-		this.History = make([]CheckHistory, v25)
-		for i := 0; i < v25; i++ {
-			v26 := NewPopulatedCheckHistory(r, easy)
-			this.History[i] = *v26
+		v28 := r.Intn(5)
+		this.History = make([]CheckHistory, v28)
+		for i := 0; i < v28; i++ {
+			v29 := NewPopulatedCheckHistory(r, easy)
+			this.History[i] = *v29
 		}
 	}
 	this.Issued = int64(r.Int63())
@@ -2703,46 +3051,62 @@ func NewPopulatedCheck(r randyCheck, easy bool) *Check {
 	if r.Intn(2) == 0 {
 		this.OccurrencesWatermark *= -1
 	}
-	v27 := r.Intn(10)
-	this.Silenced = make([]string, v27)
-	for i := 0; i < v27; i++ {
+	v30 := r.Intn(10)
+	this.Silenced = make([]string, v30)
+	for i := 0; i < v30; i++ {
 		this.Silenced[i] = string(randStringCheck(r))
 	}
 	if r.Intn(5) != 0 {
-		v28 := r.Intn(5)
-		this.Hooks = make([]*Hook, v28)
-		for i := 0; i < v28; i++ {
+		v31 := r.Intn(5)
+		this.Hooks = make([]*Hook, v31)
+		for i := 0; i < v31; i++ {
 			this.Hooks[i] = NewPopulatedHook(r, easy)
 		}
 	}
 	this.OutputMetricFormat = string(randStringCheck(r))
-	v29 := r.Intn(10)
-	this.OutputMetricHandlers = make([]string, v29)
-	for i := 0; i < v29; i++ {
+	v32 := r.Intn(10)
+	this.OutputMetricHandlers = make([]string, v32)
+	for i := 0; i < v32; i++ {
 		this.OutputMetricHandlers[i] = string(randStringCheck(r))
 	}
-	v30 := r.Intn(10)
-	this.EnvVars = make([]string, v30)
-	for i := 0; i < v30; i++ {
+	v33 := r.Intn(10)
+	this.EnvVars = make([]string, v33)
+	for i := 0; i < v33; i++ {
 		this.EnvVars[i] = string(randStringCheck(r))
 	}
-	v31 := NewPopulatedObjectMeta(r, easy)
-	this.ObjectMeta = *v31
+	v34 := NewPopulatedObjectMeta(r, easy)
+	this.ObjectMeta = *v34
 	this.MaxOutputSize = int64(r.Int63())
 	if r.Intn(2) == 0 {
 		this.MaxOutputSize *= -1
 	}
 	this.DiscardOutput = bool(bool(r.Intn(2) == 0))
 	if r.Intn(5) != 0 {
-		v32 := r.Intn(5)
-		this.Secrets = make([]*Secret, v32)
-		for i := 0; i < v32; i++ {
+		v35 := r.Intn(5)
+		this.Secrets = make([]*Secret, v35)
+		for i := 0; i < v35; i++ {
 			this.Secrets[i] = NewPopulatedSecret(r, easy)
 		}
 	}
-	v33 := r.Intn(100)
-	this.ExtendedAttributes = make([]byte, v33)
-	for i := 0; i < v33; i++ {
+	v36 := r.Intn(10)
+	this.OutputMetricThresholds = make([]string, v36)
+	for i := 0; i < v36; i++ {
+		this.OutputMetricThresholds[i] = string(randStringCheck(r))
+	}
+	v37 := r.Intn(10)
+	this.Redact = make([]string, v37)
+	for i := 0; i < v37; i++ {
+		this.Redact[i] = string(randStringCheck(r))
+	}
+	this.StructuredOutput = bool(bool(r.Intn(2) == 0))
+	v38 := r.Intn(10)
+	this.OutputMetricTags = make([]string, v38)
+	for i := 0; i < v38; i++ {
+		this.OutputMetricTags[i] = string(randStringCheck(r))
+	}
+	v39 := r.Intn(100)
+	this.ExtendedAttributes = make([]byte, v39)
+	for i := 0; i < v39; i++ {
 		this.ExtendedAttributes[i] = byte(r.Intn(256))
 	}
 	if !easy && r.Intn(10) != 0 {
@@ -2881,6 +3245,10 @@ func (m *CheckRequest) Size() (n int) {
 			n += 1 + l + sovCheck(uint64(l))
 		}
 	}
+	if m.ProxyEntity != nil {
+		l = m.ProxyEntity.Size()
+		n += 1 + l + sovCheck(uint64(l))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -3037,6 +3405,37 @@ func (m *CheckConfig) Size() (n int) {
 			n += 2 + l + sovCheck(uint64(l))
 		}
 	}
+	l = len(m.Template)
+	if l > 0 {
+		n += 2 + l + sovCheck(uint64(l))
+	}
+	if m.CgroupCPULimit != 0 {
+		n += 2 + sovCheck(uint64(m.CgroupCPULimit))
+	}
+	if m.CgroupMemoryLimit != 0 {
+		n += 2 + sovCheck(uint64(m.CgroupMemoryLimit))
+	}
+	if len(m.OutputMetricThresholds) > 0 {
+		for _, s := range m.OutputMetricThresholds {
+			l = len(s)
+			n += 2 + l + sovCheck(uint64(l))
+		}
+	}
+	if len(m.Redact) > 0 {
+		for _, s := range m.Redact {
+			l = len(s)
+			n += 2 + l + sovCheck(uint64(l))
+		}
+	}
+	if m.StructuredOutput {
+		n += 3
+	}
+	if len(m.OutputMetricTags) > 0 {
+		for _, s := range m.OutputMetricTags {
+			l = len(s)
+			n += 2 + l + sovCheck(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -3197,6 +3596,27 @@ func (m *Check) Size() (n int) {
 			n += 2 + l + sovCheck(uint64(l))
 		}
 	}
+	if len(m.OutputMetricThresholds) > 0 {
+		for _, s := range m.OutputMetricThresholds {
+			l = len(s)
+			n += 2 + l + sovCheck(uint64(l))
+		}
+	}
+	if len(m.Redact) > 0 {
+		for _, s := range m.Redact {
+			l = len(s)
+			n += 2 + l + sovCheck(uint64(l))
+		}
+	}
+	if m.StructuredOutput {
+		n += 3
+	}
+	if len(m.OutputMetricTags) > 0 {
+		for _, s := range m.OutputMetricTags {
+			l = len(s)
+			n += 2 + l + sovCheck(uint64(l))
+		}
+	}
 	l = len(m.ExtendedAttributes)
 	if l > 0 {
 		n += 2 + l + sovCheck(uint64(l))
@@ -3547,6 +3967,42 @@ func (m *CheckRequest) Unmarshal(dAtA []byte) error {
 			}
 			m.Secrets = append(m.Secrets, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProxyEntity", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ProxyEntity == nil {
+				m.ProxyEntity = &Entity{}
+			}
+			if err := m.ProxyEntity.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipCheck(dAtA[iNdEx:])
@@ -4503,14 +4959,200 @@ func (m *CheckConfig) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipCheck(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 30:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Template", wireType)
 			}
-			if skippy < 0 {
-				return ErrInvalidLengthCheck
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Template = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 31:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CgroupCPULimit", wireType)
+			}
+			m.CgroupCPULimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CgroupCPULimit |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 32:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CgroupMemoryLimit", wireType)
+			}
+			m.CgroupMemoryLimit = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CgroupMemoryLimit |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 33:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutputMetricThresholds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OutputMetricThresholds = append(m.OutputMetricThresholds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 34:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Redact", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Redact = append(m.Redact, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 35:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StructuredOutput", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StructuredOutput = bool(v != 0)
+		case 36:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutputMetricTags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OutputMetricTags = append(m.OutputMetricTags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipCheck(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthCheck
 			}
 			if (iNdEx + skippy) < 0 {
 				return ErrInvalidLengthCheck
@@ -5520,6 +6162,122 @@ func (m *Check) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 42:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutputMetricThresholds", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OutputMetricThresholds = append(m.OutputMetricThresholds, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 43:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Redact", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Redact = append(m.Redact, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 44:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StructuredOutput", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.StructuredOutput = bool(v != 0)
+		case 45:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OutputMetricTags", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowCheck
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthCheck
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthCheck
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.OutputMetricTags = append(m.OutputMetricTags, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		case 99:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field ExtendedAttributes", wireType)
@@ -0,0 +1,18 @@
+package v2
+
+// CheckCancellationType is the message type string for check cancellations.
+const CheckCancellationType = "check_cancellation"
+
+// CheckCancellation asks the agents subscribed to a check to terminate their
+// executions of the check still in flight, and to discard their results. The
+// backend sends it when the check is unpublished or deleted.
+type CheckCancellation struct {
+	// Name is the name of the check.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the check.
+	Namespace string `json:"namespace"`
+
+	// Reason tells why the check was cancelled, e.g. "check deleted".
+	Reason string `json:"reason,omitempty"`
+}
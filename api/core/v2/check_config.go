@@ -1,7 +1,6 @@
 package v2
 
 import (
-	"errors"
 	fmt "fmt"
 	"net/url"
 	"path"
@@ -82,43 +81,62 @@ func (c *CheckConfig) URIPath() string {
 	return path.Join(URLPrefix, "namespaces", url.PathEscape(c.Namespace), ChecksResource, url.PathEscape(c.Name))
 }
 
+// ExecutionBudget returns the maximum duration, in seconds, of the executions
+// of the check when it's scheduled at the given interval, or 0 if they are not
+// budgeted. The executions of high resolution checks are budgeted to their
+// interval, unless their timeout is lower.
+func (c *CheckConfig) ExecutionBudget(interval uint32) uint32 {
+	if interval == 0 || interval >= HighResolutionInterval {
+		return 0
+	}
+	if c.Timeout > 0 && c.Timeout < interval {
+		return c.Timeout
+	}
+	return interval
+}
+
 // Validate returns an error if the check does not pass validation tests.
 func (c *CheckConfig) Validate() error {
 	if err := ValidateName(c.Name); err != nil {
-		return errors.New("check name " + err.Error())
+		return NewFieldError("metadata.name", "check name "+err.Error())
 	}
 
 	if c.Cron != "" {
 		if c.Interval > 0 {
-			return errors.New("must only specify either an interval or a cron schedule")
+			return NewFieldError("spec.cron", "must only specify either an interval or a cron schedule")
 		}
 
 		if _, err := cron.ParseStandard(c.Cron); err != nil {
-			return errors.New("check cron string is invalid")
+			return NewFieldError("spec.cron", "check cron string is invalid")
 		}
 	}
 
-	if c.Interval == 0 && c.Cron == "" {
-		return errors.New("check interval must be greater than 0 or a valid cron schedule must be provided")
+	// Checks referencing a template may inherit their schedule from it
+	if c.Template != "" {
+		if err := ValidateName(c.Template); err != nil {
+			return NewFieldError("spec.template", "check template name "+err.Error())
+		}
+	} else if c.Interval == 0 && c.Cron == "" {
+		return NewFieldError("spec.interval", "check interval must be greater than 0 or a valid cron schedule must be provided")
 	}
 
 	if c.Namespace == "" {
-		return errors.New("namespace must be set")
+		return NewFieldError("metadata.namespace", "namespace must be set")
 	}
 
 	if c.Ttl > 0 && c.Ttl <= int64(c.Interval) {
-		return errors.New("ttl must be greater than check interval")
+		return NewFieldError("spec.ttl", "ttl must be greater than check interval")
 	}
 
-	for _, assetName := range c.RuntimeAssets {
+	for i, assetName := range c.RuntimeAssets {
 		if err := ValidateAssetName(assetName); err != nil {
-			return fmt.Errorf("asset's %s", err)
+			return NewFieldError(fmt.Sprintf("spec.runtime_assets[%d]", i), fmt.Sprintf("asset's %s", err))
 		}
 	}
 
-	for _, subscription := range c.Subscriptions {
+	for i, subscription := range c.Subscriptions {
 		if subscription == "" {
-			return fmt.Errorf("subscriptions cannot be empty strings")
+			return NewFieldError(fmt.Sprintf("spec.subscriptions[%d]", i), "subscriptions cannot be empty strings")
 		}
 	}
 
@@ -126,31 +144,46 @@ func (c *CheckConfig) Validate() error {
 	// alphanumeric string)
 	if c.ProxyEntityName != "" {
 		if err := ValidateName(c.ProxyEntityName); err != nil {
-			return errors.New("proxy entity name " + err.Error())
+			return NewFieldError("spec.proxy_entity_name", "proxy entity name "+err.Error())
 		}
 	}
 
 	if c.ProxyRequests != nil {
 		if err := c.ProxyRequests.Validate(); err != nil {
-			return err
+			return nestedFieldError("spec.proxy_requests", "", err)
 		}
 	}
 
 	if c.OutputMetricFormat != "" {
 		if err := ValidateOutputMetricFormat(c.OutputMetricFormat); err != nil {
-			return err
+			return NewFieldError("spec.output_metric_format", err.Error(), OutputMetricFormats...)
 		}
 	}
 
 	if c.LowFlapThreshold != 0 && c.HighFlapThreshold != 0 && c.LowFlapThreshold >= c.HighFlapThreshold {
-		return errors.New("invalid flap thresholds")
+		return NewFieldError("spec.low_flap_threshold", "invalid flap thresholds")
+	}
+
+	if c.CgroupMemoryLimit < 0 {
+		return NewFieldError("spec.cgroup_memory_limit", "cgroup memory limit can't be negative")
+	}
+
+	if err := ValidateMetricThresholds(c.OutputMetricThresholds); err != nil {
+		return NewFieldError("spec.output_metric_thresholds", err.Error())
+	}
+
+	if err := ValidateMetricTags(c.OutputMetricTags); err != nil {
+		return NewFieldError("spec.output_metric_tags", err.Error())
 	}
 
 	if err := ValidateEnvVars(c.EnvVars); err != nil {
-		return err
+		return NewFieldError("spec.env_vars", err.Error())
 	}
 
-	return c.Subdue.Validate()
+	if err := c.Subdue.Validate(); err != nil {
+		return nestedFieldError("spec.subdue", "", err)
+	}
+	return nil
 }
 
 // IsSubdued returns true if the check is subdued at the current time.
@@ -98,6 +98,47 @@ func TestCheckConfigFlapThresholdValidation(t *testing.T) {
 	assert.Error(t, c.Validate())
 }
 
+func TestCheckConfigTemplateValidation(t *testing.T) {
+	c := FixtureCheckConfig("foo")
+	c.Interval = 0
+	assert.Error(t, c.Validate())
+
+	// the schedule can be inherited from the template
+	c.Template = "linux"
+	assert.NoError(t, c.Validate())
+
+	c.Template = "linux/servers"
+	assert.Error(t, c.Validate())
+}
+
+func TestCheckConfigCgroupLimitsValidation(t *testing.T) {
+	c := FixtureCheckConfig("foo")
+	c.CgroupCPULimit = 500
+	c.CgroupMemoryLimit = 64 * 1024 * 1024
+	assert.NoError(t, c.Validate())
+
+	c.CgroupMemoryLimit = -1
+	assert.Error(t, c.Validate())
+}
+
+func TestCheckConfigMetricThresholdsValidation(t *testing.T) {
+	c := FixtureCheckConfig("foo")
+	c.OutputMetricThresholds = []string{`metric "cpu.idle" < 10 for 3 points`}
+	assert.NoError(t, c.Validate())
+
+	c.OutputMetricThresholds = append(c.OutputMetricThresholds, `metric "cpu.idle" is low`)
+	assert.Error(t, c.Validate())
+}
+
+func TestCheckConfigMetricTagsValidation(t *testing.T) {
+	c := FixtureCheckConfig("foo")
+	c.OutputMetricTags = []string{"region={{ .labels.region }}", "team=ops"}
+	assert.NoError(t, c.Validate())
+
+	c.OutputMetricTags = append(c.OutputMetricTags, "ops")
+	assert.Error(t, c.Validate())
+}
+
 func TestSortCheckConfigsByName(t *testing.T) {
 	a := FixtureCheckConfig("Abernathy")
 	b := FixtureCheckConfig("Bernard")
@@ -131,3 +172,20 @@ func TestSortCheckConfigsByName(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckConfigExecutionBudget(t *testing.T) {
+	check := FixtureCheckConfig("check")
+
+	// Checks with intervals of a minute or more are not budgeted
+	assert.Equal(t, uint32(0), check.ExecutionBudget(60))
+	assert.Equal(t, uint32(0), check.ExecutionBudget(0))
+
+	// The executions of high resolution checks are budgeted to their interval
+	assert.Equal(t, uint32(10), check.ExecutionBudget(10))
+	check.Timeout = 30
+	assert.Equal(t, uint32(10), check.ExecutionBudget(10))
+
+	// Unless their timeout is lower
+	check.Timeout = 5
+	assert.Equal(t, uint32(5), check.ExecutionBudget(10))
+}
@@ -1,8 +1,6 @@
 package v2
 
 import (
-	"errors"
-
 	"github.com/sensu/sensu-go/js"
 )
 
@@ -21,12 +19,15 @@ func FixtureProxyRequests(splay bool) *ProxyRequests {
 // Validate returns an error if the ProxyRequests does not pass validation tests
 func (p *ProxyRequests) Validate() error {
 	if p.SplayCoverage > 100 {
-		return errors.New("proxy request splay coverage must be between 0 and 100")
+		return NewFieldError("splay_coverage", "proxy request splay coverage must be between 0 and 100")
 	}
 
 	if (p.Splay) && (p.SplayCoverage == 0) {
-		return errors.New("proxy request splay coverage must be greater than 0 if splay is enabled")
+		return NewFieldError("splay_coverage", "proxy request splay coverage must be greater than 0 if splay is enabled")
 	}
 
-	return js.ParseExpressions(p.EntityAttributes)
+	if err := js.ParseExpressions(p.EntityAttributes); err != nil {
+		return NewFieldError("entity_attributes", err.Error())
+	}
+	return nil
 }
@@ -0,0 +1,65 @@
+package v2
+
+import (
+	"fmt"
+
+	utilstrings "github.com/sensu/sensu-go/util/strings"
+)
+
+// CheckPublishRequest enables or disables the scheduling of several checks at
+// once, selected by name, or by subscription and labels among all the checks
+// of a namespace.
+type CheckPublishRequest struct {
+	// Names are the names of the checks to update.
+	Names []string `json:"names,omitempty"`
+
+	// All selects all the checks of the namespace, instead of the checks
+	// listed in Names.
+	All bool `json:"all,omitempty"`
+
+	// Subscription only selects the checks with the given subscription.
+	Subscription string `json:"subscription,omitempty"`
+
+	// Labels only selects the checks with all the given labels.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Publish is the new value of the publish attribute of the checks.
+	Publish bool `json:"publish"`
+}
+
+// Validate returns an error if the request does not select any check, or
+// selects them both by name and with All.
+func (r *CheckPublishRequest) Validate() error {
+	if r.All && len(r.Names) > 0 {
+		return NewFieldError("all", "must specify either check names or all, but not both")
+	}
+	if !r.All && len(r.Names) == 0 {
+		return NewFieldError("names", "must specify check names or all")
+	}
+	for i, name := range r.Names {
+		if err := ValidateName(name); err != nil {
+			return NewFieldError(fmt.Sprintf("names[%d]", i), "check name "+err.Error())
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the check has the subscription and the labels of the
+// request.
+func (r *CheckPublishRequest) Matches(check *CheckConfig) bool {
+	if r.Subscription != "" && !utilstrings.InArray(r.Subscription, check.Subscriptions) {
+		return false
+	}
+	for key, value := range r.Labels {
+		if v, ok := check.Labels[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckPublishResponse lists the checks updated by a CheckPublishRequest.
+type CheckPublishResponse struct {
+	// Checks are the names of the updated checks.
+	Checks []string `json:"checks"`
+}
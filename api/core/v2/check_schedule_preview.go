@@ -0,0 +1,55 @@
+package v2
+
+// CheckSchedulePreview lists the executions of a check scheduled in a time
+// window, as computed by the scheduler of the backend.
+type CheckSchedulePreview struct {
+	// Check is the name of the check.
+	Check string `json:"check"`
+
+	// Namespace is the namespace of the check.
+	Namespace string `json:"namespace"`
+
+	// Scheduler is the type of scheduler of the check, such as "interval" or
+	// "round-robin cron".
+	Scheduler string `json:"scheduler"`
+
+	// Subscriptions are the subscriptions the check requests are published
+	// to.
+	Subscriptions []string `json:"subscriptions"`
+
+	// Publish is true if the check is scheduled. The executions are computed
+	// even if it isn't, to preview its schedule before publishing it.
+	Publish bool `json:"publish"`
+
+	// ProxyEntities are the names of the entities matched by the proxy
+	// requests of the check.
+	ProxyEntities []string `json:"proxy_entities,omitempty"`
+
+	// Start is the beginning of the time window, in seconds since the Unix
+	// epoch.
+	Start int64 `json:"start"`
+
+	// End is the end of the time window, in seconds since the Unix epoch.
+	End int64 `json:"end"`
+
+	// Executions are the scheduled executions, in chronological order.
+	Executions []ScheduledExecution `json:"executions"`
+
+	// Truncated is true if the window holds more executions than the
+	// scheduler previews.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// ScheduledExecution is an execution of a check scheduled by the backend.
+type ScheduledExecution struct {
+	// Time is the time of the execution, in seconds since the Unix epoch.
+	Time int64 `json:"time"`
+
+	// Subdued is true if the execution is skipped because the check is
+	// subdued at that time.
+	Subdued bool `json:"subdued,omitempty"`
+
+	// ProxyEntity is the name of the proxy entity of the execution, for
+	// proxy checks.
+	ProxyEntity string `json:"proxy_entity,omitempty"`
+}
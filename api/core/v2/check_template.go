@@ -0,0 +1,175 @@
+package v2
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	cron "github.com/robfig/cron/v3"
+)
+
+const (
+	// CheckTemplatesResource is the name of this resource type
+	CheckTemplatesResource = "checktemplates"
+)
+
+// NewCheckTemplate creates a new CheckTemplate.
+func NewCheckTemplate(meta ObjectMeta) *CheckTemplate {
+	return &CheckTemplate{ObjectMeta: meta}
+}
+
+// FixtureCheckTemplate returns a fixture for a CheckTemplate object.
+func FixtureCheckTemplate(name string) *CheckTemplate {
+	return &CheckTemplate{
+		ObjectMeta:        NewObjectMeta(name, "default"),
+		Command:           "command",
+		Interval:          60,
+		Subscriptions:     []string{"linux"},
+		Handlers:          []string{},
+		LowFlapThreshold:  20,
+		HighFlapThreshold: 60,
+	}
+}
+
+// GetObjectMeta returns the object metadata for the resource.
+func (t *CheckTemplate) GetObjectMeta() ObjectMeta {
+	return t.ObjectMeta
+}
+
+// SetObjectMeta sets the meta of the resource.
+func (t *CheckTemplate) SetObjectMeta(meta ObjectMeta) {
+	t.ObjectMeta = meta
+}
+
+// SetNamespace sets the namespace of the resource.
+func (t *CheckTemplate) SetNamespace(namespace string) {
+	t.Namespace = namespace
+}
+
+// StorePrefix returns the path prefix to this resource in the store
+func (t *CheckTemplate) StorePrefix() string {
+	return CheckTemplatesResource
+}
+
+// RBACName describes the name of the resource for RBAC purposes.
+func (t *CheckTemplate) RBACName() string {
+	return CheckTemplatesResource
+}
+
+// URIPath returns the path component of a check template URI.
+func (t *CheckTemplate) URIPath() string {
+	if t.Namespace == "" {
+		return path.Join(URLPrefix, CheckTemplatesResource, url.PathEscape(t.Name))
+	}
+	return path.Join(URLPrefix, "namespaces", url.PathEscape(t.Namespace), CheckTemplatesResource, url.PathEscape(t.Name))
+}
+
+// Validate returns an error if the check template does not pass validation
+// tests. Unlike checks, templates are not required to set a schedule.
+func (t *CheckTemplate) Validate() error {
+	if err := ValidateName(t.Name); err != nil {
+		return NewFieldError("metadata.name", "check template name "+err.Error())
+	}
+
+	if t.Namespace == "" {
+		return NewFieldError("metadata.namespace", "namespace must be set")
+	}
+
+	if t.Cron != "" {
+		if t.Interval > 0 {
+			return NewFieldError("spec.cron", "must only specify either an interval or a cron schedule")
+		}
+		if _, err := cron.ParseStandard(t.Cron); err != nil {
+			return NewFieldError("spec.cron", "check template cron string is invalid")
+		}
+	}
+
+	for i, assetName := range t.RuntimeAssets {
+		if err := ValidateAssetName(assetName); err != nil {
+			return NewFieldError(fmt.Sprintf("spec.runtime_assets[%d]", i), fmt.Sprintf("asset's %s", err))
+		}
+	}
+
+	for i, subscription := range t.Subscriptions {
+		if subscription == "" {
+			return NewFieldError(fmt.Sprintf("spec.subscriptions[%d]", i), "subscriptions cannot be empty strings")
+		}
+	}
+
+	if t.OutputMetricFormat != "" {
+		if err := ValidateOutputMetricFormat(t.OutputMetricFormat); err != nil {
+			return NewFieldError("spec.output_metric_format", err.Error(), OutputMetricFormats...)
+		}
+	}
+
+	if t.LowFlapThreshold != 0 && t.HighFlapThreshold != 0 && t.LowFlapThreshold >= t.HighFlapThreshold {
+		return NewFieldError("spec.low_flap_threshold", "invalid flap thresholds")
+	}
+
+	if err := ValidateEnvVars(t.EnvVars); err != nil {
+		return NewFieldError("spec.env_vars", err.Error())
+	}
+	return nil
+}
+
+// Apply returns a copy of the check inheriting the attributes it leaves unset
+// from the template. The schedule is inherited as a whole: the interval or
+// cron schedule of the template only applies if the check sets neither. The
+// template attribute of the copy is cleared, since it has been resolved.
+func (t *CheckTemplate) Apply(check *CheckConfig) *CheckConfig {
+	resolved := *check
+	resolved.Template = ""
+
+	if resolved.Interval == 0 && resolved.Cron == "" {
+		resolved.Interval = t.Interval
+		resolved.Cron = t.Cron
+	}
+	if resolved.Command == "" {
+		resolved.Command = t.Command
+	}
+	if resolved.Timeout == 0 {
+		resolved.Timeout = t.Timeout
+	}
+	if resolved.Ttl == 0 {
+		resolved.Ttl = t.Ttl
+	}
+	if resolved.LowFlapThreshold == 0 {
+		resolved.LowFlapThreshold = t.LowFlapThreshold
+	}
+	if resolved.HighFlapThreshold == 0 {
+		resolved.HighFlapThreshold = t.HighFlapThreshold
+	}
+	if resolved.OutputMetricFormat == "" {
+		resolved.OutputMetricFormat = t.OutputMetricFormat
+	}
+	if len(resolved.Handlers) == 0 {
+		resolved.Handlers = t.Handlers
+	}
+	if len(resolved.Subscriptions) == 0 {
+		resolved.Subscriptions = t.Subscriptions
+	}
+	if len(resolved.RuntimeAssets) == 0 {
+		resolved.RuntimeAssets = t.RuntimeAssets
+	}
+	if len(resolved.EnvVars) == 0 {
+		resolved.EnvVars = t.EnvVars
+	}
+	if len(resolved.OutputMetricHandlers) == 0 {
+		resolved.OutputMetricHandlers = t.OutputMetricHandlers
+	}
+
+	return &resolved
+}
+
+// CheckTemplateFields returns a set of fields that represent that resource
+func CheckTemplateFields(r Resource) map[string]string {
+	resource := r.(*CheckTemplate)
+	return map[string]string{
+		"check_template.name":           resource.ObjectMeta.Name,
+		"check_template.namespace":      resource.ObjectMeta.Namespace,
+		"check_template.handlers":       strings.Join(resource.Handlers, ","),
+		"check_template.runtime_assets": strings.Join(resource.RuntimeAssets, ","),
+		"check_template.subscriptions":  strings.Join(resource.Subscriptions, ","),
+	}
+}
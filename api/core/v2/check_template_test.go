@@ -0,0 +1,62 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTemplateValidate(t *testing.T) {
+	template := FixtureCheckTemplate("linux")
+	assert.NoError(t, template.Validate())
+
+	// Templates don't have to define a schedule
+	template.Interval = 0
+	assert.NoError(t, template.Validate())
+
+	template.Cron = "* * * * *"
+	template.Interval = 60
+	assert.Error(t, template.Validate())
+
+	template = FixtureCheckTemplate("linux")
+	template.LowFlapThreshold = 80
+	assert.Error(t, template.Validate())
+
+	template = FixtureCheckTemplate("linux")
+	template.Namespace = ""
+	assert.Error(t, template.Validate())
+}
+
+func TestCheckTemplateApply(t *testing.T) {
+	template := FixtureCheckTemplate("linux")
+	template.Handlers = []string{"slack"}
+	template.Timeout = 10
+
+	check := &CheckConfig{
+		ObjectMeta: NewObjectMeta("check-cpu", "default"),
+		Command:    "check-cpu.sh",
+		Template:   "linux",
+		Timeout:    30,
+	}
+	resolved := template.Apply(check)
+	require.NoError(t, resolved.Validate())
+	assert.Equal(t, "", resolved.Template)
+	assert.Equal(t, "check-cpu.sh", resolved.Command)
+	assert.Equal(t, uint32(60), resolved.Interval)
+	assert.Equal(t, uint32(30), resolved.Timeout)
+	assert.Equal(t, []string{"linux"}, resolved.Subscriptions)
+	assert.Equal(t, []string{"slack"}, resolved.Handlers)
+	assert.Equal(t, uint32(60), resolved.HighFlapThreshold)
+
+	// The check is left untouched
+	assert.Equal(t, "linux", check.Template)
+	assert.Empty(t, check.Subscriptions)
+
+	// A cron schedule of the check overrides the interval of the template
+	check.Cron = "*/5 * * * *"
+	resolved = template.Apply(check)
+	require.NoError(t, resolved.Validate())
+	assert.Equal(t, uint32(0), resolved.Interval)
+	assert.Equal(t, "*/5 * * * *", resolved.Cron)
+}
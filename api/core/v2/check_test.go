@@ -215,3 +215,43 @@ func TestCheckMerge(t *testing.T) {
 	}
 
 }
+
+func TestGetRedactedCheck(t *testing.T) {
+	check := FixtureCheck("check")
+	check.Labels = map[string]string{"password": "hunter2", "team": "ops"}
+	check.Annotations = map[string]string{"api_key": "123"}
+	check.EnvVars = []string{"PASSWORD=hunter2", "PATH=/bin", "FOO"}
+
+	redacted := check.GetRedactedCheck()
+	assert.Equal(t, map[string]string{"password": Redacted, "team": "ops"}, redacted.Labels)
+	assert.Equal(t, map[string]string{"api_key": Redacted}, redacted.Annotations)
+	assert.Equal(t, []string{"PASSWORD=" + Redacted, "PATH=/bin", "FOO"}, redacted.EnvVars)
+
+	// The original check is left untouched
+	assert.Equal(t, "hunter2", check.Labels["password"])
+	assert.Equal(t, "PASSWORD=hunter2", check.EnvVars[0])
+
+	// The redact fields override the default ones
+	check.Redact = []string{"team", "path"}
+	redacted = check.GetRedactedCheck()
+	assert.Equal(t, map[string]string{"password": "hunter2", "team": Redacted}, redacted.Labels)
+	assert.Equal(t, []string{"PASSWORD=hunter2", "PATH=" + Redacted, "FOO"}, redacted.EnvVars)
+
+	var nilCheck *Check
+	assert.Nil(t, nilCheck.GetRedactedCheck())
+}
+
+func TestCheckMarshalJSONRedacted(t *testing.T) {
+	config := FixtureCheckConfig("check")
+	config.EnvVars = []string{"API_KEY=123"}
+	config.Redact = []string{"api_key"}
+	check := NewCheck(config)
+	assert.Equal(t, []string{"api_key"}, check.Redact)
+
+	b, err := json.Marshal(check)
+	require.NoError(t, err)
+	var redacted Check
+	require.NoError(t, json.Unmarshal(b, &redacted))
+	assert.Equal(t, []string{"API_KEY=" + Redacted}, redacted.EnvVars)
+	assert.Equal(t, []string{"API_KEY=123"}, check.EnvVars)
+}
@@ -0,0 +1,261 @@
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ClusterConfigKeepaliveTimeout is the key of the keepalive timeout in the
+	// cluster configuration.
+	ClusterConfigKeepaliveTimeout = "keepalive_timeout"
+
+	// ClusterConfigKeepaliveHandlers is the key of the keepalive handlers in
+	// the cluster configuration.
+	ClusterConfigKeepaliveHandlers = "keepalive_handlers"
+
+	// ClusterConfigEventTTL is the key of the event TTL in the cluster
+	// configuration.
+	ClusterConfigEventTTL = "event_ttl"
+
+	// ClusterConfigRegistrationEvents is the key of the registration events
+	// switch in the cluster configuration.
+	ClusterConfigRegistrationEvents = "registration_events"
+
+	// ClusterConfigRequireSilencedReason is the key of the switch requiring a
+	// reason for the silenced entries in the cluster configuration.
+	ClusterConfigRequireSilencedReason = "require_silenced_reason"
+)
+
+// ClusterConfigKeys are the keys of the settings of the cluster configuration.
+var ClusterConfigKeys = []string{
+	ClusterConfigKeepaliveTimeout,
+	ClusterConfigKeepaliveHandlers,
+	ClusterConfigEventTTL,
+	ClusterConfigRegistrationEvents,
+	ClusterConfigRequireSilencedReason,
+}
+
+// Validate returns an error if the cluster configuration is invalid.
+func (c *ClusterConfig) Validate() error {
+	if err := validateKeepaliveTimeout(c.KeepaliveTimeout); err != nil {
+		return NewFieldError("spec.keepalive_timeout", err.Error())
+	}
+	for namespace, defaults := range c.NamespaceKeepalives {
+		field := fmt.Sprintf("spec.namespace_keepalives[%s]", namespace)
+		if namespace == "" {
+			return NewFieldError(field, "keepalive defaults must have a namespace")
+		}
+		if defaults == nil {
+			continue
+		}
+		if err := validateKeepaliveTimeout(defaults.Timeout); err != nil {
+			return NewFieldError(field+".timeout", fmt.Sprintf("namespace %s: %s", namespace, err))
+		}
+	}
+	for name, class := range c.EntityClasses {
+		field := fmt.Sprintf("spec.entity_classes[%s]", name)
+		if err := ValidateName(name); err != nil {
+			return NewFieldError(field, "entity class "+err.Error())
+		}
+		if isBuiltinEntityClass(name) {
+			return NewFieldError(field, fmt.Sprintf("entity class %s is built in and can't be redefined", name))
+		}
+		if class == nil {
+			return NewFieldError(field, fmt.Sprintf("entity class %s must be defined", name))
+		}
+	}
+	return nil
+}
+
+func isBuiltinEntityClass(class string) bool {
+	switch class {
+	case EntityAgentClass, EntityProxyClass, EntityBackendClass:
+		return true
+	}
+	return false
+}
+
+// ExpectsKeepalives returns whether the entities of the class are expected to
+// send keepalives. Only the agents are expected to send keepalives, besides the
+// entities of the custom classes expecting them.
+func (c *ClusterConfig) ExpectsKeepalives(class string) bool {
+	if class == EntityAgentClass {
+		return true
+	}
+	if custom := c.EntityClasses[class]; custom != nil {
+		return custom.ExpectKeepalives
+	}
+	return false
+}
+
+// SetEntityClass adds or replaces the custom entity class with the given name.
+func (c *ClusterConfig) SetEntityClass(name string, class *EntityClass) error {
+	if err := ValidateName(name); err != nil {
+		return errors.New("entity class " + err.Error())
+	}
+	if isBuiltinEntityClass(name) {
+		return fmt.Errorf("entity class %s is built in and can't be redefined", name)
+	}
+	if c.EntityClasses == nil {
+		c.EntityClasses = make(map[string]*EntityClass)
+	}
+	c.EntityClasses[name] = class
+	return nil
+}
+
+// RemoveEntityClass removes the custom entity class with the given name.
+func (c *ClusterConfig) RemoveEntityClass(name string) error {
+	if _, ok := c.EntityClasses[name]; !ok {
+		return fmt.Errorf("entity class %s is not defined", name)
+	}
+	delete(c.EntityClasses, name)
+	return nil
+}
+
+func validateKeepaliveTimeout(timeout uint32) error {
+	if timeout > 0 && timeout < 5 {
+		return errors.New("keepalive timeout must be 0 or at least 5 seconds")
+	}
+	return nil
+}
+
+// KeepaliveTimeoutFor returns the default keepalive timeout of the entities of
+// the namespace.
+func (c *ClusterConfig) KeepaliveTimeoutFor(namespace string) uint32 {
+	if defaults := c.NamespaceKeepalives[namespace]; defaults != nil && defaults.Timeout > 0 {
+		return defaults.Timeout
+	}
+	if c.KeepaliveTimeout > 0 {
+		return c.KeepaliveTimeout
+	}
+	return DefaultKeepaliveTimeout
+}
+
+// KeepaliveHandlersFor returns the default keepalive handlers of the entities
+// of the namespace.
+func (c *ClusterConfig) KeepaliveHandlersFor(namespace string) []string {
+	if defaults := c.NamespaceKeepalives[namespace]; defaults != nil && len(defaults.Handlers) > 0 {
+		return defaults.Handlers
+	}
+	if len(c.KeepaliveHandlers) > 0 {
+		return c.KeepaliveHandlers
+	}
+	return []string{KeepaliveHandlerName}
+}
+
+// Set parses value and sets it as the setting identified by key.
+func (c *ClusterConfig) Set(key, value string) error {
+	switch key {
+	case ClusterConfigKeepaliveTimeout, ClusterConfigEventTTL:
+		seconds, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s, a number of seconds is expected: %q", key, value)
+		}
+		if key == ClusterConfigKeepaliveTimeout {
+			c.KeepaliveTimeout = uint32(seconds)
+		} else {
+			c.EventTTL = uint32(seconds)
+		}
+	case ClusterConfigRegistrationEvents, ClusterConfigRequireSilencedReason:
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s, true or false is expected: %q", key, value)
+		}
+		if key == ClusterConfigRegistrationEvents {
+			c.RegistrationEvents = &enabled
+		} else {
+			c.RequireSilencedReason = enabled
+		}
+	case ClusterConfigKeepaliveHandlers:
+		c.KeepaliveHandlers = parseHandlers(value)
+	default:
+		return fmt.Errorf("unknown cluster configuration key: %q", key)
+	}
+	return nil
+}
+
+// Unset restores the default value of the setting identified by key.
+func (c *ClusterConfig) Unset(key string) error {
+	switch key {
+	case ClusterConfigKeepaliveTimeout:
+		c.KeepaliveTimeout = 0
+	case ClusterConfigEventTTL:
+		c.EventTTL = 0
+	case ClusterConfigRegistrationEvents:
+		c.RegistrationEvents = nil
+	case ClusterConfigRequireSilencedReason:
+		c.RequireSilencedReason = false
+	case ClusterConfigKeepaliveHandlers:
+		c.KeepaliveHandlers = nil
+	default:
+		return fmt.Errorf("unknown cluster configuration key: %q", key)
+	}
+	return nil
+}
+
+// SetForNamespace parses value and sets it as the keepalive default identified
+// by key of the namespace. Only the keepalive timeout and handlers can be set
+// for a namespace.
+func (c *ClusterConfig) SetForNamespace(namespace, key, value string) error {
+	if namespace == "" {
+		return errors.New("must specify namespace")
+	}
+	defaults := c.NamespaceKeepalives[namespace]
+	if defaults == nil {
+		defaults = &KeepaliveDefaults{}
+	}
+	switch key {
+	case ClusterConfigKeepaliveTimeout:
+		seconds, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid value for %s, a number of seconds is expected: %q", key, value)
+		}
+		defaults.Timeout = uint32(seconds)
+	case ClusterConfigKeepaliveHandlers:
+		defaults.Handlers = parseHandlers(value)
+	default:
+		return fmt.Errorf("%s can't be set for a namespace", key)
+	}
+	if c.NamespaceKeepalives == nil {
+		c.NamespaceKeepalives = make(map[string]*KeepaliveDefaults)
+	}
+	c.NamespaceKeepalives[namespace] = defaults
+	return nil
+}
+
+// UnsetForNamespace restores the cluster value of the keepalive default
+// identified by key of the namespace.
+func (c *ClusterConfig) UnsetForNamespace(namespace, key string) error {
+	defaults := c.NamespaceKeepalives[namespace]
+	switch key {
+	case ClusterConfigKeepaliveTimeout, ClusterConfigKeepaliveHandlers:
+	default:
+		return fmt.Errorf("%s can't be set for a namespace", key)
+	}
+	if defaults == nil {
+		return nil
+	}
+	if key == ClusterConfigKeepaliveTimeout {
+		defaults.Timeout = 0
+	} else {
+		defaults.Handlers = nil
+	}
+	if defaults.Timeout == 0 && len(defaults.Handlers) == 0 {
+		delete(c.NamespaceKeepalives, namespace)
+	}
+	return nil
+}
+
+// parseHandlers parses a comma-separated list of handlers.
+func parseHandlers(value string) []string {
+	var handlers []string
+	for _, handler := range strings.Split(value, ",") {
+		if handler = strings.TrimSpace(handler); handler != "" {
+			handlers = append(handlers, handler)
+		}
+	}
+	return handlers
+}
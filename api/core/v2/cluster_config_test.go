@@ -0,0 +1,104 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterConfigSet(t *testing.T) {
+	config := &ClusterConfig{}
+	require.NoError(t, config.Set(ClusterConfigKeepaliveTimeout, "60"))
+	require.NoError(t, config.Set(ClusterConfigEventTTL, "3600"))
+	require.NoError(t, config.Set(ClusterConfigRegistrationEvents, "false"))
+	require.NoError(t, config.Set(ClusterConfigKeepaliveHandlers, "slack, pagerduty"))
+	require.NoError(t, config.Set(ClusterConfigRequireSilencedReason, "true"))
+	disabled := false
+	assert.Equal(t, &ClusterConfig{
+		KeepaliveTimeout:      60,
+		KeepaliveHandlers:     []string{"slack", "pagerduty"},
+		EventTTL:              3600,
+		RegistrationEvents:    &disabled,
+		RequireSilencedReason: true,
+	}, config)
+	require.NoError(t, config.Unset(ClusterConfigKeepaliveHandlers))
+
+	assert.Error(t, config.Set(ClusterConfigEventTTL, "1h"))
+	assert.Error(t, config.Set(ClusterConfigRegistrationEvents, "maybe"))
+	assert.Error(t, config.Set(ClusterConfigRequireSilencedReason, "maybe"))
+	assert.Error(t, config.Set("foo", "bar"))
+
+	require.NoError(t, config.Unset(ClusterConfigKeepaliveTimeout))
+	require.NoError(t, config.Unset(ClusterConfigRegistrationEvents))
+	require.NoError(t, config.Unset(ClusterConfigRequireSilencedReason))
+	assert.Equal(t, &ClusterConfig{EventTTL: 3600}, config)
+	assert.Error(t, config.Unset("foo"))
+}
+
+func TestClusterConfigValidate(t *testing.T) {
+	assert.NoError(t, (&ClusterConfig{}).Validate())
+	assert.NoError(t, (&ClusterConfig{KeepaliveTimeout: 5}).Validate())
+	assert.Error(t, (&ClusterConfig{KeepaliveTimeout: 1}).Validate())
+	assert.Error(t, (&ClusterConfig{NamespaceKeepalives: map[string]*KeepaliveDefaults{"dev": {Timeout: 1}}}).Validate())
+	assert.Error(t, (&ClusterConfig{NamespaceKeepalives: map[string]*KeepaliveDefaults{"": {Timeout: 60}}}).Validate())
+}
+
+func TestClusterConfigSetForNamespace(t *testing.T) {
+	config := &ClusterConfig{}
+	require.NoError(t, config.SetForNamespace("dev", ClusterConfigKeepaliveTimeout, "300"))
+	require.NoError(t, config.SetForNamespace("dev", ClusterConfigKeepaliveHandlers, "slack"))
+	assert.Equal(t, &KeepaliveDefaults{Timeout: 300, Handlers: []string{"slack"}}, config.NamespaceKeepalives["dev"])
+
+	assert.Error(t, config.SetForNamespace("", ClusterConfigKeepaliveTimeout, "300"))
+	assert.Error(t, config.SetForNamespace("dev", ClusterConfigKeepaliveTimeout, "5m"))
+	assert.Error(t, config.SetForNamespace("dev", ClusterConfigEventTTL, "300"))
+
+	require.NoError(t, config.UnsetForNamespace("dev", ClusterConfigKeepaliveTimeout))
+	assert.Equal(t, &KeepaliveDefaults{Handlers: []string{"slack"}}, config.NamespaceKeepalives["dev"])
+	require.NoError(t, config.UnsetForNamespace("dev", ClusterConfigKeepaliveHandlers))
+	assert.Empty(t, config.NamespaceKeepalives)
+	require.NoError(t, config.UnsetForNamespace("prod", ClusterConfigKeepaliveHandlers))
+	assert.Error(t, config.UnsetForNamespace("dev", ClusterConfigRegistrationEvents))
+}
+
+func TestClusterConfigKeepaliveDefaults(t *testing.T) {
+	config := &ClusterConfig{}
+	assert.Equal(t, uint32(DefaultKeepaliveTimeout), config.KeepaliveTimeoutFor("dev"))
+	assert.Equal(t, []string{KeepaliveHandlerName}, config.KeepaliveHandlersFor("dev"))
+
+	config.KeepaliveTimeout = 60
+	config.KeepaliveHandlers = []string{"slack"}
+	assert.Equal(t, uint32(60), config.KeepaliveTimeoutFor("dev"))
+	assert.Equal(t, []string{"slack"}, config.KeepaliveHandlersFor("dev"))
+
+	config.NamespaceKeepalives = map[string]*KeepaliveDefaults{
+		"dev": {Timeout: 300},
+	}
+	assert.Equal(t, uint32(300), config.KeepaliveTimeoutFor("dev"))
+	assert.Equal(t, []string{"slack"}, config.KeepaliveHandlersFor("dev"))
+	assert.Equal(t, uint32(60), config.KeepaliveTimeoutFor("prod"))
+}
+
+func TestClusterConfigEntityClasses(t *testing.T) {
+	config := &ClusterConfig{}
+	assert.True(t, config.ExpectsKeepalives(EntityAgentClass))
+	assert.False(t, config.ExpectsKeepalives(EntityProxyClass))
+	assert.False(t, config.ExpectsKeepalives("service"))
+
+	require.NoError(t, config.SetEntityClass("service", &EntityClass{ExpectKeepalives: true}))
+	require.NoError(t, config.SetEntityClass("device", &EntityClass{}))
+	assert.True(t, config.ExpectsKeepalives("service"))
+	assert.False(t, config.ExpectsKeepalives("device"))
+	assert.NoError(t, config.Validate())
+
+	assert.Error(t, config.SetEntityClass(EntityProxyClass, &EntityClass{ExpectKeepalives: true}))
+	assert.Error(t, config.SetEntityClass("not valid", &EntityClass{}))
+
+	require.NoError(t, config.RemoveEntityClass("service"))
+	assert.False(t, config.ExpectsKeepalives("service"))
+	assert.Error(t, config.RemoveEntityClass("service"))
+
+	assert.Error(t, (&ClusterConfig{EntityClasses: map[string]*EntityClass{EntityAgentClass: {}}}).Validate())
+	assert.Error(t, (&ClusterConfig{EntityClasses: map[string]*EntityClass{"service": nil}}).Validate())
+}
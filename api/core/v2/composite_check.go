@@ -0,0 +1,217 @@
+package v2
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+)
+
+const (
+	// CompositeChecksResource is the name of this resource type
+	CompositeChecksResource = "compositechecks"
+
+	// CompositeOperatorAll requires all the members of a composite check to
+	// pass.
+	CompositeOperatorAll = "all"
+
+	// CompositeOperatorAny requires any member of a composite check to pass.
+	CompositeOperatorAny = "any"
+
+	// CompositeOperatorQuorum requires a quorum of the members of a composite
+	// check to pass.
+	CompositeOperatorQuorum = "quorum"
+)
+
+// String returns the entity and check of the member, e.g. web01/http.
+func (m CompositeMember) String() string {
+	return m.Entity + "/" + m.Check
+}
+
+// NewCompositeCheck creates a new CompositeCheck.
+func NewCompositeCheck(meta ObjectMeta) *CompositeCheck {
+	return &CompositeCheck{ObjectMeta: meta}
+}
+
+// FixtureCompositeCheck returns a fixture for a CompositeCheck object.
+func FixtureCompositeCheck(name string) *CompositeCheck {
+	return &CompositeCheck{
+		ObjectMeta: NewObjectMeta(name, "default"),
+		Entity:     "checkout",
+		Members: []CompositeMember{
+			{Entity: "web01", Check: "http"},
+			{Entity: "web02", Check: "http"},
+		},
+		Operator: CompositeOperatorAll,
+		Handlers: []string{},
+	}
+}
+
+// GetObjectMeta returns the object metadata for the resource.
+func (c *CompositeCheck) GetObjectMeta() ObjectMeta {
+	return c.ObjectMeta
+}
+
+// SetObjectMeta sets the meta of the resource.
+func (c *CompositeCheck) SetObjectMeta(meta ObjectMeta) {
+	c.ObjectMeta = meta
+}
+
+// SetNamespace sets the namespace of the resource.
+func (c *CompositeCheck) SetNamespace(namespace string) {
+	c.Namespace = namespace
+}
+
+// StorePrefix returns the path prefix to this resource in the store
+func (c *CompositeCheck) StorePrefix() string {
+	return CompositeChecksResource
+}
+
+// RBACName describes the name of the resource for RBAC purposes.
+func (c *CompositeCheck) RBACName() string {
+	return CompositeChecksResource
+}
+
+// URIPath returns the path component of a composite check URI.
+func (c *CompositeCheck) URIPath() string {
+	if c.Namespace == "" {
+		return path.Join(URLPrefix, CompositeChecksResource, url.PathEscape(c.Name))
+	}
+	return path.Join(URLPrefix, "namespaces", url.PathEscape(c.Namespace), CompositeChecksResource, url.PathEscape(c.Name))
+}
+
+// Validate returns an error if the composite check does not pass validation
+// tests.
+func (c *CompositeCheck) Validate() error {
+	if err := ValidateName(c.Name); err != nil {
+		return NewFieldError("metadata.name", "composite check name "+err.Error())
+	}
+	if c.Namespace == "" {
+		return NewFieldError("metadata.namespace", "namespace must be set")
+	}
+	if err := ValidateName(c.Entity); err != nil {
+		return NewFieldError("spec.entity", "composite check entity "+err.Error())
+	}
+
+	if len(c.Members) == 0 {
+		return NewFieldError("spec.members", "composite check must have members")
+	}
+	for i, member := range c.Members {
+		field := fmt.Sprintf("spec.members[%d]", i)
+		if err := ValidateName(member.Entity); err != nil {
+			return NewFieldError(field+".entity", fmt.Sprintf("member %s: entity %s", member, err))
+		}
+		if err := ValidateName(member.Check); err != nil {
+			return NewFieldError(field+".check", fmt.Sprintf("member %s: check %s", member, err))
+		}
+		if member.Entity == c.Entity && member.Check == c.Name {
+			return NewFieldError(field, "composite check can't be a member of itself")
+		}
+	}
+
+	switch c.Operator {
+	case CompositeOperatorAll, CompositeOperatorAny:
+		if c.Quorum > 0 {
+			return NewFieldError("spec.quorum", fmt.Sprintf("quorum must only be set with the %s operator", CompositeOperatorQuorum))
+		}
+	case CompositeOperatorQuorum:
+		if c.Quorum == 0 || int(c.Quorum) > len(c.Members) {
+			return NewFieldError("spec.quorum", fmt.Sprintf("quorum must be between 1 and the number of members (%d)", len(c.Members)))
+		}
+	default:
+		return NewFieldError("spec.operator",
+			fmt.Sprintf("operator must be %s, %s or %s", CompositeOperatorAll, CompositeOperatorAny, CompositeOperatorQuorum),
+			CompositeOperatorAll, CompositeOperatorAny, CompositeOperatorQuorum)
+	}
+
+	return nil
+}
+
+// HasMember returns true if the entity and check pair is a member of the
+// composite check.
+func (c *CompositeCheck) HasMember(entity, check string) bool {
+	for _, member := range c.Members {
+		if member.Entity == entity && member.Check == check {
+			return true
+		}
+	}
+	return false
+}
+
+// Evaluate returns the status and the output of the composite check, given the
+// statuses of its members. The members without a status are unknown. When the
+// composite check fails, its status is warning if the failing members are all
+// warnings, and critical otherwise.
+func (c *CompositeCheck) Evaluate(statuses map[CompositeMember]uint32) (uint32, string) {
+	passing := 0
+	warningsOnly := true
+	failing := []string{}
+	for _, member := range c.Members {
+		status, ok := statuses[member]
+		if !ok {
+			status = 3
+		}
+		if status == 0 {
+			passing++
+			continue
+		}
+		if status != 1 {
+			warningsOnly = false
+		}
+		failing = append(failing, fmt.Sprintf("%s (%s)", member, statusName(status)))
+	}
+
+	var ok bool
+	switch c.Operator {
+	case CompositeOperatorAll:
+		ok = passing == len(c.Members)
+	case CompositeOperatorAny:
+		ok = passing > 0
+	case CompositeOperatorQuorum:
+		ok = passing >= int(c.Quorum)
+	}
+
+	output := fmt.Sprintf("%d/%d members passing (%s", passing, len(c.Members), c.Operator)
+	if c.Operator == CompositeOperatorQuorum {
+		output += fmt.Sprintf(" of %d", c.Quorum)
+	}
+	output += ")"
+	if len(failing) > 0 {
+		output += ", failing: " + strings.Join(failing, ", ")
+	}
+
+	switch {
+	case ok:
+		return 0, output
+	case warningsOnly:
+		return 1, output
+	default:
+		return 2, output
+	}
+}
+
+func statusName(status uint32) string {
+	switch status {
+	case 0:
+		return "ok"
+	case 1:
+		return "warning"
+	case 2:
+		return "critical"
+	case 3:
+		return "unknown"
+	}
+	return fmt.Sprintf("status %d", status)
+}
+
+// CompositeCheckFields returns a set of fields that represent that resource
+func CompositeCheckFields(r Resource) map[string]string {
+	resource := r.(*CompositeCheck)
+	return map[string]string{
+		"composite_check.name":      resource.ObjectMeta.Name,
+		"composite_check.namespace": resource.ObjectMeta.Namespace,
+		"composite_check.entity":    resource.Entity,
+		"composite_check.operator":  resource.Operator,
+		"composite_check.handlers":  strings.Join(resource.Handlers, ","),
+	}
+}
@@ -0,0 +1,113 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompositeCheckValidate(t *testing.T) {
+	composite := FixtureCompositeCheck("checkout")
+	assert.NoError(t, composite.Validate())
+
+	composite.Operator = "most"
+	assert.Error(t, composite.Validate())
+
+	composite.Operator = CompositeOperatorQuorum
+	assert.Error(t, composite.Validate())
+	composite.Quorum = 3
+	assert.Error(t, composite.Validate())
+	composite.Quorum = 2
+	assert.NoError(t, composite.Validate())
+
+	composite = FixtureCompositeCheck("checkout")
+	composite.Members = nil
+	assert.Error(t, composite.Validate())
+
+	composite = FixtureCompositeCheck("checkout")
+	composite.Members = append(composite.Members, CompositeMember{Entity: "checkout", Check: "checkout"})
+	assert.Error(t, composite.Validate())
+
+	composite = FixtureCompositeCheck("checkout")
+	composite.Entity = ""
+	assert.Error(t, composite.Validate())
+}
+
+func TestCompositeCheckEvaluate(t *testing.T) {
+	web01 := CompositeMember{Entity: "web01", Check: "http"}
+	web02 := CompositeMember{Entity: "web02", Check: "http"}
+	web03 := CompositeMember{Entity: "web03", Check: "http"}
+
+	tests := []struct {
+		name       string
+		operator   string
+		quorum     uint32
+		statuses   map[CompositeMember]uint32
+		wantStatus uint32
+		wantOutput string
+	}{
+		{
+			name:       "all passing",
+			operator:   CompositeOperatorAll,
+			statuses:   map[CompositeMember]uint32{web01: 0, web02: 0, web03: 0},
+			wantStatus: 0,
+			wantOutput: "3/3 members passing (all)",
+		},
+		{
+			name:       "all with a warning",
+			operator:   CompositeOperatorAll,
+			statuses:   map[CompositeMember]uint32{web01: 0, web02: 1, web03: 0},
+			wantStatus: 1,
+			wantOutput: "2/3 members passing (all), failing: web02/http (warning)",
+		},
+		{
+			name:       "all with a missing status",
+			operator:   CompositeOperatorAll,
+			statuses:   map[CompositeMember]uint32{web01: 0, web02: 1},
+			wantStatus: 2,
+			wantOutput: "1/3 members passing (all), failing: web02/http (warning), web03/http (unknown)",
+		},
+		{
+			name:       "any passing",
+			operator:   CompositeOperatorAny,
+			statuses:   map[CompositeMember]uint32{web01: 2, web02: 0, web03: 2},
+			wantStatus: 0,
+			wantOutput: "1/3 members passing (any), failing: web01/http (critical), web03/http (critical)",
+		},
+		{
+			name:       "none passing",
+			operator:   CompositeOperatorAny,
+			statuses:   map[CompositeMember]uint32{web01: 2, web02: 2, web03: 2},
+			wantStatus: 2,
+			wantOutput: "0/3 members passing (any), failing: web01/http (critical), web02/http (critical), web03/http (critical)",
+		},
+		{
+			name:       "quorum reached",
+			operator:   CompositeOperatorQuorum,
+			quorum:     2,
+			statuses:   map[CompositeMember]uint32{web01: 0, web02: 2, web03: 0},
+			wantStatus: 0,
+			wantOutput: "2/3 members passing (quorum of 2), failing: web02/http (critical)",
+		},
+		{
+			name:       "quorum lost",
+			operator:   CompositeOperatorQuorum,
+			quorum:     2,
+			statuses:   map[CompositeMember]uint32{web01: 0, web02: 2, web03: 2},
+			wantStatus: 2,
+			wantOutput: "1/3 members passing (quorum of 2), failing: web02/http (critical), web03/http (critical)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			composite := FixtureCompositeCheck("checkout")
+			composite.Members = []CompositeMember{web01, web02, web03}
+			composite.Operator = tt.operator
+			composite.Quorum = tt.quorum
+
+			status, output := composite.Evaluate(tt.statuses)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantOutput, output)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package v2
+
+// DrainStatus is the status of the agent sessions of a backend, which can be
+// drained so that its agents connect to the other backends of the cluster.
+type DrainStatus struct {
+	// Backend is the name of the backend.
+	Backend string `json:"backend"`
+
+	// Draining is true when the backend rejects the agent sessions.
+	Draining bool `json:"draining"`
+
+	// Sessions is the number of agent sessions of the backend, including the
+	// ones being stopped by a drain, as last recorded by the backend.
+	Sessions int `json:"sessions"`
+
+	// MaxSessions is the maximum number of concurrent agent sessions of the
+	// backend. Zero means unlimited.
+	MaxSessions int `json:"max_sessions"`
+}
@@ -2,7 +2,6 @@ package v2
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/url"
 	"path"
@@ -50,15 +49,15 @@ func (e *Entity) URIPath() string {
 // Validate returns an error if the entity is invalid.
 func (e *Entity) Validate() error {
 	if err := ValidateName(e.Name); err != nil {
-		return errors.New("entity name " + err.Error())
+		return NewFieldError("metadata.name", "entity name "+err.Error())
 	}
 
 	if err := ValidateName(e.EntityClass); err != nil {
-		return errors.New("entity class " + err.Error())
+		return NewFieldError("spec.entity_class", "entity class "+err.Error())
 	}
 
 	if e.Namespace == "" {
-		return errors.New("namespace must be set")
+		return NewFieldError("metadata.namespace", "namespace must be set")
 	}
 
 	return nil
@@ -2,7 +2,6 @@ package v2
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"net/url"
 	"path"
@@ -48,47 +47,101 @@ func (e *Event) URIPath() string {
 // Validate returns an error if the event does not pass validation tests.
 func (e *Event) Validate() error {
 	if e.Entity == nil {
-		return errors.New("event must contain an entity")
+		return NewFieldError("spec.entity", "event must contain an entity")
 	}
 
 	if !e.HasCheck() && !e.HasMetrics() {
-		return errors.New("event must contain a check or metrics")
+		return NewFieldError("spec.check", "event must contain a check or metrics")
+	}
+
+	if e.Timestamp < 0 {
+		return NewFieldError("spec.timestamp", "event timestamp cannot be negative")
 	}
 
 	if err := e.Entity.Validate(); err != nil {
-		return errors.New("entity is invalid: " + err.Error())
+		return nestedFieldError("spec.entity", "entity is invalid: ", err)
 	}
 
 	if e.HasCheck() {
 		if err := e.Check.Validate(); err != nil {
-			return errors.New("check is invalid: " + err.Error())
+			return nestedFieldError("spec.check", "check is invalid: ", err)
+		}
+		if err := validateCheckResult(e.Check); err != nil {
+			return nestedFieldError("spec.check", "check is invalid: ", err)
 		}
 	}
 
 	if e.HasMetrics() {
 		if err := e.Metrics.Validate(); err != nil {
-			return errors.New("metrics are invalid: " + err.Error())
+			return nestedFieldError("spec.metrics", "metrics are invalid: ", err)
 		}
 	}
 
 	if e.Name != "" {
-		return errors.New("events cannot be named")
+		return NewFieldError("metadata.name", "events cannot be named")
 	}
 
 	if len(e.ID) > 0 {
 		if _, err := uuid.FromBytes(e.ID); err != nil {
-			return fmt.Errorf("event ID is invalid: %s", err)
+			return NewFieldError("spec.id", fmt.Sprintf("event ID is invalid: %s", err))
 		}
 	}
 
 	return nil
 }
 
+// validateCheckResult validates the result fields of a check, which are not
+// validated by Check.Validate since they are not part of its configuration.
+func validateCheckResult(c *Check) error {
+	if c.Executed < 0 {
+		return NewFieldError("executed", "executed timestamp cannot be negative")
+	}
+	if c.Issued < 0 {
+		return NewFieldError("issued", "issued timestamp cannot be negative")
+	}
+	if c.LastOK < 0 {
+		return NewFieldError("last_ok", "last ok timestamp cannot be negative")
+	}
+	if c.TotalStateChange > 100 {
+		return NewFieldError("total_state_change", fmt.Sprintf("total state change must be a percentage, got %d", c.TotalStateChange))
+	}
+	for i, h := range c.History {
+		if h.Executed < 0 {
+			return NewFieldError(fmt.Sprintf("history[%d].executed", i), fmt.Sprintf("history entry %d has a negative executed timestamp", i))
+		}
+	}
+	return nil
+}
+
 // HasCheck determines if an event has check data.
 func (e *Event) HasCheck() bool {
 	return e.Check != nil
 }
 
+// GetRedactedEvent redacts the event, its entity and its check. The labels and
+// annotations of the event are redacted according to the Redact fields of its
+// entity. A redacted copy is returned. The copy contains pointers to the
+// original's memory, with a redacted entity and check and different Labels and
+// Annotations.
+func (e *Event) GetRedactedEvent() *Event {
+	if e == nil {
+		return nil
+	}
+	event := &Event{}
+	*event = *e
+	event.Entity = e.Entity.GetRedactedEntity()
+	event.Check = e.Check.GetRedactedCheck()
+	if e.Labels != nil || e.Annotations != nil {
+		var redact []string
+		if e.Entity != nil {
+			redact = e.Entity.Redact
+		}
+		event.Labels = redactMap(e.Labels, redact)
+		event.Annotations = redactMap(e.Annotations, redact)
+	}
+	return event
+}
+
 // HasMetrics determines if an event has metric data.
 func (e *Event) HasMetrics() bool {
 	return e.Metrics != nil
@@ -183,6 +236,22 @@ func NewEvent(meta ObjectMeta) *Event {
 //
 // Sorting
 
+const (
+	// EventSortLastOK sorts the events by last OK time, the failing events
+	// first.
+	EventSortLastOK = "last_ok"
+
+	// EventSortDuration sorts the events by check duration, the slowest first.
+	EventSortDuration = "duration"
+)
+
+// EventSorts are the sortings of the events supported by the events API, by
+// value of its sort query parameter.
+var EventSorts = map[string]func([]*Event) sort.Interface{
+	EventSortLastOK:   EventsByLastOk,
+	EventSortDuration: EventsByDuration,
+}
+
 // EventsBySeverity can be used to sort a given collection of events by check
 // status and timestamp.
 func EventsBySeverity(es []*Event) sort.Interface {
@@ -219,6 +288,15 @@ func EventsByLastOk(es []*Event) sort.Interface {
 	)}
 }
 
+// EventsByDuration can be used to sort a given collection of events by the
+// duration of the execution of their check, the slowest first.
+func EventsByDuration(es []*Event) sort.Interface {
+	return &eventSorter{es, createCmpEvents(
+		cmpByDuration,
+		cmpByUniqueComponents,
+	)}
+}
+
 func cmpByUniqueComponents(a, b *Event) int {
 	ai, bi := "", ""
 	if a.Entity != nil {
@@ -283,6 +361,23 @@ func cmpByLastOk(a, b *Event) int {
 	return -1
 }
 
+func cmpByDuration(a, b *Event) int {
+	var ad, bd float64
+	if a.HasCheck() {
+		ad = a.Check.Duration
+	}
+	if b.HasCheck() {
+		bd = b.Check.Duration
+	}
+
+	if ad == bd {
+		return 0
+	} else if ad > bd {
+		return 1
+	}
+	return -1
+}
+
 // Based on convention we define the order of importance as critical (2),
 // warning (1), unknown (>2), and Ok (0). If event is not a check sort to
 // very end.
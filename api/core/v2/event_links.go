@@ -0,0 +1,48 @@
+package v2
+
+import (
+	"sort"
+	"strings"
+)
+
+// EventLink is a named URL giving context about an event, e.g. a dashboard.
+type EventLink struct {
+	// Name is the name of the link, e.g. dashboard.
+	Name string `json:"name"`
+
+	// URL is the URL of the link.
+	URL string `json:"url"`
+}
+
+// RunbookURL returns the URL of the runbook of the event, given by the
+// RunbookURLAnnotation of its check, or an empty string.
+func (e *Event) RunbookURL() string {
+	if !e.HasCheck() {
+		return ""
+	}
+	return strings.TrimSpace(e.Check.Annotations[RunbookURLAnnotation])
+}
+
+// Links returns the links of the event, given by the annotations of its check
+// prefixed with LinkAnnotationPrefix, sorted by name.
+func (e *Event) Links() []EventLink {
+	if !e.HasCheck() {
+		return nil
+	}
+	var links []EventLink
+	for key, value := range e.Check.Annotations {
+		if !strings.HasPrefix(key, LinkAnnotationPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(key, LinkAnnotationPrefix)
+		value = strings.TrimSpace(value)
+		if name == "" || value == "" {
+			continue
+		}
+		links = append(links, EventLink{Name: name, URL: value})
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return links[i].Name < links[j].Name
+	})
+	return links
+}
@@ -0,0 +1,31 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventLinks(t *testing.T) {
+	event := FixtureEvent("entity1", "check1")
+	assert.Empty(t, event.RunbookURL())
+	assert.Empty(t, event.Links())
+
+	event.Check.Annotations = map[string]string{
+		RunbookURLAnnotation:               "https://wiki.example.com/runbooks/check1",
+		LinkAnnotationPrefix + "grafana":   "https://grafana.example.com/d/check1",
+		LinkAnnotationPrefix + "dashboard": " https://dashboard.example.com ",
+		LinkAnnotationPrefix + "empty":     "",
+		LinkAnnotationPrefix:               "https://nameless.example.com",
+		"example.com/link.unrelated":       "https://unrelated.example.com",
+	}
+	assert.Equal(t, "https://wiki.example.com/runbooks/check1", event.RunbookURL())
+	assert.Equal(t, []EventLink{
+		{Name: "dashboard", URL: "https://dashboard.example.com"},
+		{Name: "grafana", URL: "https://grafana.example.com/d/check1"},
+	}, event.Links())
+
+	event.Check = nil
+	assert.Empty(t, event.RunbookURL())
+	assert.Empty(t, event.Links())
+}
@@ -0,0 +1,42 @@
+package v2
+
+// EventReport summarizes the check results of the events of a namespace over
+// a period, to find the noisiest checks and entities.
+type EventReport struct {
+	// Since is the start of the period, as a Unix timestamp.
+	Since int64 `json:"since"`
+
+	// Checks are the counts of the check results grouped by check, sorted by
+	// decreasing number of incidents.
+	Checks []*EventReportEntry `json:"checks"`
+
+	// Entities are the counts of the check results grouped by entity, sorted
+	// by decreasing number of incidents.
+	Entities []*EventReportEntry `json:"entities"`
+}
+
+// EventReportEntry counts the check results of the events of a check or of an
+// entity. The counts are derived from the check history of the events, so only
+// the latest results of each event are accounted for.
+type EventReportEntry struct {
+	// Name is the name of the check or of the entity.
+	Name string `json:"name"`
+
+	// Events is the number of events with check results in the period.
+	Events int `json:"events"`
+
+	// Results is the number of check results in the period.
+	Results int `json:"results"`
+
+	// Incidents is the number of check results with a non-zero status in the
+	// period.
+	Incidents int `json:"incidents"`
+
+	// StateChanges is the number of check results in the period whose status
+	// differs from the previous result of the period.
+	StateChanges int `json:"state_changes"`
+
+	// Flapping is the number of events with check results in the period that
+	// are currently flapping.
+	Flapping int `json:"flapping"`
+}
@@ -0,0 +1,83 @@
+package v2
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// FieldRequirement is a requirement on the value of a field of a resource, as
+// returned by the fields function of the resource, e.g. EventFields.
+type FieldRequirement struct {
+	// Field is the name of the field, e.g. event.check.status.
+	Field string
+
+	// Value is the value the field is compared to.
+	Value string
+
+	// NotEqual is true if the field must not be equal to the value.
+	NotEqual bool
+}
+
+// ParseFieldSelector parses a field selector made of comma-separated
+// requirements, such as "event.check.status!=0,event.entity.name==web01". Both
+// "=" and "==" test the equality of a field.
+func ParseFieldSelector(selector string) ([]FieldRequirement, error) {
+	if strings.TrimSpace(selector) == "" {
+		return nil, errors.New("field selector must not be empty")
+	}
+
+	requirements := []FieldRequirement{}
+	for _, statement := range strings.Split(selector, ",") {
+		var req FieldRequirement
+		var parts []string
+		switch {
+		case strings.Contains(statement, "!="):
+			parts = strings.SplitN(statement, "!=", 2)
+			req.NotEqual = true
+		case strings.Contains(statement, "=="):
+			parts = strings.SplitN(statement, "==", 2)
+		case strings.Contains(statement, "="):
+			parts = strings.SplitN(statement, "=", 2)
+		default:
+			return nil, fmt.Errorf("invalid requirement %q, must have the format FIELD==VALUE or FIELD!=VALUE", statement)
+		}
+		req.Field = strings.TrimSpace(parts[0])
+		req.Value = strings.TrimSpace(parts[1])
+		if req.Field == "" {
+			return nil, fmt.Errorf("invalid requirement %q, the field is missing", statement)
+		}
+		requirements = append(requirements, req)
+	}
+	return requirements, nil
+}
+
+// MatchFields returns true if the fields meet all the requirements. An error is
+// returned if a requirement refers to an unknown field.
+func MatchFields(requirements []FieldRequirement, fields map[string]string) (bool, error) {
+	for _, req := range requirements {
+		value, ok := fields[req.Field]
+		if !ok {
+			return false, fmt.Errorf("unknown field %q", req.Field)
+		}
+		if (value == req.Value) == req.NotEqual {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// EventSelection deletes or resolves all the events of a namespace selected by
+// a field selector.
+type EventSelection struct {
+	// FieldSelector selects the events with the fields of EventFields, e.g.
+	// "event.check.name==disk,event.check.status!=0".
+	FieldSelector string `json:"field_selector"`
+}
+
+// EventSelectionResponse lists the events deleted or resolved by an
+// EventSelection.
+type EventSelectionResponse struct {
+	// Events are the entity/check names of the events.
+	Events []string `json:"events"`
+}
@@ -0,0 +1,53 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFieldSelector(t *testing.T) {
+	reqs, err := ParseFieldSelector("event.check.name==disk, event.check.status!=0,event.entity.name=web01")
+	require.NoError(t, err)
+	assert.Equal(t, []FieldRequirement{
+		{Field: "event.check.name", Value: "disk"},
+		{Field: "event.check.status", Value: "0", NotEqual: true},
+		{Field: "event.entity.name", Value: "web01"},
+	}, reqs)
+
+	for _, selector := range []string{"", " ", "event.check.name", "==disk", "event.check.name==disk,"} {
+		_, err := ParseFieldSelector(selector)
+		assert.Error(t, err, selector)
+	}
+}
+
+func TestMatchFields(t *testing.T) {
+	fields := EventFields(FixtureEvent("web01", "disk"))
+
+	tests := []struct {
+		selector string
+		want     bool
+		wantErr  bool
+	}{
+		{selector: "event.check.name==disk", want: true},
+		{selector: "event.check.name==disk,event.entity.name==web01", want: true},
+		{selector: "event.check.name==disk,event.entity.name==web02", want: false},
+		{selector: "event.check.status!=0", want: false},
+		{selector: "event.check.status=0", want: true},
+		{selector: "event.unknown==foo", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			reqs, err := ParseFieldSelector(tt.selector)
+			require.NoError(t, err)
+			got, err := MatchFields(reqs, fields)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
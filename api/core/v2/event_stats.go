@@ -0,0 +1,40 @@
+package v2
+
+// EventStats are the rates of the events processed by the running backends of
+// the cluster over a rolling window, per namespace and per check.
+type EventStats struct {
+	// Window is the duration of the rolling window of the rates, in seconds.
+	// It is shorter than the configured window until the backends ran for
+	// that long.
+	Window int64 `json:"window"`
+
+	// Events is the number of events processed during the window.
+	Events int64 `json:"events"`
+
+	// Namespaces are the event rates per namespace, sorted by decreasing
+	// number of events.
+	Namespaces []EventRate `json:"namespaces"`
+
+	// Checks are the event rates per check, sorted by decreasing number of
+	// events.
+	Checks []EventRate `json:"checks"`
+}
+
+// EventRate is the rate of the events of a namespace or of a check.
+type EventRate struct {
+	// Namespace is the namespace of the events.
+	Namespace string `json:"namespace"`
+
+	// Check is the name of the check of the events. It is empty for the rates
+	// per namespace, and for the events only carrying metrics.
+	Check string `json:"check,omitempty"`
+
+	// Events is the number of events processed during the window.
+	Events int64 `json:"events"`
+
+	// PerMinute is the average number of events per minute.
+	PerMinute float64 `json:"per_minute"`
+
+	// Share is the percentage of the events processed during the window.
+	Share float64 `json:"share"`
+}
@@ -33,6 +33,33 @@ func TestEventValidate(t *testing.T) {
 	assert.NoError(t, event.Validate())
 }
 
+func TestGetRedactedEvent(t *testing.T) {
+	event := FixtureEvent("entity", "check")
+	event.Labels = map[string]string{"password": "hunter2", "team": "ops"}
+	event.Annotations = map[string]string{"api_key": "123"}
+	event.Entity.Labels = map[string]string{"secret": "foo"}
+	event.Check.Labels = map[string]string{"token": "bar"}
+
+	redacted := event.GetRedactedEvent()
+	assert.Equal(t, map[string]string{"password": Redacted, "team": "ops"}, redacted.Labels)
+	assert.Equal(t, map[string]string{"api_key": Redacted}, redacted.Annotations)
+	assert.Equal(t, Redacted, redacted.Entity.Labels["secret"])
+	assert.Equal(t, Redacted, redacted.Check.Labels["token"])
+
+	// The original event is left untouched
+	assert.Equal(t, "hunter2", event.Labels["password"])
+	assert.Equal(t, "foo", event.Entity.Labels["secret"])
+	assert.Equal(t, "bar", event.Check.Labels["token"])
+
+	// The redact fields of the entity apply to the event
+	event.Entity.Redact = []string{"team"}
+	redacted = event.GetRedactedEvent()
+	assert.Equal(t, map[string]string{"password": "hunter2", "team": Redacted}, redacted.Labels)
+
+	var nilEvent *Event
+	assert.Nil(t, nilEvent.GetRedactedEvent())
+}
+
 func TestEventValidateNoTimestamp(t *testing.T) {
 	// Events without a timestamp are valid
 	event := FixtureEvent("entity", "check")
@@ -42,6 +69,69 @@ func TestEventValidateNoTimestamp(t *testing.T) {
 	}
 }
 
+func TestEventValidateCrossFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Event)
+		wantErr bool
+	}{
+		{"valid", func(e *Event) {}, false},
+		{"no entity", func(e *Event) { e.Entity = nil }, true},
+		{"no check nor metrics", func(e *Event) { e.Check = nil }, true},
+		{"metrics only", func(e *Event) { e.Check = nil; e.Metrics = FixtureMetrics() }, false},
+		{"negative timestamp", func(e *Event) { e.Timestamp = -1 }, true},
+		{"negative executed", func(e *Event) { e.Check.Executed = -1 }, true},
+		{"negative issued", func(e *Event) { e.Check.Issued = -1 }, true},
+		{"negative last ok", func(e *Event) { e.Check.LastOK = -1 }, true},
+		{"negative history", func(e *Event) { e.Check.History = []CheckHistory{{Executed: -1}} }, true},
+		{"total state change out of range", func(e *Event) { e.Check.TotalStateChange = 101 }, true},
+		{"invalid metric point", func(e *Event) {
+			e.Metrics = FixtureMetrics()
+			e.Metrics.Points[0].Name = ""
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := FixtureEvent("entity", "check")
+			tt.mutate(event)
+			err := event.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEventValidateFieldError(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(*Event)
+		field  string
+	}{
+		{"entity name", func(e *Event) { e.Entity.Name = "" }, "spec.entity.metadata.name"},
+		{"check splay coverage", func(e *Event) {
+			e.Check.ProxyRequests = FixtureProxyRequests(true)
+			e.Check.ProxyRequests.SplayCoverage = 0
+		}, "spec.check.proxy_requests.splay_coverage"},
+		{"negative history", func(e *Event) { e.Check.History = []CheckHistory{{Executed: -1}} }, "spec.check.history[0].executed"},
+		{"metric point name", func(e *Event) {
+			e.Metrics = FixtureMetrics()
+			e.Metrics.Points[0].Name = ""
+		}, "spec.metrics.points[0].name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := FixtureEvent("entity", "check")
+			tt.mutate(event)
+			fieldErr, ok := event.Validate().(*FieldError)
+			require.True(t, ok)
+			assert.Equal(t, tt.field, fieldErr.Field)
+		})
+	}
+}
+
 func TestMarshalJSON(t *testing.T) {
 	event := FixtureEvent("entity", "check")
 	_, err := json.Marshal(event)
@@ -458,6 +548,21 @@ func TestEventsBySeverity(t *testing.T) {
 	}
 }
 
+func TestEventsByDuration(t *testing.T) {
+	slow := FixtureEvent("zeta", "check")
+	slow.Check.Duration = 10
+	fast := FixtureEvent("zeta", "check")
+	fast.Check.Duration = 0.5
+	fastDiffEntity := FixtureEvent("abba", "check")
+	fastDiffEntity.Check.Duration = 0.5
+	metrics := FixtureEvent("zeta", "metrics")
+	metrics.Check = nil
+
+	input := []*Event{fast, metrics, slow, fastDiffEntity}
+	sort.Sort(EventsByDuration(input))
+	assert.EqualValues(t, []*Event{slow, fastDiffEntity, fast, metrics}, input)
+}
+
 func TestEventsByLastOk(t *testing.T) {
 	incident := FixtureEvent("zeta", "check")
 	incident.Check.Status = 2 // crit
@@ -1,7 +1,6 @@
 package v2
 
 import (
-	"errors"
 	"net/url"
 	"path"
 )
@@ -24,13 +23,13 @@ func (e *Extension) URIPath() string {
 // Validate validates the extension.
 func (e *Extension) Validate() error {
 	if err := ValidateName(e.Name); err != nil {
-		return err
+		return NewFieldError("metadata.name", err.Error())
 	}
 	if e.URL == "" {
-		return errors.New("empty URL")
+		return NewFieldError("spec.url", "empty URL")
 	}
 	if e.Namespace == "" {
-		return errors.New("empty namespace")
+		return NewFieldError("metadata.namespace", "empty namespace")
 	}
 	return nil
 }
@@ -1,7 +1,6 @@
 package v2
 
 import (
-	"errors"
 	"fmt"
 	"net/url"
 	"path"
@@ -50,23 +49,23 @@ func (f *EventFilter) URIPath() string {
 // Validate returns an error if the filter does not pass validation tests.
 func (f *EventFilter) Validate() error {
 	if err := ValidateName(f.Name); err != nil {
-		return errors.New("filter name " + err.Error())
+		return NewFieldError("metadata.name", "filter name "+err.Error())
 	}
 
 	if found := utilstrings.InArray(f.Action, EventFilterAllActions); !found {
-		return fmt.Errorf("action '%s' is not valid", f.Action)
+		return NewFieldError("spec.action", fmt.Sprintf("action '%s' is not valid", f.Action), EventFilterAllActions...)
 	}
 
 	if len(f.Expressions) == 0 {
-		return errors.New("filter must have one or more expressions")
+		return NewFieldError("spec.expressions", "filter must have one or more expressions")
 	}
 
 	if err := js.ParseExpressions(f.Expressions); err != nil {
-		return err
+		return NewFieldError("spec.expressions", err.Error())
 	}
 
 	if f.Namespace == "" {
-		return errors.New("namespace must be set")
+		return NewFieldError("metadata.namespace", "namespace must be set")
 	}
 
 	return nil
@@ -7,6 +7,8 @@ import (
 	"path"
 	"sort"
 	"strings"
+
+	utilstrings "github.com/sensu/sensu-go/util/strings"
 )
 
 const (
@@ -33,6 +35,23 @@ const (
 	// HandlerGRPCType is a special kind of handler that represents an extension
 	HandlerGRPCType = "grpc"
 
+	// HandlerSlackType represents handlers that post event notifications to a
+	// Slack incoming webhook, without executing an external command
+	HandlerSlackType = "slack"
+
+	// HandlerPagerDutyType represents handlers that trigger and resolve
+	// PagerDuty alerts with the Events API v2, without executing an external
+	// command
+	HandlerPagerDutyType = "pagerduty"
+
+	// HandlerEmailType represents handlers that send event notifications by
+	// email, without executing an external command
+	HandlerEmailType = "email"
+
+	// HandlerWebhookType represents handlers that post event data to an HTTP
+	// endpoint, without executing an external command
+	HandlerWebhookType = "webhook"
+
 	// KeepaliveHandlerName is the name of the handler that is executed when
 	// a keepalive timeout occurs.
 	KeepaliveHandlerName = "keepalive"
@@ -42,6 +61,19 @@ const (
 	RegistrationHandlerName = "registration"
 )
 
+// HandlerTypes are the valid handler types.
+var HandlerTypes = []string{
+	HandlerPipeType,
+	HandlerSetType,
+	HandlerTCPType,
+	HandlerUDPType,
+	HandlerGRPCType,
+	HandlerSlackType,
+	HandlerPagerDutyType,
+	HandlerEmailType,
+	HandlerWebhookType,
+}
+
 // StorePrefix returns the path prefix to this resource in the store
 func (h *Handler) StorePrefix() string {
 	return HandlersResource
@@ -58,7 +90,7 @@ func (h *Handler) URIPath() string {
 // Validate returns an error if the handler does not pass validation tests.
 func (h *Handler) Validate() error {
 	if err := ValidateName(h.Name); err != nil {
-		return errors.New("handler name " + err.Error())
+		return NewFieldError("metadata.name", "handler name "+err.Error())
 	}
 
 	if err := h.validateType(); err != nil {
@@ -66,25 +98,65 @@ func (h *Handler) Validate() error {
 	}
 
 	if h.Namespace == "" {
-		return errors.New("namespace must be set")
+		return NewFieldError("metadata.namespace", "namespace must be set")
+	}
+
+	for i, selector := range h.EntitySelectors {
+		if _, err := ParseFieldSelector(selector); err != nil {
+			return NewFieldError(fmt.Sprintf("spec.entity_selectors[%d]", i), err.Error())
+		}
 	}
 
 	return nil
 }
 
+// MatchesEntity returns true if the entity has one of the subscriptions of the
+// handler and its labels meet all the entity selectors of the handler. A label
+// the entity doesn't have is considered empty.
+func (h *Handler) MatchesEntity(entity *Entity) bool {
+	if len(h.Subscriptions) > 0 {
+		if entity == nil {
+			return false
+		}
+		if len(utilstrings.Intersect(h.Subscriptions, entity.Subscriptions)) == 0 {
+			return false
+		}
+	}
+
+	var labels map[string]string
+	if entity != nil {
+		labels = entity.Labels
+	}
+	for _, selector := range h.EntitySelectors {
+		requirements, err := ParseFieldSelector(selector)
+		if err != nil {
+			return false
+		}
+		for _, req := range requirements {
+			if (labels[req.Field] == req.Value) == req.NotEqual {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func (h *Handler) validateType() error {
 	if h.Type == "" {
-		return errors.New("empty handler type")
+		return NewFieldError("spec.type", "empty handler type", HandlerTypes...)
 	}
 
 	switch h.Type {
-	case "pipe", "set", "grpc":
+	case "pipe", "set", "grpc", "slack", "pagerduty", "email", "webhook":
 		return nil
 	case "tcp", "udp":
-		return h.Socket.Validate()
+		if err := h.Socket.Validate(); err != nil {
+			return NewFieldError("spec.socket", err.Error())
+		}
+		return nil
 	}
 
-	return fmt.Errorf("unknown handler type: %s", h.Type)
+	return NewFieldError("spec.type", fmt.Sprintf("unknown handler type: %s", h.Type), HandlerTypes...)
 }
 
 // Validate returns an error if the handler socket does not pass validation tests.
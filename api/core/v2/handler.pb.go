@@ -49,10 +49,20 @@ type Handler struct {
 	RuntimeAssets []string `protobuf:"bytes,13,rep,name=runtime_assets,json=runtimeAssets,proto3" json:"runtime_assets"`
 	// Secrets is the list of Sensu secrets to set for the handler's
 	// execution environment.
-	Secrets              []*Secret `protobuf:"bytes,14,rep,name=secrets,proto3" json:"secrets"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	Secrets []*Secret `protobuf:"bytes,14,rep,name=secrets,proto3" json:"secrets"`
+	// MaxConcurrent is the maximum number of concurrent executions of the
+	// handler by a backend. The default of pipelined applies if it's 0.
+	MaxConcurrent uint32 `protobuf:"varint,15,opt,name=max_concurrent,json=maxConcurrent,proto3" json:"max_concurrent,omitempty"`
+	// Subscriptions restricts the handler to the events of the entities
+	// having at least one of these subscriptions.
+	Subscriptions []string `protobuf:"bytes,16,rep,name=subscriptions,proto3" json:"subscriptions,omitempty"`
+	// EntitySelectors restricts the handler to the events of the entities
+	// whose labels meet all these requirements, in the LABEL==VALUE or
+	// LABEL!=VALUE format, e.g. team==payments.
+	EntitySelectors      []string `protobuf:"bytes,17,rep,name=entity_selectors,json=entitySelectors,proto3" json:"entity_selectors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *Handler) Reset()         { *m = Handler{} }
@@ -154,37 +164,40 @@ func init() {
 func init() { proto.RegisterFile("handler.proto", fileDescriptor_515968b8e1a22554) }
 
 var fileDescriptor_515968b8e1a22554 = []byte{
-	// 477 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x52, 0x31, 0x6e, 0xdb, 0x30,
-	0x14, 0x35, 0x63, 0xd5, 0x92, 0xe9, 0x28, 0x03, 0x81, 0x02, 0x6c, 0x10, 0x88, 0x42, 0x80, 0xa2,
-	0x1a, 0x0a, 0x05, 0x71, 0xba, 0x34, 0xe8, 0xd0, 0x68, 0xea, 0x52, 0x14, 0x60, 0xd0, 0x0e, 0x5d,
-	0x02, 0x5a, 0x66, 0x6c, 0xb7, 0x91, 0x68, 0x88, 0x94, 0x80, 0xdc, 0x20, 0x47, 0xe8, 0x98, 0x31,
-	0x47, 0xe8, 0x11, 0x3c, 0xe6, 0x04, 0x42, 0xab, 0x6e, 0x3a, 0x41, 0xc7, 0x42, 0x5f, 0x92, 0x8b,
-	0x78, 0x11, 0xde, 0x7b, 0xff, 0xe9, 0xf3, 0x3f, 0x7e, 0x62, 0x77, 0x29, 0xd2, 0xf9, 0x8d, 0xcc,
-	0xc2, 0x75, 0xa6, 0x8c, 0x22, 0xae, 0x96, 0xa9, 0xce, 0xc3, 0x58, 0x65, 0x32, 0x2c, 0xa6, 0x87,
-	0x6f, 0x16, 0x2b, 0xb3, 0xcc, 0x67, 0x61, 0xac, 0x92, 0x93, 0x85, 0x5a, 0xa8, 0x13, 0x70, 0xcd,
-	0xf2, 0xeb, 0xf7, 0xc5, 0x69, 0x78, 0x16, 0x9e, 0x82, 0x08, 0x1a, 0xa0, 0xb6, 0xc9, 0x21, 0x4e,
-	0xa4, 0x11, 0x1d, 0xde, 0xd7, 0x32, 0xce, 0xa4, 0x69, 0xd9, 0xf1, 0x9d, 0x85, 0xed, 0x0f, 0xed,
-	0x81, 0xe4, 0x33, 0x76, 0x1a, 0xdf, 0x5c, 0x18, 0x41, 0x91, 0x8f, 0x82, 0xc9, 0xf4, 0x45, 0xf8,
-	0xe4, 0xf4, 0xf0, 0xd3, 0xec, 0x9b, 0x8c, 0xcd, 0x47, 0x69, 0x44, 0xe4, 0x6d, 0x4a, 0x36, 0x78,
-	0x2c, 0x19, 0xaa, 0x4b, 0x46, 0xfa, 0xdf, 0x5e, 0xab, 0x64, 0x65, 0x64, 0xb2, 0x36, 0xb7, 0x7c,
-	0xdb, 0x8a, 0x10, 0x6c, 0x99, 0xdb, 0xb5, 0xa4, 0x7b, 0x3e, 0x0a, 0xc6, 0x1c, 0x30, 0xa1, 0xd8,
-	0x4e, 0x72, 0x23, 0x8c, 0xca, 0xe8, 0x10, 0xe4, 0x9e, 0x36, 0x95, 0x58, 0x25, 0x89, 0x48, 0xe7,
-	0xd4, 0x6a, 0x2b, 0x1d, 0x25, 0x2f, 0xb1, 0x6d, 0x56, 0x89, 0x54, 0xb9, 0xa1, 0xcf, 0x7c, 0x14,
-	0xb8, 0xd1, 0xa4, 0x2e, 0x59, 0x2f, 0xf1, 0x1e, 0x90, 0x73, 0x3c, 0xd2, 0x2a, 0xfe, 0x2e, 0x0d,
-	0x1d, 0x41, 0x86, 0xa3, 0x9d, 0x0c, 0x5d, 0xda, 0x4b, 0xf0, 0x44, 0xd6, 0xa6, 0x64, 0x88, 0x77,
-	0x7f, 0x90, 0x00, 0x3b, 0xdd, 0xed, 0x6b, 0x6a, 0xfb, 0xc3, 0x60, 0x1c, 0xed, 0xd7, 0x25, 0xdb,
-	0x6a, 0x7c, 0x8b, 0x9a, 0x61, 0xae, 0x57, 0x37, 0xa6, 0x31, 0x3a, 0x60, 0x84, 0x61, 0x3a, 0x89,
-	0xf7, 0x80, 0xbc, 0xc2, 0x8e, 0x4c, 0x8b, 0xab, 0x42, 0x64, 0x9a, 0x8e, 0xff, 0x37, 0xec, 0x35,
-	0x6e, 0xcb, 0xb4, 0xf8, 0x22, 0x32, 0x4d, 0xde, 0xe2, 0x83, 0x2c, 0x4f, 0x9b, 0x0c, 0x57, 0x42,
-	0x6b, 0x69, 0x34, 0x75, 0xc1, 0x4e, 0xea, 0x92, 0xed, 0x54, 0xb8, 0xdb, 0xf1, 0x0b, 0xa0, 0xe4,
-	0x1d, 0xb6, 0xdb, 0x95, 0x6a, 0x7a, 0xe0, 0x0f, 0x83, 0xc9, 0xf4, 0xf9, 0x4e, 0xe2, 0x4b, 0xa8,
-	0xb6, 0x13, 0x76, 0x4e, 0xde, 0x83, 0x73, 0xe7, 0xee, 0x9e, 0x0d, 0x1e, 0xee, 0x19, 0x3a, 0xbe,
-	0xc0, 0xee, 0x93, 0xbb, 0x69, 0x16, 0xb7, 0x54, 0xda, 0xc0, 0x5b, 0x18, 0x73, 0xc0, 0xe4, 0x08,
-	0x5b, 0x6b, 0x95, 0x19, 0x58, 0xa6, 0x1b, 0x39, 0x75, 0xc9, 0x80, 0x73, 0xf8, 0x46, 0xfe, 0xdf,
-	0xdf, 0x1e, 0x7a, 0xa8, 0x3c, 0xf4, 0xb3, 0xf2, 0xd0, 0xa6, 0xf2, 0xd0, 0x63, 0xe5, 0xa1, 0x5f,
-	0x95, 0x87, 0x7e, 0xfc, 0xf1, 0x06, 0x5f, 0xf7, 0x8a, 0xe9, 0x6c, 0x04, 0xcf, 0xee, 0xec, 0x5f,
-	0x00, 0x00, 0x00, 0xff, 0xff, 0x6f, 0x03, 0x99, 0xbf, 0xe6, 0x02, 0x00, 0x00,
+	// 528 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0xff, 0x5d, 0x52, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0x8e, 0xdb, 0x34, 0x76, 0x36, 0x75, 0x5a, 0x56, 0x42, 0x5a, 0xaa, 0x2a, 0x8e, 0x2a, 0x10,
+	0x41, 0x42, 0xae, 0x9a, 0x72, 0xa1, 0xe2, 0x40, 0xcd, 0x85, 0x0b, 0x42, 0xda, 0x08, 0x0e, 0x5c,
+	0x22, 0xc7, 0xdd, 0x26, 0x86, 0xd8, 0x6b, 0x79, 0xc7, 0x16, 0xb9, 0xf5, 0xd8, 0x47, 0xe0, 0xd8,
+	0x63, 0x1f, 0x81, 0x47, 0xe8, 0xb1, 0x4f, 0x10, 0xf1, 0x73, 0xe3, 0x09, 0x38, 0x32, 0x5e, 0xdb,
+	0x41, 0xc9, 0x61, 0xac, 0x6f, 0xbe, 0xf9, 0x76, 0x76, 0xbe, 0xf5, 0x10, 0x7b, 0xe6, 0xc7, 0x17,
+	0x73, 0x91, 0xba, 0x49, 0x2a, 0x41, 0x52, 0x5b, 0x89, 0x58, 0x65, 0x6e, 0x20, 0x53, 0xe1, 0xe6,
+	0xc3, 0x83, 0x17, 0xd3, 0x10, 0x66, 0xd9, 0x04, 0xf3, 0xe8, 0x78, 0x2a, 0xa7, 0xf2, 0x58, 0xab,
+	0x26, 0xd9, 0xe5, 0xeb, 0xfc, 0xc4, 0x3d, 0x75, 0x4f, 0x34, 0xa9, 0x39, 0x8d, 0xca, 0x26, 0x07,
+	0x24, 0x12, 0xe0, 0x57, 0x78, 0x57, 0x89, 0x20, 0x15, 0x50, 0x66, 0x47, 0x57, 0x3b, 0xc4, 0x7c,
+	0x5b, 0x5e, 0x48, 0x3f, 0x10, 0xab, 0xd0, 0x5d, 0xf8, 0xe0, 0x33, 0xa3, 0x6f, 0x0c, 0x3a, 0xc3,
+	0x47, 0xee, 0xda, 0xed, 0xee, 0xfb, 0xc9, 0x67, 0x11, 0xc0, 0x3b, 0x14, 0x79, 0xbd, 0xbb, 0xa5,
+	0xd3, 0xb8, 0x5f, 0x3a, 0xc6, 0x9f, 0xa5, 0x43, 0xeb, 0x63, 0xcf, 0x65, 0x14, 0x82, 0x88, 0x12,
+	0x58, 0xf0, 0x55, 0x2b, 0x4a, 0x49, 0x13, 0x16, 0x89, 0x60, 0x5b, 0xd8, 0xb2, 0xcd, 0x35, 0xa6,
+	0x8c, 0x98, 0x51, 0x06, 0x3e, 0xc8, 0x94, 0x6d, 0x6b, 0xba, 0x4e, 0x8b, 0x0a, 0x7a, 0x8b, 0x70,
+	0x24, 0xd6, 0x2c, 0x2b, 0x55, 0x4a, 0x9f, 0x10, 0x13, 0xc2, 0x48, 0xc8, 0x0c, 0xd8, 0x0e, 0x56,
+	0x6c, 0xaf, 0x83, 0x57, 0xd7, 0x14, 0xaf, 0x01, 0x3d, 0x23, 0x2d, 0x25, 0x83, 0x2f, 0x02, 0x58,
+	0x4b, 0x7b, 0x38, 0xdc, 0xf0, 0x50, 0xb9, 0x1d, 0x69, 0x8d, 0xd7, 0x44, 0x1b, 0x06, 0xaf, 0x4e,
+	0xd0, 0x01, 0xb1, 0xaa, 0xd7, 0x57, 0xcc, 0xec, 0x6f, 0x0f, 0xda, 0xde, 0x2e, 0xde, 0xb1, 0xe2,
+	0xf8, 0x0a, 0x15, 0xc3, 0x5c, 0x86, 0x73, 0x28, 0x84, 0x96, 0x16, 0xea, 0x61, 0x2a, 0x8a, 0xd7,
+	0x80, 0x3e, 0x25, 0x96, 0x88, 0xf3, 0x71, 0xee, 0xa3, 0xae, 0xfd, 0xbf, 0x61, 0xcd, 0x71, 0x13,
+	0xd1, 0x47, 0x04, 0xf4, 0x25, 0xe9, 0xa6, 0x59, 0x5c, 0x78, 0x18, 0xfb, 0x4a, 0x09, 0x50, 0xcc,
+	0xd6, 0x72, 0x8a, 0xf2, 0x8d, 0x0a, 0xb7, 0xab, 0xfc, 0x5c, 0xa7, 0xf4, 0x15, 0x31, 0xcb, 0x5f,
+	0xaa, 0x58, 0x17, 0xcf, 0x74, 0x86, 0x0f, 0x37, 0x1c, 0x8f, 0x74, 0xb5, 0x9c, 0xb0, 0x52, 0xf2,
+	0x1a, 0xa0, 0x91, 0x6e, 0xe4, 0x7f, 0x1d, 0x07, 0x32, 0x0e, 0xb2, 0x34, 0x15, 0x31, 0xb0, 0xbd,
+	0xe2, 0x71, 0xb9, 0x8d, 0xec, 0x9b, 0x15, 0x49, 0x1f, 0x13, 0x5b, 0x65, 0x13, 0x15, 0xa4, 0x61,
+	0x02, 0xa1, 0x8c, 0x15, 0xdb, 0x2f, 0xc6, 0xe3, 0xeb, 0x24, 0x7d, 0x46, 0xf6, 0x51, 0x1c, 0xc2,
+	0x62, 0xac, 0xc4, 0x1c, 0x37, 0x45, 0xa2, 0xed, 0x07, 0x5a, 0xb8, 0x57, 0xf2, 0xa3, 0x9a, 0x3e,
+	0xb3, 0xae, 0x6f, 0x9c, 0xc6, 0xed, 0x8d, 0x63, 0x1c, 0x9d, 0x13, 0x7b, 0xed, 0x9f, 0x14, 0x0b,
+	0x33, 0x93, 0x0a, 0xf4, 0x0e, 0xe2, 0xc2, 0x14, 0x98, 0x1e, 0x92, 0x66, 0x22, 0x53, 0xd0, 0x4b,
+	0x64, 0x7b, 0x16, 0x5a, 0xd1, 0x39, 0xd7, 0x5f, 0xaf, 0xff, 0xf7, 0x67, 0xcf, 0xb8, 0xfd, 0xd5,
+	0x33, 0xbe, 0x63, 0xdc, 0x61, 0xdc, 0x63, 0xfc, 0xc0, 0xf8, 0xf6, 0xbb, 0xd7, 0xf8, 0xb4, 0x95,
+	0x0f, 0x27, 0x2d, 0xbd, 0xee, 0xa7, 0xff, 0x00, 0x70, 0x21, 0xef, 0xfa, 0x5e, 0x03, 0x00, 0x00,
 }
 
 func (this *Handler) Equal(that interface{}) bool {
@@ -264,6 +277,25 @@ func (this *Handler) Equal(that interface{}) bool {
 			return false
 		}
 	}
+	if this.MaxConcurrent != that1.MaxConcurrent {
+		return false
+	}
+	if len(this.Subscriptions) != len(that1.Subscriptions) {
+		return false
+	}
+	for i := range this.Subscriptions {
+		if this.Subscriptions[i] != that1.Subscriptions[i] {
+			return false
+		}
+	}
+	if len(this.EntitySelectors) != len(that1.EntitySelectors) {
+		return false
+	}
+	for i := range this.EntitySelectors {
+		if this.EntitySelectors[i] != that1.EntitySelectors[i] {
+			return false
+		}
+	}
 	if !bytes.Equal(this.XXX_unrecognized, that1.XXX_unrecognized) {
 		return false
 	}
@@ -313,6 +345,9 @@ type HandlerFace interface {
 	GetEnvVars() []string
 	GetRuntimeAssets() []string
 	GetSecrets() []*Secret
+	GetMaxConcurrent() uint32
+	GetSubscriptions() []string
+	GetEntitySelectors() []string
 }
 
 func (this *Handler) Proto() github_com_golang_protobuf_proto.Message {
@@ -367,6 +402,18 @@ func (this *Handler) GetSecrets() []*Secret {
 	return this.Secrets
 }
 
+func (this *Handler) GetMaxConcurrent() uint32 {
+	return this.MaxConcurrent
+}
+
+func (this *Handler) GetSubscriptions() []string {
+	return this.Subscriptions
+}
+
+func (this *Handler) GetEntitySelectors() []string {
+	return this.EntitySelectors
+}
+
 func NewHandlerFromFace(that HandlerFace) *Handler {
 	this := &Handler{}
 	this.ObjectMeta = that.GetObjectMeta()
@@ -380,6 +427,9 @@ func NewHandlerFromFace(that HandlerFace) *Handler {
 	this.EnvVars = that.GetEnvVars()
 	this.RuntimeAssets = that.GetRuntimeAssets()
 	this.Secrets = that.GetSecrets()
+	this.MaxConcurrent = that.GetMaxConcurrent()
+	this.Subscriptions = that.GetSubscriptions()
+	this.EntitySelectors = that.GetEntitySelectors()
 	return this
 }
 
@@ -407,6 +457,33 @@ func (m *Handler) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.EntitySelectors) > 0 {
+		for iNdEx := len(m.EntitySelectors) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.EntitySelectors[iNdEx])
+			copy(dAtA[i:], m.EntitySelectors[iNdEx])
+			i = encodeVarintHandler(dAtA, i, uint64(len(m.EntitySelectors[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x8a
+		}
+	}
+	if len(m.Subscriptions) > 0 {
+		for iNdEx := len(m.Subscriptions) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Subscriptions[iNdEx])
+			copy(dAtA[i:], m.Subscriptions[iNdEx])
+			i = encodeVarintHandler(dAtA, i, uint64(len(m.Subscriptions[iNdEx])))
+			i--
+			dAtA[i] = 0x1
+			i--
+			dAtA[i] = 0x82
+		}
+	}
+	if m.MaxConcurrent != 0 {
+		i = encodeVarintHandler(dAtA, i, uint64(m.MaxConcurrent))
+		i--
+		dAtA[i] = 0x78
+	}
 	if len(m.Secrets) > 0 {
 		for iNdEx := len(m.Secrets) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -596,8 +673,19 @@ func NewPopulatedHandler(r randyHandler, easy bool) *Handler {
 			this.Secrets[i] = NewPopulatedSecret(r, easy)
 		}
 	}
+	this.MaxConcurrent = uint32(r.Uint32())
+	v7 := r.Intn(10)
+	this.Subscriptions = make([]string, v7)
+	for i := 0; i < v7; i++ {
+		this.Subscriptions[i] = string(randStringHandler(r))
+	}
+	v8 := r.Intn(10)
+	this.EntitySelectors = make([]string, v8)
+	for i := 0; i < v8; i++ {
+		this.EntitySelectors[i] = string(randStringHandler(r))
+	}
 	if !easy && r.Intn(10) != 0 {
-		this.XXX_unrecognized = randUnrecognizedHandler(r, 15)
+		this.XXX_unrecognized = randUnrecognizedHandler(r, 18)
 	}
 	return this
 }
@@ -741,6 +829,21 @@ func (m *Handler) Size() (n int) {
 			n += 1 + l + sovHandler(uint64(l))
 		}
 	}
+	if m.MaxConcurrent != 0 {
+		n += 1 + sovHandler(uint64(m.MaxConcurrent))
+	}
+	if len(m.Subscriptions) > 0 {
+		for _, s := range m.Subscriptions {
+			l = len(s)
+			n += 2 + l + sovHandler(uint64(l))
+		}
+	}
+	if len(m.EntitySelectors) > 0 {
+		for _, s := range m.EntitySelectors {
+			l = len(s)
+			n += 2 + l + sovHandler(uint64(l))
+		}
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1147,6 +1250,89 @@ func (m *Handler) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxConcurrent", wireType)
+			}
+			m.MaxConcurrent = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxConcurrent |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Subscriptions", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Subscriptions = append(m.Subscriptions, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EntitySelectors", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowHandler
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthHandler
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthHandler
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.EntitySelectors = append(m.EntitySelectors, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipHandler(dAtA[iNdEx:])
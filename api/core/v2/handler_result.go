@@ -0,0 +1,47 @@
+package v2
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+// HandlerResultOutputMaxLength is the maximum length, in bytes, of the output
+// kept in handler results.
+const HandlerResultOutputMaxLength = 4096
+
+// NewHandlerResult returns the result of the execution of the handler for the
+// event, that started at start.
+func NewHandlerResult(handler string, event *Event, start time.Time, status int32, output string) *HandlerResult {
+	return &HandlerResult{
+		Handler:   handler,
+		EventID:   event.GetUUID().String(),
+		Status:    status,
+		Duration:  time.Since(start).Seconds(),
+		Output:    truncateOutput(output, HandlerResultOutputMaxLength),
+		Timestamp: start.Unix(),
+	}
+}
+
+// truncateOutput truncates output to at most max bytes, without splitting
+// multi-byte characters.
+func truncateOutput(output string, max int) string {
+	if len(output) <= max {
+		return output
+	}
+	for max > 0 && !utf8.RuneStart(output[max]) {
+		max--
+	}
+	return output[:max]
+}
+
+// FixtureHandlerResult returns a HandlerResult fixture for testing.
+func FixtureHandlerResult(handler string) *HandlerResult {
+	return &HandlerResult{
+		Handler:   handler,
+		EventID:   "2d9a8a3e-5f5c-4b8d-9a3e-5f5c4b8d9a3e",
+		Status:    0,
+		Duration:  0.1,
+		Output:    "ok",
+		Timestamp: time.Now().Unix(),
+	}
+}
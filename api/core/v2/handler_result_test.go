@@ -0,0 +1,29 @@
+package v2
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHandlerResult(t *testing.T) {
+	event := FixtureEvent("entity1", "check1")
+	start := time.Now().Add(-time.Second)
+
+	result := NewHandlerResult("slack", event, start, 1, strings.Repeat("a", HandlerResultOutputMaxLength+1))
+	assert.Equal(t, "slack", result.Handler)
+	assert.Equal(t, event.GetUUID().String(), result.EventID)
+	assert.Equal(t, int32(1), result.Status)
+	assert.True(t, result.Duration >= 1)
+	assert.Len(t, result.Output, HandlerResultOutputMaxLength)
+	assert.Equal(t, start.Unix(), result.Timestamp)
+}
+
+func TestTruncateOutput(t *testing.T) {
+	assert.Equal(t, "abc", truncateOutput("abc", 3))
+	assert.Equal(t, "ab", truncateOutput("abc", 2))
+	// Multi-byte characters are not split
+	assert.Equal(t, "a", truncateOutput("aé", 2))
+}
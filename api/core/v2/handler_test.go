@@ -74,6 +74,42 @@ func TestHandlerValidate(t *testing.T) {
 				Type: "grpc",
 			},
 		},
+		{
+			Handler: Handler{
+				ObjectMeta: ObjectMeta{
+					Name:      "foo",
+					Namespace: "default",
+				},
+				Type: "slack",
+			},
+		},
+		{
+			Handler: Handler{
+				ObjectMeta: ObjectMeta{
+					Name:      "foo",
+					Namespace: "default",
+				},
+				Type: "pagerduty",
+			},
+		},
+		{
+			Handler: Handler{
+				ObjectMeta: ObjectMeta{
+					Name:      "foo",
+					Namespace: "default",
+				},
+				Type: "email",
+			},
+		},
+		{
+			Handler: Handler{
+				ObjectMeta: ObjectMeta{
+					Name:      "foo",
+					Namespace: "default",
+				},
+				Type: "webhook",
+			},
+		},
 		{
 			Handler: Handler{
 				ObjectMeta: ObjectMeta{
@@ -240,3 +276,75 @@ func TestSortHandlersByName(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlerValidateFieldError(t *testing.T) {
+	handler := FixtureHandler("foo")
+	handler.Type = "magic"
+
+	err := handler.Validate()
+	fieldErr, ok := err.(*FieldError)
+	require.True(t, ok)
+	assert.Equal(t, "spec.type", fieldErr.Field)
+	assert.Equal(t, HandlerTypes, fieldErr.Allowed)
+}
+
+func TestHandlerValidateEntitySelectors(t *testing.T) {
+	handler := FixtureHandler("foo")
+	handler.EntitySelectors = []string{"team==payments", "region"}
+
+	err := handler.Validate()
+	fieldErr, ok := err.(*FieldError)
+	require.True(t, ok)
+	assert.Equal(t, "spec.entity_selectors[1]", fieldErr.Field)
+}
+
+func TestHandlerMatchesEntity(t *testing.T) {
+	entity := FixtureEntity("entity1")
+	entity.Labels = map[string]string{"team": "payments", "region": "us"}
+
+	testCases := []struct {
+		name            string
+		subscriptions   []string
+		entitySelectors []string
+		expected        bool
+	}{
+		{
+			name:     "no selection",
+			expected: true,
+		},
+		{
+			name:          "one matching subscription",
+			subscriptions: []string{"windows", "linux"},
+			expected:      true,
+		},
+		{
+			name:          "no matching subscription",
+			subscriptions: []string{"windows"},
+			expected:      false,
+		},
+		{
+			name:            "all requirements met",
+			entitySelectors: []string{"team==payments", "region!=eu"},
+			expected:        true,
+		},
+		{
+			name:            "one requirement not met",
+			entitySelectors: []string{"team==payments,region==eu"},
+			expected:        false,
+		},
+		{
+			name:            "missing label is empty",
+			entitySelectors: []string{"env!=production"},
+			expected:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := FixtureHandler("handler1")
+			handler.Subscriptions = tc.subscriptions
+			handler.EntitySelectors = tc.entitySelectors
+			assert.Equal(t, tc.expected, handler.MatchesEntity(entity))
+		})
+	}
+}
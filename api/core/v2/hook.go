@@ -1,7 +1,6 @@
 package v2
 
 import (
-	"errors"
 	fmt "fmt"
 	"net/url"
 	"path"
@@ -37,7 +36,7 @@ func (h *Hook) Validate() error {
 	}
 
 	if h.Status < 0 {
-		return errors.New("hook status must be greater than or equal to 0")
+		return NewFieldError("status", "hook status must be greater than or equal to 0")
 	}
 
 	return nil
@@ -59,19 +58,19 @@ func (c *HookConfig) URIPath() string {
 // Validate returns an error if the hook does not pass validation tests.
 func (c *HookConfig) Validate() error {
 	if err := ValidateName(c.Name); err != nil {
-		return errors.New("hook name " + err.Error())
+		return NewFieldError("metadata.name", "hook name "+err.Error())
 	}
 
 	if c.Command == "" {
-		return errors.New("command cannot be empty")
+		return NewFieldError("spec.command", "command cannot be empty")
 	}
 
 	if c.Timeout <= 0 {
-		return errors.New("hook timeout must be greater than 0")
+		return NewFieldError("spec.timeout", "hook timeout must be greater than 0")
 	}
 
 	if c.Namespace == "" {
-		return errors.New("namespace must be set")
+		return NewFieldError("metadata.namespace", "namespace must be set")
 	}
 
 	return nil
@@ -80,15 +79,15 @@ func (c *HookConfig) Validate() error {
 // Validate returns an error if the check hook does not pass validation tests.
 func (h *HookList) Validate() error {
 	if h.Type == "" {
-		return errors.New("type cannot be empty")
+		return NewFieldError("type", "type cannot be empty")
 	}
 
 	if h.Hooks == nil || len(h.Hooks) == 0 {
-		return errors.New("hooks cannot be empty")
+		return NewFieldError("hooks", "hooks cannot be empty")
 	}
 
 	if !(CheckHookRegex.MatchString(h.Type) || isSeverity(h.Type)) {
-		return errors.New(
+		return NewFieldError("type",
 			"valid check hook types are \"0\"-\"255\", \"ok\", \"warning\", \"critical\", \"unknown\", and \"non-zero\"",
 		)
 	}
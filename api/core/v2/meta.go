@@ -4,6 +4,37 @@ const (
 	// ManagedByLabel is used to identify which client was used to create/update a
 	// resource
 	ManagedByLabel = "sensu.io/managed_by"
+
+	// EphemeralAnnotation is used to identify entities whose identity does not
+	// outlive their agent, e.g. agents running as kubernetes sidecars
+	EphemeralAnnotation = "sensu.io/ephemeral"
+
+	// DiscardAfterAnnotation is used on checks and entities to specify the
+	// duration, e.g. "6h", after which their events are deleted if they are
+	// not updated
+	DiscardAfterAnnotation = "sensu.io/discard-after"
+
+	// ClockOffsetAnnotation is set by the backend on agent entities whose
+	// clock is skewed, to the offset of their clock, e.g. "-2m30s"
+	ClockOffsetAnnotation = "sensu.io/clock-offset"
+
+	// KeepaliveTimeoutSetAnnotation is set by the agents on the check of their
+	// keepalives to "true" if their keepalive timeout was configured, or to
+	// "false" if the default timeout of the cluster applies
+	KeepaliveTimeoutSetAnnotation = "sensu.io/keepalive-timeout-set"
+
+	// RunbookURLAnnotation is used on checks to specify the URL of the
+	// runbook of their events, rendered by sensuctl and the built-in handlers
+	RunbookURLAnnotation = "sensu.io/runbook-url"
+
+	// LinkAnnotationPrefix prefixes the annotations of checks specifying
+	// links rendered with their events, e.g. "sensu.io/link.dashboard"
+	LinkAnnotationPrefix = "sensu.io/link."
+
+	// ReplayedAnnotation is set to "true" by the backend on the checks of the
+	// check requests it replays to agents reconnecting after missing them, so
+	// that the events of replayed requests are told apart
+	ReplayedAnnotation = "sensu.io/replayed"
 )
 
 // NewObjectMeta makes a new ObjectMeta, with Labels and Annotations assigned
@@ -0,0 +1,143 @@
+package v2
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sensu/sensu-go/util/nagios"
+)
+
+// Statuses of a breached metric threshold
+const (
+	MetricThresholdWarning  = nagios.StatusWarning
+	MetricThresholdCritical = nagios.StatusCritical
+)
+
+// metricThresholdRule matches the rules of the output_metric_thresholds
+// attribute of checks, e.g. `warning metric "cpu.idle" < 10 for 3 points` or
+// `critical metric "load" @2:5`
+var metricThresholdRule = regexp.MustCompile(
+	`^\s*(?:(warning|critical)\s+)?metric\s+"([^"]+)"\s*(?:([<>])\s*(\S+)|(\S+))(?:\s+for\s+(\d+)\s+points?)?\s*$`,
+)
+
+// MetricThreshold is a threshold evaluated against the metric points
+// extracted from the output of a check.
+type MetricThreshold struct {
+	// Status is the status of the check event when the threshold is breached,
+	// MetricThresholdCritical unless the rule starts with warning.
+	Status uint32
+
+	// Metric is the name of the metric points evaluated.
+	Metric string
+
+	// Range is the range of the values of the points, in the Nagios format.
+	// The points raising an alert for the range breach the threshold.
+	Range *nagios.Range
+
+	// Points is the number of consecutive points, across the check
+	// executions, which must raise an alert for the threshold to be breached.
+	Points int
+}
+
+// ParseMetricThreshold parses a metric threshold rule in the
+// [warning|critical] metric "<name>" <condition> [for <n> points] format. The
+// condition is either a range in the Nagios format, e.g. 10: or @10:20, or a
+// shorthand for one, < 10 for 10: and > 90 for ~:90.
+func ParseMetricThreshold(rule string) (*MetricThreshold, error) {
+	matches := metricThresholdRule.FindStringSubmatch(rule)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid metric threshold %q", rule)
+	}
+
+	spec := matches[5]
+	if operator, value := matches[3], matches[4]; operator != "" {
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return nil, fmt.Errorf("invalid metric threshold %q: invalid value %q", rule, value)
+		}
+		if operator == "<" {
+			spec = value + ":"
+		} else {
+			spec = "~:" + value
+		}
+	}
+	r, err := nagios.ParseRange(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid metric threshold %q: %s", rule, err)
+	}
+
+	t := &MetricThreshold{
+		Status: MetricThresholdCritical,
+		Metric: matches[2],
+		Range:  r,
+		Points: 1,
+	}
+	if matches[1] == "warning" {
+		t.Status = MetricThresholdWarning
+	}
+	if matches[6] != "" {
+		if t.Points, err = strconv.Atoi(matches[6]); err != nil || t.Points < 1 {
+			return nil, fmt.Errorf("invalid metric threshold %q: points must be greater than 0", rule)
+		}
+	}
+	return t, nil
+}
+
+// Breaches returns the number of consecutive points of the metric raising an
+// alert, counting from the given number of consecutive points of the previous
+// check executions. The count is unchanged if there are no points of the
+// metric, and reset by the points which don't raise an alert.
+func (t *MetricThreshold) Breaches(points []*MetricPoint, count int) int {
+	matching := make([]*MetricPoint, 0, len(points))
+	for _, point := range points {
+		if point != nil && point.Name == t.Metric {
+			matching = append(matching, point)
+		}
+	}
+	sort.SliceStable(matching, func(i, j int) bool {
+		return matching[i].Timestamp < matching[j].Timestamp
+	})
+	for _, point := range matching {
+		if t.Range.Alert(point.Value) {
+			count++
+		} else {
+			count = 0
+		}
+	}
+	return count
+}
+
+// Breached returns true if the given number of consecutive points raising an
+// alert, as returned by Breaches, breaches the threshold.
+func (t *MetricThreshold) Breached(count int) bool {
+	return count >= t.Points
+}
+
+// String returns the metric threshold rule, with its condition in the Nagios
+// range format.
+func (t *MetricThreshold) String() string {
+	var b strings.Builder
+	if t.Status == MetricThresholdWarning {
+		b.WriteString("warning ")
+	} else {
+		b.WriteString("critical ")
+	}
+	fmt.Fprintf(&b, "metric %q %s", t.Metric, t.Range)
+	if t.Points > 1 {
+		fmt.Fprintf(&b, " for %d points", t.Points)
+	}
+	return b.String()
+}
+
+// ValidateMetricThresholds returns an error if one of the metric threshold
+// rules is invalid.
+func ValidateMetricThresholds(rules []string) error {
+	for _, rule := range rules {
+		if _, err := ParseMetricThreshold(rule); err != nil {
+			return err
+		}
+	}
+	return nil
+}
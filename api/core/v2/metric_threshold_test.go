@@ -0,0 +1,101 @@
+package v2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/sensu/sensu-go/util/nagios"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMetricThreshold(t *testing.T) {
+	tests := []struct {
+		rule    string
+		want    *MetricThreshold
+		wantErr bool
+	}{
+		{
+			rule: `metric "cpu.idle" < 10 for 3 points`,
+			want: &MetricThreshold{Status: MetricThresholdCritical, Metric: "cpu.idle", Range: &nagios.Range{Start: 10, End: math.Inf(1)}, Points: 3},
+		},
+		{
+			rule: `warning metric "disk.used_percent" > 85.5`,
+			want: &MetricThreshold{Status: MetricThresholdWarning, Metric: "disk.used_percent", Range: &nagios.Range{Start: math.Inf(-1), End: 85.5}, Points: 1},
+		},
+		{
+			rule: ` critical metric "load" @2:5 for 1 point `,
+			want: &MetricThreshold{Status: MetricThresholdCritical, Metric: "load", Range: &nagios.Range{Start: 2, End: 5, Inside: true}, Points: 1},
+		},
+		{rule: `metric cpu.idle < 10`, wantErr: true},
+		{rule: `unknown metric "cpu.idle" < 10`, wantErr: true},
+		{rule: `metric "cpu.idle" =< 10`, wantErr: true},
+		{rule: `metric "cpu.idle" < ten`, wantErr: true},
+		{rule: `metric "cpu.idle" 20:10`, wantErr: true},
+		{rule: `metric "cpu.idle" < 10 for 0 points`, wantErr: true},
+		{rule: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			got, err := ParseMetricThreshold(tt.rule)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMetricThresholdBreaches(t *testing.T) {
+	points := []*MetricPoint{
+		{Name: "cpu.idle", Value: 5, Timestamp: 3},
+		{Name: "cpu.idle", Value: 50, Timestamp: 1},
+		{Name: "cpu.user", Value: 90, Timestamp: 2},
+		{Name: "cpu.idle", Value: 8, Timestamp: 2},
+	}
+	tests := []struct {
+		rule  string
+		count int
+		want  int
+	}{
+		{rule: `metric "cpu.idle" < 10`, want: 2},
+		{rule: `metric "cpu.idle" < 10`, count: 4, want: 2},
+		{rule: `metric "cpu.idle" < 60`, count: 4, want: 7},
+		{rule: `metric "cpu.idle" 6:`, want: 1},
+		{rule: `metric "cpu.user" > 80`, count: 1, want: 2},
+		{rule: `metric "cpu.system" > 0`, count: 3, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			threshold, err := ParseMetricThreshold(tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, threshold.Breaches(points, tt.count))
+		})
+	}
+}
+
+func TestMetricThresholdBreached(t *testing.T) {
+	threshold, err := ParseMetricThreshold(`metric "cpu.idle" < 10 for 3 points`)
+	require.NoError(t, err)
+	assert.False(t, threshold.Breached(2))
+	assert.True(t, threshold.Breached(3))
+	assert.True(t, threshold.Breached(4))
+}
+
+func TestMetricThresholdString(t *testing.T) {
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{`critical metric "cpu.idle" < 10 for 3 points`, `critical metric "cpu.idle" 10: for 3 points`},
+		{`warning metric "load" > 1.5`, `warning metric "load" ~:1.5`},
+		{`metric "load" @2:5`, `critical metric "load" @2:5`},
+	}
+	for _, tt := range tests {
+		threshold, err := ParseMetricThreshold(tt.rule)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, threshold.String())
+	}
+}
@@ -1,14 +1,78 @@
 package v2
 
 import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"time"
 )
 
 // Validate returns an error if metrics does not pass validation tests.
 func (m *Metrics) Validate() error {
+	for i, point := range m.Points {
+		if err := point.Validate(); err != nil {
+			return nestedFieldError(fmt.Sprintf("points[%d]", i), fmt.Sprintf("point %d is invalid: ", i), err)
+		}
+	}
+	return nil
+}
+
+// Validate returns an error if the metric point does not pass validation
+// tests.
+func (p *MetricPoint) Validate() error {
+	if p == nil {
+		return errors.New("metric point is nil")
+	}
+	if p.Name == "" {
+		return NewFieldError("name", "name must not be empty")
+	}
+	if math.IsNaN(p.Value) || math.IsInf(p.Value, 0) {
+		return NewFieldError("value", fmt.Sprintf("value of %q must be a finite number", p.Name))
+	}
+	if p.Timestamp < 0 {
+		return NewFieldError("timestamp", fmt.Sprintf("timestamp of %q cannot be negative", p.Name))
+	}
+	for i, tag := range p.Tags {
+		if tag == nil || tag.Name == "" {
+			return NewFieldError(fmt.Sprintf("tags[%d]", i), fmt.Sprintf("tags of %q must have a name", p.Name))
+		}
+	}
 	return nil
 }
 
+// ParseMetricTag parses a metric tag in the name=value format. The value may
+// be empty, but not the name.
+func ParseMetricTag(tag string) (*MetricTag, error) {
+	parts := strings.SplitN(tag, "=", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+		return nil, fmt.Errorf("invalid metric tag %q, metric tags must be of the form name=value", tag)
+	}
+	return &MetricTag{Name: strings.TrimSpace(parts[0]), Value: parts[1]}, nil
+}
+
+// ValidateMetricTags ensures that all the metric tags are well-formed.
+func ValidateMetricTags(tags []string) error {
+	for _, tag := range tags {
+		if _, err := ParseMetricTag(tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetTag sets the value of the tag of the metric point with the given name,
+// adding the tag if the point doesn't have it.
+func (p *MetricPoint) SetTag(name, value string) {
+	for _, tag := range p.Tags {
+		if tag != nil && tag.Name == name {
+			tag.Value = value
+			return
+		}
+	}
+	p.Tags = append(p.Tags, &MetricTag{Name: name, Value: value})
+}
+
 // FixtureMetrics returns a testing fixture for a Metrics object.
 func FixtureMetrics() *Metrics {
 	return &Metrics{
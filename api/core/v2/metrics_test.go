@@ -0,0 +1,73 @@
+package v2
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*MetricPoint)
+		wantErr bool
+	}{
+		{"valid", func(p *MetricPoint) {}, false},
+		{"no name", func(p *MetricPoint) { p.Name = "" }, true},
+		{"NaN value", func(p *MetricPoint) { p.Value = math.NaN() }, true},
+		{"infinite value", func(p *MetricPoint) { p.Value = math.Inf(-1) }, true},
+		{"negative timestamp", func(p *MetricPoint) { p.Timestamp = -1 }, true},
+		{"nil tag", func(p *MetricPoint) { p.Tags = append(p.Tags, nil) }, true},
+		{"unnamed tag", func(p *MetricPoint) { p.Tags[0].Name = "" }, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metrics := FixtureMetrics()
+			tt.mutate(metrics.Points[0])
+			err := metrics.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	metrics := FixtureMetrics()
+	metrics.Points = append(metrics.Points, nil)
+	assert.Error(t, metrics.Validate())
+}
+
+func TestParseMetricTag(t *testing.T) {
+	tag, err := ParseMetricTag("region=us-west-2")
+	assert.NoError(t, err)
+	assert.Equal(t, &MetricTag{Name: "region", Value: "us-west-2"}, tag)
+
+	tag, err = ParseMetricTag("query=a=b")
+	assert.NoError(t, err)
+	assert.Equal(t, &MetricTag{Name: "query", Value: "a=b"}, tag)
+
+	tag, err = ParseMetricTag("empty=")
+	assert.NoError(t, err)
+	assert.Equal(t, &MetricTag{Name: "empty"}, tag)
+
+	_, err = ParseMetricTag("region")
+	assert.Error(t, err)
+
+	_, err = ParseMetricTag("=us-west-2")
+	assert.Error(t, err)
+
+	assert.NoError(t, ValidateMetricTags([]string{"region=us-west-2", "check={{ .check.name }}"}))
+	assert.Error(t, ValidateMetricTags([]string{"region=us-west-2", "check"}))
+}
+
+func TestMetricPointSetTag(t *testing.T) {
+	point := FixtureMetricPoint()
+	point.SetTag("foo", "baz")
+	point.SetTag("region", "us-west-2")
+	assert.Equal(t, []*MetricTag{
+		{Name: "foo", Value: "baz"},
+		{Name: "region", Value: "us-west-2"},
+	}, point.Tags)
+}
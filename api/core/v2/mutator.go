@@ -1,7 +1,6 @@
 package v2
 
 import (
-	"errors"
 	"fmt"
 	"net/url"
 	"path"
@@ -30,14 +29,14 @@ func (m *Mutator) URIPath() string {
 // Validate returns an error if the mutator does not pass validation tests.
 func (m *Mutator) Validate() error {
 	if err := ValidateName(m.Name); err != nil {
-		return errors.New("mutator name " + err.Error())
+		return NewFieldError("metadata.name", "mutator name "+err.Error())
 	}
 	if m.Command == "" {
-		return errors.New("mutator command must be set")
+		return NewFieldError("spec.command", "mutator command must be set")
 	}
 
 	if m.Namespace == "" {
-		return errors.New("namespace must be set")
+		return NewFieldError("metadata.namespace", "namespace must be set")
 	}
 
 	return nil
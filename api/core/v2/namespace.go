@@ -28,7 +28,13 @@ func (n *Namespace) URIPath() string {
 // Validate returns an error if the namespace does not pass validation tests
 func (n *Namespace) Validate() error {
 	if err := ValidateName(n.Name); err != nil {
-		return fmt.Errorf("namespace name %s", err)
+		return NewFieldError("spec.name", fmt.Sprintf("namespace name %s", err))
+	}
+
+	for key := range n.Labels {
+		if key == "" {
+			return NewFieldError("spec.labels", "namespace labels must have a name")
+		}
 	}
 
 	return nil
@@ -50,7 +56,8 @@ func (n *Namespace) GetObjectMeta() ObjectMeta {
 func NamespaceFields(r Resource) map[string]string {
 	resource := r.(*Namespace)
 	return map[string]string{
-		"namespace.name": resource.Name,
+		"namespace.name":  resource.Name,
+		"namespace.owner": resource.Owner,
 	}
 }
 
@@ -0,0 +1,24 @@
+package v2
+
+// PasswordResetToken is a one-time token, issued by an administrator, with
+// which a user can set a new password without the administrator knowing it.
+type PasswordResetToken struct {
+	// Username is the name of the user whose password can be reset.
+	Username string `json:"username"`
+
+	// Token is the token to exchange for a new password.
+	Token string `json:"token"`
+
+	// ExpiresAt is the time after which the token can't be used, in seconds
+	// since the Unix epoch.
+	ExpiresAt int64 `json:"expires_at"`
+}
+
+// PasswordReset is the exchange of a password reset token for a new password.
+type PasswordReset struct {
+	// Token is the password reset token.
+	Token string `json:"token"`
+
+	// Password is the new password of the user.
+	Password string `json:"password"`
+}
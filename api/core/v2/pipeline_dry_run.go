@@ -0,0 +1,53 @@
+package v2
+
+// PipelineDryRun describes how an event would go through the pipeline, without
+// executing any handler.
+type PipelineDryRun struct {
+	// Handlers are the handlers the event would be sent to, after the
+	// expansion of handler sets.
+	Handlers []*PipelineDryRunHandler `json:"handlers"`
+
+	// Muted indicates if the namespace of the event is muted, in which case
+	// none of the handlers would run.
+	Muted bool `json:"muted,omitempty"`
+}
+
+// PipelineDryRunHandler describes how an event would be processed by a
+// handler.
+type PipelineDryRunHandler struct {
+	// Handler is the name of the handler.
+	Handler string `json:"handler"`
+
+	// Type is the type of the handler.
+	Type string `json:"type"`
+
+	// Filters are the results of the filters of the handler.
+	Filters []*PipelineDryRunFilter `json:"filters"`
+
+	// Mutator is the name of the mutator of the handler, if any.
+	Mutator string `json:"mutator,omitempty"`
+
+	// MutatorOutput is the event data the handler would receive, i.e. the
+	// output of the mutator or the JSON encoding of the event. It is empty if
+	// the event is filtered.
+	MutatorOutput string `json:"mutator_output,omitempty"`
+
+	// Error is the error that occurred while filtering or mutating the event.
+	Error string `json:"error,omitempty"`
+
+	// WouldRun indicates if the handler would be executed.
+	WouldRun bool `json:"would_run"`
+}
+
+// PipelineDryRunFilter is the result of a filter of a handler.
+type PipelineDryRunFilter struct {
+	// Name is the name of the filter.
+	Name string `json:"name"`
+
+	// Passed indicates if the event passed the filter, i.e. if it was not
+	// filtered by it.
+	Passed bool `json:"passed"`
+
+	// Error is the error that occurred while evaluating the filter.
+	Error string `json:"error,omitempty"`
+}
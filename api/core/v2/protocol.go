@@ -17,3 +17,12 @@ type BackendHandshake struct{}
 // PaginationContinueHeader is the name of the header used by the API to return
 // a potential continue token when paginating.
 const PaginationContinueHeader = "Sensu-Continue"
+
+// IdempotencyKeyHeader is the name of the header identifying a create request
+// across its retries, so that the API replays the response of the first
+// request instead of creating the resource twice.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayHeader is the name of the header added to the responses
+// replayed by the API for a repeated idempotency key.
+const IdempotentReplayHeader = "Idempotent-Replayed"
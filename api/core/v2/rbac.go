@@ -1,7 +1,6 @@
 package v2
 
 import (
-	"errors"
 	"fmt"
 	"net/url"
 	"path"
@@ -43,6 +42,8 @@ const (
 var CommonCoreResources = []string{
 	"assets",
 	"checks",
+	"checktemplates",
+	"compositechecks",
 	"entities",
 	"extensions",
 	"events",
@@ -51,6 +52,7 @@ var CommonCoreResources = []string{
 	"hooks",
 	"mutators",
 	"silenced",
+	"subscriptions",
 }
 
 var allowedVerbs = []string{
@@ -137,15 +139,15 @@ func (r *ClusterRole) URIPath() string {
 // Validate a ClusterRole
 func (r *ClusterRole) Validate() error {
 	if err := ValidateSubscriptionName(r.Name); err != nil {
-		return errors.New("the ClusterRole name " + err.Error())
+		return NewFieldError("metadata.name", "the ClusterRole name "+err.Error())
 	}
 
 	if len(r.Rules) == 0 {
-		return errors.New("a ClusterRole must have at least one rule")
+		return NewFieldError("spec.rules", "a ClusterRole must have at least one rule")
 	}
 
 	if r.Namespace != "" {
-		return errors.New("ClusterRole cannot have a namespace")
+		return NewFieldError("metadata.namespace", "ClusterRole cannot have a namespace")
 	}
 
 	for i := range r.Rules {
@@ -155,7 +157,7 @@ func (r *ClusterRole) Validate() error {
 
 		// Validate the verbs
 		if err := validateVerbs(r.Rules[i].Verbs); err != nil {
-			return err
+			return NewFieldError(fmt.Sprintf("spec.rules[%d].verbs", i), err.Error())
 		}
 	}
 
@@ -175,19 +177,19 @@ func (b *ClusterRoleBinding) URIPath() string {
 // Validate a ClusterRoleBinding
 func (b *ClusterRoleBinding) Validate() error {
 	if err := ValidateSubscriptionName(b.Name); err != nil {
-		return errors.New("the ClusterRoleBinding name " + err.Error())
+		return NewFieldError("metadata.name", "the ClusterRoleBinding name "+err.Error())
 	}
 
 	if b.RoleRef.Name == "" || b.RoleRef.Type == "" {
-		return errors.New("a ClusterRoleBinding needs a roleRef")
+		return NewFieldError("spec.role_ref", "a ClusterRoleBinding needs a roleRef")
 	}
 
 	if len(b.Subjects) == 0 {
-		return errors.New("a ClusterRoleBinding must have at least one subject")
+		return NewFieldError("spec.subjects", "a ClusterRoleBinding must have at least one subject")
 	}
 
 	if b.Namespace != "" {
-		return errors.New("ClusterRoleBinding cannot have a namespace")
+		return NewFieldError("metadata.namespace", "ClusterRoleBinding cannot have a namespace")
 	}
 
 	return nil
@@ -210,15 +212,15 @@ func (r *Role) URIPath() string {
 // Validate a Role
 func (r *Role) Validate() error {
 	if err := ValidateSubscriptionName(r.Name); err != nil {
-		return errors.New("the Role name " + err.Error())
+		return NewFieldError("metadata.name", "the Role name "+err.Error())
 	}
 
 	if r.Namespace == "" {
-		return errors.New("the Role namespace must be set")
+		return NewFieldError("metadata.namespace", "the Role namespace must be set")
 	}
 
 	if len(r.Rules) == 0 {
-		return errors.New("a Role must have at least one rule")
+		return NewFieldError("spec.rules", "a Role must have at least one rule")
 	}
 
 	for i := range r.Rules {
@@ -228,7 +230,7 @@ func (r *Role) Validate() error {
 
 		// Validate the verbs
 		if err := validateVerbs(r.Rules[i].Verbs); err != nil {
-			return err
+			return NewFieldError(fmt.Sprintf("spec.rules[%d].verbs", i), err.Error())
 		}
 	}
 
@@ -251,19 +253,19 @@ func (b *RoleBinding) URIPath() string {
 // Validate a RoleBinding
 func (b *RoleBinding) Validate() error {
 	if err := ValidateSubscriptionName(b.Name); err != nil {
-		return errors.New("the RoleBinding name " + err.Error())
+		return NewFieldError("metadata.name", "the RoleBinding name "+err.Error())
 	}
 
 	if b.Namespace == "" {
-		return errors.New("the RoleBinding namespace must be set")
+		return NewFieldError("metadata.namespace", "the RoleBinding namespace must be set")
 	}
 
 	if b.RoleRef.Name == "" || b.RoleRef.Type == "" {
-		return errors.New("a RoleBinding needs a roleRef")
+		return NewFieldError("spec.role_ref", "a RoleBinding needs a roleRef")
 	}
 
 	if len(b.Subjects) == 0 {
-		return errors.New("a RoleBinding must have at least one subject")
+		return NewFieldError("spec.subjects", "a RoleBinding must have at least one subject")
 	}
 
 	return nil
@@ -0,0 +1,85 @@
+package v2
+
+// ResourceReference is a reference, by name, to a resource of the namespace of
+// the referring resource.
+type ResourceReference struct {
+	// Type is the RBAC name of the type of the referenced resource, e.g.
+	// "handlers".
+	Type string `json:"type"`
+
+	// Name is the name of the referenced resource.
+	Name string `json:"name"`
+}
+
+// String returns the type and name of the referenced resource, e.g.
+// handlers/slack.
+func (r ResourceReference) String() string {
+	return r.Type + "/" + r.Name
+}
+
+// builtinFilters and builtinMutators are implemented by the backend, and
+// can be referenced by handlers without being resources.
+var (
+	builtinFilters  = map[string]bool{"is_incident": true, "not_silenced": true, "has_metrics": true, "deduplicate": true}
+	builtinMutators = map[string]bool{"json": true, "only_check_output": true}
+)
+
+// References returns the references of the resource to the check templates,
+// handlers, filters, mutators, hooks and assets of its namespace. The built-in
+// filters and mutators are omitted.
+func References(resource Resource) []ResourceReference {
+	refs := []ResourceReference{}
+	add := func(typ string, names ...string) {
+		for _, name := range names {
+			if name != "" {
+				refs = append(refs, ResourceReference{Type: typ, Name: name})
+			}
+		}
+	}
+
+	switch r := resource.(type) {
+	case *CheckConfig:
+		add("handlers", r.Handlers...)
+		add("handlers", r.OutputMetricHandlers...)
+		for _, hooks := range r.CheckHooks {
+			add("hooks", hooks.Hooks...)
+		}
+		add("assets", r.RuntimeAssets...)
+		add("checktemplates", r.Template)
+	case *CheckTemplate:
+		add("handlers", r.Handlers...)
+		add("handlers", r.OutputMetricHandlers...)
+		add("assets", r.RuntimeAssets...)
+	case *CompositeCheck:
+		add("handlers", r.Handlers...)
+	case *Handler:
+		add("handlers", r.Handlers...)
+		for _, filter := range r.Filters {
+			if !builtinFilters[filter] {
+				add("filters", filter)
+			}
+		}
+		if !builtinMutators[r.Mutator] {
+			add("mutators", r.Mutator)
+		}
+		add("assets", r.RuntimeAssets...)
+	case *EventFilter:
+		add("assets", r.RuntimeAssets...)
+	case *Mutator:
+		add("assets", r.RuntimeAssets...)
+	case *HookConfig:
+		add("assets", r.RuntimeAssets...)
+	}
+	return refs
+}
+
+// IsReferenced returns true if the resource references the resource of the
+// given type and name.
+func IsReferenced(resource Resource, typ, name string) bool {
+	for _, ref := range References(resource) {
+		if ref.Type == typ && ref.Name == name {
+			return true
+		}
+	}
+	return false
+}
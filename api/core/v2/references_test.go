@@ -0,0 +1,31 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferences(t *testing.T) {
+	check := FixtureCheckConfig("check-cpu")
+	check.Handlers = []string{"slack"}
+	check.RuntimeAssets = []string{"cpu-plugin"}
+	check.CheckHooks = []HookList{{Type: "critical", Hooks: []string{"ps"}}}
+	check.Template = "linux"
+	assert.Equal(t, []ResourceReference{
+		{Type: "handlers", Name: "slack"},
+		{Type: "hooks", Name: "ps"},
+		{Type: "assets", Name: "cpu-plugin"},
+		{Type: "checktemplates", Name: "linux"},
+	}, References(check))
+
+	handler := FixtureHandler("slack")
+	handler.Filters = []string{"is_incident", "business-hours"}
+	handler.Mutator = "only_check_output"
+	handler.RuntimeAssets = nil
+	assert.Equal(t, []ResourceReference{{Type: "filters", Name: "business-hours"}}, References(handler))
+	assert.True(t, IsReferenced(handler, "filters", "business-hours"))
+	assert.False(t, IsReferenced(handler, "filters", "is_incident"))
+
+	assert.Empty(t, References(FixtureEntity("web-1")))
+}
@@ -0,0 +1,56 @@
+package v2
+
+const (
+	// ReplicatorResource is the name of the replicator status resource
+	ReplicatorResource = "replicator"
+)
+
+// ReplicatorStatus is the status of the replication of the resources of the
+// cluster into its secondary clusters.
+type ReplicatorStatus struct {
+	// Resources are the replicated resource types.
+	Resources []string `json:"resources"`
+
+	// ConflictPolicy is the policy applied to the resources modified in the
+	// secondary clusters.
+	ConflictPolicy string `json:"conflict_policy"`
+
+	// Leader is true if the backend is the one replicating the resources. A
+	// single backend of the cluster replicates them at a time, so the other
+	// backends report no progress.
+	Leader bool `json:"leader"`
+
+	// Clusters are the statuses of the secondary clusters.
+	Clusters []ReplicatedClusterStatus `json:"clusters"`
+}
+
+// ReplicatedClusterStatus is the status of the replication into a secondary
+// cluster.
+type ReplicatedClusterStatus struct {
+	// Name is the name of the secondary cluster.
+	Name string `json:"name"`
+
+	// Endpoints are the etcd client URLs of the secondary cluster.
+	Endpoints []string `json:"endpoints"`
+
+	// Revision is the etcd revision of the cluster up to which the resources
+	// were replicated.
+	Revision int64 `json:"revision"`
+
+	// Replicated is the number of resources created or updated.
+	Replicated int64 `json:"replicated"`
+
+	// Deleted is the number of resources deleted.
+	Deleted int64 `json:"deleted"`
+
+	// Conflicts is the number of resources that were not replicated because
+	// they were modified in the secondary cluster.
+	Conflicts int64 `json:"conflicts"`
+
+	// LastReplicated is the time at which a resource was last replicated, in
+	// seconds since the Unix epoch.
+	LastReplicated int64 `json:"last_replicated,omitempty"`
+
+	// LastError is the last replication error.
+	LastError string `json:"last_error,omitempty"`
+}
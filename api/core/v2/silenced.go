@@ -39,16 +39,16 @@ func (s *Silenced) URIPath() string {
 // provided.
 func (s *Silenced) Validate() error {
 	if (s.Subscription == "" && s.Check == "") || (s.Subscription == "*" && s.Check == "*") {
-		return errors.New("must provide check or subscription")
+		return NewFieldError("spec.check", "must provide check or subscription")
 	}
 	if s.Subscription != "" && s.Subscription != "*" {
 		if err := ValidateSubscriptionName(s.Subscription); err != nil {
-			return fmt.Errorf("Subscription %s", err)
+			return NewFieldError("spec.subscription", fmt.Sprintf("Subscription %s", err))
 		}
 	}
 	if s.Check != "" && s.Check != "*" {
 		if err := ValidateName(s.Check); err != nil {
-			return fmt.Errorf("Check %s", err)
+			return NewFieldError("spec.check", fmt.Sprintf("Check %s", err))
 		}
 	}
 	return nil
@@ -0,0 +1,76 @@
+package v2
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// SilencedFilter selects silenced entries by creator and expiration. The zero
+// value selects all the entries.
+type SilencedFilter struct {
+	// Creator only selects the entries created by the given user.
+	Creator string
+
+	// Expired only selects the entries whose expiration has passed, but which
+	// have not been deleted yet.
+	Expired bool
+
+	// ExpiresWithin only selects the entries that expire within the given
+	// duration.
+	ExpiresWithin time.Duration
+}
+
+// NewSilencedFilter returns the filter described by the creator, expired and
+// expires_within query parameters.
+func NewSilencedFilter(query url.Values) (*SilencedFilter, error) {
+	filter := &SilencedFilter{Creator: query.Get("creator")}
+	if value := query.Get("expired"); value != "" {
+		expired, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expired parameter: %s", err)
+		}
+		filter.Expired = expired
+	}
+	if value := query.Get("expires_within"); value != "" {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expires_within parameter: %s", err)
+		}
+		filter.ExpiresWithin = d
+	}
+	return filter, nil
+}
+
+// Query returns the query parameters describing the filter.
+func (f *SilencedFilter) Query() url.Values {
+	query := url.Values{}
+	if f.Creator != "" {
+		query.Set("creator", f.Creator)
+	}
+	if f.Expired {
+		query.Set("expired", "true")
+	}
+	if f.ExpiresWithin > 0 {
+		query.Set("expires_within", f.ExpiresWithin.String())
+	}
+	return query
+}
+
+// Matches returns true if the silenced entry is selected by the filter. The
+// Expire attribute of the entry is expected to hold its remaining time to
+// live, as returned by the store, which is negative for entries that never
+// expire and 0 for the expired entries that were not deleted yet.
+func (f *SilencedFilter) Matches(s *Silenced) bool {
+	if f.Creator != "" && s.Creator != f.Creator {
+		return false
+	}
+	if f.Expired && s.Expire != 0 {
+		return false
+	}
+	if f.ExpiresWithin > 0 && (s.Expire < 0 || time.Duration(s.Expire)*time.Second > f.ExpiresWithin) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+package v2
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSilencedFilterMatches(t *testing.T) {
+	entry := func(creator string, expire int64) *Silenced {
+		s := FixtureSilenced("linux:check-cpu")
+		s.Creator = creator
+		s.Expire = expire
+		return s
+	}
+
+	tests := []struct {
+		name   string
+		filter SilencedFilter
+		entry  *Silenced
+		want   bool
+	}{
+		{"empty filter", SilencedFilter{}, entry("admin", -1), true},
+		{"creator match", SilencedFilter{Creator: "admin"}, entry("admin", -1), true},
+		{"creator mismatch", SilencedFilter{Creator: "admin"}, entry("bob", -1), false},
+		{"expired", SilencedFilter{Expired: true}, entry("admin", 0), true},
+		{"not expired", SilencedFilter{Expired: true}, entry("admin", 60), false},
+		{"never expires", SilencedFilter{Expired: true}, entry("admin", -1), false},
+		{"expires within", SilencedFilter{ExpiresWithin: time.Hour}, entry("admin", 60), true},
+		{"expires later", SilencedFilter{ExpiresWithin: time.Minute}, entry("admin", 3600), false},
+		{"never expires within", SilencedFilter{ExpiresWithin: time.Hour}, entry("admin", -1), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filter.Matches(tt.entry))
+		})
+	}
+}
+
+func TestNewSilencedFilter(t *testing.T) {
+	want := &SilencedFilter{Creator: "admin", Expired: true, ExpiresWithin: 90 * time.Minute}
+	got, err := NewSilencedFilter(want.Query())
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	_, err = NewSilencedFilter(url.Values{"expired": []string{"maybe"}})
+	assert.Error(t, err)
+
+	_, err = NewSilencedFilter(url.Values{"expires_within": []string{"soon"}})
+	assert.Error(t, err)
+}
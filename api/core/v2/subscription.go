@@ -0,0 +1,87 @@
+package v2
+
+import (
+	"net/url"
+	"path"
+)
+
+// SubscriptionMembers lists the agent entities connected to a subscription.
+type SubscriptionMembers struct {
+	// Subscription is the name of the subscription.
+	Subscription string `json:"subscription"`
+
+	// Entities are the names of the agent entities connected to the
+	// subscription.
+	Entities []string `json:"entities"`
+
+	// Description is the description of the subscription, if it is defined
+	// as a resource.
+	Description string `json:"description,omitempty"`
+
+	// Labels are the labels of the subscription, if it is defined as a
+	// resource.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+const (
+	// SubscriptionsResource is the name of this resource type
+	SubscriptionsResource = "subscriptions"
+)
+
+// NewSubscription creates a new Subscription.
+func NewSubscription(meta ObjectMeta) *Subscription {
+	return &Subscription{ObjectMeta: meta}
+}
+
+// FixtureSubscription returns a fixture for a Subscription object.
+func FixtureSubscription(name string) *Subscription {
+	return &Subscription{
+		ObjectMeta:  NewObjectMeta(name, "default"),
+		Description: "The " + name + " hosts",
+	}
+}
+
+// GetObjectMeta returns the object metadata for the resource.
+func (s *Subscription) GetObjectMeta() ObjectMeta {
+	return s.ObjectMeta
+}
+
+// SetObjectMeta sets the meta of the resource.
+func (s *Subscription) SetObjectMeta(meta ObjectMeta) {
+	s.ObjectMeta = meta
+}
+
+// SetNamespace sets the namespace of the resource.
+func (s *Subscription) SetNamespace(namespace string) {
+	s.Namespace = namespace
+}
+
+// StorePrefix returns the path prefix to this resource in the store
+func (s *Subscription) StorePrefix() string {
+	return SubscriptionsResource
+}
+
+// RBACName describes the name of the resource for RBAC purposes.
+func (s *Subscription) RBACName() string {
+	return SubscriptionsResource
+}
+
+// URIPath returns the path component of a subscription URI.
+func (s *Subscription) URIPath() string {
+	if s.Namespace == "" {
+		return path.Join(URLPrefix, SubscriptionsResource, url.PathEscape(s.Name))
+	}
+	return path.Join(URLPrefix, "namespaces", url.PathEscape(s.Namespace), SubscriptionsResource, url.PathEscape(s.Name))
+}
+
+// Validate returns an error if the subscription does not pass validation
+// tests.
+func (s *Subscription) Validate() error {
+	if err := ValidateSubscriptionName(s.Name); err != nil {
+		return NewFieldError("metadata.name", "subscription name "+err.Error())
+	}
+	if s.Namespace == "" {
+		return NewFieldError("metadata.namespace", "namespace must be set")
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package v2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriptionValidate(t *testing.T) {
+	subscription := FixtureSubscription("linux")
+	assert.NoError(t, subscription.Validate())
+
+	// Entity subscriptions can be documented too
+	subscription.Name = "entity:server1"
+	assert.NoError(t, subscription.Validate())
+
+	subscription.Name = ""
+	assert.Error(t, subscription.Validate())
+
+	subscription = FixtureSubscription("linux")
+	subscription.Namespace = ""
+	assert.Error(t, subscription.Validate())
+}
+
+func TestSubscriptionURIPath(t *testing.T) {
+	subscription := FixtureSubscription("linux")
+	assert.Equal(t, "/api/core/v2/namespaces/default/subscriptions/linux", subscription.URIPath())
+}
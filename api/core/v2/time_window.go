@@ -1,6 +1,7 @@
 package v2
 
 import (
+	"fmt"
 	"strings"
 	"time"
 )
@@ -10,10 +11,10 @@ func (t *TimeWindowWhen) Validate() error {
 	if t == nil {
 		return nil
 	}
-	for _, windows := range t.MapTimeWindows() {
-		for _, window := range windows {
+	for day, windows := range t.MapTimeWindows() {
+		for i, window := range windows {
 			if err := window.Validate(); err != nil {
-				return err
+				return NewFieldError(fmt.Sprintf("days.%s[%d]", strings.ToLower(day), i), err.Error())
 			}
 		}
 	}
@@ -31,6 +31,8 @@ var typeMap = map[string]interface{}{
 	"check_history":          &CheckHistory{},
 	"CheckRequest":           &CheckRequest{},
 	"check_request":          &CheckRequest{},
+	"CheckTemplate":          &CheckTemplate{},
+	"check_template":         &CheckTemplate{},
 	"Claims":                 &Claims{},
 	"claims":                 &Claims{},
 	"ClusterHealth":          &ClusterHealth{},
@@ -39,6 +41,8 @@ var typeMap = map[string]interface{}{
 	"cluster_role":           &ClusterRole{},
 	"ClusterRoleBinding":     &ClusterRoleBinding{},
 	"cluster_role_binding":   &ClusterRoleBinding{},
+	"CompositeCheck":         &CompositeCheck{},
+	"composite_check":        &CompositeCheck{},
 	"Deregistration":         &Deregistration{},
 	"deregistration":         &Deregistration{},
 	"Entity":                 &Entity{},
@@ -97,6 +101,8 @@ var typeMap = map[string]interface{}{
 	"silenced":               &Silenced{},
 	"Subject":                &Subject{},
 	"subject":                &Subject{},
+	"Subscription":           &Subscription{},
+	"subscription":           &Subscription{},
 	"System":                 &System{},
 	"system":                 &System{},
 	"TLSOptions":             &TLSOptions{},
@@ -32,7 +32,7 @@ func (u *User) URIPath() string {
 // Validate returns an error if the entity is invalid.
 func (u *User) Validate() error {
 	if err := ValidateNameStrict(u.Username); err != nil {
-		return fmt.Errorf("username %s", err)
+		return NewFieldError("spec.username", fmt.Sprintf("username %s", err))
 	}
 
 	return nil
@@ -1,8 +1,14 @@
 package v2
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 // ConstrainedResource defines a resources that has contraints on it's attributes
@@ -10,8 +16,57 @@ type ConstrainedResource interface {
 	Validate() error
 }
 
-// NameRegex is used to validate the name of a resource
-var NameRegex = regexp.MustCompile(`\A[\w\.\-\:]+\z`)
+// FieldError is a validation error of a single field of a resource, which
+// identifies the field with its path, e.g. "metadata.name" or "spec.type", and
+// optionally lists the values allowed for it.
+type FieldError struct {
+	Field   string   `json:"field"`
+	Message string   `json:"message"`
+	Allowed []string `json:"allowed,omitempty"`
+}
+
+// NewFieldError returns a new FieldError for the given field.
+func NewFieldError(field, message string, allowed ...string) *FieldError {
+	return &FieldError{Field: field, Message: message, Allowed: allowed}
+}
+
+// Error returns the message of the error, so that FieldError can be returned
+// by validators in place of plain errors.
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
+// nestedFieldError returns the error of the validation of a nested value, such
+// as the proxy requests of a check or the entity of an event, as a FieldError
+// of the field holding it, whose message is prefixed with prefix. The path of
+// the FieldError of one of its own fields is prefixed with the path of the
+// field; the fields of nested resources are not wrapped in a spec, so it isn't
+// part of their path.
+func nestedFieldError(field, prefix string, err error) *FieldError {
+	fieldErr, ok := err.(*FieldError)
+	if !ok {
+		return NewFieldError(field, prefix+err.Error())
+	}
+	return &FieldError{
+		Field:   field + "." + strings.TrimPrefix(fieldErr.Field, "spec."),
+		Message: prefix + fieldErr.Message,
+		Allowed: fieldErr.Allowed,
+	}
+}
+
+// MaxNameLength is the maximum length of the name of a resource, in
+// characters. It is large enough for fully qualified domain names, which are
+// at most 253 characters long.
+const MaxNameLength = 255
+
+// MaxUsernameLength is the maximum length of the name of a user, in
+// characters.
+const MaxUsernameLength = 64
+
+// NameRegex is used to validate the name of a resource. Names can contain
+// unicode letters and digits, underscores, dots, dashes and colons, so that
+// FQDN-style entity names and the names of Sensu 1.x resources are valid.
+var NameRegex = regexp.MustCompile(`\A[\p{L}\p{M}\p{N}_\.\-\:]+\z`)
 
 // StrictNameRegex is used to validate names of resources using a strict subset
 // of charset.
@@ -20,37 +75,157 @@ var StrictNameRegex = regexp.MustCompile(`\A[a-z0-9\_\.\-]+\z`)
 // SubscriptionNameRegex is used to validate the name of a subscription, which
 // can contain a single ":" character in case of an entity subscription (e.g.
 // entity:foo)
-var SubscriptionNameRegex = regexp.MustCompile(`\A[\w\.\-]+\:?[\w\.\-]+\z`)
+var SubscriptionNameRegex = regexp.MustCompile(`\A[\p{L}\p{M}\p{N}_\.\-]+\:?[\p{L}\p{M}\p{N}_\.\-]+\z`)
 
-// ValidateName validates the name of an element so it's not empty and it does
-// not contains specical characters. Compatible with Sensu 1.0.
-func ValidateName(name string) error {
-	return validateNameWithPattern(name, NameRegex)
-}
+// NameSpec is the naming specification of a type of resource.
+type NameSpec struct {
+	// Pattern is the pattern names must match.
+	Pattern *regexp.Regexp
 
-// ValidateNameStrict validates the name of an element so it's not empty and it
-// does not contains specical characters. Not compatible with Sensu 1.0
-// resources.
-func ValidateNameStrict(name string) error {
-	return validateNameWithPattern(name, StrictNameRegex)
+	// Char matches a single character allowed in names.
+	Char *regexp.Regexp
+
+	// MaxLength is the maximum length of names, in characters.
+	MaxLength int
+
+	// Charset describes the characters names can contain, for error messages.
+	Charset string
 }
 
-// ValidateSubscriptionName validates the name of a subscription so it's not
-// empty and it does not contains special characters except for an optional ":"
-func ValidateSubscriptionName(name string) error {
-	return validateNameWithPattern(name, SubscriptionNameRegex)
+var (
+	// ResourceNameSpec is the naming specification of most resources, such as
+	// checks, entities, handlers and namespaces.
+	ResourceNameSpec = NameSpec{
+		Pattern:   NameRegex,
+		Char:      regexp.MustCompile(`\A[\p{L}\p{M}\p{N}_\.\-\:]\z`),
+		MaxLength: MaxNameLength,
+		Charset:   "letters, digits, underscores, dots, dashes and colons",
+	}
+
+	// UserNameSpec is the naming specification of users, whose names are
+	// restricted to lowercase ASCII characters since they are compared as-is
+	// in role bindings.
+	UserNameSpec = NameSpec{
+		Pattern:   StrictNameRegex,
+		Char:      regexp.MustCompile(`\A[a-z0-9\_\.\-]\z`),
+		MaxLength: MaxUsernameLength,
+		Charset:   "lowercase letters, digits, underscores, dots and dashes",
+	}
+
+	// SubscriptionNameSpec is the naming specification of subscriptions and
+	// RBAC resources. It allows entity subscriptions, whose names are made of
+	// "entity:" followed by the name of an entity.
+	SubscriptionNameSpec = NameSpec{
+		Pattern:   SubscriptionNameRegex,
+		Char:      regexp.MustCompile(`\A[\p{L}\p{M}\p{N}_\.\-\:]\z`),
+		MaxLength: len("entity:") + MaxNameLength,
+		Charset:   "letters, digits, underscores, dots, dashes and a single colon",
+	}
+
+	// AssetNameSpec is the naming specification of assets, whose names can be
+	// namespaced with forward slashes, e.g. sensu/sensu-slack-handler.
+	AssetNameSpec = NameSpec{
+		Pattern:   AssetNameRegex,
+		Char:      regexp.MustCompile(`\A[\p{L}\p{M}\p{N}\/_\.\-\:]\z`),
+		MaxLength: MaxNameLength,
+		Charset:   "letters, digits, forward slashes, underscores, dots, dashes and colons",
+	}
+)
+
+// NameSpecs maps the types of resources, as named in RBAC rules, to their
+// naming specification.
+var NameSpecs = map[string]NameSpec{
+	AssetsResource:              AssetNameSpec,
+	ChecksResource:              ResourceNameSpec,
+	ClusterRolesResource:        SubscriptionNameSpec,
+	ClusterRoleBindingsResource: SubscriptionNameSpec,
+	EntitiesResource:            ResourceNameSpec,
+	ExtensionsResource:          ResourceNameSpec,
+	EventFiltersResource:        ResourceNameSpec,
+	HandlersResource:            ResourceNameSpec,
+	HooksResource:               ResourceNameSpec,
+	MutatorsResource:            ResourceNameSpec,
+	NamespacesResource:          ResourceNameSpec,
+	RolesResource:               SubscriptionNameSpec,
+	RoleBindingsResource:        SubscriptionNameSpec,
+	UsersResource:               UserNameSpec,
 }
 
-// validateName validates the name of an element so it's not empty and it does
-// not contains specical characters
-func validateNameWithPattern(name string, rexp *regexp.Regexp) error {
+// Validate returns an error if the name does not follow the specification.
+func (s NameSpec) Validate(name string) error {
 	if name == "" {
 		return errors.New("must not be empty")
 	}
 
-	if match := rexp.MatchString(name); !match {
-		return errors.New("cannot contain spaces or special characters")
+	if length := utf8.RuneCountInString(name); length > s.MaxLength {
+		return fmt.Errorf("must be at most %d characters long, got %d", s.MaxLength, length)
+	}
+
+	if match := s.Pattern.MatchString(name); !match {
+		return fmt.Errorf("cannot contain spaces or special characters, only %s", s.Charset)
 	}
 
 	return nil
 }
+
+// Migrate returns the name unchanged if it follows the specification, or a
+// name derived from it that does. It is meant to migrate the names of
+// resources created by other systems, such as Sensu 1.x: uppercase letters are
+// lowercased if only lowercase ones are allowed, the other characters that
+// are not allowed are replaced with underscores, and names that are too long
+// are truncated and suffixed with a hash of the original name, so that
+// distinct names remain distinct.
+func (s NameSpec) Migrate(name string) string {
+	if s.Validate(name) == nil {
+		return name
+	}
+
+	runes := []rune(name)
+	for i, r := range runes {
+		if s.Char.MatchString(string(r)) {
+			continue
+		}
+		if lower := unicode.ToLower(r); s.Char.MatchString(string(lower)) {
+			runes[i] = lower
+		} else {
+			runes[i] = '_'
+		}
+	}
+	if len(runes) == 0 {
+		runes = []rune{'_'}
+	}
+
+	if len(runes) > s.MaxLength {
+		sum := sha256.Sum256([]byte(name))
+		suffix := "-" + hex.EncodeToString(sum[:4])
+		runes = append(runes[:s.MaxLength-len(suffix)], []rune(suffix)...)
+	}
+
+	migrated := string(runes)
+	if s.Validate(migrated) != nil {
+		// The pattern has constraints beyond its charset, such as the single
+		// colon of subscriptions
+		migrated = strings.Replace(migrated, ":", "_", -1)
+	}
+	return migrated
+}
+
+// ValidateName validates the name of an element so it's not empty, not too
+// long and it does not contains special characters. Compatible with Sensu 1.0.
+func ValidateName(name string) error {
+	return ResourceNameSpec.Validate(name)
+}
+
+// ValidateNameStrict validates the name of an element so it's not empty, not
+// too long and it does not contains special characters. Not compatible with
+// Sensu 1.0 resources.
+func ValidateNameStrict(name string) error {
+	return UserNameSpec.Validate(name)
+}
+
+// ValidateSubscriptionName validates the name of a subscription so it's not
+// empty, not too long and it does not contains special characters except for
+// an optional ":"
+func ValidateSubscriptionName(name string) error {
+	return SubscriptionNameSpec.Validate(name)
+}
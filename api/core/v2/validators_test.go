@@ -1,6 +1,7 @@
 package v2
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,6 +13,11 @@ func TestValidateName(t *testing.T) {
 	assert.Error(t, ValidateName("foo@bar"))
 	assert.NoError(t, ValidateName("foo-bar"))
 	assert.NoError(t, ValidateName("foo:bar"))
+	assert.NoError(t, ValidateName("Web01.example.com"))
+	assert.NoError(t, ValidateName("café-münchen"))
+	assert.NoError(t, ValidateName("サーバー1"))
+	assert.NoError(t, ValidateName(strings.Repeat("a", MaxNameLength)))
+	assert.Error(t, ValidateName(strings.Repeat("a", MaxNameLength+1)))
 }
 
 func TestValidateNameStrict(t *testing.T) {
@@ -21,6 +27,7 @@ func TestValidateNameStrict(t *testing.T) {
 	assert.Error(t, ValidateNameStrict("FOO-bar"))
 	assert.NoError(t, ValidateNameStrict("foo-bar_2"))
 	assert.Error(t, ValidateNameStrict("foo:bar"))
+	assert.Error(t, ValidateNameStrict(strings.Repeat("a", MaxUsernameLength+1)))
 }
 
 func TestValidateSubscriptionName(t *testing.T) {
@@ -31,3 +38,40 @@ func TestValidateSubscriptionName(t *testing.T) {
 	assert.NoError(t, ValidateSubscriptionName("entity:foo"))
 	assert.NoError(t, ValidateSubscriptionName("foo-bar_2"))
 }
+
+func TestNameSpecs(t *testing.T) {
+	for resource, spec := range NameSpecs {
+		assert.NoError(t, spec.Validate("foo-bar"), resource)
+		assert.Error(t, spec.Validate(""), resource)
+		assert.Error(t, spec.Validate(strings.Repeat("a", spec.MaxLength+1)), resource)
+	}
+}
+
+func TestNameSpecMigrate(t *testing.T) {
+	long := strings.Repeat("a", MaxNameLength+10)
+	tests := []struct {
+		name string
+		spec NameSpec
+		in   string
+		want string
+	}{
+		{"valid name is unchanged", ResourceNameSpec, "web01.example.com", "web01.example.com"},
+		{"spaces", ResourceNameSpec, "check disk usage", "check_disk_usage"},
+		{"uppercase user", UserNameSpec, "John.Doe", "john.doe"},
+		{"many colons", SubscriptionNameSpec, "a:b:c", "a_b_c"},
+		{"empty", ResourceNameSpec, "", "_"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.spec.Migrate(tt.in)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, tt.spec.Validate(got))
+		})
+	}
+
+	// Long names are truncated, and distinct names remain distinct
+	a, b := ResourceNameSpec.Migrate(long+"a"), ResourceNameSpec.Migrate(long+"b")
+	assert.NoError(t, ValidateName(a))
+	assert.Len(t, a, MaxNameLength)
+	assert.NotEqual(t, a, b)
+}
@@ -26,8 +26,24 @@ type Fetcher interface {
 // URLGetter gets all content at the specified URL.
 type urlGetter func(context.Context, string, map[string]string) (io.ReadCloser, error)
 
+// NewHTTPFetcher returns a Fetcher downloading assets with the given HTTP
+// client, configured for instance with a proxy.
+func NewHTTPFetcher(client *http.Client) Fetcher {
+	return &httpFetcher{
+		URLGetter: func(ctx context.Context, path string, headers map[string]string) (io.ReadCloser, error) {
+			return httpClientGet(ctx, client, path, headers)
+		},
+	}
+}
+
 // Get the target URL and return an io.ReadCloser
 func httpGet(ctx context.Context, path string, headers map[string]string) (io.ReadCloser, error) {
+	return httpClientGet(ctx, &http.Client{}, path, headers)
+}
+
+// httpClientGet gets the target URL with the given client and returns an
+// io.ReadCloser
+func httpClientGet(ctx context.Context, client *http.Client, path string, headers map[string]string) (io.ReadCloser, error) {
 	req, err := http.NewRequest(http.MethodGet, path, nil)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching asset: %s", err)
@@ -41,7 +57,6 @@ func httpGet(ctx context.Context, path string, headers map[string]string) (io.Re
 		}
 	}
 
-	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching asset: %s", err)
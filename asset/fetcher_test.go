@@ -11,7 +11,9 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/sensu/sensu-go/util/httpclient"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var (
@@ -85,3 +87,23 @@ func TestHTTPGetNon200(t *testing.T) {
 	assert.Nil(t, closer)
 	assert.EqualError(t, err, "error fetching asset: Response Code 404")
 }
+
+func TestHTTPFetcherProxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Proxies receive the absolute URL of the requests
+		assert.Equal(t, "assets.example.com", r.URL.Host)
+		_, _ = w.Write([]byte("asset"))
+	}))
+	defer proxy.Close()
+
+	client, err := httpclient.Config{ProxyURL: proxy.URL}.NewClient(0)
+	require.NoError(t, err)
+	f, err := NewHTTPFetcher(client).Fetch(context.Background(), "http://assets.example.com/asset.tar.gz", nil)
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	b, err := ioutil.ReadAll(f)
+	require.NoError(t, err)
+	assert.Equal(t, "asset", string(b))
+}
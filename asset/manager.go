@@ -17,6 +17,10 @@ const (
 
 // Manager ...
 type Manager struct {
+	// Fetcher downloads the assets. The built-in HTTP fetcher is used if it's
+	// nil.
+	Fetcher Fetcher
+
 	cacheDir string
 	entity   *types.Entity
 	stopping chan struct{}
@@ -55,7 +59,7 @@ func (m *Manager) StartAssetManager(ctx context.Context) (Getter, error) {
 		}
 	}()
 	boltDBGetter := NewBoltDBGetter(
-		db, m.cacheDir, nil, nil, nil)
+		db, m.cacheDir, m.Fetcher, nil, nil)
 
 	return NewFilteredManager(boltDBGetter, m.entity), nil
 }
@@ -6,12 +6,15 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/coreos/etcd/clientv3"
 	"github.com/gogo/protobuf/proto"
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 	"github.com/prometheus/client_golang/prometheus"
@@ -20,6 +23,8 @@ import (
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
 	"github.com/sensu/sensu-go/backend/authorization"
 	"github.com/sensu/sensu-go/backend/authorization/rbac"
+	"github.com/sensu/sensu-go/backend/clusterconfig"
+	"github.com/sensu/sensu-go/backend/etcd"
 	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/ringv2"
 	"github.com/sensu/sensu-go/backend/store"
@@ -55,6 +60,26 @@ type Agentd struct {
 	ctx          context.Context
 	cancel       context.CancelFunc
 	writeTimeout int
+
+	maxEventSize       int
+	maxCheckOutputSize int
+	requestBacklog     *RequestBacklog
+	verificationKeys   []*transport.VerificationKey
+	clusterConfig      *clusterconfig.Watcher
+
+	// name identifies the backend whose agent sessions are drained
+	name      string
+	drainRate int
+	reporter  *etcd.BackendReporter
+
+	// sessionsMu protects the fields below, which track the agent sessions
+	// to enforce the maximum number of sessions and to drain the backend
+	sessionsMu   sync.Mutex
+	sessions     map[*Session]struct{}
+	sessionCount int
+	maxSessions  int
+	draining     bool
+	stopDrain    context.CancelFunc
 }
 
 // Config configures an Agentd.
@@ -66,6 +91,44 @@ type Config struct {
 	TLS          *corev2.TLSOptions
 	RingPool     *ringv2.Pool
 	WriteTimeout int
+
+	// MaxEventSize is the maximum size, in bytes, of events and keepalives
+	// accepted from agents. Zero means unlimited.
+	MaxEventSize int
+
+	// MaxCheckOutputSize is the maximum size, in bytes, of check output
+	// accepted from agents. Zero means unlimited.
+	MaxCheckOutputSize int
+
+	// ReplayMaxAge is the maximum age, in seconds, of the check requests
+	// replayed to agents reconnecting after missing them. Zero disables the
+	// replay.
+	ReplayMaxAge int
+
+	// MaxSessions is the maximum number of concurrent agent sessions. The
+	// agents connecting beyond it are told to connect to another backend.
+	// Zero means unlimited.
+	MaxSessions int
+
+	// VerificationKeys verify the signatures of the messages of the agents.
+	// The agents must sign their messages if it's not empty.
+	VerificationKeys []*transport.VerificationKey
+
+	// ClusterConfig provides the custom entity classes, which the agents of a
+	// class other than agent must use.
+	ClusterConfig *clusterconfig.Watcher
+
+	// Name is the name of the backend, by which the drain of its agent
+	// sessions is requested.
+	Name string
+
+	// DrainRate is the number of agent sessions stopped per second while the
+	// backend is drained. Defaults to DefaultDrainRate.
+	DrainRate int
+
+	// Client records the drain status of the backend in etcd, so that the
+	// statuses of all the backends are reported. It's not recorded if nil.
+	Client *clientv3.Client
 }
 
 // Option is a functional option.
@@ -88,6 +151,26 @@ func New(c Config, opts ...Option) (*Agentd, error) {
 		ctx:          ctx,
 		cancel:       cancel,
 		writeTimeout: c.WriteTimeout,
+
+		maxEventSize:       c.MaxEventSize,
+		maxCheckOutputSize: c.MaxCheckOutputSize,
+		verificationKeys:   c.VerificationKeys,
+		clusterConfig:      c.ClusterConfig,
+		sessions:           make(map[*Session]struct{}),
+		maxSessions:        c.MaxSessions,
+		name:               c.Name,
+		drainRate:          c.DrainRate,
+	}
+	if a.drainRate <= 0 {
+		a.drainRate = DefaultDrainRate
+	}
+	if c.Client != nil {
+		a.reporter = etcd.NewBackendReporter(c.Client, drainStatusKeyPrefix, etcd.DefaultReportInterval, func() interface{} {
+			return a.DrainStatus()
+		})
+	}
+	if c.ReplayMaxAge > 0 {
+		a.requestBacklog = NewRequestBacklog(ctx, c.Bus, c.Store, time.Duration(c.ReplayMaxAge)*time.Second)
 	}
 
 	// prepare server TLS config
@@ -152,6 +235,13 @@ func (a *Agentd) Start() error {
 		}
 	}()
 
+	// Follow the drains of the backend, including the one requested before
+	// it started
+	a.watchDrain(a.ctx)
+	if a.reporter != nil {
+		a.reporter.Start(a.ctx)
+	}
+
 	sessionCounterOnce.Do(func() {
 		if err := prometheus.Register(sessionCounter); err != nil {
 			logger.WithError(err).Error("error registering session counter")
@@ -191,6 +281,21 @@ func (a *Agentd) Name() string {
 }
 
 func (a *Agentd) webSocketHandler(w http.ResponseWriter, r *http.Request) {
+	// Reserve a session before doing any work, so the agents rejected by a
+	// draining or full backend reconnect to another backend right away
+	if err := a.acquireSession(); err != nil {
+		logger.WithField("addr", r.RemoteAddr).WithError(err).Warn("rejecting agent session")
+		w.Header().Set("Retry-After", strconv.Itoa(sessionRetryAfter))
+		http.Error(w, err.Error()+", connect to another backend", http.StatusServiceUnavailable)
+		return
+	}
+	var session *Session
+	defer func() {
+		if session == nil {
+			a.releaseSession(nil)
+		}
+	}()
+
 	var marshal MarshalFunc
 	var unmarshal UnmarshalFunc
 	var contentType string
@@ -213,6 +318,14 @@ func (a *Agentd) webSocketHandler(w http.ResponseWriter, r *http.Request) {
 	responseHeader.Set("Content-Type", contentType)
 	logger.WithField("header", fmt.Sprintf("Content-Type: %s", contentType)).Debug("setting header")
 
+	// Negotiate the check result schema version with the agent
+	checkResultVersion := transport.NegotiateCheckResultVersion(r.Header.Get(transport.HeaderKeyCheckResultVersions), transport.SupportedCheckResultVersions)
+	responseHeader.Set(transport.HeaderKeyCheckResultVersion, strconv.Itoa(checkResultVersion))
+
+	// Tell the agent that it can sign its messages, bound to this session
+	signatureNonce := uuid.New().String()
+	responseHeader.Set(transport.HeaderKeySignedMessages, signatureNonce)
+
 	cfg := SessionConfig{
 		AgentAddr:     r.RemoteAddr,
 		AgentName:     r.Header.Get(transport.HeaderKeyAgentName),
@@ -222,6 +335,14 @@ func (a *Agentd) webSocketHandler(w http.ResponseWriter, r *http.Request) {
 		RingPool:      a.ringPool,
 		ContentType:   contentType,
 		WriteTimeout:  a.writeTimeout,
+
+		CheckResultVersion: checkResultVersion,
+
+		MaxEventSize:       a.maxEventSize,
+		MaxCheckOutputSize: a.maxCheckOutputSize,
+		RequestBacklog:     a.requestBacklog,
+		VerificationKeys:   a.verificationKeys,
+		SignatureNonce:     signatureNonce,
 	}
 
 	// Validate the agent namespace
@@ -233,6 +354,12 @@ func (a *Agentd) webSocketHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Validate the agent entity class, which must expect keepalives
+	if class := r.Header.Get(transport.HeaderKeyEntityClass); class != "" && !a.clusterConfig.Config().ExpectsKeepalives(class) {
+		http.Error(w, fmt.Sprintf("entity class %s is not defined, or doesn't expect keepalives", class), http.StatusBadRequest)
+		return
+	}
+
 	conn, err := upgrader.Upgrade(w, r, responseHeader)
 	if err != nil {
 		logger.WithField("addr", r.RemoteAddr).WithError(err).Error("transport error on websocket upgrade")
@@ -242,7 +369,7 @@ func (a *Agentd) webSocketHandler(w http.ResponseWriter, r *http.Request) {
 
 	cfg.Subscriptions = addEntitySubscription(cfg.AgentName, cfg.Subscriptions)
 
-	session, err := NewSession(a.ctx, cfg, transport.NewTransport(conn), a.bus, a.store, unmarshal, marshal)
+	newSession, err := NewSession(a.ctx, cfg, transport.NewTransport(conn), a.bus, a.store, unmarshal, marshal)
 	if err != nil {
 		logger.WithError(err).Error("failed to create session")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -258,7 +385,7 @@ func (a *Agentd) webSocketHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := session.Start(); err != nil {
+	if err := newSession.Start(); err != nil {
 		logger.WithError(err).Error("failed to start session")
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		if _, ok := err.(*store.ErrInternal); ok {
@@ -269,6 +396,8 @@ func (a *Agentd) webSocketHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	session = newSession
+	a.trackSession(session)
 }
 
 // AuthenticationMiddleware represents the core authentication middleware for
@@ -2,12 +2,14 @@ package agentd
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/clusterconfig"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/sensu/sensu-go/transport"
@@ -102,3 +104,57 @@ func TestAgentdMiddlewares(t *testing.T) {
 		assert.Equal(tc.expectedCode, res.StatusCode, tc.description)
 	}
 }
+
+func TestAgentdEntityClass(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stor := &mockstore.MockStore{}
+	stor.On("GetNamespace", mock.Anything, "default").Return(corev2.FixtureNamespace("default"), nil)
+	stor.On("GetClusterConfigWatcher", mock.Anything).Return((<-chan store.WatchEventClusterConfig)(make(chan store.WatchEventClusterConfig)))
+	stor.On("GetClusterConfig", mock.Anything).Return(&corev2.ClusterConfig{
+		EntityClasses: map[string]*corev2.EntityClass{
+			"appliance": {ExpectKeepalives: true},
+			"switch":    {ExpectKeepalives: false},
+		},
+	}, nil)
+	clusterConfig := clusterconfig.NewWatcher(stor)
+	clusterConfig.Start(ctx)
+
+	tests := []struct {
+		class    string
+		rejected bool
+	}{
+		{class: ""},
+		{class: corev2.EntityAgentClass},
+		{class: "appliance"},
+		{class: "switch", rejected: true},
+		{class: "undefined", rejected: true},
+		{class: corev2.EntityProxyClass, rejected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.class, func(t *testing.T) {
+			a := &Agentd{
+				ctx:           ctx,
+				store:         stor,
+				sessions:      make(map[*Session]struct{}),
+				clusterConfig: clusterConfig,
+			}
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(transport.HeaderKeyNamespace, "default")
+			if tt.class != "" {
+				req.Header.Set(transport.HeaderKeyEntityClass, tt.class)
+			}
+			w := httptest.NewRecorder()
+			a.webSocketHandler(w, req)
+
+			// The accepted agents fail to upgrade the test connection
+			// instead
+			if tt.rejected {
+				assert.Equal(t, http.StatusBadRequest, w.Code)
+				assert.Contains(t, w.Body.String(), "entity class "+tt.class+" is not defined")
+			} else {
+				assert.NotContains(t, w.Body.String(), "entity class")
+			}
+		})
+	}
+}
@@ -0,0 +1,366 @@
+package agentd
+
+import (
+	"context"
+	"path"
+	"sort"
+	"sync"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// backlogFlushInterval is the interval at which the check requests received
+// by a RequestBacklog are recorded in the store.
+const backlogFlushInterval = time.Second
+
+// RequestBacklog records the latest check requests published to the
+// subscriptions of the agents connected to the backend, so that the requests
+// issued while an agent was briefly disconnected can be replayed when it
+// reconnects, to this backend or to another one. The requests are recorded in
+// the store, for the maximum age of the requests replayed, in batches so that
+// the publishers of the bus are never blocked by the store.
+type RequestBacklog struct {
+	ctx    context.Context
+	bus    messaging.MessageBus
+	store  store.CheckRequestStore
+	maxAge time.Duration
+
+	mu           sync.Mutex
+	topics       map[string]*backlogTopic
+	disconnected map[string]time.Time
+	// pending are the requests received since the last flush, by topic and
+	// check, and cancelled are the checks cancelled since the last flush, by
+	// topic
+	pending   map[*backlogTopic]map[string]*corev2.CheckRequest
+	cancelled map[*backlogTopic]map[string]struct{}
+}
+
+// backlogTopic receives the check requests published to a topic, while agents
+// subscribed to it are connected, and for the maximum age of the requests
+// replayed after the last one disconnected.
+type backlogTopic struct {
+	namespace    string
+	subscription string
+	ch           chan interface{}
+	bus          messaging.Subscription
+	cancel       context.CancelFunc
+	watchers     int
+	idleSince    time.Time
+}
+
+// Receiver returns the channel of the check requests published to the topic.
+func (t *backlogTopic) Receiver() chan<- interface{} {
+	return t.ch
+}
+
+// stop unsubscribes from the topic before it stops receiving its requests, so
+// that the bus is never blocked by the topic, nor the topic unsubscribed once
+// it's watched again.
+func (t *backlogTopic) stop() {
+	if err := t.bus.Cancel(); err != nil {
+		logger.WithError(err).Error("unable to unsubscribe from message bus")
+	}
+	t.cancel()
+}
+
+// NewRequestBacklog creates a new RequestBacklog, which replays the check
+// requests issued at most maxAge ago. It records the requests until ctx is
+// done.
+func NewRequestBacklog(ctx context.Context, bus messaging.MessageBus, st store.CheckRequestStore, maxAge time.Duration) *RequestBacklog {
+	b := &RequestBacklog{
+		ctx:          ctx,
+		bus:          bus,
+		store:        st,
+		maxAge:       maxAge,
+		topics:       make(map[string]*backlogTopic),
+		disconnected: make(map[string]time.Time),
+		pending:      make(map[*backlogTopic]map[string]*corev2.CheckRequest),
+		cancelled:    make(map[*backlogTopic]map[string]struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// backlogKey identifies the requests of a check, and of its proxy entity for
+// proxy checks.
+func backlogKey(request *corev2.CheckRequest) string {
+	return path.Join(request.Config.Name, request.Config.ProxyEntityName)
+}
+
+// Watch starts recording the check requests published to the subscriptions of
+// the namespace, if it isn't already, for an agent connected to the backend.
+// Each call must be followed by a call to Unwatch once the agent disconnects.
+func (b *RequestBacklog) Watch(namespace string, subscriptions []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, sub := range subscriptions {
+		topic := messaging.SubscriptionTopic(namespace, sub)
+		if t, ok := b.topics[topic]; ok {
+			t.watchers++
+			continue
+		}
+		ctx, cancel := context.WithCancel(b.ctx)
+		t := &backlogTopic{
+			namespace:    namespace,
+			subscription: sub,
+			ch:           make(chan interface{}, 100),
+			cancel:       cancel,
+			watchers:     1,
+		}
+		subscription, err := b.bus.Subscribe(topic, "agentd-request-backlog", t)
+		if err != nil {
+			cancel()
+			logger.WithError(err).WithField("topic", topic).Error("unable to record the check requests of the topic")
+			continue
+		}
+		t.bus = subscription
+		b.topics[topic] = t
+		go b.record(ctx, t)
+	}
+}
+
+// Unwatch stops recording the check requests published to the subscriptions
+// of the namespace for an agent which disconnected. The requests of a
+// subscription are recorded for the maximum age of the requests replayed
+// after its last agent disconnected, so that they can be replayed.
+func (b *RequestBacklog) Unwatch(namespace string, subscriptions []string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, sub := range subscriptions {
+		t, ok := b.topics[messaging.SubscriptionTopic(namespace, sub)]
+		if !ok || t.watchers == 0 {
+			continue
+		}
+		t.watchers--
+		if t.watchers == 0 {
+			t.idleSince = time.Now()
+		}
+	}
+}
+
+func (b *RequestBacklog) record(ctx context.Context, t *backlogTopic) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-t.ch:
+			if cancellation, ok := msg.(*corev2.CheckCancellation); ok {
+				b.cancel(t, cancellation.Name)
+				continue
+			}
+			request, ok := msg.(*corev2.CheckRequest)
+			if !ok || request.Config == nil {
+				continue
+			}
+			b.mu.Lock()
+			requests, ok := b.pending[t]
+			if !ok {
+				requests = make(map[string]*corev2.CheckRequest)
+				b.pending[t] = requests
+			}
+			requests[backlogKey(request)] = request
+			b.mu.Unlock()
+		}
+	}
+}
+
+// cancel forgets the requests of the check published to the topic, which
+// must not be replayed.
+func (b *RequestBacklog) cancel(t *backlogTopic, check string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for key, r := range b.pending[t] {
+		if r.Config.Name == check {
+			delete(b.pending[t], key)
+		}
+	}
+	checks, ok := b.cancelled[t]
+	if !ok {
+		checks = make(map[string]struct{})
+		b.cancelled[t] = checks
+	}
+	checks[check] = struct{}{}
+}
+
+// run records the requests received in the store, and stops receiving the
+// requests of the idle topics, until the context of the backlog is done.
+func (b *RequestBacklog) run() {
+	ticker := time.NewTicker(backlogFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-b.ctx.Done():
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			for topic, t := range b.topics {
+				t.stop()
+				delete(b.topics, topic)
+			}
+			return
+		case <-ticker.C:
+			b.flush()
+			b.evict()
+		}
+	}
+}
+
+// flush records the requests received since the last flush in the store, and
+// deletes the ones of the checks cancelled. The requests which couldn't be
+// recorded are retried at the next flush, unless newer ones were received in
+// the meantime.
+func (b *RequestBacklog) flush() {
+	b.mu.Lock()
+	pending, cancelled := b.pending, b.cancelled
+	b.pending = make(map[*backlogTopic]map[string]*corev2.CheckRequest)
+	b.cancelled = make(map[*backlogTopic]map[string]struct{})
+	b.mu.Unlock()
+
+	for t, checks := range cancelled {
+		ctx := store.NamespaceContext(b.ctx, t.namespace)
+		for check := range checks {
+			if err := b.store.DeleteCheckRequests(ctx, t.subscription, check); err != nil {
+				logger.WithError(err).WithField("subscription", t.subscription).Error("unable to forget the check requests of a cancelled check")
+			}
+		}
+	}
+
+	var requests []*store.SubscriptionCheckRequest
+	for t, byKey := range pending {
+		for _, request := range byKey {
+			if b.expired(request) {
+				continue
+			}
+			requests = append(requests, &store.SubscriptionCheckRequest{Subscription: t.subscription, Request: request})
+		}
+	}
+	if len(requests) == 0 {
+		return
+	}
+	ttl := int64(b.maxAge / time.Second)
+	if ttl < 1 {
+		ttl = 1
+	}
+	if err := b.store.RecordCheckRequests(b.ctx, requests, ttl); err != nil {
+		logger.WithError(err).Error("unable to record the check requests, retrying")
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for t, byKey := range pending {
+			if _, ok := b.pending[t]; !ok {
+				b.pending[t] = make(map[string]*corev2.CheckRequest)
+			}
+			for key, request := range byKey {
+				if _, ok := b.pending[t][key]; !ok {
+					b.pending[t][key] = request
+				}
+			}
+		}
+	}
+}
+
+// evict stops receiving the requests of the topics without agents for longer
+// than the maximum age of the requests replayed, and forgets the
+// disconnection times older than it.
+func (b *RequestBacklog) evict() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for topic, t := range b.topics {
+		if t.watchers == 0 && time.Since(t.idleSince) > b.maxAge {
+			t.stop()
+			delete(b.topics, topic)
+		}
+	}
+	for key, disconnected := range b.disconnected {
+		if time.Since(disconnected) > b.maxAge {
+			delete(b.disconnected, key)
+		}
+	}
+}
+
+func (b *RequestBacklog) expired(request *corev2.CheckRequest) bool {
+	return time.Since(time.Unix(request.Issued, 0)) > b.maxAge
+}
+
+// Disconnect records the time the agent disconnected from the backend.
+func (b *RequestBacklog) Disconnect(namespace, agent string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.disconnected[path.Join(namespace, agent)] = time.Now()
+}
+
+// Missed returns the check requests published to the subscriptions of the
+// namespace while the agent was disconnected, ordered by issue time, and
+// issued at most maxAge ago. The agent is considered disconnected since
+// lastSeen, the time of its last keepalive, if it didn't disconnect from this
+// backend. A check published to several of the subscriptions is replayed
+// once, and the checks of the requests are tagged with the
+// ReplayedAnnotation.
+func (b *RequestBacklog) Missed(namespace, agent string, subscriptions []string, lastSeen int64) []*corev2.CheckRequest {
+	b.mu.Lock()
+	key := path.Join(namespace, agent)
+	since, ok := b.disconnected[key]
+	delete(b.disconnected, key)
+	// The requests not recorded yet
+	var candidates []*corev2.CheckRequest
+	for _, sub := range subscriptions {
+		if t, ok := b.topics[messaging.SubscriptionTopic(namespace, sub)]; ok {
+			for _, request := range b.pending[t] {
+				candidates = append(candidates, request)
+			}
+		}
+	}
+	b.mu.Unlock()
+
+	if !ok {
+		if lastSeen == 0 {
+			// The agent never connected before
+			return nil
+		}
+		since = time.Unix(lastSeen, 0)
+	}
+
+	recorded, err := b.store.GetCheckRequests(store.NamespaceContext(b.ctx, namespace), subscriptions)
+	if err != nil {
+		logger.WithError(err).WithField("agent", agent).Error("unable to get the check requests missed by the agent")
+	}
+	for _, r := range recorded {
+		candidates = append(candidates, r.Request)
+	}
+
+	latest := make(map[string]*corev2.CheckRequest)
+	for _, request := range candidates {
+		if request.Issued < since.Unix() || b.expired(request) {
+			continue
+		}
+		key := backlogKey(request)
+		if r, ok := latest[key]; !ok || r.Issued < request.Issued {
+			latest[key] = request
+		}
+	}
+
+	requests := make([]*corev2.CheckRequest, 0, len(latest))
+	for _, request := range latest {
+		requests = append(requests, replayedRequest(request))
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		return requests[i].Issued < requests[j].Issued
+	})
+	return requests
+}
+
+// replayedRequest returns a copy of the check request, whose check is tagged
+// with the ReplayedAnnotation. The recorded request is shared by the agents
+// and isn't modified.
+func replayedRequest(request *corev2.CheckRequest) *corev2.CheckRequest {
+	replayed := *request
+	config := *request.Config
+	config.Annotations = make(map[string]string, len(request.Config.Annotations)+1)
+	for k, v := range request.Config.Annotations {
+		config.Annotations[k] = v
+	}
+	config.Annotations[corev2.ReplayedAnnotation] = "true"
+	replayed.Config = &config
+	return &replayed
+}
@@ -0,0 +1,173 @@
+package agentd
+
+import (
+	"context"
+	"path"
+	"sync"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requestStore is a store.CheckRequestStore shared by the backlogs of the
+// tests, as the etcd store is shared by the backends.
+type requestStore struct {
+	mu       sync.Mutex
+	requests map[string]*store.SubscriptionCheckRequest
+}
+
+func newRequestStore() *requestStore {
+	return &requestStore{requests: make(map[string]*store.SubscriptionCheckRequest)}
+}
+
+func (s *requestStore) RecordCheckRequests(ctx context.Context, requests []*store.SubscriptionCheckRequest, ttl int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range requests {
+		s.requests[path.Join(r.Request.Config.Namespace, r.Subscription, backlogKey(r.Request))] = r
+	}
+	return nil
+}
+
+func (s *requestStore) GetCheckRequests(ctx context.Context, subscriptions []string) ([]*store.SubscriptionCheckRequest, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var requests []*store.SubscriptionCheckRequest
+	for _, r := range s.requests {
+		for _, sub := range subscriptions {
+			if r.Subscription == sub && r.Request.Config.Namespace == corev2.ContextNamespace(ctx) {
+				requests = append(requests, r)
+			}
+		}
+	}
+	return requests, nil
+}
+
+func (s *requestStore) DeleteCheckRequests(ctx context.Context, subscription, check string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, r := range s.requests {
+		if r.Subscription == subscription && r.Request.Config.Name == check {
+			delete(s.requests, key)
+		}
+	}
+	return nil
+}
+
+func (s *requestStore) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.requests)
+}
+
+func TestRequestBacklog(t *testing.T) {
+	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	require.NoError(t, err)
+	require.NoError(t, bus.Start())
+	defer func() { _ = bus.Stop() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	st := newRequestStore()
+	backlog := NewRequestBacklog(ctx, bus, st, time.Minute)
+	subscriptions := []string{"linux", "web"}
+	topic := messaging.SubscriptionTopic("default", "linux")
+	other := messaging.SubscriptionTopic("default", "web")
+	backlog.Watch("default", subscriptions)
+
+	now := time.Now().Unix()
+	publish := func(topic, name string, issued int64) {
+		request := corev2.FixtureCheckRequest(name)
+		request.Issued = issued
+		require.NoError(t, bus.Publish(topic, request))
+	}
+	publish(topic, "expired", now-120)
+	publish(topic, "before", now-30)
+	publish(topic, "second", now-5)
+	publish(topic, "first", now-10)
+	// The requests of a check published to several subscriptions are
+	// replayed once
+	publish(other, "second", now-5)
+
+	// The requests are recorded in the store, except the expired ones
+	require.Eventually(t, func() bool {
+		return st.len() == 4
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The requests are replayed by any backend
+	replayer := NewRequestBacklog(ctx, bus, st, time.Minute)
+	missed := replayer.Missed("default", "agent1", subscriptions, now-20)
+	require.Len(t, missed, 2)
+	assert.Equal(t, "first", missed[0].Config.Name)
+	assert.Equal(t, "second", missed[1].Config.Name)
+
+	// The replayed requests are tagged, without modifying the recorded ones
+	for _, request := range missed {
+		assert.Equal(t, "true", request.Config.Annotations[corev2.ReplayedAnnotation])
+	}
+	recorded, err := st.GetCheckRequests(store.NamespaceContext(ctx, "default"), subscriptions)
+	require.NoError(t, err)
+	for _, r := range recorded {
+		assert.NotContains(t, r.Request.Config.Annotations, corev2.ReplayedAnnotation)
+	}
+
+	// The requests of a cancelled check aren't replayed
+	require.NoError(t, bus.Publish(topic, &corev2.CheckCancellation{Name: "first", Namespace: "default"}))
+	require.Eventually(t, func() bool {
+		return st.len() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	missed = replayer.Missed("default", "agent1", subscriptions, now-20)
+	require.Len(t, missed, 1)
+	assert.Equal(t, "second", missed[0].Config.Name)
+
+	// Agents that never connected before don't get any replay
+	assert.Empty(t, backlog.Missed("default", "agent1", subscriptions, 0))
+
+	// The disconnection time takes precedence over the last seen time
+	backlog.Disconnect("default", "agent1")
+	assert.Empty(t, backlog.Missed("default", "agent1", subscriptions, now-20))
+}
+
+func TestRequestBacklogEviction(t *testing.T) {
+	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	require.NoError(t, err)
+	require.NoError(t, bus.Start())
+	defer func() { _ = bus.Stop() }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	backlog := NewRequestBacklog(ctx, bus, newRequestStore(), time.Millisecond)
+	topicCount := func() int {
+		backlog.mu.Lock()
+		defer backlog.mu.Unlock()
+		return len(backlog.topics)
+	}
+
+	// The topics are watched once for all the agents
+	backlog.Watch("default", []string{"linux", "entity:agent1"})
+	backlog.Watch("default", []string{"linux", "entity:agent2"})
+	require.Equal(t, 3, topicCount())
+
+	// The topics of the connected agents are kept
+	backlog.Unwatch("default", []string{"linux", "entity:agent1"})
+	time.Sleep(10 * time.Millisecond)
+	backlog.evict()
+	assert.Equal(t, 2, topicCount())
+
+	// The topics without agents are evicted once idle for the maximum age
+	backlog.Unwatch("default", []string{"linux", "entity:agent2"})
+	time.Sleep(10 * time.Millisecond)
+	backlog.evict()
+	assert.Equal(t, 0, topicCount())
+
+	// The evicted topics can be watched again
+	backlog.Watch("default", []string{"linux"})
+	assert.Equal(t, 1, topicCount())
+}
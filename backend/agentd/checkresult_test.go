@@ -0,0 +1,87 @@
+package agentd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gogo/protobuf/proto"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/testing/mockbus"
+	"github.com/sensu/sensu-go/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionCheckResultVersions(t *testing.T) {
+	tests := []struct {
+		name       string
+		negotiated int
+		msgType    string
+		version    int
+		wantErr    bool
+	}{
+		{
+			name:       "v1 agent",
+			negotiated: transport.CheckResultV1,
+			msgType:    transport.MessageTypeEvent,
+		},
+		{
+			name:       "v2 agent",
+			negotiated: transport.CheckResultV2,
+			msgType:    transport.MessageTypeCheckResult,
+			version:    transport.CheckResultV2,
+		},
+		{
+			name:       "v2 agent sending v1 results",
+			negotiated: transport.CheckResultV2,
+			msgType:    transport.MessageTypeEvent,
+		},
+		{
+			name:       "version not negotiated",
+			negotiated: transport.CheckResultV1,
+			msgType:    transport.MessageTypeCheckResult,
+			version:    transport.CheckResultV2,
+			wantErr:    true,
+		},
+	}
+	encodings := []struct {
+		name      string
+		marshal   MarshalFunc
+		unmarshal UnmarshalFunc
+	}{
+		{"json", MarshalJSON, UnmarshalJSON},
+		{"protobuf", proto.Marshal, proto.Unmarshal},
+	}
+	for _, enc := range encodings {
+		for _, tt := range tests {
+			t.Run(enc.name+" "+tt.name, func(t *testing.T) {
+				bus := &mockbus.MockBus{}
+				bus.On("Publish", messaging.TopicEventRaw, mock.Anything).Return(nil)
+				s := &Session{
+					cfg:       SessionConfig{CheckResultVersion: tt.negotiated},
+					bus:       bus,
+					unmarshal: enc.unmarshal,
+				}
+				s.handler = newSessionHandler(s)
+
+				event := corev2.FixtureEvent("entity", "check")
+				payload, err := enc.marshal(event)
+				require.NoError(t, err)
+				if tt.msgType == transport.MessageTypeCheckResult {
+					payload = transport.EncodeCheckResult(tt.version, payload)
+				}
+
+				err = s.handler.Handle(context.Background(), tt.msgType, payload)
+				if tt.wantErr {
+					assert.Error(t, err)
+					bus.AssertNotCalled(t, "Publish", messaging.TopicEventRaw, mock.Anything)
+					return
+				}
+				require.NoError(t, err)
+				bus.AssertCalled(t, "Publish", messaging.TopicEventRaw, mock.Anything)
+			})
+		}
+	}
+}
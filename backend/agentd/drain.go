@@ -0,0 +1,253 @@
+package agentd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/etcd"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+const (
+	// DefaultDrainRate is the default number of agent sessions stopped per
+	// second while a backend is drained.
+	DefaultDrainRate = 50
+
+	// sessionRetryAfter is the delay, in seconds, advertised to the agents
+	// whose session is rejected before they retry this backend.
+	sessionRetryAfter = 30
+
+	// drainStatusKeyPrefix is the prefix of the etcd keys of the drain
+	// statuses of the backends.
+	drainStatusKeyPrefix = "/sensu.io/agent_drain_statuses/"
+)
+
+var (
+	errDraining    = errors.New("backend is draining its agent sessions")
+	errMaxSessions = errors.New("backend reached its maximum number of agent sessions")
+)
+
+// acquireSession reserves a session slot for a connecting agent, unless the
+// backend is draining or reached its maximum number of sessions.
+func (a *Agentd) acquireSession() error {
+	a.sessionsMu.Lock()
+	defer a.sessionsMu.Unlock()
+	if a.draining {
+		return errDraining
+	}
+	if a.maxSessions > 0 && a.sessionCount >= a.maxSessions {
+		return errMaxSessions
+	}
+	a.sessionCount++
+	return nil
+}
+
+// releaseSession frees the session slot of an agent, and stops tracking its
+// session if it was started.
+func (a *Agentd) releaseSession(session *Session) {
+	a.sessionsMu.Lock()
+	defer a.sessionsMu.Unlock()
+	a.sessionCount--
+	if session != nil {
+		delete(a.sessions, session)
+	}
+}
+
+// trackSession tracks a started session until it stops, so that it can be
+// drained.
+func (a *Agentd) trackSession(session *Session) {
+	a.sessionsMu.Lock()
+	a.sessions[session] = struct{}{}
+	if a.draining {
+		// The backend started draining while the session was starting
+		session.cancel()
+	}
+	a.sessionsMu.Unlock()
+
+	go func() {
+		<-session.ctx.Done()
+		a.releaseSession(session)
+	}()
+}
+
+// watchDrain drains the agent sessions of the backend while its drain is
+// requested through the store, until ctx is done.
+func (a *Agentd) watchDrain(ctx context.Context) {
+	// The watcher is created first so the drains requested while the drain
+	// is loaded are not missed
+	watchChan := a.store.GetAgentDrainWatcher(ctx, a.name)
+	a.loadDrain(ctx)
+
+	go func() {
+		for {
+			select {
+			case event, ok := <-watchChan:
+				if !ok {
+					// The watcher has closed, restart it
+					watchChan = a.store.GetAgentDrainWatcher(ctx, a.name)
+					continue
+				}
+				if event.Action == store.WatchError {
+					// Changes were missed, so the drain is loaded again
+					a.loadDrain(ctx)
+					continue
+				}
+				a.setDraining(event.Draining)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+func (a *Agentd) loadDrain(ctx context.Context) {
+	draining, err := a.store.GetAgentDrain(ctx, a.name)
+	if err != nil {
+		logger.WithError(err).Error("unable to load the drain of the agent sessions")
+		return
+	}
+	a.setDraining(draining)
+}
+
+// setDraining starts or stops draining the agent sessions. While the backend
+// is draining, the new agent sessions are rejected and the current ones are
+// stopped at the drain rate, so that their agents don't all reconnect to the
+// other backends of the cluster at once.
+func (a *Agentd) setDraining(draining bool) {
+	a.sessionsMu.Lock()
+	defer a.sessionsMu.Unlock()
+	if draining == a.draining {
+		return
+	}
+	a.draining = draining
+
+	if !draining {
+		a.stopDrain()
+		a.stopDrain = nil
+		logger.Info("accepting agent sessions")
+		return
+	}
+	ctx, cancel := context.WithCancel(a.ctx)
+	a.stopDrain = cancel
+	go a.stopSessions(ctx)
+	logger.WithField("rate", a.drainRate).Warn("draining agent sessions")
+}
+
+// stopSessions stops the agent sessions at the drain rate until they're all
+// stopped or ctx is done.
+func (a *Agentd) stopSessions(ctx context.Context) {
+	ticker := time.NewTicker(time.Second / time.Duration(a.drainRate))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		if !a.stopSession() {
+			return
+		}
+	}
+}
+
+// stopSession stops one of the agent sessions which are still running, and
+// returns false if none is.
+func (a *Agentd) stopSession() bool {
+	a.sessionsMu.Lock()
+	defer a.sessionsMu.Unlock()
+	for session := range a.sessions {
+		if session.ctx.Err() == nil {
+			session.cancel()
+			return true
+		}
+	}
+	return false
+}
+
+// DrainStatus returns whether the backend is draining and its number of agent
+// sessions.
+func (a *Agentd) DrainStatus() *corev2.DrainStatus {
+	a.sessionsMu.Lock()
+	defer a.sessionsMu.Unlock()
+	return &corev2.DrainStatus{
+		Backend:     a.name,
+		Draining:    a.draining,
+		Sessions:    a.sessionCount,
+		MaxSessions: a.maxSessions,
+	}
+}
+
+// DrainController drains the agent sessions of the backends of the cluster,
+// e.g. before their maintenance, so that their agents reconnect to the other
+// backends. The drains are requested through the store, and the backends keep
+// draining until their drain is stopped.
+type DrainController struct {
+	store  store.AgentDrainStore
+	client *clientv3.Client
+}
+
+// NewDrainController creates a DrainController requesting the drains through
+// the store. The drain statuses recorded by the backends are read with client.
+func NewDrainController(store store.AgentDrainStore, client *clientv3.Client) *DrainController {
+	return &DrainController{
+		store:  store,
+		client: client,
+	}
+}
+
+// Drain starts draining the agent sessions of the backend.
+func (c *DrainController) Drain(ctx context.Context, backend string) error {
+	return c.store.UpdateAgentDrain(ctx, backend, true)
+}
+
+// Resume stops draining the agent sessions of the backend.
+func (c *DrainController) Resume(ctx context.Context, backend string) error {
+	return c.store.UpdateAgentDrain(ctx, backend, false)
+}
+
+// DrainStatuses returns the drain statuses of the running backends and of the
+// drained ones, sorted by backend name. The number of sessions of a backend is
+// the one it last recorded.
+func (c *DrainController) DrainStatuses(ctx context.Context) ([]*corev2.DrainStatus, error) {
+	drains, err := c.store.GetAgentDrains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := etcd.GetBackendReports(ctx, c.client, drainStatusKeyPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]*corev2.DrainStatus, len(reports)+len(drains))
+	for key, report := range reports {
+		status := &corev2.DrainStatus{}
+		if err := json.Unmarshal(report, status); err != nil {
+			logger.WithError(err).WithField("key", key).Error("couldn't decode the drain status of a backend")
+			continue
+		}
+		// The store is authoritative for the drains, which the backends only
+		// report once they are notified of them
+		status.Draining = false
+		statuses[status.Backend] = status
+	}
+	for _, backend := range drains {
+		if _, ok := statuses[backend]; !ok {
+			statuses[backend] = &corev2.DrainStatus{Backend: backend}
+		}
+		statuses[backend].Draining = true
+	}
+
+	sorted := make([]*corev2.DrainStatus, 0, len(statuses))
+	for _, status := range statuses {
+		sorted = append(sorted, status)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Backend < sorted[j].Backend
+	})
+	return sorted, nil
+}
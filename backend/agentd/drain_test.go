@@ -0,0 +1,138 @@
+package agentd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newDrainTestSession() *Session {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Session{ctx: ctx, cancel: cancel}
+}
+
+func newDrainTestAgentd(ctx context.Context) *Agentd {
+	return &Agentd{ctx: ctx, name: "backend-1", drainRate: 10, sessions: make(map[*Session]struct{})}
+}
+
+func TestAgentdMaxSessions(t *testing.T) {
+	a := &Agentd{sessions: make(map[*Session]struct{}), maxSessions: 1}
+	require.NoError(t, a.acquireSession())
+	session := newDrainTestSession()
+	a.trackSession(session)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	a.webSocketHandler(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "30", w.Header().Get("Retry-After"))
+	assert.Contains(t, w.Body.String(), "connect to another backend")
+
+	// The slot is freed when the session stops
+	session.cancel()
+	assert.Eventually(t, func() bool {
+		return a.acquireSession() == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestAgentdDrain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	a := newDrainTestAgentd(ctx)
+	sessions := []*Session{newDrainTestSession(), newDrainTestSession(), newDrainTestSession()}
+	for _, session := range sessions {
+		require.NoError(t, a.acquireSession())
+		a.trackSession(session)
+	}
+	// The slot of a session still starting when the drain begins
+	require.NoError(t, a.acquireSession())
+	assert.Equal(t, 4, a.DrainStatus().Sessions)
+
+	a.setDraining(true)
+	assert.True(t, a.DrainStatus().Draining)
+	assert.Equal(t, errDraining, a.acquireSession())
+
+	// The sessions are stopped gradually
+	start := time.Now()
+	for _, session := range sessions {
+		select {
+		case <-session.ctx.Done():
+		case <-time.After(time.Second):
+			t.Fatal("session not stopped by the drain")
+		}
+	}
+	assert.True(t, time.Since(start) >= 200*time.Millisecond)
+
+	// The session starting during the drain is stopped as well
+	late := newDrainTestSession()
+	a.trackSession(late)
+	assert.Error(t, late.ctx.Err())
+	assert.Eventually(t, func() bool {
+		return a.DrainStatus().Sessions == 0
+	}, time.Second, 10*time.Millisecond)
+
+	a.setDraining(false)
+	assert.False(t, a.DrainStatus().Draining)
+	assert.NoError(t, a.acquireSession())
+}
+
+func TestAgentdResumeDuringDrain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	a := newDrainTestAgentd(ctx)
+	a.drainRate = 1
+	sessions := []*Session{newDrainTestSession(), newDrainTestSession()}
+	for _, session := range sessions {
+		require.NoError(t, a.acquireSession())
+		a.trackSession(session)
+	}
+
+	// The sessions left are kept once the drain is stopped
+	a.setDraining(true)
+	a.setDraining(false)
+	time.Sleep(1500 * time.Millisecond)
+	for _, session := range sessions {
+		assert.NoError(t, session.ctx.Err())
+	}
+}
+
+func TestAgentdWatchDrain(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	watchChan := make(chan store.WatchEventAgentDrain)
+	s := &mockstore.MockStore{}
+	s.On("GetAgentDrainWatcher", mock.Anything, "backend-1").Return((<-chan store.WatchEventAgentDrain)(watchChan))
+	s.On("GetAgentDrain", mock.Anything, "backend-1").Return(true, nil).Once()
+	s.On("GetAgentDrain", mock.Anything, "backend-1").Return(false, nil)
+
+	// The drain requested before the backend started is resumed
+	a := newDrainTestAgentd(ctx)
+	a.store = s
+	a.watchDrain(ctx)
+	assert.True(t, a.DrainStatus().Draining)
+
+	watchChan <- store.WatchEventAgentDrain{Action: store.WatchDelete}
+	assert.Eventually(t, func() bool {
+		return !a.DrainStatus().Draining
+	}, time.Second, 10*time.Millisecond)
+
+	watchChan <- store.WatchEventAgentDrain{Action: store.WatchCreate, Draining: true}
+	assert.Eventually(t, func() bool {
+		return a.DrainStatus().Draining
+	}, time.Second, 10*time.Millisecond)
+
+	// Changes were missed, so the drain is loaded again
+	watchChan <- store.WatchEventAgentDrain{Action: store.WatchError}
+	assert.Eventually(t, func() bool {
+		return !a.DrainStatus().Draining
+	}, time.Second, 10*time.Millisecond)
+}
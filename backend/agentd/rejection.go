@@ -0,0 +1,141 @@
+package agentd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/transport"
+	"github.com/sirupsen/logrus"
+)
+
+// TruncatedOutputNotice is appended to the output of a check that exceeded
+// the maximum check output size.
+const TruncatedOutputNotice = "\n[output truncated by sensu-backend]"
+
+// Rejection is sent to an agent when the backend refuses to accept one of its
+// messages. Rejections are always serialized with JSON, regardless of the
+// content type negotiated by the session.
+type Rejection struct {
+	// MessageType is the type of the rejected message.
+	MessageType string `json:"message_type"`
+
+	// Reason describes why the message was rejected.
+	Reason string `json:"reason"`
+
+	// Size is the size, in bytes, of the rejected payload.
+	Size int `json:"size"`
+
+	// Event is a truncated copy of the rejected event that fits within the
+	// backend limits. The agent is expected to emit it in place of the
+	// rejected event. It is omitted if no such copy could be produced.
+	Event *corev2.Event `json:"event,omitempty"`
+}
+
+// newRejectionMessage returns a transport message for the given rejection.
+func newRejectionMessage(r *Rejection) (*transport.Message, error) {
+	payload, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return transport.NewMessage(transport.MessageTypeRejection, payload), nil
+}
+
+// truncateEvent returns a copy of event whose check output is limited to
+// maxOutputSize bytes and whose metrics are dropped. The status of the check
+// is raised to warning if it was OK, so the truncation is visible to
+// operators.
+func truncateEvent(event *corev2.Event, maxOutputSize int) *corev2.Event {
+	truncated := *event
+	truncated.Metrics = nil
+	if event.Check != nil {
+		check := *event.Check
+		if maxOutputSize > 0 && len(check.Output) > maxOutputSize {
+			cut := maxOutputSize - len(TruncatedOutputNotice)
+			if cut < 0 {
+				cut = 0
+			}
+			check.Output = check.Output[:cut] + TruncatedOutputNotice
+		}
+		if check.Status == 0 {
+			check.Status = 1
+		}
+		truncated.Check = &check
+	}
+	return &truncated
+}
+
+// checkKeepaliveSize verifies that a keepalive payload of the given size fits
+// within the configured limits. A rejection is returned if it doesn't.
+func (s *Session) checkKeepaliveSize(size int) *Rejection {
+	if s.cfg.MaxEventSize <= 0 || size <= s.cfg.MaxEventSize {
+		return nil
+	}
+	return &Rejection{
+		MessageType: transport.MessageTypeKeepalive,
+		Reason:      fmt.Sprintf("keepalive size of %d bytes exceeds the maximum of %d bytes", size, s.cfg.MaxEventSize),
+		Size:        size,
+	}
+}
+
+// checkEventSize verifies that the event, received as a payload of the given
+// size, fits within the configured limits. A rejection is returned if it
+// doesn't.
+func (s *Session) checkEventSize(event *corev2.Event, size int) *Rejection {
+	var reason string
+	if s.cfg.MaxEventSize > 0 && size > s.cfg.MaxEventSize {
+		reason = fmt.Sprintf("event size of %d bytes exceeds the maximum of %d bytes", size, s.cfg.MaxEventSize)
+	} else if s.cfg.MaxCheckOutputSize > 0 && event.HasCheck() && len(event.Check.Output) > s.cfg.MaxCheckOutputSize {
+		reason = fmt.Sprintf("check output size of %d bytes exceeds the maximum of %d bytes", len(event.Check.Output), s.cfg.MaxCheckOutputSize)
+	} else {
+		return nil
+	}
+
+	rejection := &Rejection{
+		MessageType: transport.MessageTypeEvent,
+		Reason:      reason,
+		Size:        size,
+	}
+
+	// Only hand back a truncated event if it is guaranteed to be accepted,
+	// otherwise the agent would keep resending it.
+	maxOutputSize := s.cfg.MaxCheckOutputSize
+	if maxOutputSize <= 0 {
+		// Leave room for the rest of the event
+		maxOutputSize = s.cfg.MaxEventSize / 2
+	}
+	truncated := truncateEvent(event, maxOutputSize)
+	if payload, err := s.marshal(truncated); err == nil {
+		fitsEvent := s.cfg.MaxEventSize <= 0 || len(payload) <= s.cfg.MaxEventSize
+		fitsOutput := s.cfg.MaxCheckOutputSize <= 0 || !truncated.HasCheck() || len(truncated.Check.Output) <= s.cfg.MaxCheckOutputSize
+		if fitsEvent && fitsOutput {
+			rejection.Event = truncated
+		}
+	}
+
+	return rejection
+}
+
+// reject logs the rejection and sends it to the agent. The rejected payload
+// is deliberately not logged.
+func (s *Session) reject(ctx context.Context, r *Rejection) error {
+	logger.WithFields(logrus.Fields{
+		"addr":      s.cfg.AgentAddr,
+		"namespace": s.cfg.Namespace,
+		"agent":     s.cfg.AgentName,
+		"type":      r.MessageType,
+		"size":      r.Size,
+	}).Warn("rejecting message: ", r.Reason)
+
+	msg, err := newRejectionMessage(r)
+	if err != nil {
+		return err
+	}
+	select {
+	case s.sendq <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
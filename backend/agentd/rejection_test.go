@@ -0,0 +1,81 @@
+package agentd
+
+import (
+	"strings"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckEventSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           SessionConfig
+		output        string
+		wantRejection bool
+		wantEvent     bool
+	}{
+		{
+			name:   "no limits",
+			output: strings.Repeat("a", 4096),
+		},
+		{
+			name:   "within limits",
+			cfg:    SessionConfig{MaxEventSize: 8192, MaxCheckOutputSize: 1024},
+			output: "foo",
+		},
+		{
+			name:          "check output too large",
+			cfg:           SessionConfig{MaxCheckOutputSize: 1024},
+			output:        strings.Repeat("a", 4096),
+			wantRejection: true,
+			wantEvent:     true,
+		},
+		{
+			name:          "event too large",
+			cfg:           SessionConfig{MaxEventSize: 2048},
+			output:        strings.Repeat("a", 4096),
+			wantRejection: true,
+			wantEvent:     true,
+		},
+		{
+			name:          "event too large even when truncated",
+			cfg:           SessionConfig{MaxEventSize: 16},
+			output:        "foo",
+			wantRejection: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Session{cfg: tt.cfg, marshal: MarshalJSON}
+			event := corev2.FixtureEvent("entity", "check")
+			event.Check.Output = tt.output
+			payload, err := MarshalJSON(event)
+			require.NoError(t, err)
+
+			rejection := s.checkEventSize(event, len(payload))
+			if !tt.wantRejection {
+				assert.Nil(t, rejection)
+				return
+			}
+			require.NotNil(t, rejection)
+			assert.NotEmpty(t, rejection.Reason)
+			if !tt.wantEvent {
+				assert.Nil(t, rejection.Event)
+				return
+			}
+			require.NotNil(t, rejection.Event)
+			assert.True(t, strings.HasSuffix(rejection.Event.Check.Output, TruncatedOutputNotice))
+			assert.Equal(t, uint32(1), rejection.Event.Check.Status)
+			assert.Equal(t, tt.output, event.Check.Output)
+		})
+	}
+}
+
+func TestCheckKeepaliveSize(t *testing.T) {
+	s := &Session{cfg: SessionConfig{MaxEventSize: 10}}
+	assert.Nil(t, s.checkKeepaliveSize(10))
+	assert.NotNil(t, s.checkKeepaliveSize(11))
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -36,6 +37,10 @@ const ProtobufSerializationHeader = "application/octet-stream"
 // JSONSerializationHeader is the Content-Type header which indicates JSON serialization.
 const JSONSerializationHeader = "application/json"
 
+// deregisteredEventSentinel is the timestamp of the keepalives published to
+// keepalived when ephemeral agents shut down.
+const deregisteredEventSentinel = -2
+
 // MarshalFunc is the function signature for protobuf/JSON marshaling.
 type MarshalFunc = func(pb proto.Message) ([]byte, error)
 
@@ -69,6 +74,14 @@ type Session struct {
 	marshal      MarshalFunc
 	unmarshal    UnmarshalFunc
 
+	// signatureSequence is the sequence number of the last signed message
+	// of the agent
+	signatureSequence uint64
+
+	// backlogSubscriptions are the subscriptions whose check requests are
+	// recorded by the request backlog for the agent
+	backlogSubscriptions []string
+
 	subscriptions chan messaging.Subscription
 }
 
@@ -76,6 +89,9 @@ func newSessionHandler(s *Session) *handler.MessageHandler {
 	handler := handler.NewMessageHandler()
 	handler.AddHandler(transport.MessageTypeKeepalive, s.handleKeepalive)
 	handler.AddHandler(transport.MessageTypeEvent, s.handleEvent)
+	handler.AddHandler(transport.MessageTypeCheckResult, s.handleCheckResult)
+	handler.AddHandler(transport.MessageTypeDeregistration, s.handleDeregistration)
+	handler.AddHandler(transport.MessageTypeSigned, s.handleSigned)
 
 	return handler
 }
@@ -91,6 +107,32 @@ type SessionConfig struct {
 	Subscriptions []string
 	RingPool      *ringv2.Pool
 	WriteTimeout  int
+
+	// MaxEventSize is the maximum size, in bytes, of event and keepalive
+	// payloads accepted from the agent. Zero means unlimited.
+	MaxEventSize int
+
+	// MaxCheckOutputSize is the maximum size, in bytes, of the check output
+	// of events accepted from the agent. Zero means unlimited.
+	MaxCheckOutputSize int
+
+	// RequestBacklog replays the check requests missed by the agent while it
+	// was disconnected. Nil disables the replay.
+	RequestBacklog *RequestBacklog
+
+	// CheckResultVersion is the check result schema version negotiated with
+	// the agent.
+	CheckResultVersion int
+
+	// VerificationKeys verify the signatures of the messages of the agents,
+	// which are only verified with the keys of the agent of the session.
+	// Unsigned messages are rejected if it's not empty.
+	VerificationKeys []*transport.VerificationKey
+
+	// SignatureNonce is the nonce of the session, covered by the signatures
+	// of the messages of the agent so they can't be replayed in another
+	// session.
+	SignatureNonce string
 }
 
 // NewSession creates a new Session object given the triple of a transport
@@ -156,7 +198,7 @@ func (s *Session) receiver() {
 			return
 		}
 		ctx, cancel := context.WithTimeout(s.ctx, time.Duration(s.cfg.WriteTimeout)*time.Second)
-		if err := s.handler.Handle(ctx, msg.Type, msg.Payload); err != nil {
+		if err := s.handle(ctx, msg.Type, msg.Payload); err != nil {
 			logger.WithError(err).WithFields(logrus.Fields{
 				"type":    msg.Type,
 				"payload": string(msg.Payload)}).Error("error handling message")
@@ -182,19 +224,27 @@ func (s *Session) sender() {
 		select {
 		case msg = <-s.sendq:
 		case c := <-s.checkChannel:
-			request, ok := c.(*corev2.CheckRequest)
-			if !ok {
+			switch c := c.(type) {
+			case *corev2.CheckRequest:
+				configBytes, err := s.marshal(c)
+				if err != nil {
+					logger.WithError(err).Error("session failed to serialize check request")
+					continue
+				}
+				msg = transport.NewMessage(corev2.CheckRequestType, configBytes)
+			case *corev2.CheckCancellation:
+				// Check cancellations aren't protobuf messages, so they are
+				// always sent as JSON
+				payload, err := json.Marshal(c)
+				if err != nil {
+					logger.WithError(err).Error("session failed to serialize check cancellation")
+					continue
+				}
+				msg = transport.NewMessage(corev2.CheckCancellationType, payload)
+			default:
 				logger.Error("session received non-config over check channel")
 				continue
 			}
-
-			configBytes, err := s.marshal(request)
-			if err != nil {
-				logger.WithError(err).Error("session failed to serialize check request")
-				continue
-			}
-
-			msg = transport.NewMessage(corev2.CheckRequestType, configBytes)
 		case <-s.ctx.Done():
 			return
 		}
@@ -250,11 +300,85 @@ func (s *Session) Start() (err error) {
 		}
 		s.subscriptions <- subscription
 	}
+	// Replay the missed check requests before closing the subscriptions, which
+	// guarantees that stop() doesn't close the check channel in the meantime
+	s.replayMissedRequests()
 	close(s.subscriptions)
 
+	s.addToRings()
+
 	return nil
 }
 
+// replayMissedRequests queues the check requests issued to the subscriptions
+// of the agent while it was disconnected, if enabled.
+func (s *Session) replayMissedRequests() {
+	backlog := s.cfg.RequestBacklog
+	if backlog == nil {
+		return
+	}
+	subscriptions := make([]string, 0, len(s.cfg.Subscriptions))
+	for _, sub := range s.cfg.Subscriptions {
+		if sub == "" {
+			continue
+		}
+		subscriptions = append(subscriptions, sub)
+	}
+	backlog.Watch(s.cfg.Namespace, subscriptions)
+	s.backlogSubscriptions = subscriptions
+
+	var lastSeen int64
+	ctx := context.WithValue(s.ctx, corev2.NamespaceKey, s.cfg.Namespace)
+	entity, err := s.store.GetEntityByName(ctx, s.cfg.AgentName)
+	if err != nil {
+		logger.WithError(err).Error("unable to get the last seen time of the agent")
+	} else if entity != nil {
+		lastSeen = entity.LastSeen
+	}
+
+	for _, request := range backlog.Missed(s.cfg.Namespace, s.cfg.AgentName, subscriptions, lastSeen) {
+		select {
+		case s.checkChannel <- request:
+			logger.WithFields(logrus.Fields{
+				"agent": s.cfg.AgentName,
+				"check": request.Config.Name,
+			}).Info("replaying missed check request")
+		default:
+			logger.WithField("agent", s.cfg.AgentName).Warn("check channel full, not replaying the remaining missed check requests")
+			return
+		}
+	}
+}
+
+// addToRings adds the agent to the rings of its subscriptions as soon as it
+// connects, so that the rings list the connected entities of each subscription
+// without waiting for the first keepalive. keepalived then keeps the agent in
+// the rings with each keepalive, and the agent is removed from them when it
+// disconnects.
+func (s *Session) addToRings() {
+	if s.ringPool == nil {
+		// Allow ringPool to be nil for the benefit of the tests, see stop()
+		return
+	}
+	for _, sub := range s.cfg.Subscriptions {
+		if sub == "" || strings.HasPrefix(sub, "entity:") {
+			// Entity subscriptions don't get rings
+			continue
+		}
+		ring := s.ringPool.Get(ringv2.Path(s.cfg.Namespace, sub))
+		ctx, cancel := context.WithTimeout(s.ctx, time.Second)
+		err := ring.Add(ctx, s.cfg.AgentName, int64(corev2.DefaultKeepaliveTimeout))
+		cancel()
+		if err != nil {
+			logger.WithError(err).WithFields(logrus.Fields{
+				"namespace":    s.cfg.Namespace,
+				"agent":        s.cfg.AgentName,
+				"subscription": sub,
+			}).Error("unable to add agent to ring")
+		}
+	}
+}
+
 // Stop a running session. This will cause the send and receive loops to
 // shutdown. Blocks until the session has shutdown.
 func (s *Session) Stop() {
@@ -274,6 +398,17 @@ func (s *Session) stop() {
 	sessionCounter.WithLabelValues(s.cfg.Namespace).Dec()
 	s.wg.Wait()
 
+	// Let keepalived forget the agent, which may reconnect to another backend
+	disconnect := &messaging.AgentDisconnect{Namespace: s.cfg.Namespace, Name: s.cfg.AgentName}
+	if err := s.bus.Publish(messaging.TopicKeepalive, disconnect); err != nil {
+		logger.WithError(err).Error("error publishing agent disconnect")
+	}
+
+	if s.cfg.RequestBacklog != nil {
+		s.cfg.RequestBacklog.Disconnect(s.cfg.Namespace, s.cfg.AgentName)
+		s.cfg.RequestBacklog.Unwatch(s.cfg.Namespace, s.backlogSubscriptions)
+	}
+
 	for sub := range s.subscriptions {
 		if err := sub.Cancel(); err != nil {
 			logger.WithError(err).Error("unable to unsubscribe from message bus")
@@ -307,6 +442,10 @@ func (s *Session) stop() {
 
 // handleKeepalive is the keepalive message handler.
 func (s *Session) handleKeepalive(ctx context.Context, payload []byte) error {
+	if rejection := s.checkKeepaliveSize(len(payload)); rejection != nil {
+		return s.reject(ctx, rejection)
+	}
+
 	keepalive := &corev2.Event{}
 	err := s.unmarshal(payload, keepalive)
 	if err != nil {
@@ -327,6 +466,41 @@ func (s *Session) handleKeepalive(ctx context.Context, payload []byte) error {
 	return s.bus.Publish(messaging.TopicKeepalive, keepalive)
 }
 
+// handleDeregistration is the handler of the deregistration messages, sent by
+// ephemeral agents when they shut down.
+func (s *Session) handleDeregistration(ctx context.Context, payload []byte) error {
+	keepalive := &corev2.Event{}
+	if err := s.unmarshal(payload, keepalive); err != nil {
+		return err
+	}
+
+	if err := keepalive.Validate(); err != nil {
+		return err
+	}
+
+	// Agents can only deregister their own entity
+	if keepalive.Entity.Name != s.cfg.AgentName || keepalive.Entity.Namespace != s.cfg.Namespace {
+		return fmt.Errorf("agent %q can't deregister entity %q", s.cfg.AgentName, keepalive.Entity.Name)
+	}
+
+	keepalive.Timestamp = deregisteredEventSentinel
+
+	return s.bus.Publish(messaging.TopicKeepalive, keepalive)
+}
+
+// handleCheckResult is the handler of the check_result messages, which hold
+// events encoded with a versioned check result schema.
+func (s *Session) handleCheckResult(ctx context.Context, payload []byte) error {
+	version, event, err := transport.DecodeCheckResult(payload)
+	if err != nil {
+		return err
+	}
+	if version > s.cfg.CheckResultVersion {
+		return fmt.Errorf("check result schema version %d was not negotiated with the agent", version)
+	}
+	return s.handleEvent(ctx, event)
+}
+
 // handleEvent is the event message handler.
 func (s *Session) handleEvent(ctx context.Context, payload []byte) error {
 	// Decode the payload to an event
@@ -340,6 +514,11 @@ func (s *Session) handleEvent(ctx context.Context, payload []byte) error {
 		return err
 	}
 
+	// Refuse events that exceed the configured size limits
+	if rejection := s.checkEventSize(event, len(payload)); rejection != nil {
+		return s.reject(ctx, rejection)
+	}
+
 	// Add the entity subscription to the subscriptions of this entity
 	event.Entity.Subscriptions = addEntitySubscription(event.Entity.Name, event.Entity.Subscriptions)
 
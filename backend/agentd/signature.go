@@ -0,0 +1,50 @@
+package agentd
+
+import (
+	"context"
+
+	"github.com/sensu/sensu-go/transport"
+)
+
+// handle dispatches a message received from the agent to its handler. The
+// messages must be signed when the session has verification keys, so that
+// results injected by a third party are rejected.
+func (s *Session) handle(ctx context.Context, msgType string, payload []byte) error {
+	if len(s.cfg.VerificationKeys) > 0 && msgType != transport.MessageTypeSigned {
+		return s.reject(ctx, &Rejection{
+			MessageType: msgType,
+			Reason:      "unsigned message, the backend requires signed messages",
+			Size:        len(payload),
+		})
+	}
+	return s.handler.Handle(ctx, msgType, payload)
+}
+
+// handleSigned is the signed message handler. It verifies the signature of
+// the wrapped message with the keys of the agent of the session, and rejects
+// the messages replayed within the session, before dispatching it to its
+// handler.
+func (s *Session) handleSigned(ctx context.Context, payload []byte) error {
+	msgType, msg, sequence, err := transport.VerifySignedMessage(payload, s.cfg.AgentName, s.cfg.SignatureNonce, s.cfg.VerificationKeys)
+	if err == transport.ErrInvalidSignature {
+		return s.reject(ctx, &Rejection{
+			MessageType: msgType,
+			Reason:      err.Error(),
+			Size:        len(payload),
+		})
+	}
+	if err != nil {
+		return err
+	}
+	if len(s.cfg.VerificationKeys) > 0 {
+		if sequence <= s.signatureSequence {
+			return s.reject(ctx, &Rejection{
+				MessageType: msgType,
+				Reason:      "replayed message, its sequence number doesn't increase",
+				Size:        len(payload),
+			})
+		}
+		s.signatureSequence = sequence
+	}
+	return s.handler.Handle(ctx, msgType, msg)
+}
@@ -0,0 +1,120 @@
+package agentd
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sensu/sensu-go/handler"
+	"github.com/sensu/sensu-go/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionSignedMessages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "agentd")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	secretPath := filepath.Join(dir, "secret")
+	require.NoError(t, ioutil.WriteFile(secretPath, []byte("s3cr3t"), 0600))
+	otherPath := filepath.Join(dir, "other")
+	require.NoError(t, ioutil.WriteFile(otherPath, []byte("other"), 0600))
+
+	verificationKey, err := transport.LoadVerificationKey("agent1", secretPath)
+	require.NoError(t, err)
+	otherAgentKey, err := transport.LoadVerificationKey("agent2", otherPath)
+	require.NoError(t, err)
+	keys := []*transport.VerificationKey{verificationKey, otherAgentKey}
+	signingKey, err := transport.LoadSigningKey(secretPath)
+	require.NoError(t, err)
+	otherKey, err := transport.LoadSigningKey(otherPath)
+	require.NoError(t, err)
+
+	event := transport.NewMessage(transport.MessageTypeEvent, []byte("event"))
+	tests := []struct {
+		name          string
+		keys          []*transport.VerificationKey
+		msg           *transport.Message
+		wantHandled   bool
+		wantRejection bool
+	}{
+		{"unsigned without keys", nil, event, true, false},
+		{"signed without keys", nil, signingKey.Sign(event, "nonce", 1), true, false},
+		{"signed", keys, signingKey.Sign(event, "nonce", 1), true, false},
+		{"unsigned", keys, event, false, true},
+		{"signed with the key of another agent", keys, otherKey.Sign(event, "nonce", 1), false, true},
+		{"signed for another session", keys, signingKey.Sign(event, "other", 1), false, true},
+		{"replayed", keys, signingKey.Sign(event, "nonce", 0), false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSignatureTestSession(tt.keys)
+			var handled []byte
+			s.handler.AddHandler(transport.MessageTypeEvent, func(ctx context.Context, payload []byte) error {
+				handled = payload
+				return nil
+			})
+
+			require.NoError(t, s.handle(context.Background(), tt.msg.Type, tt.msg.Payload))
+			if tt.wantHandled {
+				assert.Equal(t, "event", string(handled))
+			} else {
+				assert.Nil(t, handled)
+			}
+			if !tt.wantRejection {
+				assert.Empty(t, s.sendq)
+				return
+			}
+			require.Len(t, s.sendq, 1)
+			msg := <-s.sendq
+			assert.Equal(t, transport.MessageTypeRejection, msg.Type)
+			var rejection Rejection
+			require.NoError(t, json.Unmarshal(msg.Payload, &rejection))
+			assert.Equal(t, transport.MessageTypeEvent, rejection.MessageType)
+		})
+	}
+}
+
+func newSignatureTestSession(keys []*transport.VerificationKey) *Session {
+	s := &Session{
+		cfg:   SessionConfig{AgentName: "agent1", SignatureNonce: "nonce", VerificationKeys: keys},
+		sendq: make(chan *transport.Message, 1),
+	}
+	s.handler = handler.NewMessageHandler()
+	s.handler.AddHandler(transport.MessageTypeSigned, s.handleSigned)
+	return s
+}
+
+func TestSessionSignedMessagesReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "agentd")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	secretPath := filepath.Join(dir, "secret")
+	require.NoError(t, ioutil.WriteFile(secretPath, []byte("s3cr3t"), 0600))
+	verificationKey, err := transport.LoadVerificationKey("agent1", secretPath)
+	require.NoError(t, err)
+	signingKey, err := transport.LoadSigningKey(secretPath)
+	require.NoError(t, err)
+
+	s := newSignatureTestSession([]*transport.VerificationKey{verificationKey})
+	var handled int
+	s.handler.AddHandler(transport.MessageTypeEvent, func(ctx context.Context, payload []byte) error {
+		handled++
+		return nil
+	})
+
+	first := signingKey.Sign(transport.NewMessage(transport.MessageTypeEvent, []byte("event")), "nonce", 1)
+	second := signingKey.Sign(transport.NewMessage(transport.MessageTypeEvent, []byte("event")), "nonce", 2)
+	require.NoError(t, s.handle(context.Background(), first.Type, first.Payload))
+	require.NoError(t, s.handle(context.Background(), second.Type, second.Payload))
+	assert.Equal(t, 2, handled)
+	assert.Empty(t, s.sendq)
+
+	// A captured message can't be replayed within the session
+	require.NoError(t, s.handle(context.Background(), first.Type, first.Payload))
+	assert.Equal(t, 2, handled)
+	require.Len(t, s.sendq, 1)
+}
@@ -9,31 +9,37 @@ import (
 	"github.com/sensu/sensu-go/backend/store"
 )
 
+// SilencedController represents the controller needs of the SilencedClient.
+type SilencedController interface {
+	CreateOrReplace(context.Context, *corev2.Silenced) error
+}
+
 // SilencedClient is an API client for silencing checks.
 type SilencedClient struct {
-	store store.SilencedStore
-	auth  authorization.Authorizer
+	store      store.SilencedStore
+	controller SilencedController
+	auth       authorization.Authorizer
 }
 
-// NewSilencedClient creates a new SilencedClient, given a store and authorizer.
-func NewSilencedClient(store store.SilencedStore, auth authorization.Authorizer) *SilencedClient {
+// NewSilencedClient creates a new SilencedClient, given a store, a controller,
+// and an authorizer.
+func NewSilencedClient(store store.SilencedStore, controller SilencedController, auth authorization.Authorizer) *SilencedClient {
 	return &SilencedClient{
-		store: store,
-		auth:  auth,
+		store:      store,
+		controller: controller,
+		auth:       auth,
 	}
 }
 
-// UpdateSilenced updates a silenced entry, if authorized.
+// UpdateSilenced updates a silenced entry, if authorized. The entry is
+// validated by the controller, like the ones created through the REST API.
 func (s *SilencedClient) UpdateSilenced(ctx context.Context, silenced *corev2.Silenced) error {
 	silenced.Prepare(ctx)
-	if err := silenced.Validate(); err != nil {
-		return fmt.Errorf("couldn't update silenced entry: %s", err)
-	}
 	attrs := silencedUpdateAttrs(ctx, silenced.Name)
 	if err := authorize(ctx, s.auth, attrs); err != nil {
 		return err
 	}
-	if err := s.store.UpdateSilencedEntry(ctx, silenced); err != nil {
+	if err := s.controller.CreateOrReplace(ctx, silenced); err != nil {
 		return fmt.Errorf("couldn't update silenced entry: %s", err)
 	}
 	return nil
@@ -2,6 +2,7 @@ package api
 
 import (
 	"context"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -15,6 +16,14 @@ import (
 
 var defaultSilenced = corev2.FixtureSilenced("default:default")
 
+type mockSilencedController struct {
+	mock.Mock
+}
+
+func (m *mockSilencedController) CreateOrReplace(ctx context.Context, silenced *corev2.Silenced) error {
+	return m.Called(ctx, silenced).Error(0)
+}
+
 func TestListSilenceds(t *testing.T) {
 	tests := []struct {
 		Name   string
@@ -119,7 +128,7 @@ func TestListSilenceds(t *testing.T) {
 			ctx := test.Ctx()
 			store := test.Store()
 			auth := test.Auth()
-			client := NewSilencedClient(store, auth)
+			client := NewSilencedClient(store, new(mockSilencedController), auth)
 			silenceds, err := client.ListSilenced(ctx)
 			if err != nil && !test.ExpErr {
 				t.Fatal(err)
@@ -241,7 +250,7 @@ func TestGetSilenced(t *testing.T) {
 			ctx := test.Ctx()
 			store := test.Store()
 			auth := test.Auth()
-			client := NewSilencedClient(store, auth)
+			client := NewSilencedClient(store, new(mockSilencedController), auth)
 			silenceds, err := client.GetSilencedByName(ctx, "default:default")
 			if err != nil && !test.ExpErr {
 				t.Fatal(err)
@@ -258,11 +267,12 @@ func TestGetSilenced(t *testing.T) {
 
 func TestUpdateSilenced(t *testing.T) {
 	tests := []struct {
-		Name   string
-		Ctx    func() context.Context
-		Store  func() store.Store
-		Auth   func() authorization.Authorizer
-		ExpErr bool
+		Name       string
+		Ctx        func() context.Context
+		Store      func() store.Store
+		Auth       func() authorization.Authorizer
+		Controller func() SilencedController
+		ExpErr     bool
 	}{
 		{
 			Name: "no auth",
@@ -274,6 +284,9 @@ func TestUpdateSilenced(t *testing.T) {
 			Auth: func() authorization.Authorizer {
 				return &rbac.Authorizer{}
 			},
+			Controller: func() SilencedController {
+				return new(mockSilencedController)
+			},
 			ExpErr: true,
 		},
 		{
@@ -300,6 +313,9 @@ func TestUpdateSilenced(t *testing.T) {
 				}
 				return auth
 			},
+			Controller: func() SilencedController {
+				return new(mockSilencedController)
+			},
 			ExpErr: true,
 		},
 		{
@@ -326,6 +342,9 @@ func TestUpdateSilenced(t *testing.T) {
 				}
 				return auth
 			},
+			Controller: func() SilencedController {
+				return new(mockSilencedController)
+			},
 			ExpErr: true,
 		},
 		{
@@ -334,9 +353,37 @@ func TestUpdateSilenced(t *testing.T) {
 				return contextWithUser(defaultContext(), "legit", nil)
 			},
 			Store: func() store.Store {
-				store := new(mockstore.MockStore)
-				store.On("UpdateSilencedEntry", mock.Anything, defaultSilenced).Return(nil)
-				return store
+				return new(mockstore.MockStore)
+			},
+			Auth: func() authorization.Authorizer {
+				auth := &mockAuth{
+					attrs: map[authorization.AttributesKey]bool{
+						authorization.AttributesKey{
+							APIGroup:     "core",
+							APIVersion:   "v2",
+							Namespace:    "default",
+							Resource:     "silenced",
+							ResourceName: "default:default",
+							UserName:     "legit",
+							Verb:         "update",
+						}: true,
+					},
+				}
+				return auth
+			},
+			Controller: func() SilencedController {
+				ctrl := new(mockSilencedController)
+				ctrl.On("CreateOrReplace", mock.Anything, defaultSilenced).Return(nil)
+				return ctrl
+			},
+		},
+		{
+			Name: "invalid entry",
+			Ctx: func() context.Context {
+				return contextWithUser(defaultContext(), "legit", nil)
+			},
+			Store: func() store.Store {
+				return new(mockstore.MockStore)
 			},
 			Auth: func() authorization.Authorizer {
 				auth := &mockAuth{
@@ -354,6 +401,12 @@ func TestUpdateSilenced(t *testing.T) {
 				}
 				return auth
 			},
+			Controller: func() SilencedController {
+				ctrl := new(mockSilencedController)
+				ctrl.On("CreateOrReplace", mock.Anything, defaultSilenced).Return(errors.New("a reason is required for silenced entries"))
+				return ctrl
+			},
+			ExpErr: true,
 		},
 	}
 	for _, test := range tests {
@@ -361,7 +414,8 @@ func TestUpdateSilenced(t *testing.T) {
 			ctx := test.Ctx()
 			store := test.Store()
 			auth := test.Auth()
-			client := NewSilencedClient(store, auth)
+			ctrl := test.Controller()
+			client := NewSilencedClient(store, ctrl, auth)
 			err := client.UpdateSilenced(ctx, defaultSilenced)
 			if err != nil && !test.ExpErr {
 				t.Fatal(err)
@@ -480,7 +534,7 @@ func TestDeleteSilenced(t *testing.T) {
 			ctx := test.Ctx()
 			store := test.Store()
 			auth := test.Auth()
-			client := NewSilencedClient(store, auth)
+			client := NewSilencedClient(store, new(mockSilencedController), auth)
 			err := client.DeleteSilencedByName(ctx, "default:default")
 			if err != nil && !test.ExpErr {
 				t.Fatal(err)
@@ -599,7 +653,7 @@ func TestGetSilencedByCheckName(t *testing.T) {
 			ctx := test.Ctx()
 			store := test.Store()
 			auth := test.Auth()
-			client := NewSilencedClient(store, auth)
+			client := NewSilencedClient(store, new(mockSilencedController), auth)
 			silenceds, err := client.GetSilencedByCheckName(ctx, "default")
 			if err != nil && !test.ExpErr {
 				t.Fatal(err)
@@ -720,7 +774,7 @@ func TestGetSilencedBySubscription(t *testing.T) {
 			ctx := test.Ctx()
 			store := test.Store()
 			auth := test.Auth()
-			client := NewSilencedClient(store, auth)
+			client := NewSilencedClient(store, new(mockSilencedController), auth)
 			silenceds, err := client.GetSilencedBySubscription(ctx, "default")
 			if err != nil && !test.ExpErr {
 				t.Fatal(err)
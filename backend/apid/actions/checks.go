@@ -2,10 +2,12 @@ package actions
 
 import (
 	"encoding/json"
+	"time"
 
 	"context"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/schedulerd"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/types"
 	utilstrings "github.com/sensu/sensu-go/util/strings"
@@ -13,19 +15,31 @@ import (
 
 var (
 	adhocQueueName = "adhocRequest"
+
+	// maxSchedulePreviewWindow is the longest window for which the schedule
+	// of a check can be previewed
+	maxSchedulePreviewWindow = 30 * 24 * time.Hour
 )
 
 // CheckController exposes actions which a viewer can perform.
 type CheckController struct {
-	store      store.CheckConfigStore
-	checkQueue types.Queue
+	store         store.CheckConfigStore
+	hookStore     store.HookConfigStore
+	entityStore   store.EntityStore
+	resourceStore store.ResourceStore
+	txnStore      store.ResourceTxnStore
+	checkQueue    types.Queue
 }
 
 // NewCheckController returns new CheckController
-func NewCheckController(store store.CheckConfigStore, getter types.QueueGetter) CheckController {
+func NewCheckController(store store.Store, getter types.QueueGetter) CheckController {
 	return CheckController{
-		store:      store,
-		checkQueue: getter.GetQueue(adhocQueueName),
+		store:         store,
+		hookStore:     store,
+		entityStore:   store,
+		resourceStore: store,
+		txnStore:      store,
+		checkQueue:    getter.GetQueue(adhocQueueName),
 	}
 }
 
@@ -47,6 +61,22 @@ func (a CheckController) Find(ctx context.Context, name string) (*corev2.CheckCo
 
 // AddCheckHook adds an association between a hook and a check
 func (a CheckController) AddCheckHook(ctx context.Context, check string, checkHook corev2.HookList) error {
+	if err := checkHook.Validate(); err != nil {
+		return NewError(InvalidArgument, err)
+	}
+
+	// Make sure the hooks exist, so the check doesn't silently reference hooks
+	// that will never be executed
+	for _, name := range checkHook.Hooks {
+		hook, err := a.hookStore.GetHookConfigByName(ctx, name)
+		if err != nil {
+			return NewError(InternalErr, err)
+		}
+		if hook == nil {
+			return NewErrorf(InvalidArgument, "hook %q does not exist", name)
+		}
+	}
+
 	return a.findAndUpdateCheckConfig(ctx, check, func(check *corev2.CheckConfig) error {
 		var exists bool
 		for i, r := range check.CheckHooks {
@@ -133,13 +163,83 @@ func (a CheckController) findAndUpdateCheckConfig(
 
 	// Validate
 	if err := check.Validate(); err != nil {
-		return NewError(InvalidArgument, err)
+		return NewValidationError(err)
 	}
 
 	// Update
 	return a.updateCheckConfig(ctx, check)
 }
 
+// SetPublish enables or disables the scheduling of the checks selected by the
+// request, and returns the names of the checks updated. Each check is updated
+// at the version it was read at, so that concurrent changes of the checks are
+// not overwritten. The checks are updated in transactions of at most
+// store.MaxResourceOps operations, so the checks of the transactions
+// committed before a failing one stay updated.
+func (a CheckController) SetPublish(ctx context.Context, req *corev2.CheckPublishRequest) (*corev2.CheckPublishResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewError(InvalidArgument, err)
+	}
+
+	names := req.Names
+	if req.All {
+		checks, err := a.store.GetCheckConfigs(ctx, &store.SelectionPredicate{})
+		if err != nil {
+			return nil, NewError(InternalErr, err)
+		}
+		names = nil
+		for _, check := range checks {
+			if req.Matches(check) && check.Publish != req.Publish {
+				names = append(names, check.Name)
+			}
+		}
+	}
+
+	response := &corev2.CheckPublishResponse{Checks: []string{}}
+	ops := []store.ResourceOp{}
+	for _, name := range names {
+		check := &corev2.CheckConfig{}
+		version, err := a.txnStore.GetVersionedResource(ctx, name, check)
+		if err != nil {
+			if _, ok := err.(*store.ErrNotFound); ok {
+				if req.All {
+					// The check was deleted since it was listed
+					continue
+				}
+				return nil, NewErrorf(NotFound, "check %q does not exist", name)
+			}
+			return nil, NewError(InternalErr, err)
+		}
+		if !req.Matches(check) || check.Publish == req.Publish {
+			continue
+		}
+		check.Publish = req.Publish
+		ops = append(ops, store.ResourceOp{Resource: check, Version: version})
+		response.Checks = append(response.Checks, check.Name)
+	}
+
+	// The namespace of the checks is compared in each transaction too
+	for len(ops) > 0 {
+		n := len(ops)
+		if n > store.MaxResourceOps-1 {
+			n = store.MaxResourceOps - 1
+		}
+		if err := a.txnStore.CommitResources(ctx, ops[:n]); err != nil {
+			switch err := err.(type) {
+			case *store.ErrConflict:
+				return nil, NewError(FailedPrecondition, err)
+			case *store.ErrNotValid:
+				return nil, NewValidationError(err)
+			default:
+				return nil, NewError(InternalErr, err)
+			}
+		}
+		ops = ops[n:]
+	}
+
+	return response, nil
+}
+
 // QueueAdhocRequest takes a check request and adds it to the queue for
 // processing.
 func (a CheckController) QueueAdhocRequest(ctx context.Context, name string, adhocRequest *corev2.AdhocRequest) error {
@@ -165,3 +265,43 @@ func (a CheckController) QueueAdhocRequest(ctx context.Context, name string, adh
 	err = a.checkQueue.Enqueue(ctx, string(marshaledCheck))
 	return err
 }
+
+// SchedulePreview computes the executions of the check scheduled in the given
+// window, starting now, the way schedulerd schedules them.
+func (a CheckController) SchedulePreview(ctx context.Context, name string, window time.Duration) (*corev2.CheckSchedulePreview, error) {
+	if window <= 0 || window > maxSchedulePreviewWindow {
+		return nil, NewErrorf(InvalidArgument, "window must be between 0 and %s", maxSchedulePreviewWindow)
+	}
+
+	check, err := a.Find(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if check.Template != "" {
+		template := &corev2.CheckTemplate{}
+		if err := a.resourceStore.GetResource(ctx, check.Template, template); err != nil {
+			if _, ok := err.(*store.ErrNotFound); ok {
+				return nil, NewErrorf(InvalidArgument, "check template %q not found", check.Template)
+			}
+			return nil, NewError(InternalErr, err)
+		}
+		check = template.Apply(check)
+	}
+
+	// The entities are only needed to match the proxy entities of the check
+	var entities []*corev2.Entity
+	if check.ProxyRequests != nil {
+		entities, err = a.entityStore.GetEntities(ctx, &store.SelectionPredicate{})
+		if err != nil {
+			return nil, NewError(InternalErr, err)
+		}
+	}
+
+	start := time.Now()
+	preview, err := schedulerd.PreviewSchedule(check, entities, start, start.Add(window))
+	if err != nil {
+		return nil, NewError(InvalidArgument, err)
+	}
+	return preview, nil
+}
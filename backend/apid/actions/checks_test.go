@@ -3,14 +3,18 @@ package actions
 import (
 	"context"
 	"testing"
+	"time"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/queue"
+	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/mockqueue"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/sensu/sensu-go/testing/testutil"
 	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewCheckController(t *testing.T) {
@@ -90,3 +94,246 @@ func TestCheckAdhoc(t *testing.T) {
 	}
 
 }
+
+func TestCheckAddCheckHook(t *testing.T) {
+	ctx := testutil.NewContext(testutil.ContextWithNamespace("default"))
+
+	testCases := []struct {
+		name            string
+		hookList        corev2.HookList
+		hook            *corev2.HookConfig
+		expectedErrCode ErrCode
+		expectedErr     bool
+	}{
+		{
+			name:     "existing hook",
+			hookList: *corev2.FixtureHookList("hook1"),
+			hook:     corev2.FixtureHookConfig("hook1"),
+		},
+		{
+			name:            "missing hook",
+			hookList:        *corev2.FixtureHookList("hook1"),
+			expectedErr:     true,
+			expectedErrCode: InvalidArgument,
+		},
+		{
+			name:            "invalid type",
+			hookList:        corev2.HookList{Type: "foo", Hooks: []string{"hook1"}},
+			hook:            corev2.FixtureHookConfig("hook1"),
+			expectedErr:     true,
+			expectedErrCode: InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := &mockstore.MockStore{}
+			getter := &mockqueue.Getter{}
+			getter.On("GetQueue", mock.Anything).Return(&mockqueue.MockQueue{})
+			actions := NewCheckController(store, getter)
+
+			store.On("GetHookConfigByName", mock.Anything, "hook1").Return(tc.hook, nil)
+			store.On("GetCheckConfigByName", mock.Anything, "check1").Return(corev2.FixtureCheckConfig("check1"), nil)
+			store.On("UpdateCheckConfig", mock.Anything, mock.Anything).Return(nil)
+
+			err := actions.AddCheckHook(ctx, "check1", tc.hookList)
+			if tc.expectedErr {
+				inferErr, ok := err.(Error)
+				if assert.True(t, ok, "error should be of type Error") {
+					assert.Equal(t, tc.expectedErrCode, inferErr.Code)
+				}
+				store.AssertNotCalled(t, "UpdateCheckConfig", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			store.AssertCalled(t, "UpdateCheckConfig", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCheckSetPublish(t *testing.T) {
+	ctx := testutil.NewContext(testutil.ContextWithNamespace("default"))
+
+	web := corev2.FixtureCheckConfig("web")
+	web.Subscriptions = []string{"web"}
+	db := corev2.FixtureCheckConfig("db")
+	db.Subscriptions = []string{"db"}
+	unpublished := corev2.FixtureCheckConfig("unpublished")
+	unpublished.Subscriptions = []string{"web"}
+	unpublished.Publish = false
+
+	testCases := []struct {
+		name            string
+		req             *corev2.CheckPublishRequest
+		expectedChecks  []string
+		expectedErrCode ErrCode
+		expectedErr     bool
+	}{
+		{
+			name:           "by name",
+			req:            &corev2.CheckPublishRequest{Names: []string{"db"}},
+			expectedChecks: []string{"db"},
+		},
+		{
+			name:           "all checks with a subscription",
+			req:            &corev2.CheckPublishRequest{All: true, Subscription: "web"},
+			expectedChecks: []string{"web"},
+		},
+		{
+			name:            "missing check",
+			req:             &corev2.CheckPublishRequest{Names: []string{"missing"}},
+			expectedErr:     true,
+			expectedErrCode: NotFound,
+		},
+		{
+			name:            "no checks selected",
+			req:             &corev2.CheckPublishRequest{},
+			expectedErr:     true,
+			expectedErrCode: InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockstore.MockStore{}
+			getter := &mockqueue.Getter{}
+			getter.On("GetQueue", mock.Anything).Return(&mockqueue.MockQueue{})
+			actions := NewCheckController(st, getter)
+
+			checks := []*corev2.CheckConfig{web, db, unpublished}
+			st.On("GetCheckConfigs", mock.Anything, mock.Anything).Return(checks, nil)
+			for _, check := range checks {
+				check := check
+				st.On("GetVersionedResource", mock.Anything, check.Name, mock.Anything).Run(func(args mock.Arguments) {
+					*args.Get(2).(*corev2.CheckConfig) = *check
+				}).Return(int64(42), nil)
+			}
+			st.On("GetVersionedResource", mock.Anything, "missing", mock.Anything).Return(int64(0), &store.ErrNotFound{})
+			var committed []store.ResourceOp
+			st.On("CommitResources", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				committed = append(committed, args.Get(1).([]store.ResourceOp)...)
+			}).Return(nil)
+
+			response, err := actions.SetPublish(ctx, tc.req)
+			if tc.expectedErr {
+				inferErr, ok := err.(Error)
+				if assert.True(t, ok, "error should be of type Error") {
+					assert.Equal(t, tc.expectedErrCode, inferErr.Code)
+				}
+				st.AssertNotCalled(t, "CommitResources", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedChecks, response.Checks)
+
+			// The checks are updated at the version they were read at
+			require.Len(t, committed, len(tc.expectedChecks))
+			for _, op := range committed {
+				assert.Equal(t, int64(42), op.Version)
+				assert.Equal(t, tc.req.Publish, op.Resource.(*corev2.CheckConfig).Publish)
+			}
+		})
+	}
+}
+
+func TestCheckSchedulePreview(t *testing.T) {
+	ctx := testutil.NewContext(testutil.ContextWithNamespace("default"))
+
+	check := corev2.FixtureCheckConfig("check1")
+	check.Interval = 60
+	proxy := corev2.FixtureCheckConfig("proxy")
+	proxy.ProxyRequests = corev2.FixtureProxyRequests(false)
+	proxy.ProxyRequests.EntityAttributes = []string{`entity.name == "router"`}
+	templated := corev2.FixtureCheckConfig("templated")
+	templated.Interval = 0
+	templated.Template = "linux"
+	orphan := corev2.FixtureCheckConfig("orphan")
+	orphan.Interval = 0
+	orphan.Template = "missing"
+	entities := []*corev2.Entity{corev2.FixtureEntity("router"), corev2.FixtureEntity("switch")}
+
+	testCases := []struct {
+		name               string
+		check              string
+		window             time.Duration
+		expectedExecutions int
+		expectedErrCode    ErrCode
+		expectedErr        bool
+	}{
+		{
+			name:               "interval check",
+			check:              "check1",
+			window:             time.Hour,
+			expectedExecutions: 60,
+		},
+		{
+			name:               "proxy check",
+			check:              "proxy",
+			window:             time.Hour,
+			expectedExecutions: 60,
+		},
+		{
+			name:               "check inheriting its interval",
+			check:              "templated",
+			window:             time.Hour,
+			expectedExecutions: 120,
+		},
+		{
+			name:            "missing check template",
+			check:           "orphan",
+			window:          time.Hour,
+			expectedErr:     true,
+			expectedErrCode: InvalidArgument,
+		},
+		{
+			name:            "missing check",
+			check:           "missing",
+			window:          time.Hour,
+			expectedErr:     true,
+			expectedErrCode: NotFound,
+		},
+		{
+			name:            "window too long",
+			check:           "check1",
+			window:          365 * 24 * time.Hour,
+			expectedErr:     true,
+			expectedErrCode: InvalidArgument,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			st := &mockstore.MockStore{}
+			getter := &mockqueue.Getter{}
+			getter.On("GetQueue", mock.Anything).Return(&mockqueue.MockQueue{})
+			actions := NewCheckController(st, getter)
+
+			var nilCheck *corev2.CheckConfig
+			st.On("GetCheckConfigByName", mock.Anything, "check1").Return(check, nil)
+			st.On("GetCheckConfigByName", mock.Anything, "proxy").Return(proxy, nil)
+			st.On("GetCheckConfigByName", mock.Anything, "missing").Return(nilCheck, nil)
+			st.On("GetCheckConfigByName", mock.Anything, "templated").Return(templated, nil)
+			st.On("GetCheckConfigByName", mock.Anything, "orphan").Return(orphan, nil)
+			st.On("GetEntities", mock.Anything, mock.Anything).Return(entities, nil)
+			st.On("GetResource", mock.Anything, "linux", mock.AnythingOfType("*v2.CheckTemplate")).Return(nil).
+				Run(func(args mock.Arguments) {
+					template := args[2].(*corev2.CheckTemplate)
+					*template = *corev2.FixtureCheckTemplate("linux")
+					template.Interval = 30
+				})
+			st.On("GetResource", mock.Anything, "missing", mock.AnythingOfType("*v2.CheckTemplate")).Return(&store.ErrNotFound{})
+
+			preview, err := actions.SchedulePreview(ctx, tc.check, tc.window)
+			if tc.expectedErr {
+				inferErr, ok := err.(Error)
+				if assert.True(t, ok, "error should be of type Error") {
+					assert.Equal(t, tc.expectedErrCode, inferErr.Code)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.check, preview.Check)
+			assert.Len(t, preview.Executions, tc.expectedExecutions)
+		})
+	}
+}
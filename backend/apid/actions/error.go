@@ -1,6 +1,11 @@
 package actions
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
 
 //
 // Following defines error type w/ error codes. Helpful for
@@ -42,17 +47,38 @@ const (
 	// PaymentRequired is used when the user tries to use a feature that's gated
 	// behind a license.
 	PaymentRequired
+
+	// TooManyRequests is used when the viewer exceeded the rate of requests, or
+	// the number of concurrent requests, allowed by the API.
+	TooManyRequests
+
+	// FailedPrecondition means that the action was refused because of the
+	// state of the system, e.g. deleting a resource still referenced by other
+	// resources.
+	FailedPrecondition
+
+	// NotModified means that the requested resource did not change since the
+	// viewer last retrieved it, according to the conditions of the request.
+	NotModified
+
+	// UnprocessableEntity means that the request is well formed but can't be
+	// processed, e.g. an idempotency key reused with another request body.
+	UnprocessableEntity
 )
 
 // Default error messages if not message is provided.
 var standardErrorMessages = map[ErrCode]string{
-	InternalErr:      "internal error occurred",
-	InvalidArgument:  "invalid argument(s) received",
-	NotFound:         "not found",
-	AlreadyExistsErr: "resource already exists",
-	PermissionDenied: "unauthorized to perform action",
-	Unauthenticated:  "unauthenticated",
-	PaymentRequired:  "license required",
+	InternalErr:         "internal error occurred",
+	InvalidArgument:     "invalid argument(s) received",
+	NotFound:            "not found",
+	AlreadyExistsErr:    "resource already exists",
+	PermissionDenied:    "unauthorized to perform action",
+	Unauthenticated:     "unauthenticated",
+	PaymentRequired:     "license required",
+	TooManyRequests:     "too many requests",
+	FailedPrecondition:  "failed precondition",
+	NotModified:         "not modified",
+	UnprocessableEntity: "unprocessable entity",
 }
 
 // Error describes an issue that ocurred while performing the action.
@@ -63,6 +89,9 @@ type Error struct {
 	// Message is a developer / operator friendly message briefly describing what
 	// occurred.
 	Message string
+	// Fields describes the invalid fields of the resource, for InvalidArgument
+	// errors caused by its validation.
+	Fields []corev2.FieldError `json:"fields,omitempty"`
 }
 
 // Error method implements error interface
@@ -86,6 +115,17 @@ func NewErrorf(code ErrCode, s ...interface{}) Error {
 	return Error{Code: code, Message: fmt.Sprintf(f, s...)}
 }
 
+// NewValidationError returns a new InvalidArgument Error given the validation
+// error of a resource, which describes the invalid field, if known.
+func NewValidationError(err error) Error {
+	e := Error{Code: InvalidArgument, Message: err.Error()}
+	var fieldErr *corev2.FieldError
+	if errors.As(err, &fieldErr) {
+		e.Fields = []corev2.FieldError{*fieldErr}
+	}
+	return e
+}
+
 // StatusFromError extracts code from the given error.
 func StatusFromError(err error) (ErrCode, bool) {
 	erro, ok := err.(Error)
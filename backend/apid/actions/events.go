@@ -2,6 +2,9 @@ package actions
 
 import (
 	"context"
+	"path"
+	"sort"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
@@ -13,17 +16,23 @@ import (
 
 const deletedEventSentinel = -1
 
+// eventReportPageSize is the number of events read at once to build an event
+// report.
+const eventReportPageSize = 500
+
 // EventController expose actions in which a viewer can perform.
 type EventController struct {
-	store store.EventStore
-	bus   messaging.MessageBus
+	store       store.EventStore
+	resultStore store.HandlerResultStore
+	bus         messaging.MessageBus
 }
 
 // NewEventController returns new EventController
-func NewEventController(store store.EventStore, bus messaging.MessageBus) EventController {
+func NewEventController(store store.EventStore, resultStore store.HandlerResultStore, bus messaging.MessageBus) EventController {
 	return EventController{
-		store: store,
-		bus:   bus,
+		store:       store,
+		resultStore: resultStore,
+		bus:         bus,
 	}
 }
 
@@ -32,6 +41,19 @@ func (a EventController) List(ctx context.Context, pred *store.SelectionPredicat
 	var results []*corev2.Event
 	var err error
 
+	// The events are sorted once they are all fetched, so a sorted list can't
+	// be paginated
+	var sorter func([]*corev2.Event) sort.Interface
+	if pred.Sort != "" {
+		var ok bool
+		if sorter, ok = corev2.EventSorts[pred.Sort]; !ok {
+			return nil, NewErrorf(InvalidArgument, "invalid sort %q, must be %q or %q", pred.Sort, corev2.EventSortLastOK, corev2.EventSortDuration)
+		}
+		if pred.Limit > 0 || pred.Continue != "" {
+			return nil, NewErrorf(InvalidArgument, "sorted events can't be paginated")
+		}
+	}
+
 	// Fetch from store
 	if pred.Subcollection != "" {
 		results, err = a.store.GetEventsByEntity(ctx, pred.Subcollection, pred)
@@ -42,6 +64,9 @@ func (a EventController) List(ctx context.Context, pred *store.SelectionPredicat
 	if err != nil {
 		return nil, NewError(InternalErr, err)
 	}
+	if sorter != nil {
+		sort.Sort(sorter(results))
+	}
 
 	resources := make([]corev2.Resource, len(results))
 	for i, v := range results {
@@ -70,6 +95,32 @@ func (a EventController) Get(ctx context.Context, entity, check string) (*corev2
 	return result, nil
 }
 
+// GetHandlerResults returns the latest result of every handler executed for
+// the event indicated by the supplied entity and check.
+func (a EventController) GetHandlerResults(ctx context.Context, entity, check string) ([]*corev2.HandlerResult, error) {
+	if entity == "" || check == "" {
+		return nil, NewErrorf(InvalidArgument, "GetHandlerResults() requires both an entity and a check")
+	}
+
+	event, err := a.store.GetEventByEntityCheck(ctx, entity, check)
+	if err != nil {
+		return nil, NewError(InternalErr, err)
+	}
+	if event == nil {
+		return nil, NewErrorf(NotFound)
+	}
+
+	results, err := a.resultStore.GetHandlerResults(ctx, entity, check)
+	if err != nil {
+		return nil, NewError(InternalErr, err)
+	}
+	if results == nil {
+		results = []*corev2.HandlerResult{}
+	}
+
+	return results, nil
+}
+
 // Delete destroys the event indicated by the supplied entity and check.
 func (a EventController) Delete(ctx context.Context, entity, check string) error {
 	// Destroy (for events) requires both an entity and check
@@ -141,3 +192,161 @@ func (a EventController) CreateOrReplace(ctx context.Context, event *corev2.Even
 
 	return nil
 }
+
+// DeleteSelected destroys the events selected by the field selector of the
+// request.
+func (a EventController) DeleteSelected(ctx context.Context, req *corev2.EventSelection) (*corev2.EventSelectionResponse, error) {
+	events, err := a.selectEvents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &corev2.EventSelectionResponse{Events: []string{}}
+	for _, event := range events {
+		if err := a.Delete(ctx, event.Entity.Name, event.Check.Name); err != nil {
+			return response, err
+		}
+		response.Events = append(response.Events, path.Join(event.Entity.Name, event.Check.Name))
+	}
+	return response, nil
+}
+
+// ResolveSelected resolves the failing events selected by the field selector
+// of the request.
+func (a EventController) ResolveSelected(ctx context.Context, req *corev2.EventSelection) (*corev2.EventSelectionResponse, error) {
+	events, err := a.selectEvents(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &corev2.EventSelectionResponse{Events: []string{}}
+	for _, event := range events {
+		if event.Check.Status == 0 {
+			continue
+		}
+		event.Check.Status = 0
+		event.Check.Output = "Resolved manually with the events API"
+		event.Timestamp = time.Now().Unix()
+		if err := a.CreateOrReplace(ctx, event); err != nil {
+			return response, err
+		}
+		response.Events = append(response.Events, path.Join(event.Entity.Name, event.Check.Name))
+	}
+	return response, nil
+}
+
+// selectEvents returns the events of the namespace matching the field selector
+// of the request.
+func (a EventController) selectEvents(ctx context.Context, req *corev2.EventSelection) ([]*corev2.Event, error) {
+	requirements, err := corev2.ParseFieldSelector(req.FieldSelector)
+	if err != nil {
+		return nil, NewError(InvalidArgument, err)
+	}
+
+	events, err := a.store.GetEvents(ctx, &store.SelectionPredicate{})
+	if err != nil {
+		return nil, NewError(InternalErr, err)
+	}
+
+	selected := []*corev2.Event{}
+	for _, event := range events {
+		if !event.HasCheck() || event.Entity == nil {
+			continue
+		}
+		matches, err := corev2.MatchFields(requirements, corev2.EventFields(event))
+		if err != nil {
+			return nil, NewError(InvalidArgument, err)
+		}
+		if matches {
+			selected = append(selected, event)
+		}
+	}
+	return selected, nil
+}
+
+// Report counts the check results of the events of the namespace executed
+// since the given time, grouped by check and by entity. The check results are
+// taken from the check history of the events, which are read page by page.
+func (a EventController) Report(ctx context.Context, since time.Time) (*corev2.EventReport, error) {
+	checks := map[string]*corev2.EventReportEntry{}
+	entities := map[string]*corev2.EventReportEntry{}
+	pred := &store.SelectionPredicate{Limit: eventReportPageSize}
+	for {
+		events, err := a.store.GetEvents(ctx, pred)
+		if err != nil {
+			return nil, NewError(InternalErr, err)
+		}
+		for _, event := range events {
+			if !event.HasCheck() || event.Entity == nil {
+				continue
+			}
+			counts := reportEventCounts(event, since.Unix())
+			if counts.Results == 0 {
+				continue
+			}
+			addReportCounts(checks, event.Check.Name, counts)
+			addReportCounts(entities, event.Entity.Name, counts)
+		}
+		if pred.Continue == "" {
+			break
+		}
+	}
+
+	return &corev2.EventReport{
+		Since:    since.Unix(),
+		Checks:   sortedReportEntries(checks),
+		Entities: sortedReportEntries(entities),
+	}, nil
+}
+
+// reportEventCounts counts the results of the check history of the event
+// executed since the Unix timestamp.
+func reportEventCounts(event *corev2.Event, since int64) corev2.EventReportEntry {
+	counts := corev2.EventReportEntry{Events: 1}
+	history := event.Check.History
+	for i, result := range history {
+		if result.Executed < since {
+			continue
+		}
+		counts.Results++
+		if result.Status != 0 {
+			counts.Incidents++
+		}
+		if i > 0 && history[i-1].Executed >= since && result.Status != history[i-1].Status {
+			counts.StateChanges++
+		}
+	}
+	if event.Check.State == corev2.EventFlappingState {
+		counts.Flapping = 1
+	}
+	return counts
+}
+
+func addReportCounts(entries map[string]*corev2.EventReportEntry, name string, counts corev2.EventReportEntry) {
+	entry, ok := entries[name]
+	if !ok {
+		entry = &corev2.EventReportEntry{Name: name}
+		entries[name] = entry
+	}
+	entry.Events += counts.Events
+	entry.Results += counts.Results
+	entry.Incidents += counts.Incidents
+	entry.StateChanges += counts.StateChanges
+	entry.Flapping += counts.Flapping
+}
+
+// sortedReportEntries returns the entries sorted by decreasing number of
+// incidents, then by name.
+func sortedReportEntries(entries map[string]*corev2.EventReportEntry) []*corev2.EventReportEntry {
+	sorted := make([]*corev2.EventReportEntry, 0, len(entries))
+	for _, entry := range entries {
+		sorted = append(sorted, entry)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Incidents != sorted[j].Incidents {
+			return sorted[i].Incidents > sorted[j].Incidents
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
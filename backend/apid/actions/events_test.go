@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
@@ -12,6 +13,7 @@ import (
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewEventController(t *testing.T) {
@@ -19,10 +21,11 @@ func TestNewEventController(t *testing.T) {
 
 	store := &mockstore.MockStore{}
 	bus := &mockbus.MockBus{}
-	eventController := NewEventController(store, bus)
+	eventController := NewEventController(store, store, bus)
 
 	assert.NotNil(eventController)
 	assert.Equal(store, eventController.store)
+	assert.Equal(store, eventController.resultStore)
 	assert.Equal(bus, eventController.bus)
 }
 
@@ -82,7 +85,7 @@ func TestEventList(t *testing.T) {
 	for _, tc := range testCases {
 		s := &mockstore.MockStore{}
 		bus := &mockbus.MockBus{}
-		eventController := NewEventController(s, bus)
+		eventController := NewEventController(s, s, bus)
 
 		t.Run(tc.name, func(t *testing.T) {
 			assert := assert.New(t)
@@ -104,6 +107,29 @@ func TestEventList(t *testing.T) {
 	}
 }
 
+func TestEventListSorted(t *testing.T) {
+	fast := corev2.FixtureEvent("entity1", "fast")
+	fast.Check.Duration = 0.1
+	slow := corev2.FixtureEvent("entity2", "slow")
+	slow.Check.Duration = 12.5
+
+	s := &mockstore.MockStore{}
+	s.On("GetEvents", mock.Anything, mock.Anything).Return([]*corev2.Event{fast, slow}, nil)
+	eventController := NewEventController(s, s, &mockbus.MockBus{})
+
+	results, err := eventController.List(context.Background(), &store.SelectionPredicate{Sort: corev2.EventSortDuration})
+	require.NoError(t, err)
+	assert.Equal(t, []corev2.Resource{slow, fast}, results)
+
+	_, err = eventController.List(context.Background(), &store.SelectionPredicate{Sort: "name"})
+	require.Error(t, err)
+	assert.Equal(t, InvalidArgument, err.(Error).Code)
+
+	_, err = eventController.List(context.Background(), &store.SelectionPredicate{Sort: corev2.EventSortLastOK, Limit: 10})
+	require.Error(t, err)
+	assert.Equal(t, InvalidArgument, err.(Error).Code)
+}
+
 func TestEventFind(t *testing.T) {
 	defaultCtx := context.Background()
 
@@ -159,7 +185,7 @@ func TestEventFind(t *testing.T) {
 	for _, tc := range testCases {
 		store := &mockstore.MockStore{}
 		bus := &mockbus.MockBus{}
-		eventController := NewEventController(store, bus)
+		eventController := NewEventController(store, store, bus)
 
 		t.Run(tc.name, func(t *testing.T) {
 			assert := assert.New(t)
@@ -232,7 +258,7 @@ func TestEventDestroy(t *testing.T) {
 	for _, tc := range testCases {
 		store := &mockstore.MockStore{}
 		bus := &mockbus.MockBus{}
-		eventController := NewEventController(store, bus)
+		eventController := NewEventController(store, store, bus)
 
 		t.Run(tc.name, func(t *testing.T) {
 			assert := assert.New(t)
@@ -258,6 +284,77 @@ func TestEventDestroy(t *testing.T) {
 	}
 }
 
+func TestEventGetHandlerResults(t *testing.T) {
+	defaultCtx := context.Background()
+	results := []*corev2.HandlerResult{corev2.FixtureHandlerResult("slack")}
+
+	testCases := []struct {
+		name            string
+		event           *corev2.Event
+		results         []*corev2.HandlerResult
+		entity          string
+		check           string
+		expectedLen     int
+		expectedErrCode ErrCode
+	}{
+		{
+			name:            "No Params",
+			expectedErrCode: InvalidArgument,
+		},
+		{
+			name:            "Event Not Found",
+			entity:          "entity1",
+			check:           "check1",
+			expectedErrCode: NotFound,
+		},
+		{
+			name:        "No Results",
+			event:       corev2.FixtureEvent("entity1", "check1"),
+			entity:      "entity1",
+			check:       "check1",
+			expectedLen: 0,
+		},
+		{
+			name:        "Found",
+			event:       corev2.FixtureEvent("entity1", "check1"),
+			results:     results,
+			entity:      "entity1",
+			check:       "check1",
+			expectedLen: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		store := &mockstore.MockStore{}
+		bus := &mockbus.MockBus{}
+		eventController := NewEventController(store, store, bus)
+
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			// Mock store methods
+			store.
+				On("GetEventByEntityCheck", defaultCtx, mock.Anything, mock.Anything).
+				Return(tc.event, nil)
+			store.
+				On("GetHandlerResults", defaultCtx, mock.Anything, mock.Anything).
+				Return(tc.results, nil)
+
+			// Exec Query
+			result, err := eventController.GetHandlerResults(defaultCtx, tc.entity, tc.check)
+
+			inferErr, ok := err.(Error)
+			if ok {
+				assert.Equal(tc.expectedErrCode, inferErr.Code)
+			} else {
+				assert.NoError(err)
+				assert.NotNil(result)
+				assert.Len(result, tc.expectedLen)
+			}
+		})
+	}
+}
+
 func TestEventCreateOrReplace(t *testing.T) {
 	defaultCtx := context.Background()
 
@@ -315,7 +412,7 @@ func TestEventCreateOrReplace(t *testing.T) {
 	for _, tc := range testCases {
 		store := &mockstore.MockStore{}
 		bus := &mockbus.MockBus{}
-		actions := NewEventController(store, bus)
+		actions := NewEventController(store, store, bus)
 
 		t.Run(tc.name, func(t *testing.T) {
 			assert := assert.New(t)
@@ -352,7 +449,7 @@ func TestEventCreatedBy(t *testing.T) {
 
 	store := &mockstore.MockStore{}
 	bus := &mockbus.MockBus{}
-	actions := NewEventController(store, bus)
+	actions := NewEventController(store, store, bus)
 
 	store.On("GetEventByEntityCheck", mock.Anything, mock.Anything, mock.Anything).Return(event, nil)
 	bus.On("Publish", mock.Anything, mock.Anything).Return(nil)
@@ -363,3 +460,105 @@ func TestEventCreatedBy(t *testing.T) {
 	assert.Equal(t, "admin", event.Check.CreatedBy)
 	assert.Equal(t, "admin", event.Entity.CreatedBy)
 }
+
+func TestEventDeleteSelected(t *testing.T) {
+	store := &mockstore.MockStore{}
+	bus := &mockbus.MockBus{}
+	eventController := NewEventController(store, store, bus)
+
+	disk := corev2.FixtureEvent("entity1", "disk")
+	cpu := corev2.FixtureEvent("entity1", "cpu")
+	store.On("GetEvents", mock.Anything, mock.Anything).Return([]*corev2.Event{disk, cpu}, nil)
+	store.On("GetEventByEntityCheck", mock.Anything, "entity1", "disk").Return(disk, nil)
+	store.On("DeleteEventByEntityCheck", mock.Anything, "entity1", "disk").Return(nil).Once()
+
+	response, err := eventController.DeleteSelected(context.Background(), &corev2.EventSelection{FieldSelector: "event.check.name==disk"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"entity1/disk"}, response.Events)
+	store.AssertExpectations(t)
+
+	_, err = eventController.DeleteSelected(context.Background(), &corev2.EventSelection{})
+	inferErr, ok := err.(Error)
+	if assert.True(t, ok) {
+		assert.Equal(t, InvalidArgument, inferErr.Code)
+	}
+}
+
+func TestEventResolveSelected(t *testing.T) {
+	store := &mockstore.MockStore{}
+	bus := &mockbus.MockBus{}
+	eventController := NewEventController(store, store, bus)
+
+	failing := corev2.FixtureEvent("entity1", "disk")
+	failing.Check.Status = 2
+	passing := corev2.FixtureEvent("entity2", "disk")
+	store.On("GetEvents", mock.Anything, mock.Anything).Return([]*corev2.Event{failing, passing}, nil)
+	bus.On("Publish", mock.Anything, mock.MatchedBy(func(event *corev2.Event) bool {
+		return event.Entity.Name == "entity1" && event.Check.Status == 0
+	})).Return(nil).Once()
+
+	response, err := eventController.ResolveSelected(context.Background(), &corev2.EventSelection{FieldSelector: "event.check.name==disk"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"entity1/disk"}, response.Events)
+	bus.AssertExpectations(t)
+
+	_, err = eventController.ResolveSelected(context.Background(), &corev2.EventSelection{FieldSelector: "event.unknown==disk"})
+	inferErr, ok := err.(Error)
+	if assert.True(t, ok) {
+		assert.Equal(t, InvalidArgument, inferErr.Code)
+	}
+}
+
+func TestEventReport(t *testing.T) {
+	st := &mockstore.MockStore{}
+	bus := &mockbus.MockBus{}
+	eventController := NewEventController(st, st, bus)
+
+	since := time.Now().Add(-time.Hour)
+	history := func(statuses ...uint32) []corev2.CheckHistory {
+		history := make([]corev2.CheckHistory, len(statuses))
+		for i, status := range statuses {
+			// The first result is outside of the period
+			executed := since.Add(-time.Minute)
+			if i > 0 {
+				executed = since.Add(time.Duration(i) * time.Minute)
+			}
+			history[i] = corev2.CheckHistory{Status: status, Executed: executed.Unix()}
+		}
+		return history
+	}
+
+	flapping := corev2.FixtureEvent("entity1", "disk")
+	flapping.Check.History = history(0, 2, 0, 2)
+	flapping.Check.State = corev2.EventFlappingState
+	failing := corev2.FixtureEvent("entity2", "disk")
+	failing.Check.History = history(0, 2, 2)
+	passing := corev2.FixtureEvent("entity2", "cpu")
+	passing.Check.History = history(2, 0, 0)
+	// Events without results in the period are not accounted for
+	stale := corev2.FixtureEvent("entity3", "cpu")
+	stale.Check.History = history(2)
+	stale.Check.State = corev2.EventFlappingState
+
+	// The events are read page by page
+	st.On("GetEvents", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*store.SelectionPredicate).Continue = "next"
+	}).Return([]*corev2.Event{flapping, failing}, nil).Once()
+	st.On("GetEvents", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		args.Get(1).(*store.SelectionPredicate).Continue = ""
+	}).Return([]*corev2.Event{passing, stale}, nil).Once()
+
+	report, err := eventController.Report(context.Background(), since)
+	require.NoError(t, err)
+	assert.Equal(t, since.Unix(), report.Since)
+	st.AssertNumberOfCalls(t, "GetEvents", 2)
+
+	require.Len(t, report.Checks, 2)
+	assert.Equal(t, corev2.EventReportEntry{Name: "disk", Events: 2, Results: 5, Incidents: 4, StateChanges: 2, Flapping: 1}, *report.Checks[0])
+	assert.Equal(t, corev2.EventReportEntry{Name: "cpu", Events: 1, Results: 2}, *report.Checks[1])
+
+	require.Len(t, report.Entities, 2)
+	assert.Equal(t, "entity1", report.Entities[0].Name)
+	assert.Equal(t, "entity2", report.Entities[1].Name)
+	assert.Equal(t, 2, report.Entities[1].Incidents)
+}
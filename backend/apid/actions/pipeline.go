@@ -0,0 +1,53 @@
+package actions
+
+import (
+	"context"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// PipelineDryRunner runs events through the pipeline without executing any
+// handler.
+type PipelineDryRunner interface {
+	DryRun(context.Context, *corev2.Event) (*corev2.PipelineDryRun, error)
+}
+
+// PipelineController exposes actions which a viewer can perform on the
+// pipeline.
+type PipelineController struct {
+	runner PipelineDryRunner
+}
+
+// NewPipelineController returns a new PipelineController
+func NewPipelineController(runner PipelineDryRunner) PipelineController {
+	return PipelineController{
+		runner: runner,
+	}
+}
+
+// DryRun reports which filters would pass or reject the event, which mutator
+// output would be produced and which handlers would be executed.
+func (c PipelineController) DryRun(ctx context.Context, event *corev2.Event) (*corev2.PipelineDryRun, error) {
+	if event.Entity != nil && event.Entity.Namespace == "" {
+		event.Entity.Namespace = corev2.ContextNamespace(ctx)
+	}
+	if event.Check != nil && event.Check.Namespace == "" {
+		event.Check.Namespace = corev2.ContextNamespace(ctx)
+	}
+	if err := event.Validate(); err != nil {
+		return nil, NewError(InvalidArgument, err)
+	}
+	if event.Entity.Namespace != corev2.ContextNamespace(ctx) {
+		return nil, NewErrorf(InvalidArgument, "the namespace of the event does not match the namespace of the request")
+	}
+	if c.runner == nil {
+		return nil, NewErrorf(InternalErr, "the pipeline is not available")
+	}
+
+	result, err := c.runner.DryRun(ctx, event)
+	if err != nil {
+		return nil, NewError(InternalErr, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPipelineDryRunner struct {
+	mock.Mock
+}
+
+func (m *mockPipelineDryRunner) DryRun(ctx context.Context, event *corev2.Event) (*corev2.PipelineDryRun, error) {
+	args := m.Called(ctx, event)
+	return args.Get(0).(*corev2.PipelineDryRun), args.Error(1)
+}
+
+func TestPipelineDryRun(t *testing.T) {
+	ctx := context.WithValue(context.Background(), corev2.NamespaceKey, "default")
+
+	testCases := []struct {
+		name            string
+		event           *corev2.Event
+		runnerErr       error
+		expectedErrCode ErrCode
+		expectedErr     bool
+	}{
+		{
+			name:  "valid event",
+			event: corev2.FixtureEvent("entity1", "check1"),
+		},
+		{
+			name:            "invalid event",
+			event:           &corev2.Event{},
+			expectedErr:     true,
+			expectedErrCode: InvalidArgument,
+		},
+		{
+			name: "namespace mismatch",
+			event: func() *corev2.Event {
+				event := corev2.FixtureEvent("entity1", "check1")
+				event.Entity.Namespace = "acme"
+				return event
+			}(),
+			expectedErr:     true,
+			expectedErrCode: InvalidArgument,
+		},
+		{
+			name:            "pipeline error",
+			event:           corev2.FixtureEvent("entity1", "check1"),
+			runnerErr:       errors.New("error"),
+			expectedErr:     true,
+			expectedErrCode: InternalErr,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			runner := &mockPipelineDryRunner{}
+			runner.On("DryRun", mock.Anything, mock.Anything).Return(&corev2.PipelineDryRun{}, tc.runnerErr)
+			controller := NewPipelineController(runner)
+
+			result, err := controller.DryRun(ctx, tc.event)
+			if tc.expectedErr {
+				require.Error(t, err)
+				code, ok := StatusFromError(err)
+				require.True(t, ok)
+				assert.Equal(t, tc.expectedErrCode, code)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, result)
+		})
+	}
+}
@@ -2,9 +2,11 @@ package actions
 
 import (
 	"context"
+	"strings"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/sensu/sensu-go/backend/clusterconfig"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/types"
 )
@@ -20,12 +22,17 @@ var silencedUpdateFields = []string{
 // SilencedController exposes actions in which a viewer can perform.
 type SilencedController struct {
 	Store store.SilencedStore
+
+	// ClusterConfig holds the cluster configuration, which tells whether the
+	// silenced entries must have a reason
+	ClusterConfig *clusterconfig.Watcher
 }
 
 // NewSilencedController returns new SilencedController
-func NewSilencedController(store store.SilencedStore) SilencedController {
+func NewSilencedController(store store.SilencedStore, clusterConfig *clusterconfig.Watcher) SilencedController {
 	return SilencedController{
-		Store: store,
+		Store:         store,
+		ClusterConfig: clusterConfig,
 	}
 }
 
@@ -53,8 +60,8 @@ func (c SilencedController) Create(ctx context.Context, entry *corev2.Silenced)
 	entry.Prepare(ctx)
 
 	// Validate the silenced entry
-	if err := entry.Validate(); err != nil {
-		return NewError(InvalidArgument, err)
+	if err := c.validate(entry); err != nil {
+		return err
 	}
 
 	if claims := jwt.GetClaimsFromContext(ctx); claims != nil {
@@ -82,8 +89,8 @@ func (c SilencedController) CreateOrReplace(ctx context.Context, entry *corev2.S
 	entry.Prepare(ctx)
 
 	// Validate the silenced entry
-	if err := entry.Validate(); err != nil {
-		return NewError(InvalidArgument, err)
+	if err := c.validate(entry); err != nil {
+		return err
 	}
 
 	if claims := jwt.GetClaimsFromContext(ctx); claims != nil {
@@ -97,3 +104,14 @@ func (c SilencedController) CreateOrReplace(ctx context.Context, entry *corev2.S
 
 	return nil
 }
+
+// validate validates the silenced entry, and its reason when one is required.
+func (c SilencedController) validate(entry *corev2.Silenced) error {
+	if err := entry.Validate(); err != nil {
+		return NewError(InvalidArgument, err)
+	}
+	if c.ClusterConfig.Config().RequireSilencedReason && strings.TrimSpace(entry.Reason) == "" {
+		return NewErrorf(InvalidArgument, "a reason is required for silenced entries")
+	}
+	return nil
+}
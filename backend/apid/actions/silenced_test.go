@@ -9,17 +9,20 @@ import (
 	jwt "github.com/dgrijalva/jwt-go"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	coreJWT "github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/sensu/sensu-go/backend/clusterconfig"
+	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewSilencedController(t *testing.T) {
 	assert := assert.New(t)
 
 	store := &mockstore.MockStore{}
-	actions := NewSilencedController(store)
+	actions := NewSilencedController(store, nil)
 
 	assert.NotNil(actions)
 	assert.Equal(store, actions.Store)
@@ -92,7 +95,7 @@ func TestSilencedQuery(t *testing.T) {
 
 	for _, tc := range testCases {
 		store := &mockstore.MockStore{}
-		actions := NewSilencedController(store)
+		actions := NewSilencedController(store, nil)
 
 		t.Run(tc.name, func(t *testing.T) {
 			assert := assert.New(t)
@@ -170,7 +173,7 @@ func TestSilencedCreateOrReplace(t *testing.T) {
 
 	for _, tc := range testCases {
 		store := &mockstore.MockStore{}
-		actions := NewSilencedController(store)
+		actions := NewSilencedController(store, nil)
 
 		t.Run(tc.name, func(t *testing.T) {
 			assert := assert.New(t)
@@ -292,7 +295,7 @@ func TestSilencedCreate(t *testing.T) {
 
 	for _, tc := range testCases {
 		store := &mockstore.MockStore{}
-		actions := NewSilencedController(store)
+		actions := NewSilencedController(store, nil)
 
 		t.Run(tc.name, func(t *testing.T) {
 			assert := assert.New(t)
@@ -340,7 +343,7 @@ func TestSilencedCreatedBy(t *testing.T) {
 	silenced := corev2.FixtureSilenced("silenced1:*")
 
 	store := &mockstore.MockStore{}
-	actions := NewSilencedController(store)
+	actions := NewSilencedController(store, nil)
 
 	var s *corev2.Silenced
 	store.On("UpdateSilencedEntry", mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -354,3 +357,33 @@ func TestSilencedCreatedBy(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "admin", silenced.CreatedBy)
 }
+
+func TestSilencedRequireReason(t *testing.T) {
+	st := &mockstore.MockStore{}
+	st.On("GetClusterConfigWatcher", mock.Anything).Return((<-chan store.WatchEventClusterConfig)(make(chan store.WatchEventClusterConfig)))
+	st.On("GetClusterConfig", mock.Anything).Return(&corev2.ClusterConfig{RequireSilencedReason: true}, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	clusterConfig := clusterconfig.NewWatcher(st)
+	clusterConfig.Start(ctx)
+	actions := NewSilencedController(st, clusterConfig)
+
+	var s *corev2.Silenced
+	st.On("UpdateSilencedEntry", mock.Anything, mock.Anything).Return(nil)
+	st.On("GetSilencedEntryByName", mock.Anything, mock.Anything).Return(s, nil)
+
+	silenced := corev2.FixtureSilenced("silenced1:*")
+	silenced.Reason = " "
+	err := actions.Create(context.Background(), silenced)
+	require.Error(t, err)
+	assert.Equal(t, InvalidArgument, err.(Error).Code)
+
+	err = actions.CreateOrReplace(context.Background(), silenced)
+	require.Error(t, err)
+	assert.Equal(t, InvalidArgument, err.(Error).Code)
+	st.AssertNotCalled(t, "UpdateSilencedEntry", mock.Anything, mock.Anything)
+
+	silenced.Reason = "maintenance"
+	assert.NoError(t, actions.Create(context.Background(), silenced))
+	assert.NoError(t, actions.CreateOrReplace(context.Background(), silenced))
+}
@@ -2,12 +2,18 @@ package actions
 
 import (
 	"context"
+	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/authentication/bcrypt"
+	"github.com/sensu/sensu-go/backend/authentication/jwt"
 	"github.com/sensu/sensu-go/backend/store"
 )
 
+// PasswordResetTokenExpiration is the time during which the password reset
+// tokens can be used.
+const PasswordResetTokenExpiration = 24 * time.Hour
+
 // UserController exposes actions in which a viewer can perform.
 type UserController struct {
 	store store.UserStore
@@ -172,6 +178,67 @@ func (a UserController) RemoveAllGroups(ctx context.Context, username string) er
 	})
 }
 
+// ResetPasswordToken issues a one-time token with which the user can reset
+// its password, so that administrators never need to know it.
+func (a UserController) ResetPasswordToken(ctx context.Context, name string) (*corev2.PasswordResetToken, error) {
+	user, err := a.findUser(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if user.Disabled {
+		return nil, NewErrorf(InvalidArgument, "user is disabled")
+	}
+
+	token, expiresAt, err := jwt.PasswordResetToken(user, PasswordResetTokenExpiration)
+	if err != nil {
+		return nil, NewError(InternalErr, err)
+	}
+	return &corev2.PasswordResetToken{
+		Username:  user.Username,
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// ResetPassword exchanges a password reset token for a new password. The
+// token can't be used again once the password is reset, even by concurrent
+// requests, since the password is only replaced if it's still the one the
+// token was issued for.
+func (a UserController) ResetPassword(ctx context.Context, reset *corev2.PasswordReset) error {
+	claims, err := jwt.ParsePasswordResetToken(reset.Token)
+	if err != nil {
+		return NewErrorf(Unauthenticated, "invalid password reset token")
+	}
+
+	user, err := a.store.GetUser(ctx, claims.Subject)
+	if err != nil {
+		return NewError(InternalErr, err)
+	}
+	if user == nil || user.Disabled || !claims.ValidFor(user) {
+		return NewErrorf(Unauthenticated, "invalid password reset token")
+	}
+
+	previous := user.Password
+	user.Password = reset.Password
+	if err := user.ValidatePassword(); err != nil {
+		return NewError(InvalidArgument, err)
+	}
+	hash, err := bcrypt.HashPassword(reset.Password)
+	if err != nil {
+		return NewError(InternalErr, err)
+	}
+
+	err = a.store.ResetUserPassword(ctx, user.Username, previous, hash)
+	switch err.(type) {
+	case nil:
+		return nil
+	case *store.ErrConflict, *store.ErrNotFound:
+		return NewErrorf(Unauthenticated, "invalid password reset token")
+	default:
+		return NewError(InternalErr, err)
+	}
+}
+
 func (a UserController) findUser(ctx context.Context, name string) (*corev2.User, error) {
 	result, serr := a.store.GetUser(ctx, name)
 	if serr != nil {
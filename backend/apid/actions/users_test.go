@@ -5,12 +5,14 @@ import (
 	"errors"
 	"testing"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/sensu/sensu-go/testing/testutil"
 	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewUserController(t *testing.T) {
@@ -463,3 +465,47 @@ func TestUserEnable(t *testing.T) {
 		})
 	}
 }
+
+func TestUserResetPassword(t *testing.T) {
+	st := &mockstore.MockStore{}
+	actions := NewUserController(st)
+	ctx := context.Background()
+
+	user := types.FixtureUser("user1")
+	st.On("GetUser", mock.Anything, "user1").Return(user, nil)
+	st.On("ResetUserPassword", mock.Anything, "user1", user.Password, mock.Anything).Run(func(args mock.Arguments) {
+		user.Password = args.String(3)
+	}).Return(nil).Once()
+
+	token, err := actions.ResetPasswordToken(ctx, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, "user1", token.Username)
+	assert.NotEmpty(t, token.Token)
+	assert.NotZero(t, token.ExpiresAt)
+
+	err = actions.ResetPassword(ctx, &corev2.PasswordReset{Token: "invalid", Password: "n3wP@ssw0rd"})
+	require.Error(t, err)
+	assert.Equal(t, Unauthenticated, err.(Error).Code)
+
+	require.NoError(t, actions.ResetPassword(ctx, &corev2.PasswordReset{Token: token.Token, Password: "n3wP@ssw0rd"}))
+	assert.NotEqual(t, "n3wP@ssw0rd", user.Password)
+
+	// The token can only be used once
+	err = actions.ResetPassword(ctx, &corev2.PasswordReset{Token: token.Token, Password: "an0th3rP@ssw0rd"})
+	require.Error(t, err)
+	assert.Equal(t, Unauthenticated, err.(Error).Code)
+
+	// Nor by concurrent requests, whose password was reset in the meantime
+	token, err = actions.ResetPasswordToken(ctx, "user1")
+	require.NoError(t, err)
+	st.On("ResetUserPassword", mock.Anything, "user1", user.Password, mock.Anything).Return(&store.ErrConflict{Key: "user1"})
+	err = actions.ResetPassword(ctx, &corev2.PasswordReset{Token: token.Token, Password: "an0th3rP@ssw0rd"})
+	require.Error(t, err)
+	assert.Equal(t, Unauthenticated, err.(Error).Code)
+
+	// Tokens aren't issued for disabled users
+	user.Disabled = true
+	_, err = actions.ResetPasswordToken(ctx, "user1")
+	require.Error(t, err)
+	assert.Equal(t, InvalidArgument, err.(Error).Code)
+}
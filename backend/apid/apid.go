@@ -1,18 +1,24 @@
 package apid
 
 import (
+	"compress/gzip"
 	"context"
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net"
 	"net/http"
+	"os"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/NYTimes/gziphandler"
 	"github.com/coreos/etcd/clientv3"
 	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/graphql"
@@ -20,9 +26,11 @@ import (
 	"github.com/sensu/sensu-go/backend/apid/routers"
 	"github.com/sensu/sensu-go/backend/authentication"
 	"github.com/sensu/sensu-go/backend/authorization/rbac"
+	"github.com/sensu/sensu-go/backend/clusterconfig"
 	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/types"
+	"github.com/sensu/sensu-go/util/tlsreload"
 )
 
 // APId is the backend HTTP API.
@@ -42,6 +50,8 @@ type APId struct {
 	eventStore          store.EventStore
 	queueGetter         types.QueueGetter
 	tls                 *types.TLSOptions
+	tlsReloader         *tlsreload.Reloader
+	unixSocket          string
 	cluster             clientv3.Cluster
 	etcdClientTLSConfig *tls.Config
 	clusterVersion      string
@@ -53,6 +63,7 @@ type Option func(*APId) error
 // Config configures APId.
 type Config struct {
 	ListenAddress       string
+	UnixSocket          string
 	URL                 string
 	Bus                 messaging.MessageBus
 	Store               store.Store
@@ -65,6 +76,33 @@ type Config struct {
 	ClusterVersion      string
 	GraphQLService      *graphql.Service
 	HealthRouter        *routers.HealthRouter
+	PipelineDryRunner   actions.PipelineDryRunner
+	RateLimiter         *middlewares.RateLimiter
+	IdempotencyStore    *middlewares.IdempotencyStore
+	// APIUsageTracker aggregates the API requests per user and route served
+	// by /cluster/api-usage
+	APIUsageTracker *middlewares.APIUsageTracker
+	// EventStats reports the event rates per namespace and per check served
+	// by /cluster/event-stats
+	EventStats routers.EventStatsController
+	// ClusterConfig holds the cluster configuration
+	ClusterConfig *clusterconfig.Watcher
+
+	// Replicator reports the status of the replication into the secondary
+	// clusters, and is nil when the replicator is not enabled
+	Replicator routers.ReplicatorController
+
+	// AgentDrainer drains the agent sessions of the backends of the cluster
+	// through /cluster/drain
+	AgentDrainer routers.DrainController
+
+	// SwitchInspector lists the keepalive and check TTL monitors served by
+	// /debug/monitors
+	SwitchInspector routers.SwitchInspector
+
+	// SubscriptionLister lists the subscriptions and their connected entities
+	// served by /subscriptions
+	SubscriptionLister routers.SubscriptionLister
 }
 
 // New creates a new APId.
@@ -83,6 +121,7 @@ func New(c Config, opts ...Option) (*APId, error) {
 		etcdClientTLSConfig: c.EtcdClientTLSConfig,
 		Authenticator:       c.Authenticator,
 		clusterVersion:      c.ClusterVersion,
+		unixSocket:          c.UnixSocket,
 	}
 
 	// prepare TLS config
@@ -93,21 +132,41 @@ func New(c Config, opts ...Option) (*APId, error) {
 		if err != nil {
 			return nil, err
 		}
+		if c.TLS.CertFile != "" && c.TLS.KeyFile != "" {
+			// Reload the certificate when it is rotated
+			a.tlsReloader, err = tlsreload.New(c.TLS.CertFile, c.TLS.KeyFile, c.TLS.TrustedCAFile)
+			if err != nil {
+				return nil, err
+			}
+			a.tlsReloader.Configure(tlsServerConfig)
+		}
 	}
 
+	_ = prometheus.Register(middlewares.APIRequests)
+	_ = prometheus.Register(middlewares.APIRequestDuration)
+
 	router := NewRouter()
 	_ = PublicSubrouter(router, c)
 	a.GraphQLSubrouter = GraphQLSubrouter(router, c)
 	_ = AuthenticationSubrouter(router, c)
 	a.CoreSubrouter = CoreSubrouter(router, c)
 	a.EntityLimitedCoreSubrouter = EntityLimitedCoreSubrouter(router, c)
+	_ = DebugSubrouter(router, c)
+	_ = APIDocsSubrouter(router, c)
+
+	// Compress the responses of the clients that support it
+	gzipHandler, err := gziphandler.NewGzipLevelAndMinSize(gzip.DefaultCompression, gziphandler.DefaultMinSize)
+	if err != nil {
+		return nil, err
+	}
 
 	a.HTTPServer = &http.Server{
 		Addr:         c.ListenAddress,
-		Handler:      router,
+		Handler:      gzipHandler(router),
 		WriteTimeout: 15 * time.Second,
 		ReadTimeout:  15 * time.Second,
 		TLSConfig:    tlsServerConfig,
+		ConnContext:  middlewares.LocalConnContext,
 	}
 
 	for _, o := range opts {
@@ -155,31 +214,47 @@ func CoreSubrouter(router *mux.Router, cfg Config) *mux.Router {
 		middlewares.SimpleLogger{},
 		middlewares.Namespace{},
 		middlewares.Authentication{Store: cfg.Store},
+		middlewares.APIUsage{Tracker: cfg.APIUsageTracker},
 		middlewares.AuthorizationAttributes{},
+		middlewares.RateLimit{Limiter: cfg.RateLimiter},
 		middlewares.Authorization{Authorizer: &rbac.Authorizer{Store: cfg.Store}},
 		middlewares.LimitRequest{},
+		middlewares.Idempotency{Store: cfg.IdempotencyStore},
 		middlewares.Pagination{},
+		middlewares.ETag{},
 	)
 	mountRouters(
 		subrouter,
 		routers.NewAssetRouter(cfg.Store),
 		routers.NewAPIKeysRouter(cfg.Store),
+		routers.NewAPIUsageRouter(cfg.APIUsageTracker),
 		routers.NewChecksRouter(cfg.Store, cfg.QueueGetter),
+		routers.NewCheckTemplatesRouter(cfg.Store),
 		routers.NewClusterRolesRouter(cfg.Store),
 		routers.NewClusterRoleBindingsRouter(cfg.Store),
+		routers.NewClusterConfigRouter(cfg.Store),
 		routers.NewClusterRouter(actions.NewClusterController(cfg.Cluster, cfg.Store)),
+		routers.NewCompositeChecksRouter(cfg.Store),
+		routers.NewDrainRouter(cfg.AgentDrainer),
 		routers.NewEventFiltersRouter(cfg.Store),
+		routers.NewEventStatsRouter(cfg.EventStats),
 		routers.NewExtensionsRouter(cfg.Store),
 		routers.NewHandlersRouter(cfg.Store),
 		routers.NewHooksRouter(cfg.Store),
 		routers.NewMutatorsRouter(cfg.Store),
 		routers.NewNamespacesRouter(cfg.Store, &rbac.Authorizer{Store: cfg.Store}),
+		routers.NewPipelineRouter(actions.NewPipelineController(cfg.PipelineDryRunner)),
+		routers.NewReplicatorRouter(cfg.Replicator),
+		routers.NewResourcesRouter(cfg.Store, &rbac.Authorizer{Store: cfg.Store}),
 		routers.NewRolesRouter(cfg.Store),
 		routers.NewRoleBindingsRouter(cfg.Store),
-		routers.NewSilencedRouter(cfg.Store),
+		routers.NewSilencedRouter(cfg.Store, cfg.ClusterConfig),
 		routers.NewTessenRouter(actions.NewTessenController(cfg.Store, cfg.Bus)),
 		routers.NewUsersRouter(cfg.Store),
 	)
+	if cfg.SubscriptionLister != nil {
+		mountRouters(subrouter, routers.NewSubscriptionsRouter(cfg.SubscriptionLister, cfg.Store))
+	}
 
 	return subrouter
 }
@@ -192,15 +267,19 @@ func EntityLimitedCoreSubrouter(router *mux.Router, cfg Config) *mux.Router {
 		middlewares.SimpleLogger{},
 		middlewares.Namespace{},
 		middlewares.Authentication{Store: cfg.Store},
+		middlewares.APIUsage{Tracker: cfg.APIUsageTracker},
 		middlewares.AuthorizationAttributes{},
+		middlewares.RateLimit{Limiter: cfg.RateLimiter},
 		middlewares.Authorization{Authorizer: &rbac.Authorizer{Store: cfg.Store}},
 		middlewares.LimitRequest{},
+		middlewares.Idempotency{Store: cfg.IdempotencyStore},
 		middlewares.Pagination{},
+		middlewares.ETag{},
 	)
 	mountRouters(
 		subrouter,
-		routers.NewEntitiesRouter(cfg.Store, cfg.EventStore),
-		routers.NewEventsRouter(cfg.EventStore, cfg.Bus),
+		routers.NewEntitiesRouter(cfg.Store, cfg.EventStore, cfg.Bus),
+		routers.NewEventsRouter(cfg.EventStore, cfg.Store, cfg.Store, cfg.Bus, &rbac.Authorizer{Store: cfg.Store}),
 	)
 
 	return subrouter
@@ -221,6 +300,10 @@ func GraphQLSubrouter(router *mux.Router, cfg Config) *mux.Router {
 		// https://github.com/graphql/graphiql
 		// https://graphql.org/learn/introspection/
 		middlewares.Authentication{IgnoreUnauthorized: true, Store: cfg.Store},
+		middlewares.APIUsage{Tracker: cfg.APIUsageTracker},
+		// GraphQL queries, like the ones of the dashboard, mostly list
+		// resources
+		middlewares.RateLimit{Limiter: cfg.RateLimiter, Class: middlewares.RouteClassList},
 	)
 
 	mountRouters(
@@ -244,6 +327,7 @@ func PublicSubrouter(router *mux.Router, cfg Config) *mux.Router {
 		cfg.HealthRouter,
 		routers.NewVersionRouter(actions.NewVersionController(cfg.ClusterVersion)),
 		routers.NewTessenMetricRouter(actions.NewTessenMetricController(cfg.Bus)),
+		routers.NewPasswordResetRouter(cfg.Store),
 	)
 
 	subrouter.Handle("/metrics", promhttp.Handler())
@@ -251,6 +335,21 @@ func PublicSubrouter(router *mux.Router, cfg Config) *mux.Router {
 	return subrouter
 }
 
+// APIDocsSubrouter initializes a subrouter that serves the OpenAPI document of
+// the routes of the router to the authenticated users
+func APIDocsSubrouter(router *mux.Router, cfg Config) *mux.Router {
+	subrouter := NewSubrouter(
+		router.NewRoute(),
+		middlewares.SimpleLogger{},
+		middlewares.Authentication{Store: cfg.Store},
+		middlewares.LimitRequest{},
+	)
+
+	mountRouters(subrouter, routers.NewAPIDocsRouter(router))
+
+	return subrouter
+}
+
 func notFoundHandler(w http.ResponseWriter, req *http.Request) {
 	w.WriteHeader(http.StatusNotFound)
 	resp := map[string]interface{}{
@@ -267,6 +366,21 @@ func (a *APId) Start() error {
 		return fmt.Errorf("failed to start apid: %s", err)
 	}
 
+	if a.unixSocket != "" {
+		if err := a.serveUnixSocket(); err != nil {
+			_ = ln.Close()
+			return err
+		}
+	}
+
+	if a.tlsReloader != nil {
+		a.wg.Add(1)
+		go func() {
+			defer a.wg.Done()
+			a.tlsReloader.Watch(a.stopping, tlsreload.DefaultInterval)
+		}()
+	}
+
 	a.wg.Add(1)
 
 	go func() {
@@ -286,6 +400,61 @@ func (a *APId) Start() error {
 	return nil
 }
 
+// serveUnixSocket serves the API, without TLS, on the unix socket. The socket
+// is only accessible to the user running the backend, since its requests
+// don't need credentials.
+func (a *APId) serveUnixSocket() error {
+	// Remove the socket left behind by a previous backend process
+	if err := os.Remove(a.unixSocket); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to start apid: %s", err)
+	}
+	ln, err := listenUnixSocket(a.unixSocket)
+	if err != nil {
+		return fmt.Errorf("failed to start apid: %s", err)
+	}
+	logger.Info("starting apid on unix socket: ", a.unixSocket)
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		if err := a.HTTPServer.Serve(ln); err != nil && err != http.ErrServerClosed {
+			a.errChan <- fmt.Errorf("failure while serving api on unix socket: %s", err)
+		}
+	}()
+
+	return nil
+}
+
+// listenUnixSocket listens on the unix socket at the given path, only
+// accessible by the user of the backend. The socket is created in a private
+// directory and moved into place once its permissions are restricted, so that
+// other users can never connect to it in the meantime.
+func listenUnixSocket(path string) (net.Listener, error) {
+	dir, err := ioutil.TempDir(filepath.Dir(path), ".sensu-api-socket")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	tmp := filepath.Join(dir, filepath.Base(path))
+	ln, err := net.Listen("unix", tmp)
+	if err != nil {
+		return nil, err
+	}
+	// The socket is moved, it can't be removed from its temporary path once
+	// the listener is closed
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := os.Chmod(tmp, 0600); err != nil {
+		_ = ln.Close()
+		return nil, err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
 // Stop httpApi.
 func (a *APId) Stop() error {
 	if err := a.HTTPServer.Shutdown(context.TODO()); err != nil {
@@ -296,6 +465,10 @@ func (a *APId) Stop() error {
 		}
 	}
 
+	if a.unixSocket != "" {
+		_ = os.Remove(a.unixSocket)
+	}
+
 	a.running.Store(false)
 	close(a.stopping)
 	a.wg.Wait()
@@ -319,3 +492,21 @@ func mountRouters(parent *mux.Router, subRouters ...routers.Router) {
 		subRouter.Mount(parent)
 	}
 }
+
+// DebugSubrouter initializes a subrouter that handles all requests coming to
+// /debug, which inspect the internal state of the backends
+func DebugSubrouter(router *mux.Router, cfg Config) *mux.Router {
+	subrouter := NewSubrouter(
+		router.PathPrefix("/debug/"),
+		middlewares.SimpleLogger{},
+		middlewares.Authentication{Store: cfg.Store},
+		middlewares.AuthorizationAttributes{},
+		middlewares.Authorization{Authorizer: &rbac.Authorizer{Store: cfg.Store}},
+		middlewares.LimitRequest{},
+	)
+	if cfg.SwitchInspector != nil {
+		mountRouters(subrouter, routers.NewMonitorsRouter(cfg.SwitchInspector))
+	}
+
+	return subrouter
+}
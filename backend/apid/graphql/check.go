@@ -135,6 +135,20 @@ func (r *checkImpl) NodeID(p graphql.ResolveParams) (string, error) {
 	return globalid.CheckTranslator.EncodeToString(p.Context, &config), nil
 }
 
+// Metadata implements response to request for 'metadata' field.
+func (r *checkImpl) Metadata(p graphql.ResolveParams) (interface{}, error) {
+	check := p.Source.(*corev2.Check)
+	check = check.GetRedactedCheck()
+	return check.GetObjectMeta(), nil
+}
+
+// EnvVars implements response to request for 'envVars' field.
+func (r *checkImpl) EnvVars(p graphql.ResolveParams) ([]string, error) {
+	check := p.Source.(*corev2.Check)
+	check = check.GetRedactedCheck()
+	return check.EnvVars, nil
+}
+
 // Executed implements response to request for 'executed' field.
 func (r *checkImpl) Executed(p graphql.ResolveParams) (time.Time, error) {
 	c := p.Source.(*corev2.Check)
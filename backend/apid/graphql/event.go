@@ -90,6 +90,22 @@ func (r *eventImpl) Silences(p graphql.ResolveParams) (interface{}, error) {
 	return records, err
 }
 
+// RunbookURL implements response to request for 'runbookURL' field.
+func (r *eventImpl) RunbookURL(p graphql.ResolveParams) (string, error) {
+	event := p.Source.(*corev2.Event)
+	return event.RunbookURL(), nil
+}
+
+// Links implements response to request for 'links' field.
+func (r *eventImpl) Links(p graphql.ResolveParams) (interface{}, error) {
+	event := p.Source.(*corev2.Event)
+	links := event.Links()
+	if links == nil {
+		links = []corev2.EventLink{}
+	}
+	return links, nil
+}
+
 // IsTypeOf is used to determine if a given value is associated with the type
 func (r *eventImpl) IsTypeOf(s interface{}, p graphql.IsTypeOfParams) bool {
 	_, ok := s.(*corev2.Event)
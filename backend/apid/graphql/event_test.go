@@ -102,3 +102,32 @@ func TestEventTypeSilencesField(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, res, 4)
 }
+
+func TestEventTypeLinksField(t *testing.T) {
+	event := corev2.FixtureEvent("my-entity", "my-check")
+	impl := &eventImpl{}
+	params := graphql.ResolveParams{Source: event}
+
+	// no links
+	res, err := impl.Links(params)
+	require.NoError(t, err)
+	assert.Equal(t, []corev2.EventLink{}, res)
+
+	event.Check.Annotations = map[string]string{
+		corev2.RunbookURLAnnotation:               "https://wiki.example.com/my-check",
+		corev2.LinkAnnotationPrefix + "dashboard": "https://grafana.example.com",
+		corev2.LinkAnnotationPrefix + "logs":      "https://logs.example.com",
+		corev2.LinkAnnotationPrefix + "empty":     " ",
+		"unrelated":                               "https://example.com",
+	}
+	res, err = impl.Links(params)
+	require.NoError(t, err)
+	assert.Equal(t, []corev2.EventLink{
+		{Name: "dashboard", URL: "https://grafana.example.com"},
+		{Name: "logs", URL: "https://logs.example.com"},
+	}, res)
+
+	url, err := impl.RunbookURL(params)
+	require.NoError(t, err)
+	assert.Equal(t, "https://wiki.example.com/my-check", url)
+}
@@ -93,6 +93,18 @@ type EventSilencedFieldResolver interface {
 	Silenced(p graphql.ResolveParams) ([]string, error)
 }
 
+// EventRunbookURLFieldResolver implement to resolve requests for the Event's runbookURL field.
+type EventRunbookURLFieldResolver interface {
+	// RunbookURL implements response to request for runbookURL field.
+	RunbookURL(p graphql.ResolveParams) (string, error)
+}
+
+// EventLinksFieldResolver implement to resolve requests for the Event's links field.
+type EventLinksFieldResolver interface {
+	// Links implements response to request for links field.
+	Links(p graphql.ResolveParams) (interface{}, error)
+}
+
 // EventToJSONFieldResolver implement to resolve requests for the Event's toJSON field.
 type EventToJSONFieldResolver interface {
 	// ToJSON implements response to request for toJSON field.
@@ -175,6 +187,8 @@ type EventFieldResolvers interface {
 	EventIsSilencedFieldResolver
 	EventSilencesFieldResolver
 	EventSilencedFieldResolver
+	EventRunbookURLFieldResolver
+	EventLinksFieldResolver
 	EventToJSONFieldResolver
 }
 
@@ -372,6 +386,25 @@ func (_ EventAliases) Silenced(p graphql.ResolveParams) ([]string, error) {
 	return ret, err
 }
 
+// RunbookURL implements response to request for 'runbookURL' field.
+func (_ EventAliases) RunbookURL(p graphql.ResolveParams) (string, error) {
+	val, err := graphql.DefaultResolver(p.Source, p.Info.FieldName)
+	ret, ok := val.(string)
+	if err != nil {
+		return ret, err
+	}
+	if !ok {
+		return ret, errors.New("unable to coerce value for field 'runbookURL'")
+	}
+	return ret, err
+}
+
+// Links implements response to request for 'links' field.
+func (_ EventAliases) Links(p graphql.ResolveParams) (interface{}, error) {
+	val, err := graphql.DefaultResolver(p.Source, p.Info.FieldName)
+	return val, err
+}
+
 // ToJSON implements response to request for 'toJSON' field.
 func (_ EventAliases) ToJSON(p graphql.ResolveParams) (interface{}, error) {
 	val, err := graphql.DefaultResolver(p.Source, p.Info.FieldName)
@@ -483,6 +516,20 @@ func _ObjTypeEventSilencedHandler(impl interface{}) graphql1.FieldResolveFn {
 	}
 }
 
+func _ObjTypeEventRunbookURLHandler(impl interface{}) graphql1.FieldResolveFn {
+	resolver := impl.(EventRunbookURLFieldResolver)
+	return func(frp graphql1.ResolveParams) (interface{}, error) {
+		return resolver.RunbookURL(frp)
+	}
+}
+
+func _ObjTypeEventLinksHandler(impl interface{}) graphql1.FieldResolveFn {
+	resolver := impl.(EventLinksFieldResolver)
+	return func(frp graphql1.ResolveParams) (interface{}, error) {
+		return resolver.Links(frp)
+	}
+}
+
 func _ObjTypeEventToJSONHandler(impl interface{}) graphql1.FieldResolveFn {
 	resolver := impl.(EventToJSONFieldResolver)
 	return func(frp graphql1.ResolveParams) (interface{}, error) {
@@ -550,6 +597,13 @@ func _ObjectTypeEventConfigFn() graphql1.ObjectConfig {
 				Name:              "isSilenced",
 				Type:              graphql1.NewNonNull(graphql1.Boolean),
 			},
+			"links": &graphql1.Field{
+				Args:              graphql1.FieldConfigArgument{},
+				DeprecationReason: "",
+				Description:       "links are the named URLs giving context about the event, e.g. a dashboard.",
+				Name:              "links",
+				Type:              graphql1.NewNonNull(graphql1.NewList(graphql1.NewNonNull(graphql.OutputType("EventLink")))),
+			},
 			"metadata": &graphql1.Field{
 				Args:              graphql1.FieldConfigArgument{},
 				DeprecationReason: "",
@@ -564,6 +618,13 @@ func _ObjectTypeEventConfigFn() graphql1.ObjectConfig {
 				Name:              "namespace",
 				Type:              graphql1.NewNonNull(graphql1.String),
 			},
+			"runbookURL": &graphql1.Field{
+				Args:              graphql1.FieldConfigArgument{},
+				DeprecationReason: "",
+				Description:       "runbookURL is the URL of the runbook of the event, if any.",
+				Name:              "runbookURL",
+				Type:              graphql1.String,
+			},
 			"silenced": &graphql1.Field{
 				Args:              graphql1.FieldConfigArgument{},
 				DeprecationReason: "",
@@ -629,8 +690,10 @@ var _ObjectTypeEventDesc = graphql.ObjectDesc{
 		"isNewIncident": _ObjTypeEventIsNewIncidentHandler,
 		"isResolution":  _ObjTypeEventIsResolutionHandler,
 		"isSilenced":    _ObjTypeEventIsSilencedHandler,
+		"links":         _ObjTypeEventLinksHandler,
 		"metadata":      _ObjTypeEventMetadataHandler,
 		"namespace":     _ObjTypeEventNamespaceHandler,
+		"runbookURL":    _ObjTypeEventRunbookURLHandler,
 		"silenced":      _ObjTypeEventSilencedHandler,
 		"silences":      _ObjTypeEventSilencesHandler,
 		"timestamp":     _ObjTypeEventTimestampHandler,
@@ -639,6 +702,219 @@ var _ObjectTypeEventDesc = graphql.ObjectDesc{
 	},
 }
 
+// EventLinkNameFieldResolver implement to resolve requests for the EventLink's name field.
+type EventLinkNameFieldResolver interface {
+	// Name implements response to request for name field.
+	Name(p graphql.ResolveParams) (string, error)
+}
+
+// EventLinkUrlFieldResolver implement to resolve requests for the EventLink's url field.
+type EventLinkUrlFieldResolver interface {
+	// Url implements response to request for url field.
+	Url(p graphql.ResolveParams) (string, error)
+}
+
+//
+// EventLinkFieldResolvers represents a collection of methods whose products represent the
+// response values of the 'EventLink' type.
+//
+// == Example SDL
+//
+//   """
+//   Dog's are not hooman.
+//   """
+//   type Dog implements Pet {
+//     "name of this fine beast."
+//     name:  String!
+//
+//     "breed of this silly animal; probably shibe."
+//     breed: [Breed]
+//   }
+//
+// == Example generated interface
+//
+//   // DogResolver ...
+//   type DogFieldResolvers interface {
+//     DogNameFieldResolver
+//     DogBreedFieldResolver
+//
+//     // IsTypeOf is used to determine if a given value is associated with the Dog type
+//     IsTypeOf(interface{}, graphql.IsTypeOfParams) bool
+//   }
+//
+// == Example implementation ...
+//
+//   // DogResolver implements DogFieldResolvers interface
+//   type DogResolver struct {
+//     logger logrus.LogEntry
+//     store interface{
+//       store.BreedStore
+//       store.DogStore
+//     }
+//   }
+//
+//   // Name implements response to request for name field.
+//   func (r *DogResolver) Name(p graphql.ResolveParams) (interface{}, error) {
+//     // ... implementation details ...
+//     dog := p.Source.(DogGetter)
+//     return dog.GetName()
+//   }
+//
+//   // Breed implements response to request for breed field.
+//   func (r *DogResolver) Breed(p graphql.ResolveParams) (interface{}, error) {
+//     // ... implementation details ...
+//     dog := p.Source.(DogGetter)
+//     breed := r.store.GetBreed(dog.GetBreedName())
+//     return breed
+//   }
+//
+//   // IsTypeOf is used to determine if a given value is associated with the Dog type
+//   func (r *DogResolver) IsTypeOf(p graphql.IsTypeOfParams) bool {
+//     // ... implementation details ...
+//     _, ok := p.Value.(DogGetter)
+//     return ok
+//   }
+//
+type EventLinkFieldResolvers interface {
+	EventLinkNameFieldResolver
+	EventLinkUrlFieldResolver
+}
+
+// EventLinkAliases implements all methods on EventLinkFieldResolvers interface by using reflection to
+// match name of field to a field on the given value. Intent is reduce friction
+// of writing new resolvers by removing all the instances where you would simply
+// have the resolvers method return a field.
+//
+// == Example SDL
+//
+//    type Dog {
+//      name:   String!
+//      weight: Float!
+//      dob:    DateTime
+//      breed:  [Breed]
+//    }
+//
+// == Example generated aliases
+//
+//   type DogAliases struct {}
+//   func (_ DogAliases) Name(p graphql.ResolveParams) (interface{}, error) {
+//     // reflect...
+//   }
+//   func (_ DogAliases) Weight(p graphql.ResolveParams) (interface{}, error) {
+//     // reflect...
+//   }
+//   func (_ DogAliases) Dob(p graphql.ResolveParams) (interface{}, error) {
+//     // reflect...
+//   }
+//   func (_ DogAliases) Breed(p graphql.ResolveParams) (interface{}, error) {
+//     // reflect...
+//   }
+//
+// == Example Implementation
+//
+//   type DogResolver struct { // Implements DogResolver
+//     DogAliases
+//     store store.BreedStore
+//   }
+//
+//   // NOTE:
+//   // All other fields are satisified by DogAliases but since this one
+//   // requires hitting the store we implement it in our resolver.
+//   func (r *DogResolver) Breed(p graphql.ResolveParams) interface{} {
+//     dog := v.(*Dog)
+//     return r.BreedsById(dog.BreedIDs)
+//   }
+//
+type EventLinkAliases struct{}
+
+// Name implements response to request for 'name' field.
+func (_ EventLinkAliases) Name(p graphql.ResolveParams) (string, error) {
+	val, err := graphql.DefaultResolver(p.Source, p.Info.FieldName)
+	ret, ok := val.(string)
+	if err != nil {
+		return ret, err
+	}
+	if !ok {
+		return ret, errors.New("unable to coerce value for field 'name'")
+	}
+	return ret, err
+}
+
+// Url implements response to request for 'url' field.
+func (_ EventLinkAliases) Url(p graphql.ResolveParams) (string, error) {
+	val, err := graphql.DefaultResolver(p.Source, p.Info.FieldName)
+	ret, ok := val.(string)
+	if err != nil {
+		return ret, err
+	}
+	if !ok {
+		return ret, errors.New("unable to coerce value for field 'url'")
+	}
+	return ret, err
+}
+
+// EventLinkType EventLink is a named URL giving context about an event, e.g. a dashboard.
+var EventLinkType = graphql.NewType("EventLink", graphql.ObjectKind)
+
+// RegisterEventLink registers EventLink object type with given service.
+func RegisterEventLink(svc *graphql.Service, impl EventLinkFieldResolvers) {
+	svc.RegisterObject(_ObjectTypeEventLinkDesc, impl)
+}
+func _ObjTypeEventLinkNameHandler(impl interface{}) graphql1.FieldResolveFn {
+	resolver := impl.(EventLinkNameFieldResolver)
+	return func(frp graphql1.ResolveParams) (interface{}, error) {
+		return resolver.Name(frp)
+	}
+}
+
+func _ObjTypeEventLinkUrlHandler(impl interface{}) graphql1.FieldResolveFn {
+	resolver := impl.(EventLinkUrlFieldResolver)
+	return func(frp graphql1.ResolveParams) (interface{}, error) {
+		return resolver.Url(frp)
+	}
+}
+
+func _ObjectTypeEventLinkConfigFn() graphql1.ObjectConfig {
+	return graphql1.ObjectConfig{
+		Description: "EventLink is a named URL giving context about an event, e.g. a dashboard.",
+		Fields: graphql1.Fields{
+			"name": &graphql1.Field{
+				Args:              graphql1.FieldConfigArgument{},
+				DeprecationReason: "",
+				Description:       "name of the link.",
+				Name:              "name",
+				Type:              graphql1.NewNonNull(graphql1.String),
+			},
+			"url": &graphql1.Field{
+				Args:              graphql1.FieldConfigArgument{},
+				DeprecationReason: "",
+				Description:       "url of the link.",
+				Name:              "url",
+				Type:              graphql1.NewNonNull(graphql1.String),
+			},
+		},
+		Interfaces: []*graphql1.Interface{},
+		IsTypeOf: func(_ graphql1.IsTypeOfParams) bool {
+			// NOTE:
+			// Panic by default. Intent is that when Service is invoked, values of
+			// these fields are updated with instantiated resolvers. If these
+			// defaults are called it is most certainly programmer err.
+			// If you're see this comment then: 'Whoops! Sorry, my bad.'
+			panic("Unimplemented; see EventLinkFieldResolvers.")
+		},
+		Name: "EventLink",
+	}
+}
+
+// describe EventLink's configuration; kept private to avoid unintentional tampering of configuration at runtime.
+var _ObjectTypeEventLinkDesc = graphql.ObjectDesc{
+	Config: _ObjectTypeEventLinkConfigFn,
+	FieldHandlers: map[string]graphql.FieldHandler{
+		"name": _ObjTypeEventLinkNameHandler,
+		"url":  _ObjTypeEventLinkUrlHandler,
+	},
+}
+
 // EventConnectionNodesFieldResolver implement to resolve requests for the EventConnection's nodes field.
 type EventConnectionNodesFieldResolver interface {
 	// Nodes implements response to request for nodes field.
@@ -112,6 +112,7 @@ func NewService(cfg ServiceConfig) (*Service, error) {
 	// Register event types
 	schema.RegisterEvent(svc, &eventImpl{})
 	schema.RegisterEventConnection(svc, &schema.EventConnectionAliases{})
+	schema.RegisterEventLink(svc, &schema.EventLinkAliases{})
 
 	// Register event filter types
 	schema.RegisterEventFilter(svc, &eventFilterImpl{})
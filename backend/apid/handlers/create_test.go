@@ -8,6 +8,7 @@ import (
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/fixture"
@@ -53,7 +54,7 @@ func TestHandlers_CreateResource(t *testing.T) {
 			body: marshal(t, fixture.Resource{ObjectMeta: corev2.ObjectMeta{}}),
 			storeFunc: func(s *mockstore.MockStore) {
 				s.On("CreateResource", mock.Anything, mock.AnythingOfType("*fixture.Resource")).
-					Return(&store.ErrNotValid{})
+					Return(&store.ErrNotValid{Err: corev2.NewFieldError("spec.foo", "invalid")})
 			},
 			wantErr: true,
 		},
@@ -99,6 +100,29 @@ func TestHandlers_CreateResource(t *testing.T) {
 	}
 }
 
+func TestCreateResourceValidationError(t *testing.T) {
+	s := &mockstore.MockStore{}
+	h := Handlers{
+		Resource: &fixture.Resource{},
+		Store:    s,
+	}
+
+	fieldErr := corev2.NewFieldError("spec.type", "unknown type: foo", "bar", "baz")
+	s.On("CreateResource", mock.Anything, mock.AnythingOfType("*fixture.Resource")).
+		Return(&store.ErrNotValid{Err: fieldErr})
+
+	body := marshal(t, fixture.Resource{ObjectMeta: corev2.ObjectMeta{}})
+	r, _ := http.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+	_, err := h.CreateResource(r)
+
+	actionErr, ok := err.(actions.Error)
+	if !ok {
+		t.Fatalf("expected an actions.Error, got %T", err)
+	}
+	assert.Equal(t, actions.InvalidArgument, actionErr.Code)
+	assert.Equal(t, []corev2.FieldError{*fieldErr}, actionErr.Fields)
+}
+
 func TestCreatedByCreate(t *testing.T) {
 	claims, err := jwt.NewClaims(&corev2.User{Username: "admin"})
 	assert.NoError(t, err)
@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"net/url"
+	"strings"
 
 	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/store"
 )
@@ -17,10 +20,24 @@ func (h Handlers) DeleteResource(r *http.Request) (interface{}, error) {
 		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
 
+	if len(h.Referrers) > 0 && r.URL.Query().Get("force") != "true" {
+		referrers, err := h.ReferencedBy(r.Context(), name)
+		if err != nil {
+			return nil, err
+		}
+		if len(referrers) > 0 {
+			return nil, actions.NewErrorf(actions.FailedPrecondition,
+				"%s/%s is referenced by %s, remove the references or force its deletion",
+				h.Resource.RBACName(), name, strings.Join(referrers, ", "))
+		}
+	}
+
 	if err := h.Store.DeleteResource(r.Context(), h.Resource.StorePrefix(), name); err != nil {
 		switch err := err.(type) {
 		case *store.ErrNotFound:
 			return nil, actions.NewErrorf(actions.NotFound)
+		case *store.ErrNotValid:
+			return nil, actions.NewValidationError(err)
 		default:
 			return nil, actions.NewError(actions.InternalErr, err)
 		}
@@ -28,3 +45,24 @@ func (h Handlers) DeleteResource(r *http.Request) (interface{}, error) {
 
 	return nil, nil
 }
+
+// ReferencedBy returns the type and name of the resources of the namespace
+// referencing the resource with the given name.
+func (h Handlers) ReferencedBy(ctx context.Context, name string) ([]string, error) {
+	referrers := []string{}
+	for _, referrer := range h.Referrers {
+		resources, err := h.listResources(ctx, referrer, &store.SelectionPredicate{})
+		if err != nil {
+			return nil, err
+		}
+		for _, resource := range resources {
+			if resource != nil && corev2.IsReferenced(resource, h.Resource.RBACName(), name) {
+				referrers = append(referrers, corev2.ResourceReference{
+					Type: resource.RBACName(),
+					Name: resource.GetObjectMeta().Name,
+				}.String())
+			}
+		}
+	}
+	return referrers, nil
+}
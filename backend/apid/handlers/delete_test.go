@@ -5,10 +5,14 @@ import (
 	"testing"
 
 	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/fixture"
 	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestHandlers_DeleteResource(t *testing.T) {
@@ -74,3 +78,38 @@ func TestHandlers_DeleteResource(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlers_DeleteReferencedResource(t *testing.T) {
+	store := &mockstore.MockStore{}
+	store.On("ListResources", mock.Anything, "checks", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			checks := args[2].(*[]*corev2.CheckConfig)
+			check := corev2.FixtureCheckConfig("check-cpu")
+			check.Handlers = []string{"slack"}
+			*checks = append(*checks, check, corev2.FixtureCheckConfig("check-mem"))
+		}).
+		Return(nil)
+
+	h := Handlers{
+		Resource:  &corev2.Handler{},
+		Store:     store,
+		Referrers: []corev2.Resource{&corev2.CheckConfig{}},
+	}
+
+	r, _ := http.NewRequest(http.MethodDelete, "/", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "slack"})
+	_, err := h.DeleteResource(r)
+	require.Error(t, err)
+	code, _ := actions.StatusFromError(err)
+	assert.Equal(t, actions.FailedPrecondition, code)
+	assert.Contains(t, err.Error(), "checks/check-cpu")
+	assert.NotContains(t, err.Error(), "check-mem")
+	store.AssertNotCalled(t, "DeleteResource", mock.Anything, mock.Anything, mock.Anything)
+
+	// The deletion can be forced
+	store.On("DeleteResource", mock.Anything, "handlers", "slack").Return(nil)
+	r, _ = http.NewRequest(http.MethodDelete, "/?force=true", nil)
+	r = mux.SetURLVars(r, map[string]string{"id": "slack"})
+	_, err = h.DeleteResource(r)
+	assert.NoError(t, err)
+}
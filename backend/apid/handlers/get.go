@@ -8,6 +8,7 @@ import (
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/apid/middlewares"
 	"github.com/sensu/sensu-go/backend/store"
 )
 
@@ -25,6 +26,24 @@ func (h Handlers) GetResource(r *http.Request) (interface{}, error) {
 		return nil, actions.NewErrorf(actions.InternalErr)
 	}
 
+	// The ETag of the resource is derived from the version it is read at, in
+	// the same read so that they always match
+	if txnStore, ok := h.Store.(store.ResourceTxnStore); ok && middlewares.WantsETag(r) {
+		version, err := txnStore.GetVersionedResource(r.Context(), name, resource)
+		if err != nil {
+			switch err := err.(type) {
+			case *store.ErrNotFound:
+				return nil, actions.NewErrorf(actions.NotFound)
+			default:
+				return nil, actions.NewError(actions.InternalErr, err)
+			}
+		}
+		if middlewares.SetVersionETag(r, version) {
+			return nil, actions.NewErrorf(actions.NotModified)
+		}
+		return resource, nil
+	}
+
 	if err := h.Store.GetResource(r.Context(), name, resource); err != nil {
 		switch err := err.(type) {
 		case *store.ErrNotFound:
@@ -2,13 +2,17 @@ package handlers
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
 	"github.com/gorilla/mux"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/apid/middlewares"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/fixture"
 	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -86,3 +90,52 @@ func TestHandlers_GetResource(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlers_GetResourceVersionETag(t *testing.T) {
+	st := &mockstore.MockStore{}
+	st.On("GetVersionedResource", mock.Anything, "foo", mock.AnythingOfType("*fixture.Resource")).Return(int64(42), nil)
+	h := Handlers{
+		Resource: &fixture.Resource{},
+		Store:    st,
+	}
+
+	var err error
+	handler := middlewares.ETag{}.Then(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = mux.SetURLVars(r, map[string]string{"id": "foo"})
+		_, err = h.GetResource(r)
+	}))
+
+	// The resource and its version are read at once
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	assert.NoError(t, err)
+	assert.Equal(t, middlewares.VersionETag(42), w.Header().Get("ETag"))
+	st.AssertNumberOfCalls(t, "GetVersionedResource", 1)
+	st.AssertNotCalled(t, "GetResource", mock.Anything, mock.Anything, mock.Anything)
+
+	// The resource is not returned if the client already has it
+	r.Header.Set("If-None-Match", middlewares.VersionETag(42))
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	code, _ := actions.StatusFromError(err)
+	assert.Equal(t, actions.NotModified, code)
+}
+
+func TestHandlers_GetResourceVersionETagNotFound(t *testing.T) {
+	st := &mockstore.MockStore{}
+	st.On("GetVersionedResource", mock.Anything, "foo", mock.AnythingOfType("*fixture.Resource")).Return(int64(0), &store.ErrNotFound{Key: "foo"})
+	h := Handlers{
+		Resource: &fixture.Resource{},
+		Store:    st,
+	}
+
+	var err error
+	handler := middlewares.ETag{}.Then(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r = mux.SetURLVars(r, map[string]string{"id": "foo"})
+		_, err = h.GetResource(r)
+	}))
+	r, _ := http.NewRequest(http.MethodGet, "/", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	code, _ := actions.StatusFromError(err)
+	assert.Equal(t, actions.NotFound, code)
+}
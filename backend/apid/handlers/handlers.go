@@ -12,6 +12,11 @@ import (
 type Handlers struct {
 	Resource corev2.Resource
 	Store    store.ResourceStore
+
+	// Referrers are the types of the resources that may reference the
+	// resources of this type. A resource referenced by other resources of its
+	// namespace is only deleted if the force query parameter is true.
+	Referrers []corev2.Resource
 }
 
 // CheckMeta inspects the resource metadata and ensures it matches what was
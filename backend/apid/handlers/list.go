@@ -11,14 +11,19 @@ import (
 
 // ListResources lists all resources for the resource type
 func (h Handlers) ListResources(ctx context.Context, pred *store.SelectionPredicate) ([]corev2.Resource, error) {
+	return h.listResources(ctx, h.Resource, pred)
+}
+
+// listResources lists all resources of the type of resource
+func (h Handlers) listResources(ctx context.Context, resource corev2.Resource, pred *store.SelectionPredicate) ([]corev2.Resource, error) {
 	// Get the type of the resource and create a slice type of []type
-	typeOfResource := reflect.TypeOf(h.Resource)
+	typeOfResource := reflect.TypeOf(resource)
 	sliceOfResource := reflect.SliceOf(typeOfResource)
 	// Create a pointer to our slice type and then set the slice value
 	ptr := reflect.New(sliceOfResource)
 	ptr.Elem().Set(reflect.MakeSlice(sliceOfResource, 0, 0))
 
-	if err := h.Store.ListResources(ctx, h.Resource.StorePrefix(), ptr.Interface(), pred); err != nil {
+	if err := h.Store.ListResources(ctx, resource.StorePrefix(), ptr.Interface(), pred); err != nil {
 		return nil, actions.NewError(actions.InternalErr, err)
 	}
 
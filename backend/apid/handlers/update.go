@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"reflect"
@@ -8,12 +9,14 @@ import (
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/apid/middlewares"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
 	"github.com/sensu/sensu-go/backend/store"
 )
 
 // CreateOrUpdateResource creates or updates the resource given in the request
-// body, regardless of whether it already exists or not
+// body, regardless of whether it already exists or not. If the request has an
+// If-Match header, the resource is only updated if it still matches it.
 func (h Handlers) CreateOrUpdateResource(r *http.Request) (interface{}, error) {
 	payload := reflect.New(reflect.TypeOf(h.Resource).Elem())
 	if err := json.NewDecoder(r.Body).Decode(payload.Interface()); err != nil {
@@ -35,10 +38,14 @@ func (h Handlers) CreateOrUpdateResource(r *http.Request) (interface{}, error) {
 		resource.SetObjectMeta(meta)
 	}
 
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" {
+		return nil, h.updateIfMatch(r.Context(), resource, ifMatch)
+	}
+
 	if err := h.Store.CreateOrUpdateResource(r.Context(), resource); err != nil {
 		switch err := err.(type) {
 		case *store.ErrNotValid:
-			return nil, actions.NewErrorf(actions.InvalidArgument)
+			return nil, actions.NewValidationError(err)
 		default:
 			return nil, actions.NewError(actions.InternalErr, err)
 		}
@@ -46,3 +53,43 @@ func (h Handlers) CreateOrUpdateResource(r *http.Request) (interface{}, error) {
 
 	return nil, nil
 }
+
+// updateIfMatch updates the resource if the stored resource matches the
+// If-Match header, i.e. the ETag of the resource when the client read it, so
+// that clients doing a read-modify-write don't overwrite concurrent changes.
+// The update is committed at the version of the resource that was compared.
+func (h Handlers) updateIfMatch(ctx context.Context, resource corev2.Resource, ifMatch string) error {
+	txnStore, ok := h.Store.(store.ResourceTxnStore)
+	if !ok {
+		return actions.NewErrorf(actions.InternalErr, "conditional updates are not supported")
+	}
+
+	name := resource.GetObjectMeta().Name
+	current, ok := reflect.New(reflect.TypeOf(h.Resource).Elem()).Interface().(corev2.Resource)
+	if !ok {
+		return actions.NewErrorf(actions.InternalErr)
+	}
+	version, err := txnStore.GetResourceVersion(ctx, name, current)
+	if err != nil {
+		return actions.NewError(actions.InternalErr, err)
+	}
+	if version == store.NoVersion {
+		return actions.NewErrorf(actions.FailedPrecondition, "the resource does not exist")
+	}
+	if !middlewares.MatchVersionETag(ifMatch, version) {
+		return actions.NewErrorf(actions.FailedPrecondition, "the resource was modified since it was read")
+	}
+
+	op := store.ResourceOp{Resource: resource, Version: version}
+	if err := txnStore.CommitResources(ctx, []store.ResourceOp{op}); err != nil {
+		switch err := err.(type) {
+		case *store.ErrConflict:
+			return actions.NewErrorf(actions.FailedPrecondition, "the resource was modified since it was read")
+		case *store.ErrNotValid:
+			return actions.NewValidationError(err)
+		default:
+			return actions.NewError(actions.InternalErr, err)
+		}
+	}
+	return nil
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/apid/middlewares"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/fixture"
@@ -44,7 +46,7 @@ func TestHandlers_UpdateResource(t *testing.T) {
 			body: marshal(t, fixture.Resource{ObjectMeta: corev2.ObjectMeta{}}),
 			storeFunc: func(s *mockstore.MockStore) {
 				s.On("CreateOrUpdateResource", mock.Anything, mock.AnythingOfType("*fixture.Resource")).
-					Return(&store.ErrNotValid{})
+					Return(&store.ErrNotValid{Err: corev2.NewFieldError("spec.foo", "invalid")})
 			},
 			wantErr: true,
 		},
@@ -110,3 +112,78 @@ func TestCreatedByUpdate(t *testing.T) {
 	_, err = h.CreateOrUpdateResource(req)
 	assert.NoError(t, err)
 }
+
+func TestHandlers_UpdateResourceIfMatch(t *testing.T) {
+	stored := fixture.Resource{ObjectMeta: corev2.ObjectMeta{Name: "foo", Namespace: "default"}, Foo: "stored"}
+
+	tests := []struct {
+		name      string
+		ifMatch   string
+		storeFunc func(*mockstore.MockStore)
+		wantCode  actions.ErrCode
+		wantErr   bool
+	}{
+		{
+			name:    "resource not modified",
+			ifMatch: middlewares.VersionETag(42),
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResourceVersion", mock.Anything, "foo", mock.Anything).Return(int64(42), nil)
+				s.On("CommitResources", mock.Anything, mock.MatchedBy(func(ops []store.ResourceOp) bool {
+					return len(ops) == 1 && ops[0].Version == 42
+				})).Return(nil)
+			},
+		},
+		{
+			name:    "resource modified since it was read",
+			ifMatch: middlewares.VersionETag(41),
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResourceVersion", mock.Anything, "foo", mock.Anything).Return(int64(42), nil)
+			},
+			wantCode: actions.FailedPrecondition,
+			wantErr:  true,
+		},
+		{
+			name:    "resource modified concurrently",
+			ifMatch: "*",
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResourceVersion", mock.Anything, "foo", mock.Anything).Return(int64(42), nil)
+				s.On("CommitResources", mock.Anything, mock.Anything).Return(&store.ErrConflict{Key: "foo"})
+			},
+			wantCode: actions.FailedPrecondition,
+			wantErr:  true,
+		},
+		{
+			name:    "resource does not exist",
+			ifMatch: "*",
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetResourceVersion", mock.Anything, "foo", mock.Anything).Return(store.NoVersion, nil)
+			},
+			wantCode: actions.FailedPrecondition,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockstore.MockStore{}
+			tt.storeFunc(store)
+			h := Handlers{
+				Resource: &fixture.Resource{},
+				Store:    store,
+			}
+
+			body := marshal(t, fixture.Resource{ObjectMeta: stored.ObjectMeta, Foo: "updated"})
+			r, _ := http.NewRequest(http.MethodPut, "/", bytes.NewReader(body))
+			r.Header.Set("If-Match", tt.ifMatch)
+			r = mux.SetURLVars(r, map[string]string{"id": "foo", "namespace": "default"})
+
+			_, err := h.CreateOrUpdateResource(r)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			code, _ := actions.StatusFromError(err)
+			assert.Equal(t, tt.wantCode, code)
+			store.AssertNotCalled(t, "CreateOrUpdateResource", mock.Anything, mock.Anything)
+		})
+	}
+}
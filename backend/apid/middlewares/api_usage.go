@@ -0,0 +1,259 @@
+package middlewares
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/sensu/sensu-go/backend/etcd"
+)
+
+// anonymousUser is the user of the requests without credentials.
+const anonymousUser = "anonymous"
+
+var (
+	// APIRequests counts the API requests per route, user and status code.
+	APIRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "sensu_go_api_requests",
+			Help: "The total number of API requests",
+		},
+		[]string{"route", "method", "user", "code"},
+	)
+
+	// APIRequestDuration observes the duration of the API requests per route
+	// and user.
+	APIRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "sensu_go_api_request_duration_seconds",
+			Help:    "The duration of the API requests",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"route", "method", "user"},
+	)
+)
+
+type apiUsageKey struct {
+	user   string
+	apiKey bool
+	method string
+	route  string
+}
+
+type apiUsageStats struct {
+	requests    int64
+	errors      int64
+	duration    time.Duration
+	maxDuration time.Duration
+	lastRequest time.Time
+}
+
+// apiUsageKeyPrefix is the prefix of the etcd keys of the API usage of the
+// backends.
+const apiUsageKeyPrefix = "/sensu.io/api_usage/"
+
+// APIUsageTracker aggregates the API requests per user and per route, so the
+// clients responsible for a load spike can be identified. Unlike the
+// prometheus metrics, it also tells apart the requests authenticated with an
+// API key. The usage of the API served by the backend is recorded in etcd, so
+// that the usage of the whole cluster is reported.
+type APIUsageTracker struct {
+	mu       sync.Mutex
+	usage    map[apiUsageKey]*apiUsageStats
+	client   *clientv3.Client
+	reporter *etcd.BackendReporter
+}
+
+// NewAPIUsageTracker returns a new APIUsageTracker. The usage of the other
+// backends is not reported if client is nil.
+func NewAPIUsageTracker(client *clientv3.Client) *APIUsageTracker {
+	t := &APIUsageTracker{
+		usage:  make(map[apiUsageKey]*apiUsageStats),
+		client: client,
+	}
+	if client != nil {
+		t.reporter = etcd.NewBackendReporter(client, apiUsageKeyPrefix, etcd.DefaultReportInterval, func() interface{} {
+			return t.localUsage()
+		})
+	}
+	return t
+}
+
+// Start records the usage of the API served by the backend in etcd until ctx
+// is done.
+func (t *APIUsageTracker) Start(ctx context.Context) {
+	if t.reporter != nil {
+		t.reporter.Start(ctx)
+	}
+}
+
+func (t *APIUsageTracker) record(key apiUsageKey, status int, start time.Time, duration time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	stats, ok := t.usage[key]
+	if !ok {
+		stats = &apiUsageStats{}
+		t.usage[key] = stats
+	}
+	stats.requests++
+	if status >= 400 {
+		stats.errors++
+	}
+	stats.duration += duration
+	if duration > stats.maxDuration {
+		stats.maxDuration = duration
+	}
+	if start.After(stats.lastRequest) {
+		stats.lastRequest = start
+	}
+}
+
+// localUsage returns the usage of the API served by the backend.
+func (t *APIUsageTracker) localUsage() []corev2.APIUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	usage := make([]corev2.APIUsage, 0, len(t.usage))
+	for key, stats := range t.usage {
+		usage = append(usage, corev2.APIUsage{
+			User:            key.user,
+			APIKey:          key.apiKey,
+			Method:          key.method,
+			Route:           key.route,
+			Requests:        stats.requests,
+			Errors:          stats.errors,
+			AverageDuration: (stats.duration / time.Duration(stats.requests)).Seconds(),
+			MaxDuration:     stats.maxDuration.Seconds(),
+			LastRequest:     stats.lastRequest.Unix(),
+		})
+	}
+	return usage
+}
+
+// Usage returns the usage of the API routes served by the running backends of
+// the cluster since they started, sorted by decreasing number of requests.
+func (t *APIUsageTracker) Usage(ctx context.Context) ([]corev2.APIUsage, error) {
+	if t == nil {
+		return []corev2.APIUsage{}, nil
+	}
+	usage := t.localUsage()
+	if t.reporter != nil {
+		reports, err := etcd.GetBackendReports(ctx, t.client, apiUsageKeyPrefix)
+		if err != nil {
+			return nil, err
+		}
+		// The usage of this backend is reported as of now rather than as of
+		// its last record
+		delete(reports, t.reporter.Key())
+		for key, report := range reports {
+			var backendUsage []corev2.APIUsage
+			if err := json.Unmarshal(report, &backendUsage); err != nil {
+				logger.WithError(err).WithField("key", key).Error("couldn't decode the API usage of a backend")
+				continue
+			}
+			usage = append(usage, backendUsage...)
+		}
+	}
+	return mergeAPIUsage(usage), nil
+}
+
+// mergeAPIUsage merges the usage of the same routes by the same users,
+// reported by several backends, and sorts it by decreasing number of requests.
+func mergeAPIUsage(usage []corev2.APIUsage) []corev2.APIUsage {
+	merged := make(map[apiUsageKey]*corev2.APIUsage, len(usage))
+	for i := range usage {
+		u := &usage[i]
+		key := apiUsageKey{user: u.User, apiKey: u.APIKey, method: u.Method, route: u.Route}
+		m, ok := merged[key]
+		if !ok {
+			merged[key] = u
+			continue
+		}
+		requests := m.Requests + u.Requests
+		if requests > 0 {
+			m.AverageDuration = (m.AverageDuration*float64(m.Requests) + u.AverageDuration*float64(u.Requests)) / float64(requests)
+		}
+		m.Requests = requests
+		m.Errors += u.Errors
+		if u.MaxDuration > m.MaxDuration {
+			m.MaxDuration = u.MaxDuration
+		}
+		if u.LastRequest > m.LastRequest {
+			m.LastRequest = u.LastRequest
+		}
+	}
+
+	sorted := make([]corev2.APIUsage, 0, len(merged))
+	for _, u := range merged {
+		sorted = append(sorted, *u)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Requests != sorted[j].Requests {
+			return sorted[i].Requests > sorted[j].Requests
+		}
+		if sorted[i].User != sorted[j].User {
+			return sorted[i].User < sorted[j].User
+		}
+		if sorted[i].Route != sorted[j].Route {
+			return sorted[i].Route < sorted[j].Route
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+	return sorted
+}
+
+// APIUsage is an HTTP middleware recording the requests in the API usage
+// metrics and in Tracker, if not nil. It must be executed after the
+// Authentication middleware to identify the user of the requests.
+type APIUsage struct {
+	Tracker *APIUsageTracker
+}
+
+// Then middleware
+func (m APIUsage) Then(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		writerWithCapture := makeResponseWriterWithCapture(w)
+		next.ServeHTTP(writerWithCapture, r)
+		duration := time.Since(start)
+
+		key := apiUsageKey{
+			user:   anonymousUser,
+			method: r.Method,
+			route:  routeTemplate(r),
+		}
+		if claims := jwt.GetClaimsFromContext(r.Context()); claims != nil && claims.Subject != "" {
+			key.user = claims.Subject
+			key.apiKey = claims.APIKey
+		}
+		status := writerWithCapture.Status()
+
+		APIRequests.WithLabelValues(key.route, key.method, key.user, strconv.Itoa(status)).Inc()
+		APIRequestDuration.WithLabelValues(key.route, key.method, key.user).Observe(duration.Seconds())
+		if m.Tracker != nil {
+			m.Tracker.record(key, status, start, duration)
+		}
+	})
+}
+
+// routeTemplate returns the path template of the route matching the request,
+// which unlike its path doesn't contain resource names and thus keeps the
+// cardinality of the metrics bounded.
+func routeTemplate(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if tpl, err := route.GetPathTemplate(); err == nil {
+			return tpl
+		}
+	}
+	return "unknown"
+}
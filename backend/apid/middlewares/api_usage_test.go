@@ -0,0 +1,71 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIUsage(t *testing.T) {
+	tracker := NewAPIUsageTracker(nil)
+	router := mux.NewRouter()
+	router.Use(func(next http.Handler) http.Handler {
+		return APIUsage{Tracker: tracker}.Then(next)
+	})
+	router.Handle("/checks/{id}", testHandler())
+	router.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+
+	send := func(path string, claims *corev2.Claims) {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if claims != nil {
+			req = req.WithContext(jwt.SetClaimsIntoContext(req, claims))
+		}
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	ci := &corev2.Claims{StandardClaims: corev2.StandardClaims("ci"), APIKey: true}
+	send("/checks/foo", ci)
+	send("/checks/bar", ci)
+	send("/events", ci)
+	send("/events", nil)
+
+	usage, err := tracker.Usage(context.Background())
+	require.NoError(t, err)
+	require.Len(t, usage, 3)
+
+	assert.Equal(t, "ci", usage[0].User)
+	assert.True(t, usage[0].APIKey)
+	assert.Equal(t, "/checks/{id}", usage[0].Route)
+	assert.Equal(t, int64(2), usage[0].Requests)
+	assert.Equal(t, int64(0), usage[0].Errors)
+	assert.NotZero(t, usage[0].LastRequest)
+
+	assert.Equal(t, anonymousUser, usage[1].User)
+	assert.Equal(t, "/events", usage[1].Route)
+	assert.Equal(t, int64(1), usage[1].Errors)
+
+	assert.Equal(t, "ci", usage[2].User)
+	assert.Equal(t, "/events", usage[2].Route)
+	assert.Equal(t, int64(1), usage[2].Errors)
+}
+
+func TestMergeAPIUsage(t *testing.T) {
+	usage := mergeAPIUsage([]corev2.APIUsage{
+		{User: "ci", Method: http.MethodGet, Route: "/events", Requests: 1, Errors: 1, AverageDuration: 4, MaxDuration: 4, LastRequest: 20},
+		{User: "ci", Method: http.MethodGet, Route: "/checks", Requests: 1, AverageDuration: 1, MaxDuration: 1, LastRequest: 10},
+		{User: "ci", Method: http.MethodGet, Route: "/events", Requests: 3, AverageDuration: 2, MaxDuration: 3, LastRequest: 30},
+	})
+	assert.Equal(t, []corev2.APIUsage{
+		{User: "ci", Method: http.MethodGet, Route: "/events", Requests: 4, Errors: 1, AverageDuration: 2.5, MaxDuration: 4, LastRequest: 30},
+		{User: "ci", Method: http.MethodGet, Route: "/checks", Requests: 1, AverageDuration: 1, MaxDuration: 1, LastRequest: 10},
+	}, usage)
+}
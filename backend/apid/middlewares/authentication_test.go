@@ -1,10 +1,15 @@
 package middlewares
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
@@ -154,3 +159,58 @@ func TestMiddlewareNoUserAPIKey(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
 }
+
+func TestMiddlewareLocalSocket(t *testing.T) {
+	dir, err := ioutil.TempDir("", "sensu-apid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "api.sock")
+
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mware := Authentication{}
+	var username string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if claims := jwt.GetClaimsFromContext(r.Context()); claims != nil {
+			username = claims.Subject
+		}
+	})
+	server := &http.Server{
+		Handler:     mware.Then(handler),
+		ConnContext: LocalConnContext,
+	}
+	go func() { _ = server.Serve(ln) }()
+	defer server.Close()
+
+	client := &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+
+	// Requests received on the unix socket don't need credentials
+	res, err := client.Get("http://unix/")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, LocalUsername, username)
+}
+
+func TestMiddlewareLocalConnContextTCP(t *testing.T) {
+	mware := Authentication{}
+	server := httptest.NewUnstartedServer(mware.Then(testHandler()))
+	server.Config.ConnContext = LocalConnContext
+	server.Start()
+	defer server.Close()
+
+	// Requests received over TCP still need credentials
+	res, err := http.Get(server.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
@@ -67,8 +67,19 @@ func (a AuthorizationAttributes) Then(next http.Handler) http.Handler {
 		// Other resources have snowflake paths; see their corresponding router
 		// and the expected paths above.
 		switch attrs.Resource {
+		case "checks":
+			// Enabling or disabling the publication of several checks updates
+			// them rather than creating a check
+			if r.Method == http.MethodPost && path.Base(r.URL.Path) == "publish" && attrs.ResourceName == "" {
+				attrs.Verb = "update"
+			}
 		case "events":
 			attrs.ResourceName = path.Join(vars["entity"], vars["check"])
+			// Resolving several events updates them rather than creating an
+			// event
+			if r.Method == http.MethodPost && path.Base(r.URL.Path) == "resolve" && attrs.ResourceName == "" {
+				attrs.Verb = "update"
+			}
 		case "silenced":
 			if strings.Contains(r.URL.Path, "/silenced/checks") {
 				attrs.ResourceName = path.Join("checks", vars["check"])
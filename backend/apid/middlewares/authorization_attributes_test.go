@@ -238,6 +238,42 @@ func TestLegacyAuthorizationAttributes(t *testing.T) {
 				Verb:         "create",
 			},
 		},
+		{
+			description: "POST /api/core/v2/namespaces/default/checks/publish",
+			method:      "POST",
+			path:        "/api/core/v2/namespaces/default/checks/publish",
+			expected: authorization.Attributes{
+				APIGroup:   "core",
+				APIVersion: "v2",
+				Namespace:  "default",
+				Resource:   "checks",
+				Verb:       "update",
+			},
+		},
+		{
+			description: "POST /api/core/v2/namespaces/default/events/resolve",
+			method:      "POST",
+			path:        "/api/core/v2/namespaces/default/events/resolve",
+			expected: authorization.Attributes{
+				APIGroup:   "core",
+				APIVersion: "v2",
+				Namespace:  "default",
+				Resource:   "events",
+				Verb:       "update",
+			},
+		},
+		{
+			description: "DELETE /api/core/v2/namespaces/default/events",
+			method:      "DELETE",
+			path:        "/api/core/v2/namespaces/default/events",
+			expected: authorization.Attributes{
+				APIGroup:   "core",
+				APIVersion: "v2",
+				Namespace:  "default",
+				Resource:   "events",
+				Verb:       "delete",
+			},
+		},
 		{
 			description: "PUT /api/core/v2/namespaces/default/checks/foo/hooks/bar",
 			method:      "PUT",
@@ -540,9 +576,11 @@ func TestLegacyAuthorizationAttributes(t *testing.T) {
 			router.PathPrefix("/api/{group}/{version}/namespaces/{namespace}/{resource:cluster}/members/{id}").Handler(testHandler)
 			router.PathPrefix("/api/{group}/{version}/namespaces/{namespace}/{resource:cluster}/members").Handler(testHandler)
 			router.PathPrefix("/api/{group}/{version}/namespaces/{namespace}/{resource:events}/{entity}/{check}").Handler(testHandler)
+			router.PathPrefix("/api/{group}/{version}/namespaces/{namespace}/{resource:events}/resolve").Handler(testHandler)
 			router.PathPrefix("/api/{group}/{version}/namespaces/{namespace}/{resource:events}/{entity}").Handler(testHandler)
 			router.PathPrefix("/api/{group}/{version}/namespaces/{namespace}/{resource:silenced}/checks/{check}").Handler(testHandler)
 			router.PathPrefix("/api/{group}/{version}/namespaces/{namespace}/{resource:silenced}/subscriptions/{subscription}").Handler(testHandler)
+			router.PathPrefix("/api/{group}/{version}/namespaces/{namespace}/{resource:checks}/publish").Handler(testHandler)
 			router.PathPrefix("/api/{group}/{version}/namespaces/{namespace}/{resource}/{id}").Handler(testHandler)
 			router.PathPrefix("/api/{group}/{version}/namespaces/{namespace}/{resource}").Handler(testHandler)
 			router.PathPrefix("/api/{group}/{version}/{resource}/{id}/{subresource}").Handler(testHandler)
@@ -32,6 +32,14 @@ func writeErr(w http.ResponseWriter, err error) {
 		st = http.StatusForbidden
 	case actions.Unauthenticated:
 		st = http.StatusUnauthorized
+	case actions.TooManyRequests:
+		st = http.StatusTooManyRequests
+	case actions.FailedPrecondition:
+		st = http.StatusConflict
+	case actions.NotModified:
+		st = http.StatusNotModified
+	case actions.UnprocessableEntity:
+		st = http.StatusUnprocessableEntity
 	}
 
 	errJSON, err := json.Marshal(errRes)
@@ -0,0 +1,171 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ETag is an HTTP middleware that adds an ETag header to the successful
+// responses of GET requests, and replies with 304 Not Modified to the requests
+// whose If-None-Match header matches it. The handlers returning a single stored
+// resource derive the ETag from the version of the resource with
+// SetVersionETag, so that conditional requests don't need to read it; these
+// ETags are strong, since they identify the resource rather than the bytes of
+// the response. The ETags of the other responses are derived from their
+// content, and are weak, since the content may be compressed. Watch requests
+// are not buffered, and therefore don't get an ETag.
+type ETag struct{}
+
+type etagKey struct{}
+
+// etagState is shared by the ETag middleware and the handler of a request.
+type etagState struct {
+	etag string
+}
+
+// Then middleware
+func (e ETag) Then(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Watch requests stream their response, which can't be buffered
+		if r.Method != http.MethodGet || r.URL.Query().Get("watch") == "true" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		state := &etagState{}
+		r = r.WithContext(context.WithValue(r.Context(), etagKey{}, state))
+
+		// Buffer the response, since the ETag header must be written before
+		// its body
+		buf := &bufferedResponseWriter{header: w.Header(), status: http.StatusOK}
+		next.ServeHTTP(buf, r)
+
+		if state.etag != "" {
+			w.Header().Set("ETag", state.etag)
+			if buf.status == http.StatusNotModified {
+				writeNotModified(w)
+				return
+			}
+		} else if buf.status == http.StatusOK {
+			etag := computeETag(buf.body.Bytes())
+			w.Header().Set("ETag", etag)
+
+			if etagMatch(r.Header.Get("If-None-Match"), etag) {
+				writeNotModified(w)
+				return
+			}
+		}
+
+		w.WriteHeader(buf.status)
+		_, _ = w.Write(buf.body.Bytes())
+	})
+}
+
+func writeNotModified(w http.ResponseWriter) {
+	w.Header().Del("Content-Length")
+	w.Header().Del("Content-Type")
+	w.WriteHeader(http.StatusNotModified)
+}
+
+// computeETag returns the weak ETag of the content of a response.
+func computeETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return fmt.Sprintf(`W/"%x"`, sum[:16])
+}
+
+// VersionETag returns the ETag of a stored resource, derived from its version,
+// i.e. the revision of its last modification in the store.
+func VersionETag(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// WantsETag returns true if the response to the request gets an ETag, i.e. if
+// the handler should call SetVersionETag.
+func WantsETag(r *http.Request) bool {
+	_, ok := r.Context().Value(etagKey{}).(*etagState)
+	return ok
+}
+
+// SetVersionETag sets the ETag of the response to the request to the ETag of
+// the version of the resource it returns, and returns true if the If-None-Match
+// header of the request matches it, in which case the handler should reply
+// with 304 Not Modified without reading the resource.
+func SetVersionETag(r *http.Request, version int64) bool {
+	state, ok := r.Context().Value(etagKey{}).(*etagState)
+	if !ok {
+		return false
+	}
+	state.etag = VersionETag(version)
+	return etagMatch(r.Header.Get("If-None-Match"), state.etag)
+}
+
+// MatchVersionETag returns true if the value of an If-Match header matches the
+// ETag of the given version of a resource, as it would be returned by a GET
+// request. It allows the handlers of conditional updates to compare the ETag
+// given by the client with the current version of the resource. As required
+// by RFC 7232 for If-Match, the ETags are compared with the strong comparison,
+// so weak ETags never match.
+func MatchVersionETag(header string, version int64) bool {
+	return etagStrongMatch(header, VersionETag(version))
+}
+
+// etagStrongMatch returns true if the If-Match header matches the ETag, using
+// the strong comparison.
+func etagStrongMatch(ifMatch, etag string) bool {
+	if ifMatch == "" || strings.HasPrefix(etag, "W/") {
+		return false
+	}
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// etagMatch returns true if the If-None-Match header matches the ETag, using
+// the weak comparison.
+func etagMatch(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedResponseWriter is a http.ResponseWriter that keeps the status and the
+// body of the response in memory.
+type bufferedResponseWriter struct {
+	header      http.Header
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (b *bufferedResponseWriter) Header() http.Header {
+	return b.header
+}
+
+func (b *bufferedResponseWriter) Write(p []byte) (int, error) {
+	b.wroteHeader = true
+	return b.body.Write(p)
+}
+
+func (b *bufferedResponseWriter) WriteHeader(status int) {
+	if b.wroteHeader {
+		return
+	}
+	b.wroteHeader = true
+	b.status = status
+}
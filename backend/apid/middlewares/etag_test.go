@@ -0,0 +1,129 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestETag(t *testing.T) {
+	handler := ETag{}.Then(testHandler())
+
+	// The first request gets the content and its ETag
+	req := httptest.NewRequest(http.MethodGet, "/checks", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Success", w.Body.String())
+	etag := w.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+
+	// The same content has the same ETag
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+
+	// Conditional requests get a 304 when the content did not change
+	req = httptest.NewRequest(http.MethodGet, "/checks", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	// Conditional requests get the content when it changed
+	req = httptest.NewRequest(http.MethodGet, "/checks", nil)
+	req.Header.Set("If-None-Match", `W/"foo"`)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Success", w.Body.String())
+}
+
+func TestETagIgnoredRequests(t *testing.T) {
+	notFound := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+
+	tests := []struct {
+		name    string
+		method  string
+		handler http.Handler
+		status  int
+	}{
+		{"non GET requests", http.MethodPost, testHandler(), http.StatusOK},
+		{"unsuccessful responses", http.MethodGet, notFound, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/checks", nil)
+			req.Header.Set("If-None-Match", "*")
+			w := httptest.NewRecorder()
+			ETag{}.Then(tt.handler).ServeHTTP(w, req)
+			assert.Equal(t, tt.status, w.Code)
+			assert.Empty(t, w.Header().Get("ETag"))
+		})
+	}
+}
+
+func TestETagMatch(t *testing.T) {
+	assert.False(t, etagMatch("", `W/"abc"`))
+	assert.True(t, etagMatch(`W/"abc"`, `W/"abc"`))
+	assert.True(t, etagMatch(`"abc"`, `W/"abc"`))
+	assert.True(t, etagMatch(`"foo", W/"abc"`, `W/"abc"`))
+	assert.True(t, etagMatch("*", `W/"abc"`))
+	assert.False(t, etagMatch(`W/"foo"`, `W/"abc"`))
+}
+
+func TestVersionETag(t *testing.T) {
+	var read bool
+	handler := ETag{}.Then(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, WantsETag(r))
+		if SetVersionETag(r, 42) {
+			w.WriteHeader(http.StatusNotModified)
+			_, _ = w.Write([]byte("not modified"))
+			return
+		}
+		read = true
+		_, _ = w.Write([]byte("Success"))
+	}))
+
+	// The ETag is derived from the version of the resource
+	req := httptest.NewRequest(http.MethodGet, "/checks/foo", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "Success", w.Body.String())
+	assert.Equal(t, `"42"`, w.Header().Get("ETag"))
+	assert.True(t, read)
+
+	// The handler doesn't read the resource if the client has it
+	read = false
+	req.Header.Set("If-None-Match", `"42"`)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.String())
+	assert.Equal(t, `"42"`, w.Header().Get("ETag"))
+	assert.False(t, read)
+
+	// Requests without the middleware don't get an ETag
+	req = httptest.NewRequest(http.MethodGet, "/checks/foo", nil)
+	assert.False(t, WantsETag(req))
+	assert.False(t, SetVersionETag(req, 42))
+}
+
+func TestMatchVersionETag(t *testing.T) {
+	assert.True(t, MatchVersionETag(`"42"`, 42))
+	assert.True(t, MatchVersionETag(`"41", "42"`, 42))
+	assert.False(t, MatchVersionETag(`"41"`, 42))
+	assert.False(t, MatchVersionETag("", 42))
+	assert.True(t, MatchVersionETag("*", 42))
+
+	// Weak ETags never match If-Match
+	assert.False(t, MatchVersionETag(`W/"42"`, 42))
+	assert.False(t, MatchVersionETag(`W/"41", W/"42"`, 42))
+}
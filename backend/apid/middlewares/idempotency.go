@@ -0,0 +1,208 @@
+package middlewares
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+)
+
+// DefaultIdempotencyKeyTTL is the default time during which the responses of
+// the requests with an idempotency key are replayed.
+const DefaultIdempotencyKeyTTL = 10 * time.Minute
+
+// idempotencyKeyPrefix is the prefix of the etcd keys of the responses of the
+// requests with an idempotency key.
+const idempotencyKeyPrefix = "/sensu.io/idempotency/"
+
+// IdempotencyStore records in etcd the responses of the POST requests carrying
+// an idempotency key, so that any backend of the cluster can replay them when
+// the requests are retried. The responses are attached to a lease, so that
+// etcd deletes them once their TTL expires.
+type IdempotencyStore struct {
+	client *clientv3.Client
+	ttl    time.Duration
+}
+
+// idempotentResponse is the response to a request with an idempotency key.
+// It is pending while the request is being processed. RequestHash is the
+// digest of the body of the request, so that the key can't be reused for
+// another request.
+type idempotentResponse struct {
+	Pending     bool        `json:"pending,omitempty"`
+	RequestHash string      `json:"request_hash,omitempty"`
+	Status      int         `json:"status,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// NewIdempotencyStore returns a new IdempotencyStore keeping the responses for
+// the given time.
+func NewIdempotencyStore(client *clientv3.Client, ttl time.Duration) *IdempotencyStore {
+	return &IdempotencyStore{client: client, ttl: ttl}
+}
+
+func idempotencyKeyPath(key string) string {
+	return idempotencyKeyPrefix + digest([]byte(key))
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// claim returns the response recorded for the key, or records a pending
+// response for it, along with the digest of the body of the request, and
+// returns its lease, in which case the caller must record or forget the
+// response.
+func (s *IdempotencyStore) claim(ctx context.Context, key, requestHash string) (*idempotentResponse, clientv3.LeaseID, error) {
+	lease, err := s.client.Grant(ctx, int64(s.ttl/time.Second))
+	if err != nil {
+		return nil, 0, err
+	}
+	pending, err := json.Marshal(&idempotentResponse{Pending: true, RequestHash: requestHash})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	path := idempotencyKeyPath(key)
+	resp, err := s.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(path), "=", 0)).
+		Then(clientv3.OpPut(path, string(pending), clientv3.WithLease(lease.ID))).
+		Else(clientv3.OpGet(path)).
+		Commit()
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.Succeeded {
+		return nil, lease.ID, nil
+	}
+
+	// The key was claimed by a previous request, the lease isn't needed
+	if _, err := s.client.Revoke(ctx, lease.ID); err != nil {
+		logger.WithError(err).Warn("couldn't revoke the lease of an idempotency key")
+	}
+	kvs := resp.Responses[0].GetResponseRange().Kvs
+	if len(kvs) == 0 {
+		// The response expired in the meantime
+		return s.claim(ctx, key, requestHash)
+	}
+	var recorded idempotentResponse
+	if err := json.Unmarshal(kvs[0].Value, &recorded); err != nil {
+		return nil, 0, err
+	}
+	return &recorded, 0, nil
+}
+
+// record records the response of the key.
+func (s *IdempotencyStore) record(ctx context.Context, key string, lease clientv3.LeaseID, resp *idempotentResponse) error {
+	bytes, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Put(ctx, idempotencyKeyPath(key), string(bytes), clientv3.WithLease(lease))
+	return err
+}
+
+// forget removes the pending response of the key, so the request can be
+// processed again.
+func (s *IdempotencyStore) forget(ctx context.Context, lease clientv3.LeaseID) error {
+	_, err := s.client.Revoke(ctx, lease)
+	return err
+}
+
+// Idempotency is an HTTP middleware that replays the response of a POST
+// request when it's retried with the same idempotency key by the same client,
+// instead of processing it again. Responses with a server error status are
+// not replayed, so the request is processed again, and a retry received while
+// the request is still being processed is rejected with a conflict. A request
+// reusing the key of a request with another body is rejected as
+// unprocessable. It should be executed after the Authentication middleware, so
+// that the keys are scoped per user, and after the LimitRequest middleware, as
+// it reads the body of the requests.
+type Idempotency struct {
+	Store *IdempotencyStore
+}
+
+// Then middleware
+func (m Idempotency) Then(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idempotencyKey := r.Header.Get(corev2.IdempotencyKeyHeader)
+		if m.Store == nil || r.Method != http.MethodPost || idempotencyKey == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		key := rateLimitClient(r) + " " + r.URL.Path + " " + idempotencyKey
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			writeErr(w, actions.NewErrorf(actions.InvalidArgument, "couldn't read the request body: %s", err))
+			return
+		}
+		_ = r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		requestHash := digest(body)
+
+		resp, lease, err := m.Store.claim(r.Context(), key, requestHash)
+		if err != nil {
+			// Process the request as if it had no key rather than failing it
+			logger.WithError(err).Error("couldn't claim the idempotency key of the request")
+			next.ServeHTTP(w, r)
+			return
+		}
+		if resp == nil {
+			m.process(w, r, next, key, requestHash, lease)
+			return
+		}
+
+		if resp.RequestHash != requestHash {
+			writeErr(w, actions.NewErrorf(actions.UnprocessableEntity, "the idempotency key was used for a request with another body"))
+			return
+		}
+		if resp.Pending {
+			writeErr(w, actions.NewErrorf(actions.AlreadyExistsErr, "a request with the same idempotency key is being processed"))
+			return
+		}
+		for k, v := range resp.Header {
+			w.Header()[k] = v
+		}
+		w.Header().Set(corev2.IdempotentReplayHeader, "true")
+		w.WriteHeader(resp.Status)
+		_, _ = w.Write(resp.Body)
+	})
+}
+
+func (m Idempotency) process(w http.ResponseWriter, r *http.Request, next http.Handler, key, requestHash string, lease clientv3.LeaseID) {
+	buf := &bufferedResponseWriter{header: w.Header(), status: http.StatusOK}
+	next.ServeHTTP(buf, r)
+
+	// Record the response even if the client is gone, it may retry
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if buf.status >= 500 {
+		if err := m.Store.forget(ctx, lease); err != nil {
+			logger.WithError(err).Error("couldn't forget the idempotency key of the request")
+		}
+	} else {
+		resp := &idempotentResponse{
+			RequestHash: requestHash,
+			Status:      buf.status,
+			Header:      buf.header.Clone(),
+			Body:        buf.body.Bytes(),
+		}
+		if err := m.Store.record(ctx, key, lease, resp); err != nil {
+			logger.WithError(err).Error("couldn't record the response of the request")
+		}
+	}
+
+	w.WriteHeader(buf.status)
+	_, _ = w.Write(buf.body.Bytes())
+}
@@ -0,0 +1,117 @@
+// +build integration
+
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/etcd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotency(t *testing.T) {
+	e, cleanup := etcd.NewTestEtcd(t)
+	defer cleanup()
+	client := e.NewEmbeddedClient()
+	defer client.Close()
+
+	calls := 0
+	status := http.StatusCreated
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(status)
+	})
+	// Two backends sharing the same etcd cluster
+	handlers := []http.Handler{
+		Idempotency{Store: NewIdempotencyStore(client, time.Minute)}.Then(next),
+		Idempotency{Store: NewIdempotencyStore(client, time.Minute)}.Then(next),
+	}
+
+	request := func(backend int, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/checks", strings.NewReader(`{"name":"check-cpu"}`))
+		if key != "" {
+			req.Header.Set(corev2.IdempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		handlers[backend].ServeHTTP(w, req)
+		return w
+	}
+
+	// The first request is processed
+	w := request(0, "foo")
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Empty(t, w.Header().Get(corev2.IdempotentReplayHeader))
+	assert.Equal(t, 1, calls)
+
+	// Its retries are replayed, by any backend
+	for _, backend := range []int{0, 1} {
+		w = request(backend, "foo")
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "true", w.Header().Get(corev2.IdempotentReplayHeader))
+	}
+	assert.Equal(t, 1, calls)
+
+	// The key can't be reused for another request
+	req := httptest.NewRequest(http.MethodPost, "/checks", strings.NewReader(`{"name":"check-mem"}`))
+	req.Header.Set(corev2.IdempotencyKeyHeader, "foo")
+	w = httptest.NewRecorder()
+	handlers[1].ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Equal(t, 1, calls)
+
+	// Requests with another key or without key are processed
+	request(0, "bar")
+	request(1, "")
+	request(1, "")
+	assert.Equal(t, 4, calls)
+
+	// Server errors are not replayed
+	status = http.StatusInternalServerError
+	request(0, "baz")
+	request(1, "baz")
+	assert.Equal(t, 6, calls)
+}
+
+func TestIdempotencyPending(t *testing.T) {
+	e, cleanup := etcd.NewTestEtcd(t)
+	defer cleanup()
+	client := e.NewEmbeddedClient()
+	defer client.Close()
+
+	store := NewIdempotencyStore(client, time.Minute)
+	calls := 0
+	handler := Idempotency{Store: store}.Then(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusCreated)
+		}),
+	)
+
+	// Claim the key as if another backend was processing the request
+	req := httptest.NewRequest(http.MethodPost, "/checks", nil)
+	req.Header.Set(corev2.IdempotencyKeyHeader, "foo")
+	resp, lease, err := store.claim(context.Background(), rateLimitClient(req)+" /checks foo", digest(nil))
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	// Its retries are rejected while it's being processed
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, 0, calls)
+
+	// Once the pending response is gone, e.g. because its lease expired, the
+	// request is processed again
+	require.NoError(t, store.forget(context.Background(), lease))
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,268 @@
+package middlewares
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"golang.org/x/time/rate"
+)
+
+// RouteClass identifies a class of API routes sharing the same rate limit.
+type RouteClass string
+
+const (
+	// RouteClassList is the class of the routes listing resources, which are
+	// the most expensive for the store.
+	RouteClassList RouteClass = "list"
+
+	// RouteClassRead is the class of the routes retrieving a single resource.
+	RouteClassRead RouteClass = "read"
+
+	// RouteClassWrite is the class of the routes creating, updating or
+	// deleting resources.
+	RouteClassWrite RouteClass = "write"
+)
+
+const (
+	// RateLimitHeader is the header containing the number of requests per
+	// second allowed for the route class of the request.
+	RateLimitHeader = "X-RateLimit-Limit"
+
+	// RateLimitRemainingHeader is the header containing the number of
+	// requests the client can still send right away.
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+
+	// RateLimitResetHeader is the header containing the time, in seconds since
+	// the epoch, at which the client will be allowed to send a full burst of
+	// requests again.
+	RateLimitResetHeader = "X-RateLimit-Reset"
+
+	// rateLimiterIdleTimeout is the time after which the limiters of clients
+	// that stopped sending requests are discarded.
+	rateLimiterIdleTimeout = 10 * time.Minute
+)
+
+// RateLimiterConfig configures a RateLimiter.
+type RateLimiterConfig struct {
+	// Limits are the number of requests per second allowed for each client,
+	// per route class. Route classes without a limit, or with a limit of 0,
+	// are not rate limited.
+	Limits map[RouteClass]rate.Limit
+
+	// Burst is the maximum number of requests a client can send at once.
+	Burst int
+
+	// MaxConcurrentRequests is the maximum number of requests processed
+	// concurrently, for all clients. 0 means unlimited.
+	MaxConcurrentRequests int
+}
+
+// RateLimiter keeps track of the rate of requests of each client, i.e. each
+// authenticated user or each IP address, and of the number of requests being
+// processed. It is shared by the RateLimit middlewares of all the subrouters.
+type RateLimiter struct {
+	config    RateLimiterConfig
+	inflight  chan struct{}
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	lastPrune time.Time
+}
+
+// clientLimiter is the token bucket of a client for a route class. It holds
+// up to burst tokens, refilled at the rate limit, and each request takes one.
+type clientLimiter struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// rateLimitStatus is the outcome of the reservation of a request.
+type rateLimitStatus struct {
+	// Delay is the delay after which the request would be allowed, or 0 if
+	// it is allowed right away.
+	Delay time.Duration
+	// Remaining is the number of requests the client can still send right
+	// away.
+	Remaining int
+	// Reset is the time at which the bucket of the client is full again.
+	Reset time.Time
+}
+
+// NewRateLimiter returns a new RateLimiter.
+func NewRateLimiter(config RateLimiterConfig) *RateLimiter {
+	l := &RateLimiter{
+		config:    config,
+		clients:   make(map[string]*clientLimiter),
+		lastPrune: time.Now(),
+	}
+	if config.MaxConcurrentRequests > 0 {
+		l.inflight = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+	return l
+}
+
+// reserve takes a token from the bucket of the client for the given class,
+// if there is one left, and returns the resulting status. It returns false if
+// the class is not rate limited.
+func (l *RateLimiter) reserve(client string, class RouteClass, now time.Time) (rateLimitStatus, bool) {
+	limit := float64(l.config.Limits[class])
+	if limit <= 0 {
+		return rateLimitStatus{}, false
+	}
+	burst := float64(l.config.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastPrune) > rateLimiterIdleTimeout {
+		for key, c := range l.clients {
+			if now.Sub(c.lastSeen) > rateLimiterIdleTimeout {
+				delete(l.clients, key)
+			}
+		}
+		l.lastPrune = now
+	}
+
+	key := string(class) + "/" + client
+	c, ok := l.clients[key]
+	if !ok {
+		c = &clientLimiter{tokens: burst, lastSeen: now}
+		l.clients[key] = c
+	}
+
+	// Refill the bucket for the time elapsed since the last request
+	if elapsed := now.Sub(c.lastSeen).Seconds(); elapsed > 0 {
+		c.tokens = math.Min(burst, c.tokens+elapsed*limit)
+	}
+	c.lastSeen = now
+
+	var status rateLimitStatus
+	if c.tokens >= 1 {
+		c.tokens--
+	} else {
+		// The request is rejected, it doesn't consume a token
+		status.Delay = seconds((1 - c.tokens) / limit)
+	}
+	status.Remaining = int(math.Floor(c.tokens))
+	status.Reset = now.Add(seconds((burst - c.tokens) / limit))
+	return status, true
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
+
+// acquire reserves a slot for a request being processed, and returns false if
+// there is none left. release must be called once the request is processed.
+func (l *RateLimiter) acquire() bool {
+	if l.inflight == nil {
+		return true
+	}
+	select {
+	case l.inflight <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (l *RateLimiter) release() {
+	if l.inflight != nil {
+		<-l.inflight
+	}
+}
+
+// RateLimit is an HTTP middleware that rejects the requests of clients that
+// exceed their rate limit, or that exceed the number of concurrent requests,
+// with a 429 Too Many Requests status. It should be executed after the
+// Authentication and AuthorizationAttributes middlewares, so that requests are
+// limited per user and per route class.
+type RateLimit struct {
+	Limiter *RateLimiter
+
+	// Class forces the route class of all the requests, instead of inferring
+	// it from the authorization attributes or the method of the request.
+	Class RouteClass
+}
+
+// Then middleware
+func (m RateLimit) Then(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if m.Limiter == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		class := m.routeClass(r)
+		if status, limited := m.Limiter.reserve(rateLimitClient(r), class, time.Now()); limited {
+			// The rate limit headers are set on every response of the
+			// limited route classes, so that clients can pace themselves
+			limit := m.Limiter.config.Limits[class]
+			w.Header().Set(RateLimitHeader, strconv.FormatFloat(float64(limit), 'f', -1, 64))
+			w.Header().Set(RateLimitRemainingHeader, strconv.Itoa(status.Remaining))
+			w.Header().Set(RateLimitResetHeader, strconv.FormatInt(int64(math.Ceil(float64(status.Reset.UnixNano())/float64(time.Second))), 10))
+
+			if status.Delay > 0 {
+				retryAfter := int64(math.Ceil(status.Delay.Seconds()))
+				w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+				writeErr(w, actions.NewErrorf(actions.TooManyRequests, "rate limit exceeded"))
+				return
+			}
+		}
+
+		if !m.Limiter.acquire() {
+			w.Header().Set("Retry-After", "1")
+			writeErr(w, actions.NewErrorf(actions.TooManyRequests, "too many concurrent requests"))
+			return
+		}
+		defer m.Limiter.release()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// routeClass returns the route class of the request.
+func (m RateLimit) routeClass(r *http.Request) RouteClass {
+	if m.Class != "" {
+		return m.Class
+	}
+	if attrs := authorization.GetAttributes(r.Context()); attrs != nil {
+		switch attrs.Verb {
+		case "list":
+			return RouteClassList
+		case "get":
+			return RouteClassRead
+		case "":
+		default:
+			return RouteClassWrite
+		}
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		return RouteClassRead
+	default:
+		return RouteClassWrite
+	}
+}
+
+// rateLimitClient identifies the client of the request, i.e. the
+// authenticated user or, for anonymous requests, the remote IP address.
+func rateLimitClient(r *http.Request) string {
+	if claims := jwt.GetClaimsFromContext(r.Context()); claims != nil && claims.Subject != "" {
+		return "user:" + claims.Subject
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
@@ -0,0 +1,179 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/time/rate"
+)
+
+func TestRateLimit(t *testing.T) {
+	limiter := NewRateLimiter(RateLimiterConfig{
+		Limits: map[RouteClass]rate.Limit{
+			RouteClassList: 0.001,
+		},
+		Burst: 2,
+	})
+	handler := RateLimit{Limiter: limiter}.Then(testHandler())
+
+	list := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/checks", nil)
+		req.RemoteAddr = remoteAddr
+		req = req.WithContext(authorization.SetAttributes(req.Context(), &authorization.Attributes{Verb: "list"}))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	// The burst is allowed
+	for i := 0; i < 2; i++ {
+		w := list("10.0.0.1:1234")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "0.001", w.Header().Get(RateLimitHeader))
+		assert.Equal(t, strconv.Itoa(1-i), w.Header().Get(RateLimitRemainingHeader))
+		assert.NotEmpty(t, w.Header().Get(RateLimitResetHeader))
+	}
+
+	// The next request exceeds the limit
+	w := list("10.0.0.1:1234")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Retry-After"))
+	assert.Equal(t, "0.001", w.Header().Get(RateLimitHeader))
+	assert.Equal(t, "0", w.Header().Get(RateLimitRemainingHeader))
+	reset, err := strconv.ParseInt(w.Header().Get(RateLimitResetHeader), 10, 64)
+	assert.NoError(t, err)
+	assert.True(t, reset > time.Now().Unix())
+
+	// Other clients have their own limit
+	w = list("10.0.0.2:1234")
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Other route classes are not limited
+	req := httptest.NewRequest(http.MethodPut, "/checks/foo", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get(RateLimitHeader))
+	assert.Empty(t, w.Header().Get(RateLimitRemainingHeader))
+}
+
+func TestRateLimiterReserve(t *testing.T) {
+	limiter := NewRateLimiter(RateLimiterConfig{
+		Limits: map[RouteClass]rate.Limit{RouteClassRead: 1},
+		Burst:  2,
+	})
+	now := time.Now()
+
+	status, limited := limiter.reserve("ip:10.0.0.1", RouteClassRead, now)
+	assert.True(t, limited)
+	assert.Equal(t, time.Duration(0), status.Delay)
+	assert.Equal(t, 1, status.Remaining)
+	assert.Equal(t, now.Add(time.Second), status.Reset)
+
+	status, _ = limiter.reserve("ip:10.0.0.1", RouteClassRead, now)
+	assert.Equal(t, time.Duration(0), status.Delay)
+	assert.Equal(t, 0, status.Remaining)
+	assert.Equal(t, now.Add(2*time.Second), status.Reset)
+
+	// The rejected requests don't consume tokens
+	status, _ = limiter.reserve("ip:10.0.0.1", RouteClassRead, now)
+	assert.Equal(t, time.Second, status.Delay)
+	status, _ = limiter.reserve("ip:10.0.0.1", RouteClassRead, now.Add(500*time.Millisecond))
+	assert.Equal(t, 500*time.Millisecond, status.Delay)
+
+	// The bucket is refilled over time
+	status, _ = limiter.reserve("ip:10.0.0.1", RouteClassRead, now.Add(1500*time.Millisecond))
+	assert.Equal(t, time.Duration(0), status.Delay)
+	assert.Equal(t, 0, status.Remaining)
+
+	_, limited = limiter.reserve("ip:10.0.0.1", RouteClassWrite, now)
+	assert.False(t, limited)
+}
+
+func TestRateLimitConcurrentRequests(t *testing.T) {
+	limiter := NewRateLimiter(RateLimiterConfig{MaxConcurrentRequests: 1})
+
+	release := make(chan struct{})
+	started := make(chan struct{})
+	blocking := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+	})
+	mware := RateLimit{Limiter: limiter}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		mware.Then(blocking).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}()
+	<-started
+
+	w := httptest.NewRecorder()
+	mware.Then(testHandler()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	close(release)
+	<-done
+
+	w = httptest.NewRecorder()
+	mware.Then(testHandler()).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRateLimitRouteClass(t *testing.T) {
+	tests := []struct {
+		name   string
+		mware  RateLimit
+		method string
+		verb   string
+		want   RouteClass
+	}{
+		{"list verb", RateLimit{}, http.MethodGet, "list", RouteClassList},
+		{"get verb", RateLimit{}, http.MethodGet, "get", RouteClassRead},
+		{"create verb", RateLimit{}, http.MethodPost, "create", RouteClassWrite},
+		{"no attributes get", RateLimit{}, http.MethodGet, "", RouteClassRead},
+		{"no attributes post", RateLimit{}, http.MethodPost, "", RouteClassWrite},
+		{"forced class", RateLimit{Class: RouteClassList}, http.MethodPost, "", RouteClassList},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/", nil)
+			if tt.verb != "" {
+				req = req.WithContext(authorization.SetAttributes(req.Context(), &authorization.Attributes{Verb: tt.verb}))
+			}
+			assert.Equal(t, tt.want, tt.mware.routeClass(req))
+		})
+	}
+}
+
+func TestRateLimitClient(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = "10.0.0.1:1234"
+	assert.Equal(t, "ip:10.0.0.1", rateLimitClient(req))
+
+	claims := &corev2.Claims{}
+	claims.Subject = "admin"
+	req = req.WithContext(jwt.SetClaimsIntoContext(req, claims))
+	assert.Equal(t, "user:admin", rateLimitClient(req))
+}
+
+func TestRateLimiterPrune(t *testing.T) {
+	limiter := NewRateLimiter(RateLimiterConfig{
+		Limits: map[RouteClass]rate.Limit{RouteClassRead: 1},
+	})
+	now := time.Now()
+	limiter.reserve("ip:10.0.0.1", RouteClassRead, now)
+	assert.Len(t, limiter.clients, 1)
+
+	later := now.Add(2 * rateLimiterIdleTimeout)
+	limiter.reserve("ip:10.0.0.2", RouteClassRead, later)
+	assert.Len(t, limiter.clients, 1)
+}
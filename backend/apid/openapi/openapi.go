@@ -0,0 +1,339 @@
+// Package openapi generates the OpenAPI document of apid from its routers, so
+// that the document is always in sync with the routes actually served.
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/gorilla/mux"
+)
+
+// Version is the version of the OpenAPI specification of the documents.
+const Version = "3.0.3"
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Info describes the API.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem describes the operations available on a path, by lower-cased HTTP
+// method.
+type PathItem map[string]*Operation
+
+// Operation describes a single API operation on a path.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+// Parameter describes a path or query parameter of an operation.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody describes the body of the requests of an operation.
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response describes a response of an operation.
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType describes the schema of a request or response body.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components holds the schemas referenced by the operations.
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas,omitempty"`
+}
+
+// RouteMeta describes a route in addition to what can be learned from its
+// path template and methods.
+type RouteMeta struct {
+	// Summary is a short summary of what the route does.
+	Summary string
+
+	// Tag groups the route with the related ones. It defaults to the resource
+	// of the route, or to the first segment of its path.
+	Tag string
+
+	// Parameters are the query parameters accepted by the route.
+	Parameters []Parameter
+
+	// Request is a value of the type of the request body, if any.
+	Request interface{}
+
+	// Response is a value of the type of the response body, if any.
+	Response interface{}
+
+	// Status is the status code of the successful responses of the route. It
+	// defaults to the status written by the routers for actions: 200 for the
+	// routes with a response body, 201 for the POST and PUT routes without,
+	// and 204 for the other ones.
+	Status int
+
+	// Errors are the status codes of the errors documented for the route, in
+	// addition to the default error response.
+	Errors []int
+}
+
+// ListParameters are the query parameters of the routes listing resources.
+var ListParameters = []Parameter{
+	{
+		Name:        "limit",
+		In:          "query",
+		Description: "maximum number of resources to return per page",
+		Schema:      &Schema{Type: "integer"},
+	},
+	{
+		Name:        "continue",
+		In:          "query",
+		Description: "token of the next page to return, from the Sensu-Continue header",
+		Schema:      &Schema{Type: "string"},
+	},
+}
+
+// describedHandler is the handler of a described route. It carries the
+// description of the route, so that the documents only depend on the router
+// they are generated from.
+type describedHandler struct {
+	http.Handler
+	meta RouteMeta
+}
+
+// Describe attaches the description of the route to it, for the documents
+// generated from its router, and returns the route. The handler of the route
+// must be set beforehand.
+func Describe(route *mux.Route, meta RouteMeta) *mux.Route {
+	return route.Handler(describedHandler{Handler: route.GetHandler(), meta: meta})
+}
+
+func describedBy(route *mux.Route) RouteMeta {
+	if handler, ok := route.GetHandler().(describedHandler); ok {
+		return handler.meta
+	}
+	return RouteMeta{}
+}
+
+// errorSchema is the schema of the errors written by the routers.
+var errorSchema = &Schema{
+	Type: "object",
+	Properties: map[string]*Schema{
+		"message": {Type: "string"},
+		"code":    {Type: "integer", Format: "int64"},
+	},
+}
+
+// literalPattern matches the patterns of the path variables that only match a
+// single literal value, such as {resource:checks}.
+var literalPattern = regexp.MustCompile(`^[A-Za-z0-9_.\-]+$`)
+
+// Generate generates the OpenAPI document of the routes of the router, and of
+// its subrouters. Routes without methods, such as path prefixes, are not
+// documented.
+func Generate(router *mux.Router, info Info) (*Document, error) {
+	doc := &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   make(map[string]*PathItem),
+		Components: Components{
+			Schemas: map[string]*Schema{"Error": errorSchema},
+		},
+	}
+	schemas := newSchemaRegistry(doc.Components.Schemas)
+
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			// The route doesn't have a path
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			// The route doesn't have methods
+			return nil
+		}
+
+		path, params, literals := parseTemplate(template)
+		item, ok := doc.Paths[path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[path] = item
+		}
+		meta := describedBy(route)
+		for _, method := range methods {
+			(*item)[strings.ToLower(method)] = newOperation(method, path, params, literals, meta, schemas)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func newOperation(method, path string, params []string, literals map[string]string, meta RouteMeta, schemas *schemaRegistry) *Operation {
+	op := &Operation{
+		OperationID: operationID(method, path),
+		Summary:     meta.Summary,
+		Responses: map[string]*Response{
+			"default": {
+				Description: "error",
+				Content:     jsonContent(&Schema{Ref: "#/components/schemas/Error"}),
+			},
+		},
+	}
+
+	tag := meta.Tag
+	if tag == "" {
+		tag = literals["resource"]
+	}
+	if tag == "" {
+		for _, segment := range strings.Split(path, "/") {
+			if segment != "" {
+				tag = segment
+				break
+			}
+		}
+	}
+	if tag != "" {
+		op.Tags = []string{tag}
+	}
+
+	for _, name := range params {
+		op.Parameters = append(op.Parameters, Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "string"},
+		})
+	}
+	op.Parameters = append(op.Parameters, meta.Parameters...)
+
+	if meta.Request != nil {
+		op.RequestBody = &RequestBody{
+			Required: true,
+			Content:  jsonContent(schemas.schemaOf(meta.Request)),
+		}
+	}
+
+	status := meta.Status
+	if status == 0 {
+		switch {
+		case meta.Response != nil || method == http.MethodGet:
+			status = http.StatusOK
+		case method == http.MethodPost || method == http.MethodPut:
+			status = http.StatusCreated
+		default:
+			status = http.StatusNoContent
+		}
+	}
+	success := &Response{Description: http.StatusText(status)}
+	if meta.Response != nil {
+		success.Content = jsonContent(schemas.schemaOf(meta.Response))
+	}
+	op.Responses[strconv.Itoa(status)] = success
+
+	for _, code := range meta.Errors {
+		op.Responses[strconv.Itoa(code)] = &Response{
+			Description: http.StatusText(code),
+			Content:     jsonContent(&Schema{Ref: "#/components/schemas/Error"}),
+		}
+	}
+
+	return op
+}
+
+func jsonContent(schema *Schema) map[string]MediaType {
+	return map[string]MediaType{"application/json": {Schema: schema}}
+}
+
+// parseTemplate converts a mux path template to an OpenAPI path. The path
+// variables matching a single literal value are replaced by that value and
+// returned in literals, while the other ones become path parameters.
+func parseTemplate(template string) (path string, params []string, literals map[string]string) {
+	literals = make(map[string]string)
+	var b strings.Builder
+	for i := 0; i < len(template); i++ {
+		if template[i] != '{' {
+			b.WriteByte(template[i])
+			continue
+		}
+		// Find the matching closing brace, the pattern may contain braces
+		depth, end := 0, i
+		for ; end < len(template); end++ {
+			if template[end] == '{' {
+				depth++
+			} else if template[end] == '}' {
+				depth--
+				if depth == 0 {
+					break
+				}
+			}
+		}
+		variable := template[i+1 : end]
+		i = end
+
+		name, pattern := variable, ""
+		if idx := strings.Index(variable, ":"); idx >= 0 {
+			name, pattern = variable[:idx], variable[idx+1:]
+		}
+		if pattern != "" && literalPattern.MatchString(pattern) {
+			literals[name] = pattern
+			b.WriteString(pattern)
+			continue
+		}
+		params = append(params, name)
+		b.WriteString("{" + name + "}")
+	}
+	return b.String(), params, literals
+}
+
+// operationID returns a unique identifier of the operation, such as
+// getApiCoreV2NamespacesNamespaceChecksId.
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	upper := true
+	for _, r := range path {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
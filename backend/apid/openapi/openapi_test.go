@@ -0,0 +1,121 @@
+package openapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTemplate(t *testing.T) {
+	path, params, literals := parseTemplate("/api/{group:core}/{version:v2}/namespaces/{namespace}/{resource:checks}/{id:[a-z]{2,}}")
+	assert.Equal(t, "/api/core/v2/namespaces/{namespace}/checks/{id}", path)
+	assert.Equal(t, []string{"namespace", "id"}, params)
+	assert.Equal(t, map[string]string{"group": "core", "version": "v2", "resource": "checks"}, literals)
+}
+
+func TestOperationID(t *testing.T) {
+	assert.Equal(t, "getApiCoreV2NamespacesNamespaceChecksId", operationID(http.MethodGet, "/api/core/v2/namespaces/{namespace}/checks/{id}"))
+	assert.Equal(t, "postAuthToken", operationID(http.MethodPost, "/auth/token"))
+}
+
+type testMeta struct {
+	Name   string            `json:"name"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+type testEmbedded struct {
+	Promoted bool `json:"promoted"`
+}
+
+type testResource struct {
+	testEmbedded
+	Metadata testMeta       `json:"metadata"`
+	Interval uint32         `json:"interval"`
+	Created  time.Time      `json:"created"`
+	Children []testResource `json:"children"`
+	Hidden   string         `json:"-"`
+	Any      interface{}    `json:"any"`
+	private  string
+}
+
+func TestSchemaOf(t *testing.T) {
+	schemas := map[string]*Schema{}
+	registry := newSchemaRegistry(schemas)
+
+	schema := registry.schemaOf([]*testResource{})
+	assert.Equal(t, "array", schema.Type)
+	assert.Equal(t, "#/components/schemas/testResource", schema.Items.Ref)
+
+	resource := schemas["testResource"]
+	require.NotNil(t, resource)
+	assert.Equal(t, &Schema{Type: "boolean"}, resource.Properties["promoted"])
+	assert.Equal(t, &Schema{Ref: "#/components/schemas/testMeta"}, resource.Properties["metadata"])
+	assert.Equal(t, &Schema{Type: "integer", Format: "int64"}, resource.Properties["interval"])
+	assert.Equal(t, &Schema{Type: "string", Format: "date-time"}, resource.Properties["created"])
+	assert.Equal(t, "#/components/schemas/testResource", resource.Properties["children"].Items.Ref)
+	assert.Equal(t, &Schema{}, resource.Properties["any"])
+	assert.NotContains(t, resource.Properties, "Hidden")
+	assert.NotContains(t, resource.Properties, "private")
+
+	meta := schemas["testMeta"]
+	require.NotNil(t, meta)
+	assert.Equal(t, &Schema{Type: "object", AdditionalProperties: &Schema{Type: "string"}}, meta.Properties["labels"])
+}
+
+func TestGenerate(t *testing.T) {
+	handler := func(http.ResponseWriter, *http.Request) {}
+	router := mux.NewRouter()
+	subrouter := router.PathPrefix("/api/{group:core}/{version:v2}/").Subrouter()
+	Describe(subrouter.HandleFunc("/namespaces/{namespace}/{resource:things}", handler).Methods(http.MethodGet), RouteMeta{
+		Summary:    "List the things",
+		Parameters: ListParameters,
+		Response:   []testResource{},
+	})
+	subrouter.HandleFunc("/namespaces/{namespace}/{resource:things}/{id}", handler).Methods(http.MethodPut, http.MethodDelete)
+	Describe(subrouter.HandleFunc("/namespaces/{namespace}/{resource:things}/{id}/reset", handler).Methods(http.MethodPost), RouteMeta{
+		Summary: "Reset a thing",
+		Status:  http.StatusNoContent,
+		Errors:  []int{http.StatusNotFound},
+	})
+	// Routes without methods aren't documented
+	router.HandleFunc("/metrics", handler)
+
+	doc, err := Generate(router, Info{Title: "test", Version: "1.0.0"})
+	require.NoError(t, err)
+	assert.Equal(t, Version, doc.OpenAPI)
+	assert.Len(t, doc.Paths, 3)
+
+	list := (*doc.Paths["/api/core/v2/namespaces/{namespace}/things"])["get"]
+	require.NotNil(t, list)
+	assert.Equal(t, "List the things", list.Summary)
+	assert.Equal(t, []string{"things"}, list.Tags)
+	assert.Len(t, list.Parameters, 3)
+	assert.Equal(t, "array", list.Responses["200"].Content["application/json"].Schema.Type)
+	assert.Contains(t, doc.Components.Schemas, "testResource")
+
+	item := *doc.Paths["/api/core/v2/namespaces/{namespace}/things/{id}"]
+	require.Contains(t, item, "put")
+	assert.Contains(t, item["put"].Responses, "201")
+	require.Contains(t, item, "delete")
+	assert.Contains(t, item["delete"].Responses, "204")
+	assert.Len(t, item["delete"].Parameters, 2)
+
+	reset := (*doc.Paths["/api/core/v2/namespaces/{namespace}/things/{id}/reset"])["post"]
+	require.NotNil(t, reset)
+	assert.Contains(t, reset.Responses, "204")
+	assert.NotContains(t, reset.Responses, "201")
+	require.Contains(t, reset.Responses, "404")
+	assert.Equal(t, "#/components/schemas/Error", reset.Responses["404"].Content["application/json"].Schema.Ref)
+
+	// The descriptions are carried by the routes of the router, and don't
+	// leak in the documents of other routers
+	other := mux.NewRouter()
+	other.HandleFunc("/namespaces/{namespace}/{resource:things}", handler).Methods(http.MethodGet)
+	doc, err = Generate(other, Info{Title: "test", Version: "1.0.0"})
+	require.NoError(t, err)
+	assert.Empty(t, (*doc.Paths["/namespaces/{namespace}/things"])["get"].Summary)
+}
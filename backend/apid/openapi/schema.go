@@ -0,0 +1,142 @@
+package openapi
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schema is the JSON schema of a request or response body.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaRegistry derives the schemas of Go types from their JSON encoding,
+// and registers the schemas of named struct types in the components of the
+// document so they can be referenced.
+type schemaRegistry struct {
+	schemas map[string]*Schema
+	names   map[reflect.Type]string
+}
+
+func newSchemaRegistry(schemas map[string]*Schema) *schemaRegistry {
+	return &schemaRegistry{
+		schemas: schemas,
+		names:   make(map[reflect.Type]string),
+	}
+}
+
+// schemaOf returns the schema of the type of v.
+func (s *schemaRegistry) schemaOf(v interface{}) *Schema {
+	return s.schemaOfType(reflect.TypeOf(v))
+}
+
+func (s *schemaRegistry) schemaOfType(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded as base64 strings
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.schemaOfType(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schemaOfType(t.Elem())}
+	case reflect.Struct:
+		return s.structSchema(t)
+	}
+
+	// Interfaces may hold any value
+	return &Schema{}
+}
+
+// structSchema returns a reference to the schema of a named struct type, or
+// the schema of an anonymous struct type.
+func (s *schemaRegistry) structSchema(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		s.addProperties(schema, t)
+		return schema
+	}
+
+	name, ok := s.names[t]
+	if !ok {
+		name = t.Name()
+		if _, taken := s.schemas[name]; taken {
+			// Qualify the name with the package of the type to keep it unique
+			name = strings.Title(path.Base(t.PkgPath())) + name
+		}
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		// Register the schema before its properties, which may reference it
+		s.names[t] = name
+		s.schemas[name] = schema
+		s.addProperties(schema, t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// addProperties adds the properties of the JSON encoding of the fields of the
+// struct type to the schema.
+func (s *schemaRegistry) addProperties(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if field.Anonymous && name == "" {
+			// The fields of embedded structs are promoted
+			embedded := field.Type
+			for embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				s.addProperties(schema, embedded)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			// Unexported field
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = s.schemaOfType(field.Type)
+	}
+}
@@ -0,0 +1,41 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// APIUsageController represents the controller needs of the APIUsageRouter.
+type APIUsageController interface {
+	Usage(ctx context.Context) ([]corev2.APIUsage, error)
+}
+
+// APIUsageRouter handles requests for /cluster/api-usage, which reports the
+// API requests served by the backends of the cluster per user and per route.
+type APIUsageRouter struct {
+	controller APIUsageController
+}
+
+// NewAPIUsageRouter instantiates a new router for the API usage.
+func NewAPIUsageRouter(ctrl APIUsageController) *APIUsageRouter {
+	return &APIUsageRouter{
+		controller: ctrl,
+	}
+}
+
+// Mount the APIUsageRouter on the given parent Router
+func (r *APIUsageRouter) Mount(parent *mux.Router) {
+	routes := ResourceRoute{
+		Router:     parent,
+		PathPrefix: "/{resource:cluster}/api-usage",
+	}
+
+	routes.Path("", r.usage).Methods(http.MethodGet)
+}
+
+func (r *APIUsageRouter) usage(req *http.Request) (interface{}, error) {
+	return r.controller.Usage(req.Context())
+}
@@ -0,0 +1,40 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAPIUsageController struct {
+	usage []corev2.APIUsage
+}
+
+func (m *mockAPIUsageController) Usage(ctx context.Context) ([]corev2.APIUsage, error) {
+	return m.usage, nil
+}
+
+func TestAPIUsageRouter(t *testing.T) {
+	usage := []corev2.APIUsage{
+		{User: "ci", APIKey: true, Method: http.MethodGet, Route: "/events", Requests: 10, Errors: 1},
+	}
+
+	router := mux.NewRouter()
+	NewAPIUsageRouter(&mockAPIUsageController{usage: usage}).Mount(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/cluster/api-usage", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got []corev2.APIUsage
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	assert.Equal(t, usage, got)
+}
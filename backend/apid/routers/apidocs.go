@@ -0,0 +1,35 @@
+package routers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sensu/sensu-go/backend/apid/openapi"
+	"github.com/sensu/sensu-go/version"
+)
+
+// APIDocsRouter handles requests for /apidocs, which serves the OpenAPI
+// document of the API
+type APIDocsRouter struct {
+	root *mux.Router
+}
+
+// NewAPIDocsRouter instantiates new router serving the OpenAPI document of the
+// routes of the root router
+func NewAPIDocsRouter(root *mux.Router) *APIDocsRouter {
+	return &APIDocsRouter{root: root}
+}
+
+// Mount the APIDocsRouter to a parent Router
+func (r *APIDocsRouter) Mount(parent *mux.Router) {
+	parent.HandleFunc("/apidocs", actionHandler(r.document)).Methods(http.MethodGet)
+}
+
+func (r *APIDocsRouter) document(req *http.Request) (interface{}, error) {
+	// Generate the document on each request so it includes the routes mounted
+	// after this router
+	return openapi.Generate(r.root, openapi.Info{
+		Title:   "Sensu Go API",
+		Version: version.Semver(),
+	})
+}
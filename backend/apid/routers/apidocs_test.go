@@ -0,0 +1,41 @@
+package routers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sensu/sensu-go/backend/apid/openapi"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIDocs(t *testing.T) {
+	router := mux.NewRouter()
+	NewHandlersRouter(&mockstore.MockStore{}).Mount(router)
+	NewAPIDocsRouter(router).Mount(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/apidocs")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var doc openapi.Document
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&doc))
+	assert.Equal(t, openapi.Version, doc.OpenAPI)
+	assert.Contains(t, doc.Paths, "/apidocs")
+
+	item, ok := doc.Paths["/namespaces/{namespace}/handlers/{id}"]
+	require.True(t, ok)
+	op, ok := (*item)["get"]
+	require.True(t, ok)
+	assert.Equal(t, "Get a Handler", op.Summary)
+	assert.Equal(t, []string{"handlers"}, op.Tags)
+	assert.Equal(t, "#/components/schemas/Handler", op.Responses["200"].Content["application/json"].Schema.Ref)
+	assert.Contains(t, doc.Components.Schemas, "Handler")
+}
@@ -38,6 +38,7 @@ func (r *APIKeysRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/{resource:apikeys}",
+		Resource:   &corev2.APIKey{},
 	}
 
 	routes.Del(r.handlers.DeleteResource)
@@ -16,8 +16,9 @@ type AssetsRouter struct {
 func NewAssetRouter(store store.ResourceStore) *AssetsRouter {
 	return &AssetsRouter{
 		handlers: handlers.Handlers{
-			Resource: &corev2.Asset{},
-			Store:    store,
+			Resource:  &corev2.Asset{},
+			Store:     store,
+			Referrers: referrerTypes[(&corev2.Asset{}).RBACName()],
 		},
 	}
 }
@@ -27,6 +28,7 @@ func (r *AssetsRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/namespaces/{namespace}/{resource:assets}",
+		Resource:   &corev2.Asset{},
 	}
 
 	routes.Get(r.handlers.GetResource)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/sensu/sensu-go/backend/api"
+	"github.com/sensu/sensu-go/backend/apid/openapi"
 	"github.com/sensu/sensu-go/backend/authentication"
 	"github.com/sensu/sensu-go/backend/store"
 
@@ -28,10 +29,26 @@ func NewAuthenticationRouter(store store.Store, authenticator *authentication.Au
 
 // Mount the authentication routes on given mux.Router.
 func (a *AuthenticationRouter) Mount(r *mux.Router) {
-	r.HandleFunc("/auth", a.login).Methods(http.MethodGet)
-	r.HandleFunc("/auth/test", a.test).Methods(http.MethodGet)
-	r.HandleFunc("/auth/token", a.token).Methods(http.MethodPost)
-	r.HandleFunc("/auth/logout", a.logout).Methods(http.MethodPost)
+	openapi.Describe(r.HandleFunc("/auth", a.login).Methods(http.MethodGet), openapi.RouteMeta{
+		Summary:  "Get an access token with basic authentication",
+		Response: &corev2.Tokens{},
+		Errors:   []int{http.StatusUnauthorized},
+	})
+	openapi.Describe(r.HandleFunc("/auth/test", a.test).Methods(http.MethodGet), openapi.RouteMeta{
+		Summary: "Test the credentials of basic authentication",
+		Status:  http.StatusOK,
+		Errors:  []int{http.StatusUnauthorized},
+	})
+	openapi.Describe(r.HandleFunc("/auth/token", a.token).Methods(http.MethodPost), openapi.RouteMeta{
+		Summary:  "Refresh an access token",
+		Request:  &corev2.Tokens{},
+		Response: &corev2.Tokens{},
+		Errors:   []int{http.StatusBadRequest, http.StatusUnauthorized},
+	})
+	openapi.Describe(r.HandleFunc("/auth/logout", a.logout).Methods(http.MethodPost), openapi.RouteMeta{
+		Summary: "Log out",
+		Status:  http.StatusOK,
+	})
 }
 
 // login handles the login flow
@@ -0,0 +1,40 @@
+package routers
+
+import (
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// CheckTemplatesRouter handles /checktemplates requests.
+type CheckTemplatesRouter struct {
+	handlers handlers.Handlers
+}
+
+// NewCheckTemplatesRouter creates a new CheckTemplatesRouter.
+func NewCheckTemplatesRouter(store store.ResourceStore) *CheckTemplatesRouter {
+	return &CheckTemplatesRouter{
+		handlers: handlers.Handlers{
+			Resource:  &corev2.CheckTemplate{},
+			Store:     store,
+			Referrers: referrerTypes[(&corev2.CheckTemplate{}).RBACName()],
+		},
+	}
+}
+
+// Mount the CheckTemplatesRouter to a parent Router
+func (r *CheckTemplatesRouter) Mount(parent *mux.Router) {
+	routes := ResourceRoute{
+		Router:     parent,
+		PathPrefix: "/namespaces/{namespace}/{resource:checktemplates}",
+		Resource:   &corev2.CheckTemplate{},
+	}
+
+	routes.Del(r.handlers.DeleteResource)
+	routes.Get(r.handlers.GetResource)
+	routes.List(r.handlers.ListResources, corev2.CheckTemplateFields)
+	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:checktemplates}", corev2.CheckTemplateFields)
+	routes.Post(r.handlers.CreateResource)
+	routes.Put(r.handlers.CreateOrUpdateResource)
+}
@@ -1,8 +1,12 @@
 package routers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"path"
@@ -12,6 +16,7 @@ import (
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/authentication/jwt"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/types"
 )
@@ -21,12 +26,20 @@ type checkController interface {
 	AddCheckHook(context.Context, string, corev2.HookList) error
 	RemoveCheckHook(context.Context, string, string, string) error
 	QueueAdhocRequest(context.Context, string, *corev2.AdhocRequest) error
+	SetPublish(context.Context, *corev2.CheckPublishRequest) (*corev2.CheckPublishResponse, error)
+	SchedulePreview(context.Context, string, time.Duration) (*corev2.CheckSchedulePreview, error)
 }
 
+// defaultSchedulePreviewWindow is the window of the schedule preview when
+// none is requested.
+const defaultSchedulePreviewWindow = time.Hour
+
 // ChecksRouter handles requests for /checks
 type ChecksRouter struct {
-	controller checkController
-	handlers   handlers.Handlers
+	controller     checkController
+	handlers       handlers.Handlers
+	pauseStore     store.CheckPauseStore
+	namespaceStore store.NamespaceStore
 }
 
 // NewChecksRouter instantiates new router for controlling check resources
@@ -37,6 +50,8 @@ func NewChecksRouter(store store.Store, getter types.QueueGetter) *ChecksRouter
 			Resource: &corev2.CheckConfig{},
 			Store:    store,
 		},
+		pauseStore:     store,
+		namespaceStore: store,
 	}
 }
 
@@ -45,18 +60,24 @@ func (r *ChecksRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/namespaces/{namespace}/{resource:checks}",
+		Resource:   &corev2.CheckConfig{},
 	}
 
 	routes.Del(r.handlers.DeleteResource)
 	routes.Get(r.handlers.GetResource)
 	routes.List(r.handlers.ListResources, corev2.CheckConfigFields)
 	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:checks}", corev2.CheckConfigFields)
-	routes.Post(r.handlers.CreateResource)
-	routes.Put(r.handlers.CreateOrUpdateResource)
+	routes.Post(r.checkInterval(r.handlers.CreateResource))
+	routes.Put(r.checkInterval(r.handlers.CreateOrUpdateResource))
 
 	// Custom
+	routes.Path("publish", r.setPublish).Methods(http.MethodPost)
 	routes.Path("{id}/hooks/{type}", r.addCheckHook).Methods(http.MethodPut)
 	routes.Path("{id}/hooks/{type}/hook/{hook}", r.removeCheckHook).Methods(http.MethodDelete)
+	routes.Path("{id}/schedule-preview", r.schedulePreview).Methods(http.MethodGet)
+	routes.Path("{id}/pause", r.getPause).Methods(http.MethodGet)
+	routes.Path("{id}/pause", r.pause).Methods(http.MethodPost)
+	routes.Path("{id}/resume", r.resume).Methods(http.MethodPost)
 
 	// handlefunc returns a custom status and response
 	parent.HandleFunc(path.Join(routes.PathPrefix, "{id}/execute"), r.adhocRequest).Methods(http.MethodPost)
@@ -73,11 +94,153 @@ func (r *ChecksRouter) addCheckHook(req *http.Request) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	typ, err := url.PathUnescape(params["type"])
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Type == "" {
+		cfg.Type = typ
+	} else if cfg.Type != typ {
+		return nil, actions.NewErrorf(actions.InvalidArgument, "hook type %q does not match the type of the URL %q", cfg.Type, typ)
+	}
 	err = r.controller.AddCheckHook(req.Context(), id, cfg)
 
 	return nil, err
 }
 
+func (r *ChecksRouter) setPublish(req *http.Request) (interface{}, error) {
+	publishReq := &corev2.CheckPublishRequest{}
+	if err := UnmarshalBody(req, publishReq); err != nil {
+		return nil, err
+	}
+
+	return r.controller.SetPublish(req.Context(), publishReq)
+}
+
+func (r *ChecksRouter) schedulePreview(req *http.Request) (interface{}, error) {
+	id, err := url.PathUnescape(mux.Vars(req)["id"])
+	if err != nil {
+		return nil, err
+	}
+	window := defaultSchedulePreviewWindow
+	if value := req.URL.Query().Get("window"); value != "" {
+		window, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, actions.NewErrorf(actions.InvalidArgument, "invalid window %q: %s", value, err)
+		}
+	}
+	return r.controller.SchedulePreview(req.Context(), id, window)
+}
+
+// checkInterval rejects the checks whose interval is below the minimum check
+// interval of their namespace, or whose timeout exceeds their execution
+// budget, before passing the request to next
+func (r *ChecksRouter) checkInterval(next actionHandlerFunc) actionHandlerFunc {
+	return func(req *http.Request) (interface{}, error) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return nil, actions.NewError(actions.InvalidArgument, err)
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		// Invalid checks are reported by next
+		var check corev2.CheckConfig
+		if err := json.Unmarshal(body, &check); err != nil {
+			return next(req)
+		}
+
+		namespace, err := url.PathUnescape(mux.Vars(req)["namespace"])
+		if err != nil {
+			return nil, actions.NewError(actions.InvalidArgument, err)
+		}
+		check.Namespace = namespace
+		if err := validateInterval(req.Context(), r.namespaceStore, &check); err != nil {
+			return nil, err
+		}
+		return next(req)
+	}
+}
+
+// validateInterval returns an error if the check interval is below the
+// minimum check interval of the namespace, or if the check timeout exceeds its
+// execution budget
+func validateInterval(ctx context.Context, nsStore store.NamespaceStore, check *corev2.CheckConfig) error {
+	if check.Interval == 0 {
+		return nil
+	}
+	ns, err := nsStore.GetNamespace(ctx, check.Namespace)
+	if err != nil {
+		return actions.NewError(actions.InternalErr, err)
+	}
+	if ns != nil && check.Interval < ns.MinCheckInterval {
+		msg := fmt.Sprintf("must be at least %d seconds in namespace %q", ns.MinCheckInterval, check.Namespace)
+		return actions.NewValidationError(corev2.NewFieldError("spec.interval", msg))
+	}
+	if budget := check.ExecutionBudget(check.Interval); budget > 0 && check.Timeout > budget {
+		msg := fmt.Sprintf("must be at most the check interval of %d seconds for intervals below %d seconds", check.Interval, corev2.HighResolutionInterval)
+		return actions.NewValidationError(corev2.NewFieldError("spec.timeout", msg))
+	}
+	return nil
+}
+
+func (r *ChecksRouter) getPause(req *http.Request) (interface{}, error) {
+	id, err := url.PathUnescape(mux.Vars(req)["id"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	pause, err := r.pauseStore.GetCheckPause(req.Context(), id)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	if pause == nil {
+		pause = &corev2.CheckPause{}
+	}
+	return pause, nil
+}
+
+func (r *ChecksRouter) pause(req *http.Request) (interface{}, error) {
+	id, err := url.PathUnescape(mux.Vars(req)["id"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	// The body, with the reason of the pause, is optional
+	pause := &corev2.CheckPause{}
+	if err := json.NewDecoder(req.Body).Decode(pause); err != nil && err != io.EOF {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	pause.Paused = true
+	pause.PausedBy = ""
+	if claims := jwt.GetClaimsFromContext(req.Context()); claims != nil {
+		pause.PausedBy = claims.Subject
+	}
+	pause.Timestamp = time.Now().Unix()
+
+	if err := r.pauseStore.UpdateCheckPause(req.Context(), id, pause); err != nil {
+		switch err.(type) {
+		case *store.ErrNotFound:
+			return nil, actions.NewErrorf(actions.NotFound)
+		default:
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+	}
+	return pause, nil
+}
+
+func (r *ChecksRouter) resume(req *http.Request) (interface{}, error) {
+	id, err := url.PathUnescape(mux.Vars(req)["id"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	pause := &corev2.CheckPause{}
+	if err := r.pauseStore.UpdateCheckPause(req.Context(), id, pause); err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	return pause, nil
+}
+
 func (r *ChecksRouter) removeCheckHook(req *http.Request) (interface{}, error) {
 	params := mux.Vars(req)
 	id, err := url.PathUnescape(params["id"])
@@ -8,11 +8,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/mockqueue"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/sensu/sensu-go/testing/testutil"
@@ -35,6 +37,16 @@ func (m *mockCheckController) QueueAdhocRequest(ctx context.Context, check strin
 	return m.Called(ctx, check, req).Error(0)
 }
 
+func (m *mockCheckController) SetPublish(ctx context.Context, req *corev2.CheckPublishRequest) (*corev2.CheckPublishResponse, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*corev2.CheckPublishResponse), args.Error(1)
+}
+
+func (m *mockCheckController) SchedulePreview(ctx context.Context, check string, window time.Duration) (*corev2.CheckSchedulePreview, error) {
+	args := m.Called(ctx, check, window)
+	return args.Get(0).(*corev2.CheckSchedulePreview), args.Error(1)
+}
+
 func TestHttpApiChecksAdhocRequest(t *testing.T) {
 	defaultCtx := testutil.NewContext(
 		testutil.ContextWithNamespace("default"),
@@ -71,15 +83,20 @@ func TestHttpApiChecksAdhocRequest(t *testing.T) {
 func TestChecksRouter(t *testing.T) {
 	// Setup the router
 	s := &mockstore.MockStore{}
-	router := ChecksRouter{handlers: handlers.Handlers{
-		Resource: &corev2.CheckConfig{},
-		Store:    s,
-	}}
+	router := ChecksRouter{
+		handlers: handlers.Handlers{
+			Resource: &corev2.CheckConfig{},
+			Store:    s,
+		},
+		pauseStore:     s,
+		namespaceStore: s,
+	}
 	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
 	router.Mount(parentRouter)
 
 	empty := &corev2.CheckConfig{}
 	fixture := corev2.FixtureCheckConfig("foo")
+	s.On("GetNamespace", mock.Anything, mock.Anything).Return((*corev2.Namespace)(nil), nil)
 
 	tests := []routerTestCase{}
 	tests = append(tests, getTestCases(fixture)...)
@@ -92,6 +109,164 @@ func TestChecksRouter(t *testing.T) {
 	}
 }
 
+func TestChecksRouterPause(t *testing.T) {
+	tests := []routerTestCase{
+		{
+			name:   "it returns the pause of a running check",
+			method: http.MethodGet,
+			path:   "/namespaces/default/checks/check1/pause",
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetCheckPause", mock.Anything, "check1").Return((*corev2.CheckPause)(nil), nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "it returns an error if the pause can't be retrieved",
+			method: http.MethodGet,
+			path:   "/namespaces/default/checks/check1/pause",
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetCheckPause", mock.Anything, "check1").Return((*corev2.CheckPause)(nil), &store.ErrInternal{})
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "it returns 400 if the pause is invalid",
+			method:         http.MethodPost,
+			path:           "/namespaces/default/checks/check1/pause",
+			body:           []byte("foo"),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "it returns 404 if the check does not exist",
+			method: http.MethodPost,
+			path:   "/namespaces/default/checks/check1/pause",
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("UpdateCheckPause", mock.Anything, "check1", mock.Anything).Return(&store.ErrNotFound{}).Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:   "it pauses the check",
+			method: http.MethodPost,
+			path:   "/namespaces/default/checks/check1/pause",
+			body:   []byte(`{"reason": "maintenance"}`),
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("UpdateCheckPause", mock.Anything, "check1", mock.MatchedBy(func(p *corev2.CheckPause) bool {
+					return p.Paused && p.Reason == "maintenance" && p.Timestamp > 0
+				})).Return(nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "it pauses the check without a reason",
+			method: http.MethodPost,
+			path:   "/namespaces/default/checks/check1/pause",
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("UpdateCheckPause", mock.Anything, "check1", mock.MatchedBy(func(p *corev2.CheckPause) bool {
+					return p.Paused
+				})).Return(nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "it resumes the check",
+			method: http.MethodPost,
+			path:   "/namespaces/default/checks/check1/resume",
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("UpdateCheckPause", mock.Anything, "check1", &corev2.CheckPause{}).Return(nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		s := &mockstore.MockStore{}
+		router := &ChecksRouter{pauseStore: s}
+		parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+		router.Mount(parentRouter)
+		run(t, tt, parentRouter, s)
+	}
+}
+
+func TestChecksRouterMinInterval(t *testing.T) {
+	namespace := corev2.FixtureNamespace("default")
+	namespace.MinCheckInterval = 10
+
+	check := corev2.FixtureCheckConfig("check1")
+	check.Interval = 5
+	fastCheck := marshal(check)
+	check.Interval = 10
+	slowCheck := marshal(check)
+	check.Timeout = 20
+	longCheck := marshal(check)
+
+	tests := []routerTestCase{
+		{
+			name:   "it rejects a check created with an interval below the minimum",
+			method: http.MethodPost,
+			path:   "/namespaces/default/checks",
+			body:   fastCheck,
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetNamespace", mock.Anything, "default").Return(namespace, nil)
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "it rejects a check updated with an interval below the minimum",
+			method: http.MethodPut,
+			path:   "/namespaces/default/checks/check1",
+			body:   fastCheck,
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetNamespace", mock.Anything, "default").Return(namespace, nil)
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "it rejects a high resolution check with a timeout above its interval",
+			method: http.MethodPut,
+			path:   "/namespaces/default/checks/check1",
+			body:   longCheck,
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetNamespace", mock.Anything, "default").Return(namespace, nil)
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "it returns 500 if the namespace can't be retrieved",
+			method: http.MethodPut,
+			path:   "/namespaces/default/checks/check1",
+			body:   slowCheck,
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetNamespace", mock.Anything, "default").Return((*corev2.Namespace)(nil), &store.ErrInternal{})
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "it accepts a check with the minimum interval",
+			method: http.MethodPut,
+			path:   "/namespaces/default/checks/check1",
+			body:   slowCheck,
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetNamespace", mock.Anything, "default").Return(namespace, nil)
+				s.On("CreateOrUpdateResource", mock.Anything, mock.AnythingOfType("*v2.CheckConfig")).Return(nil)
+			},
+			wantStatusCode: http.StatusCreated,
+		},
+	}
+	for _, tt := range tests {
+		s := &mockstore.MockStore{}
+		router := &ChecksRouter{
+			handlers: handlers.Handlers{
+				Resource: &corev2.CheckConfig{},
+				Store:    s,
+			},
+			namespaceStore: s,
+		}
+		parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+		router.Mount(parentRouter)
+		run(t, tt, parentRouter, s)
+	}
+}
+
 func TestChecksRouterCustomRoutes(t *testing.T) {
 	type controllerFunc func(*mockCheckController)
 
@@ -119,6 +294,40 @@ func TestChecksRouterCustomRoutes(t *testing.T) {
 			},
 			wantStatusCode: http.StatusCreated,
 		},
+		{
+			name:           "it rejects a check hook whose type does not match the URL",
+			method:         http.MethodPut,
+			path:           "/namespaces/default/checks/check1/hooks/critical",
+			body:           marshal(corev2.FixtureHookList("hook1")),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "it enables or disables the publication of several checks",
+			method: http.MethodPost,
+			path:   "/namespaces/default/checks/publish",
+			body:   marshal(&corev2.CheckPublishRequest{All: true, Subscription: "web"}),
+			controllerFunc: func(c *mockCheckController) {
+				c.On("SetPublish", mock.Anything, &corev2.CheckPublishRequest{All: true, Subscription: "web"}).
+					Return(&corev2.CheckPublishResponse{Checks: []string{"check1"}}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "it previews the schedule of a check",
+			method: http.MethodGet,
+			path:   "/namespaces/default/checks/check1/schedule-preview?window=2h",
+			controllerFunc: func(c *mockCheckController) {
+				c.On("SchedulePreview", mock.Anything, "check1", 2*time.Hour).
+					Return(&corev2.CheckSchedulePreview{Check: "check1"}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:           "it rejects an invalid schedule preview window",
+			method:         http.MethodGet,
+			path:           "/namespaces/default/checks/check1/schedule-preview?window=forever",
+			wantStatusCode: http.StatusBadRequest,
+		},
 		{
 			name:   "it deletes a check hook from a check",
 			method: http.MethodDelete,
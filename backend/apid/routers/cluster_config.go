@@ -0,0 +1,58 @@
+package routers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// ClusterConfigRouter handles requests for /cluster/config, the settings
+// shared by all the backends of the cluster.
+type ClusterConfigRouter struct {
+	store store.ClusterConfigStore
+}
+
+// NewClusterConfigRouter instantiates a new router for the cluster
+// configuration.
+func NewClusterConfigRouter(store store.ClusterConfigStore) *ClusterConfigRouter {
+	return &ClusterConfigRouter{
+		store: store,
+	}
+}
+
+// Mount the ClusterConfigRouter on the given parent Router
+func (r *ClusterConfigRouter) Mount(parent *mux.Router) {
+	routes := ResourceRoute{
+		Router:     parent,
+		PathPrefix: "/{resource:cluster}/config",
+	}
+
+	routes.Path("", r.get).Methods(http.MethodGet)
+	routes.Path("", r.update).Methods(http.MethodPut)
+}
+
+func (r *ClusterConfigRouter) get(req *http.Request) (interface{}, error) {
+	config, err := r.store.GetClusterConfig(req.Context())
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	return config, nil
+}
+
+func (r *ClusterConfigRouter) update(req *http.Request) (interface{}, error) {
+	config := &corev2.ClusterConfig{}
+	if err := UnmarshalBody(req, config); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	if err := r.store.UpdateClusterConfig(req.Context(), config); err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	return config, nil
+}
@@ -0,0 +1,68 @@
+package routers
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestClusterConfigRouter(t *testing.T) {
+	clusterConfigPath := corev2.URLPrefix + "/cluster/config"
+	disabled := false
+	tests := []routerTestCase{
+		{
+			name:   "it returns the cluster configuration",
+			method: http.MethodGet,
+			path:   clusterConfigPath,
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetClusterConfig", mock.Anything).Return(&corev2.ClusterConfig{}, nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "it returns an error if the cluster configuration can't be retrieved",
+			method: http.MethodGet,
+			path:   clusterConfigPath,
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetClusterConfig", mock.Anything).Return((*corev2.ClusterConfig)(nil), &store.ErrInternal{})
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "it returns 400 if the cluster configuration can't be decoded",
+			method:         http.MethodPut,
+			path:           clusterConfigPath,
+			body:           []byte("foo"),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "it returns 400 if the cluster configuration is invalid",
+			method:         http.MethodPut,
+			path:           clusterConfigPath,
+			body:           []byte(`{"keepalive_timeout": 1}`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "it updates the cluster configuration",
+			method: http.MethodPut,
+			path:   clusterConfigPath,
+			body:   []byte(`{"event_ttl": 3600, "registration_events": false}`),
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("UpdateClusterConfig", mock.Anything, &corev2.ClusterConfig{EventTTL: 3600, RegistrationEvents: &disabled}).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		s := &mockstore.MockStore{}
+		router := NewClusterConfigRouter(s)
+		parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+		router.Mount(parentRouter)
+		run(t, tt, parentRouter, s)
+	}
+}
@@ -27,6 +27,7 @@ func (r *ClusterRoleBindingsRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/{resource:clusterrolebindings}",
+		Resource:   &corev2.ClusterRoleBinding{},
 	}
 
 	routes.Del(r.handlers.DeleteResource)
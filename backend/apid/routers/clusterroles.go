@@ -27,6 +27,7 @@ func (r *ClusterRolesRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/{resource:clusterroles}",
+		Resource:   &corev2.ClusterRole{},
 	}
 
 	routes.Del(r.handlers.DeleteResource)
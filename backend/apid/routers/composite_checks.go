@@ -0,0 +1,39 @@
+package routers
+
+import (
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// CompositeChecksRouter handles /compositechecks requests.
+type CompositeChecksRouter struct {
+	handlers handlers.Handlers
+}
+
+// NewCompositeChecksRouter creates a new CompositeChecksRouter.
+func NewCompositeChecksRouter(store store.ResourceStore) *CompositeChecksRouter {
+	return &CompositeChecksRouter{
+		handlers: handlers.Handlers{
+			Resource: &corev2.CompositeCheck{},
+			Store:    store,
+		},
+	}
+}
+
+// Mount the CompositeChecksRouter to a parent Router
+func (r *CompositeChecksRouter) Mount(parent *mux.Router) {
+	routes := ResourceRoute{
+		Router:     parent,
+		PathPrefix: "/namespaces/{namespace}/{resource:compositechecks}",
+		Resource:   &corev2.CompositeCheck{},
+	}
+
+	routes.Del(r.handlers.DeleteResource)
+	routes.Get(r.handlers.GetResource)
+	routes.List(r.handlers.ListResources, corev2.CompositeCheckFields)
+	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:compositechecks}", corev2.CompositeCheckFields)
+	routes.Post(r.handlers.CreateResource)
+	routes.Put(r.handlers.CreateOrUpdateResource)
+}
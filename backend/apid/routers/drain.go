@@ -0,0 +1,97 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// DrainController represents the controller needs of the DrainRouter.
+type DrainController interface {
+	Drain(ctx context.Context, backend string) error
+	Resume(ctx context.Context, backend string) error
+	DrainStatuses(ctx context.Context) ([]*corev2.DrainStatus, error)
+}
+
+// DrainRouter handles requests for /cluster/drain, which drains the agent
+// sessions of a backend of the cluster before its maintenance.
+type DrainRouter struct {
+	controller DrainController
+}
+
+// NewDrainRouter instantiates a new router for the agent sessions drains.
+func NewDrainRouter(ctrl DrainController) *DrainRouter {
+	return &DrainRouter{
+		controller: ctrl,
+	}
+}
+
+// Mount the DrainRouter on the given parent Router
+func (r *DrainRouter) Mount(parent *mux.Router) {
+	routes := ResourceRoute{
+		Router:     parent,
+		PathPrefix: "/{resource:cluster}/drain",
+	}
+
+	routes.Path("", r.list).Methods(http.MethodGet)
+	routes.Path("{backend}", r.status).Methods(http.MethodGet)
+	routes.Path("{backend}", r.drain).Methods(http.MethodPost)
+	routes.Path("{backend}", r.resume).Methods(http.MethodDelete)
+}
+
+func (r *DrainRouter) list(req *http.Request) (interface{}, error) {
+	statuses, err := r.controller.DrainStatuses(req.Context())
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	return statuses, nil
+}
+
+func (r *DrainRouter) status(req *http.Request) (interface{}, error) {
+	backend, err := url.PathUnescape(mux.Vars(req)["backend"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	statuses, err := r.controller.DrainStatuses(req.Context())
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	for _, status := range statuses {
+		if status.Backend == backend {
+			return status, nil
+		}
+	}
+	return nil, actions.NewErrorf(actions.NotFound, "backend %q is neither running nor drained", backend)
+}
+
+func (r *DrainRouter) drain(req *http.Request) (interface{}, error) {
+	backend, err := url.PathUnescape(mux.Vars(req)["backend"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	return nil, drainError(r.controller.Drain(req.Context(), backend))
+}
+
+func (r *DrainRouter) resume(req *http.Request) (interface{}, error) {
+	backend, err := url.PathUnescape(mux.Vars(req)["backend"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	return nil, drainError(r.controller.Resume(req.Context(), backend))
+}
+
+func drainError(err error) error {
+	switch err.(type) {
+	case nil:
+		return nil
+	case *store.ErrNotValid:
+		return actions.NewError(actions.InvalidArgument, err)
+	default:
+		return actions.NewError(actions.InternalErr, err)
+	}
+}
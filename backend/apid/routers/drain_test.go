@@ -0,0 +1,126 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockDrainController struct {
+	drains map[string]bool
+	err    error
+}
+
+func (m *mockDrainController) Drain(ctx context.Context, backend string) error {
+	m.drains[backend] = true
+	return m.err
+}
+
+func (m *mockDrainController) Resume(ctx context.Context, backend string) error {
+	delete(m.drains, backend)
+	return m.err
+}
+
+func (m *mockDrainController) DrainStatuses(ctx context.Context) ([]*corev2.DrainStatus, error) {
+	return []*corev2.DrainStatus{
+		{Backend: "backend-1", Draining: m.drains["backend-1"], Sessions: 3, MaxSessions: 10},
+	}, m.err
+}
+
+func TestDrainRouter(t *testing.T) {
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		drains         map[string]bool
+		err            error
+		wantStatusCode int
+		wantDrains     map[string]bool
+		wantBody       string
+	}{
+		{
+			name:           "list",
+			method:         http.MethodGet,
+			path:           "/cluster/drain",
+			drains:         map[string]bool{"backend-1": true},
+			wantStatusCode: http.StatusOK,
+			wantBody:       `[{"backend":"backend-1","draining":true,"sessions":3,"max_sessions":10}]`,
+		},
+		{
+			name:           "status",
+			method:         http.MethodGet,
+			path:           "/cluster/drain/backend-1",
+			wantStatusCode: http.StatusOK,
+			wantBody:       `{"backend":"backend-1","draining":false,"sessions":3,"max_sessions":10}`,
+		},
+		{
+			name:           "unknown backend",
+			method:         http.MethodGet,
+			path:           "/cluster/drain/backend-2",
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:           "drain",
+			method:         http.MethodPost,
+			path:           "/cluster/drain/backend-2",
+			wantStatusCode: http.StatusCreated,
+			wantDrains:     map[string]bool{"backend-2": true},
+		},
+		{
+			name:           "resume",
+			method:         http.MethodDelete,
+			path:           "/cluster/drain/backend-1",
+			drains:         map[string]bool{"backend-1": true},
+			wantStatusCode: http.StatusNoContent,
+			wantDrains:     map[string]bool{},
+		},
+		{
+			name:           "invalid backend name",
+			method:         http.MethodPost,
+			path:           "/cluster/drain/backend-1",
+			err:            &store.ErrNotValid{Err: errors.New("invalid")},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "store error",
+			method:         http.MethodGet,
+			path:           "/cluster/drain",
+			err:            &store.ErrInternal{Message: "unavailable"},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.drains == nil {
+				tt.drains = map[string]bool{}
+			}
+			controller := &mockDrainController{drains: tt.drains, err: tt.err}
+			router := mux.NewRouter()
+			NewDrainRouter(controller).Mount(router)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, tt.wantStatusCode, w.Code)
+
+			if tt.wantBody != "" {
+				var got, want interface{}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+				require.NoError(t, json.Unmarshal([]byte(tt.wantBody), &want))
+				assert.Equal(t, want, got)
+			}
+			if tt.wantDrains != nil {
+				assert.Equal(t, tt.wantDrains, controller.drains)
+			}
+		})
+	}
+}
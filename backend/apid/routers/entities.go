@@ -1,11 +1,16 @@
 package routers
 
 import (
+	"net/http"
+	"net/url"
+
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sirupsen/logrus"
 )
 
 // EntitiesRouter handles requests for /entities
@@ -13,10 +18,11 @@ type EntitiesRouter struct {
 	handlers   handlers.Handlers
 	store      store.Store
 	eventStore store.EventStore
+	bus        messaging.MessageBus
 }
 
 // NewEntitiesRouter instantiates new router for controlling entities resources
-func NewEntitiesRouter(store store.Store, events store.EventStore) *EntitiesRouter {
+func NewEntitiesRouter(store store.Store, events store.EventStore, bus messaging.MessageBus) *EntitiesRouter {
 	return &EntitiesRouter{
 		handlers: handlers.Handlers{
 			Resource: &corev2.Entity{},
@@ -24,6 +30,7 @@ func NewEntitiesRouter(store store.Store, events store.EventStore) *EntitiesRout
 		},
 		store:      store,
 		eventStore: events,
+		bus:        bus,
 	}
 }
 
@@ -32,17 +39,89 @@ func (r *EntitiesRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/namespaces/{namespace}/{resource:entities}",
+		Resource:   &corev2.Entity{},
+	}
+
+	routes.Del(r.deleteEntity)
+	routes.Get(r.handlers.GetResource)
+	routes.List(r.handlers.ListResources, corev2.EntityFields)
+	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:entities}", corev2.EntityFields)
+	routes.Post(r.handlers.CreateResource)
+	routes.Put(r.updateEntity)
+	routes.Path("{id}/keepalive", r.getKeepalive).Methods(http.MethodGet)
+}
+
+// getKeepalive returns the failing keepalive record of the entity, with the
+// time at which its keepalive failure is checked again. The time of the record
+// is zero if the keepalives of the entity are not failing.
+func (r *EntitiesRouter) getKeepalive(req *http.Request) (interface{}, error) {
+	vars := mux.Vars(req)
+	namespace, err := url.PathUnescape(vars["namespace"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
 	}
+	name, err := url.PathUnescape(vars["id"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	entity := &corev2.Entity{ObjectMeta: corev2.NewObjectMeta(name, namespace)}
+	keepalive, err := r.store.GetFailingKeepalive(req.Context(), entity)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	if keepalive == nil {
+		keepalive = corev2.NewKeepaliveRecord(entity, 0)
+	}
+	return keepalive, nil
+}
 
+// updateEntity creates or updates the entity and notifies keepalived of the
+// change
+func (r *EntitiesRouter) updateEntity(req *http.Request) (interface{}, error) {
+	if _, err := r.handlers.CreateOrUpdateResource(req); err != nil {
+		return nil, err
+	}
+	r.publishChange(req, false)
+	return nil, nil
+}
+
+// deleteEntity deletes the entity and its events and notifies keepalived of
+// the deletion
+func (r *EntitiesRouter) deleteEntity(req *http.Request) (interface{}, error) {
 	deleter := actions.EntityDeleter{
 		EntityStore: r.store,
 		EventStore:  r.eventStore,
 	}
+	if _, err := deleter.Delete(req); err != nil {
+		return nil, err
+	}
+	r.publishChange(req, true)
+	return nil, nil
+}
 
-	routes.Del(deleter.Delete)
-	routes.Get(r.handlers.GetResource)
-	routes.List(r.handlers.ListResources, corev2.EntityFields)
-	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:entities}", corev2.EntityFields)
-	routes.Post(r.handlers.CreateResource)
-	routes.Put(r.handlers.CreateOrUpdateResource)
+// publishChange publishes the change made to the entity of the request on the
+// bus. The change is already stored, so a failure to publish it is only
+// logged, keepalived then notices it with the next keepalive of the entity.
+func (r *EntitiesRouter) publishChange(req *http.Request, deleted bool) {
+	vars := mux.Vars(req)
+	namespace, err := url.PathUnescape(vars["namespace"])
+	if err != nil {
+		return
+	}
+	name, err := url.PathUnescape(vars["id"])
+	if err != nil {
+		return
+	}
+	change := &messaging.EntityConfigChange{
+		Namespace: namespace,
+		Name:      name,
+		Deleted:   deleted,
+	}
+	if err := r.bus.Publish(messaging.TopicEntityConfig, change); err != nil {
+		logger.WithFields(logrus.Fields{
+			"entity":    change.Name,
+			"namespace": change.Namespace,
+		}).WithError(err).Error("error publishing entity change")
+	}
 }
@@ -1,10 +1,14 @@
 package routers
 
 import (
+	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/testing/mockbus"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/stretchr/testify/mock"
 )
@@ -16,7 +20,9 @@ func TestEntitiesRouter(t *testing.T) {
 	s.On("DeleteEventByEntityCheck", mock.Anything, "foo", "bar").Return(nil)
 	s.On("DeleteEntityByName", mock.Anything, "foo").Return(nil)
 	s.On("GetEntityByName", mock.Anything, "foo").Return(corev2.FixtureEntity("foo"), nil)
-	router := NewEntitiesRouter(s, s)
+	bus := &mockbus.MockBus{}
+	bus.On("Publish", messaging.TopicEntityConfig, mock.Anything).Return(nil)
+	router := NewEntitiesRouter(s, s, bus)
 	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
 	router.Mount(parentRouter)
 
@@ -37,3 +43,83 @@ func TestEntitiesRouter(t *testing.T) {
 		run(t, tt, parentRouter, s)
 	}
 }
+
+func TestEntitiesRouterPublishesChanges(t *testing.T) {
+	s := &mockstore.MockStore{}
+	s.On("CreateOrUpdateResource", mock.Anything, mock.Anything).Return(nil)
+	s.On("GetEventsByEntity", mock.Anything, "foo", mock.Anything).Return([]*corev2.Event{}, nil)
+	s.On("GetEntityByName", mock.Anything, "foo").Return(corev2.FixtureEntity("foo"), nil)
+	s.On("DeleteEntityByName", mock.Anything, "foo").Return(nil)
+	bus := &mockbus.MockBus{}
+	bus.On("Publish", messaging.TopicEntityConfig, mock.Anything).Return(nil)
+	router := NewEntitiesRouter(s, s, bus)
+	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+	router.Mount(parentRouter)
+
+	fixture := corev2.FixtureEntity("foo")
+	tests := []routerTestCase{
+		{
+			name:           "update",
+			method:         http.MethodPut,
+			path:           fixture.URIPath(),
+			body:           marshal(fixture),
+			wantStatusCode: http.StatusCreated,
+		},
+		{
+			name:           "delete",
+			method:         http.MethodDelete,
+			path:           fixture.URIPath(),
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		run(t, tt, parentRouter, s)
+	}
+
+	bus.AssertCalled(t, "Publish", messaging.TopicEntityConfig, &messaging.EntityConfigChange{Namespace: "default", Name: "foo"})
+	bus.AssertCalled(t, "Publish", messaging.TopicEntityConfig, &messaging.EntityConfigChange{Namespace: "default", Name: "foo", Deleted: true})
+}
+
+func TestEntitiesRouterKeepalive(t *testing.T) {
+	s := &mockstore.MockStore{}
+	router := NewEntitiesRouter(s, s, &mockbus.MockBus{})
+	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+	router.Mount(parentRouter)
+
+	fixture := corev2.FixtureEntity("foo")
+	tests := []routerTestCase{
+		{
+			name:   "failing keepalive",
+			method: http.MethodGet,
+			path:   fixture.URIPath() + "/keepalive",
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetFailingKeepalive", mock.Anything, mock.Anything).
+					Return(corev2.NewKeepaliveRecord(fixture, 42), nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "keepalive not failing",
+			method: http.MethodGet,
+			path:   fixture.URIPath() + "/keepalive",
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetFailingKeepalive", mock.Anything, mock.Anything).
+					Return((*corev2.KeepaliveRecord)(nil), nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "store err",
+			method: http.MethodGet,
+			path:   fixture.URIPath() + "/keepalive",
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetFailingKeepalive", mock.Anything, mock.Anything).
+					Return((*corev2.KeepaliveRecord)(nil), errors.New("error")).Once()
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		run(t, tt, parentRouter, s)
+	}
+}
@@ -0,0 +1,51 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+)
+
+// EventStatsController represents the controller needs of the
+// EventStatsRouter.
+type EventStatsController interface {
+	Stats(ctx context.Context) (*corev2.EventStats, error)
+}
+
+// EventStatsRouter handles requests for /cluster/event-stats, which reports
+// the rates of the events processed by the backends of the cluster per
+// namespace and per check.
+type EventStatsRouter struct {
+	controller EventStatsController
+}
+
+// NewEventStatsRouter instantiates a new router for the event statistics.
+func NewEventStatsRouter(ctrl EventStatsController) *EventStatsRouter {
+	return &EventStatsRouter{
+		controller: ctrl,
+	}
+}
+
+// Mount the EventStatsRouter on the given parent Router
+func (r *EventStatsRouter) Mount(parent *mux.Router) {
+	routes := ResourceRoute{
+		Router:     parent,
+		PathPrefix: "/{resource:cluster}/event-stats",
+	}
+
+	routes.Path("", r.stats).Methods(http.MethodGet)
+}
+
+func (r *EventStatsRouter) stats(req *http.Request) (interface{}, error) {
+	if r.controller == nil {
+		return nil, actions.NewErrorf(actions.NotFound, "the event statistics are not enabled")
+	}
+	stats, err := r.controller.Stats(req.Context())
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	return stats, nil
+}
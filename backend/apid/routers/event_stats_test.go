@@ -0,0 +1,43 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockEventStatsController struct {
+	stats *corev2.EventStats
+}
+
+func (m *mockEventStatsController) Stats(ctx context.Context) (*corev2.EventStats, error) {
+	return m.stats, nil
+}
+
+func TestEventStatsRouter(t *testing.T) {
+	stats := &corev2.EventStats{
+		Window:     900,
+		Events:     30,
+		Namespaces: []corev2.EventRate{{Namespace: "default", Events: 30, PerMinute: 2, Share: 100}},
+		Checks:     []corev2.EventRate{{Namespace: "default", Check: "disk", Events: 30, PerMinute: 2, Share: 100}},
+	}
+
+	router := mux.NewRouter()
+	NewEventStatsRouter(&mockEventStatsController{stats: stats}).Mount(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/cluster/event-stats", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got corev2.EventStats
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	assert.Equal(t, *stats, got)
+}
@@ -1,15 +1,24 @@
 package routers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"path"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/apid/openapi"
+	"github.com/sensu/sensu-go/backend/authorization"
 	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/store"
 )
@@ -17,6 +26,8 @@ import (
 // EventsRouter handles requests for /events
 type EventsRouter struct {
 	controller eventController
+	watchStore store.WatchStore
+	auth       authorization.Authorizer
 }
 
 // eventController represents the controller needs of the EventsRouter.
@@ -25,12 +36,46 @@ type eventController interface {
 	Delete(ctx context.Context, entity, check string) error
 	Get(ctx context.Context, entity, check string) (*corev2.Event, error)
 	List(ctx context.Context, pred *store.SelectionPredicate) ([]corev2.Resource, error)
+	GetHandlerResults(ctx context.Context, entity, check string) ([]*corev2.HandlerResult, error)
+	DeleteSelected(ctx context.Context, req *corev2.EventSelection) (*corev2.EventSelectionResponse, error)
+	ResolveSelected(ctx context.Context, req *corev2.EventSelection) (*corev2.EventSelectionResponse, error)
+	Report(ctx context.Context, since time.Time) (*corev2.EventReport, error)
+}
+
+// defaultEventReportPeriod is the period covered by the event report when the
+// since query parameter is missing.
+const defaultEventReportPeriod = 24 * time.Hour
+
+// maxIngestedEvents is the maximum number of events ingested by a single
+// request.
+const maxIngestedEvents = 100
+
+// maxIngestedBytes is the maximum size, in bytes, of the body of a request
+// ingesting events.
+const maxIngestedBytes = 512000
+
+// EventIngestResult is the result of the ingestion of one of the events of a
+// request, in the order of the request.
+type EventIngestResult struct {
+	// Namespace is the namespace of the event.
+	Namespace string `json:"namespace"`
+
+	// Entity is the name of the entity of the event.
+	Entity string `json:"entity"`
+
+	// Check is the name of the check of the event.
+	Check string `json:"check"`
+
+	// Error is the reason why the event wasn't ingested, if it wasn't.
+	Error string `json:"error,omitempty"`
 }
 
 // NewEventsRouter instantiates new events controller
-func NewEventsRouter(store store.EventStore, bus messaging.MessageBus) *EventsRouter {
+func NewEventsRouter(store store.EventStore, resultStore store.HandlerResultStore, watchStore store.WatchStore, bus messaging.MessageBus, auth authorization.Authorizer) *EventsRouter {
 	return &EventsRouter{
-		controller: actions.NewEventController(store, bus),
+		controller: actions.NewEventController(store, resultStore, bus),
+		watchStore: watchStore,
+		auth:       auth,
 	}
 }
 
@@ -39,14 +84,43 @@ func (r *EventsRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/namespaces/{namespace}/{resource:events}",
+		Resource:   &corev2.Event{},
 	}
 
 	routes.Post(r.create)
+	ingest := actionHandler(r.ingest)
+	openapi.Describe(parent.HandleFunc("/{resource:events}", func(w http.ResponseWriter, req *http.Request) {
+		req.Body = http.MaxBytesReader(w, req.Body, maxIngestedBytes)
+		ingest(w, req)
+	}).Methods(http.MethodPost), openapi.RouteMeta{
+		Summary:  "Ingest one or several events produced outside of the agents, in their namespace",
+		Request:  &corev2.Event{},
+		Response: []EventIngestResult{},
+		Errors:   []int{http.StatusBadRequest, http.StatusForbidden},
+	})
+	routes.Path("", r.deleteSelected).Methods(http.MethodDelete)
+	routes.Path("resolve", r.resolveSelected).Methods(http.MethodPost)
+	// The report is mounted outside of the events, whose entity names it
+	// would shadow
+	openapi.Describe(handleAction(parent, "/namespaces/{namespace}/reports/{resource:events}", r.report).Methods(http.MethodGet), openapi.RouteMeta{
+		Summary:  "Count the check results of the events, grouped by check and by entity",
+		Response: &corev2.EventReport{},
+		Errors:   []int{http.StatusBadRequest},
+		Parameters: []openapi.Parameter{{
+			Name:        "since",
+			In:          "query",
+			Description: "Duration of the period covered by the report, e.g. 24h",
+			Schema:      &openapi.Schema{Type: "string"},
+		}},
+	})
+	// Watch requests must be matched before the listing of events
+	parent.HandleFunc(routes.PathPrefix, r.watch).Methods(http.MethodGet).Queries("watch", "true")
 	routes.List(r.controller.List, corev2.EventFields)
 	routes.ListAllNamespaces(r.controller.List, "/{resource:events}", corev2.EventFields)
 	routes.Path("{entity}/{check}", r.get).Methods(http.MethodGet)
 	routes.Path("{entity}/{check}", r.delete).Methods(http.MethodDelete)
 	routes.Path("{entity}/{check}", r.createOrReplace).Methods(http.MethodPost, http.MethodPut)
+	routes.Path("{entity}/{check}/handled", r.handled).Methods(http.MethodGet)
 
 	// Additionaly allow a subcollection to be specified when listing events,
 	// which correspond to the entity name here
@@ -62,6 +136,13 @@ func (r *EventsRouter) get(req *http.Request) (interface{}, error) {
 	return record, err
 }
 
+func (r *EventsRouter) handled(req *http.Request) (interface{}, error) {
+	params := actions.QueryParams(mux.Vars(req))
+	entity := url.PathEscape(params["entity"])
+	check := url.PathEscape(params["check"])
+	return r.controller.GetHandlerResults(req.Context(), entity, check)
+}
+
 func (r *EventsRouter) delete(req *http.Request) (interface{}, error) {
 	params := actions.QueryParams(mux.Vars(req))
 	entity := url.PathEscape(params["entity"])
@@ -69,6 +150,39 @@ func (r *EventsRouter) delete(req *http.Request) (interface{}, error) {
 	return nil, r.controller.Delete(req.Context(), entity, check)
 }
 
+// deleteSelected deletes the events selected by the fieldSelector query
+// parameter
+func (r *EventsRouter) deleteSelected(req *http.Request) (interface{}, error) {
+	selection := &corev2.EventSelection{
+		FieldSelector: req.URL.Query().Get("fieldSelector"),
+	}
+	return r.controller.DeleteSelected(req.Context(), selection)
+}
+
+// resolveSelected resolves the events selected by the field selector of the
+// request body
+func (r *EventsRouter) resolveSelected(req *http.Request) (interface{}, error) {
+	selection := &corev2.EventSelection{}
+	if err := UnmarshalBody(req, selection); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	return r.controller.ResolveSelected(req.Context(), selection)
+}
+
+// report counts the check results of the events executed during the period
+// given by the since query parameter
+func (r *EventsRouter) report(req *http.Request) (interface{}, error) {
+	period := defaultEventReportPeriod
+	if since := req.URL.Query().Get("since"); since != "" {
+		var err error
+		period, err = time.ParseDuration(since)
+		if err != nil || period <= 0 {
+			return nil, actions.NewErrorf(actions.InvalidArgument, "invalid since duration: %s", since)
+		}
+	}
+	return r.controller.Report(req.Context(), time.Now().Add(-period))
+}
+
 func (r *EventsRouter) create(req *http.Request) (interface{}, error) {
 	event := &corev2.Event{}
 	if err := UnmarshalBody(req, event); err != nil {
@@ -99,6 +213,123 @@ func (r *EventsRouter) createOrReplace(req *http.Request) (interface{}, error) {
 	return nil, err
 }
 
+// ingest processes the events of the request body, a single event or a list of
+// events, as if they were received from agents. The events are all validated,
+// and the user must be allowed to create each of them, before any of them is
+// ingested. The result of the ingestion of each event is returned.
+func (r *EventsRouter) ingest(req *http.Request) (interface{}, error) {
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	var events []*corev2.Event
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &events)
+	} else {
+		event := &corev2.Event{}
+		err = json.Unmarshal(body, event)
+		events = append(events, event)
+	}
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	if len(events) == 0 {
+		return nil, actions.NewErrorf(actions.InvalidArgument, "no event to ingest")
+	}
+	if len(events) > maxIngestedEvents {
+		return nil, actions.NewErrorf(actions.InvalidArgument, "at most %d events can be ingested at once", maxIngestedEvents)
+	}
+
+	reqAttrs := authorization.GetAttributes(req.Context())
+	if reqAttrs == nil {
+		return nil, actions.NewErrorf(actions.InternalErr, "could not retrieve the request info")
+	}
+
+	// Validate the whole batch, so that no event is ingested if any is invalid
+	now := time.Now().Unix()
+	var invalid, denied []string
+	for i, event := range events {
+		if err := prepareIngestedEvent(event, now); err != nil {
+			invalid = append(invalid, fmt.Sprintf("event #%d: %s", i, err))
+			continue
+		}
+		attrs := &authorization.Attributes{
+			APIGroup:     "core",
+			APIVersion:   "v2",
+			Namespace:    event.Namespace,
+			Resource:     event.RBACName(),
+			ResourceName: path.Join(event.Entity.Name, event.Check.Name),
+			User:         reqAttrs.User,
+			Verb:         "create",
+		}
+		authorized, err := r.auth.Authorize(req.Context(), attrs)
+		if err != nil {
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+		if !authorized {
+			denied = append(denied, fmt.Sprintf("event #%d: unauthorized to create the event %s in the namespace %s", i, attrs.ResourceName, event.Namespace))
+		}
+	}
+	if len(invalid) > 0 {
+		return nil, actions.NewError(actions.InvalidArgument, errors.New(strings.Join(invalid, "; ")))
+	}
+	if len(denied) > 0 {
+		return nil, actions.NewError(actions.PermissionDenied, errors.New(strings.Join(denied, "; ")))
+	}
+
+	results := make([]EventIngestResult, len(events))
+	for i, event := range events {
+		results[i] = EventIngestResult{
+			Namespace: event.Namespace,
+			Entity:    event.Entity.Name,
+			Check:     event.Check.Name,
+		}
+		if err := r.controller.CreateOrReplace(req.Context(), event); err != nil {
+			results[i].Error = err.Error()
+		}
+	}
+	return results, nil
+}
+
+// prepareIngestedEvent fills the missing namespace and timestamps of an
+// ingested event, and validates it. The entity is a proxy entity unless its
+// class is given.
+func prepareIngestedEvent(event *corev2.Event, now int64) error {
+	if event.Entity == nil || !event.HasCheck() {
+		return fmt.Errorf("event must contain an entity and a check")
+	}
+
+	namespace := event.Namespace
+	for _, ns := range []string{event.Entity.Namespace, event.Check.Namespace} {
+		if namespace == "" {
+			namespace = ns
+		} else if ns != "" && ns != namespace {
+			return fmt.Errorf("the namespaces of the event, its entity and its check don't match")
+		}
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	event.Namespace = namespace
+	event.Entity.Namespace = namespace
+	event.Check.Namespace = namespace
+
+	if event.Timestamp == 0 {
+		event.Timestamp = now
+	}
+	if event.Check.Executed == 0 {
+		event.Check.Executed = now
+	}
+	if event.Check.Issued == 0 {
+		event.Check.Issued = event.Check.Executed
+	}
+	if event.Entity.EntityClass == "" {
+		event.Entity.EntityClass = corev2.EntityProxyClass
+	}
+
+	return event.Validate()
+}
+
 // validateEventPayload validates the event payload against the URL path values
 func validateEventPayload(event *corev2.Event, vars map[string]string) error {
 	if event.Entity != nil {
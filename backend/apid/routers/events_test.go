@@ -3,16 +3,21 @@ package routers
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/authorization"
 	"github.com/sensu/sensu-go/backend/store"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 type mockEventController struct {
@@ -37,6 +42,26 @@ func (m *mockEventController) List(ctx context.Context, pred *store.SelectionPre
 	return args.Get(0).([]corev2.Resource), args.Error(1)
 }
 
+func (m *mockEventController) GetHandlerResults(ctx context.Context, entity, check string) ([]*corev2.HandlerResult, error) {
+	args := m.Called(ctx, entity, check)
+	return args.Get(0).([]*corev2.HandlerResult), args.Error(1)
+}
+
+func (m *mockEventController) DeleteSelected(ctx context.Context, req *corev2.EventSelection) (*corev2.EventSelectionResponse, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*corev2.EventSelectionResponse), args.Error(1)
+}
+
+func (m *mockEventController) ResolveSelected(ctx context.Context, req *corev2.EventSelection) (*corev2.EventSelectionResponse, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*corev2.EventSelectionResponse), args.Error(1)
+}
+
+func (m *mockEventController) Report(ctx context.Context, since time.Time) (*corev2.EventReport, error) {
+	args := m.Called(ctx, since)
+	return args.Get(0).(*corev2.EventReport), args.Error(1)
+}
+
 func TestEventsRouter(t *testing.T) {
 	type controllerFunc func(*mockEventController)
 
@@ -88,6 +113,28 @@ func TestEventsRouter(t *testing.T) {
 			},
 			wantStatusCode: http.StatusOK,
 		},
+		{
+			name:   "it returns 404 if the event of the handler results is not found",
+			method: http.MethodGet,
+			path:   fixture.URIPath() + "/handled",
+			controllerFunc: func(c *mockEventController) {
+				c.On("GetHandlerResults", mock.Anything, "foo", "check-cpu").
+					Return([]*corev2.HandlerResult(nil), actions.NewErrorf(actions.NotFound)).
+					Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:   "it returns 200 and the handler results",
+			method: http.MethodGet,
+			path:   fixture.URIPath() + "/handled",
+			controllerFunc: func(c *mockEventController) {
+				c.On("GetHandlerResults", mock.Anything, "foo", "check-cpu").
+					Return([]*corev2.HandlerResult{corev2.FixtureHandlerResult("slack")}, nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+		},
 		{
 			name:   "it returns 500 if the store encounters an error while listing events",
 			method: http.MethodGet,
@@ -377,6 +424,80 @@ func TestEventsRouter(t *testing.T) {
 			},
 			wantStatusCode: http.StatusNoContent,
 		},
+		{
+			name:   "it returns 400 if the field selector of the events to delete is invalid",
+			method: http.MethodDelete,
+			path:   empty.URIPath() + "?fieldSelector=foo",
+			controllerFunc: func(c *mockEventController) {
+				c.On("DeleteSelected", mock.Anything, &corev2.EventSelection{FieldSelector: "foo"}).
+					Return((*corev2.EventSelectionResponse)(nil), actions.NewErrorf(actions.InvalidArgument)).
+					Once()
+			},
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "it returns 200 and the deleted events",
+			method: http.MethodDelete,
+			path:   empty.URIPath() + "?fieldSelector=event.check.name%3D%3Dcheck-cpu",
+			controllerFunc: func(c *mockEventController) {
+				c.On("DeleteSelected", mock.Anything, &corev2.EventSelection{FieldSelector: "event.check.name==check-cpu"}).
+					Return(&corev2.EventSelectionResponse{Events: []string{"foo/check-cpu"}}, nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		//
+		// RESOLVE
+		//
+		{
+			name:           "it returns 400 if the selection of the events to resolve is not decodable",
+			method:         http.MethodPost,
+			path:           empty.URIPath() + "/resolve",
+			body:           []byte(`foo`),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "it returns 200 and the resolved events",
+			method: http.MethodPost,
+			path:   empty.URIPath() + "/resolve",
+			body:   []byte(`{"field_selector": "event.check.status!=0"}`),
+			controllerFunc: func(c *mockEventController) {
+				c.On("ResolveSelected", mock.Anything, &corev2.EventSelection{FieldSelector: "event.check.status!=0"}).
+					Return(&corev2.EventSelectionResponse{Events: []string{"foo/check-cpu"}}, nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		//
+		// INGEST
+		//
+		{
+			name:           "it returns 400 if the ingested body is too large",
+			method:         http.MethodPost,
+			path:           "/api/core/v2/events",
+			body:           bytes.Repeat([]byte(" "), maxIngestedBytes+1),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		//
+		// REPORT
+		//
+		{
+			name:           "it returns 400 if the period of the report is invalid",
+			method:         http.MethodGet,
+			path:           "/api/core/v2/namespaces/default/reports/events?since=yesterday",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "it returns 200 and the report",
+			method: http.MethodGet,
+			path:   "/api/core/v2/namespaces/default/reports/events?since=1h",
+			controllerFunc: func(c *mockEventController) {
+				c.On("Report", mock.Anything, mock.MatchedBy(func(since time.Time) bool {
+					return time.Since(since) >= time.Hour
+				})).Return(&corev2.EventReport{}, nil).Once()
+			},
+			wantStatusCode: http.StatusOK,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -412,3 +533,128 @@ func TestEventsRouter(t *testing.T) {
 		})
 	}
 }
+
+func newIngestRequest(t *testing.T, body []byte) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/events", bytes.NewReader(body))
+	ctx := authorization.SetAttributes(req.Context(), &authorization.Attributes{
+		User: corev2.User{Username: "cron"},
+	})
+	return req.WithContext(ctx)
+}
+
+func TestEventsRouterIngest(t *testing.T) {
+	minimal := []byte(`{"entity": {"metadata": {"name": "backups"}}, "check": {"metadata": {"name": "nightly"}, "status": 1, "output": "2 files skipped"}}`)
+
+	controller := &mockEventController{}
+	controller.On("CreateOrReplace", mock.Anything, mock.MatchedBy(func(event *corev2.Event) bool {
+		return event.Namespace == "default" &&
+			event.Entity.Namespace == "default" &&
+			event.Entity.EntityClass == corev2.EntityProxyClass &&
+			event.Check.Namespace == "default" &&
+			event.Check.Status == 1 &&
+			event.Check.Executed > 0 &&
+			event.Check.Issued == event.Check.Executed &&
+			event.Timestamp > 0
+	})).Return(nil).Once()
+	router := EventsRouter{controller: controller, auth: denyingAuthorizer{}}
+
+	results, err := router.ingest(newIngestRequest(t, minimal))
+	require.NoError(t, err)
+	assert.Equal(t, []EventIngestResult{{Namespace: "default", Entity: "backups", Check: "nightly"}}, results)
+	controller.AssertExpectations(t)
+
+	// Several events can be ingested at once
+	acme := corev2.FixtureEvent("web01", "http")
+	acme.Namespace = "acme"
+	acme.Entity.Namespace = "acme"
+	acme.Check.Namespace = "acme"
+	controller = &mockEventController{}
+	controller.On("CreateOrReplace", mock.Anything, mock.Anything).Return(nil).Twice()
+	router = EventsRouter{controller: controller, auth: denyingAuthorizer{}}
+
+	_, err = router.ingest(newIngestRequest(t, marshal([]*corev2.Event{corev2.FixtureEvent("web01", "http"), acme})))
+	require.NoError(t, err)
+	controller.AssertExpectations(t)
+
+	// The events are all ingested despite the failure of one of them
+	controller = &mockEventController{}
+	controller.On("CreateOrReplace", mock.Anything, mock.MatchedBy(func(event *corev2.Event) bool {
+		return event.Namespace == "acme"
+	})).Return(errors.New("store unavailable")).Once()
+	controller.On("CreateOrReplace", mock.Anything, mock.Anything).Return(nil).Twice()
+	router = EventsRouter{controller: controller, auth: denyingAuthorizer{}}
+
+	results, err = router.ingest(newIngestRequest(t, marshal([]*corev2.Event{
+		corev2.FixtureEvent("web01", "http"), acme, corev2.FixtureEvent("web02", "http"),
+	})))
+	require.NoError(t, err)
+	assert.Equal(t, []EventIngestResult{
+		{Namespace: "default", Entity: "web01", Check: "http"},
+		{Namespace: "acme", Entity: "web01", Check: "http", Error: "store unavailable"},
+		{Namespace: "default", Entity: "web02", Check: "http"},
+	}, results)
+	controller.AssertExpectations(t)
+}
+
+func TestEventsRouterIngestErrors(t *testing.T) {
+	mismatch := corev2.FixtureEvent("web01", "http")
+	mismatch.Check.Namespace = "acme"
+	noCheck := corev2.FixtureEvent("web01", "http")
+	noCheck.Check = nil
+	invalid := corev2.FixtureEvent("web01", "http")
+	invalid.Check.Executed = -1
+
+	tests := []struct {
+		name string
+		body []byte
+		auth authorization.Authorizer
+		code actions.ErrCode
+	}{
+		{name: "invalid json", body: []byte(`{`), code: actions.InvalidArgument},
+		{name: "no events", body: []byte(`[]`), code: actions.InvalidArgument},
+		{name: "namespace mismatch", body: marshal(mismatch), code: actions.InvalidArgument},
+		{name: "no check", body: marshal(noCheck), code: actions.InvalidArgument},
+		{name: "invalid event", body: marshal(invalid), code: actions.InvalidArgument},
+		{
+			name: "unauthorized event",
+			body: marshal([]*corev2.Event{corev2.FixtureEvent("web01", "http"), corev2.FixtureEvent("web02", "http")}),
+			auth: denyingAuthorizer{name: "web02/http"},
+			code: actions.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := &mockEventController{}
+			auth := tt.auth
+			if auth == nil {
+				auth = denyingAuthorizer{}
+			}
+			router := EventsRouter{controller: controller, auth: auth}
+
+			_, err := router.ingest(newIngestRequest(t, tt.body))
+			require.Error(t, err)
+			assert.Equal(t, tt.code, errorCode(t, err))
+			controller.AssertNotCalled(t, "CreateOrReplace", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestEventsRouterIngestInvalidEvents(t *testing.T) {
+	noCheck := corev2.FixtureEvent("web02", "http")
+	noCheck.Check = nil
+	invalid := corev2.FixtureEvent("web03", "http")
+	invalid.Check.Executed = -1
+
+	controller := &mockEventController{}
+	router := EventsRouter{controller: controller, auth: denyingAuthorizer{}}
+
+	// Every invalid event is reported, and none of the events is ingested
+	_, err := router.ingest(newIngestRequest(t, marshal([]*corev2.Event{corev2.FixtureEvent("web01", "http"), noCheck, invalid})))
+	require.Error(t, err)
+	assert.Equal(t, actions.InvalidArgument, errorCode(t, err))
+	assert.Contains(t, err.Error(), "event #1: ")
+	assert.Contains(t, err.Error(), "event #2: ")
+	assert.NotContains(t, err.Error(), "event #0: ")
+	controller.AssertNotCalled(t, "CreateOrReplace", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,108 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// eventWatchTimeout is the duration after which a watch of events ends, so that
+// it never exceeds the write timeout of the API. Clients reconnect to keep
+// watching.
+const eventWatchTimeout = 10 * time.Second
+
+// eventWatchFilter selects the events streamed to a watch client.
+type eventWatchFilter struct {
+	namespace string
+	entity    string
+	check     string
+	status    *uint32
+}
+
+func newEventWatchFilter(req *http.Request) (*eventWatchFilter, error) {
+	query := req.URL.Query()
+	filter := &eventWatchFilter{
+		namespace: corev2.ContextNamespace(req.Context()),
+		entity:    query.Get("entity"),
+		check:     query.Get("check"),
+	}
+	if s := query.Get("status"); s != "" {
+		status, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return nil, errors.New("invalid status: " + s)
+		}
+		filter.status = new(uint32)
+		*filter.status = uint32(status)
+	}
+	return filter, nil
+}
+
+func (f *eventWatchFilter) matches(event *corev2.Event) bool {
+	if event.Namespace != f.namespace {
+		return false
+	}
+	if f.entity != "" && (event.Entity == nil || event.Entity.Name != f.entity) {
+		return false
+	}
+	if f.check != "" || f.status != nil {
+		if !event.HasCheck() {
+			return false
+		}
+		if f.check != "" && event.Check.Name != f.check {
+			return false
+		}
+		if f.status != nil && event.Check.Status != *f.status {
+			return false
+		}
+	}
+	return true
+}
+
+// watch streams the events of the namespace as they are stored by any backend
+// of the cluster, as newline-delimited JSON, until the client disconnects or
+// eventWatchTimeout elapses. The events can be selected with the entity, check
+// and status query parameters.
+func (r *EventsRouter) watch(w http.ResponseWriter, req *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		WriteError(w, actions.NewErrorf(actions.InternalErr, "streaming is not supported"))
+		return
+	}
+	filter, err := newEventWatchFilter(req)
+	if err != nil {
+		WriteError(w, actions.NewError(actions.InvalidArgument, err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), eventWatchTimeout)
+	defer cancel()
+	watch := r.watchStore.WatchResources(ctx, &corev2.Event{})
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	encoder := json.NewEncoder(w)
+	for response := range watch {
+		// Deleted events are not streamed, and the events missed when the
+		// watch is interrupted are not fetched again
+		if response.Action == store.WatchDelete || response.Action == store.WatchError {
+			continue
+		}
+		event, ok := response.Resource.(*corev2.Event)
+		if !ok || !filter.matches(event) {
+			continue
+		}
+		if err := encoder.Encode(event); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,72 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventWatchFilter(t *testing.T) {
+	req := httptest.NewRequest("GET", "/events?watch=true&check=check-cpu&status=2", nil)
+	req = req.WithContext(context.WithValue(req.Context(), corev2.NamespaceKey, "default"))
+	filter, err := newEventWatchFilter(req)
+	require.NoError(t, err)
+
+	event := corev2.FixtureEvent("web-1", "check-cpu")
+	event.Check.Status = 2
+	assert.True(t, filter.matches(event))
+
+	event.Check.Status = 0
+	assert.False(t, filter.matches(event))
+
+	event.Check.Status = 2
+	event.Namespace = "acme"
+	assert.False(t, filter.matches(event))
+
+	event = corev2.FixtureEvent("web-1", "check-mem")
+	event.Check.Status = 2
+	assert.False(t, filter.matches(event))
+}
+
+func TestEventWatchFilterInvalidStatus(t *testing.T) {
+	req := httptest.NewRequest("GET", "/events?watch=true&status=critical", nil)
+	_, err := newEventWatchFilter(req)
+	assert.Error(t, err)
+}
+
+func TestEventsRouterWatch(t *testing.T) {
+	critical := corev2.FixtureEvent("web-1", "check-cpu")
+	critical.Check.Status = 2
+	ok := corev2.FixtureEvent("web-2", "check-cpu")
+	ch := make(chan store.WatchEventResource, 5)
+	ch <- store.WatchEventResource{Action: store.WatchCreate, Resource: ok}
+	ch <- store.WatchEventResource{Action: store.WatchError}
+	ch <- store.WatchEventResource{Action: store.WatchDelete, Resource: critical}
+	ch <- store.WatchEventResource{Action: store.WatchUpdate, Resource: critical}
+	close(ch)
+
+	st := &mockstore.MockStore{}
+	st.On("WatchResources", mock.Anything, &corev2.Event{}).Return((<-chan store.WatchEventResource)(ch))
+	router := &EventsRouter{watchStore: st}
+
+	req := httptest.NewRequest("GET", "/events?watch=true&status=2", nil)
+	req = req.WithContext(context.WithValue(req.Context(), corev2.NamespaceKey, "default"))
+	w := httptest.NewRecorder()
+	router.watch(w, req)
+
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	// Only the stored events matching the filter are streamed
+	var event corev2.Event
+	decoder := json.NewDecoder(w.Body)
+	require.NoError(t, decoder.Decode(&event))
+	assert.Equal(t, "web-1", event.Entity.Name)
+	assert.False(t, decoder.More())
+}
@@ -27,6 +27,7 @@ func (r *ExtensionsRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/namespaces/{namespace}/{resource:extensions}",
+		Resource:   &corev2.Extension{},
 	}
 
 	routes.Del(r.handlers.DeleteResource)
@@ -16,8 +16,9 @@ type EventFiltersRouter struct {
 func NewEventFiltersRouter(store store.ResourceStore) *EventFiltersRouter {
 	return &EventFiltersRouter{
 		handlers: handlers.Handlers{
-			Resource: &corev2.EventFilter{},
-			Store:    store,
+			Resource:  &corev2.EventFilter{},
+			Store:     store,
+			Referrers: referrerTypes[(&corev2.EventFilter{}).RBACName()],
 		},
 	}
 }
@@ -27,6 +28,7 @@ func (r *EventFiltersRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/namespaces/{namespace}/{resource:filters}",
+		Resource:   &corev2.EventFilter{},
 	}
 
 	routes.Del(r.handlers.DeleteResource)
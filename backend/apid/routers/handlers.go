@@ -16,8 +16,9 @@ type HandlersRouter struct {
 func NewHandlersRouter(store store.ResourceStore) *HandlersRouter {
 	return &HandlersRouter{
 		handlers: handlers.Handlers{
-			Resource: &corev2.Handler{},
-			Store:    store,
+			Resource:  &corev2.Handler{},
+			Store:     store,
+			Referrers: referrerTypes[(&corev2.Handler{}).RBACName()],
 		},
 	}
 }
@@ -27,6 +28,7 @@ func (r *HandlersRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/namespaces/{namespace}/{resource:handlers}",
+		Resource:   &corev2.Handler{},
 	}
 	routes.Del(r.handlers.DeleteResource)
 	routes.Get(r.handlers.GetResource)
@@ -9,6 +9,7 @@ import (
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/openapi"
 )
 
 // HealthController represents the controller needs of the HealthRouter
@@ -31,7 +32,10 @@ func NewHealthRouter(ctrl HealthController) *HealthRouter {
 
 // Mount the HealthRouter to a parent Router
 func (r *HealthRouter) Mount(parent *mux.Router) {
-	parent.HandleFunc("/health", r.health).Methods(http.MethodGet)
+	openapi.Describe(parent.HandleFunc("/health", r.health).Methods(http.MethodGet), openapi.RouteMeta{
+		Summary:  "Get the health of the cluster",
+		Response: &corev2.HealthResponse{},
+	})
 }
 
 func (r *HealthRouter) health(w http.ResponseWriter, req *http.Request) {
@@ -3,6 +3,7 @@ package routers
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -253,7 +254,7 @@ var createResourceInvalidTestCase = func(resource corev2.Resource) routerTestCas
 		body:   marshal(resource),
 		storeFunc: func(s *mockstore.MockStore) {
 			s.On("CreateResource", mock.Anything, mock.AnythingOfType(typ)).
-				Return(&store.ErrNotValid{}).
+				Return(&store.ErrNotValid{Err: errors.New("invalid")}).
 				Once()
 		},
 		wantStatusCode: http.StatusBadRequest,
@@ -342,7 +343,7 @@ var updateResourceInvalidTestCase = func(resource corev2.Resource) routerTestCas
 		body:   marshal(resource),
 		storeFunc: func(s *mockstore.MockStore) {
 			s.On("CreateOrUpdateResource", mock.Anything, mock.AnythingOfType(typ)).
-				Return(&store.ErrNotValid{}).
+				Return(&store.ErrNotValid{Err: errors.New("invalid")}).
 				Once()
 		},
 		wantStatusCode: http.StatusBadRequest,
@@ -409,6 +410,9 @@ var deleteResourceNotFoundTestCase = func(resource corev2.Resource) routerTestCa
 		path:   resource.URIPath(),
 		body:   []byte(`{"metadata": {"namespace":"default","name":"foo"}}`),
 		storeFunc: func(s *mockstore.MockStore) {
+			// The resource isn't referenced by any other resource
+			s.On("ListResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
 			s.On("DeleteResource", mock.Anything, resource.StorePrefix(), resource.GetObjectMeta().Name).
 				Return(&store.ErrNotFound{}).
 				Once()
@@ -424,6 +428,8 @@ var deleteResourceStoreErrTestCase = func(resource corev2.Resource) routerTestCa
 		path:   resource.URIPath(),
 		body:   []byte(`{"metadata": {"namespace":"default","name":"foo"}}`),
 		storeFunc: func(s *mockstore.MockStore) {
+			s.On("ListResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
 			s.On("DeleteResource", mock.Anything, resource.StorePrefix(), resource.GetObjectMeta().Name).
 				Return(&store.ErrInternal{}).
 				Once()
@@ -439,6 +445,8 @@ var deleteResourceSuccessTestCase = func(resource corev2.Resource) routerTestCas
 		path:   resource.URIPath(),
 		body:   []byte(`{"metadata": {"namespace":"default","name":"foo"}}`),
 		storeFunc: func(s *mockstore.MockStore) {
+			s.On("ListResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
 			s.On("DeleteResource", mock.Anything, resource.StorePrefix(), resource.GetObjectMeta().Name).
 				Return(nil).
 				Once()
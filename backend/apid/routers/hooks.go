@@ -27,6 +27,7 @@ func (r *HooksRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/namespaces/{namespace}/{resource:hooks}",
+		Resource:   &corev2.HookConfig{},
 	}
 
 	routes.Del(r.handlers.DeleteResource)
@@ -35,6 +35,7 @@ func List(list ListControllerFunc, fields FieldsFunc) http.HandlerFunc {
 		pred := &store.SelectionPredicate{
 			Continue: corev2.PageContinueFromContext(r.Context()),
 			Limit:    int64(corev2.PageSizeFromContext(r.Context())),
+			Sort:     r.URL.Query().Get("sort"),
 		}
 
 		params := actions.QueryParams(mux.Vars(r))
@@ -0,0 +1,41 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/liveness"
+)
+
+// SwitchInspector represents the needs of the MonitorsRouter
+type SwitchInspector interface {
+	Switches(ctx context.Context) ([]liveness.SwitchState, error)
+}
+
+// MonitorsRouter handles requests for /debug/monitors, which lists the
+// keepalive and check TTL monitors of the backends
+type MonitorsRouter struct {
+	inspector SwitchInspector
+}
+
+// NewMonitorsRouter instantiates a new router for inspecting monitors
+func NewMonitorsRouter(inspector SwitchInspector) *MonitorsRouter {
+	return &MonitorsRouter{
+		inspector: inspector,
+	}
+}
+
+// Mount the MonitorsRouter to a parent Router
+func (r *MonitorsRouter) Mount(parent *mux.Router) {
+	parent.HandleFunc("/{resource:monitors}", actionHandler(r.list)).Methods(http.MethodGet)
+}
+
+func (r *MonitorsRouter) list(req *http.Request) (interface{}, error) {
+	switches, err := r.inspector.Switches(req.Context())
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	return switches, nil
+}
@@ -0,0 +1,58 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/sensu/sensu-go/backend/liveness"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSwitchInspector struct {
+	switches []liveness.SwitchState
+	err      error
+}
+
+func (f fakeSwitchInspector) Switches(context.Context) ([]liveness.SwitchState, error) {
+	return f.switches, f.err
+}
+
+func TestMonitorsRouter(t *testing.T) {
+	switches := []liveness.SwitchState{
+		{SwitchSet: "keepalived", Target: "default/entity1", State: "alive", TTL: 120, Deadline: 1600000120, LastUpdate: 1600000000},
+		{SwitchSet: "eventd", Target: "default/entity1/check1", State: "dead", TTL: 30, Deadline: 1600000030, LastUpdate: 1600000000},
+	}
+
+	tests := []struct {
+		name           string
+		inspector      SwitchInspector
+		wantStatusCode int
+	}{
+		{"monitors listed", fakeSwitchInspector{switches: switches}, http.StatusOK},
+		{"inspector error", fakeSwitchInspector{err: errors.New("error")}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := mux.NewRouter().PathPrefix("/debug/").Subrouter()
+			NewMonitorsRouter(tt.inspector).Mount(parent)
+
+			req := httptest.NewRequest(http.MethodGet, "/debug/monitors", nil)
+			w := httptest.NewRecorder()
+			parent.ServeHTTP(w, req)
+			require.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var got []liveness.SwitchState
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+			assert.Equal(t, switches, got)
+		})
+	}
+}
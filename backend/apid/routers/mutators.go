@@ -16,8 +16,9 @@ type MutatorsRouter struct {
 func NewMutatorsRouter(store store.ResourceStore) *MutatorsRouter {
 	return &MutatorsRouter{
 		handlers: handlers.Handlers{
-			Resource: &corev2.Mutator{},
-			Store:    store,
+			Resource:  &corev2.Mutator{},
+			Store:     store,
+			Referrers: referrerTypes[(&corev2.Mutator{}).RBACName()],
 		},
 	}
 }
@@ -27,6 +28,7 @@ func (r *MutatorsRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/namespaces/{namespace}/{resource:mutators}",
+		Resource:   &corev2.Mutator{},
 	}
 
 	routes.Del(r.handlers.DeleteResource)
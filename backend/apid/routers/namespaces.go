@@ -2,27 +2,34 @@ package routers
 
 import (
 	"context"
+	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/api"
+	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/authentication/jwt"
 	"github.com/sensu/sensu-go/backend/authorization"
 	"github.com/sensu/sensu-go/backend/store"
 )
 
 // NamespacesRouter handles requests for /namespaces
 type NamespacesRouter struct {
-	handlers handlers.Handlers
-	store    store.ResourceStore
-	auth     authorization.Authorizer
+	handlers  handlers.Handlers
+	store     store.ResourceStore
+	muteStore store.NamespaceMuteStore
+	auth      authorization.Authorizer
 }
 
 // NewNamespacesRouter instantiates new router for controlling check resources
-func NewNamespacesRouter(store store.ResourceStore, auth authorization.Authorizer) *NamespacesRouter {
+func NewNamespacesRouter(store store.Store, auth authorization.Authorizer) *NamespacesRouter {
 	return &NamespacesRouter{
-		store: store,
-		auth:  auth,
+		store:     store,
+		muteStore: store,
+		auth:      auth,
 		handlers: handlers.Handlers{
 			Resource: &corev2.Namespace{},
 			Store:    store,
@@ -35,6 +42,7 @@ func (r *NamespacesRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/{resource:namespaces}",
+		Resource:   &corev2.Namespace{},
 	}
 
 	routes.Del(r.handlers.DeleteResource)
@@ -42,6 +50,10 @@ func (r *NamespacesRouter) Mount(parent *mux.Router) {
 	routes.List(r.list, corev2.NamespaceFields)
 	routes.Post(r.handlers.CreateResource)
 	routes.Put(r.handlers.CreateOrUpdateResource)
+
+	// Custom
+	routes.Path("{id}/mute", r.getMute).Methods(http.MethodGet)
+	routes.Path("{id}/mute", r.updateMute).Methods(http.MethodPut)
 }
 
 func (r *NamespacesRouter) list(ctx context.Context, _ *store.SelectionPredicate) ([]corev2.Resource, error) {
@@ -56,3 +68,49 @@ func (r *NamespacesRouter) list(ctx context.Context, _ *store.SelectionPredicate
 	}
 	return result, nil
 }
+
+func (r *NamespacesRouter) getMute(req *http.Request) (interface{}, error) {
+	namespace, err := url.PathUnescape(mux.Vars(req)["id"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	mute, err := r.muteStore.GetNamespaceMute(req.Context(), namespace)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+	if mute == nil {
+		mute = &corev2.NamespaceMute{}
+	}
+	return mute, nil
+}
+
+func (r *NamespacesRouter) updateMute(req *http.Request) (interface{}, error) {
+	namespace, err := url.PathUnescape(mux.Vars(req)["id"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	mute := &corev2.NamespaceMute{}
+	if err := UnmarshalBody(req, mute); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	if mute.Muted {
+		if claims := jwt.GetClaimsFromContext(req.Context()); claims != nil {
+			mute.MutedBy = claims.Subject
+		}
+		mute.Timestamp = time.Now().Unix()
+	} else {
+		mute = &corev2.NamespaceMute{}
+	}
+
+	if err := r.muteStore.UpdateNamespaceMute(req.Context(), namespace, mute); err != nil {
+		switch err.(type) {
+		case *store.ErrNamespaceMissing:
+			return nil, actions.NewErrorf(actions.NotFound)
+		default:
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+	}
+	return mute, nil
+}
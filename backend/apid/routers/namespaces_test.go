@@ -2,11 +2,13 @@ package routers
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/authorization/rbac"
+	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/stretchr/testify/mock"
 )
@@ -80,3 +82,72 @@ func TestNamespaceRouterList(t *testing.T) {
 		t.Fatal("expected namespaces to be returned")
 	}
 }
+
+func TestNamespacesRouterMute(t *testing.T) {
+	tests := []routerTestCase{
+		{
+			name:   "it returns the muting switch of an unmuted namespace",
+			method: http.MethodGet,
+			path:   "/namespaces/foo/mute",
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetNamespaceMute", mock.Anything, "foo").Return((*corev2.NamespaceMute)(nil), nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "it returns an error if the muting switch can't be retrieved",
+			method: http.MethodGet,
+			path:   "/namespaces/foo/mute",
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("GetNamespaceMute", mock.Anything, "foo").Return((*corev2.NamespaceMute)(nil), &store.ErrInternal{})
+			},
+			wantStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:           "it returns 400 if the muting switch is invalid",
+			method:         http.MethodPut,
+			path:           "/namespaces/foo/mute",
+			body:           []byte("foo"),
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "it returns 404 if the namespace does not exist",
+			method: http.MethodPut,
+			path:   "/namespaces/foo/mute",
+			body:   []byte(`{"muted": true}`),
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("UpdateNamespaceMute", mock.Anything, "foo", mock.Anything).Return(&store.ErrNamespaceMissing{Namespace: "foo"})
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:   "it mutes the namespace",
+			method: http.MethodPut,
+			path:   "/namespaces/foo/mute",
+			body:   []byte(`{"muted": true, "reason": "maintenance"}`),
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("UpdateNamespaceMute", mock.Anything, "foo", mock.MatchedBy(func(m *corev2.NamespaceMute) bool {
+					return m.Muted && m.Reason == "maintenance" && m.Timestamp > 0
+				})).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+		{
+			name:   "it unmutes the namespace",
+			method: http.MethodPut,
+			path:   "/namespaces/foo/mute",
+			body:   []byte(`{"muted": false, "reason": "maintenance"}`),
+			storeFunc: func(s *mockstore.MockStore) {
+				s.On("UpdateNamespaceMute", mock.Anything, "foo", &corev2.NamespaceMute{}).Return(nil)
+			},
+			wantStatusCode: http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		s := &mockstore.MockStore{}
+		router := NewNamespacesRouter(s, nil)
+		parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+		router.Mount(parentRouter)
+		run(t, tt, parentRouter, s)
+	}
+}
@@ -0,0 +1,54 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/apid/openapi"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// PasswordResetController represents the controller needs of the
+// PasswordResetRouter.
+type PasswordResetController interface {
+	ResetPassword(ctx context.Context, reset *corev2.PasswordReset) error
+}
+
+// PasswordResetRouter handles the exchanges of password reset tokens for new
+// passwords. The tokens authenticate the requests, so the router must be
+// mounted without the authentication middlewares.
+type PasswordResetRouter struct {
+	controller PasswordResetController
+}
+
+// NewPasswordResetRouter instantiates a new router for password resets.
+func NewPasswordResetRouter(store store.Store) *PasswordResetRouter {
+	return &PasswordResetRouter{controller: actions.NewUserController(store)}
+}
+
+// Mount the PasswordResetRouter to a parent Router
+func (r *PasswordResetRouter) Mount(parent *mux.Router) {
+	openapi.Describe(parent.HandleFunc("/auth/reset_password", r.resetPassword).Methods(http.MethodPost), openapi.RouteMeta{
+		Summary: "Exchange a password reset token for a new password",
+		Request: &corev2.PasswordReset{},
+		Status:  http.StatusNoContent,
+		Errors:  []int{http.StatusBadRequest},
+	})
+}
+
+func (r *PasswordResetRouter) resetPassword(w http.ResponseWriter, req *http.Request) {
+	reset := &corev2.PasswordReset{}
+	if err := UnmarshalBody(req, reset); err != nil {
+		WriteError(w, actions.NewError(actions.InvalidArgument, err))
+		return
+	}
+
+	if err := r.controller.ResetPassword(req.Context(), reset); err != nil {
+		WriteError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,66 @@
+package routers
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mockPasswordResetController struct {
+	mock.Mock
+}
+
+func (m *mockPasswordResetController) ResetPassword(ctx context.Context, reset *corev2.PasswordReset) error {
+	return m.Called(ctx, reset).Error(0)
+}
+
+func TestPasswordResetRouter(t *testing.T) {
+	controller := &mockPasswordResetController{}
+	router := &PasswordResetRouter{controller: controller}
+	parent := mux.NewRouter()
+	router.Mount(parent)
+
+	tests := []struct {
+		name           string
+		body           string
+		controllerErr  error
+		wantStatusCode int
+	}{
+		{
+			name:           "invalid body",
+			body:           "foo",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid token",
+			body:           `{"token":"invalid","password":"n3wP@ssw0rd"}`,
+			controllerErr:  actions.NewErrorf(actions.Unauthenticated),
+			wantStatusCode: http.StatusUnauthorized,
+		},
+		{
+			name:           "password reset",
+			body:           `{"token":"token","password":"n3wP@ssw0rd"}`,
+			wantStatusCode: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantStatusCode != http.StatusBadRequest {
+				controller.On("ResetPassword", mock.Anything, mock.Anything).Return(tt.controllerErr).Once()
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/auth/reset_password", bytes.NewReader([]byte(tt.body)))
+			res := httptest.NewRecorder()
+			parent.ServeHTTP(res, req)
+			assert.Equal(t, tt.wantStatusCode, res.Code)
+		})
+	}
+}
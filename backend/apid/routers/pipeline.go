@@ -0,0 +1,46 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+)
+
+// PipelineController represents the controller needs of the PipelineRouter.
+type PipelineController interface {
+	DryRun(context.Context, *corev2.Event) (*corev2.PipelineDryRun, error)
+}
+
+// PipelineRouter handles requests for /events/pipeline.
+type PipelineRouter struct {
+	controller PipelineController
+}
+
+// NewPipelineRouter instantiates a new router for the pipeline.
+func NewPipelineRouter(ctrl PipelineController) *PipelineRouter {
+	return &PipelineRouter{
+		controller: ctrl,
+	}
+}
+
+// Mount the PipelineRouter on the given parent Router
+func (r *PipelineRouter) Mount(parent *mux.Router) {
+	routes := ResourceRoute{
+		Router:     parent,
+		PathPrefix: "/namespaces/{namespace}/{resource:events}/pipeline",
+	}
+
+	routes.Path("dry-run", r.dryRun).Methods(http.MethodPost)
+}
+
+func (r *PipelineRouter) dryRun(req *http.Request) (interface{}, error) {
+	event := &corev2.Event{}
+	if err := UnmarshalBody(req, event); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
+	return r.controller.DryRun(req.Context(), event)
+}
@@ -0,0 +1,59 @@
+package routers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockPipelineController struct {
+	mock.Mock
+}
+
+func (m *mockPipelineController) DryRun(ctx context.Context, event *corev2.Event) (*corev2.PipelineDryRun, error) {
+	args := m.Called(ctx, event)
+	return args.Get(0).(*corev2.PipelineDryRun), args.Error(1)
+}
+
+func TestPipelineDryRun(t *testing.T) {
+	controller := &mockPipelineController{}
+	pipelineRouter := NewPipelineRouter(controller)
+	router := mux.NewRouter()
+	pipelineRouter.Mount(router)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	fixture := &corev2.PipelineDryRun{
+		Handlers: []*corev2.PipelineDryRunHandler{{Handler: "slack", WouldRun: true}},
+	}
+	controller.On("DryRun", mock.Anything, mock.Anything).Return(fixture, nil)
+
+	b, _ := json.Marshal(corev2.FixtureEvent("entity1", "check1"))
+	endpoint := "/namespaces/default/events/pipeline/dry-run"
+	req := newRequest(t, http.MethodPost, server.URL+endpoint, bytes.NewReader(b))
+
+	resp, err := new(http.Client).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var result corev2.PipelineDryRun
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&result))
+	assert.Equal(t, fixture, &result)
+
+	// Invalid payloads are rejected
+	req = newRequest(t, http.MethodPost, server.URL+endpoint, bytes.NewReader([]byte("foo")))
+	resp, err = new(http.Client).Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,45 @@
+package routers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+)
+
+// ReplicatorController represents the controller needs of the
+// ReplicatorRouter.
+type ReplicatorController interface {
+	Status() *corev2.ReplicatorStatus
+}
+
+// ReplicatorRouter handles requests for /replicator.
+type ReplicatorRouter struct {
+	controller ReplicatorController
+}
+
+// NewReplicatorRouter instantiates a new router for the replicator status.
+// The controller is nil when the replicator is not enabled.
+func NewReplicatorRouter(ctrl ReplicatorController) *ReplicatorRouter {
+	return &ReplicatorRouter{
+		controller: ctrl,
+	}
+}
+
+// Mount the ReplicatorRouter on the given parent Router
+func (r *ReplicatorRouter) Mount(parent *mux.Router) {
+	routes := ResourceRoute{
+		Router:     parent,
+		PathPrefix: "/" + corev2.ReplicatorResource,
+	}
+
+	routes.Path("", r.status).Methods(http.MethodGet)
+}
+
+func (r *ReplicatorRouter) status(req *http.Request) (interface{}, error) {
+	if r.controller == nil {
+		return nil, actions.NewErrorf(actions.NotFound, "the replicator is not enabled")
+	}
+	return r.controller.Status(), nil
+}
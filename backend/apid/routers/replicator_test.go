@@ -0,0 +1,55 @@
+package routers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockReplicatorController struct {
+	status *corev2.ReplicatorStatus
+}
+
+func (m *mockReplicatorController) Status() *corev2.ReplicatorStatus {
+	return m.status
+}
+
+func TestReplicatorRouter(t *testing.T) {
+	status := &corev2.ReplicatorStatus{
+		Resources:      []string{"checks"},
+		ConflictPolicy: "overwrite",
+		Clusters:       []corev2.ReplicatedClusterStatus{{Name: "eu", Replicated: 2}},
+	}
+
+	tests := []struct {
+		name           string
+		controller     ReplicatorController
+		wantStatusCode int
+	}{
+		{"enabled", &mockReplicatorController{status: status}, http.StatusOK},
+		{"disabled", nil, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := mux.NewRouter()
+			NewReplicatorRouter(tt.controller).Mount(router)
+
+			req := httptest.NewRequest(http.MethodGet, "/"+corev2.ReplicatorResource, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			require.Equal(t, tt.wantStatusCode, w.Code)
+
+			if tt.wantStatusCode == http.StatusOK {
+				var got corev2.ReplicatorStatus
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+				assert.Equal(t, *status, got)
+			}
+		})
+	}
+}
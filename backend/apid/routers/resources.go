@@ -0,0 +1,234 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/authentication/jwt"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/types"
+)
+
+// bulkResources are the types of resources that can be modified in bulk. The
+// other types are not stored as is by their router, such as users, whose
+// password is hashed, or entities, whose changes are sent to keepalived.
+var bulkResources = map[string]bool{
+	(&corev2.Asset{}).RBACName():              true,
+	(&corev2.CheckConfig{}).RBACName():        true,
+	(&corev2.ClusterRole{}).RBACName():        true,
+	(&corev2.ClusterRoleBinding{}).RBACName(): true,
+	(&corev2.EventFilter{}).RBACName():        true,
+	(&corev2.Extension{}).RBACName():          true,
+	(&corev2.Handler{}).RBACName():            true,
+	(&corev2.HookConfig{}).RBACName():         true,
+	(&corev2.Mutator{}).RBACName():            true,
+	(&corev2.Namespace{}).RBACName():          true,
+	(&corev2.Role{}).RBACName():               true,
+	(&corev2.RoleBinding{}).RBACName():        true,
+}
+
+// referrerTypes are the types of the resources that may reference the
+// resources of each type, by RBAC name. A referenced resource is only deleted
+// if the force query parameter is true.
+var referrerTypes = map[string][]corev2.Resource{
+	(&corev2.Asset{}).RBACName():         {&corev2.CheckConfig{}, &corev2.CheckTemplate{}, &corev2.Handler{}, &corev2.EventFilter{}, &corev2.Mutator{}, &corev2.HookConfig{}},
+	(&corev2.CheckTemplate{}).RBACName(): {&corev2.CheckConfig{}},
+	(&corev2.EventFilter{}).RBACName():   {&corev2.Handler{}},
+	(&corev2.Handler{}).RBACName():       {&corev2.CheckConfig{}, &corev2.CheckTemplate{}, &corev2.CompositeCheck{}, &corev2.Handler{}},
+	(&corev2.Mutator{}).RBACName():       {&corev2.Handler{}},
+}
+
+// bulkStore is the store used by the ResourcesRouter.
+type bulkStore interface {
+	store.NamespaceStore
+	store.ResourceStore
+	store.ResourceTxnStore
+}
+
+// ResourcesRouter handles requests for /resources, which create, update or
+// delete several resources in a single transaction, so that either all of
+// them or none are modified.
+type ResourcesRouter struct {
+	store bulkStore
+	auth  authorization.Authorizer
+}
+
+// NewResourcesRouter instantiates a new router for modifying resources in
+// bulk
+func NewResourcesRouter(store store.Store, auth authorization.Authorizer) *ResourcesRouter {
+	return &ResourcesRouter{
+		store: store,
+		auth:  auth,
+	}
+}
+
+// Mount the ResourcesRouter to a parent Router
+func (r *ResourcesRouter) Mount(parent *mux.Router) {
+	routes := ResourceRoute{
+		Router:     parent,
+		PathPrefix: "/{resource:resources}",
+	}
+
+	routes.Path("", r.update).Methods(http.MethodPut)
+	routes.Path("", r.delete).Methods(http.MethodDelete)
+}
+
+// update creates or updates the wrapped resources of the request body
+func (r *ResourcesRouter) update(req *http.Request) (interface{}, error) {
+	resources, err := r.decode(req, "update")
+	if err != nil {
+		return nil, err
+	}
+
+	ops := make([]store.ResourceOp, 0, len(resources))
+	for _, resource := range resources {
+		if check, ok := resource.(*corev2.CheckConfig); ok {
+			if err := validateInterval(req.Context(), r.store, check); err != nil {
+				return nil, err
+			}
+		}
+		meta := resource.GetObjectMeta()
+		if claims := jwt.GetClaimsFromContext(req.Context()); claims != nil {
+			meta.CreatedBy = claims.StandardClaims.Subject
+			resource.SetObjectMeta(meta)
+		}
+		ops = append(ops, store.ResourceOp{Resource: resource})
+	}
+	return nil, r.commit(req.Context(), ops)
+}
+
+// delete deletes the wrapped resources of the request body
+func (r *ResourcesRouter) delete(req *http.Request) (interface{}, error) {
+	resources, err := r.decode(req, "delete")
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL.Query().Get("force") != "true" {
+		if err := r.checkReferrers(req.Context(), resources); err != nil {
+			return nil, err
+		}
+	}
+
+	// The keys depending on the resources, such as the pauses of checks or
+	// the silenced entries of namespaces, are deleted by the store in the
+	// same transaction
+	ops := make([]store.ResourceOp, 0, len(resources))
+	for _, resource := range resources {
+		ops = append(ops, store.ResourceOp{Resource: resource, Delete: true})
+	}
+	return nil, r.commit(req.Context(), ops)
+}
+
+// checkReferrers returns an error if one of the resources to delete is
+// referenced by a resource of its namespace that is not deleted along with it,
+// as for the deletion of a single resource
+func (r *ResourcesRouter) checkReferrers(ctx context.Context, resources []corev2.Resource) error {
+	deleted := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		meta := resource.GetObjectMeta()
+		ref := corev2.ResourceReference{Type: resource.RBACName(), Name: meta.Name}
+		deleted[path.Join(meta.Namespace, ref.String())] = true
+	}
+
+	for _, resource := range resources {
+		kinds := referrerTypes[resource.RBACName()]
+		if len(kinds) == 0 {
+			continue
+		}
+		meta := resource.GetObjectMeta()
+		h := handlers.Handlers{Resource: resource, Store: r.store, Referrers: kinds}
+		referrers, err := h.ReferencedBy(context.WithValue(ctx, corev2.NamespaceKey, meta.Namespace), meta.Name)
+		if err != nil {
+			return actions.NewError(actions.InternalErr, err)
+		}
+		remaining := []string{}
+		for _, referrer := range referrers {
+			if !deleted[path.Join(meta.Namespace, referrer)] {
+				remaining = append(remaining, referrer)
+			}
+		}
+		if len(remaining) > 0 {
+			return actions.NewErrorf(actions.FailedPrecondition,
+				"%s/%s is referenced by %s, remove the references or force its deletion",
+				resource.RBACName(), meta.Name, strings.Join(remaining, ", "))
+		}
+	}
+	return nil
+}
+
+// decode decodes the wrapped resources of the request body, and verifies that
+// they can be modified in bulk and that the user is authorized to apply the
+// verb to each of them
+func (r *ResourcesRouter) decode(req *http.Request, verb string) ([]corev2.Resource, error) {
+	var wrappers []types.Wrapper
+	if err := json.NewDecoder(req.Body).Decode(&wrappers); err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	if len(wrappers) > store.MaxResourceOps {
+		return nil, actions.NewErrorf(actions.InvalidArgument, "at most %d resources can be modified at once", store.MaxResourceOps)
+	}
+
+	reqAttrs := authorization.GetAttributes(req.Context())
+	if reqAttrs == nil {
+		return nil, actions.NewErrorf(actions.InternalErr, "could not retrieve the request info")
+	}
+
+	resources := make([]corev2.Resource, 0, len(wrappers))
+	for i, wrapper := range wrappers {
+		resource := wrapper.Value
+		if wrapper.APIVersion != "core/v2" || resource == nil || !bulkResources[resource.RBACName()] {
+			return nil, actions.NewErrorf(actions.InvalidArgument, "resource #%d of type %s/%s can't be modified in bulk", i, wrapper.APIVersion, wrapper.Type)
+		}
+
+		// Namespaced resources are in the default namespace if none is
+		// given, as with sensuctl
+		meta := resource.GetObjectMeta()
+		if meta.Namespace == "" {
+			resource.SetNamespace("default")
+			meta = resource.GetObjectMeta()
+		}
+
+		attrs := &authorization.Attributes{
+			APIGroup:     "core",
+			APIVersion:   "v2",
+			Namespace:    meta.Namespace,
+			Resource:     resource.RBACName(),
+			ResourceName: meta.Name,
+			User:         reqAttrs.User,
+			Verb:         verb,
+		}
+		authorized, err := r.auth.Authorize(req.Context(), attrs)
+		if err != nil {
+			return nil, actions.NewError(actions.InternalErr, err)
+		}
+		if !authorized {
+			return nil, actions.NewErrorf(actions.PermissionDenied)
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+func (r *ResourcesRouter) commit(ctx context.Context, ops []store.ResourceOp) error {
+	if err := r.store.CommitResources(ctx, ops); err != nil {
+		switch err := err.(type) {
+		case *store.ErrNotValid:
+			return actions.NewValidationError(err)
+		case *store.ErrNamespaceMissing:
+			return actions.NewError(actions.InvalidArgument, err)
+		default:
+			return actions.NewError(actions.InternalErr, fmt.Errorf("no resource was modified: %s", err))
+		}
+	}
+	return nil
+}
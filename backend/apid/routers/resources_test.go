@@ -0,0 +1,190 @@
+package routers
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/authorization"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/sensu/sensu-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// denyingAuthorizer denies the requests for the resource with the given name
+type denyingAuthorizer struct {
+	name string
+}
+
+func (a denyingAuthorizer) Authorize(ctx context.Context, attrs *authorization.Attributes) (bool, error) {
+	return attrs.ResourceName != a.name, nil
+}
+
+func newBulkRequest(t *testing.T, method string, values ...corev2.Resource) *http.Request {
+	t.Helper()
+	wrappers := []types.Wrapper{}
+	for _, value := range values {
+		wrappers = append(wrappers, types.WrapResource(value))
+	}
+	req := httptest.NewRequest(method, "/resources", bytes.NewReader(marshal(wrappers)))
+	ctx := authorization.SetAttributes(req.Context(), &authorization.Attributes{
+		User: corev2.User{Username: "admin"},
+	})
+	return req.WithContext(ctx)
+}
+
+func errorCode(t *testing.T, err error) actions.ErrCode {
+	t.Helper()
+	actionErr, ok := err.(actions.Error)
+	require.True(t, ok, "%v is not an actions.Error", err)
+	return actionErr.Code
+}
+
+func TestResourcesRouterUpdate(t *testing.T) {
+	namespace := corev2.FixtureNamespace("acme")
+	check := corev2.FixtureCheckConfig("check1")
+	check.Namespace = "acme"
+	handler := corev2.FixtureHandler("handler1")
+	handler.Namespace = ""
+
+	s := &mockstore.MockStore{}
+	s.On("GetNamespace", mock.Anything, "acme").Return((*corev2.Namespace)(nil), nil)
+	s.On("CommitResources", mock.Anything, mock.MatchedBy(func(ops []store.ResourceOp) bool {
+		return len(ops) == 3 &&
+			ops[0].Resource.GetObjectMeta().Name == "acme" &&
+			ops[1].Resource.GetObjectMeta().Name == "check1" &&
+			ops[2].Resource.GetObjectMeta().Namespace == "default" &&
+			!ops[0].Delete && !ops[1].Delete && !ops[2].Delete
+	})).Return(nil).Once()
+
+	router := NewResourcesRouter(s, denyingAuthorizer{})
+	_, err := router.update(newBulkRequest(t, http.MethodPut, namespace, check, handler))
+	require.NoError(t, err)
+	s.AssertExpectations(t)
+}
+
+// referencingStore returns a store whose only referrers are the given handlers
+func referencingStore(handlers ...*corev2.Handler) *mockstore.MockStore {
+	s := &mockstore.MockStore{}
+	s.On("ListResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		if list, ok := args[2].(*[]*corev2.Handler); ok {
+			*list = append(*list, handlers...)
+		}
+	}).Return(nil)
+	return s
+}
+
+func TestResourcesRouterDelete(t *testing.T) {
+	s := referencingStore()
+	s.On("CommitResources", mock.Anything, mock.MatchedBy(func(ops []store.ResourceOp) bool {
+		return len(ops) == 2 && ops[0].Delete && ops[1].Delete
+	})).Return(nil).Once()
+
+	router := NewResourcesRouter(s, denyingAuthorizer{})
+	req := newBulkRequest(t, http.MethodDelete, corev2.FixtureCheckConfig("check1"), corev2.FixtureMutator("mutator1"))
+	_, err := router.delete(req)
+	require.NoError(t, err)
+	s.AssertExpectations(t)
+}
+
+func TestResourcesRouterDeleteReferenced(t *testing.T) {
+	mutator := corev2.FixtureMutator("mutator1")
+	handler := corev2.FixtureHandler("handler1")
+	handler.Mutator = "mutator1"
+
+	// A referenced resource is not deleted
+	s := referencingStore(handler)
+	router := NewResourcesRouter(s, denyingAuthorizer{})
+	_, err := router.delete(newBulkRequest(t, http.MethodDelete, mutator))
+	require.Error(t, err)
+	assert.Equal(t, actions.FailedPrecondition, errorCode(t, err))
+	s.AssertNotCalled(t, "CommitResources", mock.Anything, mock.Anything)
+
+	// Unless its referrers are deleted along with it
+	s = referencingStore(handler)
+	s.On("CommitResources", mock.Anything, mock.Anything).Return(nil).Once()
+	router = NewResourcesRouter(s, denyingAuthorizer{})
+	_, err = router.delete(newBulkRequest(t, http.MethodDelete, mutator, handler))
+	require.NoError(t, err)
+	s.AssertExpectations(t)
+
+	// Or its deletion is forced
+	s = referencingStore(handler)
+	s.On("CommitResources", mock.Anything, mock.Anything).Return(nil).Once()
+	router = NewResourcesRouter(s, denyingAuthorizer{})
+	req := newBulkRequest(t, http.MethodDelete, mutator)
+	req.URL.RawQuery = "force=true"
+	_, err = router.delete(req)
+	require.NoError(t, err)
+	s.AssertNotCalled(t, "ListResources", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestResourcesRouterDeleteNotEmptyNamespace(t *testing.T) {
+	s := referencingStore()
+	s.On("CommitResources", mock.Anything, mock.Anything).Return(&store.ErrNotValid{Err: errors.New("namespace is not empty")}).Once()
+	router := NewResourcesRouter(s, denyingAuthorizer{})
+	_, err := router.delete(newBulkRequest(t, http.MethodDelete, corev2.FixtureNamespace("acme")))
+	require.Error(t, err)
+	assert.Equal(t, actions.InvalidArgument, errorCode(t, err))
+}
+
+func TestResourcesRouterErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []corev2.Resource
+		auth      authorization.Authorizer
+		storeErr  error
+		wantCode  actions.ErrCode
+	}{
+		{
+			name:      "resources with a dedicated router are rejected",
+			resources: []corev2.Resource{corev2.FixtureCheckConfig("check1"), corev2.FixtureUser("foo")},
+			wantCode:  actions.InvalidArgument,
+		},
+		{
+			name:      "nothing is modified if a resource is not authorized",
+			resources: []corev2.Resource{corev2.FixtureCheckConfig("check1"), corev2.FixtureHandler("handler1")},
+			auth:      denyingAuthorizer{name: "handler1"},
+			wantCode:  actions.PermissionDenied,
+		},
+		{
+			name:      "missing namespaces are invalid",
+			resources: []corev2.Resource{corev2.FixtureHandler("handler1")},
+			storeErr:  &store.ErrNamespaceMissing{Namespace: "default"},
+			wantCode:  actions.InvalidArgument,
+		},
+		{
+			name:      "store errors are internal errors",
+			resources: []corev2.Resource{corev2.FixtureHandler("handler1")},
+			storeErr:  &store.ErrInternal{Message: "error"},
+			wantCode:  actions.InternalErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mockstore.MockStore{}
+			s.On("GetNamespace", mock.Anything, mock.Anything).Return((*corev2.Namespace)(nil), nil)
+			s.On("CommitResources", mock.Anything, mock.Anything).Return(tt.storeErr)
+
+			auth := tt.auth
+			if auth == nil {
+				auth = denyingAuthorizer{}
+			}
+			router := NewResourcesRouter(s, auth)
+			_, err := router.update(newBulkRequest(t, http.MethodPut, tt.resources...))
+			require.Error(t, err)
+			assert.Equal(t, tt.wantCode, errorCode(t, err))
+			if tt.storeErr == nil {
+				s.AssertNotCalled(t, "CommitResources", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
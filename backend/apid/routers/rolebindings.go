@@ -27,6 +27,7 @@ func (r *RoleBindingsRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/namespaces/{namespace}/{resource:rolebindings}",
+		Resource:   &corev2.RoleBinding{},
 	}
 
 	routes.Del(r.handlers.DeleteResource)
@@ -27,6 +27,7 @@ func (r *RolesRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/namespaces/{namespace}/{resource:roles}",
+		Resource:   &corev2.Role{},
 	}
 
 	routes.Del(r.handlers.DeleteResource)
@@ -2,17 +2,22 @@ package routers
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"path"
+	"reflect"
 
 	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/apid/openapi"
 )
 
 type errorBody struct {
-	Message string `json:"message"`
-	Code    uint32 `json:"code"`
+	Message string              `json:"message"`
+	Code    uint32              `json:"code"`
+	Fields  []corev2.FieldError `json:"fields,omitempty"`
 }
 
 // RespondWith given writer and resource, marshal to JSON and write response.
@@ -56,6 +61,7 @@ func WriteError(w http.ResponseWriter, err error) {
 	if ok {
 		errBody.Message = actionErr.Message
 		errBody.Code = uint32(actionErr.Code)
+		errBody.Fields = actionErr.Fields
 		st = HTTPStatusFromCode(actionErr.Code)
 	} else {
 		errBody.Message = err.Error()
@@ -99,6 +105,14 @@ func HTTPStatusFromCode(code actions.ErrCode) int {
 		return http.StatusNotFound
 	case actions.Unauthenticated:
 		return http.StatusUnauthorized
+	case actions.TooManyRequests:
+		return http.StatusTooManyRequests
+	case actions.FailedPrecondition:
+		return http.StatusConflict
+	case actions.NotModified:
+		return http.StatusNotModified
+	case actions.UnprocessableEntity:
+		return http.StatusUnprocessableEntity
 	}
 
 	logger.WithField("code", code).Error("unknown error code")
@@ -169,26 +183,73 @@ type listHandlerFunc func(w http.ResponseWriter, req *http.Request) (interface{}
 type ResourceRoute struct {
 	Router     *mux.Router
 	PathPrefix string
+
+	// Resource is a value of the type of the resources of the routes, used to
+	// describe them in the API docs. Optional.
+	Resource interface{}
+}
+
+// describe describes the route in the API docs if the type of the resources is
+// known. The summary is formatted with the kind of the resources.
+func (r *ResourceRoute) describe(route *mux.Route, meta openapi.RouteMeta) *mux.Route {
+	if r.Resource == nil {
+		return route
+	}
+	kind := reflect.Indirect(reflect.ValueOf(r.Resource)).Type().Name()
+	meta.Summary = fmt.Sprintf(meta.Summary, kind)
+	return openapi.Describe(route, meta)
 }
 
 // Get reads
 func (r *ResourceRoute) Get(fn actionHandlerFunc) *mux.Route {
-	return r.Path("{id}", fn).Methods(http.MethodGet)
+	route := r.Path("{id}", fn).Methods(http.MethodGet)
+	return r.describe(route, openapi.RouteMeta{
+		Summary:  "Get a %s",
+		Response: r.Resource,
+		Status:   http.StatusOK,
+		Errors:   []int{http.StatusNotFound},
+	})
 }
 
 // List resources
 func (r *ResourceRoute) List(fn ListControllerFunc, fields FieldsFunc) *mux.Route {
-	return r.Router.HandleFunc(r.PathPrefix, listerHandler(fn, fields)).Methods(http.MethodGet)
+	route := r.Router.HandleFunc(r.PathPrefix, listerHandler(fn, fields)).Methods(http.MethodGet)
+	return r.describe(route, openapi.RouteMeta{
+		Summary:    "List the %s resources",
+		Parameters: openapi.ListParameters,
+		Response:   r.resourceList(),
+		Status:     http.StatusOK,
+	})
 }
 
 // ListAllNamespaces return all resources across all namespaces
 func (r *ResourceRoute) ListAllNamespaces(fn ListControllerFunc, path string, fields FieldsFunc) *mux.Route {
-	return r.Router.HandleFunc(path, listerHandler(fn, fields)).Methods(http.MethodGet)
+	route := r.Router.HandleFunc(path, listerHandler(fn, fields)).Methods(http.MethodGet)
+	return r.describe(route, openapi.RouteMeta{
+		Summary:    "List the %s resources of all namespaces",
+		Parameters: openapi.ListParameters,
+		Response:   r.resourceList(),
+		Status:     http.StatusOK,
+	})
+}
+
+// resourceList returns a slice of the type of the resources
+func (r *ResourceRoute) resourceList() interface{} {
+	if r.Resource == nil {
+		return nil
+	}
+	return reflect.MakeSlice(reflect.SliceOf(reflect.TypeOf(r.Resource)), 0, 0).Interface()
 }
 
 // Post creates
 func (r *ResourceRoute) Post(fn actionHandlerFunc) *mux.Route {
-	return r.Path("", fn).Methods(http.MethodPost)
+	route := r.Path("", fn).Methods(http.MethodPost)
+	return r.describe(route, openapi.RouteMeta{
+		Summary: "Create a %s",
+		Request: r.Resource,
+		Status:  http.StatusCreated,
+		Errors:  []int{http.StatusBadRequest, http.StatusConflict},
+	})
 }
 
 // TODO: uncomment this and use it once controller update fits
@@ -200,12 +261,23 @@ func (r *ResourceRoute) Post(fn actionHandlerFunc) *mux.Route {
 
 // Put updates/replaces
 func (r *ResourceRoute) Put(fn actionHandlerFunc) *mux.Route {
-	return r.Path("{id}", fn).Methods(http.MethodPut)
+	route := r.Path("{id}", fn).Methods(http.MethodPut)
+	return r.describe(route, openapi.RouteMeta{
+		Summary: "Create or update a %s",
+		Request: r.Resource,
+		Status:  http.StatusCreated,
+		Errors:  []int{http.StatusBadRequest},
+	})
 }
 
 // Del deletes
 func (r *ResourceRoute) Del(fn actionHandlerFunc) *mux.Route {
-	return r.Path("{id}", fn).Methods(http.MethodDelete)
+	route := r.Path("{id}", fn).Methods(http.MethodDelete)
+	return r.describe(route, openapi.RouteMeta{
+		Summary: "Delete a %s",
+		Status:  http.StatusNoContent,
+		Errors:  []int{http.StatusNotFound},
+	})
 }
 
 // Path adds custom path
@@ -8,6 +8,7 @@ import (
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/clusterconfig"
 	"github.com/sensu/sensu-go/backend/store"
 )
 
@@ -25,9 +26,9 @@ type silencedController interface {
 }
 
 // NewSilencedRouter instantiates new router for controlling user resources
-func NewSilencedRouter(store store.Store) *SilencedRouter {
+func NewSilencedRouter(store store.Store, clusterConfig *clusterconfig.Watcher) *SilencedRouter {
 	return &SilencedRouter{
-		controller: actions.NewSilencedController(store),
+		controller: actions.NewSilencedController(store, clusterConfig),
 		handlers: handlers.Handlers{
 			Resource: &corev2.Silenced{},
 			Store:    store,
@@ -40,14 +41,15 @@ func (r *SilencedRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/namespaces/{namespace}/{resource:silenced}",
+		Resource:   &corev2.Silenced{},
 	}
 
 	routes.Del(r.handlers.DeleteResource)
 	routes.Get(r.handlers.GetResource)
 	routes.Post(r.create)
 	routes.Put(r.createOrReplace)
-	routes.List(r.handlers.ListResources, corev2.SilencedFields)
-	routes.ListAllNamespaces(r.handlers.ListResources, "/{resource:silenced}", corev2.SilencedFields)
+	routes.Router.HandleFunc(routes.PathPrefix, r.listResources).Methods(http.MethodGet)
+	routes.Router.HandleFunc("/{resource:silenced}", r.listResources).Methods(http.MethodGet)
 
 	// Custom routes for listing by subscription and checks for a specific
 	// namespace, in addition to all namespaces for checks.
@@ -84,7 +86,39 @@ func (r *SilencedRouter) createOrReplace(req *http.Request) (interface{}, error)
 	return nil, err
 }
 
+// listResources lists the silenced entries with pagination, unless they are
+// filtered by creator or expiration, in which case they are listed from the
+// silenced store, which reports their remaining time to live.
+func (r *SilencedRouter) listResources(w http.ResponseWriter, req *http.Request) {
+	filter, err := corev2.NewSilencedFilter(req.URL.Query())
+	if err != nil {
+		WriteError(w, actions.NewError(actions.InvalidArgument, err))
+		return
+	}
+	if *filter == (corev2.SilencedFilter{}) {
+		listerHandler(r.handlers.ListResources, corev2.SilencedFields)(w, req)
+		return
+	}
+	listHandler(r.list)(w, req)
+}
+
 func (r *SilencedRouter) list(w http.ResponseWriter, req *http.Request) (interface{}, error) {
+	filter, err := corev2.NewSilencedFilter(req.URL.Query())
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+
 	params := mux.Vars(req)
-	return r.controller.List(req.Context(), params["subscription"], params["check"])
+	entries, err := r.controller.List(req.Context(), params["subscription"], params["check"])
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*corev2.Silenced, 0, len(entries))
+	for _, entry := range entries {
+		if filter.Matches(entry) {
+			results = append(results, entry)
+		}
+	}
+	return results, nil
 }
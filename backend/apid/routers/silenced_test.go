@@ -3,6 +3,7 @@ package routers
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -18,7 +19,7 @@ import (
 func TestSilencedRouter(t *testing.T) {
 	// Setup the router
 	s := &mockstore.MockStore{}
-	router := NewSilencedRouter(s)
+	router := NewSilencedRouter(s, nil)
 	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
 	router.Mount(parentRouter)
 
@@ -148,6 +149,23 @@ func TestSilencedRouterCustomRoutes(t *testing.T) {
 			},
 			wantStatusCode: http.StatusCreated,
 		},
+		{
+			name:           "it returns 400 if the silenced entries filter is invalid",
+			method:         http.MethodGet,
+			path:           empty.URIPath() + "?expired=maybe",
+			wantStatusCode: http.StatusBadRequest,
+		},
+		{
+			name:   "it lists the silenced entries matching the filter",
+			method: http.MethodGet,
+			path:   empty.URIPath() + "?creator=admin&expires_within=1h",
+			controllerFunc: func(c *mockSilencedController) {
+				c.On("List", mock.Anything, "", "").
+					Return([]*corev2.Silenced{fixture}, nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -183,3 +201,36 @@ func TestSilencedRouterCustomRoutes(t *testing.T) {
 		})
 	}
 }
+
+func TestSilencedRouterListFilter(t *testing.T) {
+	stale := corev2.FixtureSilenced("linux:check-cpu")
+	stale.Creator = "admin"
+	stale.Expire = -1
+	expiring := corev2.FixtureSilenced("linux:check-mem")
+	expiring.Creator = "admin"
+	expiring.Expire = 60
+	other := corev2.FixtureSilenced("linux:check-disk")
+	other.Creator = "bob"
+	other.Expire = 60
+
+	controller := &mockSilencedController{}
+	controller.On("List", mock.Anything, "linux", "").Return([]*corev2.Silenced{stale, expiring, other}, nil)
+	router := SilencedRouter{controller: controller}
+	parentRouter := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+	router.Mount(parentRouter)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/core/v2/namespaces/default/silenced/subscriptions/linux?creator=admin&expires_within=5m", nil)
+	w := httptest.NewRecorder()
+	parentRouter.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("StatusCode = %v, want %v: %s", w.Code, http.StatusOK, w.Body.String())
+	}
+	var results []*corev2.Silenced
+	if err := json.Unmarshal(w.Body.Bytes(), &results); err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Name != expiring.Name {
+		t.Errorf("got %v, want only %s", results, expiring.Name)
+	}
+}
@@ -0,0 +1,98 @@
+package routers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/actions"
+	"github.com/sensu/sensu-go/backend/apid/handlers"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// SubscriptionLister represents the needs of the SubscriptionsRouter
+type SubscriptionLister interface {
+	Subscriptions(ctx context.Context, namespace string) ([]corev2.SubscriptionMembers, error)
+}
+
+// SubscriptionsRouter handles requests for /subscriptions, which lists the
+// subscriptions of a namespace with their connected agent entities, and
+// manages the subscriptions defined as resources to document them
+type SubscriptionsRouter struct {
+	lister   SubscriptionLister
+	handlers handlers.Handlers
+}
+
+// NewSubscriptionsRouter instantiates a new router for subscriptions
+func NewSubscriptionsRouter(lister SubscriptionLister, store store.ResourceStore) *SubscriptionsRouter {
+	return &SubscriptionsRouter{
+		lister: lister,
+		handlers: handlers.Handlers{
+			Resource: &corev2.Subscription{},
+			Store:    store,
+		},
+	}
+}
+
+// Mount the SubscriptionsRouter to a parent Router
+func (r *SubscriptionsRouter) Mount(parent *mux.Router) {
+	routes := ResourceRoute{
+		Router:     parent,
+		PathPrefix: "/namespaces/{namespace}/{resource:subscriptions}",
+		Resource:   &corev2.Subscription{},
+	}
+
+	routes.Path("", r.list).Methods(http.MethodGet)
+	routes.Del(r.handlers.DeleteResource)
+	routes.Get(r.handlers.GetResource)
+	routes.Post(r.handlers.CreateResource)
+	routes.Put(r.handlers.CreateOrUpdateResource)
+}
+
+// list lists the subscriptions with connected entities along with the
+// subscriptions defined as resources, which may not have any yet.
+func (r *SubscriptionsRouter) list(req *http.Request) (interface{}, error) {
+	namespace, err := url.PathUnescape(mux.Vars(req)["namespace"])
+	if err != nil {
+		return nil, actions.NewError(actions.InvalidArgument, err)
+	}
+	subscriptions, err := r.lister.Subscriptions(req.Context(), namespace)
+	if err != nil {
+		return nil, actions.NewError(actions.InternalErr, err)
+	}
+
+	ctx := store.NamespaceContext(req.Context(), namespace)
+	resources, err := r.handlers.ListResources(ctx, &store.SelectionPredicate{})
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[string]int, len(subscriptions))
+	for i, s := range subscriptions {
+		index[s.Subscription] = i
+	}
+	for _, resource := range resources {
+		subscription, ok := resource.(*corev2.Subscription)
+		if !ok {
+			continue
+		}
+		i, ok := index[subscription.Name]
+		if !ok {
+			i = len(subscriptions)
+			subscriptions = append(subscriptions, corev2.SubscriptionMembers{
+				Subscription: subscription.Name,
+				Entities:     []string{},
+			})
+		}
+		subscriptions[i].Description = subscription.Description
+		subscriptions[i].Labels = subscription.Labels
+	}
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].Subscription < subscriptions[j].Subscription
+	})
+
+	return subscriptions, nil
+}
@@ -0,0 +1,120 @@
+package routers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSubscriptionLister struct {
+	subscriptions map[string][]corev2.SubscriptionMembers
+	err           error
+}
+
+func (f fakeSubscriptionLister) Subscriptions(ctx context.Context, namespace string) ([]corev2.SubscriptionMembers, error) {
+	return f.subscriptions[namespace], f.err
+}
+
+func TestSubscriptionsRouter(t *testing.T) {
+	subscriptions := map[string][]corev2.SubscriptionMembers{
+		"default": {
+			{Subscription: "linux", Entities: []string{"agent1", "agent2"}},
+		},
+		"acme": {
+			{Subscription: "windows", Entities: []string{"agent3"}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		lister         SubscriptionLister
+		wantStatusCode int
+	}{
+		{"subscriptions listed", fakeSubscriptionLister{subscriptions: subscriptions}, http.StatusOK},
+		{"lister error", fakeSubscriptionLister{err: errors.New("error")}, http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &mockstore.MockStore{}
+			s.On("ListResources", mock.Anything, corev2.SubscriptionsResource, mock.Anything, mock.Anything).Return(nil)
+
+			parent := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+			NewSubscriptionsRouter(tt.lister, s).Mount(parent)
+
+			req := httptest.NewRequest(http.MethodGet, corev2.URLPrefix+"/namespaces/default/subscriptions", nil)
+			w := httptest.NewRecorder()
+			parent.ServeHTTP(w, req)
+			require.Equal(t, tt.wantStatusCode, w.Code)
+			if tt.wantStatusCode != http.StatusOK {
+				return
+			}
+
+			var got []corev2.SubscriptionMembers
+			require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+			assert.Equal(t, subscriptions["default"], got)
+		})
+	}
+}
+
+func TestSubscriptionsRouterListDocumented(t *testing.T) {
+	lister := fakeSubscriptionLister{subscriptions: map[string][]corev2.SubscriptionMembers{
+		"default": {
+			{Subscription: "linux", Entities: []string{"agent1"}},
+		},
+	}}
+
+	linux := corev2.FixtureSubscription("linux")
+	linux.Labels = map[string]string{"team": "ops"}
+	database := corev2.FixtureSubscription("database")
+
+	s := &mockstore.MockStore{}
+	s.On("ListResources", mock.Anything, corev2.SubscriptionsResource, mock.AnythingOfType("*[]*v2.Subscription"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			list := args.Get(2).(*[]*corev2.Subscription)
+			*list = []*corev2.Subscription{linux, database}
+		}).Return(nil)
+
+	parent := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+	NewSubscriptionsRouter(lister, s).Mount(parent)
+
+	req := httptest.NewRequest(http.MethodGet, corev2.URLPrefix+"/namespaces/default/subscriptions", nil)
+	w := httptest.NewRecorder()
+	parent.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var got []corev2.SubscriptionMembers
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&got))
+	want := []corev2.SubscriptionMembers{
+		{Subscription: "database", Entities: []string{}, Description: database.Description},
+		{Subscription: "linux", Entities: []string{"agent1"}, Description: linux.Description, Labels: linux.Labels},
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestSubscriptionsRouterResources(t *testing.T) {
+	s := &mockstore.MockStore{}
+	parent := mux.NewRouter().PathPrefix(corev2.URLPrefix).Subrouter()
+	NewSubscriptionsRouter(fakeSubscriptionLister{}, s).Mount(parent)
+
+	empty := &corev2.Subscription{}
+	fixture := corev2.FixtureSubscription("foo")
+
+	tests := []routerTestCase{}
+	tests = append(tests, getTestCases(fixture)...)
+	tests = append(tests, createTestCases(empty)...)
+	tests = append(tests, updateTestCases(fixture)...)
+	tests = append(tests, deleteTestCases(fixture)...)
+	for _, tt := range tests {
+		run(t, tt, parent, s)
+	}
+}
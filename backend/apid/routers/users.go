@@ -23,6 +23,7 @@ type UserController interface {
 	AddGroup(ctx context.Context, name string, group string) error
 	RemoveGroup(ctx context.Context, name string, group string) error
 	RemoveAllGroups(ctx context.Context, name string) error
+	ResetPasswordToken(ctx context.Context, name string) (*corev2.PasswordResetToken, error)
 }
 
 // UsersRouter handles requests for /users
@@ -42,6 +43,7 @@ func (r *UsersRouter) Mount(parent *mux.Router) {
 	routes := ResourceRoute{
 		Router:     parent,
 		PathPrefix: "/{resource:users}",
+		Resource:   &corev2.User{},
 	}
 	routes.List(r.controller.List, corev2.UserFields)
 	routes.Get(r.get)
@@ -54,6 +56,7 @@ func (r *UsersRouter) Mount(parent *mux.Router) {
 	routes.Path("{id}/{subresource:groups}", r.removeAllGroups).Methods(http.MethodDelete)
 	routes.Path("{id}/{subresource:groups}/{user-group-name}", r.addGroup).Methods(http.MethodPut)
 	routes.Path("{id}/{subresource:groups}/{user-group-name}", r.removeGroup).Methods(http.MethodDelete)
+	routes.Path("{id}/{subresource:reset_password}", r.resetPasswordToken).Methods(http.MethodPost)
 
 	// TODO: Remove?
 	routes.Path("{id}/{subresource:password}", r.updatePassword).Methods(http.MethodPut)
@@ -146,6 +149,16 @@ func (r *UsersRouter) updatePassword(req *http.Request) (interface{}, error) {
 	return nil, err
 }
 
+func (r *UsersRouter) resetPasswordToken(req *http.Request) (interface{}, error) {
+	params := mux.Vars(req)
+	id, err := url.PathUnescape(params["id"])
+	if err != nil {
+		return nil, err
+	}
+
+	return r.controller.ResetPasswordToken(req.Context(), id)
+}
+
 func (r *UsersRouter) addGroup(req *http.Request) (interface{}, error) {
 	params := mux.Vars(req)
 	id, err := url.PathUnescape(params["id"])
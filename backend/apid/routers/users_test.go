@@ -60,6 +60,14 @@ func (m *mockUserController) RemoveAllGroups(ctx context.Context, name string) e
 	return m.Called(ctx, name).Error(0)
 }
 
+func (m *mockUserController) ResetPasswordToken(ctx context.Context, name string) (*corev2.PasswordResetToken, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*corev2.PasswordResetToken), args.Error(1)
+}
+
 func TestUsersRouter(t *testing.T) {
 	type controllerFunc func(*mockUserController)
 
@@ -167,6 +175,28 @@ func TestUsersRouter(t *testing.T) {
 			},
 			wantStatusCode: http.StatusCreated,
 		},
+		{
+			name:   "it returns 404 when resetting the password of a missing user",
+			method: http.MethodPost,
+			path:   fixture.URIPath() + "/reset_password",
+			controllerFunc: func(c *mockUserController) {
+				c.On("ResetPasswordToken", mock.Anything, "foo").
+					Return(nil, actions.NewErrorf(actions.NotFound)).
+					Once()
+			},
+			wantStatusCode: http.StatusNotFound,
+		},
+		{
+			name:   "it returns 200 with a password reset token",
+			method: http.MethodPost,
+			path:   fixture.URIPath() + "/reset_password",
+			controllerFunc: func(c *mockUserController) {
+				c.On("ResetPasswordToken", mock.Anything, "foo").
+					Return(&corev2.PasswordResetToken{Username: "foo", Token: "token"}, nil).
+					Once()
+			},
+			wantStatusCode: http.StatusOK,
+		},
 		{
 			name:           "it returns 400 if the payload to update is not decodable",
 			method:         http.MethodPut,
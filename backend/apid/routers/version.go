@@ -7,6 +7,7 @@ import (
 
 	"github.com/gorilla/mux"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/apid/openapi"
 )
 
 // VersionController represents the controller needs of the VersionRouter
@@ -28,7 +29,10 @@ func NewVersionRouter(ctrl VersionController) *VersionRouter {
 
 // Mount the VersionRouter to a parent Router
 func (r *VersionRouter) Mount(parent *mux.Router) {
-	parent.HandleFunc("/version", r.version).Methods(http.MethodGet)
+	openapi.Describe(parent.HandleFunc("/version", r.version).Methods(http.MethodGet), openapi.RouteMeta{
+		Summary:  "Get the version of the backend components",
+		Response: &corev2.Version{},
+	})
 }
 
 func (r *VersionRouter) version(w http.ResponseWriter, _ *http.Request) {
@@ -1,8 +1,15 @@
 package jwt
 
 import (
+	"bytes"
 	"context"
+	"crypto"
 	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -25,12 +32,21 @@ const (
 	IssuerURLKey key = iota
 )
 
+// minSecretSize is the minimum size in bytes of the secrets provided to sign
+// the tokens with HMAC.
+const minSecretSize = 32
+
 var (
 	defaultExpiration = time.Minute * 5
 	secret            []byte
-	privateKey        *ecdsa.PrivateKey
-	publicKey         *ecdsa.PublicKey
+	privateKey        crypto.PrivateKey
+	publicKey         crypto.PublicKey
 	signingMethod     jwt.SigningMethod
+
+	// verificationKeys holds, by key id, the keys that no longer sign tokens
+	// but still verify them, so that the keys can be rotated without
+	// invalidating the tokens already issued.
+	verificationKeys = map[string]interface{}{}
 )
 
 func init() {
@@ -60,21 +76,38 @@ func AccessToken(claims *corev2.Claims) (*jwt.Token, string, error) {
 	claims.ExpiresAt = time.Now().Add(defaultExpiration).Unix()
 
 	token := jwt.NewWithClaims(signingMethod, claims)
+	tokenString, err := signToken(token)
+	if err != nil {
+		return nil, "", err
+	}
+	return token, tokenString, nil
+}
 
-	// Determine which key to use to sign the token
-	var key interface{}
+// signToken signs the token with the current key. The id of the key is set in
+// the kid header of the token, so that the key verifying it can be found
+// among the keys of the backends once it has been rotated.
+func signToken(token *jwt.Token) (string, error) {
 	if signingMethod == jwt.SigningMethodHS256 {
-		key = secret
-	} else {
-		key = privateKey
+		token.Header["kid"] = keyID(secret)
+		return token.SignedString(secret)
 	}
+	token.Header["kid"] = keyID(publicKey)
+	return token.SignedString(privateKey)
+}
 
-	// Sign the token
-	tokenString, err := token.SignedString(key)
-	if err != nil {
-		return nil, "", err
+// keyID returns the id of an HMAC secret or a public key, derived from the key
+// so that every backend configured with the same key agrees on its id.
+func keyID(key interface{}) string {
+	data, ok := key.([]byte)
+	if !ok {
+		der, err := x509.MarshalPKIXPublicKey(key)
+		if err != nil {
+			return ""
+		}
+		data = der
 	}
-	return token, tokenString, nil
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
 }
 
 // NewClaims creates new claim based on username
@@ -140,47 +173,127 @@ func ExtractBearerToken(r *http.Request) string {
 	return tokenString
 }
 
-// LoadKeyPair loads a private and public key pair from files
+// LoadKeyPair loads a private and public key pair from files. ECDSA and RSA
+// keys are supported.
 func LoadKeyPair(privatePath, publicPath string) error {
 	if privatePath != "" && publicPath == "" {
 		return errors.New("a public key is required when specifying a private key")
 	}
 
+	var public crypto.PublicKey
 	if publicPath != "" {
 		publicBytes, err := ioutil.ReadFile(publicPath)
 		if err != nil {
 			return fmt.Errorf("unable to read the public key file: %s", err)
 		}
-		if publicKey, err = jwt.ParseECPublicKeyFromPEM(publicBytes); err != nil {
-			return fmt.Errorf("unable to parse the ECDSA public key: %v", err)
+		if public, err = parsePublicKey(publicBytes); err != nil {
+			return err
 		}
 	}
 
-	if privatePath != "" {
-		privateBytes, err := ioutil.ReadFile(privatePath)
-		if err != nil {
-			return fmt.Errorf("unable to read the private key file: %s", err)
-		}
-		if privateKey, err = jwt.ParseECPrivateKeyFromPEM(privateBytes); err != nil {
-			return fmt.Errorf("unable to parse the ECDSA private key: %v", err)
-		}
+	if privatePath == "" {
+		publicKey = public
+		return nil
+	}
 
-		// Determine the signing method to use
-		switch bitSize := publicKey.Curve.Params().BitSize; bitSize {
+	privateBytes, err := ioutil.ReadFile(privatePath)
+	if err != nil {
+		return fmt.Errorf("unable to read the private key file: %s", err)
+	}
+
+	// Determine the signing method to use
+	var private crypto.PrivateKey
+	var method jwt.SigningMethod
+	if ecKey, err := jwt.ParseECPrivateKeyFromPEM(privateBytes); err == nil {
+		if keyID(&ecKey.PublicKey) != keyID(public) {
+			return errors.New("the private key does not match the public key")
+		}
+		switch bitSize := ecKey.Curve.Params().BitSize; bitSize {
 		case 256:
-			signingMethod = jwt.SigningMethodES256
+			method = jwt.SigningMethodES256
 		case 384:
-			signingMethod = jwt.SigningMethodES384
+			method = jwt.SigningMethodES384
 		case 521:
-			signingMethod = jwt.SigningMethodES512
+			method = jwt.SigningMethodES512
 		default:
-			return fmt.Errorf("could not determine a signing method for curve %s", publicKey.Curve.Params().Name)
+			return fmt.Errorf("could not determine a signing method for curve %s", ecKey.Curve.Params().Name)
+		}
+		private = ecKey
+	} else if rsaKey, err := jwt.ParseRSAPrivateKeyFromPEM(privateBytes); err == nil {
+		if keyID(&rsaKey.PublicKey) != keyID(public) {
+			return errors.New("the private key does not match the public key")
 		}
+		method = jwt.SigningMethodRS256
+		private = rsaKey
+	} else {
+		return errors.New("unable to parse the private key, expected an ECDSA or RSA key")
+	}
+
+	privateKey, publicKey, signingMethod = private, public, method
+	return nil
+}
+
+// LoadSecret loads the secret signing the tokens with HMAC from a file holding
+// it base64 encoded. It replaces the secret stored in etcd by InitSecret, and
+// must be the same on every backend of the cluster.
+func LoadSecret(path string) error {
+	s, err := readSecret(path)
+	if err != nil {
+		return err
 	}
+	secret = s
+	return nil
+}
 
+// LoadVerificationKeys loads the keys which verify the tokens signed before a
+// rotation, from files holding either a PEM encoded public key or a base64
+// encoded secret.
+func LoadVerificationKeys(paths ...string) error {
+	keys := make(map[string]interface{}, len(paths))
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to read the verification key file: %s", err)
+		}
+		var key interface{}
+		if bytes.Contains(data, []byte("-----BEGIN")) {
+			if key, err = parsePublicKey(data); err != nil {
+				return fmt.Errorf("%s: %s", path, err)
+			}
+		} else if key, err = readSecret(path); err != nil {
+			return err
+		}
+		keys[keyID(key)] = key
+	}
+	verificationKeys = keys
 	return nil
 }
 
+func readSecret(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the secret file: %s", err)
+	}
+	s, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	if err != nil {
+		return nil, fmt.Errorf("secret file %s is not base64 encoded: %s", path, err)
+	}
+	if len(s) < minSecretSize {
+		return nil, fmt.Errorf("secret file %s must hold at least %d bytes", path, minSecretSize)
+	}
+	return s, nil
+}
+
+func parsePublicKey(data []byte) (crypto.PublicKey, error) {
+	if key, err := jwt.ParseECPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	if key, err := jwt.ParseRSAPublicKeyFromPEM(data); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("unable to parse the public key, expected an ECDSA or RSA key")
+}
+
 // InitSecret initializes and retrieves the secret for our signing tokens
 func InitSecret(store store.Store) error {
 	// Retrieve the secret
@@ -197,37 +310,60 @@ func InitSecret(store store.Store) error {
 // parseToken takes a signed token and parse it to verify its integrity
 func parseToken(tokenString string) (*jwt.Token, error) {
 	t, err := jwt.ParseWithClaims(tokenString, &types.Claims{}, func(token *jwt.Token) (interface{}, error) {
-		if token.Header["alg"] == jwt.SigningMethodHS256.Alg() {
-			// Validate the signing method used
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		key, err := tokenKey(token)
+		if err != nil {
+			return nil, err
+		}
+
+		// Validate that the algorithm used matches the key, so that a public
+		// key can't be used as an HMAC secret
+		alg := token.Method.Alg()
+		switch key.(type) {
+		case []byte:
+			if alg == jwt.SigningMethodHS256.Alg() {
+				return key, nil
 			}
+		case *ecdsa.PublicKey:
+			switch alg {
+			case jwt.SigningMethodES256.Alg(), jwt.SigningMethodES384.Alg(), jwt.SigningMethodES512.Alg():
+				return key, nil
+			}
+		case *rsa.PublicKey:
+			switch alg {
+			case jwt.SigningMethodRS256.Alg(), jwt.SigningMethodRS384.Alg(), jwt.SigningMethodRS512.Alg():
+				return key, nil
+			}
+		}
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	})
+	return t, err
+}
 
-			// Use the secret to verify the signature
+// tokenKey returns the key verifying the token: the key identified by its kid
+// header, or the current key for the tokens issued without it.
+func tokenKey(token *jwt.Token) (interface{}, error) {
+	if kid, ok := token.Header["kid"].(string); ok && kid != "" {
+		if len(secret) > 0 && kid == keyID(secret) {
 			return secret, nil
 		}
-
-		// Validate that we do have a public key available
-		if publicKey == nil {
-			return nil, errors.New("no public key available to validate the signature")
+		if publicKey != nil && kid == keyID(publicKey) {
+			return publicKey, nil
 		}
-
-		// Validate the signing method used
-		if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		if key, ok := verificationKeys[kid]; ok {
+			return key, nil
 		}
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
 
-		// Validate the algorith used
-		switch alg := token.Header["alg"]; alg {
-		case jwt.SigningMethodES256.Alg(), jwt.SigningMethodES384.Alg(), jwt.SigningMethodES512.Alg():
-		default:
-			return nil, fmt.Errorf("unexpected algorith %q found in token header", alg)
-		}
+	if token.Header["alg"] == jwt.SigningMethodHS256.Alg() {
+		return secret, nil
+	}
 
-		// Use the public key to verify the signature
-		return publicKey, nil
-	})
-	return t, err
+	// Validate that we do have a public key available
+	if publicKey == nil {
+		return nil, errors.New("no public key available to validate the signature")
+	}
+	return publicKey, nil
 }
 
 // RefreshToken returns a refresh token for a specific user
@@ -240,17 +376,7 @@ func RefreshToken(claims *corev2.Claims) (*jwt.Token, string, error) {
 	claims.Id = jti
 
 	token := jwt.NewWithClaims(signingMethod, claims)
-
-	// Determine which key to use to sign the token
-	var key interface{}
-	if signingMethod == jwt.SigningMethodHS256 {
-		key = secret
-	} else {
-		key = privateKey
-	}
-
-	// Sign the token
-	tokenString, err := token.SignedString(key)
+	tokenString, err := signToken(token)
 	if err != nil {
 		return nil, "", err
 	}
@@ -306,3 +432,71 @@ func ValidateToken(tokenString string) (*jwt.Token, error) {
 	}
 	return nil, err
 }
+
+// PasswordResetClaims are the claims of the tokens with which users reset
+// their password.
+type PasswordResetClaims struct {
+	jwt.StandardClaims
+
+	// Fingerprint identifies the password of the user when the token was
+	// issued, so that the token can't be used once the password is reset.
+	Fingerprint string `json:"fingerprint"`
+}
+
+// ValidFor returns whether the token can reset the password of user.
+func (c *PasswordResetClaims) ValidFor(user *corev2.User) bool {
+	return c.Subject == user.Username && hmac.Equal([]byte(c.Fingerprint), []byte(passwordFingerprint(user)))
+}
+
+// PasswordResetToken issues a token allowing to reset the password of user
+// once, until it expires.
+func PasswordResetToken(user *corev2.User, expiration time.Duration) (string, int64, error) {
+	jti, err := GenJTI()
+	if err != nil {
+		return "", 0, err
+	}
+	expiresAt := time.Now().Add(expiration).Unix()
+	claims := &PasswordResetClaims{
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: expiresAt,
+			Id:        jti,
+			Subject:   user.Username,
+		},
+		Fingerprint: passwordFingerprint(user),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	tokenString, err := token.SignedString(passwordResetKey())
+	if err != nil {
+		return "", 0, err
+	}
+	return tokenString, expiresAt, nil
+}
+
+// ParsePasswordResetToken verifies a token issued by PasswordResetToken and
+// returns its claims.
+func ParsePasswordResetToken(tokenString string) (*PasswordResetClaims, error) {
+	claims := &PasswordResetClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return passwordResetKey(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// passwordResetKey derives the key signing the password reset tokens from the
+// secret, so that they can't be used as access tokens, and vice versa.
+func passwordResetKey() []byte {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write([]byte("password-reset"))
+	return mac.Sum(nil)
+}
+
+func passwordFingerprint(user *corev2.User) string {
+	sum := sha256.Sum256([]byte(user.Password))
+	return hex.EncodeToString(sum[:8])
+}
@@ -1,8 +1,12 @@
 package jwt
 
 import (
+	"encoding/base64"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 
 	jwt "github.com/dgrijalva/jwt-go"
@@ -12,6 +16,7 @@ import (
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var testTime = crock.NewTime(time.Now())
@@ -209,6 +214,12 @@ func TestLoadKeyPair(t *testing.T) {
 			publicPath:        "testdata/ecdsa-p521-public.pem",
 			wantSigningMethod: jwt.SigningMethodES512,
 		},
+		{
+			name:              "valid RSA key pair",
+			privatePath:       "testdata/rsa.pem",
+			publicPath:        "testdata/rsa.pub",
+			wantSigningMethod: jwt.SigningMethodRS256,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -224,6 +235,67 @@ func TestLoadKeyPair(t *testing.T) {
 	}
 }
 
+func writeSecretFile(t *testing.T, dir, name, secret string) string {
+	path := filepath.Join(dir, name)
+	encoded := base64.StdEncoding.EncodeToString([]byte(secret))
+	require.NoError(t, ioutil.WriteFile(path, []byte(encoded+"\n"), 0600))
+	return path
+}
+
+func TestKeyRotation(t *testing.T) {
+	defer func() {
+		signingMethod = jwt.SigningMethodHS256
+		privateKey = nil
+		publicKey = nil
+		verificationKeys = map[string]interface{}{}
+	}()
+
+	dir, err := ioutil.TempDir("", "jwt")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	previousSecret := writeSecretFile(t, dir, "previous", "a previous secret of at least 32 bytes")
+	currentSecret := writeSecretFile(t, dir, "current", "the current secret of at least 32 bytes")
+
+	newToken := func() string {
+		_, tokenString, err := AccessToken(&v2.Claims{StandardClaims: jwt.StandardClaims{Subject: "foo"}})
+		require.NoError(t, err)
+		return tokenString
+	}
+
+	require.NoError(t, LoadSecret(previousSecret))
+	hmacToken := newToken()
+
+	// Rotate the secret, the previous one only verifies the tokens
+	require.NoError(t, LoadSecret(currentSecret))
+	_, err = ValidateToken(hmacToken)
+	assert.Error(t, err)
+	require.NoError(t, LoadVerificationKeys(previousSecret))
+	_, err = ValidateToken(hmacToken)
+	assert.NoError(t, err)
+	_, err = ValidateToken(newToken())
+	assert.NoError(t, err)
+
+	// Switch to an RSA key pair, then rotate it for an ECDSA one
+	require.NoError(t, LoadKeyPair("testdata/rsa.pem", "testdata/rsa.pub"))
+	rsaToken := newToken()
+	_, err = ValidateToken(rsaToken)
+	assert.NoError(t, err)
+
+	require.NoError(t, LoadKeyPair("testdata/ecdsa-p521-private.pem", "testdata/ecdsa-p521-public.pem"))
+	_, err = ValidateToken(rsaToken)
+	assert.Error(t, err)
+	require.NoError(t, LoadVerificationKeys("testdata/rsa.pub", previousSecret))
+	_, err = ValidateToken(rsaToken)
+	assert.NoError(t, err)
+	_, err = ValidateToken(hmacToken)
+	assert.NoError(t, err)
+	_, err = ValidateToken(newToken())
+	assert.NoError(t, err)
+
+	// Secrets too short are rejected
+	assert.Error(t, LoadSecret(writeSecretFile(t, dir, "short", "short")))
+}
+
 func TestParseToken(t *testing.T) {
 	privateKey = nil
 	publicKey = nil
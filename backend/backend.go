@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"runtime/debug"
+	"strings"
 	"sync"
 	"time"
 
@@ -19,30 +20,42 @@ import (
 	"github.com/sensu/sensu-go/backend/apid"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 	"github.com/sensu/sensu-go/backend/apid/graphql"
+	"github.com/sensu/sensu-go/backend/apid/middlewares"
 	"github.com/sensu/sensu-go/backend/apid/routers"
 	"github.com/sensu/sensu-go/backend/authentication"
 	"github.com/sensu/sensu-go/backend/authentication/jwt"
 	"github.com/sensu/sensu-go/backend/authentication/providers/basic"
 	"github.com/sensu/sensu-go/backend/authorization/rbac"
+	"github.com/sensu/sensu-go/backend/clusterconfig"
 	"github.com/sensu/sensu-go/backend/daemon"
 	"github.com/sensu/sensu-go/backend/dashboardd"
 	"github.com/sensu/sensu-go/backend/etcd"
+	"github.com/sensu/sensu-go/backend/eventarchive"
 	"github.com/sensu/sensu-go/backend/eventd"
+	"github.com/sensu/sensu-go/backend/eventlogd"
 	"github.com/sensu/sensu-go/backend/keepalived"
+	"github.com/sensu/sensu-go/backend/lifecycled"
 	"github.com/sensu/sensu-go/backend/liveness"
 	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/pipelined"
 	"github.com/sensu/sensu-go/backend/queue"
+	"github.com/sensu/sensu-go/backend/replicatord"
 	"github.com/sensu/sensu-go/backend/ringv2"
 	"github.com/sensu/sensu-go/backend/schedulerd"
 	"github.com/sensu/sensu-go/backend/secrets"
 	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/encryption"
 	etcdstore "github.com/sensu/sensu-go/backend/store/etcd"
 	"github.com/sensu/sensu-go/backend/tessend"
+	"github.com/sensu/sensu-go/command"
 	"github.com/sensu/sensu-go/rpc"
 	"github.com/sensu/sensu-go/system"
+	sensutransport "github.com/sensu/sensu-go/transport"
+	"github.com/sensu/sensu-go/util/httpclient"
 	"github.com/sensu/sensu-go/util/retry"
+	"github.com/sensu/sensu-go/util/systemd"
 	"github.com/spf13/viper"
+	"golang.org/x/time/rate"
 	"google.golang.org/grpc"
 )
 
@@ -59,7 +72,7 @@ func (e ErrStartup) Error() string {
 // and coordinating the daemons
 type Backend struct {
 	Client                 *clientv3.Client
-	Daemons                []daemon.Daemon
+	Daemons                []daemon.Spec
 	Etcd                   *etcd.Etcd
 	Store                  store.Store
 	EventStore             EventStoreUpdater
@@ -176,8 +189,8 @@ func newClient(ctx context.Context, config *Config, backend *Backend) (*clientv3
 
 // Initialize instantiates a Backend struct with the provided config, by
 // configuring etcd and establishing a list of daemons, which constitute our
-// backend. The daemons will later be started according to their position in the
-// b.Daemons list, and stopped in reverse order
+// backend. The daemons will later be started by a supervisor in the order of
+// their dependencies, and stopped in reverse order
 func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	var err error
 	// Initialize a Backend struct
@@ -191,14 +204,36 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		return nil, err
 	}
 
+	// Encrypt the events and entities written to the store, if configured
+	keyWrapper := config.StoreEncryptionKeyWrapper
+	if keyWrapper == nil && config.StoreEncryptionKeyFile != "" {
+		keyFiles := append([]string{config.StoreEncryptionKeyFile}, config.StoreEncryptionPreviousKeyFiles...)
+		keyWrapper, err = encryption.NewLocalKeyWrapperFromFiles(keyFiles...)
+		if err != nil {
+			return nil, fmt.Errorf("error loading the store encryption keys: %s", err)
+		}
+	}
+	var valueCipher *encryption.Cipher
+	if keyWrapper != nil {
+		valueCipher, err = encryption.NewCipher(keyWrapper)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing the store encryption: %s", err)
+		}
+	}
+
 	// Create the store, which lives on top of etcd
-	stor := etcdstore.NewStore(b.Client, config.EtcdName)
+	stor := etcdstore.NewEncryptedStore(b.Client, config.EtcdName, valueCipher)
 	b.Store = stor
 
 	if _, err := stor.GetClusterID(b.runCtx); err != nil {
 		return nil, err
 	}
 
+	// Watch the cluster configuration, so the daemons follow its changes
+	// without being restarted
+	clusterConfig := clusterconfig.NewWatcher(stor)
+	clusterConfig.Start(b.runCtx)
+
 	// Initialize the JWT secret. This method is idempotent and needs to be ran
 	// at every startup so the JWT signatures remain valid
 	if err := jwt.InitSecret(b.Store); err != nil {
@@ -208,11 +243,31 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	eventStoreProxy := store.NewEventStoreProxy(stor)
 	b.EventStore = eventStoreProxy
 
+	// Archive the events once they are deleted, if an event archive is
+	// configured
+	var eventStore store.EventStore = eventStoreProxy
+	if config.EventArchiveURL != "" {
+		archiver, err := eventarchive.New(config.EventArchiveURL, config.EventArchiveRegion)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing the event archive: %s", err)
+		}
+		writer := eventarchive.NewWriter(archiver)
+		writer.Start(b.runCtx)
+		eventStore = &eventarchive.EventStore{EventStore: eventStoreProxy, Writer: writer}
+	}
+
 	logger.Debug("Registering backend...")
 
 	backendID := etcd.NewBackendIDGetter(b.runCtx, b.Client)
 	logger.Debug("Done registering backend.")
-	b.Daemons = append(b.Daemons, backendID)
+
+	// The backend ID getter and the bus are shared by components created only
+	// once, so they can't be restarted
+	b.Daemons = append(b.Daemons, daemon.Spec{
+		Name:     backendID.Name(),
+		New:      existingDaemon(backendID),
+		Critical: true,
+	})
 
 	// Initialize an etcd getter
 	queueGetter := queue.EtcdGetter{Client: b.Client, BackendIDGetter: backendID}
@@ -222,7 +277,11 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	if err != nil {
 		return nil, fmt.Errorf("error initializing %s: %s", bus.Name(), err)
 	}
-	b.Daemons = append(b.Daemons, bus)
+	b.Daemons = append(b.Daemons, daemon.Spec{
+		Name:     bus.Name(),
+		New:      existingDaemon(bus),
+		Critical: true,
+	})
 
 	// Initialize asset manager
 	backendEntity := b.getBackendEntity(config)
@@ -233,61 +292,110 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		return nil, fmt.Errorf("error initializing asset manager: %s", err)
 	}
 
-	// Initialize the secrets provider manager
+	// Initialize the secrets provider manager. The secrets of the checks are
+	// only sent to the agents over TLS connections
 	b.SecretsProviderManager = secrets.NewProviderManager()
+	b.SecretsProviderManager.Getter = secrets.ReferenceGetter{}
+	b.SecretsProviderManager.TLSenabled = config.TLS != nil || config.AgentTLSOptions != nil
+	b.SecretsProviderManager.AddProvider(secrets.NewEnvProvider(config.SecretsEnvPrefix))
+	if config.SecretsVaultAddress != "" {
+		b.SecretsProviderManager.AddProvider(secrets.NewVaultProvider(config.SecretsVaultAddress, config.SecretsVaultToken))
+	}
 
 	// Initialize pipelined
-	pipeline, err := pipelined.New(pipelined.Config{
-		Store: stor,
-		Bus:   bus,
-		ExtensionExecutorGetter: rpc.NewGRPCExtensionExecutor,
-		AssetGetter:             assetGetter,
-		BufferSize:              viper.GetInt(FlagPipelinedBufferSize),
-		WorkerCount:             viper.GetInt(FlagPipelinedWorkers),
-		StoreTimeout:            2 * time.Minute,
-		SecretsProviderManager:  b.SecretsProviderManager,
-	})
+	handlerHTTP := httpclient.Config{
+		ProxyURL:      viper.GetString(FlagPipelinedHandlerProxyURL),
+		NoProxy:       viper.GetStringSlice(FlagPipelinedHandlerNoProxy),
+		TrustedCAFile: viper.GetString(FlagPipelinedHandlerTrustedCAFile),
+	}
+	handlerHTTPClient, err := handlerHTTP.NewClient(0)
 	if err != nil {
-		return nil, fmt.Errorf("error initializing %s: %s", pipeline.Name(), err)
-	}
-	b.Daemons = append(b.Daemons, pipeline)
-
-	// Initialize eventd
-	event, err := eventd.New(
-		b.runCtx,
-		eventd.Config{
-			Store:           stor,
-			EventStore:      eventStoreProxy,
-			Bus:             bus,
-			LivenessFactory: liveness.EtcdFactory(b.runCtx, b.Client),
-			Client:          b.Client,
-			BufferSize:      viper.GetInt(FlagEventdBufferSize),
-			WorkerCount:     viper.GetInt(FlagEventdWorkers),
-			StoreTimeout:    2 * time.Minute,
-		},
-	)
+		return nil, fmt.Errorf("bad handler HTTP configuration: %s", err)
+	}
+	drainTimeout := time.Duration(viper.GetInt(FlagShutdownTimeout)) * time.Second
+	var pipeline *pipelined.Pipelined
+	pipelineSpec, err := newDaemonSpec(func() (daemon.Daemon, error) {
+		var err error
+		pipeline, err = pipelined.New(pipelined.Config{
+			Store:                   stor,
+			Bus:                     bus,
+			ExtensionExecutorGetter: rpc.NewGRPCExtensionExecutor,
+			AssetGetter:             assetGetter,
+			BufferSize:              viper.GetInt(FlagPipelinedBufferSize),
+			WorkerCount:             viper.GetInt(FlagPipelinedWorkers),
+			StoreTimeout:            2 * time.Minute,
+			SecretsProviderManager:  b.SecretsProviderManager,
+			HandlerSandbox: &command.Sandbox{
+				User:          viper.GetString(FlagPipelinedHandlerUser),
+				Group:         viper.GetString(FlagPipelinedHandlerGroup),
+				CPUTimeLimit:  viper.GetInt(FlagPipelinedHandlerCPUTimeLimit),
+				MemoryLimit:   viper.GetInt64(FlagPipelinedHandlerMemoryLimit),
+				MaxOutputSize: viper.GetInt64(FlagPipelinedHandlerMaxOutputSize),
+			},
+			HandlerHTTPClient:      handlerHTTPClient,
+			HandlerMaxConcurrent:   viper.GetUint32(FlagPipelinedHandlerMaxConcurrent),
+			DeduplicationWindow:    time.Duration(viper.GetInt(FlagPipelinedDeduplicationWindow)) * time.Second,
+			DrainTimeout:           drainTimeout,
+			StormMaxEventRate:      viper.GetFloat64(FlagPipelinedStormMaxEventRate),
+			StormMaxFailingPercent: viper.GetFloat64(FlagPipelinedStormMaxFailingPercent),
+			StormMinEntities:       viper.GetInt(FlagPipelinedStormMinEntities),
+			StormWindow:            time.Duration(viper.GetInt(FlagPipelinedStormWindow)) * time.Second,
+		})
+		return pipeline, err
+	}, bus.Name())
+	if err != nil {
+		return nil, fmt.Errorf("error initializing pipelined: %s", err)
+	}
+	b.Daemons = append(b.Daemons, pipelineSpec)
+
+	// Initialize eventd. It depends on pipelined so that, on shutdown, the
+	// events it drains are still handled by pipelined. Likewise, agentd is
+	// declared after eventd so it stops accepting events before eventd stops
+	eventStats := eventd.NewEventStatsTracker(b.Client)
+	eventStats.Start(b.runCtx)
+	spec, err := newDaemonSpec(func() (daemon.Daemon, error) {
+		return eventd.New(
+			b.runCtx,
+			eventd.Config{
+				Store:           stor,
+				EventStore:      eventStore,
+				Bus:             bus,
+				LivenessFactory: liveness.EtcdFactory(b.runCtx, b.Client),
+				Client:          b.Client,
+				BufferSize:      viper.GetInt(FlagEventdBufferSize),
+				WorkerCount:     viper.GetInt(FlagEventdWorkers),
+				StoreTimeout:    2 * time.Minute,
+				MaxClockSkew:    time.Duration(viper.GetInt(FlagEventdMaxClockSkew)) * time.Second,
+				DrainTimeout:    drainTimeout,
+				ClusterConfig:   clusterConfig,
+				StatsTracker:    eventStats,
+			},
+		)
+	}, bus.Name(), pipelineSpec.Name)
 	if err != nil {
-		return nil, fmt.Errorf("error initializing %s: %s", event.Name(), err)
+		return nil, fmt.Errorf("error initializing eventd: %s", err)
 	}
-	b.Daemons = append(b.Daemons, event)
+	b.Daemons = append(b.Daemons, spec)
 
 	ringPool := ringv2.NewPool(b.Client)
 
 	// Initialize schedulerd
-	scheduler, err := schedulerd.New(
-		b.runCtx,
-		schedulerd.Config{
-			Store:                  stor,
-			Bus:                    bus,
-			QueueGetter:            queueGetter,
-			RingPool:               ringPool,
-			Client:                 b.Client,
-			SecretsProviderManager: b.SecretsProviderManager,
-		})
+	spec, err = newDaemonSpec(func() (daemon.Daemon, error) {
+		return schedulerd.New(
+			b.runCtx,
+			schedulerd.Config{
+				Store:                  stor,
+				Bus:                    bus,
+				QueueGetter:            queueGetter,
+				RingPool:               ringPool,
+				Client:                 b.Client,
+				SecretsProviderManager: b.SecretsProviderManager,
+			})
+	}, bus.Name(), backendID.Name())
 	if err != nil {
-		return nil, fmt.Errorf("error initializing %s: %s", scheduler.Name(), err)
+		return nil, fmt.Errorf("error initializing schedulerd: %s", err)
 	}
-	b.Daemons = append(b.Daemons, scheduler)
+	b.Daemons = append(b.Daemons, spec)
 
 	// Use the common TLS flags for agentd if wasn't explicitely configured with
 	// its own TLS configuration
@@ -295,38 +403,74 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		config.AgentTLSOptions = config.TLS
 	}
 
-	// Initialize agentd
-	agent, err := agentd.New(agentd.Config{
-		Host:         config.AgentHost,
-		Port:         config.AgentPort,
-		Bus:          bus,
-		Store:        stor,
-		TLS:          config.AgentTLSOptions,
-		RingPool:     ringPool,
-		WriteTimeout: config.AgentWriteTimeout,
-	})
+	// Load the keys verifying the signatures of the messages of the agents,
+	// given as agent=path
+	verificationKeys := make([]*sensutransport.VerificationKey, 0, len(config.AgentVerificationKeys))
+	for _, entry := range config.AgentVerificationKeys {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("error initializing agentd: invalid agent verification key %q, expected agent=path", entry)
+		}
+		key, err := sensutransport.LoadVerificationKey(parts[0], parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("error initializing agentd: %s", err)
+		}
+		verificationKeys = append(verificationKeys, key)
+	}
+
+	// Initialize agentd. Its listener is only opened once the store is
+	// reachable, so the agents aren't accepted before their events can be
+	// processed
+	spec, err = newDaemonSpec(func() (daemon.Daemon, error) {
+		return agentd.New(agentd.Config{
+			Host:         config.AgentHost,
+			Port:         config.AgentPort,
+			Bus:          bus,
+			Store:        stor,
+			TLS:          config.AgentTLSOptions,
+			RingPool:     ringPool,
+			WriteTimeout: config.AgentWriteTimeout,
+
+			MaxEventSize:       config.AgentMaxEventSize,
+			MaxCheckOutputSize: config.AgentMaxCheckOutputSize,
+			ReplayMaxAge:       config.AgentReplayMaxAge,
+			MaxSessions:        config.AgentMaxSessions,
+			VerificationKeys:   verificationKeys,
+			ClusterConfig:      clusterConfig,
+			Name:               getDefaultBackendID(),
+			Client:             b.Client,
+		})
+	}, bus.Name())
 	if err != nil {
-		return nil, fmt.Errorf("error initializing %s: %s", agent.Name(), err)
+		return nil, fmt.Errorf("error initializing agentd: %s", err)
 	}
-	b.Daemons = append(b.Daemons, agent)
+	spec.Ready = storeReady(stor)
+	b.Daemons = append(b.Daemons, spec)
+	agentdSpec := spec
 
 	// Initialize keepalived
-	keepalive, err := keepalived.New(keepalived.Config{
-		DeregistrationHandler: config.DeregistrationHandler,
-		Bus:             bus,
-		Store:           stor,
-		EventStore:      stor,
-		LivenessFactory: liveness.EtcdFactory(b.runCtx, b.Client),
-		RingPool:        ringPool,
-		BufferSize:      viper.GetInt(FlagKeepalivedBufferSize),
-		WorkerCount:     viper.GetInt(FlagKeepalivedWorkers),
-		StoreTimeout:    2 * time.Minute,
-	})
+	spec, err = newDaemonSpec(func() (daemon.Daemon, error) {
+		return keepalived.New(keepalived.Config{
+			DeregistrationHandler: config.DeregistrationHandler,
+			Bus:                   bus,
+			Store:                 stor,
+			EventStore:            eventStore,
+			LivenessFactory:       liveness.EtcdFactory(b.runCtx, b.Client),
+			RingPool:              ringPool,
+			BufferSize:            viper.GetInt(FlagKeepalivedBufferSize),
+			WorkerCount:           viper.GetInt(FlagKeepalivedWorkers),
+			StoreTimeout:          2 * time.Minute,
+
+			DisableRegistrationEvents: viper.GetBool(FlagKeepalivedDisableRegistrationEvents),
+			RegistrationHandlers:      viper.GetStringSlice(FlagKeepalivedRegistrationHandlers),
+			ClusterConfig:             clusterConfig,
+			MaxClockSkew:              time.Duration(viper.GetInt(FlagEventdMaxClockSkew)) * time.Second,
+		})
+	}, bus.Name())
 	if err != nil {
-		return nil, fmt.Errorf("error initializing %s: %s", keepalive.Name(), err)
+		return nil, fmt.Errorf("error initializing keepalived: %s", err)
 	}
-	b.Daemons = append(b.Daemons, keepalive)
-
+	b.Daemons = append(b.Daemons, spec)
 	// Prepare the etcd client TLS config
 	etcdClientTLSInfo := (transport.TLSInfo)(config.EtcdClientTLSInfo)
 	etcdClientTLSConfig, err := etcdClientTLSInfo.ClientConfig()
@@ -354,6 +498,18 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		logger.WithError(err).Error("could not load the key pair for the JWT signature")
 	}
 
+	// Load the JWT keys provided to replace the secret stored in etcd, or
+	// kept to verify the tokens signed before a key rotation. They must be the
+	// same on every backend of the cluster
+	if path := viper.GetString(FlagJWTSecretFile); path != "" {
+		if err := jwt.LoadSecret(path); err != nil {
+			return nil, fmt.Errorf("error loading the JWT secret: %s", err)
+		}
+	}
+	if err := jwt.LoadVerificationKeys(viper.GetStringSlice(FlagJWTVerificationKeyFiles)...); err != nil {
+		return nil, fmt.Errorf("error loading the JWT verification keys: %s", err)
+	}
+
 	// Initialize the health router
 	b.HealthRouter = routers.NewHealthRouter(actions.NewHealthController(stor, b.Client.Cluster, b.EtcdClientTLSConfig))
 
@@ -362,13 +518,13 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 	b.GraphQLService, err = graphql.NewService(graphql.ServiceConfig{
 		AssetClient:       api.NewAssetClient(stor, auth),
 		CheckClient:       api.NewCheckClient(stor, actions.NewCheckController(stor, queueGetter), auth),
-		EntityClient:      api.NewEntityClient(stor, eventStoreProxy, auth),
-		EventClient:       api.NewEventClient(eventStoreProxy, auth, bus),
+		EntityClient:      api.NewEntityClient(stor, eventStore, auth),
+		EventClient:       api.NewEventClient(eventStore, auth, bus),
 		EventFilterClient: api.NewEventFilterClient(stor, auth),
 		HandlerClient:     api.NewHandlerClient(stor, auth),
 		HealthController:  actions.NewHealthController(stor, b.Client.Cluster, etcdClientTLSConfig),
 		MutatorClient:     api.NewMutatorClient(stor, auth),
-		SilencedClient:    api.NewSilencedClient(stor, auth),
+		SilencedClient:    api.NewSilencedClient(stor, actions.NewSilencedController(stor, clusterConfig), auth),
 		NamespaceClient:   api.NewNamespaceClient(stor, auth),
 		HookClient:        api.NewHookConfigClient(stor, auth),
 		UserClient:        api.NewUserClient(stor, auth),
@@ -381,13 +537,44 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		return nil, fmt.Errorf("error initializing graphql.Service: %s", err)
 	}
 
+	// Initialize replicatord, if secondary clusters are configured
+	apidDeps := []string{bus.Name(), pipelineSpec.Name, agentdSpec.Name}
+	var replicate *replicatord.Replicatord
+	if len(config.ReplicatorSecondaries) > 0 {
+		secondaries, err := replicatord.ParseSecondaries(config.ReplicatorSecondaries)
+		if err != nil {
+			return nil, fmt.Errorf("error initializing replicatord: %s", err)
+		}
+		spec, err := newDaemonSpec(func() (daemon.Daemon, error) {
+			var err error
+			replicate, err = replicatord.New(replicatord.Config{
+				Client:         b.Client,
+				Secondaries:    secondaries,
+				TLS:            etcdClientTLSConfig,
+				Resources:      config.ReplicatorResources,
+				ConflictPolicy: config.ReplicatorConflictPolicy,
+			})
+			return replicate, err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error initializing replicatord: %s", err)
+		}
+		b.Daemons = append(b.Daemons, spec)
+		apidDeps = append(apidDeps, spec.Name)
+	}
+
+	// Record the API usage of the backend, to report the usage of the cluster
+	apiUsage := middlewares.NewAPIUsageTracker(b.Client)
+	apiUsage.Start(b.runCtx)
+
 	// Initialize apid
 	apidConfig := apid.Config{
 		ListenAddress:       config.APIListenAddress,
+		UnixSocket:          config.APIUnixSocket,
 		URL:                 config.APIURL,
 		Bus:                 bus,
 		Store:               stor,
-		EventStore:          eventStoreProxy,
+		EventStore:          eventStore,
 		QueueGetter:         queueGetter,
 		TLS:                 config.TLS,
 		Cluster:             b.Client.Cluster,
@@ -396,27 +583,94 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 		ClusterVersion:      clusterVersion,
 		GraphQLService:      b.GraphQLService,
 		HealthRouter:        b.HealthRouter,
-	}
-	api, err := apid.New(apidConfig)
+		RateLimiter: middlewares.NewRateLimiter(middlewares.RateLimiterConfig{
+			Limits: map[middlewares.RouteClass]rate.Limit{
+				middlewares.RouteClassList:  config.APIListRateLimit,
+				middlewares.RouteClassRead:  config.APIReadRateLimit,
+				middlewares.RouteClassWrite: config.APIWriteRateLimit,
+			},
+			Burst:                 config.APIBurstLimit,
+			MaxConcurrentRequests: config.APIMaxConcurrentRequests,
+		}),
+		IdempotencyStore:   middlewares.NewIdempotencyStore(b.Client, middlewares.DefaultIdempotencyKeyTTL),
+		APIUsageTracker:    apiUsage,
+		EventStats:         eventStats,
+		ClusterConfig:      clusterConfig,
+		SwitchInspector:    liveness.NewInspector(b.Client),
+		SubscriptionLister: ringPool,
+		AgentDrainer:       agentd.NewDrainController(stor, b.Client),
+	}
+	spec, err = newDaemonSpec(func() (daemon.Daemon, error) {
+		// Use the current instances of pipelined and replicatord, which are
+		// recreated when they are restarted
+		apidConfig.PipelineDryRunner = pipeline
+		if replicate != nil {
+			apidConfig.Replicator = replicate
+		}
+		return apid.New(apidConfig)
+	}, apidDeps...)
 	if err != nil {
-		return nil, fmt.Errorf("error initializing %s: %s", api.Name(), err)
+		return nil, fmt.Errorf("error initializing apid: %s", err)
 	}
-	b.Daemons = append(b.Daemons, api)
+	spec.Ready = storeReady(stor)
+	b.Daemons = append(b.Daemons, spec)
+	apidName := spec.Name
 
 	// Initialize tessend
-	tessen, err := tessend.New(
-		b.runCtx,
-		tessend.Config{
-			Store:      stor,
-			EventStore: eventStoreProxy,
-			RingPool:   ringPool,
-			Client:     b.Client,
-			Bus:        bus,
-		})
+	spec, err = newDaemonSpec(func() (daemon.Daemon, error) {
+		return tessend.New(
+			b.runCtx,
+			tessend.Config{
+				Store:      stor,
+				EventStore: eventStoreProxy,
+				RingPool:   ringPool,
+				Client:     b.Client,
+				Bus:        bus,
+			})
+	}, bus.Name())
 	if err != nil {
-		return nil, fmt.Errorf("error initializing %s: %s", tessen.Name(), err)
+		return nil, fmt.Errorf("error initializing tessend: %s", err)
+	}
+	b.Daemons = append(b.Daemons, spec)
+
+	// Initialize lifecycled, if EC2 instance state-change notifications are
+	// configured
+	if config.EC2DeregistrationQueueURL != "" {
+		spec, err := newDaemonSpec(func() (daemon.Daemon, error) {
+			return lifecycled.New(lifecycled.Config{
+				Store:        stor,
+				EventStore:   eventStore,
+				Bus:          bus,
+				StoreTimeout: 2 * time.Minute,
+				QueueURL:     config.EC2DeregistrationQueueURL,
+				Region:       config.EC2DeregistrationRegion,
+				States:       config.EC2DeregistrationStates,
+			})
+		}, bus.Name())
+		if err != nil {
+			return nil, fmt.Errorf("error initializing lifecycled: %s", err)
+		}
+		b.Daemons = append(b.Daemons, spec)
+	}
+
+	// Initialize eventlogd, if an event log file or Kafka topic is configured
+	if config.EventLogFile != "" || config.EventLogKafkaURL != "" {
+		spec, err := newDaemonSpec(func() (daemon.Daemon, error) {
+			return eventlogd.New(eventlogd.Config{
+				Bus:        bus,
+				File:       config.EventLogFile,
+				MaxSize:    config.EventLogMaxSize,
+				MaxBackups: config.EventLogMaxBackups,
+				KafkaURL:   config.EventLogKafkaURL,
+				KafkaTopic: config.EventLogKafkaTopic,
+				BufferSize: config.EventLogBufferSize,
+			})
+		}, bus.Name())
+		if err != nil {
+			return nil, fmt.Errorf("error initializing eventlogd: %s", err)
+		}
+		b.Daemons = append(b.Daemons, spec)
 	}
-	b.Daemons = append(b.Daemons, tessen)
 
 	// Initialize dashboardd TLS config
 	var dashboardTLSConfig *corev2.TLSOptions
@@ -434,20 +688,72 @@ func Initialize(ctx context.Context, config *Config) (*Backend, error) {
 			KeyFile:  config.TLS.GetKeyFile(),
 		}
 	}
-	dashboard, err := dashboardd.New(dashboardd.Config{
-		APIDConfig: apidConfig,
-		Host:       config.DashboardHost,
-		Port:       config.DashboardPort,
-		TLS:        dashboardTLSConfig,
-	})
+	spec, err = newDaemonSpec(func() (daemon.Daemon, error) {
+		return dashboardd.New(dashboardd.Config{
+			APIDConfig: apidConfig,
+			Host:       config.DashboardHost,
+			Port:       config.DashboardPort,
+			TLS:        dashboardTLSConfig,
+		})
+	}, apidName)
 	if err != nil {
-		return nil, fmt.Errorf("error initializing %s: %s", dashboard.Name(), err)
+		return nil, fmt.Errorf("error initializing dashboardd: %s", err)
 	}
-	b.Daemons = append(b.Daemons, dashboard)
+	spec.Ready = storeReady(stor)
+	b.Daemons = append(b.Daemons, spec)
 
 	return b, nil
 }
 
+// existingDaemon returns a daemon.Spec constructor returning d, for the daemons
+// created once and never restarted.
+func existingDaemon(d daemon.Daemon) func() (daemon.Daemon, error) {
+	return func() (daemon.Daemon, error) {
+		return d, nil
+	}
+}
+
+// newDaemonSpec returns the spec of a daemon built by create, depending on the
+// daemons named dependsOn. The daemon is created right away so configuration
+// errors are reported by Initialize, and create is only called again to
+// restart it.
+func newDaemonSpec(create func() (daemon.Daemon, error), dependsOn ...string) (daemon.Spec, error) {
+	d, err := create()
+	if err != nil {
+		return daemon.Spec{}, err
+	}
+	return daemon.Spec{
+		Name:      d.Name(),
+		DependsOn: dependsOn,
+		New: func() (daemon.Daemon, error) {
+			if d != nil {
+				first := d
+				d = nil
+				return first, nil
+			}
+			return create()
+		},
+	}, nil
+}
+
+// storeReady returns a readiness check waiting for the store to be reachable.
+func storeReady(stor store.Store) func(context.Context) error {
+	return func(ctx context.Context) error {
+		backoff := retry.ExponentialBackoff{
+			Ctx:                  ctx,
+			InitialDelayInterval: 100 * time.Millisecond,
+			MaxDelayInterval:     5 * time.Second,
+		}
+		return backoff.Retry(func(int) (bool, error) {
+			if _, err := stor.GetClusterID(ctx); err != nil {
+				logger.WithError(err).Warn("waiting for the store to be ready")
+				return false, nil
+			}
+			return true, nil
+		})
+	}
+}
+
 func (b *Backend) runOnce() error {
 	var derr error
 
@@ -466,32 +772,22 @@ func (b *Backend) runOnce() error {
 		}()
 	}
 
-	eg := errGroup{
-		out: make(chan error),
+	// Start the daemons in the order of their dependencies. The supervisor
+	// restarts the daemons crashing afterwards
+	sup, err := daemon.NewSupervisor(daemon.SupervisorConfig{}, b.Daemons...)
+	if err != nil {
+		return ErrStartup{Err: err, Name: "daemons"}
 	}
-	sg := stopGroup{}
-
-	// Loop across the daemons in order to start them, then add them to our groups
-	for _, d := range b.Daemons {
-		if err := d.Start(); err != nil {
-			return ErrStartup{Err: err, Name: d.Name()}
+	if err := sup.Start(b.runCtx); err != nil {
+		if serr, ok := err.(daemon.StartError); ok {
+			return ErrStartup{Err: serr.Err, Name: serr.Name}
 		}
-
-		// Add the daemon to our errGroup
-		eg.errors = append(eg.errors, d)
-
-		// Add the daemon to our stopGroup
-		sg = append(sg, daemonStopper{
-			Name:    d.Name(),
-			stopper: d,
-		})
+		return ErrStartup{Err: err, Name: sup.Name()}
 	}
 
-	// Reverse the order of our stopGroup so daemons are stopped in the proper
-	// order (last one started is first one stopped)
-	for i := len(sg)/2 - 1; i >= 0; i-- {
-		opp := len(sg) - 1 - i
-		sg[i], sg[opp] = sg[opp], sg[i]
+	eg := errGroup{
+		out:    make(chan error),
+		errors: []errorer{sup},
 	}
 
 	if b.Etcd != nil {
@@ -500,13 +796,19 @@ func (b *Backend) runOnce() error {
 	}
 	eg.Go()
 
+	// The store is available and the daemons, including the API, are serving
+	systemd.Notify(systemd.Ready)
+	go systemd.Watchdog(b.runCtx, b.healthCheck)
+
 	select {
 	case err := <-eg.Err():
 		logger.WithError(err).Error("backend stopped working and is restarting")
+		systemd.Notify(systemd.Reloading)
 	case <-b.runCtx.Done():
 		logger.Info("backend shutting down")
+		systemd.Notify(systemd.Stopping)
 	}
-	if err := sg.Stop(); err != nil {
+	if err := sup.Stop(); err != nil {
 		if derr == nil {
 			derr = err
 		}
@@ -521,6 +823,14 @@ func (b *Backend) runOnce() error {
 	return derr
 }
 
+// healthCheck returns an error if the backend can't read from its etcd
+// server. The read is serializable, so the backend stays healthy when the etcd
+// cluster loses its quorum.
+func (b *Backend) healthCheck(ctx context.Context) error {
+	_, err := b.Client.Get(ctx, "/sensu.io/health", clientv3.WithSerializable(), clientv3.WithCountOnly())
+	return err
+}
+
 // RunWithInitializer is like Run but accepts an initialization function to use
 // for initialization, instead of using the default Initialize().
 func (b *Backend) RunWithInitializer(initialize func(context.Context, *Config) (*Backend, error)) error {
@@ -580,28 +890,6 @@ func (b *Backend) Run() error {
 	return b.RunWithInitializer(Initialize)
 }
 
-type stopper interface {
-	Stop() error
-}
-
-type daemonStopper struct {
-	stopper
-	Name string
-}
-
-type stopGroup []daemonStopper
-
-func (s stopGroup) Stop() (err error) {
-	for _, stopper := range s {
-		logger.Info("shutting down ", stopper.Name)
-		e := stopper.Stop()
-		if err == nil {
-			err = e
-		}
-	}
-	return err
-}
-
 type errorer interface {
 	Err() <-chan error
 }
@@ -0,0 +1,77 @@
+// Package clusterconfig provides the cluster configuration to the daemons of
+// the backend, kept up to date as it is changed through the API.
+package clusterconfig
+
+import (
+	"context"
+	"sync/atomic"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// Watcher holds the cluster configuration, which it keeps up to date by
+// watching the store. A nil Watcher holds the empty configuration, so the
+// daemons created without one keep their default behavior.
+type Watcher struct {
+	store  store.ClusterConfigStore
+	config atomic.Value
+}
+
+// NewWatcher creates a watcher of the cluster configuration of the store s.
+func NewWatcher(s store.ClusterConfigStore) *Watcher {
+	w := &Watcher{store: s}
+	w.config.Store(&corev2.ClusterConfig{})
+	return w
+}
+
+// Start loads the cluster configuration and keeps it up to date until ctx is
+// cancelled. The empty configuration is held until the cluster configuration
+// is loaded.
+func (w *Watcher) Start(ctx context.Context) {
+	// The watcher is created first so the changes made while the
+	// configuration is loaded are not missed
+	watchChan := w.store.GetClusterConfigWatcher(ctx)
+	w.load(ctx)
+	go w.watch(ctx, watchChan)
+}
+
+// Config returns the current cluster configuration, which must not be
+// modified.
+func (w *Watcher) Config() *corev2.ClusterConfig {
+	if w == nil {
+		return &corev2.ClusterConfig{}
+	}
+	return w.config.Load().(*corev2.ClusterConfig)
+}
+
+func (w *Watcher) load(ctx context.Context) {
+	config, err := w.store.GetClusterConfig(ctx)
+	if err != nil {
+		logger.WithError(err).Error("unable to load the cluster configuration")
+		return
+	}
+	w.config.Store(config)
+}
+
+func (w *Watcher) watch(ctx context.Context, watchChan <-chan store.WatchEventClusterConfig) {
+	for {
+		select {
+		case event, ok := <-watchChan:
+			if !ok {
+				// The watcher has closed, restart it
+				watchChan = w.store.GetClusterConfigWatcher(ctx)
+				continue
+			}
+			if event.Action == store.WatchError {
+				// Changes were missed, so the configuration is loaded again
+				w.load(ctx)
+				continue
+			}
+			logger.Info("cluster configuration updated")
+			w.config.Store(event.ClusterConfig)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
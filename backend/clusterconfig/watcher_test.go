@@ -0,0 +1,48 @@
+package clusterconfig
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNilWatcher(t *testing.T) {
+	var w *Watcher
+	assert.Equal(t, &corev2.ClusterConfig{}, w.Config())
+}
+
+func TestWatcher(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	loaded := &corev2.ClusterConfig{KeepaliveTimeout: 60}
+	reloaded := &corev2.ClusterConfig{KeepaliveTimeout: 30}
+	updated := &corev2.ClusterConfig{EventTTL: 3600}
+
+	watchChan := make(chan store.WatchEventClusterConfig)
+	s := &mockstore.MockStore{}
+	s.On("GetClusterConfigWatcher", mock.Anything).Return((<-chan store.WatchEventClusterConfig)(watchChan))
+	s.On("GetClusterConfig", mock.Anything).Return(loaded, nil).Once()
+	s.On("GetClusterConfig", mock.Anything).Return(reloaded, nil)
+
+	w := NewWatcher(s)
+	w.Start(ctx)
+	assert.Equal(t, loaded, w.Config())
+
+	watchChan <- store.WatchEventClusterConfig{Action: store.WatchUpdate, ClusterConfig: updated}
+	assert.Eventually(t, func() bool {
+		return w.Config() == updated
+	}, time.Second, 10*time.Millisecond)
+
+	// Changes were missed, so the configuration is loaded again
+	watchChan <- store.WatchEventClusterConfig{Action: store.WatchError}
+	assert.Eventually(t, func() bool {
+		return w.Config() == reloaded
+	}, time.Second, 10*time.Millisecond)
+}
@@ -19,9 +19,12 @@ import (
 )
 
 const (
-	flagInitAdminUsername = "cluster-admin-username"
-	flagInitAdminPassword = "cluster-admin-password"
-	flagInteractive       = "interactive"
+	flagInitAdminUsername        = "cluster-admin-username"
+	flagInitAdminPassword        = "cluster-admin-password"
+	flagInitAgentUsername        = "agent-username"
+	flagInitAgentPassword        = "agent-password"
+	flagInteractive              = "interactive"
+	flagIgnoreAlreadyInitialized = "ignore-already-initialized"
 )
 
 type seedConfig struct {
@@ -32,6 +35,7 @@ type seedConfig struct {
 type initOpts struct {
 	AdminUsername string `survey:"cluster-admin-username"`
 	AdminPassword string `survey:"cluster-admin-password"`
+	AgentPassword string `survey:"agent-password"`
 }
 
 func (i *initOpts) administerQuestionnaire() error {
@@ -50,6 +54,13 @@ func (i *initOpts) administerQuestionnaire() error {
 			},
 			Validate: survey.Required,
 		},
+		{
+			Name: "agent-password",
+			Prompt: &survey.Password{
+				Message: "Agent Password:",
+			},
+			Validate: survey.Required,
+		},
 	}
 
 	return survey.Ask(qs, i)
@@ -130,6 +141,7 @@ func InitCommand() *cobra.Command {
 
 			uname := viper.GetString(flagInitAdminUsername)
 			pword := viper.GetString(flagInitAdminPassword)
+			agentPword := viper.GetString(flagInitAgentPassword)
 
 			if viper.GetBool(flagInteractive) {
 				var opts initOpts
@@ -138,27 +150,43 @@ func InitCommand() *cobra.Command {
 				}
 				uname = opts.AdminUsername
 				pword = opts.AdminPassword
+				agentPword = opts.AgentPassword
 			}
 
 			if uname == "" || pword == "" {
 				return fmt.Errorf("both %s and %s are required to be set", flagInitAdminUsername, flagInitAdminPassword)
 			}
+			if agentPword == "" {
+				return fmt.Errorf("%s is required to be set", flagInitAgentPassword)
+			}
 
 			seedConfig := seedConfig{
 				Config: *cfg,
 				SeedConfig: seeds.Config{
 					AdminUsername: uname,
 					AdminPassword: pword,
+					AgentUsername: viper.GetString(flagInitAgentUsername),
+					AgentPassword: agentPword,
 				},
 			}
 
-			return seedCluster(client, seedConfig)
+			err = seedCluster(client, seedConfig)
+			if err == seeds.ErrAlreadyInitialized && viper.GetBool(flagIgnoreAlreadyInitialized) {
+				// Provisioning tools run init on every deployment, an initialized
+				// store is what they expect
+				fmt.Fprintln(cmd.OutOrStdout(), err)
+				return nil
+			}
+			return err
 		},
 	}
 
 	cmd.Flags().String(flagInitAdminUsername, "", "cluster admin username")
 	cmd.Flags().String(flagInitAdminPassword, "", "cluster admin password")
+	cmd.Flags().String(flagInitAgentUsername, seeds.DefaultAgentUsername, "username of the user of the agents")
+	cmd.Flags().String(flagInitAgentPassword, "", "password of the user of the agents")
 	cmd.Flags().Bool(flagInteractive, false, "interactive mode")
+	cmd.Flags().Bool(flagIgnoreAlreadyInitialized, false, "exit successfully if the cluster is already initialized")
 
 	setupErr = handleConfig(cmd, false)
 
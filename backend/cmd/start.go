@@ -15,12 +15,17 @@ import (
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend"
 	"github.com/sensu/sensu-go/backend/etcd"
+	"github.com/sensu/sensu-go/backend/eventlogd"
+	"github.com/sensu/sensu-go/backend/lifecycled"
+	"github.com/sensu/sensu-go/backend/replicatord"
+	"github.com/sensu/sensu-go/backend/secrets"
 	"github.com/sensu/sensu-go/util/path"
 	stringsutil "github.com/sensu/sensu-go/util/strings"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -162,18 +167,54 @@ func StartCommand(initialize InitializeFunc) *cobra.Command {
 			}
 
 			cfg := &backend.Config{
-				AgentHost:             viper.GetString(flagAgentHost),
-				AgentPort:             viper.GetInt(flagAgentPort),
-				AgentWriteTimeout:     viper.GetInt(backend.FlagAgentWriteTimeout),
-				APIListenAddress:      viper.GetString(flagAPIListenAddress),
-				APIURL:                viper.GetString(flagAPIURL),
-				DashboardHost:         viper.GetString(flagDashboardHost),
-				DashboardPort:         viper.GetInt(flagDashboardPort),
-				DashboardTLSCertFile:  viper.GetString(flagDashboardCertFile),
-				DashboardTLSKeyFile:   viper.GetString(flagDashboardKeyFile),
-				DeregistrationHandler: viper.GetString(flagDeregistrationHandler),
-				CacheDir:              viper.GetString(flagCacheDir),
-				StateDir:              viper.GetString(flagStateDir),
+				AgentHost:                viper.GetString(flagAgentHost),
+				AgentPort:                viper.GetInt(flagAgentPort),
+				AgentWriteTimeout:        viper.GetInt(backend.FlagAgentWriteTimeout),
+				AgentMaxEventSize:        viper.GetInt(backend.FlagAgentMaxEventSize),
+				AgentMaxCheckOutputSize:  viper.GetInt(backend.FlagAgentMaxCheckOutputSize),
+				AgentReplayMaxAge:        viper.GetInt(backend.FlagAgentReplayMaxAge),
+				AgentMaxSessions:         viper.GetInt(backend.FlagAgentMaxSessions),
+				AgentVerificationKeys:    viper.GetStringSlice(backend.FlagAgentVerificationKeys),
+				APIListenAddress:         viper.GetString(flagAPIListenAddress),
+				APIUnixSocket:            viper.GetString(backend.FlagAPIUnixSocket),
+				APIURL:                   viper.GetString(flagAPIURL),
+				APIListRateLimit:         rate.Limit(viper.GetFloat64(backend.FlagAPIListRateLimit)),
+				APIReadRateLimit:         rate.Limit(viper.GetFloat64(backend.FlagAPIReadRateLimit)),
+				APIWriteRateLimit:        rate.Limit(viper.GetFloat64(backend.FlagAPIWriteRateLimit)),
+				APIBurstLimit:            viper.GetInt(backend.FlagAPIBurstLimit),
+				APIMaxConcurrentRequests: viper.GetInt(backend.FlagAPIMaxConcurrentRequests),
+				DashboardHost:            viper.GetString(flagDashboardHost),
+				DashboardPort:            viper.GetInt(flagDashboardPort),
+				DashboardTLSCertFile:     viper.GetString(flagDashboardCertFile),
+				DashboardTLSKeyFile:      viper.GetString(flagDashboardKeyFile),
+				DeregistrationHandler:    viper.GetString(flagDeregistrationHandler),
+				CacheDir:                 viper.GetString(flagCacheDir),
+				StateDir:                 viper.GetString(flagStateDir),
+
+				EC2DeregistrationQueueURL: viper.GetString(backend.FlagEC2DeregistrationQueueURL),
+				EC2DeregistrationRegion:   viper.GetString(backend.FlagEC2DeregistrationRegion),
+				EC2DeregistrationStates:   viper.GetStringSlice(backend.FlagEC2DeregistrationStates),
+
+				ReplicatorSecondaries:    viper.GetStringSlice(backend.FlagReplicatorSecondaries),
+				ReplicatorResources:      viper.GetStringSlice(backend.FlagReplicatorResources),
+				ReplicatorConflictPolicy: viper.GetString(backend.FlagReplicatorConflictPolicy),
+
+				EventLogFile:       viper.GetString(backend.FlagEventLogFile),
+				EventLogMaxSize:    viper.GetInt64(backend.FlagEventLogMaxSize),
+				EventLogMaxBackups: viper.GetInt(backend.FlagEventLogMaxBackups),
+				EventLogKafkaURL:   viper.GetString(backend.FlagEventLogKafkaURL),
+				EventLogKafkaTopic: viper.GetString(backend.FlagEventLogKafkaTopic),
+				EventLogBufferSize: viper.GetInt(backend.FlagEventLogBufferSize),
+
+				EventArchiveURL:    viper.GetString(backend.FlagEventArchiveURL),
+				EventArchiveRegion: viper.GetString(backend.FlagEventArchiveRegion),
+
+				SecretsEnvPrefix:    viper.GetString(backend.FlagSecretsEnvPrefix),
+				SecretsVaultAddress: viper.GetString(backend.FlagSecretsVaultAddress),
+				SecretsVaultToken:   viper.GetString(backend.FlagSecretsVaultToken),
+
+				StoreEncryptionKeyFile:          viper.GetString(backend.FlagStoreEncryptionKeyFile),
+				StoreEncryptionPreviousKeyFiles: viper.GetStringSlice(backend.FlagStoreEncryptionPreviousKeyFiles),
 
 				EtcdAdvertiseClientURLs:      viper.GetStringSlice(flagEtcdAdvertiseClientURLs),
 				EtcdListenClientURLs:         viper.GetStringSlice(flagEtcdListenClientURLs),
@@ -300,11 +341,59 @@ func handleConfig(cmd *cobra.Command, server bool) error {
 		viper.SetDefault(flagLogLevel, "warn")
 		viper.SetDefault(backend.FlagEventdWorkers, 100)
 		viper.SetDefault(backend.FlagEventdBufferSize, 100)
+		viper.SetDefault(backend.FlagEventdMaxClockSkew, 300)
 		viper.SetDefault(backend.FlagKeepalivedWorkers, 100)
 		viper.SetDefault(backend.FlagKeepalivedBufferSize, 100)
+		viper.SetDefault(backend.FlagKeepalivedDisableRegistrationEvents, false)
+		viper.SetDefault(backend.FlagKeepalivedRegistrationHandlers, []string{corev2.RegistrationHandlerName})
 		viper.SetDefault(backend.FlagPipelinedWorkers, 100)
 		viper.SetDefault(backend.FlagPipelinedBufferSize, 100)
+		viper.SetDefault(backend.FlagPipelinedHandlerUser, "")
+		viper.SetDefault(backend.FlagPipelinedHandlerGroup, "")
+		viper.SetDefault(backend.FlagPipelinedHandlerCPUTimeLimit, 0)
+		viper.SetDefault(backend.FlagPipelinedHandlerMemoryLimit, 0)
+		viper.SetDefault(backend.FlagPipelinedHandlerMaxOutputSize, 0)
+		viper.SetDefault(backend.FlagPipelinedHandlerMaxConcurrent, 0)
+		viper.SetDefault(backend.FlagPipelinedDeduplicationWindow, 300)
+		viper.SetDefault(backend.FlagPipelinedHandlerProxyURL, "")
+		viper.SetDefault(backend.FlagPipelinedHandlerNoProxy, []string{})
+		viper.SetDefault(backend.FlagPipelinedHandlerTrustedCAFile, "")
+		viper.SetDefault(backend.FlagPipelinedStormMaxEventRate, 0)
+		viper.SetDefault(backend.FlagPipelinedStormMaxFailingPercent, 0)
+		viper.SetDefault(backend.FlagPipelinedStormMinEntities, 10)
+		viper.SetDefault(backend.FlagPipelinedStormWindow, 60)
+		viper.SetDefault(backend.FlagShutdownTimeout, 30)
 		viper.SetDefault(backend.FlagAgentWriteTimeout, 15)
+		viper.SetDefault(backend.FlagAgentMaxEventSize, 0)
+		viper.SetDefault(backend.FlagAgentMaxCheckOutputSize, 0)
+		viper.SetDefault(backend.FlagAgentReplayMaxAge, 0)
+		viper.SetDefault(backend.FlagAgentMaxSessions, 0)
+		viper.SetDefault(backend.FlagAgentVerificationKeys, []string{})
+		viper.SetDefault(backend.FlagEC2DeregistrationQueueURL, "")
+		viper.SetDefault(backend.FlagEC2DeregistrationRegion, "")
+		viper.SetDefault(backend.FlagEC2DeregistrationStates, lifecycled.DefaultStates)
+		viper.SetDefault(backend.FlagAPIListRateLimit, 0)
+		viper.SetDefault(backend.FlagAPIReadRateLimit, 0)
+		viper.SetDefault(backend.FlagAPIWriteRateLimit, 0)
+		viper.SetDefault(backend.FlagAPIBurstLimit, 10)
+		viper.SetDefault(backend.FlagAPIMaxConcurrentRequests, 0)
+		viper.SetDefault(backend.FlagAPIUnixSocket, "")
+		viper.SetDefault(backend.FlagReplicatorSecondaries, []string{})
+		viper.SetDefault(backend.FlagReplicatorResources, replicatord.DefaultResources)
+		viper.SetDefault(backend.FlagReplicatorConflictPolicy, replicatord.ConflictPolicyOverwrite)
+		viper.SetDefault(backend.FlagEventLogFile, "")
+		viper.SetDefault(backend.FlagEventLogMaxSize, 0)
+		viper.SetDefault(backend.FlagEventLogMaxBackups, eventlogd.DefaultMaxBackups)
+		viper.SetDefault(backend.FlagEventLogKafkaURL, "")
+		viper.SetDefault(backend.FlagEventLogKafkaTopic, "")
+		viper.SetDefault(backend.FlagEventLogBufferSize, eventlogd.DefaultBufferSize)
+		viper.SetDefault(backend.FlagEventArchiveURL, "")
+		viper.SetDefault(backend.FlagEventArchiveRegion, "")
+		viper.SetDefault(backend.FlagSecretsEnvPrefix, secrets.DefaultEnvPrefix)
+		viper.SetDefault(backend.FlagSecretsVaultAddress, "")
+		viper.SetDefault(backend.FlagSecretsVaultToken, "")
+		viper.SetDefault(backend.FlagStoreEncryptionKeyFile, "")
+		viper.SetDefault(backend.FlagStoreEncryptionPreviousKeyFiles, []string{})
 	}
 
 	// Etcd defaults
@@ -351,13 +440,63 @@ func handleConfig(cmd *cobra.Command, server bool) error {
 		cmd.Flags().String(flagLogLevel, viper.GetString(flagLogLevel), "logging level [panic, fatal, error, warn, info, debug]")
 		cmd.Flags().Int(backend.FlagEventdWorkers, viper.GetInt(backend.FlagEventdWorkers), "number of workers spawned for processing incoming events")
 		cmd.Flags().Int(backend.FlagEventdBufferSize, viper.GetInt(backend.FlagEventdBufferSize), "number of incoming events that can be buffered")
+		cmd.Flags().Int(backend.FlagEventdMaxClockSkew, viper.GetInt(backend.FlagEventdMaxClockSkew), "allowed skew, in seconds, between the event timestamps and the backend clock (0 to disable)")
 		cmd.Flags().Int(backend.FlagKeepalivedWorkers, viper.GetInt(backend.FlagKeepalivedWorkers), "number of workers spawned for processing incoming keepalives")
 		cmd.Flags().Int(backend.FlagKeepalivedBufferSize, viper.GetInt(backend.FlagKeepalivedBufferSize), "number of incoming keepalives that can be buffered")
+		cmd.Flags().Bool(backend.FlagKeepalivedDisableRegistrationEvents, viper.GetBool(backend.FlagKeepalivedDisableRegistrationEvents), "disable the registration events of new entities")
+		cmd.Flags().StringSlice(backend.FlagKeepalivedRegistrationHandlers, viper.GetStringSlice(backend.FlagKeepalivedRegistrationHandlers), "handlers of the registration events of new entities")
 		cmd.Flags().Int(backend.FlagPipelinedWorkers, viper.GetInt(backend.FlagPipelinedWorkers), "number of workers spawned for handling events through the event pipeline")
 		cmd.Flags().Int(backend.FlagPipelinedBufferSize, viper.GetInt(backend.FlagPipelinedBufferSize), "number of events to handle that can be buffered")
+		cmd.Flags().String(backend.FlagPipelinedHandlerUser, viper.GetString(backend.FlagPipelinedHandlerUser), "user (name or uid) to execute pipe handlers as")
+		cmd.Flags().String(backend.FlagPipelinedHandlerGroup, viper.GetString(backend.FlagPipelinedHandlerGroup), "group (name or gid) to execute pipe handlers as")
+		cmd.Flags().Int(backend.FlagPipelinedHandlerCPUTimeLimit, viper.GetInt(backend.FlagPipelinedHandlerCPUTimeLimit), "maximum CPU time in seconds for pipe handlers (0 for unlimited)")
+		cmd.Flags().Int64(backend.FlagPipelinedHandlerMemoryLimit, viper.GetInt64(backend.FlagPipelinedHandlerMemoryLimit), "maximum virtual memory in bytes for pipe handlers (0 for unlimited)")
+		cmd.Flags().Int64(backend.FlagPipelinedHandlerMaxOutputSize, viper.GetInt64(backend.FlagPipelinedHandlerMaxOutputSize), "maximum output size in bytes retained from pipe handlers (0 for unlimited)")
+		cmd.Flags().Uint32(backend.FlagPipelinedHandlerMaxConcurrent, viper.GetUint32(backend.FlagPipelinedHandlerMaxConcurrent), "default maximum number of concurrent executions of a handler, for the handlers that don't set max_concurrent (0 for unlimited)")
+		cmd.Flags().Int(backend.FlagPipelinedDeduplicationWindow, viper.GetInt(backend.FlagPipelinedDeduplicationWindow), "number of seconds during which the deduplicate filter denies the events identical to the last one handled")
+		cmd.Flags().String(backend.FlagPipelinedHandlerProxyURL, viper.GetString(backend.FlagPipelinedHandlerProxyURL), "URL of the HTTP proxy used by the built-in HTTP handlers (defaults to the proxy environment variables)")
+		cmd.Flags().StringSlice(backend.FlagPipelinedHandlerNoProxy, viper.GetStringSlice(backend.FlagPipelinedHandlerNoProxy), "hosts the built-in HTTP handlers reach without the proxy")
+		cmd.Flags().String(backend.FlagPipelinedHandlerTrustedCAFile, viper.GetString(backend.FlagPipelinedHandlerTrustedCAFile), "TLS CA certificate bundle in PEM format trusted by the built-in HTTP handlers")
+		cmd.Flags().Float64(backend.FlagPipelinedStormMaxEventRate, viper.GetFloat64(backend.FlagPipelinedStormMaxEventRate), "number of events per second of a namespace received by the backend above which an event storm is detected, batching its events into summary events (0 to disable)")
+		cmd.Flags().Float64(backend.FlagPipelinedStormMaxFailingPercent, viper.GetFloat64(backend.FlagPipelinedStormMaxFailingPercent), "percentage of the entities failing the same check above which an event storm is detected (0 to disable)")
+		cmd.Flags().Int(backend.FlagPipelinedStormMinEntities, viper.GetInt(backend.FlagPipelinedStormMinEntities), "minimum number of entities running a check for the failing percentage of event storms to apply")
+		cmd.Flags().Int(backend.FlagPipelinedStormWindow, viper.GetInt(backend.FlagPipelinedStormWindow), "number of seconds over which event storms are detected, and interval of their summary events")
+		cmd.Flags().Int(backend.FlagShutdownTimeout, viper.GetInt(backend.FlagShutdownTimeout), "number of seconds eventd and pipelined each spend handling their buffered events on shutdown (0 to wait until they are all handled)")
 		cmd.Flags().Int(backend.FlagAgentWriteTimeout, viper.GetInt(backend.FlagAgentWriteTimeout), "timeout in seconds for agent writes")
+		cmd.Flags().Int(backend.FlagAgentMaxEventSize, viper.GetInt(backend.FlagAgentMaxEventSize), "maximum size in bytes of events and keepalives accepted from agents (0 for unlimited)")
+		cmd.Flags().Int(backend.FlagAgentMaxCheckOutputSize, viper.GetInt(backend.FlagAgentMaxCheckOutputSize), "maximum size in bytes of check output accepted from agents (0 for unlimited)")
+		cmd.Flags().Int(backend.FlagAgentReplayMaxAge, viper.GetInt(backend.FlagAgentReplayMaxAge), "maximum age in seconds of the missed check requests replayed to reconnecting agents (0 to disable)")
+		cmd.Flags().Int(backend.FlagAgentMaxSessions, viper.GetInt(backend.FlagAgentMaxSessions), "maximum number of concurrent agent sessions, beyond which agents are told to connect to another backend (0 for unlimited)")
+		cmd.Flags().StringSlice(backend.FlagAgentVerificationKeys, viper.GetStringSlice(backend.FlagAgentVerificationKeys), "agent=path pairs of the files of the ed25519 public keys or HMAC secrets verifying the signatures of the messages of each agent, which must then sign them")
+		cmd.Flags().String(backend.FlagEC2DeregistrationQueueURL, viper.GetString(backend.FlagEC2DeregistrationQueueURL), "URL of the SQS queue receiving EC2 instance state-change notifications, used to deregister the entities of terminated instances")
+		cmd.Flags().String(backend.FlagEC2DeregistrationRegion, viper.GetString(backend.FlagEC2DeregistrationRegion), "AWS region of the EC2 deregistration SQS queue (defaults to the region of the queue URL)")
+		cmd.Flags().StringSlice(backend.FlagEC2DeregistrationStates, viper.GetStringSlice(backend.FlagEC2DeregistrationStates), "EC2 instance states that cause entities to be deregistered")
+		cmd.Flags().Float64(backend.FlagAPIListRateLimit, viper.GetFloat64(backend.FlagAPIListRateLimit), "maximum number of requests per second, per user or IP address, to the API routes listing resources (0 for unlimited)")
+		cmd.Flags().Float64(backend.FlagAPIReadRateLimit, viper.GetFloat64(backend.FlagAPIReadRateLimit), "maximum number of requests per second, per user or IP address, to the API routes retrieving a single resource (0 for unlimited)")
+		cmd.Flags().Float64(backend.FlagAPIWriteRateLimit, viper.GetFloat64(backend.FlagAPIWriteRateLimit), "maximum number of requests per second, per user or IP address, to the API routes modifying resources (0 for unlimited)")
+		cmd.Flags().Int(backend.FlagAPIBurstLimit, viper.GetInt(backend.FlagAPIBurstLimit), "maximum number of requests a user or IP address can send at once to rate limited API routes")
+		cmd.Flags().Int(backend.FlagAPIMaxConcurrentRequests, viper.GetInt(backend.FlagAPIMaxConcurrentRequests), "maximum number of API requests processed concurrently (0 for unlimited)")
+		cmd.Flags().String(backend.FlagAPIUnixSocket, viper.GetString(backend.FlagAPIUnixSocket), "path of a unix socket on which the API is also served, without authentication, to the user running the backend")
+		cmd.Flags().StringSlice(backend.FlagReplicatorSecondaries, viper.GetStringSlice(backend.FlagReplicatorSecondaries), "secondary cluster, as name=url, into which resources are replicated (repeat the name for each etcd client URL of a cluster)")
+		cmd.Flags().StringSlice(backend.FlagReplicatorResources, viper.GetStringSlice(backend.FlagReplicatorResources), "resource types replicated into the secondary clusters (assets, checks, filters, handlers, hooks, mutators)")
+		cmd.Flags().String(backend.FlagReplicatorConflictPolicy, viper.GetString(backend.FlagReplicatorConflictPolicy), "policy applied to resources modified in the secondary clusters ("+strings.Join(replicatord.ConflictPolicies, ", ")+")")
+		cmd.Flags().String(backend.FlagEventLogFile, viper.GetString(backend.FlagEventLogFile), "path of a file, or named pipe, to which every processed event is written as newline-delimited JSON")
+		cmd.Flags().Int64(backend.FlagEventLogMaxSize, viper.GetInt64(backend.FlagEventLogMaxSize), "size in bytes after which the event log file is rotated (0 to never rotate)")
+		cmd.Flags().Int(backend.FlagEventLogMaxBackups, viper.GetInt(backend.FlagEventLogMaxBackups), "number of rotated event log files kept")
+		cmd.Flags().String(backend.FlagEventLogKafkaURL, viper.GetString(backend.FlagEventLogKafkaURL), "URL of a Kafka REST Proxy to which every processed event is published")
+		cmd.Flags().String(backend.FlagEventLogKafkaTopic, viper.GetString(backend.FlagEventLogKafkaTopic), "Kafka topic to which every processed event is published")
+		cmd.Flags().Int(backend.FlagEventLogBufferSize, viper.GetInt(backend.FlagEventLogBufferSize), "number of events buffered before the event log drops them")
+		cmd.Flags().String(backend.FlagEventArchiveURL, viper.GetString(backend.FlagEventArchiveURL), "path or file:// URL of a file, or s3://bucket/prefix URL, to which the events are archived as newline-delimited JSON once deleted (disabled if empty)")
+		cmd.Flags().String(backend.FlagEventArchiveRegion, viper.GetString(backend.FlagEventArchiveRegion), "AWS region of the S3 bucket of the event archive (defaults to the AWS_REGION environment variable)")
+		cmd.Flags().String(backend.FlagSecretsEnvPrefix, viper.GetString(backend.FlagSecretsEnvPrefix), "prefix of the backend environment variables from which the env secrets provider reads the secrets")
+		cmd.Flags().String(backend.FlagSecretsVaultAddress, viper.GetString(backend.FlagSecretsVaultAddress), "URL of the Vault server from which the vault secrets provider reads the secrets (the provider is disabled if empty)")
+		cmd.Flags().String(backend.FlagSecretsVaultToken, viper.GetString(backend.FlagSecretsVaultToken), "token authenticating the backend with Vault (preferably set with the SENSU_BACKEND_SECRETS_VAULT_TOKEN environment variable)")
+		cmd.Flags().String(backend.FlagStoreEncryptionKeyFile, viper.GetString(backend.FlagStoreEncryptionKeyFile), "path to the file holding the base64 encoded 256-bit key encrypting the events and entities in the store (disabled if empty)")
+		cmd.Flags().StringSlice(backend.FlagStoreEncryptionPreviousKeyFiles, viper.GetStringSlice(backend.FlagStoreEncryptionPreviousKeyFiles), "paths to the files holding the previous store encryption keys, still needed to read the values they encrypted")
 		cmd.Flags().String(backend.FlagJWTPrivateKeyFile, viper.GetString(backend.FlagJWTPrivateKeyFile), "path to the PEM-encoded private key to use to sign JWTs")
 		cmd.Flags().String(backend.FlagJWTPublicKeyFile, viper.GetString(backend.FlagJWTPublicKeyFile), "path to the PEM-encoded public key to use to verify JWT signatures")
+		cmd.Flags().String(backend.FlagJWTSecretFile, viper.GetString(backend.FlagJWTSecretFile), "path to the file holding the base64 encoded secret to use to sign JWTs with HMAC, instead of the secret generated and stored in etcd")
+		cmd.Flags().StringSlice(backend.FlagJWTVerificationKeyFiles, viper.GetStringSlice(backend.FlagJWTVerificationKeyFiles), "paths to the files holding the previous PEM-encoded public keys or base64 encoded secrets still accepted to verify JWT signatures")
 
 		// Etcd server flags
 		cmd.Flags().StringSlice(flagEtcdPeerURLs, viper.GetStringSlice(flagEtcdPeerURLs), "list of URLs to listen on for peer traffic")
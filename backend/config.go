@@ -3,6 +3,8 @@ package backend
 import (
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/etcd"
+	"github.com/sensu/sensu-go/backend/store/encryption"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -19,25 +21,203 @@ const (
 	FlagEventdWorkers = "eventd-workers"
 	// FlagEventdBufferSize defines the buffer size for eventd
 	FlagEventdBufferSize = "eventd-buffer-size"
+	// FlagEventdMaxClockSkew defines the allowed skew, in seconds, between the
+	// timestamps of the events and the clock of the backend
+	FlagEventdMaxClockSkew = "eventd-max-clock-skew"
 	// FlagKeepalivedWorkers defines the number of workers for keepalived
 	FlagKeepalivedWorkers = "keepalived-workers"
 	// FlagKeepalivedBufferSize defines buffer size for keepalived
 	FlagKeepalivedBufferSize = "keepalived-buffer-size"
+	// FlagKeepalivedDisableRegistrationEvents disables the registration
+	// events of new entities
+	FlagKeepalivedDisableRegistrationEvents = "keepalived-disable-registration-events"
+	// FlagKeepalivedRegistrationHandlers defines the handlers of the
+	// registration events
+	FlagKeepalivedRegistrationHandlers = "keepalived-registration-handlers"
 	// FlagPipelinedWorkers defines the number of workers for pipelined
 	FlagPipelinedWorkers = "pipelined-workers"
 	// FlagPipelinedBufferSize defines the buffer size for pipelined
 	FlagPipelinedBufferSize = "pipelined-buffer-size"
+	// FlagPipelinedHandlerUser defines the user pipe handlers are executed as
+	FlagPipelinedHandlerUser = "pipelined-handler-user"
+	// FlagPipelinedHandlerGroup defines the group pipe handlers are executed as
+	FlagPipelinedHandlerGroup = "pipelined-handler-group"
+	// FlagPipelinedHandlerCPUTimeLimit defines the maximum CPU time, in
+	// seconds, of a pipe handler
+	FlagPipelinedHandlerCPUTimeLimit = "pipelined-handler-cpu-time-limit"
+	// FlagPipelinedHandlerMemoryLimit defines the maximum virtual memory, in
+	// bytes, of a pipe handler
+	FlagPipelinedHandlerMemoryLimit = "pipelined-handler-memory-limit"
+	// FlagPipelinedHandlerMaxOutputSize defines the maximum output size, in
+	// bytes, retained from a pipe handler
+	FlagPipelinedHandlerMaxOutputSize = "pipelined-handler-max-output-size"
+	// FlagPipelinedHandlerMaxConcurrent defines the default maximum number of
+	// concurrent executions of a handler
+	FlagPipelinedHandlerMaxConcurrent = "pipelined-handler-max-concurrent"
+	// FlagPipelinedDeduplicationWindow defines the period, in seconds, during
+	// which the deduplicate filter denies duplicate events
+	FlagPipelinedDeduplicationWindow = "pipelined-deduplication-window"
+	// FlagPipelinedHandlerProxyURL defines the URL of the HTTP proxy used by
+	// the built-in HTTP handlers
+	FlagPipelinedHandlerProxyURL = "pipelined-handler-proxy-url"
+	// FlagPipelinedHandlerNoProxy defines the hosts the built-in HTTP
+	// handlers reach without the proxy
+	FlagPipelinedHandlerNoProxy = "pipelined-handler-no-proxy"
+	// FlagPipelinedHandlerTrustedCAFile defines the CA certificates trusted
+	// by the built-in HTTP handlers
+	FlagPipelinedHandlerTrustedCAFile = "pipelined-handler-trusted-ca-file"
+	// FlagPipelinedStormMaxEventRate defines the number of events per second
+	// above which an event storm is detected
+	FlagPipelinedStormMaxEventRate = "pipelined-storm-max-event-rate"
+	// FlagPipelinedStormMaxFailingPercent defines the percentage of the
+	// entities failing the same check above which an event storm is detected
+	FlagPipelinedStormMaxFailingPercent = "pipelined-storm-max-failing-percent"
+	// FlagPipelinedStormMinEntities defines the minimum number of entities
+	// running a check for the failing percentage to apply
+	FlagPipelinedStormMinEntities = "pipelined-storm-min-entities"
+	// FlagPipelinedStormWindow defines the period, in seconds, over which
+	// event storms are detected
+	FlagPipelinedStormWindow = "pipelined-storm-window"
+	// FlagShutdownTimeout defines the time, in seconds, eventd and pipelined
+	// spend handling their buffered events when the backend shuts down
+	FlagShutdownTimeout = "shutdown-timeout"
 
 	// FlagAgentWriteTimeout specifies the time in seconds to wait before
 	// giving up on a write to an agent and disposing of the connection.
 	FlagAgentWriteTimeout = "agent-write-timeout"
 
+	// FlagAgentMaxEventSize specifies the maximum size in bytes of events and
+	// keepalives accepted from agents.
+	FlagAgentMaxEventSize = "agent-max-event-size"
+
+	// FlagAgentMaxCheckOutputSize specifies the maximum size in bytes of
+	// check output accepted from agents.
+	FlagAgentMaxCheckOutputSize = "agent-max-check-output-size"
+
+	// FlagAgentReplayMaxAge specifies the maximum age in seconds of the check
+	// requests replayed to agents reconnecting after missing them.
+	FlagAgentReplayMaxAge = "agent-replay-max-age"
+
+	// FlagAgentMaxSessions specifies the maximum number of concurrent agent
+	// sessions of the backend.
+	FlagAgentMaxSessions = "agent-max-sessions"
+
+	// FlagAgentVerificationKeys specifies the files of the keys verifying the
+	// signatures of the messages of the agents, by agent name.
+	FlagAgentVerificationKeys = "agent-verification-keys"
+
+	// FlagEC2DeregistrationQueueURL specifies the URL of the SQS queue
+	// receiving EC2 instance state-change notifications.
+	FlagEC2DeregistrationQueueURL = "ec2-deregistration-queue-url"
+
+	// FlagEC2DeregistrationRegion specifies the AWS region of the SQS queue.
+	FlagEC2DeregistrationRegion = "ec2-deregistration-region"
+
+	// FlagEC2DeregistrationStates specifies the EC2 instance states that
+	// cause entities to be deregistered.
+	FlagEC2DeregistrationStates = "ec2-deregistration-states"
+
+	// FlagAPIListRateLimit specifies the maximum number of requests per
+	// second, per client, to the API routes listing resources.
+	FlagAPIListRateLimit = "api-list-rate-limit"
+
+	// FlagAPIReadRateLimit specifies the maximum number of requests per
+	// second, per client, to the API routes retrieving a single resource.
+	FlagAPIReadRateLimit = "api-read-rate-limit"
+
+	// FlagAPIWriteRateLimit specifies the maximum number of requests per
+	// second, per client, to the API routes modifying resources.
+	FlagAPIWriteRateLimit = "api-write-rate-limit"
+
+	// FlagAPIBurstLimit specifies the maximum number of requests a client can
+	// send at once to rate limited API routes.
+	FlagAPIBurstLimit = "api-burst-limit"
+
+	// FlagAPIMaxConcurrentRequests specifies the maximum number of API
+	// requests processed concurrently.
+	FlagAPIMaxConcurrentRequests = "api-max-concurrent-requests"
+
+	// FlagAPIUnixSocket specifies the path of a unix socket on which the API
+	// is additionally served, without authentication.
+	FlagAPIUnixSocket = "api-unix-socket"
+
+	// FlagReplicatorSecondaries specifies the secondary clusters, as
+	// name=url values, into which resources are replicated.
+	FlagReplicatorSecondaries = "replicator-secondary"
+
+	// FlagReplicatorResources specifies the resource types replicated into
+	// the secondary clusters.
+	FlagReplicatorResources = "replicator-resources"
+
+	// FlagReplicatorConflictPolicy specifies how resources modified in the
+	// secondary clusters are handled.
+	FlagReplicatorConflictPolicy = "replicator-conflict-policy"
+
+	// FlagEventLogFile specifies the path of the file, or named pipe, to
+	// which every processed event is written.
+	FlagEventLogFile = "event-log-file"
+
+	// FlagEventLogMaxSize specifies the size in bytes after which the event
+	// log file is rotated.
+	FlagEventLogMaxSize = "event-log-max-size"
+
+	// FlagEventLogMaxBackups specifies the number of rotated event log files
+	// kept.
+	FlagEventLogMaxBackups = "event-log-max-backups"
+
+	// FlagEventLogKafkaURL specifies the URL of the Kafka REST Proxy to which
+	// every processed event is published.
+	FlagEventLogKafkaURL = "event-log-kafka-rest-url"
+
+	// FlagEventLogKafkaTopic specifies the Kafka topic to which every
+	// processed event is published.
+	FlagEventLogKafkaTopic = "event-log-kafka-topic"
+
+	// FlagEventLogBufferSize specifies the number of events buffered before
+	// the event log drops them.
+	FlagEventLogBufferSize = "event-log-buffer-size"
+
+	// FlagEventArchiveURL specifies the file or the S3 bucket to which the
+	// events are archived before being deleted.
+	FlagEventArchiveURL = "event-archive-url"
+
+	// FlagEventArchiveRegion specifies the AWS region of the S3 bucket to
+	// which the events are archived.
+	FlagEventArchiveRegion = "event-archive-region"
+
+	// FlagSecretsEnvPrefix specifies the prefix of the backend environment
+	// variables read by the env secrets provider.
+	FlagSecretsEnvPrefix = "secrets-env-prefix"
+
+	// FlagSecretsVaultAddress specifies the URL of the Vault server queried by
+	// the vault secrets provider.
+	FlagSecretsVaultAddress = "secrets-vault-address"
+
+	// FlagSecretsVaultToken specifies the token authenticating the backend
+	// with Vault.
+	FlagSecretsVaultToken = "secrets-vault-token"
+
+	// FlagStoreEncryptionKeyFile specifies the path of the file holding the
+	// base64 encoded key encrypting the events and entities in the store.
+	FlagStoreEncryptionKeyFile = "store-encryption-key-file"
+
+	// FlagStoreEncryptionPreviousKeyFiles specifies the paths of the files
+	// holding the keys used before a key rotation, which are still needed to
+	// read the values they encrypted.
+	FlagStoreEncryptionPreviousKeyFiles = "store-encryption-previous-key-files"
+
 	// FlagJWTPrivateKeyFile defines the path to the private key file for JWT
 	// signatures
 	FlagJWTPrivateKeyFile = "jwt-private-key-file"
 	// FlagJWTPublicKeyFile defines the path to the public key file for JWT
 	// signatures validation
 	FlagJWTPublicKeyFile = "jwt-public-key-file"
+	// FlagJWTSecretFile defines the path to the file holding the secret for
+	// JWT HMAC signatures
+	FlagJWTSecretFile = "jwt-secret-file"
+	// FlagJWTVerificationKeyFiles defines the paths to the files holding the
+	// previous keys still accepted for JWT signatures validation
+	FlagJWTVerificationKeyFiles = "jwt-verification-key-files"
 )
 
 // Config specifies a Backend configuration.
@@ -52,9 +232,21 @@ type Config struct {
 	AgentTLSOptions   *corev2.TLSOptions
 	AgentWriteTimeout int
 
+	AgentMaxEventSize       int
+	AgentMaxCheckOutputSize int
+	AgentReplayMaxAge       int
+	AgentMaxSessions        int
+	AgentVerificationKeys   []string
+
 	// Apid Configuration
-	APIListenAddress string
-	APIURL           string
+	APIListenAddress         string
+	APIUnixSocket            string
+	APIURL                   string
+	APIListRateLimit         rate.Limit
+	APIReadRateLimit         rate.Limit
+	APIWriteRateLimit        rate.Limit
+	APIBurstLimit            int
+	APIMaxConcurrentRequests int
 
 	// Dashboardd Configuration
 	DashboardHost        string
@@ -65,6 +257,39 @@ type Config struct {
 	// Pipelined Configuration
 	DeregistrationHandler string
 
+	// Lifecycled Configuration
+	EC2DeregistrationQueueURL string
+	EC2DeregistrationRegion   string
+	EC2DeregistrationStates   []string
+
+	// Replicatord Configuration
+	ReplicatorSecondaries    []string
+	ReplicatorResources      []string
+	ReplicatorConflictPolicy string
+
+	// Eventlogd Configuration
+	EventLogFile       string
+	EventLogMaxSize    int64
+	EventLogMaxBackups int
+	EventLogKafkaURL   string
+	EventLogKafkaTopic string
+	EventLogBufferSize int
+
+	// Event archive Configuration
+	EventArchiveURL    string
+	EventArchiveRegion string
+
+	// Secrets providers Configuration
+	SecretsEnvPrefix    string
+	SecretsVaultAddress string
+	SecretsVaultToken   string
+
+	// Store encryption Configuration. StoreEncryptionKeyWrapper takes
+	// precedence over the key files, e.g. to use a key management service.
+	StoreEncryptionKeyFile          string
+	StoreEncryptionPreviousKeyFiles []string
+	StoreEncryptionKeyWrapper       encryption.KeyWrapper
+
 	// Etcd configuration
 	EtcdAdvertiseClientURLs      []string
 	EtcdInitialAdvertisePeerURLs []string
@@ -0,0 +1,394 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sensu/sensu-go/util/retry"
+	"github.com/sirupsen/logrus"
+)
+
+var logger = logrus.WithFields(logrus.Fields{
+	"component": "supervisor",
+})
+
+const (
+	// DefaultRestartDelay is the default delay before restarting a crashed
+	// daemon.
+	DefaultRestartDelay = time.Second
+
+	// DefaultMaxRestartDelay is the default maximum delay before restarting a
+	// daemon crashing repeatedly.
+	DefaultMaxRestartDelay = time.Minute
+)
+
+// Spec describes a daemon managed by a Supervisor.
+type Spec struct {
+	// Name is the name of the daemon.
+	Name string
+
+	// DependsOn are the names of the daemons started before this one. The
+	// daemon is restarted along with them.
+	DependsOn []string
+
+	// New creates the daemon. Daemons can't be started again once stopped, so
+	// it is called again to restart the daemon after a crash.
+	New func() (Daemon, error)
+
+	// Ready, if not nil, blocks the start of the daemon until it returns,
+	// e.g. until the store is reachable. The daemon isn't started if it
+	// returns an error.
+	Ready func(context.Context) error
+
+	// Critical daemons aren't restarted when they crash, the crash is
+	// reported by the Err channel of the supervisor instead. This is meant
+	// for the daemons shared by components created once, which would keep
+	// using the crashed daemon.
+	Critical bool
+}
+
+// StartError is returned by Supervisor.Start when a daemon can't be started.
+type StartError struct {
+	Name string
+	Err  error
+}
+
+func (e StartError) Error() string {
+	return fmt.Sprintf("error starting %s: %s", e.Name, e.Err)
+}
+
+// SupervisorConfig configures a Supervisor.
+type SupervisorConfig struct {
+	// RestartDelay is the delay before restarting a crashed daemon. It is
+	// doubled every time the restart fails, up to MaxRestartDelay.
+	RestartDelay time.Duration
+
+	// MaxRestartDelay is the maximum delay before restarting a daemon.
+	MaxRestartDelay time.Duration
+
+	// MaxRestarts is the number of consecutive failed attempts to restart a
+	// daemon after which the supervisor gives up and reports a terminal
+	// error. 0 means unlimited.
+	MaxRestarts int
+}
+
+// Supervisor starts daemons in the order of their dependencies, stops them in
+// reverse order, and restarts the daemons that crash, along with the daemons
+// depending on them, rather than letting a single crash take down the
+// process.
+type Supervisor struct {
+	config SupervisorConfig
+	specs  []Spec
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	crashes chan crash
+	errChan chan error
+
+	mu      sync.Mutex
+	running map[string]*instance
+}
+
+type instance struct {
+	daemon Daemon
+	done   chan struct{}
+}
+
+type crash struct {
+	name     string
+	instance *instance
+	err      error
+}
+
+// NewSupervisor creates a Supervisor for the daemons described by specs. An
+// error is returned if a dependency is unknown or if the dependencies are
+// circular.
+func NewSupervisor(config SupervisorConfig, specs ...Spec) (*Supervisor, error) {
+	if config.RestartDelay <= 0 {
+		config.RestartDelay = DefaultRestartDelay
+	}
+	if config.MaxRestartDelay < config.RestartDelay {
+		config.MaxRestartDelay = DefaultMaxRestartDelay
+	}
+	sorted, err := sortSpecs(specs)
+	if err != nil {
+		return nil, err
+	}
+	return &Supervisor{
+		config:  config,
+		specs:   sorted,
+		crashes: make(chan crash, len(sorted)),
+		errChan: make(chan error, 1),
+		running: make(map[string]*instance),
+	}, nil
+}
+
+// sortSpecs sorts specs in the order of their dependencies, keeping the order
+// of the independent daemons.
+func sortSpecs(specs []Spec) ([]Spec, error) {
+	index := make(map[string]int, len(specs))
+	for i, spec := range specs {
+		if _, ok := index[spec.Name]; ok {
+			return nil, fmt.Errorf("daemon %q is declared twice", spec.Name)
+		}
+		index[spec.Name] = i
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(specs))
+	sorted := make([]Spec, 0, len(specs))
+
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("circular dependency on daemon %q", specs[i].Name)
+		}
+		state[i] = visiting
+		for _, dep := range specs[i].DependsOn {
+			j, ok := index[dep]
+			if !ok {
+				return fmt.Errorf("daemon %q depends on unknown daemon %q", specs[i].Name, dep)
+			}
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		state[i] = visited
+		sorted = append(sorted, specs[i])
+		return nil
+	}
+	for i := range specs {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return sorted, nil
+}
+
+// Start starts the daemons in the order of their dependencies, and supervises
+// them until Stop is called. The daemons already started are stopped if one
+// of them can't be started.
+func (s *Supervisor) Start(ctx context.Context) error {
+	s.ctx, s.cancel = context.WithCancel(ctx)
+
+	for _, spec := range s.specs {
+		if err := s.start(spec); err != nil {
+			s.cancel()
+			_ = s.stop(s.specs)
+			return err
+		}
+	}
+
+	s.wg.Add(1)
+	go s.supervise()
+
+	return nil
+}
+
+// Stop stops the supervision and the daemons, in the reverse order of their
+// dependencies.
+func (s *Supervisor) Stop() error {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+	return s.stop(s.specs)
+}
+
+// Err returns a channel receiving an error when a critical daemon crashes, or
+// when a crashed daemon can't be restarted after MaxRestarts attempts.
+func (s *Supervisor) Err() <-chan error {
+	return s.errChan
+}
+
+// Name returns the name of the supervisor.
+func (s *Supervisor) Name() string {
+	return "supervisor"
+}
+
+// Daemons returns the running daemons.
+func (s *Supervisor) Daemons() []Daemon {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	daemons := make([]Daemon, 0, len(s.running))
+	for _, spec := range s.specs {
+		if inst, ok := s.running[spec.Name]; ok {
+			daemons = append(daemons, inst.daemon)
+		}
+	}
+	return daemons
+}
+
+// start creates and starts the daemon of spec, once it is ready.
+func (s *Supervisor) start(spec Spec) error {
+	if spec.Ready != nil {
+		logger.WithField("daemon", spec.Name).Debug("waiting for the daemon to be ready")
+		if err := spec.Ready(s.ctx); err != nil {
+			return StartError{Name: spec.Name, Err: fmt.Errorf("not ready: %s", err)}
+		}
+	}
+	d, err := spec.New()
+	if err != nil {
+		return StartError{Name: spec.Name, Err: fmt.Errorf("initialization failed: %s", err)}
+	}
+	logger.WithField("daemon", spec.Name).Info("starting daemon")
+	if err := d.Start(); err != nil {
+		return StartError{Name: spec.Name, Err: err}
+	}
+
+	inst := &instance{daemon: d, done: make(chan struct{})}
+	s.mu.Lock()
+	s.running[spec.Name] = inst
+	s.mu.Unlock()
+
+	s.wg.Add(1)
+	go s.watch(spec.Name, inst)
+	return nil
+}
+
+// watch reports the crash of a daemon, unless it is stopped first.
+func (s *Supervisor) watch(name string, inst *instance) {
+	defer s.wg.Done()
+	select {
+	case err := <-inst.daemon.Err():
+		select {
+		case <-inst.done:
+			// The daemon was stopped on purpose
+			return
+		default:
+		}
+		if err == nil {
+			err = errors.New("daemon stopped unexpectedly")
+		}
+		select {
+		case s.crashes <- crash{name: name, instance: inst, err: err}:
+		case <-s.ctx.Done():
+		}
+	case <-inst.done:
+	case <-s.ctx.Done():
+	}
+}
+
+// stop stops the running daemons among specs, in reverse order.
+func (s *Supervisor) stop(specs []Spec) (err error) {
+	for i := len(specs) - 1; i >= 0; i-- {
+		name := specs[i].Name
+		s.mu.Lock()
+		inst, ok := s.running[name]
+		delete(s.running, name)
+		s.mu.Unlock()
+		if !ok {
+			continue
+		}
+		close(inst.done)
+		logger.WithField("daemon", name).Info("shutting down daemon")
+		if e := inst.daemon.Stop(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// supervise restarts the crashed daemons until the supervision is stopped.
+func (s *Supervisor) supervise() {
+	defer s.wg.Done()
+	for {
+		select {
+		case c := <-s.crashes:
+			s.mu.Lock()
+			current := s.running[c.name] == c.instance
+			s.mu.Unlock()
+			if !current {
+				// The daemon was already restarted along with a dependency
+				continue
+			}
+			if s.critical(c.name) {
+				s.errChan <- fmt.Errorf("%s crashed: %s", c.name, c.err)
+				return
+			}
+			logger.WithField("daemon", c.name).WithError(c.err).Error("daemon crashed, restarting it")
+			if err := s.restart(c.name); err != nil {
+				if s.ctx.Err() == nil {
+					s.errChan <- fmt.Errorf("could not restart %s: %s", c.name, err)
+				}
+				return
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// restart stops the daemon name and the daemons depending on it, and starts
+// them again with an exponential backoff.
+func (s *Supervisor) restart(name string) error {
+	affected := s.dependents(name)
+	if err := s.stop(affected); err != nil {
+		logger.WithField("daemon", name).WithError(err).Warn("error stopping the crashed daemons")
+	}
+
+	backoff := retry.ExponentialBackoff{
+		Ctx:                  s.ctx,
+		InitialDelayInterval: s.config.RestartDelay,
+		MaxDelayInterval:     s.config.MaxRestartDelay,
+	}
+	if s.config.MaxRestarts > 0 {
+		backoff.MaxRetryAttempts = s.config.MaxRestarts + 1
+	}
+	return backoff.Retry(func(attempt int) (bool, error) {
+		if attempt == 0 {
+			// Wait for the restart delay before the first attempt too, so a
+			// daemon crashing right away isn't restarted in a tight loop
+			return false, nil
+		}
+		for _, spec := range affected {
+			if err := s.start(spec); err != nil {
+				logger.WithField("daemon", spec.Name).WithError(err).Error("could not restart daemon")
+				_ = s.stop(affected)
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+}
+
+// dependents returns the spec of the daemon name and the specs of the daemons
+// depending on it, directly or not, in the order of their dependencies.
+func (s *Supervisor) dependents(name string) []Spec {
+	affected := map[string]bool{name: true}
+	var specs []Spec
+	for _, spec := range s.specs {
+		if !affected[spec.Name] {
+			for _, dep := range spec.DependsOn {
+				if affected[dep] {
+					affected[spec.Name] = true
+					break
+				}
+			}
+		}
+		if affected[spec.Name] {
+			specs = append(specs, spec)
+		}
+	}
+	return specs
+}
+
+func (s *Supervisor) critical(name string) bool {
+	for _, spec := range s.specs {
+		if spec.Name == name {
+			return spec.Critical
+		}
+	}
+	return false
+}
@@ -0,0 +1,222 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testDaemon struct {
+	name  string
+	errCh chan error
+	log   *eventLog
+}
+
+func (d *testDaemon) Start() error {
+	d.log.add("start " + d.name)
+	return nil
+}
+
+func (d *testDaemon) Stop() error {
+	d.log.add("stop " + d.name)
+	return nil
+}
+
+func (d *testDaemon) Err() <-chan error {
+	return d.errCh
+}
+
+func (d *testDaemon) Name() string {
+	return d.name
+}
+
+type eventLog struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (l *eventLog) add(event string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events = append(l.events, event)
+}
+
+func (l *eventLog) get() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string{}, l.events...)
+}
+
+// testSpec returns the spec of a test daemon, and a function returning its
+// current instance.
+func testSpec(log *eventLog, name string, deps ...string) (Spec, func() *testDaemon) {
+	var mu sync.Mutex
+	var current *testDaemon
+	spec := Spec{
+		Name:      name,
+		DependsOn: deps,
+		New: func() (Daemon, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			current = &testDaemon{name: name, errCh: make(chan error, 1), log: log}
+			return current, nil
+		},
+	}
+	return spec, func() *testDaemon {
+		mu.Lock()
+		defer mu.Unlock()
+		return current
+	}
+}
+
+var testConfig = SupervisorConfig{
+	RestartDelay:    time.Millisecond,
+	MaxRestartDelay: 10 * time.Millisecond,
+}
+
+func TestSupervisorStartOrder(t *testing.T) {
+	log := &eventLog{}
+	api, _ := testSpec(log, "api", "store", "bus")
+	bus, _ := testSpec(log, "bus")
+	store, _ := testSpec(log, "store")
+
+	sup, err := NewSupervisor(testConfig, api, bus, store)
+	require.NoError(t, err)
+	require.NoError(t, sup.Start(context.Background()))
+	require.NoError(t, sup.Stop())
+
+	assert.Equal(t, []string{
+		"start store", "start bus", "start api",
+		"stop api", "stop bus", "stop store",
+	}, log.get())
+}
+
+func TestNewSupervisorInvalidDependencies(t *testing.T) {
+	log := &eventLog{}
+	a, _ := testSpec(log, "a", "b")
+	b, _ := testSpec(log, "b", "a")
+	c, _ := testSpec(log, "c", "d")
+
+	_, err := NewSupervisor(testConfig, a, b)
+	assert.Error(t, err, "circular dependency")
+
+	_, err = NewSupervisor(testConfig, c)
+	assert.Error(t, err, "unknown dependency")
+
+	_, err = NewSupervisor(testConfig, c, c)
+	assert.Error(t, err, "duplicate daemon")
+}
+
+func TestSupervisorStartFailure(t *testing.T) {
+	log := &eventLog{}
+	bus, _ := testSpec(log, "bus")
+	api := Spec{
+		Name:      "api",
+		DependsOn: []string{"bus"},
+		New: func() (Daemon, error) {
+			return nil, errors.New("bad config")
+		},
+	}
+
+	sup, err := NewSupervisor(testConfig, api, bus)
+	require.NoError(t, err)
+	err = sup.Start(context.Background())
+	require.Error(t, err)
+	startErr, ok := err.(StartError)
+	require.True(t, ok)
+	assert.Equal(t, "api", startErr.Name)
+
+	assert.Equal(t, []string{"start bus", "stop bus"}, log.get())
+}
+
+func TestSupervisorRestartsCrashedDaemon(t *testing.T) {
+	log := &eventLog{}
+	bus, _ := testSpec(log, "bus")
+	pipeline, currentPipeline := testSpec(log, "pipeline", "bus")
+	api, currentAPI := testSpec(log, "api", "pipeline")
+
+	sup, err := NewSupervisor(testConfig, bus, pipeline, api)
+	require.NoError(t, err)
+	require.NoError(t, sup.Start(context.Background()))
+	defer func() { _ = sup.Stop() }()
+
+	crashed := currentPipeline()
+	oldAPI := currentAPI()
+	crashed.errCh <- errors.New("crash")
+
+	require.Eventually(t, func() bool {
+		return currentAPI() != oldAPI && len(sup.Daemons()) == 3
+	}, time.Second, time.Millisecond)
+	assert.NotEqual(t, crashed, currentPipeline())
+	assert.Equal(t, []string{
+		"start bus", "start pipeline", "start api",
+		"stop api", "stop pipeline",
+		"start pipeline", "start api",
+	}, log.get())
+
+	select {
+	case err := <-sup.Err():
+		t.Fatalf("unexpected error: %s", err)
+	default:
+	}
+}
+
+func TestSupervisorCriticalCrash(t *testing.T) {
+	log := &eventLog{}
+	bus, currentBus := testSpec(log, "bus")
+	bus.Critical = true
+
+	sup, err := NewSupervisor(testConfig, bus)
+	require.NoError(t, err)
+	require.NoError(t, sup.Start(context.Background()))
+	defer func() { _ = sup.Stop() }()
+
+	currentBus().errCh <- errors.New("crash")
+
+	select {
+	case err := <-sup.Err():
+		assert.Contains(t, err.Error(), "bus crashed")
+	case <-time.After(time.Second):
+		t.Fatal("the crash of a critical daemon wasn't reported")
+	}
+}
+
+func TestSupervisorReadiness(t *testing.T) {
+	log := &eventLog{}
+	store, _ := testSpec(log, "store")
+	agentd, _ := testSpec(log, "agentd", "store")
+	ready := make(chan struct{})
+	agentd.Ready = func(ctx context.Context) error {
+		select {
+		case <-ready:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	sup, err := NewSupervisor(testConfig, store, agentd)
+	require.NoError(t, err)
+
+	started := make(chan error, 1)
+	go func() {
+		started <- sup.Start(context.Background())
+	}()
+
+	select {
+	case <-started:
+		t.Fatal("agentd was started before being ready")
+	case <-time.After(10 * time.Millisecond):
+	}
+	assert.Equal(t, []string{"start store"}, log.get())
+
+	close(ready)
+	require.NoError(t, <-started)
+	require.NoError(t, sup.Stop())
+	assert.Equal(t, []string{"start store", "start agentd", "stop agentd", "stop store"}, log.get())
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/sensu/sensu-go/backend/dashboardd/asset"
 	"github.com/sensu/sensu-go/dashboard"
 	"github.com/sensu/sensu-go/types"
+	"github.com/sensu/sensu-go/util/tlsreload"
 	"github.com/sirupsen/logrus"
 )
 
@@ -40,6 +41,8 @@ type Dashboardd struct {
 	httpServer *http.Server
 	logger     *logrus.Entry
 
+	tlsReloader *tlsreload.Reloader
+
 	Config
 	Assets *asset.Collection
 
@@ -74,6 +77,14 @@ func New(cfg Config, opts ...Option) (*Dashboardd, error) {
 	if err != nil {
 		return nil, err
 	}
+	if cfg.TLS != nil && cfg.TLS.CertFile != "" && cfg.TLS.KeyFile != "" {
+		// Reload the certificate when it is rotated
+		d.tlsReloader, err = tlsreload.New(cfg.TLS.CertFile, cfg.TLS.KeyFile, cfg.TLS.TrustedCAFile)
+		if err != nil {
+			return nil, err
+		}
+		d.tlsReloader.Configure(tlsServerConfig)
+	}
 
 	handler, err := httpRouter(cfg.APIDConfig, d)
 	if err != nil {
@@ -103,6 +114,14 @@ func (d *Dashboardd) Start() error {
 	if err != nil {
 		return fmt.Errorf("failed to start dashboardd: %s", err)
 	}
+	if d.tlsReloader != nil {
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			d.tlsReloader.Watch(d.stopping, tlsreload.DefaultInterval)
+		}()
+	}
+
 	d.wg.Add(1)
 
 	go func() {
@@ -0,0 +1,98 @@
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/google/uuid"
+)
+
+// DefaultReportInterval is the default interval at which a BackendReporter
+// records its report.
+const DefaultReportInterval = 10 * time.Second
+
+// BackendReporter records a report of the backend, such as its statistics, in
+// etcd at a regular interval, so that the reports of all the backends of the
+// cluster can be aggregated. The report is attached to a lease, so that it's
+// removed once the backend is gone.
+type BackendReporter struct {
+	client   *clientv3.Client
+	key      string
+	interval time.Duration
+	report   func() interface{}
+}
+
+// NewBackendReporter returns a new BackendReporter recording the report
+// returned by report, marshaled to JSON, under the given key prefix.
+func NewBackendReporter(client *clientv3.Client, prefix string, interval time.Duration, report func() interface{}) *BackendReporter {
+	return &BackendReporter{
+		client:   client,
+		key:      prefix + uuid.New().String(),
+		interval: interval,
+		report:   report,
+	}
+}
+
+// Key returns the key of the report of the backend.
+func (r *BackendReporter) Key() string {
+	return r.key
+}
+
+// Start records the report until ctx is done.
+func (r *BackendReporter) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		var lease clientv3.LeaseID
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			var err error
+			if lease, err = r.record(ctx, lease); err != nil && ctx.Err() == nil {
+				logger.WithError(err).WithField("key", r.key).Warn("couldn't record the report of the backend")
+			}
+		}
+	}()
+}
+
+// record records the report under the lease, which is granted if it's zero or
+// expired, and returns the lease.
+func (r *BackendReporter) record(ctx context.Context, lease clientv3.LeaseID) (clientv3.LeaseID, error) {
+	if lease != 0 {
+		if _, err := r.client.KeepAliveOnce(ctx, lease); err != nil {
+			lease = 0
+		}
+	}
+	if lease == 0 {
+		resp, err := r.client.Grant(ctx, int64(3*r.interval/time.Second))
+		if err != nil {
+			return 0, err
+		}
+		lease = resp.ID
+	}
+	report, err := json.Marshal(r.report())
+	if err != nil {
+		return lease, err
+	}
+	_, err = r.client.Put(ctx, r.key, string(report), clientv3.WithLease(lease))
+	return lease, err
+}
+
+// GetBackendReports returns the reports recorded under the key prefix by the
+// backends of the cluster, by key.
+func GetBackendReports(ctx context.Context, client *clientv3.Client, prefix string) (map[string][]byte, error) {
+	resp, err := client.Get(ctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, err
+	}
+	reports := make(map[string][]byte, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		reports[string(kv.Key)] = kv.Value
+	}
+	return reports, nil
+}
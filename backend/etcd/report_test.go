@@ -0,0 +1,51 @@
+// +build integration
+
+package etcd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackendReporter(t *testing.T) {
+	e, cleanup := NewTestEtcd(t)
+	defer cleanup()
+	client := e.NewEmbeddedClient()
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reporters := []*BackendReporter{
+		NewBackendReporter(client, "/sensu.io/test/", time.Second, func() interface{} { return 1 }),
+		NewBackendReporter(client, "/sensu.io/test/", time.Second, func() interface{} { return 2 }),
+	}
+	for _, reporter := range reporters {
+		lease, err := reporter.record(ctx, 0)
+		require.NoError(t, err)
+
+		// The lease is kept alive by the next records
+		next, err := reporter.record(ctx, lease)
+		require.NoError(t, err)
+		assert.Equal(t, lease, next)
+	}
+
+	reports, err := GetBackendReports(ctx, client, "/sensu.io/test/")
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, "1", string(reports[reporters[0].Key()]))
+	assert.Equal(t, "2", string(reports[reporters[1].Key()]))
+
+	// The report of a gone backend expires along with its lease
+	lease, err := reporters[0].record(ctx, 0)
+	require.NoError(t, err)
+	_, err = client.Revoke(ctx, lease)
+	require.NoError(t, err)
+	reports, err = GetBackendReports(ctx, client, "/sensu.io/test/")
+	require.NoError(t, err)
+	assert.Len(t, reports, 1)
+}
@@ -0,0 +1,103 @@
+// Package eventarchive archives the events deleted from the event store, e.g.
+// when they are discarded after their TTL or deleted through the API, as
+// newline-delimited JSON to a file or to an S3 bucket, so that their history
+// is preserved for post-incident analysis and compliance.
+package eventarchive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sirupsen/logrus"
+)
+
+// Archiver archives events.
+type Archiver interface {
+	// Archive archives a batch of events.
+	Archive(ctx context.Context, events []*corev2.Event) error
+}
+
+// New creates the archiver of the given destination, which is either the path
+// of a file, a file:// URL, or an s3://bucket/prefix URL. The region of the S3
+// bucket is read from the AWS_REGION environment variable if region is empty.
+// An S3-compatible service can be used instead of S3 with the endpoint
+// parameter of the URL, e.g. s3://bucket/prefix?endpoint=http://minio:9000.
+func New(destination, region string) (Archiver, error) {
+	u, err := url.Parse(destination)
+	if err != nil || u.Scheme == "" {
+		return newFileArchiver(destination), nil
+	}
+
+	switch u.Scheme {
+	case "file":
+		return newFileArchiver(u.Path), nil
+	case "s3":
+		if u.Host == "" {
+			return nil, fmt.Errorf("no bucket in event archive URL %q", destination)
+		}
+		if region == "" {
+			region = os.Getenv("AWS_REGION")
+		}
+		if region == "" {
+			return nil, fmt.Errorf("no AWS region configured for event archive %q", destination)
+		}
+		return newS3Archiver(u.Host, strings.Trim(u.Path, "/"), region, u.Query().Get("endpoint")), nil
+	}
+	return nil, fmt.Errorf("unsupported event archive URL %q, a file path, file:// or s3:// URL is expected", destination)
+}
+
+// EventStore archives the events deleted from the event store it wraps.
+type EventStore struct {
+	store.EventStore
+
+	// Writer archives the deleted events asynchronously.
+	Writer *Writer
+}
+
+// DeleteEventByEntityCheck deletes the event, then archives it
+// asynchronously. The deletion never waits for, nor depends on, the archiving
+// of the event.
+func (s *EventStore) DeleteEventByEntityCheck(ctx context.Context, entity, check string) error {
+	event, err := s.EventStore.GetEventByEntityCheck(ctx, entity, check)
+	if err != nil {
+		return err
+	}
+	if err := s.EventStore.DeleteEventByEntityCheck(ctx, entity, check); err != nil {
+		return err
+	}
+	if event != nil {
+		s.Writer.Write(event)
+	}
+	return nil
+}
+
+func eventFields(event *corev2.Event) logrus.Fields {
+	fields := logrus.Fields{"namespace": event.Namespace}
+	if event.Entity != nil {
+		fields["entity"] = event.Entity.Name
+	}
+	if event.Check != nil {
+		fields["check"] = event.Check.Name
+	}
+	return fields
+}
+
+// marshal encodes the events as newline-delimited JSON.
+func marshal(events []*corev2.Event) ([]byte, error) {
+	var buf []byte
+	for _, event := range events {
+		b, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, b...)
+		buf = append(buf, '\n')
+	}
+	return buf, nil
+}
@@ -0,0 +1,194 @@
+package eventarchive
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/sensu/sensu-go/util/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeArchiver struct {
+	archived []*corev2.Event
+	err      error
+}
+
+func (a *fakeArchiver) Archive(ctx context.Context, events []*corev2.Event) error {
+	if a.err != nil {
+		return a.err
+	}
+	a.archived = append(a.archived, events...)
+	return nil
+}
+
+type staticCredentials struct{}
+
+func (staticCredentials) Credentials(ctx context.Context) (aws.Credentials, error) {
+	return aws.Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}, nil
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name        string
+		destination string
+		region      string
+		wantErr     bool
+	}{
+		{"path", "/var/lib/sensu/events.ndjson", "", false},
+		{"file URL", "file:///var/lib/sensu/events.ndjson", "", false},
+		{"s3", "s3://archive/sensu", "us-west-2", false},
+		{"s3 without bucket", "s3:///sensu", "us-west-2", true},
+		{"unsupported scheme", "ftp://archive/sensu", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.destination, tt.region)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEventStoreDeleteEventByEntityCheck(t *testing.T) {
+	event := corev2.FixtureEvent("web01", "http")
+	st := &mockstore.MockStore{}
+	st.On("GetEventByEntityCheck", mock.Anything, "web01", "http").Return(event, nil)
+	st.On("DeleteEventByEntityCheck", mock.Anything, "web01", "http").Return(nil)
+
+	writer := NewWriter(&fakeArchiver{})
+	s := &EventStore{EventStore: st, Writer: writer}
+	require.NoError(t, s.DeleteEventByEntityCheck(context.Background(), "web01", "http"))
+	st.AssertCalled(t, "DeleteEventByEntityCheck", mock.Anything, "web01", "http")
+	require.Equal(t, 1, len(writer.events))
+	assert.Equal(t, event, <-writer.events)
+}
+
+func TestEventStoreDeleteEventByEntityCheckDeleteError(t *testing.T) {
+	st := &mockstore.MockStore{}
+	st.On("GetEventByEntityCheck", mock.Anything, "web01", "http").Return(corev2.FixtureEvent("web01", "http"), nil)
+	st.On("DeleteEventByEntityCheck", mock.Anything, "web01", "http").Return(errors.New("etcd unavailable"))
+
+	// The events which couldn't be deleted aren't archived
+	writer := NewWriter(&fakeArchiver{})
+	s := &EventStore{EventStore: st, Writer: writer}
+	assert.Error(t, s.DeleteEventByEntityCheck(context.Background(), "web01", "http"))
+	assert.Equal(t, 0, len(writer.events))
+}
+
+func TestWriter(t *testing.T) {
+	archiver := &fakeArchiver{}
+	writer := NewWriter(archiver)
+	writer.batchSize = 2
+	writer.interval = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	writer.Start(ctx)
+	for _, entity := range []string{"web01", "web02", "web03"} {
+		assert.True(t, writer.Write(corev2.FixtureEvent(entity, "http")))
+	}
+
+	// The buffered events are archived once the writer is stopped
+	cancel()
+	<-writer.Done()
+	require.Equal(t, 3, len(archiver.archived))
+	assert.Equal(t, "web03", archiver.archived[2].Entity.Name)
+}
+
+func TestWriterRetry(t *testing.T) {
+	archiver := &fakeArchiver{err: errors.New("s3 unavailable")}
+	writer := NewWriter(archiver)
+	writer.batchSize = 2
+	batch := []*corev2.Event{corev2.FixtureEvent("web01", "http"), corev2.FixtureEvent("web02", "http")}
+
+	// The batch is kept until it's archived
+	batch = writer.flush(context.Background(), batch)
+	assert.Equal(t, 2, len(batch))
+	archiver.err = nil
+	batch = writer.flush(context.Background(), batch)
+	assert.Empty(t, batch)
+	assert.Equal(t, 2, len(archiver.archived))
+}
+
+func TestWriterBufferFull(t *testing.T) {
+	writer := NewWriter(&fakeArchiver{})
+	writer.events = make(chan *corev2.Event, 1)
+
+	// The writer never blocks
+	assert.True(t, writer.Write(corev2.FixtureEvent("web01", "http")))
+	assert.False(t, writer.Write(corev2.FixtureEvent("web02", "http")))
+}
+
+func TestFileArchiver(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eventarchive")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.ndjson")
+
+	archiver := newFileArchiver(path)
+	ctx := context.Background()
+	require.NoError(t, archiver.Archive(ctx, []*corev2.Event{corev2.FixtureEvent("web01", "http")}))
+	require.NoError(t, archiver.Archive(ctx, []*corev2.Event{corev2.FixtureEvent("web02", "http")}))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var entities []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event corev2.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		entities = append(entities, event.Entity.Name)
+	}
+	require.NoError(t, scanner.Err())
+	assert.Equal(t, []string{"web01", "web02"}, entities)
+}
+
+func TestS3Archiver(t *testing.T) {
+	var gotPath, gotBody string
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "application/x-ndjson", r.Header.Get("Content-Type"))
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/"))
+		assert.NotEmpty(t, r.Header.Get("X-Amz-Content-Sha256"))
+		body, _ := ioutil.ReadAll(r.Body)
+		gotPath, gotBody = r.URL.Path, string(body)
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	archiver := newS3Archiver("archive", "sensu/events", "us-west-2", server.URL)
+	archiver.credentials = staticCredentials{}
+	require.NoError(t, archiver.Archive(context.Background(), []*corev2.Event{corev2.FixtureEvent("web01", "http")}))
+	assert.True(t, strings.HasPrefix(gotPath, "/archive/sensu/events/"), gotPath)
+	assert.True(t, strings.HasSuffix(gotPath, ".ndjson"), gotPath)
+	assert.Contains(t, gotBody, `"name":"web01"`)
+	assert.True(t, strings.HasSuffix(gotBody, "}\n"))
+
+	status = http.StatusForbidden
+	assert.Error(t, archiver.Archive(context.Background(), []*corev2.Event{corev2.FixtureEvent("web01", "http")}))
+}
+
+func TestS3ArchiverObjectURL(t *testing.T) {
+	archiver := newS3Archiver("archive", "sensu", "us-west-2", "")
+	u, err := archiver.objectURL("sensu/2020/01/02/1.ndjson")
+	require.NoError(t, err)
+	assert.Equal(t, "https://archive.s3.us-west-2.amazonaws.com/sensu/2020/01/02/1.ndjson", u.String())
+}
@@ -0,0 +1,41 @@
+package eventarchive
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// fileArchiver appends the events to a file, which is opened for each batch
+// so that it can be rotated by renaming it.
+type fileArchiver struct {
+	path string
+	mu   sync.Mutex
+}
+
+func newFileArchiver(path string) *fileArchiver {
+	return &fileArchiver{path: path}
+}
+
+// Archive appends the events to the file, one JSON object per line.
+func (a *fileArchiver) Archive(ctx context.Context, events []*corev2.Event) error {
+	buf, err := marshal(events)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	f, err := os.OpenFile(a.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(buf); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,104 @@
+package eventarchive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/util/aws"
+)
+
+// s3Timeout is the timeout of the requests to S3.
+const s3Timeout = 30 * time.Second
+
+// s3Archiver uploads each batch of events as an object of an S3 bucket, named
+// after the date and time of the upload, e.g.
+// prefix/2020/01/02/1577934245000000000-<uuid>.ndjson.
+type s3Archiver struct {
+	bucket      string
+	prefix      string
+	region      string
+	endpoint    string
+	credentials aws.CredentialsProvider
+	httpClient  *http.Client
+}
+
+func newS3Archiver(bucket, prefix, region, endpoint string) *s3Archiver {
+	return &s3Archiver{
+		bucket:      bucket,
+		prefix:      prefix,
+		region:      region,
+		endpoint:    endpoint,
+		credentials: &aws.DefaultCredentialsProvider{},
+		httpClient:  &http.Client{Timeout: s3Timeout},
+	}
+}
+
+// objectURL returns the URL of the object with the given key. The bucket is
+// in the path of the URL with an S3-compatible endpoint, and in its host
+// otherwise.
+func (a *s3Archiver) objectURL(key string) (*url.URL, error) {
+	if a.endpoint == "" {
+		return &url.URL{
+			Scheme: "https",
+			Host:   fmt.Sprintf("%s.s3.%s.amazonaws.com", a.bucket, a.region),
+			Path:   "/" + key,
+		}, nil
+	}
+	u, err := url.Parse(a.endpoint)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join("/", u.Path, a.bucket, key)
+	return u, nil
+}
+
+// Archive uploads the events as a newline-delimited JSON object.
+func (a *s3Archiver) Archive(ctx context.Context, events []*corev2.Event) error {
+	payload, err := marshal(events)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	key := path.Join(a.prefix, now.Format("2006/01/02"), fmt.Sprintf("%d-%s.ndjson", now.UnixNano(), id))
+	u, err := a.objectURL(key)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", u.String(), bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	creds, err := a.credentials.Credentials(ctx)
+	if err != nil {
+		return err
+	}
+	aws.SignRequest(req, payload, creds, a.region, "s3", now)
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("s3 PutObject %s: unexpected status %d: %s", key, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
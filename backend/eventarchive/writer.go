@@ -0,0 +1,140 @@
+package eventarchive
+
+import (
+	"context"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+const (
+	// DefaultBufferSize is the default number of events buffered by a Writer
+	// before the events written are dropped.
+	DefaultBufferSize = 10000
+
+	// DefaultBatchSize is the default maximum number of events archived at
+	// once by a Writer.
+	DefaultBatchSize = 500
+
+	// DefaultFlushInterval is the default interval at which a Writer archives
+	// the events buffered, or retries archiving the events it couldn't.
+	DefaultFlushInterval = 10 * time.Second
+
+	// flushTimeout is the time given to a Writer to archive its buffered
+	// events once it's stopped.
+	flushTimeout = 30 * time.Second
+)
+
+// Writer archives events asynchronously, in batches, so that archiving the
+// events neither slows down nor prevents their deletion. The events are
+// buffered in memory until they are archived. A batch which can't be archived
+// is retried at the next flush, and the events written while the buffer is
+// full are dropped.
+type Writer struct {
+	archiver  Archiver
+	events    chan *corev2.Event
+	batchSize int
+	interval  time.Duration
+	done      chan struct{}
+}
+
+// NewWriter creates a Writer archiving the events with archiver.
+func NewWriter(archiver Archiver) *Writer {
+	return &Writer{
+		archiver:  archiver,
+		events:    make(chan *corev2.Event, DefaultBufferSize),
+		batchSize: DefaultBatchSize,
+		interval:  DefaultFlushInterval,
+		done:      make(chan struct{}),
+	}
+}
+
+// Start archives the events written until ctx is done, after which the
+// buffered events are archived.
+func (w *Writer) Start(ctx context.Context) {
+	go w.run(ctx)
+}
+
+// Done returns a channel closed once the writer stopped, after archiving the
+// buffered events.
+func (w *Writer) Done() <-chan struct{} {
+	return w.done
+}
+
+// Write buffers the event to be archived. It never blocks, and returns false
+// if the event was dropped because the buffer is full.
+func (w *Writer) Write(event *corev2.Event) bool {
+	select {
+	case w.events <- event:
+		return true
+	default:
+		logger.WithFields(eventFields(event)).Error("event archive buffer full, dropping the event")
+		return false
+	}
+}
+
+func (w *Writer) run(ctx context.Context) {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	batch := make([]*corev2.Event, 0, w.batchSize)
+	for {
+		// Stop reading the events while a full batch is waiting to be
+		// archived, so that they're buffered
+		events := w.events
+		if len(batch) >= w.batchSize {
+			events = nil
+		}
+
+		select {
+		case event := <-events:
+			batch = append(batch, event)
+			if len(batch) < w.batchSize {
+				continue
+			}
+		case <-ticker.C:
+		case <-ctx.Done():
+			w.stop(batch)
+			return
+		}
+		batch = w.flush(ctx, batch)
+	}
+}
+
+// flush archives the batch, and returns the events left to archive: none if
+// the batch was archived, the whole batch otherwise.
+func (w *Writer) flush(ctx context.Context, batch []*corev2.Event) []*corev2.Event {
+	if len(batch) == 0 {
+		return batch
+	}
+	if err := w.archiver.Archive(ctx, batch); err != nil {
+		logger.WithError(err).WithField("events", len(batch)).Error("couldn't archive the events, retrying at the next flush")
+		return batch
+	}
+	return batch[:0]
+}
+
+// stop archives the batch and the buffered events, in batches, within the
+// flush timeout.
+func (w *Writer) stop(batch []*corev2.Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	for {
+		select {
+		case event := <-w.events:
+			batch = append(batch, event)
+			if len(batch) < w.batchSize {
+				continue
+			}
+		default:
+		}
+		if len(batch) == 0 {
+			return
+		}
+		if batch = w.flush(ctx, batch); len(batch) > 0 {
+			logger.WithField("events", len(batch)+len(w.events)).Error("couldn't archive the events before stopping, dropping them")
+			return
+		}
+	}
+}
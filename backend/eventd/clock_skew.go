@@ -0,0 +1,51 @@
+package eventd
+
+import (
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// checkClockSkew compares the timestamp of the event with the clock of the
+// backend. When they differ by more than the allowed skew, the offset of the
+// agent clock is recorded on the entity of the event, and the timestamps from
+// the future are replaced with the current time, so that they don't confuse
+// the TTL and occurrences of the event.
+func (e *Eventd) checkClockSkew(event *corev2.Event, now time.Time) {
+	if e.maxClockSkew <= 0 || event.Timestamp <= 0 {
+		return
+	}
+
+	offset := time.Duration(event.Timestamp-now.Unix()) * time.Second
+	if offset <= e.maxClockSkew && offset >= -e.maxClockSkew {
+		return
+	}
+
+	fields := logrus.Fields{
+		"entity":       event.Entity.Name,
+		"namespace":    event.Entity.Namespace,
+		"clock_offset": offset.String(),
+	}
+	if event.HasCheck() {
+		fields["check"] = event.Check.Name
+	}
+	logger.WithFields(fields).Warn("event timestamp is skewed, the clock of the agent may be wrong")
+
+	if event.Entity.EntityClass == corev2.EntityAgentClass {
+		if event.Entity.Annotations == nil {
+			event.Entity.Annotations = make(map[string]string)
+		}
+		event.Entity.Annotations[corev2.ClockOffsetAnnotation] = offset.String()
+	}
+
+	if offset < 0 {
+		// Events from the past are kept as they are, they might have been
+		// delayed
+		return
+	}
+	event.Timestamp = now.Unix()
+	if event.HasCheck() && event.Check.Executed > now.Unix() {
+		event.Check.Executed = now.Unix()
+	}
+}
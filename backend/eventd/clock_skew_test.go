@@ -0,0 +1,74 @@
+package eventd
+
+import (
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckClockSkew(t *testing.T) {
+	now := time.Unix(1000000, 0)
+
+	tests := []struct {
+		name          string
+		maxClockSkew  time.Duration
+		timestamp     int64
+		entityClass   string
+		wantTimestamp int64
+		wantOffset    string
+	}{
+		{
+			name:          "disabled",
+			timestamp:     now.Unix() + 3600,
+			entityClass:   corev2.EntityAgentClass,
+			wantTimestamp: now.Unix() + 3600,
+		},
+		{
+			name:          "allowed skew",
+			maxClockSkew:  time.Minute,
+			timestamp:     now.Unix() + 30,
+			entityClass:   corev2.EntityAgentClass,
+			wantTimestamp: now.Unix() + 30,
+		},
+		{
+			name:          "event from the future",
+			maxClockSkew:  time.Minute,
+			timestamp:     now.Unix() + 3600,
+			entityClass:   corev2.EntityAgentClass,
+			wantTimestamp: now.Unix(),
+			wantOffset:    "1h0m0s",
+		},
+		{
+			name:          "event from the past",
+			maxClockSkew:  time.Minute,
+			timestamp:     now.Unix() - 120,
+			entityClass:   corev2.EntityAgentClass,
+			wantTimestamp: now.Unix() - 120,
+			wantOffset:    "-2m0s",
+		},
+		{
+			name:          "proxy entity",
+			maxClockSkew:  time.Minute,
+			timestamp:     now.Unix() + 3600,
+			entityClass:   corev2.EntityProxyClass,
+			wantTimestamp: now.Unix(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &Eventd{maxClockSkew: tt.maxClockSkew}
+			event := corev2.FixtureEvent("entity", "check")
+			event.Entity.EntityClass = tt.entityClass
+			event.Timestamp = tt.timestamp
+			event.Check.Executed = tt.timestamp
+
+			e.checkClockSkew(event, now)
+
+			assert.Equal(t, tt.wantTimestamp, event.Timestamp)
+			assert.Equal(t, tt.wantTimestamp, event.Check.Executed)
+			assert.Equal(t, tt.wantOffset, event.Entity.Annotations[corev2.ClockOffsetAnnotation])
+		})
+	}
+}
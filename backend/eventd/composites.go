@@ -0,0 +1,214 @@
+package eventd
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/cache"
+	"github.com/sirupsen/logrus"
+)
+
+// compositesOf returns the composite checks having the entity and the check of
+// the event as a member.
+func compositesOf(event *corev2.Event, cache *cache.Resource) []*corev2.CompositeCheck {
+	composites := []*corev2.CompositeCheck{}
+	for _, value := range cache.Get(event.Entity.Namespace) {
+		composite, ok := value.Resource.(*corev2.CompositeCheck)
+		if ok && composite.HasMember(event.Entity.Name, event.Check.Name) {
+			composites = append(composites, composite)
+		}
+	}
+	return composites
+}
+
+// evaluateComposites records the status of the event for the composite checks
+// having its entity and its check as a member, and evaluates them. The errors
+// are only logged, since the event itself was processed.
+func (e *Eventd) evaluateComposites(ctx context.Context, event *corev2.Event) {
+	if e.compositeCache == nil {
+		return
+	}
+	member := corev2.CompositeMember{Entity: event.Entity.Name, Check: event.Check.Name}
+	for _, composite := range compositesOf(event, e.compositeCache) {
+		tctx, cancel := context.WithTimeout(ctx, e.storeTimeout)
+		statuses, err := e.store.UpdateCompositeCheckState(tctx, composite, member, event.Check.Status)
+		cancel()
+		if err == nil {
+			err = e.publishComposite(ctx, composite, statuses)
+		}
+		if err != nil {
+			logCompositeError(composite, err)
+		}
+	}
+}
+
+// watchComposites evaluates the composite checks when they are created or
+// updated, and when the event of one of their members is deleted, until ctx
+// is done.
+func (e *Eventd) watchComposites(ctx context.Context) {
+	composites := e.store.WatchResources(ctx, &corev2.CompositeCheck{})
+	deletedEvents := e.store.WatchDeletedEvents(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case update, ok := <-composites:
+			if !ok {
+				return
+			}
+			e.handleCompositeUpdate(ctx, update)
+		case deletion, ok := <-deletedEvents:
+			if !ok {
+				return
+			}
+			e.handleEventDeletion(ctx, deletion)
+		}
+	}
+}
+
+// handleCompositeUpdate records the statuses of the members of the composite
+// check of the update from their events and evaluates it, or forgets them if
+// the composite check was deleted. All the composite checks are initialized
+// again if updates may have been missed.
+func (e *Eventd) handleCompositeUpdate(ctx context.Context, update store.WatchEventResource) {
+	if update.Action == store.WatchError {
+		e.initializeComposites(ctx)
+		return
+	}
+	composite, ok := update.Resource.(*corev2.CompositeCheck)
+	if !ok {
+		return
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, e.storeTimeout)
+	defer cancel()
+	if update.Action == store.WatchDelete {
+		if err := e.store.DeleteCompositeCheckStates(tctx, composite); err != nil {
+			logCompositeError(composite, err)
+		}
+		return
+	}
+	if err := e.initializeComposite(tctx, composite); err != nil {
+		logCompositeError(composite, err)
+	}
+}
+
+// handleEventDeletion forgets the status of the deleted event for the
+// composite checks having its entity and its check as a member, and evaluates
+// them. All the composite checks are initialized again if deletions may have
+// been missed.
+func (e *Eventd) handleEventDeletion(ctx context.Context, deletion store.WatchEventResource) {
+	if deletion.Action == store.WatchError {
+		e.initializeComposites(ctx)
+		return
+	}
+	event, ok := deletion.Resource.(*corev2.Event)
+	if !ok || !event.HasCheck() || event.Entity == nil {
+		return
+	}
+
+	member := corev2.CompositeMember{Entity: event.Entity.Name, Check: event.Check.Name}
+	for _, composite := range compositesOf(event, e.compositeCache) {
+		tctx, cancel := context.WithTimeout(ctx, e.storeTimeout)
+		statuses, err := e.store.DeleteCompositeCheckState(tctx, composite, member)
+		cancel()
+		// The status of the member was already forgotten by another backend
+		if err == nil && statuses == nil {
+			continue
+		}
+		if err == nil {
+			err = e.publishComposite(ctx, composite, statuses)
+		}
+		if err != nil {
+			logCompositeError(composite, err)
+		}
+	}
+}
+
+// initializeComposites initializes all the composite checks of the cache.
+func (e *Eventd) initializeComposites(ctx context.Context) {
+	for _, value := range e.compositeCache.GetAll() {
+		composite, ok := value.Resource.(*corev2.CompositeCheck)
+		if !ok {
+			continue
+		}
+		tctx, cancel := context.WithTimeout(ctx, e.storeTimeout)
+		err := e.initializeComposite(tctx, composite)
+		cancel()
+		if err != nil {
+			logCompositeError(composite, err)
+		}
+	}
+}
+
+// initializeComposite records the statuses of the members of the composite
+// check from their events, and evaluates it.
+func (e *Eventd) initializeComposite(ctx context.Context, composite *corev2.CompositeCheck) error {
+	statuses, err := e.store.InitializeCompositeCheckStates(ctx, composite)
+	if err != nil {
+		return err
+	}
+	return e.publishComposite(ctx, composite, statuses)
+}
+
+// publishComposite evaluates the composite check given the statuses of its
+// members, and publishes the event of the composite check if its status or
+// its output changed. The event goes through eventd like any other event, so
+// composite checks can be members of other composite checks.
+func (e *Eventd) publishComposite(ctx context.Context, composite *corev2.CompositeCheck, statuses map[corev2.CompositeMember]uint32) error {
+	status, output := composite.Evaluate(statuses)
+
+	tctx, cancel := context.WithTimeout(store.NamespaceContext(ctx, composite.Namespace), e.storeTimeout)
+	defer cancel()
+	current, err := e.eventStore.GetEventByEntityCheck(tctx, composite.Entity, composite.Name)
+	if err != nil {
+		return err
+	}
+	if current != nil && current.HasCheck() && current.Check.Status == status && current.Check.Output == output {
+		return nil
+	}
+
+	return e.bus.Publish(messaging.TopicEventRaw, compositeEvent(composite, status, output, time.Now()))
+}
+
+func logCompositeError(composite *corev2.CompositeCheck, err error) {
+	logger.WithFields(logrus.Fields{
+		"composite_check": composite.Name,
+		"namespace":       composite.Namespace,
+	}).WithError(err).Error("error evaluating composite check")
+}
+
+// compositeEvent creates the event of the composite check on its virtual
+// entity, which is created as a proxy entity by eventd.
+func compositeEvent(composite *corev2.CompositeCheck, status uint32, output string, now time.Time) *corev2.Event {
+	uid, _ := uuid.NewRandom()
+	return &corev2.Event{
+		ObjectMeta: corev2.ObjectMeta{
+			Namespace: composite.Namespace,
+		},
+		Timestamp: now.Unix(),
+		Entity: &corev2.Entity{
+			ObjectMeta: corev2.ObjectMeta{
+				Name:      composite.Entity,
+				Namespace: composite.Namespace,
+			},
+			EntityClass: corev2.EntityProxyClass,
+		},
+		Check: &corev2.Check{
+			ObjectMeta: corev2.ObjectMeta{
+				Name:      composite.Name,
+				Namespace: composite.Namespace,
+			},
+			Handlers: composite.Handlers,
+			Status:   status,
+			Output:   output,
+			Executed: now.Unix(),
+			Issued:   now.Unix(),
+		},
+		ID: uid[:],
+	}
+}
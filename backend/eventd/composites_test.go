@@ -0,0 +1,168 @@
+package eventd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/cache"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type compositeReceiver struct {
+	ch chan interface{}
+}
+
+func (r compositeReceiver) Receiver() chan<- interface{} {
+	return r.ch
+}
+
+// newCompositeEventd returns an eventd evaluating the checkout composite
+// check, and a receiver of the events it publishes.
+func newCompositeEventd(t *testing.T) (*Eventd, *mockstore.MockStore, compositeReceiver, func()) {
+	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	require.NoError(t, err)
+	require.NoError(t, bus.Start())
+	receiver := compositeReceiver{ch: make(chan interface{}, 1)}
+	sub, err := bus.Subscribe(messaging.TopicEventRaw, "composites", receiver)
+	require.NoError(t, err)
+
+	s := &mockstore.MockStore{}
+	e := &Eventd{
+		store:          s,
+		eventStore:     s,
+		bus:            bus,
+		storeTimeout:   time.Minute,
+		compositeCache: cache.NewFromResources([]corev2.Resource{corev2.FixtureCompositeCheck("checkout")}, false),
+	}
+	stop := func() {
+		assert.NoError(t, sub.Cancel())
+		assert.NoError(t, bus.Stop())
+	}
+	return e, s, receiver, stop
+}
+
+func TestCompositesOf(t *testing.T) {
+	checkout := corev2.FixtureCompositeCheck("checkout")
+	search := corev2.FixtureCompositeCheck("search")
+	search.Members = []corev2.CompositeMember{{Entity: "web01", Check: "search"}}
+	composites := cache.NewFromResources([]corev2.Resource{checkout, search}, false)
+
+	assert.Equal(t, []*corev2.CompositeCheck{checkout}, compositesOf(corev2.FixtureEvent("web02", "http"), composites))
+	assert.Empty(t, compositesOf(corev2.FixtureEvent("web03", "http"), composites))
+}
+
+func TestEvaluateComposites(t *testing.T) {
+	web01 := corev2.CompositeMember{Entity: "web01", Check: "http"}
+	web02 := corev2.CompositeMember{Entity: "web02", Check: "http"}
+
+	tests := []struct {
+		name        string
+		status      uint32
+		current     *corev2.Event
+		wantPublish bool
+		wantStatus  uint32
+	}{
+		{
+			name:        "first evaluation",
+			wantPublish: true,
+			wantStatus:  0,
+		},
+		{
+			name:        "member failing",
+			status:      2,
+			current:     compositeEvent(corev2.FixtureCompositeCheck("checkout"), 0, "2/2 members passing (all)", time.Now()),
+			wantPublish: true,
+			wantStatus:  2,
+		},
+		{
+			name:        "unchanged",
+			current:     compositeEvent(corev2.FixtureCompositeCheck("checkout"), 0, "2/2 members passing (all)", time.Now()),
+			wantPublish: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, s, receiver, stop := newCompositeEventd(t)
+			defer stop()
+			event := corev2.FixtureEvent("web02", "http")
+			event.Check.Status = tt.status
+
+			composite := corev2.FixtureCompositeCheck("checkout")
+			statuses := map[corev2.CompositeMember]uint32{web01: 0, web02: tt.status}
+			s.On("UpdateCompositeCheckState", mock.Anything, composite, web02, tt.status).Return(statuses, nil)
+			s.On("GetEventByEntityCheck", mock.Anything, "checkout", "checkout").Return(tt.current, nil)
+
+			e.evaluateComposites(context.Background(), event)
+
+			// The events of the members aren't read
+			s.AssertNotCalled(t, "GetEventByEntityCheck", mock.Anything, "web01", "http")
+			if !tt.wantPublish {
+				assert.Equal(t, 0, len(receiver.ch))
+				return
+			}
+			require.Equal(t, 1, len(receiver.ch))
+			published := (<-receiver.ch).(*corev2.Event)
+			assert.Equal(t, "checkout", published.Entity.Name)
+			assert.Equal(t, corev2.EntityProxyClass, published.Entity.EntityClass)
+			assert.Equal(t, "checkout", published.Check.Name)
+			assert.Equal(t, tt.wantStatus, published.Check.Status)
+			assert.NoError(t, published.Validate())
+		})
+	}
+}
+
+func TestHandleCompositeUpdate(t *testing.T) {
+	web01 := corev2.CompositeMember{Entity: "web01", Check: "http"}
+	composite := corev2.FixtureCompositeCheck("checkout")
+
+	e, s, receiver, stop := newCompositeEventd(t)
+	defer stop()
+	var nilEvent *corev2.Event
+	s.On("InitializeCompositeCheckStates", mock.Anything, composite).Return(map[corev2.CompositeMember]uint32{web01: 0}, nil)
+	s.On("GetEventByEntityCheck", mock.Anything, "checkout", "checkout").Return(nilEvent, nil)
+	s.On("DeleteCompositeCheckStates", mock.Anything, composite).Return(nil)
+
+	// A created composite check is evaluated from the events of its members
+	e.handleCompositeUpdate(context.Background(), store.WatchEventResource{Action: store.WatchCreate, Resource: composite})
+	require.Equal(t, 1, len(receiver.ch))
+	published := (<-receiver.ch).(*corev2.Event)
+	assert.Equal(t, uint32(2), published.Check.Status)
+	assert.Equal(t, "1/2 members passing (all), failing: web02/http (unknown)", published.Check.Output)
+
+	// The statuses of a deleted composite check are forgotten
+	e.handleCompositeUpdate(context.Background(), store.WatchEventResource{Action: store.WatchDelete, Resource: composite})
+	s.AssertCalled(t, "DeleteCompositeCheckStates", mock.Anything, composite)
+	assert.Equal(t, 0, len(receiver.ch))
+}
+
+func TestHandleEventDeletion(t *testing.T) {
+	web01 := corev2.CompositeMember{Entity: "web01", Check: "http"}
+	web02 := corev2.CompositeMember{Entity: "web02", Check: "http"}
+	composite := corev2.FixtureCompositeCheck("checkout")
+	current := compositeEvent(composite, 0, "2/2 members passing (all)", time.Now())
+
+	e, s, receiver, stop := newCompositeEventd(t)
+	defer stop()
+	var nilStatuses map[corev2.CompositeMember]uint32
+	s.On("DeleteCompositeCheckState", mock.Anything, composite, web01).Return(nilStatuses, nil)
+	s.On("DeleteCompositeCheckState", mock.Anything, composite, web02).Return(map[corev2.CompositeMember]uint32{web01: 0}, nil)
+	s.On("GetEventByEntityCheck", mock.Anything, "checkout", "checkout").Return(current, nil)
+
+	// The status was already forgotten by another backend
+	e.handleEventDeletion(context.Background(), store.WatchEventResource{Action: store.WatchDelete, Resource: corev2.FixtureEvent("web01", "http")})
+	assert.Equal(t, 0, len(receiver.ch))
+
+	// The member without an event is unknown
+	e.handleEventDeletion(context.Background(), store.WatchEventResource{Action: store.WatchDelete, Resource: corev2.FixtureEvent("web02", "http")})
+	require.Equal(t, 1, len(receiver.ch))
+	published := (<-receiver.ch).(*corev2.Event)
+	assert.Equal(t, uint32(2), published.Check.Status)
+	assert.Equal(t, "1/2 members passing (all), failing: web02/http (unknown)", published.Check.Output)
+}
@@ -0,0 +1,109 @@
+package eventd
+
+import (
+	"context"
+	"path"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/liveness"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sirupsen/logrus"
+)
+
+// discardSwitchSetName is the name of the switchset tracking the events to
+// discard once they are no longer updated.
+const discardSwitchSetName = "eventd-discard"
+
+// discardAfter returns the number of seconds after which the event must be
+// deleted if it is not updated, as configured by the DiscardAfterAnnotation
+// of its entity or its check, or defaultTTL if neither has it. The event must
+// be kept if it's 0. The entity annotation takes precedence over the check
+// one.
+func discardAfter(event *corev2.Event, defaultTTL int64) int64 {
+	value, ok := event.Entity.Annotations[corev2.DiscardAfterAnnotation]
+	if !ok {
+		value, ok = event.Check.Annotations[corev2.DiscardAfterAnnotation]
+	}
+	if !ok {
+		return defaultTTL
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		logger.WithFields(logrus.Fields{
+			"entity":    event.Entity.Name,
+			"check":     event.Check.Name,
+			"namespace": event.Entity.Namespace,
+		}).Warnf("invalid %s annotation: %q", corev2.DiscardAfterAnnotation, value)
+		return 0
+	}
+	if d < time.Second {
+		return 1
+	}
+	return int64(d / time.Second)
+}
+
+// discardKey creates a key to identify the event for discard monitoring
+func discardKey(event *corev2.Event) string {
+	return path.Join(event.Entity.Namespace, event.Check.Name, event.Entity.Name)
+}
+
+// updateDiscardSwitch resets the discard switch of the event, or buries it if
+// the event no longer needs to be discarded.
+func (e *Eventd) updateDiscardSwitch(event, prevEvent *corev2.Event) {
+	switches := e.livenessFactory(discardSwitchSetName, e.discard, e.discardReset, logger)
+	switchKey := discardKey(event)
+
+	defaultTTL := int64(e.clusterConfig.Config().EventTTL)
+	if ttl := discardAfter(event, defaultTTL); ttl > 0 {
+		if err := switches.Alive(context.TODO(), switchKey, ttl); err != nil {
+			// The event is kept until it is updated again, there is no need to
+			// fail its processing
+			logger.WithError(err).Error("error resetting discard switch")
+		}
+	} else if prevEvent != nil && prevEvent.HasCheck() && discardAfter(prevEvent, defaultTTL) > 0 {
+		// The event must no longer be discarded
+		if err := switches.Bury(context.TODO(), switchKey); err != nil {
+			logger.WithError(err).Error("error burying discard switch")
+		}
+	}
+}
+
+func (e *Eventd) discardReset(key string, prev liveness.State, leader bool) (bury bool) {
+	return false
+}
+
+// discard deletes the event identified by key, which was not updated in time.
+func (e *Eventd) discard(key string, prev liveness.State, leader bool) (bury bool) {
+	lager := logger.WithFields(logrus.Fields{
+		"status":          liveness.Dead.String(),
+		"previous_status": prev.String()})
+
+	namespace, check, entity, err := parseKey(key)
+	if err != nil || entity == "" {
+		lager.WithField("key", key).Error("bad discard key")
+		return true
+	}
+
+	lager = lager.WithFields(logrus.Fields{
+		"check":     check,
+		"entity":    entity,
+		"namespace": namespace})
+
+	if !leader {
+		// Only the backend that flipped the switch deletes the event
+		return false
+	}
+
+	ctx := store.NamespaceContext(context.Background(), namespace)
+	ctx, cancel := context.WithTimeout(ctx, e.storeTimeout)
+	defer cancel()
+
+	if err := e.eventStore.DeleteEventByEntityCheck(ctx, entity, check); err != nil {
+		lager.WithError(err).Error("error discarding event")
+		return false
+	}
+
+	lager.Info("event discarded after not being updated")
+	return true
+}
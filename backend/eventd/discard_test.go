@@ -0,0 +1,167 @@
+package eventd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/liveness"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDiscardAfter(t *testing.T) {
+	tests := []struct {
+		name              string
+		entityAnnotations map[string]string
+		checkAnnotations  map[string]string
+		defaultTTL        int64
+		want              int64
+	}{
+		{
+			name: "no annotation",
+			want: 0,
+		},
+		{
+			name:       "default ttl",
+			defaultTTL: 3600,
+			want:       3600,
+		},
+		{
+			name:             "annotation takes precedence over the default ttl",
+			checkAnnotations: map[string]string{corev2.DiscardAfterAnnotation: "2h"},
+			defaultTTL:       3600,
+			want:             7200,
+		},
+		{
+			name:             "check annotation",
+			checkAnnotations: map[string]string{corev2.DiscardAfterAnnotation: "2h"},
+			want:             7200,
+		},
+		{
+			name:              "entity annotation takes precedence",
+			entityAnnotations: map[string]string{corev2.DiscardAfterAnnotation: "30m"},
+			checkAnnotations:  map[string]string{corev2.DiscardAfterAnnotation: "2h"},
+			want:              1800,
+		},
+		{
+			name:             "invalid duration",
+			checkAnnotations: map[string]string{corev2.DiscardAfterAnnotation: "forever"},
+			want:             0,
+		},
+		{
+			name:             "negative duration",
+			checkAnnotations: map[string]string{corev2.DiscardAfterAnnotation: "-1h"},
+			want:             0,
+		},
+		{
+			name:             "sub-second duration",
+			checkAnnotations: map[string]string{corev2.DiscardAfterAnnotation: "10ms"},
+			want:             1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := corev2.FixtureEvent("entity", "check")
+			event.Entity.Annotations = tt.entityAnnotations
+			event.Check.Annotations = tt.checkAnnotations
+			assert.Equal(t, tt.want, discardAfter(event, tt.defaultTTL))
+		})
+	}
+}
+
+func TestUpdateDiscardSwitch(t *testing.T) {
+	discarded := corev2.FixtureEvent("entity", "check")
+	discarded.Check.Annotations = map[string]string{corev2.DiscardAfterAnnotation: "1h"}
+	kept := corev2.FixtureEvent("entity", "check")
+
+	tests := []struct {
+		name         string
+		event        *corev2.Event
+		prevEvent    *corev2.Event
+		switchesFunc func(*mockSwitchSet)
+	}{
+		{
+			name:  "events without discard setting are not tracked",
+			event: kept,
+		},
+		{
+			name:  "events with discard setting reset their switch",
+			event: discarded,
+			switchesFunc: func(s *mockSwitchSet) {
+				s.On("Alive", mock.Anything, "default/check/entity", int64(3600)).Return(nil)
+			},
+		},
+		{
+			name:      "events that lost their discard setting bury their switch",
+			event:     kept,
+			prevEvent: discarded,
+			switchesFunc: func(s *mockSwitchSet) {
+				s.On("Bury", mock.Anything, "default/check/entity").Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			switches := &mockSwitchSet{}
+			if tt.switchesFunc != nil {
+				tt.switchesFunc(switches)
+			}
+			e := &Eventd{livenessFactory: newFakeFactory(switches)}
+			e.updateDiscardSwitch(tt.event, tt.prevEvent)
+			switches.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDiscard(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    string
+		leader bool
+		store  func(*mockstore.MockStore)
+		bury   bool
+	}{
+		{
+			name: "bury invalid keys",
+			key:  "default/foo",
+			bury: true,
+		},
+		{
+			name: "only the leader discards the event",
+			key:  "default/foo/bar",
+			bury: false,
+		},
+		{
+			name:   "discard the event",
+			key:    "default/foo/bar",
+			leader: true,
+			store: func(store *mockstore.MockStore) {
+				store.On("DeleteEventByEntityCheck", mock.Anything, "bar", "foo").Return(nil)
+			},
+			bury: true,
+		},
+		{
+			name:   "do not bury on store error",
+			key:    "default/foo/bar",
+			leader: true,
+			store: func(store *mockstore.MockStore) {
+				store.On("DeleteEventByEntityCheck", mock.Anything, "bar", "foo").Return(errors.New("error"))
+			},
+			bury: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := new(mockstore.MockStore)
+			if tt.store != nil {
+				tt.store(store)
+			}
+			e := &Eventd{store: store, eventStore: store, storeTimeout: time.Second}
+			assert.Equal(t, tt.bury, e.discard(tt.key, liveness.Alive, tt.leader))
+			store.AssertExpectations(t)
+		})
+	}
+}
@@ -7,11 +7,13 @@ import (
 	"path"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/coreos/etcd/clientv3"
 	"github.com/prometheus/client_golang/prometheus"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/clusterconfig"
 	"github.com/sensu/sensu-go/backend/keepalived"
 	"github.com/sensu/sensu-go/backend/liveness"
 	"github.com/sensu/sensu-go/backend/messaging"
@@ -69,10 +71,17 @@ type Eventd struct {
 	errChan         chan error
 	mu              *sync.Mutex
 	shutdownChan    chan struct{}
+	drainExpired    chan struct{}
 	wg              *sync.WaitGroup
 	Logger          Logger
 	silencedCache   *cache.Resource
+	compositeCache  *cache.Resource
 	storeTimeout    time.Duration
+	maxClockSkew    time.Duration
+	drainTimeout    time.Duration
+	persisted       int64
+	clusterConfig   *clusterconfig.Watcher
+	statsTracker    *EventStatsTracker
 }
 
 // Option is a functional option.
@@ -88,6 +97,23 @@ type Config struct {
 	BufferSize      int
 	WorkerCount     int
 	StoreTimeout    time.Duration
+
+	// MaxClockSkew is the allowed difference between the timestamps of the
+	// events and the clock of the backend. Clock skew is ignored if it is 0.
+	MaxClockSkew time.Duration
+
+	// DrainTimeout is the time eventd spends handling its buffered events
+	// when it is stopped. The events still buffered past this delay are only
+	// written to the store, without going through the pipeline. Buffered
+	// events are handled until they are all processed if it is 0.
+	DrainTimeout time.Duration
+
+	// ClusterConfig provides the event TTL of the cluster, if any.
+	ClusterConfig *clusterconfig.Watcher
+
+	// StatsTracker counts the processed events per namespace and per check,
+	// if not nil.
+	StatsTracker *EventStatsTracker
 }
 
 // New creates a new Eventd.
@@ -113,19 +139,30 @@ func New(ctx context.Context, c Config, opts ...Option) (*Eventd, error) {
 		livenessFactory: c.LivenessFactory,
 		errChan:         make(chan error, 1),
 		shutdownChan:    make(chan struct{}, 1),
+		drainExpired:    make(chan struct{}),
 		eventChan:       make(chan interface{}, c.BufferSize),
 		wg:              &sync.WaitGroup{},
 		mu:              &sync.Mutex{},
 		Logger:          &RawLogger{},
 		storeTimeout:    c.StoreTimeout,
+		maxClockSkew:    c.MaxClockSkew,
+		drainTimeout:    c.DrainTimeout,
+		clusterConfig:   c.ClusterConfig,
+		statsTracker:    c.StatsTracker,
 	}
 
 	e.ctx, e.cancel = context.WithCancel(ctx)
-	cache, err := cache.New(e.ctx, c.Client, &corev2.Silenced{}, false)
+	silenced, err := cache.New(e.ctx, c.Store, &corev2.Silenced{}, false)
+	if err != nil {
+		return nil, err
+	}
+	e.silencedCache = silenced
+
+	composites, err := cache.New(e.ctx, c.Store, &corev2.CompositeCheck{}, false)
 	if err != nil {
 		return nil, err
 	}
-	e.silencedCache = cache
+	e.compositeCache = composites
 
 	for _, o := range opts {
 		if err := o(e); err != nil {
@@ -154,6 +191,9 @@ func (e *Eventd) Start() error {
 		return err
 	}
 	e.startHandlers()
+	if e.compositeCache != nil {
+		go e.watchComposites(e.ctx)
+	}
 
 	return nil
 }
@@ -168,9 +208,7 @@ func (e *Eventd) startHandlers() {
 				case <-e.shutdownChan:
 					// drain the event channel.
 					for msg := range e.eventChan {
-						if err := e.handleMessage(msg); err != nil {
-							logger.WithError(err).Error("eventd - error handling event")
-						}
+						e.handle(msg)
 					}
 					return
 
@@ -180,6 +218,12 @@ func (e *Eventd) startHandlers() {
 					// return from this goroutine and emit a fatal error. It is then
 					// the responsility of eventd's parent to shutdown eventd.
 					if !ok {
+						select {
+						case <-e.shutdownChan:
+							// eventd is stopping, the channel is drained
+							return
+						default:
+						}
 						select {
 						// If this channel send doesn't occur immediately it means
 						// another goroutine has placed an error there already; we
@@ -190,15 +234,53 @@ func (e *Eventd) startHandlers() {
 						return
 					}
 
-					if err := e.handleMessage(msg); err != nil {
-						logger.WithError(err).Error("eventd - error handling event")
-					}
+					e.handle(msg)
 				}
 			}
 		}()
 	}
 }
 
+// handle handles an event received from the bus. Once the drain timeout
+// expired during shutdown, the event is only persisted.
+func (e *Eventd) handle(msg interface{}) {
+	select {
+	case <-e.drainExpired:
+		if err := e.persistMessage(msg); err != nil {
+			logger.WithError(err).Error("eventd - error persisting event")
+		}
+		return
+	default:
+	}
+	if err := e.handleMessage(msg); err != nil {
+		logger.WithError(err).Error("eventd - error handling event")
+	}
+}
+
+// persistMessage writes an event to the store, without publishing it to the
+// pipeline, so its check state isn't lost when eventd runs out of time to
+// handle it during shutdown.
+func (e *Eventd) persistMessage(msg interface{}) error {
+	event, ok := msg.(*corev2.Event)
+	if !ok {
+		return errors.New("received non-Event on event channel")
+	}
+	if err := event.Validate(); err != nil {
+		return err
+	}
+	if !event.HasCheck() {
+		// Metrics are not stored
+		return nil
+	}
+	ctx := context.WithValue(context.Background(), corev2.NamespaceKey, event.Entity.Namespace)
+	trackLastOK(event, time.Now())
+	if _, _, err := e.eventStore.UpdateEvent(ctx, event); err != nil {
+		return err
+	}
+	atomic.AddInt64(&e.persisted, 1)
+	return nil
+}
+
 // eventKey creates a key to identify the event for liveness monitoring
 func eventKey(event *corev2.Event) string {
 	// Typically we want the entity name to be the thing we monitor, but if
@@ -230,13 +312,17 @@ func (e *Eventd) handleMessage(msg interface{}) error {
 		return errors.New("received non-Event on event channel")
 	}
 
-	logEvent(event)
-
-	// Validate the received event
+	// Validate the received event before anything dereferences its entity or
+	// check
 	if err := event.Validate(); err != nil {
 		return err
 	}
 
+	logEvent(event)
+	e.statsTracker.Record(event)
+
+	e.checkClockSkew(event, time.Now())
+
 	// If the event does not contain a check (rather, it contains metrics)
 	// publish the event without writing to the store
 	if !event.HasCheck() {
@@ -255,12 +341,34 @@ func (e *Eventd) handleMessage(msg interface{}) error {
 	// Add any silenced subscriptions to the event
 	getSilenced(ctx, event, e.silencedCache)
 
+	// Raise the status of the check for the metric thresholds it breached,
+	// counting the points breaching them from the previous executions
+	var breaches map[string]int
+	if len(event.Check.OutputMetricThresholds) > 0 {
+		prevBreaches, err := e.store.GetMetricThresholdBreaches(ctx, event.Entity.Name, event.Check.Name)
+		if err != nil {
+			return err
+		}
+		breaches = evaluateMetricThresholds(event, prevBreaches)
+	}
+
+	// Record when the check was executed and last passed, for the events
+	// that are not merged with a previous one
+	trackLastOK(event, time.Now())
+
 	// Merge the new event with the stored event if a match is found
 	event, prevEvent, err := e.eventStore.UpdateEvent(ctx, event)
 	if err != nil {
 		return err
 	}
 
+	// The breaches are only counted once the event is stored
+	if breaches != nil {
+		if err := e.store.UpdateMetricThresholdBreaches(ctx, event.Entity.Name, event.Check.Name, breaches); err != nil {
+			logger.WithError(err).Error("error storing metric threshold breaches")
+		}
+	}
+
 	e.Logger.Println(event)
 
 	switches := e.livenessFactory("eventd", e.dead, e.alive, logger)
@@ -281,6 +389,11 @@ func (e *Eventd) handleMessage(msg interface{}) error {
 		}
 	}
 
+	e.updateDiscardSwitch(event, prevEvent)
+
+	// Update the composite checks aggregating this event
+	e.evaluateComposites(ctx, event)
+
 	EventsProcessed.WithLabelValues(EventsProcessedLabelSuccess).Inc()
 
 	return e.bus.Publish(messaging.TopicEvent, event)
@@ -476,13 +589,42 @@ func (e *Eventd) Stop() error {
 	if err := e.subscription.Cancel(); err != nil {
 		logger.WithError(err).Error("unable to unsubscribe from message bus")
 	}
-	e.cancel()
-	close(e.eventChan)
+	// Handle the buffered events before cancelling the context of eventd, so
+	// the events received before the shutdown aren't lost
 	close(e.shutdownChan)
-	e.wg.Wait()
+	close(e.eventChan)
+	if !e.waitDrained() {
+		logger.WithField("timeout", e.drainTimeout).Warn("eventd drain timeout expired, persisting the remaining events without handling them")
+		close(e.drainExpired)
+		e.wg.Wait()
+	}
+	if n := atomic.LoadInt64(&e.persisted); n > 0 {
+		logger.WithField("events", n).Warn("events were persisted without being handled")
+	}
+	e.cancel()
 	return nil
 }
 
+// waitDrained waits for the workers to handle the buffered events, and
+// returns false if they didn't within the drain timeout.
+func (e *Eventd) waitDrained() bool {
+	if e.drainTimeout <= 0 {
+		e.wg.Wait()
+		return true
+	}
+	drained := make(chan struct{})
+	go func() {
+		e.wg.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+		return true
+	case <-time.After(e.drainTimeout):
+		return false
+	}
+}
+
 // Err returns a channel to listen for terminal errors on.
 func (e *Eventd) Err() <-chan error {
 	return e.errChan
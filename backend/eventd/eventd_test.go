@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -50,6 +51,7 @@ func newEventd(store store.Store, bus messaging.MessageBus, livenessFactory live
 		livenessFactory: livenessFactory,
 		errChan:         make(chan error, 1),
 		shutdownChan:    make(chan struct{}, 1),
+		drainExpired:    make(chan struct{}),
 		eventChan:       make(chan interface{}, 100),
 		wg:              &sync.WaitGroup{},
 		mu:              &sync.Mutex{},
@@ -94,6 +96,7 @@ func TestEventHandling(t *testing.T) {
 	).Return(nilEvent, nil)
 	event.Check.Occurrences = 1
 	event.Check.State = corev2.EventPassingState
+	event.Check.Executed = event.Timestamp
 	event.Check.LastOK = event.Timestamp
 	mockStore.On("UpdateEvent", mock.Anything).Return(event, nilEvent, nil)
 
@@ -121,6 +124,41 @@ func TestEventHandling(t *testing.T) {
 	assert.Equal(t, event.Timestamp, event.Check.LastOK)
 }
 
+func TestEventdDrainTimeout(t *testing.T) {
+	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	require.NoError(t, err)
+	require.NoError(t, bus.Start())
+
+	mockStore := &mockstore.MockStore{}
+	e := newEventd(mockStore, bus, newFakeFactory(&fakeSwitchSet{}))
+	e.workerCount = 1
+	e.drainTimeout = 10 * time.Millisecond
+
+	var nilEvent *corev2.Event
+	mockStore.On("GetEntityByName", mock.Anything, "entity").
+		Return(corev2.FixtureEntity("entity"), nil)
+	mockStore.On("GetEventByEntityCheck", mock.Anything, "entity", "check").
+		Return(nilEvent, nil)
+	mockStore.On("GetSilencedEntriesBySubscription", mock.Anything).
+		Return([]*corev2.Silenced{}, nil)
+	mockStore.On("GetSilencedEntriesByCheckName", mock.Anything).
+		Return([]*corev2.Silenced{}, nil)
+	mockStore.On("UpdateEvent", mock.Anything).
+		Return(corev2.FixtureEvent("entity", "check"), nilEvent, nil).
+		After(50 * time.Millisecond)
+
+	// Buffer more events than eventd can handle before the drain timeout
+	for i := 0; i < 3; i++ {
+		e.eventChan <- corev2.FixtureEvent("entity", "check")
+	}
+	require.NoError(t, e.Start())
+	require.NoError(t, e.Stop())
+
+	// The events left when the timeout expired are persisted anyway
+	mockStore.AssertNumberOfCalls(t, "UpdateEvent", 3)
+	assert.True(t, atomic.LoadInt64(&e.persisted) > 0)
+}
+
 func TestEventMonitor(t *testing.T) {
 	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
 	require.NoError(t, err)
@@ -203,6 +241,11 @@ func TestCheckTTL(t *testing.T) {
 		switchesFunc     switchesFunc
 		wantErr          bool
 	}{
+		{
+			name:    "an event without an entity is rejected",
+			msg:     &corev2.Event{Check: corev2.FixtureCheck("check")},
+			wantErr: true,
+		},
 		{
 			name:    "a check without TTL shouldn't bury its switchset",
 			msg:     nonTTLCheck,
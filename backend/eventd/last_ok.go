@@ -0,0 +1,28 @@
+package eventd
+
+import (
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// trackLastOK makes sure the check of the event records when it was executed
+// and, if it passed, when it last passed. Events created through the API, and
+// the first event of a check, don't go through the merge with a previous
+// event that maintains the last OK time, so without this a check that never
+// failed would appear to never have passed.
+func trackLastOK(event *corev2.Event, now time.Time) {
+	if !event.HasCheck() {
+		return
+	}
+	check := event.Check
+	if check.Executed == 0 {
+		check.Executed = event.Timestamp
+		if check.Executed <= 0 {
+			check.Executed = now.Unix()
+		}
+	}
+	if check.Status == 0 {
+		check.LastOK = check.Executed
+	}
+}
@@ -0,0 +1,49 @@
+package eventd
+
+import (
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackLastOK(t *testing.T) {
+	now := time.Unix(1000, 0)
+
+	tests := []struct {
+		name         string
+		timestamp    int64
+		executed     int64
+		status       uint32
+		lastOK       int64
+		wantExecuted int64
+		wantLastOK   int64
+	}{
+		{"passing check", 900, 950, 0, 0, 950, 950},
+		{"failing check keeps last ok", 900, 950, 2, 500, 950, 500},
+		{"executed defaults to the event timestamp", 900, 0, 0, 0, 900, 900},
+		{"executed defaults to now", 0, 0, 0, 0, 1000, 1000},
+		{"failing check without executed time", 0, 0, 1, 0, 1000, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := corev2.FixtureEvent("entity", "check")
+			event.Timestamp = tt.timestamp
+			event.Check.Executed = tt.executed
+			event.Check.Status = tt.status
+			event.Check.LastOK = tt.lastOK
+
+			trackLastOK(event, now)
+			assert.Equal(t, tt.wantExecuted, event.Check.Executed)
+			assert.Equal(t, tt.wantLastOK, event.Check.LastOK)
+		})
+	}
+}
+
+func TestTrackLastOKMetrics(t *testing.T) {
+	event := corev2.FixtureEvent("entity", "check")
+	event.Check = nil
+	trackLastOK(event, time.Now())
+	assert.Nil(t, event.Check)
+}
@@ -0,0 +1,74 @@
+package eventd
+
+import (
+	"fmt"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// evaluateMetricThresholds evaluates the metric thresholds of the check of the
+// event against its metric points, and raises the status of the check to the
+// status of the most severe threshold breached, so that checks which only
+// output metrics can alert. The thresholds breached are appended to the
+// output of the check.
+//
+// The consecutive points breaching each threshold are counted from the ones of
+// the previous executions of the check, prevBreaches, and returned by rule, so
+// that a threshold held across check executions is breached. Only the current
+// rules of the check are returned, and nil if it has none.
+func evaluateMetricThresholds(event *corev2.Event, prevBreaches map[string]int) map[string]int {
+	if !event.HasCheck() || len(event.Check.OutputMetricThresholds) == 0 {
+		return nil
+	}
+
+	var points []*corev2.MetricPoint
+	if event.HasMetrics() {
+		points = event.Metrics.Points
+	}
+	fields := logrus.Fields{
+		"entity":    event.Entity.Name,
+		"namespace": event.Entity.Namespace,
+		"check":     event.Check.Name,
+	}
+
+	breaches := make(map[string]int, len(event.Check.OutputMetricThresholds))
+	var breached []string
+	status := event.Check.Status
+	for _, rule := range event.Check.OutputMetricThresholds {
+		threshold, err := corev2.ParseMetricThreshold(rule)
+		if err != nil {
+			logger.WithFields(fields).WithError(err).Warn("skipping invalid metric threshold")
+			continue
+		}
+		count := threshold.Breaches(points, prevBreaches[rule])
+		breaches[rule] = count
+		if !threshold.Breached(count) {
+			continue
+		}
+		breached = append(breached, fmt.Sprintf("%s: %s", metricThresholdLabel(threshold.Status), rule))
+		if threshold.Status > status {
+			status = threshold.Status
+		}
+	}
+
+	if len(breached) == 0 {
+		return breaches
+	}
+
+	event.Check.Status = status
+	output := strings.TrimRight(event.Check.Output, "\n")
+	if output != "" {
+		output += "\n"
+	}
+	event.Check.Output = output + strings.Join(breached, "\n") + "\n"
+	return breaches
+}
+
+func metricThresholdLabel(status uint32) string {
+	if status == corev2.MetricThresholdWarning {
+		return "WARNING"
+	}
+	return "CRITICAL"
+}
@@ -0,0 +1,109 @@
+package eventd
+
+import (
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateMetricThresholds(t *testing.T) {
+	points := []*corev2.MetricPoint{
+		{Name: "cpu.idle", Value: 8, Timestamp: 1},
+		{Name: "cpu.idle", Value: 5, Timestamp: 2},
+		{Name: "cpu.idle", Value: 3, Timestamp: 3},
+	}
+
+	tests := []struct {
+		name         string
+		status       uint32
+		thresholds   []string
+		prevBreaches map[string]int
+		wantStatus   uint32
+		wantOutput   string
+		wantBreaches map[string]int
+	}{
+		{
+			name:       "no thresholds",
+			wantOutput: "ok\n",
+		},
+		{
+			name:         "threshold not breached",
+			thresholds:   []string{`metric "cpu.idle" < 5 for 3 points`},
+			wantOutput:   "ok\n",
+			wantBreaches: map[string]int{`metric "cpu.idle" < 5 for 3 points`: 1},
+		},
+		{
+			name:         "critical threshold breached",
+			thresholds:   []string{`metric "cpu.idle" < 10 for 3 points`},
+			wantStatus:   2,
+			wantOutput:   "ok\nCRITICAL: metric \"cpu.idle\" < 10 for 3 points\n",
+			wantBreaches: map[string]int{`metric "cpu.idle" < 10 for 3 points`: 3},
+		},
+		{
+			name:         "threshold breached across events",
+			thresholds:   []string{`metric "cpu.idle" < 9 for 4 points`},
+			prevBreaches: map[string]int{`metric "cpu.idle" < 9 for 4 points`: 1, `metric "cpu.idle" < 1`: 4},
+			wantStatus:   2,
+			wantOutput:   "ok\nCRITICAL: metric \"cpu.idle\" < 9 for 4 points\n",
+			wantBreaches: map[string]int{`metric "cpu.idle" < 9 for 4 points`: 4},
+		},
+		{
+			name:         "breaches reset by the points not breaching",
+			thresholds:   []string{`metric "cpu.idle" @6:10 for 2 points`},
+			prevBreaches: map[string]int{`metric "cpu.idle" @6:10 for 2 points`: 1},
+			wantOutput:   "ok\n",
+			wantBreaches: map[string]int{`metric "cpu.idle" @6:10 for 2 points`: 0},
+		},
+		{
+			name: "most severe threshold wins",
+			thresholds: []string{
+				`warning metric "cpu.idle" < 10`,
+				`critical metric "cpu.idle" < 4`,
+			},
+			wantStatus:   2,
+			wantOutput:   "ok\nWARNING: warning metric \"cpu.idle\" < 10\nCRITICAL: critical metric \"cpu.idle\" < 4\n",
+			wantBreaches: map[string]int{`critical metric "cpu.idle" < 4`: 1, `warning metric "cpu.idle" < 10`: 3},
+		},
+		{
+			name:         "status is never lowered",
+			status:       2,
+			thresholds:   []string{`warning metric "cpu.idle" < 10`},
+			wantStatus:   2,
+			wantOutput:   "ok\nWARNING: warning metric \"cpu.idle\" < 10\n",
+			wantBreaches: map[string]int{`warning metric "cpu.idle" < 10`: 3},
+		},
+		{
+			name:         "invalid thresholds are skipped",
+			thresholds:   []string{`metric "cpu.idle" is low`, `warning metric "cpu.idle" 4:`},
+			wantStatus:   1,
+			wantOutput:   "ok\nWARNING: warning metric \"cpu.idle\" 4:\n",
+			wantBreaches: map[string]int{`warning metric "cpu.idle" 4:`: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := corev2.FixtureEvent("entity", "check")
+			event.Check.Status = tt.status
+			event.Check.Output = "ok\n"
+			event.Check.OutputMetricThresholds = tt.thresholds
+			event.Metrics = &corev2.Metrics{Points: points}
+
+			breaches := evaluateMetricThresholds(event, tt.prevBreaches)
+			assert.Equal(t, tt.wantStatus, event.Check.Status)
+			assert.Equal(t, tt.wantOutput, event.Check.Output)
+			assert.Equal(t, tt.wantBreaches, breaches)
+		})
+	}
+}
+
+func TestEvaluateMetricThresholdsWithoutMetrics(t *testing.T) {
+	event := corev2.FixtureEvent("entity", "check")
+	event.Check.OutputMetricThresholds = []string{`metric "cpu.idle" < 10 for 2 points`}
+	prevBreaches := map[string]int{`metric "cpu.idle" < 10 for 2 points`: 1}
+
+	// The breaches are kept until the metric is reported again
+	breaches := evaluateMetricThresholds(event, prevBreaches)
+	assert.Equal(t, uint32(0), event.Check.Status)
+	assert.Equal(t, prevBreaches, breaches)
+}
@@ -0,0 +1,215 @@
+package eventd
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/etcd"
+)
+
+const (
+	// eventStatsBuckets is the number of one-minute buckets of the rolling
+	// window of the event statistics.
+	eventStatsBuckets = 15
+
+	// eventStatsKeyPrefix is the prefix of the etcd keys of the event
+	// statistics of the backends.
+	eventStatsKeyPrefix = "/sensu.io/event_stats/"
+)
+
+type eventStatsKey struct {
+	namespace string
+	check     string
+}
+
+type eventStatsBucket struct {
+	minute int64
+	counts map[eventStatsKey]int64
+}
+
+// eventStatsReport is the record of the event counts of a backend, per minute,
+// from which the statistics of the cluster are computed.
+type eventStatsReport struct {
+	Start  int64             `json:"start"`
+	Counts []eventStatsCount `json:"counts"`
+}
+
+type eventStatsCount struct {
+	Minute    int64  `json:"minute"`
+	Namespace string `json:"namespace"`
+	Check     string `json:"check,omitempty"`
+	Events    int64  `json:"events"`
+}
+
+// EventStatsTracker counts the events processed by eventd per namespace and
+// per check over the last 15 minutes, so the checks generating most of the
+// events can be identified. It's shared by the successive instances of eventd
+// so that a restart doesn't reset it. The counts of the backend are recorded
+// in etcd, so that the statistics of the whole cluster are reported.
+type EventStatsTracker struct {
+	mu       sync.Mutex
+	start    time.Time
+	buckets  [eventStatsBuckets]eventStatsBucket
+	client   *clientv3.Client
+	reporter *etcd.BackendReporter
+}
+
+// NewEventStatsTracker returns a new EventStatsTracker. The events of the
+// other backends are not counted if client is nil.
+func NewEventStatsTracker(client *clientv3.Client) *EventStatsTracker {
+	t := &EventStatsTracker{start: time.Now(), client: client}
+	if client != nil {
+		t.reporter = etcd.NewBackendReporter(client, eventStatsKeyPrefix, etcd.DefaultReportInterval, func() interface{} {
+			return t.localReport()
+		})
+	}
+	return t
+}
+
+// Start records the event counts of the backend in etcd until ctx is done.
+func (t *EventStatsTracker) Start(ctx context.Context) {
+	if t.reporter != nil {
+		t.reporter.Start(ctx)
+	}
+}
+
+// Record counts the event, if the tracker is not nil.
+func (t *EventStatsTracker) Record(event *corev2.Event) {
+	if t == nil {
+		return
+	}
+	key := eventStatsKey{namespace: event.Entity.Namespace}
+	if event.HasCheck() {
+		key.check = event.Check.Name
+	}
+	t.record(key, time.Now())
+}
+
+func (t *EventStatsTracker) record(key eventStatsKey, now time.Time) {
+	minute := now.Unix() / 60
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bucket := &t.buckets[minute%eventStatsBuckets]
+	if bucket.minute != minute || bucket.counts == nil {
+		bucket.minute = minute
+		bucket.counts = make(map[eventStatsKey]int64)
+	}
+	bucket.counts[key]++
+}
+
+// Stats returns the rates of the events processed by the running backends of
+// the cluster over the rolling window.
+func (t *EventStatsTracker) Stats(ctx context.Context) (*corev2.EventStats, error) {
+	if t == nil {
+		return &corev2.EventStats{Namespaces: []corev2.EventRate{}, Checks: []corev2.EventRate{}}, nil
+	}
+	reports := []eventStatsReport{t.localReport()}
+	if t.reporter != nil {
+		records, err := etcd.GetBackendReports(ctx, t.client, eventStatsKeyPrefix)
+		if err != nil {
+			return nil, err
+		}
+		// The events of this backend are counted as of now rather than as of
+		// its last record
+		delete(records, t.reporter.Key())
+		for key, record := range records {
+			var report eventStatsReport
+			if err := json.Unmarshal(record, &report); err != nil {
+				logger.WithError(err).WithField("key", key).Error("couldn't decode the event statistics of a backend")
+				continue
+			}
+			reports = append(reports, report)
+		}
+	}
+	return eventStats(reports, time.Now()), nil
+}
+
+// localReport returns the event counts of the backend.
+func (t *EventStatsTracker) localReport() eventStatsReport {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	report := eventStatsReport{Start: t.start.Unix(), Counts: []eventStatsCount{}}
+	for _, bucket := range t.buckets {
+		for key, count := range bucket.counts {
+			report.Counts = append(report.Counts, eventStatsCount{
+				Minute:    bucket.minute,
+				Namespace: key.namespace,
+				Check:     key.check,
+				Events:    count,
+			})
+		}
+	}
+	return report
+}
+
+// eventStats merges the event counts of the backends within the rolling
+// window ending at now, and returns their rates. The window starts with the
+// earliest backend if it ran for less than the whole window.
+func eventStats(reports []eventStatsReport, now time.Time) *corev2.EventStats {
+	minute := now.Unix() / 60
+	checks := map[eventStatsKey]int64{}
+	namespaces := map[eventStatsKey]int64{}
+	var total int64
+
+	window := time.Duration(0)
+	for _, report := range reports {
+		if w := now.Sub(time.Unix(report.Start, 0)); w > window {
+			window = w
+		}
+		for _, count := range report.Counts {
+			if count.Minute <= minute-eventStatsBuckets || count.Minute > minute {
+				continue
+			}
+			key := eventStatsKey{namespace: count.Namespace, check: count.Check}
+			checks[key] += count.Events
+			namespaces[eventStatsKey{namespace: key.namespace}] += count.Events
+			total += count.Events
+		}
+	}
+
+	if window > eventStatsBuckets*time.Minute {
+		window = eventStatsBuckets * time.Minute
+	}
+	if window < time.Minute {
+		window = time.Minute
+	}
+
+	return &corev2.EventStats{
+		Window:     int64(window.Seconds()),
+		Events:     total,
+		Namespaces: eventRates(namespaces, total, window),
+		Checks:     eventRates(checks, total, window),
+	}
+}
+
+// eventRates converts the event counts to rates, sorted by decreasing number
+// of events.
+func eventRates(counts map[eventStatsKey]int64, total int64, window time.Duration) []corev2.EventRate {
+	rates := make([]corev2.EventRate, 0, len(counts))
+	for key, count := range counts {
+		rates = append(rates, corev2.EventRate{
+			Namespace: key.namespace,
+			Check:     key.check,
+			Events:    count,
+			PerMinute: float64(count) / window.Minutes(),
+			Share:     100 * float64(count) / float64(total),
+		})
+	}
+	sort.Slice(rates, func(i, j int) bool {
+		if rates[i].Events != rates[j].Events {
+			return rates[i].Events > rates[j].Events
+		}
+		if rates[i].Namespace != rates[j].Namespace {
+			return rates[i].Namespace < rates[j].Namespace
+		}
+		return rates[i].Check < rates[j].Check
+	})
+	return rates
+}
@@ -0,0 +1,84 @@
+package eventd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventStatsTracker(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	tracker := &EventStatsTracker{start: start}
+
+	disk := eventStatsKey{namespace: "default", check: "disk"}
+	cpu := eventStatsKey{namespace: "default", check: "cpu"}
+	web := eventStatsKey{namespace: "web", check: "http"}
+	for i := 0; i < 6; i++ {
+		tracker.record(disk, start.Add(time.Duration(i)*time.Minute))
+	}
+	tracker.record(cpu, start)
+	tracker.record(web, start.Add(5*time.Minute))
+
+	stats := eventStats([]eventStatsReport{tracker.localReport()}, start.Add(10*time.Minute))
+	assert.Equal(t, int64(600), stats.Window)
+	assert.Equal(t, int64(8), stats.Events)
+	require.Len(t, stats.Checks, 3)
+	assert.Equal(t, corev2.EventRate{Namespace: "default", Check: "disk", Events: 6, PerMinute: 0.6, Share: 75}, stats.Checks[0])
+	assert.Equal(t, "cpu", stats.Checks[1].Check)
+	assert.Equal(t, "http", stats.Checks[2].Check)
+	require.Len(t, stats.Namespaces, 2)
+	assert.Equal(t, corev2.EventRate{Namespace: "default", Events: 7, PerMinute: 0.7, Share: 87.5}, stats.Namespaces[0])
+
+	// The events older than the window are forgotten, including the ones of a
+	// reused bucket
+	tracker.record(web, start.Add(15*time.Minute))
+	stats = eventStats([]eventStatsReport{tracker.localReport()}, start.Add(16*time.Minute))
+	assert.Equal(t, int64(900), stats.Window)
+	assert.Equal(t, int64(6), stats.Events)
+	assert.Equal(t, "disk", stats.Checks[0].Check)
+	assert.Equal(t, int64(4), stats.Checks[0].Events)
+}
+
+func TestEventStatsMergesBackends(t *testing.T) {
+	start := time.Unix(1600000000, 0)
+	minute := start.Unix() / 60
+	reports := []eventStatsReport{
+		{
+			Start: start.Unix(),
+			Counts: []eventStatsCount{
+				{Minute: minute, Namespace: "default", Check: "disk", Events: 3},
+				{Minute: minute + 1, Namespace: "default", Check: "disk", Events: 1},
+			},
+		},
+		{
+			// The window starts with the earliest backend
+			Start: start.Add(-5 * time.Minute).Unix(),
+			Counts: []eventStatsCount{
+				{Minute: minute, Namespace: "default", Check: "disk", Events: 2},
+				{Minute: minute, Namespace: "web", Check: "http", Events: 4},
+				// Older than the window
+				{Minute: minute - 20, Namespace: "web", Check: "http", Events: 10},
+			},
+		},
+	}
+
+	stats := eventStats(reports, start.Add(5*time.Minute))
+	assert.Equal(t, int64(600), stats.Window)
+	assert.Equal(t, int64(10), stats.Events)
+	require.Len(t, stats.Checks, 2)
+	assert.Equal(t, corev2.EventRate{Namespace: "default", Check: "disk", Events: 6, PerMinute: 0.6, Share: 60}, stats.Checks[0])
+	assert.Equal(t, corev2.EventRate{Namespace: "web", Check: "http", Events: 4, PerMinute: 0.4, Share: 40}, stats.Checks[1])
+	require.Len(t, stats.Namespaces, 2)
+}
+
+func TestEventStatsTrackerNil(t *testing.T) {
+	var tracker *EventStatsTracker
+	tracker.Record(corev2.FixtureEvent("web01", "http"))
+	stats, err := tracker.Stats(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, stats.Checks)
+}
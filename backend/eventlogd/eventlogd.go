@@ -0,0 +1,250 @@
+// Package eventlogd exports every event processed by the backend, as
+// newline-delimited JSON, to a file or named pipe, and/or to a Kafka topic
+// through a Kafka REST Proxy, so that the full event stream can be consumed
+// by external systems without configuring a handler for each check.
+package eventlogd
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/messaging"
+)
+
+const (
+	// DefaultBufferSize is the number of events buffered when none is
+	// configured.
+	DefaultBufferSize = 1000
+
+	// DefaultMaxBackups is the number of rotated files kept when none is
+	// configured.
+	DefaultMaxBackups = 3
+
+	// maxBatchSize is the maximum number of events written at once.
+	maxBatchSize = 100
+)
+
+// DroppedEvents counts the events that were not exported, either because the
+// buffer was full or because a sink couldn't write them.
+var DroppedEvents = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "sensu_go_eventlogd_dropped_events_total",
+		Help: "Number of events dropped by the event log",
+	},
+	[]string{"reason"},
+)
+
+// sink is a destination of the exported events.
+type sink interface {
+	// Write writes a batch of events.
+	Write(events []*corev2.Event) error
+
+	// Close releases the resources of the sink.
+	Close() error
+
+	// Name identifies the sink in the logs.
+	Name() string
+}
+
+// Eventlogd exports the events published on the event topic of the bus.
+type Eventlogd struct {
+	bus          messaging.MessageBus
+	subscription messaging.Subscription
+	sinks        []sink
+	receiver     chan interface{}
+	eventChan    chan interface{}
+	stopping     chan struct{}
+	wg           sync.WaitGroup
+	errChan      chan error
+	dropped      int64
+}
+
+// Config configures Eventlogd.
+type Config struct {
+	// Bus is the message bus on which the events are published.
+	Bus messaging.MessageBus
+
+	// File is the path of the file, or named pipe, the events are written
+	// to. The events are not written to a file if empty.
+	File string
+
+	// MaxSize is the size in bytes after which the file is rotated. The file
+	// is never rotated if 0, or if it is a named pipe.
+	MaxSize int64
+
+	// MaxBackups is the number of rotated files kept. DefaultMaxBackups is
+	// used if 0.
+	MaxBackups int
+
+	// KafkaURL is the URL of the Kafka REST Proxy the events are published
+	// to. The events are not published to Kafka if empty.
+	KafkaURL string
+
+	// KafkaTopic is the Kafka topic the events are published to.
+	KafkaTopic string
+
+	// BufferSize is the number of events buffered between the bus and the
+	// sinks, before new events are dropped. DefaultBufferSize is used if 0.
+	BufferSize int
+}
+
+// Option is a functional option.
+type Option func(*Eventlogd) error
+
+// New creates a new Eventlogd.
+func New(c Config, opts ...Option) (*Eventlogd, error) {
+	if c.File == "" && c.KafkaURL == "" {
+		return nil, errors.New("no event log file or Kafka REST Proxy configured")
+	}
+	if c.KafkaURL != "" && c.KafkaTopic == "" {
+		return nil, errors.New("a Kafka topic is required to publish events to Kafka")
+	}
+	if c.BufferSize == 0 {
+		c.BufferSize = DefaultBufferSize
+	}
+	if c.MaxBackups == 0 {
+		c.MaxBackups = DefaultMaxBackups
+	}
+
+	e := &Eventlogd{
+		bus:       c.Bus,
+		receiver:  make(chan interface{}),
+		eventChan: make(chan interface{}, c.BufferSize),
+		stopping:  make(chan struct{}),
+		errChan:   make(chan error, 1),
+	}
+	if c.File != "" {
+		e.sinks = append(e.sinks, newFileSink(c.File, c.MaxSize, c.MaxBackups))
+	}
+	if c.KafkaURL != "" {
+		e.sinks = append(e.sinks, newKafkaSink(c.KafkaURL, c.KafkaTopic))
+	}
+
+	for _, o := range opts {
+		if err := o(e); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// Receiver returns the event channel of Eventlogd. The bus blocks until the
+// events published are received, so they are moved to a bounded buffer
+// without blocking, and dropped while the buffer is full, so that a slow sink
+// never stalls the publishers.
+func (e *Eventlogd) Receiver() chan<- interface{} {
+	return e.receiver
+}
+
+// Start Eventlogd, subscribing to the event topic of the bus.
+func (e *Eventlogd) Start() error {
+	sub, err := e.bus.Subscribe(messaging.TopicEvent, "eventlogd", e)
+	if err != nil {
+		return err
+	}
+	e.subscription = sub
+	_ = prometheus.Register(DroppedEvents)
+
+	e.wg.Add(2)
+	go e.receive()
+	go e.export()
+	return nil
+}
+
+// Stop Eventlogd. The buffered events are written before it returns.
+func (e *Eventlogd) Stop() error {
+	err := e.subscription.Cancel()
+	close(e.stopping)
+	e.wg.Wait()
+	for _, s := range e.sinks {
+		if cerr := s.Close(); cerr != nil {
+			logger.WithError(cerr).WithField("sink", s.Name()).Error("couldn't close event log")
+		}
+	}
+	close(e.errChan)
+	return err
+}
+
+// Err returns a channel to listen for terminal errors on.
+func (e *Eventlogd) Err() <-chan error {
+	return e.errChan
+}
+
+// Name returns the daemon name.
+func (e *Eventlogd) Name() string {
+	return "eventlogd"
+}
+
+// Dropped returns the number of events dropped because the buffer was full,
+// or because they couldn't be written to one of the sinks.
+func (e *Eventlogd) Dropped() int64 {
+	return atomic.LoadInt64(&e.dropped)
+}
+
+// receive moves the events received from the bus to the buffer, until the
+// daemon is stopped. The events received while the buffer is full are
+// dropped.
+func (e *Eventlogd) receive() {
+	defer e.wg.Done()
+	// Let export write the buffered events and return
+	defer close(e.eventChan)
+	for {
+		select {
+		case msg := <-e.receiver:
+			select {
+			case e.eventChan <- msg:
+			default:
+				atomic.AddInt64(&e.dropped, 1)
+				DroppedEvents.WithLabelValues("buffer_full").Inc()
+				logger.Warn("event log buffer full, dropping event")
+			}
+		case <-e.stopping:
+			return
+		}
+	}
+}
+
+// export writes the buffered events to the sinks in batches, until the
+// buffer is closed.
+func (e *Eventlogd) export() {
+	defer e.wg.Done()
+	for msg := range e.eventChan {
+		e.write(e.batch(msg))
+	}
+}
+
+// batch returns the events of the given message, and of the messages
+// already buffered, up to maxBatchSize.
+func (e *Eventlogd) batch(msg interface{}) []*corev2.Event {
+	events := make([]*corev2.Event, 0, maxBatchSize)
+	for ok := true; ok; {
+		if event, isEvent := msg.(*corev2.Event); isEvent {
+			events = append(events, event)
+		}
+		if len(events) == maxBatchSize {
+			return events
+		}
+		select {
+		case msg, ok = <-e.eventChan:
+		default:
+			return events
+		}
+	}
+	return events
+}
+
+func (e *Eventlogd) write(events []*corev2.Event) {
+	if len(events) == 0 {
+		return
+	}
+	for _, s := range e.sinks {
+		if err := s.Write(events); err != nil {
+			atomic.AddInt64(&e.dropped, int64(len(events)))
+			DroppedEvents.WithLabelValues("write_failed").Add(float64(len(events)))
+			logger.WithError(err).WithField("sink", s.Name()).Errorf("couldn't export %d events", len(events))
+		}
+	}
+}
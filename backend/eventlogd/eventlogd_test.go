@@ -0,0 +1,191 @@
+package eventlogd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func readEvents(t *testing.T, path string) []*corev2.Event {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var events []*corev2.Event
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event corev2.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		events = append(events, &event)
+	}
+	require.NoError(t, scanner.Err())
+	return events
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"file", Config{File: "events.log"}, false},
+		{"kafka", Config{KafkaURL: "http://localhost:8082", KafkaTopic: "events"}, false},
+		{"nothing to export to", Config{}, true},
+		{"kafka without topic", Config{KafkaURL: "http://localhost:8082"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eventlogd")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.log")
+
+	event := corev2.FixtureEvent("entity", "check")
+	b, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	// Each file holds two events
+	s := newFileSink(path, int64(2*(len(b)+1)), 2)
+	defer s.Close()
+	for i := 0; i < 7; i++ {
+		require.NoError(t, s.Write([]*corev2.Event{event}))
+	}
+
+	assert.Len(t, readEvents(t, path), 1)
+	assert.Len(t, readEvents(t, path+".1"), 2)
+	assert.Len(t, readEvents(t, path+".2"), 2)
+	_, err = os.Stat(path + ".3")
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestKafkaSink(t *testing.T) {
+	var body struct {
+		Records []kafkaRecord `json:"records"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/topics/sensu-events", r.URL.Path)
+		assert.Equal(t, kafkaContentType, r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		_, _ = w.Write([]byte(`{"offsets":[]}`))
+	}))
+	defer server.Close()
+
+	s := newKafkaSink(server.URL+"/", "sensu-events")
+	require.NoError(t, s.Write([]*corev2.Event{corev2.FixtureEvent("entity", "check")}))
+	require.Len(t, body.Records, 1)
+	assert.Equal(t, "default/entity", body.Records[0].Key)
+	assert.Equal(t, "check", body.Records[0].Value.Check.Name)
+}
+
+func TestKafkaSinkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error_code":40403,"message":"Topic not found."}`, http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	s := newKafkaSink(server.URL, "missing")
+	assert.Error(t, s.Write([]*corev2.Event{corev2.FixtureEvent("entity", "check")}))
+}
+
+func TestEventlogd(t *testing.T) {
+	dir, err := ioutil.TempDir("", "eventlogd")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.log")
+
+	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	require.NoError(t, err)
+	require.NoError(t, bus.Start())
+	defer bus.Stop()
+
+	e, err := New(Config{Bus: bus, File: path})
+	require.NoError(t, err)
+	require.NoError(t, e.Start())
+
+	for _, name := range []string{"disk", "memory"} {
+		require.NoError(t, bus.Publish(messaging.TopicEvent, corev2.FixtureEvent("entity", name)))
+	}
+	// Messages that are not events are ignored
+	require.NoError(t, bus.Publish(messaging.TopicEvent, "garbage"))
+
+	assert.Eventually(t, func() bool {
+		b, err := ioutil.ReadFile(path)
+		return err == nil && bytes.Count(b, []byte("\n")) == 2
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, e.Stop())
+
+	events := readEvents(t, path)
+	require.Len(t, events, 2)
+	assert.Equal(t, "disk", events[0].Check.Name)
+	assert.Equal(t, "memory", events[1].Check.Name)
+	assert.Equal(t, int64(0), e.Dropped())
+}
+
+// blockingSink blocks the writes until it is released.
+type blockingSink struct {
+	release chan struct{}
+}
+
+func (s blockingSink) Write(events []*corev2.Event) error {
+	<-s.release
+	return nil
+}
+
+func (s blockingSink) Close() error { return nil }
+
+func (s blockingSink) Name() string { return "blocking" }
+
+func TestEventlogdDropsEventsWhenBufferFull(t *testing.T) {
+	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	require.NoError(t, err)
+	require.NoError(t, bus.Start())
+	defer bus.Stop()
+
+	e, err := New(Config{Bus: bus, File: "events.log", BufferSize: 1})
+	require.NoError(t, err)
+	sink := blockingSink{release: make(chan struct{})}
+	e.sinks = []sink{sink}
+	require.NoError(t, e.Start())
+
+	// The publishers are not blocked by the sink
+	published := make(chan struct{})
+	go func() {
+		for i := 0; i < 10; i++ {
+			_ = bus.Publish(messaging.TopicEvent, corev2.FixtureEvent("entity", "check"))
+		}
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the bus is blocked by the event log")
+	}
+
+	assert.True(t, e.Dropped() > 0)
+	close(sink.release)
+	require.NoError(t, e.Stop())
+}
@@ -0,0 +1,117 @@
+package eventlogd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// fileSink writes the events as newline-delimited JSON to a file, which is
+// rotated when it reaches its maximum size, or to a named pipe.
+type fileSink struct {
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	file *os.File
+	pipe bool
+	size int64
+}
+
+func newFileSink(path string, maxSize int64, maxBackups int) *fileSink {
+	return &fileSink{
+		path:       path,
+		maxSize:    maxSize,
+		maxBackups: maxBackups,
+	}
+}
+
+// Write writes the events, one JSON object per line. The file is opened, or
+// rotated, as needed.
+func (s *fileSink) Write(events []*corev2.Event) error {
+	var buf []byte
+	for _, event := range events {
+		b, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		buf = append(buf, b...)
+		buf = append(buf, '\n')
+	}
+
+	if s.file == nil {
+		if err := s.open(); err != nil {
+			return err
+		}
+	}
+	if s.maxSize > 0 && !s.pipe && s.size > 0 && s.size+int64(len(buf)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := s.file.Write(buf)
+	s.size += int64(n)
+	if err != nil {
+		// Open the file again for the next events, in case it was removed
+		// or the reader of the pipe went away
+		_ = s.Close()
+		return err
+	}
+	return nil
+}
+
+// Close closes the file.
+func (s *fileSink) Close() error {
+	if s.file == nil {
+		return nil
+	}
+	err := s.file.Close()
+	s.file = nil
+	return err
+}
+
+// Name returns the path of the file.
+func (s *fileSink) Name() string {
+	return s.path
+}
+
+func (s *fileSink) open() error {
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE|openFlags, 0640)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	s.file = f
+	s.pipe = info.Mode()&os.ModeNamedPipe != 0
+	s.size = info.Size()
+	return nil
+}
+
+// rotate renames the file to path.1, after renaming the previous backups to
+// path.2, path.3 and so on, deleting the oldest one, and opens a new file.
+func (s *fileSink) rotate() error {
+	if err := s.Close(); err != nil {
+		return err
+	}
+	for i := s.maxBackups - 1; i > 0; i-- {
+		err := os.Rename(s.backup(i), s.backup(i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(s.path, s.backup(1)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return s.open()
+}
+
+func (s *fileSink) backup(i int) string {
+	return fmt.Sprintf("%s.%d", s.path, i)
+}
@@ -0,0 +1,9 @@
+// +build !windows
+
+package eventlogd
+
+import "syscall"
+
+// openFlags are added to the flags used to open the event log. Opening a named
+// pipe without a reader fails, instead of blocking, when it is non-blocking.
+const openFlags = syscall.O_NONBLOCK
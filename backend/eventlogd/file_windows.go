@@ -0,0 +1,6 @@
+// +build windows
+
+package eventlogd
+
+// openFlags are added to the flags used to open the event log.
+const openFlags = 0
@@ -0,0 +1,83 @@
+package eventlogd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+const (
+	// kafkaContentType is the content type of the JSON records produced
+	// through the v2 API of the Kafka REST Proxy.
+	kafkaContentType = "application/vnd.kafka.json.v2+json"
+
+	// kafkaTimeout is the timeout of the requests to the Kafka REST Proxy.
+	kafkaTimeout = 10 * time.Second
+)
+
+// kafkaRecord is a record produced to a Kafka topic. Its key, the namespace
+// and name of the entity, keeps the events of an entity in the same partition.
+type kafkaRecord struct {
+	Key   string        `json:"key"`
+	Value *corev2.Event `json:"value"`
+}
+
+// kafkaSink publishes the events to a Kafka topic through a Kafka REST Proxy.
+type kafkaSink struct {
+	url    string
+	client *http.Client
+}
+
+func newKafkaSink(proxyURL, topic string) *kafkaSink {
+	return &kafkaSink{
+		url:    strings.TrimRight(proxyURL, "/") + path.Join("/topics", url.PathEscape(topic)),
+		client: &http.Client{Timeout: kafkaTimeout},
+	}
+}
+
+// Write produces a record for each event with a single request.
+func (s *kafkaSink) Write(events []*corev2.Event) error {
+	records := make([]kafkaRecord, 0, len(events))
+	for _, event := range events {
+		var key string
+		if event.Entity != nil {
+			key = path.Join(event.Entity.Namespace, event.Entity.Name)
+		}
+		records = append(records, kafkaRecord{Key: key, Value: event})
+	}
+	body, err := json.Marshal(map[string]interface{}{"records": records})
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.client.Post(s.url, kafkaContentType, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		msg, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("kafka rest proxy returned %s: %s", resp.Status, bytes.TrimSpace(msg))
+	}
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+	return nil
+}
+
+// Close does nothing, since every batch is a separate request.
+func (s *kafkaSink) Close() error {
+	return nil
+}
+
+// Name returns the URL of the topic.
+func (s *kafkaSink) Name() string {
+	return s.url
+}
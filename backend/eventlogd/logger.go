@@ -0,0 +1,7 @@
+package eventlogd
+
+import "github.com/sirupsen/logrus"
+
+var logger = logrus.WithFields(logrus.Fields{
+	"component": "eventlogd",
+})
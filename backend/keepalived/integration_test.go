@@ -43,6 +43,7 @@ func TestKeepaliveMonitor(t *testing.T) {
 	defer subscription.Cancel()
 
 	entity := corev2.FixtureEntity("entity1")
+	entity.EntityClass = corev2.EntityAgentClass
 	ctx := store.NamespaceContext(context.Background(), entity.Namespace)
 
 	store, err := testutil.NewStoreInstance()
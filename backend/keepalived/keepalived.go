@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -11,6 +12,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/sensu/sensu-go/agent"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/clusterconfig"
 	"github.com/sensu/sensu-go/backend/liveness"
 	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/ringv2"
@@ -40,7 +42,15 @@ const (
 	RegistrationHandlerName = "registration"
 )
 
-const deletedEventSentinel = -1
+const (
+	deletedEventSentinel      = -1
+	deregisteredEventSentinel = -2
+)
+
+// DefaultHandoffGracePeriod is the default time given to the agents of a
+// stopping backend to reconnect to another backend before their keepalives
+// fail.
+const DefaultHandoffGracePeriod = 2 * time.Minute
 
 // Keepalived is responsible for monitoring keepalive events and recording
 // keepalives for entities.
@@ -53,13 +63,27 @@ type Keepalived struct {
 	mu                    *sync.Mutex
 	wg                    *sync.WaitGroup
 	keepaliveChan         chan interface{}
-	subscription          messaging.Subscription
+	subscriptions         []messaging.Subscription
 	errChan               chan error
 	livenessFactory       liveness.Factory
 	ringPool              *ringv2.Pool
 	ctx                   context.Context
 	cancel                context.CancelFunc
 	storeTimeout          time.Duration
+	registrationEvents    bool
+	registrationHandlers  []string
+	handoffGracePeriod    time.Duration
+	agentsMu              sync.Mutex
+	agents                map[string]connectedAgent
+	clusterConfig         *clusterconfig.Watcher
+	maxClockSkew          time.Duration
+}
+
+// connectedAgent is an agent sending its keepalives to this backend.
+type connectedAgent struct {
+	entity   *corev2.Entity
+	lastSeen int64
+	timeout  int64
 }
 
 // Option is a functional option.
@@ -76,6 +100,24 @@ type Config struct {
 	BufferSize            int
 	WorkerCount           int
 	StoreTimeout          time.Duration
+	// DisableRegistrationEvents stops the publication of registration events
+	// for the new entities.
+	DisableRegistrationEvents bool
+	// RegistrationHandlers are the handlers of the registration events. The
+	// registration handler is used if it's empty.
+	RegistrationHandlers []string
+	// HandoffGracePeriod is the time given to the agents connected to this
+	// backend when it stops to reconnect to another backend, which monitors
+	// their keepalives in the meantime. DefaultHandoffGracePeriod is used if
+	// it's 0.
+	HandoffGracePeriod time.Duration
+	// ClusterConfig provides the default keepalive timeout and the
+	// registration events switch of the cluster, if any.
+	ClusterConfig *clusterconfig.Watcher
+	// MaxClockSkew is the allowed difference between the timestamps of the
+	// keepalives and the clock of the backend, beyond which the clock offset
+	// of the agent is recorded on its entity. 0 disables the check.
+	MaxClockSkew time.Duration
 }
 
 // New creates a new Keepalived.
@@ -92,6 +134,12 @@ func New(c Config, opts ...Option) (*Keepalived, error) {
 		logger.Warn("StoreTimeout not set")
 		c.StoreTimeout = time.Minute
 	}
+	if len(c.RegistrationHandlers) == 0 {
+		c.RegistrationHandlers = []string{corev2.RegistrationHandlerName}
+	}
+	if c.HandoffGracePeriod == 0 {
+		c.HandoffGracePeriod = DefaultHandoffGracePeriod
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -109,6 +157,12 @@ func New(c Config, opts ...Option) (*Keepalived, error) {
 		ctx:                   ctx,
 		cancel:                cancel,
 		storeTimeout:          c.StoreTimeout,
+		registrationEvents:    !c.DisableRegistrationEvents,
+		registrationHandlers:  c.RegistrationHandlers,
+		handoffGracePeriod:    c.HandoffGracePeriod,
+		agents:                make(map[string]connectedAgent),
+		clusterConfig:         c.ClusterConfig,
+		maxClockSkew:          c.MaxClockSkew,
 	}
 	for _, o := range opts {
 		if err := o(k); err != nil {
@@ -126,14 +180,19 @@ func (k *Keepalived) Receiver() chan<- interface{} {
 // Start starts the daemon, returning an error if preconditions for startup
 // fail.
 func (k *Keepalived) Start() error {
-	sub, err := k.bus.Subscribe(messaging.TopicKeepalive, "keepalived", k)
-	if err != nil {
-		return err
+	// The changes made to entities through the API are received along with
+	// the keepalives, so that their monitors are updated right away
+	for _, topic := range []string{messaging.TopicKeepalive, messaging.TopicEntityConfig} {
+		sub, err := k.bus.Subscribe(topic, "keepalived", k)
+		if err != nil {
+			k.cancelSubscriptions()
+			return err
+		}
+		k.subscriptions = append(k.subscriptions, sub)
 	}
 
-	k.subscription = sub
 	if err := k.initFromStore(context.Background()); err != nil {
-		_ = sub.Cancel()
+		k.cancelSubscriptions()
 		return err
 	}
 
@@ -143,16 +202,107 @@ func (k *Keepalived) Start() error {
 }
 
 // Stop stops the daemon, returning an error if one was encountered during
-// shutdown.
+// shutdown. The keepalive monitors of the agents connected to the backend are
+// handed off to the other backends.
 func (k *Keepalived) Stop() error {
 	k.cancel()
-	err := k.subscription.Cancel()
+	err := k.cancelSubscriptions()
 	close(k.keepaliveChan)
 	k.wg.Wait()
+	k.handoff()
 	close(k.errChan)
 	return err
 }
 
+// handoff hands the keepalive monitors of the agents connected to this
+// backend off to the other backends. The liveness switches of the agents are
+// watched by every backend, so they are rearmed for at least the grace period:
+// the backend an agent reconnects to takes its monitor over with its next
+// keepalive, and the other backends alert if it doesn't reconnect in time.
+// Without it, the keepalives of the agents reconnecting after their keepalive
+// timeout would fail.
+func (k *Keepalived) handoff() {
+	now := time.Now().Unix()
+	grace := int64(k.handoffGracePeriod / time.Second)
+	if grace < liveness.FallbackTTL {
+		grace = liveness.FallbackTTL
+	}
+	k.agentsMu.Lock()
+	ttls := make(map[string]int64, len(k.agents))
+	for id, agent := range k.agents {
+		// Skip the agents that stopped sending keepalives to this backend,
+		// whose monitors are left as they are
+		if now-agent.lastSeen >= agent.timeout {
+			continue
+		}
+		ttl := agent.timeout
+		if ttl < grace {
+			ttl = grace
+		}
+		ttls[id] = ttl
+	}
+	k.agentsMu.Unlock()
+	if len(ttls) == 0 {
+		return
+	}
+
+	switches := k.livenessFactory(k.Name(), k.dead, k.alive, logger)
+	ctx, cancel := context.WithTimeout(context.Background(), k.storeTimeout)
+	defer cancel()
+	var handedOff int
+	for id, ttl := range ttls {
+		if err := switches.Alive(ctx, id, ttl); err != nil {
+			logger.WithError(err).WithField("entity", id).Error("error handing off keepalive monitor")
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		handedOff++
+	}
+	logger.WithFields(logrus.Fields{
+		"agents":     len(ttls),
+		"handed_off": handedOff,
+	}).Info("handed off keepalive monitors to the other backends")
+}
+
+// trackAgent records the keepalive of an agent connected to this backend.
+func (k *Keepalived) trackAgent(entity *corev2.Entity, timeout int64) {
+	if !k.expectsKeepalives(entity) {
+		return
+	}
+	k.agentsMu.Lock()
+	defer k.agentsMu.Unlock()
+	k.agents[path.Join(entity.Namespace, entity.Name)] = connectedAgent{
+		entity: &corev2.Entity{
+			ObjectMeta: corev2.NewObjectMeta(entity.Name, entity.Namespace),
+		},
+		lastSeen: time.Now().Unix(),
+		timeout:  timeout,
+	}
+}
+
+// untrackAgent forgets an agent that disconnected from this backend, shut down
+// or whose entity was deleted.
+func (k *Keepalived) untrackAgent(namespace, name string) {
+	k.agentsMu.Lock()
+	defer k.agentsMu.Unlock()
+	delete(k.agents, path.Join(namespace, name))
+}
+
+// cancelSubscriptions cancels the subscriptions to the bus, returning the
+// first error encountered.
+func (k *Keepalived) cancelSubscriptions() error {
+	var err error
+	for _, sub := range k.subscriptions {
+		if cerr := sub.Cancel(); cerr != nil && err == nil {
+			err = cerr
+		}
+	}
+	k.subscriptions = nil
+	return err
+}
+
 // Err returns a channel that the caller can use to listen for terminal errors
 // indicating a premature shutdown of the Daemon.
 func (k *Keepalived) Err() <-chan error {
@@ -217,6 +367,17 @@ func (k *Keepalived) initFromStore(ctx context.Context) error {
 	return nil
 }
 
+// validateKeepalive validates the keepalive event, whose timestamp may be one
+// of the sentinels used to signal deleted and deregistered entities.
+func validateKeepalive(event *corev2.Event) error {
+	if event.Timestamp == deletedEventSentinel || event.Timestamp == deregisteredEventSentinel {
+		validated := *event
+		validated.Timestamp = 0
+		return validated.Validate()
+	}
+	return event.Validate()
+}
+
 func (k *Keepalived) startWorkers() {
 	k.wg = &sync.WaitGroup{}
 	k.wg.Add(k.workerCount)
@@ -237,25 +398,40 @@ func (k *Keepalived) processKeepalives(ctx context.Context) {
 	switches := k.livenessFactory(k.Name(), k.alive, k.dead, logger)
 
 	for msg := range k.keepaliveChan {
-		event, ok = msg.(*corev2.Event)
-		if !ok {
-			logger.Error("keepalived received non-Event on keepalive channel")
+		if disconnect, ok := msg.(*messaging.AgentDisconnect); ok {
+			k.untrackAgent(disconnect.Namespace, disconnect.Name)
+			continue
+		}
+		if change, ok := msg.(*messaging.EntityConfigChange); ok {
+			if err := k.handleEntityConfigChange(ctx, switches, change); err != nil {
+				if _, ok := err.(*store.ErrInternal); ok {
+					// Fatal error
+					select {
+					case k.errChan <- err:
+					case <-k.ctx.Done():
+					}
+					return
+				}
+				logger.WithError(err).Error("error handling entity change")
+			}
 			continue
 		}
 
-		entity := event.Entity
-		if entity == nil {
-			logger.Error("keepalive channel received keepalive with nil event")
+		event, ok = msg.(*corev2.Event)
+		if !ok {
+			logger.Error("keepalived received non-Event on keepalive channel")
 			continue
 		}
 
-		if err := entity.Validate(); err != nil {
+		if err := validateKeepalive(event); err != nil {
 			logger.WithError(err).Error("invalid keepalive event")
 			continue
 		}
+		entity := event.Entity
 
 		if event.Timestamp == deletedEventSentinel {
 			// The keepalive event was deleted, so we should bury its associated switch
+			k.untrackAgent(entity.Namespace, entity.Name)
 			id := path.Join(entity.Namespace, entity.Name)
 			tctx, cancel := context.WithTimeout(ctx, k.storeTimeout)
 			err := switches.Bury(tctx, id)
@@ -274,7 +450,41 @@ func (k *Keepalived) processKeepalives(ctx context.Context) {
 			continue
 		}
 
-		if err := k.handleEntityRegistration(entity); err != nil {
+		if event.Timestamp == deregisteredEventSentinel {
+			// The agent of the entity shut down, deregister the entity right away if
+			// it's ephemeral rather than waiting for its keepalive to time out
+			k.untrackAgent(entity.Namespace, entity.Name)
+			if err := k.handleEntityDeregistration(ctx, switches, entity); err != nil {
+				if _, ok := err.(*store.ErrInternal); ok {
+					// Fatal error
+					select {
+					case k.errChan <- err:
+					case <-k.ctx.Done():
+					}
+					return
+				}
+				logger.WithError(err).Error("error deregistering entity")
+			}
+			continue
+		}
+
+		if !k.expectsKeepalives(entity) {
+			logger.WithFields(logrus.Fields{
+				"entity":       entity.Name,
+				"namespace":    entity.Namespace,
+				"entity_class": entity.EntityClass,
+			}).Warn("ignoring keepalive of an entity whose class doesn't expect keepalives")
+			continue
+		}
+
+		ttl := k.keepaliveTimeout(event)
+		if event.Check != nil {
+			// The keepalive event and the rings of the entity use the
+			// effective timeout
+			event.Check.Timeout = uint32(ttl)
+		}
+
+		if err := k.handleEntityRegistration(entity, ttl); err != nil {
 			logger.WithError(err).Error("error handling entity registration")
 			if _, ok := err.(*store.ErrInternal); ok {
 				// Fatal error
@@ -286,13 +496,6 @@ func (k *Keepalived) processKeepalives(ctx context.Context) {
 			}
 		}
 
-		// Retrieve the keepalive timeout or use a default value in case an older
-		// agent version was used, since entity.KeepaliveTimeout no longer exist
-		ttl := int64(corev2.DefaultKeepaliveTimeout)
-		if event.Check != nil {
-			ttl = int64(event.Check.Timeout)
-		}
-
 		key := path.Join(entity.Namespace, entity.Name)
 
 		tctx, cancel := context.WithTimeout(ctx, k.storeTimeout)
@@ -310,6 +513,7 @@ func (k *Keepalived) processKeepalives(ctx context.Context) {
 			}
 			continue
 		}
+		k.trackAgent(entity, ttl)
 
 		if err := k.handleUpdate(event); err != nil {
 			logger.WithError(err).Error("error updating event")
@@ -330,8 +534,40 @@ func (k *Keepalived) HandleError(err error) {
 	logger.WithError(err).Error(err)
 }
 
-func (k *Keepalived) handleEntityRegistration(entity *corev2.Entity) error {
-	if entity.EntityClass != corev2.EntityAgentClass {
+// keepaliveTimeout returns the keepalive timeout of the agent of the event.
+// The agents flag whether their timeout was configured, and the default
+// timeout of the namespace of the entity, or of the cluster, is used if it
+// wasn't. Older agents don't flag it, so their timeout is used unless they
+// don't specify one either, since entity.KeepaliveTimeout no longer exists.
+func (k *Keepalived) keepaliveTimeout(event *corev2.Event) int64 {
+	check := event.Check
+	if check != nil && check.Timeout > 0 && check.Annotations[corev2.KeepaliveTimeoutSetAnnotation] != "false" {
+		return int64(check.Timeout)
+	}
+	return int64(k.clusterConfig.Config().KeepaliveTimeoutFor(event.Entity.Namespace))
+}
+
+// expectsKeepalives returns whether the entity is expected to send keepalives,
+// according to its class.
+func (k *Keepalived) expectsKeepalives(entity *corev2.Entity) bool {
+	return k.clusterConfig.Config().ExpectsKeepalives(entity.EntityClass)
+}
+
+// registrationEventsEnabled returns whether registration events are published
+// for the new entities. The cluster configuration takes precedence over the
+// configuration of the backend.
+func (k *Keepalived) registrationEventsEnabled() bool {
+	if enabled := k.clusterConfig.Config().RegistrationEvents; enabled != nil {
+		return *enabled
+	}
+	return k.registrationEvents
+}
+
+// handleEntityRegistration publishes a registration event if the entity of the
+// agent does not exist yet, or checks that the entity is not claimed by
+// another agent otherwise. ttl is the keepalive timeout of the agent.
+func (k *Keepalived) handleEntityRegistration(entity *corev2.Entity, ttl int64) error {
+	if !k.expectsKeepalives(entity) {
 		return nil
 	}
 
@@ -345,26 +581,267 @@ func (k *Keepalived) handleEntityRegistration(entity *corev2.Entity) error {
 		return err
 	}
 
-	if fetchedEntity == nil {
-		event := createRegistrationEvent(entity)
+	// Ephemeral entities are registered every time their agent restarts, don't
+	// notify about them
+	if fetchedEntity == nil && !isEphemeral(entity) && k.registrationEventsEnabled() {
+		event := createRegistrationEvent(entity, k.registrationHandlers)
 		err = k.bus.Publish(messaging.TopicEvent, event)
 	}
+	if fetchedEntity != nil {
+		err = k.handleEntityConflict(entity, fetchedEntity, ttl)
+	}
 
 	return err
 }
 
-func createKeepaliveEvent(rawEvent *corev2.Event) *corev2.Event {
+// handleEntityConflict publishes a warning event if the keepalive of the
+// entity comes from another host than the last one received, while that one
+// is still alive. This happens when several agents use the same entity name,
+// such as the agents of VMs cloned from the same image. An OK event is
+// published once no conflicting keepalive was received for the keepalive
+// timeout. The conflicts are recorded in the store, under a lease, so that
+// they are resolved whichever backend the agents send their keepalives to,
+// and forgotten once the entity stops sending keepalives.
+func (k *Keepalived) handleEntityConflict(entity, fetchedEntity *corev2.Entity, ttl int64) error {
+	now := time.Now().Unix()
+
+	current, previous := entityFingerprint(entity), entityFingerprint(fetchedEntity)
+	conflict := current != "" && previous != "" && current != previous && now-fetchedEntity.LastSeen < ttl
+
+	ctx := corev2.SetContextFromResource(k.ctx, entity)
+	tctx, cancel := context.WithTimeout(ctx, k.storeTimeout)
+	defer cancel()
+
+	if conflict {
+		// The record outlives the keepalive timeout, so that the keepalive
+		// following it resolves the conflict
+		if err := k.store.RecordKeepaliveConflict(tctx, entity, 2*ttl); err != nil {
+			return err
+		}
+		logger.WithFields(logrus.Fields{
+			"entity":    entity.Name,
+			"namespace": entity.Namespace,
+		}).Warn("keepalives received from several agents for the same entity")
+		event := createConflictEvent(entity, ttl, k.clusterConfig.Config())
+		event.Check.Status = 1
+		event.Check.Output = fmt.Sprintf(
+			"Keepalives for entity %s were received from several agents, on hosts %q and %q; each agent needs a unique entity name",
+			entity.Name, fetchedEntity.System.Hostname, entity.System.Hostname)
+		return k.bus.Publish(messaging.TopicEventRaw, event)
+	}
+
+	lastConflict, err := k.store.GetKeepaliveConflict(tctx, entity)
+	if err != nil {
+		return err
+	}
+	if lastConflict == 0 || now-lastConflict < ttl {
+		return nil
+	}
+	// Only the backend deleting the record resolves the conflict
+	if deleted, err := k.store.DeleteKeepaliveConflict(tctx, entity); err != nil || !deleted {
+		return err
+	}
+	event := createConflictEvent(entity, ttl, k.clusterConfig.Config())
+	event.Check.Output = fmt.Sprintf("Keepalives for entity %s are received from a single agent", entity.Name)
+	return k.bus.Publish(messaging.TopicEventRaw, event)
+}
+
+// virtualInterfacePrefixes are the prefixes of the names of the network
+// interfaces that are not tied to the hardware of a host, such as the
+// loopback, bridges, tunnels and container interfaces. Their MAC addresses are
+// often identical across hosts, or change when the host restarts.
+var virtualInterfacePrefixes = []string{
+	"lo", "docker", "veth", "br-", "virbr", "vnet", "vmnet", "tun", "tap",
+	"cni", "flannel", "cali", "weave", "vxlan", "kube-", "cilium", "podman",
+	"lxc", "lxd", "zt", "wg", "utun", "awdl", "llw", "vEthernet",
+}
+
+// isVirtualInterface returns true if the network interface is not tied to the
+// hardware of the host.
+func isVirtualInterface(iface corev2.NetworkInterface) bool {
+	if iface.MAC == "" || strings.Trim(iface.MAC, "0:-") == "" {
+		return true
+	}
+	for _, prefix := range virtualInterfacePrefixes {
+		if strings.HasPrefix(iface.Name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// entityFingerprint identifies the host of an agent entity by the MAC
+// addresses of its physical network interfaces, which are unique to each host,
+// unlike its hostname or the addresses of its virtual interfaces. It is empty
+// if the entity has no physical network interface.
+func entityFingerprint(entity *corev2.Entity) string {
+	macs := []string{}
+	for _, iface := range entity.System.Network.Interfaces {
+		if !isVirtualInterface(iface) {
+			macs = append(macs, strings.ToLower(iface.MAC))
+		}
+	}
+	sort.Strings(macs)
+	return strings.Join(macs, ",")
+}
+
+// handleEntityDeregistration deregisters the entity of an agent that shut down,
+// if the entity is ephemeral, and buries its keepalive switch.
+func (k *Keepalived) handleEntityDeregistration(ctx context.Context, switches liveness.Interface, entity *corev2.Entity) error {
+	ctx = corev2.SetContextFromResource(ctx, entity)
+	tctx, cancel := context.WithTimeout(ctx, k.storeTimeout)
+	defer cancel()
+	fetchedEntity, err := k.store.GetEntityByName(tctx, entity.Name)
+	if err != nil {
+		// Warning: do not wrap this error
+		return err
+	}
+
+	if fetchedEntity == nil || !fetchedEntity.Deregister {
+		// Entities that are not ephemeral are kept until their keepalive times out
+		return nil
+	}
+
+	deregisterer := &Deregistration{
+		EntityStore:  k.store,
+		EventStore:   k.eventStore,
+		MessageBus:   k.bus,
+		StoreTimeout: k.storeTimeout,
+	}
+	if err := deregisterer.Deregister(fetchedEntity); err != nil {
+		return err
+	}
+	logger.WithFields(logrus.Fields{
+		"entity":    entity.Name,
+		"namespace": entity.Namespace,
+	}).Info("deregistered entity of agent that shut down")
+
+	tctx, cancel = context.WithTimeout(ctx, k.storeTimeout)
+	defer cancel()
+	return switches.Bury(tctx, path.Join(entity.Namespace, entity.Name))
+}
+
+// handleEntityConfigChange updates the keepalive monitor of an entity changed
+// through the API, instead of waiting for its next keepalive or its timeout.
+// The switch of a deleted entity is buried, and an entity whose keepalive is
+// failing is deregistered right away once it's configured to be.
+func (k *Keepalived) handleEntityConfigChange(ctx context.Context, switches liveness.Interface, change *messaging.EntityConfigChange) error {
+	id := path.Join(change.Namespace, change.Name)
+	ctx = store.NamespaceContext(ctx, change.Namespace)
+
+	if change.Deleted {
+		k.untrackAgent(change.Namespace, change.Name)
+		tctx, cancel := context.WithTimeout(ctx, k.storeTimeout)
+		defer cancel()
+		return switches.Bury(tctx, id)
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, k.storeTimeout)
+	defer cancel()
+	entity, err := k.store.GetEntityByName(tctx, change.Name)
+	if err != nil {
+		// Warning: do not wrap this error
+		return err
+	}
+	if entity == nil {
+		tctx, cancel := context.WithTimeout(ctx, k.storeTimeout)
+		defer cancel()
+		return switches.Bury(tctx, id)
+	}
+
+	tctx, cancel = context.WithTimeout(ctx, k.storeTimeout)
+	defer cancel()
+	event, err := k.store.GetEventByEntityCheck(tctx, change.Name, corev2.KeepaliveCheckName)
+	if err != nil {
+		// Warning: do not wrap this error
+		return err
+	}
+	if event == nil || !event.HasCheck() {
+		// The switch of the entity is armed by its first keepalive
+		return nil
+	}
+	if !entity.Deregister || event.Check.Status == 0 {
+		// The entity is deregistered if its keepalive times out
+		return k.rearm(ctx, switches, id, event)
+	}
+
+	deregisterer := &Deregistration{
+		EntityStore:  k.store,
+		EventStore:   k.eventStore,
+		MessageBus:   k.bus,
+		StoreTimeout: k.storeTimeout,
+	}
+	if err := deregisterer.Deregister(entity); err != nil {
+		return err
+	}
+	logger.WithFields(logrus.Fields{
+		"entity":    entity.Name,
+		"namespace": entity.Namespace,
+	}).Info("deregistered entity with a failing keepalive")
+
+	tctx, cancel = context.WithTimeout(ctx, k.storeTimeout)
+	defer cancel()
+	return switches.Bury(tctx, id)
+}
+
+// rearm re-arms the keepalive switch of the entity of the keepalive event if
+// its keepalive timeout changed since the switch was armed, e.g. because the
+// default timeout of its namespace changed, so that the new timeout applies
+// without waiting for the next keepalive of the entity.
+func (k *Keepalived) rearm(ctx context.Context, switches liveness.Interface, id string, event *corev2.Event) error {
+	ttl := k.keepaliveTimeout(event)
+	if ttl == int64(event.Check.Timeout) {
+		return nil
+	}
+	event.Check.Timeout = uint32(ttl)
+
+	tctx, cancel := context.WithTimeout(ctx, k.storeTimeout)
+	defer cancel()
+	if event.Check.Status == 0 {
+		err := switches.Alive(tctx, id, ttl)
+		if err != nil {
+			return err
+		}
+	} else if err := switches.Dead(tctx, id, ttl); err != nil {
+		return err
+	}
+
+	// The keepalive event records the timeout of the switch
+	tctx, cancel = context.WithTimeout(ctx, k.storeTimeout)
+	defer cancel()
+	if _, _, err := k.eventStore.UpdateEvent(tctx, event); err != nil {
+		return err
+	}
+	logger.WithFields(logrus.Fields{
+		"entity":    event.Entity.Name,
+		"namespace": event.Entity.Namespace,
+		"timeout":   ttl,
+	}).Info("re-armed keepalive with the new timeout")
+	return nil
+}
+
+// isEphemeral returns true if the identity of the entity does not outlive its
+// agent.
+func isEphemeral(entity *corev2.Entity) bool {
+	return entity.Annotations[corev2.EphemeralAnnotation] == "true"
+}
+
+// createKeepaliveEvent creates the keepalive event of the entity of rawEvent.
+// The keepalive defaults of its namespace in the cluster configuration are used
+// if the entity doesn't set its own.
+func createKeepaliveEvent(rawEvent *corev2.Event, config *corev2.ClusterConfig) *corev2.Event {
+	namespace := rawEvent.Entity.Namespace
 	check := rawEvent.Check
 	if check == nil {
 		check = &corev2.Check{
 			Interval: agent.DefaultKeepaliveInterval,
-			Timeout:  corev2.DefaultKeepaliveTimeout,
+			Timeout:  config.KeepaliveTimeoutFor(namespace),
 		}
 	}
 
 	// Use the entity keepalive handlers if defined, otherwise fallback to the
-	// default keepalive handler
-	handlers := []string{corev2.KeepaliveHandlerName}
+	// default keepalive handlers
+	handlers := config.KeepaliveHandlersFor(namespace)
 	if len(rawEvent.Entity.KeepaliveHandlers) > 0 {
 		handlers = rawEvent.Entity.KeepaliveHandlers
 	}
@@ -397,14 +874,14 @@ func createKeepaliveEvent(rawEvent *corev2.Event) *corev2.Event {
 	return keepaliveEvent
 }
 
-func createRegistrationEvent(entity *corev2.Entity) *corev2.Event {
+func createRegistrationEvent(entity *corev2.Entity, handlers []string) *corev2.Event {
 	registrationCheck := &corev2.Check{
 		ObjectMeta: corev2.ObjectMeta{
 			Name:      corev2.RegistrationCheckName,
 			Namespace: entity.Namespace,
 		},
 		Interval: 1,
-		Handlers: []string{corev2.RegistrationHandlerName},
+		Handlers: handlers,
 		Status:   1,
 	}
 	registrationEvent := &corev2.Event{
@@ -419,6 +896,39 @@ func createRegistrationEvent(entity *corev2.Entity) *corev2.Event {
 	return registrationEvent
 }
 
+func createConflictEvent(entity *corev2.Entity, ttl int64, config *corev2.ClusterConfig) *corev2.Event {
+	// Use the entity keepalive handlers if defined, otherwise fallback to the
+	// default keepalive handlers
+	handlers := config.KeepaliveHandlersFor(entity.Namespace)
+	if len(entity.KeepaliveHandlers) > 0 {
+		handlers = entity.KeepaliveHandlers
+	}
+
+	conflictCheck := &corev2.Check{
+		ObjectMeta: corev2.ObjectMeta{
+			Name:      corev2.EntityConflictCheckName,
+			Namespace: entity.Namespace,
+		},
+		Interval: uint32(ttl),
+		Handlers: handlers,
+		Executed: time.Now().Unix(),
+		Issued:   time.Now().Unix(),
+	}
+	conflictEvent := &corev2.Event{
+		ObjectMeta: corev2.ObjectMeta{
+			Namespace: entity.Namespace,
+		},
+		Timestamp: time.Now().Unix(),
+		Entity:    entity,
+		Check:     conflictCheck,
+	}
+
+	uid, _ := uuid.NewRandom()
+	conflictEvent.ID = uid[:]
+
+	return conflictEvent
+}
+
 func (k *Keepalived) alive(key string, prev liveness.State, leader bool) bool {
 	lager := logger.WithFields(logrus.Fields{
 		"status":          liveness.Alive.String(),
@@ -483,6 +993,13 @@ func (k *Keepalived) dead(key string, prev liveness.State, leader bool) bool {
 		return true
 	}
 
+	if !k.expectsKeepalives(entity) {
+		// The class of the entity was changed, or no longer expects
+		// keepalives
+		lager.Info("entity class doesn't expect keepalives, no longer tracking them")
+		return true
+	}
+
 	if entity.Deregister {
 		deregisterer := &Deregistration{
 			EntityStore:  k.store,
@@ -507,7 +1024,7 @@ func (k *Keepalived) dead(key string, prev liveness.State, leader bool) bool {
 	}
 
 	// this is a real keepalive event, emit it.
-	event := createKeepaliveEvent(currentEvent)
+	event := createKeepaliveEvent(currentEvent, k.clusterConfig.Config())
 	timeSinceLastSeen := time.Now().Unix() - entity.LastSeen
 	warningTimeout := int64(event.Check.Timeout)
 	criticalTimeout := event.Check.Ttl
@@ -536,10 +1053,6 @@ func (k *Keepalived) dead(key string, prev liveness.State, leader bool) bool {
 		return false
 	}
 
-	if entity.EntityClass != corev2.EntityAgentClass {
-		return false
-	}
-
 	for _, sub := range entity.Subscriptions {
 		ring := k.ringPool.Get(ringv2.Path(namespace, sub))
 		if err := ring.Remove(ctx, name); err != nil {
@@ -559,6 +1072,25 @@ func parseKey(key string) (namespace, name string, err error) {
 	return parts[0], parts[1], nil
 }
 
+// recordClockOffset sets the clock offset annotation of the entity to the
+// offset of the clock of its agent if the timestamp of its keepalive is skewed,
+// and removes it once the skew is within tolerance again. The annotation is
+// stored with the entity on every keepalive.
+func (k *Keepalived) recordClockOffset(entity *corev2.Entity, timestamp int64, now time.Time) {
+	if k.maxClockSkew <= 0 || timestamp <= 0 {
+		return
+	}
+	offset := time.Duration(timestamp-now.Unix()) * time.Second
+	if offset <= k.maxClockSkew && offset >= -k.maxClockSkew {
+		delete(entity.Annotations, corev2.ClockOffsetAnnotation)
+		return
+	}
+	if entity.Annotations == nil {
+		entity.Annotations = make(map[string]string)
+	}
+	entity.Annotations[corev2.ClockOffsetAnnotation] = offset.String()
+}
+
 // handleUpdate sets the entity's last seen time and publishes an OK check event
 // to the message bus.
 func (k *Keepalived) handleUpdate(e *corev2.Event) error {
@@ -571,17 +1103,18 @@ func (k *Keepalived) handleUpdate(e *corev2.Event) error {
 	}
 
 	entity.LastSeen = e.Timestamp
+	k.recordClockOffset(entity, e.Timestamp, time.Now())
 
 	if err := k.store.UpdateEntity(ctx, entity); err != nil {
 		logger.WithError(err).Error("error updating entity in store")
 		// Warning: do not wrap this error
 		return err
 	}
-	event := createKeepaliveEvent(e)
+	event := createKeepaliveEvent(e, k.clusterConfig.Config())
 	event.Check.Status = 0
 	event.Check.Output = fmt.Sprintf("Keepalive last sent from %s at %s", entity.Name, time.Unix(entity.LastSeen, 0).String())
 
-	if entity.EntityClass == corev2.EntityAgentClass {
+	if k.expectsKeepalives(entity) {
 		// Refresh the rings that the entity is involved in
 		for _, sub := range entity.Subscriptions {
 			if strings.HasPrefix(sub, "entity:") {
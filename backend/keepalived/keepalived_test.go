@@ -2,11 +2,15 @@ package keepalived
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"github.com/sensu/sensu-go/backend/clusterconfig"
 	"github.com/sensu/sensu-go/backend/liveness"
 	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockbus"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
@@ -184,6 +188,7 @@ func TestEventProcessing(t *testing.T) {
 	test.Store.On("GetFailingKeepalives", mock.Anything).Return([]*corev2.KeepaliveRecord{}, nil)
 	require.NoError(t, test.Keepalived.Start())
 	event := corev2.FixtureEvent("entity", "keepalive")
+	event.Entity.EntityClass = corev2.EntityAgentClass
 	event.Check.Status = 1
 
 	test.Store.On("UpdateEntity", mock.Anything, event.Entity).Return(nil)
@@ -207,12 +212,20 @@ func TestProcessRegistration(t *testing.T) {
 		entity.EntityClass = class
 		return entity
 	}
+	newEphemeralEntity := func() *corev2.Entity {
+		entity := newEntityWithClass("agent")
+		entity.Annotations = map[string]string{corev2.EphemeralAnnotation: "true"}
+		return entity
+	}
 
 	tt := []struct {
-		name        string
-		entity      *corev2.Entity
-		storeEntity *corev2.Entity
-		expectedLen int
+		name             string
+		entity           *corev2.Entity
+		storeEntity      *corev2.Entity
+		disabled         bool
+		handlers         []string
+		expectedLen      int
+		expectedHandlers []string
 	}{
 		{
 			name:        "Registered Entity Without Agent Class",
@@ -227,10 +240,32 @@ func TestProcessRegistration(t *testing.T) {
 			expectedLen: 0,
 		},
 		{
-			name:        "Non-Registered Entity With Agent Class",
+			name:             "Non-Registered Entity With Agent Class",
+			entity:           newEntityWithClass("agent"),
+			storeEntity:      nil,
+			expectedLen:      1,
+			expectedHandlers: []string{corev2.RegistrationHandlerName},
+		},
+		{
+			name:        "Non-Registered Entity With Registration Events Disabled",
 			entity:      newEntityWithClass("agent"),
 			storeEntity: nil,
-			expectedLen: 1,
+			disabled:    true,
+			expectedLen: 0,
+		},
+		{
+			name:             "Non-Registered Entity With Registration Handlers",
+			entity:           newEntityWithClass("agent"),
+			storeEntity:      nil,
+			handlers:         []string{"cmdb", "slack"},
+			expectedLen:      1,
+			expectedHandlers: []string{"cmdb", "slack"},
+		},
+		{
+			name:        "Non-Registered Ephemeral Entity With Agent Class",
+			entity:      newEphemeralEntity(),
+			storeEntity: nil,
+			expectedLen: 0,
 		},
 	}
 
@@ -255,22 +290,197 @@ func TestProcessRegistration(t *testing.T) {
 				WorkerCount:     1,
 				BufferSize:      1,
 				StoreTimeout:    time.Minute,
+
+				DisableRegistrationEvents: tc.disabled,
+				RegistrationHandlers:      tc.handlers,
 			})
 			require.NoError(t, err)
 
 			store.On("GetEntityByName", mock.Anything, "agent1").Return(tc.storeEntity, nil)
-			err = keepalived.handleEntityRegistration(tc.entity)
+			store.On("GetKeepaliveConflict", mock.Anything, mock.Anything).Return(int64(0), nil)
+			err = keepalived.handleEntityRegistration(tc.entity, corev2.DefaultKeepaliveTimeout)
 			require.NoError(t, err)
 
-			assert.Equal(t, tc.expectedLen, len(tsub.ch))
+			require.Equal(t, tc.expectedLen, len(tsub.ch))
+			if tc.expectedLen > 0 {
+				event := (<-tsub.ch).(*corev2.Event)
+				assert.Equal(t, tc.expectedHandlers, event.Check.Handlers)
+			}
 			assert.NoError(t, subscription.Cancel())
 		})
 	}
 }
 
+func TestClusterConfig(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	enabled := true
+	st := &mockstore.MockStore{}
+	st.On("GetClusterConfigWatcher", mock.Anything).Return((<-chan store.WatchEventClusterConfig)(make(chan store.WatchEventClusterConfig)))
+	st.On("GetClusterConfig", mock.Anything).Return(&corev2.ClusterConfig{
+		KeepaliveTimeout:   60,
+		RegistrationEvents: &enabled,
+		NamespaceKeepalives: map[string]*corev2.KeepaliveDefaults{
+			"dev": {Timeout: 300},
+		},
+		EntityClasses: map[string]*corev2.EntityClass{
+			"service": {ExpectKeepalives: true},
+		},
+	}, nil)
+	clusterConfig := clusterconfig.NewWatcher(st)
+	clusterConfig.Start(ctx)
+
+	keepalived, err := New(Config{
+		Store:                     st,
+		DisableRegistrationEvents: true,
+	})
+	require.NoError(t, err)
+	event := corev2.FixtureEvent("entity1", "keepalive")
+	event.Check.Timeout = corev2.DefaultKeepaliveTimeout
+	event.Check.Annotations[corev2.KeepaliveTimeoutSetAnnotation] = "false"
+	assert.Equal(t, int64(corev2.DefaultKeepaliveTimeout), keepalived.keepaliveTimeout(event))
+	assert.False(t, keepalived.registrationEventsEnabled())
+	service := corev2.FixtureEntity("service1")
+	service.EntityClass = "service"
+	assert.False(t, keepalived.expectsKeepalives(service))
+
+	// The cluster configuration takes precedence
+	keepalived, err = New(Config{
+		Store:                     st,
+		DisableRegistrationEvents: true,
+		ClusterConfig:             clusterConfig,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), keepalived.keepaliveTimeout(event))
+	assert.True(t, keepalived.registrationEventsEnabled())
+	assert.True(t, keepalived.expectsKeepalives(service))
+
+	// The defaults of the namespace take precedence over the ones of the
+	// cluster
+	event.Entity.Namespace = "dev"
+	assert.Equal(t, int64(300), keepalived.keepaliveTimeout(event))
+
+	// The timeout of the agents is used if it was configured, or if older
+	// agents don't tell
+	event.Check.Annotations[corev2.KeepaliveTimeoutSetAnnotation] = "true"
+	assert.Equal(t, int64(corev2.DefaultKeepaliveTimeout), keepalived.keepaliveTimeout(event))
+	delete(event.Check.Annotations, corev2.KeepaliveTimeoutSetAnnotation)
+	event.Check.Timeout = 30
+	assert.Equal(t, int64(30), keepalived.keepaliveTimeout(event))
+	event.Check.Timeout = 0
+	assert.Equal(t, int64(300), keepalived.keepaliveTimeout(event))
+}
+
+func TestHandleEntityConflict(t *testing.T) {
+	newAgentEntity := func(hostname, mac string) *corev2.Entity {
+		entity := corev2.FixtureEntity("agent1")
+		entity.EntityClass = corev2.EntityAgentClass
+		entity.System.Hostname = hostname
+		entity.System.Network.Interfaces = []corev2.NetworkInterface{{Name: "eth0", MAC: mac}}
+		entity.LastSeen = time.Now().Unix()
+		return entity
+	}
+
+	messageBus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	require.NoError(t, err)
+	require.NoError(t, messageBus.Start())
+	defer func() { assert.NoError(t, messageBus.Stop()) }()
+
+	tsub := testSubscriber{
+		ch: make(chan interface{}, 1),
+	}
+	subscription, err := messageBus.Subscribe(messaging.TopicEventRaw, "testSubscriber", tsub)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, subscription.Cancel()) }()
+
+	st := &mockstore.MockStore{}
+	keepalived, err := New(Config{
+		Store:           st,
+		Bus:             messageBus,
+		LivenessFactory: fakeFactory,
+		WorkerCount:     1,
+		BufferSize:      1,
+		StoreTimeout:    time.Minute,
+	})
+	require.NoError(t, err)
+	st.On("GetKeepaliveConflict", mock.Anything, mock.Anything).Return(int64(0), nil).Twice()
+
+	// Keepalives from the same host don't conflict
+	stored := newAgentEntity("web01", "52:54:00:00:00:01")
+	require.NoError(t, keepalived.handleEntityConflict(newAgentEntity("web01", "52:54:00:00:00:01"), stored, 120))
+	require.Equal(t, 0, len(tsub.ch))
+
+	// The previous host is not alive anymore
+	stored.LastSeen = time.Now().Unix() - 300
+	require.NoError(t, keepalived.handleEntityConflict(newAgentEntity("web02", "52:54:00:00:00:02"), stored, 120))
+	require.Equal(t, 0, len(tsub.ch))
+
+	// Keepalives from another live host conflict, and the conflict is
+	// recorded for the other backends
+	st.On("RecordKeepaliveConflict", mock.Anything, mock.Anything, int64(240)).Return(nil)
+	stored.LastSeen = time.Now().Unix()
+	require.NoError(t, keepalived.handleEntityConflict(newAgentEntity("web02", "52:54:00:00:00:02"), stored, 120))
+	require.Equal(t, 1, len(tsub.ch))
+	event := (<-tsub.ch).(*corev2.Event)
+	assert.Equal(t, corev2.EntityConflictCheckName, event.Check.Name)
+	assert.Equal(t, uint32(1), event.Check.Status)
+	assert.Contains(t, event.Check.Output, "web02")
+
+	st.AssertCalled(t, "RecordKeepaliveConflict", mock.Anything, mock.Anything, int64(240))
+
+	// The conflict is not resolved until no conflicting keepalive was
+	// received for the keepalive timeout
+	st.On("GetKeepaliveConflict", mock.Anything, mock.Anything).Return(time.Now().Unix()-60, nil).Once()
+	stored = newAgentEntity("web01", "52:54:00:00:00:01")
+	require.NoError(t, keepalived.handleEntityConflict(newAgentEntity("web01", "52:54:00:00:00:01"), stored, 120))
+	require.Equal(t, 0, len(tsub.ch))
+
+	// The conflict is resolved by the backend deleting its record
+	st.On("GetKeepaliveConflict", mock.Anything, mock.Anything).Return(time.Now().Unix()-300, nil)
+	st.On("DeleteKeepaliveConflict", mock.Anything, mock.Anything).Return(true, nil).Once()
+	require.NoError(t, keepalived.handleEntityConflict(newAgentEntity("web01", "52:54:00:00:00:01"), stored, 120))
+	require.Equal(t, 1, len(tsub.ch))
+	event = (<-tsub.ch).(*corev2.Event)
+	assert.Equal(t, uint32(0), event.Check.Status)
+
+	// Another backend already resolved the conflict
+	st.On("DeleteKeepaliveConflict", mock.Anything, mock.Anything).Return(false, nil)
+	require.NoError(t, keepalived.handleEntityConflict(newAgentEntity("web01", "52:54:00:00:00:01"), stored, 120))
+	require.Equal(t, 0, len(tsub.ch))
+}
+
+func TestEntityFingerprint(t *testing.T) {
+	entity := corev2.FixtureEntity("agent1")
+	entity.System.Hostname = "web01"
+	entity.System.Network.Interfaces = []corev2.NetworkInterface{
+		{Name: "lo", MAC: "00:00:00:00:00:00"},
+		{Name: "eth1", MAC: "52:54:00:00:00:02"},
+		{Name: "docker0", MAC: "02:42:ac:11:00:01"},
+		{Name: "veth1234", MAC: "02:42:ac:11:00:02"},
+		{Name: "eth0", MAC: "52:54:00:00:00:01"},
+		{Name: "tun0"},
+	}
+	assert.Equal(t, "52:54:00:00:00:01,52:54:00:00:00:02", entityFingerprint(entity))
+
+	// The hostname and the virtual interfaces don't identify the host
+	clone := corev2.FixtureEntity("agent1")
+	clone.System.Hostname = "web02"
+	clone.System.Network.Interfaces = []corev2.NetworkInterface{
+		{Name: "eth0", MAC: "52:54:00:00:00:01"},
+		{Name: "docker0", MAC: "02:42:ac:11:00:03"},
+		{Name: "eth1", MAC: "52:54:00:00:00:02"},
+	}
+	assert.Equal(t, entityFingerprint(entity), entityFingerprint(clone))
+
+	// Entities without physical interfaces have no fingerprint
+	clone.System.Network.Interfaces = []corev2.NetworkInterface{{Name: "docker0", MAC: "02:42:ac:11:00:03"}}
+	assert.Empty(t, entityFingerprint(clone))
+}
+
 func TestCreateKeepaliveEvent(t *testing.T) {
 	event := corev2.FixtureEvent("entity1", "keepalive")
-	keepaliveEvent := createKeepaliveEvent(event)
+	keepaliveEvent := createKeepaliveEvent(event, &corev2.ClusterConfig{})
 	assert.Equal(t, "keepalive", keepaliveEvent.Check.Name)
 	assert.Equal(t, uint32(60), keepaliveEvent.Check.Interval)
 	assert.Equal(t, []string{"keepalive"}, keepaliveEvent.Check.Handlers)
@@ -280,15 +490,31 @@ func TestCreateKeepaliveEvent(t *testing.T) {
 	assert.NotEqual(t, int64(0), keepaliveEvent.Check.Issued)
 
 	event.Check = nil
-	keepaliveEvent = createKeepaliveEvent(event)
+	keepaliveEvent = createKeepaliveEvent(event, &corev2.ClusterConfig{})
 	assert.Equal(t, "keepalive", keepaliveEvent.Check.Name)
 	assert.Equal(t, uint32(20), keepaliveEvent.Check.Interval)
 	assert.Equal(t, uint32(120), keepaliveEvent.Check.Timeout)
+
+	// The keepalive defaults of the namespace are used
+	config := &corev2.ClusterConfig{
+		KeepaliveHandlers: []string{"slack"},
+		NamespaceKeepalives: map[string]*corev2.KeepaliveDefaults{
+			"default": {Timeout: 300},
+		},
+	}
+	keepaliveEvent = createKeepaliveEvent(event, config)
+	assert.Equal(t, uint32(300), keepaliveEvent.Check.Timeout)
+	assert.Equal(t, []string{"slack"}, keepaliveEvent.Check.Handlers)
+
+	// The keepalive handlers of the entity take precedence
+	event.Entity.KeepaliveHandlers = []string{"pagerduty"}
+	keepaliveEvent = createKeepaliveEvent(event, config)
+	assert.Equal(t, []string{"pagerduty"}, keepaliveEvent.Check.Handlers)
 }
 
 func TestCreateRegistrationEvent(t *testing.T) {
 	event := corev2.FixtureEntity("entity1")
-	keepaliveEvent := createRegistrationEvent(event)
+	keepaliveEvent := createRegistrationEvent(event, []string{RegistrationHandlerName})
 	assert.Equal(t, RegistrationCheckName, keepaliveEvent.Check.Name)
 	assert.Equal(t, uint32(1), keepaliveEvent.Check.Interval)
 	assert.Equal(t, []string{RegistrationHandlerName}, keepaliveEvent.Check.Handlers)
@@ -356,7 +582,9 @@ func TestDeadCallbackNoEvent(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	store.On("GetEntityByName", mock.Anything, mock.Anything).Return(corev2.FixtureEntity("foo"), nil)
+	entity := corev2.FixtureEntity("foo")
+	entity.EntityClass = corev2.EntityAgentClass
+	store.On("GetEntityByName", mock.Anything, mock.Anything).Return(entity, nil)
 	store.On("GetEventByEntityCheck", mock.Anything, mock.Anything, mock.Anything).Return((*corev2.Event)(nil), nil)
 
 	// The switch should be buried since the event is nil
@@ -364,3 +592,236 @@ func TestDeadCallbackNoEvent(t *testing.T) {
 		t.Fatalf("got bury: %v, want bury: %v", got, want)
 	}
 }
+
+func TestDeadCallbackNoKeepalives(t *testing.T) {
+	test := newKeepalivedTest(t)
+	defer test.Dispose(t)
+
+	entity := corev2.FixtureEntity("router1")
+	entity.EntityClass = corev2.EntityProxyClass
+	test.Store.On("GetEntityByName", mock.Anything, "router1").Return(entity, nil)
+
+	// The switch is buried without creating a keepalive event
+	assert.True(t, test.Keepalived.dead("default/router1", liveness.Alive, true))
+	test.Store.AssertNotCalled(t, "GetEventByEntityCheck", mock.Anything, "router1", "keepalive")
+}
+
+func TestStopHandsOffKeepalives(t *testing.T) {
+	test := newKeepalivedTest(t)
+	defer test.Dispose(t)
+	switches := &buryRecorder{}
+	test.Keepalived.livenessFactory = func(string, liveness.EventFunc, liveness.EventFunc, logrus.FieldLogger) liveness.Interface {
+		return switches
+	}
+	test.Store.On("GetFailingKeepalives", mock.Anything).Return([]*corev2.KeepaliveRecord{}, nil)
+	require.NoError(t, test.Keepalived.Start())
+
+	connected := corev2.FixtureEntity("agent1")
+	connected.EntityClass = corev2.EntityAgentClass
+	test.Keepalived.trackAgent(connected, 20)
+
+	// An agent whose keepalive timeout exceeds the grace period
+	slow := corev2.FixtureEntity("agent2")
+	slow.EntityClass = corev2.EntityAgentClass
+	test.Keepalived.trackAgent(slow, 300)
+
+	// An agent which stopped sending its keepalives to this backend
+	gone := corev2.FixtureEntity("agent3")
+	gone.EntityClass = corev2.EntityAgentClass
+	test.Keepalived.trackAgent(gone, 120)
+	agent := test.Keepalived.agents["default/agent3"]
+	agent.lastSeen -= 300
+	test.Keepalived.agents["default/agent3"] = agent
+
+	proxy := corev2.FixtureEntity("proxy")
+	proxy.EntityClass = corev2.EntityProxyClass
+	test.Keepalived.trackAgent(proxy, 120)
+
+	// The switches of the connected agents are rearmed for the other backends
+	require.NoError(t, test.Keepalived.Stop())
+	assert.ElementsMatch(t, []string{
+		fmt.Sprintf("default/agent1 alive %d", int64(DefaultHandoffGracePeriod/time.Second)),
+		"default/agent2 alive 300",
+	}, switches.armed)
+}
+
+func TestAgentDisconnectUntracksAgent(t *testing.T) {
+	test := newKeepalivedTest(t)
+	defer test.Dispose(t)
+	test.Store.On("GetFailingKeepalives", mock.Anything).Return([]*corev2.KeepaliveRecord{}, nil)
+	require.NoError(t, test.Keepalived.Start())
+
+	agent := corev2.FixtureEntity("agent1")
+	agent.EntityClass = corev2.EntityAgentClass
+	test.Keepalived.trackAgent(agent, 120)
+
+	test.Keepalived.keepaliveChan <- &messaging.AgentDisconnect{Namespace: "default", Name: "agent1"}
+	assert.Eventually(t, func() bool {
+		test.Keepalived.agentsMu.Lock()
+		defer test.Keepalived.agentsMu.Unlock()
+		return len(test.Keepalived.agents) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+type buryRecorder struct {
+	fakeLivenessInterface
+	buried []string
+	armed  []string
+}
+
+func (b *buryRecorder) Bury(_ context.Context, id string) error {
+	b.buried = append(b.buried, id)
+	return nil
+}
+
+func (b *buryRecorder) Alive(_ context.Context, id string, ttl int64) error {
+	b.armed = append(b.armed, fmt.Sprintf("%s alive %d", id, ttl))
+	return nil
+}
+
+func (b *buryRecorder) Dead(_ context.Context, id string, ttl int64) error {
+	b.armed = append(b.armed, fmt.Sprintf("%s dead %d", id, ttl))
+	return nil
+}
+
+func TestHandleEntityConfigChange(t *testing.T) {
+	// The keepalive events record the timeout their switch was armed with
+	failing := corev2.FixtureEvent("foo", corev2.KeepaliveCheckName)
+	failing.Check.Status = 1
+	failing.Check.Timeout = corev2.DefaultKeepaliveTimeout
+	passing := corev2.FixtureEvent("foo", corev2.KeepaliveCheckName)
+	passing.Check.Timeout = corev2.DefaultKeepaliveTimeout
+
+	// The default timeout changed since these switches were armed
+	stale := corev2.FixtureEvent("foo", corev2.KeepaliveCheckName)
+	stale.Check.Timeout = 60
+	stale.Check.Annotations = map[string]string{corev2.KeepaliveTimeoutSetAnnotation: "false"}
+	staleFailing := corev2.FixtureEvent("foo", corev2.KeepaliveCheckName)
+	staleFailing.Check.Status = 1
+	staleFailing.Check.Timeout = 60
+	staleFailing.Check.Annotations = map[string]string{corev2.KeepaliveTimeoutSetAnnotation: "false"}
+
+	// The timeout configured by the agent doesn't change
+	configured := corev2.FixtureEvent("foo", corev2.KeepaliveCheckName)
+	configured.Check.Timeout = 60
+
+	deregister := corev2.FixtureEntity("foo")
+	deregister.Deregister = true
+
+	tests := []struct {
+		name           string
+		change         *messaging.EntityConfigChange
+		entity         *corev2.Entity
+		event          *corev2.Event
+		wantBuried     []string
+		wantArmed      []string
+		wantDeregister bool
+	}{
+		{
+			name:       "deleted entity",
+			change:     &messaging.EntityConfigChange{Namespace: "default", Name: "foo", Deleted: true},
+			wantBuried: []string{"default/foo"},
+		},
+		{
+			name:       "missing entity",
+			change:     &messaging.EntityConfigChange{Namespace: "default", Name: "foo"},
+			wantBuried: []string{"default/foo"},
+		},
+		{
+			name:   "entity not deregistered",
+			change: &messaging.EntityConfigChange{Namespace: "default", Name: "foo"},
+			entity: corev2.FixtureEntity("foo"),
+			event:  failing,
+		},
+		{
+			name:   "passing keepalive",
+			change: &messaging.EntityConfigChange{Namespace: "default", Name: "foo"},
+			entity: deregister,
+			event:  passing,
+		},
+		{
+			name:           "failing keepalive",
+			change:         &messaging.EntityConfigChange{Namespace: "default", Name: "foo"},
+			entity:         deregister,
+			event:          failing,
+			wantBuried:     []string{"default/foo"},
+			wantDeregister: true,
+		},
+		{
+			name:      "changed timeout",
+			change:    &messaging.EntityConfigChange{Namespace: "default", Name: "foo"},
+			entity:    corev2.FixtureEntity("foo"),
+			event:     stale,
+			wantArmed: []string{"default/foo alive 120"},
+		},
+		{
+			name:      "changed timeout of failing keepalive",
+			change:    &messaging.EntityConfigChange{Namespace: "default", Name: "foo"},
+			entity:    corev2.FixtureEntity("foo"),
+			event:     staleFailing,
+			wantArmed: []string{"default/foo dead 120"},
+		},
+		{
+			name:   "configured timeout",
+			change: &messaging.EntityConfigChange{Namespace: "default", Name: "foo"},
+			entity: corev2.FixtureEntity("foo"),
+			event:  configured,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &mockstore.MockStore{}
+			store.On("GetEntityByName", mock.Anything, "foo").Return(tt.entity, nil)
+			store.On("GetEventByEntityCheck", mock.Anything, "foo", corev2.KeepaliveCheckName).Return(tt.event, nil)
+			store.On("DeleteEntity", mock.Anything, mock.Anything).Return(nil)
+			store.On("GetEventsByEntity", mock.Anything, "foo", mock.Anything).Return([]*corev2.Event{}, nil)
+			store.On("UpdateEvent", mock.Anything).Return(tt.event, (*corev2.Event)(nil), nil)
+
+			k, err := New(Config{
+				Store:           store,
+				EventStore:      store,
+				Bus:             &mockbus.MockBus{},
+				LivenessFactory: fakeFactory,
+				StoreTimeout:    time.Second,
+			})
+			require.NoError(t, err)
+
+			switches := &buryRecorder{}
+			require.NoError(t, k.handleEntityConfigChange(context.Background(), switches, tt.change))
+			assert.Equal(t, tt.wantBuried, switches.buried)
+			assert.Equal(t, tt.wantArmed, switches.armed)
+			if len(tt.wantArmed) > 0 {
+				store.AssertCalled(t, "UpdateEvent", mock.MatchedBy(func(event *corev2.Event) bool {
+					return event.Check.Timeout == corev2.DefaultKeepaliveTimeout
+				}))
+			} else {
+				store.AssertNotCalled(t, "UpdateEvent", mock.Anything)
+			}
+			if tt.wantDeregister {
+				store.AssertCalled(t, "DeleteEntity", mock.Anything, tt.entity)
+			} else {
+				store.AssertNotCalled(t, "DeleteEntity", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestRecordClockOffset(t *testing.T) {
+	keepalived := &Keepalived{maxClockSkew: time.Minute}
+	now := time.Now()
+	entity := corev2.FixtureEntity("agent1")
+
+	// Skewed clocks are recorded
+	keepalived.recordClockOffset(entity, now.Add(-5*time.Minute).Unix(), now)
+	assert.Equal(t, "-5m0s", entity.Annotations[corev2.ClockOffsetAnnotation])
+
+	// The annotation is removed once the skew is within tolerance
+	keepalived.recordClockOffset(entity, now.Add(30*time.Second).Unix(), now)
+	_, ok := entity.Annotations[corev2.ClockOffsetAnnotation]
+	assert.False(t, ok)
+
+	// The check can be disabled
+	keepalived.maxClockSkew = 0
+	keepalived.recordClockOffset(entity, now.Add(time.Hour).Unix(), now)
+	assert.Empty(t, entity.Annotations[corev2.ClockOffsetAnnotation])
+}
@@ -0,0 +1,280 @@
+// Package lifecycled deregisters the entities of EC2 instances that were
+// stopped or terminated, based on the EC2 instance state-change notifications
+// delivered to an SQS queue.
+package lifecycled
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/keepalived"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/util/aws"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// InstanceIDLabel is the entity label matched against the instance ID of
+	// EC2 notifications, in addition to the entity name.
+	InstanceIDLabel = "cloud_instance_id"
+
+	// stateChangeDetailType is the detail type of EC2 instance state-change
+	// notifications emitted by CloudWatch Events/EventBridge.
+	stateChangeDetailType = "EC2 Instance State-change Notification"
+
+	// retryInterval is the time to wait before polling the queue again after
+	// an error.
+	retryInterval = 5 * time.Second
+)
+
+// DefaultStates are the EC2 instance states that cause entities to be
+// deregistered when none are configured.
+var DefaultStates = []string{"terminated"}
+
+// Lifecycled consumes EC2 instance state-change notifications and deregisters
+// the corresponding entities.
+type Lifecycled struct {
+	store        store.Store
+	deregisterer keepalived.Deregisterer
+	sqs          *sqsClient
+	states       map[string]bool
+	storeTimeout time.Duration
+	ctx          context.Context
+	cancel       context.CancelFunc
+	wg           sync.WaitGroup
+	errChan      chan error
+}
+
+// Config configures Lifecycled.
+type Config struct {
+	Store        store.Store
+	EventStore   store.EventStore
+	Bus          messaging.MessageBus
+	StoreTimeout time.Duration
+
+	// QueueURL is the URL of the SQS queue receiving the EC2 instance
+	// state-change notifications, either directly or through SNS.
+	QueueURL string
+
+	// Region is the AWS region of the queue. It is determined from the queue
+	// URL if empty.
+	Region string
+
+	// States are the instance states that cause entities to be deregistered.
+	// DefaultStates is used if empty.
+	States []string
+
+	// Credentials provides the AWS credentials. An
+	// aws.DefaultCredentialsProvider is used if nil.
+	Credentials aws.CredentialsProvider
+}
+
+// Option is a functional option.
+type Option func(*Lifecycled) error
+
+// New creates a new Lifecycled.
+func New(c Config, opts ...Option) (*Lifecycled, error) {
+	if c.QueueURL == "" {
+		return nil, errors.New("no SQS queue URL configured")
+	}
+	region := c.Region
+	if region == "" {
+		var err error
+		region, err = regionFromQueueURL(c.QueueURL)
+		if err != nil {
+			return nil, err
+		}
+	}
+	credentials := c.Credentials
+	if credentials == nil {
+		credentials = &aws.DefaultCredentialsProvider{}
+	}
+	states := c.States
+	if len(states) == 0 {
+		states = DefaultStates
+	}
+
+	l := &Lifecycled{
+		store: c.Store,
+		deregisterer: &keepalived.Deregistration{
+			EntityStore:  c.Store,
+			EventStore:   c.EventStore,
+			MessageBus:   c.Bus,
+			StoreTimeout: c.StoreTimeout,
+		},
+		sqs: &sqsClient{
+			queueURL:    c.QueueURL,
+			region:      region,
+			credentials: credentials,
+			httpClient:  &http.Client{Timeout: 2 * sqsWaitTime * time.Second},
+		},
+		states:       make(map[string]bool, len(states)),
+		storeTimeout: c.StoreTimeout,
+		errChan:      make(chan error, 1),
+	}
+	for _, state := range states {
+		l.states[state] = true
+	}
+	l.ctx, l.cancel = context.WithCancel(context.Background())
+
+	for _, o := range opts {
+		if err := o(l); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// Start Lifecycled.
+func (l *Lifecycled) Start() error {
+	l.wg.Add(1)
+	go l.poll()
+	return nil
+}
+
+// Stop Lifecycled.
+func (l *Lifecycled) Stop() error {
+	l.cancel()
+	l.wg.Wait()
+	close(l.errChan)
+	return nil
+}
+
+// Err returns a channel to listen for terminal errors on.
+func (l *Lifecycled) Err() <-chan error {
+	return l.errChan
+}
+
+// Name returns the daemon name.
+func (l *Lifecycled) Name() string {
+	return "lifecycled"
+}
+
+func (l *Lifecycled) poll() {
+	defer l.wg.Done()
+	for {
+		messages, err := l.sqs.receive(l.ctx)
+		if l.ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			logger.WithError(err).Error("couldn't receive EC2 notifications")
+			select {
+			case <-time.After(retryInterval):
+				continue
+			case <-l.ctx.Done():
+				return
+			}
+		}
+		for _, msg := range messages {
+			if err := l.handleMessage(l.ctx, msg.Body); err != nil {
+				// Leave the message in the queue, it will be received again
+				// once its visibility timeout expires.
+				logger.WithError(err).WithField("message_id", msg.MessageID).Error("couldn't handle EC2 notification")
+				continue
+			}
+			if err := l.sqs.delete(l.ctx, msg.ReceiptHandle); err != nil {
+				logger.WithError(err).WithField("message_id", msg.MessageID).Error("couldn't delete EC2 notification")
+			}
+		}
+	}
+}
+
+// notification is an EC2 instance state-change notification.
+type notification struct {
+	DetailType string `json:"detail-type"`
+	Source     string `json:"source"`
+	Detail     struct {
+		InstanceID string `json:"instance-id"`
+		State      string `json:"state"`
+	} `json:"detail"`
+}
+
+// snsEnvelope wraps notifications delivered to SQS through SNS.
+type snsEnvelope struct {
+	Type    string `json:"Type"`
+	Message string `json:"Message"`
+}
+
+// parseNotification decodes the body of an SQS message. Notifications
+// delivered through SNS are unwrapped.
+func parseNotification(body string) (*notification, error) {
+	var envelope snsEnvelope
+	if err := json.Unmarshal([]byte(body), &envelope); err == nil && envelope.Type == "Notification" {
+		body = envelope.Message
+	}
+	var n notification
+	if err := json.Unmarshal([]byte(body), &n); err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// handleMessage deregisters the entities of the instance referenced by the
+// message, if any. Messages that are not relevant are ignored.
+func (l *Lifecycled) handleMessage(ctx context.Context, body string) error {
+	n, err := parseNotification(body)
+	if err != nil {
+		// Malformed messages will never be handled, don't retry them.
+		logger.WithError(err).Warn("ignoring malformed EC2 notification")
+		return nil
+	}
+	if n.DetailType != stateChangeDetailType || n.Detail.InstanceID == "" {
+		return nil
+	}
+	if !l.states[n.Detail.State] {
+		return nil
+	}
+
+	entities, err := l.instanceEntities(ctx, n.Detail.InstanceID)
+	if err != nil {
+		return err
+	}
+	for _, entity := range entities {
+		fields := logrus.Fields{
+			"namespace":   entity.Namespace,
+			"entity":      entity.Name,
+			"instance_id": n.Detail.InstanceID,
+			"state":       n.Detail.State,
+		}
+		if err := l.deregisterer.Deregister(entity); err != nil {
+			return err
+		}
+		logger.WithFields(fields).Info("deregistered entity of EC2 instance")
+	}
+	return nil
+}
+
+// instanceEntities returns the entities of the given EC2 instance, across all
+// namespaces.
+func (l *Lifecycled) instanceEntities(ctx context.Context, instanceID string) ([]*corev2.Entity, error) {
+	tctx, cancel := context.WithTimeout(ctx, l.storeTimeout)
+	defer cancel()
+	namespaces, err := l.store.ListNamespaces(tctx, &store.SelectionPredicate{})
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*corev2.Entity
+	for _, namespace := range namespaces {
+		nctx := store.NamespaceContext(ctx, namespace.Name)
+		tctx, cancel := context.WithTimeout(nctx, l.storeTimeout)
+		entities, err := l.store.GetEntities(tctx, &store.SelectionPredicate{})
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		for _, entity := range entities {
+			if entity.Name == instanceID || entity.Labels[InstanceIDLabel] == instanceID {
+				matches = append(matches, entity)
+			}
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,106 @@
+package lifecycled
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const terminatedNotification = `{
+  "version": "0",
+  "detail-type": "EC2 Instance State-change Notification",
+  "source": "aws.ec2",
+  "region": "us-east-1",
+  "detail": {"instance-id": "i-1234", "state": "terminated"}
+}`
+
+type fakeDeregisterer struct {
+	deregistered []string
+}
+
+func (d *fakeDeregisterer) Deregister(e *corev2.Entity) error {
+	d.deregistered = append(d.deregistered, e.Name)
+	return nil
+}
+
+func TestParseNotification(t *testing.T) {
+	n, err := parseNotification(terminatedNotification)
+	require.NoError(t, err)
+	assert.Equal(t, "i-1234", n.Detail.InstanceID)
+	assert.Equal(t, "terminated", n.Detail.State)
+
+	// Notifications delivered through SNS
+	envelope, err := json.Marshal(snsEnvelope{Type: "Notification", Message: terminatedNotification})
+	require.NoError(t, err)
+	n, err = parseNotification(string(envelope))
+	require.NoError(t, err)
+	assert.Equal(t, "i-1234", n.Detail.InstanceID)
+}
+
+func TestRegionFromQueueURL(t *testing.T) {
+	region, err := regionFromQueueURL("https://sqs.eu-west-1.amazonaws.com/123456789012/queue")
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-1", region)
+
+	region, err = regionFromQueueURL("https://us-east-2.queue.amazonaws.com/123456789012/queue")
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-2", region)
+
+	_, err = regionFromQueueURL("http://localhost:9324/queue")
+	assert.Error(t, err)
+}
+
+func TestHandleMessage(t *testing.T) {
+	byName := corev2.FixtureEntity("i-1234")
+	byLabel := corev2.FixtureEntity("web-1")
+	byLabel.Labels = map[string]string{InstanceIDLabel: "i-1234"}
+	other := corev2.FixtureEntity("web-2")
+
+	st := &mockstore.MockStore{}
+	st.On("ListNamespaces", mock.Anything, mock.Anything).Return([]*corev2.Namespace{corev2.FixtureNamespace("default")}, nil)
+	st.On("GetEntities", mock.Anything, mock.Anything).Return([]*corev2.Entity{byName, byLabel, other}, nil)
+
+	tests := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{
+			name: "terminated instance",
+			body: terminatedNotification,
+			want: []string{"i-1234", "web-1"},
+		},
+		{
+			name: "ignored state",
+			body: `{"detail-type": "EC2 Instance State-change Notification", "detail": {"instance-id": "i-1234", "state": "running"}}`,
+		},
+		{
+			name: "other notification",
+			body: `{"detail-type": "EC2 Spot Instance Interruption Warning", "detail": {"instance-id": "i-1234"}}`,
+		},
+		{
+			name: "malformed message",
+			body: "foo",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deregisterer := &fakeDeregisterer{}
+			l := &Lifecycled{
+				store:        st,
+				deregisterer: deregisterer,
+				states:       map[string]bool{"terminated": true},
+				storeTimeout: time.Second,
+			}
+			require.NoError(t, l.handleMessage(context.Background(), tt.body))
+			assert.Equal(t, tt.want, deregisterer.deregistered)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package lifecycled
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sensu/sensu-go/util/aws"
+)
+
+const (
+	sqsAPIVersion = "2012-11-05"
+
+	// sqsWaitTime is the long polling duration, in seconds, of ReceiveMessage
+	// requests. 20 seconds is the maximum allowed by SQS.
+	sqsWaitTime = 20
+
+	// sqsMaxMessages is the maximum number of messages retrieved at once.
+	sqsMaxMessages = 10
+)
+
+// sqsMessage is a message received from an SQS queue.
+type sqsMessage struct {
+	MessageID     string `xml:"MessageId"`
+	ReceiptHandle string `xml:"ReceiptHandle"`
+	Body          string `xml:"Body"`
+}
+
+type receiveMessageResponse struct {
+	Messages []sqsMessage `xml:"ReceiveMessageResult>Message"`
+}
+
+type sqsErrorResponse struct {
+	Code    string `xml:"Error>Code"`
+	Message string `xml:"Error>Message"`
+}
+
+// sqsClient is a minimal client of the SQS query API, limited to consuming
+// messages from a single queue.
+type sqsClient struct {
+	queueURL    string
+	region      string
+	credentials aws.CredentialsProvider
+	httpClient  *http.Client
+}
+
+// regionFromQueueURL extracts the AWS region from a queue URL, e.g.
+// https://sqs.us-east-1.amazonaws.com/123456789012/queue.
+func regionFromQueueURL(queueURL string) (string, error) {
+	u, err := url.Parse(queueURL)
+	if err != nil {
+		return "", err
+	}
+	parts := strings.Split(u.Hostname(), ".")
+	switch {
+	case len(parts) >= 4 && parts[0] == "sqs":
+		return parts[1], nil
+	case len(parts) >= 4 && parts[1] == "queue":
+		// Legacy endpoint, e.g. us-east-1.queue.amazonaws.com
+		return parts[0], nil
+	}
+	return "", fmt.Errorf("couldn't determine the region of queue %q", queueURL)
+}
+
+// receive long polls the queue for messages.
+func (c *sqsClient) receive(ctx context.Context) ([]sqsMessage, error) {
+	params := url.Values{}
+	params.Set("Action", "ReceiveMessage")
+	params.Set("MaxNumberOfMessages", strconv.Itoa(sqsMaxMessages))
+	params.Set("WaitTimeSeconds", strconv.Itoa(sqsWaitTime))
+
+	body, err := c.do(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	var resp receiveMessageResponse
+	if err := xml.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("couldn't decode ReceiveMessage response: %s", err)
+	}
+	return resp.Messages, nil
+}
+
+// delete removes a message from the queue.
+func (c *sqsClient) delete(ctx context.Context, receiptHandle string) error {
+	params := url.Values{}
+	params.Set("Action", "DeleteMessage")
+	params.Set("ReceiptHandle", receiptHandle)
+
+	_, err := c.do(ctx, params)
+	return err
+}
+
+func (c *sqsClient) do(ctx context.Context, params url.Values) ([]byte, error) {
+	params.Set("Version", sqsAPIVersion)
+	payload := []byte(params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.queueURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	creds, err := c.credentials.Credentials(ctx)
+	if err != nil {
+		return nil, err
+	}
+	aws.SignRequest(req, payload, creds, c.region, "sqs", time.Now().UTC())
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var sqsErr sqsErrorResponse
+		if err := xml.Unmarshal(body, &sqsErr); err == nil && sqsErr.Code != "" {
+			return nil, fmt.Errorf("sqs %s: %s: %s", params.Get("Action"), sqsErr.Code, sqsErr.Message)
+		}
+		return nil, fmt.Errorf("sqs %s: unexpected status %d", params.Get("Action"), resp.StatusCode)
+	}
+	return body, nil
+}
@@ -2,6 +2,7 @@ package liveness
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"path"
@@ -18,6 +19,8 @@ import (
 var (
 	switches = make(map[string]Interface)
 	switchMu sync.Mutex
+
+	errTTLChanged = errors.New("lease ttl changed")
 )
 
 // SwitchPrefix contains the base path for switchset, which are tracked under
@@ -231,7 +234,13 @@ func (t *SwitchSet) getLeaseID(ctx context.Context, ttl int64, switchID string)
 	if len(resp.Kvs) > 0 {
 		_, err = fmt.Sscanf(string(resp.Kvs[0].Value), "%x", &leaseID)
 		if err == nil {
-			_, err = t.client.KeepAliveOnce(ctx, leaseID)
+			var ka *clientv3.LeaseKeepAliveResponse
+			ka, err = t.client.KeepAliveOnce(ctx, leaseID)
+			if err == nil && ka.TTL != ttl {
+				// The lease keeps the TTL it was granted with, so a new
+				// lease is needed for the new TTL to apply
+				err = errTTLChanged
+			}
 		}
 	}
 	if len(resp.Kvs) == 0 || err != nil {
@@ -203,3 +203,102 @@ func TestBuryOnCallback(t *testing.T) {
 	// Ensure that the expired callback never fires
 	time.Sleep(6 * time.Second)
 }
+
+func TestLeaseTTLChange(t *testing.T) {
+	e, cleanup := etcd.NewTestEtcd(t)
+	defer cleanup()
+
+	client := e.NewEmbeddedClient()
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	toggle := NewSwitchSet(client, "test", nil, nil, logger)
+
+	if err := toggle.Alive(ctx, "default/entity1", 5); err != nil {
+		t.Fatal(err)
+	}
+
+	// The lease is reused as long as the TTL doesn't change
+	first, ops, err := toggle.getLeaseID(ctx, 5, "default/entity1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 0 {
+		t.Error("lease of unchanged ttl should be reused")
+	}
+
+	// A new lease is granted for a new TTL
+	if err := toggle.Alive(ctx, "default/entity1", 10); err != nil {
+		t.Fatal(err)
+	}
+	leaseID, ops, err := toggle.getLeaseID(ctx, 10, "default/entity1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ops) != 0 {
+		t.Error("lease of unchanged ttl should be reused")
+	}
+	if leaseID == first {
+		t.Fatal("expected a new lease for the new ttl")
+	}
+	resp, err := client.TimeToLive(ctx, leaseID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := resp.GrantedTTL, int64(10); got != want {
+		t.Errorf("bad granted ttl: got %d, want %d", got, want)
+	}
+}
+
+func TestInspectorSwitches(t *testing.T) {
+	e, cleanup := etcd.NewTestEtcd(t)
+	defer cleanup()
+
+	client := e.NewEmbeddedClient()
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	toggle := NewSwitchSet(client, "test", nil, nil, logger)
+	if err := toggle.Alive(ctx, "default/entity1", 60); err != nil {
+		t.Fatal(err)
+	}
+	if err := toggle.Dead(ctx, "default/entity2", 30); err != nil {
+		t.Fatal(err)
+	}
+	if err := toggle.Dead(ctx, "default/entity3", 30); err != nil {
+		t.Fatal(err)
+	}
+	if err := toggle.Bury(ctx, "default/entity3"); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().Unix()
+	states, err := NewInspector(client).Switches(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := len(states), 2; got != want {
+		t.Fatalf("bad number of switches: got %d, want %d", got, want)
+	}
+
+	for i, want := range []SwitchState{
+		{SwitchSet: "test", Target: "default/entity1", State: "alive", TTL: 60},
+		{SwitchSet: "test", Target: "default/entity2", State: "dead", TTL: 30},
+	} {
+		got := states[i]
+		if got.Deadline < now+want.TTL-2 || got.Deadline > now+want.TTL+2 {
+			t.Errorf("bad deadline of %s: got %d, want about %d", want.Target, got.Deadline, now+want.TTL)
+		}
+		if got.LastUpdate < now-2 || got.LastUpdate > now+2 {
+			t.Errorf("bad last update of %s: got %d, want about %d", want.Target, got.LastUpdate, now)
+		}
+		got.Deadline, got.LastUpdate = 0, 0
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("bad switch: got %v, want %v", got, want)
+		}
+	}
+}
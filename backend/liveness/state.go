@@ -0,0 +1,109 @@
+package liveness
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+)
+
+// SwitchState is the state of a switch, as monitored by its SwitchSet.
+type SwitchState struct {
+	// SwitchSet is the name of the switch set of the switch, such as
+	// "keepalived" for keepalives or "eventd" for check TTLs.
+	SwitchSet string `json:"switch_set"`
+
+	// Target is the ID of the switch, such as namespace/entity for
+	// keepalives or namespace/entity/check for check TTLs.
+	Target string `json:"target"`
+
+	// State is the state of the switch, "alive" or "dead".
+	State string `json:"state"`
+
+	// TTL is the time-to-live of the switch, in seconds.
+	TTL int64 `json:"ttl"`
+
+	// Deadline is the time at which the switch is presumed dead if it's alive,
+	// or at which the next dead callback is issued if it's dead, in seconds
+	// since the Unix epoch. It is 0 if the lease of the switch expired.
+	Deadline int64 `json:"deadline"`
+
+	// LastUpdate is the time at which the lease of the switch was last
+	// granted or renewed, in seconds since the Unix epoch.
+	LastUpdate int64 `json:"last_update"`
+}
+
+// Inspector reads the state of the switches of every SwitchSet.
+type Inspector struct {
+	client *clientv3.Client
+}
+
+// NewInspector creates a new Inspector using the given etcd client.
+func NewInspector(client *clientv3.Client) *Inspector {
+	return &Inspector{client: client}
+}
+
+// Switches returns the state of the switches of every SwitchSet. The switches
+// are stored in etcd, so they include the ones monitored by other backends.
+func (i *Inspector) Switches(ctx context.Context) ([]SwitchState, error) {
+	resp, err := i.client.Get(ctx, SwitchPrefix+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("error reading switches: %s", err)
+	}
+
+	states := []SwitchState{}
+	for _, kv := range resp.Kvs {
+		key := string(kv.Key)
+		state, ok := switchState(key, kv.Value)
+		if !ok {
+			continue
+		}
+
+		if kv.Lease != 0 {
+			lease, err := i.client.TimeToLive(ctx, clientv3.LeaseID(kv.Lease))
+			if err != nil {
+				return nil, fmt.Errorf("error reading lease of switch %q: %s", key, err)
+			}
+			if lease.TTL >= 0 {
+				now := time.Now().Unix()
+				state.Deadline = now + lease.TTL
+				state.LastUpdate = now - (lease.GrantedTTL - lease.TTL)
+			}
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// switchState returns the state of the switch stored at the given key, or
+// false if the key is not the key of a live switch, e.g. if it is the key of
+// a lease or of a buried switch.
+func switchState(key string, value []byte) (SwitchState, bool) {
+	leasePrefix := path.Join(SwitchPrefix, "lease") + "/"
+	if strings.HasPrefix(key, leasePrefix) || string(value) == buried {
+		return SwitchState{}, false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(key, SwitchPrefix+"/"), "/", 2)
+	if len(parts) != 2 {
+		return SwitchState{}, false
+	}
+
+	var ttl int64
+	if _, err := fmt.Sscanf(string(value), "%d", &ttl); err != nil || ttl == 0 {
+		return SwitchState{}, false
+	}
+	state := SwitchState{
+		SwitchSet: parts[0],
+		Target:    parts[1],
+		State:     Alive.String(),
+		TTL:       ttl,
+	}
+	if ttl < 0 {
+		state.State = Dead.String()
+		state.TTL = -ttl
+	}
+	return state, true
+}
@@ -0,0 +1,64 @@
+package liveness
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSwitchState(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+		want  SwitchState
+		ok    bool
+	}{
+		{
+			name:  "alive switch",
+			key:   "/sensu.io/switchsets/keepalived/default/entity1",
+			value: "120",
+			want:  SwitchState{SwitchSet: "keepalived", Target: "default/entity1", State: "alive", TTL: 120},
+			ok:    true,
+		},
+		{
+			name:  "dead switch",
+			key:   "/sensu.io/switchsets/eventd/default/entity1/check1",
+			value: "-60",
+			want:  SwitchState{SwitchSet: "eventd", Target: "default/entity1/check1", State: "dead", TTL: 60},
+			ok:    true,
+		},
+		{
+			name:  "buried switch",
+			key:   "/sensu.io/switchsets/keepalived/default/entity1",
+			value: buried,
+		},
+		{
+			name:  "lease",
+			key:   "/sensu.io/switchsets/lease/keepalived/default/entity1",
+			value: "694d71a1a1b2c3d4",
+		},
+		{
+			name:  "missing target",
+			key:   "/sensu.io/switchsets/keepalived",
+			value: "120",
+		},
+		{
+			name:  "invalid ttl",
+			key:   "/sensu.io/switchsets/keepalived/default/entity1",
+			value: "foo",
+		},
+		{
+			name:  "zero ttl",
+			key:   "/sensu.io/switchsets/keepalived/default/entity1",
+			value: "0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := switchState(tt.key, []byte(tt.value))
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -29,6 +29,10 @@ const (
 
 	// TopicTessenMetric is the topic prefix for tessen api metrics to Tessend.
 	TopicTessenMetric = "sensu:tessen-metric"
+
+	// TopicEntityConfig is the topic for the changes made to the configuration
+	// of entities through the API.
+	TopicEntityConfig = "sensu:entity-config"
 )
 
 var (
@@ -88,3 +92,26 @@ type MessageBus interface {
 func SubscriptionTopic(namespace, sub string) string {
 	return fmt.Sprintf("%s:%s:%s", TopicSubscriptions, namespace, sub)
 }
+
+// EntityConfigChange is published to TopicEntityConfig when an entity is
+// updated or deleted through the API.
+type EntityConfigChange struct {
+	// Namespace is the namespace of the entity.
+	Namespace string
+
+	// Name is the name of the entity.
+	Name string
+
+	// Deleted is true if the entity was deleted.
+	Deleted bool
+}
+
+// AgentDisconnect is published to TopicKeepalive when the session of an agent
+// with the backend is closed.
+type AgentDisconnect struct {
+	// Namespace is the namespace of the agent.
+	Namespace string
+
+	// Name is the name of the agent.
+	Name string
+}
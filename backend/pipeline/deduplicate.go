@@ -0,0 +1,129 @@
+package pipeline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+const (
+	// DeduplicateFilterName is the name of the built-in filter that denies
+	// the events identical to the last event handled by the handler, within
+	// the deduplication window.
+	DeduplicateFilterName = "deduplicate"
+
+	// fingerprintOutputSize is the size of the check output, in bytes, taken
+	// into account by event fingerprints, so that long outputs that only
+	// differ in their details are considered identical.
+	fingerprintOutputSize = 256
+)
+
+// EventFingerprint returns the fingerprint of an event, computed from its
+// entity, its check, its check status and the beginning of its check output.
+func EventFingerprint(event *corev2.Event) string {
+	output := event.Check.Output
+	if len(output) > fingerprintOutputSize {
+		output = output[:fingerprintOutputSize]
+	}
+	outputSum := sha256.Sum256([]byte(output))
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s\x00%s\x00%s\x00%d\x00%x",
+		event.Entity.Namespace,
+		event.Entity.Name,
+		event.Check.Name,
+		event.Check.Status,
+		outputSum,
+	)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Deduplicator remembers the fingerprints of the events handled by the
+// handlers using the deduplicate filter. It is shared by the pipelines of
+// pipelined.
+type Deduplicator struct {
+	window    time.Duration
+	mu        sync.Mutex
+	handled   map[string]handledEvent
+	lastPrune time.Time
+}
+
+type handledEvent struct {
+	fingerprint string
+	time        time.Time
+}
+
+// NewDeduplicator creates a new Deduplicator which denies duplicate events
+// for the given window.
+func NewDeduplicator(window time.Duration) *Deduplicator {
+	return &Deduplicator{
+		window:    window,
+		handled:   make(map[string]handledEvent),
+		lastPrune: time.Now(),
+	}
+}
+
+// deduplicationKey identifies the events of a check and entity handled by a
+// handler.
+func deduplicationKey(handler *corev2.Handler, event *corev2.Event) string {
+	return path.Join(handler.Namespace, handler.Name, event.Entity.Name, event.Check.Name)
+}
+
+// IsDuplicate returns true if the last event of the same check and entity
+// handled by the handler has the same fingerprint as the event, and was
+// handled within the deduplication window.
+func (d *Deduplicator) IsDuplicate(handler *corev2.Handler, event *corev2.Event) bool {
+	if d == nil || !event.HasCheck() {
+		return false
+	}
+
+	d.mu.Lock()
+	last, ok := d.handled[deduplicationKey(handler, event)]
+	d.mu.Unlock()
+	if !ok || time.Since(last.time) >= d.window {
+		return false
+	}
+	return last.fingerprint == EventFingerprint(event)
+}
+
+// Record records the fingerprint of an event handled by the handler.
+func (d *Deduplicator) Record(handler *corev2.Handler, event *corev2.Event) {
+	if d == nil || !event.HasCheck() {
+		return
+	}
+
+	now := time.Now()
+	fingerprint := EventFingerprint(event)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handled[deduplicationKey(handler, event)] = handledEvent{
+		fingerprint: fingerprint,
+		time:        now,
+	}
+
+	// Forget the events handled before the window, at most once per window
+	if now.Sub(d.lastPrune) < d.window {
+		return
+	}
+	for key, handled := range d.handled {
+		if now.Sub(handled.time) >= d.window {
+			delete(d.handled, key)
+		}
+	}
+	d.lastPrune = now
+}
+
+// usesFilter returns true if the handler uses the filter with the given name.
+func usesFilter(handler *corev2.Handler, name string) bool {
+	for _, filter := range handler.Filters {
+		if filter == name {
+			return true
+		}
+	}
+	return false
+}
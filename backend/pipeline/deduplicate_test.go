@@ -0,0 +1,78 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventFingerprint(t *testing.T) {
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Output = strings.Repeat("a", fingerprintOutputSize)
+	fingerprint := EventFingerprint(event)
+
+	// Only the beginning of the output is taken into account
+	event.Check.Output += "details"
+	assert.Equal(t, fingerprint, EventFingerprint(event))
+
+	event.Check.Status = 2
+	assert.NotEqual(t, fingerprint, EventFingerprint(event))
+
+	other := corev2.FixtureEvent("entity2", "check1")
+	other.Check.Output = event.Check.Output
+	other.Check.Status = 2
+	assert.NotEqual(t, EventFingerprint(event), EventFingerprint(other))
+}
+
+func TestDeduplicator(t *testing.T) {
+	handler := corev2.FixtureHandler("ticketing")
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Status = 2
+
+	d := NewDeduplicator(time.Minute)
+	assert.False(t, d.IsDuplicate(handler, event))
+
+	d.Record(handler, event)
+	assert.True(t, d.IsDuplicate(handler, event))
+
+	// Other handlers handle the event
+	assert.False(t, d.IsDuplicate(corev2.FixtureHandler("slack"), event))
+
+	// A different event isn't a duplicate
+	changed := corev2.FixtureEvent("entity1", "check1")
+	changed.Check.Status = 0
+	assert.False(t, d.IsDuplicate(handler, changed))
+
+	// Events aren't duplicates after the window
+	d = NewDeduplicator(10 * time.Millisecond)
+	d.Record(handler, event)
+	time.Sleep(20 * time.Millisecond)
+	assert.False(t, d.IsDuplicate(handler, event))
+}
+
+func TestDeduplicatorNil(t *testing.T) {
+	var d *Deduplicator
+	handler := corev2.FixtureHandler("ticketing")
+	event := corev2.FixtureEvent("entity1", "check1")
+	d.Record(handler, event)
+	assert.False(t, d.IsDuplicate(handler, event))
+}
+
+func TestPipelineDeduplicateFilter(t *testing.T) {
+	p := &Pipeline{deduplicator: NewDeduplicator(time.Minute)}
+	handler := corev2.FixtureHandler("ticketing")
+	handler.Filters = []string{DeduplicateFilterName}
+	event := corev2.FixtureEvent("entity1", "check1")
+
+	filter, err := p.filterEvent(handler, event)
+	assert.NoError(t, err)
+	assert.Equal(t, "", filter)
+
+	p.deduplicator.Record(handler, event)
+	filter, err = p.filterEvent(handler, event)
+	assert.NoError(t, err)
+	assert.Equal(t, DeduplicateFilterName, filter)
+}
@@ -0,0 +1,95 @@
+package pipeline
+
+import (
+	"context"
+	"sort"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	utillogging "github.com/sensu/sensu-go/util/logging"
+)
+
+// DryRun takes a Sensu event through the filters and mutators of its handlers,
+// and reports how each of them would process it. Unlike HandleEvent, the
+// filters of a handler are all evaluated, even once one of them rejected the
+// event, or if its namespace is muted, and the handlers are not executed.
+func (p *Pipeline) DryRun(ctx context.Context, event *corev2.Event) (*corev2.PipelineDryRun, error) {
+	ctx = context.WithValue(ctx, corev2.NamespaceKey, event.Entity.Namespace)
+	logger.WithFields(utillogging.EventFields(event, false)).Debug("dry-running event")
+
+	handlers, err := p.expandHandlers(ctx, eventHandlers(event), 1)
+	if err != nil {
+		return nil, err
+	}
+
+	muted, err := p.isMuted(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(handlers))
+	for name := range handlers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := &corev2.PipelineDryRun{
+		Handlers: []*corev2.PipelineDryRunHandler{},
+		Muted:    muted,
+	}
+	for _, name := range names {
+		handler := handlers[name].Handler
+		handlerResult := &corev2.PipelineDryRunHandler{
+			Handler: handler.Name,
+			Type:    handler.Type,
+			Filters: []*corev2.PipelineDryRunFilter{},
+			Mutator: handler.Mutator,
+		}
+		result.Handlers = append(result.Handlers, handlerResult)
+
+		filtered := false
+		if len(handler.Subscriptions) > 0 || len(handler.EntitySelectors) > 0 {
+			matches := handler.MatchesEntity(event.Entity)
+			handlerResult.Filters = append(handlerResult.Filters, &corev2.PipelineDryRunFilter{
+				Name:   EntitySelectorsFilterName,
+				Passed: matches,
+			})
+			filtered = !matches
+		}
+		for _, filterName := range handler.Filters {
+			filterResult := &corev2.PipelineDryRunFilter{Name: filterName}
+			handlerResult.Filters = append(handlerResult.Filters, filterResult)
+			rejected, err := p.evaluateFilter(handler, filterName, event)
+			if err != nil {
+				if _, ok := err.(*store.ErrInternal); ok {
+					// Fatal error
+					return nil, err
+				}
+				// Like HandleEvent, stop filtering the event but let it
+				// through
+				filterResult.Error = err.Error()
+				filterResult.Passed = true
+				break
+			}
+			filterResult.Passed = !rejected
+			filtered = filtered || rejected
+		}
+		if filtered {
+			continue
+		}
+
+		eventData, err := p.mutateEvent(handler, event)
+		if err != nil {
+			if _, ok := err.(*store.ErrInternal); ok {
+				// Fatal error
+				return nil, err
+			}
+			handlerResult.Error = err.Error()
+			continue
+		}
+		handlerResult.MutatorOutput = string(eventData)
+		handlerResult.WouldRun = !muted
+	}
+
+	return result, nil
+}
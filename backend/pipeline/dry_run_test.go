@@ -0,0 +1,93 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineDryRun(t *testing.T) {
+	store := &mockstore.MockStore{}
+	p := &Pipeline{store: store}
+
+	allowFilterBar := &corev2.EventFilter{
+		ObjectMeta:  corev2.NewObjectMeta("allowFilterBar", "default"),
+		Action:      corev2.EventFilterActionAllow,
+		Expressions: []string{`event.check.output == "bar"`},
+	}
+	filtered := corev2.FixtureHandler("filtered")
+	filtered.Filters = []string{"allowFilterBar", "is_incident"}
+	outputOnly := corev2.FixtureHandler("output_only")
+	outputOnly.Mutator = "only_check_output"
+
+	store.On("GetHandlerByName", mock.Anything, "filtered").Return(filtered, nil)
+	store.On("GetHandlerByName", mock.Anything, "output_only").Return(outputOnly, nil)
+	store.On("GetEventFilterByName", mock.Anything, "allowFilterBar").Return(allowFilterBar, nil)
+	store.On("GetNamespaceMute", mock.Anything, "default").Return((*corev2.NamespaceMute)(nil), nil)
+
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Output = "foo"
+	event.Check.Status = 1
+	event.Check.Handlers = []string{"output_only", "filtered"}
+
+	result, err := p.DryRun(context.Background(), event)
+	require.NoError(t, err)
+	require.Len(t, result.Handlers, 2)
+
+	// The handlers are sorted by name
+	assert.Equal(t, "filtered", result.Handlers[0].Handler)
+	assert.False(t, result.Handlers[0].WouldRun)
+	assert.Empty(t, result.Handlers[0].MutatorOutput)
+	require.Len(t, result.Handlers[0].Filters, 2)
+	assert.False(t, result.Handlers[0].Filters[0].Passed)
+	// Filters are evaluated even once the event was rejected
+	assert.True(t, result.Handlers[0].Filters[1].Passed)
+
+	assert.Equal(t, "output_only", result.Handlers[1].Handler)
+	assert.True(t, result.Handlers[1].WouldRun)
+	assert.Equal(t, "foo", result.Handlers[1].MutatorOutput)
+}
+
+func TestPipelineDryRunEntitySelectors(t *testing.T) {
+	store := &mockstore.MockStore{}
+	p := &Pipeline{store: store}
+
+	handler := corev2.FixtureHandler("payments")
+	handler.EntitySelectors = []string{"team==payments"}
+	store.On("GetHandlerByName", mock.Anything, "payments").Return(handler, nil)
+	store.On("GetNamespaceMute", mock.Anything, "default").Return((*corev2.NamespaceMute)(nil), nil)
+
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Entity.Labels = map[string]string{"team": "search"}
+	event.Check.Handlers = []string{"payments"}
+
+	result, err := p.DryRun(context.Background(), event)
+	require.NoError(t, err)
+	require.Len(t, result.Handlers, 1)
+	assert.False(t, result.Handlers[0].WouldRun)
+	require.Len(t, result.Handlers[0].Filters, 1)
+	assert.Equal(t, EntitySelectorsFilterName, result.Handlers[0].Filters[0].Name)
+	assert.False(t, result.Handlers[0].Filters[0].Passed)
+}
+
+func TestPipelineDryRunMuted(t *testing.T) {
+	store := &mockstore.MockStore{}
+	p := &Pipeline{store: store}
+
+	store.On("GetHandlerByName", mock.Anything, "handler1").Return(corev2.FixtureHandler("handler1"), nil)
+	store.On("GetNamespaceMute", mock.Anything, "default").Return(&corev2.NamespaceMute{Muted: true}, nil)
+
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Handlers = []string{"handler1"}
+
+	result, err := p.DryRun(context.Background(), event)
+	require.NoError(t, err)
+	assert.True(t, result.Muted)
+	require.Len(t, result.Handlers, 1)
+	assert.False(t, result.Handlers[0].WouldRun)
+}
@@ -0,0 +1,258 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// Settings of email handlers, read from the handler environment variables and
+// secrets.
+const (
+	// SMTPHostSetting is the host of the SMTP server. It is required.
+	SMTPHostSetting = "SMTP_HOST"
+
+	// SMTPPortSetting is the port of the SMTP server. DefaultSMTPPort is used
+	// if empty.
+	SMTPPortSetting = "SMTP_PORT"
+
+	// SMTPUsernameSetting is the username used to authenticate with the SMTP
+	// server. Authentication is disabled if empty.
+	SMTPUsernameSetting = "SMTP_USERNAME"
+
+	// SMTPPasswordSetting is the password used to authenticate with the SMTP
+	// server.
+	SMTPPasswordSetting = "SMTP_PASSWORD"
+
+	// SMTPTLSSetting is the TLS mode of the connection to the SMTP server:
+	// "starttls" (the default), "tls" for implicit TLS, or "none".
+	SMTPTLSSetting = "SMTP_TLS"
+
+	// SMTPInsecureSkipVerifySetting disables the verification of the SMTP
+	// server certificate when set to "true".
+	SMTPInsecureSkipVerifySetting = "SMTP_INSECURE_SKIP_VERIFY"
+
+	// EmailFromSetting is the sender address. It is required.
+	EmailFromSetting = "EMAIL_FROM"
+
+	// EmailToSetting is the comma-delimited list of recipients. It is
+	// required.
+	EmailToSetting = "EMAIL_TO"
+
+	// EmailSubjectTemplateSetting is the Go template of the subject, executed
+	// with the event. DefaultEmailSubjectTemplate is used if empty or if it
+	// fails to render.
+	EmailSubjectTemplateSetting = "EMAIL_SUBJECT_TEMPLATE"
+
+	// EmailBodyTemplateSetting is the Go template of the body, executed with
+	// the event. DefaultEmailBodyTemplate is used if empty or if it fails to
+	// render.
+	EmailBodyTemplateSetting = "EMAIL_BODY_TEMPLATE"
+)
+
+const (
+	// DefaultSMTPPort specifies the default port of SMTP servers.
+	DefaultSMTPPort = "587"
+
+	// DefaultEmailSubjectTemplate is the template of the email subjects when
+	// none is configured.
+	DefaultEmailSubjectTemplate = "[Sensu] {{ .Entity.Name }}{{ with .Check }}/{{ .Name }}: {{ .State }}{{ end }}"
+
+	// DefaultEmailBodyTemplate is the template of the email bodies when none
+	// is configured. It lists the runbook and the other links of the event.
+	DefaultEmailBodyTemplate = `Entity: {{ .Entity.Name }}
+Namespace: {{ .Entity.Namespace }}
+{{ with .Check }}Check: {{ .Name }}
+Status: {{ .Status }}
+State: {{ .State }}
+{{ end }}{{ with .RunbookURL }}Runbook: {{ . }}
+{{ end }}{{ range .Links }}{{ .Name }}: {{ .URL }}
+{{ end }}{{ with .Check }}
+{{ .Output }}{{ end }}`
+)
+
+// SMTP TLS modes
+const (
+	smtpTLSStartTLS = "starttls"
+	smtpTLSImplicit = "tls"
+	smtpTLSNone     = "none"
+)
+
+// emailMessage is an email to send.
+type emailMessage struct {
+	From    string
+	To      []string
+	Subject string
+	Body    string
+}
+
+// bytes returns the message in the Internet Message Format.
+func (m *emailMessage) bytes(now time.Time) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", m.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(m.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.Replace(m.Body, "\n", "\r\n", -1))
+	buf.WriteString("\r\n")
+	return buf.Bytes()
+}
+
+// emailHandler sends a notification of the event by email. Mutators don't
+// apply to email handlers, the email is built from the event itself.
+func (p *Pipeline) emailHandler(handler *corev2.Handler, event *corev2.Event) error {
+	ctx := corev2.SetContextFromResource(context.Background(), handler)
+	fields := logrus.Fields{
+		"namespace":  handler.Namespace,
+		"handler":    handler.Name,
+		"event_uuid": event.GetUUID().String(),
+		"entity":     event.Entity.Name,
+	}
+	if event.HasCheck() {
+		fields["check"] = event.Check.Name
+	}
+
+	settings, err := p.handlerSettings(ctx, handler)
+	if err != nil {
+		logger.WithFields(fields).WithError(err).Error("failed to retrieve secrets for handler")
+		return err
+	}
+
+	msg, err := newEmailMessage(event, settings)
+	if err != nil {
+		return fmt.Errorf("email handler %q: %s", handler.Name, err)
+	}
+
+	timeout := handler.Timeout
+	if timeout == 0 {
+		timeout = DefaultSocketTimeout
+	}
+
+	if err := sendEmail(settings, msg, time.Duration(timeout)*time.Second); err != nil {
+		logger.WithFields(fields).WithError(err).Error("failed to execute event email handler")
+		return err
+	}
+
+	fields["recipients"] = msg.To
+	logger.WithFields(fields).Info("event email handler executed")
+	return nil
+}
+
+// newEmailMessage builds the email of the event, with the subject and body
+// rendered from the configured templates.
+func newEmailMessage(event *corev2.Event, settings map[string]string) (*emailMessage, error) {
+	from := settings[EmailFromSetting]
+	if from == "" {
+		return nil, fmt.Errorf("no %s", EmailFromSetting)
+	}
+	var to []string
+	for _, recipient := range strings.Split(settings[EmailToSetting], ",") {
+		if recipient = strings.TrimSpace(recipient); recipient != "" {
+			to = append(to, recipient)
+		}
+	}
+	if len(to) == 0 {
+		return nil, fmt.Errorf("no %s", EmailToSetting)
+	}
+
+	subject := renderTemplate("email subject", settings[EmailSubjectTemplateSetting], DefaultEmailSubjectTemplate, event)
+	// Headers can't span multiple lines
+	subject = strings.Join(strings.Fields(subject), " ")
+
+	body := renderTemplate("email body", settings[EmailBodyTemplateSetting], DefaultEmailBodyTemplate, event)
+
+	return &emailMessage{
+		From:    from,
+		To:      to,
+		Subject: subject,
+		Body:    body,
+	}, nil
+}
+
+// sendEmail sends the message with the SMTP server configured in settings.
+func sendEmail(settings map[string]string, msg *emailMessage, timeout time.Duration) error {
+	host := settings[SMTPHostSetting]
+	if host == "" {
+		return fmt.Errorf("no %s", SMTPHostSetting)
+	}
+	port := settings[SMTPPortSetting]
+	if port == "" {
+		port = DefaultSMTPPort
+	}
+	mode := strings.ToLower(settings[SMTPTLSSetting])
+	if mode == "" {
+		mode = smtpTLSStartTLS
+	}
+	tlsConfig := &tls.Config{
+		ServerName:         host,
+		InsecureSkipVerify: settings[SMTPInsecureSkipVerifySetting] == "true",
+	}
+
+	address := net.JoinHostPort(host, port)
+	var conn net.Conn
+	var err error
+	switch mode {
+	case smtpTLSImplicit:
+		conn, err = tls.DialWithDialer(&net.Dialer{Timeout: timeout}, "tcp", address, tlsConfig)
+	case smtpTLSStartTLS, smtpTLSNone:
+		conn, err = net.DialTimeout("tcp", address, timeout)
+	default:
+		return fmt.Errorf("invalid %s %q, must be one of %s, %s or %s",
+			SMTPTLSSetting, mode, smtpTLSStartTLS, smtpTLSImplicit, smtpTLSNone)
+	}
+	if err != nil {
+		return err
+	}
+	_ = conn.SetDeadline(time.Now().Add(timeout))
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if mode == smtpTLSStartTLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return err
+		}
+	}
+	if username := settings[SMTPUsernameSetting]; username != "" {
+		auth := smtp.PlainAuth("", username, settings[SMTPPasswordSetting], host)
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(msg.From); err != nil {
+		return err
+	}
+	for _, recipient := range msg.To {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg.bytes(time.Now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
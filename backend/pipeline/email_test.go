@@ -0,0 +1,129 @@
+package pipeline
+
+import (
+	"bufio"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSMTPServer accepts a single SMTP session and sends the recipients and
+// data it received on the returned channel.
+func fakeSMTPServer(t *testing.T) (net.Listener, <-chan []string) {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		tp := textproto.NewConn(conn)
+		var lines []string
+		_ = tp.PrintfLine("220 localhost ESMTP")
+		for {
+			line, err := tp.ReadLine()
+			if err != nil {
+				return
+			}
+			switch {
+			case strings.HasPrefix(line, "EHLO"), strings.HasPrefix(line, "HELO"):
+				_ = tp.PrintfLine("250 localhost")
+			case strings.HasPrefix(line, "RCPT TO:"):
+				lines = append(lines, line)
+				_ = tp.PrintfLine("250 OK")
+			case line == "DATA":
+				_ = tp.PrintfLine("354 Go ahead")
+				data, err := tp.ReadDotLines()
+				if err != nil {
+					return
+				}
+				lines = append(lines, data...)
+				_ = tp.PrintfLine("250 OK")
+			case line == "QUIT":
+				_ = tp.PrintfLine("221 Bye")
+				received <- lines
+				return
+			default:
+				_ = tp.PrintfLine("250 OK")
+			}
+		}
+	}()
+	return ln, received
+}
+
+func TestPipelineEmailHandler(t *testing.T) {
+	ln, received := fakeSMTPServer(t)
+	defer ln.Close()
+	host, port, err := net.SplitHostPort(ln.Addr().String())
+	require.NoError(t, err)
+
+	p := &Pipeline{secretsProviderManager: secrets.NewProviderManager()}
+
+	handler := corev2.FixtureHandler("email")
+	handler.Type = corev2.HandlerEmailType
+	handler.EnvVars = []string{
+		SMTPHostSetting + "=" + host,
+		SMTPPortSetting + "=" + port,
+		SMTPTLSSetting + "=none",
+		EmailFromSetting + "=sensu@example.com",
+		EmailToSetting + "=ops@example.com, oncall@example.com",
+		EmailSubjectTemplateSetting + "={{ .Check.Name }} on {{ .Entity.Name }}",
+	}
+
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Output = "disk full"
+
+	require.NoError(t, p.emailHandler(handler, event))
+
+	lines := <-received
+	assert.Contains(t, lines, "RCPT TO:<ops@example.com>")
+	assert.Contains(t, lines, "RCPT TO:<oncall@example.com>")
+	assert.Contains(t, lines, "Subject: check1 on entity1")
+	assert.Contains(t, lines, "disk full")
+}
+
+func TestNewEmailMessage(t *testing.T) {
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.State = corev2.EventFailingState
+
+	_, err := newEmailMessage(event, map[string]string{EmailToSetting: "ops@example.com"})
+	assert.Error(t, err, "missing sender")
+
+	_, err = newEmailMessage(event, map[string]string{EmailFromSetting: "sensu@example.com"})
+	assert.Error(t, err, "missing recipients")
+
+	msg, err := newEmailMessage(event, map[string]string{
+		EmailFromSetting: "sensu@example.com",
+		EmailToSetting:   "ops@example.com",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ops@example.com"}, msg.To)
+	assert.Equal(t, "[Sensu] entity1/check1: failing", msg.Subject)
+
+	scanner := bufio.NewScanner(strings.NewReader(msg.Body))
+	require.True(t, scanner.Scan())
+	assert.Equal(t, "Entity: entity1", scanner.Text())
+	assert.NotContains(t, msg.Body, "Runbook")
+
+	event.Check.Output = "disk full"
+	event.Check.Annotations = map[string]string{
+		corev2.RunbookURLAnnotation:               "https://wiki.example.com/runbooks/check1",
+		corev2.LinkAnnotationPrefix + "dashboard": "https://dashboard.example.com/entity1",
+	}
+	msg, err = newEmailMessage(event, map[string]string{
+		EmailFromSetting: "sensu@example.com",
+		EmailToSetting:   "ops@example.com",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, msg.Body, "State: failing\nRunbook: https://wiki.example.com/runbooks/check1\ndashboard: https://dashboard.example.com/entity1\n\ndisk full")
+}
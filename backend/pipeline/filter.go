@@ -11,10 +11,16 @@ import (
 	utillogging "github.com/sensu/sensu-go/util/logging"
 )
 
+// EntitySelectorsFilterName is the name reported for the events denied because
+// their entity doesn't match the subscriptions or the entity selectors of the
+// handler.
+const EntitySelectorsFilterName = "entity_selectors"
+
 // Returns true if the event should be filtered/denied.
 func evaluateEventFilter(event *corev2.Event, filter *corev2.EventFilter, assets asset.RuntimeAssetSet) bool {
-	// Redact the entity to avoid leaking sensitive information
-	event.Entity = event.Entity.GetRedactedEntity()
+	// Redact the event, its entity and its check to avoid leaking sensitive
+	// information
+	event = event.GetRedactedEvent()
 
 	fields := utillogging.EventFields(event, false)
 	fields["filter"] = filter.Name
@@ -84,100 +90,129 @@ func (p *Pipeline) filterEvent(handler *corev2.Handler, event *corev2.Event) (st
 	fields := utillogging.EventFields(event, false)
 	fields["handler"] = handler.Name
 
+	// Deny the events of the entities the handler doesn't select, before
+	// evaluating any filter
+	if !handler.MatchesEntity(event.Entity) {
+		logger.WithFields(fields).Debug("denying event of an entity not selected by the handler")
+		return EntitySelectorsFilterName, nil
+	}
+
 	// Iterate through all event filters, the event is filtered if
 	// a filter returns true.
 	for _, filterName := range handler.Filters {
-		fields["filter"] = filterName
-
-		switch filterName {
-		case "is_incident":
-			// Deny an event if it is neither an incident nor resolution.
-			if !event.IsIncident() && !event.IsResolution() {
-				logger.WithFields(fields).Debug("denying event that is not an incident/resolution")
-				return filterName, nil
-			}
-		case "has_metrics":
-			// Deny an event if it does not have metrics
-			if !event.HasMetrics() {
-				logger.WithFields(fields).Debug("denying event without metrics")
-				return filterName, nil
-			}
-		case "not_silenced":
-			// Deny event that is silenced.
-			if event.IsSilenced() {
-				logger.WithFields(fields).Debug("denying event that is silenced")
-				return filterName, nil
-			}
-		default:
-			// Retrieve the filter from the store with its name
-			ctx := corev2.SetContextFromResource(context.Background(), event.Entity)
-			tctx, cancel := context.WithTimeout(ctx, p.storeTimeout)
-			filter, err := p.store.GetEventFilterByName(tctx, filterName)
-			cancel()
-			if err != nil {
-				logger.WithFields(fields).WithError(err).
-					Warning("could not retrieve filter")
-				return "", err
-			}
+		filtered, err := p.evaluateFilter(handler, filterName, event)
+		if err != nil {
+			return "", err
+		}
+		if filtered {
+			return filterName, nil
+		}
+	}
 
-			if filter != nil {
-				// Execute the filter, evaluating each of its
-				// expressions against the event. The event is rejected
-				// if the product of all expressions is true.
-				ctx := corev2.SetContextFromResource(context.Background(), filter)
-				matchedAssets := asset.GetAssets(ctx, p.store, filter.RuntimeAssets)
-				assets, err := asset.GetAll(context.TODO(), p.assetGetter, matchedAssets)
-				if err != nil {
-					logger.WithFields(fields).WithError(err).Error("failed to retrieve assets for filter")
-					if _, ok := err.(*store.ErrInternal); ok {
-						// Fatal error
-						return "", err
-					}
-				}
-				filtered := evaluateEventFilter(event, filter, assets)
-				if filtered {
-					logger.WithFields(fields).Debug("denying event with custom filter")
-					return filterName, nil
-				}
-				continue
-			}
+	logger.WithFields(fields).Debug("allowing event")
+	return "", nil
+}
 
-			// If the filter didn't exist, it might be an extension filter
-			ext, err := p.store.GetExtension(ctx, filterName)
+// evaluateFilter evaluates the filter of the handler with the given name.
+// Returns true if the event was filtered and any error encountered
+func (p *Pipeline) evaluateFilter(handler *corev2.Handler, filterName string, event *corev2.Event) (bool, error) {
+	// Prepare the logging
+	fields := utillogging.EventFields(event, false)
+	fields["handler"] = handler.Name
+	fields["filter"] = filterName
+
+	switch filterName {
+	case "is_incident":
+		// Deny an event if it is neither an incident nor resolution.
+		if !event.IsIncident() && !event.IsResolution() {
+			logger.WithFields(fields).Debug("denying event that is not an incident/resolution")
+			return true, nil
+		}
+	case "has_metrics":
+		// Deny an event if it does not have metrics
+		if !event.HasMetrics() {
+			logger.WithFields(fields).Debug("denying event without metrics")
+			return true, nil
+		}
+	case "not_silenced":
+		// Deny event that is silenced.
+		if event.IsSilenced() {
+			logger.WithFields(fields).Debug("denying event that is silenced")
+			return true, nil
+		}
+	case DeduplicateFilterName:
+		// Deny an event identical to the last one handled by the handler
+		if p.deduplicator.IsDuplicate(handler, event) {
+			logger.WithFields(fields).Debug("denying duplicate event")
+			return true, nil
+		}
+	default:
+		// Retrieve the filter from the store with its name
+		ctx := corev2.SetContextFromResource(context.Background(), event.Entity)
+		tctx, cancel := context.WithTimeout(ctx, p.storeTimeout)
+		filter, err := p.store.GetEventFilterByName(tctx, filterName)
+		cancel()
+		if err != nil {
+			logger.WithFields(fields).WithError(err).
+				Warning("could not retrieve filter")
+			return false, err
+		}
+
+		if filter != nil {
+			// Execute the filter, evaluating each of its
+			// expressions against the event. The event is rejected
+			// if the product of all expressions is true.
+			ctx := corev2.SetContextFromResource(context.Background(), filter)
+			matchedAssets := asset.GetAssets(ctx, p.store, filter.RuntimeAssets)
+			assets, err := asset.GetAll(context.TODO(), p.assetGetter, matchedAssets)
 			if err != nil {
-				logger.WithFields(fields).WithError(err).
-					Warning("could not retrieve filter")
+				logger.WithFields(fields).WithError(err).Error("failed to retrieve assets for filter")
 				if _, ok := err.(*store.ErrInternal); ok {
 					// Fatal error
-					return "", err
+					return false, err
 				}
-				continue
 			}
-
-			executor, err := p.extensionExecutor(ext)
-			if err != nil {
-				logger.WithFields(fields).WithError(err).
-					Error("could not execute filter")
-				continue
+			filtered := evaluateEventFilter(event, filter, assets)
+			if filtered {
+				logger.WithFields(fields).Debug("denying event with custom filter")
 			}
-			defer func() {
-				if err := executor.Close(); err != nil {
-					logger.WithError(err).Debug("error closing grpc client")
-				}
-			}()
-			filtered, err := executor.FilterEvent(event)
-			if err != nil {
-				logger.WithFields(fields).WithError(err).
-					Error("could not execute filter")
-				continue
+			return filtered, nil
+		}
+
+		// If the filter didn't exist, it might be an extension filter
+		ext, err := p.store.GetExtension(ctx, filterName)
+		if err != nil {
+			logger.WithFields(fields).WithError(err).
+				Warning("could not retrieve filter")
+			if _, ok := err.(*store.ErrInternal); ok {
+				// Fatal error
+				return false, err
 			}
-			if filtered {
-				logger.WithFields(fields).Debug("denying event with custom filter extension")
-				return filterName, nil
+			return false, nil
+		}
+
+		executor, err := p.extensionExecutor(ext)
+		if err != nil {
+			logger.WithFields(fields).WithError(err).
+				Error("could not execute filter")
+			return false, nil
+		}
+		defer func() {
+			if err := executor.Close(); err != nil {
+				logger.WithError(err).Debug("error closing grpc client")
 			}
+		}()
+		filtered, err := executor.FilterEvent(event)
+		if err != nil {
+			logger.WithFields(fields).WithError(err).
+				Error("could not execute filter")
+			return false, nil
+		}
+		if filtered {
+			logger.WithFields(fields).Debug("denying event with custom filter extension")
+			return true, nil
 		}
 	}
 
-	logger.WithFields(fields).Debug("allowing event")
-	return "", nil
+	return false, nil
 }
@@ -308,3 +308,60 @@ func TestPipelineWhenFilter(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineEntitySelectorsFilter(t *testing.T) {
+	p := &Pipeline{}
+
+	testCases := []struct {
+		name            string
+		subscriptions   []string
+		entitySelectors []string
+		expectedFilter  string
+	}{
+		{
+			name:           "no selection",
+			expectedFilter: "",
+		},
+		{
+			name:           "matching subscription",
+			subscriptions:  []string{"windows", "linux"},
+			expectedFilter: "",
+		},
+		{
+			name:           "no matching subscription",
+			subscriptions:  []string{"windows"},
+			expectedFilter: EntitySelectorsFilterName,
+		},
+		{
+			name:            "matching labels",
+			entitySelectors: []string{"team==payments", "region!=eu"},
+			expectedFilter:  "",
+		},
+		{
+			name:            "no matching label",
+			entitySelectors: []string{"team==payments,region==us"},
+			expectedFilter:  EntitySelectorsFilterName,
+		},
+		{
+			name:            "matching subscription but no matching label",
+			subscriptions:   []string{"linux"},
+			entitySelectors: []string{"team==search"},
+			expectedFilter:  EntitySelectorsFilterName,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := types.FixtureHandler("handler1")
+			handler.Subscriptions = tc.subscriptions
+			handler.EntitySelectors = tc.entitySelectors
+
+			event := types.FixtureEvent("entity1", "check1")
+			event.Entity.Labels = map[string]string{"team": "payments"}
+
+			f, err := p.filterEvent(handler, event)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedFilter, f)
+		})
+	}
+}
@@ -94,6 +94,7 @@ func TestPipelineHandleEvent(t *testing.T) {
 	store.On("GetHandlerByName", mock.Anything, "handler1").Return(handler, nil)
 	store.On("GetHandlerByName", mock.Anything, "handler2").Return((*corev2.Handler)(nil), nil)
 	store.On("GetExtension", mock.Anything, "handler2").Return(extension, nil)
+	store.On("GetNamespaceMute", mock.Anything, "default").Return((*corev2.NamespaceMute)(nil), nil)
 	m := &mockExec{}
 	m.On("HandleEvent", event, mock.Anything).Return(rpc.HandleEventResponse{
 		Output: "ok",
@@ -107,6 +108,20 @@ func TestPipelineHandleEvent(t *testing.T) {
 	m.AssertCalled(t, "HandleEvent", event, mock.Anything)
 }
 
+func TestPipelineHandleEventMuted(t *testing.T) {
+	store := &mockstore.MockStore{}
+	p := &Pipeline{store: store}
+
+	store.On("GetHandlerByName", mock.Anything, "handler1").Return(corev2.FixtureHandler("handler1"), nil)
+	store.On("GetNamespaceMute", mock.Anything, "default").Return(&corev2.NamespaceMute{Muted: true}, nil)
+
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Handlers = []string{"handler1"}
+
+	assert.NoError(t, p.HandleEvent(context.Background(), event))
+	store.AssertNotCalled(t, "UpdateHandlerResults", mock.Anything, mock.Anything)
+}
+
 func TestPipelineExpandHandlers(t *testing.T) {
 	type storeFunc func(*mockstore.MockStore)
 
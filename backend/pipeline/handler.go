@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"strings"
 	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
@@ -37,23 +38,22 @@ func (p *Pipeline) HandleEvent(ctx context.Context, event *corev2.Event) error {
 	// Prepare log entry
 	fields := utillogging.EventFields(event, false)
 
-	var handlerList []string
-
-	if event.HasCheck() {
-		handlerList = append(handlerList, event.Check.Handlers...)
+	handlers, err := p.expandHandlers(ctx, eventHandlers(event), 1)
+	if err != nil {
+		return err
 	}
 
-	if event.HasMetrics() {
-		handlerList = append(handlerList, event.Metrics.Handlers...)
+	if len(handlers) == 0 {
+		logger.WithFields(fields).Info("no handlers available")
+		return nil
 	}
 
-	handlers, err := p.expandHandlers(ctx, handlerList, 1)
+	muted, err := p.isMuted(ctx, event)
 	if err != nil {
 		return err
 	}
-
-	if len(handlers) == 0 {
-		logger.WithFields(fields).Info("no handlers available")
+	if muted {
+		logger.WithFields(fields).Info("namespace is muted, not handling event")
 		return nil
 	}
 
@@ -73,6 +73,9 @@ func (p *Pipeline) HandleEvent(ctx context.Context, event *corev2.Event) error {
 			logger.WithFields(fields).Infof("event filtered by filter %q", filter)
 			continue
 		}
+		if usesFilter(handler, DeduplicateFilterName) {
+			p.deduplicator.Record(handler, event)
+		}
 
 		eventData, err := p.mutateEvent(handler, event)
 		if err != nil {
@@ -84,38 +87,97 @@ func (p *Pipeline) HandleEvent(ctx context.Context, event *corev2.Event) error {
 			continue
 		}
 
-		logger.WithFields(fields).Info("sending event to handler")
+		// The fields are copied since the execution may be queued
+		handlerFields := logrus.Fields{}
+		for k, v := range fields {
+			handlerFields[k] = v
+		}
+		u := u
+		err = p.handlerLimiter.Execute(ctx, handler, func(ctx context.Context) error {
+			ctx = context.WithValue(ctx, corev2.NamespaceKey, event.Entity.Namespace)
+			return p.executeHandler(ctx, u, event, eventData, handlerFields)
+		})
+		if err == ErrHandlerQueueFull {
+			logger.WithFields(fields).Warn(err)
+			p.resultRecorder.Record(event, corev2.NewHandlerResult(handler.Name, event, time.Now(), 1, err.Error()))
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
 
-		switch handler.Type {
-		case "pipe":
-			if _, err := p.pipeHandler(handler, event, eventData); err != nil {
-				logger.WithFields(fields).Error(err)
-				if _, ok := err.(*store.ErrInternal); ok {
-					return err
-				}
-			}
-		case "tcp", "udp":
-			if _, err := p.socketHandler(handler, event, eventData); err != nil {
-				logger.WithFields(fields).Error(err)
-				if _, ok := err.(*store.ErrInternal); ok {
-					return err
-				}
-			}
-		case "grpc":
-			if _, err := p.grpcHandler(u.Extension, event, eventData); err != nil {
-				logger.WithFields(fields).Error(err)
-				if _, ok := err.(*store.ErrInternal); ok {
-					return err
-				}
-			}
-		default:
-			return errors.New("unknown handler type")
+	return nil
+}
+
+// executeHandler sends the event data to the handler, and stores the result of
+// the execution. The errors of the execution are only logged, except the
+// store errors and the unknown handler types, which are returned.
+func (p *Pipeline) executeHandler(ctx context.Context, u handlerExtensionUnion, event *corev2.Event, eventData []byte, fields logrus.Fields) error {
+	handler := u.Handler
+	logger.WithFields(fields).Info("sending event to handler")
+
+	start := time.Now()
+	var status int
+	var output string
+	var err error
+
+	switch handler.Type {
+	case "pipe":
+		var result *command.ExecutionResponse
+		result, err = p.pipeHandler(handler, event, eventData)
+		if result != nil {
+			status, output = result.Status, result.Output
+		}
+	case "tcp", "udp":
+		_, err = p.socketHandler(handler, event, eventData)
+	case "slack":
+		err = p.slackHandler(handler, event)
+	case "pagerduty":
+		err = p.pagerdutyHandler(handler, event)
+	case "email":
+		err = p.emailHandler(handler, event)
+	case "webhook":
+		err = p.webhookHandler(ctx, handler, event)
+	case "grpc":
+		var result rpc.HandleEventResponse
+		result, err = p.grpcHandler(u.Extension, event, eventData)
+		output = result.Output
+	default:
+		return errors.New("unknown handler type")
+	}
+
+	if err != nil {
+		logger.WithFields(fields).Error(err)
+		if status == 0 {
+			status = 1
 		}
+		output = err.Error()
 	}
+	p.resultRecorder.Record(event, corev2.NewHandlerResult(handler.Name, event, start, int32(status), output))
 
+	if _, ok := err.(*store.ErrInternal); ok {
+		return err
+	}
 	return nil
 }
 
+// eventHandlers returns the names of the handlers of the event check and
+// metrics.
+func eventHandlers(event *corev2.Event) []string {
+	var handlerList []string
+
+	if event.HasCheck() {
+		handlerList = append(handlerList, event.Check.Handlers...)
+	}
+
+	if event.HasMetrics() {
+		handlerList = append(handlerList, event.Metrics.Handlers...)
+	}
+
+	return handlerList
+}
+
 // expandHandlers turns a list of Sensu handler names into a list of
 // handlers, while expanding handler sets with support for some
 // nesting. Handlers are fetched from etcd.
@@ -230,14 +292,21 @@ func (p *Pipeline) pipeHandler(handler *corev2.Handler, event *corev2.Event, eve
 		return nil, err
 	}
 
+	// Pass the message rendered from the handler template, if any
+	envVars := handler.EnvVars
+	if message, ok := handlerMessage(handler.EnvVars, secrets, event); ok {
+		envVars = append(append([]string{}, handler.EnvVars...), HandlerMessageEnvVar+"="+message)
+	}
+
 	// Prepare environment variables
-	env := environment.MergeEnvironments(os.Environ(), handler.EnvVars, secrets)
+	env := environment.MergeEnvironments(os.Environ(), envVars, secrets)
 
 	handlerExec := command.ExecutionRequest{}
 	handlerExec.Command = handler.Command
 	handlerExec.Timeout = int(handler.Timeout)
 	handlerExec.Env = env
 	handlerExec.Input = string(eventData[:])
+	handlerExec.Sandbox = p.handlerSandbox
 
 	// Only add assets to execution context if handler requires them
 	if len(handler.RuntimeAssets) != 0 {
@@ -253,7 +322,7 @@ func (p *Pipeline) pipeHandler(handler *corev2.Handler, event *corev2.Event, eve
 				return nil, err
 			}
 		} else {
-			handlerExec.Env = environment.MergeEnvironments(os.Environ(), assets.Env(), handler.EnvVars, secrets)
+			handlerExec.Env = environment.MergeEnvironments(os.Environ(), assets.Env(), envVars, secrets)
 		}
 	}
 
@@ -270,6 +339,23 @@ func (p *Pipeline) pipeHandler(handler *corev2.Handler, event *corev2.Event, eve
 	return result, err
 }
 
+// handlerSettings returns the settings of the handlers implemented in the
+// backend, i.e. the handler environment variables and secrets, by name.
+func (p *Pipeline) handlerSettings(ctx context.Context, handler *corev2.Handler) (map[string]string, error) {
+	secrets, err := p.secretsProviderManager.SubSecrets(ctx, handler.Secrets)
+	if err != nil {
+		return nil, err
+	}
+	settings := make(map[string]string)
+	for _, env := range environment.MergeEnvironments(nil, handler.EnvVars, secrets) {
+		kv := strings.SplitN(env, "=", 2)
+		if len(kv) == 2 {
+			settings[kv[0]] = kv[1]
+		}
+	}
+	return settings, nil
+}
+
 func (p *Pipeline) grpcHandler(ext *corev2.Extension, evt *corev2.Event, mutated []byte) (rpc.HandleEventResponse, error) {
 	// Prepare log entry
 	fields := logrus.Fields{
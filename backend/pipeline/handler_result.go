@@ -0,0 +1,106 @@
+package pipeline
+
+import (
+	"context"
+	"path"
+	"sync"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// handlerResultFlushInterval is the interval at which the handler results
+// recorded by a HandlerResultRecorder are stored.
+const handlerResultFlushInterval = time.Second
+
+// HandlerResultRecorder records the results of the handler executions, and
+// stores them in batches so that the handlers never wait for the store. Only
+// the latest result of a handler for the event of an entity and check is
+// stored, as the store only keeps that one. It is shared by the pipelines of
+// pipelined.
+type HandlerResultRecorder struct {
+	store   store.HandlerResultStore
+	timeout time.Duration
+	done    chan struct{}
+
+	mu      sync.Mutex
+	pending map[string]*store.EventHandlerResult
+}
+
+// NewHandlerResultRecorder creates a new HandlerResultRecorder, which stores
+// the results recorded until ctx is done, each batch within timeout.
+func NewHandlerResultRecorder(ctx context.Context, st store.HandlerResultStore, timeout time.Duration) *HandlerResultRecorder {
+	r := &HandlerResultRecorder{
+		store:   st,
+		timeout: timeout,
+		done:    make(chan struct{}),
+		pending: make(map[string]*store.EventHandlerResult),
+	}
+	go r.run(ctx)
+	return r
+}
+
+// Record records the result of a handler execution for the event. Events
+// without a check have no results.
+func (r *HandlerResultRecorder) Record(event *corev2.Event, result *corev2.HandlerResult) {
+	if r == nil || !event.HasCheck() || event.Entity == nil {
+		return
+	}
+	update := &store.EventHandlerResult{
+		Namespace: event.Entity.Namespace,
+		Entity:    event.Entity.Name,
+		Check:     event.Check.Name,
+		Result:    result,
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending[path.Join(update.Namespace, update.Entity, update.Check, result.Handler)] = update
+}
+
+// Wait waits for the context of the recorder to be done, and for the results
+// recorded until then to be stored.
+func (r *HandlerResultRecorder) Wait() {
+	if r != nil {
+		<-r.done
+	}
+}
+
+func (r *HandlerResultRecorder) run(ctx context.Context) {
+	defer close(r.done)
+	ticker := time.NewTicker(handlerResultFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// The results of the last executions are stored once the
+			// pipelines are stopped
+			r.flush(context.Background())
+			return
+		case <-ticker.C:
+			r.flush(ctx)
+		}
+	}
+}
+
+// flush stores the results recorded since the last flush. Failing to store
+// them does not interrupt event handling, so they are only logged.
+func (r *HandlerResultRecorder) flush(ctx context.Context) {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[string]*store.EventHandlerResult)
+	r.mu.Unlock()
+	if len(pending) == 0 {
+		return
+	}
+
+	results := make([]*store.EventHandlerResult, 0, len(pending))
+	for _, result := range pending {
+		results = append(results, result)
+	}
+	tctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+	if err := r.store.UpdateHandlerResults(tctx, results); err != nil {
+		logger.WithError(err).WithField("results", len(results)).Warn("failed to store handler results")
+	}
+}
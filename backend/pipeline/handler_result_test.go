@@ -0,0 +1,51 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHandlerResultRecorder(t *testing.T) {
+	st := &mockstore.MockStore{}
+	var stored []*store.EventHandlerResult
+	st.On("UpdateHandlerResults", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = append(stored, args.Get(1).([]*store.EventHandlerResult)...)
+	}).Return(nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	recorder := NewHandlerResultRecorder(ctx, st, time.Second)
+
+	event := corev2.FixtureEvent("entity1", "check1")
+	failed := corev2.FixtureHandlerResult("slack")
+	failed.Status = 1
+	recorder.Record(event, failed)
+	recorder.Record(event, corev2.FixtureHandlerResult("email"))
+	// Only the latest result of a handler is stored
+	latest := corev2.FixtureHandlerResult("slack")
+	recorder.Record(event, latest)
+	// Events without a check have no results
+	metrics := corev2.FixtureEvent("entity1", "check1")
+	metrics.Check = nil
+	recorder.Record(metrics, corev2.FixtureHandlerResult("influxdb"))
+
+	// The results recorded are stored once the recorder is stopped
+	cancel()
+	recorder.Wait()
+	st.AssertNumberOfCalls(t, "UpdateHandlerResults", 1)
+	assert.Len(t, stored, 2)
+	for _, r := range stored {
+		assert.Equal(t, "default", r.Namespace)
+		assert.Equal(t, "entity1", r.Entity)
+		assert.Equal(t, "check1", r.Check)
+		if r.Result.Handler == "slack" {
+			assert.Equal(t, latest, r.Result)
+		}
+	}
+}
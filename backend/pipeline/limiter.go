@@ -0,0 +1,172 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"path"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// handlerQueueSize is the maximum number of executions of a handler queued
+// while the handler runs its maximum number of concurrent executions.
+const handlerQueueSize = 1000
+
+// HandlerDropped counts the handler executions dropped because the queue of
+// the handler is full.
+var HandlerDropped = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "sensu_go_handler_dropped_total",
+		Help: "Number of handler executions dropped because the queue of the handler is full",
+	},
+	[]string{"namespace", "handler"},
+)
+
+// HandlerQueueDepth is the number of executions of each handler waiting for
+// one of its concurrent executions to complete.
+var HandlerQueueDepth = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "sensu_go_handler_queue_depth",
+		Help: "Number of handler executions queued because of the concurrency limit of the handler",
+	},
+	[]string{"namespace", "handler"},
+)
+
+// ErrHandlerQueueFull is returned by HandlerLimiter.Execute when the queue of
+// the handler is full.
+var ErrHandlerQueueFull = errors.New("handler queue full, dropping execution")
+
+// HandlerLimiter limits the number of concurrent executions of each handler.
+// It is shared by the pipelines of pipelined, so that a slow handler can't
+// spawn an unbounded number of processes or requests during event storms. The
+// executions of a limited handler are queued, and executed by as many workers
+// as the handler allows concurrent executions, so that a slow handler never
+// stalls the pipeline workers handling the events of the other handlers.
+type HandlerLimiter struct {
+	ctx        context.Context
+	defaultMax uint32
+	mu         sync.Mutex
+	queues     map[string]*handlerQueue
+	wg         sync.WaitGroup
+}
+
+// handlerQueue holds the queued executions of a handler. Its workers are
+// started as executions are queued, and stop once the queue is empty.
+type handlerQueue struct {
+	max     uint32
+	depth   prometheus.Gauge
+	jobs    chan func()
+	mu      sync.Mutex
+	workers uint32
+}
+
+// NewHandlerLimiter creates a new HandlerLimiter, whose queued executions are
+// given ctx. defaultMax applies to the handlers that don't set MaxConcurrent;
+// they are unlimited if it's 0.
+func NewHandlerLimiter(ctx context.Context, defaultMax uint32) *HandlerLimiter {
+	return &HandlerLimiter{
+		ctx:        ctx,
+		defaultMax: defaultMax,
+		queues:     make(map[string]*handlerQueue),
+	}
+}
+
+// Execute executes the handler with execute. The executions of an unlimited
+// handler are executed right away with ctx, and the error of execute is
+// returned. The executions of a limited handler are queued and executed with
+// the context of the limiter, and their errors must be handled by execute;
+// ErrHandlerQueueFull is returned if the queue of the handler is full, and the
+// execution is dropped.
+func (l *HandlerLimiter) Execute(ctx context.Context, handler *corev2.Handler, execute func(context.Context) error) error {
+	max := handler.MaxConcurrent
+	if l != nil && max == 0 {
+		max = l.defaultMax
+	}
+	if l == nil || max == 0 {
+		return execute(ctx)
+	}
+
+	l.wg.Add(1)
+	job := func() {
+		defer l.wg.Done()
+		if err := execute(l.ctx); err != nil {
+			logger.WithError(err).WithField("handler", handler.Name).Error("error executing queued handler")
+		}
+	}
+	if !l.queue(handler, max).submit(job) {
+		l.wg.Done()
+		HandlerDropped.WithLabelValues(handler.Namespace, handler.Name).Inc()
+		return ErrHandlerQueueFull
+	}
+	return nil
+}
+
+// Wait waits for the queued executions to complete.
+func (l *HandlerLimiter) Wait() {
+	if l != nil {
+		l.wg.Wait()
+	}
+}
+
+// queue returns the queue of the handler, which is replaced when the limit of
+// the handler changes. The executions of a replaced queue are executed as
+// usual.
+func (l *HandlerLimiter) queue(handler *corev2.Handler, max uint32) *handlerQueue {
+	key := path.Join(handler.Namespace, handler.Name)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	q, ok := l.queues[key]
+	if !ok || q.max != max {
+		q = &handlerQueue{
+			max:   max,
+			depth: HandlerQueueDepth.WithLabelValues(handler.Namespace, handler.Name),
+			jobs:  make(chan func(), handlerQueueSize),
+		}
+		l.queues[key] = q
+	}
+	return q
+}
+
+// submit queues the job, and starts a worker if the queue has less than its
+// maximum number of workers. It returns false if the queue is full.
+func (q *handlerQueue) submit(job func()) bool {
+	select {
+	case q.jobs <- job:
+	default:
+		return false
+	}
+	q.depth.Set(float64(len(q.jobs)))
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.workers < q.max {
+		q.workers++
+		go q.work()
+	}
+	return true
+}
+
+// work executes the queued jobs until the queue is empty.
+func (q *handlerQueue) work() {
+	for {
+		select {
+		case job := <-q.jobs:
+			q.depth.Set(float64(len(q.jobs)))
+			job()
+		default:
+			// A job queued before the worker count is decremented must be
+			// executed by this worker, since no worker is started for it
+			q.mu.Lock()
+			if len(q.jobs) > 0 {
+				q.mu.Unlock()
+				continue
+			}
+			q.workers--
+			q.mu.Unlock()
+			return
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerLimiter(t *testing.T) {
+	limiter := NewHandlerLimiter(context.Background(), 1)
+	handler := corev2.FixtureHandler("ticketing")
+
+	// The executions are queued without waiting for the running one
+	release := make(chan struct{})
+	var running, maxRunning, executed int32
+	execute := func(context.Context) error {
+		n := atomic.AddInt32(&running, 1)
+		for {
+			max := atomic.LoadInt32(&maxRunning)
+			if n <= max || atomic.CompareAndSwapInt32(&maxRunning, max, n) {
+				break
+			}
+		}
+		<-release
+		atomic.AddInt32(&running, -1)
+		atomic.AddInt32(&executed, 1)
+		return nil
+	}
+	for i := 0; i < 3; i++ {
+		require.NoError(t, limiter.Execute(context.Background(), handler, execute))
+	}
+
+	// Other handlers have their own queue
+	other := corev2.FixtureHandler("slack")
+	otherDone := make(chan struct{})
+	require.NoError(t, limiter.Execute(context.Background(), other, func(context.Context) error {
+		close(otherDone)
+		return nil
+	}))
+	select {
+	case <-otherDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the other handler is stalled by the queue of the handler")
+	}
+
+	// The queued executions are executed one at a time
+	close(release)
+	limiter.Wait()
+	assert.Equal(t, int32(3), atomic.LoadInt32(&executed))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&maxRunning))
+}
+
+func TestHandlerLimiterMaxConcurrent(t *testing.T) {
+	limiter := NewHandlerLimiter(context.Background(), 1)
+	handler := corev2.FixtureHandler("ticketing")
+	handler.MaxConcurrent = 2
+
+	// Both executions run concurrently
+	release, started := make(chan struct{}), make(chan struct{}, 2)
+	for i := 0; i < 2; i++ {
+		require.NoError(t, limiter.Execute(context.Background(), handler, func(context.Context) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		}))
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the executions didn't run concurrently")
+		}
+	}
+	close(release)
+	limiter.Wait()
+}
+
+func TestHandlerLimiterQueueFull(t *testing.T) {
+	limiter := NewHandlerLimiter(context.Background(), 1)
+	handler := corev2.FixtureHandler("ticketing")
+
+	release := make(chan struct{})
+	block := func(context.Context) error {
+		<-release
+		return nil
+	}
+	// The running execution, and the queued ones
+	var err error
+	for i := 0; i <= handlerQueueSize+1 && err == nil; i++ {
+		err = limiter.Execute(context.Background(), handler, block)
+	}
+	assert.Equal(t, ErrHandlerQueueFull, err)
+
+	close(release)
+	limiter.Wait()
+}
+
+func TestHandlerLimiterUnlimited(t *testing.T) {
+	handler := corev2.FixtureHandler("ticketing")
+	for _, limiter := range []*HandlerLimiter{nil, NewHandlerLimiter(context.Background(), 0)} {
+		// The executions are executed right away, and their errors returned
+		var executed int
+		for i := 0; i < 10; i++ {
+			require.NoError(t, limiter.Execute(context.Background(), handler, func(context.Context) error {
+				executed++
+				return nil
+			}))
+		}
+		assert.Equal(t, 10, executed)
+
+		err := errors.New("error")
+		assert.Equal(t, err, limiter.Execute(context.Background(), handler, func(context.Context) error {
+			return err
+		}))
+	}
+}
@@ -0,0 +1,29 @@
+package pipeline
+
+import (
+	"context"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// isMuted returns true if the namespace of the event is muted, in which case
+// none of its handlers must be executed. Only internal store errors are
+// returned, the namespace is considered unmuted on other errors so that
+// notifications are not lost. The muting switches are served from the cache
+// of pipelined, so no store request is made per event.
+func (p *Pipeline) isMuted(ctx context.Context, event *corev2.Event) (bool, error) {
+	tctx, cancel := context.WithTimeout(ctx, p.storeTimeout)
+	defer cancel()
+
+	mute, err := p.store.GetNamespaceMute(tctx, event.Entity.Namespace)
+	if err != nil {
+		if _, ok := err.(*store.ErrInternal); ok {
+			return false, err
+		}
+		logger.WithError(err).Warn("could not retrieve the muting switch of the namespace")
+		return false, nil
+	}
+
+	return mute != nil && mute.Muted, nil
+}
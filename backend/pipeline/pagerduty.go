@@ -0,0 +1,166 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// Settings of pagerduty handlers, read from the handler environment variables
+// and secrets.
+const (
+	// PagerDutyRoutingKeySetting is the integration key of the PagerDuty
+	// service. It is required.
+	PagerDutyRoutingKeySetting = "PAGERDUTY_ROUTING_KEY"
+
+	// PagerDutySummaryTemplateSetting is the Go template of the alert summary,
+	// executed with the event. DefaultPagerDutySummaryTemplate is used if
+	// empty or if it fails to render.
+	PagerDutySummaryTemplateSetting = "PAGERDUTY_SUMMARY_TEMPLATE"
+)
+
+// DefaultPagerDutySummaryTemplate is the template of the alert summaries when
+// none is configured.
+const DefaultPagerDutySummaryTemplate = "{{ .Entity.Name }}/{{ .Check.Name }}: {{ .Check.Output }}"
+
+// pagerDutySummaryMaxLength is the maximum length of alert summaries accepted
+// by PagerDuty.
+const pagerDutySummaryMaxLength = 1024
+
+// PagerDutyEventsURL is the URL of the PagerDuty Events API v2.
+var PagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// pagerDutyEvent is a PagerDuty Events API v2 event.
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+	Links       []pagerDutyLink   `json:"links,omitempty"`
+}
+
+type pagerDutyLink struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string      `json:"summary"`
+	Source        string      `json:"source"`
+	Severity      string      `json:"severity"`
+	Component     string      `json:"component,omitempty"`
+	Group         string      `json:"group,omitempty"`
+	CustomDetails interface{} `json:"custom_details,omitempty"`
+}
+
+// pagerDutySeverity returns the PagerDuty severity of the given check status.
+func pagerDutySeverity(status uint32) string {
+	switch status {
+	case 1:
+		return "warning"
+	case 2:
+		return "critical"
+	default:
+		return "error"
+	}
+}
+
+// pagerDutyDedupKey returns the key deduplicating the alerts of the event, so
+// that all the occurrences of an incident are grouped and resolved together.
+func pagerDutyDedupKey(event *corev2.Event) string {
+	return fmt.Sprintf("%s/%s/%s", event.Entity.Namespace, event.Entity.Name, event.Check.Name)
+}
+
+// pagerdutyHandler triggers a PagerDuty alert for incidents, and resolves it
+// once the incident is resolved. Other events are ignored. Mutators don't
+// apply to pagerduty handlers, the alert is built from the event itself.
+func (p *Pipeline) pagerdutyHandler(handler *corev2.Handler, event *corev2.Event) error {
+	ctx := corev2.SetContextFromResource(context.Background(), handler)
+	fields := logrus.Fields{
+		"namespace":  handler.Namespace,
+		"handler":    handler.Name,
+		"event_uuid": event.GetUUID().String(),
+		"entity":     event.Entity.Name,
+	}
+	if event.HasCheck() {
+		fields["check"] = event.Check.Name
+	}
+
+	if !event.IsIncident() && !event.IsResolution() {
+		logger.WithFields(fields).Debug("event is neither an incident nor a resolution, not sending it to pagerduty")
+		return nil
+	}
+
+	settings, err := p.handlerSettings(ctx, handler)
+	if err != nil {
+		logger.WithFields(fields).WithError(err).Error("failed to retrieve secrets for handler")
+		return err
+	}
+
+	routingKey := settings[PagerDutyRoutingKeySetting]
+	if routingKey == "" {
+		return fmt.Errorf("pagerduty handler %q has no %s", handler.Name, PagerDutyRoutingKeySetting)
+	}
+
+	payload, err := json.Marshal(newPagerDutyEvent(event, routingKey, settings[PagerDutySummaryTemplateSetting]))
+	if err != nil {
+		return err
+	}
+
+	timeout := handler.Timeout
+	if timeout == 0 {
+		timeout = DefaultHTTPTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	if err := postJSON(ctx, p.httpClient, PagerDutyEventsURL, payload, nil); err != nil {
+		logger.WithFields(fields).WithError(err).Error("failed to execute event pagerduty handler")
+		return err
+	}
+
+	fields["event_action"] = pdEvent.EventAction
+	fields["dedup_key"] = pdEvent.DedupKey
+	logger.WithFields(fields).Info("event pagerduty handler executed")
+	return nil
+}
+
+// newPagerDutyEvent builds the PagerDuty event of an incident or a
+// resolution. The alerts of incidents link to the runbook and the other links
+// of the event.
+func newPagerDutyEvent(event *corev2.Event, routingKey, summaryTemplate string) *pagerDutyEvent {
+	pdEvent := &pagerDutyEvent{
+		RoutingKey:  routingKey,
+		EventAction: "resolve",
+		DedupKey:    pagerDutyDedupKey(event),
+	}
+	if !event.IsIncident() {
+		return pdEvent
+	}
+
+	summary := renderTemplate("pagerduty summary", summaryTemplate, DefaultPagerDutySummaryTemplate, event)
+	if len(summary) > pagerDutySummaryMaxLength {
+		summary = summary[:pagerDutySummaryMaxLength]
+	}
+
+	pdEvent.EventAction = "trigger"
+	pdEvent.Payload = &pagerDutyPayload{
+		Summary:       summary,
+		Source:        event.Entity.Name,
+		Severity:      pagerDutySeverity(event.Check.Status),
+		Component:     event.Check.Name,
+		Group:         event.Entity.Namespace,
+		CustomDetails: event,
+	}
+	if runbook := event.RunbookURL(); runbook != "" {
+		pdEvent.Links = append(pdEvent.Links, pagerDutyLink{Href: runbook, Text: "Runbook"})
+	}
+	for _, link := range event.Links() {
+		pdEvent.Links = append(pdEvent.Links, pagerDutyLink{Href: link.URL, Text: link.Name})
+	}
+	return pdEvent
+}
@@ -0,0 +1,93 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelinePagerDutyHandler(t *testing.T) {
+	var received []pagerDutyEvent
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var pdEvent pagerDutyEvent
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&pdEvent))
+		received = append(received, pdEvent)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	defaultURL := PagerDutyEventsURL
+	PagerDutyEventsURL = server.URL
+	defer func() { PagerDutyEventsURL = defaultURL }()
+
+	p := &Pipeline{secretsProviderManager: secrets.NewProviderManager()}
+
+	handler := corev2.FixtureHandler("pagerduty")
+	handler.Type = corev2.HandlerPagerDutyType
+	handler.EnvVars = []string{PagerDutyRoutingKeySetting + "=abc123"}
+
+	event := corev2.FixtureEvent("entity1", "check1")
+
+	// OK events that don't resolve an incident are ignored
+	event.Check.Status = 0
+	event.Check.History = []corev2.CheckHistory{{Status: 0}, {Status: 0}}
+	require.NoError(t, p.pagerdutyHandler(handler, event))
+	assert.Len(t, received, 0)
+
+	// Incidents trigger alerts
+	event.Check.Status = 2
+	event.Check.Output = "disk full"
+	event.Check.History = []corev2.CheckHistory{{Status: 0}, {Status: 2}}
+	require.NoError(t, p.pagerdutyHandler(handler, event))
+	require.Len(t, received, 1)
+	assert.Equal(t, "abc123", received[0].RoutingKey)
+	assert.Equal(t, "trigger", received[0].EventAction)
+	assert.Equal(t, "default/entity1/check1", received[0].DedupKey)
+	require.NotNil(t, received[0].Payload)
+	assert.Equal(t, "entity1/check1: disk full", received[0].Payload.Summary)
+	assert.Equal(t, "critical", received[0].Payload.Severity)
+	assert.Empty(t, received[0].Links)
+
+	// Resolutions resolve the alerts
+	event.Check.Status = 0
+	event.Check.History = []corev2.CheckHistory{{Status: 2}, {Status: 0}}
+	require.NoError(t, p.pagerdutyHandler(handler, event))
+	require.Len(t, received, 2)
+	assert.Equal(t, "resolve", received[1].EventAction)
+	assert.Equal(t, "default/entity1/check1", received[1].DedupKey)
+	assert.Nil(t, received[1].Payload)
+}
+
+func TestPipelinePagerDutyHandlerNoRoutingKey(t *testing.T) {
+	p := &Pipeline{secretsProviderManager: secrets.NewProviderManager()}
+
+	handler := corev2.FixtureHandler("pagerduty")
+	handler.Type = corev2.HandlerPagerDutyType
+
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Status = 1
+
+	assert.Error(t, p.pagerdutyHandler(handler, event))
+}
+
+func TestNewPagerDutyEventLinks(t *testing.T) {
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Status = 2
+	event.Check.History = []corev2.CheckHistory{{Status: 0}, {Status: 2}}
+	event.Check.Annotations = map[string]string{
+		corev2.RunbookURLAnnotation:               "https://wiki.example.com/runbooks/check1",
+		corev2.LinkAnnotationPrefix + "dashboard": "https://dashboard.example.com/entity1",
+	}
+
+	pdEvent := newPagerDutyEvent(event, "abc123", "")
+	assert.Equal(t, []pagerDutyLink{
+		{Href: "https://wiki.example.com/runbooks/check1", Text: "Runbook"},
+		{Href: "https://dashboard.example.com/entity1", Text: "dashboard"},
+	}, pdEvent.Links)
+}
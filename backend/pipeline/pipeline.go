@@ -1,6 +1,7 @@
 package pipeline
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/sensu/sensu-go/asset"
@@ -21,6 +22,11 @@ type Pipeline struct {
 	executor               command.Executor
 	storeTimeout           time.Duration
 	secretsProviderManager *secrets.ProviderManager
+	handlerSandbox         *command.Sandbox
+	httpClient             *http.Client
+	handlerLimiter         *HandlerLimiter
+	deduplicator           *Deduplicator
+	resultRecorder         *HandlerResultRecorder
 }
 
 // Config holds the configuration for a Pipeline.
@@ -30,6 +36,23 @@ type Config struct {
 	AssetGetter             asset.Getter
 	StoreTimeout            time.Duration
 	SecretsProviderManager  *secrets.ProviderManager
+	// HandlerSandbox restricts the privileges and resources of pipe handlers.
+	HandlerSandbox *command.Sandbox
+	// HTTPClient is the client of the handlers calling HTTP APIs. The default
+	// HTTP client is used if it's nil.
+	HTTPClient *http.Client
+	// HandlerLimiter limits the concurrent executions of the handlers. It
+	// should be shared by the pipelines; the handlers are unlimited if it's
+	// nil.
+	HandlerLimiter *HandlerLimiter
+	// Deduplicator remembers the events handled by the handlers using the
+	// deduplicate filter. It should be shared by the pipelines; the filter
+	// lets all the events through if it's nil.
+	Deduplicator *Deduplicator
+	// HandlerResultRecorder stores the results of the handler executions. It
+	// should be shared by the pipelines; the results are not stored if it's
+	// nil.
+	HandlerResultRecorder *HandlerResultRecorder
 }
 
 // Option is a functional option used to configure Pipelines.
@@ -44,6 +67,11 @@ func New(c Config, options ...Option) *Pipeline {
 		executor:               command.NewExecutor(),
 		storeTimeout:           c.StoreTimeout,
 		secretsProviderManager: c.SecretsProviderManager,
+		handlerSandbox:         c.HandlerSandbox,
+		httpClient:             c.HTTPClient,
+		handlerLimiter:         c.HandlerLimiter,
+		deduplicator:           c.Deduplicator,
+		resultRecorder:         c.HandlerResultRecorder,
 	}
 	for _, o := range options {
 		o(pipeline)
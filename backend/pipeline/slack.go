@@ -0,0 +1,159 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// Settings of slack handlers, read from the handler environment variables and
+// secrets, as with the sensu-slack-handler plugin.
+const (
+	// SlackWebhookURLSetting is the URL of the Slack incoming webhook. It is
+	// required.
+	SlackWebhookURLSetting = "SLACK_WEBHOOK_URL"
+
+	// SlackChannelSetting is the channel to post to. The default channel of
+	// the webhook is used if empty.
+	SlackChannelSetting = "SLACK_CHANNEL"
+
+	// SlackUsernameSetting is the username of the messages.
+	SlackUsernameSetting = "SLACK_USERNAME"
+
+	// SlackTemplateSetting is the Go template of the message text, executed
+	// with the event. DefaultSlackTemplate is used if empty or if it fails to
+	// render.
+	SlackTemplateSetting = "SLACK_TEMPLATE"
+)
+
+// DefaultSlackTemplate is the template of the slack messages when none is
+// configured.
+const DefaultSlackTemplate = "{{ .Entity.Name }}{{ with .Check }}/{{ .Name }}: {{ .State }}\n{{ .Output }}{{ end }}"
+
+// slackMessage is the payload of Slack incoming webhooks.
+type slackMessage struct {
+	Channel     string            `json:"channel,omitempty"`
+	Username    string            `json:"username,omitempty"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+type slackAttachment struct {
+	Fallback  string       `json:"fallback"`
+	Color     string       `json:"color"`
+	Title     string       `json:"title"`
+	TitleLink string       `json:"title_link,omitempty"`
+	Text      string       `json:"text"`
+	Fields    []slackField `json:"fields,omitempty"`
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+// slackColor returns the color of the message attachment for the given check
+// status.
+func slackColor(status uint32) string {
+	switch status {
+	case 0:
+		return "good"
+	case 1:
+		return "warning"
+	case 2:
+		return "danger"
+	default:
+		return "#808080"
+	}
+}
+
+// slackHandler posts a notification of the event to a Slack incoming webhook.
+// Mutators don't apply to slack handlers, the message is built from the event
+// itself.
+func (p *Pipeline) slackHandler(handler *corev2.Handler, event *corev2.Event) error {
+	ctx := corev2.SetContextFromResource(context.Background(), handler)
+	fields := logrus.Fields{
+		"namespace":  handler.Namespace,
+		"handler":    handler.Name,
+		"event_uuid": event.GetUUID().String(),
+		"entity":     event.Entity.Name,
+	}
+	if event.HasCheck() {
+		fields["check"] = event.Check.Name
+	}
+
+	settings, err := p.handlerSettings(ctx, handler)
+	if err != nil {
+		logger.WithFields(fields).WithError(err).Error("failed to retrieve secrets for handler")
+		return err
+	}
+
+	webhookURL := settings[SlackWebhookURLSetting]
+	if webhookURL == "" {
+		return fmt.Errorf("slack handler %q has no %s", handler.Name, SlackWebhookURLSetting)
+	}
+
+	payload, err := json.Marshal(newSlackMessage(event, settings))
+	if err != nil {
+		return err
+	}
+
+	timeout := handler.Timeout
+	if timeout == 0 {
+		timeout = DefaultHTTPTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	if err := postJSON(ctx, p.httpClient, webhookURL, payload, nil); err != nil {
+		logger.WithFields(fields).WithError(err).Error("failed to execute event slack handler")
+		return err
+	}
+
+	logger.WithFields(fields).Info("event slack handler executed")
+	return nil
+}
+
+// newSlackMessage builds the slack message of the event, with the text
+// rendered from the configured template. The title links to the runbook of the
+// event, which is listed with its other links as fields.
+func newSlackMessage(event *corev2.Event, settings map[string]string) *slackMessage {
+	text := renderTemplate("slack", settings[SlackTemplateSetting], DefaultSlackTemplate, event)
+
+	var title string
+	var status uint32
+	if event.HasCheck() {
+		title = fmt.Sprintf("%s/%s", event.Entity.Name, event.Check.Name)
+		status = event.Check.Status
+	} else {
+		title = event.Entity.Name
+	}
+
+	runbook := event.RunbookURL()
+	var fields []slackField
+	if runbook != "" {
+		fields = append(fields, slackField{Title: "Runbook", Value: runbook})
+	}
+	for _, link := range event.Links() {
+		fields = append(fields, slackField{Title: link.Name, Value: link.URL, Short: true})
+	}
+
+	return &slackMessage{
+		Channel:  settings[SlackChannelSetting],
+		Username: settings[SlackUsernameSetting],
+		Attachments: []slackAttachment{
+			{
+				Fallback:  text,
+				Color:     slackColor(status),
+				Title:     title,
+				TitleLink: runbook,
+				Text:      text,
+				Fields:    fields,
+			},
+		},
+	}
+}
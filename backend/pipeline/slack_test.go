@@ -0,0 +1,91 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/secrets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineSlackHandler(t *testing.T) {
+	var msg slackMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	p := &Pipeline{secretsProviderManager: secrets.NewProviderManager()}
+
+	handler := corev2.FixtureHandler("slack")
+	handler.Type = corev2.HandlerSlackType
+	handler.EnvVars = []string{
+		SlackWebhookURLSetting + "=" + server.URL,
+		SlackChannelSetting + "=#alerts",
+		SlackTemplateSetting + "={{ .Check.Name }} is {{ .Check.State }} on {{ .Entity.Name }}",
+	}
+
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Status = 2
+	event.Check.State = corev2.EventFailingState
+
+	require.NoError(t, p.slackHandler(handler, event))
+	assert.Equal(t, "#alerts", msg.Channel)
+	require.Len(t, msg.Attachments, 1)
+	assert.Equal(t, "danger", msg.Attachments[0].Color)
+	assert.Equal(t, "entity1/check1", msg.Attachments[0].Title)
+	assert.Equal(t, "check1 is failing on entity1", msg.Attachments[0].Text)
+	assert.Empty(t, msg.Attachments[0].TitleLink)
+	assert.Empty(t, msg.Attachments[0].Fields)
+}
+
+func TestNewSlackMessageLinks(t *testing.T) {
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Annotations = map[string]string{
+		corev2.RunbookURLAnnotation:               "https://wiki.example.com/runbooks/check1",
+		corev2.LinkAnnotationPrefix + "dashboard": "https://dashboard.example.com/entity1",
+	}
+
+	msg := newSlackMessage(event, map[string]string{})
+	require.Len(t, msg.Attachments, 1)
+	assert.Equal(t, "https://wiki.example.com/runbooks/check1", msg.Attachments[0].TitleLink)
+	assert.Equal(t, []slackField{
+		{Title: "Runbook", Value: "https://wiki.example.com/runbooks/check1"},
+		{Title: "dashboard", Value: "https://dashboard.example.com/entity1", Short: true},
+	}, msg.Attachments[0].Fields)
+}
+
+func TestPipelineSlackHandlerErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_token", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	p := &Pipeline{secretsProviderManager: secrets.NewProviderManager()}
+	event := corev2.FixtureEvent("entity1", "check1")
+
+	handler := corev2.FixtureHandler("slack")
+	handler.Type = corev2.HandlerSlackType
+	assert.Error(t, p.slackHandler(handler, event), "missing webhook URL")
+
+	handler.EnvVars = []string{SlackWebhookURLSetting + "=" + server.URL}
+	assert.Error(t, p.slackHandler(handler, event), "unsuccessful status")
+
+}
+
+func TestNewSlackMessageInvalidTemplate(t *testing.T) {
+	event := corev2.FixtureEvent("entity1", "check1")
+	want := newSlackMessage(event, map[string]string{})
+
+	// Templates that fail to render fall back to the default template
+	for _, text := range []string{"{{ .Check.Name", "{{ .Check.Nmae }}"} {
+		msg := newSlackMessage(event, map[string]string{SlackTemplateSetting: text})
+		assert.Equal(t, want, msg)
+	}
+}
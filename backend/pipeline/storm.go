@@ -0,0 +1,429 @@
+package pipeline
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// StormEntityName is the name of the proxy entity of the summary events
+	// of event storms.
+	StormEntityName = "sensu-event-storm"
+
+	// StormCheckName is the name of the check of the summary events of event
+	// storms.
+	StormCheckName = "event-storm"
+
+	// DefaultStormWindow is the default period over which event storms are
+	// detected.
+	DefaultStormWindow = time.Minute
+
+	// DefaultStormMinEntities is the default minimum number of entities
+	// running a check for the percentage of them failing it to be considered.
+	DefaultStormMinEntities = 10
+
+	// stormSummaryChecks is the number of checks listed in the output of the
+	// summary events, the ones with the most events.
+	stormSummaryChecks = 10
+)
+
+var (
+	// EventStorm is the number of namespaces in which an event storm pauses
+	// the handling of individual events.
+	EventStorm = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "sensu_go_event_storm",
+			Help: "Number of namespaces in which an event storm is pausing the handling of individual events",
+		},
+	)
+
+	// StormBatchedEvents counts the events batched into the summary events of
+	// event storms instead of being handled.
+	StormBatchedEvents = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "sensu_go_event_storm_batched_events_total",
+			Help: "Number of events batched into event storm summaries instead of being handled",
+		},
+	)
+)
+
+// StormConfig configures the detection of event storms. A storm is detected
+// when either threshold is exceeded; detection is disabled if both are 0.
+type StormConfig struct {
+	// MaxEventRate is the number of events per second of a namespace,
+	// averaged over the window, above which a storm is detected. The events
+	// are counted per backend, so it applies to the events each backend of a
+	// cluster receives.
+	MaxEventRate float64
+
+	// MaxFailingPercent is the percentage of the entities running a check
+	// that must be failing it for a storm to be detected. The entities count
+	// if they ran the check within the window.
+	MaxFailingPercent float64
+
+	// MinEntities is the minimum number of entities running a check for the
+	// failing percentage to apply, so that a check run by a few entities
+	// can't cause a storm.
+	MinEntities int
+
+	// Window is the period over which the events are counted, and the
+	// interval at which summary events are produced during a storm.
+	Window time.Duration
+}
+
+// StormDetector detects event storms and acts as a circuit breaker for them:
+// while a storm is in progress in a namespace, the events of checks of the
+// namespace are batched into summary events instead of being handled
+// individually. The events of the other namespaces are still handled. It is
+// shared by the pipelines of pipelined, and its state is kept per backend:
+// each backend of a cluster detects storms from the events it receives, and
+// produces its own summary events.
+type StormDetector struct {
+	config     StormConfig
+	mu         sync.Mutex
+	namespaces map[string]*namespaceStorm
+}
+
+// namespaceStorm is the state of the circuit breaker of a namespace. It is
+// forgotten once the namespace is idle for a window.
+type namespaceStorm struct {
+	rate    map[int64]int
+	results map[string]map[string]checkResult
+	engaged bool
+	reason  string
+	batch   *stormBatch
+}
+
+// checkResult is the last result of a check for an entity.
+type checkResult struct {
+	failing bool
+	time    time.Time
+}
+
+// stormBatch holds the events of a namespace batched during a storm.
+type stormBatch struct {
+	events   int
+	status   uint32
+	checks   map[string]int
+	entities map[string]struct{}
+	handlers map[string]struct{}
+}
+
+// NewStormDetector creates a new StormDetector.
+func NewStormDetector(config StormConfig) *StormDetector {
+	if config.Window <= 0 {
+		config.Window = DefaultStormWindow
+	}
+	if config.MinEntities <= 0 {
+		config.MinEntities = DefaultStormMinEntities
+	}
+	return &StormDetector{
+		config:     config,
+		namespaces: make(map[string]*namespaceStorm),
+	}
+}
+
+// Enabled returns true if the detector detects event storms.
+func (d *StormDetector) Enabled() bool {
+	return d != nil && (d.config.MaxEventRate > 0 || d.config.MaxFailingPercent > 0)
+}
+
+// Window returns the period over which event storms are detected.
+func (d *StormDetector) Window() time.Duration {
+	return d.config.Window
+}
+
+// Observe records an event received by pipelined, and returns true if the
+// event is batched because of a storm in its namespace, in which case it must
+// not be handled. Metrics-only events and resolutions are never batched, so
+// that the incidents batched during a storm are still resolved.
+func (d *StormDetector) Observe(event *corev2.Event, now time.Time) bool {
+	if !d.Enabled() {
+		return false
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	namespace := event.Entity.Namespace
+	ns, ok := d.namespaces[namespace]
+	if !ok {
+		ns = &namespaceStorm{
+			rate:    make(map[int64]int),
+			results: make(map[string]map[string]checkResult),
+		}
+		d.namespaces[namespace] = ns
+	}
+
+	// Only what the configured thresholds need is recorded
+	if d.config.MaxEventRate > 0 {
+		ns.rate[now.Unix()]++
+	}
+	if d.config.MaxFailingPercent > 0 && event.HasCheck() {
+		entities, ok := ns.results[event.Check.Name]
+		if !ok {
+			entities = make(map[string]checkResult)
+			ns.results[event.Check.Name] = entities
+		}
+		entities[event.Entity.Name] = checkResult{
+			failing: event.Check.Status != 0,
+			time:    now,
+		}
+	}
+
+	if !ns.engaged {
+		reason := d.detectRate(ns, now)
+		if reason == "" && event.HasCheck() {
+			reason = d.detectFailing(ns, event.Check.Name, now)
+		}
+		if reason == "" {
+			return false
+		}
+		ns.engaged, ns.reason = true, reason
+		EventStorm.Inc()
+		logger.WithFields(logrus.Fields{"namespace": namespace, "reason": reason}).Warn("event storm detected, batching events into summaries until it subsides")
+	}
+
+	if !event.HasCheck() || event.IsResolution() {
+		return false
+	}
+	ns.add(event)
+	StormBatchedEvents.Inc()
+	return true
+}
+
+// add adds the event to the batch of the namespace.
+func (ns *namespaceStorm) add(event *corev2.Event) {
+	b := ns.batch
+	if b == nil {
+		b = &stormBatch{
+			checks:   make(map[string]int),
+			entities: make(map[string]struct{}),
+			handlers: make(map[string]struct{}),
+		}
+		ns.batch = b
+	}
+	b.events++
+	b.checks[event.Check.Name]++
+	b.entities[event.Entity.Name] = struct{}{}
+	for _, handler := range eventHandlers(event) {
+		b.handlers[handler] = struct{}{}
+	}
+	if event.Check.Status > b.status {
+		b.status = event.Check.Status
+	}
+}
+
+// detectRate returns the reason of the storm if the event rate of the
+// namespace exceeds its threshold, or an empty string.
+func (d *StormDetector) detectRate(ns *namespaceStorm, now time.Time) string {
+	if d.config.MaxEventRate <= 0 {
+		return ""
+	}
+	since := now.Add(-d.config.Window).Unix()
+	var count int
+	for second, n := range ns.rate {
+		if second <= since {
+			delete(ns.rate, second)
+			continue
+		}
+		count += n
+	}
+	rate := float64(count) / d.config.Window.Seconds()
+	if rate <= d.config.MaxEventRate {
+		return ""
+	}
+	return fmt.Sprintf("%.1f events per second over the last %s", rate, d.config.Window)
+}
+
+// detectFailing returns the reason of the storm if too many entities are
+// failing the check, or an empty string.
+func (d *StormDetector) detectFailing(ns *namespaceStorm, check string, now time.Time) string {
+	if d.config.MaxFailingPercent <= 0 {
+		return ""
+	}
+	since := now.Add(-d.config.Window)
+	var total, failing int
+	for _, result := range ns.results[check] {
+		if result.time.Before(since) {
+			continue
+		}
+		total++
+		if result.failing {
+			failing++
+		}
+	}
+	if total < d.config.MinEntities {
+		return ""
+	}
+	percent := 100 * float64(failing) / float64(total)
+	if percent <= d.config.MaxFailingPercent {
+		return ""
+	}
+	return fmt.Sprintf("%.0f%% of %d entities failing check %s", percent, total, check)
+}
+
+// detectAnyFailing returns the reason of the storm if too many entities are
+// failing one of the checks of the namespace, or an empty string.
+func (d *StormDetector) detectAnyFailing(ns *namespaceStorm, now time.Time) string {
+	for check := range ns.results {
+		if reason := d.detectFailing(ns, check, now); reason != "" {
+			return reason
+		}
+	}
+	return ""
+}
+
+// prune forgets the event counts and the check results older than the
+// window, and the namespaces left without any.
+func (d *StormDetector) prune(now time.Time) {
+	since := now.Add(-d.config.Window)
+	for namespace, ns := range d.namespaces {
+		for second := range ns.rate {
+			if second <= since.Unix() {
+				delete(ns.rate, second)
+			}
+		}
+		for check, entities := range ns.results {
+			for entity, result := range entities {
+				if result.time.Before(since) {
+					delete(entities, entity)
+				}
+			}
+			if len(entities) == 0 {
+				delete(ns.results, check)
+			}
+		}
+		if !ns.engaged && len(ns.rate) == 0 && len(ns.results) == 0 {
+			delete(d.namespaces, namespace)
+		}
+	}
+}
+
+// Flush returns the summary events of the events batched since the last
+// flush, and disengages the circuit breakers of the namespaces where the storm
+// subsided, or all of them if final is true. It should be called once per
+// window.
+func (d *StormDetector) Flush(now time.Time, final bool) []*corev2.Event {
+	if !d.Enabled() {
+		return nil
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var events []*corev2.Event
+	for namespace, ns := range d.namespaces {
+		if !ns.engaged {
+			continue
+		}
+
+		subsided := final
+		if !subsided {
+			reason := d.detectRate(ns, now)
+			if reason == "" {
+				reason = d.detectAnyFailing(ns, now)
+			}
+			if reason == "" {
+				subsided = true
+			} else {
+				ns.reason = reason
+			}
+		}
+
+		if ns.batch != nil {
+			events = append(events, stormSummaryEvent(namespace, ns.batch, ns.reason, subsided, now))
+			ns.batch = nil
+		}
+
+		if subsided {
+			ns.engaged, ns.reason = false, ""
+			EventStorm.Dec()
+			logger.WithField("namespace", namespace).Warn("event storm subsided, resuming the handling of individual events")
+		}
+	}
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Entity.Namespace < events[j].Entity.Namespace
+	})
+
+	d.prune(now)
+	return events
+}
+
+// stormSummaryEvent creates the summary event of the events of a namespace
+// batched during a storm. It is handled by all the handlers of the batched
+// events.
+func stormSummaryEvent(namespace string, b *stormBatch, reason string, subsided bool, now time.Time) *corev2.Event {
+	handlers := make([]string, 0, len(b.handlers))
+	for handler := range b.handlers {
+		handlers = append(handlers, handler)
+	}
+	sort.Strings(handlers)
+
+	checks := make([]string, 0, len(b.checks))
+	for check := range b.checks {
+		checks = append(checks, check)
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		if b.checks[checks[i]] != b.checks[checks[j]] {
+			return b.checks[checks[i]] > b.checks[checks[j]]
+		}
+		return checks[i] < checks[j]
+	})
+
+	var output strings.Builder
+	if subsided {
+		fmt.Fprintf(&output, "Event storm subsided (%s).\n", reason)
+	} else {
+		fmt.Fprintf(&output, "Event storm in progress (%s).\n", reason)
+	}
+	fmt.Fprintf(&output, "%d events of %d checks on %d entities were not handled individually.\n", b.events, len(b.checks), len(b.entities))
+	for i, check := range checks {
+		if i == stormSummaryChecks {
+			fmt.Fprintf(&output, "... and %d more checks\n", len(checks)-i)
+			break
+		}
+		fmt.Fprintf(&output, "%s: %d events\n", check, b.checks[check])
+	}
+
+	// The storm itself deserves attention even if the batched events passed
+	status := b.status
+	if status == 0 {
+		status = 1
+	}
+
+	uid, _ := uuid.NewRandom()
+	return &corev2.Event{
+		ObjectMeta: corev2.ObjectMeta{
+			Namespace: namespace,
+		},
+		Timestamp: now.Unix(),
+		Entity: &corev2.Entity{
+			ObjectMeta: corev2.ObjectMeta{
+				Name:      StormEntityName,
+				Namespace: namespace,
+			},
+			EntityClass: corev2.EntityProxyClass,
+		},
+		Check: &corev2.Check{
+			ObjectMeta: corev2.ObjectMeta{
+				Name:      StormCheckName,
+				Namespace: namespace,
+			},
+			Handlers: handlers,
+			Status:   status,
+			Output:   output.String(),
+			Executed: now.Unix(),
+			Issued:   now.Unix(),
+		},
+		ID: uid[:],
+	}
+}
@@ -0,0 +1,142 @@
+package pipeline
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func stormEvent(entity, check string, status uint32) *corev2.Event {
+	event := corev2.FixtureEvent(entity, check)
+	event.Check.Status = status
+	event.Check.Handlers = []string{"slack"}
+	return event
+}
+
+func TestStormDetectorDisabled(t *testing.T) {
+	d := NewStormDetector(StormConfig{})
+	assert.False(t, d.Enabled())
+	assert.False(t, d.Observe(stormEvent("entity", "check", 2), time.Now()))
+	assert.Nil(t, d.Flush(time.Now(), true))
+
+	var nilDetector *StormDetector
+	assert.False(t, nilDetector.Observe(stormEvent("entity", "check", 2), time.Now()))
+}
+
+func TestStormDetectorEventRate(t *testing.T) {
+	d := NewStormDetector(StormConfig{MaxEventRate: 1, Window: 10 * time.Second})
+	now := time.Unix(1000, 0)
+
+	for i := 0; i < 10; i++ {
+		assert.False(t, d.Observe(stormEvent(fmt.Sprintf("entity%d", i), "check", 0), now))
+	}
+	// The 11th event exceeds 1 event per second over 10 seconds
+	assert.True(t, d.Observe(stormEvent("entity10", "check", 2), now))
+	assert.True(t, d.Observe(stormEvent("entity11", "disk", 1), now))
+
+	// Metrics are never batched
+	metrics := stormEvent("entity12", "check", 0)
+	metrics.Check = nil
+	metrics.Metrics = corev2.FixtureMetrics()
+	assert.False(t, d.Observe(metrics, now))
+
+	// Resolutions are never batched
+	resolution := stormEvent("entity12", "check", 0)
+	resolution.Check.History = append(resolution.Check.History, corev2.CheckHistory{Status: 2}, corev2.CheckHistory{Status: 0})
+	require.True(t, resolution.IsResolution())
+	assert.False(t, d.Observe(resolution, now))
+
+	// The storm is still in progress at the end of the window
+	events := d.Flush(now.Add(5*time.Second), false)
+	require.Len(t, events, 1)
+	summary := events[0]
+	assert.Equal(t, StormEntityName, summary.Entity.Name)
+	assert.Equal(t, "default", summary.Entity.Namespace)
+	assert.Equal(t, StormCheckName, summary.Check.Name)
+	assert.Equal(t, uint32(2), summary.Check.Status)
+	assert.Equal(t, []string{"slack"}, summary.Check.Handlers)
+	assert.Contains(t, summary.Check.Output, "Event storm in progress")
+	assert.Contains(t, summary.Check.Output, "2 events of 2 checks on 2 entities")
+	assert.NoError(t, summary.Validate())
+
+	// The storm subsides once the events are out of the window
+	assert.True(t, d.Observe(stormEvent("entity13", "check", 0), now.Add(5*time.Second)))
+	events = d.Flush(now.Add(20*time.Second), false)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0].Check.Output, "Event storm subsided")
+	assert.Equal(t, uint32(1), events[0].Check.Status)
+
+	assert.False(t, d.Observe(stormEvent("entity14", "check", 2), now.Add(20*time.Second)))
+	assert.Empty(t, d.Flush(now.Add(30*time.Second), false))
+}
+
+func TestStormDetectorFailingEntities(t *testing.T) {
+	d := NewStormDetector(StormConfig{MaxFailingPercent: 50, MinEntities: 4, Window: time.Minute})
+	now := time.Unix(1000, 0)
+
+	// Too few entities run the check for the percentage to apply
+	assert.False(t, d.Observe(stormEvent("entity0", "check", 2), now))
+	assert.False(t, d.Observe(stormEvent("entity1", "check", 2), now))
+	assert.False(t, d.Observe(stormEvent("entity2", "check", 0), now))
+
+	// 3 of the 4 entities are failing the check
+	assert.True(t, d.Observe(stormEvent("entity3", "check", 2), now))
+
+	// The events of the other checks are batched as well
+	assert.True(t, d.Observe(stormEvent("entity0", "other", 0), now))
+
+	// A final flush disengages the circuit breaker
+	events := d.Flush(now, true)
+	require.Len(t, events, 1)
+	assert.Contains(t, events[0].Check.Output, "Event storm subsided (75% of 4 entities failing check check)")
+	assert.False(t, d.Observe(stormEvent("entity4", "other", 0), now.Add(2*time.Minute)))
+}
+
+func TestStormDetectorNamespaces(t *testing.T) {
+	d := NewStormDetector(StormConfig{MaxEventRate: 1, Window: 10 * time.Second})
+	now := time.Unix(1000, 0)
+
+	for i := 0; i < 11; i++ {
+		d.Observe(stormEvent(fmt.Sprintf("entity%d", i), "check", 2), now)
+	}
+	assert.True(t, d.Observe(stormEvent("entity11", "check", 2), now))
+
+	// A storm in a namespace doesn't pause the handling of the events of the
+	// other namespaces
+	event := stormEvent("entity0", "check", 2)
+	event.Entity.Namespace = "acme"
+	event.Check.Namespace = "acme"
+	assert.False(t, d.Observe(event, now))
+
+	events := d.Flush(now.Add(time.Second), false)
+	require.Len(t, events, 1)
+	assert.Equal(t, "default", events[0].Entity.Namespace)
+}
+
+func TestStormDetectorPrune(t *testing.T) {
+	tests := []struct {
+		name   string
+		config StormConfig
+	}{
+		{name: "event rate", config: StormConfig{MaxEventRate: 100, Window: 10 * time.Second}},
+		{name: "failing entities", config: StormConfig{MaxFailingPercent: 50, Window: 10 * time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewStormDetector(tt.config)
+			now := time.Unix(1000, 0)
+			for i := 0; i < 10; i++ {
+				assert.False(t, d.Observe(stormEvent(fmt.Sprintf("entity%d", i), "check", 0), now.Add(time.Duration(i)*time.Second)))
+			}
+			assert.Len(t, d.namespaces, 1)
+
+			// The idle namespaces are forgotten after a window
+			assert.Empty(t, d.Flush(now.Add(time.Minute), false))
+			assert.Empty(t, d.namespaces)
+		})
+	}
+}
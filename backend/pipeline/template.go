@@ -0,0 +1,148 @@
+package pipeline
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"text/template"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/util/environment"
+)
+
+const (
+	// HandlerTemplateSetting is the Go template of the message of pipe
+	// handlers, read from the handler environment variables and secrets. The
+	// rendered message is passed to the handler command in the
+	// HandlerMessageEnvVar environment variable.
+	HandlerTemplateSetting = "SENSU_HANDLER_TEMPLATE"
+
+	// HandlerMessageEnvVar is the environment variable of pipe handlers which
+	// holds the message rendered from HandlerTemplateSetting.
+	HandlerMessageEnvVar = "SENSU_HANDLER_MESSAGE"
+
+	// DefaultHandlerMessageTemplate is the template of the message of pipe
+	// handlers whose template fails to render.
+	DefaultHandlerMessageTemplate = "{{ .Entity.Name }}{{ with .Check }}/{{ .Name }}: {{ .State }}\n{{ .Output }}{{ end }}"
+)
+
+// templateFuncs are the functions available in the templates of the handlers
+// implemented in the backend, and of the messages of pipe handlers.
+var templateFuncs = template.FuncMap{
+	// json encodes a value as JSON, e.g. to embed strings in JSON documents
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	// status returns the name of a check status, e.g. CRITICAL for 2
+	"status": statusName,
+	// duration humanizes a number of seconds, e.g. 1h2m0s for 3720
+	"duration": humanizeDuration,
+	// timestamp formats a Unix timestamp as RFC 3339, e.g. .Check.Executed
+	"timestamp": func(timestamp int64) string {
+		return time.Unix(timestamp, 0).UTC().Format(time.RFC3339)
+	},
+	// since humanizes the time elapsed since a Unix timestamp, e.g.
+	// .Check.LastOK
+	"since": func(timestamp int64) string {
+		return time.Since(time.Unix(timestamp, 0)).Round(time.Second).String()
+	},
+}
+
+// statusName returns the name of a check status.
+func statusName(status uint32) string {
+	switch status {
+	case 0:
+		return "OK"
+	case 1:
+		return "WARNING"
+	case 2:
+		return "CRITICAL"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// humanizeDuration humanizes a number of seconds of any numeric type, such as
+// the uint32 .Check.Interval or the float64 .Check.Duration.
+func humanizeDuration(seconds interface{}) (string, error) {
+	var s float64
+	switch v := seconds.(type) {
+	case int:
+		s = float64(v)
+	case int32:
+		s = float64(v)
+	case int64:
+		s = float64(v)
+	case uint:
+		s = float64(v)
+	case uint32:
+		s = float64(v)
+	case uint64:
+		s = float64(v)
+	case float32:
+		s = float64(v)
+	case float64:
+		s = v
+	default:
+		return "", fmt.Errorf("duration: %v is not a number of seconds", seconds)
+	}
+	d := time.Duration(math.Round(s)) * time.Second
+	return d.String(), nil
+}
+
+// executeTemplate executes the Go template text, which formats the messages of
+// the handlers implemented in the backend, with the event.
+func executeTemplate(name, text string, event *corev2.Event) (string, error) {
+	tmpl, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid %s template: %s", name, err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, event); err != nil {
+		return "", fmt.Errorf("couldn't execute %s template: %s", name, err)
+	}
+	return buf.String(), nil
+}
+
+// renderTemplate renders a message of a handler with the event, from the
+// configured template text or from defaultText if none is configured. A
+// template that fails to render, e.g. because of a typo in a field name, falls
+// back to defaultText so that the event is still notified, and the error is
+// logged for the template to be fixed.
+func renderTemplate(name, text, defaultText string, event *corev2.Event) string {
+	if text != "" {
+		message, err := executeTemplate(name, text, event)
+		if err == nil {
+			return message
+		}
+		logger.WithField("event_uuid", event.GetUUID().String()).WithError(err).Error("falling back to the default template")
+	}
+	message, err := executeTemplate(name, defaultText, event)
+	if err != nil {
+		logger.WithField("event_uuid", event.GetUUID().String()).WithError(err).Error("couldn't render the default template")
+	}
+	return message
+}
+
+// handlerMessage renders the message of a pipe handler from the template
+// configured in its environment variables or secrets. It returns false if the
+// handler has no template. DefaultHandlerMessageTemplate is rendered instead
+// if the template fails to render.
+func handlerMessage(envVars, secrets []string, event *corev2.Event) (string, bool) {
+	var text string
+	found := false
+	for _, env := range environment.MergeEnvironments(nil, envVars, secrets) {
+		kv := strings.SplitN(env, "=", 2)
+		if len(kv) == 2 && kv[0] == HandlerTemplateSetting {
+			text, found = kv[1], true
+		}
+	}
+	if !found {
+		return "", false
+	}
+	return renderTemplate("handler message", text, DefaultHandlerMessageTemplate, event), true
+}
@@ -0,0 +1,88 @@
+package pipeline
+
+import (
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteTemplateHelpers(t *testing.T) {
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Status = 2
+	event.Check.Interval = 3720
+	event.Check.Duration = 1.6
+	event.Check.Executed = 1577836800
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "status name",
+			template: "{{ status .Check.Status }}",
+			want:     "CRITICAL",
+		},
+		{
+			name:     "integer duration",
+			template: "{{ duration .Check.Interval }}",
+			want:     "1h2m0s",
+		},
+		{
+			name:     "float duration",
+			template: "{{ duration .Check.Duration }}",
+			want:     "2s",
+		},
+		{
+			name:     "invalid duration",
+			template: "{{ duration .Check.Name }}",
+			wantErr:  true,
+		},
+		{
+			name:     "timestamp",
+			template: "{{ timestamp .Check.Executed }}",
+			want:     "2020-01-01T00:00:00Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := executeTemplate("test", tt.template, event)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHandlerMessage(t *testing.T) {
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Status = 1
+
+	_, ok := handlerMessage([]string{"FOO=bar"}, nil, event)
+	assert.False(t, ok)
+
+	envVars := []string{HandlerTemplateSetting + "={{ .Entity.Name }}: {{ status .Check.Status }}"}
+	message, ok := handlerMessage(envVars, nil, event)
+	assert.True(t, ok)
+	assert.Equal(t, "entity1: WARNING", message)
+
+	// Secrets take precedence over environment variables
+	secrets := []string{HandlerTemplateSetting + "={{ .Check.Name }}"}
+	message, _ = handlerMessage(envVars, secrets, event)
+	assert.Equal(t, "check1", message)
+
+	// Templates that fail to render fall back to the default message
+	defaultMessage, err := executeTemplate("test", DefaultHandlerMessageTemplate, event)
+	require.NoError(t, err)
+	for _, text := range []string{"{{ .Check.Name ", "{{ .Check.Nmae }}"} {
+		message, ok = handlerMessage([]string{HandlerTemplateSetting + "=" + text}, nil, event)
+		assert.True(t, ok)
+		assert.Equal(t, defaultMessage, message)
+	}
+}
@@ -0,0 +1,177 @@
+package pipeline
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// Settings of webhook handlers, read from the handler environment variables
+// and secrets.
+const (
+	// WebhookURLSetting is the URL the payloads are posted to. It is required.
+	WebhookURLSetting = "WEBHOOK_URL"
+
+	// WebhookTemplateSetting is the Go template of the JSON payload, executed
+	// with the event. The event itself is posted if empty, or if the template
+	// fails to render a valid JSON document.
+	WebhookTemplateSetting = "WEBHOOK_TEMPLATE"
+
+	// WebhookHeadersSetting is the comma-delimited list of additional headers
+	// of the requests, as Name=value pairs.
+	WebhookHeadersSetting = "WEBHOOK_HEADERS"
+
+	// WebhookRetriesSetting is the number of times failed requests are
+	// retried. DefaultWebhookRetries is used if empty.
+	WebhookRetriesSetting = "WEBHOOK_RETRIES"
+
+	// WebhookHMACSecretSetting is the key used to sign the payloads. Payloads
+	// are not signed if empty.
+	WebhookHMACSecretSetting = "WEBHOOK_HMAC_SECRET"
+)
+
+const (
+	// DefaultWebhookRetries specifies the default number of times failed
+	// webhook requests are retried.
+	DefaultWebhookRetries = 2
+
+	// WebhookSignatureHeader is the header containing the HMAC-SHA256
+	// signature of the payload, as sha256=<hex digest>.
+	WebhookSignatureHeader = "X-Sensu-Signature"
+)
+
+// webhookRetryInterval is the time to wait before the first retry. It doubles
+// after every attempt, and the retries stop once the context of the handler is
+// done.
+var webhookRetryInterval = time.Second
+
+// webhookHandler posts the event, or the payload rendered from the configured
+// template, to a URL. Mutators don't apply to webhook handlers, the payload is
+// built from the event itself.
+func (p *Pipeline) webhookHandler(ctx context.Context, handler *corev2.Handler, event *corev2.Event) error {
+	ctx = corev2.SetContextFromResource(ctx, handler)
+	fields := logrus.Fields{
+		"namespace":  handler.Namespace,
+		"handler":    handler.Name,
+		"event_uuid": event.GetUUID().String(),
+		"entity":     event.Entity.Name,
+	}
+	if event.HasCheck() {
+		fields["check"] = event.Check.Name
+	}
+
+	settings, err := p.handlerSettings(ctx, handler)
+	if err != nil {
+		logger.WithFields(fields).WithError(err).Error("failed to retrieve secrets for handler")
+		return err
+	}
+
+	url := settings[WebhookURLSetting]
+	if url == "" {
+		return fmt.Errorf("webhook handler %q has no %s", handler.Name, WebhookURLSetting)
+	}
+	retries := DefaultWebhookRetries
+	if s := settings[WebhookRetriesSetting]; s != "" {
+		retries, err = strconv.Atoi(s)
+		if err != nil || retries < 0 {
+			return fmt.Errorf("webhook handler %q has an invalid %s: %q", handler.Name, WebhookRetriesSetting, s)
+		}
+	}
+
+	payload, err := newWebhookPayload(event, settings[WebhookTemplateSetting])
+	if err != nil {
+		return err
+	}
+	header := webhookHeader(settings[WebhookHeadersSetting])
+	if secret := settings[WebhookHMACSecretSetting]; secret != "" {
+		header.Set(WebhookSignatureHeader, webhookSignature(secret, payload))
+	}
+
+	timeout := handler.Timeout
+	if timeout == 0 {
+		timeout = DefaultHTTPTimeout
+	}
+
+	interval := webhookRetryInterval
+retry:
+	for attempt := 0; ; attempt++ {
+		tctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+		err = postJSON(tctx, p.httpClient, url, payload, header)
+		cancel()
+		if err == nil || attempt >= retries || !retryable(err) {
+			break
+		}
+		logger.WithFields(fields).WithError(err).Warn("webhook request failed, retrying")
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			break retry
+		case <-timer.C:
+		}
+		interval *= 2
+	}
+	if err != nil {
+		logger.WithFields(fields).WithError(err).Error("failed to execute event webhook handler")
+		return err
+	}
+
+	logger.WithFields(fields).Info("event webhook handler executed")
+	return nil
+}
+
+// newWebhookPayload returns the payload of the event, i.e. the JSON document
+// rendered from the template, or the event itself if there is no template or
+// if it fails to render a valid JSON document.
+func newWebhookPayload(event *corev2.Event, tmpl string) ([]byte, error) {
+	if tmpl != "" {
+		payload, err := executeTemplate("webhook", tmpl, event)
+		if err == nil && !json.Valid([]byte(payload)) {
+			err = fmt.Errorf("webhook template did not produce valid JSON: %s", payload)
+		}
+		if err == nil {
+			return []byte(payload), nil
+		}
+		logger.WithField("event_uuid", event.GetUUID().String()).WithError(err).Error("falling back to the event as the webhook payload")
+	}
+	return json.Marshal(event)
+}
+
+// webhookHeader parses a comma-delimited list of Name=value headers.
+func webhookHeader(headers string) http.Header {
+	header := http.Header{}
+	for _, h := range strings.Split(headers, ",") {
+		kv := strings.SplitN(h, "=", 2)
+		if len(kv) != 2 || strings.TrimSpace(kv[0]) == "" {
+			continue
+		}
+		header.Set(strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1]))
+	}
+	return header
+}
+
+// webhookSignature returns the HMAC-SHA256 signature of the payload.
+func webhookSignature(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// retryable returns true if the request that failed with err can be retried,
+// i.e. if it failed because of a network or a server error, or rate limiting.
+func retryable(err error) bool {
+	if statusErr, ok := err.(*httpStatusError); ok {
+		return statusErr.StatusCode >= 500 || statusErr.StatusCode == http.StatusTooManyRequests
+	}
+	return true
+}
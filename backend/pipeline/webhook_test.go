@@ -0,0 +1,144 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/secrets"
+	"github.com/sensu/sensu-go/util/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipelineWebhookHandler(t *testing.T) {
+	defaultInterval := webhookRetryInterval
+	webhookRetryInterval = time.Millisecond
+	defer func() { webhookRetryInterval = defaultInterval }()
+
+	var requests int
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		body, _ = ioutil.ReadAll(r.Body)
+		header = r.Header
+	}))
+	defer server.Close()
+
+	p := &Pipeline{secretsProviderManager: secrets.NewProviderManager()}
+
+	handler := corev2.FixtureHandler("webhook")
+	handler.Type = corev2.HandlerWebhookType
+	handler.EnvVars = []string{
+		WebhookURLSetting + "=" + server.URL,
+		WebhookTemplateSetting + `={"entity": {{ json .Entity.Name }}, "output": {{ json .Check.Output }}}`,
+		WebhookHeadersSetting + "=X-Team=ops, X-Env=prod",
+		WebhookHMACSecretSetting + "=s3cr3t",
+	}
+
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Output = "\"quoted\" output"
+
+	require.NoError(t, p.webhookHandler(context.Background(), handler, event))
+	assert.Equal(t, 2, requests)
+	assert.JSONEq(t, `{"entity": "entity1", "output": "\"quoted\" output"}`, string(body))
+	assert.Equal(t, "ops", header.Get("X-Team"))
+	assert.Equal(t, "prod", header.Get("X-Env"))
+	assert.Equal(t, webhookSignature("s3cr3t", body), header.Get(WebhookSignatureHeader))
+}
+
+func TestPipelineWebhookHandlerProxy(t *testing.T) {
+	var host string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.URL.Host
+	}))
+	defer proxy.Close()
+
+	client, err := httpclient.Config{ProxyURL: proxy.URL}.NewClient(0)
+	require.NoError(t, err)
+	p := &Pipeline{secretsProviderManager: secrets.NewProviderManager(), httpClient: client}
+
+	handler := corev2.FixtureHandler("webhook")
+	handler.Type = corev2.HandlerWebhookType
+	handler.EnvVars = []string{WebhookURLSetting + "=http://hooks.example.com/sensu"}
+
+	require.NoError(t, p.webhookHandler(context.Background(), handler, corev2.FixtureEvent("entity1", "check1")))
+	assert.Equal(t, "hooks.example.com", host)
+}
+
+func TestPipelineWebhookHandlerErrors(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	p := &Pipeline{secretsProviderManager: secrets.NewProviderManager()}
+	event := corev2.FixtureEvent("entity1", "check1")
+
+	handler := corev2.FixtureHandler("webhook")
+	handler.Type = corev2.HandlerWebhookType
+	assert.Error(t, p.webhookHandler(context.Background(), handler, event), "missing URL")
+
+	handler.EnvVars = []string{WebhookURLSetting + "=" + server.URL}
+	assert.Error(t, p.webhookHandler(context.Background(), handler, event))
+	assert.Equal(t, 1, requests, "client errors are not retried")
+}
+
+func TestPipelineWebhookHandlerCancelled(t *testing.T) {
+	defaultInterval := webhookRetryInterval
+	webhookRetryInterval = time.Hour
+	defer func() { webhookRetryInterval = defaultInterval }()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		time.AfterFunc(10*time.Millisecond, cancel)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	p := &Pipeline{secretsProviderManager: secrets.NewProviderManager()}
+	handler := corev2.FixtureHandler("webhook")
+	handler.Type = corev2.HandlerWebhookType
+	handler.EnvVars = []string{WebhookURLSetting + "=" + server.URL}
+
+	// The retries stop once the context is done, without waiting
+	done := make(chan error, 1)
+	go func() {
+		done <- p.webhookHandler(ctx, handler, corev2.FixtureEvent("entity1", "check1"))
+	}()
+	select {
+	case err := <-done:
+		assert.Error(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the handler is still waiting to retry")
+	}
+	assert.Equal(t, 1, requests)
+}
+
+func TestNewWebhookPayloadInvalidTemplate(t *testing.T) {
+	event := corev2.FixtureEvent("entity1", "check1")
+	want, err := json.Marshal(event)
+	require.NoError(t, err)
+
+	// Templates that fail to render a JSON document fall back to the event
+	for _, tmpl := range []string{"{{ .Check.Name }}", "{{ .Check.Name", `{"check": {{ json .Check.Nmae }}}`} {
+		payload, err := newWebhookPayload(event, tmpl)
+		require.NoError(t, err)
+		assert.Equal(t, want, payload)
+	}
+}
@@ -0,0 +1,254 @@
+package pipelined
+
+import (
+	"context"
+	"path"
+	"sync"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// cachedStore serves the handlers, event filters, mutators and namespace
+// muting switches of the pipelines from caches kept up to date by watching the
+// store, rather than reading them from the store for every event. The other
+// resources are read from the store.
+type cachedStore struct {
+	store.Store
+	handlers *resourceCache
+	filters  *resourceCache
+	mutators *resourceCache
+	mutes    *muteCache
+}
+
+// newCachedStore fills the caches of the store s, which are updated until ctx
+// is cancelled. The resources are listed within the given timeout.
+func newCachedStore(ctx context.Context, s store.Store, timeout time.Duration) (*cachedStore, error) {
+	handlers, err := newResourceCache(ctx, s, &corev2.Handler{}, timeout, func(ctx context.Context) ([]corev2.Resource, error) {
+		handlers, err := s.GetHandlers(ctx, &store.SelectionPredicate{})
+		resources := make([]corev2.Resource, len(handlers))
+		for i := range handlers {
+			resources[i] = handlers[i]
+		}
+		return resources, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	filters, err := newResourceCache(ctx, s, &corev2.EventFilter{}, timeout, func(ctx context.Context) ([]corev2.Resource, error) {
+		filters, err := s.GetEventFilters(ctx, &store.SelectionPredicate{})
+		resources := make([]corev2.Resource, len(filters))
+		for i := range filters {
+			resources[i] = filters[i]
+		}
+		return resources, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mutators, err := newResourceCache(ctx, s, &corev2.Mutator{}, timeout, func(ctx context.Context) ([]corev2.Resource, error) {
+		mutators, err := s.GetMutators(ctx, &store.SelectionPredicate{})
+		resources := make([]corev2.Resource, len(mutators))
+		for i := range mutators {
+			resources[i] = mutators[i]
+		}
+		return resources, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	mutes, err := newMuteCache(ctx, s, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cachedStore{
+		Store:    s,
+		handlers: handlers,
+		filters:  filters,
+		mutators: mutators,
+		mutes:    mutes,
+	}, nil
+}
+
+// GetHandlerByName gets a cached handler by name, within the namespace stored
+// in ctx. The handler is nil if none was found.
+func (s *cachedStore) GetHandlerByName(ctx context.Context, name string) (*corev2.Handler, error) {
+	handler, _ := s.handlers.get(corev2.ContextNamespace(ctx), name).(*corev2.Handler)
+	return handler, nil
+}
+
+// GetEventFilterByName gets a cached event filter by name, within the
+// namespace stored in ctx. The filter is nil if none was found.
+func (s *cachedStore) GetEventFilterByName(ctx context.Context, name string) (*corev2.EventFilter, error) {
+	filter, _ := s.filters.get(corev2.ContextNamespace(ctx), name).(*corev2.EventFilter)
+	return filter, nil
+}
+
+// GetMutatorByName gets a cached mutator by name, within the namespace stored
+// in ctx. The mutator is nil if none was found.
+func (s *cachedStore) GetMutatorByName(ctx context.Context, name string) (*corev2.Mutator, error) {
+	mutator, _ := s.mutators.get(corev2.ContextNamespace(ctx), name).(*corev2.Mutator)
+	return mutator, nil
+}
+
+// GetNamespaceMute gets the cached muting switch of a namespace. The result is
+// nil if the namespace is not muted.
+func (s *cachedStore) GetNamespaceMute(ctx context.Context, namespace string) (*corev2.NamespaceMute, error) {
+	return s.mutes.get(namespace), nil
+}
+
+// resourceCache caches the resources of a type, of all the namespaces, by
+// namespace and name.
+type resourceCache struct {
+	mu        sync.RWMutex
+	resources map[string]corev2.Resource
+	list      func(context.Context) ([]corev2.Resource, error)
+	timeout   time.Duration
+}
+
+// newResourceCache fills a cache of the resources of the type of resource,
+// listed by list, and watches their changes until ctx is cancelled.
+func newResourceCache(ctx context.Context, s store.Store, resource corev2.Resource, timeout time.Duration, list func(context.Context) ([]corev2.Resource, error)) (*resourceCache, error) {
+	// The watcher is created first so the changes made while the resources
+	// are listed are not missed
+	watcher := s.WatchResources(ctx, resource)
+
+	cache := &resourceCache{list: list, timeout: timeout}
+	if err := cache.load(ctx); err != nil {
+		return nil, err
+	}
+	go cache.watch(ctx, watcher)
+
+	return cache, nil
+}
+
+func resourceCacheKey(namespace, name string) string {
+	return path.Join(namespace, name)
+}
+
+// load replaces the cached resources with the resources of the store.
+func (c *resourceCache) load(ctx context.Context) error {
+	tctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	resources, err := c.list(tctx)
+	if err != nil {
+		return err
+	}
+
+	cached := make(map[string]corev2.Resource, len(resources))
+	for _, resource := range resources {
+		meta := resource.GetObjectMeta()
+		cached[resourceCacheKey(meta.Namespace, meta.Name)] = resource
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.resources = cached
+	return nil
+}
+
+func (c *resourceCache) watch(ctx context.Context, watcher <-chan store.WatchEventResource) {
+	for event := range watcher {
+		if event.Action == store.WatchError {
+			// Changes were missed, so all the resources are loaded again
+			if err := c.load(ctx); err != nil {
+				logger.WithError(err).Error("unable to reload the cached resources")
+			}
+			continue
+		}
+
+		meta := event.Resource.GetObjectMeta()
+		key := resourceCacheKey(meta.Namespace, meta.Name)
+		c.mu.Lock()
+		if event.Action == store.WatchDelete {
+			delete(c.resources, key)
+		} else {
+			c.resources[key] = event.Resource
+		}
+		c.mu.Unlock()
+	}
+}
+
+// get returns the cached resource with the given namespace and name, or nil if
+// there is none.
+func (c *resourceCache) get(namespace, name string) corev2.Resource {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.resources[resourceCacheKey(namespace, name)]
+}
+
+// muteCache caches the muting switches of the muted namespaces, by namespace.
+type muteCache struct {
+	mu      sync.RWMutex
+	mutes   map[string]*corev2.NamespaceMute
+	store   store.NamespaceMuteStore
+	timeout time.Duration
+}
+
+// newMuteCache fills a cache of the muting switches of the namespaces, and
+// watches their changes until ctx is cancelled.
+func newMuteCache(ctx context.Context, s store.NamespaceMuteStore, timeout time.Duration) (*muteCache, error) {
+	// The watcher is created first so the changes made while the muting
+	// switches are listed are not missed
+	watcher := s.GetNamespaceMuteWatcher(ctx)
+
+	cache := &muteCache{store: s, timeout: timeout}
+	if err := cache.load(ctx); err != nil {
+		return nil, err
+	}
+	go cache.watch(ctx, watcher)
+
+	return cache, nil
+}
+
+// load replaces the cached muting switches with the ones of the store.
+func (c *muteCache) load(ctx context.Context) error {
+	tctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	mutes, err := c.store.GetNamespaceMutes(tctx)
+	if err != nil {
+		return err
+	}
+	if mutes == nil {
+		mutes = make(map[string]*corev2.NamespaceMute)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mutes = mutes
+	return nil
+}
+
+func (c *muteCache) watch(ctx context.Context, watcher <-chan store.WatchEventNamespaceMute) {
+	for event := range watcher {
+		if event.Action == store.WatchError {
+			// Changes were missed, so all the muting switches are loaded
+			// again
+			if err := c.load(ctx); err != nil {
+				logger.WithError(err).Error("unable to reload the cached namespace muting switches")
+			}
+			continue
+		}
+
+		c.mu.Lock()
+		if event.NamespaceMute.Muted {
+			c.mutes[event.Namespace] = event.NamespaceMute
+		} else {
+			delete(c.mutes, event.Namespace)
+		}
+		c.mu.Unlock()
+	}
+}
+
+// get returns the cached muting switch of the namespace, or nil if the
+// namespace is not muted.
+func (c *muteCache) get(namespace string) *corev2.NamespaceMute {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.mutes[namespace]
+}
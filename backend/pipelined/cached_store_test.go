@@ -0,0 +1,104 @@
+package pipelined
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/testing/mockstore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockCachedStore mocks the store calls of the cached store, without any
+// handlers, filters, mutators or muted namespaces.
+func mockCachedStore(s *mockstore.MockStore) {
+	s.On("WatchResources", mock.Anything, mock.Anything).Return((<-chan store.WatchEventResource)(make(chan store.WatchEventResource)))
+	s.On("GetHandlers", mock.Anything, mock.Anything).Return([]*corev2.Handler{}, nil)
+	s.On("GetEventFilters", mock.Anything, mock.Anything).Return([]*corev2.EventFilter{}, nil)
+	s.On("GetMutators", mock.Anything, mock.Anything).Return([]*corev2.Mutator{}, nil)
+	s.On("GetNamespaceMuteWatcher", mock.Anything).Return((<-chan store.WatchEventNamespaceMute)(make(chan store.WatchEventNamespaceMute)))
+	s.On("GetNamespaceMutes", mock.Anything).Return(map[string]*corev2.NamespaceMute{}, nil)
+}
+
+func TestCachedStore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	handlers := make(chan store.WatchEventResource)
+	s := &mockstore.MockStore{}
+	s.On("WatchResources", mock.Anything, &corev2.Handler{}).Return((<-chan store.WatchEventResource)(handlers))
+	s.On("WatchResources", mock.Anything, mock.Anything).Return((<-chan store.WatchEventResource)(make(chan store.WatchEventResource)))
+	s.On("GetHandlers", mock.Anything, mock.Anything).Return([]*corev2.Handler{corev2.FixtureHandler("slack")}, nil)
+	s.On("GetEventFilters", mock.Anything, mock.Anything).Return([]*corev2.EventFilter{corev2.FixtureEventFilter("production")}, nil)
+	s.On("GetMutators", mock.Anything, mock.Anything).Return([]*corev2.Mutator{}, nil)
+	mutes := make(chan store.WatchEventNamespaceMute)
+	s.On("GetNamespaceMuteWatcher", mock.Anything).Return((<-chan store.WatchEventNamespaceMute)(mutes))
+	s.On("GetNamespaceMutes", mock.Anything).Return(map[string]*corev2.NamespaceMute{"acme": {Muted: true}}, nil)
+
+	cached, err := newCachedStore(ctx, s, time.Second)
+	require.NoError(t, err)
+
+	defaultCtx := store.NamespaceContext(ctx, "default")
+	handler, err := cached.GetHandlerByName(defaultCtx, "slack")
+	require.NoError(t, err)
+	assert.Equal(t, "slack", handler.Name)
+	filter, err := cached.GetEventFilterByName(defaultCtx, "production")
+	require.NoError(t, err)
+	assert.Equal(t, "production", filter.Name)
+	mutator, err := cached.GetMutatorByName(defaultCtx, "json")
+	require.NoError(t, err)
+	assert.Nil(t, mutator)
+
+	// Resources of other namespaces are not returned
+	handler, err = cached.GetHandlerByName(store.NamespaceContext(ctx, "acme"), "slack")
+	require.NoError(t, err)
+	assert.Nil(t, handler)
+
+	// The cache follows the changes of the resources
+	pagerduty := corev2.FixtureHandler("pagerduty")
+	handlers <- store.WatchEventResource{Resource: pagerduty, Action: store.WatchCreate}
+	handlers <- store.WatchEventResource{Resource: corev2.FixtureHandler("slack"), Action: store.WatchDelete}
+	// The watch events are handled in order, so this one is handled once the
+	// previous ones are
+	handlers <- store.WatchEventResource{Resource: pagerduty, Action: store.WatchUpdate}
+
+	handler, err = cached.GetHandlerByName(defaultCtx, "pagerduty")
+	require.NoError(t, err)
+	assert.Equal(t, "pagerduty", handler.Name)
+	handler, err = cached.GetHandlerByName(defaultCtx, "slack")
+	require.NoError(t, err)
+	assert.Nil(t, handler)
+
+	// The muting switches of the namespaces are cached and followed too
+	mute, err := cached.GetNamespaceMute(ctx, "acme")
+	require.NoError(t, err)
+	assert.True(t, mute.Muted)
+	mute, err = cached.GetNamespaceMute(ctx, "default")
+	require.NoError(t, err)
+	assert.Nil(t, mute)
+
+	mutes <- store.WatchEventNamespaceMute{Namespace: "default", NamespaceMute: &corev2.NamespaceMute{Muted: true}, Action: store.WatchCreate}
+	mutes <- store.WatchEventNamespaceMute{Namespace: "acme", NamespaceMute: &corev2.NamespaceMute{}, Action: store.WatchDelete}
+	mutes <- store.WatchEventNamespaceMute{Namespace: "default", NamespaceMute: &corev2.NamespaceMute{Muted: true}, Action: store.WatchUpdate}
+
+	mute, err = cached.GetNamespaceMute(ctx, "default")
+	require.NoError(t, err)
+	assert.True(t, mute.Muted)
+	mute, err = cached.GetNamespaceMute(ctx, "acme")
+	require.NoError(t, err)
+	assert.Nil(t, mute)
+}
+
+func TestCachedStoreListError(t *testing.T) {
+	s := &mockstore.MockStore{}
+	s.On("WatchResources", mock.Anything, mock.Anything).Return((<-chan store.WatchEventResource)(make(chan store.WatchEventResource)))
+	s.On("GetHandlers", mock.Anything, mock.Anything).Return([]*corev2.Handler(nil), errors.New("error"))
+
+	_, err := newCachedStore(context.Background(), s, time.Second)
+	assert.Error(t, err)
+}
@@ -3,10 +3,12 @@ package pipelined
 
 import (
 	"context"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/asset"
 	"github.com/sensu/sensu-go/backend/messaging"
@@ -15,10 +17,13 @@ import (
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/command"
 	"github.com/sensu/sensu-go/rpc"
+	"github.com/sirupsen/logrus"
 )
 
 var defaultStoreTimeout = time.Minute
 
+var defaultDeduplicationWindow = 5 * time.Minute
+
 // ExtensionExecutorGetterFunc gets an ExtensionExecutor. Used to decouple
 // Pipelined from gRPC.
 type ExtensionExecutorGetterFunc func(*corev2.Extension) (rpc.ExtensionExecutor, error)
@@ -30,6 +35,9 @@ type ExtensionExecutorGetterFunc func(*corev2.Extension) (rpc.ExtensionExecutor,
 type Pipelined struct {
 	assetGetter            asset.Getter
 	stopping               chan struct{}
+	draining               chan struct{}
+	stopCtx                context.Context
+	stopCancel             context.CancelFunc
 	running                *atomic.Value
 	wg                     *sync.WaitGroup
 	errChan                chan error
@@ -42,6 +50,13 @@ type Pipelined struct {
 	workerCount            int
 	storeTimeout           time.Duration
 	secretsProviderManager *secrets.ProviderManager
+	handlerSandbox         *command.Sandbox
+	handlerHTTPClient      *http.Client
+	handlerLimiter         *pipeline.HandlerLimiter
+	deduplicator           *pipeline.Deduplicator
+	resultRecorder         *pipeline.HandlerResultRecorder
+	stormDetector          *pipeline.StormDetector
+	drainTimeout           time.Duration
 }
 
 // Config configures a Pipelined.
@@ -54,6 +69,35 @@ type Config struct {
 	WorkerCount             int
 	StoreTimeout            time.Duration
 	SecretsProviderManager  *secrets.ProviderManager
+	HandlerSandbox          *command.Sandbox
+	// HandlerHTTPClient is the HTTP client of the built-in handlers calling
+	// HTTP APIs, configured for instance with a proxy.
+	HandlerHTTPClient *http.Client
+	// HandlerMaxConcurrent is the maximum number of concurrent executions of
+	// the handlers that don't set their own, or 0 for unlimited.
+	HandlerMaxConcurrent uint32
+	// DeduplicationWindow is the period during which the deduplicate filter
+	// denies the events identical to the last one handled.
+	DeduplicationWindow time.Duration
+	// DrainTimeout is the time pipelined spends handling its buffered events
+	// when it is stopped, after which the handlers still running are
+	// cancelled and the remaining events are dropped. Buffered events are
+	// handled until they are all processed if it is 0.
+	DrainTimeout time.Duration
+	// StormMaxEventRate is the number of events per second of a namespace
+	// above which an event storm is detected in the namespace. During a storm,
+	// its events are batched into summary events instead of being handled
+	// individually.
+	StormMaxEventRate float64
+	// StormMaxFailingPercent is the percentage of the entities failing the
+	// same check above which an event storm is detected. Storms are not
+	// detected if both thresholds are 0.
+	StormMaxFailingPercent float64
+	// StormMinEntities is the minimum number of entities running a check for
+	// StormMaxFailingPercent to apply.
+	StormMinEntities int
+	// StormWindow is the period over which event storms are detected.
+	StormWindow time.Duration
 }
 
 // Option is a functional option used to configure Pipelined.
@@ -73,12 +117,23 @@ func New(c Config, options ...Option) (*Pipelined, error) {
 		logger.Warn("StoreTimeout not configured")
 		c.StoreTimeout = defaultStoreTimeout
 	}
+	if c.DeduplicationWindow == 0 {
+		c.DeduplicationWindow = defaultDeduplicationWindow
+	}
+
+	stormDetector := pipeline.NewStormDetector(pipeline.StormConfig{
+		MaxEventRate:      c.StormMaxEventRate,
+		MaxFailingPercent: c.StormMaxFailingPercent,
+		MinEntities:       c.StormMinEntities,
+		Window:            c.StormWindow,
+	})
 
 	p := &Pipelined{
 		store:                  c.Store,
 		bus:                    c.Bus,
 		extensionExecutor:      c.ExtensionExecutorGetter,
 		stopping:               make(chan struct{}, 1),
+		draining:               make(chan struct{}),
 		running:                &atomic.Value{},
 		wg:                     &sync.WaitGroup{},
 		errChan:                make(chan error, 1),
@@ -88,7 +143,15 @@ func New(c Config, options ...Option) (*Pipelined, error) {
 		assetGetter:            c.AssetGetter,
 		storeTimeout:           c.StoreTimeout,
 		secretsProviderManager: c.SecretsProviderManager,
+		handlerSandbox:         c.HandlerSandbox,
+		handlerHTTPClient:      c.HandlerHTTPClient,
+		deduplicator:           pipeline.NewDeduplicator(c.DeduplicationWindow),
+		stormDetector:          stormDetector,
+		drainTimeout:           c.DrainTimeout,
 	}
+	p.stopCtx, p.stopCancel = context.WithCancel(context.Background())
+	p.handlerLimiter = pipeline.NewHandlerLimiter(p.stopCtx, c.HandlerMaxConcurrent)
+	p.resultRecorder = pipeline.NewHandlerResultRecorder(p.stopCtx, c.Store, c.StoreTimeout)
 	for _, o := range options {
 		if err := o(p); err != nil {
 			return nil, err
@@ -105,29 +168,82 @@ func (p *Pipelined) Receiver() chan<- interface{} {
 // Start pipelined, subscribing to the "event" message bus topic to
 // pass Sensu events to the pipelines for handling (goroutines).
 func (p *Pipelined) Start() error {
+	_ = prometheus.Register(pipeline.HandlerDropped)
+	_ = prometheus.Register(pipeline.HandlerQueueDepth)
+	_ = prometheus.Register(pipeline.EventStorm)
+	_ = prometheus.Register(pipeline.StormBatchedEvents)
+
 	sub, err := p.bus.Subscribe(messaging.TopicEvent, "pipelined", p)
 	if err != nil {
 		return err
 	}
 	p.subscription = sub
 
+	// The pipelines read the handlers, filters and mutators of every event,
+	// so they are cached
+	cached, err := newCachedStore(p.stopCtx, p.store, p.storeTimeout)
+	if err != nil {
+		_ = sub.Cancel()
+		return err
+	}
+	p.store = cached
+
 	p.createPipelines(p.workerCount, p.eventChan)
 
+	if p.stormDetector.Enabled() {
+		p.wg.Add(1)
+		go p.handleStorms()
+	}
+
 	return nil
 }
 
-// Stop pipelined.
+// Stop pipelined. The buffered events are handled before it returns, within
+// the drain timeout.
 func (p *Pipelined) Stop() error {
 	p.running.Store(false)
+	err := p.subscription.Cancel()
+	close(p.draining)
+	if !p.waitDrained() {
+		logger.WithFields(logrus.Fields{
+			"timeout": p.drainTimeout,
+			"events":  len(p.eventChan),
+		}).Warn("pipelined drain timeout expired, dropping the remaining events")
+	}
 	close(p.stopping)
+	p.stopCancel()
 	p.wg.Wait()
+	p.resultRecorder.Wait()
 	close(p.errChan)
-	err := p.subscription.Cancel()
 	close(p.eventChan)
 
 	return err
 }
 
+// waitDrained waits for the pipelines to handle the buffered events, and for
+// the queued handler executions to complete, and returns false if they didn't
+// within the drain timeout.
+func (p *Pipelined) waitDrained() bool {
+	drained := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		p.handlerLimiter.Wait()
+		close(drained)
+	}()
+	var timeout <-chan time.Time
+	if p.drainTimeout > 0 {
+		timer := time.NewTimer(p.drainTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case <-drained:
+		return true
+	case <-timeout:
+		return false
+	}
+}
+
 // Err returns a channel to listen for terminal errors on.
 func (p *Pipelined) Err() <-chan error {
 	return p.errChan
@@ -138,18 +254,34 @@ func (p *Pipelined) Name() string {
 	return "pipelined"
 }
 
+// DryRun reports how the event would go through the pipeline, without
+// executing any handler.
+func (p *Pipelined) DryRun(ctx context.Context, event *corev2.Event) (*corev2.PipelineDryRun, error) {
+	return p.newPipeline().DryRun(ctx, event)
+}
+
+// newPipeline creates a new pipeline with the pipelined configuration.
+func (p *Pipelined) newPipeline() *pipeline.Pipeline {
+	return pipeline.New(pipeline.Config{
+		Store:                   p.store,
+		ExtensionExecutorGetter: p.extensionExecutor,
+		AssetGetter:             p.assetGetter,
+		StoreTimeout:            p.storeTimeout,
+		SecretsProviderManager:  p.secretsProviderManager,
+		HandlerSandbox:          p.handlerSandbox,
+		HTTPClient:              p.handlerHTTPClient,
+		HandlerLimiter:          p.handlerLimiter,
+		Deduplicator:            p.deduplicator,
+		HandlerResultRecorder:   p.resultRecorder,
+	})
+}
+
 // createPipelines creates several goroutines, responsible for pulling
 // Sensu events from a channel (bound to message bus "event" topic)
 // and for handling them.
 func (p *Pipelined) createPipelines(count int, channel chan interface{}) {
 	for i := 1; i <= count; i++ {
-		pipeline := pipeline.New(pipeline.Config{
-			Store:                   p.store,
-			ExtensionExecutorGetter: p.extensionExecutor,
-			AssetGetter:             p.assetGetter,
-			StoreTimeout:            p.storeTimeout,
-			SecretsProviderManager:  p.secretsProviderManager,
-		})
+		pipeline := p.newPipeline()
 		p.wg.Add(1)
 		go func() {
 			defer p.wg.Done()
@@ -158,26 +290,87 @@ func (p *Pipelined) createPipelines(count int, channel chan interface{}) {
 				case <-p.stopping:
 					return
 				case msg := <-channel:
-					event, ok := msg.(*corev2.Event)
-					if !ok {
-						continue
+					if !p.handleMessage(pipeline, msg) {
+						return
 					}
-
-					ctx, cancel := context.WithCancel(context.Background())
-					err := pipeline.HandleEvent(ctx, event)
-					cancel()
-					if err != nil {
-						if _, ok := err.(*store.ErrInternal); ok {
-							select {
-							case p.errChan <- err:
-							case <-p.stopping:
+				case <-p.draining:
+					// Handle the buffered events, until there are none left
+					// or the drain timeout expires
+					for {
+						select {
+						case <-p.stopping:
+							return
+						case msg := <-channel:
+							if !p.handleMessage(pipeline, msg) {
+								return
 							}
+						default:
 							return
 						}
-						logger.Error(err)
 					}
 				}
 			}
 		}()
 	}
 }
+
+// handleStorms handles the summary events of the event storms once per
+// detection window, and a last time when pipelined drains its events.
+func (p *Pipelined) handleStorms() {
+	defer p.wg.Done()
+	pipeline := p.newPipeline()
+	ticker := time.NewTicker(p.stormDetector.Window())
+	defer ticker.Stop()
+	for {
+		final := false
+		select {
+		case <-ticker.C:
+		case <-p.draining:
+			final = true
+		}
+		for _, event := range p.stormDetector.Flush(time.Now(), final) {
+			if !p.handleEvent(pipeline, event) {
+				return
+			}
+		}
+		if final {
+			return
+		}
+	}
+}
+
+// handleMessage handles an event received from the bus. It returns false if
+// the pipeline must stop.
+func (p *Pipelined) handleMessage(pipeline *pipeline.Pipeline, msg interface{}) bool {
+	event, ok := msg.(*corev2.Event)
+	if !ok {
+		return true
+	}
+
+	// The events received during an event storm are batched into summary
+	// events
+	if p.stormDetector.Observe(event, time.Now()) {
+		return true
+	}
+
+	return p.handleEvent(pipeline, event)
+}
+
+// handleEvent takes an event through the pipeline. It returns false if the
+// pipeline must stop.
+func (p *Pipelined) handleEvent(pipeline *pipeline.Pipeline, event *corev2.Event) bool {
+	ctx, cancel := context.WithCancel(p.stopCtx)
+	err := pipeline.HandleEvent(ctx, event)
+	cancel()
+	if err != nil {
+		if _, ok := err.(*store.ErrInternal); ok {
+			select {
+			case p.errChan <- err:
+			case <-p.stopping:
+			}
+			return false
+		}
+		logger.Error(err)
+	}
+	return true
+}
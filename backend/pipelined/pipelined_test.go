@@ -4,7 +4,9 @@ package pipelined
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/sensu/sensu-go/types"
@@ -17,6 +19,7 @@ func TestPipelined(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, bus.Start())
 	store := &mockstore.MockStore{}
+	mockCachedStore(store)
 
 	p, err := New(Config{Bus: bus, Store: store})
 	require.NoError(t, err)
@@ -42,3 +45,26 @@ func TestPipelined(t *testing.T) {
 
 	assert.NoError(t, p.Stop())
 }
+
+func TestPipelinedDrainsBufferedEvents(t *testing.T) {
+	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	require.NoError(t, err)
+	require.NoError(t, bus.Start())
+	store := &mockstore.MockStore{}
+	mockCachedStore(store)
+
+	p, err := New(Config{Bus: bus, Store: store, BufferSize: 10, DrainTimeout: time.Minute})
+	require.NoError(t, err)
+
+	// Events without handlers, buffered before pipelined starts
+	for i := 0; i < 10; i++ {
+		event := corev2.FixtureEvent("entity1", "check1")
+		event.Check.Handlers = nil
+		p.eventChan <- event
+	}
+
+	require.NoError(t, p.Start())
+	require.NoError(t, p.Stop())
+
+	assert.Equal(t, 0, len(p.eventChan))
+}
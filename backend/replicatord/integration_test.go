@@ -0,0 +1,189 @@
+// +build integration,!race
+
+package replicatord
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/sensu/sensu-go/backend/etcd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T) (*clientv3.Client, func()) {
+	e, cleanup := etcd.NewTestEtcd(t)
+	client, err := e.NewClient()
+	require.NoError(t, err)
+	return client, func() {
+		_ = client.Close()
+		cleanup()
+	}
+}
+
+// eventually waits for the key of the client to have the given value, or to
+// be deleted if value is empty
+func eventually(t *testing.T, client *clientv3.Client, key, value string) {
+	t.Helper()
+	assert.Eventually(t, func() bool {
+		resp, err := client.Get(context.Background(), key)
+		if err != nil {
+			return false
+		}
+		if value == "" {
+			return len(resp.Kvs) == 0
+		}
+		return len(resp.Kvs) == 1 && string(resp.Kvs[0].Value) == value
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestReplicator(t *testing.T) {
+	for _, policy := range ConflictPolicies {
+		t.Run(policy, func(t *testing.T) {
+			ctx := context.Background()
+			primary, cleanupPrimary := newTestClient(t)
+			defer cleanupPrimary()
+			secondary, cleanupSecondary := newTestClient(t)
+			defer cleanupSecondary()
+
+			for _, client := range []*clientv3.Client{primary, secondary} {
+				_, err := client.Put(ctx, "/sensu.io/namespaces/default", "default")
+				require.NoError(t, err)
+			}
+			_, err := primary.Put(ctx, "/sensu.io/checks/default/check1", "v1")
+			require.NoError(t, err)
+			_, err = secondary.Put(ctx, "/sensu.io/checks/default/local", "local")
+			require.NoError(t, err)
+			_, err = secondary.Put(ctx, "/sensu.io/checks/default/check2", "modified")
+			require.NoError(t, err)
+
+			r, err := New(Config{
+				Client:         primary,
+				Secondaries:    []Secondary{{Name: "eu", Client: secondary}},
+				Resources:      []string{"checks"},
+				ConflictPolicy: policy,
+			})
+			require.NoError(t, err)
+			require.NoError(t, r.Start())
+			defer func() {
+				require.NoError(t, r.Stop())
+			}()
+
+			// The existing checks are synchronized
+			eventually(t, secondary, "/sensu.io/checks/default/check1", "v1")
+
+			// The changes are replicated
+			_, err = primary.Put(ctx, "/sensu.io/checks/default/check1", "v2")
+			require.NoError(t, err)
+			eventually(t, secondary, "/sensu.io/checks/default/check1", "v2")
+			_, err = primary.Delete(ctx, "/sensu.io/checks/default/check1")
+			require.NoError(t, err)
+			eventually(t, secondary, "/sensu.io/checks/default/check1", "")
+
+			// The checks modified in, or created by, the secondary cluster
+			// are only overwritten or deleted with the overwrite policy
+			_, err = primary.Put(ctx, "/sensu.io/checks/default/check2", "v1")
+			require.NoError(t, err)
+			if policy == ConflictPolicyOverwrite {
+				eventually(t, secondary, "/sensu.io/checks/default/check2", "v1")
+				eventually(t, secondary, "/sensu.io/checks/default/local", "")
+			} else {
+				assert.Eventually(t, func() bool {
+					return r.Status().Clusters[0].Conflicts == 1
+				}, 5*time.Second, 10*time.Millisecond)
+				eventually(t, secondary, "/sensu.io/checks/default/check2", "modified")
+				eventually(t, secondary, "/sensu.io/checks/default/local", "local")
+			}
+
+			status := r.Status().Clusters[0]
+			assert.NotZero(t, status.Revision)
+			assert.NotZero(t, status.Replicated)
+			assert.Empty(t, status.LastError)
+		})
+	}
+}
+
+func TestReplicatorRestart(t *testing.T) {
+	ctx := context.Background()
+	primary, cleanupPrimary := newTestClient(t)
+	defer cleanupPrimary()
+	secondary, cleanupSecondary := newTestClient(t)
+	defer cleanupSecondary()
+
+	for _, client := range []*clientv3.Client{primary, secondary} {
+		_, err := client.Put(ctx, "/sensu.io/namespaces/default", "default")
+		require.NoError(t, err)
+	}
+	_, err := primary.Put(ctx, "/sensu.io/checks/default/check1", "v1")
+	require.NoError(t, err)
+
+	config := Config{
+		Client:         primary,
+		Secondaries:    []Secondary{{Name: "eu", Client: secondary}},
+		Resources:      []string{"checks"},
+		ConflictPolicy: ConflictPolicyPreserve,
+	}
+	r, err := New(config)
+	require.NoError(t, err)
+	require.NoError(t, r.Start())
+	eventually(t, secondary, "/sensu.io/checks/default/check1", "v1")
+	require.NoError(t, r.Stop())
+
+	// The resources replicated before the restart are not mistaken for
+	// resources modified in the secondary cluster
+	_, err = primary.Put(ctx, "/sensu.io/checks/default/check1", "v2")
+	require.NoError(t, err)
+	r, err = New(config)
+	require.NoError(t, err)
+	require.NoError(t, r.Start())
+	defer func() {
+		require.NoError(t, r.Stop())
+	}()
+	eventually(t, secondary, "/sensu.io/checks/default/check1", "v2")
+	assert.Zero(t, r.Status().Clusters[0].Conflicts)
+}
+
+func TestReplicatorElection(t *testing.T) {
+	ctx := context.Background()
+	primary, cleanupPrimary := newTestClient(t)
+	defer cleanupPrimary()
+	secondary, cleanupSecondary := newTestClient(t)
+	defer cleanupSecondary()
+
+	for _, client := range []*clientv3.Client{primary, secondary} {
+		_, err := client.Put(ctx, "/sensu.io/namespaces/default", "default")
+		require.NoError(t, err)
+	}
+
+	config := Config{
+		Client:      primary,
+		Secondaries: []Secondary{{Name: "eu", Client: secondary}},
+		Resources:   []string{"checks"},
+	}
+	first, err := New(config)
+	require.NoError(t, err)
+	require.NoError(t, first.Start())
+	assert.Eventually(t, func() bool {
+		return first.Status().Leader
+	}, 5*time.Second, 10*time.Millisecond)
+
+	second, err := New(config)
+	require.NoError(t, err)
+	require.NoError(t, second.Start())
+	defer func() {
+		require.NoError(t, second.Stop())
+	}()
+	time.Sleep(100 * time.Millisecond)
+	assert.False(t, second.Status().Leader)
+
+	// Another backend takes over once the leader is stopped
+	require.NoError(t, first.Stop())
+	assert.Eventually(t, func() bool {
+		return second.Status().Leader
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = primary.Put(ctx, "/sensu.io/checks/default/check1", "v1")
+	require.NoError(t, err)
+	eventually(t, secondary, "/sensu.io/checks/default/check1", "v1")
+}
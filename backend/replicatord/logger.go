@@ -0,0 +1,7 @@
+package replicatord
+
+import "github.com/sirupsen/logrus"
+
+var logger = logrus.WithFields(logrus.Fields{
+	"component": "replicatord",
+})
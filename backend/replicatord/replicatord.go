@@ -0,0 +1,372 @@
+// Package replicatord mirrors resources of the cluster of the backend into
+// secondary clusters, by watching their keys in etcd and writing them into
+// the etcd of the secondary clusters, to keep the configuration of
+// multi-region deployments consistent.
+package replicatord
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/clientv3/concurrency"
+	"github.com/prometheus/client_golang/prometheus"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+const (
+	// ConflictPolicyOverwrite replaces the resources modified in the
+	// secondary clusters, and deletes the resources that only exist in them.
+	ConflictPolicyOverwrite = "overwrite"
+
+	// ConflictPolicyPreserve keeps the resources modified in, or created by,
+	// the secondary clusters.
+	ConflictPolicyPreserve = "preserve"
+
+	// dialTimeout is the timeout of the connections to the secondary
+	// clusters.
+	dialTimeout = 5 * time.Second
+
+	// retryInterval is the time to wait before replicating the resources
+	// again after an error.
+	retryInterval = 5 * time.Second
+
+	// electionTTL is the TTL, in seconds, of the lease of the backend
+	// replicating the resources. Another backend takes over once it expires.
+	electionTTL = 15
+)
+
+// electionKey is the key of the election of the backend replicating the
+// resources, so that a single backend of the cluster writes into the
+// secondary clusters at a time.
+var electionKey = path.Join(store.Root, "replicatord", "leader")
+
+// Resources are the resource types that can be replicated, and their etcd
+// key prefixes.
+var Resources = map[string]string{
+	"assets":   "assets",
+	"checks":   "checks",
+	"filters":  "event-filters",
+	"handlers": "handlers",
+	"hooks":    "hooks",
+	"mutators": "mutators",
+}
+
+// DefaultResources are the resource types replicated when none are
+// configured.
+var DefaultResources = []string{"checks", "handlers", "filters", "assets"}
+
+// ConflictPolicies are the supported conflict policies.
+var ConflictPolicies = []string{ConflictPolicyOverwrite, ConflictPolicyPreserve}
+
+// Secondary is a cluster into which the resources are replicated.
+type Secondary struct {
+	// Name of the cluster.
+	Name string
+
+	// Endpoints are the etcd client URLs of the cluster.
+	Endpoints []string
+
+	// Client is used instead of connecting to the endpoints, if set.
+	Client *clientv3.Client
+}
+
+// ParseSecondaries parses secondary clusters given as name=url values. The
+// URLs of the values with the same name are the endpoints of one cluster.
+func ParseSecondaries(values []string) ([]Secondary, error) {
+	var secondaries []Secondary
+	indexes := map[string]int{}
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid secondary cluster %q, must be name=url", value)
+		}
+		i, ok := indexes[parts[0]]
+		if !ok {
+			i = len(secondaries)
+			indexes[parts[0]] = i
+			secondaries = append(secondaries, Secondary{Name: parts[0]})
+		}
+		secondaries[i].Endpoints = append(secondaries[i].Endpoints, parts[1])
+	}
+	return secondaries, nil
+}
+
+// Replicatord replicates the resources of the cluster into its secondary
+// clusters.
+type Replicatord struct {
+	client      *clientv3.Client
+	secondaries []*secondary
+	resources   []string
+	policy      string
+	ctx         context.Context
+	cancel      context.CancelFunc
+	wg          sync.WaitGroup
+	errChan     chan error
+
+	mu     sync.Mutex
+	leader bool
+}
+
+// Config configures Replicatord.
+type Config struct {
+	// Client is the etcd client of the cluster.
+	Client *clientv3.Client
+
+	// Secondaries are the clusters into which the resources are replicated.
+	Secondaries []Secondary
+
+	// TLS is the TLS configuration of the connections to the secondary
+	// clusters.
+	TLS *tls.Config
+
+	// Resources are the replicated resource types. DefaultResources is used
+	// if empty.
+	Resources []string
+
+	// ConflictPolicy is the policy applied to the resources modified in the
+	// secondary clusters. ConflictPolicyOverwrite is used if empty.
+	ConflictPolicy string
+}
+
+// Option is a functional option.
+type Option func(*Replicatord) error
+
+// New creates a new Replicatord.
+func New(c Config, opts ...Option) (*Replicatord, error) {
+	if len(c.Secondaries) == 0 {
+		return nil, errors.New("no secondary cluster configured")
+	}
+	resources := c.Resources
+	if len(resources) == 0 {
+		resources = DefaultResources
+	}
+	for _, resource := range resources {
+		if _, ok := Resources[resource]; !ok {
+			return nil, fmt.Errorf("resources of type %q can't be replicated", resource)
+		}
+	}
+	policy := c.ConflictPolicy
+	if policy == "" {
+		policy = ConflictPolicyOverwrite
+	}
+	if policy != ConflictPolicyOverwrite && policy != ConflictPolicyPreserve {
+		return nil, fmt.Errorf("invalid conflict policy %q, must be one of: %s", policy, strings.Join(ConflictPolicies, ", "))
+	}
+
+	r := &Replicatord{
+		client:    c.Client,
+		resources: resources,
+		policy:    policy,
+		errChan:   make(chan error, 1),
+	}
+	for _, s := range c.Secondaries {
+		client := s.Client
+		if client == nil {
+			var err error
+			client, err = clientv3.New(clientv3.Config{
+				Endpoints:   s.Endpoints,
+				DialTimeout: dialTimeout,
+				TLS:         c.TLS,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("couldn't connect to secondary cluster %s: %s", s.Name, err)
+			}
+		}
+		r.secondaries = append(r.secondaries, newSecondary(s, client, policy))
+	}
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+
+	_ = prometheus.Register(ReplicationFailures)
+
+	for _, o := range opts {
+		if err := o(r); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Start Replicatord.
+func (r *Replicatord) Start() error {
+	r.wg.Add(1)
+	go r.lead()
+	return nil
+}
+
+// Stop Replicatord.
+func (r *Replicatord) Stop() error {
+	r.cancel()
+	r.wg.Wait()
+	for _, s := range r.secondaries {
+		if s.owned {
+			_ = s.client.Close()
+		}
+	}
+	close(r.errChan)
+	return nil
+}
+
+// Err returns a channel to listen for terminal errors on.
+func (r *Replicatord) Err() <-chan error {
+	return r.errChan
+}
+
+// Name returns the daemon name.
+func (r *Replicatord) Name() string {
+	return "replicatord"
+}
+
+// Status returns the status of the replication into the secondary clusters.
+func (r *Replicatord) Status() *corev2.ReplicatorStatus {
+	r.mu.Lock()
+	leader := r.leader
+	r.mu.Unlock()
+	status := &corev2.ReplicatorStatus{
+		Resources:      append([]string{}, r.resources...),
+		ConflictPolicy: r.policy,
+		Leader:         leader,
+	}
+	for _, s := range r.secondaries {
+		status.Clusters = append(status.Clusters, s.Status())
+	}
+	sort.Strings(status.Resources)
+	return status
+}
+
+// lead campaigns to replicate the resources, until the daemon is stopped.
+// The backends of the cluster all campaign, and the one elected replicates
+// the resources until it loses its lease.
+func (r *Replicatord) lead() {
+	defer r.wg.Done()
+	for {
+		err := r.campaign()
+		if r.ctx.Err() != nil {
+			return
+		}
+		logger.WithError(err).Error("replication leadership lost, campaigning again")
+		select {
+		case <-time.After(retryInterval):
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+// campaign waits for the backend to be elected, then replicates the resources
+// until the daemon is stopped or the lease of the backend is lost.
+func (r *Replicatord) campaign() error {
+	session, err := concurrency.NewSession(r.client, concurrency.WithTTL(electionTTL))
+	if err != nil {
+		return err
+	}
+	// Closing the session revokes its lease, so that another backend takes
+	// over without waiting for it to expire
+	defer session.Close()
+
+	election := concurrency.NewElection(session, electionKey)
+	if err := election.Campaign(r.ctx, fmt.Sprintf("%x", session.Lease())); err != nil {
+		return err
+	}
+	logger.Info("elected to replicate the resources")
+
+	ctx, cancel := context.WithCancel(r.ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-session.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	r.setLeader(true)
+	defer r.setLeader(false)
+	var wg sync.WaitGroup
+	for _, resource := range r.resources {
+		wg.Add(1)
+		go func(prefix string) {
+			defer wg.Done()
+			r.replicate(ctx, prefix)
+		}(path.Join(store.Root, Resources[resource]) + "/")
+	}
+	wg.Wait()
+	return errors.New("replication lease expired")
+}
+
+func (r *Replicatord) setLeader(leader bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.leader = leader
+}
+
+// replicate replicates the keys with the given prefix. The keys are
+// synchronized, then their changes are watched, until ctx is cancelled.
+// Everything is synchronized again after an error.
+func (r *Replicatord) replicate(ctx context.Context, prefix string) {
+	for {
+		revision, err := r.sync(ctx, prefix)
+		if err == nil {
+			err = r.watch(ctx, prefix, revision)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		logger.WithError(err).WithField("prefix", prefix).Error("replication interrupted, synchronizing again")
+		select {
+		case <-time.After(retryInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sync replicates all the keys with the given prefix, and returns the
+// revision of the cluster at which they were read.
+func (r *Replicatord) sync(ctx context.Context, prefix string) (int64, error) {
+	tctx, cancel := context.WithTimeout(ctx, opTimeout)
+	defer cancel()
+	resp, err := r.client.Get(tctx, prefix, clientv3.WithPrefix())
+	if err != nil {
+		return 0, err
+	}
+	for _, s := range r.secondaries {
+		s.sync(ctx, prefix, resp.Kvs, resp.Header.Revision)
+	}
+	return resp.Header.Revision, nil
+}
+
+// watch replicates the changes of the keys with the given prefix, made after
+// the given revision.
+func (r *Replicatord) watch(ctx context.Context, prefix string, revision int64) error {
+	ctx, cancel := context.WithCancel(clientv3.WithRequireLeader(ctx))
+	defer cancel()
+	for resp := range r.client.Watch(ctx, prefix, clientv3.WithPrefix(), clientv3.WithRev(revision+1)) {
+		if err := resp.Err(); err != nil {
+			return err
+		}
+		for _, event := range resp.Events {
+			for _, s := range r.secondaries {
+				key, value := string(event.Kv.Key), event.Kv.Value
+				switch event.Type {
+				case clientv3.EventTypePut:
+					s.put(ctx, key, value)
+				case clientv3.EventTypeDelete:
+					s.delete(ctx, key)
+				}
+			}
+		}
+		for _, s := range r.secondaries {
+			s.setRevision(resp.Header.Revision)
+		}
+	}
+	return errors.New("watch channel closed")
+}
@@ -0,0 +1,100 @@
+package replicatord
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSecondaries(t *testing.T) {
+	secondaries, err := ParseSecondaries([]string{
+		"eu=https://eu-1:2379",
+		"us=https://us-1:2379",
+		"eu=https://eu-2:2379",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Secondary{
+		{Name: "eu", Endpoints: []string{"https://eu-1:2379", "https://eu-2:2379"}},
+		{Name: "us", Endpoints: []string{"https://us-1:2379"}},
+	}, secondaries)
+
+	for _, value := range []string{"eu", "=https://eu-1:2379", "eu="} {
+		_, err := ParseSecondaries([]string{value})
+		assert.Error(t, err, value)
+	}
+}
+
+func TestNew(t *testing.T) {
+	secondaries := []Secondary{{Name: "eu", Client: &clientv3.Client{}}}
+
+	tests := []struct {
+		name    string
+		config  Config
+		wantErr bool
+	}{
+		{"defaults", Config{Secondaries: secondaries}, false},
+		{"no secondaries", Config{}, true},
+		{"unknown resource", Config{Secondaries: secondaries, Resources: []string{"entities"}}, true},
+		{"invalid policy", Config{Secondaries: secondaries, ConflictPolicy: "merge"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := New(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			status := r.Status()
+			assert.Equal(t, []string{"assets", "checks", "filters", "handlers"}, status.Resources)
+			assert.Equal(t, ConflictPolicyOverwrite, status.ConflictPolicy)
+			require.Len(t, status.Clusters, 1)
+			assert.Equal(t, "eu", status.Clusters[0].Name)
+		})
+	}
+}
+
+func TestSecondaryRetry(t *testing.T) {
+	s := newSecondary(Secondary{Name: "retry"}, &clientv3.Client{}, ConflictPolicyOverwrite)
+
+	// The operation is retried until it succeeds
+	var attempts int
+	s.retry(context.Background(), "key", func(ctx context.Context) error {
+		_, ok := ctx.Deadline()
+		assert.True(t, ok)
+		attempts++
+		if attempts < maxAttempts {
+			return errors.New("unavailable")
+		}
+		return nil
+	})
+	assert.Equal(t, maxAttempts, attempts)
+	assert.Empty(t, s.Status().LastError)
+	assert.Equal(t, float64(0), testutil.ToFloat64(ReplicationFailures.WithLabelValues("retry")))
+
+	// The failure is recorded after the last attempt
+	attempts = 0
+	s.retry(context.Background(), "key", func(context.Context) error {
+		attempts++
+		return errors.New("unavailable")
+	})
+	assert.Equal(t, maxAttempts, attempts)
+	assert.Equal(t, "key: unavailable", s.Status().LastError)
+	assert.Equal(t, float64(1), testutil.ToFloat64(ReplicationFailures.WithLabelValues("retry")))
+
+	// The operation isn't retried once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	attempts = 0
+	s.retry(ctx, "other", func(context.Context) error {
+		attempts++
+		return errors.New("unavailable")
+	})
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, "key: unavailable", s.Status().LastError)
+}
@@ -0,0 +1,317 @@
+package replicatord
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/mvcc/mvccpb"
+	"github.com/prometheus/client_golang/prometheus"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// opTimeout is the timeout of each attempt to replicate or delete a key
+	// in a secondary cluster, so that an unresponsive cluster can't stall
+	// the replication.
+	opTimeout = 10 * time.Second
+
+	// maxAttempts is the number of attempts to replicate or delete a key
+	// before the failure is recorded. The key is replicated again by the
+	// next synchronization.
+	maxAttempts = 3
+
+	// retryBackoff is the time to wait after the first failed attempt, which
+	// is doubled after each attempt.
+	retryBackoff = 500 * time.Millisecond
+)
+
+// ReplicationFailures counts the keys that couldn't be replicated into, or
+// deleted from, each secondary cluster.
+var ReplicationFailures = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "sensu_go_replication_failures_total",
+		Help: "Number of keys that couldn't be replicated into a secondary cluster",
+	},
+	[]string{"cluster"},
+)
+
+// namespaceKeyBuilder builds the keys of the namespaces, which must exist in
+// the secondary clusters for their resources to be replicated
+var namespaceKeyBuilder = store.NewKeyBuilder("namespaces")
+
+// replicatedRoot is the root of the keys of the secondary clusters holding
+// the digests of the values last replicated, by key of the replicated
+// resources. They are written along with the resources, so that any backend
+// of the cluster can tell whether a resource was modified in the secondary
+// cluster since it was replicated.
+var replicatedRoot = path.Join(store.Root, "replicated")
+
+// secondary replicates keys into a secondary cluster, and keeps track of its
+// status.
+type secondary struct {
+	client *clientv3.Client
+	owned  bool
+	policy string
+
+	mu     sync.Mutex
+	status corev2.ReplicatedClusterStatus
+}
+
+func newSecondary(s Secondary, client *clientv3.Client, policy string) *secondary {
+	return &secondary{
+		client: client,
+		owned:  s.Client == nil,
+		policy: policy,
+		status: corev2.ReplicatedClusterStatus{
+			Name:      s.Name,
+			Endpoints: s.Endpoints,
+		},
+	}
+}
+
+// Status returns a copy of the status of the secondary cluster.
+func (s *secondary) Status() corev2.ReplicatedClusterStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := s.status
+	status.Endpoints = append([]string{}, s.status.Endpoints...)
+	return status
+}
+
+// sync replicates the given keys, and deletes the keys with the given prefix
+// that don't exist in the cluster anymore.
+func (s *secondary) sync(ctx context.Context, prefix string, kvs []*mvccpb.KeyValue, revision int64) {
+	keys := make(map[string]bool, len(kvs))
+	for _, kv := range kvs {
+		keys[string(kv.Key)] = true
+		s.put(ctx, string(kv.Key), kv.Value)
+	}
+
+	tctx, cancel := context.WithTimeout(ctx, opTimeout)
+	defer cancel()
+	resp, err := s.client.Get(tctx, prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		s.fail(prefix, err)
+		return
+	}
+	for _, kv := range resp.Kvs {
+		if !keys[string(kv.Key)] {
+			s.delete(ctx, string(kv.Key))
+		}
+	}
+	s.setRevision(revision)
+}
+
+// put replicates the value of the key, unless the key was modified in the
+// secondary cluster and such modifications are preserved.
+func (s *secondary) put(ctx context.Context, key string, value []byte) {
+	s.retry(ctx, key, func(ctx context.Context) error {
+		return s.tryPut(ctx, key, value)
+	})
+}
+
+// delete deletes the key, unless the key was modified in, or created by, the
+// secondary cluster and such modifications are preserved.
+func (s *secondary) delete(ctx context.Context, key string) {
+	s.retry(ctx, key, func(ctx context.Context) error {
+		return s.tryDelete(ctx, key)
+	})
+}
+
+// retry calls op until it succeeds, each time with a context that times out
+// after opTimeout, and waits longer after each failed attempt. The failure is
+// recorded after maxAttempts attempts, or given up on if ctx is done.
+func (s *secondary) retry(ctx context.Context, key string, op func(context.Context) error) {
+	backoff := retryBackoff
+	for attempt := 1; ; attempt++ {
+		tctx, cancel := context.WithTimeout(ctx, opTimeout)
+		err := op(tctx)
+		cancel()
+		if err == nil || ctx.Err() != nil {
+			return
+		}
+		if attempt == maxAttempts {
+			s.fail(key, err)
+			return
+		}
+		logger.WithFields(logrus.Fields{
+			"cluster": s.status.Name,
+			"key":     key,
+		}).WithError(err).Debug("couldn't replicate resource, retrying")
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff *= 2
+	}
+}
+
+func (s *secondary) tryPut(ctx context.Context, key string, value []byte) error {
+	current, replicated, err := s.get(ctx, key)
+	if err != nil {
+		return err
+	}
+	digest := digestOf(value)
+	var modRevision int64
+	if current != nil {
+		if bytes.Equal(current.Value, value) {
+			if replicated != digest {
+				return s.mark(ctx, key, current.ModRevision, digest)
+			}
+			return nil
+		}
+		if s.policy == ConflictPolicyPreserve && replicated != digestOf(current.Value) {
+			s.conflict(key)
+			return nil
+		}
+		modRevision = current.ModRevision
+	}
+
+	// The namespace of the resource must exist, and the resource must not
+	// have been modified since it was read
+	namespace := store.ParseResourceKey(key).Namespace
+	resp, err := s.client.Txn(ctx).If(
+		clientv3.Compare(clientv3.Version(namespaceKeyBuilder.Build(namespace)), ">", 0),
+		clientv3.Compare(clientv3.ModRevision(key), "=", modRevision),
+	).Then(
+		clientv3.OpPut(key, string(value)),
+		clientv3.OpPut(replicatedKey(key), digest),
+	).Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return fmt.Errorf("namespace %q is missing or the resource was modified concurrently", namespace)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.Replicated++
+	s.status.LastReplicated = time.Now().Unix()
+	return nil
+}
+
+func (s *secondary) tryDelete(ctx context.Context, key string) error {
+	current, replicated, err := s.get(ctx, key)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		if replicated != "" {
+			_, err := s.client.Delete(ctx, replicatedKey(key))
+			return err
+		}
+		return nil
+	}
+	if s.policy == ConflictPolicyPreserve {
+		if replicated == "" {
+			// The resource was created in the secondary cluster
+			return nil
+		}
+		if replicated != digestOf(current.Value) {
+			s.conflict(key)
+			return nil
+		}
+	}
+
+	resp, err := s.client.Txn(ctx).If(
+		clientv3.Compare(clientv3.ModRevision(key), "=", current.ModRevision),
+	).Then(
+		clientv3.OpDelete(key),
+		clientv3.OpDelete(replicatedKey(key)),
+	).Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		return fmt.Errorf("the resource was modified concurrently")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.Deleted++
+	s.status.LastReplicated = time.Now().Unix()
+	return nil
+}
+
+// get returns the key-value of the key in the secondary cluster, or nil if it
+// doesn't exist, and the digest of the value last replicated for the key, or
+// an empty string if it was never replicated.
+func (s *secondary) get(ctx context.Context, key string) (*mvccpb.KeyValue, string, error) {
+	resp, err := s.client.Txn(ctx).Then(
+		clientv3.OpGet(key),
+		clientv3.OpGet(replicatedKey(key)),
+	).Commit()
+	if err != nil {
+		return nil, "", err
+	}
+	var current *mvccpb.KeyValue
+	if kvs := resp.Responses[0].GetResponseRange().Kvs; len(kvs) > 0 {
+		current = kvs[0]
+	}
+	var replicated string
+	if kvs := resp.Responses[1].GetResponseRange().Kvs; len(kvs) > 0 {
+		replicated = string(kvs[0].Value)
+	}
+	return current, replicated, nil
+}
+
+// mark records the digest of the value replicated for the key, unless the
+// key was modified since it was read.
+func (s *secondary) mark(ctx context.Context, key string, modRevision int64, digest string) error {
+	_, err := s.client.Txn(ctx).If(
+		clientv3.Compare(clientv3.ModRevision(key), "=", modRevision),
+	).Then(clientv3.OpPut(replicatedKey(key), digest)).Commit()
+	return err
+}
+
+// replicatedKey returns the key holding the digest of the value last
+// replicated for the key.
+func replicatedKey(key string) string {
+	return replicatedRoot + strings.TrimPrefix(key, store.Root)
+}
+
+func digestOf(value []byte) string {
+	sum := sha256.Sum256(value)
+	return hex.EncodeToString(sum[:])
+}
+
+func (s *secondary) setRevision(revision int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if revision > s.status.Revision {
+		s.status.Revision = revision
+	}
+}
+
+func (s *secondary) conflict(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.Conflicts++
+	logger.WithFields(logrus.Fields{
+		"cluster": s.status.Name,
+		"key":     key,
+	}).Warn("not replicating resource modified in secondary cluster")
+}
+
+func (s *secondary) fail(key string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status.LastError = fmt.Sprintf("%s: %s", key, err)
+	ReplicationFailures.WithLabelValues(s.status.Name).Inc()
+	logger.WithFields(logrus.Fields{
+		"cluster": s.status.Name,
+		"key":     key,
+	}).WithError(err).Error("couldn't replicate resource")
+}
@@ -1,9 +1,13 @@
 package ringv2
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/coreos/etcd/clientv3"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 )
 
 // Pool is a pool of rings. It exists to help users avoid creating too many
@@ -36,3 +40,34 @@ func (p *Pool) Get(path string) *Ring {
 	p.mu.Unlock()
 	return ring
 }
+
+// Subscriptions returns the subscriptions of the namespace whose ring has
+// items, with the names of the agent entities in each ring. The rings are
+// maintained by agentd and keepalived, so that they only contain the entities
+// connected to the subscription.
+func (p *Pool) Subscriptions(ctx context.Context, namespace string) ([]corev2.SubscriptionMembers, error) {
+	prefix := Path(namespace, "")
+	resp, err := p.client.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list the rings of namespace %q: %s", namespace, err)
+	}
+
+	subscriptions := []corev2.SubscriptionMembers{}
+	for _, kv := range resp.Kvs {
+		// The items of the rings are stored under {subscription}/items/{entity}
+		parts := strings.Split(strings.TrimPrefix(string(kv.Key), prefix), "/")
+		if len(parts) != 3 || parts[1] != "items" {
+			continue
+		}
+		subscription, entity := parts[0], parts[2]
+		if n := len(subscriptions); n == 0 || subscriptions[n-1].Subscription != subscription {
+			subscriptions = append(subscriptions, corev2.SubscriptionMembers{
+				Subscription: subscription,
+				Entities:     []string{},
+			})
+		}
+		members := &subscriptions[len(subscriptions)-1]
+		members.Entities = append(members.Entities, entity)
+	}
+	return subscriptions, nil
+}
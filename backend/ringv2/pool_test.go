@@ -3,9 +3,11 @@
 package ringv2
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/etcd"
 )
 
@@ -30,3 +32,40 @@ func TestPool(t *testing.T) {
 		t.Fatal("rings should not be equal")
 	}
 }
+
+func TestPoolSubscriptions(t *testing.T) {
+	t.Parallel()
+
+	e, cleanup := etcd.NewTestEtcd(t)
+	defer cleanup()
+
+	client := e.NewEmbeddedClient()
+	defer client.Close()
+
+	pool := NewPool(client)
+	ctx := context.Background()
+
+	for _, item := range []struct{ namespace, subscription, entity string }{
+		{"default", "linux", "agent1"},
+		{"default", "linux", "agent2"},
+		{"default", "windows", "agent3"},
+		{"acme", "linux", "agent4"},
+	} {
+		ring := pool.Get(Path(item.namespace, item.subscription))
+		if err := ring.Add(ctx, item.entity, 60); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, err := pool.Subscriptions(ctx, "default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []corev2.SubscriptionMembers{
+		{Subscription: "linux", Entities: []string{"agent1", "agent2"}},
+		{Subscription: "windows", Entities: []string{"agent3"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("bad subscriptions: got %v, want %v", got, want)
+	}
+}
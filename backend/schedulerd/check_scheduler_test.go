@@ -60,6 +60,8 @@ func newIntervalScheduler(ctx context.Context, t *testing.T, executor string) *T
 	s.On("GetAssets", mock.Anything, &store.SelectionPredicate{}).Return([]*corev2.Asset{&asset}, nil)
 	s.On("GetHookConfigs", mock.Anything, &store.SelectionPredicate{}).Return([]*corev2.HookConfig{&hook}, nil)
 	s.On("GetCheckConfigByName", mock.Anything, mock.Anything).Return(scheduler.check, nil)
+	s.On("GetCheckPause", mock.Anything, "check1").Return((*corev2.CheckPause)(nil), nil)
+	s.On("GetNamespace", mock.Anything, mock.Anything).Return((*corev2.Namespace)(nil), nil)
 
 	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
 	require.NoError(t, err)
@@ -72,7 +74,7 @@ func newIntervalScheduler(ctx context.Context, t *testing.T, executor string) *T
 
 	switch executor {
 	case "adhoc":
-		scheduler.exec = NewAdhocRequestExecutor(ctx, s, &queue.Memory{}, scheduler.msgBus, &cache.Resource{}, pm)
+		scheduler.exec = NewAdhocRequestExecutor(ctx, s, &queue.Memory{}, scheduler.msgBus, &cache.Resource{}, &cache.Resource{}, pm)
 	default:
 		scheduler.exec = NewCheckExecutor(scheduler.msgBus, "default", s, &cache.Resource{}, pm)
 	}
@@ -98,6 +100,7 @@ func newCronScheduler(ctx context.Context, t *testing.T, executor string) *TestC
 	s.On("GetAssets", mock.Anything, &store.SelectionPredicate{}).Return([]*corev2.Asset{&asset}, nil)
 	s.On("GetHookConfigs", mock.Anything, &store.SelectionPredicate{}).Return([]*corev2.HookConfig{&hook}, nil)
 	s.On("GetCheckConfigByName", mock.Anything, mock.Anything).Return(scheduler.check, nil)
+	s.On("GetCheckPause", mock.Anything, "check1").Return((*corev2.CheckPause)(nil), nil)
 
 	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
 	require.NoError(t, err)
@@ -110,7 +113,7 @@ func newCronScheduler(ctx context.Context, t *testing.T, executor string) *TestC
 
 	switch executor {
 	case "adhoc":
-		scheduler.exec = NewAdhocRequestExecutor(ctx, s, &queue.Memory{}, scheduler.msgBus, &cache.Resource{}, pm)
+		scheduler.exec = NewAdhocRequestExecutor(ctx, s, &queue.Memory{}, scheduler.msgBus, &cache.Resource{}, &cache.Resource{}, pm)
 	default:
 		scheduler.exec = NewCheckExecutor(scheduler.msgBus, "default", s, &cache.Resource{}, pm)
 	}
@@ -301,3 +304,134 @@ func TestCheckSubdueCron(t *testing.T) {
 	// We should have no element in our channel
 	assert.Equal(0, len(scheduler.channel))
 }
+
+// waitForCheckRequest waits for a check request matching match to be received
+// on ch, and returns whether one was received before the timeout.
+func waitForCheckRequest(ch <-chan interface{}, timeout time.Duration, match func(*corev2.CheckRequest) bool) bool {
+	deadline := time.After(timeout)
+	for {
+		select {
+		case msg := <-ch:
+			if request, ok := msg.(*corev2.CheckRequest); ok && match(request) {
+				return true
+			}
+		case <-deadline:
+			return false
+		}
+	}
+}
+
+func TestIntervalSchedulingUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	// Start a scheduler
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scheduler := newIntervalScheduler(ctx, t, "check")
+
+	// Schedule the check hourly, so it won't execute before being updated
+	check := scheduler.check
+	check.Interval = 3600
+	check.Subscriptions = []string{"subscription1"}
+
+	var subs []messaging.Subscription
+	for _, name := range []string{"subscription1", "subscription2"} {
+		topic := messaging.SubscriptionTopic(check.Namespace, name)
+		sub, err := scheduler.msgBus.Subscribe(topic, "scheduler", scheduler)
+		if err != nil {
+			assert.FailNow(err.Error())
+		}
+		subs = append(subs, sub)
+	}
+	defer func() {
+		for _, sub := range subs {
+			sub.Cancel()
+		}
+		assert.NoError(scheduler.msgBus.Stop())
+	}()
+
+	scheduler.scheduler.Start()
+	mockTime.Start()
+	defer mockTime.Stop()
+
+	// The new interval applies without waiting for the hourly execution
+	updated := *check
+	updated.Interval = 1
+	scheduler.scheduler.Interrupt(&updated)
+	assert.True(waitForCheckRequest(scheduler.channel, 10*time.Second, func(request *corev2.CheckRequest) bool {
+		return request.Config.Interval == 1
+	}))
+
+	// The new subscriptions apply at the next execution
+	resubscribed := updated
+	resubscribed.Subscriptions = []string{"subscription2"}
+	scheduler.scheduler.Interrupt(&resubscribed)
+	assert.True(waitForCheckRequest(scheduler.channel, 10*time.Second, func(request *corev2.CheckRequest) bool {
+		return len(request.Config.Subscriptions) == 1 && request.Config.Subscriptions[0] == "subscription2"
+	}))
+
+	assert.NoError(scheduler.scheduler.Stop())
+}
+
+func TestCronSchedulingUpdate(t *testing.T) {
+	assert := assert.New(t)
+
+	// Start a scheduler
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scheduler := newCronScheduler(ctx, t, "check")
+
+	// Schedule the check yearly, so it won't execute before being updated
+	check := scheduler.check
+	check.Cron = "0 0 1 1 *"
+	check.Subscriptions = []string{"subscription1"}
+
+	topic := messaging.SubscriptionTopic(check.Namespace, "subscription1")
+	sub, err := scheduler.msgBus.Subscribe(topic, "scheduler", scheduler)
+	if err != nil {
+		assert.FailNow(err.Error())
+	}
+	defer func() {
+		sub.Cancel()
+		assert.NoError(scheduler.msgBus.Stop())
+	}()
+
+	scheduler.scheduler.Start()
+	mockTime.Start()
+	defer mockTime.Stop()
+
+	// The new cron schedule applies without waiting for the yearly execution
+	updated := *check
+	updated.Cron = "* * * * *"
+	scheduler.scheduler.Interrupt(&updated)
+	assert.True(waitForCheckRequest(scheduler.channel, 10*time.Second, func(request *corev2.CheckRequest) bool {
+		return request.Config.Cron == "* * * * *"
+	}))
+
+	assert.NoError(scheduler.scheduler.Stop())
+}
+
+func TestInterruptStoppedScheduler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	scheduler := newIntervalScheduler(ctx, t, "check")
+	defer func() {
+		assert.NoError(t, scheduler.msgBus.Stop())
+	}()
+
+	scheduler.scheduler.Start()
+	require.NoError(t, scheduler.scheduler.Stop())
+
+	// A check updated while its scheduler is being stopped must not block
+	// the check watcher
+	done := make(chan struct{})
+	go func() {
+		scheduler.scheduler.Interrupt(scheduler.check)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("interrupting a stopped scheduler blocked")
+	}
+}
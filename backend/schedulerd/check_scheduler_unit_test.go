@@ -1,11 +1,16 @@
 package schedulerd
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	"github.com/sensu/sensu-go/backend/store/cache"
+	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/sensu/sensu-go/types"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestToggleIntervalSchedule(t *testing.T) {
@@ -52,3 +57,55 @@ func TestToggleCronSchedule(t *testing.T) {
 	// no state change
 	assert.False(t, sched.toggleSchedule())
 }
+
+func TestCheckExecutorIsPaused(t *testing.T) {
+	st := &mockstore.MockStore{}
+	st.On("GetCheckPause", mock.Anything, "paused").Return(&types.CheckPause{Paused: true}, nil)
+	st.On("GetCheckPause", mock.Anything, "running").Return((*types.CheckPause)(nil), nil)
+	st.On("GetCheckPause", mock.Anything, "unknown").Return((*types.CheckPause)(nil), errors.New("error"))
+	executor := NewCheckExecutor(nil, "default", st, &cache.Resource{}, nil)
+
+	assert.True(t, executor.isPaused(context.Background(), types.FixtureCheckConfig("paused")))
+	assert.False(t, executor.isPaused(context.Background(), types.FixtureCheckConfig("running")))
+
+	// Checks keep running if their pause can't be retrieved
+	assert.False(t, executor.isPaused(context.Background(), types.FixtureCheckConfig("unknown")))
+}
+
+func TestToggleRoundRobinCronSchedule(t *testing.T) {
+	check := types.FixtureCheckConfig("foobar")
+	check.Cron = "* * * * *"
+	sched := &RoundRobinCronScheduler{
+		check:  check,
+		logger: logger.WithFields(logrus.Fields{}),
+	}
+	sched.setLastState()
+
+	// no state change
+	assert.False(t, sched.toggleSchedule())
+
+	// subscriptions change
+	sched.check.Subscriptions = []string{"linux", "windows"}
+	assert.True(t, sched.toggleSchedule())
+	assert.Equal(t, []string{"linux", "windows"}, sched.lastSubscriptionsState)
+
+	// no state change
+	assert.False(t, sched.toggleSchedule())
+}
+
+func TestBudgeted(t *testing.T) {
+	check := types.FixtureCheckConfig("foobar")
+
+	// The check is returned as is if it's not budgeted
+	assert.Equal(t, check, budgeted(check, 60))
+
+	// The timeout of a high resolution check is capped to its interval,
+	// without altering the check of the scheduler
+	check.Timeout = 30
+	got := budgeted(check, 10)
+	assert.Equal(t, uint32(10), got.Timeout)
+	assert.Equal(t, uint32(30), check.Timeout)
+
+	check.Timeout = 5
+	assert.Equal(t, check, budgeted(check, 10))
+}
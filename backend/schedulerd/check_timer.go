@@ -31,12 +31,7 @@ type IntervalTimer struct {
 
 // NewIntervalTimer establishes new check timer given a name & an initial interval
 func NewIntervalTimer(name string, interval uint) *IntervalTimer {
-	// Calculate a check execution splay to ensure
-	// execution is consistent between process restarts.
-	sum := md5.Sum([]byte(name))
-	splay := binary.LittleEndian.Uint64(sum[:])
-
-	timer := &IntervalTimer{splay: splay}
+	timer := &IntervalTimer{splay: intervalSplay(name)}
 	timer.SetDuration("", interval)
 	return timer
 }
@@ -57,9 +52,15 @@ func (timerPtr *IntervalTimer) Start() {
 	timerPtr.timer = time.NewTimer(initOffset)
 }
 
-// Next reset's timer using interval
+// Next reset's timer to the next execution time. The execution times are
+// computed from the splay and the interval rather than from the previous
+// execution, so that they don't drift, even with sub-minute intervals.
 func (timerPtr *IntervalTimer) Next() {
-	if !timerPtr.timer.Reset(timerPtr.interval) {
+	next := intervalOffset(timerPtr.splay, timerPtr.interval, time.Now())
+	if next == 0 {
+		next = timerPtr.interval
+	}
+	if !timerPtr.timer.Reset(next) {
 		select {
 		case <-timerPtr.timer.C:
 		default:
@@ -79,10 +80,23 @@ func (timerPtr *IntervalTimer) Stop() {
 
 // Calculate the first execution time using splay & interval
 func (timerPtr *IntervalTimer) calcInitialOffset() time.Duration {
-	now := uint64(time.Now().UnixNano())
-	offset := (timerPtr.splay - now) % uint64(timerPtr.interval)
-	logger.WithField("offset", time.Duration(offset)/time.Second).Debug("initial offset for interval timer (in seconds)")
-	return time.Duration(offset) / time.Nanosecond
+	offset := intervalOffset(timerPtr.splay, timerPtr.interval, time.Now())
+	logger.WithField("offset", offset/time.Second).Debug("initial offset for interval timer (in seconds)")
+	return offset
+}
+
+// intervalSplay calculates a check execution splay from the check name, to
+// ensure execution is consistent between process restarts.
+func intervalSplay(name string) uint64 {
+	sum := md5.Sum([]byte(name))
+	return binary.LittleEndian.Uint64(sum[:])
+}
+
+// intervalOffset calculates the duration between now and the next execution
+// of a check with the given splay & interval.
+func intervalOffset(splay uint64, interval time.Duration, now time.Time) time.Duration {
+	offset := (splay - uint64(now.UnixNano())) % uint64(interval)
+	return time.Duration(offset)
 }
 
 // A CronTimer handles starting and stopping timers for a given check
@@ -2,15 +2,18 @@ package schedulerd
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"sync"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/messaging"
 	"github.com/sensu/sensu-go/backend/ringv2"
+	"github.com/sensu/sensu-go/backend/secrets"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/backend/store/cache"
-	"github.com/sensu/sensu-go/backend/secrets"
+	stringsutil "github.com/sensu/sensu-go/util/strings"
+	"github.com/sirupsen/logrus"
 )
 
 // CheckWatcher manages all the check schedulers
@@ -22,11 +25,21 @@ type CheckWatcher struct {
 	ctx                    context.Context
 	ringPool               *ringv2.Pool
 	entityCache            *cache.Resource
+	templateCache          *cache.Resource
 	secretsProviderManager *secrets.ProviderManager
+
+	// templated holds the checks referencing a check template, as defined,
+	// so they can be resolved again when the templates change.
+	templated map[string]*corev2.CheckConfig
+
+	// published holds the resolved checks currently published, so that their
+	// executions in flight can be cancelled once they're unpublished or
+	// deleted.
+	published map[string]*corev2.CheckConfig
 }
 
 // NewCheckWatcher creates a new ScheduleManager.
-func NewCheckWatcher(ctx context.Context, msgBus messaging.MessageBus, store store.Store, pool *ringv2.Pool, cache *cache.Resource, secretsProviderManager *secrets.ProviderManager) *CheckWatcher {
+func NewCheckWatcher(ctx context.Context, msgBus messaging.MessageBus, store store.Store, pool *ringv2.Pool, cache, templateCache *cache.Resource, secretsProviderManager *secrets.ProviderManager) *CheckWatcher {
 	watcher := &CheckWatcher{
 		store:                  store,
 		items:                  make(map[string]Scheduler),
@@ -34,7 +47,10 @@ func NewCheckWatcher(ctx context.Context, msgBus messaging.MessageBus, store sto
 		ctx:                    ctx,
 		ringPool:               pool,
 		entityCache:            cache,
+		templateCache:          templateCache,
 		secretsProviderManager: secretsProviderManager,
+		templated:              make(map[string]*corev2.CheckConfig),
+		published:              make(map[string]*corev2.CheckConfig),
 	}
 
 	return watcher
@@ -81,6 +97,7 @@ func (c *CheckWatcher) startScheduler(check *corev2.CheckConfig) error {
 
 	// Register new check scheduler
 	c.items[key] = scheduler
+	c.trackPublished(key, check, "check unpublished")
 	return nil
 }
 
@@ -96,7 +113,13 @@ func (c *CheckWatcher) Start() error {
 	defer c.mu.Unlock()
 
 	for _, cfg := range checkConfigs {
-		if err := c.startScheduler(cfg); err != nil {
+		c.trackTemplate(cfg)
+		check, err := resolveCheckTemplate(c.templateCache, cfg)
+		if err != nil {
+			logger.WithError(err).WithField("check", cfg.Name).Error("unable to schedule check")
+			continue
+		}
+		if err := c.startScheduler(check); err != nil {
 			return err
 		}
 	}
@@ -107,13 +130,18 @@ func (c *CheckWatcher) Start() error {
 }
 
 func (c *CheckWatcher) startWatcher() {
-	watchChan := c.store.GetCheckConfigWatcher(c.ctx)
+	watchChan := c.store.WatchChecks(c.ctx)
+	templateChan := c.templateCache.Watch(c.ctx)
 	for {
 		select {
 		case watchEvent, ok := <-watchChan:
 			if ok {
 				c.handleWatchEvent(watchEvent)
 			}
+		case _, ok := <-templateChan:
+			if ok {
+				c.handleTemplatesUpdate()
+			}
 		case <-c.ctx.Done():
 			c.mu.Lock()
 			defer c.mu.Unlock()
@@ -128,6 +156,11 @@ func (c *CheckWatcher) startWatcher() {
 }
 
 func (c *CheckWatcher) handleWatchEvent(watchEvent store.WatchEventCheckConfig) {
+	if watchEvent.Action == store.WatchError {
+		c.resync()
+		return
+	}
+
 	check := watchEvent.CheckConfig
 	key := concatUniqueKey(check.Name, check.Namespace)
 
@@ -136,42 +169,200 @@ func (c *CheckWatcher) handleWatchEvent(watchEvent store.WatchEventCheckConfig)
 
 	switch watchEvent.Action {
 	case store.WatchCreate:
+		c.trackTemplate(check)
+		resolved, err := resolveCheckTemplate(c.templateCache, check)
+		if err != nil {
+			logger.WithError(err).WithField("check", check.Name).Error("unable to schedule check")
+			return
+		}
 		// we need to spin up a new CheckScheduler for the newly created check
-		if err := c.startScheduler(check); err != nil {
+		if err := c.startScheduler(resolved); err != nil {
 			logger.WithError(err).Error("unable to start check scheduler")
 		}
 	case store.WatchUpdate:
-		// Interrupt the check scheduler, causing the check to execute and the timer to be reset.
 		logger.Info("check configs updated")
-		sched, ok := c.items[key]
-		if !ok {
-			logger.Info("starting new scheduler")
-			if err := c.startScheduler(check); err != nil {
-				logger.WithError(err).Error("unable to start check scheduler")
-			}
-			return
+		c.trackTemplate(check)
+		c.updateScheduler(check)
+	case store.WatchDelete:
+		delete(c.templated, key)
+		c.stopScheduler(key, "check deleted")
+	}
+}
+
+// resync synchronizes the schedulers with the checks of the store, once changes
+// of the checks were missed by the watcher. The schedulers of the checks still
+// defined are updated, and the others are stopped.
+func (c *CheckWatcher) resync() {
+	checkConfigs, err := c.store.GetCheckConfigs(c.ctx, &store.SelectionPredicate{})
+	if err != nil {
+		logger.WithError(err).Error("unable to resynchronize the check schedulers")
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	defined := make(map[string]struct{}, len(checkConfigs))
+	for _, check := range checkConfigs {
+		defined[concatUniqueKey(check.Name, check.Namespace)] = struct{}{}
+		c.trackTemplate(check)
+		c.updateScheduler(check)
+	}
+	for key := range c.items {
+		if _, ok := defined[key]; !ok {
+			delete(c.templated, key)
+			c.stopScheduler(key, "check deleted")
 		}
-		if sched.Type() == GetSchedulerType(check) {
-			logger.Info("restarting scheduler")
-			sched.Interrupt(check)
-		} else {
-			logger.Info("stopping existing scheduler, starting new scheduler")
-			if err := sched.Stop(); err != nil {
-				logger.WithError(err).Error("error stopping check scheduler")
-			}
-			delete(c.items, key)
-			if err := c.startScheduler(check); err != nil {
-				logger.WithError(err).Error("unable to start check scheduler")
-			}
+	}
+}
+
+// handleTemplatesUpdate updates the schedulers of the checks referencing a
+// check template, once the templates have changed.
+func (c *CheckWatcher) handleTemplatesUpdate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, check := range c.templated {
+		c.updateScheduler(check)
+	}
+}
+
+// updateScheduler updates the scheduler of the check after the check, or its
+// template, changed. It assumes mu is locked.
+func (c *CheckWatcher) updateScheduler(check *corev2.CheckConfig) {
+	key := concatUniqueKey(check.Name, check.Namespace)
+	resolved, err := resolveCheckTemplate(c.templateCache, check)
+	if err != nil {
+		logger.WithError(err).WithField("check", check.Name).Error("unable to schedule check")
+		c.stopScheduler(key, "check unschedulable")
+		return
+	}
+	check = resolved
+
+	// Interrupt the check scheduler, which resets its timer if the schedule of
+	// the check changed, so the change doesn't wait for the next execution.
+	sched, ok := c.items[key]
+	if !ok {
+		logger.Info("starting new scheduler")
+		if err := c.startScheduler(check); err != nil {
+			logger.WithError(err).Error("unable to start check scheduler")
 		}
-	case store.WatchDelete:
-		// Call stop on the scheduler.
-		sched, ok := c.items[key]
+		return
+	}
+	if sched.Type() == GetSchedulerType(check) {
+		logger.Info("restarting scheduler")
+		sched.Interrupt(check)
+		c.trackPublished(key, check, "check unpublished")
+	} else {
+		logger.Info("stopping existing scheduler, starting new scheduler")
+		if err := sched.Stop(); err != nil {
+			logger.WithError(err).Error("error stopping check scheduler")
+		}
+		delete(c.items, key)
+		if err := c.startScheduler(check); err != nil {
+			logger.WithError(err).Error("unable to start check scheduler")
+		}
+	}
+}
+
+// stopScheduler stops the scheduler of the check identified by key, if any,
+// and cancels its executions in flight for the given reason if it was
+// published. It assumes mu is locked.
+func (c *CheckWatcher) stopScheduler(key, reason string) {
+	sched, ok := c.items[key]
+	if ok {
+		sched.Stop()
+		delete(c.items, key)
+	}
+	if check, ok := c.published[key]; ok {
+		delete(c.published, key)
+		c.cancelExecutions(check, check.Subscriptions, reason)
+	}
+}
+
+// trackPublished records whether the resolved check is published, and cancels
+// the executions in flight of the check once it's unpublished, for the given
+// reason. The executions of the agents of the subscriptions removed from a
+// published check are cancelled as well, since they won't be scheduled again.
+// It assumes mu is locked.
+func (c *CheckWatcher) trackPublished(key string, check *corev2.CheckConfig, reason string) {
+	previous, ok := c.published[key]
+	if check.Publish {
+		c.published[key] = check
 		if ok {
-			sched.Stop()
-			delete(c.items, key)
+			if removed := removedSubscriptions(previous.Subscriptions, check.Subscriptions); len(removed) > 0 {
+				c.cancelExecutions(previous, removed, "check unsubscribed")
+			}
+		}
+		return
+	}
+	if ok {
+		delete(c.published, key)
+		c.cancelExecutions(previous, previous.Subscriptions, reason)
+	}
+}
+
+// removedSubscriptions returns the previous subscriptions which aren't part of
+// the current ones.
+func removedSubscriptions(previous, current []string) []string {
+	var removed []string
+	for _, sub := range previous {
+		if !stringsutil.InArray(sub, current) {
+			removed = append(removed, sub)
+		}
+	}
+	return removed
+}
+
+// cancelExecutions asks the agents of the given subscriptions of the check to
+// terminate their executions of the check in flight, and to discard their
+// results.
+func (c *CheckWatcher) cancelExecutions(check *corev2.CheckConfig, subscriptions []string, reason string) {
+	cancellation := &corev2.CheckCancellation{
+		Name:      check.Name,
+		Namespace: check.Namespace,
+		Reason:    reason,
+	}
+	for _, sub := range subscriptions {
+		topic := messaging.SubscriptionTopic(check.Namespace, sub)
+		if err := c.bus.Publish(topic, cancellation); err != nil {
+			logger.WithError(err).WithFields(logrus.Fields{
+				"check": check.Name,
+				"topic": topic,
+			}).Error("unable to cancel the check executions")
+		}
+	}
+}
+
+// trackTemplate records whether the check references a check template. It
+// assumes mu is locked.
+func (c *CheckWatcher) trackTemplate(check *corev2.CheckConfig) {
+	key := concatUniqueKey(check.Name, check.Namespace)
+	if check.Template == "" {
+		delete(c.templated, key)
+		return
+	}
+	c.templated[key] = check
+}
+
+// resolveCheckTemplate returns the check resolved against the check template
+// it references, if any, from the cached templates.
+func resolveCheckTemplate(templates *cache.Resource, check *corev2.CheckConfig) (*corev2.CheckConfig, error) {
+	if check.Template == "" {
+		return check, nil
+	}
+	for _, value := range templates.Get(check.Namespace) {
+		template, ok := value.Resource.(*corev2.CheckTemplate)
+		if !ok || template.Name != check.Template {
+			continue
+		}
+		resolved := template.Apply(check)
+		if err := resolved.Validate(); err != nil {
+			return nil, fmt.Errorf("check resolved against template %q is invalid: %s", check.Template, err)
 		}
+		return resolved, nil
 	}
+	return nil, fmt.Errorf("check template %q not found", check.Template)
 }
 
 func concatUniqueKey(args ...string) string {
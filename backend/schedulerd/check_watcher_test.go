@@ -34,12 +34,14 @@ func TestCheckWatcherSmoke(t *testing.T) {
 	st.On("GetCheckConfigByName", mock.Anything, "b").Return(checkB, nil)
 	st.On("GetAssets", mock.Anything, &store.SelectionPredicate{}).Return([]*corev2.Asset{}, nil)
 	st.On("GetHookConfigs", mock.Anything, &store.SelectionPredicate{}).Return([]*corev2.HookConfig{}, nil)
+	st.On("GetCheckPause", mock.Anything, mock.Anything).Return((*corev2.CheckPause)(nil), nil)
+	st.On("GetNamespace", mock.Anything, mock.Anything).Return((*corev2.Namespace)(nil), nil)
 
 	watcherChan := make(chan store.WatchEventCheckConfig)
-	st.On("GetCheckConfigWatcher", mock.Anything).Return((<-chan store.WatchEventCheckConfig)(watcherChan), nil)
+	st.On("WatchChecks", mock.Anything).Return((<-chan store.WatchEventCheckConfig)(watcherChan), nil)
 
 	pm := secrets.NewProviderManager()
-	watcher := NewCheckWatcher(ctx, bus, st, nil, &cache.Resource{}, pm)
+	watcher := NewCheckWatcher(ctx, bus, st, nil, &cache.Resource{}, &cache.Resource{}, pm)
 	require.NoError(t, watcher.Start())
 
 	checkAA := corev2.FixtureCheckConfig("a")
@@ -64,4 +66,9 @@ func TestCheckWatcherSmoke(t *testing.T) {
 		CheckConfig: checkB,
 		Action:      store.WatchCreate,
 	}
+
+	// Changes were missed, so the checks are fetched again
+	watcherChan <- store.WatchEventCheckConfig{
+		Action: store.WatchError,
+	}
 }
@@ -0,0 +1,105 @@
+package schedulerd
+
+import (
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/messaging"
+	"github.com/sensu/sensu-go/backend/store/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveCheckTemplate(t *testing.T) {
+	templates := cache.NewFromResources([]corev2.Resource{corev2.FixtureCheckTemplate("linux")}, false)
+
+	check := corev2.FixtureCheckConfig("check-cpu")
+	resolved, err := resolveCheckTemplate(templates, check)
+	require.NoError(t, err)
+	assert.Equal(t, check, resolved)
+
+	check = &corev2.CheckConfig{
+		ObjectMeta: corev2.NewObjectMeta("check-cpu", "default"),
+		Command:    "check-cpu.sh",
+		Template:   "linux",
+	}
+	resolved, err = resolveCheckTemplate(templates, check)
+	require.NoError(t, err)
+	assert.Equal(t, "check-cpu.sh", resolved.Command)
+	assert.Equal(t, uint32(60), resolved.Interval)
+	assert.Equal(t, IntervalType, GetSchedulerType(resolved))
+
+	// The templates of other namespaces don't apply
+	check.Namespace = "dev"
+	_, err = resolveCheckTemplate(templates, check)
+	assert.Error(t, err)
+
+	check.Namespace = "default"
+	check.Template = "windows"
+	_, err = resolveCheckTemplate(templates, check)
+	assert.Error(t, err)
+}
+
+func TestCheckWatcherCancelExecutions(t *testing.T) {
+	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
+	require.NoError(t, err)
+	require.NoError(t, bus.Start())
+	defer func() { _ = bus.Stop() }()
+
+	sub := testSubscriber{ch: make(chan interface{}, 10)}
+	subscription, err := bus.Subscribe(messaging.SubscriptionTopic("default", "linux"), "testSubscriber", sub)
+	require.NoError(t, err)
+	defer func() { _ = subscription.Cancel() }()
+
+	watcher := &CheckWatcher{
+		bus:       bus,
+		items:     make(map[string]Scheduler),
+		published: make(map[string]*corev2.CheckConfig),
+	}
+	receive := func() *corev2.CheckCancellation {
+		select {
+		case msg := <-sub.ch:
+			cancellation, ok := msg.(*corev2.CheckCancellation)
+			require.True(t, ok)
+			return cancellation
+		case <-time.After(time.Second):
+			t.Fatal("no check cancellation received")
+			return nil
+		}
+	}
+
+	check := corev2.FixtureCheckConfig("check-cpu")
+	key := concatUniqueKey(check.Name, check.Namespace)
+	watcher.trackPublished(key, check, "check unpublished")
+
+	// Unpublishing the check cancels its executions
+	unpublished := corev2.FixtureCheckConfig("check-cpu")
+	unpublished.Publish = false
+	watcher.trackPublished(key, unpublished, "check unpublished")
+	cancellation := receive()
+	assert.Equal(t, "check-cpu", cancellation.Name)
+	assert.Equal(t, "default", cancellation.Namespace)
+	assert.Equal(t, "check unpublished", cancellation.Reason)
+
+	// Deleting an unpublished check doesn't cancel anything
+	watcher.stopScheduler(key, "check deleted")
+	assert.Empty(t, sub.ch)
+
+	// Deleting a published check cancels its executions
+	watcher.trackPublished(key, check, "check unpublished")
+	watcher.stopScheduler(key, "check deleted")
+	assert.Equal(t, "check deleted", receive().Reason)
+	assert.Empty(t, watcher.published)
+
+	// Removing a subscription from a published check cancels the executions
+	// of its agents, but keeping it doesn't
+	check.Subscriptions = []string{"linux", "windows"}
+	watcher.trackPublished(key, check, "check unpublished")
+	updated := corev2.FixtureCheckConfig("check-cpu")
+	updated.Subscriptions = []string{"windows", "macos"}
+	watcher.trackPublished(key, updated, "check unpublished")
+	assert.Equal(t, "check unsubscribed", receive().Reason)
+	watcher.trackPublished(key, updated, "check unpublished")
+	assert.Empty(t, sub.ch)
+}
@@ -70,6 +70,7 @@ func (s *CronScheduler) Start() {
 
 func (s *CronScheduler) start() {
 	s.logger.Info("starting new cron scheduler")
+	s.setLastState()
 	timer := NewCronTimer(s.check.Name, s.check.Cron)
 	executor := NewCheckExecutor(s.bus, s.check.Namespace, s.store, s.entityCache, s.secretsProviderManager)
 	timer.Start()
@@ -80,12 +81,11 @@ func (s *CronScheduler) start() {
 			timer.Stop()
 			return
 		case check := <-s.interrupt:
-			// if a schedule change is detected, restart the timer
+			// if a schedule change is detected, reset the timer to the new
+			// cron schedule right away rather than at the next execution
 			s.check = check
 			if s.toggleSchedule() {
-				timer.Stop()
-				defer s.Start()
-				return
+				s.resetTimer(timer)
 			}
 			continue
 		case <-timer.C():
@@ -94,9 +94,13 @@ func (s *CronScheduler) start() {
 	}
 }
 
-// Interrupt refreshes the scheduler with a revised check config.
+// Interrupt refreshes the scheduler with a revised check config. It returns
+// without effect once the scheduler is stopped.
 func (s *CronScheduler) Interrupt(check *corev2.CheckConfig) {
-	s.interrupt <- check
+	select {
+	case s.interrupt <- check:
+	case <-s.ctx.Done():
+	}
 }
 
 // Stop stops the cron scheduler.
@@ -25,7 +25,7 @@ type Executor interface {
 	processCheck(ctx context.Context, check *corev2.CheckConfig) error
 	getEntities(ctx context.Context) ([]cache.Value, error)
 	publishProxyCheckRequests(entities []*corev2.Entity, check *corev2.CheckConfig) error
-	execute(check *corev2.CheckConfig) error
+	execute(check *corev2.CheckConfig, proxyEntity *corev2.Entity) error
 	buildRequest(check *corev2.CheckConfig) (*corev2.CheckRequest, error)
 }
 
@@ -46,9 +46,32 @@ func NewCheckExecutor(bus messaging.MessageBus, namespace string, store store.St
 // ProcessCheck processes a check by publishing its proxy requests (if any)
 // and publishing the check itself
 func (c *CheckExecutor) processCheck(ctx context.Context, check *corev2.CheckConfig) error {
+	if c.isPaused(ctx, check) {
+		return nil
+	}
 	return processCheck(ctx, c, check)
 }
 
+// isPaused returns true if the scheduling of the check is paused. The check
+// is considered not paused if its pause can't be retrieved, so that it keeps
+// being executed.
+func (c *CheckExecutor) isPaused(ctx context.Context, check *corev2.CheckConfig) bool {
+	fields := logrus.Fields{
+		"check":     check.Name,
+		"namespace": check.Namespace,
+	}
+	pause, err := c.store.GetCheckPause(ctx, check.Name)
+	if err != nil {
+		logger.WithFields(fields).WithError(err).Warn("could not retrieve the pause of the check")
+		return false
+	}
+	if pause != nil && pause.Paused {
+		logger.WithFields(fields).Debug("check is paused")
+		return true
+	}
+	return false
+}
+
 func (c *CheckExecutor) getEntities(ctx context.Context) ([]cache.Value, error) {
 	return c.entityCache.Get(store.NewNamespaceFromContext(ctx)), nil
 }
@@ -57,7 +80,7 @@ func (c *CheckExecutor) publishProxyCheckRequests(entities []*corev2.Entity, che
 	return publishProxyCheckRequests(c, entities, check)
 }
 
-func (c *CheckExecutor) execute(check *corev2.CheckConfig) error {
+func (c *CheckExecutor) execute(check *corev2.CheckConfig, proxyEntity *corev2.Entity) error {
 	// Ensure the check is configured to publish check requests
 	if !check.Publish {
 		return nil
@@ -68,6 +91,7 @@ func (c *CheckExecutor) execute(check *corev2.CheckConfig) error {
 	if err != nil {
 		return err
 	}
+	request.ProxyEntity = proxyEntity.GetRedactedEntity()
 
 	for _, sub := range check.Subscriptions {
 		topic := messaging.SubscriptionTopic(check.Namespace, sub)
@@ -85,7 +109,7 @@ func (c *CheckExecutor) execute(check *corev2.CheckConfig) error {
 	return err
 }
 
-func (c *CheckExecutor) executeOnEntity(check *corev2.CheckConfig, entity string) error {
+func (c *CheckExecutor) executeOnEntity(check *corev2.CheckConfig, entity string, proxyEntity *corev2.Entity) error {
 	// Ensure the check is configured to publish check requests
 	if !check.Publish {
 		return nil
@@ -96,6 +120,7 @@ func (c *CheckExecutor) executeOnEntity(check *corev2.CheckConfig, entity string
 	if err != nil {
 		return err
 	}
+	request.ProxyEntity = proxyEntity.GetRedactedEntity()
 
 	topic := messaging.SubscriptionTopic(check.Namespace, fmt.Sprintf("entity:%s", entity))
 	logger.WithFields(logrus.Fields{
@@ -142,11 +167,12 @@ type AdhocRequestExecutor struct {
 	cancel                 context.CancelFunc
 	listenQueueErr         chan error
 	entityCache            *cache.Resource
+	templateCache          *cache.Resource
 	secretsProviderManager *secrets.ProviderManager
 }
 
 // NewAdhocRequestExecutor returns a new AdhocRequestExecutor.
-func NewAdhocRequestExecutor(ctx context.Context, store store.Store, queue types.Queue, bus messaging.MessageBus, cache *cache.Resource, secretsProviderManager *secrets.ProviderManager) *AdhocRequestExecutor {
+func NewAdhocRequestExecutor(ctx context.Context, store store.Store, queue types.Queue, bus messaging.MessageBus, cache, templateCache *cache.Resource, secretsProviderManager *secrets.ProviderManager) *AdhocRequestExecutor {
 	ctx, cancel := context.WithCancel(ctx)
 	executor := &AdhocRequestExecutor{
 		adhocQueue:             queue,
@@ -155,6 +181,7 @@ func NewAdhocRequestExecutor(ctx context.Context, store store.Store, queue types
 		ctx:                    ctx,
 		cancel:                 cancel,
 		entityCache:            cache,
+		templateCache:          templateCache,
 		secretsProviderManager: secretsProviderManager,
 	}
 	go executor.listenQueue(ctx)
@@ -186,7 +213,16 @@ func (a *AdhocRequestExecutor) listenQueue(ctx context.Context) {
 			continue
 		}
 
-		if err = a.processCheck(ctx, &check); err != nil {
+		resolved, err := resolveCheckTemplate(a.templateCache, &check)
+		if err != nil {
+			a.listenQueueErr <- fmt.Errorf("unable to process check: %s", err)
+			if ackErr := item.Ack(ctx); ackErr != nil {
+				a.listenQueueErr <- ackErr
+			}
+			continue
+		}
+
+		if err = a.processCheck(ctx, resolved); err != nil {
 			a.listenQueueErr <- err
 			if nackErr := item.Nack(ctx); nackErr != nil {
 				a.listenQueueErr <- nackErr
@@ -214,12 +250,13 @@ func (a *AdhocRequestExecutor) publishProxyCheckRequests(entities []*corev2.Enti
 	return publishProxyCheckRequests(a, entities, check)
 }
 
-func (a *AdhocRequestExecutor) execute(check *corev2.CheckConfig) error {
+func (a *AdhocRequestExecutor) execute(check *corev2.CheckConfig, proxyEntity *corev2.Entity) error {
 	var err error
 	request, err := a.buildRequest(check)
 	if err != nil {
 		return err
 	}
+	request.ProxyEntity = proxyEntity.GetRedactedEntity()
 
 	for _, sub := range check.Subscriptions {
 		topic := messaging.SubscriptionTopic(check.Namespace, sub)
@@ -244,7 +281,7 @@ func publishProxyCheckRequests(e Executor, entities []*corev2.Entity, check *cor
 	var splay time.Duration
 	if check.ProxyRequests.Splay {
 		var err error
-		if splay, err = calculateSplayInterval(check, len(entities)); err != nil {
+		if splay, err = calculateSplayInterval(check, len(entities), time.Now()); err != nil {
 			return err
 		}
 	}
@@ -255,7 +292,7 @@ func publishProxyCheckRequests(e Executor, entities []*corev2.Entity, check *cor
 		if err != nil {
 			return err
 		}
-		if err := e.execute(substitutedCheck); err != nil {
+		if err := e.execute(substitutedCheck, entity); err != nil {
 			return err
 		}
 	}
@@ -282,17 +319,20 @@ func processCheck(ctx context.Context, executor Executor, check *corev2.CheckCon
 			logger.WithFields(fields).Warn("no matching entities, check will not be published")
 		}
 	} else {
-		return executor.execute(check)
+		return executor.execute(check, nil)
 	}
 	return nil
 }
 
 func processRoundRobinCheck(ctx context.Context, executor *CheckExecutor, check *corev2.CheckConfig, proxyEntities []*corev2.Entity, agentEntities []string) error {
+	if executor.isPaused(ctx, check) {
+		return nil
+	}
 	if check.ProxyRequests != nil {
 		return publishRoundRobinProxyCheckRequests(executor, check, proxyEntities, agentEntities)
 	}
 	for _, entity := range agentEntities {
-		if err := executor.executeOnEntity(check, entity); err != nil {
+		if err := executor.executeOnEntity(check, entity, nil); err != nil {
 			return err
 		}
 	}
@@ -303,7 +343,7 @@ func publishRoundRobinProxyCheckRequests(executor *CheckExecutor, check *corev2.
 	var splay time.Duration
 	if check.ProxyRequests.Splay {
 		var err error
-		if splay, err = calculateSplayInterval(check, len(proxyEntities)); err != nil {
+		if splay, err = calculateSplayInterval(check, len(proxyEntities), time.Now()); err != nil {
 			return err
 		}
 	}
@@ -315,7 +355,7 @@ func publishRoundRobinProxyCheckRequests(executor *CheckExecutor, check *corev2.
 		if err != nil {
 			return err
 		}
-		if err := executor.executeOnEntity(substitutedCheck, agentEntity); err != nil {
+		if err := executor.executeOnEntity(substitutedCheck, agentEntity, proxyEntity); err != nil {
 			return err
 		}
 		dreamtime := splay - time.Now().Sub(now)
@@ -344,6 +384,8 @@ func buildRequest(check *corev2.CheckConfig, s store.Store, secretsProviderManag
 			return nil, err
 		}
 		request.Secrets = secrets
+	} else if len(check.Secrets) > 0 {
+		logger.WithFields(fields).Warn("secrets of check not sent, agent transport is not using TLS")
 	}
 
 	assets, err := s.GetAssets(ctx, &store.SelectionPredicate{})
@@ -27,7 +27,7 @@ func TestAdhocExecutor(t *testing.T) {
 	bus, err := messaging.NewWizardBus(messaging.WizardBusConfig{})
 	require.NoError(t, err)
 	pm := secrets.NewProviderManager()
-	newAdhocExec := NewAdhocRequestExecutor(context.Background(), store, &queue.Memory{}, bus, &cache.Resource{}, pm)
+	newAdhocExec := NewAdhocRequestExecutor(context.Background(), store, &queue.Memory{}, bus, &cache.Resource{}, &cache.Resource{}, pm)
 	defer newAdhocExec.Stop()
 	assert.NoError(t, newAdhocExec.bus.Start())
 
@@ -115,6 +115,9 @@ func TestPublishProxyCheckRequest(t *testing.T) {
 			assert.True(ok)
 			assert.Equal("check1", res.Config.Name)
 			assert.Equal("entity1", res.Config.ProxyEntityName)
+			if assert.NotNil(res.ProxyEntity) {
+				assert.Equal("entity1", res.ProxyEntity.Name)
+			}
 		}
 	}()
 
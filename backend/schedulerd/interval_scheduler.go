@@ -14,6 +14,7 @@ import (
 // IntervalScheduler schedules checks to be executed on a timer
 type IntervalScheduler struct {
 	lastIntervalState      uint32
+	interval               uint32
 	check                  *corev2.CheckConfig
 	store                  store.Store
 	bus                    messaging.MessageBus
@@ -56,7 +57,7 @@ func (s *IntervalScheduler) schedule(timer CheckTimer, executor *CheckExecutor)
 
 	s.logger.Debug("check is not subdued")
 
-	if err := executor.processCheck(s.ctx, s.check); err != nil {
+	if err := executor.processCheck(s.ctx, budgeted(s.check, s.interval)); err != nil {
 		logger.WithError(err).Error("error executing check")
 	}
 }
@@ -69,7 +70,9 @@ func (s *IntervalScheduler) Start() {
 
 func (s *IntervalScheduler) start() {
 	s.logger.Info("starting new interval scheduler")
-	timer := NewIntervalTimer(s.check.Name, uint(s.check.Interval))
+	s.setLastState()
+	s.interval = checkInterval(s.ctx, s.store, s.check, s.logger)
+	timer := NewIntervalTimer(s.check.Name, uint(s.interval))
 	executor := NewCheckExecutor(s.bus, s.check.Namespace, s.store, s.entityCache, s.secretsProviderManager)
 
 	timer.Start()
@@ -80,12 +83,12 @@ func (s *IntervalScheduler) start() {
 			timer.Stop()
 			return
 		case check := <-s.interrupt:
-			// if a schedule change is detected, restart the timer
+			// if a schedule change is detected, reset the timer to the new
+			// interval right away rather than at the next execution
 			s.check = check
 			if s.toggleSchedule() {
-				timer.Stop()
-				defer s.Start()
-				return
+				s.interval = checkInterval(s.ctx, s.store, s.check, s.logger)
+				s.resetTimer(timer)
 			}
 			continue
 		case <-timer.C():
@@ -94,9 +97,13 @@ func (s *IntervalScheduler) start() {
 	}
 }
 
-// Interrupt refreshes the scheduler with a revised check config.
+// Interrupt refreshes the scheduler with a revised check config. It returns
+// without effect once the scheduler is stopped.
 func (s *IntervalScheduler) Interrupt(check *corev2.CheckConfig) {
-	s.interrupt <- check
+	select {
+	case s.interrupt <- check:
+	case <-s.ctx.Done():
+	}
 }
 
 // Stop stops the IntervalScheduler
@@ -127,7 +134,7 @@ func (s *IntervalScheduler) setLastState() {
 
 // Reset timer
 func (s *IntervalScheduler) resetTimer(timer CheckTimer) {
-	timer.SetDuration("", uint(s.check.Interval))
+	timer.SetDuration("", uint(s.interval))
 	timer.Next()
 }
 
@@ -135,3 +142,32 @@ func (s *IntervalScheduler) resetTimer(timer CheckTimer) {
 func (s *IntervalScheduler) Type() SchedulerType {
 	return IntervalType
 }
+
+// checkInterval returns the interval of the check, raised to the minimum check
+// interval of its namespace if it's lower. The interval of the check is
+// returned as is if the namespace can't be retrieved.
+func checkInterval(ctx context.Context, nsStore store.NamespaceStore, check *corev2.CheckConfig, logger *logrus.Entry) uint32 {
+	namespace, err := nsStore.GetNamespace(ctx, check.Namespace)
+	if err != nil {
+		logger.WithError(err).Warn("could not retrieve the minimum check interval of the namespace")
+		return check.Interval
+	}
+	if namespace == nil || check.Interval >= namespace.MinCheckInterval {
+		return check.Interval
+	}
+	logger.WithField("min_check_interval", namespace.MinCheckInterval).Warn("check interval is below the minimum of the namespace, using the minimum")
+	return namespace.MinCheckInterval
+}
+
+// budgeted returns the check with its timeout set to its execution budget at
+// the given interval, so that the agents terminate its executions before the
+// next ones are scheduled. The check is returned as is if it's not budgeted.
+func budgeted(check *corev2.CheckConfig, interval uint32) *corev2.CheckConfig {
+	budget := check.ExecutionBudget(interval)
+	if budget == 0 || budget == check.Timeout {
+		return check
+	}
+	budgetedCheck := *check
+	budgetedCheck.Timeout = budget
+	return &budgetedCheck
+}
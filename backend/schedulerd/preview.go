@@ -0,0 +1,141 @@
+package schedulerd
+
+import (
+	"errors"
+
+	time "github.com/echlebek/timeproxy"
+	cron "github.com/robfig/cron/v3"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store/cache"
+	"github.com/sensu/sensu-go/types/dynamic"
+)
+
+// MaxPreviewExecutions is the maximum number of executions returned by
+// PreviewSchedule.
+const MaxPreviewExecutions = 1000
+
+// PreviewSchedule computes the executions of the check scheduled between
+// start and end, the way the schedulers compute them, including the splay of
+// interval checks, cron schedules, subdue windows and the splay of proxy
+// requests, whose entities are matched among the given entities.
+//
+// The executions of round-robin interval checks are triggered by their ring,
+// so their times are only approximate.
+func PreviewSchedule(check *corev2.CheckConfig, entities []*corev2.Entity, start, end time.Time) (*corev2.CheckSchedulePreview, error) {
+	if !end.After(start) {
+		return nil, errors.New("the end of the window must be after its start")
+	}
+	if check.Interval == 0 && check.Cron == "" {
+		return nil, errors.New("check has neither an interval nor a cron schedule")
+	}
+
+	schedulerType := GetSchedulerType(check)
+	preview := &corev2.CheckSchedulePreview{
+		Check:         check.Name,
+		Namespace:     check.Namespace,
+		Scheduler:     schedulerType.String(),
+		Subscriptions: check.Subscriptions,
+		Publish:       check.Publish,
+		Start:         start.Unix(),
+		End:           end.Unix(),
+		Executions:    []corev2.ScheduledExecution{},
+	}
+
+	var proxyEntities []*corev2.Entity
+	if check.ProxyRequests != nil {
+		values := make([]cache.Value, 0, len(entities))
+		for _, entity := range entities {
+			values = append(values, cache.Value{Resource: entity, Synth: dynamic.Synthesize(entity)})
+		}
+		proxyEntities = matchEntities(values, check.ProxyRequests)
+		if len(proxyEntities) == 0 {
+			// The check is not published without matching entities
+			return preview, nil
+		}
+		for _, entity := range proxyEntities {
+			preview.ProxyEntities = append(preview.ProxyEntities, entity.Name)
+		}
+	}
+
+	next, err := nextExecution(check, start)
+	if err != nil {
+		return nil, err
+	}
+	for t := next(time.Time{}); !t.After(end); t = next(t) {
+		var subdued bool
+		if subdue := check.GetSubdue(); subdue != nil {
+			subdued, _ = subdue.InWindows(t)
+		}
+		if check.ProxyRequests == nil {
+			if !addExecution(preview, corev2.ScheduledExecution{Time: t.Unix(), Subdued: subdued}) {
+				break
+			}
+			continue
+		}
+
+		var splay time.Duration
+		if check.ProxyRequests.Splay {
+			if splay, err = calculateSplayInterval(check, len(proxyEntities), t); err != nil {
+				return nil, err
+			}
+		}
+		for i, entity := range proxyEntities {
+			// The requests of round-robin checks are published after each
+			// splay, and the other ones before each splay
+			offset := time.Duration(i) * splay
+			if !check.RoundRobin {
+				offset += splay
+			}
+			execution := corev2.ScheduledExecution{
+				Time:        t.Add(offset).Unix(),
+				Subdued:     subdued,
+				ProxyEntity: entity.Name,
+			}
+			if !addExecution(preview, execution) {
+				return preview, nil
+			}
+		}
+	}
+	return preview, nil
+}
+
+// nextExecution returns a function returning the time of the execution of the
+// check following the given one, or the first execution after start for the
+// zero time.
+func nextExecution(check *corev2.CheckConfig, start time.Time) (func(time.Time) time.Time, error) {
+	if check.Cron != "" {
+		schedule, err := cron.ParseStandard(check.Cron)
+		if err != nil {
+			return nil, err
+		}
+		return func(t time.Time) time.Time {
+			if t.IsZero() {
+				t = start
+			}
+			return schedule.Next(t)
+		}, nil
+	}
+
+	interval := time.Duration(check.Interval) * time.Second
+	return func(t time.Time) time.Time {
+		if !t.IsZero() {
+			return t.Add(interval)
+		}
+		if check.RoundRobin {
+			return start.Add(interval)
+		}
+		return start.Add(intervalOffset(intervalSplay(check.Name), interval, start))
+	}, nil
+}
+
+// addExecution adds the execution to the preview and returns true, unless
+// the preview already has the maximum number of executions, in which case it
+// is marked as truncated.
+func addExecution(preview *corev2.CheckSchedulePreview, execution corev2.ScheduledExecution) bool {
+	if len(preview.Executions) >= MaxPreviewExecutions {
+		preview.Truncated = true
+		return false
+	}
+	preview.Executions = append(preview.Executions, execution)
+	return true
+}
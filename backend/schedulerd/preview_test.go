@@ -0,0 +1,97 @@
+package schedulerd
+
+import (
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreviewScheduleInterval(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check1")
+	check.Interval = 60
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	preview, err := PreviewSchedule(check, nil, start, start.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "interval", preview.Scheduler)
+	require.Len(t, preview.Executions, 60)
+
+	// The first execution is offset by the splay of the check, and the
+	// following ones are one interval apart
+	first := preview.Executions[0].Time
+	assert.Equal(t, start.Add(intervalOffset(intervalSplay("check1"), time.Minute, start)).Unix(), first)
+	for i, execution := range preview.Executions {
+		assert.Equal(t, first+int64(i)*60, execution.Time)
+		assert.False(t, execution.Subdued)
+	}
+}
+
+func TestPreviewScheduleCronSubdue(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check1")
+	check.Interval = 0
+	check.Cron = "0 * * * *"
+	check.Subdue = &corev2.TimeWindowWhen{
+		Days: corev2.TimeWindowDays{
+			All: []*corev2.TimeWindowTimeRange{{Begin: "1:30AM", End: "2:30AM"}},
+		},
+	}
+	start := time.Date(2020, 1, 1, 0, 30, 0, 0, time.UTC)
+
+	preview, err := PreviewSchedule(check, nil, start, start.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, "cron", preview.Scheduler)
+	assert.Equal(t, []corev2.ScheduledExecution{
+		{Time: start.Add(30 * time.Minute).Unix()},
+		{Time: start.Add(90 * time.Minute).Unix(), Subdued: true},
+		{Time: start.Add(150 * time.Minute).Unix()},
+	}, preview.Executions)
+}
+
+func TestPreviewScheduleProxySplay(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check1")
+	check.Interval = 30
+	check.ProxyRequests = corev2.FixtureProxyRequests(true)
+	check.ProxyRequests.EntityAttributes = []string{`entity.entity_class == "proxy"`}
+
+	entities := []*corev2.Entity{
+		corev2.FixtureEntity("router"),
+		corev2.FixtureEntity("agent"),
+		corev2.FixtureEntity("switch"),
+	}
+	entities[0].EntityClass = corev2.EntityProxyClass
+	entities[2].EntityClass = corev2.EntityProxyClass
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	preview, err := PreviewSchedule(check, entities, start, start.Add(30*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"router", "switch"}, preview.ProxyEntities)
+	require.Len(t, preview.Executions, 2)
+
+	// 30s * 90% / 2 = 13.5s between the requests of each entity
+	first := start.Add(intervalOffset(intervalSplay("check1"), 30*time.Second, start))
+	assert.Equal(t, "router", preview.Executions[0].ProxyEntity)
+	assert.Equal(t, first.Add(13500*time.Millisecond).Unix(), preview.Executions[0].Time)
+	assert.Equal(t, "switch", preview.Executions[1].ProxyEntity)
+	assert.Equal(t, first.Add(27*time.Second).Unix(), preview.Executions[1].Time)
+}
+
+func TestPreviewScheduleTruncated(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check1")
+	check.Interval = 1
+	start := time.Now()
+
+	preview, err := PreviewSchedule(check, nil, start, start.Add(24*time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, preview.Executions, MaxPreviewExecutions)
+	assert.True(t, preview.Truncated)
+}
+
+func TestPreviewScheduleInvalidWindow(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check1")
+	start := time.Now()
+	_, err := PreviewSchedule(check, nil, start, start)
+	assert.Error(t, err)
+}
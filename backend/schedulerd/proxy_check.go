@@ -71,19 +71,19 @@ func substituteProxyEntityTokens(entity *corev2.Entity, check *corev2.CheckConfi
 }
 
 // calculateSplayInterval calculates the duration between publishing proxy
-// requests to each individual entity (based on a configurable splay %)
-func calculateSplayInterval(check *corev2.CheckConfig, numEntities int) (time.Duration, error) {
+// requests to each individual entity (based on a configurable splay %), for
+// an execution at the given time
+func calculateSplayInterval(check *corev2.CheckConfig, numEntities int, now time.Time) (time.Duration, error) {
 	next := time.Second * time.Duration(check.Interval)
 	if check.Cron != "" {
 		schedule, err := cron.ParseStandard(check.Cron)
 		if err != nil {
 			return 0, err
 		}
-		now := time.Now()
 		then := schedule.Next(now)
 		next = then.Sub(now)
 		if next < 5*time.Second {
-			now = time.Now().Add(next + time.Second)
+			now = now.Add(next + time.Second)
 			then = schedule.Next(now)
 			next = then.Sub(now)
 		}
@@ -114,20 +114,20 @@ func TestSplayCalculation(t *testing.T) {
 
 	// 10s * 90% / 3 = 3
 	check.Interval = 10
-	splay, err := calculateSplayInterval(check, 3)
+	splay, err := calculateSplayInterval(check, 3, time.Now())
 	assert.Equal(3*time.Second, splay)
 	assert.Nil(err)
 
 	// 20s * 50% / 5 = 2
 	check.Interval = 20
 	check.ProxyRequests.SplayCoverage = 50
-	splay, err = calculateSplayInterval(check, 5)
+	splay, err = calculateSplayInterval(check, 5, time.Now())
 	assert.Equal(2*time.Second, splay)
 	assert.Nil(err)
 
 	// invalid cron string
 	check.Cron = "invalid"
-	splay, err = calculateSplayInterval(check, 5)
+	splay, err = calculateSplayInterval(check, 5, time.Now())
 	assert.Equal(time.Duration(0), splay)
 	assert.NotNil(err)
 
@@ -135,7 +135,7 @@ func TestSplayCalculation(t *testing.T) {
 	// this test will depend on when it is run, but the
 	// largest splay calculation will be 15
 	check.Cron = "* * * * *"
-	splay, err = calculateSplayInterval(check, 2)
+	splay, err = calculateSplayInterval(check, 2, time.Now())
 	assert.True(splay >= 0 && splay <= 15*time.Second)
 	assert.Nil(err)
 }
@@ -2,6 +2,7 @@ package schedulerd
 
 import (
 	"context"
+	"reflect"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/messaging"
@@ -15,18 +16,19 @@ import (
 // RoundRobinCronScheduler is like CronScheduler, but only schedules checks
 // on a single entity at a time.
 type RoundRobinCronScheduler struct {
-	lastCronState string
-	check         *corev2.CheckConfig
-	store         store.Store
-	bus           messaging.MessageBus
-	logger        *logrus.Entry
-	ctx           context.Context
-	cancel        context.CancelFunc
-	interrupt     chan *corev2.CheckConfig
-	ringPool      *ringv2.Pool
-	cancels       map[string]ringCancel
-	executor      *CheckExecutor
-	entityCache   *cache.Resource
+	lastCronState          string
+	lastSubscriptionsState []string
+	check                  *corev2.CheckConfig
+	store                  store.Store
+	bus                    messaging.MessageBus
+	logger                 *logrus.Entry
+	ctx                    context.Context
+	cancel                 context.CancelFunc
+	interrupt              chan *corev2.CheckConfig
+	ringPool               *ringv2.Pool
+	cancels                map[string]ringCancel
+	executor               *CheckExecutor
+	entityCache            *cache.Resource
 }
 
 // NewRoundRobinCronScheduler creates a new RoundRobinCronScheduler.
@@ -96,7 +98,7 @@ func (s *RoundRobinCronScheduler) start() {
 		case check := <-s.interrupt:
 			s.check = check
 			if s.toggleSchedule() {
-				s.logger.Info("cron schedule updated")
+				s.logger.Info("schedule updated")
 				s.updateRings()
 			}
 		case <-entityWatcher:
@@ -177,6 +179,10 @@ func (s *RoundRobinCronScheduler) toggleSchedule() (stateChanged bool) {
 		s.logger.Info("cron schedule has changed")
 		return true
 	}
+	if !reflect.DeepEqual(s.lastSubscriptionsState, s.check.Subscriptions) {
+		s.logger.Info("subscriptions have changed")
+		return true
+	}
 	s.logger.Info("cron schedule has not changed")
 	return false
 }
@@ -184,11 +190,16 @@ func (s *RoundRobinCronScheduler) toggleSchedule() (stateChanged bool) {
 // Update the CronScheduler with the last schedule states
 func (s *RoundRobinCronScheduler) setLastState() {
 	s.lastCronState = s.check.Cron
+	s.lastSubscriptionsState = s.check.Subscriptions
 }
 
-// Interrupt refreshes the scheduler with a revised check config.
+// Interrupt refreshes the scheduler with a revised check config. It returns
+// without effect once the scheduler is stopped.
 func (s *RoundRobinCronScheduler) Interrupt(check *corev2.CheckConfig) {
-	s.interrupt <- check
+	select {
+	case s.interrupt <- check:
+	case <-s.ctx.Done():
+	}
 }
 
 // Stop stops the scheduler
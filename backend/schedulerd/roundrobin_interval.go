@@ -71,6 +71,7 @@ func (s *RoundRobinIntervalScheduler) updateRings() {
 			return
 		}
 	}
+	interval := checkInterval(s.ctx, s.store, s.check, s.logger)
 	newCancels := make(map[string]ringCancel)
 	for _, sub := range s.check.Subscriptions {
 		key := ringv2.Path(s.check.Namespace, sub)
@@ -87,9 +88,9 @@ func (s *RoundRobinIntervalScheduler) updateRings() {
 		// Create a new watcher
 		ctx, cancel := context.WithCancel(s.ctx)
 		ring := s.ringPool.Get(key)
-		wc := ring.Watch(ctx, s.check.Name, agentEntitiesRequest, int(s.check.Interval), s.check.Cron)
+		wc := ring.Watch(ctx, s.check.Name, agentEntitiesRequest, int(interval), s.check.Cron)
 		val := ringCancel{Cancel: cancel, AgentEntitiesRequest: agentEntitiesRequest}
-		go s.handleEvents(s.executor, wc, proxyEntities)
+		go s.handleEvents(s.executor, wc, proxyEntities, interval)
 		newCancels[key] = val
 	}
 	// clean up any remaining watchers that are no longer valid
@@ -107,9 +108,9 @@ func (s *RoundRobinIntervalScheduler) Start() {
 	go s.start()
 }
 
-func (s *RoundRobinIntervalScheduler) handleEvents(executor *CheckExecutor, ch <-chan ringv2.Event, proxyEntities []*corev2.Entity) {
+func (s *RoundRobinIntervalScheduler) handleEvents(executor *CheckExecutor, ch <-chan ringv2.Event, proxyEntities []*corev2.Entity, interval uint32) {
 	for event := range ch {
-		s.handleEvent(executor, event, proxyEntities)
+		s.handleEvent(executor, event, proxyEntities, interval)
 	}
 }
 
@@ -121,7 +122,7 @@ func getAgentEntity(event ringv2.Event) string {
 	return entity
 }
 
-func (s *RoundRobinIntervalScheduler) handleEvent(executor *CheckExecutor, event ringv2.Event, proxyEntities []*corev2.Entity) {
+func (s *RoundRobinIntervalScheduler) handleEvent(executor *CheckExecutor, event ringv2.Event, proxyEntities []*corev2.Entity, interval uint32) {
 	switch event.Type {
 	case ringv2.EventError:
 		s.logger.WithError(event.Err).Error("error scheduling check")
@@ -140,7 +141,7 @@ func (s *RoundRobinIntervalScheduler) handleEvent(executor *CheckExecutor, event
 		// The ring has produced a trigger for the entity, and a check should
 		// be executed.
 		s.logger.WithFields(logrus.Fields{"agents": event.Values}).Info("executing round robin check on agents")
-		s.schedule(executor, proxyEntities, event.Values)
+		s.schedule(executor, proxyEntities, event.Values, interval)
 
 	case ringv2.EventClosing:
 		s.logger.Warn("shutting down scheduler")
@@ -172,7 +173,7 @@ func (s *RoundRobinIntervalScheduler) start() {
 	}
 }
 
-func (s *RoundRobinIntervalScheduler) schedule(executor *CheckExecutor, proxyEntities []*corev2.Entity, agentEntities []string) {
+func (s *RoundRobinIntervalScheduler) schedule(executor *CheckExecutor, proxyEntities []*corev2.Entity, agentEntities []string, interval uint32) {
 	if s.check.IsSubdued() {
 		s.logger.Debug("check is subdued")
 		return
@@ -180,7 +181,7 @@ func (s *RoundRobinIntervalScheduler) schedule(executor *CheckExecutor, proxyEnt
 
 	s.logger.Debug("check is not subdued")
 
-	if err := processRoundRobinCheck(s.ctx, executor, s.check, proxyEntities, agentEntities); err != nil {
+	if err := processRoundRobinCheck(s.ctx, executor, budgeted(s.check, interval), proxyEntities, agentEntities); err != nil {
 		logger.WithError(err).Error("error executing check")
 	}
 }
@@ -207,9 +208,13 @@ func (s *RoundRobinIntervalScheduler) setLastState() {
 	s.lastSubscriptionsState = s.check.Subscriptions
 }
 
-// Interrupt refreshes the scheduler with a revised check config.
+// Interrupt refreshes the scheduler with a revised check config. It returns
+// without effect once the scheduler is stopped.
 func (s *RoundRobinIntervalScheduler) Interrupt(check *corev2.CheckConfig) {
-	s.interrupt <- check
+	select {
+	case s.interrupt <- check:
+	case <-s.ctx.Done():
+	}
 }
 
 // Stop stops the scheduler
@@ -84,13 +84,17 @@ func New(ctx context.Context, c Config, opts ...Option) (*Schedulerd, error) {
 		secretsProviderManager: c.SecretsProviderManager,
 	}
 	s.ctx, s.cancel = context.WithCancel(ctx)
-	cache, err := cache.New(s.ctx, c.Client, &corev2.Entity{}, true)
+	entityCache, err := cache.New(s.ctx, c.Store, &corev2.Entity{}, true)
 	if err != nil {
 		return nil, err
 	}
-	s.entityCache = cache
-	s.checkWatcher = NewCheckWatcher(s.ctx, c.Bus, c.Store, c.RingPool, cache, s.secretsProviderManager)
-	s.adhocRequestExecutor = NewAdhocRequestExecutor(s.ctx, s.store, s.queueGetter.GetQueue(adhocQueueName), s.bus, s.entityCache, s.secretsProviderManager)
+	s.entityCache = entityCache
+	templateCache, err := cache.New(s.ctx, c.Store, &corev2.CheckTemplate{}, false)
+	if err != nil {
+		return nil, err
+	}
+	s.checkWatcher = NewCheckWatcher(s.ctx, c.Bus, c.Store, c.RingPool, entityCache, templateCache, s.secretsProviderManager)
+	s.adhocRequestExecutor = NewAdhocRequestExecutor(s.ctx, s.store, s.queueGetter.GetQueue(adhocQueueName), s.bus, s.entityCache, templateCache, s.secretsProviderManager)
 
 	for _, o := range opts {
 		if err := o(s); err != nil {
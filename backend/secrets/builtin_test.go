@@ -0,0 +1,93 @@
+package secrets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReferenceGetter(t *testing.T) {
+	provider, id, err := ReferenceGetter{}.Get(context.Background(), "vault://secret/data/db#password")
+	require.NoError(t, err)
+	assert.Equal(t, "vault", provider)
+	assert.Equal(t, "secret/data/db#password", id)
+
+	for _, name := range []string{"DB_PASS", "://DB_PASS", "env://"} {
+		_, _, err := ReferenceGetter{}.Get(context.Background(), name)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestEnvProvider(t *testing.T) {
+	provider := NewEnvProvider("")
+	provider.lookup = func(name string) (string, bool) {
+		if name == "SENSU_SECRET_DB_PASS" {
+			return "hunter2", true
+		}
+		return "", false
+	}
+	assert.Equal(t, "env", provider.GetObjectMeta().Name)
+
+	value, err := provider.Get("DB_PASS")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", value)
+
+	_, err = provider.Get("PATH")
+	assert.Error(t, err)
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/db":
+			_, _ = w.Write([]byte(`{"data":{"data":{"password":"hunter2","port":5432},"metadata":{"version":1}}}`))
+		case "/v1/kv/db":
+			_, _ = w.Write([]byte(`{"data":{"password":"swordfish"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	defer server.Close()
+
+	provider := NewVaultProvider(server.URL+"/", "s.token")
+	assert.Equal(t, "vault", provider.GetObjectMeta().Name)
+
+	tests := []struct {
+		id      string
+		want    string
+		wantErr bool
+	}{
+		{id: "secret/data/db#password", want: "hunter2"},
+		{id: "kv/db#password", want: "swordfish"},
+		{id: "secret/data/db#user", wantErr: true},
+		{id: "secret/data/db#port", wantErr: true},
+		{id: "secret/data/missing#password", wantErr: true},
+		{id: "secret/data/db", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			value, err := provider.Get(tt.id)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+
+	provider.Token = "invalid"
+	_, err := provider.Get("secret/data/db#password")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "permission denied")
+}
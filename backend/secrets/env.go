@@ -0,0 +1,49 @@
+package secrets
+
+import (
+	"fmt"
+	"os"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// DefaultEnvPrefix is the prefix of the environment variables read by the env
+// provider when none is configured.
+const DefaultEnvPrefix = "SENSU_SECRET_"
+
+// EnvProvider is the "env" secrets provider, which reads the secrets from the
+// environment of the backend. The reference env://DB_PASS resolves to the
+// value of the variable named after the prefix followed by the id, e.g.
+// SENSU_SECRET_DB_PASS, so that checks and handlers can't read any other
+// variable of the backend environment.
+type EnvProvider struct {
+	builtinProvider
+
+	// Prefix is prepended to the secret ids to get the names of the variables.
+	Prefix string
+
+	lookup func(string) (string, bool)
+}
+
+// NewEnvProvider creates the env provider, reading the variables starting
+// with prefix, or DefaultEnvPrefix if empty.
+func NewEnvProvider(prefix string) *EnvProvider {
+	if prefix == "" {
+		prefix = DefaultEnvPrefix
+	}
+	return &EnvProvider{
+		builtinProvider: builtinProvider{ObjectMeta: corev2.ObjectMeta{Name: "env"}},
+		Prefix:          prefix,
+		lookup:          os.LookupEnv,
+	}
+}
+
+// Get returns the value of the environment variable of the secret.
+func (p *EnvProvider) Get(id string) (string, error) {
+	name := p.Prefix + id
+	value, ok := p.lookup(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return value, nil
+}
@@ -0,0 +1,64 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// ReferenceGetter is a Getter resolving secret references of the form
+// provider://id, e.g. vault://secret/database#password, where the id is
+// interpreted by the provider.
+type ReferenceGetter struct{}
+
+// Get splits the secret reference into the name of its provider and the id of
+// the secret.
+func (ReferenceGetter) Get(ctx context.Context, name string) (string, string, error) {
+	i := strings.Index(name, "://")
+	if i <= 0 || i+3 == len(name) {
+		return "", "", fmt.Errorf("invalid secret reference %q, expected provider://id", name)
+	}
+	return name[:i], name[i+3:], nil
+}
+
+// builtinProvider implements the corev2.Resource interface for the providers
+// configured with the backend flags, which aren't stored.
+type builtinProvider struct {
+	corev2.ObjectMeta
+}
+
+// GetObjectMeta returns the object metadata of the provider.
+func (p *builtinProvider) GetObjectMeta() corev2.ObjectMeta {
+	return p.ObjectMeta
+}
+
+// SetObjectMeta sets the object metadata of the provider.
+func (p *builtinProvider) SetObjectMeta(meta corev2.ObjectMeta) {
+	p.ObjectMeta = meta
+}
+
+// SetNamespace is a no-op, providers aren't namespaced.
+func (p *builtinProvider) SetNamespace(string) {}
+
+// StorePrefix returns the path prefix of the providers.
+func (p *builtinProvider) StorePrefix() string {
+	return "providers"
+}
+
+// RBACName returns the name of the providers for RBAC purposes.
+func (p *builtinProvider) RBACName() string {
+	return "providers"
+}
+
+// URIPath returns the path component of the provider URI.
+func (p *builtinProvider) URIPath() string {
+	return path.Join(corev2.URLPrefix, "providers", p.Name)
+}
+
+// Validate returns an error if the provider has no name.
+func (p *builtinProvider) Validate() error {
+	return corev2.ValidateName(p.Name)
+}
@@ -0,0 +1,99 @@
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// VaultProvider is the "vault" secrets provider, which reads the secrets from
+// the key/value secrets engines of HashiCorp Vault. The secret ids are of the
+// form path#key, e.g. vault://secret/data/database#password reads the
+// password key of the secret/data/database path.
+type VaultProvider struct {
+	builtinProvider
+
+	// Address is the URL of the Vault server, e.g. https://vault:8200.
+	Address string
+
+	// Token is the token authenticating the backend with Vault.
+	Token string
+
+	// Client is the HTTP client used to query Vault.
+	Client *http.Client
+}
+
+// NewVaultProvider creates the vault provider querying the Vault server at
+// address with token.
+func NewVaultProvider(address, token string) *VaultProvider {
+	return &VaultProvider{
+		builtinProvider: builtinProvider{ObjectMeta: corev2.ObjectMeta{Name: "vault"}},
+		Address:         strings.TrimSuffix(address, "/"),
+		Token:           token,
+		Client:          &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// vaultResponse is the response of Vault to reads of the key/value secrets
+// engines.
+type vaultResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []string        `json:"errors"`
+}
+
+// Get reads the secret from Vault and returns the value of its key.
+func (p *VaultProvider) Get(id string) (string, error) {
+	i := strings.LastIndex(id, "#")
+	if i <= 0 || i == len(id)-1 {
+		return "", fmt.Errorf("invalid vault secret id %q, expected path#key", id)
+	}
+	secretPath, key := strings.Trim(id[:i], "/"), id[i+1:]
+
+	req, err := http.NewRequest(http.MethodGet, p.Address+"/v1/"+secretPath, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", p.Token)
+
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("could not query vault: %s", err)
+	}
+	defer resp.Body.Close()
+
+	var body vaultResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil && resp.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("could not decode the vault response: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		if len(body.Errors) > 0 {
+			return "", fmt.Errorf("vault returned %s: %s", resp.Status, strings.Join(body.Errors, ", "))
+		}
+		return "", fmt.Errorf("vault returned %s", resp.Status)
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(body.Data, &values); err != nil {
+		return "", fmt.Errorf("could not decode the vault secret %s: %s", secretPath, err)
+	}
+	// Version 2 of the engine nests the secret under data, next to its metadata
+	if data, ok := values["data"].(map[string]interface{}); ok {
+		if _, ok := values["metadata"]; ok {
+			values = data
+		}
+	}
+
+	value, ok := values[key]
+	if !ok {
+		return "", fmt.Errorf("key %q not found in vault secret %s", key, secretPath)
+	}
+	s, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("key %q of vault secret %s is not a string", key, secretPath)
+	}
+	return s, nil
+}
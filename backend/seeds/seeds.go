@@ -11,18 +11,41 @@ import (
 	"github.com/sensu/sensu-go/types"
 )
 
+// DefaultAgentUsername is the username of the agent user if none is
+// configured.
+const DefaultAgentUsername = "agent"
+
 type Config struct {
 	// AdminUsername is the username of the cluster admin.
 	AdminUsername string
 
 	// AdminPassword is the password of the cluster admin.
 	AdminPassword string
+
+	// AgentUsername is the username of the user of the agents. It defaults to
+	// DefaultAgentUsername.
+	AgentUsername string
+
+	// AgentPassword is the password of the user of the agents. It is
+	// required, so that no cluster is seeded with a well-known password.
+	AgentPassword string
 }
 
 var ErrAlreadyInitialized = errors.New("sensu-backend already initialized")
 
+// ErrAgentPasswordRequired is returned by SeedCluster if the password of the
+// agent user is not configured.
+var ErrAgentPasswordRequired = errors.New("the password of the agent user is required")
+
 // SeedCluster seeds the cluster according to the provided config.
 func SeedCluster(ctx context.Context, store store.Store, config Config) error {
+	if config.AgentUsername == "" {
+		config.AgentUsername = DefaultAgentUsername
+	}
+	if config.AgentPassword == "" {
+		return ErrAgentPasswordRequired
+	}
+
 	errs := make(chan error, 1)
 	go func() {
 		var err error
@@ -76,7 +99,7 @@ func SeedCluster(ctx context.Context, store store.Store, config Config) error {
 		}
 
 		// Create the agent user
-		if err = setupAgentUser(store, "agent", "P@ssw0rd!"); err != nil {
+		if err = setupAgentUser(store, config.AgentUsername, config.AgentPassword); err != nil {
 			logger.WithError(err).Error("could not initialize the agent user")
 			return
 		}
@@ -110,6 +133,7 @@ func SeedInitialData(store store.Store) (err error) {
 	config := Config{
 		AdminUsername: "admin",
 		AdminPassword: "P@ssw0rd!",
+		AgentPassword: "P@ssw0rd!",
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
 	defer cancel()
@@ -313,7 +337,7 @@ func setupAdminUser(store store.Store, username, password string) error {
 }
 
 func setupAgentUser(store store.Store, username, password string) error {
-	hash, err := bcrypt.HashPassword("P@ssw0rd!")
+	hash, err := bcrypt.HashPassword(password)
 	if err != nil {
 		return err
 	}
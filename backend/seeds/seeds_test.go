@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/sensu/sensu-go/backend/authentication/bcrypt"
 	"github.com/sensu/sensu-go/backend/store/etcd/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,3 +39,34 @@ func TestSeedInitialData(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, defaultNamespace, "default namespace should be present after seed process")
 }
+
+func TestSeedClusterAgentUser(t *testing.T) {
+	ctx := context.Background()
+	st, serr := testutil.NewStoreInstance()
+	if serr != nil {
+		assert.FailNow(t, serr.Error())
+	}
+	defer st.Teardown()
+
+	config := Config{
+		AdminUsername: "root",
+		AdminPassword: "root-s3cr3t",
+		AgentUsername: "collector",
+	}
+	// The agent password has no default, and nothing is seeded without it
+	assert.Equal(t, ErrAgentPasswordRequired, SeedCluster(ctx, st, config))
+
+	config.AgentPassword = "collector-s3cr3t"
+	require.NoError(t, SeedCluster(ctx, st, config))
+	assert.Equal(t, ErrAlreadyInitialized, SeedCluster(ctx, st, config))
+
+	agent, err := st.GetUser(ctx, "collector")
+	require.NoError(t, err)
+	require.NotNil(t, agent)
+	assert.Equal(t, []string{"system:agents"}, agent.Groups)
+	assert.True(t, bcrypt.CheckPassword(agent.Password, "collector-s3cr3t"))
+
+	agent, err = st.GetUser(ctx, DefaultAgentUsername)
+	require.NoError(t, err)
+	assert.Nil(t, agent)
+}
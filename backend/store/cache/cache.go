@@ -9,13 +9,22 @@ import (
 	"sync/atomic"
 	"time"
 
-	"github.com/coreos/etcd/clientv3"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/store"
-	"github.com/sensu/sensu-go/backend/store/etcd"
 	"github.com/sensu/sensu-go/types/dynamic"
 )
 
+// DefaultNotifyDelay is the default delay over which the changes of the
+// cached resources are coalesced into a single notification of the watchers.
+const DefaultNotifyDelay = time.Second
+
+// Store is the store the cached resources are retrieved from and watched in.
+// The resources are read through it so that the encrypted ones are decrypted.
+type Store interface {
+	ListResources(ctx context.Context, kind string, resources interface{}, pred *store.SelectionPredicate) error
+	WatchResources(ctx context.Context, resource corev2.Resource) <-chan store.WatchEventResource
+}
+
 // Value contains a cached value, and its synthesized companion.
 type Value struct {
 	Resource corev2.Resource
@@ -85,25 +94,26 @@ type cacheWatcher struct {
 	ch  chan struct{}
 }
 
-// Resource is a cache of resources. The cache uses a watcher on a certain
-// type of resources in order to keep itself up to date. Cache resources can be
-// efficiently retrieved from the cache by namespace.
+// Resource is a cache of resources. The cache watches a certain type of
+// resources in the store in order to keep itself up to date. Cache resources
+// can be efficiently retrieved from the cache by namespace.
 // `sync/atomic` expects the first word in an allocated struct to be 64-bit
 // aligned on both ARM and x86-32. See https://goo.gl/zW7dgq for more details.
 type Resource struct {
-	count      int64
-	watcher    <-chan store.WatchEventResource
-	cache      cache
-	cacheMu    sync.Mutex
-	watchers   []cacheWatcher
-	watchersMu sync.Mutex
-	synthesize bool
-	resourceT  corev2.Resource
-	client     *clientv3.Client
+	count       int64
+	watcher     <-chan store.WatchEventResource
+	cache       cache
+	cacheMu     sync.Mutex
+	watchers    []cacheWatcher
+	watchersMu  sync.Mutex
+	synthesize  bool
+	resourceT   corev2.Resource
+	store       Store
+	notifyDelay time.Duration
 }
 
 // getResources retrieves the resources from the store
-func getResources(ctx context.Context, client *clientv3.Client, resource corev2.Resource) ([]corev2.Resource, error) {
+func getResources(ctx context.Context, st Store, resource corev2.Resource) ([]corev2.Resource, error) {
 	// Get the type of the resource and create a slice type of []type
 	typeOfResource := reflect.TypeOf(resource)
 	sliceOfResource := reflect.SliceOf(typeOfResource)
@@ -111,12 +121,7 @@ func getResources(ctx context.Context, client *clientv3.Client, resource corev2.
 	ptr := reflect.New(sliceOfResource)
 	ptr.Elem().Set(reflect.MakeSlice(sliceOfResource, 0, 0))
 
-	// Get a keybuilderFunc for this resource
-	keyBuilderFunc := func(ctx context.Context, name string) string {
-		return store.NewKeyBuilder(resource.StorePrefix()).WithContext(ctx).Build("")
-	}
-
-	err := etcd.List(ctx, client, keyBuilderFunc, ptr.Interface(), &store.SelectionPredicate{})
+	err := st.ListResources(ctx, resource.StorePrefix(), ptr.Interface(), &store.SelectionPredicate{})
 	if err != nil {
 		return nil, fmt.Errorf("error creating ResourceCacher: %s", err)
 	}
@@ -135,9 +140,13 @@ func getResources(ctx context.Context, client *clientv3.Client, resource corev2.
 }
 
 // New creates a new resource cache. It retrieves all resources from the
-// store on creation.
-func New(ctx context.Context, client *clientv3.Client, resource corev2.Resource, synthesize bool) (*Resource, error) {
-	resources, err := getResources(ctx, client, resource)
+// store on creation, and then watches their changes until ctx is cancelled.
+func New(ctx context.Context, st Store, resource corev2.Resource, synthesize bool) (*Resource, error) {
+	// The watcher is created first so the changes made while the resources
+	// are retrieved are not missed
+	watcher := st.WatchResources(ctx, resource)
+
+	resources, err := getResources(ctx, st, resource)
 	if err != nil {
 		return nil, err
 	}
@@ -145,10 +154,12 @@ func New(ctx context.Context, client *clientv3.Client, resource corev2.Resource,
 	cache := buildCache(resources, synthesize)
 
 	cacher := &Resource{
-		cache:      cache,
-		synthesize: synthesize,
-		resourceT:  resource,
-		client:     client,
+		cache:       cache,
+		synthesize:  synthesize,
+		resourceT:   resource,
+		store:       st,
+		watcher:     watcher,
+		notifyDelay: DefaultNotifyDelay,
 	}
 	atomic.StoreInt64(&cacher.count, int64(len(resources)))
 
@@ -191,6 +202,9 @@ func (r *Resource) Count() int64 {
 }
 
 // Watch allows cache users to get notified when the cache has new values.
+// The changes made within the notify delay are coalesced into a single
+// notification, and the changes of the entities which only update their
+// last_seen timestamp, i.e. their keepalives, are not notified.
 // When the context is canceled, the channel will be closed.
 func (r *Resource) Watch(ctx context.Context) <-chan struct{} {
 	watcher := cacheWatcher{
@@ -229,30 +243,102 @@ func (r *Resource) notifyWatchers() {
 }
 
 func (r *Resource) start(ctx context.Context) {
-	// 1s is the minimum scheduling interval, and so is the rate that
-	// the cache will update at.
-	ticker := time.NewTicker(time.Second * 5)
-	defer ticker.Stop()
+	// The notifications are delayed so that the changes made meanwhile are
+	// coalesced
+	var notify <-chan time.Time
 	for {
 		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			updates, err := r.rebuild(ctx)
-			if err != nil {
-				logger.WithError(err).Error("couldn't rebuild cache")
+		case event, ok := <-r.watcher:
+			if !ok {
+				return
+			}
+			var updates bool
+			if event.Action == store.WatchError {
+				// Changes were missed, so the whole cache is rebuilt
+				var err error
+				if updates, err = r.rebuild(ctx); err != nil {
+					logger.WithError(err).Error("couldn't rebuild cache")
+				}
+			} else {
+				updates = r.update(event)
 			}
-			if updates {
-				r.notifyWatchers()
+			if updates && notify == nil {
+				notify = time.After(r.notifyDelay)
 			}
+		case <-notify:
+			notify = nil
+			r.notifyWatchers()
 		}
 	}
 }
 
+// update applies the change of a resource to the cache, and returns true if
+// the watchers must be notified of it. The cached slices are replaced rather
+// than modified, since they are returned to the callers of Get.
+func (r *Resource) update(event store.WatchEventResource) bool {
+	value := getCacheValue(event.Resource, r.synthesize)
+	key := getCacheKey(event.Resource)
+
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	values := r.cache[key]
+	idx := sort.Search(len(values), func(i int) bool {
+		return !resourceLT(values[i], value)
+	})
+	found := idx < len(values) && values[idx].Resource.GetObjectMeta().Name == event.Resource.GetObjectMeta().Name
+
+	var newValues []Value
+	notify := true
+	switch {
+	case event.Action == store.WatchDelete && !found:
+		return false
+	case event.Action == store.WatchDelete:
+		newValues = make([]Value, 0, len(values)-1)
+		newValues = append(newValues, values[:idx]...)
+		newValues = append(newValues, values[idx+1:]...)
+		atomic.AddInt64(&r.count, -1)
+	case found:
+		newValues = make([]Value, len(values))
+		copy(newValues, values)
+		newValues[idx] = value
+		notify = !onlyLastSeenChanged(values[idx].Resource, event.Resource)
+	default:
+		newValues = make([]Value, 0, len(values)+1)
+		newValues = append(newValues, values[:idx]...)
+		newValues = append(newValues, value)
+		newValues = append(newValues, values[idx:]...)
+		atomic.AddInt64(&r.count, 1)
+	}
+
+	if len(newValues) == 0 {
+		delete(r.cache, key)
+		return notify
+	}
+	r.cache[key] = newValues
+	return notify
+}
+
+// onlyLastSeenChanged returns true if the resources are entities which only
+// differ by their last_seen timestamp, as updated by their keepalives.
+func onlyLastSeenChanged(prev, next corev2.Resource) bool {
+	oldEntity, ok := prev.(*corev2.Entity)
+	if !ok {
+		return false
+	}
+	newEntity, ok := next.(*corev2.Entity)
+	if !ok {
+		return false
+	}
+	a, b := *oldEntity, *newEntity
+	a.LastSeen, b.LastSeen = 0, 0
+	return a.Equal(&b)
+}
+
 // rebuild the cache using the store as the source of truth
 func (r *Resource) rebuild(ctx context.Context) (bool, error) {
 	logger.Debugf("rebuilding the cache for resource type %T", r.resourceT)
-	resources, err := getResources(ctx, r.client, r.resourceT)
+	resources, err := getResources(ctx, r.store, r.resourceT)
 	if err != nil {
 		return false, err
 	}
@@ -70,7 +70,7 @@ func TestEntityCacheIntegration(t *testing.T) {
 	cacheCtx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	cache, err := New(cacheCtx, client, &corev2.Entity{}, true)
+	cache, err := New(cacheCtx, store, &corev2.Entity{}, true)
 	if err != nil {
 		t.Fatal(err)
 	}
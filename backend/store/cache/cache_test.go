@@ -4,6 +4,7 @@ import (
 	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/sensu/sensu-go/backend/store/etcd"
 	"github.com/sensu/sensu-go/types"
@@ -118,7 +119,7 @@ func TestResourceRebuild(t *testing.T) {
 
 	cacher := Resource{
 		cache:     make(map[string][]Value),
-		client:    client,
+		store:     s,
 		resourceT: &fixture.Resource{},
 	}
 
@@ -173,3 +174,94 @@ func TestResourceRebuild(t *testing.T) {
 	assert.Len(t, cacher.cache["default"], 1)
 	assert.Equal(t, int64(1), cacher.Count())
 }
+
+func TestResourceUpdate(t *testing.T) {
+	cacher := Resource{
+		cache: buildCache([]corev2.Resource{
+			fixtureEntity("a", "1"),
+			fixtureEntity("a", "3"),
+		}, false),
+		count: 2,
+	}
+	before := cacher.Get("a")
+
+	// Resource created
+	assert.True(t, cacher.update(store.WatchEventResource{Resource: fixtureEntity("a", "2"), Action: store.WatchCreate}))
+	got := cacher.Get("a")
+	require.Len(t, got, 3)
+	assert.Equal(t, "2", got[1].Resource.GetObjectMeta().Name)
+	assert.Equal(t, int64(3), cacher.Count())
+
+	// The slices returned before are left unchanged
+	assert.Len(t, before, 2)
+
+	// Resource updated
+	updated := fixtureEntity("a", "2")
+	updated.EntityClass = corev2.EntityProxyClass
+	assert.True(t, cacher.update(store.WatchEventResource{Resource: updated, Action: store.WatchUpdate}))
+	got = cacher.Get("a")
+	require.Len(t, got, 3)
+	assert.Equal(t, updated, got[1].Resource)
+	assert.Equal(t, int64(3), cacher.Count())
+
+	// Keepalives are cached but not notified
+	seen := fixtureEntity("a", "2")
+	seen.EntityClass = corev2.EntityProxyClass
+	seen.LastSeen = updated.LastSeen + 20
+	assert.False(t, cacher.update(store.WatchEventResource{Resource: seen, Action: store.WatchUpdate}))
+	assert.Equal(t, seen, cacher.Get("a")[1].Resource)
+
+	// Resource created in a new namespace
+	cacher.update(store.WatchEventResource{Resource: fixtureEntity("b", "1"), Action: store.WatchCreate})
+	assert.Len(t, cacher.Get("b"), 1)
+	assert.Equal(t, int64(4), cacher.Count())
+
+	// Resources deleted
+	cacher.update(store.WatchEventResource{Resource: fixtureEntity("a", "1"), Action: store.WatchDelete})
+	cacher.update(store.WatchEventResource{Resource: fixtureEntity("b", "1"), Action: store.WatchDelete})
+	assert.False(t, cacher.update(store.WatchEventResource{Resource: fixtureEntity("b", "2"), Action: store.WatchDelete}))
+	got = cacher.Get("a")
+	require.Len(t, got, 2)
+	assert.Equal(t, "2", got[0].Resource.GetObjectMeta().Name)
+	assert.Empty(t, cacher.Get("b"))
+	assert.Equal(t, int64(2), cacher.Count())
+}
+
+func TestResourceNotifications(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	events := make(chan store.WatchEventResource)
+	cacher := &Resource{
+		cache:       buildCache(nil, false),
+		watcher:     events,
+		notifyDelay: 100 * time.Millisecond,
+	}
+	watcher := cacher.Watch(ctx)
+	go cacher.start(ctx)
+
+	// The changes are coalesced into a single notification
+	for _, name := range []string{"1", "2", "3"} {
+		events <- store.WatchEventResource{Resource: fixtureEntity("a", name), Action: store.WatchCreate}
+	}
+	select {
+	case <-watcher:
+	case <-time.After(5 * time.Second):
+		t.Fatal("watchers not notified")
+	}
+	assert.Len(t, cacher.Get("a"), 3)
+	select {
+	case <-watcher:
+		t.Fatal("watchers notified more than once")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	// Keepalives are not notified
+	entity := fixtureEntity("a", "1")
+	entity.LastSeen += 20
+	events <- store.WatchEventResource{Resource: entity, Action: store.WatchUpdate}
+	select {
+	case <-watcher:
+		t.Fatal("watchers notified of a keepalive")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -0,0 +1,153 @@
+// Package encryption provides the envelope encryption of the values stored by
+// the backend. The values are encrypted with AES-GCM using a data key, which is
+// itself encrypted by a KeyWrapper and stored alongside every value, so that
+// the key encryption key can be kept outside of the store, e.g. in a key
+// management service.
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// KeySize is the size in bytes of the AES-256 keys.
+const KeySize = 32
+
+// prefix starts the encrypted values. The leading NUL byte can neither start
+// a JSON document nor a protobuf message, so encrypted values are never
+// mistaken for plaintext ones.
+var prefix = []byte("\x00sensu-enc:v1")
+
+// KeyWrapper encrypts and decrypts the data keys, i.e. it holds the key
+// encryption key. It can be implemented with a key management service.
+type KeyWrapper interface {
+	// WrapKey encrypts a data key.
+	WrapKey(key []byte) ([]byte, error)
+
+	// UnwrapKey decrypts a data key encrypted by WrapKey.
+	UnwrapKey(wrapped []byte) ([]byte, error)
+}
+
+// Cipher encrypts values with a data key generated when it is created, and
+// decrypts values encrypted with any data key its KeyWrapper can unwrap.
+type Cipher struct {
+	wrapper KeyWrapper
+
+	aead    cipher.AEAD
+	wrapped []byte
+
+	mu   sync.Mutex
+	keys map[string]cipher.AEAD
+}
+
+// NewCipher creates a cipher generating its data key and wrapping it with
+// wrapper.
+func NewCipher(wrapper KeyWrapper) (*Cipher, error) {
+	key := make([]byte, KeySize)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return nil, fmt.Errorf("could not generate the data key: %s", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := wrapper.WrapKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("could not wrap the data key: %s", err)
+	}
+	return &Cipher{
+		wrapper: wrapper,
+		aead:    aead,
+		wrapped: wrapped,
+		keys:    map[string]cipher.AEAD{string(wrapped): aead},
+	}, nil
+}
+
+// IsEncrypted returns whether data was encrypted by a Cipher.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, prefix)
+}
+
+// Encrypt encrypts plaintext, and authenticates it along with additionalData,
+// which must be given again to decrypt it. The result holds the wrapped data
+// key, the nonce and the ciphertext.
+func (c *Cipher) Encrypt(plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(prefix)+binary.MaxVarintLen64+len(c.wrapped)+len(nonce)+len(plaintext)+c.aead.Overhead())
+	out = append(out, prefix...)
+	var n [binary.MaxVarintLen64]byte
+	out = append(out, n[:binary.PutUvarint(n[:], uint64(len(c.wrapped)))]...)
+	out = append(out, c.wrapped...)
+	out = append(out, nonce...)
+	return c.aead.Seal(out, nonce, plaintext, additionalData), nil
+}
+
+// Decrypt decrypts data encrypted by Encrypt with the same additionalData,
+// unwrapping its data key if it was encrypted by another cipher.
+func (c *Cipher) Decrypt(data, additionalData []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return nil, errors.New("value is not encrypted")
+	}
+	data = data[len(prefix):]
+
+	size, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < size {
+		return nil, errors.New("malformed encrypted value")
+	}
+	wrapped := data[n : n+int(size)]
+	data = data[n+int(size):]
+
+	aead, err := c.dataKey(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("malformed encrypted value")
+	}
+	nonce, ciphertext := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt value: %s", err)
+	}
+	return plaintext, nil
+}
+
+// dataKey returns the data key wrapped as wrapped, which is unwrapped only
+// once since it can involve a remote key management service.
+func (c *Cipher) dataKey(wrapped []byte) (cipher.AEAD, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if aead, ok := c.keys[string(wrapped)]; ok {
+		return aead, nil
+	}
+	key, err := c.wrapper.UnwrapKey(wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("could not unwrap the data key: %s", err)
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	c.keys[string(wrapped)] = aead
+	return aead, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,104 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, KeySize)
+}
+
+func TestCipher(t *testing.T) {
+	wrapper, err := NewLocalKeyWrapper(testKey(1))
+	require.NoError(t, err)
+	c, err := NewCipher(wrapper)
+	require.NoError(t, err)
+
+	plaintext := []byte("check output with a password")
+	key := []byte("/sensu.io/events/default/entity/check")
+	encrypted, err := c.Encrypt(plaintext, key)
+	require.NoError(t, err)
+	assert.True(t, IsEncrypted(encrypted))
+	assert.False(t, bytes.Contains(encrypted, plaintext))
+	assert.False(t, IsEncrypted(plaintext))
+
+	decrypted, err := c.Decrypt(encrypted, key)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	// The values are bound to their additional data
+	_, err = c.Decrypt(encrypted, []byte("/sensu.io/events/default/other/check"))
+	assert.Error(t, err)
+
+	// Values encrypted by other ciphers, e.g. of other backends, are decrypted
+	// as long as their data key can be unwrapped
+	other, err := NewCipher(wrapper)
+	require.NoError(t, err)
+	decrypted, err = other.Decrypt(encrypted, key)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	// Tampered values are rejected
+	encrypted[len(encrypted)-1] ^= 1
+	_, err = c.Decrypt(encrypted, key)
+	assert.Error(t, err)
+
+	_, err = c.Decrypt(encrypted[:len(prefix)+2], key)
+	assert.Error(t, err)
+	_, err = c.Decrypt(plaintext, key)
+	assert.Error(t, err)
+}
+
+func TestLocalKeyWrapperRotation(t *testing.T) {
+	oldWrapper, err := NewLocalKeyWrapper(testKey(1))
+	require.NoError(t, err)
+	oldCipher, err := NewCipher(oldWrapper)
+	require.NoError(t, err)
+	encrypted, err := oldCipher.Encrypt([]byte("value"), nil)
+	require.NoError(t, err)
+
+	// The previous key still unwraps the data keys it wrapped
+	wrapper, err := NewLocalKeyWrapper(testKey(2), testKey(1))
+	require.NoError(t, err)
+	c, err := NewCipher(wrapper)
+	require.NoError(t, err)
+	decrypted, err := c.Decrypt(encrypted, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "value", string(decrypted))
+
+	// Without it, the values can't be decrypted
+	wrapper, err = NewLocalKeyWrapper(testKey(2))
+	require.NoError(t, err)
+	c, err = NewCipher(wrapper)
+	require.NoError(t, err)
+	_, err = c.Decrypt(encrypted, nil)
+	assert.Error(t, err)
+}
+
+func TestNewLocalKeyWrapperFromFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "encryption")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	keyFile := filepath.Join(dir, "key")
+	encoded := base64.StdEncoding.EncodeToString(testKey(1))
+	require.NoError(t, ioutil.WriteFile(keyFile, []byte(encoded+"\n"), 0600))
+	_, err = NewLocalKeyWrapperFromFiles(keyFile)
+	assert.NoError(t, err)
+
+	shortKeyFile := filepath.Join(dir, "short")
+	require.NoError(t, ioutil.WriteFile(shortKeyFile, []byte(base64.StdEncoding.EncodeToString([]byte("short"))), 0600))
+	_, err = NewLocalKeyWrapperFromFiles(shortKeyFile)
+	assert.Error(t, err)
+
+	_, err = NewLocalKeyWrapperFromFiles(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
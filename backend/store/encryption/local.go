@@ -0,0 +1,94 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+)
+
+// keyIDSize is the size of the key ids prepended to the wrapped data keys.
+const keyIDSize = 4
+
+// LocalKeyWrapper wraps the data keys with AES-GCM using local keys. The
+// first key wraps the new data keys, while the others are only used to unwrap
+// the data keys wrapped before a key rotation.
+type LocalKeyWrapper struct {
+	ids   [][]byte
+	aeads []cipher.AEAD
+}
+
+// NewLocalKeyWrapper creates a LocalKeyWrapper from keys of KeySize bytes.
+func NewLocalKeyWrapper(keys ...[]byte) (*LocalKeyWrapper, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("at least one key is required")
+	}
+	w := &LocalKeyWrapper{}
+	for _, key := range keys {
+		if len(key) != KeySize {
+			return nil, fmt.Errorf("keys must be %d bytes long", KeySize)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(key)
+		w.ids = append(w.ids, sum[:keyIDSize])
+		w.aeads = append(w.aeads, aead)
+	}
+	return w, nil
+}
+
+// NewLocalKeyWrapperFromFiles creates a LocalKeyWrapper from the keys read
+// from the files at paths, each holding a base64 encoded key.
+func NewLocalKeyWrapperFromFiles(paths ...string) (*LocalKeyWrapper, error) {
+	keys := make([][]byte, 0, len(paths))
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+		if err != nil {
+			return nil, fmt.Errorf("key file %s is not base64 encoded: %s", path, err)
+		}
+		keys = append(keys, key)
+	}
+	return NewLocalKeyWrapper(keys...)
+}
+
+// WrapKey encrypts key with the first key of the wrapper. The wrapped key is
+// prefixed with the id of the key encrypting it.
+func (w *LocalKeyWrapper) WrapKey(key []byte) ([]byte, error) {
+	aead := w.aeads[0]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	out := append(append([]byte{}, w.ids[0]...), nonce...)
+	return aead.Seal(out, nonce, key, nil), nil
+}
+
+// UnwrapKey decrypts a key wrapped by any of the keys of the wrapper.
+func (w *LocalKeyWrapper) UnwrapKey(wrapped []byte) ([]byte, error) {
+	if len(wrapped) < keyIDSize {
+		return nil, errors.New("malformed wrapped key")
+	}
+	for i, id := range w.ids {
+		if !bytes.Equal(id, wrapped[:keyIDSize]) {
+			continue
+		}
+		aead := w.aeads[i]
+		data := wrapped[keyIDSize:]
+		if len(data) < aead.NonceSize() {
+			return nil, errors.New("malformed wrapped key")
+		}
+		return aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	}
+	return nil, errors.New("the key wrapping the data key is not configured")
+}
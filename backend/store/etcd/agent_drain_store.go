@@ -0,0 +1,79 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+const (
+	agentDrainsPathPrefix = "agent_drains"
+)
+
+func getAgentDrainsPath() string {
+	return path.Join(EtcdRoot, agentDrainsPathPrefix) + "/"
+}
+
+func getAgentDrainPath(backend string) string {
+	return getAgentDrainsPath() + backend
+}
+
+func validateBackendName(backend string) error {
+	if backend == "" || strings.Contains(backend, "/") {
+		return &store.ErrNotValid{Err: errors.New("the backend name must not be empty or contain a slash")}
+	}
+	return nil
+}
+
+// GetAgentDrain returns whether the agent sessions of the backend are drained.
+func (s *Store) GetAgentDrain(ctx context.Context, backend string) (bool, error) {
+	if err := validateBackendName(backend); err != nil {
+		return false, err
+	}
+
+	resp, err := s.client.Get(ctx, getAgentDrainPath(backend), clientv3.WithCountOnly())
+	if err != nil {
+		return false, &store.ErrInternal{Message: err.Error()}
+	}
+	return resp.Count > 0, nil
+}
+
+// GetAgentDrains returns the names of the backends whose agent sessions are
+// drained.
+func (s *Store) GetAgentDrains(ctx context.Context) ([]string, error) {
+	prefix := getAgentDrainsPath()
+	resp, err := s.client.Get(ctx, prefix, clientv3.WithPrefix(), clientv3.WithKeysOnly())
+	if err != nil {
+		return nil, &store.ErrInternal{Message: err.Error()}
+	}
+
+	backends := make([]string, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		backends = append(backends, strings.TrimPrefix(string(kv.Key), prefix))
+	}
+	return backends, nil
+}
+
+// UpdateAgentDrain starts draining the agent sessions of the backend, or stops
+// draining them if draining is false. A drain is recorded by the presence of
+// its key.
+func (s *Store) UpdateAgentDrain(ctx context.Context, backend string, draining bool) error {
+	if err := validateBackendName(backend); err != nil {
+		return err
+	}
+
+	var err error
+	if draining {
+		_, err = s.client.Put(ctx, getAgentDrainPath(backend), "")
+	} else {
+		_, err = s.client.Delete(ctx, getAgentDrainPath(backend))
+	}
+	if err != nil {
+		return &store.ErrInternal{Message: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+// +build integration,!race
+
+package etcd
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAgentDrainStorage(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		watcher := s.GetAgentDrainWatcher(ctx, "backend-1")
+
+		require.NoError(t, s.UpdateAgentDrain(ctx, "backend-1", true))
+		require.NoError(t, s.UpdateAgentDrain(ctx, "backend-2", true))
+		draining, err := s.GetAgentDrain(ctx, "backend-1")
+		require.NoError(t, err)
+		assert.True(t, draining)
+
+		backends, err := s.GetAgentDrains(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"backend-1", "backend-2"}, backends)
+
+		event := <-watcher
+		assert.Equal(t, store.WatchCreate, event.Action)
+		assert.True(t, event.Draining)
+
+		require.NoError(t, s.UpdateAgentDrain(ctx, "backend-1", false))
+		draining, err = s.GetAgentDrain(ctx, "backend-1")
+		require.NoError(t, err)
+		assert.False(t, draining)
+
+		event = <-watcher
+		assert.Equal(t, store.WatchDelete, event.Action)
+		assert.False(t, event.Draining)
+
+		// The backend names can't be mistaken for a key prefix
+		assert.Error(t, s.UpdateAgentDrain(ctx, "backend/1", true))
+	})
+}
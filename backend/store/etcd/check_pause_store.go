@@ -0,0 +1,77 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/types"
+)
+
+const (
+	checkPausesPathPrefix = "check_pauses"
+)
+
+var (
+	checkPauseKeyBuilder = store.NewKeyBuilder(checkPausesPathPrefix)
+)
+
+func getCheckPausePath(ctx context.Context, name string) string {
+	return checkPauseKeyBuilder.WithContext(ctx).Build(name)
+}
+
+// GetCheckPause gets the pause of a check.
+func (s *Store) GetCheckPause(ctx context.Context, name string) (*types.CheckPause, error) {
+	if name == "" {
+		return nil, &store.ErrNotValid{Err: errors.New("must specify name")}
+	}
+
+	resp, err := s.client.Get(ctx, getCheckPausePath(ctx, name))
+	if err != nil {
+		return nil, &store.ErrInternal{Message: err.Error()}
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+
+	pause := &types.CheckPause{}
+	if err := unmarshal(resp.Kvs[0].Value, pause); err != nil {
+		return nil, &store.ErrDecode{Err: err}
+	}
+
+	return pause, nil
+}
+
+// UpdateCheckPause pauses or resumes a check.
+func (s *Store) UpdateCheckPause(ctx context.Context, name string, pause *types.CheckPause) error {
+	if name == "" {
+		return &store.ErrNotValid{Err: errors.New("must specify name")}
+	}
+
+	key := getCheckPausePath(ctx, name)
+	if !pause.Paused {
+		if _, err := s.client.Delete(ctx, key); err != nil {
+			return &store.ErrInternal{Message: err.Error()}
+		}
+		return nil
+	}
+
+	bytes, err := marshal(pause)
+	if err != nil {
+		return &store.ErrEncode{Key: key, Err: err}
+	}
+
+	checkKey := GetCheckConfigsPath(ctx, name)
+	cmp := clientv3.Compare(clientv3.Version(checkKey), ">", 0)
+	req := clientv3.OpPut(key, string(bytes))
+	res, err := s.client.Txn(ctx).If(cmp).Then(req).Commit()
+	if err != nil {
+		return &store.ErrInternal{Message: err.Error()}
+	}
+	if !res.Succeeded {
+		return &store.ErrNotFound{Key: checkKey}
+	}
+
+	return nil
+}
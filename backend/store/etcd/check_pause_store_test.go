@@ -0,0 +1,47 @@
+// +build integration,!race
+
+package etcd
+
+import (
+	"context"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPauseStorage(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		check := corev2.FixtureCheckConfig("check1")
+		ctx := context.WithValue(context.Background(), corev2.NamespaceKey, check.Namespace)
+		require.NoError(t, s.UpdateCheckConfig(ctx, check))
+
+		pause, err := s.GetCheckPause(ctx, "check1")
+		require.NoError(t, err)
+		assert.Nil(t, pause)
+
+		paused := &corev2.CheckPause{Paused: true, Reason: "maintenance", PausedBy: "admin"}
+		require.NoError(t, s.UpdateCheckPause(ctx, "check1", paused))
+
+		pause, err = s.GetCheckPause(ctx, "check1")
+		require.NoError(t, err)
+		assert.Equal(t, paused, pause)
+
+		require.NoError(t, s.UpdateCheckPause(ctx, "check1", &corev2.CheckPause{}))
+		pause, err = s.GetCheckPause(ctx, "check1")
+		require.NoError(t, err)
+		assert.Nil(t, pause)
+
+		// Missing checks can't be paused
+		assert.Error(t, s.UpdateCheckPause(ctx, "missing", paused))
+
+		// Deleting a check resumes it
+		require.NoError(t, s.UpdateCheckPause(ctx, "check1", paused))
+		require.NoError(t, s.DeleteCheckConfigByName(ctx, "check1"))
+		pause, err = s.GetCheckPause(ctx, "check1")
+		require.NoError(t, err)
+		assert.Nil(t, pause)
+	})
+}
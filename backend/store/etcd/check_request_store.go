@@ -0,0 +1,123 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"path"
+
+	"github.com/coreos/etcd/clientv3"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+const (
+	checkRequestsPathPrefix = "check_requests"
+)
+
+// getCheckRequestsPath returns the path prefix of the check requests issued to
+// the subscription of the namespace.
+func getCheckRequestsPath(namespace, subscription string) string {
+	return path.Join(EtcdRoot, checkRequestsPathPrefix, namespace, subscription) + "/"
+}
+
+// getCheckRequestPath returns the path of the latest check request of a check
+// issued to the subscription, and of its proxy entity for proxy checks.
+func getCheckRequestPath(subscription string, request *corev2.CheckRequest) string {
+	return getCheckRequestsPath(request.Config.Namespace, subscription) + path.Join(request.Config.Name, request.Config.ProxyEntityName) + "/"
+}
+
+// RecordCheckRequests records the check requests issued to the subscriptions
+// under a single lease, expiring after ttl seconds.
+func (s *Store) RecordCheckRequests(ctx context.Context, requests []*store.SubscriptionCheckRequest, ttl int64) error {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	values := make(map[string]string, len(requests))
+	for _, r := range requests {
+		if r.Request == nil || r.Request.Config == nil || r.Request.Config.Namespace == "" || r.Subscription == "" {
+			return &store.ErrNotValid{Err: errors.New("must specify the subscription and the namespace of the check request")}
+		}
+		b, err := marshal(r.Request)
+		if err != nil {
+			return &store.ErrEncode{Err: err}
+		}
+		values[getCheckRequestPath(r.Subscription, r.Request)] = string(b)
+	}
+
+	lease, err := s.client.Grant(ctx, ttl)
+	if err != nil {
+		return &store.ErrInternal{Message: err.Error()}
+	}
+	ops := make([]clientv3.Op, 0, len(values))
+	for key, value := range values {
+		ops = append(ops, clientv3.OpPut(key, value, clientv3.WithLease(lease.ID)))
+	}
+
+	// Split the operations in transactions of the maximum size allowed by etcd
+	for len(ops) > 0 {
+		n := len(ops)
+		if n > maxTxnOps {
+			n = maxTxnOps
+		}
+		if _, err := s.client.Txn(ctx).Then(ops[:n]...).Commit(); err != nil {
+			return &store.ErrInternal{Message: err.Error()}
+		}
+		ops = ops[n:]
+	}
+	return nil
+}
+
+// GetCheckRequests gets the check requests recorded for the subscriptions of
+// the namespace, in as few transactions as possible.
+func (s *Store) GetCheckRequests(ctx context.Context, subscriptions []string) ([]*store.SubscriptionCheckRequest, error) {
+	namespace := corev2.ContextNamespace(ctx)
+	if namespace == "" {
+		return nil, &store.ErrNotValid{Err: errors.New("namespace missing from context")}
+	}
+
+	var requests []*store.SubscriptionCheckRequest
+	for i := 0; i < len(subscriptions); i += maxTxnOps {
+		end := i + maxTxnOps
+		if end > len(subscriptions) {
+			end = len(subscriptions)
+		}
+		gets := make([]clientv3.Op, 0, end-i)
+		for _, subscription := range subscriptions[i:end] {
+			gets = append(gets, clientv3.OpGet(getCheckRequestsPath(namespace, subscription), clientv3.WithPrefix()))
+		}
+		resp, err := s.client.Txn(ctx).Then(gets...).Commit()
+		if err != nil {
+			return nil, &store.ErrInternal{Message: err.Error()}
+		}
+		for j, r := range resp.Responses {
+			for _, kv := range r.GetResponseRange().Kvs {
+				request := &corev2.CheckRequest{}
+				if err := unmarshal(kv.Value, request); err != nil {
+					return nil, &store.ErrDecode{Key: string(kv.Key), Err: err}
+				}
+				requests = append(requests, &store.SubscriptionCheckRequest{
+					Subscription: subscriptions[i+j],
+					Request:      request,
+				})
+			}
+		}
+	}
+	return requests, nil
+}
+
+// DeleteCheckRequests deletes the check requests of the check recorded for
+// the subscription of the namespace, including the ones of its proxy
+// entities.
+func (s *Store) DeleteCheckRequests(ctx context.Context, subscription, check string) error {
+	namespace := corev2.ContextNamespace(ctx)
+	if namespace == "" {
+		return &store.ErrNotValid{Err: errors.New("namespace missing from context")}
+	}
+
+	key := getCheckRequestsPath(namespace, subscription) + check + "/"
+	if _, err := s.client.Delete(ctx, key, clientv3.WithPrefix()); err != nil {
+		return &store.ErrInternal{Message: err.Error()}
+	}
+	return nil
+}
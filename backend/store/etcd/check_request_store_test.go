@@ -0,0 +1,56 @@
+// +build integration,!race
+
+package etcd
+
+import (
+	"context"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckRequestStorage(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx := context.WithValue(context.Background(), corev2.NamespaceKey, "default")
+
+		request := func(check string, issued int64) *corev2.CheckRequest {
+			r := corev2.FixtureCheckRequest(check)
+			r.Issued = issued
+			return r
+		}
+		proxy := request("check1", 3)
+		proxy.Config.ProxyEntityName = "proxy"
+		require.NoError(t, s.RecordCheckRequests(ctx, []*store.SubscriptionCheckRequest{
+			{Subscription: "linux", Request: request("check1", 1)},
+			{Subscription: "linux", Request: proxy},
+			{Subscription: "web", Request: request("check2", 1)},
+			{Subscription: "windows", Request: request("check3", 1)},
+		}, 60))
+
+		// The latest request of each check replaces the previous one
+		require.NoError(t, s.RecordCheckRequests(ctx, []*store.SubscriptionCheckRequest{
+			{Subscription: "linux", Request: request("check1", 2)},
+		}, 60))
+
+		requests, err := s.GetCheckRequests(ctx, []string{"linux", "web"})
+		require.NoError(t, err)
+		require.Len(t, requests, 3)
+		assert.Equal(t, "linux", requests[0].Subscription)
+		assert.Equal(t, int64(2), requests[0].Request.Issued)
+		assert.Equal(t, "proxy", requests[1].Request.Config.ProxyEntityName)
+		assert.Equal(t, "web", requests[2].Subscription)
+
+		// The requests of the proxy entities of the check are deleted too
+		require.NoError(t, s.DeleteCheckRequests(ctx, "linux", "check1"))
+		requests, err = s.GetCheckRequests(ctx, []string{"linux"})
+		require.NoError(t, err)
+		assert.Empty(t, requests)
+
+		// A request without subscription is rejected
+		err = s.RecordCheckRequests(ctx, []*store.SubscriptionCheckRequest{{Request: request("check1", 1)}}, 60)
+		assert.Error(t, err)
+	})
+}
@@ -33,10 +33,14 @@ func (s *Store) DeleteCheckConfigByName(ctx context.Context, name string) error
 		return &store.ErrNotValid{Err: errors.New("must specify name")}
 	}
 
-	if _, err := s.client.Delete(ctx, GetCheckConfigsPath(ctx, name)); err != nil {
-		return &store.ErrInternal{Message: err.Error()}
+	// The check is resumed along with its deletion, so it's not paused if
+	// it's created again
+	namespace := store.NewNamespaceFromContext(ctx)
+	err := cascadeOf(GetCheckConfigsPath(ctx, name), checksPathPrefix, namespace, name).delete(ctx, s.client)
+	if _, ok := err.(*store.ErrNotFound); ok {
+		return nil
 	}
-	return nil
+	return err
 }
 
 // GetCheckConfigs returns check configurations for an (optional) namespace.
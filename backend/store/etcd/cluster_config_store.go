@@ -0,0 +1,54 @@
+package etcd
+
+import (
+	"context"
+	"path"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+const (
+	clusterConfigPathPrefix = "cluster_config"
+)
+
+func getClusterConfigPath() string {
+	return path.Join(EtcdRoot, clusterConfigPathPrefix)
+}
+
+// GetClusterConfig gets the cluster configuration.
+func (s *Store) GetClusterConfig(ctx context.Context) (*corev2.ClusterConfig, error) {
+	resp, err := s.client.Get(ctx, getClusterConfigPath())
+	if err != nil {
+		return nil, &store.ErrInternal{Message: err.Error()}
+	}
+
+	config := &corev2.ClusterConfig{}
+	if len(resp.Kvs) == 0 {
+		return config, nil
+	}
+	if err := unmarshal(resp.Kvs[0].Value, config); err != nil {
+		return nil, &store.ErrDecode{Err: err}
+	}
+
+	return config, nil
+}
+
+// UpdateClusterConfig updates the cluster configuration.
+func (s *Store) UpdateClusterConfig(ctx context.Context, config *corev2.ClusterConfig) error {
+	if err := config.Validate(); err != nil {
+		return &store.ErrNotValid{Err: err}
+	}
+
+	key := getClusterConfigPath()
+	bytes, err := marshal(config)
+	if err != nil {
+		return &store.ErrEncode{Key: key, Err: err}
+	}
+
+	if _, err := s.client.Put(ctx, key, string(bytes)); err != nil {
+		return &store.ErrInternal{Message: err.Error()}
+	}
+
+	return nil
+}
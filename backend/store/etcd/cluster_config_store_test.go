@@ -0,0 +1,41 @@
+// +build integration,!race
+
+package etcd
+
+import (
+	"context"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClusterConfigStorage(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		config, err := s.GetClusterConfig(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &corev2.ClusterConfig{}, config)
+
+		watcher := s.GetClusterConfigWatcher(ctx)
+
+		disabled := false
+		updated := &corev2.ClusterConfig{KeepaliveTimeout: 60, EventTTL: 3600, RegistrationEvents: &disabled}
+		require.NoError(t, s.UpdateClusterConfig(ctx, updated))
+
+		config, err = s.GetClusterConfig(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, updated, config)
+
+		event := <-watcher
+		assert.Equal(t, store.WatchCreate, event.Action)
+		assert.Equal(t, updated, event.ClusterConfig)
+
+		// Invalid configurations are refused
+		assert.Error(t, s.UpdateClusterConfig(ctx, &corev2.ClusterConfig{KeepaliveTimeout: 1}))
+	})
+}
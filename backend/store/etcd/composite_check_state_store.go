@@ -0,0 +1,166 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/etcd/clientv3"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+const (
+	compositeCheckStatesPathPrefix = "composite_check_states"
+)
+
+// getCompositeCheckStatesPath returns the path prefix of the statuses of the
+// members of a composite check.
+func getCompositeCheckStatesPath(composite *corev2.CompositeCheck) string {
+	return path.Join(EtcdRoot, compositeCheckStatesPathPrefix, composite.Namespace, composite.Name) + "/"
+}
+
+func getCompositeCheckStatePath(composite *corev2.CompositeCheck, member corev2.CompositeMember) string {
+	return getCompositeCheckStatesPath(composite) + path.Join(member.Entity, member.Check)
+}
+
+// compositeCheckStates returns the statuses of the members of the composite
+// check found in resp. The statuses of former members are ignored.
+func compositeCheckStates(composite *corev2.CompositeCheck, resp *clientv3.GetResponse) (map[corev2.CompositeMember]uint32, error) {
+	prefix := getCompositeCheckStatesPath(composite)
+	states := make(map[corev2.CompositeMember]uint32, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		parts := strings.Split(strings.TrimPrefix(string(kv.Key), prefix), "/")
+		if len(parts) != 2 {
+			continue
+		}
+		member := corev2.CompositeMember{Entity: parts[0], Check: parts[1]}
+		if !composite.HasMember(member.Entity, member.Check) {
+			continue
+		}
+		status, err := strconv.ParseUint(string(kv.Value), 10, 32)
+		if err != nil {
+			return nil, &store.ErrDecode{Key: string(kv.Key), Err: err}
+		}
+		states[member] = uint32(status)
+	}
+	return states, nil
+}
+
+func validateCompositeCheck(composite *corev2.CompositeCheck) error {
+	if composite == nil || composite.Namespace == "" || composite.Name == "" {
+		return &store.ErrNotValid{Err: errors.New("must specify the namespace and name of the composite check")}
+	}
+	return nil
+}
+
+// InitializeCompositeCheckStates records the statuses of the members of the
+// composite check from their events, and returns them by member. The events
+// are read in as few transactions as possible.
+func (s *Store) InitializeCompositeCheckStates(ctx context.Context, composite *corev2.CompositeCheck) (map[corev2.CompositeMember]uint32, error) {
+	if err := validateCompositeCheck(composite); err != nil {
+		return nil, err
+	}
+
+	gets := make([]clientv3.Op, 0, len(composite.Members))
+	for _, member := range composite.Members {
+		gets = append(gets, clientv3.OpGet(path.Join(EtcdRoot, eventsPathPrefix, composite.Namespace, member.Entity, member.Check)))
+	}
+
+	states := make(map[corev2.CompositeMember]uint32, len(composite.Members))
+	for i := 0; i < len(gets); i += maxTxnOps {
+		end := i + maxTxnOps
+		if end > len(gets) {
+			end = len(gets)
+		}
+		resp, err := s.client.Txn(ctx).Then(gets[i:end]...).Commit()
+		if err != nil {
+			return nil, &store.ErrInternal{Message: err.Error()}
+		}
+		for j, r := range resp.Responses {
+			kvs := r.GetResponseRange().Kvs
+			if len(kvs) == 0 {
+				continue
+			}
+			event := &corev2.Event{}
+			if err := decode(s.cipher, string(kvs[0].Key), kvs[0].Value, event); err != nil {
+				return nil, &store.ErrDecode{Key: string(kvs[0].Key), Err: err}
+			}
+			if event.HasCheck() {
+				states[composite.Members[i+j]] = event.Check.Status
+			}
+		}
+	}
+
+	// Forget the statuses of the former members along with the recorded ones
+	ops := []clientv3.Op{clientv3.OpDelete(getCompositeCheckStatesPath(composite), clientv3.WithPrefix())}
+	for member, status := range states {
+		ops = append(ops, clientv3.OpPut(getCompositeCheckStatePath(composite, member), strconv.FormatUint(uint64(status), 10)))
+	}
+	for len(ops) > 0 {
+		n := len(ops)
+		if n > maxTxnOps {
+			n = maxTxnOps
+		}
+		if _, err := s.client.Txn(ctx).Then(ops[:n]...).Commit(); err != nil {
+			return nil, &store.ErrInternal{Message: err.Error()}
+		}
+		ops = ops[n:]
+	}
+
+	return states, nil
+}
+
+// UpdateCompositeCheckState records the status of the member of the composite
+// check, and returns the statuses of all its members in the same transaction.
+func (s *Store) UpdateCompositeCheckState(ctx context.Context, composite *corev2.CompositeCheck, member corev2.CompositeMember, status uint32) (map[corev2.CompositeMember]uint32, error) {
+	if err := validateCompositeCheck(composite); err != nil {
+		return nil, err
+	}
+
+	put := clientv3.OpPut(getCompositeCheckStatePath(composite, member), strconv.FormatUint(uint64(status), 10))
+	get := clientv3.OpGet(getCompositeCheckStatesPath(composite), clientv3.WithPrefix())
+	resp, err := s.client.Txn(ctx).Then(put, get).Commit()
+	if err != nil {
+		return nil, &store.ErrInternal{Message: err.Error()}
+	}
+	return compositeCheckStates(composite, (*clientv3.GetResponse)(resp.Responses[1].GetResponseRange()))
+}
+
+// DeleteCompositeCheckState forgets the status of the member of the composite
+// check, and returns the statuses of its remaining members in the same
+// transaction. The statuses are nil if the status of the member wasn't
+// recorded, so that a single backend evaluates the composite check.
+func (s *Store) DeleteCompositeCheckState(ctx context.Context, composite *corev2.CompositeCheck, member corev2.CompositeMember) (map[corev2.CompositeMember]uint32, error) {
+	if err := validateCompositeCheck(composite); err != nil {
+		return nil, err
+	}
+
+	key := getCompositeCheckStatePath(composite, member)
+	cmp := clientv3.Compare(clientv3.Version(key), ">", 0)
+	del := clientv3.OpDelete(key)
+	get := clientv3.OpGet(getCompositeCheckStatesPath(composite), clientv3.WithPrefix())
+	resp, err := s.client.Txn(ctx).If(cmp).Then(del, get).Commit()
+	if err != nil {
+		return nil, &store.ErrInternal{Message: err.Error()}
+	}
+	if !resp.Succeeded {
+		return nil, nil
+	}
+	return compositeCheckStates(composite, (*clientv3.GetResponse)(resp.Responses[1].GetResponseRange()))
+}
+
+// DeleteCompositeCheckStates forgets the statuses of the members of the
+// composite check.
+func (s *Store) DeleteCompositeCheckStates(ctx context.Context, composite *corev2.CompositeCheck) error {
+	if err := validateCompositeCheck(composite); err != nil {
+		return err
+	}
+
+	if _, err := s.client.Delete(ctx, getCompositeCheckStatesPath(composite), clientv3.WithPrefix()); err != nil {
+		return &store.ErrInternal{Message: err.Error()}
+	}
+	return nil
+}
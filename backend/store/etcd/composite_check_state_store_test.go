@@ -0,0 +1,60 @@
+// +build integration,!race
+
+package etcd
+
+import (
+	"context"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompositeCheckStateStorage(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		web01 := corev2.CompositeMember{Entity: "web01", Check: "http"}
+		web02 := corev2.CompositeMember{Entity: "web02", Check: "http"}
+		composite := corev2.FixtureCompositeCheck("checkout")
+		ctx := context.WithValue(context.Background(), corev2.NamespaceKey, composite.Namespace)
+
+		event := corev2.FixtureEvent("web01", "http")
+		event.Check.Status = 2
+		_, _, err := s.UpdateEvent(ctx, event)
+		require.NoError(t, err)
+
+		// The statuses are initialized from the events of the members
+		statuses, err := s.InitializeCompositeCheckStates(ctx, composite)
+		require.NoError(t, err)
+		assert.Equal(t, map[corev2.CompositeMember]uint32{web01: 2}, statuses)
+
+		statuses, err = s.UpdateCompositeCheckState(ctx, composite, web02, 1)
+		require.NoError(t, err)
+		assert.Equal(t, map[corev2.CompositeMember]uint32{web01: 2, web02: 1}, statuses)
+
+		statuses, err = s.DeleteCompositeCheckState(ctx, composite, web01)
+		require.NoError(t, err)
+		assert.Equal(t, map[corev2.CompositeMember]uint32{web02: 1}, statuses)
+
+		// A status already forgotten returns no statuses
+		statuses, err = s.DeleteCompositeCheckState(ctx, composite, web01)
+		require.NoError(t, err)
+		assert.Nil(t, statuses)
+
+		// The statuses of the former members are forgotten
+		composite.Members = []corev2.CompositeMember{web01}
+		statuses, err = s.InitializeCompositeCheckStates(ctx, composite)
+		require.NoError(t, err)
+		assert.Equal(t, map[corev2.CompositeMember]uint32{web01: 2}, statuses)
+		composite.Members = []corev2.CompositeMember{web01, web02}
+		statuses, err = s.UpdateCompositeCheckState(ctx, composite, web01, 0)
+		require.NoError(t, err)
+		assert.Equal(t, map[corev2.CompositeMember]uint32{web01: 0}, statuses)
+
+		require.NoError(t, s.DeleteCompositeCheckStates(ctx, composite))
+		statuses, err = s.UpdateCompositeCheckState(ctx, composite, web02, 0)
+		require.NoError(t, err)
+		assert.Equal(t, map[corev2.CompositeMember]uint32{web02: 0}, statuses)
+	})
+}
@@ -0,0 +1,34 @@
+package etcd
+
+import (
+	"errors"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store/encryption"
+)
+
+// sealValue encrypts the encoded value v stored at key, if it is an event or
+// an entity and c is not nil. The key is authenticated along with the value,
+// so that an encrypted value copied to another key can't be read.
+func sealValue(c *encryption.Cipher, key string, v interface{}, data []byte) ([]byte, error) {
+	switch v.(type) {
+	case *corev2.Event, *corev2.Entity:
+	default:
+		return data, nil
+	}
+	if c == nil {
+		return data, nil
+	}
+	return c.Encrypt(data, []byte(key))
+}
+
+// openValue decrypts data stored at key if it is encrypted.
+func openValue(c *encryption.Cipher, key string, data []byte) ([]byte, error) {
+	if !encryption.IsEncrypted(data) {
+		return data, nil
+	}
+	if c == nil {
+		return nil, errors.New("value is encrypted but store encryption is not configured")
+	}
+	return c.Decrypt(data, []byte(key))
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 
 	"github.com/coreos/etcd/clientv3"
-	"github.com/gogo/protobuf/proto"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/store"
 )
@@ -66,8 +65,8 @@ func (s *Store) GetEntityByName(ctx context.Context, name string) (*corev2.Entit
 		return nil, nil
 	}
 	entity := &corev2.Entity{}
-	if err := unmarshal(resp.Kvs[0].Value, entity); err != nil {
-		return nil, &store.ErrDecode{Err: err}
+	if err := decode(s.cipher, string(resp.Kvs[0].Key), resp.Kvs[0].Value, entity); err != nil {
+		return nil, &store.ErrDecode{Key: string(resp.Kvs[0].Key), Err: err}
 	}
 
 	if entity.Labels == nil {
@@ -82,7 +81,7 @@ func (s *Store) GetEntityByName(ctx context.Context, name string) (*corev2.Entit
 // GetEntities returns the entities for the namespace in the supplied context.
 func (s *Store) GetEntities(ctx context.Context, pred *store.SelectionPredicate) ([]*corev2.Entity, error) {
 	entities := []*corev2.Entity{}
-	err := List(ctx, s.client, GetEntitiesPath, &entities, pred)
+	err := list(ctx, s.client, s.cipher, GetEntitiesPath, &entities, pred)
 	return entities, err
 }
 
@@ -92,13 +91,14 @@ func (s *Store) UpdateEntity(ctx context.Context, e *corev2.Entity) error {
 		return &store.ErrNotValid{Err: err}
 	}
 
-	eStr, err := proto.Marshal(e)
+	key := getEntityPath(e)
+	eStr, err := encode(s.cipher, key, e)
 	if err != nil {
-		return &store.ErrEncode{Err: err}
+		return &store.ErrEncode{Key: key, Err: err}
 	}
 
 	cmp := clientv3.Compare(clientv3.Version(getNamespacePath(e.Namespace)), ">", 0)
-	req := clientv3.OpPut(getEntityPath(e), string(eStr))
+	req := clientv3.OpPut(key, string(eStr))
 	res, err := s.client.Txn(ctx).If(cmp).Then(req).Commit()
 	if err != nil {
 		return &store.ErrInternal{Message: err.Error()}
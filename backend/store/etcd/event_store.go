@@ -9,7 +9,6 @@ import (
 	"time"
 
 	"github.com/coreos/etcd/clientv3"
-	"github.com/gogo/protobuf/proto"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/backend/store/provider"
 
@@ -65,7 +64,12 @@ func (s *Store) DeleteEventByEntityCheck(ctx context.Context, entityName, checkN
 		return &store.ErrNotValid{Err: err}
 	}
 
-	if _, err := s.client.Delete(ctx, path); err != nil {
+	// Delete the results of the handlers executed for the event and the
+	// breaches of the metric thresholds of its check along with it
+	deleteEvent := clientv3.OpDelete(path)
+	deleteResults := clientv3.OpDelete(getHandlerResultsPath(ctx, entityName, checkName), clientv3.WithPrefix())
+	deleteBreaches := clientv3.OpDelete(getMetricThresholdBreachesPath(ctx, entityName, checkName))
+	if _, err := s.client.Txn(ctx).Then(deleteEvent, deleteResults, deleteBreaches).Commit(); err != nil {
 		return &store.ErrInternal{Message: err.Error()}
 	}
 	return err
@@ -103,8 +107,8 @@ func (s *Store) GetEvents(ctx context.Context, pred *store.SelectionPredicate) (
 	events := []*corev2.Event{}
 	for _, kv := range resp.Kvs {
 		event := &corev2.Event{}
-		if err := unmarshal(kv.Value, event); err != nil {
-			return nil, &store.ErrDecode{Err: err}
+		if err := decode(s.cipher, string(kv.Key), kv.Value, event); err != nil {
+			return nil, &store.ErrDecode{Key: string(kv.Key), Err: err}
 		}
 
 		if event.Labels == nil {
@@ -152,8 +156,8 @@ func (s *Store) GetEventsByEntity(ctx context.Context, entityName string, pred *
 	events := []*corev2.Event{}
 	for _, kv := range resp.Kvs {
 		event := &corev2.Event{}
-		if err := unmarshal(kv.Value, event); err != nil {
-			return nil, &store.ErrDecode{Err: err}
+		if err := decode(s.cipher, string(kv.Key), kv.Value, event); err != nil {
+			return nil, &store.ErrDecode{Key: string(kv.Key), Err: err}
 		}
 
 		if event.Labels == nil {
@@ -197,8 +201,8 @@ func (s *Store) GetEventByEntityCheck(ctx context.Context, entityName, checkName
 
 	eventBytes := resp.Kvs[0].Value
 	event := &corev2.Event{}
-	if err := unmarshal(eventBytes, event); err != nil {
-		return nil, &store.ErrDecode{Err: err}
+	if err := decode(s.cipher, string(resp.Kvs[0].Key), eventBytes, event); err != nil {
+		return nil, &store.ErrDecode{Key: string(resp.Kvs[0].Key), Err: err}
 	}
 
 	if event.Labels == nil {
@@ -279,13 +283,14 @@ func (s *Store) UpdateEvent(ctx context.Context, event *corev2.Event) (*corev2.E
 
 	// update the history
 	// marshal the new event and store it.
-	eventBytes, err := proto.Marshal(persistEvent)
+	key := getEventPath(event)
+	eventBytes, err := encode(s.cipher, key, persistEvent)
 	if err != nil {
-		return nil, nil, &store.ErrEncode{Err: err}
+		return nil, nil, &store.ErrEncode{Key: key, Err: err}
 	}
 
 	cmp := namespaceExistsForResource(event.Entity)
-	req := clientv3.OpPut(getEventPath(event), string(eventBytes))
+	req := clientv3.OpPut(key, string(eventBytes))
 	res, err := s.client.Txn(ctx).If(cmp).Then(req).Commit()
 	if err != nil {
 		return nil, nil, &store.ErrInternal{Message: err.Error()}
@@ -3,12 +3,14 @@
 package etcd
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/encryption"
 	"github.com/sensu/sensu-go/testing/mockstore"
 	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
@@ -37,6 +39,49 @@ func TestEventStorageMaxOutputSize(t *testing.T) {
 	})
 }
 
+func TestEventStorageEncryption(t *testing.T) {
+	testWithEtcdStore(t, func(plain *Store) {
+		wrapper, err := encryption.NewLocalKeyWrapper(bytes.Repeat([]byte{1}, encryption.KeySize))
+		require.NoError(t, err)
+		c, err := encryption.NewCipher(wrapper)
+		require.NoError(t, err)
+		s := NewEncryptedStore(plain.client, "", c)
+
+		event := corev2.FixtureEvent("entity1", "check1")
+		event.Check.Output = "password=hunter2"
+		ctx := store.NamespaceContext(context.Background(), event.Entity.Namespace)
+		_, _, err = s.UpdateEvent(ctx, event)
+		require.NoError(t, err)
+
+		// The event is encrypted in etcd
+		resp, err := s.client.Get(ctx, getEventPath(event))
+		require.NoError(t, err)
+		require.Len(t, resp.Kvs, 1)
+		assert.True(t, encryption.IsEncrypted(resp.Kvs[0].Value))
+		assert.False(t, bytes.Contains(resp.Kvs[0].Value, []byte("hunter2")))
+
+		got, err := s.GetEventByEntityCheck(ctx, "entity1", "check1")
+		require.NoError(t, err)
+		assert.Equal(t, "password=hunter2", got.Check.Output)
+
+		events, err := s.GetEvents(ctx, &store.SelectionPredicate{})
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, "password=hunter2", events[0].Check.Output)
+
+		// Encrypted events copied to another key can't be read
+		other := corev2.FixtureEvent("entity2", "check1")
+		_, err = s.client.Put(ctx, getEventPath(other), string(resp.Kvs[0].Value))
+		require.NoError(t, err)
+		_, err = s.GetEventByEntityCheck(ctx, "entity2", "check1")
+		assert.Error(t, err)
+
+		// Encrypted events can't be read without the cipher
+		_, err = plain.GetEventByEntityCheck(ctx, "entity1", "check1")
+		assert.Error(t, err)
+	})
+}
+
 func TestEventStorage(t *testing.T) {
 	testWithEtcd(t, func(s store.Store) {
 		// Create new namespaces
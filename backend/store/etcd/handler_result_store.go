@@ -0,0 +1,81 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/types"
+)
+
+var (
+	handlerResultsPathPrefix = "handler_results"
+	handlerResultKeyBuilder  = store.NewKeyBuilder(handlerResultsPathPrefix)
+)
+
+// getHandlerResultsPath returns the path of the results of the handlers
+// executed for the event of the given entity and check.
+func getHandlerResultsPath(ctx context.Context, entity, check string) string {
+	return handlerResultKeyBuilder.WithContext(ctx).WithExactMatch().Build(entity, check)
+}
+
+// GetHandlerResults gets the latest result of every handler executed for the
+// event of the given entity and check.
+func (s *Store) GetHandlerResults(ctx context.Context, entity, check string) ([]*types.HandlerResult, error) {
+	if entity == "" || check == "" {
+		return nil, &store.ErrNotValid{Err: errors.New("must specify entity and check name")}
+	}
+
+	resp, err := s.client.Get(ctx, getHandlerResultsPath(ctx, entity, check), clientv3.WithPrefix())
+	if err != nil {
+		return nil, &store.ErrInternal{Message: err.Error()}
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+
+	results := make([]*types.HandlerResult, len(resp.Kvs))
+	for i, kv := range resp.Kvs {
+		result := &types.HandlerResult{}
+		if err := unmarshal(kv.Value, result); err != nil {
+			return nil, &store.ErrDecode{Err: err}
+		}
+		results[i] = result
+	}
+
+	return results, nil
+}
+
+// UpdateHandlerResults stores the results of handler executions, in
+// transactions of the maximum size allowed by etcd.
+func (s *Store) UpdateHandlerResults(ctx context.Context, results []*store.EventHandlerResult) error {
+	ops := make([]clientv3.Op, 0, len(results))
+	for _, r := range results {
+		if r.Namespace == "" || r.Entity == "" || r.Check == "" {
+			return &store.ErrNotValid{Err: errors.New("must specify namespace, entity and check name")}
+		}
+		if r.Result == nil || r.Result.Handler == "" {
+			return &store.ErrNotValid{Err: errors.New("must specify handler name")}
+		}
+
+		bytes, err := marshal(r.Result)
+		if err != nil {
+			return &store.ErrEncode{Err: err}
+		}
+		key := handlerResultKeyBuilder.WithNamespace(r.Namespace).Build(r.Entity, r.Check, r.Result.Handler)
+		ops = append(ops, clientv3.OpPut(key, string(bytes)))
+	}
+
+	for len(ops) > 0 {
+		n := len(ops)
+		if n > maxTxnOps {
+			n = maxTxnOps
+		}
+		if _, err := s.client.Txn(ctx).Then(ops[:n]...).Commit(); err != nil {
+			return &store.ErrInternal{Message: err.Error()}
+		}
+		ops = ops[n:]
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// +build integration,!race
+
+package etcd
+
+import (
+	"context"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandlerResultStorage(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		event := corev2.FixtureEvent("entity1", "check1")
+		ctx := context.WithValue(context.Background(), corev2.NamespaceKey, event.Entity.Namespace)
+
+		results, err := s.GetHandlerResults(ctx, "entity1", "check1")
+		require.NoError(t, err)
+		assert.Empty(t, results)
+
+		update := func(result *corev2.HandlerResult) *store.EventHandlerResult {
+			return &store.EventHandlerResult{Namespace: "default", Entity: "entity1", Check: "check1", Result: result}
+		}
+		slack := corev2.FixtureHandlerResult("slack")
+		email := corev2.FixtureHandlerResult("email")
+		require.NoError(t, s.UpdateHandlerResults(ctx, []*store.EventHandlerResult{update(slack), update(email)}))
+
+		// The latest result of a handler replaces the previous one
+		slack.Status = 1
+		slack.Output = "channel_not_found"
+		require.NoError(t, s.UpdateHandlerResults(ctx, []*store.EventHandlerResult{update(slack)}))
+
+		results, err = s.GetHandlerResults(ctx, "entity1", "check1")
+		require.NoError(t, err)
+		assert.Equal(t, []*corev2.HandlerResult{email, slack}, results)
+
+		// Results of other checks are not included
+		results, err = s.GetHandlerResults(ctx, "entity1", "check")
+		require.NoError(t, err)
+		assert.Empty(t, results)
+
+		// Deleting the event deletes its results
+		require.NoError(t, s.DeleteEventByEntityCheck(ctx, "entity1", "check1"))
+		results, err = s.GetHandlerResults(ctx, "entity1", "check1")
+		require.NoError(t, err)
+		assert.Empty(t, results)
+
+		_, err = s.GetHandlerResults(ctx, "", "check1")
+		assert.Error(t, err)
+		assert.Error(t, s.UpdateHandlerResults(ctx, []*store.EventHandlerResult{update(&corev2.HandlerResult{})}))
+	})
+}
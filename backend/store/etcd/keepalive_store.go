@@ -3,6 +3,8 @@ package etcd
 import (
 	"context"
 	"path"
+	"strconv"
+	"time"
 
 	"github.com/coreos/etcd/clientv3"
 	"github.com/gogo/protobuf/proto"
@@ -11,16 +13,40 @@ import (
 )
 
 const (
-	keepalivesPathPrefix = "keepalives"
+	keepalivesPathPrefix         = "keepalives"
+	keepaliveStatesPathPrefix    = "keepalive_states"
+	keepaliveConflictsPathPrefix = "keepalive_conflicts"
+
+	// maxTxnOps is the default maximum number of operations in an etcd
+	// transaction
+	maxTxnOps = 128
 )
 
 func getKeepalivePath(keepalivesPath string, entity *types.Entity) string {
 	return path.Join(keepalivesPath, entity.Namespace, entity.Name)
 }
 
+// getKeepaliveStatePath returns the canonical path of the failing keepalive
+// record of an entity. Unlike the records of each backend, it is shared by the
+// backends, and holds the record of the backend which processed the last
+// keepalive of the entity.
+func getKeepaliveStatePath(entity *types.Entity) string {
+	return path.Join(EtcdRoot, keepaliveStatesPathPrefix, entity.Namespace, entity.Name)
+}
+
+// getKeepaliveConflictPath returns the path of the conflict record of an
+// entity, shared by the backends so that the conflict is resolved whichever
+// backend the agents send their keepalives to.
+func getKeepaliveConflictPath(entity *types.Entity) string {
+	return path.Join(EtcdRoot, keepaliveConflictsPathPrefix, entity.Namespace, entity.Name)
+}
+
 // DeleteFailingKeepalive deletes a failing KeepaliveRecord.
 func (s *Store) DeleteFailingKeepalive(ctx context.Context, entity *types.Entity) error {
-	_, err := s.client.Delete(ctx, getKeepalivePath(s.keepalivesPath, entity))
+	_, err := s.client.Txn(ctx).Then(
+		clientv3.OpDelete(getKeepalivePath(s.keepalivesPath, entity)),
+		clientv3.OpDelete(getKeepaliveStatePath(entity)),
+	).Commit()
 	return err
 }
 
@@ -49,6 +75,25 @@ func (s *Store) GetFailingKeepalives(ctx context.Context) ([]*types.KeepaliveRec
 	return keepalives, nil
 }
 
+// GetFailingKeepalive gets the failing KeepaliveRecord of an entity, or nil if
+// its keepalives are not failing, whichever backend processed the last
+// keepalive of the entity.
+func (s *Store) GetFailingKeepalive(ctx context.Context, entity *types.Entity) (*types.KeepaliveRecord, error) {
+	resp, err := s.client.Get(ctx, getKeepaliveStatePath(entity))
+	if err != nil {
+		return nil, &store.ErrInternal{Message: err.Error()}
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+
+	keepalive := &types.KeepaliveRecord{}
+	if err := unmarshal(resp.Kvs[0].Value, keepalive); err != nil {
+		return nil, &store.ErrDecode{Key: string(resp.Kvs[0].Key), Err: err}
+	}
+	return keepalive, nil
+}
+
 // UpdateFailingKeepalive updates a failing KeepaliveRecord.
 func (s *Store) UpdateFailingKeepalive(ctx context.Context, entity *types.Entity, expiration int64) error {
 	kr := types.NewKeepaliveRecord(entity, expiration)
@@ -58,8 +103,10 @@ func (s *Store) UpdateFailingKeepalive(ctx context.Context, entity *types.Entity
 	}
 
 	cmp := clientv3.Compare(clientv3.Version(getNamespacePath(entity.Namespace)), ">", 0)
-	req := clientv3.OpPut(getKeepalivePath(s.keepalivesPath, entity), string(krBytes))
-	res, err := s.client.Txn(ctx).If(cmp).Then(req).Commit()
+	res, err := s.client.Txn(ctx).If(cmp).Then(
+		clientv3.OpPut(getKeepalivePath(s.keepalivesPath, entity), string(krBytes)),
+		clientv3.OpPut(getKeepaliveStatePath(entity), string(krBytes)),
+	).Commit()
 	if err != nil {
 		return &store.ErrInternal{Message: err.Error()}
 	}
@@ -69,3 +116,44 @@ func (s *Store) UpdateFailingKeepalive(ctx context.Context, entity *types.Entity
 
 	return err
 }
+
+// RecordKeepaliveConflict records the time of a conflicting keepalive of
+// entity, under a lease expiring after ttl seconds.
+func (s *Store) RecordKeepaliveConflict(ctx context.Context, entity *types.Entity, ttl int64) error {
+	lease, err := s.client.Grant(ctx, ttl)
+	if err != nil {
+		return &store.ErrInternal{Message: err.Error()}
+	}
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	if _, err := s.client.Put(ctx, getKeepaliveConflictPath(entity), now, clientv3.WithLease(lease.ID)); err != nil {
+		return &store.ErrInternal{Message: err.Error()}
+	}
+	return nil
+}
+
+// GetKeepaliveConflict gets the time, in seconds since the Unix epoch, of the
+// last conflicting keepalive of entity, or 0 if none was recorded.
+func (s *Store) GetKeepaliveConflict(ctx context.Context, entity *types.Entity) (int64, error) {
+	resp, err := s.client.Get(ctx, getKeepaliveConflictPath(entity))
+	if err != nil {
+		return 0, &store.ErrInternal{Message: err.Error()}
+	}
+	if len(resp.Kvs) == 0 {
+		return 0, nil
+	}
+	conflict, err := strconv.ParseInt(string(resp.Kvs[0].Value), 10, 64)
+	if err != nil {
+		return 0, &store.ErrDecode{Key: string(resp.Kvs[0].Key), Err: err}
+	}
+	return conflict, nil
+}
+
+// DeleteKeepaliveConflict deletes the conflict record of entity. It returns
+// false if the record was already deleted, e.g. by another backend.
+func (s *Store) DeleteKeepaliveConflict(ctx context.Context, entity *types.Entity) (bool, error) {
+	resp, err := s.client.Delete(ctx, getKeepaliveConflictPath(entity))
+	if err != nil {
+		return false, &store.ErrInternal{Message: err.Error()}
+	}
+	return resp.Deleted > 0, nil
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/coreos/etcd/clientv3"
 	"github.com/sensu/sensu-go/backend/store"
 	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
@@ -23,9 +24,77 @@ func TestKeepaliveStorage(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, 1, len(records))
 
+		record, err := store.GetFailingKeepalive(ctx, entity)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), record.Time)
+
+		record, err = store.GetFailingKeepalive(ctx, types.FixtureEntity("other"))
+		assert.NoError(t, err)
+		assert.Nil(t, record)
+
+		// The record of the last update is returned
+		err = store.UpdateFailingKeepalive(ctx, entity, 2)
+		assert.NoError(t, err)
+		record, err = store.GetFailingKeepalive(ctx, entity)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), record.Time)
+
+		err = store.DeleteFailingKeepalive(ctx, entity)
+		assert.NoError(t, err)
+		record, err = store.GetFailingKeepalive(ctx, entity)
+		assert.NoError(t, err)
+		assert.Nil(t, record)
+
 		// Updating a keepalive in a nonexistent org and env should not work
 		entity.Namespace = "missing"
 		err = store.UpdateFailingKeepalive(ctx, entity, 1)
 		assert.Error(t, err)
 	})
 }
+
+func TestFailingKeepaliveOfAnotherBackend(t *testing.T) {
+	testWithEtcdClient(t, func(s store.Store, client *clientv3.Client) {
+		other := NewStore(client, "other")
+		entity := types.FixtureEntity("entity")
+		ctx := context.WithValue(context.Background(), types.NamespaceKey, entity.Namespace)
+
+		// The keepalives of the entity failed on another backend, then on this
+		// one
+		assert.NoError(t, other.UpdateFailingKeepalive(ctx, entity, 1))
+		assert.NoError(t, s.UpdateFailingKeepalive(ctx, entity, 2))
+		record, err := other.GetFailingKeepalive(ctx, entity)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), record.Time)
+
+		// The stale record of the other backend isn't returned once the
+		// keepalives of the entity recover
+		assert.NoError(t, s.DeleteFailingKeepalive(ctx, entity))
+		record, err = other.GetFailingKeepalive(ctx, entity)
+		assert.NoError(t, err)
+		assert.Nil(t, record)
+	})
+}
+
+func TestKeepaliveConflict(t *testing.T) {
+	testWithEtcd(t, func(store store.Store) {
+		entity := types.FixtureEntity("entity")
+		ctx := context.WithValue(context.Background(), types.NamespaceKey, entity.Namespace)
+
+		conflict, err := store.GetKeepaliveConflict(ctx, entity)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), conflict)
+
+		assert.NoError(t, store.RecordKeepaliveConflict(ctx, entity, 60))
+		conflict, err = store.GetKeepaliveConflict(ctx, entity)
+		assert.NoError(t, err)
+		assert.NotZero(t, conflict)
+
+		// Only the first deletion resolves the conflict
+		deleted, err := store.DeleteKeepaliveConflict(ctx, entity)
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		deleted, err = store.DeleteKeepaliveConflict(ctx, entity)
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+	})
+}
@@ -0,0 +1,63 @@
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+var (
+	metricThresholdBreachesPathPrefix = "metric_threshold_breaches"
+	metricThresholdBreachKeyBuilder   = store.NewKeyBuilder(metricThresholdBreachesPathPrefix)
+)
+
+// getMetricThresholdBreachesPath returns the path of the metric threshold
+// breaches of the event of the given entity and check.
+func getMetricThresholdBreachesPath(ctx context.Context, entity, check string) string {
+	return metricThresholdBreachKeyBuilder.WithContext(ctx).Build(entity, check)
+}
+
+// GetMetricThresholdBreaches gets the number of consecutive points breaching
+// each metric threshold of the check of the event of the given entity and
+// check, by rule.
+func (s *Store) GetMetricThresholdBreaches(ctx context.Context, entity, check string) (map[string]int, error) {
+	if entity == "" || check == "" {
+		return nil, &store.ErrNotValid{Err: errors.New("must specify entity and check name")}
+	}
+
+	key := getMetricThresholdBreachesPath(ctx, entity, check)
+	resp, err := s.client.Get(ctx, key)
+	if err != nil {
+		return nil, &store.ErrInternal{Message: err.Error()}
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+
+	var breaches map[string]int
+	if err := json.Unmarshal(resp.Kvs[0].Value, &breaches); err != nil {
+		return nil, &store.ErrDecode{Key: key, Err: err}
+	}
+	return breaches, nil
+}
+
+// UpdateMetricThresholdBreaches stores the number of consecutive points
+// breaching each metric threshold of the check of the event of the given
+// entity and check, replacing the previous ones.
+func (s *Store) UpdateMetricThresholdBreaches(ctx context.Context, entity, check string, breaches map[string]int) error {
+	if entity == "" || check == "" {
+		return &store.ErrNotValid{Err: errors.New("must specify entity and check name")}
+	}
+
+	key := getMetricThresholdBreachesPath(ctx, entity, check)
+	bytes, err := json.Marshal(breaches)
+	if err != nil {
+		return &store.ErrEncode{Key: key, Err: err}
+	}
+	if _, err := s.client.Put(ctx, key, string(bytes)); err != nil {
+		return &store.ErrInternal{Message: err.Error()}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// +build integration,!race
+
+package etcd
+
+import (
+	"context"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricThresholdBreachStorage(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx := context.WithValue(context.Background(), corev2.NamespaceKey, "default")
+
+		breaches, err := s.GetMetricThresholdBreaches(ctx, "entity1", "check1")
+		require.NoError(t, err)
+		assert.Nil(t, breaches)
+
+		require.NoError(t, s.UpdateMetricThresholdBreaches(ctx, "entity1", "check1", map[string]int{
+			`metric "cpu.idle" < 10 for 3 points`: 1,
+		}))
+
+		// The latest breaches replace the previous ones
+		want := map[string]int{`metric "cpu.idle" < 5 for 2 points`: 2}
+		require.NoError(t, s.UpdateMetricThresholdBreaches(ctx, "entity1", "check1", want))
+		breaches, err = s.GetMetricThresholdBreaches(ctx, "entity1", "check1")
+		require.NoError(t, err)
+		assert.Equal(t, want, breaches)
+
+		// Breaches of other checks are not included
+		breaches, err = s.GetMetricThresholdBreaches(ctx, "entity1", "check")
+		require.NoError(t, err)
+		assert.Nil(t, breaches)
+
+		// Deleting the event deletes its breaches
+		require.NoError(t, s.DeleteEventByEntityCheck(ctx, "entity1", "check1"))
+		breaches, err = s.GetMetricThresholdBreaches(ctx, "entity1", "check1")
+		require.NoError(t, err)
+		assert.Nil(t, breaches)
+
+		_, err = s.GetMetricThresholdBreaches(ctx, "", "check1")
+		assert.Error(t, err)
+		assert.Error(t, s.UpdateMetricThresholdBreaches(ctx, "entity1", "", want))
+	})
+}
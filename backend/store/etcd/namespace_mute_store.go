@@ -0,0 +1,93 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"path"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/types"
+)
+
+const (
+	namespaceMutesPathPrefix = "namespace_mutes"
+)
+
+func getNamespaceMutePath(namespace string) string {
+	return path.Join(EtcdRoot, namespaceMutesPathPrefix, namespace)
+}
+
+// GetNamespaceMute gets the muting switch of a namespace.
+func (s *Store) GetNamespaceMute(ctx context.Context, namespace string) (*types.NamespaceMute, error) {
+	if namespace == "" {
+		return nil, &store.ErrNotValid{Err: errors.New("must specify namespace")}
+	}
+
+	resp, err := s.client.Get(ctx, getNamespaceMutePath(namespace))
+	if err != nil {
+		return nil, &store.ErrInternal{Message: err.Error()}
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+
+	mute := &types.NamespaceMute{}
+	if err := unmarshal(resp.Kvs[0].Value, mute); err != nil {
+		return nil, &store.ErrDecode{Err: err}
+	}
+
+	return mute, nil
+}
+
+// GetNamespaceMutes gets the muting switches of the muted namespaces, by
+// namespace.
+func (s *Store) GetNamespaceMutes(ctx context.Context) (map[string]*types.NamespaceMute, error) {
+	resp, err := s.client.Get(ctx, getNamespaceMutePath("")+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, &store.ErrInternal{Message: err.Error()}
+	}
+
+	mutes := make(map[string]*types.NamespaceMute, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		mute := &types.NamespaceMute{}
+		if err := unmarshal(kv.Value, mute); err != nil {
+			return nil, &store.ErrDecode{Key: string(kv.Key), Err: err}
+		}
+		mutes[path.Base(string(kv.Key))] = mute
+	}
+
+	return mutes, nil
+}
+
+// UpdateNamespaceMute mutes or unmutes a namespace.
+func (s *Store) UpdateNamespaceMute(ctx context.Context, namespace string, mute *types.NamespaceMute) error {
+	if namespace == "" {
+		return &store.ErrNotValid{Err: errors.New("must specify namespace")}
+	}
+
+	key := getNamespaceMutePath(namespace)
+	if !mute.Muted {
+		if _, err := s.client.Delete(ctx, key); err != nil {
+			return &store.ErrInternal{Message: err.Error()}
+		}
+		return nil
+	}
+
+	bytes, err := marshal(mute)
+	if err != nil {
+		return &store.ErrEncode{Key: key, Err: err}
+	}
+
+	cmp := clientv3.Compare(clientv3.Version(getNamespacePath(namespace)), ">", 0)
+	req := clientv3.OpPut(key, string(bytes))
+	res, err := s.client.Txn(ctx).If(cmp).Then(req).Commit()
+	if err != nil {
+		return &store.ErrInternal{Message: err.Error()}
+	}
+	if !res.Succeeded {
+		return &store.ErrNamespaceMissing{Namespace: namespace}
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+// +build integration,!race
+
+package etcd
+
+import (
+	"context"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNamespaceMuteStorage(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx := context.Background()
+
+		mute, err := s.GetNamespaceMute(ctx, "default")
+		require.NoError(t, err)
+		assert.Nil(t, mute)
+
+		muted := &corev2.NamespaceMute{Muted: true, Reason: "maintenance", MutedBy: "admin"}
+		require.NoError(t, s.UpdateNamespaceMute(ctx, "default", muted))
+
+		mute, err = s.GetNamespaceMute(ctx, "default")
+		require.NoError(t, err)
+		assert.Equal(t, muted, mute)
+
+		mutes, err := s.GetNamespaceMutes(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]*corev2.NamespaceMute{"default": muted}, mutes)
+
+		require.NoError(t, s.UpdateNamespaceMute(ctx, "default", &corev2.NamespaceMute{}))
+		mute, err = s.GetNamespaceMute(ctx, "default")
+		require.NoError(t, err)
+		assert.Nil(t, mute)
+
+		mutes, err = s.GetNamespaceMutes(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, mutes)
+
+		// Missing namespaces can't be muted
+		assert.Error(t, s.UpdateNamespaceMute(ctx, "missing", muted))
+	})
+}
+
+func TestNamespaceMuteWatcher(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		watcher := s.GetNamespaceMuteWatcher(ctx)
+
+		muted := &corev2.NamespaceMute{Muted: true, Reason: "maintenance"}
+		require.NoError(t, s.UpdateNamespaceMute(ctx, "default", muted))
+		event := <-watcher
+		assert.Equal(t, store.WatchCreate, event.Action)
+		assert.Equal(t, "default", event.Namespace)
+		assert.Equal(t, muted, event.NamespaceMute)
+
+		require.NoError(t, s.UpdateNamespaceMute(ctx, "default", &corev2.NamespaceMute{}))
+		event = <-watcher
+		assert.Equal(t, store.WatchDelete, event.Action)
+		assert.Equal(t, "default", event.Namespace)
+		assert.False(t, event.NamespaceMute.Muted)
+	})
+}
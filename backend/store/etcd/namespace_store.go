@@ -63,34 +63,9 @@ func (s *Store) DeleteNamespace(ctx context.Context, name string) error {
 		return &store.ErrNotValid{Err: errors.New("must specify name")}
 	}
 
-	// Validate whether there are any resources referencing the namespace
-	getresp, err := s.client.Txn(ctx).Then(
-		v3.OpGet(checkKeyBuilder.WithNamespace(name).Build(), v3.WithPrefix(), v3.WithCountOnly()),
-		v3.OpGet(entityKeyBuilder.WithNamespace(name).Build(), v3.WithPrefix(), v3.WithCountOnly()),
-		v3.OpGet(assetKeyBuilder.WithNamespace(name).Build(), v3.WithPrefix(), v3.WithCountOnly()),
-		v3.OpGet(handlerKeyBuilder.WithNamespace(name).Build(), v3.WithPrefix(), v3.WithCountOnly()),
-		v3.OpGet(mutatorKeyBuilder.WithNamespace(name).Build(), v3.WithPrefix(), v3.WithCountOnly()),
-	).Commit()
-	if err != nil {
-		return err
-	}
-	for _, r := range getresp.Responses {
-		if r.GetResponseRange().Count > 0 {
-			return &store.ErrNotValid{Err: errors.New("namespace is not empty")}
-		}
-	}
-
-	// Delete the resource
-	resp, err := s.client.Delete(ctx, getNamespacePath(name), v3.WithPrefix())
-	if err != nil {
-		return &store.ErrInternal{Message: err.Error()}
-	}
-
-	if resp.Deleted != 1 {
-		return &store.ErrNotFound{Key: getNamespacePath(name)}
-	}
-
-	return nil
+	// The namespace is unmuted and its silenced entries are deleted along
+	// with it, if it's empty
+	return cascadeOf(getNamespacePath(name), namespacesPathPrefix, "", name).delete(ctx, s.client)
 }
 
 // GetNamespace returns a single namespace with the given name
@@ -2,9 +2,7 @@ package etcd
 
 import (
 	"context"
-	"fmt"
 
-	"github.com/gogo/protobuf/proto"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/store"
 )
@@ -17,13 +15,7 @@ func (s *Store) CreateResource(ctx context.Context, resource corev2.Resource) er
 
 	key := store.KeyFromResource(resource)
 	namespace := resource.GetObjectMeta().Namespace
-
-	msg, ok := resource.(proto.Message)
-	if !ok {
-		return &store.ErrEncode{Key: key, Err: fmt.Errorf("%T is not proto.Message", resource)}
-	}
-
-	return Create(ctx, s.client, key, namespace, msg)
+	return create(ctx, s.client, s.cipher, key, namespace, resource)
 }
 
 // CreateOrUpdateResource creates or updates the given resource regardless of
@@ -35,20 +27,21 @@ func (s *Store) CreateOrUpdateResource(ctx context.Context, resource corev2.Reso
 
 	key := store.KeyFromResource(resource)
 	namespace := resource.GetObjectMeta().Namespace
-	return CreateOrUpdate(ctx, s.client, key, namespace, resource)
+	return createOrUpdate(ctx, s.client, s.cipher, key, namespace, resource)
 }
 
 // DeleteResource deletes the resource using the given resource prefix and name
 func (s *Store) DeleteResource(ctx context.Context, resourcePrefix, name string) error {
 	key := store.KeyFromArgs(ctx, resourcePrefix, name)
-	return Delete(ctx, s.client, key)
+	namespace := store.NewNamespaceFromContext(ctx)
+	return cascadeOf(key, resourcePrefix, namespace, name).delete(ctx, s.client)
 }
 
 // GetResource retrieves a resource with the given name and stores it into the
 // resource pointer
 func (s *Store) GetResource(ctx context.Context, name string, resource corev2.Resource) error {
 	key := store.KeyFromArgs(ctx, resource.StorePrefix(), name)
-	return Get(ctx, s.client, key, resource)
+	return get(ctx, s.client, s.cipher, key, resource)
 }
 
 // ListResources retrieves all resources for the resourcePrefix type and stores
@@ -58,5 +51,5 @@ func (s *Store) ListResources(ctx context.Context, resourcePrefix string, resour
 		return store.NewKeyBuilder(resourcePrefix).WithContext(ctx).Build("")
 	}
 
-	return List(ctx, s.client, keyBuilderFunc, resources, pred)
+	return list(ctx, s.client, s.cipher, keyBuilderFunc, resources, pred)
 }
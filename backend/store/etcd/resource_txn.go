@@ -0,0 +1,268 @@
+package etcd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/coreos/etcd/clientv3"
+	"github.com/coreos/etcd/etcdserver/etcdserverpb"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// CommitResources applies all the operations in a single transaction, so
+// that either all of them or none are applied
+func (s *Store) CommitResources(ctx context.Context, ops []store.ResourceOp) error {
+	if len(ops) > store.MaxResourceOps {
+		return &store.ErrNotValid{
+			Err: fmt.Errorf("a transaction can't have more than %d operations", store.MaxResourceOps),
+		}
+	}
+
+	keys := make(map[string]bool, len(ops))
+	createdNamespaces := map[string]bool{}
+	for _, op := range ops {
+		if op.Resource == nil {
+			return &store.ErrNotValid{Err: errors.New("operation without a resource")}
+		}
+		if !op.Delete {
+			if err := op.Resource.Validate(); err != nil {
+				return &store.ErrNotValid{Err: err}
+			}
+			if namespace, ok := op.Resource.(*corev2.Namespace); ok {
+				createdNamespaces[namespace.Name] = true
+			}
+		}
+
+		// etcd rejects transactions modifying the same key more than once
+		key := store.KeyFromResource(op.Resource)
+		if keys[key] {
+			return &store.ErrNotValid{Err: fmt.Errorf("the key %s is modified more than once", key)}
+		}
+		keys[key] = true
+	}
+
+	var namespaces []string
+	checkedNamespaces := map[string]bool{}
+	var versioned []store.ResourceOp
+	var cascades []cascade
+	comparisons := []clientv3.Cmp{}
+	requests := make([]clientv3.Op, 0, len(ops))
+	for _, op := range ops {
+		key := store.KeyFromResource(op.Resource)
+
+		// Make sure the namespace of the resource exists, unless the
+		// transaction creates it
+		namespace := op.Resource.GetObjectMeta().Namespace
+		if !op.Delete && namespace != "" && !createdNamespaces[namespace] && !checkedNamespaces[namespace] {
+			checkedNamespaces[namespace] = true
+			namespaces = append(namespaces, namespace)
+			comparisons = append(comparisons, namespaceFound(namespace))
+		}
+
+		switch {
+		case op.Version == store.NoVersion:
+			versioned = append(versioned, op)
+			comparisons = append(comparisons, keyNotFound(key))
+		case op.Version > 0:
+			versioned = append(versioned, op)
+			comparisons = append(comparisons, clientv3.Compare(clientv3.ModRevision(key), "=", op.Version))
+		}
+
+		if op.Delete {
+			c := cascadeOf(key, op.Resource.StorePrefix(), namespace, op.Resource.GetObjectMeta().Name)
+			cascades = append(cascades, c)
+			comparisons = append(comparisons, c.conditions()...)
+			requests = append(requests, clientv3.OpDelete(key))
+			requests = append(requests, c.deletes...)
+			continue
+		}
+		bytes, err := encode(s.cipher, key, op.Resource)
+		if err != nil {
+			return &store.ErrEncode{Key: key, Err: err}
+		}
+		requests = append(requests, clientv3.OpPut(key, string(bytes)))
+	}
+
+	// Retrieve the namespaces, the versioned resources and the keys the
+	// deleted resources depend on if the transaction fails, to report the
+	// reason of the failure
+	failure := make([]clientv3.Op, 0, len(namespaces)+len(versioned))
+	for _, namespace := range namespaces {
+		failure = append(failure, getNamespace(namespace))
+	}
+	for _, op := range versioned {
+		failure = append(failure, getKey(store.KeyFromResource(op.Resource)))
+	}
+	for _, c := range cascades {
+		failure = append(failure, c.gets()...)
+	}
+	if len(comparisons) > store.MaxResourceOps || len(requests) > store.MaxResourceOps || len(failure) > store.MaxResourceOps {
+		return &store.ErrNotValid{
+			Err: fmt.Errorf("a transaction can't have more than %d operations, including the namespaces, versions and dependent resources", store.MaxResourceOps),
+		}
+	}
+
+	resp, err := s.client.Txn(ctx).If(comparisons...).Then(requests...).Else(failure...).Commit()
+	if err != nil {
+		return &store.ErrInternal{Message: err.Error()}
+	}
+	if resp.Succeeded {
+		return nil
+	}
+
+	for i, namespace := range namespaces {
+		if len(resp.Responses[i].GetResponseRange().Kvs) == 0 {
+			return &store.ErrNamespaceMissing{Namespace: namespace}
+		}
+	}
+	for i, op := range versioned {
+		version := store.NoVersion
+		if kvs := resp.Responses[len(namespaces)+i].GetResponseRange().Kvs; len(kvs) > 0 {
+			version = kvs[0].ModRevision
+		}
+		if version != op.Version {
+			return &store.ErrConflict{Key: store.KeyFromResource(op.Resource)}
+		}
+	}
+	responses := resp.Responses[len(namespaces)+len(versioned):]
+	for _, c := range cascades {
+		if err := c.check(responses[:len(c.empty)]); err != nil {
+			return err
+		}
+		responses = responses[len(c.empty):]
+	}
+
+	// Unknown error
+	return &store.ErrNotValid{Err: errors.New("could not commit the transaction")}
+}
+
+// GetResourceVersion returns the version of the resource with the given name,
+// which is the revision of its last modification in etcd
+func (s *Store) GetResourceVersion(ctx context.Context, name string, resource corev2.Resource) (int64, error) {
+	key := store.KeyFromArgs(ctx, resource.StorePrefix(), name)
+	resp, err := s.client.Get(ctx, key, clientv3.WithLimit(1), clientv3.WithKeysOnly())
+	if err != nil {
+		return 0, &store.ErrInternal{Message: err.Error()}
+	}
+	if len(resp.Kvs) == 0 {
+		return store.NoVersion, nil
+	}
+	return resp.Kvs[0].ModRevision, nil
+}
+
+// GetVersionedResource gets the resource with the given name and returns its
+// version, which is the revision of its last modification in etcd, from a
+// single read
+func (s *Store) GetVersionedResource(ctx context.Context, name string, resource corev2.Resource) (int64, error) {
+	key := store.KeyFromArgs(ctx, resource.StorePrefix(), name)
+	resp, err := s.client.Get(ctx, key, clientv3.WithLimit(1))
+	if err != nil {
+		return 0, &store.ErrInternal{Message: err.Error()}
+	}
+	if len(resp.Kvs) == 0 {
+		return 0, &store.ErrNotFound{Key: key}
+	}
+	if err := decode(s.cipher, key, resp.Kvs[0].Value, resource); err != nil {
+		return 0, &store.ErrDecode{Key: key, Err: err}
+	}
+	return resp.Kvs[0].ModRevision, nil
+}
+
+// cascade describes how to delete a resource along with the keys that depend
+// on it, in a single transaction.
+type cascade struct {
+	// key is the key of the resource.
+	key string
+
+	// empty are the prefixes that must not have any key for the resource to
+	// be deleted, such as the resources of a namespace.
+	empty []string
+
+	// deletes are the operations deleting the keys that depend on the
+	// resource, such as the pause of a check.
+	deletes []clientv3.Op
+}
+
+// cascadeOf returns the cascade of the resource stored at the given key, with
+// the given store prefix, namespace and name.
+func cascadeOf(key, resourcePrefix, namespace, name string) cascade {
+	c := cascade{key: key}
+	switch resourcePrefix {
+	case namespacesPathPrefix:
+		c.empty = []string{
+			checkKeyBuilder.WithNamespace(name).Build(),
+			entityKeyBuilder.WithNamespace(name).Build(),
+			assetKeyBuilder.WithNamespace(name).Build(),
+			handlerKeyBuilder.WithNamespace(name).Build(),
+			mutatorKeyBuilder.WithNamespace(name).Build(),
+		}
+		// Unmute the namespace and lift its silences, so that they don't
+		// apply if it's created again
+		c.deletes = []clientv3.Op{
+			clientv3.OpDelete(getNamespaceMutePath(name)),
+			clientv3.OpDelete(silencedKeyBuilder.WithNamespace(name).Build(), clientv3.WithPrefix()),
+		}
+	case checksPathPrefix:
+		// Resume the check, so it's not paused if it's created again
+		c.deletes = []clientv3.Op{
+			clientv3.OpDelete(checkPauseKeyBuilder.WithNamespace(namespace).Build(name)),
+		}
+	}
+	return c
+}
+
+// conditions returns the comparisons that must succeed for the resource to
+// be deleted.
+func (c cascade) conditions() []clientv3.Cmp {
+	cmps := make([]clientv3.Cmp, 0, len(c.empty))
+	for _, prefix := range c.empty {
+		cmps = append(cmps, clientv3.Compare(clientv3.CreateRevision(prefix), "=", 0).WithPrefix())
+	}
+	return cmps
+}
+
+// gets returns the operations counting the keys of the prefixes that must be
+// empty, to report why the resource can't be deleted.
+func (c cascade) gets() []clientv3.Op {
+	ops := make([]clientv3.Op, 0, len(c.empty))
+	for _, prefix := range c.empty {
+		ops = append(ops, clientv3.OpGet(prefix, clientv3.WithPrefix(), clientv3.WithCountOnly()))
+	}
+	return ops
+}
+
+// check returns an error if one of the responses to the operations returned
+// by gets counted keys.
+func (c cascade) check(responses []*etcdserverpb.ResponseOp) error {
+	for _, resp := range responses {
+		if resp.GetResponseRange().Count > 0 {
+			return &store.ErrNotValid{Err: fmt.Errorf("%s can't be deleted: namespace is not empty", c.key)}
+		}
+	}
+	return nil
+}
+
+// delete deletes the resource and the keys that depend on it in a single
+// transaction. It returns ErrNotFound if the resource doesn't exist.
+func (c cascade) delete(ctx context.Context, client *clientv3.Client) error {
+	if len(c.empty) == 0 && len(c.deletes) == 0 {
+		return Delete(ctx, client, c.key)
+	}
+
+	cmps := append(c.conditions(), clientv3.Compare(clientv3.CreateRevision(c.key), ">", 0))
+	requests := append([]clientv3.Op{clientv3.OpDelete(c.key)}, c.deletes...)
+	failure := append([]clientv3.Op{getKey(c.key)}, c.gets()...)
+	resp, err := client.Txn(ctx).If(cmps...).Then(requests...).Else(failure...).Commit()
+	if err != nil {
+		return &store.ErrInternal{Message: err.Error()}
+	}
+	if resp.Succeeded {
+		return nil
+	}
+	if len(resp.Responses[0].GetResponseRange().Kvs) == 0 {
+		return &store.ErrNotFound{Key: c.key}
+	}
+	return c.check(resp.Responses[1:])
+}
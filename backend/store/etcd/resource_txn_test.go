@@ -0,0 +1,140 @@
+// +build integration,!race
+
+package etcd
+
+import (
+	"context"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitResources(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx := context.WithValue(context.Background(), corev2.NamespaceKey, "default")
+
+		// The namespace of the resources can be created by the transaction
+		namespace := corev2.FixtureNamespace("acme")
+		check := corev2.FixtureCheckConfig("check1")
+		check.Namespace = "acme"
+		handler := corev2.FixtureHandler("handler1")
+		require.NoError(t, s.CommitResources(ctx, []store.ResourceOp{
+			{Resource: namespace},
+			{Resource: check, Version: store.NoVersion},
+			{Resource: handler},
+		}))
+
+		acmeCtx := context.WithValue(context.Background(), corev2.NamespaceKey, "acme")
+		got, err := s.GetCheckConfigByName(acmeCtx, "check1")
+		require.NoError(t, err)
+		assert.Equal(t, check.Command, got.Command)
+
+		// Nothing is applied if a resource is not at the expected version
+		version, err := s.GetResourceVersion(acmeCtx, "check1", &corev2.CheckConfig{})
+		require.NoError(t, err)
+		assert.True(t, version > 0)
+		check.Command = "true"
+		err = s.CommitResources(ctx, []store.ResourceOp{
+			{Resource: handler, Delete: true},
+			{Resource: check, Version: version + 1},
+		})
+		assert.IsType(t, &store.ErrConflict{}, err)
+		_, err = s.GetHandlerByName(ctx, "handler1")
+		require.NoError(t, err)
+
+		// Nothing is applied if a namespace is missing
+		mutator := corev2.FixtureMutator("mutator1")
+		mutator.Namespace = "missing"
+		err = s.CommitResources(ctx, []store.ResourceOp{
+			{Resource: handler, Delete: true},
+			{Resource: mutator},
+		})
+		assert.IsType(t, &store.ErrNamespaceMissing{}, err)
+
+		// Everything is applied with the expected versions
+		require.NoError(t, s.CommitResources(ctx, []store.ResourceOp{
+			{Resource: handler, Delete: true},
+			{Resource: check, Version: version},
+		}))
+		got, err = s.GetCheckConfigByName(acmeCtx, "check1")
+		require.NoError(t, err)
+		assert.Equal(t, "true", got.Command)
+		deleted, err := s.GetHandlerByName(ctx, "handler1")
+		require.NoError(t, err)
+		assert.Nil(t, deleted)
+
+		version, err = s.GetResourceVersion(ctx, "handler1", &corev2.Handler{})
+		require.NoError(t, err)
+		assert.Equal(t, store.NoVersion, version)
+
+		// The resources are read along with their version
+		versioned := &corev2.CheckConfig{}
+		version, err = s.GetVersionedResource(acmeCtx, "check1", versioned)
+		require.NoError(t, err)
+		assert.Equal(t, "true", versioned.Command)
+		current, err := s.GetResourceVersion(acmeCtx, "check1", &corev2.CheckConfig{})
+		require.NoError(t, err)
+		assert.Equal(t, current, version)
+		_, err = s.GetVersionedResource(ctx, "handler1", &corev2.Handler{})
+		assert.IsType(t, &store.ErrNotFound{}, err)
+
+		// A resource can't be modified twice in a transaction
+		err = s.CommitResources(ctx, []store.ResourceOp{{Resource: handler}, {Resource: handler}})
+		assert.IsType(t, &store.ErrNotValid{}, err)
+	})
+}
+
+func TestCommitResourcesCascade(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx := context.Background()
+		acmeCtx := context.WithValue(ctx, corev2.NamespaceKey, "acme")
+
+		namespace := corev2.FixtureNamespace("acme")
+		check := corev2.FixtureCheckConfig("check1")
+		check.Namespace = "acme"
+		require.NoError(t, s.CommitResources(ctx, []store.ResourceOp{
+			{Resource: namespace},
+			{Resource: check},
+		}))
+		require.NoError(t, s.UpdateCheckPause(acmeCtx, "check1", &corev2.CheckPause{Paused: true}))
+		require.NoError(t, s.UpdateNamespaceMute(ctx, "acme", &corev2.NamespaceMute{Muted: true}))
+		silenced := corev2.FixtureSilenced("*:check1")
+		silenced.Namespace = "acme"
+		require.NoError(t, s.UpdateSilencedEntry(acmeCtx, silenced))
+
+		// A namespace can't be deleted while it has resources
+		err := s.CommitResources(ctx, []store.ResourceOp{{Resource: namespace, Delete: true}})
+		assert.IsType(t, &store.ErrNotValid{}, err)
+		ns, err := s.GetNamespace(ctx, "acme")
+		require.NoError(t, err)
+		assert.NotNil(t, ns)
+
+		// The pause of a check is deleted along with it
+		require.NoError(t, s.CommitResources(ctx, []store.ResourceOp{{Resource: check, Delete: true}}))
+		pause, err := s.GetCheckPause(acmeCtx, "check1")
+		require.NoError(t, err)
+		assert.Nil(t, pause)
+
+		// The mute and the silenced entries of a namespace are deleted along
+		// with it
+		require.NoError(t, s.CommitResources(ctx, []store.ResourceOp{{Resource: namespace, Delete: true}}))
+		mute, err := s.GetNamespaceMute(ctx, "acme")
+		require.NoError(t, err)
+		assert.Nil(t, mute)
+		entry, err := s.GetSilencedEntryByName(acmeCtx, silenced.Name)
+		require.NoError(t, err)
+		assert.Nil(t, entry)
+
+		// The single deletes cascade in the same way
+		require.NoError(t, s.CreateNamespace(ctx, namespace))
+		require.NoError(t, s.UpdateNamespaceMute(ctx, "acme", &corev2.NamespaceMute{Muted: true}))
+		require.NoError(t, s.DeleteResource(ctx, namespace.StorePrefix(), "acme"))
+		mute, err = s.GetNamespaceMute(ctx, "acme")
+		require.NoError(t, err)
+		assert.Nil(t, mute)
+		assert.IsType(t, &store.ErrNotFound{}, s.DeleteResource(ctx, namespace.StorePrefix(), "acme"))
+	})
+}
@@ -205,12 +205,24 @@ func (s *Store) arraySilencedEntries(ctx context.Context, resp *clientv3.GetResp
 		if err != nil {
 			return nil, &store.ErrDecode{Err: err}
 		}
-		silencedEntry.Expire = ttl.TTL
+		silencedEntry.Expire = silencedExpire(kv.Lease, ttl.TTL)
 		silencedArray[i] = silencedEntry
 	}
 	return silencedArray, nil
 }
 
+// silencedExpire returns the remaining time to live of a silenced entry, given
+// the lease of its key and the remaining time to live of the lease. It is
+// negative for the entries that never expire, and 0 for the expired entries
+// that etcd has not deleted yet, whose lease may have a negative time to live
+// until it is revoked.
+func silencedExpire(lease, ttl int64) int64 {
+	if lease != 0 && ttl < 0 {
+		return 0
+	}
+	return ttl
+}
+
 func (s *Store) arrayTxnSilencedEntries(ctx context.Context, resp *clientv3.TxnResponse) ([]*corev2.Silenced, error) {
 	results := []*corev2.Silenced{}
 	for _, resp := range resp.Responses {
@@ -224,7 +236,7 @@ func (s *Store) arrayTxnSilencedEntries(ctx context.Context, resp *clientv3.TxnR
 			if err := unmarshal(kv.Value, &silenced); err != nil {
 				return nil, &store.ErrDecode{Err: fmt.Errorf("couldn't get silenced entries: %s", err)}
 			}
-			silenced.Expire = ttl.TTL
+			silenced.Expire = silencedExpire(kv.Lease, ttl.TTL)
 			results = append(results, &silenced)
 		}
 	}
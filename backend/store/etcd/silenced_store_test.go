@@ -169,3 +169,13 @@ func TestSilencedStorageWithBeginAndExpire(t *testing.T) {
 		})
 	})
 }
+
+func TestSilencedExpire(t *testing.T) {
+	// Entries without a lease never expire
+	assert.Equal(t, int64(-1), silencedExpire(0, -1))
+	assert.Equal(t, int64(60), silencedExpire(1, 60))
+	// The lease of an expired entry may have a negative time to live until
+	// it is revoked
+	assert.Equal(t, int64(0), silencedExpire(1, 0))
+	assert.Equal(t, int64(0), silencedExpire(1, -1))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/coreos/etcd/clientv3"
 	"github.com/gogo/protobuf/proto"
 	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/encryption"
 	"github.com/sensu/sensu-go/types"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
@@ -25,21 +26,35 @@ const (
 type Store struct {
 	client         *clientv3.Client
 	keepalivesPath string
+	cipher         *encryption.Cipher
 }
 
 // NewStore creates a new Store.
 func NewStore(client *clientv3.Client, name string) *Store {
+	return NewEncryptedStore(client, name, nil)
+}
+
+// NewEncryptedStore creates a new Store encrypting the events and entities it
+// writes with cipher, or not encrypting them if cipher is nil. The values it
+// encrypted can only be read by a Store whose cipher unwraps their data key.
+func NewEncryptedStore(client *clientv3.Client, name string, cipher *encryption.Cipher) *Store {
 	store := &Store{
 		client:         client,
 		keepalivesPath: path.Join(EtcdRoot, keepalivesPathPrefix, name),
+		cipher:         cipher,
 	}
 
 	return store
 }
 
-// Create the given key with the serialized object.
+// Create the given key with the serialized object. The object is not
+// encrypted; the events and entities are encrypted by the methods of Store.
 func Create(ctx context.Context, client *clientv3.Client, key, namespace string, object interface{}) error {
-	bytes, err := marshal(object)
+	return create(ctx, client, nil, key, namespace, object)
+}
+
+func create(ctx context.Context, client *clientv3.Client, c *encryption.Cipher, key, namespace string, object interface{}) error {
+	bytes, err := encode(c, key, object)
 	if err != nil {
 		return &store.ErrEncode{Key: key, Err: err}
 	}
@@ -80,9 +95,13 @@ func Create(ctx context.Context, client *clientv3.Client, key, namespace string,
 }
 
 // CreateOrUpdate writes the given key with the serialized object, regarless of
-// its current existence
+// its current existence. The object is not encrypted.
 func CreateOrUpdate(ctx context.Context, client *clientv3.Client, key, namespace string, object interface{}) error {
-	bytes, err := marshal(object)
+	return createOrUpdate(ctx, client, nil, key, namespace, object)
+}
+
+func createOrUpdate(ctx context.Context, client *clientv3.Client, c *encryption.Cipher, key, namespace string, object interface{}) error {
+	bytes, err := encode(c, key, object)
 	if err != nil {
 		return &store.ErrEncode{Key: key, Err: err}
 	}
@@ -126,8 +145,13 @@ func Delete(ctx context.Context, client *clientv3.Client, key string) error {
 	return nil
 }
 
-// Get retrieves an object with the given key
+// Get retrieves an object with the given key. Encrypted objects can only be
+// retrieved by the methods of Store.
 func Get(ctx context.Context, client *clientv3.Client, key string, object interface{}) error {
+	return get(ctx, client, nil, key, object)
+}
+
+func get(ctx context.Context, client *clientv3.Client, c *encryption.Cipher, key string, object interface{}) error {
 	// Fetch the key from the store
 	resp, err := client.Get(ctx, key, clientv3.WithLimit(1))
 	if err != nil {
@@ -140,7 +164,7 @@ func Get(ctx context.Context, client *clientv3.Client, key string, object interf
 	}
 
 	// Deserialize the object to the given object
-	if err := unmarshal(resp.Kvs[0].Value, object); err != nil {
+	if err := decode(c, key, resp.Kvs[0].Value, object); err != nil {
 		return &store.ErrDecode{Key: key, Err: err}
 	}
 
@@ -151,8 +175,13 @@ func Get(ctx context.Context, client *clientv3.Client, key string, object interf
 type KeyBuilderFn func(context.Context, string) string
 
 // List retrieves all keys from storage under the provided prefix key, while
-// supporting all namespaces, and deserialize it into objsPtr.
+// supporting all namespaces, and deserialize it into objsPtr. Encrypted objects
+// can only be retrieved by the methods of Store.
 func List(ctx context.Context, client *clientv3.Client, keyBuilder KeyBuilderFn, objsPtr interface{}, pred *store.SelectionPredicate) error {
+	return list(ctx, client, nil, keyBuilder, objsPtr, pred)
+}
+
+func list(ctx context.Context, client *clientv3.Client, c *encryption.Cipher, keyBuilder KeyBuilderFn, objsPtr interface{}, pred *store.SelectionPredicate) error {
 	// Make sure the interface is a pointer, and that the element at this address
 	// is a slice.
 	v := reflect.ValueOf(objsPtr)
@@ -188,15 +217,19 @@ func List(ctx context.Context, client *clientv3.Client, keyBuilder KeyBuilderFn,
 	}
 
 	for _, kv := range resp.Kvs {
+		value, err := openValue(c, string(kv.Key), kv.Value)
+		if err != nil {
+			return &store.ErrDecode{Key: key, Err: err}
+		}
 		var obj interface{}
-		if len(kv.Value) > 0 && kv.Value[0] == '{' {
+		if len(value) > 0 && value[0] == '{' {
 			obj = reflect.New(v.Type().Elem().Elem()).Interface()
-			if err := json.Unmarshal(kv.Value, obj); err != nil {
+			if err := json.Unmarshal(value, obj); err != nil {
 				return &store.ErrDecode{Key: key, Err: err}
 			}
 		} else {
 			msg := reflect.New(v.Type().Elem().Elem()).Interface().(proto.Message)
-			if err := proto.Unmarshal(kv.Value, msg); err != nil {
+			if err := proto.Unmarshal(value, msg); err != nil {
 				return &store.ErrDecode{Key: key, Err: err}
 			}
 			obj = msg
@@ -350,6 +383,16 @@ func ComputeContinueToken(ctx context.Context, r corev2.Resource) string {
 }
 
 func unmarshal(data []byte, v interface{}) error {
+	return decode(nil, "", data, v)
+}
+
+// decode decodes data, stored at key, into v. It is decrypted with c if it is
+// encrypted.
+func decode(c *encryption.Cipher, key string, data []byte, v interface{}) error {
+	data, err := openValue(c, key, data)
+	if err != nil {
+		return err
+	}
 	if len(data) > 0 && data[0] == '{' {
 		if err := json.Unmarshal(data, v); err != nil {
 			return err
@@ -367,7 +410,13 @@ func unmarshal(data []byte, v interface{}) error {
 	return nil
 }
 
-func marshal(v interface{}) (bytes []byte, err error) {
+func marshal(v interface{}) ([]byte, error) {
+	return encode(nil, "", v)
+}
+
+// encode encodes v, stored at key. It is encrypted with c if it is an event or
+// an entity and c is not nil.
+func encode(c *encryption.Cipher, key string, v interface{}) (bytes []byte, err error) {
 	switch v.(type) {
 	case types.Wrapper:
 		// Supporting protobuf serialization for wrapped resources is not
@@ -388,5 +437,5 @@ func marshal(v interface{}) (bytes []byte, err error) {
 			return nil, err
 		}
 	}
-	return
+	return sealValue(c, key, v, bytes)
 }
@@ -471,3 +471,15 @@ func TestUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestMarshalWithoutProtobuf(t *testing.T) {
+	template := corev2.FixtureCheckTemplate("linux")
+	data, err := marshal(template)
+	require.NoError(t, err)
+
+	got := &corev2.CheckTemplate{}
+	require.NoError(t, unmarshal(data, got))
+	assert.Equal(t, template.Name, got.Name)
+	assert.Equal(t, template.Interval, got.Interval)
+	assert.Equal(t, template.Subscriptions, got.Subscriptions)
+}
@@ -163,3 +163,42 @@ func (s *Store) UpdateUser(u *corev2.User) error {
 	}
 	return nil
 }
+
+// ResetUserPassword replaces the password hash of a User, unless it was
+// modified since it was checked.
+func (s *Store) ResetUserPassword(ctx context.Context, username, previous, password string) error {
+	key := getUserPath(username)
+	resp, err := s.client.Get(ctx, key)
+	if err != nil {
+		return &store.ErrInternal{Message: err.Error()}
+	}
+	if len(resp.Kvs) == 0 {
+		return &store.ErrNotFound{Key: key}
+	}
+
+	user := &corev2.User{}
+	if err := unmarshal(resp.Kvs[0].Value, user); err != nil {
+		return &store.ErrDecode{Key: key, Err: err}
+	}
+	if user.Disabled || user.Password != previous {
+		return &store.ErrConflict{Key: key}
+	}
+	user.Password = password
+	bytes, err := proto.Marshal(user)
+	if err != nil {
+		return &store.ErrEncode{Key: key, Err: err}
+	}
+
+	// The password is only replaced if the user wasn't modified since it
+	// was read, e.g. by another request resetting its password
+	txn, err := s.client.Txn(ctx).If(
+		clientv3.Compare(clientv3.ModRevision(key), "=", resp.Kvs[0].ModRevision),
+	).Then(clientv3.OpPut(key, string(bytes))).Commit()
+	if err != nil {
+		return &store.ErrInternal{Message: err.Error()}
+	}
+	if !txn.Succeeded {
+		return &store.ErrConflict{Key: key}
+	}
+	return nil
+}
@@ -199,3 +199,23 @@ func testGetAllUsersPagination(t *testing.T, ctx context.Context, etcd store.Sto
 		}
 	}
 }
+
+func TestResetUserPassword(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		user := types.FixtureUser("foo")
+		require.NoError(t, s.CreateUser(user))
+		previous := user.Password
+
+		require.NoError(t, s.ResetUserPassword(context.Background(), "foo", previous, "first"))
+		result, err := s.GetUser(context.Background(), "foo")
+		require.NoError(t, err)
+		assert.Equal(t, "first", result.Password)
+
+		// The password can't be reset again from the same password
+		err = s.ResetUserPassword(context.Background(), "foo", previous, "second")
+		assert.IsType(t, &store.ErrConflict{}, err)
+
+		err = s.ResetUserPassword(context.Background(), "bar", previous, "second")
+		assert.IsType(t, &store.ErrNotFound{}, err)
+	})
+}
@@ -77,6 +77,7 @@ func (w *Watcher) start() {
 	if w.recursive {
 		baseOpts = append(baseOpts, clientv3.WithPrefix())
 	}
+	baseOpts = append(baseOpts, w.opts...)
 	opts := make([]clientv3.OpOption, len(baseOpts))
 	copy(opts, baseOpts)
 	if w.revision != 0 {
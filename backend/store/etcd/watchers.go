@@ -2,40 +2,86 @@ package etcd
 
 import (
 	"context"
+	"path"
 	"reflect"
 
 	"github.com/coreos/etcd/clientv3"
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/backend/store/encryption"
 )
 
-// GetCheckConfigWatcher returns a channel that emits WatchEventCheckConfig structs notifying
-// the caller that a CheckConfig was updated. If the watcher runs into a terminal error
-// or the context passed is cancelled, then the channel will be closed. The
-// watcher will do its best to recover on errors.
-func (s *Store) GetCheckConfigWatcher(ctx context.Context) <-chan store.WatchEventCheckConfig {
-	key := checkKeyBuilder.WithContext(ctx).Build()
-	w := Watch(ctx, s.client, key, true)
+// WatchChecks returns a channel that emits WatchEventCheckConfig structs
+// notifying the caller that a CheckConfig was updated. The channel is closed
+// once the context passed is cancelled, and the watcher does its best to
+// recover on errors in the meantime.
+func (s *Store) WatchChecks(ctx context.Context) <-chan store.WatchEventCheckConfig {
 	ch := make(chan store.WatchEventCheckConfig, 1)
 
 	go func() {
 		defer close(ch)
-		for response := range w.Result() {
-			// schedulerd does not support a full refresh of the check schedulers
-			if response.Type == store.WatchError {
-				continue
+		for response := range s.WatchResources(ctx, &corev2.CheckConfig{}) {
+			check, _ := response.Resource.(*corev2.CheckConfig)
+			select {
+			case ch <- store.WatchEventCheckConfig{Action: response.Action, CheckConfig: check}:
+			case <-ctx.Done():
 			}
+		}
+	}()
 
-			var checkConfig corev2.CheckConfig
+	return ch
+}
 
-			if err := unmarshal(response.Object, &checkConfig); err != nil {
-				logger.WithField("key", response.Key).WithError(err).Error("unable to unmarshal check config from key")
-				continue
+// WatchAssets returns a channel that emits WatchEventAsset structs notifying
+// the caller that an Asset was updated.
+func (s *Store) WatchAssets(ctx context.Context) <-chan store.WatchEventAsset {
+	ch := make(chan store.WatchEventAsset, 1)
+
+	go func() {
+		defer close(ch)
+		for response := range s.WatchResources(ctx, &corev2.Asset{}) {
+			asset, _ := response.Resource.(*corev2.Asset)
+			select {
+			case ch <- store.WatchEventAsset{Action: response.Action, Asset: asset}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+
+	return ch
+}
+
+// WatchHooks returns a channel that emits WatchEventHookConfig structs
+// notifying the caller that a HookConfig was updated.
+func (s *Store) WatchHooks(ctx context.Context) <-chan store.WatchEventHookConfig {
+	ch := make(chan store.WatchEventHookConfig, 1)
+
+	go func() {
+		defer close(ch)
+		for response := range s.WatchResources(ctx, &corev2.HookConfig{}) {
+			hook, _ := response.Resource.(*corev2.HookConfig)
+			select {
+			case ch <- store.WatchEventHookConfig{Action: response.Action, HookConfig: hook}:
+			case <-ctx.Done():
 			}
+		}
+	}()
+
+	return ch
+}
+
+// WatchSilenced returns a channel that emits WatchEventSilenced structs
+// notifying the caller that a silenced entry was updated.
+func (s *Store) WatchSilenced(ctx context.Context) <-chan store.WatchEventSilenced {
+	ch := make(chan store.WatchEventSilenced, 1)
 
-			ch <- store.WatchEventCheckConfig{
-				Action:      response.Type,
-				CheckConfig: &checkConfig,
+	go func() {
+		defer close(ch)
+		for response := range s.WatchResources(ctx, &corev2.Silenced{}) {
+			silenced, _ := response.Resource.(*corev2.Silenced)
+			select {
+			case ch <- store.WatchEventSilenced{Action: response.Action, Silenced: silenced}:
+			case <-ctx.Done():
 			}
 		}
 	}()
@@ -43,6 +89,22 @@ func (s *Store) GetCheckConfigWatcher(ctx context.Context) <-chan store.WatchEve
 	return ch
 }
 
+// WatchResources returns a channel that emits WatchEventResource structs
+// notifying the caller that a resource of the type of the given resource was
+// updated.
+func (s *Store) WatchResources(ctx context.Context, resource corev2.Resource) <-chan store.WatchEventResource {
+	key := store.NewKeyBuilder(resource.StorePrefix()).WithContext(ctx).Build()
+	return resourceWatcher(ctx, s.client, s.cipher, key, reflect.TypeOf(resource))
+}
+
+// WatchDeletedEvents returns a channel that emits WatchEventResource structs
+// notifying the caller that an event was deleted, with the event as it was
+// before its deletion. The updates of the events are not watched.
+func (s *Store) WatchDeletedEvents(ctx context.Context) <-chan store.WatchEventResource {
+	key := eventKeyBuilder.WithContext(ctx).Build()
+	return resourceWatcher(ctx, s.client, s.cipher, key, reflect.TypeOf(&corev2.Event{}), clientv3.WithFilterPut())
+}
+
 // GetTessenConfigWatcher returns a channel that emits WatchEventTessenConfig
 // structs notifying the caller that a TessenConfig was updated. If the watcher
 // runs into a terminal error or the context passed is cancelled, then the
@@ -81,9 +143,124 @@ func (s *Store) GetTessenConfigWatcher(ctx context.Context) <-chan store.WatchEv
 	return ch
 }
 
-// GetResourceWatcher ...
-func GetResourceWatcher(ctx context.Context, client *clientv3.Client, key string, elemType reflect.Type) <-chan store.WatchEventResource {
-	w := Watch(ctx, client, key, true)
+// GetAgentDrainWatcher returns a channel that emits WatchEventAgentDrain
+// structs notifying the caller that the drain of the agent sessions of the
+// backend was started or stopped. An event with the WatchError action is
+// emitted when changes may have been missed. If the watcher runs into a
+// terminal error or the context passed is cancelled, then the channel will be
+// closed.
+func (s *Store) GetAgentDrainWatcher(ctx context.Context, backend string) <-chan store.WatchEventAgentDrain {
+	ch := make(chan store.WatchEventAgentDrain, 1)
+	w := Watch(ctx, s.client, getAgentDrainPath(backend), false)
+
+	go func() {
+		defer close(ch)
+		for response := range w.Result() {
+			event := store.WatchEventAgentDrain{
+				Action:   response.Type,
+				Draining: response.Type == store.WatchCreate || response.Type == store.WatchUpdate,
+			}
+
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// GetClusterConfigWatcher returns a channel that emits WatchEventClusterConfig
+// structs notifying the caller that the cluster configuration was updated. An
+// event with the WatchError action and no configuration is emitted when
+// changes may have been missed. If the watcher runs into a terminal error or
+// the context passed is cancelled, then the channel will be closed.
+func (s *Store) GetClusterConfigWatcher(ctx context.Context) <-chan store.WatchEventClusterConfig {
+	ch := make(chan store.WatchEventClusterConfig, 1)
+	w := Watch(ctx, s.client, getClusterConfigPath(), false)
+
+	go func() {
+		defer close(ch)
+		for response := range w.Result() {
+			event := store.WatchEventClusterConfig{Action: response.Type}
+			switch response.Type {
+			case store.WatchError:
+			case store.WatchDelete:
+				event.ClusterConfig = &corev2.ClusterConfig{}
+			default:
+				var config corev2.ClusterConfig
+				if err := unmarshal(response.Object, &config); err != nil {
+					logger.WithField("key", response.Key).WithError(err).Error("unable to unmarshal cluster config from key")
+					continue
+				}
+				event.ClusterConfig = &config
+			}
+
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// GetNamespaceMuteWatcher returns a channel that emits
+// WatchEventNamespaceMute structs notifying the caller that a namespace was
+// muted or unmuted. An event with the WatchError action and no muting switch
+// is emitted when changes may have been missed. If the watcher runs into a
+// terminal error or the context passed is cancelled, then the channel will be
+// closed.
+func (s *Store) GetNamespaceMuteWatcher(ctx context.Context) <-chan store.WatchEventNamespaceMute {
+	ch := make(chan store.WatchEventNamespaceMute, 1)
+	w := Watch(ctx, s.client, getNamespaceMutePath(""), true)
+
+	go func() {
+		defer close(ch)
+		for response := range w.Result() {
+			event := store.WatchEventNamespaceMute{Action: response.Type}
+			switch response.Type {
+			case store.WatchError:
+			case store.WatchDelete:
+				event.Namespace = path.Base(response.Key)
+				event.NamespaceMute = &corev2.NamespaceMute{}
+			default:
+				var mute corev2.NamespaceMute
+				if err := unmarshal(response.Object, &mute); err != nil {
+					logger.WithField("key", response.Key).WithError(err).Error("unable to unmarshal namespace mute from key")
+					continue
+				}
+				event.Namespace = path.Base(response.Key)
+				event.NamespaceMute = &mute
+			}
+
+			select {
+			case ch <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch
+}
+
+// GetResourceWatcher returns a channel that emits WatchEventResource structs
+// notifying the caller that a resource stored under key was updated. The
+// resources are unmarshaled into values of elemType, a pointer type. An event
+// with the WatchError action and no resource is emitted when changes may have
+// been missed. The watcher is created with the etcd client options passed in,
+// if any. Encrypted resources can only be watched by the methods of Store.
+func GetResourceWatcher(ctx context.Context, client *clientv3.Client, key string, elemType reflect.Type, opts ...clientv3.OpOption) <-chan store.WatchEventResource {
+	return resourceWatcher(ctx, client, nil, key, elemType, opts...)
+}
+
+func resourceWatcher(ctx context.Context, client *clientv3.Client, c *encryption.Cipher, key string, elemType reflect.Type, opts ...clientv3.OpOption) <-chan store.WatchEventResource {
+	w := Watch(ctx, client, key, true, opts...)
 	ch := make(chan store.WatchEventResource, 1)
 
 	go func() {
@@ -99,7 +276,7 @@ func GetResourceWatcher(ctx context.Context, client *clientv3.Client, key string
 			var resource corev2.Resource
 			elemPtr := reflect.New(elemType.Elem())
 
-			if err := unmarshal(response.Object, elemPtr.Interface()); err != nil {
+			if err := decode(c, response.Key, response.Object, elemPtr.Interface()); err != nil {
 				logger.WithField("key", response.Key).WithError(err).
 					Error("unable to unmarshal resource from key")
 				continue
@@ -0,0 +1,67 @@
+// +build integration,!race
+
+package etcd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchChecks(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ctx = context.WithValue(ctx, corev2.NamespaceKey, "default")
+
+		watcher := s.WatchChecks(ctx)
+
+		check := corev2.FixtureCheckConfig("check")
+		require.NoError(t, s.UpdateCheckConfig(ctx, check))
+		require.NoError(t, s.DeleteCheckConfigByName(ctx, check.Name))
+
+		for _, action := range []store.WatchActionType{store.WatchCreate, store.WatchDelete} {
+			select {
+			case event := <-watcher:
+				assert.Equal(t, action, event.Action)
+				assert.Equal(t, "check", event.CheckConfig.Name)
+			case <-time.After(10 * time.Second):
+				t.Fatalf("no %s event received", action)
+			}
+		}
+
+		cancel()
+		for range watcher {
+		}
+	})
+}
+
+func TestWatchAssets(t *testing.T) {
+	testWithEtcd(t, func(s store.Store) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		ctx = context.WithValue(ctx, corev2.NamespaceKey, "default")
+
+		watcher := s.WatchAssets(ctx)
+
+		asset := corev2.FixtureAsset("asset")
+		require.NoError(t, s.UpdateAsset(ctx, asset))
+		asset.URL = "https://example.com/updated.tar.gz"
+		require.NoError(t, s.UpdateAsset(ctx, asset))
+
+		for _, action := range []store.WatchActionType{store.WatchCreate, store.WatchUpdate} {
+			select {
+			case event := <-watcher:
+				assert.Equal(t, action, event.Action)
+				assert.Equal(t, "asset", event.Asset.Name)
+			case <-time.After(10 * time.Second):
+				t.Fatalf("no %s event received", action)
+			}
+		}
+	})
+}
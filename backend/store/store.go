@@ -20,6 +20,16 @@ func (e *ErrAlreadyExists) Error() string {
 	return fmt.Sprintf("could not create the key %s", e.Key)
 }
 
+// ErrConflict is returned when a transaction is not committed because a
+// resource is not at the expected version
+type ErrConflict struct {
+	Key string
+}
+
+func (e *ErrConflict) Error() string {
+	return fmt.Sprintf("the key %s was modified concurrently", e.Key)
+}
+
 // ErrDecode is returned when an object could not be decoded
 type ErrDecode struct {
 	Key string
@@ -68,6 +78,11 @@ func (e *ErrNotValid) Error() string {
 	return fmt.Sprintf("resource is invalid: %s", e.Err.Error())
 }
 
+// Unwrap returns the validation error.
+func (e *ErrNotValid) Unwrap() error {
+	return e.Err
+}
+
 // ErrInternal is returned when something generally bad happened while
 // interacting with the store. Other, more specific errors should be
 // returned when appropriate.
@@ -93,6 +108,9 @@ type SelectionPredicate struct {
 	Limit int64
 	// Subcollection represents a sub-collection of the primary collection
 	Subcollection string
+	// Sort is the order of the resources requested by the client, supported
+	// by some resource types only
+	Sort string
 }
 
 // A WatchEventCheckConfig contains the modified store object and the action that occured
@@ -109,6 +127,44 @@ type WatchEventHookConfig struct {
 	Action     WatchActionType
 }
 
+// WatchEventAsset contains the modified asset and the action that occurred
+// during the modification.
+type WatchEventAsset struct {
+	Asset  *corev2.Asset
+	Action WatchActionType
+}
+
+// WatchEventSilenced contains the modified silenced entry and the action that
+// occurred during the modification.
+type WatchEventSilenced struct {
+	Silenced *corev2.Silenced
+	Action   WatchActionType
+}
+
+// WatchEventClusterConfig is a notification that the cluster configuration
+// has been updated. The cluster configuration is nil for WatchError events.
+type WatchEventClusterConfig struct {
+	ClusterConfig *corev2.ClusterConfig
+	Action        WatchActionType
+}
+
+// WatchEventAgentDrain is a notification that the drain of the agent sessions
+// of a backend was started or stopped. Draining is false for WatchError
+// events.
+type WatchEventAgentDrain struct {
+	Draining bool
+	Action   WatchActionType
+}
+
+// WatchEventNamespaceMute is a notification that a namespace was muted or
+// unmuted. The muting switch of an unmuted namespace is not muted, and the
+// namespace and muting switch are empty for WatchError events.
+type WatchEventNamespaceMute struct {
+	Namespace     string
+	NamespaceMute *corev2.NamespaceMute
+	Action        WatchActionType
+}
+
 // WatchEventTessenConfig is a notification that the tessen config store has been updated.
 type WatchEventTessenConfig struct {
 	TessenConfig *corev2.TessenConfig
@@ -125,6 +181,10 @@ type WatchEventResource struct {
 // processses. Each Sensu resources is represented by its own interface. A
 // MockStore is available in order to mock a store implementation
 type Store interface {
+	// AgentDrainStore provides an interface for draining the agent sessions
+	// of the backends
+	AgentDrainStore
+
 	// AssetStore provides an interface for managing checks assets
 	AssetStore
 
@@ -134,9 +194,24 @@ type Store interface {
 	// CheckConfigStore provides an interface for managing checks configuration
 	CheckConfigStore
 
+	// CheckPauseStore provides an interface for pausing checks
+	CheckPauseStore
+
+	// CheckRequestStore provides an interface for recording the latest check
+	// requests issued to the subscriptions
+	CheckRequestStore
+
 	// ClusterIDStore provides an interface for managing the sensu cluster id
 	ClusterIDStore
 
+	// ClusterConfigStore provides an interface for managing the cluster
+	// configuration
+	ClusterConfigStore
+
+	// CompositeCheckStateStore provides an interface for managing the
+	// statuses of the members of composite checks
+	CompositeCheckStateStore
+
 	// EntityStore provides an interface for managing entities
 	EntityStore
 
@@ -149,6 +224,10 @@ type Store interface {
 	// HandlerStore provides an interface for managing events handlers
 	HandlerStore
 
+	// HandlerResultStore provides an interface for managing the results of
+	// handler executions
+	HandlerResultStore
+
 	// HealthStore provides an interface for getting cluster health information
 	HealthStore
 
@@ -158,12 +237,19 @@ type Store interface {
 	// KeepaliveStore provides an interface for managing entities keepalives
 	KeepaliveStore
 
+	// MetricThresholdStore provides an interface for managing the breaches
+	// of the metric thresholds of checks
+	MetricThresholdStore
+
 	// MutatorStore provides an interface for managing events mutators
 	MutatorStore
 
 	// NamespaceStore provides an interface for managing namespaces
 	NamespaceStore
 
+	// NamespaceMuteStore provides an interface for muting namespaces
+	NamespaceMuteStore
+
 	// ClusterRoleStore provides an interface for managing cluster roles
 	ClusterRoleStore
 
@@ -186,17 +272,45 @@ type Store interface {
 	// UserStore provides an interface for managing users
 	UserStore
 
+	// WatchStore provides an interface for watching the changes of resources
+	WatchStore
+
 	// ExtensionRegistry tracks third-party extensions.
 	ExtensionRegistry
 
 	// ResourceStore ...
 	ResourceStore
 
+	// ResourceTxnStore provides an interface for modifying several resources
+	// atomically
+	ResourceTxnStore
+
 	// NewInitializer returns the Initializer interfaces, which provides the
 	// required mechanism to verify if a store is initialized
 	NewInitializer() (Initializer, error)
 }
 
+// AgentDrainStore provides methods for draining the agent sessions of the
+// backends of the cluster, identified by their name. The drains are kept
+// until they're stopped, including across the restarts of the backends.
+type AgentDrainStore interface {
+	// GetAgentDrain returns whether the agent sessions of the backend are
+	// drained.
+	GetAgentDrain(ctx context.Context, backend string) (bool, error)
+
+	// GetAgentDrains returns the names of the backends whose agent sessions
+	// are drained.
+	GetAgentDrains(ctx context.Context) ([]string, error)
+
+	// UpdateAgentDrain starts draining the agent sessions of the backend, or
+	// stops draining them if draining is false.
+	UpdateAgentDrain(ctx context.Context, backend string, draining bool) error
+
+	// GetAgentDrainWatcher returns a watcher of the drain of the agent
+	// sessions of the backend.
+	GetAgentDrainWatcher(ctx context.Context, backend string) <-chan WatchEventAgentDrain
+}
+
 // AssetStore provides methods for managing checks assets
 type AssetStore interface {
 	// DeleteAssetByName deletes an asset using the given name and the
@@ -246,12 +360,85 @@ type CheckConfigStore interface {
 
 	// UpdateCheckConfig creates or updates a given check's configuration.
 	UpdateCheckConfig(ctx context.Context, check *types.CheckConfig) error
+}
+
+// CheckPauseStore provides methods for pausing the scheduling of checks
+type CheckPauseStore interface {
+	// GetCheckPause returns the pause of the check with the given name, within
+	// the namespace stored in ctx. The result is nil if the check is not
+	// paused.
+	GetCheckPause(ctx context.Context, name string) (*types.CheckPause, error)
+
+	// UpdateCheckPause pauses or resumes the check with the given name, within
+	// the namespace stored in ctx.
+	UpdateCheckPause(ctx context.Context, name string, pause *types.CheckPause) error
+}
+
+// CheckRequestStore provides methods for recording the latest check request
+// of each check issued to the subscriptions, so that any backend of the
+// cluster can replay them to the agents which missed them. The requests are
+// only kept for the time they're recorded for.
+type CheckRequestStore interface {
+	// RecordCheckRequests records the check requests issued to the
+	// subscriptions under a lease expiring after ttl seconds, replacing the
+	// requests previously recorded for the same checks and subscriptions.
+	RecordCheckRequests(ctx context.Context, requests []*SubscriptionCheckRequest, ttl int64) error
+
+	// GetCheckRequests returns the check requests recorded for the
+	// subscriptions, within the namespace stored in ctx.
+	GetCheckRequests(ctx context.Context, subscriptions []string) ([]*SubscriptionCheckRequest, error)
+
+	// DeleteCheckRequests deletes the check requests of the check recorded
+	// for the subscription, within the namespace stored in ctx.
+	DeleteCheckRequests(ctx context.Context, subscription, check string) error
+}
+
+// SubscriptionCheckRequest is a check request issued to a subscription of the
+// namespace of its check.
+type SubscriptionCheckRequest struct {
+	// Subscription is the subscription the request was issued to.
+	Subscription string
+
+	// Request is the check request.
+	Request *corev2.CheckRequest
+}
+
+// ClusterConfigStore provides methods for managing the cluster configuration
+type ClusterConfigStore interface {
+	// GetClusterConfig returns the cluster configuration, which is empty if
+	// it was never updated.
+	GetClusterConfig(ctx context.Context) (*corev2.ClusterConfig, error)
 
-	// GetCheckConfigWatcher returns a channel that emits CheckConfigWatchEvents notifying
-	// the caller that a CheckConfig was updated. If the watcher runs into a terminal error
-	// or the context passed is cancelled, then the channel will be closed. The caller must
-	// restart the watcher, if needed.
-	GetCheckConfigWatcher(ctx context.Context) <-chan WatchEventCheckConfig
+	// UpdateClusterConfig updates the cluster configuration.
+	UpdateClusterConfig(ctx context.Context, config *corev2.ClusterConfig) error
+
+	// GetClusterConfigWatcher returns a cluster configuration watcher
+	GetClusterConfigWatcher(ctx context.Context) <-chan WatchEventClusterConfig
+}
+
+// CompositeCheckStateStore provides methods for managing the statuses of the
+// members of composite checks, which are recorded so that composite checks are
+// evaluated without reading the events of all their members.
+type CompositeCheckStateStore interface {
+	// InitializeCompositeCheckStates records the statuses of the members of
+	// the composite check from their events, forgetting the statuses of its
+	// former members, and returns the statuses by member. The members without
+	// an event have no status.
+	InitializeCompositeCheckStates(ctx context.Context, composite *corev2.CompositeCheck) (map[corev2.CompositeMember]uint32, error)
+
+	// UpdateCompositeCheckState records the status of the member of the
+	// composite check, and returns the statuses of all its members.
+	UpdateCompositeCheckState(ctx context.Context, composite *corev2.CompositeCheck, member corev2.CompositeMember, status uint32) (map[corev2.CompositeMember]uint32, error)
+
+	// DeleteCompositeCheckState forgets the status of the member of the
+	// composite check, and returns the statuses of its remaining members. The
+	// statuses are nil if the status of the member wasn't recorded, e.g. if
+	// another backend already forgot it.
+	DeleteCompositeCheckState(ctx context.Context, composite *corev2.CompositeCheck, member corev2.CompositeMember) (map[corev2.CompositeMember]uint32, error)
+
+	// DeleteCompositeCheckStates forgets the statuses of the members of the
+	// composite check.
+	DeleteCompositeCheckStates(ctx context.Context, composite *corev2.CompositeCheck) error
 }
 
 // ClusterIDStore provides methods for managing the sensu cluster id
@@ -411,6 +598,36 @@ type HandlerStore interface {
 	UpdateHandler(ctx context.Context, handler *types.Handler) error
 }
 
+// HandlerResultStore provides methods for managing the results of handler
+// executions
+type HandlerResultStore interface {
+	// GetHandlerResults returns the latest result of every handler executed
+	// for the event of the given entity and check, within the namespace stored
+	// in ctx. A nil slice with no error is returned if none were found.
+	GetHandlerResults(ctx context.Context, entity, check string) ([]*types.HandlerResult, error)
+
+	// UpdateHandlerResults stores the results of handler executions, in as
+	// few transactions as possible, replacing the previous results of the
+	// handlers for the same events.
+	UpdateHandlerResults(ctx context.Context, results []*EventHandlerResult) error
+}
+
+// EventHandlerResult is the result of a handler execution for the event of an
+// entity and check.
+type EventHandlerResult struct {
+	// Namespace is the namespace of the event.
+	Namespace string
+
+	// Entity is the name of the entity of the event.
+	Entity string
+
+	// Check is the name of the check of the event.
+	Check string
+
+	// Result is the result of the handler execution.
+	Result *types.HandlerResult
+}
+
 // HealthStore provides methods for cluster health
 type HealthStore interface {
 	GetClusterHealth(ctx context.Context, cluster clientv3.Cluster, etcdClientTLSConfig *tls.Config) *types.HealthResponse
@@ -424,9 +641,42 @@ type KeepaliveStore interface {
 	// GetFailingKeepalives returns a slice of failing keepalives.
 	GetFailingKeepalives(ctx context.Context) ([]*types.KeepaliveRecord, error)
 
+	// GetFailingKeepalive returns the failing keepalive of the given entity, or
+	// nil if its keepalives are not failing.
+	GetFailingKeepalive(ctx context.Context, entity *types.Entity) (*types.KeepaliveRecord, error)
+
 	// UpdateFailingKeepalive updates the given entity keepalive with the given expiration
 	// in unix timestamp format
 	UpdateFailingKeepalive(ctx context.Context, entity *types.Entity, expiration int64) error
+
+	// RecordKeepaliveConflict records that keepalives were received from
+	// several agents for the given entity. The record expires after ttl
+	// seconds.
+	RecordKeepaliveConflict(ctx context.Context, entity *types.Entity, ttl int64) error
+
+	// GetKeepaliveConflict returns the unix timestamp at which the last
+	// conflicting keepalive of the given entity was recorded, or 0 if none was.
+	GetKeepaliveConflict(ctx context.Context, entity *types.Entity) (int64, error)
+
+	// DeleteKeepaliveConflict deletes the conflict record of the given entity,
+	// and returns false if it was already deleted.
+	DeleteKeepaliveConflict(ctx context.Context, entity *types.Entity) (bool, error)
+}
+
+// MetricThresholdStore provides methods for managing the number of
+// consecutive points breaching the metric thresholds of checks, which are
+// counted across the check executions
+type MetricThresholdStore interface {
+	// GetMetricThresholdBreaches returns the number of consecutive points
+	// breaching each metric threshold of the check of the event of the given
+	// entity and check, by rule, within the namespace stored in ctx. A nil
+	// map with no error is returned if none were stored.
+	GetMetricThresholdBreaches(ctx context.Context, entity, check string) (map[string]int, error)
+
+	// UpdateMetricThresholdBreaches stores the number of consecutive points
+	// breaching each metric threshold of the check of the event of the given
+	// entity and check, replacing the previous ones.
+	UpdateMetricThresholdBreaches(ctx context.Context, entity, check string, breaches map[string]int) error
 }
 
 // MutatorStore provides methods for managing events mutators
@@ -468,6 +718,24 @@ type NamespaceStore interface {
 	UpdateNamespace(ctx context.Context, org *types.Namespace) error
 }
 
+// NamespaceMuteStore provides methods for muting namespaces
+type NamespaceMuteStore interface {
+	// GetNamespaceMute returns the muting switch of the given namespace. The
+	// result is nil if the namespace is not muted.
+	GetNamespaceMute(ctx context.Context, namespace string) (*types.NamespaceMute, error)
+
+	// GetNamespaceMutes returns the muting switches of the muted namespaces,
+	// by namespace.
+	GetNamespaceMutes(ctx context.Context) (map[string]*types.NamespaceMute, error)
+
+	// GetNamespaceMuteWatcher returns a watcher of the muting switches of the
+	// namespaces.
+	GetNamespaceMuteWatcher(ctx context.Context) <-chan WatchEventNamespaceMute
+
+	// UpdateNamespaceMute mutes or unmutes the given namespace.
+	UpdateNamespaceMute(ctx context.Context, namespace string, mute *types.NamespaceMute) error
+}
+
 // ResourceStore ...
 type ResourceStore interface {
 	CreateResource(ctx context.Context, resource corev2.Resource) error
@@ -481,6 +749,49 @@ type ResourceStore interface {
 	ListResources(ctx context.Context, kind string, resources interface{}, pred *SelectionPredicate) error
 }
 
+// NoVersion is the version of the resources that do not exist. A ResourceOp
+// with this version only creates its resource if it doesn't already exist.
+const NoVersion int64 = -1
+
+// MaxResourceOps is the maximum number of operations of a transaction.
+const MaxResourceOps = 128
+
+// ResourceOp is an operation on a resource, applied as part of a transaction.
+type ResourceOp struct {
+	// Resource is the resource to create or update, or to delete if Delete is
+	// true, in which case only its metadata is used.
+	Resource corev2.Resource
+
+	// Delete is true if the resource is deleted.
+	Delete bool
+
+	// Version is the version the resource must have for the transaction to be
+	// committed, as returned by GetResourceVersion, or NoVersion if it must
+	// not exist. The operation is applied regardless of the version of the
+	// resource if Version is 0.
+	Version int64
+}
+
+// ResourceTxnStore provides methods for modifying several resources
+// atomically
+type ResourceTxnStore interface {
+	// CommitResources applies all the operations in a single transaction, so
+	// that either all of them or none are applied. The namespace of each
+	// resource must exist, or be created by the transaction.
+	CommitResources(ctx context.Context, ops []ResourceOp) error
+
+	// GetResourceVersion returns the version of the resource with the given
+	// name, which changes every time the resource is modified, or NoVersion
+	// if it doesn't exist.
+	GetResourceVersion(ctx context.Context, name string, resource corev2.Resource) (int64, error)
+
+	// GetVersionedResource gets the resource with the given name and returns
+	// its version, in a single read, so that the resource can be updated at
+	// the version it was read at. It returns ErrNotFound if the resource
+	// doesn't exist.
+	GetVersionedResource(ctx context.Context, name string, resource corev2.Resource) (int64, error)
+}
+
 // RoleBindingStore provides methods for managing RBAC role bindings
 type RoleBindingStore interface {
 	// Create a given role binding
@@ -596,6 +907,43 @@ type UserStore interface {
 
 	// UpdateHandler updates a given user.
 	UpdateUser(user *types.User) error
+
+	// ResetUserPassword replaces the password hash of the given user with
+	// password, if its stored password hash is still previous. A *ErrConflict
+	// is returned otherwise, or if the user was disabled in the meantime, so
+	// that a password can only be reset once with the same token.
+	ResetUserPassword(ctx context.Context, username, previous, password string) error
+}
+
+// WatchStore provides methods for watching the changes of resources, so the
+// daemons can keep their copies of the resources up to date without polling
+// the store. The resources of the namespace stored in ctx are watched, or the
+// resources of all the namespaces if there is none. The channels are closed
+// once ctx is cancelled.
+//
+// Changes may be missed when the watch is interrupted, e.g. once the store has
+// compacted the revisions it was resuming from. The channels then emit an
+// event with the WatchError action and no resource, after which the watched
+// resources must be fetched again.
+type WatchStore interface {
+	// WatchChecks watches the check configurations.
+	WatchChecks(ctx context.Context) <-chan WatchEventCheckConfig
+
+	// WatchAssets watches the assets.
+	WatchAssets(ctx context.Context) <-chan WatchEventAsset
+
+	// WatchHooks watches the hook configurations.
+	WatchHooks(ctx context.Context) <-chan WatchEventHookConfig
+
+	// WatchSilenced watches the silenced entries.
+	WatchSilenced(ctx context.Context) <-chan WatchEventSilenced
+
+	// WatchResources watches the resources of the type of the given resource.
+	WatchResources(ctx context.Context, resource corev2.Resource) <-chan WatchEventResource
+
+	// WatchDeletedEvents watches the deletions of the events, which are
+	// emitted as they were before their deletion.
+	WatchDeletedEvents(ctx context.Context) <-chan WatchEventResource
 }
 
 // Initializer provides methods to verify if a store is initialized
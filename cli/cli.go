@@ -8,6 +8,8 @@ import (
 	"github.com/sensu/sensu-go/cli/client"
 	"github.com/sensu/sensu-go/cli/client/config"
 	"github.com/sensu/sensu-go/cli/client/config/basic"
+	"github.com/sensu/sensu-go/cli/commands/timeutil"
+	"github.com/sensu/sensu-go/cli/elements/table"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
 )
@@ -30,6 +32,19 @@ type SensuCli struct {
 // New SensuCLI given persistent flags from command
 func New(flags *pflag.FlagSet) *SensuCli {
 	conf := basic.Load(flags)
+
+	timeutil.SetTimeFormat(conf.TimeFormat())
+	if flags != nil {
+		if noHeader, err := flags.GetBool("no-header"); err == nil {
+			table.SetNoHeader(noHeader)
+		}
+	}
+
+	return NewWithConfig(conf)
+}
+
+// NewWithConfig returns a SensuCLI given its configuration
+func NewWithConfig(conf config.Config) *SensuCli {
 	client := client.New(conf)
 	logger := logrus.WithFields(logrus.Fields{
 		"component": "cli-client",
@@ -3,6 +3,7 @@ package client
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 )
@@ -76,6 +77,83 @@ func (client *RestClient) ExecuteCheck(req *corev2.AdhocRequest) error {
 	return nil
 }
 
+// SetChecksPublish enables or disables the publication of the checks selected
+// by the provided request
+func (client *RestClient) SetChecksPublish(req *corev2.CheckPublishRequest) (*corev2.CheckPublishResponse, error) {
+	bytes, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	path := ChecksPath(client.config.Namespace(), "publish")
+	res, err := client.R().SetBody(bytes).Post(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	response := &corev2.CheckPublishResponse{}
+	err = json.Unmarshal(res.Body(), response)
+	return response, err
+}
+
+// CheckSchedulePreview fetches the executions of a check scheduled by the
+// backend in the given window, starting now
+func (client *RestClient) CheckSchedulePreview(name string, window time.Duration) (*corev2.CheckSchedulePreview, error) {
+	path := ChecksPath(client.config.Namespace(), name, "schedule-preview")
+	res, err := client.R().SetQueryParam("window", window.String()).Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("GET %q: %s", path, err)
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	preview := &corev2.CheckSchedulePreview{}
+	err = json.Unmarshal(res.Body(), preview)
+	return preview, err
+}
+
+// PauseCheck pauses the scheduling of a check, and returns its pause
+func (client *RestClient) PauseCheck(name, reason string) (*corev2.CheckPause, error) {
+	bytes, err := json.Marshal(&corev2.CheckPause{Paused: true, Reason: reason})
+	if err != nil {
+		return nil, err
+	}
+
+	path := ChecksPath(client.config.Namespace(), name, "pause")
+	res, err := client.R().SetBody(bytes).Post(path)
+	if err != nil {
+		return nil, fmt.Errorf("POST %q: %s", path, err)
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	pause := &corev2.CheckPause{}
+	err = json.Unmarshal(res.Body(), pause)
+	return pause, err
+}
+
+// ResumeCheck resumes the scheduling of a paused check
+func (client *RestClient) ResumeCheck(name string) error {
+	path := ChecksPath(client.config.Namespace(), name, "resume")
+	res, err := client.R().Post(path)
+	if err != nil {
+		return fmt.Errorf("POST %q: %s", path, err)
+	}
+
+	if res.StatusCode() >= 400 {
+		return UnmarshalError(res)
+	}
+	return nil
+}
+
 // FetchCheck fetches a specific check
 func (client *RestClient) FetchCheck(name string) (*corev2.CheckConfig, error) {
 	var check *corev2.CheckConfig
@@ -94,6 +172,49 @@ func (client *RestClient) FetchCheck(name string) (*corev2.CheckConfig, error) {
 	return check, err
 }
 
+// ModifyCheck fetches the check with the given name, modifies it with the
+// given function and updates it, unless the check was modified in the
+// meantime. The update is conditioned on the ETag of the fetched check, so
+// concurrent changes are reported as an error instead of being overwritten.
+func (client *RestClient) ModifyCheck(name string, modify func(*corev2.CheckConfig) error) error {
+	path := ChecksPath(client.config.Namespace(), name)
+	res, err := client.R().Get(path)
+	if err != nil {
+		return fmt.Errorf("GET %q: %s", path, err)
+	}
+
+	if res.StatusCode() >= 400 {
+		return UnmarshalError(res)
+	}
+
+	var check corev2.CheckConfig
+	if err := json.Unmarshal(res.Body(), &check); err != nil {
+		return err
+	}
+	if err := modify(&check); err != nil {
+		return err
+	}
+
+	bytes, err := json.Marshal(&check)
+	if err != nil {
+		return err
+	}
+	req := client.R().SetBody(bytes)
+	if etag := res.Header().Get("ETag"); etag != "" {
+		req.SetHeader("If-Match", etag)
+	}
+	res, err = req.Put(path)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode() >= 400 {
+		return UnmarshalError(res)
+	}
+
+	return nil
+}
+
 // AddCheckHook associates an existing hook with an existing check
 func (client *RestClient) AddCheckHook(check *corev2.CheckConfig, checkHook *corev2.HookList) error {
 	path := ChecksPath(check.Namespace, check.Name, "hooks", checkHook.Type)
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli/client/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModifyCheck(t *testing.T) {
+	const etag = `W/"abc"`
+	var updated *corev2.CheckConfig
+	testHandler := func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/core/v2/namespaces/default/checks/check-cpu", r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("ETag", etag)
+			_ = json.NewEncoder(w).Encode(corev2.FixtureCheckConfig("check-cpu"))
+		case http.MethodPut:
+			if r.Header.Get("If-Match") != etag {
+				w.WriteHeader(http.StatusConflict)
+				_, _ = w.Write([]byte(`{"message": "the resource was modified since it was read"}`))
+				return
+			}
+			updated = &corev2.CheckConfig{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(updated))
+			w.WriteHeader(http.StatusCreated)
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(testHandler))
+	defer server.Close()
+
+	mockConfig := &config.MockConfig{}
+	client := &RestClient{resty: resty.New(), config: mockConfig}
+	mockConfig.On("APIUrl").Return(server.URL)
+	mockConfig.On("Namespace").Return("default")
+	mockConfig.On("Tokens").Return(&corev2.Tokens{})
+
+	err := client.ModifyCheck("check-cpu", func(check *corev2.CheckConfig) error {
+		check.Command = "check-cpu.rb -w 80"
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, "check-cpu.rb -w 80", updated.Command)
+}
+
+func TestModifyCheckConflict(t *testing.T) {
+	testHandler := func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("ETag", `W/"abc"`)
+			_ = json.NewEncoder(w).Encode(corev2.FixtureCheckConfig("check-cpu"))
+		case http.MethodPut:
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"message": "the resource was modified since it was read"}`))
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(testHandler))
+	defer server.Close()
+
+	mockConfig := &config.MockConfig{}
+	client := &RestClient{resty: resty.New(), config: mockConfig}
+	mockConfig.On("APIUrl").Return(server.URL)
+	mockConfig.On("Namespace").Return("default")
+	mockConfig.On("Tokens").Return(&corev2.Tokens{})
+
+	err := client.ModifyCheck("check-cpu", func(check *corev2.CheckConfig) error {
+		return nil
+	})
+	assert.EqualError(t, err, "the resource was modified since it was read")
+}
@@ -4,11 +4,13 @@ import (
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/go-resty/resty/v2"
 	"github.com/sensu/sensu-go/cli/client/config"
+	apiclient "github.com/sensu/sensu-go/client"
 	"github.com/sensu/sensu-go/version"
 	"github.com/sirupsen/logrus"
 )
@@ -21,8 +23,9 @@ var ErrNotImplemented = errors.New("method not implemented")
 
 // RestClient wraps resty.Client
 type RestClient struct {
-	resty  *resty.Client
-	config config.Config
+	resty     *resty.Client
+	transport *http.Transport
+	config    config.Config
 
 	configured   bool
 	expiredToken bool
@@ -37,7 +40,8 @@ func init() {
 // New builds a new client with defaults
 func New(config config.Config) *RestClient {
 	restyInst := resty.New()
-	client := &RestClient{resty: restyInst, config: config}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	client := &RestClient{resty: restyInst, transport: transport, config: config}
 
 	// set http client timeout
 	restyInst.SetTimeout(15 * time.Second)
@@ -49,6 +53,17 @@ func New(config config.Config) *RestClient {
 	restyInst.SetHeader("Accept", "application/json")
 	restyInst.SetHeader("Content-Type", "application/json")
 
+	// Send the requests through the transport of the Go client of the API,
+	// which retries the requests that failed because of a network error or a
+	// temporary server error, such as a flaky load balancer in front of the
+	// API, and identifies the create requests with an idempotency key so that
+	// they are never processed twice
+	restyInst.SetTransport(&apiclient.Transport{
+		Base:       transport,
+		MaxRetries: apiclient.DefaultMaxRetries,
+		RetryWait:  apiclient.DefaultRetryWait,
+	})
+
 	// Check that Access-Token has not expired
 	restyInst.OnBeforeRequest(func(c *resty.Client, r *resty.Request) error {
 		c.SetHeader("User-Agent", "sensuctl/"+version.Semver())
@@ -122,7 +137,7 @@ func (client *RestClient) R() *resty.Request {
 
 // SetTLSClientConfig assigns client TLS config
 func (client *RestClient) SetTLSClientConfig(c *tls.Config) {
-	client.resty.SetTLSClientConfig(c)
+	client.transport.TLSClientConfig = c
 }
 
 // Reset client so that it reconfigure on next request
@@ -173,4 +188,8 @@ func ApplyListOptions(request *resty.Request, options *ListOptions) {
 	if options.ContinueToken != "" {
 		request.SetQueryParam("continue", options.ContinueToken)
 	}
+
+	if options.Sort != "" {
+		request.SetQueryParam("sort", options.Sort)
+	}
 }
@@ -7,10 +7,14 @@ import (
 	"strings"
 
 	"github.com/coreos/etcd/clientv3"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 )
 
 var clusterMembersPath = CreateBasePath(coreAPIGroup, coreAPIVersion, "cluster", "members")
 var clusterIDPath = CreateBasePath(coreAPIGroup, coreAPIVersion, "cluster", "id")
+var clusterAPIUsagePath = CreateBasePath(coreAPIGroup, coreAPIVersion, "cluster", "api-usage")
+var clusterEventStatsPath = CreateBasePath(coreAPIGroup, coreAPIVersion, "cluster", "event-stats")
+var clusterConfigPath = CreateBasePath(coreAPIGroup, coreAPIVersion, "cluster", "config")
 
 // MemberList lists all members in the cluster.
 func (c *RestClient) MemberList() (*clientv3.MemberListResponse, error) {
@@ -83,3 +87,67 @@ func (c *RestClient) FetchClusterID() (string, error) {
 
 	return string(res.Body()), err
 }
+
+// FetchAPIUsage fetches the API usage per user and per route of the backend
+// serving the request.
+func (c *RestClient) FetchAPIUsage() ([]corev2.APIUsage, error) {
+	path := clusterAPIUsagePath()
+	res, err := c.R().Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("GET %q: %s", path, err)
+	}
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+	var result []corev2.APIUsage
+	err = json.Unmarshal(res.Body(), &result)
+	return result, err
+}
+
+// FetchEventStats fetches the event rates per namespace and per check of the
+// backend serving the request.
+func (c *RestClient) FetchEventStats() (*corev2.EventStats, error) {
+	path := clusterEventStatsPath()
+	res, err := c.R().Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("GET %q: %s", path, err)
+	}
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+	var result corev2.EventStats
+	return &result, json.Unmarshal(res.Body(), &result)
+}
+
+// FetchClusterConfig fetches the cluster configuration.
+func (c *RestClient) FetchClusterConfig() (*corev2.ClusterConfig, error) {
+	path := clusterConfigPath()
+	res, err := c.R().Get(path)
+	if err != nil {
+		return nil, fmt.Errorf("GET %q: %s", path, err)
+	}
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+	result := &corev2.ClusterConfig{}
+	err = json.Unmarshal(res.Body(), result)
+	return result, err
+}
+
+// UpdateClusterConfig updates the cluster configuration.
+func (c *RestClient) UpdateClusterConfig(config *corev2.ClusterConfig) error {
+	bytes, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	path := clusterConfigPath()
+	res, err := c.R().SetBody(bytes).Put(path)
+	if err != nil {
+		return fmt.Errorf("PUT %q: %s", path, err)
+	}
+	if res.StatusCode() >= 400 {
+		return UnmarshalError(res)
+	}
+	return nil
+}
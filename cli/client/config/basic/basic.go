@@ -2,10 +2,12 @@ package basic
 
 import (
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 
+	"github.com/sensu/sensu-go/cli/client/config"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
 	"github.com/sensu/sensu-go/types"
 	"github.com/sirupsen/logrus"
@@ -14,6 +16,7 @@ import (
 
 const (
 	clusterFilename = "cluster"
+	clustersDirname = "clusters"
 	profileFilename = "profile"
 )
 
@@ -26,6 +29,10 @@ type Config struct {
 	Cluster
 	Profile
 	path string
+
+	// clusterName is the name of the cluster selected with the --cluster
+	// flag
+	clusterName string
 }
 
 // Cluster contains the Sensu cluster access information
@@ -34,12 +41,27 @@ type Cluster struct {
 	TrustedCAFile         string `json:"trusted-ca-file"`
 	InsecureSkipTLSVerify bool   `json:"insecure-skip-tls-verify"`
 	*types.Tokens
+
+	// KeychainTokens is true if the tokens are stored in the keychain of the
+	// operating system rather than in the cluster configuration file
+	KeychainTokens bool `json:"keychain-tokens,omitempty"`
+}
+
+// stored returns the cluster configuration as stored in the cluster
+// configuration file, without the tokens stored in the keychain
+func (c Cluster) stored() Cluster {
+	if c.KeychainTokens {
+		c.Tokens = nil
+	}
+	return c
 }
 
 // Profile contains the active configuration
 type Profile struct {
-	Format    string `json:"format"`
-	Namespace string `json:"namespace"`
+	Format                 string `json:"format"`
+	Namespace              string `json:"namespace"`
+	SilencedReasonTemplate string `json:"silenced-reason-template,omitempty"`
+	TimeFormat             string `json:"time-format,omitempty"`
 }
 
 // Load imports the CLI configuration and returns an initialized Config struct
@@ -68,6 +90,10 @@ func Load(flags *pflag.FlagSet) *Config {
 		if value, err := flags.GetString("config-dir"); err == nil && value != "" {
 			conf.path = value
 		}
+
+		if value, err := flags.GetString("cluster"); err == nil && value != config.AllClusters {
+			conf.clusterName = value
+		}
 	}
 
 	// Load the profile config file
@@ -76,9 +102,12 @@ func Load(flags *pflag.FlagSet) *Config {
 	}
 
 	// Load the cluster config file
-	if err := conf.open(clusterFilename); err != nil {
+	if err := conf.open(conf.clusterFile()); err != nil {
 		logger.Debug(err)
 	}
+	if err := conf.loadKeychainTokens(); err != nil {
+		logger.WithError(err).Warn("couldn't read the tokens from the keychain")
+	}
 
 	if flags != nil {
 		// Override namespace
@@ -112,6 +141,64 @@ func (c *Config) flags(flags *pflag.FlagSet) {
 	}
 }
 
+// ClusterName returns the name of the cluster of the configuration
+func (c *Config) ClusterName() string {
+	if c.clusterName == "" {
+		return config.DefaultCluster
+	}
+	return c.clusterName
+}
+
+// ClusterNames returns the names of the configured clusters, including the
+// default cluster if it is configured
+func (c *Config) ClusterNames() ([]string, error) {
+	var names []string
+	if _, err := os.Stat(filepath.Join(c.path, clusterFilename)); err == nil {
+		names = append(names, config.DefaultCluster)
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(c.path, clustersDirname))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, file := range files {
+		if file.IsDir() || file.Name() == config.DefaultCluster {
+			continue
+		}
+		names = append(names, file.Name())
+	}
+	return names, nil
+}
+
+// WithCluster returns a copy of the configuration, with the cluster
+// configuration of the given cluster instead of the current one. The cluster
+// flags, such as --api-url, are not applied to it.
+func (c *Config) WithCluster(name string) (*Config, error) {
+	conf := &Config{
+		Profile: c.Profile,
+		path:    c.path,
+	}
+	if name != config.DefaultCluster {
+		conf.clusterName = name
+	}
+	if err := conf.open(conf.clusterFile()); err != nil {
+		return nil, fmt.Errorf("cluster %q is not configured: %s", name, err)
+	}
+	if err := conf.loadKeychainTokens(); err != nil {
+		logger.WithError(err).Warn("couldn't read the tokens from the keychain")
+	}
+	return conf, nil
+}
+
+// clusterFile returns the path of the cluster configuration file, relative to
+// the configuration directory
+func (c *Config) clusterFile() string {
+	if c.clusterName == "" || c.clusterName == config.DefaultCluster {
+		return clusterFilename
+	}
+	return filepath.Join(clustersDirname, filepath.Base(c.clusterName))
+}
+
 func (c *Config) open(path string) error {
 	content, err := ioutil.ReadFile(filepath.Join(c.path, path))
 	if err != nil {
@@ -85,3 +85,42 @@ func TestOpenMissingFile(t *testing.T) {
 	err := config.open("/tmp/sensu/missingfile")
 	assert.Error(t, err)
 }
+
+func TestClusters(t *testing.T) {
+	// Create a dummy directory for testing
+	dir, err := ioutil.TempDir("", "sensu")
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, os.RemoveAll(dir))
+	}()
+
+	// Create the default cluster and a named cluster
+	require.NoError(t, write(&Cluster{APIUrl: "https://default"}, filepath.Join(dir, clusterFilename)))
+	require.NoError(t, write(&Cluster{APIUrl: "https://us"}, filepath.Join(dir, clustersDirname, "us")))
+
+	// Select the named cluster
+	flags := pflag.NewFlagSet("config-dir", pflag.ContinueOnError)
+	flags.String("config-dir", dir, "")
+	flags.String("cluster", "us", "")
+	config := Load(flags)
+	assert.Equal(t, "us", config.ClusterName())
+	assert.Equal(t, "https://us", config.APIUrl())
+
+	names, err := config.ClusterNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "us"}, names)
+
+	other, err := config.WithCluster("default")
+	require.NoError(t, err)
+	assert.Equal(t, "default", other.ClusterName())
+	assert.Equal(t, "https://default", other.APIUrl())
+
+	_, err = config.WithCluster("eu")
+	assert.Error(t, err)
+
+	// The configuration of the named cluster is saved into its file
+	require.NoError(t, config.SaveAPIUrl("https://us-east"))
+	reloaded, err := other.WithCluster("us")
+	require.NoError(t, err)
+	assert.Equal(t, "https://us-east", reloaded.APIUrl())
+}
@@ -0,0 +1,91 @@
+package basic
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+
+	"github.com/sensu/sensu-go/types"
+)
+
+// keychainService is the service of the keychain items of sensuctl
+const keychainService = "sensuctl"
+
+// errKeychainItemNotFound is returned by keychains without the requested item
+var errKeychainItemNotFound = errors.New("keychain item not found")
+
+// keychain stores secrets in the keychain of the operating system, by account
+type keychain interface {
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+}
+
+// newKeychain returns the keychain of the operating system, or nil if none is
+// available
+var newKeychain = systemKeychain
+
+// keychainAccount returns the keychain account of the tokens of the cluster,
+// the absolute path of its configuration file, so that each configuration
+// directory and cluster has its own tokens
+func (c *Config) keychainAccount() string {
+	path := filepath.Join(c.path, c.clusterFile())
+	if absolute, err := filepath.Abs(path); err == nil {
+		return absolute
+	}
+	return path
+}
+
+// loadKeychainTokens reads the tokens of the cluster from the keychain, if they
+// are stored there
+func (c *Config) loadKeychainTokens() error {
+	if !c.Cluster.KeychainTokens {
+		return nil
+	}
+	kc := newKeychain()
+	if kc == nil {
+		return errors.New("the tokens are stored in the keychain, but no keychain is available")
+	}
+	secret, err := kc.Get(c.keychainAccount())
+	if err != nil {
+		return err
+	}
+	data, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return err
+	}
+	tokens := &types.Tokens{}
+	if err := json.Unmarshal(data, tokens); err != nil {
+		return err
+	}
+	c.Cluster.Tokens = tokens
+	return nil
+}
+
+// saveKeychainTokens stores the tokens of the cluster in the keychain, or
+// deletes them from it if they are empty, and returns whether they are stored
+// there. The tokens are base64 encoded so they can be passed to the keychain
+// tools as is.
+func (c *Config) saveKeychainTokens(tokens *types.Tokens) bool {
+	kc := newKeychain()
+	if kc == nil {
+		return false
+	}
+	account := c.keychainAccount()
+	if tokens == nil || tokens.Access == "" {
+		if err := kc.Delete(account); err != nil && err != errKeychainItemNotFound {
+			logger.WithError(err).Debug("couldn't delete the tokens from the keychain")
+		}
+		return false
+	}
+	data, err := json.Marshal(tokens)
+	if err != nil {
+		return false
+	}
+	if err := kc.Set(account, base64.StdEncoding.EncodeToString(data)); err != nil {
+		logger.WithError(err).Warn("couldn't store the tokens in the keychain, storing them in the configuration file")
+		return false
+	}
+	return true
+}
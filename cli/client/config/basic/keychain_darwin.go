@@ -0,0 +1,63 @@
+package basic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// securityKeychain stores secrets in the macOS keychain with the security
+// tool
+type securityKeychain struct{}
+
+func systemKeychain() keychain {
+	if _, err := exec.LookPath("security"); err != nil {
+		return nil
+	}
+	return securityKeychain{}
+}
+
+// securityItemNotFound is the exit code of the security tool when the item
+// doesn't exist
+const securityItemNotFound = 44
+
+func (securityKeychain) Get(account string) (string, error) {
+	cmd := exec.Command("security", "find-generic-password", "-s", keychainService, "-a", account, "-w")
+	out, err := cmd.Output()
+	if err != nil {
+		return "", securityError(err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Set passes the secret through the standard input of the security tool, in
+// interactive mode, so it doesn't appear in the arguments of the process.
+func (securityKeychain) Set(account, secret string) error {
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf(
+		"add-generic-password -U -s %s -a %s -w %s\n",
+		strconv.Quote(keychainService), strconv.Quote(account), strconv.Quote(secret),
+	))
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (securityKeychain) Delete(account string) error {
+	cmd := exec.Command("security", "delete-generic-password", "-s", keychainService, "-a", account)
+	return securityError(cmd.Run())
+}
+
+func securityError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == securityItemNotFound {
+		return errKeychainItemNotFound
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+package basic
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// secretToolKeychain stores secrets in the Secret Service, e.g. the GNOME
+// keyring, with the secret-tool tool of libsecret
+type secretToolKeychain struct{}
+
+// systemKeychain returns the Secret Service keychain if secret-tool is
+// installed and a D-Bus session is running, which is usually not the case on
+// servers
+func systemKeychain() keychain {
+	if os.Getenv("DBUS_SESSION_BUS_ADDRESS") == "" {
+		return nil
+	}
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return nil
+	}
+	return secretToolKeychain{}
+}
+
+// Get returns the secret of the account. secret-tool exits with an error and
+// prints nothing when the item doesn't exist.
+func (secretToolKeychain) Get(account string) (string, error) {
+	cmd := exec.Command("secret-tool", "lookup", "service", keychainService, "account", account)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if stderr.Len() == 0 {
+			return "", errKeychainItemNotFound
+		}
+		return "", fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Set passes the secret through the standard input of secret-tool, so it
+// doesn't appear in the arguments of the process.
+func (secretToolKeychain) Set(account, secret string) error {
+	cmd := exec.Command("secret-tool", "store", "--label", keychainService+" "+account,
+		"service", keychainService, "account", account)
+	cmd.Stdin = strings.NewReader(secret)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (secretToolKeychain) Delete(account string) error {
+	return exec.Command("secret-tool", "clear", "service", keychainService, "account", account).Run()
+}
@@ -0,0 +1,9 @@
+// +build !darwin,!linux
+
+package basic
+
+// systemKeychain returns nil, no keychain is supported on this platform, so
+// the tokens are stored in the configuration file
+func systemKeychain() keychain {
+	return nil
+}
@@ -0,0 +1,94 @@
+package basic
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/sensu/sensu-go/types"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	// Never use the keychain of the operating system in the tests
+	newKeychain = func() keychain { return nil }
+}
+
+type fakeKeychain map[string]string
+
+func (k fakeKeychain) Get(account string) (string, error) {
+	secret, ok := k[account]
+	if !ok {
+		return "", errKeychainItemNotFound
+	}
+	return secret, nil
+}
+
+func (k fakeKeychain) Set(account, secret string) error {
+	k[account] = secret
+	return nil
+}
+
+func (k fakeKeychain) Delete(account string) error {
+	if _, ok := k[account]; !ok {
+		return errKeychainItemNotFound
+	}
+	delete(k, account)
+	return nil
+}
+
+func TestSaveTokensKeychain(t *testing.T) {
+	kc := fakeKeychain{}
+	newKeychain = func() keychain { return kc }
+	defer func() { newKeychain = func() keychain { return nil } }()
+
+	dir, cleanup := tmpDir(t)
+	defer cleanup()
+
+	flags := pflag.NewFlagSet("config-dir", pflag.ContinueOnError)
+	flags.String("config-dir", dir, "")
+
+	config := Load(flags)
+	require.NoError(t, config.SaveAPIUrl("http://127.0.0.1:8080"))
+
+	tokens := &types.Tokens{Access: "foo", Refresh: "bar"}
+	require.NoError(t, config.SaveTokens(tokens))
+	assert.Equal(t, tokens, config.Tokens())
+	assert.Len(t, kc, 1)
+
+	// The tokens are not in the cluster file, even when it's written again
+	require.NoError(t, config.SaveInsecureSkipTLSVerify(true))
+	content, err := ioutil.ReadFile(filepath.Join(dir, clusterFilename))
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "foo")
+	assert.Contains(t, string(content), `"keychain-tokens": true`)
+
+	// The tokens are read from the keychain
+	config = Load(flags)
+	assert.Equal(t, tokens, config.Tokens())
+	assert.Equal(t, "http://127.0.0.1:8080", config.APIUrl())
+
+	// Empty tokens are deleted from the keychain
+	require.NoError(t, config.SaveTokens(&types.Tokens{}))
+	assert.Empty(t, kc)
+	config = Load(flags)
+	assert.False(t, config.Cluster.KeychainTokens)
+}
+
+func TestSaveTokensNoKeychain(t *testing.T) {
+	dir, cleanup := tmpDir(t)
+	defer cleanup()
+
+	flags := pflag.NewFlagSet("config-dir", pflag.ContinueOnError)
+	flags.String("config-dir", dir, "")
+
+	config := Load(flags)
+	tokens := &types.Tokens{Access: "foo"}
+	require.NoError(t, config.SaveTokens(tokens))
+	assert.False(t, config.Cluster.KeychainTokens)
+
+	config = Load(flags)
+	assert.Equal(t, tokens, config.Tokens())
+}
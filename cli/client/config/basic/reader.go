@@ -31,6 +31,20 @@ func (c *Config) Namespace() string {
 	return c.Profile.Namespace
 }
 
+// SilencedReasonTemplate returns the template applied to the reason of the
+// silenced entries created by the user
+func (c *Config) SilencedReasonTemplate() string {
+	return c.Profile.SilencedReasonTemplate
+}
+
+// TimeFormat returns the user's preferred time format
+func (c *Config) TimeFormat() string {
+	if c.Profile.TimeFormat == "" {
+		return config.DefaultTimeFormat
+	}
+	return c.Profile.TimeFormat
+}
+
 // Tokens returns the active cluster JWT
 func (c *Config) Tokens() *types.Tokens {
 	return c.Cluster.Tokens
@@ -33,6 +33,21 @@ func TestNamespaceDefault(t *testing.T) {
 	assert.Equal(t, config.DefaultNamespace, conf.Namespace())
 }
 
+func TestSilencedReasonTemplate(t *testing.T) {
+	conf := &Config{Profile: Profile{SilencedReasonTemplate: "[ops] "}}
+	assert.Equal(t, conf.Profile.SilencedReasonTemplate, conf.SilencedReasonTemplate())
+}
+
+func TestTimeFormat(t *testing.T) {
+	conf := &Config{Profile: Profile{TimeFormat: "utc"}}
+	assert.Equal(t, conf.Profile.TimeFormat, conf.TimeFormat())
+}
+
+func TestTimeFormatDefault(t *testing.T) {
+	conf := &Config{}
+	assert.Equal(t, config.DefaultTimeFormat, conf.TimeFormat())
+}
+
 func TestTokens(t *testing.T) {
 	tokens := &types.Tokens{Access: "foobar"}
 	conf := &Config{Cluster: Cluster{Tokens: tokens}}
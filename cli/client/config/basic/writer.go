@@ -13,7 +13,7 @@ import (
 func (c *Config) SaveAPIUrl(url string) error {
 	c.Cluster.APIUrl = url
 
-	return write(c.Cluster, filepath.Join(c.path, clusterFilename))
+	return write(c.Cluster.stored(), filepath.Join(c.path, c.clusterFile()))
 }
 
 // SaveFormat saves the user's format preference into a configuration file
@@ -27,7 +27,7 @@ func (c *Config) SaveFormat(format string) error {
 func (c *Config) SaveInsecureSkipTLSVerify(verify bool) error {
 	c.Cluster.InsecureSkipTLSVerify = verify
 
-	return write(c.Cluster, filepath.Join(c.path, clusterFilename))
+	return write(c.Cluster.stored(), filepath.Join(c.path, c.clusterFile()))
 }
 
 // SaveNamespace saves the user's default namespace to a configuration file
@@ -37,18 +37,37 @@ func (c *Config) SaveNamespace(namespace string) error {
 	return write(c.Profile, filepath.Join(c.path, profileFilename))
 }
 
-// SaveTokens saves the JWT into a configuration file
+// SaveSilencedReasonTemplate saves the template applied to the reason of the
+// silenced entries into a configuration file
+func (c *Config) SaveSilencedReasonTemplate(template string) error {
+	c.Profile.SilencedReasonTemplate = template
+
+	return write(c.Profile, filepath.Join(c.path, profileFilename))
+}
+
+// SaveTimeFormat saves the user's time format preference into a configuration
+// file
+func (c *Config) SaveTimeFormat(format string) error {
+	c.Profile.TimeFormat = format
+
+	return write(c.Profile, filepath.Join(c.path, profileFilename))
+}
+
+// SaveTokens saves the JWT into the keychain of the operating system if one is
+// available, and into a configuration file otherwise
 func (c *Config) SaveTokens(tokens *types.Tokens) error {
 	// Update the configuration loaded in memory
 	c.Cluster.Tokens = tokens
+	c.Cluster.KeychainTokens = c.saveKeychainTokens(tokens)
 
 	// Load the configuration from the file so we don't save any configuration
 	// that was overrided with a configuration flag
 	savedConfig := &Config{}
-	_ = savedConfig.open(filepath.Join(c.path, clusterFilename))
+	_ = savedConfig.open(filepath.Join(c.path, c.clusterFile()))
 	savedConfig.Cluster.Tokens = tokens
+	savedConfig.Cluster.KeychainTokens = c.Cluster.KeychainTokens
 
-	return write(savedConfig.Cluster, filepath.Join(c.path, clusterFilename))
+	return write(savedConfig.Cluster.stored(), filepath.Join(c.path, c.clusterFile()))
 }
 
 // SaveTrustedCAFile saves the Trusted CA file
@@ -63,7 +82,7 @@ func (c *Config) SaveTrustedCAFile(file string) error {
 		c.Cluster.TrustedCAFile = ""
 	}
 
-	return write(c.Cluster, filepath.Join(c.path, clusterFilename))
+	return write(c.Cluster.stored(), filepath.Join(c.path, c.clusterFile()))
 }
 
 func write(data interface{}, path string) error {
@@ -78,5 +97,5 @@ func write(data interface{}, path string) error {
 		return err
 	}
 
-	return ioutil.WriteFile(path, bytes, 0644)
+	return ioutil.WriteFile(path, bytes, 0600)
 }
@@ -8,6 +8,14 @@ const (
 	// DefaultNamespace represents the default namespace
 	DefaultNamespace = "default"
 
+	// DefaultCluster is the name of the cluster configured without the
+	// --cluster flag
+	DefaultCluster = "default"
+
+	// AllClusters is the value of the --cluster flag that runs the list and
+	// info commands against all the configured clusters
+	AllClusters = "all"
+
 	// DefaultFormat is the default format output for printers.
 	DefaultFormat = FormatTabular
 
@@ -23,8 +31,24 @@ const (
 	// FormatYAML indicates YAML format for printers. It has the same layout
 	// as wrapped JSON.
 	FormatYAML = "yaml"
+
+	// DefaultTimeFormat is the default format of the times displayed by
+	// printers.
+	DefaultTimeFormat = TimeFormatLocal
+
+	// TimeFormatLocal displays times in RFC3339 format, in the local time zone.
+	TimeFormatLocal = "local"
+
+	// TimeFormatUTC displays times in RFC3339 format, in UTC.
+	TimeFormatUTC = "utc"
+
+	// TimeFormatHumanized displays times relative to now, e.g. "5m ago".
+	TimeFormatHumanized = "humanized"
 )
 
+// TimeFormats are the supported time formats
+var TimeFormats = []string{TimeFormatLocal, TimeFormatUTC, TimeFormatHumanized}
+
 // Config is an abstract configuration
 type Config interface {
 	Read
@@ -37,6 +61,8 @@ type Read interface {
 	Format() string
 	InsecureSkipTLSVerify() bool
 	Namespace() string
+	SilencedReasonTemplate() string
+	TimeFormat() string
 	Tokens() *types.Tokens
 	TrustedCAFile() string
 }
@@ -47,6 +73,8 @@ type Write interface {
 	SaveFormat(string) error
 	SaveInsecureSkipTLSVerify(bool) error
 	SaveNamespace(string) error
+	SaveSilencedReasonTemplate(string) error
+	SaveTimeFormat(string) error
 	SaveTokens(*types.Tokens) error
 	SaveTrustedCAFile(string) error
 }
@@ -32,6 +32,32 @@ func (client *RestClient) FetchEntity(name string) (*corev2.Entity, error) {
 	return entity, err
 }
 
+// FetchEntityKeepalive fetches the failing keepalive record of an entity, whose
+// time is zero if its keepalives are not failing
+func (client *RestClient) FetchEntityKeepalive(name string) (*corev2.KeepaliveRecord, error) {
+	keepalive := &corev2.KeepaliveRecord{}
+
+	path := EntitiesPath(client.config.Namespace(), name, "keepalive")
+	res, err := client.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	err = json.Unmarshal(res.Body(), keepalive)
+	return keepalive, err
+}
+
+// FetchEntityEvents fetches the events of an entity
+func (client *RestClient) FetchEntityEvents(name string) ([]corev2.Event, error) {
+	var events []corev2.Event
+	err := client.List(EventsPath(client.config.Namespace(), name), &events, &ListOptions{}, nil)
+	return events, err
+}
+
 // UpdateEntity updates given entity on configured Sensu instance
 func (client *RestClient) UpdateEntity(entity *corev2.Entity) (err error) {
 	bytes, err := json.Marshal(entity)
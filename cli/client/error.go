@@ -4,19 +4,35 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/go-resty/resty/v2"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/backend/apid/actions"
 )
 
 // APIError describes an error message returned by the REST API
 type APIError struct {
-	Message string `json:"message"`
-	Code    uint32 `json:"code,omitempty"`
+	Message string              `json:"message"`
+	Code    uint32              `json:"code,omitempty"`
+	Fields  []corev2.FieldError `json:"fields,omitempty"`
 }
 
+// Error returns the message of the error, followed by the invalid fields of
+// the resource, if any, one per line.
 func (a APIError) Error() string {
-	return a.Message
+	if len(a.Fields) == 0 {
+		return a.Message
+	}
+	var b strings.Builder
+	b.WriteString(a.Message)
+	for _, field := range a.Fields {
+		fmt.Fprintf(&b, "\n  %s: %s", field.Field, field.Message)
+		if len(field.Allowed) > 0 {
+			fmt.Fprintf(&b, " (allowed values: %s)", strings.Join(field.Allowed, ", "))
+		}
+	}
+	return b.String()
 }
 
 // UnmarshalError decode the API error
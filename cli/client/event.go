@@ -2,6 +2,8 @@ package client
 
 import (
 	"encoding/json"
+	"io"
+	"strconv"
 	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
@@ -10,6 +12,9 @@ import (
 // EventsPath is the api path for events.
 var EventsPath = createNSBasePath(coreAPIGroup, coreAPIVersion, "events")
 
+// eventReportPath is the api path for the event reports.
+var eventReportPath = createNSBasePath(coreAPIGroup, coreAPIVersion, "reports", "events")
+
 // FetchEvent fetches a specific event
 func (client *RestClient) FetchEvent(entity, check string) (*corev2.Event, error) {
 	var event *corev2.Event
@@ -28,6 +33,25 @@ func (client *RestClient) FetchEvent(entity, check string) (*corev2.Event, error
 	return event, err
 }
 
+// FetchEventHandlerResults fetches the latest result of every handler executed
+// for a specific event
+func (client *RestClient) FetchEventHandlerResults(entity, check string) ([]corev2.HandlerResult, error) {
+	var results []corev2.HandlerResult
+
+	path := EventsPath(client.config.Namespace(), entity, check, "handled")
+	res, err := client.R().Get(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	err = json.Unmarshal(res.Body(), &results)
+	return results, err
+}
+
 // DeleteEvent deletes an event.
 func (client *RestClient) DeleteEvent(namespace, entity, check string) error {
 	return client.Delete(EventsPath(namespace, entity, check))
@@ -53,6 +77,34 @@ func (client *RestClient) UpdateEvent(event *corev2.Event) error {
 	return nil
 }
 
+// DryRunEvent reports how an event would go through the pipeline, without
+// executing any handler.
+func (client *RestClient) DryRunEvent(event *corev2.Event) (*corev2.PipelineDryRun, error) {
+	var result *corev2.PipelineDryRun
+
+	bytes, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := client.config.Namespace()
+	if event.Entity != nil && event.Entity.Namespace != "" {
+		namespace = event.Entity.Namespace
+	}
+	path := EventsPath(namespace, "pipeline", "dry-run")
+	res, err := client.R().SetBody(bytes).Post(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	err = json.Unmarshal(res.Body(), &result)
+	return result, err
+}
+
 // ResolveEvent resolves an event.
 func (client *RestClient) ResolveEvent(event *corev2.Event) error {
 	event.Check.Status = 0
@@ -60,3 +112,113 @@ func (client *RestClient) ResolveEvent(event *corev2.Event) error {
 	event.Timestamp = int64(time.Now().Unix())
 	return client.UpdateEvent(event)
 }
+
+// DeleteEvents deletes the events of the namespace selected by the field
+// selector.
+func (client *RestClient) DeleteEvents(namespace, selector string) (*corev2.EventSelectionResponse, error) {
+	res, err := client.R().SetQueryParam("fieldSelector", selector).Delete(EventsPath(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	response := &corev2.EventSelectionResponse{}
+	err = json.Unmarshal(res.Body(), response)
+	return response, err
+}
+
+// ResolveEvents resolves the events of the namespace selected by the field
+// selector.
+func (client *RestClient) ResolveEvents(namespace, selector string) (*corev2.EventSelectionResponse, error) {
+	bytes, err := json.Marshal(&corev2.EventSelection{FieldSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.R().SetBody(bytes).Post(EventsPath(namespace, "resolve"))
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	response := &corev2.EventSelectionResponse{}
+	err = json.Unmarshal(res.Body(), response)
+	return response, err
+}
+
+// FetchEventReport fetches the counts of the check results of the events of
+// the namespace executed during the period, grouped by check and by entity.
+func (client *RestClient) FetchEventReport(namespace string, since time.Duration) (*corev2.EventReport, error) {
+	res, err := client.R().SetQueryParam("since", since.String()).Get(eventReportPath(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	report := &corev2.EventReport{}
+	err = json.Unmarshal(res.Body(), report)
+	return report, err
+}
+
+// EventWatchOptions selects the events streamed by WatchEvents. Empty fields
+// select all the events.
+type EventWatchOptions struct {
+	Entity string
+	Check  string
+	Status *uint32
+}
+
+// WatchEvents streams the events of the namespace stored by the backends,
+// selected by the options, to fn. It returns once the backend ends the watch,
+// after a few seconds, or when fn returns an error.
+func (client *RestClient) WatchEvents(namespace string, options *EventWatchOptions, fn func(*corev2.Event) error) error {
+	request := client.R().
+		SetDoNotParseResponse(true).
+		SetQueryParam("watch", "true").
+		// Compressed responses are buffered by the API
+		SetHeader("Accept-Encoding", "identity")
+	if options != nil {
+		if options.Entity != "" {
+			request.SetQueryParam("entity", options.Entity)
+		}
+		if options.Check != "" {
+			request.SetQueryParam("check", options.Check)
+		}
+		if options.Status != nil {
+			request.SetQueryParam("status", strconv.FormatUint(uint64(*options.Status), 10))
+		}
+	}
+
+	res, err := request.Get(EventsPath(namespace))
+	if err != nil {
+		return err
+	}
+	body := res.RawBody()
+	defer body.Close()
+
+	if res.StatusCode() >= 400 {
+		return UnmarshalError(res)
+	}
+
+	decoder := json.NewDecoder(body)
+	for {
+		var event corev2.Event
+		if err := decoder.Decode(&event); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := fn(&event); err != nil {
+			return err
+		}
+	}
+}
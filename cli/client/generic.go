@@ -11,6 +11,8 @@ import (
 	"github.com/sensu/sensu-go/types"
 )
 
+var resourcesPath = CreateBasePath(coreAPIGroup, coreAPIVersion, "resources")
+
 // Delete sends a DELETE request to the given path
 func (client *RestClient) Delete(path string) error {
 	res, err := client.R().Delete(path)
@@ -189,3 +191,29 @@ func (client *RestClient) PutResource(r types.Wrapper) error {
 	}
 	return nil
 }
+
+// PutResources creates or updates the resources in a single transaction, so
+// that either all of them or none are modified.
+func (client *RestClient) PutResources(resources []*types.Wrapper) error {
+	res, err := client.R().SetBody(resources).Put(resourcesPath())
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() >= 400 {
+		return UnmarshalError(res)
+	}
+	return nil
+}
+
+// DeleteResources deletes the resources in a single transaction, so that
+// either all of them or none are deleted.
+func (client *RestClient) DeleteResources(resources []*types.Wrapper) error {
+	res, err := client.R().SetBody(resources).Delete(resourcesPath())
+	if err != nil {
+		return err
+	}
+	if res.StatusCode() >= 400 {
+		return UnmarshalError(res)
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package client
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/coreos/etcd/clientv3"
 	"github.com/go-resty/resty/v2"
@@ -22,6 +23,10 @@ type ListOptions struct {
 	// advantage of the API's pagination capabilities. ChunkSize <= 0 means
 	// fetch everything all at once; do not use pagination.
 	ChunkSize int
+
+	// Sort is the order of the resources returned by the API, supported by
+	// some resource types only.
+	Sort string
 }
 
 // APIClient client methods across the Sensu API
@@ -45,6 +50,7 @@ type APIClient interface {
 	RoleBindingAPIClient
 	UserAPIClient
 	SilencedAPIClient
+	SubscriptionAPIClient
 	GenericClient
 	ClusterMemberClient
 	LicenseClient
@@ -73,6 +79,12 @@ type GenericClient interface {
 
 	// PutResource puts a resource according to its URIPath.
 	PutResource(types.Wrapper) error
+
+	// PutResources creates or updates resources in a single transaction.
+	PutResources([]*types.Wrapper) error
+
+	// DeleteResources deletes resources in a single transaction.
+	DeleteResources([]*types.Wrapper) error
 }
 
 // AuthenticationAPIClient client methods for authenticating
@@ -96,6 +108,11 @@ type CheckAPIClient interface {
 	DeleteCheck(string, string) error
 	ExecuteCheck(*corev2.AdhocRequest) error
 	FetchCheck(string) (*corev2.CheckConfig, error)
+	ModifyCheck(string, func(*corev2.CheckConfig) error) error
+	SetChecksPublish(*corev2.CheckPublishRequest) (*corev2.CheckPublishResponse, error)
+	CheckSchedulePreview(string, time.Duration) (*corev2.CheckSchedulePreview, error)
+	PauseCheck(string, string) (*corev2.CheckPause, error)
+	ResumeCheck(string) error
 	UpdateCheck(*corev2.CheckConfig) error
 
 	AddCheckHook(check *corev2.CheckConfig, checkHook *corev2.HookList) error
@@ -121,6 +138,8 @@ type EntityAPIClient interface {
 	CreateEntity(entity *corev2.Entity) error
 	DeleteEntity(string, string) error
 	FetchEntity(ID string) (*corev2.Entity, error)
+	FetchEntityKeepalive(ID string) (*corev2.KeepaliveRecord, error)
+	FetchEntityEvents(ID string) ([]corev2.Event, error)
 	UpdateEntity(entity *corev2.Entity) error
 }
 
@@ -135,11 +154,26 @@ type FilterAPIClient interface {
 // EventAPIClient client methods for events
 type EventAPIClient interface {
 	FetchEvent(string, string) (*corev2.Event, error)
+	FetchEventHandlerResults(entity, check string) ([]corev2.HandlerResult, error)
 
 	// DeleteEvent deletes the event identified by entity, check.
 	DeleteEvent(namespace, entity, check string) error
 	UpdateEvent(*corev2.Event) error
 	ResolveEvent(*corev2.Event) error
+	DryRunEvent(*corev2.Event) (*corev2.PipelineDryRun, error)
+
+	// DeleteEvents deletes the events of the namespace selected by the field
+	// selector.
+	DeleteEvents(namespace, selector string) (*corev2.EventSelectionResponse, error)
+	// ResolveEvents resolves the events of the namespace selected by the field
+	// selector.
+	ResolveEvents(namespace, selector string) (*corev2.EventSelectionResponse, error)
+	// FetchEventReport fetches the counts of the check results of the events
+	// of the namespace executed during the period.
+	FetchEventReport(namespace string, since time.Duration) (*corev2.EventReport, error)
+	// WatchEvents streams the events of the namespace processed by the
+	// backend, selected by the options, to fn.
+	WatchEvents(namespace string, options *EventWatchOptions, fn func(*corev2.Event) error) error
 }
 
 // ExtensionAPIClient client methods for extensions
@@ -183,6 +217,7 @@ type NamespaceAPIClient interface {
 	UpdateNamespace(*corev2.Namespace) error
 	DeleteNamespace(string) error
 	FetchNamespace(string) (*corev2.Namespace, error)
+	UpdateNamespaceMute(string, *corev2.NamespaceMute) (*corev2.NamespaceMute, error)
 }
 
 // UserAPIClient client methods for users
@@ -194,6 +229,7 @@ type UserAPIClient interface {
 	ReinstateUser(string) error
 	RemoveGroupFromUser(string, string) error
 	RemoveAllGroupsFromUser(string) error
+	ResetPasswordToken(string) (*corev2.PasswordResetToken, error)
 	SetGroupsForUser(string, []string) error
 	UpdatePassword(string, string) error
 }
@@ -212,6 +248,13 @@ type RoleBindingAPIClient interface {
 	FetchRoleBinding(string) (*corev2.RoleBinding, error)
 }
 
+// SubscriptionAPIClient client methods for subscriptions
+type SubscriptionAPIClient interface {
+	// ListSubscriptions lists the subscriptions of the namespace with their
+	// connected agent entities.
+	ListSubscriptions(namespace string) ([]corev2.SubscriptionMembers, error)
+}
+
 // SilencedAPIClient client methods for silenced
 type SilencedAPIClient interface {
 	// CreateSilenced creates a new silenced entry from its input.
@@ -221,8 +264,8 @@ type SilencedAPIClient interface {
 	DeleteSilenced(namespace string, name string) error
 
 	// ListSilenceds lists all silenced entries, optionally constraining by
-	// subscription or check.
-	ListSilenceds(namespace, subscription, check string, options *ListOptions, header *http.Header) ([]types.Silenced, error)
+	// subscription or check, and by the filter.
+	ListSilenceds(namespace, subscription, check string, filter *types.SilencedFilter, options *ListOptions, header *http.Header) ([]types.Silenced, error)
 
 	// FetchSilenced fetches the silenced entry by ID.
 	FetchSilenced(id string) (*corev2.Silenced, error)
@@ -247,6 +290,18 @@ type ClusterMemberClient interface {
 
 	// FetchClusterID gets the sensu cluster id.
 	FetchClusterID() (string, error)
+
+	// FetchAPIUsage gets the API usage per user and per route.
+	FetchAPIUsage() ([]corev2.APIUsage, error)
+
+	// FetchEventStats gets the event rates per namespace and per check.
+	FetchEventStats() (*corev2.EventStats, error)
+
+	// FetchClusterConfig gets the cluster configuration.
+	FetchClusterConfig() (*corev2.ClusterConfig, error)
+
+	// UpdateClusterConfig updates the cluster configuration.
+	UpdateClusterConfig(*corev2.ClusterConfig) error
 }
 
 // LicenseClient specifies the enteprise client methods for license management.
@@ -72,3 +72,26 @@ func (client *RestClient) FetchNamespace(namespaceName string) (*corev2.Namespac
 	err = json.Unmarshal(res.Body(), &namespace)
 	return namespace, err
 }
+
+// UpdateNamespaceMute mutes or unmutes a namespace, and returns its muting
+// switch
+func (client *RestClient) UpdateNamespaceMute(namespace string, mute *corev2.NamespaceMute) (*corev2.NamespaceMute, error) {
+	bytes, err := json.Marshal(mute)
+	if err != nil {
+		return nil, err
+	}
+
+	path := NamespacesPath(namespace, "mute")
+	res, err := client.R().SetBody(bytes).Put(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	result := &corev2.NamespaceMute{}
+	err = json.Unmarshal(res.Body(), result)
+	return result, err
+}
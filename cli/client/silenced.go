@@ -35,7 +35,10 @@ func (client *RestClient) DeleteSilenced(namespace, name string) error {
 }
 
 // ListSilenceds fetches all silenced entries from configured Sensu instance
-func (client *RestClient) ListSilenceds(namespace, sub, check string, options *ListOptions, header *http.Header) ([]corev2.Silenced, error) {
+func (client *RestClient) ListSilenceds(namespace, sub, check string, filter *corev2.SilencedFilter, options *ListOptions, header *http.Header) ([]corev2.Silenced, error) {
+	if filter == nil {
+		filter = &corev2.SilencedFilter{}
+	}
 	if sub != "" && check != "" {
 		name, err := corev2.SilencedName(sub, check)
 		if err != nil {
@@ -45,12 +48,16 @@ func (client *RestClient) ListSilenceds(namespace, sub, check string, options *L
 		if err != nil {
 			return nil, err
 		}
+		if !filter.Matches(silenced) {
+			return []corev2.Silenced{}, nil
+		}
 		return []corev2.Silenced{*silenced}, nil
 	}
 	path := silencedPath(namespace)
 	request := client.R()
 
 	ApplyListOptions(request, options)
+	request.SetQueryParamsFromValues(filter.Query())
 
 	if sub != "" {
 		path = silencedPath(namespace, "subscriptions", sub)
@@ -0,0 +1,27 @@
+package client
+
+import (
+	"encoding/json"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// SubscriptionsPath is the api path for subscriptions.
+var SubscriptionsPath = createNSBasePath(coreAPIGroup, coreAPIVersion, "subscriptions")
+
+// ListSubscriptions lists the subscriptions of the namespace with their
+// connected agent entities.
+func (client *RestClient) ListSubscriptions(namespace string) ([]corev2.SubscriptionMembers, error) {
+	res, err := client.R().Get(SubscriptionsPath(namespace))
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	var subscriptions []corev2.SubscriptionMembers
+	err = json.Unmarshal(res.Body(), &subscriptions)
+	return subscriptions, err
+}
@@ -1,6 +1,8 @@
 package testing
 
 import (
+	"time"
+
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 )
 
@@ -28,12 +30,49 @@ func (c *MockClient) ExecuteCheck(req *corev2.AdhocRequest) error {
 	return args.Error(0)
 }
 
+// SetChecksPublish for use with mock lib
+func (c *MockClient) SetChecksPublish(req *corev2.CheckPublishRequest) (*corev2.CheckPublishResponse, error) {
+	args := c.Called(req)
+	return args.Get(0).(*corev2.CheckPublishResponse), args.Error(1)
+}
+
+// CheckSchedulePreview for use with mock lib
+func (c *MockClient) CheckSchedulePreview(name string, window time.Duration) (*corev2.CheckSchedulePreview, error) {
+	args := c.Called(name, window)
+	return args.Get(0).(*corev2.CheckSchedulePreview), args.Error(1)
+}
+
+// PauseCheck for use with mock lib
+func (c *MockClient) PauseCheck(name, reason string) (*corev2.CheckPause, error) {
+	args := c.Called(name, reason)
+	return args.Get(0).(*corev2.CheckPause), args.Error(1)
+}
+
+// ResumeCheck for use with mock lib
+func (c *MockClient) ResumeCheck(name string) error {
+	args := c.Called(name)
+	return args.Error(0)
+}
+
 // FetchCheck for use with mock lib
 func (c *MockClient) FetchCheck(name string) (*corev2.CheckConfig, error) {
 	args := c.Called(name)
 	return args.Get(0).(*corev2.CheckConfig), args.Error(1)
 }
 
+// ModifyCheck for use with mock lib, which fetches and updates the check
+// with the FetchCheck and UpdateCheck mocks
+func (c *MockClient) ModifyCheck(name string, modify func(*corev2.CheckConfig) error) error {
+	check, err := c.FetchCheck(name)
+	if err != nil {
+		return err
+	}
+	if err := modify(check); err != nil {
+		return err
+	}
+	return c.UpdateCheck(check)
+}
+
 // AddCheckHook for use with mock lib
 func (c *MockClient) AddCheckHook(check *corev2.CheckConfig, checkHook *corev2.HookList) error {
 	args := c.Called(check, checkHook)
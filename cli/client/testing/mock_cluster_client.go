@@ -1,6 +1,9 @@
 package testing
 
-import "github.com/coreos/etcd/clientv3"
+import (
+	"github.com/coreos/etcd/clientv3"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
 
 // MemberList ...
 func (c *MockClient) MemberList() (*clientv3.MemberListResponse, error) {
@@ -31,3 +34,27 @@ func (c *MockClient) FetchClusterID() (string, error) {
 	args := c.Called()
 	return args.Get(0).(string), args.Error(1)
 }
+
+// FetchAPIUsage ...
+func (c *MockClient) FetchAPIUsage() ([]corev2.APIUsage, error) {
+	args := c.Called()
+	return args.Get(0).([]corev2.APIUsage), args.Error(1)
+}
+
+// FetchEventStats ...
+func (c *MockClient) FetchEventStats() (*corev2.EventStats, error) {
+	args := c.Called()
+	return args.Get(0).(*corev2.EventStats), args.Error(1)
+}
+
+// FetchClusterConfig ...
+func (c *MockClient) FetchClusterConfig() (*corev2.ClusterConfig, error) {
+	args := c.Called()
+	return args.Get(0).(*corev2.ClusterConfig), args.Error(1)
+}
+
+// UpdateClusterConfig ...
+func (c *MockClient) UpdateClusterConfig(config *corev2.ClusterConfig) error {
+	args := c.Called(config)
+	return args.Error(0)
+}
@@ -37,6 +37,18 @@ func (m *MockConfig) Namespace() string {
 	return args.String(0)
 }
 
+// SilencedReasonTemplate mocks the silenced reason template config
+func (m *MockConfig) SilencedReasonTemplate() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+// TimeFormat mocks the time format config
+func (m *MockConfig) TimeFormat() string {
+	args := m.Called()
+	return args.String(0)
+}
+
 // TrustedCAFile mocks the trusted CA file config
 func (m *MockConfig) TrustedCAFile() string {
 	args := m.Called()
@@ -67,6 +79,18 @@ func (m *MockConfig) SaveNamespace(namespace string) error {
 	return args.Error(0)
 }
 
+// SaveSilencedReasonTemplate mocks saving the silenced reason template
+func (m *MockConfig) SaveSilencedReasonTemplate(template string) error {
+	args := m.Called(template)
+	return args.Error(0)
+}
+
+// SaveTimeFormat mocks saving the time format
+func (m *MockConfig) SaveTimeFormat(format string) error {
+	args := m.Called(format)
+	return args.Error(0)
+}
+
 // SaveTokens mocks saving the tokens
 func (m *MockConfig) SaveTokens(tokens *types.Tokens) error {
 	args := m.Called(tokens)
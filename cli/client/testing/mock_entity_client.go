@@ -10,6 +10,18 @@ func (c *MockClient) FetchEntity(ID string) (*corev2.Entity, error) {
 	return args.Get(0).(*corev2.Entity), args.Error(1)
 }
 
+// FetchEntityKeepalive for use with mock lib
+func (c *MockClient) FetchEntityKeepalive(ID string) (*corev2.KeepaliveRecord, error) {
+	args := c.Called(ID)
+	return args.Get(0).(*corev2.KeepaliveRecord), args.Error(1)
+}
+
+// FetchEntityEvents for use with mock lib
+func (c *MockClient) FetchEntityEvents(ID string) ([]corev2.Event, error) {
+	args := c.Called(ID)
+	return args.Get(0).([]corev2.Event), args.Error(1)
+}
+
 // DeleteEntity for use with mock lib
 func (c *MockClient) DeleteEntity(namespace, name string) error {
 	args := c.Called(namespace, name)
@@ -1,7 +1,10 @@
 package testing
 
 import (
+	"time"
+
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli/client"
 )
 
 // FetchEvent for use with mock lib
@@ -10,6 +13,12 @@ func (c *MockClient) FetchEvent(entity, check string) (*corev2.Event, error) {
 	return args.Get(0).(*corev2.Event), args.Error(1)
 }
 
+// FetchEventHandlerResults for use with mock lib
+func (c *MockClient) FetchEventHandlerResults(entity, check string) ([]corev2.HandlerResult, error) {
+	args := c.Called(entity, check)
+	return args.Get(0).([]corev2.HandlerResult), args.Error(1)
+}
+
 // DeleteEvent for use with mock lib
 func (c *MockClient) DeleteEvent(namespace, entity, check string) error {
 	args := c.Called(namespace, entity, check)
@@ -27,3 +36,33 @@ func (c *MockClient) ResolveEvent(event *corev2.Event) error {
 	args := c.Called(event)
 	return args.Error(0)
 }
+
+// DryRunEvent for use with mock lib
+func (c *MockClient) DryRunEvent(event *corev2.Event) (*corev2.PipelineDryRun, error) {
+	args := c.Called(event)
+	return args.Get(0).(*corev2.PipelineDryRun), args.Error(1)
+}
+
+// DeleteEvents for use with mock lib
+func (c *MockClient) DeleteEvents(namespace, selector string) (*corev2.EventSelectionResponse, error) {
+	args := c.Called(namespace, selector)
+	return args.Get(0).(*corev2.EventSelectionResponse), args.Error(1)
+}
+
+// ResolveEvents for use with mock lib
+func (c *MockClient) ResolveEvents(namespace, selector string) (*corev2.EventSelectionResponse, error) {
+	args := c.Called(namespace, selector)
+	return args.Get(0).(*corev2.EventSelectionResponse), args.Error(1)
+}
+
+// FetchEventReport for use with mock lib
+func (c *MockClient) FetchEventReport(namespace string, since time.Duration) (*corev2.EventReport, error) {
+	args := c.Called(namespace, since)
+	return args.Get(0).(*corev2.EventReport), args.Error(1)
+}
+
+// WatchEvents for use with mock lib
+func (c *MockClient) WatchEvents(namespace string, options *client.EventWatchOptions, fn func(*corev2.Event) error) error {
+	args := c.Called(namespace, options, fn)
+	return args.Error(0)
+}
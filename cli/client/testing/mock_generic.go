@@ -49,3 +49,15 @@ func (c *MockClient) PutResource(r types.Wrapper) error {
 	args := c.Called(r)
 	return args.Error(0)
 }
+
+// PutResources ...
+func (c *MockClient) PutResources(r []*types.Wrapper) error {
+	args := c.Called(r)
+	return args.Error(0)
+}
+
+// DeleteResources ...
+func (c *MockClient) DeleteResources(r []*types.Wrapper) error {
+	args := c.Called(r)
+	return args.Error(0)
+}
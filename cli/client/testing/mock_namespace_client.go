@@ -27,3 +27,9 @@ func (c *MockClient) FetchNamespace(namespace string) (*corev2.Namespace, error)
 	args := c.Called(namespace)
 	return args.Get(0).(*corev2.Namespace), args.Error(1)
 }
+
+// UpdateNamespaceMute for use with mock lib
+func (c *MockClient) UpdateNamespaceMute(namespace string, mute *corev2.NamespaceMute) (*corev2.NamespaceMute, error) {
+	args := c.Called(namespace, mute)
+	return args.Get(0).(*corev2.NamespaceMute), args.Error(1)
+}
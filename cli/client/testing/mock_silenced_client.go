@@ -34,7 +34,7 @@ func (c *MockClient) FetchSilenced(id string) (*types.Silenced, error) {
 }
 
 // ListSilenceds for use with mock lib
-func (c *MockClient) ListSilenceds(namespace, sub, check string, options *client.ListOptions, header *http.Header) ([]corev2.Silenced, error) {
-	args := c.Called(namespace, sub, check, options, header)
+func (c *MockClient) ListSilenceds(namespace, sub, check string, filter *corev2.SilencedFilter, options *client.ListOptions, header *http.Header) ([]corev2.Silenced, error) {
+	args := c.Called(namespace, sub, check, filter, options, header)
 	return args.Get(0).([]corev2.Silenced), args.Error(1)
 }
@@ -0,0 +1,11 @@
+package testing
+
+import (
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// ListSubscriptions for use with mock lib
+func (c *MockClient) ListSubscriptions(namespace string) ([]corev2.SubscriptionMembers, error) {
+	args := c.Called(namespace)
+	return args.Get(0).([]corev2.SubscriptionMembers), args.Error(1)
+}
@@ -52,6 +52,12 @@ func (c *MockClient) SetGroupsForUser(username string, groups []string) error {
 	return args.Error(0)
 }
 
+// ResetPasswordToken for use with mock lib
+func (c *MockClient) ResetPasswordToken(username string) (*corev2.PasswordResetToken, error) {
+	args := c.Called(username)
+	return args.Get(0).(*corev2.PasswordResetToken), args.Error(1)
+}
+
 // UpdatePassword for use with mock lib
 func (c *MockClient) UpdatePassword(username, pwd string) error {
 	args := c.Called(username, pwd)
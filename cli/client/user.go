@@ -161,3 +161,21 @@ func (client *RestClient) UpdatePassword(username, pwd string) error {
 
 	return nil
 }
+
+// ResetPasswordToken issues a one-time token with which the given user can
+// reset its password
+func (client *RestClient) ResetPasswordToken(username string) (*corev2.PasswordResetToken, error) {
+	path := UsersPath(username, "reset_password")
+	res, err := client.R().Post(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode() >= 400 {
+		return nil, UnmarshalError(res)
+	}
+
+	token := &corev2.PasswordResetToken{}
+	err = json.Unmarshal(res.Body(), token)
+	return token, err
+}
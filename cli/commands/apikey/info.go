@@ -4,11 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/commands/timeutil"
 	"github.com/sensu/sensu-go/cli/elements/list"
 	"github.com/spf13/cobra"
 )
@@ -64,7 +64,7 @@ func printToList(v interface{}, writer io.Writer) error {
 			},
 			{
 				Label: "Created At",
-				Value: time.Unix(r.CreatedAt, 0).String(),
+				Value: timeutil.HumanTimestamp(r.CreatedAt),
 			},
 		},
 	}
@@ -37,7 +37,11 @@ func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 					Namespace: cli.Config.Namespace(),
 				},
 			}
-			if err := cli.Client.Delete(asset.URIPath()); err != nil {
+			path := asset.URIPath()
+			if force, _ := cmd.Flags().GetBool("force"); force {
+				path += "?force=true"
+			}
+			if err := cli.Client.Delete(path); err != nil {
 				return err
 			}
 
@@ -47,6 +51,7 @@ func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 	}
 
 	cmd.Flags().Bool("skip-confirm", false, "skip interactive confirmation prompt")
+	cmd.Flags().Bool("force", false, "delete the asset even if other resources reference it")
 
 	return cmd
 }
@@ -13,8 +13,9 @@ import (
 	"github.com/stretchr/testify/mock"
 )
 
-// Match a tabular output header
-var tabularHeaderPattern = ".*\n( |─)+\n"
+// Match a tabular output header, which is a line of tab-separated values when
+// the output is not a terminal
+var tabularHeaderPattern = "^[^\n]*\n"
 
 func TestOutdatedCommand(t *testing.T) {
 	assert := assert.New(t)
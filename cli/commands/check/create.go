@@ -49,8 +49,8 @@ func CreateCommand(cli *cli.SensuCli) *cobra.Command {
 				if opts.Interval != "" && opts.Cron != "" {
 					return fmt.Errorf("cannot specify --interval and --cron at the same time")
 				}
-				if opts.Interval == "" && opts.Cron == "" {
-					return fmt.Errorf("must specify --interval or --cron")
+				if opts.Interval == "" && opts.Cron == "" && opts.Template == "" {
+					return fmt.Errorf("must specify --interval, --cron or --template")
 				}
 			}
 
@@ -97,6 +97,7 @@ func CreateCommand(cli *cli.SensuCli) *cobra.Command {
 	cmd.Flags().String("output-metric-handlers", "", "comma separated list of handlers to set on output check metrics")
 	cmd.Flags().String("output-metric-format", "", "the output metric format to be used to parse check output for metric extraction")
 	cmd.Flags().Bool("round-robin", false, "enable round-robin scheduling")
+	cmd.Flags().String("template", "", "the check template the check inherits the attributes it leaves unset from")
 
 	helpers.AddInteractiveFlag(cmd.Flags())
 	return cmd
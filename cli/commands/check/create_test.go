@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	client "github.com/sensu/sensu-go/cli/client/testing"
 	test "github.com/sensu/sensu-go/cli/commands/testing"
 	"github.com/stretchr/testify/assert"
@@ -52,6 +53,21 @@ func TestCreateCommandRunEClosureWithAllFlags(t *testing.T) {
 	assert.Regexp("Created", out)
 }
 
+func TestCreateCommandRunEClosureWithTemplate(t *testing.T) {
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("CreateCheck", mock.MatchedBy(func(check *corev2.CheckConfig) bool {
+		return check.Template == "linux" && check.Interval == 0
+	})).Return(nil)
+
+	cmd := CreateCommand(cli)
+	require.NoError(t, cmd.Flags().Set("command", "check-cpu.sh"))
+	require.NoError(t, cmd.Flags().Set("template", "linux"))
+	out, err := test.RunCmd(cmd, []string{"check-cpu"})
+	require.NoError(t, err)
+	assert.Regexp(t, "Created", out)
+}
+
 func TestCreateCommandRunEClosureWithDeps(t *testing.T) {
 	assert := assert.New(t)
 
@@ -22,6 +22,9 @@ func HelpCommand(cli *cli.SensuCli) *cobra.Command {
 		ListCommand(cli),
 		InfoCommand(cli),
 		UpdateCommand(cli),
+		SchedulePreviewCommand(cli),
+		PauseCommand(cli),
+		ResumeCommand(cli),
 
 		// Remove commands (clear out fields)
 		subcommands.RemoveCheckHookCommand(cli),
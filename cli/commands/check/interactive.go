@@ -36,6 +36,7 @@ type checkOpts struct {
 	OutputMetricFormat   string `survey:"output-metric-format"`
 	OutputMetricHandlers string `survey:"output-metric-handlers"`
 	RoundRobin           string `survey:"round-robin"`
+	Template             string `survey:"template"`
 }
 
 func newCheckOpts() *checkOpts {
@@ -64,6 +65,7 @@ func (opts *checkOpts) withCheck(check *types.CheckConfig) {
 	opts.OutputMetricHandlers = strings.Join(check.OutputMetricHandlers, ",")
 	opts.RoundRobin = strconv.FormatBool(check.RoundRobin)
 	opts.Publish = strconv.FormatBool(check.Publish)
+	opts.Template = check.Template
 }
 
 func (opts *checkOpts) withFlags(flags *pflag.FlagSet) {
@@ -85,6 +87,7 @@ func (opts *checkOpts) withFlags(flags *pflag.FlagSet) {
 	opts.OutputMetricHandlers, _ = flags.GetString("output-metric-handlers")
 	roundRobinBool, _ := flags.GetBool("round-robin")
 	opts.RoundRobin = strconv.FormatBool(roundRobinBool)
+	opts.Template, _ = flags.GetString("template")
 
 	if namespace := helpers.GetChangedStringValueFlag("namespace", flags); namespace != "" {
 		opts.Namespace = namespace
@@ -147,6 +150,14 @@ func (opts *checkOpts) administerQuestionnaire(editing bool) error {
 				return nil
 			},
 		},
+		{
+			Name: "template",
+			Prompt: &survey.Input{
+				Message: "Template:",
+				Help:    "Optional check template the check inherits the attributes it leaves unset from.",
+				Default: opts.Template,
+			},
+		},
 		{
 			Name: "timeout",
 			Prompt: &survey.Input{
@@ -295,4 +306,5 @@ func (opts *checkOpts) Copy(check *types.CheckConfig) {
 	}
 	check.OutputMetricHandlers = helpers.SafeSplitCSV(opts.OutputMetricHandlers)
 	check.RoundRobin, _ = strconv.ParseBool(opts.RoundRobin)
+	check.Template = opts.Template
 }
@@ -0,0 +1,62 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/sensu/sensu-go/cli"
+	"github.com/spf13/cobra"
+)
+
+// PauseCommand adds a command that allows user to pause the scheduling of
+// checks, without modifying their definition
+func PauseCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "pause [NAME]",
+		Short:        "pause the scheduling of a check, without modifying its definition",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// If no name is present print out usage
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			reason, _ := cmd.Flags().GetString("reason")
+			if _, err := cli.Client.PauseCheck(args[0], reason); err != nil {
+				return err
+			}
+
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), "Paused")
+			return err
+		},
+	}
+
+	_ = cmd.Flags().String("reason", "", "reason for pausing the check")
+
+	return cmd
+}
+
+// ResumeCommand adds a command that allows user to resume the scheduling of
+// paused checks
+func ResumeCommand(cli *cli.SensuCli) *cobra.Command {
+	return &cobra.Command{
+		Use:          "resume [NAME]",
+		Short:        "resume the scheduling of a paused check",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// If no name is present print out usage
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			if err := cli.Client.ResumeCheck(args[0]); err != nil {
+				return err
+			}
+
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), "Resumed")
+			return err
+		},
+	}
+}
@@ -0,0 +1,58 @@
+package check
+
+import (
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseCommandRunEClosureWithoutName(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := PauseCommand(cli)
+	out, err := test.RunCmd(cmd, []string{})
+
+	assert.Regexp(t, "Usage", out)
+	assert.Error(t, err)
+}
+
+func TestPauseCommandRunEClosureWithReason(t *testing.T) {
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("PauseCheck", "check1", "maintenance").Return(&corev2.CheckPause{Paused: true, Reason: "maintenance"}, nil)
+
+	cmd := PauseCommand(cli)
+	require.NoError(t, cmd.Flags().Set("reason", "maintenance"))
+	out, err := test.RunCmd(cmd, []string{"check1"})
+
+	assert.Regexp(t, "Paused", out)
+	assert.NoError(t, err)
+}
+
+func TestPauseCommandRunEClosureWithServerErr(t *testing.T) {
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("PauseCheck", "check1", "").Return((*corev2.CheckPause)(nil), errors.New("oh noes"))
+
+	cmd := PauseCommand(cli)
+	out, err := test.RunCmd(cmd, []string{"check1"})
+
+	assert.Empty(t, out)
+	assert.Error(t, err)
+}
+
+func TestResumeCommandRunEClosure(t *testing.T) {
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("ResumeCheck", "check1").Return(nil)
+
+	cmd := ResumeCommand(cli)
+	out, err := test.RunCmd(cmd, []string{"check1"})
+
+	assert.Regexp(t, "Resumed", out)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,133 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client/config"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/commands/timeutil"
+	"github.com/sensu/sensu-go/cli/elements/list"
+	"github.com/sensu/sensu-go/cli/elements/table"
+	"github.com/spf13/cobra"
+)
+
+// SchedulePreviewCommand previews the executions of a check scheduled by the
+// backend
+func SchedulePreviewCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "schedule-preview [NAME]",
+		Short:        "preview the next executions of a check scheduled by the backend",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			window, _ := cmd.Flags().GetDuration("window")
+			preview, err := cli.Client.CheckSchedulePreview(args[0], window)
+			if err != nil {
+				return err
+			}
+
+			// The preview is not a resource, so it can't be wrapped
+			format := helpers.GetChangedStringValueFlag("format", cmd.Flags())
+			if format == "" {
+				format = cli.Config.Format()
+			}
+			if format == config.FormatWrappedJSON {
+				format = config.FormatJSON
+			}
+			return helpers.PrintFormatted("", format, preview, cmd.OutOrStdout(), printSchedulePreview)
+		},
+	}
+
+	cmd.Flags().Duration("window", time.Hour, "duration of the window, starting now, in which the executions are previewed")
+	helpers.AddFormatFlag(cmd.Flags())
+
+	return cmd
+}
+
+func printSchedulePreview(v interface{}, w io.Writer) error {
+	preview, ok := v.(*corev2.CheckSchedulePreview)
+	if !ok {
+		return fmt.Errorf("%t is not a CheckSchedulePreview", v)
+	}
+
+	rows := []*list.Row{
+		{
+			Label: "Scheduler",
+			Value: preview.Scheduler,
+		},
+		{
+			Label: "Subscriptions",
+			Value: strings.Join(preview.Subscriptions, ", "),
+		},
+		{
+			Label: "Publish?",
+			Value: strconv.FormatBool(preview.Publish),
+		},
+		{
+			Label: "Window",
+			Value: fmt.Sprintf("%s - %s", timeutil.HumanTimestamp(preview.Start), timeutil.HumanTimestamp(preview.End)),
+		},
+	}
+	if preview.ProxyEntities != nil {
+		rows = append(rows, &list.Row{
+			Label: "Proxy Entities",
+			Value: strings.Join(preview.ProxyEntities, ", "),
+		})
+	}
+	cfg := &list.Config{
+		Title: preview.Check,
+		Rows:  rows,
+	}
+	if err := list.Print(w, cfg); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+
+	if len(preview.Executions) == 0 {
+		fmt.Fprintln(w, "No executions scheduled in the window")
+		return nil
+	}
+
+	columns := []*table.Column{
+		{
+			Title: "Time",
+			CellTransformer: func(data interface{}) string {
+				execution, _ := data.(corev2.ScheduledExecution)
+				return timeutil.HumanTimestamp(execution.Time)
+			},
+		},
+		{
+			Title: "Subdued",
+			CellTransformer: func(data interface{}) string {
+				execution, _ := data.(corev2.ScheduledExecution)
+				return strconv.FormatBool(execution.Subdued)
+			},
+		},
+	}
+	if preview.ProxyEntities != nil {
+		columns = append(columns, &table.Column{
+			Title: "Proxy Entity",
+			CellTransformer: func(data interface{}) string {
+				execution, _ := data.(corev2.ScheduledExecution)
+				return execution.ProxyEntity
+			},
+		})
+	}
+	table.New(columns).Render(w, preview.Executions)
+
+	if preview.Truncated {
+		fmt.Fprintf(w, "\nOnly the first %d executions are shown, use a shorter window to see the others\n", len(preview.Executions))
+	}
+	return nil
+}
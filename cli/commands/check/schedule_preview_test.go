@@ -0,0 +1,90 @@
+package check
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fixtureSchedulePreview() *corev2.CheckSchedulePreview {
+	return &corev2.CheckSchedulePreview{
+		Check:         "check1",
+		Namespace:     "default",
+		Scheduler:     "interval",
+		Subscriptions: []string{"linux"},
+		Publish:       true,
+		Start:         1577836800,
+		End:           1577840400,
+		Executions: []corev2.ScheduledExecution{
+			{Time: 1577836830},
+			{Time: 1577838630, Subdued: true},
+		},
+	}
+}
+
+func TestSchedulePreviewCommand(t *testing.T) {
+	cli := test.NewCLI()
+	cmd := SchedulePreviewCommand(cli)
+
+	assert.NotNil(t, cmd.RunE)
+	assert.Regexp(t, "schedule-preview", cmd.Use)
+	assert.NotNil(t, cmd.Flags().Lookup("window"))
+}
+
+func TestSchedulePreviewCommandRunEClosure(t *testing.T) {
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("CheckSchedulePreview", "check1", 2*time.Hour).Return(fixtureSchedulePreview(), nil)
+
+	cmd := SchedulePreviewCommand(cli)
+	require.NoError(t, cmd.Flags().Set("window", "2h"))
+	require.NoError(t, cmd.Flags().Set("format", "tabular"))
+	out, err := test.RunCmd(cmd, []string{"check1"})
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "check1")
+	assert.Contains(t, out, "interval")
+	assert.Contains(t, out, "Subdued")
+	assert.NotContains(t, out, "Proxy Entity")
+}
+
+func TestSchedulePreviewCommandRunEClosureWithJSON(t *testing.T) {
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("CheckSchedulePreview", "check1", time.Hour).Return(fixtureSchedulePreview(), nil)
+
+	cmd := SchedulePreviewCommand(cli)
+	require.NoError(t, cmd.Flags().Set("format", "wrapped-json"))
+	out, err := test.RunCmd(cmd, []string{"check1"})
+	require.NoError(t, err)
+
+	var preview corev2.CheckSchedulePreview
+	require.NoError(t, json.Unmarshal([]byte(out), &preview))
+	assert.Len(t, preview.Executions, 2)
+}
+
+func TestSchedulePreviewCommandRunEClosureWithErr(t *testing.T) {
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	var nilPreview *corev2.CheckSchedulePreview
+	client.On("CheckSchedulePreview", "check1", time.Hour).Return(nilPreview, errors.New("check not found"))
+
+	cmd := SchedulePreviewCommand(cli)
+	_, err := test.RunCmd(cmd, []string{"check1"})
+	assert.EqualError(t, err, "check not found")
+}
+
+func TestSchedulePreviewCommandMissingArgs(t *testing.T) {
+	cli := test.NewCLI()
+	cmd := SchedulePreviewCommand(cli)
+	out, err := test.RunCmd(cmd, []string{})
+	require.Error(t, err)
+	assert.Contains(t, out, "Usage")
+}
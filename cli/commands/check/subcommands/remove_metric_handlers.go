@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli"
 	"github.com/spf13/cobra"
 )
@@ -22,18 +23,13 @@ func RemoveOutputMetricHandlersCommand(cli *cli.SensuCli) *cobra.Command {
 				return errors.New("invalid argument(s) received")
 			}
 
-			check, err := cli.Client.FetchCheck(args[0])
+			err := cli.Client.ModifyCheck(args[0], func(check *corev2.CheckConfig) error {
+				check.OutputMetricHandlers = nil
+				return check.Validate()
+			})
 			if err != nil {
 				return err
 			}
-			check.OutputMetricHandlers = nil
-
-			if err := check.Validate(); err != nil {
-				return err
-			}
-			if err := cli.Client.UpdateCheck(check); err != nil {
-				return err
-			}
 
 			fmt.Fprintln(cmd.OutOrStdout(), "Removed")
 			return nil
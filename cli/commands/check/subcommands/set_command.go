@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli"
 	"github.com/spf13/cobra"
 )
@@ -23,18 +24,13 @@ func SetCommandCommand(cli *cli.SensuCli) *cobra.Command {
 			checkName := args[0]
 			value := args[1]
 
-			check, err := cli.Client.FetchCheck(checkName)
+			err := cli.Client.ModifyCheck(checkName, func(check *corev2.CheckConfig) error {
+				check.Command = value
+				return check.Validate()
+			})
 			if err != nil {
 				return err
 			}
-			check.Command = value
-
-			if err := check.Validate(); err != nil {
-				return err
-			}
-			if err := cli.Client.UpdateCheck(check); err != nil {
-				return err
-			}
 
 			fmt.Fprintln(cmd.OutOrStdout(), "Updated")
 			return nil
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli"
 	"github.com/spf13/cobra"
 )
@@ -24,23 +25,19 @@ func SetIntervalCommand(cli *cli.SensuCli) *cobra.Command {
 			checkName := args[0]
 			value := args[1]
 
-			check, err := cli.Client.FetchCheck(checkName)
+			interval, err := strconv.ParseUint(value, 10, 32)
 			if err != nil {
 				return err
 			}
-			interval, err := strconv.ParseUint(value, 10, 32)
-			check.Interval = uint32(interval)
-			check.Cron = ""
 
+			err = cli.Client.ModifyCheck(checkName, func(check *corev2.CheckConfig) error {
+				check.Interval = uint32(interval)
+				check.Cron = ""
+				return check.Validate()
+			})
 			if err != nil {
 				return err
 			}
-			if err := check.Validate(); err != nil {
-				return err
-			}
-			if err := cli.Client.UpdateCheck(check); err != nil {
-				return err
-			}
 
 			fmt.Fprintln(cmd.OutOrStdout(), "Updated")
 			return nil
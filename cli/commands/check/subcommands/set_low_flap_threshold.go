@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli"
 	"github.com/spf13/cobra"
 )
@@ -24,22 +25,18 @@ func SetLowFlapThresholdCommand(cli *cli.SensuCli) *cobra.Command {
 			checkName := args[0]
 			value := args[1]
 
-			check, err := cli.Client.FetchCheck(checkName)
+			lowFlapThreshold, err := strconv.ParseUint(value, 10, 32)
 			if err != nil {
 				return err
 			}
-			lowFlapThreshold, err := strconv.ParseUint(value, 10, 32)
-			check.LowFlapThreshold = uint32(lowFlapThreshold)
 
+			err = cli.Client.ModifyCheck(checkName, func(check *corev2.CheckConfig) error {
+				check.LowFlapThreshold = uint32(lowFlapThreshold)
+				return check.Validate()
+			})
 			if err != nil {
 				return err
 			}
-			if err := check.Validate(); err != nil {
-				return err
-			}
-			if err := cli.Client.UpdateCheck(check); err != nil {
-				return err
-			}
 
 			fmt.Fprintln(cmd.OutOrStdout(), "Updated")
 			return nil
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli"
 	"github.com/sensu/sensu-go/types"
 	"github.com/spf13/cobra"
@@ -25,13 +26,9 @@ func SetProxyRequestsCommand(cli *cli.SensuCli) *cobra.Command {
 				return errors.New("invalid argument(s) received")
 			}
 
-			check, err := cli.Client.FetchCheck(args[0])
-			if err != nil {
-				return err
-			}
-
 			filePath, _ := cmd.Flags().GetString("file")
 			var in *os.File
+			var err error
 
 			if len(filePath) > 0 {
 				in, err = os.Open(filePath)
@@ -54,11 +51,11 @@ func SetProxyRequestsCommand(cli *cli.SensuCli) *cobra.Command {
 				proxyRequest.SplayCoverage = types.DefaultSplayCoverage
 			}
 
-			check.ProxyRequests = &proxyRequest
-			if err := check.Validate(); err != nil {
-				return err
-			}
-			if err := cli.Client.UpdateCheck(check); err != nil {
+			err = cli.Client.ModifyCheck(args[0], func(check *corev2.CheckConfig) error {
+				check.ProxyRequests = &proxyRequest
+				return check.Validate()
+			})
+			if err != nil {
 				return err
 			}
 
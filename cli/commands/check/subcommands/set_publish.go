@@ -4,18 +4,30 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli"
 	"github.com/spf13/cobra"
 )
 
-// SetPublishCommand updates the publish of a check
+// SetPublishCommand updates the publish of a check, or of all the checks of a
+// namespace
 func SetPublishCommand(cli *cli.SensuCli) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:          "set-publish [NAME] [VALUE]",
-		Short:        "set publish of a check",
+		Short:        "set publish of a check, or of all checks with --all",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			all, _ := cmd.Flags().GetBool("all")
+			subscription, _ := cmd.Flags().GetString("subscription")
+			if all {
+				return setChecksPublish(cmd, cli, args, subscription)
+			}
+			if subscription != "" {
+				return errors.New("--subscription can only be used with --all")
+			}
+
 			if len(args) != 2 {
 				_ = cmd.Help()
 				return errors.New("invalid argument(s) received")
@@ -24,27 +36,57 @@ func SetPublishCommand(cli *cli.SensuCli) *cobra.Command {
 			checkName := args[0]
 			value := args[1]
 
-			check, err := cli.Client.FetchCheck(checkName)
+			publish, err := strconv.ParseBool(value)
 			if err != nil {
 				return err
 			}
-			publish, err := strconv.ParseBool(value)
-			check.Publish = publish
 
+			err = cli.Client.ModifyCheck(checkName, func(check *corev2.CheckConfig) error {
+				check.Publish = publish
+				return check.Validate()
+			})
 			if err != nil {
 				return err
 			}
-			if err := check.Validate(); err != nil {
-				return err
-			}
-			if err := cli.Client.UpdateCheck(check); err != nil {
-				return err
-			}
 
 			fmt.Fprintln(cmd.OutOrStdout(), "Updated")
 			return nil
 		},
 	}
 
+	cmd.Flags().Bool("all", false, "update all the checks of the namespace")
+	cmd.Flags().String("subscription", "", "only update the checks with this subscription (requires --all)")
+
 	return cmd
 }
+
+// setChecksPublish updates the publish of all the checks of the namespace,
+// with the given subscription if any, in a single request
+func setChecksPublish(cmd *cobra.Command, cli *cli.SensuCli, args []string, subscription string) error {
+	if len(args) != 1 {
+		_ = cmd.Help()
+		return errors.New("invalid argument(s) received")
+	}
+
+	publish, err := strconv.ParseBool(args[0])
+	if err != nil {
+		return err
+	}
+
+	req := &corev2.CheckPublishRequest{
+		All:          true,
+		Subscription: subscription,
+		Publish:      publish,
+	}
+	response, err := cli.Client.SetChecksPublish(req)
+	if err != nil {
+		return err
+	}
+
+	if len(response.Checks) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No checks updated")
+		return nil
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Updated %d check(s): %s\n", len(response.Checks), strings.Join(response.Checks, ", "))
+	return nil
+}
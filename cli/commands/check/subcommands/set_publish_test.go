@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"testing"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	client "github.com/sensu/sensu-go/cli/client/testing"
 	test "github.com/sensu/sensu-go/cli/commands/testing"
 	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSetPublishCommand(t *testing.T) {
@@ -60,3 +62,44 @@ func TestSetPublishCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestSetPublishCommandAll(t *testing.T) {
+	testCases := []struct {
+		testName       string
+		args           []string
+		flags          map[string]string
+		response       *corev2.CheckPublishResponse
+		responseErr    error
+		expectedOutput string
+		expectError    bool
+	}{
+		{"no value", []string{}, map[string]string{"all": "true"}, nil, nil, "Usage", true},
+		{"subscription without all", []string{"false"}, map[string]string{"subscription": "web"}, nil, nil, "", true},
+		{"invalid value", []string{"yes"}, map[string]string{"all": "true"}, nil, nil, "", true},
+		{"request error", []string{"false"}, map[string]string{"all": "true"}, nil, fmt.Errorf("error"), "", true},
+		{"no checks", []string{"false"}, map[string]string{"all": "true"}, &corev2.CheckPublishResponse{}, nil, "No checks updated", false},
+		{"valid input", []string{"false"}, map[string]string{"all": "true", "subscription": "web"}, &corev2.CheckPublishResponse{Checks: []string{"check1", "check2"}}, nil, "Updated 2 check\\(s\\): check1, check2", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			cli := test.NewMockCLI()
+
+			client := cli.Client.(*client.MockClient)
+			client.On("SetChecksPublish", mock.Anything).Return(tc.response, tc.responseErr)
+
+			cmd := SetPublishCommand(cli)
+			for flag, value := range tc.flags {
+				require.NoError(t, cmd.Flags().Set(flag, value))
+			}
+			out, err := test.RunCmd(cmd, tc.args)
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.Regexp(t, tc.expectedOutput, out)
+		})
+	}
+}
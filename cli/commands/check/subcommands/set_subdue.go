@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli"
 	"github.com/sensu/sensu-go/cli/commands/timeutil"
 	"github.com/sensu/sensu-go/types"
@@ -25,13 +26,9 @@ func SetSubdueCommand(cli *cli.SensuCli) *cobra.Command {
 				return errors.New("invalid argument(s) received")
 			}
 
-			check, err := cli.Client.FetchCheck(args[0])
-			if err != nil {
-				return err
-			}
-
 			subduePath, _ := cmd.Flags().GetString("file")
 			var in *os.File
+			var err error
 
 			if len(subduePath) > 0 {
 				in, err = os.Open(subduePath)
@@ -54,11 +51,12 @@ func SetSubdueCommand(cli *cli.SensuCli) *cobra.Command {
 					}
 				}
 			}
-			check.Subdue = &timeWindows
-			if err := check.Validate(); err != nil {
-				return err
-			}
-			if err := cli.Client.UpdateCheck(check); err != nil {
+
+			err = cli.Client.ModifyCheck(args[0], func(check *corev2.CheckConfig) error {
+				check.Subdue = &timeWindows
+				return check.Validate()
+			})
+			if err != nil {
 				return err
 			}
 
@@ -0,0 +1,115 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/commands/timeutil"
+	"github.com/sensu/sensu-go/cli/elements/table"
+	"github.com/spf13/cobra"
+)
+
+// APIUsageCommand shows the API requests served by the backend per user and
+// per route
+func APIUsageCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "api-usage",
+		Short:        "show the API usage per user and route of the cluster",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+			usage, err := cli.Client.FetchAPIUsage()
+			if err != nil {
+				return err
+			}
+
+			user, err := cmd.Flags().GetString("user")
+			if err != nil {
+				return err
+			}
+			if user != "" {
+				filtered := []corev2.APIUsage{}
+				for _, u := range usage {
+					if u.User == user {
+						filtered = append(filtered, u)
+					}
+				}
+				usage = filtered
+			}
+
+			return helpers.Print(cmd, cli.Config.Format(), printAPIUsageToTable, nil, usage)
+		},
+	}
+
+	helpers.AddFormatFlag(cmd.Flags())
+	cmd.Flags().String("user", "", "only show the API usage of the given user")
+
+	return cmd
+}
+
+func printAPIUsageToTable(results interface{}, writer io.Writer) {
+	usageColumn := func(title string, fn func(corev2.APIUsage) string) *table.Column {
+		return &table.Column{
+			Title: title,
+			CellTransformer: func(data interface{}) string {
+				usage, ok := data.(corev2.APIUsage)
+				if !ok {
+					return cli.TypeError
+				}
+				return fn(usage)
+			},
+		}
+	}
+	formatDuration := func(seconds float64) string {
+		return time.Duration(seconds * float64(time.Second)).Round(time.Microsecond).String()
+	}
+
+	user := usageColumn("User", func(u corev2.APIUsage) string {
+		return u.User
+	})
+	user.ColumnStyle = table.PrimaryTextStyle
+
+	table := table.New([]*table.Column{
+		user,
+		usageColumn("Credentials", func(u corev2.APIUsage) string {
+			if u.APIKey {
+				return "api key"
+			}
+			return "token"
+		}),
+		usageColumn("Method", func(u corev2.APIUsage) string {
+			return u.Method
+		}),
+		usageColumn("Route", func(u corev2.APIUsage) string {
+			return u.Route
+		}),
+		usageColumn("Requests", func(u corev2.APIUsage) string {
+			return fmt.Sprintf("%d", u.Requests)
+		}),
+		usageColumn("Errors", func(u corev2.APIUsage) string {
+			if u.Requests == 0 {
+				return "0"
+			}
+			return fmt.Sprintf("%d (%.1f%%)", u.Errors, 100*float64(u.Errors)/float64(u.Requests))
+		}),
+		usageColumn("Avg Duration", func(u corev2.APIUsage) string {
+			return formatDuration(u.AverageDuration)
+		}),
+		usageColumn("Max Duration", func(u corev2.APIUsage) string {
+			return formatDuration(u.MaxDuration)
+		}),
+		usageColumn("Last Request", func(u corev2.APIUsage) string {
+			return timeutil.HumanTimestamp(u.LastRequest)
+		}),
+	})
+
+	table.Render(writer, results)
+}
@@ -0,0 +1,54 @@
+package cluster
+
+import (
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIUsageCommand(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := APIUsageCommand(cli)
+
+	assert.NotNil(t, cmd, "cmd should be returned")
+	assert.NotNil(t, cmd.RunE, "cmd should be able to be executed")
+	assert.Regexp(t, "api-usage", cmd.Use)
+	assert.Regexp(t, "API usage", cmd.Short)
+}
+
+func TestAPIUsageCommandRunEClosure(t *testing.T) {
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).On("FetchAPIUsage").Return([]corev2.APIUsage{
+		{User: "ci", APIKey: true, Method: "GET", Route: "/api/core/v2/namespaces/{namespace}/events", Requests: 200, Errors: 50, AverageDuration: 0.02},
+		{User: "admin", Method: "PUT", Route: "/api/core/v2/namespaces/{namespace}/checks/{id}", Requests: 3},
+	}, nil)
+
+	cmd := APIUsageCommand(cli)
+	out, err := test.RunCmd(cmd, []string{})
+	require.NoError(t, err)
+	assert.Contains(t, out, "ci")
+	assert.Contains(t, out, "api key")
+	assert.Contains(t, out, "50 (25.0%)")
+	assert.Contains(t, out, "20ms")
+	assert.Contains(t, out, "admin")
+
+	require.NoError(t, cmd.Flags().Set("user", "admin"))
+	out, err = test.RunCmd(cmd, []string{})
+	require.NoError(t, err)
+	assert.Contains(t, out, "admin")
+	assert.NotContains(t, out, "api key")
+}
+
+func TestAPIUsageCommandRunEClosureWithErr(t *testing.T) {
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).On("FetchAPIUsage").Return([]corev2.APIUsage(nil), errors.New("error"))
+
+	cmd := APIUsageCommand(cli)
+	_, err := test.RunCmd(cmd, []string{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,291 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/elements/list"
+	"github.com/spf13/cobra"
+)
+
+// ConfigCommand manages the configuration shared by the backends of the
+// cluster
+func ConfigCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "manage the configuration shared by the backends of the cluster",
+	}
+
+	cmd.AddCommand(
+		ConfigInfoCommand(cli),
+		ConfigSetCommand(cli),
+		ConfigUnsetCommand(cli),
+		ConfigSetEntityClassCommand(cli),
+		ConfigRemoveEntityClassCommand(cli),
+	)
+
+	return cmd
+}
+
+// ConfigInfoCommand shows the cluster configuration
+func ConfigInfoCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "info",
+		Short:        "show the cluster configuration",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			config, err := cli.Client.FetchClusterConfig()
+			if err != nil {
+				return err
+			}
+
+			// Determine the format to use to output the data
+			flag := helpers.GetChangedStringValueFlag("format", cmd.Flags())
+			format := cli.Config.Format()
+			return helpers.PrintFormatted(flag, format, config, cmd.OutOrStdout(), printConfigToList)
+		},
+	}
+
+	helpers.AddFormatFlag(cmd.Flags())
+
+	return cmd
+}
+
+const forNamespaceFlag = "for-namespace"
+
+// ConfigSetCommand sets a setting of the cluster configuration
+func ConfigSetCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "set KEY VALUE",
+		Short:        "set a setting of the cluster configuration",
+		Long:         configKeysDescription("set a setting of the cluster configuration, which the backends apply without being restarted"),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 2 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			namespace, err := cmd.Flags().GetString(forNamespaceFlag)
+			if err != nil {
+				return err
+			}
+			return updateConfig(cli, cmd, func(config *corev2.ClusterConfig) error {
+				if namespace != "" {
+					return config.SetForNamespace(namespace, args[0], args[1])
+				}
+				return config.Set(args[0], args[1])
+			})
+		},
+	}
+
+	addForNamespaceFlag(cmd)
+
+	return cmd
+}
+
+// ConfigUnsetCommand restores the default value of a setting of the cluster
+// configuration
+func ConfigUnsetCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "unset KEY",
+		Short:        "restore the default value of a setting of the cluster configuration",
+		Long:         configKeysDescription("restore the default value of a setting of the cluster configuration"),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			namespace, err := cmd.Flags().GetString(forNamespaceFlag)
+			if err != nil {
+				return err
+			}
+			return updateConfig(cli, cmd, func(config *corev2.ClusterConfig) error {
+				if namespace != "" {
+					return config.UnsetForNamespace(namespace, args[0])
+				}
+				return config.Unset(args[0])
+			})
+		},
+	}
+
+	addForNamespaceFlag(cmd)
+
+	return cmd
+}
+
+// ConfigSetEntityClassCommand adds or replaces a custom entity class
+func ConfigSetEntityClassCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "set-entity-class CLASS",
+		Short:        "add or replace a custom entity class",
+		Long:         "add or replace a custom entity class. Only the entities of the classes expecting keepalives get registration and keepalive events, the agent class expects them and the proxy and backend classes don't.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			expectKeepalives, err := cmd.Flags().GetBool("expect-keepalives")
+			if err != nil {
+				return err
+			}
+			return updateConfig(cli, cmd, func(config *corev2.ClusterConfig) error {
+				return config.SetEntityClass(args[0], &corev2.EntityClass{ExpectKeepalives: expectKeepalives})
+			})
+		},
+	}
+
+	cmd.Flags().Bool("expect-keepalives", false, "whether the entities of the class are expected to send keepalives")
+
+	return cmd
+}
+
+// ConfigRemoveEntityClassCommand removes a custom entity class
+func ConfigRemoveEntityClassCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "remove-entity-class CLASS",
+		Short:        "remove a custom entity class",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			return updateConfig(cli, cmd, func(config *corev2.ClusterConfig) error {
+				return config.RemoveEntityClass(args[0])
+			})
+		},
+	}
+
+	return cmd
+}
+
+func configKeysDescription(description string) string {
+	return fmt.Sprintf(
+		"%s\n\nKeys: %s\n\nThe %s and %s keys can also be set for the entities of a namespace with --%s.",
+		description, strings.Join(corev2.ClusterConfigKeys, ", "),
+		corev2.ClusterConfigKeepaliveTimeout, corev2.ClusterConfigKeepaliveHandlers, forNamespaceFlag,
+	)
+}
+
+// addForNamespaceFlag adds the flag selecting the namespace whose keepalive
+// defaults are set. It's distinct from the global namespace flag so the
+// cluster settings are not scoped to the current namespace by mistake.
+func addForNamespaceFlag(cmd *cobra.Command) {
+	cmd.Flags().String(forNamespaceFlag, "", "set the keepalive defaults of the entities of the given namespace instead of the cluster")
+}
+
+// updateConfig applies update to the cluster configuration.
+func updateConfig(cli *cli.SensuCli, cmd *cobra.Command, update func(*corev2.ClusterConfig) error) error {
+	config, err := cli.Client.FetchClusterConfig()
+	if err != nil {
+		return err
+	}
+	if err := update(config); err != nil {
+		return err
+	}
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if err := cli.Client.UpdateClusterConfig(config); err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(cmd.OutOrStdout(), "Updated")
+	return err
+}
+
+func printConfigToList(v interface{}, writer io.Writer) error {
+	config, ok := v.(*corev2.ClusterConfig)
+	if !ok {
+		return fmt.Errorf("%t is not a cluster config", v)
+	}
+
+	keepaliveTimeout := fmt.Sprintf("%ds (default)", corev2.DefaultKeepaliveTimeout)
+	if config.KeepaliveTimeout > 0 {
+		keepaliveTimeout = fmt.Sprintf("%ds", config.KeepaliveTimeout)
+	}
+	eventTTL := "none"
+	if config.EventTTL > 0 {
+		eventTTL = fmt.Sprintf("%ds", config.EventTTL)
+	}
+	keepaliveHandlers := fmt.Sprintf("%s (default)", corev2.KeepaliveHandlerName)
+	if len(config.KeepaliveHandlers) > 0 {
+		keepaliveHandlers = strings.Join(config.KeepaliveHandlers, ",")
+	}
+	registrationEvents := "backend configuration"
+	if config.RegistrationEvents != nil {
+		registrationEvents = strconv.FormatBool(*config.RegistrationEvents)
+	}
+
+	cfg := &list.Config{
+		Title: "Cluster Configuration",
+		Rows: []*list.Row{
+			{
+				Label: "Keepalive Timeout",
+				Value: keepaliveTimeout,
+			},
+			{
+				Label: "Keepalive Handlers",
+				Value: keepaliveHandlers,
+			},
+			{
+				Label: "Event TTL",
+				Value: eventTTL,
+			},
+			{
+				Label: "Registration Events",
+				Value: registrationEvents,
+			},
+			{
+				Label: "Require Silenced Reason",
+				Value: strconv.FormatBool(config.RequireSilencedReason),
+			},
+		},
+	}
+
+	namespaces := make([]string, 0, len(config.NamespaceKeepalives))
+	for namespace := range config.NamespaceKeepalives {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		cfg.Rows = append(cfg.Rows, &list.Row{
+			Label: fmt.Sprintf("Keepalives (%s)", namespace),
+			Value: fmt.Sprintf("timeout: %ds, handlers: %s",
+				config.KeepaliveTimeoutFor(namespace),
+				strings.Join(config.KeepaliveHandlersFor(namespace), ",")),
+		})
+	}
+
+	classes := make([]string, 0, len(config.EntityClasses))
+	for class := range config.EntityClasses {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+	for _, class := range classes {
+		cfg.Rows = append(cfg.Rows, &list.Row{
+			Label: fmt.Sprintf("Entity Class (%s)", class),
+			Value: fmt.Sprintf("expect keepalives: %t", config.ExpectsKeepalives(class)),
+		})
+	}
+
+	return list.Print(writer, cfg)
+}
@@ -0,0 +1,164 @@
+package cluster
+
+import (
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigInfoCommand(t *testing.T) {
+	enabled := true
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).On("FetchClusterConfig").Return(&corev2.ClusterConfig{
+		EventTTL:           3600,
+		RegistrationEvents: &enabled,
+		NamespaceKeepalives: map[string]*corev2.KeepaliveDefaults{
+			"dev": {Timeout: 300, Handlers: []string{"slack"}},
+		},
+		EntityClasses: map[string]*corev2.EntityClass{
+			"service": {ExpectKeepalives: true},
+		},
+	}, nil)
+
+	cmd := ConfigInfoCommand(cli)
+	out, err := test.RunCmd(cmd, []string{})
+	require.NoError(t, err)
+	assert.Contains(t, out, "120s (default)")
+	assert.Contains(t, out, "keepalive (default)")
+	assert.Contains(t, out, "3600s")
+	assert.Contains(t, out, "true")
+	assert.Contains(t, out, "timeout: 300s, handlers: slack")
+	assert.Contains(t, out, "expect keepalives: true")
+}
+
+func TestConfigSetCommand(t *testing.T) {
+	cli := test.NewMockCLI()
+	mockClient := cli.Client.(*client.MockClient)
+	mockClient.On("FetchClusterConfig").Return(&corev2.ClusterConfig{EventTTL: 3600}, nil)
+	mockClient.On("UpdateClusterConfig", &corev2.ClusterConfig{KeepaliveTimeout: 60, EventTTL: 3600}).Return(nil)
+
+	cmd := ConfigSetCommand(cli)
+	out, err := test.RunCmd(cmd, []string{"keepalive_timeout", "60"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Updated")
+}
+
+func TestConfigSetCommandForNamespace(t *testing.T) {
+	cli := test.NewMockCLI()
+	mockClient := cli.Client.(*client.MockClient)
+	mockClient.On("FetchClusterConfig").Return(&corev2.ClusterConfig{KeepaliveTimeout: 60}, nil)
+	mockClient.On("UpdateClusterConfig", &corev2.ClusterConfig{
+		KeepaliveTimeout: 60,
+		NamespaceKeepalives: map[string]*corev2.KeepaliveDefaults{
+			"dev": {Handlers: []string{"slack", "pagerduty"}},
+		},
+	}).Return(nil)
+
+	cmd := ConfigSetCommand(cli)
+	require.NoError(t, cmd.Flags().Set("for-namespace", "dev"))
+	out, err := test.RunCmd(cmd, []string{"keepalive_handlers", "slack,pagerduty"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Updated")
+}
+
+func TestConfigSetCommandInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{
+			name: "missing value",
+			args: []string{"event_ttl"},
+		},
+		{
+			name: "unknown key",
+			args: []string{"foo", "bar"},
+		},
+		{
+			name: "invalid value",
+			args: []string{"registration_events", "maybe"},
+		},
+		{
+			name: "invalid configuration",
+			args: []string{"keepalive_timeout", "1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := test.NewMockCLI()
+			mockClient := cli.Client.(*client.MockClient)
+			mockClient.On("FetchClusterConfig").Return(&corev2.ClusterConfig{}, nil)
+
+			cmd := ConfigSetCommand(cli)
+			_, err := test.RunCmd(cmd, tt.args)
+			assert.Error(t, err)
+			mockClient.AssertNotCalled(t, "UpdateClusterConfig", mock.Anything)
+		})
+	}
+}
+
+func TestConfigUnsetCommand(t *testing.T) {
+	disabled := false
+	cli := test.NewMockCLI()
+	mockClient := cli.Client.(*client.MockClient)
+	mockClient.On("FetchClusterConfig").Return(&corev2.ClusterConfig{EventTTL: 3600, RegistrationEvents: &disabled}, nil)
+	mockClient.On("UpdateClusterConfig", &corev2.ClusterConfig{EventTTL: 3600}).Return(nil)
+
+	cmd := ConfigUnsetCommand(cli)
+	out, err := test.RunCmd(cmd, []string{"registration_events"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Updated")
+}
+
+func TestConfigSetCommandWithErr(t *testing.T) {
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).On("FetchClusterConfig").Return((*corev2.ClusterConfig)(nil), errors.New("error"))
+
+	cmd := ConfigSetCommand(cli)
+	_, err := test.RunCmd(cmd, []string{"event_ttl", "60"})
+	assert.Error(t, err)
+}
+
+func TestConfigSetEntityClassCommand(t *testing.T) {
+	cli := test.NewMockCLI()
+	mockClient := cli.Client.(*client.MockClient)
+	mockClient.On("FetchClusterConfig").Return(&corev2.ClusterConfig{}, nil)
+	mockClient.On("UpdateClusterConfig", &corev2.ClusterConfig{
+		EntityClasses: map[string]*corev2.EntityClass{
+			"service": {ExpectKeepalives: true},
+		},
+	}).Return(nil)
+
+	cmd := ConfigSetEntityClassCommand(cli)
+	require.NoError(t, cmd.Flags().Set("expect-keepalives", "true"))
+	out, err := test.RunCmd(cmd, []string{"service"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Updated")
+
+	// The built-in classes can't be redefined
+	cmd = ConfigSetEntityClassCommand(cli)
+	_, err = test.RunCmd(cmd, []string{"proxy"})
+	assert.Error(t, err)
+}
+
+func TestConfigRemoveEntityClassCommand(t *testing.T) {
+	cli := test.NewMockCLI()
+	mockClient := cli.Client.(*client.MockClient)
+	mockClient.On("FetchClusterConfig").Return(&corev2.ClusterConfig{
+		EntityClasses: map[string]*corev2.EntityClass{
+			"service": {ExpectKeepalives: true},
+		},
+	}, nil)
+	mockClient.On("UpdateClusterConfig", &corev2.ClusterConfig{EntityClasses: map[string]*corev2.EntityClass{}}).Return(nil)
+
+	cmd := ConfigRemoveEntityClassCommand(cli)
+	out, err := test.RunCmd(cmd, []string{"service"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Updated")
+}
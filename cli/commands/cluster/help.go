@@ -19,6 +19,8 @@ func HelpCommand(cli *cli.SensuCli) *cobra.Command {
 		MemberRemoveCommand(cli),
 		HealthCommand(cli),
 		IDCommand(cli),
+		APIUsageCommand(cli),
+		ConfigCommand(cli),
 	)
 
 	return cmd
@@ -22,14 +22,20 @@ import (
 	"github.com/sensu/sensu-go/cli/commands/filter"
 	"github.com/sensu/sensu-go/cli/commands/handler"
 	"github.com/sensu/sensu-go/cli/commands/hook"
+	"github.com/sensu/sensu-go/cli/commands/lint"
+	"github.com/sensu/sensu-go/cli/commands/login"
 	"github.com/sensu/sensu-go/cli/commands/logout"
+	"github.com/sensu/sensu-go/cli/commands/migrate"
 	"github.com/sensu/sensu-go/cli/commands/mutator"
 	"github.com/sensu/sensu-go/cli/commands/namespace"
 	"github.com/sensu/sensu-go/cli/commands/role"
 	"github.com/sensu/sensu-go/cli/commands/rolebinding"
 	"github.com/sensu/sensu-go/cli/commands/silenced"
+	"github.com/sensu/sensu-go/cli/commands/stats"
+	"github.com/sensu/sensu-go/cli/commands/subscription"
 	"github.com/sensu/sensu-go/cli/commands/tessen"
 	"github.com/sensu/sensu-go/cli/commands/user"
+	"github.com/sensu/sensu-go/cli/commands/validate"
 	"github.com/spf13/cobra"
 )
 
@@ -39,6 +45,7 @@ func AddCommands(rootCmd *cobra.Command, cli *cli.SensuCli) {
 		configure.Command(cli),
 		completion.Command(rootCmd),
 		env.Command(cli),
+		login.Command(cli),
 		logout.Command(cli),
 
 		// Management Commands
@@ -59,6 +66,7 @@ func AddCommands(rootCmd *cobra.Command, cli *cli.SensuCli) {
 		rolebinding.HelpCommand(cli),
 		user.HelpCommand(cli),
 		silenced.HelpCommand(cli),
+		subscription.HelpCommand(cli),
 		create.CreateCommand(cli),
 		delete.DeleteCommand(cli),
 		//extension.HelpCommand(cli),
@@ -66,7 +74,11 @@ func AddCommands(rootCmd *cobra.Command, cli *cli.SensuCli) {
 		edit.Command(cli),
 		tessen.HelpCommand(cli),
 		dump.Command(cli),
+		lint.Command(cli),
+		migrate.HelpCommand(cli),
 		command.HelpCommand(cli),
+		stats.Command(cli),
+		validate.Command(cli),
 	)
 
 	for _, cmd := range rootCmd.Commands() {
@@ -16,6 +16,8 @@ func HelpCommand(cli *cli.SensuCli) *cobra.Command {
 	cmd.AddCommand(
 		SetFormatCommand(cli),
 		SetNamespaceCommand(cli),
+		SetSilencedReasonTemplateCommand(cli),
+		SetTimeFormatCommand(cli),
 		ViewCommand(cli),
 	)
 
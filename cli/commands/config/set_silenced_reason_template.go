@@ -0,0 +1,55 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"text/template"
+
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/commands/hooks"
+	"github.com/spf13/cobra"
+)
+
+// SetSilencedReasonTemplateCommand given argument changes the template
+// applied to the reason of the silenced entries created with the active
+// profile
+func SetSilencedReasonTemplateCommand(cli *cli.SensuCli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set-silenced-reason-template [TEMPLATE]",
+		Short: "Set the template of the reason of silenced entries for active profile",
+		Long: `Set the template of the reason of silenced entries for active profile.
+
+A template without actions, e.g. "[ops] ", is prepended to the reason. A
+template with actions is executed with the .Reason, .Username and .Namespace
+fields, e.g. "{{.Reason}} (silenced by {{.Username}})". An empty template
+leaves the reason unchanged.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			newTemplate := args[0]
+			if _, err := template.New("reason").Parse(newTemplate); err != nil {
+				return fmt.Errorf("invalid silenced reason template: %s", err)
+			}
+
+			if err := cli.Config.SaveSilencedReasonTemplate(newTemplate); err != nil {
+				fmt.Fprintf(
+					cmd.OutOrStderr(),
+					"Unable to write new configuration file with error: %s\n",
+					err,
+				)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "Updated")
+			return nil
+		},
+		Annotations: map[string]string{
+			// We want to be able to run this command regardless of whether the CLI
+			// has been configured.
+			hooks.ConfigurationRequirement: hooks.ConfigurationNotRequired,
+		},
+	}
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+
+	clienttest "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSilencedReasonTemplateExec(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := SetSilencedReasonTemplateCommand(cli)
+
+	config := cli.Config.(*clienttest.MockConfig)
+	config.On("SaveSilencedReasonTemplate", "{{.Reason}} ({{.Username}})").Return(nil)
+
+	out, err := test.RunCmd(cmd, []string{"{{.Reason}} ({{.Username}})"})
+	assert.Equal(t, "Updated\n", out)
+	assert.NoError(t, err)
+}
+
+func TestSetSilencedReasonTemplateInvalid(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := SetSilencedReasonTemplateCommand(cli)
+
+	_, err := test.RunCmd(cmd, []string{"{{.Reason"})
+	assert.Error(t, err)
+}
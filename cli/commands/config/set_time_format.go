@@ -0,0 +1,54 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client/config"
+	"github.com/sensu/sensu-go/cli/commands/hooks"
+	utilstrings "github.com/sensu/sensu-go/util/strings"
+	"github.com/spf13/cobra"
+)
+
+// SetTimeFormatCommand given argument changes the time format for active
+// profile
+func SetTimeFormatCommand(cli *cli.SensuCli) *cobra.Command {
+	return &cobra.Command{
+		Use:          "set-time-format [FORMAT]",
+		Short:        "Set time format for active profile (" + strings.Join(config.TimeFormats, ", ") + ")",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			newFormat := args[0]
+			if !utilstrings.InArray(newFormat, config.TimeFormats) {
+				return fmt.Errorf(
+					"invalid time format %q, must be one of: %s",
+					newFormat,
+					strings.Join(config.TimeFormats, ", "),
+				)
+			}
+
+			if err := cli.Config.SaveTimeFormat(newFormat); err != nil {
+				fmt.Fprintf(
+					cmd.OutOrStderr(),
+					"Unable to write new configuration file with error: %s\n",
+					err,
+				)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), "Updated")
+			return nil
+		},
+		Annotations: map[string]string{
+			// We want to be able to run this command regardless of whether the CLI
+			// has been configured.
+			hooks.ConfigurationRequirement: hooks.ConfigurationNotRequired,
+		},
+	}
+}
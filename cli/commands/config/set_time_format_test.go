@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+
+	clienttest "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetTimeFormatExec(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := SetTimeFormatCommand(cli)
+
+	config := cli.Config.(*clienttest.MockConfig)
+	config.On("SaveTimeFormat", "humanized").Return(nil)
+
+	out, err := test.RunCmd(cmd, []string{"humanized"})
+	assert.Equal(t, "Updated\n", out)
+	assert.NoError(t, err)
+}
+
+func TestSetTimeFormatInvalid(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := SetTimeFormatCommand(cli)
+
+	_, err := test.RunCmd(cmd, []string{"epoch"})
+	assert.Error(t, err)
+}
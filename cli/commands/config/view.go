@@ -9,6 +9,7 @@ import (
 	"github.com/sensu/sensu-go/cli"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
 	"github.com/sensu/sensu-go/cli/commands/hooks"
+	"github.com/sensu/sensu-go/cli/commands/timeutil"
 	"github.com/sensu/sensu-go/cli/elements/list"
 	"github.com/spf13/cobra"
 )
@@ -25,11 +26,13 @@ func ViewCommand(cli *cli.SensuCli) *cobra.Command {
 				return errors.New("no active configuration found")
 			}
 			activeConfig := map[string]string{
-				"api-url":        cli.Config.APIUrl(),
-				"namespace":      cli.Config.Namespace(),
-				"format":         cli.Config.Format(),
-				"username":       helpers.GetCurrentUsername(cli.Config),
-				"jwt_expires_at": strconv.Itoa(int(cli.Config.Tokens().GetExpiresAt())),
+				"api-url":                  cli.Config.APIUrl(),
+				"namespace":                cli.Config.Namespace(),
+				"format":                   cli.Config.Format(),
+				"time-format":              cli.Config.TimeFormat(),
+				"silenced-reason-template": cli.Config.SilencedReasonTemplate(),
+				"username":                 helpers.GetCurrentUsername(cli.Config),
+				"jwt_expires_at":           strconv.Itoa(int(cli.Config.Tokens().GetExpiresAt())),
 			}
 
 			// Determine the format to use to output the data
@@ -70,16 +73,34 @@ func printToList(v interface{}, writer io.Writer) error {
 				Label: "Format",
 				Value: r["format"],
 			},
+			{
+				Label: "Time Format",
+				Value: r["time-format"],
+			},
+			{
+				Label: "Silenced Reason Template",
+				Value: r["silenced-reason-template"],
+			},
 			{
 				Label: "Username",
 				Value: r["username"],
 			},
 			{
 				Label: "JWT Expiration Timestamp",
-				Value: r["jwt_expires_at"],
+				Value: jwtExpiration(r["jwt_expires_at"]),
 			},
 		},
 	}
 
 	return list.Print(writer, cfg)
 }
+
+// jwtExpiration formats the expiration timestamp of the access token
+// according to the time format preference
+func jwtExpiration(value string) string {
+	timestamp, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return value
+	}
+	return timeutil.HumanTimestamp(timestamp)
+}
@@ -8,13 +8,14 @@ import (
 	"github.com/sensu/sensu-go/cli"
 	config "github.com/sensu/sensu-go/cli/client/config"
 	hooks "github.com/sensu/sensu-go/cli/commands/hooks"
+	"github.com/sensu/sensu-go/cli/commands/login"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 )
 
 type configureAnswers struct {
 	URL                   string `survey:"url"`
-	Username              string `survey:"username"`
+	Username              string
 	Password              string
 	Format                string `survey:"format"`
 	Namespace             string `survey:"namespace"`
@@ -25,18 +26,11 @@ type configureAnswers struct {
 // Command defines new configuration command
 func Command(cli *cli.SensuCli) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:          "configure",
-		Short:        "Initialize sensuctl configuration",
+		Use:   "configure",
+		Short: "Initialize sensuctl configuration",
+		Long: `Initialize the sensuctl configuration: the backend URL, the TLS settings, the
+namespace and the output format. Use "sensuctl login" to authenticate.`,
 		SilenceUsage: true,
-		PreRun: func(cmd *cobra.Command, args []string) {
-			flags := cmd.Flags()
-			nonInteractive, _ := flags.GetBool("non-interactive")
-			if nonInteractive {
-				// Mark flags are required for bash-completions
-				_ = cmd.MarkFlagRequired("username")
-				_ = cmd.MarkFlagRequired("password")
-			}
-		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) != 0 {
 				_ = cmd.Help()
@@ -69,27 +63,6 @@ func Command(cli *cli.SensuCli) *cobra.Command {
 				)
 			}
 
-			// Authenticate
-			tokens, err := cli.Client.CreateAccessToken(
-				answers.URL, answers.Username, answers.Password,
-			)
-			if err != nil {
-				fmt.Fprintln(cmd.OutOrStderr())
-				return fmt.Errorf("unable to authenticate with error: %s", err)
-			} else if tokens == nil {
-				fmt.Fprintln(cmd.OutOrStderr())
-				return fmt.Errorf("bad username or password")
-			}
-
-			// Write new credentials to disk
-			if err = cli.Config.SaveTokens(tokens); err != nil {
-				fmt.Fprintln(cmd.OutOrStderr())
-				return fmt.Errorf(
-					"unable to write new configuration file with error: %s",
-					err,
-				)
-			}
-
 			// Write CLI preferences to disk
 			if err = cli.Config.SaveFormat(answers.Format); err != nil {
 				fmt.Fprintln(cmd.OutOrStderr())
@@ -127,6 +100,14 @@ func Command(cli *cli.SensuCli) *cobra.Command {
 				}
 			}
 
+			// Authenticate with the deprecated credentials flags, if given
+			if answers.Username != "" {
+				return login.Authenticate(cli, answers.URL, answers.Username, answers.Password)
+			}
+			if !nonInteractive {
+				fmt.Fprintln(cmd.OutOrStdout(), `Configuration saved, run "sensuctl login" to authenticate`)
+			}
+
 			return nil
 		},
 		Annotations: map[string]string{
@@ -140,6 +121,8 @@ func Command(cli *cli.SensuCli) *cobra.Command {
 	_ = cmd.Flags().StringP("url", "", cli.Config.APIUrl(), "the sensu backend url")
 	_ = cmd.Flags().StringP("username", "", "", "username")
 	_ = cmd.Flags().StringP("password", "", "", "password")
+	_ = cmd.Flags().MarkDeprecated("username", `use "sensuctl login" to authenticate`)
+	_ = cmd.Flags().MarkDeprecated("password", `use "sensuctl login --password-stdin" to authenticate`)
 	_ = cmd.Flags().StringP("format", "", cli.Config.Format(), "preferred output format")
 	_ = cmd.Flags().StringP("namespace", "", cli.Config.Namespace(), "namespace")
 
@@ -149,8 +132,6 @@ func Command(cli *cli.SensuCli) *cobra.Command {
 func (answers *configureAnswers) administerQuestionnaire(c config.Config) error {
 	qs := []*survey.Question{
 		askForURL(c),
-		askForUsername(),
-		askForPassword(),
 		askForNamespace(c),
 		askForDefaultFormat(c),
 	}
@@ -178,23 +159,6 @@ func askForURL(c config.Config) *survey.Question {
 	}
 }
 
-func askForUsername() *survey.Question {
-	return &survey.Question{
-		Name: "username",
-		Prompt: &survey.Input{
-			Message: "Username:",
-			Default: "",
-		},
-	}
-}
-
-func askForPassword() *survey.Question {
-	return &survey.Question{
-		Name:   "password",
-		Prompt: &survey.Password{Message: "Password:"},
-	}
-}
-
 func askForDefaultFormat(c config.Config) *survey.Question {
 	format := c.Format()
 
@@ -72,3 +72,33 @@ func TestCommandRunEClosureWithFlags(t *testing.T) {
 	mockConfig.AssertCalled(t, "SaveInsecureSkipTLSVerify", false)
 	mockConfig.AssertCalled(t, "SaveTrustedCAFile", "")
 }
+
+func TestCommandRunEClosureWithoutCredentials(t *testing.T) {
+	cli := test.NewMockCLI()
+	mockClient := cli.Client.(*client.MockClient)
+	mockConfig := cli.Config.(*client.MockConfig)
+	mockConfig.On("APIUrl").Return("http://127.0.0.1:8080")
+	mockConfig.On("Format").Return(config.DefaultFormat)
+	mockConfig.On("SaveAPIUrl", mock.Anything).Return(nil)
+	mockConfig.On("SaveFormat", mock.Anything).Return(nil)
+	mockConfig.On("SaveNamespace", mock.Anything).Return(nil)
+	mockConfig.On("SaveInsecureSkipTLSVerify", mock.Anything).Return(nil)
+	mockConfig.On("SaveTrustedCAFile", mock.Anything).Return(nil)
+
+	rootCmd := root.Command()
+	cmd := Command(cli)
+	require.NoError(t, cmd.Flags().Set("non-interactive", "true"))
+	require.NoError(t, cmd.Flags().Set("url", "http://127.0.0.1:8080"))
+	rootCmd.AddCommand(cmd)
+
+	buf := new(bytes.Buffer)
+	rootCmd.SetOutput(buf)
+	rootCmd.SetArgs([]string{"configure"})
+	_, err := rootCmd.ExecuteC()
+	require.NoError(t, err)
+
+	// The credentials are left to sensuctl login
+	mockClient.AssertNotCalled(t, "CreateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+	mockConfig.AssertNotCalled(t, "SaveTokens", mock.Anything)
+	mockConfig.AssertCalled(t, "SaveAPIUrl", "http://127.0.0.1:8080")
+}
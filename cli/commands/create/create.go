@@ -19,6 +19,8 @@ func CreateCommand(cli *cli.SensuCli) *cobra.Command {
 
 	_ = cmd.Flags().StringSliceP("file", "f", nil, "Files, directories, or URLs to create resources from")
 	_ = cmd.Flags().BoolP("recursive", "r", false, "Follow subdirectories")
+	_ = cmd.Flags().Bool("atomic", false, "Create all the resources in a single transaction, so that none is created if one of them can't be")
+	_ = cmd.Flags().Bool("env-fill", false, "Replace the ${VAR} references in the string values of the resources with the values of the environment variables")
 
 	return cmd
 }
@@ -36,19 +38,41 @@ func execute(cli *cli.SensuCli) func(*cobra.Command, []string) error {
 		if err != nil {
 			return err
 		}
-		processor := resource.NewPutter()
-		if len(inputs) == 0 {
-			return resource.ProcessStdin(cli, client, processor)
+		atomic, err := cmd.Flags().GetBool("atomic")
+		if err != nil {
+			return err
+		}
+		var processor resource.Processor = resource.NewPutter()
+		collector := &resource.Collector{}
+		if atomic {
+			processor = collector
 		}
-		recurse, err := cmd.Flags().GetBool("recursive")
+		envFill, err := cmd.Flags().GetBool("env-fill")
 		if err != nil {
 			return err
 		}
-		for _, input := range inputs {
-			if err := resource.Process(cli, client, input, recurse, processor); err != nil {
-				return err
-			}
+		if err := process(cmd, cli, client, inputs, envFill, processor); err != nil {
+			return err
+		}
+		if atomic {
+			return cli.Client.PutResources(collector.Resources)
 		}
 		return nil
 	}
 }
+
+func process(cmd *cobra.Command, cli *cli.SensuCli, client *http.Client, inputs []string, envFill bool, processor resource.Processor) error {
+	if len(inputs) == 0 {
+		return resource.ProcessStdin(cli, client, envFill, processor)
+	}
+	recurse, err := cmd.Flags().GetBool("recursive")
+	if err != nil {
+		return err
+	}
+	for _, input := range inputs {
+		if err := resource.Process(cli, client, input, recurse, envFill, processor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -110,6 +110,63 @@ func TestCreateCommand(t *testing.T) {
 	client.AssertCalled(t, "PutResource", mock.Anything)
 }
 
+func TestCreateCommandAtomic(t *testing.T) {
+	cli := cmdtesting.NewMockCLI()
+	client := cli.Client.(*mockclient.MockClient)
+	client.On("PutResources", mock.MatchedBy(func(resources []*types.Wrapper) bool {
+		return len(resources) == 3
+	})).Return(nil)
+
+	cmd := CreateCommand(cli)
+	td, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(td)
+
+	fp := filepath.Join(td, "input")
+
+	f, err := os.Create(fp)
+	require.NoError(t, err)
+
+	err = resourceSpecTmpl.Execute(f, resources)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.NoError(t, cmd.Flags().Set("file", fp))
+	require.NoError(t, cmd.Flags().Set("atomic", "true"))
+	_, err = cmdtesting.RunCmd(cmd, nil)
+	require.NoError(t, err)
+
+	client.AssertNotCalled(t, "PutResource", mock.Anything)
+	client.AssertExpectations(t)
+}
+
+func TestCreateCommandEnvFill(t *testing.T) {
+	cli := cmdtesting.NewMockCLI()
+	client := cli.Client.(*mockclient.MockClient)
+	client.On("PutResource", mock.MatchedBy(func(w types.Wrapper) bool {
+		handler, ok := w.Value.(*types.Handler)
+		return ok && handler.Command == "notify --url https://hooks.example.com"
+	})).Return(nil)
+
+	cmd := CreateCommand(cli)
+	td, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(td)
+
+	fp := filepath.Join(td, "input")
+	spec := "type: Handler\nspec:\n  metadata:\n    name: notify\n  type: pipe\n  command: notify --url ${SENSU_TEST_WEBHOOK_URL}\n"
+	require.NoError(t, ioutil.WriteFile(fp, []byte(spec), 0644))
+
+	require.NoError(t, os.Setenv("SENSU_TEST_WEBHOOK_URL", "https://hooks.example.com"))
+	defer os.Unsetenv("SENSU_TEST_WEBHOOK_URL")
+
+	require.NoError(t, cmd.Flags().Set("file", fp))
+	require.NoError(t, cmd.Flags().Set("env-fill", "true"))
+	_, err = cmdtesting.RunCmd(cmd, nil)
+	require.NoError(t, err)
+
+	client.AssertExpectations(t)
+}
 func TestCreateCommandYAML(t *testing.T) {
 	cli := cmdtesting.NewMockCLI()
 	client := cli.Client.(*mockclient.MockClient)
@@ -22,6 +22,7 @@ func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 	}
 
 	_ = cmd.Flags().StringP("file", "f", "", "File to delete resources from")
+	_ = cmd.Flags().Bool("atomic", false, "Delete all the resources in a single transaction, so that none is deleted if one of them can't be")
 
 	return cmd
 }
@@ -51,6 +52,13 @@ func execute(cli *cli.SensuCli) func(*cobra.Command, []string) error {
 			return err
 		}
 
+		atomic, err := cmd.Flags().GetBool("atomic")
+		if err != nil {
+			return err
+		}
+		if atomic {
+			return cli.Client.DeleteResources(resources)
+		}
 		return DeleteResources(cli.Client, resources)
 	}
 }
@@ -76,3 +76,33 @@ func TestDeleteCommand(t *testing.T) {
 	client.AssertCalled(t, "Delete", mock.Anything)
 	client.AssertCalled(t, "Delete", mock.Anything)
 }
+
+func TestDeleteCommandAtomic(t *testing.T) {
+	cli := cmdtesting.NewMockCLI()
+	client := cli.Client.(*mockclient.MockClient)
+	client.On("DeleteResources", mock.MatchedBy(func(resources []*types.Wrapper) bool {
+		return len(resources) == 3
+	})).Return(nil)
+
+	cmd := DeleteCommand(cli)
+	td, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(td)
+
+	fp := filepath.Join(td, "input")
+
+	f, err := os.Create(fp)
+	require.NoError(t, err)
+
+	err = resourceSpecTmpl.Execute(f, resources)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	require.NoError(t, cmd.Flags().Set("file", fp))
+	require.NoError(t, cmd.Flags().Set("atomic", "true"))
+	_, err = cmdtesting.RunCmd(cmd, nil)
+	require.NoError(t, err)
+
+	client.AssertNotCalled(t, "Delete", mock.Anything)
+	client.AssertExpectations(t)
+}
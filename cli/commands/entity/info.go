@@ -4,13 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"sort"
+	"strconv"
 	"strings"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
 	"github.com/sensu/sensu-go/cli/commands/timeutil"
 	"github.com/sensu/sensu-go/cli/elements/globals"
 	"github.com/sensu/sensu-go/cli/elements/list"
+	"github.com/sensu/sensu-go/cli/elements/table"
 	"github.com/sensu/sensu-go/types"
 	"github.com/spf13/cobra"
 )
@@ -37,7 +41,23 @@ func InfoCommand(cli *cli.SensuCli) *cobra.Command {
 			// Determine the format to use to output the data
 			flag := helpers.GetChangedStringValueFlag("format", cmd.Flags())
 			format := cli.Config.Format()
-			return helpers.PrintFormatted(flag, format, r, cmd.OutOrStdout(), printToList)
+			return helpers.PrintFormatted(flag, format, r, cmd.OutOrStdout(), func(v interface{}, w io.Writer) error {
+				// The keepalive state and the events are only shown in the
+				// tabular format, the other formats print the entity as is
+				keepalive, err := cli.Client.FetchEntityKeepalive(entityName)
+				if err != nil {
+					return err
+				}
+				events, err := cli.Client.FetchEntityEvents(entityName)
+				if err != nil {
+					return err
+				}
+				if err := printToList(v, keepalive, events, w); err != nil {
+					return err
+				}
+				printEventSummary(events, w)
+				return nil
+			})
 		},
 	}
 
@@ -46,7 +66,7 @@ func InfoCommand(cli *cli.SensuCli) *cobra.Command {
 	return cmd
 }
 
-func printToList(v interface{}, writer io.Writer) error {
+func printToList(v interface{}, keepalive *types.KeepaliveRecord, events []types.Event, writer io.Writer) error {
 	r, ok := v.(*types.Entity)
 	if !ok {
 		return fmt.Errorf("%t is not an Entity", v)
@@ -70,6 +90,14 @@ func printToList(v interface{}, writer io.Writer) error {
 				Label: "Last Seen",
 				Value: timeutil.HumanTimestamp(r.LastSeen),
 			},
+			{
+				Label: "Keepalive",
+				Value: keepaliveState(keepalive, events),
+			},
+			{
+				Label: "Events",
+				Value: strconv.Itoa(len(events)),
+			},
 			{
 				Label: "Hostname",
 				Value: r.System.Hostname,
@@ -104,3 +132,91 @@ func printToList(v interface{}, writer io.Writer) error {
 
 	return list.Print(writer, cfg)
 }
+
+// keepaliveState describes the keepalive state of the entity, with the status
+// of its keepalive event if its keepalives are failing
+func keepaliveState(keepalive *types.KeepaliveRecord, events []types.Event) string {
+	if keepalive.Time == 0 {
+		return "OK"
+	}
+	state := fmt.Sprintf("Failing, checked again %s", timeutil.HumanTimestamp(keepalive.Time))
+	for _, event := range events {
+		if event.HasCheck() && event.Check.Name == corev2.KeepaliveCheckName {
+			state = fmt.Sprintf("%s (status %d)", state, event.Check.Status)
+		}
+	}
+	return state
+}
+
+// eventStatusSummary is the summary of the events of the entity with a given
+// status
+type eventStatusSummary struct {
+	Status uint32
+	Checks []string
+}
+
+// summarizeEvents groups the checks of the events by status, ordered by
+// status
+func summarizeEvents(events []types.Event) []eventStatusSummary {
+	checks := map[uint32][]string{}
+	for _, event := range events {
+		if !event.HasCheck() {
+			continue
+		}
+		checks[event.Check.Status] = append(checks[event.Check.Status], event.Check.Name)
+	}
+
+	summary := make([]eventStatusSummary, 0, len(checks))
+	for status, names := range checks {
+		sort.Strings(names)
+		summary = append(summary, eventStatusSummary{Status: status, Checks: names})
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		return summary[i].Status < summary[j].Status
+	})
+	return summary
+}
+
+func printEventSummary(events []types.Event, writer io.Writer) {
+	summary := summarizeEvents(events)
+	if len(summary) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(writer)
+
+	table := table.New([]*table.Column{
+		{
+			Title:       "Status",
+			ColumnStyle: table.PrimaryTextStyle,
+			CellTransformer: func(data interface{}) string {
+				summary, ok := data.(eventStatusSummary)
+				if !ok {
+					return cli.TypeError
+				}
+				return strconv.Itoa(int(summary.Status))
+			},
+		},
+		{
+			Title: "Events",
+			CellTransformer: func(data interface{}) string {
+				summary, ok := data.(eventStatusSummary)
+				if !ok {
+					return cli.TypeError
+				}
+				return strconv.Itoa(len(summary.Checks))
+			},
+		},
+		{
+			Title: "Checks",
+			CellTransformer: func(data interface{}) string {
+				summary, ok := data.(eventStatusSummary)
+				if !ok {
+					return cli.TypeError
+				}
+				return strings.Join(summary.Checks, ", ")
+			},
+		},
+	})
+
+	table.Render(writer, summary)
+}
@@ -56,6 +56,8 @@ func TestInfoCommandRunEClosureWithTable(t *testing.T) {
 	cli := test.NewCLI()
 	client := cli.Client.(*client.MockClient)
 	client.On("FetchEntity", "in").Return(types.FixtureEntity("name-one"), nil)
+	client.On("FetchEntityKeepalive", "in").Return(&types.KeepaliveRecord{}, nil)
+	client.On("FetchEntityEvents", "in").Return([]types.Event{}, nil)
 
 	cmd := InfoCommand(cli)
 	require.NoError(t, cmd.Flags().Set("format", "tabular"))
@@ -65,9 +67,52 @@ func TestInfoCommandRunEClosureWithTable(t *testing.T) {
 	assert.NotEmpty(out)
 	assert.Contains(out, "Host")
 	assert.Contains(out, "OS")
+	assert.Contains(out, "Keepalive")
 	assert.Nil(err)
 }
 
+func TestInfoCommandRunEClosureWithTableEvents(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("FetchEntity", "in").Return(types.FixtureEntity("name-one"), nil)
+	client.On("FetchEntityKeepalive", "in").Return(types.NewKeepaliveRecord(types.FixtureEntity("name-one"), 1), nil)
+	keepalive := types.FixtureEvent("name-one", "keepalive")
+	keepalive.Check.Status = 2
+	disk := types.FixtureEvent("name-one", "disk")
+	disk.Check.Status = 2
+	cpu := types.FixtureEvent("name-one", "cpu")
+	client.On("FetchEntityEvents", "in").Return([]types.Event{*keepalive, *disk, *cpu}, nil)
+
+	cmd := InfoCommand(cli)
+	require.NoError(t, cmd.Flags().Set("format", "tabular"))
+
+	out, err := test.RunCmd(cmd, []string{"in"})
+
+	assert.Nil(err)
+	assert.Contains(out, "Failing")
+	assert.Contains(out, "(status 2)")
+	assert.Contains(out, "0\t1\tcpu")
+	assert.Contains(out, "2\t2\tdisk, keepalive")
+}
+
+func TestInfoCommandRunEClosureWithTableErr(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("FetchEntity", "in").Return(types.FixtureEntity("name-one"), nil)
+	client.On("FetchEntityKeepalive", "in").Return((*types.KeepaliveRecord)(nil), errors.New("my-err"))
+
+	cmd := InfoCommand(cli)
+	require.NoError(t, cmd.Flags().Set("format", "tabular"))
+
+	_, err := test.RunCmd(cmd, []string{"in"})
+
+	assert.Error(err)
+}
+
 func TestInfoCommandRunEClosureWithErr(t *testing.T) {
 	assert := assert.New(t)
 
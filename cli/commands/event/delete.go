@@ -16,6 +16,10 @@ func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 		Short:        "delete events",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if selector, _ := cmd.Flags().GetString("selector"); selector != "" {
+				return deleteSelected(cmd, cli, args, selector)
+			}
+
 			if len(args) != 2 {
 				_ = cmd.Help()
 				return errors.New("invalid argument(s) received")
@@ -44,6 +48,32 @@ func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 	}
 
 	_ = cmd.Flags().Bool("skip-confirm", false, "skip interactive confirmation prompt")
+	_ = cmd.Flags().String("selector", "", "delete all the events of the namespace selected by this field selector instead, e.g. event.check.name==disk")
 
 	return cmd
 }
+
+// deleteSelected deletes the events of the namespace selected by the field
+// selector in a single request
+func deleteSelected(cmd *cobra.Command, cli *cli.SensuCli, args []string, selector string) error {
+	if len(args) != 0 {
+		_ = cmd.Help()
+		return errors.New("no argument can be given with --selector")
+	}
+
+	if skipConfirm, _ := cmd.Flags().GetBool("skip-confirm"); !skipConfirm {
+		confirm := &helpers.ConfirmDestructiveOp{Type: "the events selected by", Op: "delete"}
+		if confirmed, _ := confirm.Ask(selector); !confirmed {
+			fmt.Fprintln(cmd.OutOrStdout(), "Canceled")
+			return nil
+		}
+	}
+
+	response, err := cli.Client.DeleteEvents(cli.Config.Namespace(), selector)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(cmd.OutOrStdout(), "Deleted %d event(s)\n", len(response.Events))
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	client "github.com/sensu/sensu-go/cli/client/testing"
 	test "github.com/sensu/sensu-go/cli/commands/testing"
 	"github.com/stretchr/testify/assert"
@@ -71,3 +72,21 @@ func TestDeleteCommandRunEFailConfirm(t *testing.T) {
 	assert.Contains(out, "Canceled")
 	assert.NoError(err)
 }
+
+func TestDeleteCommandSelector(t *testing.T) {
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).
+		On("DeleteEvents", "default", "event.check.name==disk").
+		Return(&corev2.EventSelectionResponse{Events: []string{"foo/disk", "bar/disk"}}, nil)
+
+	cmd := DeleteCommand(cli)
+	require.NoError(t, cmd.Flags().Set("skip-confirm", "t"))
+	require.NoError(t, cmd.Flags().Set("selector", "event.check.name==disk"))
+	out, err := test.RunCmd(cmd, []string{})
+
+	require.NoError(t, err)
+	assert.Contains(t, out, "Deleted 2 event(s)")
+
+	_, err = test.RunCmd(cmd, []string{"foo", "disk"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,163 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/elements/globals"
+	"github.com/sensu/sensu-go/cli/elements/table"
+	"github.com/spf13/cobra"
+)
+
+// DryRunCommand reports how an event would go through the pipeline
+func DryRunCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "dry-run",
+		Short:        "show which filters, mutators and handlers would process an event from file or stdin, without executing the handlers",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			eventPath, _ := cmd.Flags().GetString("file")
+			var in *os.File
+			var err error
+
+			if len(eventPath) > 0 {
+				in, err = os.Open(eventPath)
+				if err != nil {
+					return err
+				}
+
+				defer func() { _ = in.Close() }()
+			} else {
+				in = os.Stdin
+			}
+
+			event := &corev2.Event{}
+			if err := json.NewDecoder(in).Decode(event); err != nil {
+				return err
+			}
+
+			result, err := cli.Client.DryRunEvent(event)
+			if err != nil {
+				return err
+			}
+
+			// Determine the format to use to output the data
+			flag := helpers.GetChangedStringValueFlag("format", cmd.Flags())
+			format := cli.Config.Format()
+			return helpers.PrintFormatted(flag, format, result, cmd.OutOrStdout(), printDryRunToTable)
+		},
+	}
+
+	cmd.Flags().StringP("file", "f", "", "event definition file")
+	helpers.AddFormatFlag(cmd.Flags())
+
+	return cmd
+}
+
+func printDryRunToTable(v interface{}, writer io.Writer) error {
+	result, ok := v.(*corev2.PipelineDryRun)
+	if !ok {
+		return fmt.Errorf("%t is not a pipeline dry run", v)
+	}
+	if len(result.Handlers) == 0 {
+		_, err := fmt.Fprintln(writer, "No handlers would process the event")
+		return err
+	}
+	if result.Muted {
+		if _, err := fmt.Fprintln(writer, "The namespace of the event is muted, no handlers would run"); err != nil {
+			return err
+		}
+	}
+
+	handlers := make([]corev2.PipelineDryRunHandler, len(result.Handlers))
+	for i, handler := range result.Handlers {
+		handlers[i] = *handler
+	}
+
+	table := table.New([]*table.Column{
+		{
+			Title:       "Handler",
+			ColumnStyle: table.PrimaryTextStyle,
+			CellTransformer: func(data interface{}) string {
+				handler, ok := data.(corev2.PipelineDryRunHandler)
+				if !ok {
+					return cli.TypeError
+				}
+				return handler.Handler
+			},
+		},
+		{
+			Title: "Type",
+			CellTransformer: func(data interface{}) string {
+				handler, ok := data.(corev2.PipelineDryRunHandler)
+				if !ok {
+					return cli.TypeError
+				}
+				return handler.Type
+			},
+		},
+		{
+			Title: "Filters",
+			CellTransformer: func(data interface{}) string {
+				handler, ok := data.(corev2.PipelineDryRunHandler)
+				if !ok {
+					return cli.TypeError
+				}
+				filters := make([]string, len(handler.Filters))
+				for i, filter := range handler.Filters {
+					verdict := "reject"
+					if filter.Passed {
+						verdict = "pass"
+					}
+					filters[i] = fmt.Sprintf("%s (%s)", filter.Name, verdict)
+				}
+				return strings.Join(filters, ", ")
+			},
+		},
+		{
+			Title: "Mutator",
+			CellTransformer: func(data interface{}) string {
+				handler, ok := data.(corev2.PipelineDryRunHandler)
+				if !ok {
+					return cli.TypeError
+				}
+				return handler.Mutator
+			},
+		},
+		{
+			Title: "Would Run",
+			CellTransformer: func(data interface{}) string {
+				handler, ok := data.(corev2.PipelineDryRunHandler)
+				if !ok {
+					return cli.TypeError
+				}
+				return globals.BooleanStyleP(handler.WouldRun)
+			},
+		},
+		{
+			Title: "Error",
+			CellTransformer: func(data interface{}) string {
+				handler, ok := data.(corev2.PipelineDryRunHandler)
+				if !ok {
+					return cli.TypeError
+				}
+				return handler.Error
+			},
+		},
+	})
+
+	table.Render(writer, handlers)
+	return nil
+}
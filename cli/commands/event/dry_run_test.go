@@ -0,0 +1,81 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func writeEventFile(t *testing.T) string {
+	t.Helper()
+	f, err := ioutil.TempFile("", "event")
+	require.NoError(t, err)
+	defer f.Close()
+	require.NoError(t, json.NewEncoder(f).Encode(corev2.FixtureEvent("foo", "check_foo")))
+	return f.Name()
+}
+
+func TestDryRunCommand(t *testing.T) {
+	path := writeEventFile(t)
+	defer os.Remove(path)
+
+	result := &corev2.PipelineDryRun{
+		Handlers: []*corev2.PipelineDryRunHandler{
+			{
+				Handler:  "slack",
+				Type:     corev2.HandlerSlackType,
+				Filters:  []*corev2.PipelineDryRunFilter{{Name: "is_incident", Passed: true}},
+				WouldRun: true,
+			},
+		},
+	}
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).
+		On("DryRunEvent", mock.AnythingOfType("*v2.Event")).
+		Return(result, nil)
+	cli.Config.(*client.MockConfig).On("Format").Return("tabular")
+
+	cmd := DryRunCommand(cli)
+	require.NoError(t, cmd.Flags().Set("file", path))
+
+	out, err := test.RunCmd(cmd, []string{})
+	require.NoError(t, err)
+	assert.Contains(t, out, "slack")
+	assert.Contains(t, out, "is_incident (pass)")
+}
+
+func TestDryRunCommandWithErr(t *testing.T) {
+	path := writeEventFile(t)
+	defer os.Remove(path)
+
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).
+		On("DryRunEvent", mock.AnythingOfType("*v2.Event")).
+		Return((*corev2.PipelineDryRun)(nil), fmt.Errorf("error"))
+	cli.Config.(*client.MockConfig).On("Format").Return("json")
+
+	cmd := DryRunCommand(cli)
+	require.NoError(t, cmd.Flags().Set("file", path))
+
+	out, err := test.RunCmd(cmd, []string{})
+	assert.Error(t, err)
+	assert.Empty(t, out)
+}
+
+func TestDryRunCommandMissingFile(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := DryRunCommand(cli)
+	require.NoError(t, cmd.Flags().Set("file", "/nonexistent/event.json"))
+
+	_, err := test.RunCmd(cmd, []string{})
+	assert.Error(t, err)
+}
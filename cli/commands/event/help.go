@@ -17,6 +17,9 @@ func HelpCommand(cli *cli.SensuCli) *cobra.Command {
 	cmd.AddCommand(InfoCommand(cli))
 	cmd.AddCommand(DeleteCommand(cli))
 	cmd.AddCommand(ResolveCommand(cli))
+	cmd.AddCommand(DryRunCommand(cli))
+	cmd.AddCommand(TailCommand(cli))
+	cmd.AddCommand(ReportCommand(cli))
 
 	return cmd
 }
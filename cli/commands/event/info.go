@@ -9,13 +9,18 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/commands/timeutil"
 	"github.com/sensu/sensu-go/cli/elements/list"
+	"github.com/sensu/sensu-go/cli/elements/table"
 	"github.com/sensu/sensu-go/types"
 	"github.com/spf13/cobra"
 )
 
+const flagHandled = "handled"
+
 // InfoCommand defines new event info command
 func InfoCommand(cli *cli.SensuCli) *cobra.Command {
 	cmd := &cobra.Command{
@@ -28,22 +33,38 @@ func InfoCommand(cli *cli.SensuCli) *cobra.Command {
 				return errors.New("invalid argument(s) received")
 			}
 
-			// Fetch event from API
 			entity := args[0]
 			check := args[1]
+
+			// Determine the format to use to output the data
+			flag := helpers.GetChangedStringValueFlag("format", cmd.Flags())
+			format := cli.Config.Format()
+
+			handled, err := cmd.Flags().GetBool(flagHandled)
+			if err != nil {
+				return err
+			}
+			if handled {
+				// Fetch the results of the event handlers from API
+				results, err := cli.Client.FetchEventHandlerResults(entity, check)
+				if err != nil {
+					return err
+				}
+				return helpers.PrintFormatted(flag, format, results, cmd.OutOrStdout(), printHandlerResultsToTable)
+			}
+
+			// Fetch event from API
 			event, err := cli.Client.FetchEvent(entity, check)
 			if err != nil {
 				return err
 			}
 
-			// Determine the format to use to output the data
-			flag := helpers.GetChangedStringValueFlag("format", cmd.Flags())
-			format := cli.Config.Format()
 			return helpers.PrintFormatted(flag, format, event, cmd.OutOrStdout(), printToList)
 		},
 	}
 
 	helpers.AddFormatFlag(cmd.Flags())
+	cmd.Flags().Bool(flagHandled, false, "show the results of the handlers executed for the event")
 
 	return cmd
 }
@@ -96,6 +117,19 @@ func printToList(v interface{}, writer io.Writer) error {
 		cfg.Rows = append(cfg.Rows, silencedBy)
 	}
 
+	if runbook := event.RunbookURL(); runbook != "" {
+		cfg.Rows = append(cfg.Rows, &list.Row{
+			Label: "Runbook",
+			Value: runbook,
+		})
+	}
+	for _, link := range event.Links() {
+		cfg.Rows = append(cfg.Rows, &list.Row{
+			Label: fmt.Sprintf("Link (%s)", link.Name),
+			Value: link.URL,
+		})
+	}
+
 	var uuidVal string
 	if id := event.GetUUID(); id != uuid.Nil {
 		// Only populate the uuid if it's nonzero
@@ -104,7 +138,7 @@ func printToList(v interface{}, writer io.Writer) error {
 	cfg.Rows = append(cfg.Rows, []*list.Row{
 		{
 			Label: "Timestamp",
-			Value: time.Unix(event.Timestamp, 0).String(),
+			Value: timeutil.HumanTimestamp(event.Timestamp),
 		},
 		{
 			Label: "UUID",
@@ -113,3 +147,78 @@ func printToList(v interface{}, writer io.Writer) error {
 
 	return list.Print(writer, cfg)
 }
+
+func printHandlerResultsToTable(v interface{}, writer io.Writer) error {
+	results, ok := v.([]corev2.HandlerResult)
+	if !ok {
+		return fmt.Errorf("%t is not a list of handler results", v)
+	}
+
+	table := table.New([]*table.Column{
+		{
+			Title:       "Handler",
+			ColumnStyle: table.PrimaryTextStyle,
+			CellTransformer: func(data interface{}) string {
+				result, ok := data.(corev2.HandlerResult)
+				if !ok {
+					return cli.TypeError
+				}
+				return result.Handler
+			},
+		},
+		{
+			Title: "Status",
+			CellTransformer: func(data interface{}) string {
+				result, ok := data.(corev2.HandlerResult)
+				if !ok {
+					return cli.TypeError
+				}
+				return strconv.Itoa(int(result.Status))
+			},
+		},
+		{
+			Title: "Duration",
+			CellTransformer: func(data interface{}) string {
+				result, ok := data.(corev2.HandlerResult)
+				if !ok {
+					return cli.TypeError
+				}
+				duration := time.Duration(result.Duration * float64(time.Second))
+				return duration.Round(time.Millisecond).String()
+			},
+		},
+		{
+			Title: "Output",
+			CellTransformer: func(data interface{}) string {
+				result, ok := data.(corev2.HandlerResult)
+				if !ok {
+					return cli.TypeError
+				}
+				return strings.TrimSuffix(result.Output, "\n")
+			},
+		},
+		{
+			Title: "Timestamp",
+			CellTransformer: func(data interface{}) string {
+				result, ok := data.(corev2.HandlerResult)
+				if !ok {
+					return cli.TypeError
+				}
+				return timeutil.HumanTimestamp(result.Timestamp)
+			},
+		},
+		{
+			Title: "Event UUID",
+			CellTransformer: func(data interface{}) string {
+				result, ok := data.(corev2.HandlerResult)
+				if !ok {
+					return cli.TypeError
+				}
+				return result.EventID
+			},
+		},
+	})
+
+	table.Render(writer, results)
+	return nil
+}
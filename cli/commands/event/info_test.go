@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	client "github.com/sensu/sensu-go/cli/client/testing"
 	test "github.com/sensu/sensu-go/cli/commands/testing"
 	"github.com/sensu/sensu-go/types"
@@ -64,6 +65,29 @@ func TestInfoCommandRunEClosureWithTable(t *testing.T) {
 	assert.Contains(t, out, "Check")
 }
 
+func TestInfoCommandRunEClosureWithLinks(t *testing.T) {
+	event := types.FixtureEvent("foo", "check_foo")
+	event.Check.Annotations = map[string]string{
+		corev2.RunbookURLAnnotation:               "https://wiki.example.com/runbooks/check_foo",
+		corev2.LinkAnnotationPrefix + "dashboard": "https://dashboard.example.com/foo",
+	}
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).
+		On("FetchEvent", "foo", "check_foo").
+		Return(event, nil)
+	cli.Config.(*client.MockConfig).On("Format").Return("tabular")
+
+	cmd := InfoCommand(cli)
+	require.NoError(t, cmd.Flags().Set("format", "tabular"))
+
+	out, err := test.RunCmd(cmd, []string{"foo", "check_foo"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Runbook")
+	assert.Contains(t, out, "https://wiki.example.com/runbooks/check_foo")
+	assert.Contains(t, out, "Link (dashboard)")
+	assert.Contains(t, out, "https://dashboard.example.com/foo")
+}
+
 func TestInfoCommandRunEClosureWithErr(t *testing.T) {
 	cli := test.NewMockCLI()
 	cli.Client.(*client.MockClient).
@@ -78,3 +102,34 @@ func TestInfoCommandRunEClosureWithErr(t *testing.T) {
 	assert.Equal(t, "error", err.Error())
 	assert.Empty(t, out)
 }
+
+func TestInfoCommandRunEClosureHandled(t *testing.T) {
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).
+		On("FetchEventHandlerResults", "foo", "check_foo").
+		Return([]types.HandlerResult{*types.FixtureHandlerResult("slack")}, nil)
+	cli.Config.(*client.MockConfig).On("Format").Return("tabular")
+
+	cmd := InfoCommand(cli)
+	require.NoError(t, cmd.Flags().Set("handled", "true"))
+
+	out, err := test.RunCmd(cmd, []string{"foo", "check_foo"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Handler")
+	assert.Contains(t, out, "slack")
+}
+
+func TestInfoCommandRunEClosureHandledWithErr(t *testing.T) {
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).
+		On("FetchEventHandlerResults", "foo", "check_foo").
+		Return([]types.HandlerResult(nil), fmt.Errorf("error"))
+	cli.Config.(*client.MockConfig).On("Format").Return("json")
+
+	cmd := InfoCommand(cli)
+	require.NoError(t, cmd.Flags().Set("handled", "true"))
+
+	out, err := test.RunCmd(cmd, []string{"foo", "check_foo"})
+	assert.Error(t, err)
+	assert.Empty(t, out)
+}
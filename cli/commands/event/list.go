@@ -2,6 +2,7 @@ package event
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strconv"
@@ -13,6 +14,7 @@ import (
 	"github.com/sensu/sensu-go/cli/client"
 	"github.com/sensu/sensu-go/cli/commands/flags"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/commands/timeutil"
 	"github.com/sensu/sensu-go/cli/elements/globals"
 	"github.com/sensu/sensu-go/cli/elements/table"
 
@@ -40,6 +42,17 @@ func ListCommand(cli *cli.SensuCli) *cobra.Command {
 				return err
 			}
 
+			// The events are sorted by the API, which returns them all at once
+			opts.Sort, _ = cmd.Flags().GetString("sort")
+			if opts.Sort != "" {
+				if _, ok := corev2.EventSorts[opts.Sort]; !ok {
+					return fmt.Errorf("invalid sort %q, must be one of %q or %q", opts.Sort, corev2.EventSortLastOK, corev2.EventSortDuration)
+				}
+				if opts.ChunkSize > 0 {
+					return errors.New("sorted events can't be fetched in chunks")
+				}
+			}
+
 			// Fetch events from API
 			var header http.Header
 			results := []corev2.Event{}
@@ -62,6 +75,7 @@ func ListCommand(cli *cli.SensuCli) *cobra.Command {
 	helpers.AddFieldSelectorFlag(cmd.Flags())
 	helpers.AddLabelSelectorFlag(cmd.Flags())
 	helpers.AddChunkSizeFlag(cmd.Flags())
+	cmd.Flags().String("sort", "", fmt.Sprintf("sort the events by %q (failing events first, then the oldest last OK time) or %q (slowest first)", corev2.EventSortLastOK, corev2.EventSortDuration))
 
 	return cmd
 }
@@ -126,8 +140,28 @@ func printToTable(results interface{}, writer io.Writer) {
 				if !ok {
 					return cli.TypeError
 				}
-				time := time.Unix(event.Timestamp, 0)
-				return time.String()
+				return timeutil.HumanTimestamp(event.Timestamp)
+			},
+		},
+		{
+			Title: "Last OK",
+			CellTransformer: func(data interface{}) string {
+				event, ok := data.(corev2.Event)
+				if !ok {
+					return cli.TypeError
+				}
+				return timeutil.HumanTimestamp(event.Check.LastOK)
+			},
+		},
+		{
+			Title: "Duration",
+			CellTransformer: func(data interface{}) string {
+				event, ok := data.(corev2.Event)
+				if !ok {
+					return cli.TypeError
+				}
+				duration := time.Duration(event.Check.Duration * float64(time.Second))
+				return duration.Round(time.Millisecond).String()
 			},
 		},
 		{
@@ -3,10 +3,12 @@ package event
 import (
 	"errors"
 	"net/http"
+	"strings"
 	"testing"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli"
+	sensuclient "github.com/sensu/sensu-go/cli/client"
 	client "github.com/sensu/sensu-go/cli/client/testing"
 	"github.com/sensu/sensu-go/cli/commands/flags"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
@@ -101,11 +103,59 @@ func TestListCommandRunEClosureWithTable(t *testing.T) {
 	assert.Contains(out, "Check")     // Heading
 	assert.Contains(out, "Output")    // Heading
 	assert.Contains(out, "Timestamp") // Heading
+	assert.Contains(out, "Last OK")   // Heading
+	assert.Contains(out, "Duration")  // Heading
 	assert.Contains(out, "something")
 	assert.Contains(out, "funny")
 	assert.Nil(err)
 }
 
+func TestListCommandRunEClosureWithSort(t *testing.T) {
+	cli := newConfiguredCLI()
+	client := cli.Client.(*client.MockClient)
+	resources := []corev2.Event{}
+	sorted := func(opts *sensuclient.ListOptions) bool {
+		return opts.Sort == corev2.EventSortDuration
+	}
+	client.On("List", mock.Anything, &resources, mock.MatchedBy(sorted), mock.Anything).Return(nil).Run(
+		func(args mock.Arguments) {
+			fast := corev2.FixtureEvent("1", "fast")
+			fast.Check.Duration = 0.1
+			slow := corev2.FixtureEvent("2", "slow")
+			slow.Check.Duration = 12.5
+			resources := args[1].(*[]corev2.Event)
+			*resources = []corev2.Event{*slow, *fast}
+		},
+	)
+
+	cmd := ListCommand(cli)
+	require.NoError(t, cmd.Flags().Set(flags.Format, "none"))
+	require.NoError(t, cmd.Flags().Set("sort", "duration"))
+	out, err := test.RunCmd(cmd, []string{})
+	require.NoError(t, err)
+
+	assert.Contains(t, out, "12.5s")
+	assert.Contains(t, out, "100ms")
+	assert.True(t, strings.Index(out, "slow") < strings.Index(out, "fast"))
+}
+
+func TestListCommandRunEClosureWithInvalidSort(t *testing.T) {
+	cli := newConfiguredCLI()
+	cmd := ListCommand(cli)
+	require.NoError(t, cmd.Flags().Set("sort", "name"))
+	_, err := test.RunCmd(cmd, []string{})
+	assert.Error(t, err)
+}
+
+func TestListCommandRunEClosureWithSortAndChunkSize(t *testing.T) {
+	cli := newConfiguredCLI()
+	cmd := ListCommand(cli)
+	require.NoError(t, cmd.Flags().Set("sort", "last_ok"))
+	require.NoError(t, cmd.Flags().Set("chunk-size", "100"))
+	_, err := test.RunCmd(cmd, []string{})
+	assert.Error(t, err)
+}
+
 func TestListCommandRunEClosureWithErr(t *testing.T) {
 	assert := assert.New(t)
 	cli := newConfiguredCLI()
@@ -132,6 +182,9 @@ func TestListFlags(t *testing.T) {
 
 	flag = cmd.Flag("format")
 	assert.NotNil(flag)
+
+	flag = cmd.Flag("sort")
+	assert.NotNil(flag)
 }
 
 func newConfiguredCLI() *cli.SensuCli {
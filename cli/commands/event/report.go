@@ -0,0 +1,134 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/elements/table"
+	"github.com/spf13/cobra"
+)
+
+// ReportCommand summarizes the check results of the events
+func ReportCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "report",
+		Short:        "summarize the check results of the events by check and by entity, to find the noisiest ones",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			since, _ := cmd.Flags().GetDuration("since")
+			if since <= 0 {
+				return errors.New("--since must be a positive duration")
+			}
+			limit, _ := cmd.Flags().GetInt("limit")
+
+			report, err := cli.Client.FetchEventReport(cli.Config.Namespace(), since)
+			if err != nil {
+				return err
+			}
+
+			// Determine the format to use to output the data
+			flag := helpers.GetChangedStringValueFlag("format", cmd.Flags())
+			format := cli.Config.Format()
+			return helpers.PrintFormatted(flag, format, report, cmd.OutOrStdout(), func(v interface{}, w io.Writer) error {
+				return printReportToTable(v, w, limit)
+			})
+		},
+	}
+
+	cmd.Flags().Duration("since", 24*time.Hour, "period covered by the report")
+	cmd.Flags().Int("limit", 10, "maximum number of checks and entities listed in each table, 0 lists all of them")
+	helpers.AddFormatFlag(cmd.Flags())
+
+	return cmd
+}
+
+func printReportToTable(v interface{}, writer io.Writer, limit int) error {
+	report, ok := v.(*corev2.EventReport)
+	if !ok {
+		return fmt.Errorf("%t is not an event report", v)
+	}
+
+	// Only list the checks that changed state among the flappiest ones
+	flappiest := []*corev2.EventReportEntry{}
+	for _, entry := range report.Checks {
+		if entry.StateChanges > 0 {
+			flappiest = append(flappiest, entry)
+		}
+	}
+	sort.SliceStable(flappiest, func(i, j int) bool {
+		return flappiest[i].StateChanges > flappiest[j].StateChanges
+	})
+
+	sections := []struct {
+		title   string
+		entries []*corev2.EventReportEntry
+	}{
+		{"Top offending checks", report.Checks},
+		{"Top offending entities", report.Entities},
+		{"Flappiest checks", flappiest},
+	}
+	for i, section := range sections {
+		if i > 0 {
+			fmt.Fprintln(writer)
+		}
+		fmt.Fprintf(writer, "%s since %s\n", section.title, time.Unix(report.Since, 0).Format(time.RFC3339))
+		entries := section.entries
+		if limit > 0 && len(entries) > limit {
+			entries = entries[:limit]
+		}
+		if len(entries) == 0 {
+			fmt.Fprintln(writer, "None")
+			continue
+		}
+		newReportTable().Render(writer, entries)
+	}
+	return nil
+}
+
+// newReportTable returns a table of the counts of the entries of an event
+// report.
+func newReportTable() *table.Table {
+	return table.New([]*table.Column{
+		{
+			Title:       "Name",
+			ColumnStyle: table.PrimaryTextStyle,
+			CellTransformer: func(data interface{}) string {
+				entry, ok := data.(*corev2.EventReportEntry)
+				if !ok {
+					return cli.TypeError
+				}
+				return entry.Name
+			},
+		},
+		reportCountColumn("Events", func(entry *corev2.EventReportEntry) int { return entry.Events }),
+		reportCountColumn("Results", func(entry *corev2.EventReportEntry) int { return entry.Results }),
+		reportCountColumn("Incidents", func(entry *corev2.EventReportEntry) int { return entry.Incidents }),
+		reportCountColumn("State Changes", func(entry *corev2.EventReportEntry) int { return entry.StateChanges }),
+		reportCountColumn("Flapping", func(entry *corev2.EventReportEntry) int { return entry.Flapping }),
+	})
+}
+
+func reportCountColumn(title string, count func(*corev2.EventReportEntry) int) *table.Column {
+	return &table.Column{
+		Title: title,
+		CellTransformer: func(data interface{}) string {
+			entry, ok := data.(*corev2.EventReportEntry)
+			if !ok {
+				return cli.TypeError
+			}
+			return strconv.Itoa(count(entry))
+		},
+	}
+}
@@ -0,0 +1,64 @@
+package event
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	"github.com/sensu/sensu-go/cli/commands/flags"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReportCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := newConfiguredCLI()
+	cmd := ReportCommand(cli)
+
+	assert.NotNil(cmd, "cmd should be returned")
+	assert.NotNil(cmd.RunE, "cmd should be able to be executed")
+	assert.Regexp("report", cmd.Use)
+	assert.Regexp("events", cmd.Short)
+}
+
+func TestReportCommandRunEClosure(t *testing.T) {
+	assert := assert.New(t)
+	cli := newConfiguredCLI()
+	client := cli.Client.(*client.MockClient)
+	report := &corev2.EventReport{
+		Since: time.Now().Add(-time.Hour).Unix(),
+		Checks: []*corev2.EventReportEntry{
+			{Name: "check-disk", Events: 2, Results: 10, Incidents: 8},
+			{Name: "check-cpu", Events: 1, Results: 5, Incidents: 2, StateChanges: 4},
+		},
+		Entities: []*corev2.EventReportEntry{
+			{Name: "web-1", Events: 3, Results: 15, Incidents: 10, StateChanges: 4},
+		},
+	}
+	client.On("FetchEventReport", "default", time.Hour).Return(report, nil)
+
+	cmd := ReportCommand(cli)
+	require.NoError(t, cmd.Flags().Set(flags.Format, "tabular"))
+	require.NoError(t, cmd.Flags().Set("since", "1h"))
+	out, err := test.RunCmd(cmd, []string{})
+
+	assert.NoError(err)
+	assert.Contains(out, "Top offending checks")
+	assert.Contains(out, "check-disk")
+	assert.Contains(out, "web-1")
+	assert.Contains(out, "Flappiest checks")
+}
+
+func TestReportCommandRunEClosureWithErr(t *testing.T) {
+	cli := newConfiguredCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("FetchEventReport", "default", 24*time.Hour).Return((*corev2.EventReport)(nil), errors.New("fire"))
+
+	cmd := ReportCommand(cli)
+	_, err := test.RunCmd(cmd, []string{})
+	assert.EqualError(t, err, "fire")
+}
@@ -15,6 +15,10 @@ func ResolveCommand(cli *cli.SensuCli) *cobra.Command {
 		Short:        "manually resolves an event",
 		SilenceUsage: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if selector, _ := cmd.Flags().GetString("selector"); selector != "" {
+				return resolveSelected(cmd, cli, args, selector)
+			}
+
 			if len(args) != 2 {
 				_ = cmd.Help()
 				return errors.New("invalid argument(s) received")
@@ -38,5 +42,24 @@ func ResolveCommand(cli *cli.SensuCli) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().String("selector", "", "resolve all the events of the namespace selected by this field selector instead, e.g. event.check.name==disk")
+
 	return cmd
 }
+
+// resolveSelected resolves the events of the namespace selected by the field
+// selector in a single request
+func resolveSelected(cmd *cobra.Command, cli *cli.SensuCli, args []string, selector string) error {
+	if len(args) != 0 {
+		_ = cmd.Help()
+		return errors.New("no argument can be given with --selector")
+	}
+
+	response, err := cli.Client.ResolveEvents(cli.Config.Namespace(), selector)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "Resolved %d event(s)\n", len(response.Events))
+	return nil
+}
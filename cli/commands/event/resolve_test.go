@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"testing"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	client "github.com/sensu/sensu-go/cli/client/testing"
 	test "github.com/sensu/sensu-go/cli/commands/testing"
 	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestResolveCommand(t *testing.T) {
@@ -63,3 +65,17 @@ func TestResolveCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveCommandSelector(t *testing.T) {
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).
+		On("ResolveEvents", "default", "event.check.status!=0").
+		Return(&corev2.EventSelectionResponse{Events: []string{"foo/disk"}}, nil)
+
+	cmd := ResolveCommand(cli)
+	require.NoError(t, cmd.Flags().Set("selector", "event.check.status!=0"))
+	out, err := test.RunCmd(cmd, []string{})
+
+	require.NoError(t, err)
+	assert.Contains(t, out, "Resolved 1 event(s)")
+}
@@ -0,0 +1,90 @@
+package event
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/commands/timeutil"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagEntity = "entity"
+	flagCheck  = "check"
+	flagStatus = "status"
+)
+
+// TailCommand defines new event tail command
+func TailCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "tail",
+		Short:        "print the events as they are processed, until interrupted",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			options := &client.EventWatchOptions{}
+			options.Entity, _ = cmd.Flags().GetString(flagEntity)
+			options.Check, _ = cmd.Flags().GetString(flagCheck)
+			if cmd.Flags().Changed(flagStatus) {
+				status, err := cmd.Flags().GetUint32(flagStatus)
+				if err != nil {
+					return err
+				}
+				options.Status = &status
+			}
+
+			// Determine the format to use to output the data
+			flag := helpers.GetChangedStringValueFlag("format", cmd.Flags())
+			format := cli.Config.Format()
+			print := func(event *corev2.Event) error {
+				return helpers.PrintFormatted(flag, format, event, cmd.OutOrStdout(), printTailLine)
+			}
+
+			// The backend ends each watch after a few seconds, so keep watching
+			// until interrupted
+			for {
+				if err := cli.Client.WatchEvents(cli.Config.Namespace(), options, print); err != nil {
+					return err
+				}
+			}
+		},
+	}
+
+	helpers.AddFormatFlag(cmd.Flags())
+	cmd.Flags().String(flagEntity, "", "only print the events of the entity")
+	cmd.Flags().String(flagCheck, "", "only print the events of the check")
+	cmd.Flags().Uint32(flagStatus, 0, "only print the events with the check status")
+
+	return cmd
+}
+
+// printTailLine prints an event on a single line, with the first line of its
+// check output.
+func printTailLine(v interface{}, w io.Writer) error {
+	event, ok := v.(*corev2.Event)
+	if !ok {
+		return fmt.Errorf("%t is not an Event", v)
+	}
+	entity, check, output := "", "", ""
+	var status uint32
+	if event.Entity != nil {
+		entity = event.Entity.Name
+	}
+	if event.HasCheck() {
+		check = event.Check.Name
+		status = event.Check.Status
+		output = strings.TrimSpace(strings.SplitN(event.Check.Output, "\n", 2)[0])
+	}
+	_, err := fmt.Fprintf(w, "%s  %s  %s  status %d  %s\n", timeutil.HumanTimestamp(event.Timestamp), entity, check, status, output)
+	return err
+}
@@ -0,0 +1,60 @@
+package event
+
+import (
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	sensuclient "github.com/sensu/sensu-go/cli/client"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	"github.com/sensu/sensu-go/cli/commands/flags"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTailCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := newConfiguredCLI()
+	cmd := TailCommand(cli)
+
+	assert.NotNil(cmd, "cmd should be returned")
+	assert.NotNil(cmd.RunE, "cmd should be able to be executed")
+	assert.Regexp("tail", cmd.Use)
+	assert.Regexp("events", cmd.Short)
+}
+
+func TestTailCommandRunEClosure(t *testing.T) {
+	assert := assert.New(t)
+	cli := newConfiguredCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("WatchEvents", "default", mock.Anything, mock.Anything).Return(errors.New("connection lost")).Run(
+		func(args mock.Arguments) {
+			options := args[1].(*sensuclient.EventWatchOptions)
+			assert.Equal("check-cpu", options.Check)
+			require.NotNil(t, options.Status)
+			assert.Equal(uint32(2), *options.Status)
+			fn := args[2].(func(*corev2.Event) error)
+			require.NoError(t, fn(corev2.FixtureEvent("web-1", "check-cpu")))
+		},
+	)
+
+	cmd := TailCommand(cli)
+	require.NoError(t, cmd.Flags().Set(flags.Format, "tabular"))
+	require.NoError(t, cmd.Flags().Set("check", "check-cpu"))
+	require.NoError(t, cmd.Flags().Set("status", "2"))
+	out, err := test.RunCmd(cmd, []string{})
+
+	assert.Contains(out, "web-1")
+	assert.Contains(out, "check-cpu")
+	assert.EqualError(err, "connection lost")
+}
+
+func TestTailCommandRunEClosureWithArgs(t *testing.T) {
+	cli := newConfiguredCLI()
+	cmd := TailCommand(cli)
+	_, err := test.RunCmd(cmd, []string{"foo"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,201 @@
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/elements/table"
+	"github.com/sensu/sensu-go/js"
+	"github.com/sensu/sensu-go/types/dynamic"
+	"github.com/spf13/cobra"
+)
+
+// EvalCommand evaluates a filter against an event
+func EvalCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "eval [NAME]",
+		Short:        "evaluate the expressions of a filter against an event from file or stdin",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			// Fetch the filter from API
+			filter, err := cli.Client.FetchFilter(args[0])
+			if err != nil {
+				return err
+			}
+
+			eventPath, _ := cmd.Flags().GetString("file")
+			var in *os.File
+
+			if len(eventPath) > 0 {
+				in, err = os.Open(eventPath)
+				if err != nil {
+					return err
+				}
+
+				defer func() { _ = in.Close() }()
+			} else {
+				in = os.Stdin
+			}
+
+			event := &corev2.Event{}
+			if err := json.NewDecoder(in).Decode(event); err != nil {
+				return err
+			}
+			if event.Entity == nil {
+				return errors.New("the event must contain an entity")
+			}
+
+			evaluation := evaluateFilter(filter, event, time.Now().UTC())
+			return printEvaluation(cmd.OutOrStdout(), filter, evaluation)
+		},
+	}
+
+	cmd.Flags().StringP("file", "f", "", "event definition file")
+
+	return cmd
+}
+
+// expressionResult is the result of a filter expression.
+type expressionResult struct {
+	Expression string
+	Match      bool
+	Err        error
+}
+
+// filterEvaluation is the result of the evaluation of a filter against an
+// event.
+type filterEvaluation struct {
+	// Filtered indicates if the filter rejects the event
+	Filtered bool
+	// Reason explains why the event is filtered or not
+	Reason string
+	// Expressions are the results of every expression of the filter
+	Expressions []expressionResult
+}
+
+// evaluateFilter evaluates the filter against the event like the backend does,
+// except that every expression is evaluated and the runtime assets of the
+// filter are not loaded.
+func evaluateFilter(filter *corev2.EventFilter, event *corev2.Event, now time.Time) filterEvaluation {
+	// The backend redacts the event, its entity and its check before
+	// evaluating filters
+	event = event.GetRedactedEvent()
+
+	synth := dynamic.Synthesize(event)
+	parameters := map[string]interface{}{"event": synth}
+
+	var evaluation filterEvaluation
+	for _, expression := range filter.Expressions {
+		match, err := js.Evaluate(expression, parameters, nil)
+		evaluation.Expressions = append(evaluation.Expressions, expressionResult{
+			Expression: expression,
+			Match:      match && err == nil,
+			Err:        err,
+		})
+	}
+
+	// The first expression that did not match determines the outcome
+	var unmatched *expressionResult
+	for i := range evaluation.Expressions {
+		if !evaluation.Expressions[i].Match {
+			unmatched = &evaluation.Expressions[i]
+			break
+		}
+	}
+
+	if filter.When != nil {
+		inWindows, err := filter.When.InWindows(now)
+		if err != nil {
+			evaluation.Reason = fmt.Sprintf("unable to determine if the current time is in the filter time windows: %s", err)
+			return evaluation
+		}
+		if !inWindows {
+			evaluation.Filtered = filter.Action == corev2.EventFilterActionAllow
+			evaluation.Reason = "the current time is outside of the filter time windows"
+			return evaluation
+		}
+	}
+
+	switch filter.Action {
+	case corev2.EventFilterActionAllow:
+		if unmatched != nil {
+			evaluation.Filtered = true
+			evaluation.Reason = unmatchedReason(unmatched)
+		} else {
+			evaluation.Reason = "all the expressions evaluated to true"
+		}
+	case corev2.EventFilterActionDeny:
+		if unmatched != nil {
+			evaluation.Reason = unmatchedReason(unmatched)
+		} else {
+			evaluation.Filtered = true
+			evaluation.Reason = "all the expressions evaluated to true"
+		}
+	default:
+		evaluation.Reason = fmt.Sprintf("unknown filter action %q", filter.Action)
+	}
+
+	return evaluation
+}
+
+func unmatchedReason(result *expressionResult) string {
+	if result.Err != nil {
+		return fmt.Sprintf("expression %q failed: %s", result.Expression, result.Err)
+	}
+	return fmt.Sprintf("expression %q evaluated to false", result.Expression)
+}
+
+func printEvaluation(w io.Writer, filter *corev2.EventFilter, evaluation filterEvaluation) error {
+	table := table.New([]*table.Column{
+		{
+			Title:       "Expression",
+			ColumnStyle: table.PrimaryTextStyle,
+			CellTransformer: func(data interface{}) string {
+				result, ok := data.(expressionResult)
+				if !ok {
+					return cli.TypeError
+				}
+				return result.Expression
+			},
+		},
+		{
+			Title: "Result",
+			CellTransformer: func(data interface{}) string {
+				result, ok := data.(expressionResult)
+				if !ok {
+					return cli.TypeError
+				}
+				if result.Err != nil {
+					return "error: " + result.Err.Error()
+				}
+				return fmt.Sprint(result.Match)
+			},
+		},
+	})
+	table.Render(w, evaluation.Expressions)
+
+	verdict := "allowed"
+	if evaluation.Filtered {
+		verdict = "rejected"
+	}
+	if _, err := fmt.Fprintf(w, "\nThe event would be %s by the %s filter %q: %s\n",
+		verdict, filter.Action, filter.Name, evaluation.Reason); err != nil {
+		return err
+	}
+	if len(filter.RuntimeAssets) > 0 {
+		_, err := fmt.Fprintln(w, "Warning: the runtime assets of the filter are not loaded, the expressions relying on them fail.")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package filter
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluateFilter(t *testing.T) {
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Status = 2
+	event.Check.Occurrences = 3
+
+	allow := corev2.FixtureEventFilter("allow")
+	allow.Expressions = []string{"event.check.status == 2", "event.check.occurrences == 1"}
+	evaluation := evaluateFilter(allow, event, time.Now())
+	assert.True(t, evaluation.Filtered)
+	assert.Contains(t, evaluation.Reason, "event.check.occurrences == 1")
+	require.Len(t, evaluation.Expressions, 2)
+	assert.True(t, evaluation.Expressions[0].Match)
+	assert.False(t, evaluation.Expressions[1].Match)
+
+	allow.Expressions = []string{"event.check.status == 2"}
+	evaluation = evaluateFilter(allow, event, time.Now())
+	assert.False(t, evaluation.Filtered)
+
+	deny := corev2.FixtureDenyEventFilter("deny")
+	deny.Expressions = []string{"event.check.status == 2"}
+	evaluation = evaluateFilter(deny, event, time.Now())
+	assert.True(t, evaluation.Filtered)
+
+	// Expressions that fail do not match
+	deny.Expressions = []string{"event.check.foo.bar == 2"}
+	evaluation = evaluateFilter(deny, event, time.Now())
+	assert.False(t, evaluation.Filtered)
+	assert.Error(t, evaluation.Expressions[0].Err)
+	assert.Contains(t, evaluation.Reason, "failed")
+
+	// The event, its entity and its check are redacted
+	event.Labels = map[string]string{"password": "hunter2"}
+	event.Check.Labels = map[string]string{"password": "hunter2"}
+	allow.Expressions = []string{"event.labels.password == 'hunter2'"}
+	evaluation = evaluateFilter(allow, event, time.Now())
+	assert.True(t, evaluation.Filtered)
+	allow.Expressions = []string{"event.check.labels.password == 'hunter2'"}
+	evaluation = evaluateFilter(allow, event, time.Now())
+	assert.True(t, evaluation.Filtered)
+}
+
+func TestEvalCommandRunEClosure(t *testing.T) {
+	f, err := ioutil.TempFile("", "event")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	event := corev2.FixtureEvent("entity1", "check1")
+	event.Check.Status = 1
+	require.NoError(t, json.NewEncoder(f).Encode(event))
+	require.NoError(t, f.Close())
+
+	filter := corev2.FixtureEventFilter("incidents")
+	filter.Expressions = []string{"event.check.status != 0"}
+
+	cli := test.NewCLI()
+	cli.Client.(*client.MockClient).On("FetchFilter", "incidents").Return(filter, nil)
+
+	cmd := EvalCommand(cli)
+	require.NoError(t, cmd.Flags().Set("file", f.Name()))
+	out, err := test.RunCmd(cmd, []string{"incidents"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "event.check.status != 0")
+	assert.Contains(t, out, "would be allowed")
+}
+
+func TestEvalCommandMissingArgs(t *testing.T) {
+	cli := test.NewCLI()
+	cmd := EvalCommand(cli)
+	_, err := test.RunCmd(cmd, []string{})
+	assert.Error(t, err)
+}
@@ -16,6 +16,7 @@ func HelpCommand(cli *cli.SensuCli) *cobra.Command {
 	cmd.AddCommand(
 		CreateCommand(cli),
 		DeleteCommand(cli),
+		EvalCommand(cli),
 		InfoCommand(cli),
 		ListCommand(cli),
 		UpdateCommand(cli),
@@ -64,11 +64,14 @@ func CreateCommand(cli *cli.SensuCli) *cobra.Command {
 	cmd.Flags().String("command", "", "command to be executed. The event data is passed to the process via STDIN")
 	cmd.Flags().String("env-vars", "", "comma separated list of key=value environment variables for the mutator command")
 	cmd.Flags().String("filters", "", "comma separated list of filters to use when filtering events for the handler")
+	cmd.Flags().String("subscriptions", "", "comma separated list of subscriptions, the handler only runs for the events of entities having one of them")
+	cmd.Flags().String("entity-selectors", "", "comma separated list of entity label requirements, e.g. team==payments, the handler only runs for the events of entities meeting all of them")
 	cmd.Flags().String("handlers", "", "comma separated list of handlers to call using the handler set")
 	cmd.Flags().StringP("mutator", "m", "", "Sensu event mutator (name) to use to mutate event data for the handler")
 	cmd.Flags().String("socket-host", "", "host of handler socket")
 	cmd.Flags().String("socket-port", "", "port of handler socket")
 	cmd.Flags().StringP("timeout", "i", "", "execution duration timeout in seconds (hard stop)")
+	cmd.Flags().String("max-concurrent", "", "maximum number of concurrent executions of the handler (0 for the backend default)")
 	cmd.Flags().StringP("type", "t", typeDefault, "type of handler (pipe, tcp, udp, or set)")
 	cmd.Flags().StringP("runtime-assets", "r", "", "comma separated list of assets this handler depends on")
 
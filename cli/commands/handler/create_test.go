@@ -2,10 +2,12 @@ package handler
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	client "github.com/sensu/sensu-go/cli/client/testing"
 	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/sensu/sensu-go/types"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -53,6 +55,39 @@ func TestCreateCommandRunEClosureWithFlags(t *testing.T) {
 	assert.Nil(err)
 }
 
+func TestCreateCommandRunEClosureWithEntitySelectors(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("CreateHandler", mock.MatchedBy(func(h *types.Handler) bool {
+		return strings.Join(h.Subscriptions, ",") == "linux,windows" &&
+			strings.Join(h.EntitySelectors, ",") == "team==payments,region!=eu"
+	})).Return(nil)
+
+	cmd := CreateCommand(cli)
+	require.NoError(t, cmd.Flags().Set("command", "notify"))
+	require.NoError(t, cmd.Flags().Set("subscriptions", "linux, windows"))
+	require.NoError(t, cmd.Flags().Set("entity-selectors", "team==payments,region!=eu"))
+	out, err := test.RunCmd(cmd, []string{"payments"})
+
+	assert.Regexp("Created", out)
+	assert.Nil(err)
+}
+
+func TestCreateCommandRunEClosureWithInvalidEntitySelectors(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewMockCLI()
+	cmd := CreateCommand(cli)
+	require.NoError(t, cmd.Flags().Set("command", "notify"))
+	require.NoError(t, cmd.Flags().Set("entity-selectors", "team"))
+	out, err := test.RunCmd(cmd, []string{"payments"})
+
+	require.Error(t, err)
+	assert.Regexp("Usage", out)
+}
+
 func TestCreateCommandRunEClosureWithAPIErr(t *testing.T) {
 	assert := assert.New(t)
 
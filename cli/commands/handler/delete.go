@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
 	"github.com/spf13/cobra"
 )
@@ -32,7 +33,12 @@ func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 				}
 			}
 
-			err := cli.Client.DeleteHandler(namespace, name)
+			var err error
+			if force, _ := cmd.Flags().GetBool("force"); force {
+				err = cli.Client.Delete(client.HandlersPath(namespace, name) + "?force=true")
+			} else {
+				err = cli.Client.DeleteHandler(namespace, name)
+			}
 			if err != nil {
 				return err
 			}
@@ -43,6 +49,7 @@ func DeleteCommand(cli *cli.SensuCli) *cobra.Command {
 	}
 
 	cmd.Flags().Bool("skip-confirm", false, "skip interactive confirmation prompt")
+	cmd.Flags().Bool("force", false, "delete the handler even if other resources reference it")
 
 	return cmd
 }
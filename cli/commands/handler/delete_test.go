@@ -76,3 +76,19 @@ func TestDeleteCommandRunEFailConfirm(t *testing.T) {
 	assert.Contains(out, "Canceled")
 	assert.NoError(err)
 }
+
+func TestDeleteCommandRunEClosureWithForce(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("Delete", "/api/core/v2/namespaces/default/handlers/test-handler?force=true").Return(nil)
+
+	cmd := DeleteCommand(cli)
+	require.NoError(t, cmd.Flags().Set("skip-confirm", "t"))
+	require.NoError(t, cmd.Flags().Set("force", "t"))
+	out, err := test.RunCmd(cmd, []string{"test-handler"})
+
+	assert.Regexp("Deleted", out)
+	assert.Nil(err)
+}
@@ -76,6 +76,12 @@ func printToList(v interface{}, writer io.Writer) error {
 			table.TitleStyle("CALL:"),
 			strings.Join(handler.Handlers, ","),
 		)
+	case types.HandlerSlackType, types.HandlerPagerDutyType, types.HandlerEmailType, types.HandlerWebhookType:
+		execute = fmt.Sprintf(
+			"%s %s",
+			table.TitleStyle("NOTIFY:"),
+			handler.Type,
+		)
 	default:
 		execute = "UNKNOWN"
 	}
@@ -95,10 +101,22 @@ func printToList(v interface{}, writer io.Writer) error {
 				Label: "Timeout",
 				Value: strconv.FormatInt(int64(handler.Timeout), 10),
 			},
+			{
+				Label: "Max Concurrent",
+				Value: strconv.FormatInt(int64(handler.MaxConcurrent), 10),
+			},
 			{
 				Label: "Filters",
 				Value: strings.Join(handler.Filters, ", "),
 			},
+			{
+				Label: "Subscriptions",
+				Value: strings.Join(handler.Subscriptions, ", "),
+			},
+			{
+				Label: "Entity Selectors",
+				Value: strings.Join(handler.EntitySelectors, ", "),
+			},
 			{
 				Label: "Mutator",
 				Value: handler.Mutator,
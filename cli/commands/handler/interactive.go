@@ -11,18 +11,21 @@ import (
 )
 
 type handlerOpts struct {
-	Name          string `survey:"name"`
-	Command       string `survey:"command"`
-	EnvVars       string `survey:"env-vars"`
-	Filters       string `survey:"filters"`
-	Handlers      string `survey:"handlers"`
-	Mutator       string `survey:"mutator"`
-	SocketHost    string `survey:"socketHost"`
-	SocketPort    string `survey:"socketPort"`
-	Timeout       string `survey:"timeout"`
-	Type          string `survey:"type"`
-	Namespace     string
-	RuntimeAssets string `survey:"assets"`
+	Name            string `survey:"name"`
+	Command         string `survey:"command"`
+	EnvVars         string `survey:"env-vars"`
+	Filters         string `survey:"filters"`
+	Subscriptions   string `survey:"subscriptions"`
+	EntitySelectors string `survey:"entity-selectors"`
+	Handlers        string `survey:"handlers"`
+	MaxConcurrent   string `survey:"max-concurrent"`
+	Mutator         string `survey:"mutator"`
+	SocketHost      string `survey:"socketHost"`
+	SocketPort      string `survey:"socketPort"`
+	Timeout         string `survey:"timeout"`
+	Type            string `survey:"type"`
+	Namespace       string
+	RuntimeAssets   string `survey:"assets"`
 }
 
 const (
@@ -42,9 +45,12 @@ func (opts *handlerOpts) withHandler(handler *types.Handler) {
 	opts.Command = handler.Command
 	opts.EnvVars = strings.Join(handler.EnvVars, ",")
 	opts.Filters = strings.Join(handler.Filters, ",")
+	opts.Subscriptions = strings.Join(handler.Subscriptions, ",")
+	opts.EntitySelectors = strings.Join(handler.EntitySelectors, ",")
 	opts.Handlers = strings.Join(handler.Handlers, ",")
 	opts.Mutator = handler.Mutator
 	opts.Timeout = strconv.FormatUint(uint64(handler.Timeout), 10)
+	opts.MaxConcurrent = strconv.FormatUint(uint64(handler.MaxConcurrent), 10)
 	opts.Type = handler.Type
 	opts.RuntimeAssets = strings.Join(handler.RuntimeAssets, ",")
 
@@ -58,11 +64,14 @@ func (opts *handlerOpts) withFlags(flags *pflag.FlagSet) {
 	opts.Command, _ = flags.GetString("command")
 	opts.EnvVars, _ = flags.GetString("env-vars")
 	opts.Filters, _ = flags.GetString("filters")
+	opts.Subscriptions, _ = flags.GetString("subscriptions")
+	opts.EntitySelectors, _ = flags.GetString("entity-selectors")
 	opts.Handlers, _ = flags.GetString("handlers")
 	opts.Mutator, _ = flags.GetString("mutator")
 	opts.SocketHost, _ = flags.GetString("socket-host")
 	opts.SocketPort, _ = flags.GetString("socket-port")
 	opts.Timeout, _ = flags.GetString("timeout")
+	opts.MaxConcurrent, _ = flags.GetString("max-concurrent")
 	opts.Type, _ = flags.GetString("type")
 	opts.RuntimeAssets, _ = flags.GetString("runtime-assets")
 
@@ -131,6 +140,22 @@ func (opts *handlerOpts) queryForBaseParameters(editing bool) error {
 				Help:    "comma separated list of filters to use when filtering events for the handler",
 			},
 		},
+		{
+			Name: "subscriptions",
+			Prompt: &survey.Input{
+				Message: "Subscriptions:",
+				Default: opts.Subscriptions,
+				Help:    "comma separated list of subscriptions, the handler only runs for the events of entities having one of them",
+			},
+		},
+		{
+			Name: "entity-selectors",
+			Prompt: &survey.Input{
+				Message: "Entity Selectors:",
+				Default: opts.EntitySelectors,
+				Help:    "comma separated list of entity label requirements (e.g. team==payments), the handler only runs for the events of entities meeting all of them",
+			},
+		},
 		{
 			Name: "mutator",
 			Prompt: &survey.Input{
@@ -145,11 +170,19 @@ func (opts *handlerOpts) queryForBaseParameters(editing bool) error {
 				Default: opts.Timeout,
 			},
 		},
+		{
+			Name: "max-concurrent",
+			Prompt: &survey.Input{
+				Message: "Max Concurrent Executions:",
+				Help:    "maximum number of concurrent executions of the handler (0 for the backend default)",
+				Default: opts.MaxConcurrent,
+			},
+		},
 		{
 			Name: "type",
 			Prompt: &survey.Select{
 				Message: "Type:",
-				Options: []string{"pipe", "tcp", "udp", "set"},
+				Options: []string{"pipe", "tcp", "udp", "set", "slack", "pagerduty", "email", "webhook"},
 				Default: opts.Type,
 			},
 			Validate: survey.Required,
@@ -237,6 +270,13 @@ func (opts *handlerOpts) Copy(handler *types.Handler) {
 		handler.Timeout = 0
 	}
 
+	if len(opts.MaxConcurrent) > 0 {
+		m, _ := strconv.ParseUint(opts.MaxConcurrent, 10, 32)
+		handler.MaxConcurrent = uint32(m)
+	} else {
+		handler.MaxConcurrent = 0
+	}
+
 	if len(opts.SocketHost) > 0 && len(opts.SocketPort) > 0 {
 		p, _ := strconv.ParseUint(opts.SocketPort, 10, 32)
 		handler.Socket = &types.HandlerSocket{
@@ -251,6 +291,18 @@ func (opts *handlerOpts) Copy(handler *types.Handler) {
 		handler.Filters[i] = strings.TrimSpace(f)
 	}
 
+	subscriptions := helpers.SafeSplitCSV(opts.Subscriptions)
+	handler.Subscriptions = make([]string, len(subscriptions))
+	for i, s := range subscriptions {
+		handler.Subscriptions[i] = strings.TrimSpace(s)
+	}
+
+	selectors := helpers.SafeSplitCSV(opts.EntitySelectors)
+	handler.EntitySelectors = make([]string, len(selectors))
+	for i, s := range selectors {
+		handler.EntitySelectors[i] = strings.TrimSpace(s)
+	}
+
 	handlers := helpers.SafeSplitCSV(opts.Handlers)
 	handler.Handlers = make([]string, len(handlers))
 	for i, h := range handlers {
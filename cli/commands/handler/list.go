@@ -148,6 +148,12 @@ func printToTable(results interface{}, writer io.Writer) {
 						table.TitleStyle("CALL:"),
 						strings.Join(handler.Handlers, ","),
 					)
+				case corev2.HandlerSlackType, corev2.HandlerPagerDutyType, corev2.HandlerEmailType, corev2.HandlerWebhookType:
+					return fmt.Sprintf(
+						"%s %s",
+						table.TitleStyle("NOTIFY:"),
+						handler.Type,
+					)
 				default:
 					return "UNKNOWN"
 				}
@@ -47,7 +47,7 @@ func ConfigurationPresent(cmd *cobra.Command, cli *cli.SensuCli) error {
 
 	if tokens == nil || tokens.Access == "" {
 		return fmt.Errorf(
-			"Unable to locate credentials. You can configure credentials by running \"%s configure\"",
+			"Unable to locate credentials. You can authenticate by running \"%s login\"",
 			os.Args[0],
 		)
 	}
@@ -0,0 +1,163 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client"
+	"github.com/sensu/sensu-go/cli/commands/flags"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/elements/table"
+	"github.com/spf13/cobra"
+)
+
+// resources are the types of the resources referencing, or referenced by,
+// other resources of their namespace.
+var resources = []corev2.Resource{
+	&corev2.CheckConfig{},
+	&corev2.CheckTemplate{},
+	&corev2.CompositeCheck{},
+	&corev2.Handler{},
+	&corev2.EventFilter{},
+	&corev2.Mutator{},
+	&corev2.HookConfig{},
+	&corev2.Asset{},
+}
+
+// danglingReference is a reference to a resource that doesn't exist.
+type danglingReference struct {
+	Namespace string                   `json:"namespace"`
+	Referrer  string                   `json:"referrer"`
+	Reference corev2.ResourceReference `json:"reference"`
+}
+
+// Command reports the dangling references between resources
+func Command(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "lint",
+		Short:        "report the references of checks, handlers, filters, mutators and hooks to resources that don't exist",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			namespace := cli.Config.Namespace()
+			if ok, _ := cmd.Flags().GetBool(flags.AllNamespaces); ok {
+				namespace = corev2.NamespaceTypeAll
+			}
+
+			dangling, err := findDanglingReferences(cli.Client, namespace)
+			if err != nil {
+				return err
+			}
+
+			// Determine the format to use to output the data
+			flag := helpers.GetChangedStringValueFlag("format", cmd.Flags())
+			format := cli.Config.Format()
+			if err := helpers.PrintFormatted(flag, format, dangling, cmd.OutOrStdout(), printToTable); err != nil {
+				return err
+			}
+			if len(dangling) > 0 {
+				return fmt.Errorf("found %d dangling reference(s)", len(dangling))
+			}
+			return nil
+		},
+	}
+
+	helpers.AddFormatFlag(cmd.Flags())
+	helpers.AddAllNamespace(cmd.Flags())
+
+	return cmd
+}
+
+// findDanglingReferences lists the resources of the namespace and returns
+// their references to resources that don't exist.
+func findDanglingReferences(c client.GenericClient, namespace string) ([]danglingReference, error) {
+	listed := []corev2.Resource{}
+	exists := map[string]bool{}
+	for _, typ := range resources {
+		req := reflect.New(reflect.TypeOf(typ).Elem()).Interface().(corev2.Resource)
+		req.SetNamespace(namespace)
+		objs := reflect.New(reflect.SliceOf(reflect.TypeOf(typ)))
+		if err := c.List(req.URIPath(), objs.Interface(), &client.ListOptions{}, nil); err != nil {
+			return nil, err
+		}
+		objs = objs.Elem()
+		for i := 0; i < objs.Len(); i++ {
+			resource := objs.Index(i).Interface().(corev2.Resource)
+			meta := resource.GetObjectMeta()
+			exists[meta.Namespace+"/"+resource.RBACName()+"/"+meta.Name] = true
+			listed = append(listed, resource)
+		}
+	}
+
+	dangling := []danglingReference{}
+	for _, resource := range listed {
+		meta := resource.GetObjectMeta()
+		for _, ref := range corev2.References(resource) {
+			if exists[meta.Namespace+"/"+ref.String()] {
+				continue
+			}
+			dangling = append(dangling, danglingReference{
+				Namespace: meta.Namespace,
+				Referrer:  corev2.ResourceReference{Type: resource.RBACName(), Name: meta.Name}.String(),
+				Reference: ref,
+			})
+		}
+	}
+	return dangling, nil
+}
+
+func printToTable(results interface{}, writer io.Writer) error {
+	dangling, ok := results.([]danglingReference)
+	if !ok {
+		return fmt.Errorf("%t is not a list of dangling references", results)
+	}
+	if len(dangling) == 0 {
+		_, err := fmt.Fprintln(writer, "No dangling references")
+		return err
+	}
+
+	table := table.New([]*table.Column{
+		{
+			Title:       "Namespace",
+			ColumnStyle: table.PrimaryTextStyle,
+			CellTransformer: func(data interface{}) string {
+				ref, ok := data.(danglingReference)
+				if !ok {
+					return cli.TypeError
+				}
+				return ref.Namespace
+			},
+		},
+		{
+			Title: "Resource",
+			CellTransformer: func(data interface{}) string {
+				ref, ok := data.(danglingReference)
+				if !ok {
+					return cli.TypeError
+				}
+				return ref.Referrer
+			},
+		},
+		{
+			Title: "Missing Reference",
+			CellTransformer: func(data interface{}) string {
+				ref, ok := data.(danglingReference)
+				if !ok {
+					return cli.TypeError
+				}
+				return ref.Reference.String()
+			},
+		},
+	})
+
+	table.Render(writer, dangling)
+	return nil
+}
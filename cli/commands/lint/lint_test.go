@@ -0,0 +1,56 @@
+package lint
+
+import (
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewMockCLI()
+	cmd := Command(cli)
+
+	assert.NotNil(cmd, "cmd should be returned")
+	assert.NotNil(cmd.RunE, "cmd should be able to be executed")
+	assert.Regexp("lint", cmd.Use)
+	assert.NotNil(cmd.Flag("all-namespaces"))
+}
+
+func TestCommandRunEClosure(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewMockCLI()
+	config := cli.Config.(*client.MockConfig)
+	config.On("Format").Return("tabular")
+	client := cli.Client.(*client.MockClient)
+
+	check := corev2.FixtureCheckConfig("check-cpu")
+	check.Handlers = []string{"slack", "pagerduty"}
+	slack := corev2.FixtureHandler("slack")
+	slack.Filters = []string{"is_incident", "business-hours"}
+	client.On("List", "/api/core/v2/namespaces/default/checks", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args[1].(*[]*corev2.CheckConfig) = []*corev2.CheckConfig{check}
+		}).
+		Return(nil)
+	client.On("List", "/api/core/v2/namespaces/default/handlers", mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args[1].(*[]*corev2.Handler) = []*corev2.Handler{slack}
+		}).
+		Return(nil)
+	client.On("List", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	cmd := Command(cli)
+	out, err := test.RunCmd(cmd, []string{})
+
+	assert.Contains(out, "handlers/pagerduty")
+	assert.Contains(out, "filters/business-hours")
+	assert.NotContains(out, "is_incident")
+	assert.EqualError(err, "found 2 dangling reference(s)")
+}
@@ -0,0 +1,135 @@
+package login
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AlecAivazis/survey"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/commands/hooks"
+	"github.com/spf13/cobra"
+)
+
+const (
+	flagURL           = "url"
+	flagUsername      = "username"
+	flagPasswordStdin = "password-stdin"
+)
+
+type loginAnswers struct {
+	Username string `survey:"username"`
+	Password string `survey:"password"`
+}
+
+// Command defines the login command, which authenticates sensuctl against the
+// configured Sensu backend
+func Command(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Authenticate sensuctl with the Sensu backend",
+		Long: `Authenticate sensuctl with the Sensu backend, prompting for the username and
+the password unless the password is read from the standard input with
+--password-stdin. The access tokens are stored in the keychain of the
+operating system where available, and in the cluster configuration file
+otherwise.`,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			flags := cmd.Flags()
+			url, _ := flags.GetString(flagURL)
+			if url == "" {
+				return errors.New("no API URL is defined, run \"sensuctl configure\" or use --url")
+			}
+			if flags.Changed(flagURL) {
+				if err := cli.Config.SaveAPIUrl(url); err != nil {
+					return fmt.Errorf("unable to write new configuration file with error: %s", err)
+				}
+			}
+
+			answers := &loginAnswers{}
+			answers.Username, _ = flags.GetString(flagUsername)
+			passwordStdin, _ := flags.GetBool(flagPasswordStdin)
+			if passwordStdin {
+				if answers.Username == "" {
+					return fmt.Errorf("--%s is required with --%s", flagUsername, flagPasswordStdin)
+				}
+				password, err := readPassword(cmd.InOrStdin())
+				if err != nil {
+					return err
+				}
+				answers.Password = password
+			} else if err := answers.administerQuestionnaire(); err != nil {
+				return err
+			}
+
+			if err := Authenticate(cli, url, answers.Username, answers.Password); err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Logged in as %s\n", answers.Username)
+			return nil
+		},
+		Annotations: map[string]string{
+			// The credentials are what this command configures
+			hooks.ConfigurationRequirement: hooks.ConfigurationNotRequired,
+		},
+	}
+
+	_ = cmd.Flags().String(flagURL, cli.Config.APIUrl(), "the sensu backend url")
+	_ = cmd.Flags().String(flagUsername, "", "username")
+	_ = cmd.Flags().Bool(flagPasswordStdin, false, "read the password from the standard input")
+
+	return cmd
+}
+
+// Authenticate obtains access tokens for the user from the backend at url and
+// saves them
+func Authenticate(cli *cli.SensuCli, url, username, password string) error {
+	tokens, err := cli.Client.CreateAccessToken(url, username, password)
+	if err != nil {
+		return fmt.Errorf("unable to authenticate with error: %s", err)
+	} else if tokens == nil {
+		return errors.New("bad username or password")
+	}
+
+	if err := cli.Config.SaveTokens(tokens); err != nil {
+		return fmt.Errorf("unable to save the access tokens with error: %s", err)
+	}
+	return nil
+}
+
+// readPassword reads the password from the first line of r
+func readPassword(r io.Reader) (string, error) {
+	password, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("unable to read the password from the standard input: %s", err)
+	}
+	password = strings.TrimRight(password, "\r\n")
+	if password == "" {
+		return "", errors.New("no password was read from the standard input")
+	}
+	return password, nil
+}
+
+func (answers *loginAnswers) administerQuestionnaire() error {
+	var qs []*survey.Question
+	if answers.Username == "" {
+		qs = append(qs, &survey.Question{
+			Name:     "username",
+			Prompt:   &survey.Input{Message: "Username:"},
+			Validate: survey.Required,
+		})
+	}
+	qs = append(qs, &survey.Question{
+		Name:   "password",
+		Prompt: &survey.Password{Message: "Password:"},
+	})
+
+	return survey.Ask(qs, answers)
+}
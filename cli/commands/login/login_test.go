@@ -0,0 +1,99 @@
+package login
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	clienttest "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/sensu/sensu-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginPasswordStdin(t *testing.T) {
+	cli := test.NewMockCLI()
+	config := cli.Config.(*clienttest.MockConfig)
+	config.On("APIUrl").Return("http://127.0.0.1:8080")
+	tokens := types.FixtureTokens("foo", "bar")
+	config.On("SaveTokens", tokens).Return(nil)
+	client := cli.Client.(*clienttest.MockClient)
+	client.On("CreateAccessToken", "http://127.0.0.1:8080", "admin", "P@ssw0rd!").Return(tokens, nil)
+
+	cmd := Command(cli)
+	cmd.SetIn(strings.NewReader("P@ssw0rd!\n"))
+	require.NoError(t, cmd.Flags().Set(flagUsername, "admin"))
+	require.NoError(t, cmd.Flags().Set(flagPasswordStdin, "true"))
+
+	out, err := test.RunCmd(cmd, []string{})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Logged in as admin")
+	config.AssertNotCalled(t, "SaveAPIUrl", mock.Anything)
+}
+
+func TestLoginURL(t *testing.T) {
+	cli := test.NewMockCLI()
+	config := cli.Config.(*clienttest.MockConfig)
+	config.On("APIUrl").Return("")
+	config.On("SaveAPIUrl", "https://sensu.example.com:8080").Return(nil)
+	config.On("SaveTokens", mock.Anything).Return(nil)
+	client := cli.Client.(*clienttest.MockClient)
+	client.On("CreateAccessToken", "https://sensu.example.com:8080", "admin", "secret").
+		Return(types.FixtureTokens("foo", "bar"), nil)
+
+	cmd := Command(cli)
+	cmd.SetIn(strings.NewReader("secret"))
+	require.NoError(t, cmd.Flags().Set(flagURL, "https://sensu.example.com:8080"))
+	require.NoError(t, cmd.Flags().Set(flagUsername, "admin"))
+	require.NoError(t, cmd.Flags().Set(flagPasswordStdin, "true"))
+
+	_, err := test.RunCmd(cmd, []string{})
+	require.NoError(t, err)
+	config.AssertCalled(t, "SaveAPIUrl", "https://sensu.example.com:8080")
+}
+
+func TestLoginErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		username string
+		stdin    string
+		tokens   *types.Tokens
+		err      error
+	}{
+		{name: "no url", username: "admin", stdin: "secret"},
+		{name: "no username", url: "http://127.0.0.1:8080", stdin: "secret"},
+		{name: "no password", url: "http://127.0.0.1:8080", username: "admin"},
+		{name: "bad password", url: "http://127.0.0.1:8080", username: "admin", stdin: "secret"},
+		{name: "authentication error", url: "http://127.0.0.1:8080", username: "admin", stdin: "secret", err: errors.New("error")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := test.NewMockCLI()
+			config := cli.Config.(*clienttest.MockConfig)
+			config.On("APIUrl").Return(tt.url)
+			client := cli.Client.(*clienttest.MockClient)
+			client.On("CreateAccessToken", tt.url, tt.username, tt.stdin).Return(tt.tokens, tt.err)
+
+			cmd := Command(cli)
+			cmd.SetIn(strings.NewReader(tt.stdin))
+			require.NoError(t, cmd.Flags().Set(flagUsername, tt.username))
+			require.NoError(t, cmd.Flags().Set(flagPasswordStdin, "true"))
+
+			_, err := test.RunCmd(cmd, []string{})
+			assert.Error(t, err)
+			config.AssertNotCalled(t, "SaveTokens", mock.Anything)
+		})
+	}
+}
+
+func TestReadPassword(t *testing.T) {
+	password, err := readPassword(strings.NewReader("secret\r\nignored\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "secret", password)
+
+	_, err = readPassword(strings.NewReader("\n"))
+	assert.Error(t, err)
+}
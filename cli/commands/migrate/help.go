@@ -0,0 +1,21 @@
+package migrate
+
+import (
+	"github.com/sensu/sensu-go/cli"
+	"github.com/spf13/cobra"
+)
+
+// HelpCommand defines new parent
+func HelpCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Convert the configuration of other monitoring tools into Sensu resources",
+	}
+
+	// Add sub-commands
+	cmd.AddCommand(
+		NagiosCommand(cli),
+	)
+
+	return cmd
+}
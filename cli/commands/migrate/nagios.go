@@ -0,0 +1,128 @@
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client/config"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/types"
+	"github.com/spf13/cobra"
+)
+
+var nagiosDescription = `sensuctl migrate nagios
+
+Convert the hosts and services of a Nagios configuration into Sensu proxy
+entities and checks, to be created with sensuctl create. Example:
+$ sensuctl migrate nagios --cfg /etc/nagios/ -f nagios.yml
+$ sensuctl create -f nagios.yml
+
+The conversion is best-effort:
+- hosts become proxy entities, with their address as the "address" label and
+  their hostgroups as subscriptions;
+- services become checks executed on behalf of their hosts by the agents of the
+  given subscription, with the $ARGn$ and $USERn$ macros of their command
+  expanded, and the $HOSTNAME$ and $HOSTADDRESS$ macros replaced by tokens;
+- the directives and objects that could not be converted are reported on
+  stderr.
+`
+
+// NagiosCommand converts a Nagios configuration into Sensu resources.
+func NagiosCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "nagios [--cfg PATH] [-f FILE]",
+		Short:        "convert the hosts and services of a Nagios configuration into entities and checks",
+		Long:         nagiosDescription,
+		SilenceUsage: true,
+		RunE:         executeNagios(cli),
+	}
+
+	format := cli.Config.Format()
+	if format != config.FormatWrappedJSON && format != config.FormatYAML {
+		format = config.FormatYAML
+	}
+	_ = cmd.Flags().StringP("format", "", format, fmt.Sprintf(`format of data returned ("%s"|"%s")`, config.FormatWrappedJSON, config.FormatYAML))
+	_ = cmd.Flags().StringP("file", "f", "", "file to write resources to")
+	_ = cmd.Flags().String("cfg", "/etc/nagios/", "Nagios configuration file or directory")
+	_ = cmd.Flags().String("subscription", "nagios", "subscription of the agents executing the checks")
+
+	return cmd
+}
+
+func executeNagios(cli *cli.SensuCli) func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			_ = cmd.Help()
+			return errors.New("invalid argument(s) received")
+		}
+
+		path, err := cmd.Flags().GetString("cfg")
+		if err != nil {
+			return err
+		}
+		subscription, err := cmd.Flags().GetString("subscription")
+		if err != nil {
+			return err
+		}
+
+		nagios, err := loadNagiosConfig(path)
+		if err != nil {
+			return fmt.Errorf("could not load the Nagios configuration: %s", err)
+		}
+		conversion, err := convertNagios(nagios, cli.Config.Namespace(), subscription)
+		if err != nil {
+			return fmt.Errorf("could not convert the Nagios configuration: %s", err)
+		}
+
+		var w io.Writer = cmd.OutOrStdout()
+		fp, err := cmd.Flags().GetString("file")
+		if err != nil {
+			return err
+		}
+		if fp != "" {
+			f, err := os.Create(fp)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+
+		resources := make([]types.Resource, 0, len(conversion.Entities)+len(conversion.Checks))
+		for _, entity := range conversion.Entities {
+			resources = append(resources, entity)
+		}
+		for _, check := range conversion.Checks {
+			resources = append(resources, check)
+		}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			return err
+		}
+		switch format {
+		case config.FormatWrappedJSON:
+			err = helpers.PrintWrappedJSONList(resources, w)
+		case config.FormatYAML:
+			err = helpers.PrintYAML(resources, w)
+		default:
+			err = fmt.Errorf("invalid output format: %s", format)
+		}
+		if err != nil {
+			return err
+		}
+
+		stderr := cmd.OutOrStderr()
+		_, _ = fmt.Fprintf(stderr, "Converted %d host(s) and %d service(s)\n", len(conversion.Entities), len(conversion.Checks))
+		if lines := conversion.Report.Lines(); len(lines) > 0 {
+			_, _ = fmt.Fprintln(stderr, "Not converted:")
+			for _, line := range lines {
+				_, _ = fmt.Fprintf(stderr, "- %s\n", line)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,308 @@
+package migrate
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// nagiosObject is an object defined in the Nagios configuration, e.g. a host
+// or a service.
+type nagiosObject struct {
+	Type       string
+	Directives map[string]string
+	File       string
+	Line       int
+}
+
+// Get returns the value of a directive of the object.
+func (o *nagiosObject) Get(directive string) string {
+	return o.Directives[directive]
+}
+
+// List returns the comma separated values of a directive of the object.
+func (o *nagiosObject) List(directive string) []string {
+	var values []string
+	for _, value := range strings.Split(o.Directives[directive], ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// Registered returns false if the object is only a template.
+func (o *nagiosObject) Registered() bool {
+	return o.Get("register") != "0"
+}
+
+func (o *nagiosObject) String() string {
+	return fmt.Sprintf("%s:%d", o.File, o.Line)
+}
+
+// nagiosConfig is the object configuration of Nagios, along with the main
+// configuration directives relevant to its conversion.
+type nagiosConfig struct {
+	Objects []*nagiosObject
+
+	// Macros are the $USERn$ macros of the resource files.
+	Macros map[string]string
+
+	// IntervalLength is the number of seconds per unit of the check
+	// intervals.
+	IntervalLength int
+
+	files map[string]bool
+}
+
+// loadNagiosConfig loads the Nagios configuration from a file or a directory.
+// The directories are walked for .cfg files, and the cfg_file, cfg_dir and
+// resource_file directives of the main configuration file are followed.
+func loadNagiosConfig(path string) (*nagiosConfig, error) {
+	config := &nagiosConfig{
+		Macros:         make(map[string]string),
+		IntervalLength: 60,
+		files:          make(map[string]bool),
+	}
+	if err := config.load(path); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (c *nagiosConfig) load(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return c.loadFile(path)
+	}
+
+	var files []string
+	err = filepath.Walk(path, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && filepath.Ext(path) == ".cfg" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		if err := c.loadFile(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *nagiosConfig) loadFile(path string) error {
+	path = filepath.Clean(path)
+	if c.files[path] {
+		return nil
+	}
+	c.files[path] = true
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	includes, err := c.parse(f, path)
+	if err != nil {
+		return err
+	}
+	for _, include := range includes {
+		if !filepath.IsAbs(include) {
+			include = filepath.Join(filepath.Dir(path), include)
+		}
+		if err := c.load(include); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parse parses the object definitions and the main configuration directives
+// of a file, and returns the paths of the files and directories it includes.
+func (c *nagiosConfig) parse(r io.Reader, path string) ([]string, error) {
+	var (
+		includes []string
+		object   *nagiosObject
+		lineno   int
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineno++
+		line := stripNagiosComment(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if object == nil {
+			if strings.HasPrefix(line, "define") {
+				definition := strings.TrimSpace(strings.TrimPrefix(line, "define"))
+				if !strings.HasSuffix(definition, "{") {
+					return nil, fmt.Errorf("%s:%d: expected { after object type", path, lineno)
+				}
+				object = &nagiosObject{
+					Type:       strings.TrimSpace(strings.TrimSuffix(definition, "{")),
+					Directives: make(map[string]string),
+					File:       path,
+					Line:       lineno,
+				}
+				continue
+			}
+
+			// Main configuration and resource file directives
+			kv := strings.SplitN(line, "=", 2)
+			if len(kv) != 2 {
+				return nil, fmt.Errorf("%s:%d: unexpected %q", path, lineno, line)
+			}
+			key, value := strings.TrimSpace(kv[0]), strings.TrimSpace(kv[1])
+			switch {
+			case key == "cfg_file", key == "cfg_dir", key == "resource_file":
+				includes = append(includes, value)
+			case key == "interval_length":
+				var length int
+				if _, err := fmt.Sscanf(value, "%d", &length); err != nil || length <= 0 {
+					return nil, fmt.Errorf("%s:%d: invalid interval_length %q", path, lineno, value)
+				}
+				c.IntervalLength = length
+			case strings.HasPrefix(key, "$USER") && strings.HasSuffix(key, "$"):
+				c.Macros[key] = value
+			}
+			continue
+		}
+
+		end := strings.HasSuffix(line, "}")
+		line = strings.TrimSpace(strings.TrimSuffix(line, "}"))
+		if line != "" {
+			key, value := line, ""
+			if i := strings.IndexAny(line, " \t"); i > 0 {
+				key, value = line[:i], strings.TrimSpace(line[i:])
+			}
+			object.Directives[key] = value
+		}
+		if end {
+			c.Objects = append(c.Objects, object)
+			object = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if object != nil {
+		return nil, fmt.Errorf("%s:%d: unterminated %s definition", path, object.Line, object.Type)
+	}
+	return includes, nil
+}
+
+// stripNagiosComment strips the comments and the surrounding whitespace of a
+// line. Comments start with # or ; at the beginning of a line, and with an
+// unescaped ; anywhere else.
+func stripNagiosComment(line string) string {
+	line = strings.TrimSpace(line)
+	if strings.HasPrefix(line, "#") {
+		return ""
+	}
+	for i := 0; i < len(line); i++ {
+		if line[i] == ';' && (i == 0 || line[i-1] != '\\') {
+			line = line[:i]
+			break
+		}
+	}
+	return strings.TrimSpace(strings.Replace(line, `\;`, ";", -1))
+}
+
+// resolve applies the templates referenced by the use directive of the
+// objects, and returns the registered objects of each type. The directives of
+// an object take precedence over the ones of its templates, and the templates
+// listed first take precedence over the following ones. The values starting
+// with + are appended to the inherited ones, and null values cancel them.
+func (c *nagiosConfig) resolve() (map[string][]*nagiosObject, error) {
+	templates := make(map[string]*nagiosObject)
+	for _, object := range c.Objects {
+		if name := object.Get("name"); name != "" {
+			templates[object.Type+"/"+name] = object
+		}
+	}
+
+	resolved := make(map[*nagiosObject]map[string]string)
+	var inherit func(object *nagiosObject, seen map[*nagiosObject]bool) (map[string]string, error)
+	inherit = func(object *nagiosObject, seen map[*nagiosObject]bool) (map[string]string, error) {
+		if directives, ok := resolved[object]; ok {
+			return directives, nil
+		}
+		if seen[object] {
+			return nil, fmt.Errorf("%s: circular template inheritance", object)
+		}
+		seen[object] = true
+
+		directives := make(map[string]string)
+		uses := object.List("use")
+		for i := len(uses) - 1; i >= 0; i-- {
+			template, ok := templates[object.Type+"/"+uses[i]]
+			if !ok {
+				return nil, fmt.Errorf("%s: %s template %q not found", object, object.Type, uses[i])
+			}
+			inherited, err := inherit(template, seen)
+			if err != nil {
+				return nil, err
+			}
+			for key, value := range inherited {
+				directives[key] = value
+			}
+		}
+		for key, value := range object.Directives {
+			switch {
+			case value == "null":
+				// null cancels the inherited value
+				delete(directives, key)
+			case strings.HasPrefix(value, "+") && directives[key] != "":
+				// Additive inheritance
+				directives[key] += "," + value[1:]
+			default:
+				directives[key] = strings.TrimPrefix(value, "+")
+			}
+		}
+		// Neither the name nor the registration of templates are inherited
+		if object.Get("name") == "" {
+			delete(directives, "name")
+		}
+		directives["register"] = object.Get("register")
+		delete(directives, "use")
+
+		resolved[object] = directives
+		return directives, nil
+	}
+
+	objects := make(map[string][]*nagiosObject)
+	for _, object := range c.Objects {
+		if !object.Registered() {
+			continue
+		}
+		directives, err := inherit(object, make(map[*nagiosObject]bool))
+		if err != nil {
+			return nil, err
+		}
+		objects[object.Type] = append(objects[object.Type], &nagiosObject{
+			Type:       object.Type,
+			Directives: directives,
+			File:       object.File,
+			Line:       object.Line,
+		})
+	}
+	return objects, nil
+}
@@ -0,0 +1,182 @@
+package migrate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeNagiosConfig writes the given files into a temporary directory and
+// returns its path.
+func writeNagiosConfig(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "nagios")
+	require.NoError(t, err)
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+	return dir
+}
+
+func TestStripNagiosComment(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"", ""},
+		{"# comment", ""},
+		{"; comment", ""},
+		{"  host_name  web1  ", "host_name  web1"},
+		{"host_name web1 ; comment", "host_name web1"},
+		{`command_line echo a\;b ; comment`, "command_line echo a;b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			assert.Equal(t, tt.want, stripNagiosComment(tt.line))
+		})
+	}
+}
+
+func TestLoadNagiosConfig(t *testing.T) {
+	dir := writeNagiosConfig(t, map[string]string{
+		"nagios.cfg": `
+log_file=/var/log/nagios/nagios.log
+interval_length=30
+resource_file=private/resource.txt
+cfg_file=objects/hosts.cfg
+cfg_dir=conf.d
+`,
+		"private/resource.txt": "$USER1$=/usr/lib/nagios/plugins\n",
+		"objects/hosts.cfg": `
+define host {
+	host_name  web1 ; the first web server
+	address    10.0.0.1
+}
+`,
+		"conf.d/services.cfg": `
+define service{
+	host_name            web1
+	service_description  HTTP
+	check_command        check_http }
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	// The main configuration file includes all the others
+	config, err := loadNagiosConfig(filepath.Join(dir, "nagios.cfg"))
+	require.NoError(t, err)
+	assert.Equal(t, 30, config.IntervalLength)
+	assert.Equal(t, map[string]string{"$USER1$": "/usr/lib/nagios/plugins"}, config.Macros)
+	require.Len(t, config.Objects, 2)
+	assert.Equal(t, "host", config.Objects[0].Type)
+	assert.Equal(t, "10.0.0.1", config.Objects[0].Get("address"))
+	assert.Equal(t, 2, config.Objects[0].Line)
+	assert.Equal(t, "service", config.Objects[1].Type)
+	assert.Equal(t, "check_http", config.Objects[1].Get("check_command"))
+
+	// The files are not loaded twice when walking the directory
+	config, err = loadNagiosConfig(dir)
+	require.NoError(t, err)
+	assert.Len(t, config.Objects, 2)
+}
+
+func TestLoadNagiosConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"missing brace", "define host\n", "nagios.cfg:1: expected { after object type"},
+		{"unterminated definition", "define host {\nhost_name web1\n", "nagios.cfg:1: unterminated host definition"},
+		{"unexpected line", "host_name web1\n", `nagios.cfg:1: unexpected "host_name web1"`},
+		{"invalid interval length", "interval_length=0\n", `nagios.cfg:1: invalid interval_length "0"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeNagiosConfig(t, map[string]string{"nagios.cfg": tt.content})
+			defer os.RemoveAll(dir)
+
+			_, err := loadNagiosConfig(dir)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestNagiosConfigResolve(t *testing.T) {
+	dir := writeNagiosConfig(t, map[string]string{
+		"objects.cfg": `
+define service {
+	name                 generic-service
+	check_interval       5
+	max_check_attempts   3
+	servicegroups        base
+	register             0
+}
+
+define service {
+	name                 frequent-service
+	use                  generic-service
+	check_interval       1
+	register             0
+}
+
+define service {
+	use                  frequent-service,generic-service
+	host_name            web1
+	service_description  HTTP
+	servicegroups        +web
+	max_check_attempts   null
+}
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	config, err := loadNagiosConfig(dir)
+	require.NoError(t, err)
+	objects, err := config.resolve()
+	require.NoError(t, err)
+
+	// Templates are not registered
+	require.Len(t, objects["service"], 1)
+	service := objects["service"][0]
+	assert.Equal(t, map[string]string{
+		"host_name":           "web1",
+		"service_description": "HTTP",
+		"check_interval":      "1",
+		"servicegroups":       "base,web",
+		"register":            "",
+	}, service.Directives)
+}
+
+func TestNagiosConfigResolveErrors(t *testing.T) {
+	dir := writeNagiosConfig(t, map[string]string{
+		"objects.cfg": `
+define host {
+	name  a
+	use   b
+}
+
+define host {
+	name  b
+	use   a
+}
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	config, err := loadNagiosConfig(dir)
+	require.NoError(t, err)
+	_, err = config.resolve()
+	assert.Error(t, err)
+
+	config.Objects[1].Directives["use"] = "c"
+	_, err = config.resolve()
+	assert.EqualError(t, err, config.Objects[1].String()+`: host template "c" not found`)
+}
@@ -0,0 +1,403 @@
+package migrate
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+const (
+	// defaultNagiosCheckInterval is the check_interval of the services that
+	// don't define it, in units of interval_length.
+	defaultNagiosCheckInterval = 5
+
+	// maxReportedObjects is the number of objects listed per problem in the
+	// report of the directives that could not be converted.
+	maxReportedObjects = 5
+)
+
+var (
+	// nagiosMacro matches the macros of the command lines. The empty macro
+	// $$ is an escaped $.
+	nagiosMacro = regexp.MustCompile(`\$([A-Z0-9_]*)\$`)
+
+	// invalidNameChars matches the characters not allowed in resource names.
+	invalidNameChars = regexp.MustCompile(`[^\p{L}\p{M}\p{N}_\.\-\:]+`)
+
+	// nagiosAnnotations are the descriptive directives of hosts and services
+	// converted into annotations.
+	nagiosAnnotations = []string{"alias", "display_name", "notes", "notes_url", "action_url"}
+
+	// nagiosHostDirectives are the directives of hosts which are converted.
+	nagiosHostDirectives = []string{"name", "register", "host_name", "address", "hostgroups"}
+
+	// nagiosServiceDirectives are the directives of services which are
+	// converted.
+	nagiosServiceDirectives = []string{
+		"name", "register", "service_description", "host_name", "hostgroup_name",
+		"check_command", "check_interval", "normal_check_interval", "active_checks_enabled",
+	}
+
+	// nagiosConvertedTypes are the types of the objects which are converted,
+	// or used in the conversion of other objects.
+	nagiosConvertedTypes = []string{"command", "host", "hostgroup", "service"}
+)
+
+// nagiosConversion holds the Sensu resources converted from a Nagios
+// configuration, and the report of what could not be converted.
+type nagiosConversion struct {
+	Entities []*corev2.Entity
+	Checks   []*corev2.CheckConfig
+	Report   nagiosReport
+}
+
+// nagiosReport maps the problems encountered during the conversion to the
+// objects they concern.
+type nagiosReport map[string][]string
+
+func (r nagiosReport) add(object, format string, args ...interface{}) {
+	problem := fmt.Sprintf(format, args...)
+	r[problem] = append(r[problem], object)
+}
+
+// Lines returns the problems of the report, with the objects they concern.
+func (r nagiosReport) Lines() []string {
+	lines := make([]string, 0, len(r))
+	for problem, objects := range r {
+		line := problem
+		if len(objects) > maxReportedObjects {
+			line += fmt.Sprintf(": %s and %d more", strings.Join(objects[:maxReportedObjects], ", "), len(objects)-maxReportedObjects)
+		} else if len(objects) > 0 && objects[0] != "" {
+			line += ": " + strings.Join(objects, ", ")
+		}
+		lines = append(lines, line)
+	}
+	sort.Strings(lines)
+	return lines
+}
+
+// nagiosConverter converts the objects of a Nagios configuration.
+type nagiosConverter struct {
+	config       *nagiosConfig
+	namespace    string
+	subscription string
+	commands     map[string]string
+	hostgroups   map[string][]string
+	hosts        map[string]*corev2.Entity
+	checks       map[string]bool
+	result       *nagiosConversion
+}
+
+// convertNagios converts the hosts of a Nagios configuration into proxy
+// entities, and its services into checks executed on behalf of these
+// entities by the agents of the given subscription.
+func convertNagios(config *nagiosConfig, namespace, subscription string) (*nagiosConversion, error) {
+	objects, err := config.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	c := &nagiosConverter{
+		config:       config,
+		namespace:    namespace,
+		subscription: subscription,
+		commands:     make(map[string]string),
+		hostgroups:   make(map[string][]string),
+		hosts:        make(map[string]*corev2.Entity),
+		checks:       make(map[string]bool),
+		result:       &nagiosConversion{Report: make(nagiosReport)},
+	}
+
+	for _, command := range objects["command"] {
+		c.commands[command.Get("command_name")] = command.Get("command_line")
+	}
+	for _, hostgroup := range objects["hostgroup"] {
+		name := hostgroup.Get("hostgroup_name")
+		c.hostgroups[name] = append(c.hostgroups[name], hostgroup.List("members")...)
+		if hostgroup.Get("hostgroup_members") != "" {
+			c.result.Report.add(name, "hostgroup directive %q not converted", "hostgroup_members")
+		}
+	}
+	for _, host := range objects["host"] {
+		c.convertHost(host)
+	}
+	for _, service := range objects["service"] {
+		c.convertService(service)
+	}
+
+	var types []string
+	for typ := range objects {
+		if !contains(nagiosConvertedTypes, typ) {
+			types = append(types, typ)
+		}
+	}
+	sort.Strings(types)
+	for _, typ := range types {
+		c.result.Report.add("", "%d %s definition(s) not converted", len(objects[typ]), typ)
+	}
+
+	return c.result, nil
+}
+
+func (c *nagiosConverter) convertHost(host *nagiosObject) {
+	name := host.Get("host_name")
+	object := fmt.Sprintf("%s (%s)", name, host)
+	if err := corev2.ValidateName(name); err != nil {
+		c.result.Report.add(object, "host skipped, its name %s", err)
+		return
+	}
+
+	entity := corev2.NewEntity(corev2.ObjectMeta{
+		Name:        name,
+		Namespace:   c.namespace,
+		Labels:      make(map[string]string),
+		Annotations: annotations(host),
+	})
+	entity.EntityClass = corev2.EntityProxyClass
+
+	// The address is a label so that it can be used as a token by the
+	// commands of the checks
+	entity.Labels["address"] = host.Get("address")
+	if entity.Labels["address"] == "" {
+		entity.Labels["address"] = name
+	}
+
+	groups := host.List("hostgroups")
+	for group, members := range c.hostgroups {
+		if contains(members, name) || contains(members, "*") {
+			groups = append(groups, group)
+		}
+	}
+	for _, group := range groups {
+		subscription := sanitizeName(group)
+		if !contains(entity.Subscriptions, subscription) {
+			entity.Subscriptions = append(entity.Subscriptions, subscription)
+		}
+	}
+	sort.Strings(entity.Subscriptions)
+
+	if err := entity.Validate(); err != nil {
+		c.result.Report.add(object, "host skipped, %s", err)
+		return
+	}
+	c.reportDirectives(host, object, nagiosHostDirectives)
+	c.hosts[name] = entity
+	c.result.Entities = append(c.result.Entities, entity)
+}
+
+func (c *nagiosConverter) convertService(service *nagiosObject) {
+	description := service.Get("service_description")
+	object := fmt.Sprintf("%s (%s)", description, service)
+
+	hosts := c.serviceHosts(service)
+	if len(hosts) == 0 {
+		c.result.Report.add(object, "service skipped, it doesn't apply to any converted host")
+		return
+	}
+
+	args := strings.Split(service.Get("check_command"), "!")
+	line, ok := c.commands[args[0]]
+	if !ok {
+		c.result.Report.add(object, "service skipped, its check command %q is not defined", args[0])
+		return
+	}
+	command := c.expandMacros(line, args[1:], description, object)
+
+	name := sanitizeName(description)
+	if c.checks[name] {
+		name += "-" + sanitizeName(hosts[0])
+	}
+	if c.checks[name] {
+		c.result.Report.add(object, "service skipped, a check named %q was already converted", name)
+		return
+	}
+
+	check := corev2.NewCheckConfig(corev2.ObjectMeta{
+		Name:        name,
+		Namespace:   c.namespace,
+		Annotations: annotations(service),
+	})
+	check.Command = command
+	check.Subscriptions = []string{c.subscription}
+	check.Interval = c.serviceInterval(service, object)
+	check.Publish = service.Get("active_checks_enabled") != "0"
+	check.RoundRobin = true
+	if len(hosts) == 1 {
+		check.ProxyEntityName = hosts[0]
+	} else {
+		quoted := make([]string, len(hosts))
+		for i, host := range hosts {
+			quoted[i] = strconv.Quote(host)
+		}
+		check.ProxyRequests = &corev2.ProxyRequests{
+			EntityAttributes: []string{
+				fmt.Sprintf("[%s].indexOf(entity.name) >= 0", strings.Join(quoted, ", ")),
+			},
+		}
+	}
+
+	if err := check.Validate(); err != nil {
+		c.result.Report.add(object, "service skipped, %s", err)
+		return
+	}
+	c.reportDirectives(service, object, nagiosServiceDirectives)
+	c.checks[name] = true
+	c.result.Checks = append(c.result.Checks, check)
+}
+
+// serviceHosts returns the sorted names of the converted hosts the service
+// applies to, through its host_name and hostgroup_name directives. The *
+// wildcard and the ! exclusions are supported.
+func (c *nagiosConverter) serviceHosts(service *nagiosObject) []string {
+	included := make(map[string]bool)
+	excluded := make(map[string]bool)
+	add := func(name string) {
+		if strings.HasPrefix(name, "!") {
+			excluded[name[1:]] = true
+			return
+		}
+		if name == "*" {
+			for host := range c.hosts {
+				included[host] = true
+			}
+			return
+		}
+		included[name] = true
+	}
+	for _, name := range service.List("host_name") {
+		add(name)
+	}
+	// The hostgroups of the hosts are their subscriptions
+	for _, group := range service.List("hostgroup_name") {
+		members := included
+		if strings.HasPrefix(group, "!") {
+			members, group = excluded, group[1:]
+		}
+		for host, entity := range c.hosts {
+			if contains(entity.Subscriptions, sanitizeName(group)) {
+				members[host] = true
+			}
+		}
+	}
+
+	var hosts []string
+	for host := range included {
+		if _, ok := c.hosts[host]; ok && !excluded[host] {
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// serviceInterval returns the interval of the service in seconds.
+func (c *nagiosConverter) serviceInterval(service *nagiosObject, object string) uint32 {
+	value := service.Get("check_interval")
+	if value == "" {
+		value = service.Get("normal_check_interval")
+	}
+	interval := float64(defaultNagiosCheckInterval)
+	if value != "" {
+		var err error
+		if interval, err = strconv.ParseFloat(value, 64); err != nil || interval <= 0 {
+			c.result.Report.add(object, "invalid check interval %q replaced by the default one", value)
+			interval = defaultNagiosCheckInterval
+		}
+	}
+	return uint32(math.Max(1, math.Round(interval*float64(c.config.IntervalLength))))
+}
+
+// expandMacros expands the macros of the command line of a service. The
+// arguments and the $USERn$ macros are replaced by their values, and the
+// macros of the host by the tokens of the proxy entity. The other macros are
+// left as is and reported.
+func (c *nagiosConverter) expandMacros(line string, args []string, description, object string) string {
+	expand := func(s string, macros func(string) (string, bool)) string {
+		return nagiosMacro.ReplaceAllStringFunc(s, func(macro string) string {
+			name := strings.Trim(macro, "$")
+			if value, ok := macros(name); ok {
+				return value
+			}
+			return macro
+		})
+	}
+
+	// The arguments can contain macros themselves
+	line = expand(line, func(name string) (string, bool) {
+		if !strings.HasPrefix(name, "ARG") {
+			return "", false
+		}
+		n, err := strconv.Atoi(strings.TrimPrefix(name, "ARG"))
+		if err != nil || n < 1 {
+			return "", false
+		}
+		if n > len(args) {
+			return "", true
+		}
+		return args[n-1], true
+	})
+
+	return expand(line, func(name string) (string, bool) {
+		switch name {
+		case "":
+			return "$", true
+		case "HOSTNAME":
+			return "{{ .name }}", true
+		case "HOSTADDRESS":
+			return "{{ .labels.address }}", true
+		case "SERVICEDESC":
+			return description, true
+		}
+		if value, ok := c.config.Macros["$"+name+"$"]; ok {
+			return value, true
+		}
+		c.result.Report.add(object, "command macro $%s$ not converted", name)
+		return "", false
+	})
+}
+
+// reportDirectives reports the directives of the object that were not
+// converted.
+func (c *nagiosConverter) reportDirectives(o *nagiosObject, object string, converted []string) {
+	for directive := range o.Directives {
+		if contains(converted, directive) || contains(nagiosAnnotations, directive) {
+			continue
+		}
+		c.result.Report.add(object, "%s directive %q not converted", o.Type, directive)
+	}
+}
+
+// annotations returns the descriptive directives of the object as
+// annotations.
+func annotations(o *nagiosObject) map[string]string {
+	annotations := make(map[string]string)
+	for _, directive := range nagiosAnnotations {
+		if value := o.Get(directive); value != "" {
+			annotations[directive] = value
+		}
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+	return annotations
+}
+
+// sanitizeName returns the given Nagios name as a valid resource name.
+func sanitizeName(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.Trim(invalidNameChars.ReplaceAllString(name, "-"), "-")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
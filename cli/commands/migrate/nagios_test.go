@@ -0,0 +1,221 @@
+package migrate
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const nagiosObjects = `
+define command {
+	command_name  check_http
+	command_line  $USER1$/check_http -H $HOSTADDRESS$ -p $ARG1$ $ARG2$
+}
+
+define command {
+	command_name  check_ping
+	command_line  $USER1$/check_ping -H $HOSTNAME$ -w $ARG1$ -c $ARG2$ -t $TIMEOUT$
+}
+
+define hostgroup {
+	hostgroup_name  web servers
+	members         web1
+}
+
+define host {
+	name                generic-host
+	max_check_attempts  3
+	register            0
+}
+
+define host {
+	use        generic-host
+	host_name  web1
+	alias      First web server
+	address    10.0.0.1
+}
+
+define host {
+	use         generic-host
+	host_name   web2
+	hostgroups  web servers
+}
+
+define host {
+	host_name  db1
+}
+
+define service {
+	hostgroup_name       web servers
+	service_description  HTTP
+	check_command        check_http!8080
+	check_interval       2
+}
+
+define service {
+	host_name              *
+	hostgroup_name         !web servers
+	service_description    PING
+	check_command          check_ping!100.0,20%!500.0,60%
+	active_checks_enabled  0
+	notes                  Round trip average
+}
+
+define service {
+	host_name            db1
+	service_description  MySQL
+	check_command        check_mysql
+}
+
+define contact {
+	contact_name  admin
+}
+`
+
+func TestConvertNagios(t *testing.T) {
+	dir := writeNagiosConfig(t, map[string]string{
+		"nagios.cfg":  "$USER1$=/usr/lib/nagios/plugins\n",
+		"objects.cfg": nagiosObjects,
+	})
+	defer os.RemoveAll(dir)
+
+	config, err := loadNagiosConfig(dir)
+	require.NoError(t, err)
+	conversion, err := convertNagios(config, "default", "nagios")
+	require.NoError(t, err)
+
+	require.Len(t, conversion.Entities, 3)
+	web1 := conversion.Entities[0]
+	assert.Equal(t, "web1", web1.Name)
+	assert.Equal(t, "default", web1.Namespace)
+	assert.Equal(t, corev2.EntityProxyClass, web1.EntityClass)
+	assert.Equal(t, map[string]string{"address": "10.0.0.1"}, web1.Labels)
+	assert.Equal(t, map[string]string{"alias": "First web server"}, web1.Annotations)
+	assert.Equal(t, []string{"web-servers"}, web1.Subscriptions)
+	web2 := conversion.Entities[1]
+	assert.Equal(t, map[string]string{"address": "web2"}, web2.Labels)
+	assert.Equal(t, []string{"web-servers"}, web2.Subscriptions)
+	assert.Empty(t, conversion.Entities[2].Subscriptions)
+
+	require.Len(t, conversion.Checks, 2)
+	http := conversion.Checks[0]
+	assert.Equal(t, "http", http.Name)
+	assert.Equal(t, "/usr/lib/nagios/plugins/check_http -H {{ .labels.address }} -p 8080 ", http.Command)
+	assert.Equal(t, []string{"nagios"}, http.Subscriptions)
+	assert.Equal(t, uint32(120), http.Interval)
+	assert.True(t, http.Publish)
+	assert.True(t, http.RoundRobin)
+	assert.Empty(t, http.ProxyEntityName)
+	require.NotNil(t, http.ProxyRequests)
+	assert.Equal(t, []string{`["web1", "web2"].indexOf(entity.name) >= 0`}, http.ProxyRequests.EntityAttributes)
+
+	ping := conversion.Checks[1]
+	assert.Equal(t, "ping", ping.Name)
+	assert.Equal(t, "/usr/lib/nagios/plugins/check_ping -H {{ .name }} -w 100.0,20% -c 500.0,60% -t $TIMEOUT$", ping.Command)
+	assert.Equal(t, uint32(300), ping.Interval)
+	assert.False(t, ping.Publish)
+	assert.Equal(t, map[string]string{"notes": "Round trip average"}, ping.Annotations)
+	assert.Equal(t, "db1", ping.ProxyEntityName)
+	assert.Nil(t, ping.ProxyRequests)
+
+	report := conversion.Report.Lines()
+	assert.Contains(t, report, "1 contact definition(s) not converted")
+	assert.Contains(t, report, "command macro $TIMEOUT$ not converted: PING ("+filepath.Join(dir, "objects.cfg")+":47)")
+	assert.Contains(t, report, `host directive "max_check_attempts" not converted: web1 (`+filepath.Join(dir, "objects.cfg")+":23), web2 ("+filepath.Join(dir, "objects.cfg")+":30)")
+	assert.Contains(t, report, `service skipped, its check command "check_mysql" is not defined: MySQL (`+filepath.Join(dir, "objects.cfg")+":56)")
+}
+
+func TestConvertNagiosProxyEntity(t *testing.T) {
+	dir := writeNagiosConfig(t, map[string]string{
+		"objects.cfg": `
+define command {
+	command_name  check_load
+	command_line  check_load -w $ARG1$ -c $$
+}
+
+define host {
+	host_name  db1
+}
+
+define service {
+	host_name            db1
+	service_description  Load
+	check_command        check_load!5
+}
+
+define service {
+	host_name            db1
+	service_description  load
+	check_command        check_load!10
+}
+
+define service {
+	host_name            db2
+	service_description  Load
+	check_command        check_load!5
+}
+`,
+	})
+	defer os.RemoveAll(dir)
+
+	config, err := loadNagiosConfig(dir)
+	require.NoError(t, err)
+	conversion, err := convertNagios(config, "dev", "nagios")
+	require.NoError(t, err)
+
+	require.Len(t, conversion.Checks, 2)
+	assert.Equal(t, "load", conversion.Checks[0].Name)
+	assert.Equal(t, "dev", conversion.Checks[0].Namespace)
+	assert.Equal(t, "check_load -w 5 -c $", conversion.Checks[0].Command)
+	assert.Equal(t, "db1", conversion.Checks[0].ProxyEntityName)
+	assert.Nil(t, conversion.Checks[0].ProxyRequests)
+
+	// The names of the checks are unique
+	assert.Equal(t, "load-db1", conversion.Checks[1].Name)
+
+	assert.Equal(t, []string{
+		"service skipped, it doesn't apply to any converted host: Load (" + filepath.Join(dir, "objects.cfg") + ":23)",
+	}, conversion.Report.Lines())
+}
+
+func TestNagiosCommand(t *testing.T) {
+	dir := writeNagiosConfig(t, map[string]string{
+		"objects.cfg": nagiosObjects,
+	})
+	defer os.RemoveAll(dir)
+
+	cli := test.NewCLI()
+	cmd := NagiosCommand(cli)
+	require.NoError(t, cmd.Flags().Set("cfg", dir))
+	require.NoError(t, cmd.Flags().Set("format", "wrapped-json"))
+	file := filepath.Join(dir, "sensu.json")
+	require.NoError(t, cmd.Flags().Set("file", file))
+
+	out, err := test.RunCmd(cmd, []string{})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Converted 3 host(s) and 2 service(s)")
+	assert.Contains(t, out, "- 1 contact definition(s) not converted")
+
+	resources, err := ioutil.ReadFile(file)
+	require.NoError(t, err)
+	assert.Contains(t, string(resources), `"type": "Entity"`)
+	assert.Contains(t, string(resources), `"type": "CheckConfig"`)
+}
+
+func TestNagiosCommandArgs(t *testing.T) {
+	cli := test.NewCLI()
+	cmd := NagiosCommand(cli)
+
+	_, err := test.RunCmd(cmd, []string{"foo"})
+	assert.Error(t, err)
+
+	require.NoError(t, cmd.Flags().Set("cfg", "/nonexistent/nagios"))
+	_, err = test.RunCmd(cmd, []string{})
+	assert.Error(t, err)
+}
@@ -30,6 +30,8 @@ func CreateCommand(cli *cli.SensuCli) *cobra.Command {
 				opts.Name = args[0]
 			}
 
+			// Labels and the minimum check interval can only be set with flags
+			opts.withFlags(cmd.Flags())
 			if isInteractive {
 				if err := opts.administerQuestionnaire(false); err != nil {
 					return err
@@ -55,6 +57,11 @@ func CreateCommand(cli *cli.SensuCli) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().String("description", "", "description of the namespace")
+	cmd.Flags().String("owner", "", "person or team that owns the namespace")
+	cmd.Flags().String("contact", "", "how to reach the owner of the namespace, e.g. an email address")
+	cmd.Flags().StringToString("labels", nil, "comma separated list of key=value labels of the namespace")
+	cmd.Flags().Uint32("min-check-interval", 0, "minimum interval in seconds of the checks of the namespace (0 for no minimum)")
 	helpers.AddInteractiveFlag(cmd.Flags())
 	return cmd
 }
@@ -16,7 +16,10 @@ func HelpCommand(cli *cli.SensuCli) *cobra.Command {
 	cmd.AddCommand(
 		CreateCommand(cli),
 		DeleteCommand(cli),
+		InfoCommand(cli),
 		ListCommand(cli),
+		MuteCommand(cli),
+		UnmuteCommand(cli),
 	)
 
 	return cmd
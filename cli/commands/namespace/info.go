@@ -0,0 +1,96 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/elements/list"
+	"github.com/spf13/cobra"
+)
+
+// InfoCommand defines new namespace info command
+func InfoCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "info [NAME]",
+		Short:        "show detailed namespace information",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			namespace, err := cli.Client.FetchNamespace(args[0])
+			if err != nil {
+				return err
+			}
+
+			// Determine the format to use to output the data
+			flag := helpers.GetChangedStringValueFlag("format", cmd.Flags())
+			format := cli.Config.Format()
+			return helpers.PrintFormatted(flag, format, namespace, cmd.OutOrStdout(), printToList)
+		},
+	}
+
+	helpers.AddFormatFlag(cmd.Flags())
+
+	return cmd
+}
+
+func printToList(v interface{}, writer io.Writer) error {
+	r, ok := v.(*corev2.Namespace)
+	if !ok {
+		return fmt.Errorf("%t is not a Namespace", v)
+	}
+
+	labels := make([]string, 0, len(r.Labels))
+	for key, value := range r.Labels {
+		labels = append(labels, key+"="+value)
+	}
+	sort.Strings(labels)
+
+	cfg := &list.Config{
+		Title: r.Name,
+		Rows: []*list.Row{
+			{
+				Label: "Name",
+				Value: r.Name,
+			},
+			{
+				Label: "Description",
+				Value: r.Description,
+			},
+			{
+				Label: "Owner",
+				Value: r.Owner,
+			},
+			{
+				Label: "Contact",
+				Value: r.Contact,
+			},
+			{
+				Label: "Labels",
+				Value: strings.Join(labels, ", "),
+			},
+			{
+				Label: "Min Check Interval",
+				Value: minCheckInterval(r.MinCheckInterval),
+			},
+		},
+	}
+
+	return list.Print(writer, cfg)
+}
+
+func minCheckInterval(interval uint32) string {
+	if interval == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("%ds", interval)
+}
@@ -0,0 +1,76 @@
+package namespace
+
+import (
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfoCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewCLI()
+	cmd := InfoCommand(cli)
+
+	assert.NotNil(cmd, "cmd should be returned")
+	assert.NotNil(cmd.RunE, "cmd should be able to be executed")
+	assert.Regexp("info", cmd.Use)
+	assert.Regexp("namespace", cmd.Short)
+}
+
+func TestInfoCommandRunEClosure(t *testing.T) {
+	assert := assert.New(t)
+
+	namespace := corev2.FixtureNamespace("dev")
+	namespace.Description = "development environment"
+	namespace.Owner = "platform team"
+	namespace.Contact = "platform@example.com"
+	namespace.Labels = map[string]string{"region": "us-west-2", "cost-center": "42"}
+	namespace.MinCheckInterval = 10
+
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("FetchNamespace", "dev").Return(namespace, nil)
+
+	cmd := InfoCommand(cli)
+	require.NoError(t, cmd.Flags().Set("format", "tabular"))
+	out, err := test.RunCmd(cmd, []string{"dev"})
+	assert.NoError(err)
+
+	assert.Contains(out, "development environment")
+	assert.Contains(out, "platform team")
+	assert.Contains(out, "platform@example.com")
+	assert.Contains(out, "cost-center=42, region=us-west-2")
+	assert.Contains(out, "10s")
+}
+
+func TestInfoCommandRunMissingArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewCLI()
+	cmd := InfoCommand(cli)
+	out, err := test.RunCmd(cmd, []string{})
+
+	assert.Contains(out, "Usage")
+	assert.Error(err)
+}
+
+func TestInfoCommandRunEClosureWithErr(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("FetchNamespace", "dev").Return((*corev2.Namespace)(nil), errors.New("my-err"))
+
+	cmd := InfoCommand(cli)
+	out, err := test.RunCmd(cmd, []string{"dev"})
+
+	assert.Error(err)
+	assert.Equal("my-err", err.Error())
+	assert.Empty(out)
+}
@@ -3,11 +3,17 @@ package namespace
 import (
 	"github.com/AlecAivazis/survey"
 	"github.com/sensu/sensu-go/types"
+	"github.com/spf13/pflag"
 )
 
 type namespaceOpts struct {
 	Description string `survey:"description"`
 	Name        string `survey:"name"`
+	Owner       string `survey:"owner"`
+	Contact     string `survey:"contact"`
+	Labels      map[string]string
+
+	MinCheckInterval uint32
 }
 
 func newNamespaceOpts() *namespaceOpts {
@@ -15,6 +21,14 @@ func newNamespaceOpts() *namespaceOpts {
 	return &opts
 }
 
+func (opts *namespaceOpts) withFlags(flags *pflag.FlagSet) {
+	opts.Description, _ = flags.GetString("description")
+	opts.Owner, _ = flags.GetString("owner")
+	opts.Contact, _ = flags.GetString("contact")
+	opts.Labels, _ = flags.GetStringToString("labels")
+	opts.MinCheckInterval, _ = flags.GetUint32("min-check-interval")
+}
+
 func (opts *namespaceOpts) administerQuestionnaire(editing bool) error {
 	var qs []*survey.Question
 
@@ -31,9 +45,40 @@ func (opts *namespaceOpts) administerQuestionnaire(editing bool) error {
 		}...)
 	}
 
+	qs = append(qs, []*survey.Question{
+		{
+			Name: "description",
+			Prompt: &survey.Input{
+				Message: "Description:",
+				Default: opts.Description,
+			},
+		},
+		{
+			Name: "owner",
+			Prompt: &survey.Input{
+				Message: "Owner:",
+				Default: opts.Owner,
+			},
+		},
+		{
+			Name: "contact",
+			Prompt: &survey.Input{
+				Message: "Contact:",
+				Default: opts.Contact,
+			},
+		},
+	}...)
+
 	return survey.Ask(qs, opts)
 }
 
 func (opts *namespaceOpts) Copy(namespace *types.Namespace) {
 	namespace.Name = opts.Name
+	namespace.Description = opts.Description
+	namespace.Owner = opts.Owner
+	namespace.Contact = opts.Contact
+	namespace.MinCheckInterval = opts.MinCheckInterval
+	if len(opts.Labels) > 0 {
+		namespace.Labels = opts.Labels
+	}
 }
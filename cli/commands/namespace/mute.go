@@ -0,0 +1,63 @@
+package namespace
+
+import (
+	"errors"
+	"fmt"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/spf13/cobra"
+)
+
+// MuteCommand adds a command that allows user to mute namespaces, so that no
+// handlers are executed for their events
+func MuteCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "mute [NAMESPACE]",
+		Short:        "mute specified namespace, so that no handlers are executed for its events",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// If no name is present print out usage
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			reason, _ := cmd.Flags().GetString("reason")
+			mute := &corev2.NamespaceMute{Muted: true, Reason: reason}
+			if _, err := cli.Client.UpdateNamespaceMute(args[0], mute); err != nil {
+				return err
+			}
+
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), "Muted")
+			return err
+		},
+	}
+
+	_ = cmd.Flags().String("reason", "", "reason for muting the namespace")
+
+	return cmd
+}
+
+// UnmuteCommand adds a command that allows user to unmute namespaces
+func UnmuteCommand(cli *cli.SensuCli) *cobra.Command {
+	return &cobra.Command{
+		Use:          "unmute [NAMESPACE]",
+		Short:        "unmute specified namespace",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// If no name is present print out usage
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			if _, err := cli.Client.UpdateNamespaceMute(args[0], &corev2.NamespaceMute{}); err != nil {
+				return err
+			}
+
+			_, err := fmt.Fprintln(cmd.OutOrStdout(), "Unmuted")
+			return err
+		},
+	}
+}
@@ -0,0 +1,59 @@
+package namespace
+
+import (
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMuteCommandRunEClosureWithoutName(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := MuteCommand(cli)
+	out, err := test.RunCmd(cmd, []string{})
+
+	assert.Regexp(t, "Usage", out)
+	assert.Error(t, err)
+}
+
+func TestMuteCommandRunEClosureWithReason(t *testing.T) {
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	mute := &corev2.NamespaceMute{Muted: true, Reason: "maintenance"}
+	client.On("UpdateNamespaceMute", "foo", mute).Return(mute, nil)
+
+	cmd := MuteCommand(cli)
+	require.NoError(t, cmd.Flags().Set("reason", "maintenance"))
+	out, err := test.RunCmd(cmd, []string{"foo"})
+
+	assert.Regexp(t, "Muted", out)
+	assert.NoError(t, err)
+}
+
+func TestMuteCommandRunEClosureWithServerErr(t *testing.T) {
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("UpdateNamespaceMute", "foo", &corev2.NamespaceMute{Muted: true}).Return((*corev2.NamespaceMute)(nil), errors.New("oh noes"))
+
+	cmd := MuteCommand(cli)
+	out, err := test.RunCmd(cmd, []string{"foo"})
+
+	assert.Empty(t, out)
+	assert.Error(t, err)
+}
+
+func TestUnmuteCommandRunEClosure(t *testing.T) {
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("UpdateNamespaceMute", "foo", &corev2.NamespaceMute{}).Return(&corev2.NamespaceMute{}, nil)
+
+	cmd := UnmuteCommand(cli)
+	out, err := test.RunCmd(cmd, []string{"foo"})
+
+	assert.Regexp(t, "Unmuted", out)
+	assert.NoError(t, err)
+}
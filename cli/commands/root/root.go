@@ -32,6 +32,8 @@ func Command() *cobra.Command {
 	cmd.PersistentFlags().String("config-dir", path.UserConfigDir("sensuctl"), "path to directory containing configuration files")
 	cmd.PersistentFlags().String("cache-dir", path.UserCacheDir("sensuctl"), "path to directory containing cache & temporary files")
 	cmd.PersistentFlags().String("namespace", config.DefaultNamespace, "namespace in which we perform actions")
+	cmd.PersistentFlags().Bool("no-header", false, "do not print the header of tabular output")
+	cmd.PersistentFlags().String("cluster", "", "name of the configured cluster to use, or \""+config.AllClusters+"\" to run list and info commands against all of them")
 
 	return cmd
 }
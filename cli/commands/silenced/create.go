@@ -46,6 +46,17 @@ func CreateCommand(cli *cli.SensuCli) *cobra.Command {
 					return fmt.Errorf("must specify --check or --subscription")
 				}
 			}
+			if tmpl := cli.Config.SilencedReasonTemplate(); tmpl != "" {
+				reason, err := applyReasonTemplate(tmpl, reasonData{
+					Reason:    opts.Reason,
+					Username:  helpers.GetCurrentUsername(cli.Config),
+					Namespace: opts.Namespace,
+				})
+				if err != nil {
+					return err
+				}
+				opts.Reason = reason
+			}
 			var silenced types.Silenced
 			if err := opts.Apply(&silenced); err != nil {
 				return err
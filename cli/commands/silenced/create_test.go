@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"testing"
 
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	client "github.com/sensu/sensu-go/cli/client/testing"
 	test "github.com/sensu/sensu-go/cli/commands/testing"
 	"github.com/stretchr/testify/assert"
@@ -107,3 +108,26 @@ func TestCreateCommandRunEClosureWithMissingRequiredFlags(t *testing.T) {
 	require.Error(t, err)
 	assert.Empty(out)
 }
+
+func TestCreateCommandRunEClosureWithReasonTemplate(t *testing.T) {
+	cli := test.NewMockCLI()
+	config := &client.MockConfig{}
+	config.On("Namespace").Return("default")
+	config.On("SilencedReasonTemplate").Return("[ops] ")
+	config.On("Tokens").Return((*corev2.Tokens)(nil))
+	cli.Config = config
+
+	mockClient := cli.Client.(*client.MockClient)
+	mockClient.On("CreateSilenced", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		silenced := args.Get(0).(*corev2.Silenced)
+		assert.Equal(t, "[ops] just because", silenced.Reason)
+	})
+
+	cmd := CreateCommand(cli)
+	require.NoError(t, cmd.Flags().Set("reason", "just because"))
+	require.NoError(t, cmd.Flags().Set("subscription", "weeklyworldnews"))
+	out, err := test.RunCmd(cmd, []string{})
+	require.NoError(t, err)
+	assert.Regexp(t, "Created", out)
+	mockClient.AssertExpectations(t)
+}
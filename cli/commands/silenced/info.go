@@ -8,6 +8,7 @@ import (
 
 	"github.com/sensu/sensu-go/cli"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/commands/timeutil"
 	"github.com/sensu/sensu-go/cli/elements/list"
 	"github.com/sensu/sensu-go/types"
 	"github.com/spf13/cobra"
@@ -49,13 +50,13 @@ func InfoCommand(cli *cli.SensuCli) *cobra.Command {
 
 }
 
-func expireTime(beginTS, expireSeconds int64) time.Duration {
-	begin := time.Unix(beginTS, 0)
-	expire := time.Duration(expireSeconds) * time.Second
-	if time.Now().Before(begin) {
-		return (expire - time.Until(begin)).Truncate(time.Second)
+// expireTimestamp returns the timestamp at which a silenced entry expires,
+// given the number of seconds it has left to live, or 0 if it never expires
+func expireTimestamp(expireSeconds int64) int64 {
+	if expireSeconds <= 0 {
+		return 0
 	}
-	return time.Duration(expireSeconds) * time.Second
+	return time.Now().Unix() + expireSeconds
 }
 
 func printToList(v interface{}, writer io.Writer) error {
@@ -68,7 +69,7 @@ func printToList(v interface{}, writer io.Writer) error {
 		Rows: []*list.Row{
 			{
 				Label: "Expire",
-				Value: expireTime(r.Begin, r.Expire).String(),
+				Value: timeutil.HumanTimestamp(expireTimestamp(r.Expire)),
 			},
 			{
 				Label: "ExpireOnResolve",
@@ -99,7 +100,7 @@ func printToList(v interface{}, writer io.Writer) error {
 	if time.Now().Before(time.Unix(r.Begin, 0)) {
 		extraRows := []*list.Row{{
 			Label: "Begin",
-			Value: time.Unix(r.Begin, 0).Format(time.RFC822),
+			Value: timeutil.HumanTimestamp(r.Begin),
 		}}
 		cfg.Rows = append(extraRows, cfg.Rows...)
 	}
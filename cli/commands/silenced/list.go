@@ -5,12 +5,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	"github.com/sensu/sensu-go/cli"
 	"github.com/sensu/sensu-go/cli/commands/flags"
 	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/commands/timeutil"
 	"github.com/sensu/sensu-go/cli/elements/globals"
 	"github.com/sensu/sensu-go/cli/elements/table"
 
@@ -52,13 +52,24 @@ func ListCommand(cli *cli.SensuCli) *cobra.Command {
 
 			}
 
+			filter := &corev2.SilencedFilter{}
+			if filter.Creator, err = flg.GetString("creator"); err != nil {
+				return err
+			}
+			if filter.Expired, err = flg.GetBool("expired"); err != nil {
+				return err
+			}
+			if filter.ExpiresWithin, err = flg.GetDuration("expires-within"); err != nil {
+				return err
+			}
+
 			opts, err := helpers.ListOptionsFromFlags(cmd.Flags())
 			if err != nil {
 				return err
 			}
 
 			var header http.Header
-			results, err := cli.Client.ListSilenceds(namespace, sub, check, &opts, &header)
+			results, err := cli.Client.ListSilenceds(namespace, sub, check, filter, &opts, &header)
 			if err != nil {
 				return err
 			}
@@ -81,6 +92,9 @@ func ListCommand(cli *cli.SensuCli) *cobra.Command {
 
 	_ = flags.StringP("subscription", "s", "", "name of the silenced subscription")
 	_ = flags.StringP("check", "c", "", "name of the silenced check")
+	_ = flags.String("creator", "", "only list the silenced entries created by the given user")
+	_ = flags.Bool("expired", false, "only list the silenced entries whose expiration has passed")
+	_ = flags.Duration("expires-within", 0, "only list the silenced entries expiring within the given duration, e.g. 1h")
 
 	return cmd
 }
@@ -125,7 +139,7 @@ func printToTable(results interface{}, writer io.Writer) {
 				if !ok {
 					return cli.TypeError
 				}
-				return time.Unix(silenced.Begin, 0).Format(time.RFC822)
+				return timeutil.HumanTimestamp(silenced.Begin)
 			},
 		},
 		{
@@ -135,7 +149,7 @@ func printToTable(results interface{}, writer io.Writer) {
 				if !ok {
 					return cli.TypeError
 				}
-				return expireTime(silenced.Begin, silenced.Expire).String()
+				return timeutil.HumanTimestamp(expireTimestamp(silenced.Expire))
 			},
 		},
 		{
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	corev2 "github.com/sensu/sensu-go/api/core/v2"
 	client "github.com/sensu/sensu-go/cli/client/testing"
@@ -32,7 +33,7 @@ func TestListCommandRunEClosure(t *testing.T) {
 
 	cli := test.NewCLI()
 	client := cli.Client.(*client.MockClient)
-	client.On("ListSilenceds", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]corev2.Silenced{
+	client.On("ListSilenceds", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]corev2.Silenced{
 		*corev2.FixtureSilenced("foo:bar"),
 		*corev2.FixtureSilenced("bar:foo"),
 	}, nil)
@@ -53,7 +54,7 @@ func TestListCommandRunEClosureWithAll(t *testing.T) {
 
 	cli := test.NewCLI()
 	client := cli.Client.(*client.MockClient)
-	client.On("ListSilenceds", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]corev2.Silenced{
+	client.On("ListSilenceds", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]corev2.Silenced{
 		*corev2.FixtureSilenced("foo:bar"),
 	}, nil)
 
@@ -77,7 +78,7 @@ func TestListCommandRunEClosureWithTable(t *testing.T) {
 	silenced.Namespace = "defaultnamespace"
 
 	client := cli.Client.(*client.MockClient)
-	client.On("ListSilenceds", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]corev2.Silenced{*silenced}, nil)
+	client.On("ListSilenceds", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]corev2.Silenced{*silenced}, nil)
 
 	cmd := ListCommand(cli)
 	require.NoError(t, cmd.Flags().Set("format", "none"))
@@ -107,7 +108,7 @@ func TestListCommandRunEClosureWithErr(t *testing.T) {
 
 	cli := test.NewCLI()
 	client := cli.Client.(*client.MockClient)
-	client.On("ListSilenceds", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]corev2.Silenced{}, errors.New("my-err"))
+	client.On("ListSilenceds", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]corev2.Silenced{}, errors.New("my-err"))
 
 	cmd := ListCommand(cli)
 	out, err := test.RunCmd(cmd, []string{})
@@ -139,9 +140,9 @@ func TestListCommandRunEClosureWithHeader(t *testing.T) {
 
 	client := cli.Client.(*client.MockClient)
 	var header http.Header
-	client.On("ListSilenceds", mock.Anything, mock.Anything, mock.Anything, mock.Anything, &header).Return([]corev2.Silenced{}, nil).Run(
+	client.On("ListSilenceds", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, &header).Return([]corev2.Silenced{}, nil).Run(
 		func(args mock.Arguments) {
-			header := args[4].(*http.Header)
+			header := args[5].(*http.Header)
 			*header = make(http.Header)
 			header.Add(helpers.HeaderWarning, "E_TOO_MANY_ENTITIES")
 		},
@@ -155,3 +156,40 @@ func TestListCommandRunEClosureWithHeader(t *testing.T) {
 	assert.Contains(out, "E_TOO_MANY_ENTITIES")
 	assert.Contains(out, "==")
 }
+
+func TestListCommandRunEClosureWithFilter(t *testing.T) {
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	filter := &corev2.SilencedFilter{Creator: "admin", ExpiresWithin: time.Hour}
+	client.On("ListSilenceds", mock.Anything, mock.Anything, mock.Anything, filter, mock.Anything, mock.Anything).Return([]corev2.Silenced{
+		*corev2.FixtureSilenced("foo:bar"),
+	}, nil)
+
+	cmd := ListCommand(cli)
+	require.NoError(t, cmd.Flags().Set("format", "json"))
+	require.NoError(t, cmd.Flags().Set("creator", "admin"))
+	require.NoError(t, cmd.Flags().Set("expires-within", "1h"))
+	out, err := test.RunCmd(cmd, []string{})
+
+	require.NoError(t, err)
+	assert.Contains(t, out, "foo:bar")
+	client.AssertExpectations(t)
+}
+
+func TestListCommandRunEClosureWithExpiredFilter(t *testing.T) {
+	cli := test.NewCLI()
+	client := cli.Client.(*client.MockClient)
+	filter := &corev2.SilencedFilter{Expired: true}
+	client.On("ListSilenceds", mock.Anything, mock.Anything, mock.Anything, filter, mock.Anything, mock.Anything).Return([]corev2.Silenced{
+		*corev2.FixtureSilenced("foo:bar"),
+	}, nil)
+
+	cmd := ListCommand(cli)
+	require.NoError(t, cmd.Flags().Set("format", "json"))
+	require.NoError(t, cmd.Flags().Set("expired", "true"))
+	out, err := test.RunCmd(cmd, []string{})
+
+	require.NoError(t, err)
+	assert.Contains(t, out, "foo:bar")
+	client.AssertExpectations(t)
+}
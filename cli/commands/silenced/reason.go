@@ -0,0 +1,37 @@
+package silenced
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// reasonData is the data available to the silenced reason templates
+type reasonData struct {
+	Reason    string
+	Username  string
+	Namespace string
+}
+
+// applyReasonTemplate applies the silenced reason template of the profile to
+// the reason given by the user. A template without actions is used as a
+// prefix. Empty reasons are left empty, so that they can still be rejected by
+// the backend when a reason is required.
+func applyReasonTemplate(tmpl string, data reasonData) (string, error) {
+	if tmpl == "" || strings.TrimSpace(data.Reason) == "" {
+		return data.Reason, nil
+	}
+	if !strings.Contains(tmpl, "{{") {
+		return tmpl + data.Reason, nil
+	}
+
+	t, err := template.New("reason").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid silenced reason template: %s", err)
+	}
+	var b strings.Builder
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("invalid silenced reason template: %s", err)
+	}
+	return b.String(), nil
+}
@@ -0,0 +1,39 @@
+package silenced
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyReasonTemplate(t *testing.T) {
+	data := reasonData{Reason: "maintenance", Username: "admin", Namespace: "default"}
+
+	tests := []struct {
+		name     string
+		template string
+		data     reasonData
+		want     string
+	}{
+		{"no template", "", data, "maintenance"},
+		{"prefix", "[ops] ", data, "[ops] maintenance"},
+		{"template", "{{.Reason}} ({{.Username}}@{{.Namespace}})", data, "maintenance (admin@default)"},
+		{"empty reason", "[ops] ", reasonData{Username: "admin"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyReasonTemplate(tt.template, tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyReasonTemplateInvalid(t *testing.T) {
+	_, err := applyReasonTemplate("{{.Reason", reasonData{Reason: "maintenance"})
+	assert.Error(t, err)
+
+	_, err = applyReasonTemplate("{{.Ticket}}", reasonData{Reason: "maintenance"})
+	assert.Error(t, err)
+}
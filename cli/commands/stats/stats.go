@@ -0,0 +1,132 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/elements/table"
+	"github.com/spf13/cobra"
+)
+
+// Command shows the rates of the events processed by the cluster per check,
+// or per namespace
+func Command(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "stats",
+		Short:        "show the event rates per check or per namespace of the cluster",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+			perNamespace, err := cmd.Flags().GetBool("per-namespace")
+			if err != nil {
+				return err
+			}
+			limit, err := cmd.Flags().GetInt("limit")
+			if err != nil {
+				return err
+			}
+
+			stats, err := cli.Client.FetchEventStats()
+			if err != nil {
+				return err
+			}
+			if limit > 0 {
+				if len(stats.Checks) > limit {
+					stats.Checks = stats.Checks[:limit]
+				}
+				if len(stats.Namespaces) > limit {
+					stats.Namespaces = stats.Namespaces[:limit]
+				}
+			}
+
+			printTable := func(results interface{}, writer io.Writer) {
+				stats, ok := results.(*corev2.EventStats)
+				if !ok {
+					_, _ = fmt.Fprintln(writer, cli.TypeError)
+					return
+				}
+				window := time.Duration(stats.Window) * time.Second
+				_, _ = fmt.Fprintf(writer, "%d events over the last %s\n\n", stats.Events, window)
+				if perNamespace {
+					printRatesToTable(stats.Namespaces, false, writer)
+				} else {
+					printRatesToTable(stats.Checks, true, writer)
+				}
+			}
+			return helpers.Print(cmd, cli.Config.Format(), printTable, nil, stats)
+		},
+	}
+
+	helpers.AddFormatFlag(cmd.Flags())
+	cmd.Flags().Bool("per-namespace", false, "show the event rates per namespace instead of per check")
+	cmd.Flags().Int("limit", 0, "only show the given number of checks or namespaces generating the most events")
+
+	return cmd
+}
+
+// rateRow is an event rate along with the cumulative share of the rates up to
+// it, which tells how few checks generate most of the events.
+type rateRow struct {
+	corev2.EventRate
+	cumulativeShare float64
+}
+
+func printRatesToTable(rates []corev2.EventRate, withCheck bool, writer io.Writer) {
+	rows := make([]rateRow, 0, len(rates))
+	var cumulativeShare float64
+	for _, rate := range rates {
+		cumulativeShare += rate.Share
+		rows = append(rows, rateRow{EventRate: rate, cumulativeShare: cumulativeShare})
+	}
+
+	rateColumn := func(title string, fn func(rateRow) string) *table.Column {
+		return &table.Column{
+			Title: title,
+			CellTransformer: func(data interface{}) string {
+				row, ok := data.(rateRow)
+				if !ok {
+					return cli.TypeError
+				}
+				return fn(row)
+			},
+		}
+	}
+
+	namespace := rateColumn("Namespace", func(r rateRow) string {
+		return r.Namespace
+	})
+	namespace.ColumnStyle = table.PrimaryTextStyle
+	columns := []*table.Column{namespace}
+	if withCheck {
+		columns = append(columns, rateColumn("Check", func(r rateRow) string {
+			if r.Check == "" {
+				return "(metrics)"
+			}
+			return r.Check
+		}))
+	}
+	columns = append(columns,
+		rateColumn("Events", func(r rateRow) string {
+			return fmt.Sprintf("%d", r.Events)
+		}),
+		rateColumn("Per Minute", func(r rateRow) string {
+			return fmt.Sprintf("%.1f", r.PerMinute)
+		}),
+		rateColumn("Share", func(r rateRow) string {
+			return fmt.Sprintf("%.1f%%", r.Share)
+		}),
+		rateColumn("Cumulative Share", func(r rateRow) string {
+			return fmt.Sprintf("%.1f%%", r.cumulativeShare)
+		}),
+	)
+
+	table.New(columns).Render(writer, rows)
+}
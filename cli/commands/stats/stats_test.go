@@ -0,0 +1,74 @@
+package stats
+
+import (
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommand(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := Command(cli)
+
+	assert.NotNil(t, cmd, "cmd should be returned")
+	assert.NotNil(t, cmd.RunE, "cmd should be able to be executed")
+	assert.Regexp(t, "stats", cmd.Use)
+	assert.Regexp(t, "event rates", cmd.Short)
+}
+
+func TestCommandRunEClosure(t *testing.T) {
+	newStats := func() *corev2.EventStats {
+		return &corev2.EventStats{
+			Window: 900,
+			Events: 1000,
+			Namespaces: []corev2.EventRate{
+				{Namespace: "default", Events: 1000, PerMinute: 66.7, Share: 100},
+			},
+			Checks: []corev2.EventRate{
+				{Namespace: "default", Check: "disk", Events: 800, PerMinute: 53.3, Share: 80},
+				{Namespace: "default", Check: "cpu", Events: 150, PerMinute: 10, Share: 15},
+				{Namespace: "default", Events: 50, PerMinute: 3.3, Share: 5},
+			},
+		}
+	}
+	cli := test.NewMockCLI()
+	mockClient := cli.Client.(*client.MockClient)
+	mockClient.On("FetchEventStats").Return(newStats(), nil).Once()
+
+	cmd := Command(cli)
+	out, err := test.RunCmd(cmd, []string{})
+	require.NoError(t, err)
+	assert.Contains(t, out, "1000 events over the last 15m0s")
+	assert.Contains(t, out, "disk")
+	assert.Contains(t, out, "53.3")
+	assert.Contains(t, out, "95.0%")
+	assert.Contains(t, out, "(metrics)")
+
+	mockClient.On("FetchEventStats").Return(newStats(), nil).Once()
+	require.NoError(t, cmd.Flags().Set("limit", "1"))
+	out, err = test.RunCmd(cmd, []string{})
+	require.NoError(t, err)
+	assert.Contains(t, out, "disk")
+	assert.NotContains(t, out, "cpu")
+
+	mockClient.On("FetchEventStats").Return(newStats(), nil).Once()
+	require.NoError(t, cmd.Flags().Set("per-namespace", "true"))
+	out, err = test.RunCmd(cmd, []string{})
+	require.NoError(t, err)
+	assert.Contains(t, out, "66.7")
+	assert.NotContains(t, out, "disk")
+}
+
+func TestCommandRunEClosureWithErr(t *testing.T) {
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).On("FetchEventStats").Return((*corev2.EventStats)(nil), errors.New("error"))
+
+	cmd := Command(cli)
+	_, err := test.RunCmd(cmd, []string{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,22 @@
+package subscription
+
+import (
+	"github.com/sensu/sensu-go/cli"
+	"github.com/spf13/cobra"
+)
+
+// HelpCommand defines new parent
+func HelpCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subscription",
+		Short: "Manage subscriptions",
+	}
+
+	// Add sub-commands
+	cmd.AddCommand(
+		ListCommand(cli),
+		InfoCommand(cli),
+	)
+
+	return cmd
+}
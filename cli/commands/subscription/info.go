@@ -0,0 +1,94 @@
+package subscription
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/elements/list"
+	"github.com/spf13/cobra"
+)
+
+// InfoCommand shows the description, labels and connected entities of a
+// subscription
+func InfoCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "info [NAME]",
+		Short:        "show detailed subscription information",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			// The subscriptions list includes both the subscriptions with
+			// connected entities and the documented ones
+			results, err := cli.Client.ListSubscriptions(cli.Config.Namespace())
+			if err != nil {
+				return err
+			}
+			var subscription *corev2.SubscriptionMembers
+			for i := range results {
+				if results[i].Subscription == args[0] {
+					subscription = &results[i]
+					break
+				}
+			}
+			if subscription == nil {
+				return fmt.Errorf("subscription %q not found", args[0])
+			}
+
+			// Determine the format to use to output the data
+			flag := helpers.GetChangedStringValueFlag("format", cmd.Flags())
+			format := cli.Config.Format()
+			return helpers.PrintFormatted(flag, format, subscription, cmd.OutOrStdout(), printToList)
+		},
+	}
+
+	helpers.AddFormatFlag(cmd.Flags())
+
+	return cmd
+}
+
+func printToList(v interface{}, writer io.Writer) error {
+	r, ok := v.(*corev2.SubscriptionMembers)
+	if !ok {
+		return fmt.Errorf("%t is not a SubscriptionMembers", v)
+	}
+
+	labels := make([]string, 0, len(r.Labels))
+	for key, value := range r.Labels {
+		labels = append(labels, key+"="+value)
+	}
+	sort.Strings(labels)
+
+	cfg := &list.Config{
+		Title: r.Subscription,
+		Rows: []*list.Row{
+			{
+				Label: "Name",
+				Value: r.Subscription,
+			},
+			{
+				Label: "Description",
+				Value: r.Description,
+			},
+			{
+				Label: "Labels",
+				Value: strings.Join(labels, ", "),
+			},
+			{
+				Label: "Entities",
+				Value: strings.Join(r.Entities, ", "),
+			},
+		},
+	}
+
+	return list.Print(writer, cfg)
+}
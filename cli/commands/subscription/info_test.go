@@ -0,0 +1,79 @@
+package subscription
+
+import (
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfoCommand(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := InfoCommand(cli)
+
+	assert.NotNil(t, cmd, "cmd should be returned")
+	assert.NotNil(t, cmd.RunE, "cmd should be able to be executed")
+	assert.Regexp(t, "info", cmd.Use)
+	assert.Regexp(t, "subscription", cmd.Short)
+}
+
+func TestInfoCommandRunEClosure(t *testing.T) {
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).
+		On("ListSubscriptions", "default").
+		Return([]corev2.SubscriptionMembers{
+			{Subscription: "windows", Entities: []string{"agent3"}},
+			{
+				Subscription: "linux",
+				Description:  "The linux hosts",
+				Labels:       map[string]string{"team": "ops"},
+				Entities:     []string{"agent1", "agent2"},
+			},
+		}, nil)
+
+	cmd := InfoCommand(cli)
+	require.NoError(t, cmd.Flags().Set("format", "tabular"))
+	out, err := test.RunCmd(cmd, []string{"linux"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "The linux hosts")
+	assert.Contains(t, out, "team=ops")
+	assert.Contains(t, out, "agent1, agent2")
+	assert.NotContains(t, out, "agent3")
+}
+
+func TestInfoCommandRunMissingArgs(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := InfoCommand(cli)
+	out, err := test.RunCmd(cmd, []string{})
+
+	assert.Error(t, err)
+	assert.Contains(t, out, "Usage")
+}
+
+func TestInfoCommandRunEClosureNotFound(t *testing.T) {
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).
+		On("ListSubscriptions", "default").
+		Return([]corev2.SubscriptionMembers{
+			{Subscription: "windows", Entities: []string{"agent3"}},
+		}, nil)
+
+	cmd := InfoCommand(cli)
+	_, err := test.RunCmd(cmd, []string{"linux"})
+	assert.Error(t, err)
+}
+
+func TestInfoCommandRunEClosureWithErr(t *testing.T) {
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).
+		On("ListSubscriptions", "default").
+		Return([]corev2.SubscriptionMembers(nil), errors.New("error"))
+
+	cmd := InfoCommand(cli)
+	_, err := test.RunCmd(cmd, []string{"linux"})
+	assert.Error(t, err)
+}
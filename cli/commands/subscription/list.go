@@ -0,0 +1,78 @@
+package subscription
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/elements/table"
+	"github.com/spf13/cobra"
+)
+
+// ListCommand lists the subscriptions of the namespace with their connected
+// agent entities
+func ListCommand(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "list",
+		Short:        "list subscriptions and their connected entities",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			results, err := cli.Client.ListSubscriptions(cli.Config.Namespace())
+			if err != nil {
+				return err
+			}
+
+			return helpers.Print(cmd, cli.Config.Format(), printToTable, nil, results)
+		},
+	}
+
+	helpers.AddFormatFlag(cmd.Flags())
+
+	return cmd
+}
+
+func printToTable(results interface{}, writer io.Writer) {
+	table := table.New([]*table.Column{
+		{
+			Title:       "Subscription",
+			ColumnStyle: table.PrimaryTextStyle,
+			CellTransformer: func(data interface{}) string {
+				members, ok := data.(corev2.SubscriptionMembers)
+				if !ok {
+					return cli.TypeError
+				}
+				return members.Subscription
+			},
+		},
+		{
+			Title: "Entities",
+			CellTransformer: func(data interface{}) string {
+				members, ok := data.(corev2.SubscriptionMembers)
+				if !ok {
+					return cli.TypeError
+				}
+				return strings.Join(members.Entities, ",")
+			},
+		},
+		{
+			Title: "Description",
+			CellTransformer: func(data interface{}) string {
+				members, ok := data.(corev2.SubscriptionMembers)
+				if !ok {
+					return cli.TypeError
+				}
+				return members.Description
+			},
+		},
+	})
+
+	table.Render(writer, results)
+}
@@ -0,0 +1,48 @@
+package subscription
+
+import (
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCommand(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := ListCommand(cli)
+
+	assert.NotNil(t, cmd, "cmd should be returned")
+	assert.NotNil(t, cmd.RunE, "cmd should be able to be executed")
+	assert.Regexp(t, "list", cmd.Use)
+	assert.Regexp(t, "subscriptions", cmd.Short)
+}
+
+func TestListCommandRunEClosure(t *testing.T) {
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).
+		On("ListSubscriptions", "default").
+		Return([]corev2.SubscriptionMembers{
+			{Subscription: "linux", Entities: []string{"agent1", "agent2"}},
+		}, nil)
+
+	cmd := ListCommand(cli)
+	out, err := test.RunCmd(cmd, []string{})
+	require.NoError(t, err)
+	assert.Contains(t, out, "linux")
+	assert.Contains(t, out, "agent1,agent2")
+}
+
+func TestListCommandRunEClosureWithErr(t *testing.T) {
+	cli := test.NewMockCLI()
+	cli.Client.(*client.MockClient).
+		On("ListSubscriptions", "default").
+		Return([]corev2.SubscriptionMembers(nil), errors.New("error"))
+
+	cmd := ListCommand(cli)
+	_, err := test.RunCmd(cmd, []string{})
+	assert.Error(t, err)
+}
@@ -28,6 +28,8 @@ func NewMockCLI() *cli.SensuCli {
 
 	// Set defaults ...
 	config.On("Namespace").Return("default")
+	config.On("TimeFormat").Return("local")
+	config.On("SilencedReasonTemplate").Return("")
 
 	return &cli.SensuCli{
 		Client: client,
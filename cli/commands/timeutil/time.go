@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/sensu/sensu-go/cli/client/config"
 	"github.com/sensu/sensu-go/types"
 )
 
@@ -64,15 +65,67 @@ var (
 		`(Z|([\+|\-]([01][0-9]|2[0-3]):[0-5][0-9]))$`) // zone (e.g. Z or -07:00)
 )
 
-// HumanTimestamp takes a timestamp and returns a readable date using the format
-// string "2006-01-02 15:04:05.999999999 -0700 MST". If the timestamp equals 0,
-// "N/A" will be returned instead of the epoch date
+// timeFormat is the format of the times returned by HumanTimestamp
+var timeFormat = config.DefaultTimeFormat
+
+// SetTimeFormat sets the format of the times returned by HumanTimestamp, one of
+// config.TimeFormats. It is configured once, from the active profile.
+func SetTimeFormat(format string) {
+	timeFormat = format
+}
+
+// HumanTimestamp takes a timestamp and returns a readable date, according to
+// the time format preference of the active profile: RFC3339 in the local time
+// zone or in UTC, or relative to now (e.g. "5m ago"). If the timestamp equals
+// 0, "N/A" will be returned instead of the epoch date
 func HumanTimestamp(timestamp int64) string {
 	if timestamp == 0 {
 		return "N/A"
 	}
 
-	return time.Unix(timestamp, 0).String()
+	return formatTimestamp(timestamp, timeFormat, time.Now())
+}
+
+func formatTimestamp(timestamp int64, format string, now time.Time) string {
+	t := time.Unix(timestamp, 0)
+	switch format {
+	case config.TimeFormatUTC:
+		return t.UTC().Format(time.RFC3339)
+	case config.TimeFormatHumanized:
+		return humanize(t, now)
+	default:
+		return t.Local().Format(time.RFC3339)
+	}
+}
+
+// humanize returns the time relative to now, e.g. "5m ago" or "in 2h"
+func humanize(t, now time.Time) string {
+	d := now.Sub(t)
+	if d < time.Second && d > -time.Second {
+		return "now"
+	}
+
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var s string
+	switch {
+	case d < time.Minute:
+		s = fmt.Sprintf("%ds", d/time.Second)
+	case d < time.Hour:
+		s = fmt.Sprintf("%dm", d/time.Minute)
+	case d < 24*time.Hour:
+		s = fmt.Sprintf("%dh", d/time.Hour)
+	default:
+		s = fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+
+	if future {
+		return "in " + s
+	}
+	return s + " ago"
 }
 
 // ConvertToUTC takes a TimeWindowRange and converts both the begin time and
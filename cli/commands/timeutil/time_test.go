@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/sensu/sensu-go/cli/client/config"
 	"github.com/sensu/sensu-go/types"
 )
 
@@ -278,6 +279,60 @@ func TestConvertToUTC(t *testing.T) {
 	}
 }
 
+func TestFormatTimestamp(t *testing.T) {
+	now := time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		timestamp int64
+		format    string
+		want      string
+	}{
+		{
+			name:      "utc",
+			timestamp: now.Unix(),
+			format:    config.TimeFormatUTC,
+			want:      "2019-06-01T12:00:00Z",
+		},
+		{
+			name:      "local",
+			timestamp: now.Unix(),
+			format:    config.TimeFormatLocal,
+			want:      now.Local().Format(time.RFC3339),
+		},
+		{
+			name:      "humanized now",
+			timestamp: now.Unix(),
+			format:    config.TimeFormatHumanized,
+			want:      "now",
+		},
+		{
+			name:      "humanized past",
+			timestamp: now.Add(-5 * time.Minute).Unix(),
+			format:    config.TimeFormatHumanized,
+			want:      "5m ago",
+		},
+		{
+			name:      "humanized future",
+			timestamp: now.Add(3 * time.Hour).Unix(),
+			format:    config.TimeFormatHumanized,
+			want:      "in 3h",
+		},
+		{
+			name:      "humanized days",
+			timestamp: now.Add(-50 * time.Hour).Unix(),
+			format:    config.TimeFormatHumanized,
+			want:      "2d ago",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := formatTimestamp(tc.timestamp, tc.format, now); got != tc.want {
+				t.Errorf("formatTimestamp() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
 func TestHumanTimestamp(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -21,6 +21,7 @@ func HelpCommand(cli *cli.SensuCli) *cobra.Command {
 		ReinstateCommand(cli),
 		RemoveGroupCommand(cli),
 		RemoveAllGroupsCommand(cli),
+		ResetPasswordCommand(cli),
 		SetGroupsCommand(cli),
 		SetPasswordCommand(cli),
 		TestCredsCommand(cli),
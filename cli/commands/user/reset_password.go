@@ -0,0 +1,40 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sensu/sensu-go/cli"
+	"github.com/spf13/cobra"
+)
+
+// ResetPasswordCommand adds a command that issues a password reset token for
+// a user
+func ResetPasswordCommand(cli *cli.SensuCli) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reset-password [USERNAME]",
+		Short: "issue a one-time token with which the given user can reset their password",
+		Long: "Issue a one-time token with which the given user can reset their password, " +
+			"without anyone else knowing it, by sending the token and the new password " +
+			"to the /auth/reset_password API endpoint.",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// If no name is present print out usage
+			if len(args) != 1 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+
+			token, err := cli.Client.ResetPasswordToken(args[0])
+			if err != nil {
+				return err
+			}
+
+			expiresAt := time.Unix(token.ExpiresAt, 0).Format(time.RFC3339)
+			fmt.Fprintf(cmd.OutOrStdout(), "Password reset token for %s, valid until %s:\n", token.Username, expiresAt)
+			fmt.Fprintln(cmd.OutOrStdout(), token.Token)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,50 @@
+package user
+
+import (
+	"errors"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetPasswordCommandRunEClosureWithoutName(t *testing.T) {
+	cli := test.NewMockCLI()
+	cmd := ResetPasswordCommand(cli)
+	out, err := test.RunCmd(cmd, []string{})
+
+	assert.Regexp(t, "Usage", out)
+	assert.Error(t, err)
+}
+
+func TestResetPasswordCommandRunEClosure(t *testing.T) {
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("ResetPasswordToken", "foo").Return(&corev2.PasswordResetToken{
+		Username:  "foo",
+		Token:     "one-time-token",
+		ExpiresAt: 1600000000,
+	}, nil)
+
+	cmd := ResetPasswordCommand(cli)
+	out, err := test.RunCmd(cmd, []string{"foo"})
+	require.NoError(t, err)
+	assert.Contains(t, out, "Password reset token for foo")
+	assert.Contains(t, out, "one-time-token")
+}
+
+func TestResetPasswordCommandRunEClosureWithServerErr(t *testing.T) {
+	cli := test.NewMockCLI()
+	client := cli.Client.(*client.MockClient)
+	client.On("ResetPasswordToken", "bar").Return((*corev2.PasswordResetToken)(nil), errors.New("oh noes"))
+
+	cmd := ResetPasswordCommand(cli)
+	out, err := test.RunCmd(cmd, []string{"bar"})
+
+	assert.Empty(t, out)
+	require.Error(t, err)
+	assert.Equal(t, "oh noes", err.Error())
+}
@@ -0,0 +1,211 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/commands/hooks"
+	"github.com/sensu/sensu-go/cli/elements/table"
+	"github.com/sensu/sensu-go/cli/resource"
+	"github.com/sensu/sensu-go/types"
+	"github.com/spf13/cobra"
+)
+
+// invalidResource is a resource that didn't pass validation, identified by its
+// input file or URL and its position in the input. The resource is empty if
+// the input couldn't be parsed.
+type invalidResource struct {
+	Input     string `json:"input"`
+	Index     int    `json:"index"`
+	Resource  string `json:"resource"`
+	Namespace string `json:"namespace,omitempty"`
+	Error     string `json:"error"`
+}
+
+// Command validates resources from files without sending them to the API, so
+// it doesn't require a configured cluster.
+func Command(cli *cli.SensuCli) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:          "validate [-r] [[-f URL] ... ]",
+		Short:        "validate resources from file or URL (path, file://, http[s]://), or STDIN otherwise, without creating them",
+		SilenceUsage: true,
+		Annotations: map[string]string{
+			hooks.ConfigurationRequirement: hooks.ConfigurationNotRequired,
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 0 {
+				_ = cmd.Help()
+				return errors.New("invalid argument(s) received")
+			}
+			inputs, err := cmd.Flags().GetStringSlice("file")
+			if err != nil {
+				return err
+			}
+			recurse, err := cmd.Flags().GetBool("recursive")
+			if err != nil {
+				return err
+			}
+			envFill, err := cmd.Flags().GetBool("env-fill")
+			if err != nil {
+				return err
+			}
+
+			t := &http.Transport{}
+			t.RegisterProtocol("file", http.NewFileTransport(http.Dir("/")))
+			httpClient := &http.Client{Transport: t}
+
+			v := &validator{invalid: []invalidResource{}}
+			if len(inputs) == 0 {
+				if err := resource.ProcessStdin(cli, httpClient, envFill, v); err != nil {
+					return err
+				}
+			}
+			for _, input := range inputs {
+				if err := resource.Process(cli, httpClient, input, recurse, envFill, v); err != nil {
+					return err
+				}
+			}
+
+			flag := helpers.GetChangedStringValueFlag("format", cmd.Flags())
+			if err := helpers.PrintFormatted(flag, cli.Config.Format(), v.invalid, cmd.OutOrStdout(), printToTable); err != nil {
+				return err
+			}
+			if v.unparsed > 0 {
+				return fmt.Errorf("found %d invalid resource(s) out of %d, and %d input(s) that couldn't be parsed", len(v.invalid)-v.unparsed, v.count, v.unparsed)
+			}
+			if len(v.invalid) > 0 {
+				return fmt.Errorf("found %d invalid resource(s) out of %d", len(v.invalid), v.count)
+			}
+			return nil
+		},
+	}
+
+	_ = cmd.Flags().StringSliceP("file", "f", nil, "Files, directories, or URLs to validate resources from")
+	_ = cmd.Flags().BoolP("recursive", "r", false, "Follow subdirectories")
+	_ = cmd.Flags().Bool("env-fill", false, "Replace the ${VAR} references in the string values of the resources with the values of the environment variables")
+	helpers.AddFormatFlag(cmd.Flags())
+
+	return cmd
+}
+
+// validator is a resource.InputProcessor validating the resources instead of
+// sending them to the API.
+type validator struct {
+	input    string
+	index    int
+	count    int
+	unparsed int
+	invalid  []invalidResource
+}
+
+// SetInput sets the file or URL of the resources processed next.
+func (v *validator) SetInput(input string) {
+	v.input = input
+	v.index = 0
+}
+
+// ParseError records the input which couldn't be parsed, so that the other
+// inputs are validated.
+func (v *validator) ParseError(input string, err error) error {
+	v.unparsed++
+	v.invalid = append(v.invalid, invalidResource{Input: input, Error: err.Error()})
+	return nil
+}
+
+// Process validates the resources and records the invalid ones.
+func (v *validator) Process(_ client.GenericClient, resources []*types.Wrapper) error {
+	for _, w := range resources {
+		invalid := invalidResource{
+			Input:     v.input,
+			Index:     v.index,
+			Namespace: w.ObjectMeta.Namespace,
+		}
+		v.index++
+		v.count++
+
+		if w.Value == nil {
+			invalid.Resource = w.ObjectMeta.Name
+			invalid.Error = "resource is nil"
+			v.invalid = append(v.invalid, invalid)
+			continue
+		}
+		if err := validateResource(w.Value); err != nil {
+			meta := w.Value.GetObjectMeta()
+			invalid.Resource = corev2.ResourceReference{Type: w.Value.RBACName(), Name: meta.Name}.String()
+			invalid.Namespace = meta.Namespace
+			invalid.Error = err.Error()
+			v.invalid = append(v.invalid, invalid)
+		}
+	}
+	return nil
+}
+
+// validateResource validates the resource, and the syntax of the names of the
+// resources it references. Whether the referenced resources exist can't be
+// known without a cluster, see sensuctl lint.
+func validateResource(r corev2.Resource) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	for _, ref := range corev2.References(r) {
+		spec, ok := corev2.NameSpecs[ref.Type]
+		if !ok {
+			spec = corev2.ResourceNameSpec
+		}
+		if err := spec.Validate(ref.Name); err != nil {
+			return fmt.Errorf("invalid reference to %s: name %s", ref, err)
+		}
+	}
+	return nil
+}
+
+func printToTable(results interface{}, writer io.Writer) error {
+	invalid, ok := results.([]invalidResource)
+	if !ok {
+		return fmt.Errorf("%T is not a list of invalid resources", results)
+	}
+	if len(invalid) == 0 {
+		_, err := fmt.Fprintln(writer, "All resources are valid")
+		return err
+	}
+
+	column := func(title string, fn func(invalidResource) string) *table.Column {
+		return &table.Column{
+			Title: title,
+			CellTransformer: func(data interface{}) string {
+				r, ok := data.(invalidResource)
+				if !ok {
+					return cli.TypeError
+				}
+				return fn(r)
+			},
+		}
+	}
+	input := column("Input", func(r invalidResource) string {
+		if r.Resource == "" {
+			return r.Input
+		}
+		return fmt.Sprintf("%s #%d", r.Input, r.Index)
+	})
+	input.ColumnStyle = table.PrimaryTextStyle
+
+	table.New([]*table.Column{
+		input,
+		column("Resource", func(r invalidResource) string {
+			return r.Resource
+		}),
+		column("Namespace", func(r invalidResource) string {
+			return r.Namespace
+		}),
+		column("Error", func(r invalidResource) string {
+			return r.Error
+		}),
+	}).Render(writer, invalid)
+	return nil
+}
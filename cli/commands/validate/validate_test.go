@@ -0,0 +1,138 @@
+package validate
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	client "github.com/sensu/sensu-go/cli/client/testing"
+	test "github.com/sensu/sensu-go/cli/commands/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validHandler = `
+type: Handler
+api_version: core/v2
+metadata:
+  name: slack
+spec:
+  type: pipe
+  command: slack-handler
+`
+
+const handlers = validHandler + `---
+type: Handler
+api_version: core/v2
+metadata:
+  name: magic
+spec:
+  type: magic
+---
+type: Handler
+api_version: core/v2
+metadata:
+  name: pagerduty
+spec:
+  type: pipe
+  command: pagerduty-handler
+  filters:
+  - business hours
+`
+
+func writeInput(t *testing.T, content string) (string, func()) {
+	t.Helper()
+	td, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	fp := filepath.Join(td, "handlers.yaml")
+	require.NoError(t, ioutil.WriteFile(fp, []byte(content), 0644))
+	return fp, func() { _ = os.RemoveAll(td) }
+}
+
+func TestCommand(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewMockCLI()
+	cmd := Command(cli)
+
+	assert.NotNil(cmd, "cmd should be returned")
+	assert.NotNil(cmd.RunE, "cmd should be able to be executed")
+	assert.Regexp("validate", cmd.Use)
+	assert.NotNil(cmd.Flag("file"))
+}
+
+func TestCommandRunEClosure(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewMockCLI()
+	config := cli.Config.(*client.MockConfig)
+	config.On("Format").Return("tabular")
+
+	fp, cleanup := writeInput(t, handlers)
+	defer cleanup()
+
+	cmd := Command(cli)
+	require.NoError(t, cmd.Flags().Set("file", fp))
+	out, err := test.RunCmd(cmd, []string{})
+
+	assert.Contains(out, "handlers/magic")
+	assert.Contains(out, "unknown handler type")
+	assert.Contains(out, "handlers/pagerduty")
+	assert.Contains(out, "filters/business hours")
+	assert.NotContains(out, "handlers/slack")
+	assert.EqualError(err, "found 2 invalid resource(s) out of 3")
+}
+
+func TestCommandRunEClosureValid(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewMockCLI()
+	config := cli.Config.(*client.MockConfig)
+	config.On("Format").Return("tabular")
+
+	fp, cleanup := writeInput(t, validHandler)
+	defer cleanup()
+
+	cmd := Command(cli)
+	require.NoError(t, cmd.Flags().Set("file", fp))
+	out, err := test.RunCmd(cmd, []string{})
+
+	assert.Contains(out, "All resources are valid")
+	assert.NoError(err)
+
+	// The API is never called
+	cli.Client.(*client.MockClient).AssertExpectations(t)
+}
+
+func TestCommandRunEClosureDirectory(t *testing.T) {
+	assert := assert.New(t)
+
+	cli := test.NewMockCLI()
+	config := cli.Config.(*client.MockConfig)
+	config.On("Format").Return("json")
+
+	td, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(td)
+	malformed := filepath.Join(td, "a.yaml")
+	require.NoError(t, ioutil.WriteFile(malformed, []byte("type: Handler\n  spec: [\n"), 0644))
+	invalid := filepath.Join(td, "b.yaml")
+	require.NoError(t, ioutil.WriteFile(invalid, []byte(handlers), 0644))
+
+	// The files after the malformed one are validated, and the invalid
+	// resources are identified by their file
+	cmd := Command(cli)
+	require.NoError(t, cmd.Flags().Set("file", td))
+	out, err := test.RunCmd(cmd, []string{})
+
+	var got []invalidResource
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+	require.Len(t, got, 3)
+	assert.Equal(malformed, got[0].Input)
+	assert.Empty(got[0].Resource)
+	assert.Equal(invalidResource{Input: invalid, Index: 1, Resource: "handlers/magic", Namespace: "default", Error: got[1].Error}, got[1])
+	assert.Equal(invalid, got[2].Input)
+	assert.EqualError(err, "found 2 invalid resource(s) out of 3, and 1 input(s) that couldn't be parsed")
+}
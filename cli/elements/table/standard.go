@@ -1,13 +1,35 @@
 package table
 
 import (
+	"fmt"
 	"io"
+	"os"
 	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/sensu/sensu-go/cli/elements/globals"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
+// minColumnWidth is the width under which columns are not truncated to fit
+// the terminal
+const minColumnWidth = 8
+
+// ansiRe matches the ANSI escape sequences used to style the cells
+var ansiRe = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// noHeader disables the header of the tables
+var noHeader bool
+
+// SetNoHeader enables or disables the header of the tables. It is configured
+// once, from the --no-header flag.
+func SetNoHeader(disabled bool) {
+	noHeader = disabled
+}
+
 var (
 	// TitleStyle can be used to format a string; suitable for titles
 	TitleStyle = globals.TitleStyle
@@ -58,33 +80,53 @@ func New(columns []*Column) *Table {
 	return &Table{Columns: columns}
 }
 
-// Render renders table to STDOUT given row values
+// Render renders table to STDOUT given row values. When the output is a
+// terminal, the columns are truncated to fit its width; otherwise the table is
+// rendered untruncated and unstyled, as tab-separated values, so that it can
+// be processed by other programs.
 func (t *Table) Render(io io.Writer, results interface{}) {
+	width, tty := terminalWidth(io)
+	t.render(io, results, width, tty)
+}
+
+func (t *Table) render(w io.Writer, results interface{}, width int, tty bool) {
+	rows := t.rows(results)
+	if !tty {
+		t.renderTSV(w, rows)
+		return
+	}
+
+	if width > 0 {
+		t.fit(rows, width)
+	}
+
 	// (Shallow) copy standard writer
-	t.writer = newWriter(io)
-	t.writeColumns()
-	t.writeRows(results)
+	t.writer = newWriter(w)
+	if !noHeader {
+		t.writeColumns()
+	}
+	for _, cells := range rows {
+		t.writer.Append(cells)
+	}
 	t.writer.Render()
 }
 
-func (t *Table) writeRows(results interface{}) {
+// rows returns the styled cells of each row
+func (t *Table) rows(results interface{}) [][]string {
 	if reflect.TypeOf(results).Kind() != reflect.Slice {
-		return
+		return nil
 	}
 
 	slice := reflect.ValueOf(results)
 
-	rows := make([]*Row, slice.Len())
+	rows := make([][]string, 0, slice.Len())
 	for i := 0; i < slice.Len(); i++ {
-		rows[i] = &Row{Value: slice.Index(i).Interface()}
-	}
-
-	for _, row := range rows {
-		t.writeRow(row)
+		rows = append(rows, t.cells(&Row{Value: slice.Index(i).Interface()}))
 	}
+	return rows
 }
 
-func (t *Table) writeRow(row *Row) {
+func (t *Table) cells(row *Row) []string {
 	var cells []string
 	for _, column := range t.Columns {
 		cell := column.CellTransformer(row.Value)
@@ -95,8 +137,7 @@ func (t *Table) writeRow(row *Row) {
 
 		cells = append(cells, cell)
 	}
-
-	t.writer.Append(cells)
+	return cells
 }
 
 func (t *Table) writeColumns() {
@@ -109,6 +150,108 @@ func (t *Table) writeColumns() {
 	t.writer.SetHeader(fmtTitles)
 }
 
+// renderTSV writes the header and the rows as tab-separated values, without
+// styles
+func (t *Table) renderTSV(w io.Writer, rows [][]string) {
+	if !noHeader {
+		titles := make([]string, len(t.Columns))
+		for i, column := range t.Columns {
+			titles[i] = column.Title
+		}
+		_, _ = fmt.Fprintln(w, strings.Join(titles, "\t"))
+	}
+
+	replacer := strings.NewReplacer("\t", " ", "\n", " ")
+	for _, cells := range rows {
+		values := make([]string, len(cells))
+		for i, cell := range cells {
+			values[i] = replacer.Replace(ansiRe.ReplaceAllString(cell, ""))
+		}
+		_, _ = fmt.Fprintln(w, strings.Join(values, "\t"))
+	}
+}
+
+// fit truncates the cells of the widest columns, so that the table fits in
+// the given width
+func (t *Table) fit(rows [][]string, width int) {
+	widths := make([]int, len(t.Columns))
+	minWidths := make([]int, len(t.Columns))
+	for i, column := range t.Columns {
+		widths[i] = utf8.RuneCountInString(column.Title)
+		minWidths[i] = widths[i]
+		if minWidths[i] < minColumnWidth {
+			minWidths[i] = minColumnWidth
+		}
+	}
+	for _, cells := range rows {
+		for i, cell := range cells {
+			if n := visibleLen(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+
+	// Each column is padded with a space on both sides and separated from
+	// the next one by a space
+	total := 3 * len(widths)
+	for _, w := range widths {
+		total += w
+	}
+	for total > width {
+		widest := -1
+		for i, w := range widths {
+			if w > minWidths[i] && (widest < 0 || w > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			// The columns can't be truncated any further
+			break
+		}
+		widths[widest]--
+		total--
+	}
+
+	for _, cells := range rows {
+		for i, cell := range cells {
+			cells[i] = truncate(cell, widths[i])
+		}
+	}
+}
+
+// truncate shortens the cell to the given width. The styles of truncated cells
+// are removed.
+func truncate(cell string, width int) string {
+	if visibleLen(cell) <= width {
+		return cell
+	}
+	runes := []rune(ansiRe.ReplaceAllString(cell, ""))
+	return string(runes[:width-1]) + "…"
+}
+
+// visibleLen returns the number of characters of the cell, without its styles
+func visibleLen(cell string) int {
+	return utf8.RuneCountInString(ansiRe.ReplaceAllString(cell, ""))
+}
+
+// terminalWidth returns the width of the terminal w writes to, or 0 if it
+// can't be determined, and whether w is a terminal
+func terminalWidth(w io.Writer) (int, bool) {
+	f, ok := w.(*os.File)
+	if !ok {
+		return 0, false
+	}
+	fd := int(f.Fd())
+	if !terminal.IsTerminal(fd) {
+		return 0, false
+	}
+	width, _, err := terminal.GetSize(fd)
+	if err != nil {
+		return 0, true
+	}
+	return width, true
+}
+
 func newWriter(io io.Writer) *tablewriter.Table {
 	stdTableWriter := tablewriter.NewWriter(io)
 
@@ -26,12 +26,14 @@ func TestStandardTable(t *testing.T) {
 			},
 		},
 	})
-	table.Render(
+	table.render(
 		&writer,
 		[]*Row{
 			{Value: "blah"},
 			{Value: "blah blah"},
 		},
+		0,
+		true,
 	)
 
 	lines := strings.Split(writer.result, "\n")
@@ -61,6 +63,64 @@ func TestStandardTable(t *testing.T) {
 	assert.NotContains(row2, PrimaryTextStyle("cell-two"))
 }
 
+func TestTableNonTTY(t *testing.T) {
+	writer := exWriter{}
+
+	table := New([]*Column{
+		{
+			Title:       "One",
+			ColumnStyle: PrimaryTextStyle,
+			CellTransformer: func(data interface{}) string {
+				return data.(string)
+			},
+		},
+		{
+			Title: "Two",
+			CellTransformer: func(_ interface{}) string {
+				return "cell\ttwo"
+			},
+		},
+	})
+	table.Render(&writer, []string{"a very long value that is not truncated"})
+
+	assert.Equal(t, "One\tTwo\na very long value that is not truncated\tcell two\n", writer.result)
+}
+
+func TestTableNoHeader(t *testing.T) {
+	SetNoHeader(true)
+	defer SetNoHeader(false)
+
+	writer := exWriter{}
+	table := New([]*Column{
+		{
+			Title: "One",
+			CellTransformer: func(_ interface{}) string {
+				return "cell-one"
+			},
+		},
+	})
+	table.Render(&writer, []string{"blah"})
+
+	assert.Equal(t, "cell-one\n", writer.result)
+}
+
+func TestTableFit(t *testing.T) {
+	table := New([]*Column{
+		{Title: "Name"},
+		{Title: "Output"},
+	})
+	rows := [][]string{
+		{"check-cpu", strings.Repeat("x", 100)},
+		{"check-mem", PrimaryTextStyle("ok")},
+	}
+	table.fit(rows, 40)
+
+	assert.Equal(t, "check-cpu", rows[0][0])
+	assert.Equal(t, 40-6-9, visibleLen(rows[0][1]))
+	assert.True(t, strings.HasSuffix(rows[0][1], "…"))
+	assert.Equal(t, PrimaryTextStyle("ok"), rows[1][1])
+}
+
 type exWriter struct {
 	result string
 }
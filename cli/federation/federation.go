@@ -0,0 +1,257 @@
+// Package federation runs sensuctl list and info commands against all the
+// configured clusters, and merges their results.
+package federation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client/config"
+	"github.com/sensu/sensu-go/cli/client/config/basic"
+	"github.com/sensu/sensu-go/cli/commands/flags"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/elements/list"
+	"github.com/sensu/sensu-go/cli/elements/table"
+	"github.com/spf13/cobra"
+)
+
+// ClusterField is the name of the field, or the title of the column, holding
+// the name of the cluster of each result
+const ClusterField = "cluster"
+
+// NewRootFunc returns the sensuctl root command, with all of its
+// subcommands, for the given CLI
+type NewRootFunc func(*cli.SensuCli) *cobra.Command
+
+// result is the output of a command run against a cluster
+type result struct {
+	cluster string
+	output  bytes.Buffer
+	err     error
+}
+
+// Execute runs the list or info command given by args against all the
+// clusters of conf concurrently, and writes their merged results to out. The
+// errors of individual clusters are written to errOut.
+func Execute(conf *basic.Config, newRoot NewRootFunc, args []string, out, errOut io.Writer) error {
+	cmd, cmdArgs, err := newRoot(cli.NewWithConfig(conf)).Find(args)
+	if err != nil {
+		return err
+	}
+	if cmd.Name() != "list" && cmd.Name() != "info" {
+		return fmt.Errorf("--cluster %s is only supported by list and info commands", config.AllClusters)
+	}
+	if err := cmd.ParseFlags(cmdArgs); err != nil {
+		return err
+	}
+
+	names, err := conf.ClusterNames()
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		return errors.New("no cluster configured")
+	}
+
+	format := conf.Format()
+	if f := helpers.GetChangedStringValueFlag(flags.Format, cmd.Flags()); f != "" {
+		format = f
+	}
+	if cmd.Flags().Lookup(flags.Format) == nil {
+		format = config.FormatTabular
+	}
+
+	// The clusters always print the header of tabular output, so that their
+	// tables can be merged
+	noHeader, _ := cmd.Flags().GetBool("no-header")
+	table.SetNoHeader(false)
+	results := run(conf, newRoot, args, names, clusterFormat(format))
+	table.SetNoHeader(noHeader)
+
+	failed := 0
+	for _, r := range results {
+		if r.err != nil {
+			failed++
+			fmt.Fprintf(errOut, "Error: cluster %s: %s\n", r.cluster, r.err)
+		}
+	}
+
+	switch {
+	case format == config.FormatJSON || format == config.FormatWrappedJSON:
+		err = printJSON(results, out, helpers.PrintJSON)
+	case format == config.FormatYAML:
+		err = printJSON(results, out, helpers.PrintYAML)
+	case cmd.Name() == "list":
+		printTable(results, out, errOut)
+	default:
+		err = printSections(results, out)
+	}
+	if err != nil {
+		return err
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d clusters failed", failed, len(results))
+	}
+	return nil
+}
+
+// clusterFormat returns the format in which the clusters print their results,
+// for them to be merged in the given format
+func clusterFormat(format string) string {
+	switch format {
+	case config.FormatJSON, config.FormatWrappedJSON:
+		return format
+	case config.FormatYAML:
+		return config.FormatWrappedJSON
+	default:
+		return config.FormatTabular
+	}
+}
+
+// run runs the command given by args against each cluster concurrently, with
+// its own CLI and commands
+func run(conf *basic.Config, newRoot NewRootFunc, args, names []string, format string) []*result {
+	results := make([]*result, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		results[i] = &result{cluster: name}
+		wg.Add(1)
+		go func(r *result) {
+			defer wg.Done()
+			clusterConf, err := conf.WithCluster(r.cluster)
+			if err != nil {
+				r.err = err
+				return
+			}
+			root := newRoot(cli.NewWithConfig(clusterConf))
+			root.SilenceErrors = true
+			root.SetOutput(&r.output)
+
+			clusterArgs := append([]string{}, args...)
+			clusterArgs = append(clusterArgs, "--cluster", r.cluster)
+			if format != config.FormatTabular {
+				clusterArgs = append(clusterArgs, "--"+flags.Format, format)
+			}
+			root.SetArgs(clusterArgs)
+			r.err = root.Execute()
+		}(results[i])
+	}
+	wg.Wait()
+	return results
+}
+
+// printJSON merges the JSON results of the clusters in a single list, where
+// each object has a cluster field, and prints it with print
+func printJSON(results []*result, out io.Writer, print func(interface{}, io.Writer) error) error {
+	merged := []interface{}{}
+	for _, r := range results {
+		if r.err != nil {
+			continue
+		}
+		dec := json.NewDecoder(&r.output)
+		for {
+			var v interface{}
+			if err := dec.Decode(&v); err == io.EOF {
+				break
+			} else if err != nil {
+				return fmt.Errorf("invalid output of cluster %s: %s", r.cluster, err)
+			}
+			if values, ok := v.([]interface{}); ok {
+				for _, value := range values {
+					merged = append(merged, withCluster(value, r.cluster))
+				}
+			} else if v != nil {
+				merged = append(merged, withCluster(v, r.cluster))
+			}
+		}
+	}
+	return print(merged, out)
+}
+
+// withCluster adds the cluster field to the value, or wraps it in an object
+// with the cluster field if it isn't an object
+func withCluster(v interface{}, cluster string) interface{} {
+	if object, ok := v.(map[string]interface{}); ok {
+		object[ClusterField] = cluster
+		return object
+	}
+	return map[string]interface{}{ClusterField: cluster, "value": v}
+}
+
+// printTable merges the tables of the clusters, which are printed as
+// tab-separated values since their output is not a terminal, in a single
+// table with a cluster column. The titles of the clusters, such as warnings,
+// are written to errOut.
+func printTable(results []*result, out, errOut io.Writer) {
+	var titles []string
+	var rows [][]string
+	for _, r := range results {
+		if r.err != nil {
+			continue
+		}
+		header := true
+		for _, line := range strings.Split(strings.TrimRight(r.output.String(), "\n"), "\n") {
+			if line == "" {
+				continue
+			}
+			if strings.HasPrefix(line, "=== ") {
+				fmt.Fprintf(errOut, "Warning: cluster %s: %s\n", r.cluster, strings.TrimPrefix(line, "=== "))
+				continue
+			}
+			cells := strings.Split(line, "\t")
+			if header {
+				header = false
+				if titles == nil {
+					titles = cells
+				}
+				continue
+			}
+			rows = append(rows, append([]string{r.cluster}, cells...))
+		}
+	}
+	if titles == nil {
+		return
+	}
+
+	columns := make([]*table.Column, 0, len(titles)+1)
+	for i, title := range append([]string{strings.Title(ClusterField)}, titles...) {
+		i := i
+		columns = append(columns, &table.Column{
+			Title: title,
+			CellTransformer: func(data interface{}) string {
+				cells, ok := data.([]string)
+				if !ok || i >= len(cells) {
+					return ""
+				}
+				return cells[i]
+			},
+		})
+	}
+	table.New(columns).Render(out, rows)
+}
+
+// printSections prints the tabular results of the clusters one after the
+// other, each under the name of its cluster
+func printSections(results []*result, out io.Writer) error {
+	for _, r := range results {
+		if r.err != nil {
+			continue
+		}
+		cfg := &list.Config{Title: fmt.Sprintf("%s: %s", strings.Title(ClusterField), r.cluster)}
+		if err := list.Print(out, cfg); err != nil {
+			return err
+		}
+		if _, err := r.output.WriteTo(out); err != nil {
+			return err
+		}
+		fmt.Fprintln(out)
+	}
+	return nil
+}
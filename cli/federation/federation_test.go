@@ -0,0 +1,124 @@
+package federation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client/config/basic"
+	"github.com/sensu/sensu-go/cli/commands/helpers"
+	"github.com/sensu/sensu-go/cli/elements/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	Name string `json:"name"`
+}
+
+// testRoot returns a root command with list and delete commands, which list
+// the API URL of the cluster and fail for the "broken" cluster
+func testRoot(c *cli.SensuCli) *cobra.Command {
+	root := &cobra.Command{Use: "sensuctl", SilenceUsage: true}
+	root.PersistentFlags().String("cluster", "", "")
+	root.PersistentFlags().Bool("no-header", false, "")
+
+	list := &cobra.Command{
+		Use: "list",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if strings.Contains(c.Config.APIUrl(), "broken") {
+				return errors.New("connection refused")
+			}
+			results := []item{{Name: c.Config.APIUrl()}}
+			return helpers.Print(cmd, c.Config.Format(), printItems, nil, results)
+		},
+	}
+	helpers.AddFormatFlag(list.Flags())
+
+	root.AddCommand(list, &cobra.Command{Use: "delete", RunE: func(*cobra.Command, []string) error { return nil }})
+	return root
+}
+
+func printItems(results interface{}, w io.Writer) {
+	table.New([]*table.Column{
+		{
+			Title: "Name",
+			CellTransformer: func(data interface{}) string {
+				return data.(item).Name
+			},
+		},
+	}).Render(w, results)
+}
+
+// testConfig returns a configuration, and its directory, with the given
+// clusters
+func testConfig(t *testing.T, clusters ...string) (*basic.Config, string) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "sensuctl")
+	require.NoError(t, err)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "clusters"), 0755))
+	for _, name := range clusters {
+		path := filepath.Join(dir, "clusters", name)
+		if name == "default" {
+			path = filepath.Join(dir, "cluster")
+		}
+		b, _ := json.Marshal(basic.Cluster{APIUrl: "https://" + name})
+		require.NoError(t, ioutil.WriteFile(path, b, 0644))
+	}
+
+	flags := pflag.NewFlagSet("sensuctl", pflag.ContinueOnError)
+	flags.String("config-dir", dir, "")
+	return basic.Load(flags), dir
+}
+
+func TestExecuteTable(t *testing.T) {
+	conf, dir := testConfig(t, "default", "us")
+	defer os.RemoveAll(dir)
+	var out, errOut bytes.Buffer
+	require.NoError(t, Execute(conf, testRoot, []string{"list", "--cluster", "all"}, &out, &errOut))
+
+	assert.Equal(t, "Cluster\tName\ndefault\thttps://default\nus\thttps://us\n", out.String())
+	assert.Empty(t, errOut.String())
+}
+
+func TestExecuteJSON(t *testing.T) {
+	conf, dir := testConfig(t, "default", "us")
+	defer os.RemoveAll(dir)
+	var out, errOut bytes.Buffer
+	require.NoError(t, Execute(conf, testRoot, []string{"list", "--format", "json"}, &out, &errOut))
+
+	var results []map[string]string
+	require.NoError(t, json.Unmarshal(out.Bytes(), &results))
+	assert.Equal(t, []map[string]string{
+		{"cluster": "default", "name": "https://default"},
+		{"cluster": "us", "name": "https://us"},
+	}, results)
+}
+
+func TestExecuteClusterError(t *testing.T) {
+	conf, dir := testConfig(t, "broken", "us")
+	defer os.RemoveAll(dir)
+	var out, errOut bytes.Buffer
+	err := Execute(conf, testRoot, []string{"list"}, &out, &errOut)
+	assert.EqualError(t, err, "1 of 2 clusters failed")
+
+	assert.Equal(t, "Cluster\tName\nus\thttps://us\n", out.String())
+	assert.Contains(t, errOut.String(), "cluster broken: connection refused")
+}
+
+func TestExecuteUnsupportedCommand(t *testing.T) {
+	conf, dir := testConfig(t, "us")
+	defer os.RemoveAll(dir)
+	var out, errOut bytes.Buffer
+	assert.Error(t, Execute(conf, testRoot, []string{"delete"}, &out, &errOut))
+}
@@ -0,0 +1,109 @@
+package resource
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/sensu/sensu-go/types"
+)
+
+// envRefRE matches the ${VAR} references to environment variables, optionally
+// escaped with a leading $.
+var envRefRE = regexp.MustCompile(`\$?\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// ExpandEnv replaces the ${VAR} references of the string values of the
+// resources with the values of the environment variables returned by lookup,
+// such as os.LookupEnv. The references are expanded once the resources are
+// parsed, so that the values are never interpreted as JSON or YAML, and can't
+// alter the structure of the resources. Other uses of $, e.g. $VAR in check
+// commands, are left untouched, and $${VAR} is replaced with a literal ${VAR}.
+//
+// An error listing the undefined variables is returned if some references
+// can't be expanded, rather than silently replacing them with empty strings.
+func ExpandEnv(resources []*types.Wrapper, lookup func(string) (string, bool)) error {
+	e := &envExpander{lookup: lookup, seen: map[string]bool{}}
+	for _, resource := range resources {
+		e.expand(reflect.ValueOf(resource).Elem())
+	}
+	if len(e.undefined) > 0 {
+		return fmt.Errorf("undefined environment variable(s): %s", strings.Join(e.undefined, ", "))
+	}
+	return nil
+}
+
+// envExpander expands the references to environment variables of the string
+// values of resources, and records the undefined variables.
+type envExpander struct {
+	lookup    func(string) (string, bool)
+	seen      map[string]bool
+	undefined []string
+}
+
+// expand expands the references of the string values reachable from v.
+func (e *envExpander) expand(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			e.expand(v.Elem())
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+		elem := v.Elem()
+		if elem.Kind() == reflect.Ptr {
+			e.expand(elem)
+			return
+		}
+		// The value of an interface can't be modified in place
+		if v.CanSet() {
+			value := reflect.New(elem.Type()).Elem()
+			value.Set(elem)
+			e.expand(value)
+			v.Set(value)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if field := v.Field(i); field.CanSet() {
+				e.expand(field)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			e.expand(v.Index(i))
+		}
+	case reflect.Map:
+		for _, key := range v.MapKeys() {
+			value := reflect.New(v.Type().Elem()).Elem()
+			value.Set(v.MapIndex(key))
+			e.expand(value)
+			v.SetMapIndex(key, value)
+		}
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(e.expandString(v.String()))
+		}
+	}
+}
+
+// expandString expands the references of s.
+func (e *envExpander) expandString(s string) string {
+	return envRefRE.ReplaceAllStringFunc(s, func(ref string) string {
+		if ref[1] == '$' {
+			// Escaped reference
+			return ref[1:]
+		}
+		name := ref[2 : len(ref)-1]
+		value, ok := e.lookup(name)
+		if !ok {
+			if !e.seen[name] {
+				e.seen[name] = true
+				e.undefined = append(e.undefined, name)
+			}
+			return ref
+		}
+		return value
+	})
+}
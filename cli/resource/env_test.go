@@ -0,0 +1,89 @@
+package resource
+
+import (
+	"strings"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandEnv(t *testing.T) {
+	env := map[string]string{
+		"SLACK_URL": "https://hooks.slack.com/services/xyz",
+		"EMPTY":     "",
+		"INJECTION": "x\"\n  timeout: 5\n  \"y: z",
+	}
+	lookup := func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    func(*testing.T, *corev2.Handler)
+		wantErr string
+	}{
+		{
+			name: "reference",
+			data: `{"type": "Handler", "spec": {"metadata": {"name": "slack"}, "type": "pipe", "env_vars": ["WEBHOOK_URL=${SLACK_URL}"]}}`,
+			want: func(t *testing.T, handler *corev2.Handler) {
+				assert.Equal(t, []string{"WEBHOOK_URL=https://hooks.slack.com/services/xyz"}, handler.EnvVars)
+			},
+		},
+		{
+			name: "empty value",
+			data: `{"type": "Handler", "spec": {"metadata": {"name": "slack", "labels": {"team": "prefix${EMPTY}suffix"}}, "type": "pipe"}}`,
+			want: func(t *testing.T, handler *corev2.Handler) {
+				assert.Equal(t, "prefixsuffix", handler.Labels["team"])
+			},
+		},
+		{
+			name: "shell variables are untouched",
+			data: "type: Handler\nspec:\n  metadata:\n    name: slack\n  type: pipe\n  command: echo $HOME $$ $1\n",
+			want: func(t *testing.T, handler *corev2.Handler) {
+				assert.Equal(t, "echo $HOME $$ $1", handler.Command)
+			},
+		},
+		{
+			name: "escaped reference",
+			data: "type: Handler\nspec:\n  metadata:\n    name: slack\n  type: pipe\n  command: echo $${SLACK_URL}\n",
+			want: func(t *testing.T, handler *corev2.Handler) {
+				assert.Equal(t, "echo ${SLACK_URL}", handler.Command)
+			},
+		},
+		{
+			name: "values are not interpreted",
+			data: "type: Handler\nspec:\n  metadata:\n    name: slack\n  type: pipe\n  command: notify ${INJECTION}\n",
+			want: func(t *testing.T, handler *corev2.Handler) {
+				assert.Equal(t, "notify "+env["INJECTION"], handler.Command)
+				assert.Equal(t, uint32(0), handler.Timeout)
+			},
+		},
+		{
+			name:    "undefined variables",
+			data:    `{"type": "Handler", "spec": {"metadata": {"name": "${TOKEN}"}, "type": "pipe", "command": "${SLACK_URL} ${REGION} ${TOKEN}"}}`,
+			wantErr: "undefined environment variable(s): TOKEN, REGION",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resources, err := Parse(strings.NewReader(tt.data))
+			require.NoError(t, err)
+			require.Len(t, resources, 1)
+
+			err = ExpandEnv(resources, lookup)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Equal(t, tt.wantErr, err.Error())
+				return
+			}
+			require.NoError(t, err)
+			handler, ok := resources[0].Value.(*corev2.Handler)
+			require.True(t, ok)
+			tt.want(t, handler)
+		})
+	}
+}
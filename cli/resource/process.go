@@ -22,26 +22,41 @@ type Processor interface {
 	Process(client client.GenericClient, resources []*types.Wrapper) error
 }
 
+// InputProcessor is a Processor told of the file or URL the resources it
+// processes are read from, which handles the inputs that can't be parsed, e.g.
+// to process the other inputs anyway.
+type InputProcessor interface {
+	Processor
+
+	// SetInput sets the file or URL of the resources processed next.
+	SetInput(input string)
+
+	// ParseError handles the error parsing the input. Processing stops if it
+	// returns an error.
+	ParseError(input string, err error) error
+}
+
 type httpDirectory struct {
 	XMLName xml.Name `xml:"pre"`
 	Files   []string `xml:"a"`
 }
 
-// Process processes the input.
-func Process(cli *cli.SensuCli, client *http.Client, input string, recurse bool, processor Processor) error {
+// Process processes the input. The ${VAR} references to environment variables
+// of the string values of the input are expanded if envFill is set.
+func Process(cli *cli.SensuCli, client *http.Client, input string, recurse, envFill bool, processor Processor) error {
 	urly, err := url.Parse(input)
 	if err != nil {
 		return err
 	}
 	if urly.Scheme == "" || len(urly.Scheme) == 1 {
 		// We are dealing with a file path
-		return ProcessFile(cli, input, recurse, processor)
+		return ProcessFile(cli, input, recurse, envFill, processor)
 	}
-	return ProcessURL(cli, client, urly, input, recurse, processor)
+	return ProcessURL(cli, client, urly, input, recurse, envFill, processor)
 }
 
 // ProcessFile processes a file.
-func ProcessFile(cli *cli.SensuCli, input string, recurse bool, processor Processor) error {
+func ProcessFile(cli *cli.SensuCli, input string, recurse, envFill bool, processor Processor) error {
 	var tld = true
 	return filepath.Walk(input, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -57,19 +72,13 @@ func ProcessFile(cli *cli.SensuCli, input string, recurse bool, processor Proces
 		if err != nil {
 			return err
 		}
-		resources, err := Parse(f)
-		if err != nil {
-			return fmt.Errorf("in %s: %s", input, err)
-		}
-		if err := Validate(resources, cli.Config.Namespace()); err != nil {
-			return err
-		}
-		return processor.Process(cli.Client, resources)
+		defer f.Close()
+		return processInput(cli, f, path, envFill, processor)
 	})
 }
 
 // ProcessURL processes a url.
-func ProcessURL(cli *cli.SensuCli, client *http.Client, urly *url.URL, input string, recurse bool, processor Processor) error {
+func ProcessURL(cli *cli.SensuCli, client *http.Client, urly *url.URL, input string, recurse, envFill bool, processor Processor) error {
 	req, err := http.NewRequest("GET", urly.String(), nil)
 	if err != nil {
 		return err
@@ -96,15 +105,34 @@ func ProcessURL(cli *cli.SensuCli, client *http.Client, urly *url.URL, input str
 			return err
 		}
 		for _, file := range dir.Files {
-			if err := Process(cli, client, filepath.Join(input, file), recurse, processor); err != nil {
+			if err := Process(cli, client, filepath.Join(input, file), recurse, envFill, processor); err != nil {
 				return err
 			}
 		}
 	}
 
-	resources, err := Parse(resp.Body)
+	return processInput(cli, resp.Body, input, envFill, processor)
+}
+
+// ProcessStdin processes standard in.
+func ProcessStdin(cli *cli.SensuCli, client *http.Client, envFill bool, processor Processor) error {
+	return processInput(cli, os.Stdin, "stdin", envFill, processor)
+}
+
+// processInput parses the resources read from in and processes them. The
+// parse errors are handled by the processor if it's an InputProcessor.
+func processInput(cli *cli.SensuCli, in io.Reader, input string, envFill bool, processor Processor) error {
+	inputProcessor, ok := processor.(InputProcessor)
+	if ok {
+		inputProcessor.SetInput(input)
+	}
+	resources, err := parse(in, envFill)
 	if err != nil {
-		return fmt.Errorf("in %s: %s", input, err)
+		err = fmt.Errorf("in %s: %s", input, err)
+		if ok {
+			return inputProcessor.ParseError(input, err)
+		}
+		return err
 	}
 	if err := Validate(resources, cli.Config.Namespace()); err != nil {
 		return err
@@ -112,16 +140,17 @@ func ProcessURL(cli *cli.SensuCli, client *http.Client, urly *url.URL, input str
 	return processor.Process(cli.Client, resources)
 }
 
-// ProcessStdin processes standard in.
-func ProcessStdin(cli *cli.SensuCli, client *http.Client, processor Processor) error {
-	resources, err := Parse(os.Stdin)
-	if err != nil {
-		return fmt.Errorf("in stdin: %s", err)
+// parse parses the resources of in, and expands the references to environment
+// variables of their string values if envFill is set.
+func parse(in io.Reader, envFill bool) ([]*types.Wrapper, error) {
+	resources, err := Parse(in)
+	if err != nil || !envFill {
+		return resources, err
 	}
-	if err := Validate(resources, cli.Config.Namespace()); err != nil {
-		return err
+	if err := ExpandEnv(resources, os.LookupEnv); err != nil {
+		return nil, err
 	}
-	return processor.Process(cli.Client, resources)
+	return resources, nil
 }
 
 // Putter is a Processor that puts resources in the API.
@@ -144,3 +173,15 @@ func (p *Putter) Process(client client.GenericClient, resources []*types.Wrapper
 	}
 	return nil
 }
+
+// Collector is a Processor that collects resources, so that they can be
+// processed at once.
+type Collector struct {
+	Resources []*types.Wrapper
+}
+
+// Process collects the resources.
+func (c *Collector) Process(client client.GenericClient, resources []*types.Wrapper) error {
+	c.Resources = append(c.Resources, resources...)
+	return nil
+}
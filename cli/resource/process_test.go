@@ -45,7 +45,7 @@ func TestProcessURL(t *testing.T) {
 
 	urly, err := url.Parse(ts.URL)
 	assert.NoError(t, err)
-	assert.NoError(t, ProcessURL(&cli.SensuCli{Config: config}, ts.Client(), urly, ts.URL, false, processor))
+	assert.NoError(t, ProcessURL(&cli.SensuCli{Config: config}, ts.Client(), urly, ts.URL, false, false, processor))
 }
 
 func TestProcessFile(t *testing.T) {
@@ -61,5 +61,31 @@ func TestProcessFile(t *testing.T) {
 	processor.On("Process", mock.Anything, mock.Anything).Return(nil)
 	config := &config.MockConfig{}
 	config.On("Namespace").Return("")
-	assert.NoError(t, ProcessFile(&cli.SensuCli{Config: config}, fp, false, processor))
+	assert.NoError(t, ProcessFile(&cli.SensuCli{Config: config}, fp, false, false, processor))
+}
+
+func TestProcessFileEnvFill(t *testing.T) {
+	td, err := ioutil.TempDir("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(td)
+
+	fp := filepath.Join(td, "input")
+	err = ioutil.WriteFile(fp, []byte(`{"type": "Namespace", "spec": {"name": "${SENSU_TEST_NAMESPACE}"}}`), 0644)
+	require.NoError(t, err)
+
+	require.NoError(t, os.Setenv("SENSU_TEST_NAMESPACE", "staging"))
+	defer os.Unsetenv("SENSU_TEST_NAMESPACE")
+
+	processor := &mockProcessor{}
+	processor.On("Process", mock.Anything, mock.MatchedBy(func(resources []*types.Wrapper) bool {
+		return len(resources) == 1 && resources[0].Value.GetObjectMeta().Name == "staging"
+	})).Return(nil)
+	config := &config.MockConfig{}
+	config.On("Namespace").Return("")
+	assert.NoError(t, ProcessFile(&cli.SensuCli{Config: config}, fp, false, true, processor))
+
+	require.NoError(t, os.Unsetenv("SENSU_TEST_NAMESPACE"))
+	err = ProcessFile(&cli.SensuCli{Config: config}, fp, false, true, processor)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "undefined environment variable(s): SENSU_TEST_NAMESPACE")
 }
@@ -19,7 +19,9 @@ var (
 		&corev2.APIKey{},
 		&corev2.TessenConfig{},
 		&corev2.Asset{},
+		&corev2.CheckTemplate{},
 		&corev2.CheckConfig{},
+		&corev2.CompositeCheck{},
 		&corev2.Entity{},
 		&corev2.Event{},
 		&corev2.EventFilter{},
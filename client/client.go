@@ -0,0 +1,246 @@
+// Package client provides a Go client of the Sensu backend API, for building
+// automation against the API without shelling out to sensuctl.
+//
+// Every method takes a context, which bounds the whole request including its
+// retries. The errors returned for unsuccessful responses are of type
+// *APIError, and can be inspected with errors.Is against ErrNotFound,
+// ErrAlreadyExists, etc.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/version"
+)
+
+const (
+	// DefaultTimeout is the default timeout of each request attempt.
+	DefaultTimeout = 15 * time.Second
+
+	// DefaultMaxRetries is the default number of times failed requests are
+	// retried.
+	DefaultMaxRetries = 3
+
+	// DefaultRetryWait is the default wait before the first retry of a
+	// request, which doubles with each retry.
+	DefaultRetryWait = 500 * time.Millisecond
+)
+
+// Config configures a Client.
+type Config struct {
+	// URL is the URL of the backend API, e.g. https://sensu.example.com:8080.
+	URL string
+
+	// APIKey authenticates the requests with an API key. Optional.
+	APIKey string
+
+	// AccessToken authenticates the requests with an access token, as returned
+	// by Authenticate. Optional.
+	AccessToken string
+
+	// TLSConfig configures the TLS connections to the API. Ignored if
+	// HTTPClient is set.
+	TLSConfig *tls.Config
+
+	// Timeout is the timeout of each request, retries included. Defaults to
+	// DefaultTimeout. Ignored if HTTPClient is set.
+	Timeout time.Duration
+
+	// MaxRetries is the number of times the requests failing with a network
+	// error or a temporary server error are retried. Defaults to
+	// DefaultMaxRetries, negative values disable the retries.
+	MaxRetries int
+
+	// RetryWait is the wait before the first retry of a request, which doubles
+	// with each retry. Defaults to DefaultRetryWait.
+	RetryWait time.Duration
+
+	// HTTPClient is the HTTP client sending the requests, through a Transport
+	// wrapping its own. Optional.
+	HTTPClient *http.Client
+
+	// UserAgent is the user agent of the requests. Optional.
+	UserAgent string
+}
+
+// Client is a client of the Sensu backend API. It is safe for concurrent use.
+type Client struct {
+	baseURL   *url.URL
+	http      *http.Client
+	transport *Transport
+	apiKey    string
+	userAgent string
+
+	mu          sync.RWMutex
+	accessToken string
+}
+
+// New creates a new Client.
+func New(config Config) (*Client, error) {
+	if config.URL == "" {
+		return nil, errors.New("the API URL is required")
+	}
+	baseURL, err := url.Parse(strings.TrimSuffix(config.URL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid API URL: %s", err)
+	}
+
+	var httpClient http.Client
+	if config.HTTPClient != nil {
+		httpClient = *config.HTTPClient
+	} else {
+		timeout := config.Timeout
+		if timeout == 0 {
+			timeout = DefaultTimeout
+		}
+		base := http.DefaultTransport.(*http.Transport).Clone()
+		base.TLSClientConfig = config.TLSConfig
+		httpClient = http.Client{Transport: base, Timeout: timeout}
+	}
+
+	maxRetries := config.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	} else if maxRetries < 0 {
+		maxRetries = 0
+	}
+	retryWait := config.RetryWait
+	if retryWait == 0 {
+		retryWait = DefaultRetryWait
+	}
+	userAgent := config.UserAgent
+	if userAgent == "" {
+		userAgent = "sensu-go-client/" + version.Semver()
+	}
+
+	transport := &Transport{
+		Base:       httpClient.Transport,
+		MaxRetries: maxRetries,
+		RetryWait:  retryWait,
+	}
+	httpClient.Transport = transport
+
+	return &Client{
+		baseURL:     baseURL,
+		http:        &httpClient,
+		transport:   transport,
+		apiKey:      config.APIKey,
+		userAgent:   userAgent,
+		accessToken: config.AccessToken,
+	}, nil
+}
+
+// SetAccessToken sets the access token authenticating the requests.
+func (c *Client) SetAccessToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accessToken = token
+}
+
+// Authenticate authenticates against the API with a username and password,
+// and uses the returned access token for the subsequent requests.
+func (c *Client) Authenticate(ctx context.Context, username, password string) (*corev2.Tokens, error) {
+	var tokens corev2.Tokens
+	_, err := c.do(ctx, http.MethodGet, "/auth", nil, &tokens, func(req *http.Request) {
+		req.SetBasicAuth(username, password)
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.SetAccessToken(tokens.Access)
+	return &tokens, nil
+}
+
+// Do sends a request with the JSON encoding of body, if not nil, to the path
+// of the API, and decodes the JSON response into result, if not nil. The
+// path may include a query string. Requests are retried on network errors and
+// temporary server errors by the Transport of the client. POST requests carry
+// an idempotency key, so that the API doesn't process them twice when they
+// are retried.
+func (c *Client) Do(ctx context.Context, method, path string, body, result interface{}) (*http.Response, error) {
+	return c.do(ctx, method, path, body, result, nil)
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, result interface{}, prepare func(*http.Request)) (*http.Response, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return nil, err
+		}
+	}
+	reqURL, err := c.baseURL.Parse(c.baseURL.Path + path)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.send(ctx, method, reqURL.String(), payload, prepare)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	return resp, decodeResponse(resp, result)
+}
+
+func (c *Client) send(ctx context.Context, method, url string, payload []byte, prepare func(*http.Request)) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	c.mu.RLock()
+	accessToken := c.accessToken
+	c.mu.RUnlock()
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	} else if c.apiKey != "" {
+		req.Header.Set("Authorization", "Key "+c.apiKey)
+	}
+	if prepare != nil {
+		prepare(req)
+	}
+
+	return c.http.Do(req)
+}
+
+// decodeResponse decodes the JSON response into result, or returns an
+// *APIError if the response is unsuccessful.
+func decodeResponse(resp *http.Response, result interface{}) error {
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		return newAPIError(resp, body)
+	}
+	if result == nil || len(body) == 0 {
+		return nil
+	}
+	return json.Unmarshal(body, result)
+}
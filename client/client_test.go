@@ -0,0 +1,165 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, func()) {
+	server := httptest.NewServer(handler)
+	client, err := New(Config{
+		URL:       server.URL,
+		APIKey:    "my-key",
+		RetryWait: time.Millisecond,
+	})
+	require.NoError(t, err)
+	return client, server.Close
+}
+
+func TestNewRequiresURL(t *testing.T) {
+	_, err := New(Config{})
+	assert.Error(t, err)
+}
+
+func TestGet(t *testing.T) {
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/core/v2/namespaces/default/handlers/slack", r.URL.Path)
+		assert.Equal(t, "Key my-key", r.Header.Get("Authorization"))
+		_ = json.NewEncoder(w).Encode(corev2.FixtureHandler("slack"))
+	})
+	defer stop()
+
+	handler := &corev2.Handler{ObjectMeta: corev2.ObjectMeta{Name: "slack", Namespace: "default"}}
+	require.NoError(t, client.Get(context.Background(), handler))
+	assert.Equal(t, corev2.FixtureHandler("slack").Command, handler.Command)
+}
+
+func TestPut(t *testing.T) {
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		var handler corev2.Handler
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&handler))
+		assert.Equal(t, "slack", handler.Name)
+		w.WriteHeader(http.StatusCreated)
+	})
+	defer stop()
+
+	assert.NoError(t, client.Put(context.Background(), corev2.FixtureHandler("slack")))
+}
+
+func TestAPIError(t *testing.T) {
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"not found","code":5}`))
+	})
+	defer stop()
+
+	err := client.Delete(context.Background(), corev2.FixtureHandler("slack"))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrAlreadyExists))
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, "not found", apiErr.Message)
+}
+
+func TestRetries(t *testing.T) {
+	var attempts int32
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(corev2.FixtureHandler("slack"))
+	})
+	defer stop()
+
+	assert.NoError(t, client.Get(context.Background(), corev2.FixtureHandler("slack")))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+
+	// Non idempotent requests aren't retried on server errors
+	atomic.StoreInt32(&attempts, 0)
+	_, err := client.Do(context.Background(), http.MethodPatch, "/api/core/v2/namespaces/default/handlers/slack", corev2.FixtureHandler("slack"), nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&attempts))
+}
+
+func TestRetriesIdempotencyKey(t *testing.T) {
+	var attempts int32
+	keys := map[string]bool{}
+	var mu sync.Mutex
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		keys[r.Header.Get(corev2.IdempotencyKeyHeader)] = true
+		mu.Unlock()
+		if atomic.AddInt32(&attempts, 1) < 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	defer stop()
+
+	_, err := client.Do(context.Background(), http.MethodPost, "/api/core/v2/namespaces/default/handlers", corev2.FixtureHandler("slack"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&attempts))
+	// The retry carries the same key
+	assert.Len(t, keys, 1)
+	assert.NotContains(t, keys, "")
+}
+
+func TestRetriesCanceled(t *testing.T) {
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	defer stop()
+	client.transport.RetryWait = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := client.Get(ctx, corev2.FixtureHandler("slack"))
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestList(t *testing.T) {
+	client, stop := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/core/v2/namespaces/default/handlers", r.URL.Path)
+		assert.Equal(t, "1", r.URL.Query().Get("limit"))
+		switch r.URL.Query().Get("continue") {
+		case "":
+			w.Header().Set(corev2.PaginationContinueHeader, "next")
+			_ = json.NewEncoder(w).Encode([]*corev2.Handler{corev2.FixtureHandler("a")})
+		case "next":
+			_ = json.NewEncoder(w).Encode([]*corev2.Handler{corev2.FixtureHandler("b")})
+		default:
+			t.Errorf("unexpected continue token %q", r.URL.Query().Get("continue"))
+		}
+	})
+	defer stop()
+
+	handlers := []*corev2.Handler{}
+	path := CorePath("default", "handlers")
+	require.NoError(t, client.List(context.Background(), path, &handlers, &ListOptions{ChunkSize: 1}))
+	require.Len(t, handlers, 2)
+	assert.Equal(t, "a", handlers[0].Name)
+	assert.Equal(t, "b", handlers[1].Name)
+
+	assert.Error(t, client.List(context.Background(), path, handlers, nil))
+}
+
+func TestCorePath(t *testing.T) {
+	assert.Equal(t, "/api/core/v2/namespaces/default/checks/check%20cpu", CorePath("default", "checks", "check cpu"))
+	assert.Equal(t, "/api/core/v2/namespaces", CorePath("", "namespaces"))
+}
@@ -0,0 +1,84 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// The errors matched by the *APIError of the corresponding responses with
+// errors.Is.
+var (
+	ErrInvalid         = errors.New("invalid request")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrPaymentRequired = errors.New("license required")
+	ErrNotFound        = errors.New("not found")
+	ErrAlreadyExists   = errors.New("already exists")
+	ErrTooManyRequests = errors.New("too many requests")
+)
+
+// APIError is the error returned for unsuccessful API responses.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int `json:"-"`
+
+	// Message describes the error.
+	Message string `json:"message"`
+
+	// Code is the error code of the API, if any.
+	Code uint32 `json:"code,omitempty"`
+
+	// Fields are the invalid fields of the resource sent, if any.
+	Fields []corev2.FieldError `json:"fields,omitempty"`
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{}
+	if err := json.Unmarshal(body, apiErr); err != nil || apiErr.Message == "" {
+		if message := strings.TrimSpace(string(body)); message != "" {
+			apiErr.Message = message
+		} else {
+			apiErr.Message = fmt.Sprintf("the API returned: %s", resp.Status)
+		}
+	}
+	apiErr.StatusCode = resp.StatusCode
+	return apiErr
+}
+
+// Error returns the message of the error, followed by the invalid fields of
+// the resource, if any, one per line.
+func (e *APIError) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+	var b strings.Builder
+	b.WriteString(e.Message)
+	for _, field := range e.Fields {
+		fmt.Fprintf(&b, "\n  %s: %s", field.Field, field.Message)
+	}
+	return b.String()
+}
+
+// Is returns whether the error matches the target, one of the Err* errors of
+// this package.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrInvalid:
+		return e.StatusCode == http.StatusBadRequest
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrPaymentRequired:
+		return e.StatusCode == http.StatusPaymentRequired
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrAlreadyExists:
+		return e.StatusCode == http.StatusConflict
+	case ErrTooManyRequests:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"strconv"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// ListOptions configures the listing of resources.
+type ListOptions struct {
+	// FieldSelector and LabelSelector filter the resources listed.
+	FieldSelector string
+	LabelSelector string
+
+	// ChunkSize is the number of resources fetched per page. Zero fetches all
+	// the resources at once.
+	ChunkSize int
+
+	// Continue is the token of the page to fetch, as returned by ListPage.
+	Continue string
+}
+
+// CorePath returns the path of the resources of the core/v2 API group, e.g.
+// CorePath("default", "checks", "check-cpu"). Cluster-wide resources and the
+// resources of all namespaces are addressed with an empty namespace.
+func CorePath(namespace, resource string, names ...string) string {
+	parts := []string{corev2.URLPrefix}
+	if namespace != "" {
+		parts = append(parts, "namespaces", url.PathEscape(namespace))
+	}
+	parts = append(parts, resource)
+	for _, name := range names {
+		parts = append(parts, url.PathEscape(name))
+	}
+	return path.Join(parts...)
+}
+
+// Get fetches the resource identified by the name and namespace of resource,
+// and stores it in resource.
+func (c *Client) Get(ctx context.Context, resource corev2.Resource) error {
+	_, err := c.Do(ctx, http.MethodGet, resource.URIPath(), nil, resource)
+	return err
+}
+
+// Put creates or updates the resource.
+func (c *Client) Put(ctx context.Context, resource corev2.Resource) error {
+	_, err := c.Do(ctx, http.MethodPut, resource.URIPath(), resource, nil)
+	return err
+}
+
+// Delete deletes the resource identified by the name and namespace of
+// resource.
+func (c *Client) Delete(ctx context.Context, resource corev2.Resource) error {
+	_, err := c.Do(ctx, http.MethodDelete, resource.URIPath(), nil, nil)
+	return err
+}
+
+// List lists all the resources at the path, such as a path returned by
+// CorePath, and appends them to objs, a pointer to a slice. The resources are
+// fetched in pages of options.ChunkSize resources, if set.
+func (c *Client) List(ctx context.Context, path string, objs interface{}, options *ListOptions) error {
+	if options == nil {
+		options = &ListOptions{}
+	}
+	page := *options
+	for {
+		next, err := c.ListPage(ctx, path, objs, &page)
+		if err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		page.Continue = next
+	}
+}
+
+// ListPage lists a single page of the resources at the path and appends them
+// to objs, a pointer to a slice. It returns the token of the next page, to set
+// in options.Continue, or an empty string if this page is the last one.
+func (c *Client) ListPage(ctx context.Context, path string, objs interface{}, options *ListOptions) (string, error) {
+	objsValue := reflect.ValueOf(objs)
+	if objsValue.Kind() != reflect.Ptr || objsValue.Elem().Kind() != reflect.Slice {
+		return "", errors.New("objs must be a pointer to a slice")
+	}
+	if options == nil {
+		options = &ListOptions{}
+	}
+
+	query := url.Values{}
+	if options.FieldSelector != "" {
+		query.Set("fieldSelector", options.FieldSelector)
+	}
+	if options.LabelSelector != "" {
+		query.Set("labelSelector", options.LabelSelector)
+	}
+	if options.ChunkSize > 0 {
+		query.Set("limit", strconv.Itoa(options.ChunkSize))
+	}
+	if options.Continue != "" {
+		query.Set("continue", options.Continue)
+	}
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	page := reflect.New(objsValue.Elem().Type())
+	resp, err := c.Do(ctx, http.MethodGet, path, nil, page.Interface())
+	if err != nil {
+		return "", err
+	}
+	objsValue.Elem().Set(reflect.AppendSlice(objsValue.Elem(), page.Elem()))
+
+	return resp.Header.Get(corev2.PaginationContinueHeader), nil
+}
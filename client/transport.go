@@ -0,0 +1,127 @@
+package client
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// maxRetryWait caps the wait between retries.
+const maxRetryWait = 10 * time.Second
+
+// Transport is an http.RoundTripper which retries the requests to the backend
+// API that failed with a network error or a temporary server error, when it's
+// safe to: their method is idempotent, or they carry an idempotency key, which
+// Transport adds to the POST requests. Client sends its requests through it,
+// and so does sensuctl.
+type Transport struct {
+	// Base sends the requests. Defaults to http.DefaultTransport.
+	Base http.RoundTripper
+
+	// MaxRetries is the number of times a failed request is retried.
+	MaxRetries int
+
+	// RetryWait is the wait before the first retry of a request, which
+	// doubles with each retry. The Retry-After header of the responses takes
+	// precedence.
+	RetryWait time.Duration
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	if req.Method == http.MethodPost && req.Header.Get(corev2.IdempotencyKeyHeader) == "" {
+		// The key is kept across the retries, so that the API replays the
+		// response of the first attempt it processed
+		req = req.Clone(req.Context())
+		req.Header.Set(corev2.IdempotencyKeyHeader, uuid.New().String())
+	}
+	// The body of the request is read again for each retry
+	rewindable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+
+	wait := t.RetryWait
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := base.RoundTrip(req)
+		if attempt >= t.MaxRetries || !rewindable || !ShouldRetry(req, resp, err) {
+			return resp, err
+		}
+		if resp != nil {
+			if after := retryAfter(resp); after > 0 {
+				wait = after
+			}
+			_, _ = io.Copy(ioutil.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+		if wait *= 2; wait > maxRetryWait {
+			wait = maxRetryWait
+		}
+	}
+}
+
+// ShouldRetry returns whether a request to the backend API should be retried
+// given the outcome of its last attempt, i.e. whether it failed with a network
+// error or a temporary server error and it's safe to retry it, because its
+// method is idempotent or it carries an idempotency key.
+func ShouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		// The request was canceled
+		return false
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		// The request wasn't processed, it's safe to retry whatever its method
+		return true
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+	default:
+		if req.Header.Get(corev2.IdempotencyKeyHeader) == "" {
+			return false
+		}
+	}
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter returns the wait requested by the Retry-After header of the
+// response, if any.
+func retryAfter(resp *http.Response) time.Duration {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	wait := time.Duration(seconds) * time.Second
+	if wait > maxRetryWait {
+		wait = maxRetryWait
+	}
+	return wait
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransport(t *testing.T) {
+	var attempts int32
+	keys := make(chan string, 3)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		// The body is sent again with each retry
+		assert.Equal(t, "payload", string(body))
+		keys <- r.Header.Get(corev2.IdempotencyKeyHeader)
+		if atomic.AddInt32(&attempts, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	// The transport can be used by any HTTP client, such as the one of
+	// sensuctl
+	client := &http.Client{Transport: &Transport{MaxRetries: 3, RetryWait: time.Millisecond}}
+	resp, err := client.Post(server.URL, "text/plain", strings.NewReader("payload"))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&attempts))
+
+	// The retries carry the same idempotency key
+	key := <-keys
+	assert.NotEmpty(t, key)
+	assert.Equal(t, key, <-keys)
+	assert.Equal(t, key, <-keys)
+}
+
+func TestShouldRetry(t *testing.T) {
+	get, _ := http.NewRequest(http.MethodGet, "/", nil)
+	patch, _ := http.NewRequest(http.MethodPatch, "/", nil)
+	post, _ := http.NewRequest(http.MethodPost, "/", nil)
+	keyed, _ := http.NewRequest(http.MethodPost, "/", nil)
+	keyed.Header.Set(corev2.IdempotencyKeyHeader, "key")
+
+	unavailable := &http.Response{StatusCode: http.StatusServiceUnavailable}
+	assert.True(t, ShouldRetry(get, unavailable, nil))
+	assert.True(t, ShouldRetry(keyed, unavailable, nil))
+	assert.False(t, ShouldRetry(post, unavailable, nil))
+	assert.False(t, ShouldRetry(patch, unavailable, nil))
+	assert.False(t, ShouldRetry(get, &http.Response{StatusCode: http.StatusInternalServerError}, nil))
+
+	// Requests that weren't processed are always retried
+	assert.True(t, ShouldRetry(patch, &http.Response{StatusCode: http.StatusTooManyRequests}, nil))
+}
@@ -7,21 +7,18 @@ import (
 	"os"
 
 	"github.com/sensu/sensu-go/cli"
-	"github.com/sensu/sensu-go/cli/commands"
-	hooks "github.com/sensu/sensu-go/cli/commands/hooks"
 	"github.com/sensu/sensu-go/cli/commands/root"
-	"github.com/spf13/cobra"
 )
 
 func main() {
 	rootCmd := root.Command()
 	sensuCli := cli.New(rootCmd.PersistentFlags())
 
-	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
-		return hooks.ConfigurationPresent(cmd, sensuCli)
+	if executeFederated(rootCmd, sensuCli) {
+		return
 	}
 
-	commands.AddCommands(rootCmd, sensuCli)
+	addCommands(rootCmd, sensuCli)
 
 	if err := rootCmd.Execute(); err != nil {
 		os.Exit(1)
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sensu/sensu-go/cli"
+	"github.com/sensu/sensu-go/cli/client/config"
+	"github.com/sensu/sensu-go/cli/client/config/basic"
+	"github.com/sensu/sensu-go/cli/commands"
+	hooks "github.com/sensu/sensu-go/cli/commands/hooks"
+	"github.com/sensu/sensu-go/cli/commands/root"
+	"github.com/sensu/sensu-go/cli/federation"
+	"github.com/spf13/cobra"
+)
+
+// addCommands adds all of the sensuctl commands to the root command
+func addCommands(rootCmd *cobra.Command, sensuCli *cli.SensuCli) {
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
+		return hooks.ConfigurationPresent(cmd, sensuCli)
+	}
+
+	commands.AddCommands(rootCmd, sensuCli)
+}
+
+// newRoot returns the root command, with all of the sensuctl commands, for
+// the given CLI
+func newRoot(sensuCli *cli.SensuCli) *cobra.Command {
+	rootCmd := root.Command()
+	addCommands(rootCmd, sensuCli)
+	return rootCmd
+}
+
+// executeFederated runs the command against all the configured clusters when
+// the --cluster flag of the root command is "all", and returns whether it did
+func executeFederated(rootCmd *cobra.Command, sensuCli *cli.SensuCli) bool {
+	if cluster, _ := rootCmd.PersistentFlags().GetString("cluster"); cluster != config.AllClusters {
+		return false
+	}
+	conf, ok := sensuCli.Config.(*basic.Config)
+	if !ok {
+		return false
+	}
+	if err := federation.Execute(conf, newRoot, os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	return true
+}
@@ -0,0 +1,210 @@
+package command
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// cgroupRoot is the mount point of the cgroup hierarchy, or of the cgroup v1
+// hierarchies.
+var cgroupRoot = "/sys/fs/cgroup"
+
+const (
+	// cgroupCPUPeriod is the period, in microseconds, over which the CPU quota
+	// of a cgroup is enforced.
+	cgroupCPUPeriod = 100000
+
+	// cgroupMinCPUQuota is the minimum CPU quota, in microseconds, accepted by
+	// the kernel.
+	cgroupMinCPUQuota = 1000
+)
+
+var cgroupSeq uint64
+
+// cgroup is the cgroup limiting the resources of a command. The shell of the
+// command waits until it is moved into the cgroup before running the command,
+// so that none of its children escape the limits.
+type cgroup struct {
+	// dirs are the directories of the cgroup, one per controller with cgroup
+	// v1 and a single one with cgroup v2.
+	dirs []string
+
+	// The shell reads a line from the pipe once moved into the cgroup.
+	pipeR, pipeW *os.File
+}
+
+// newCgroup creates a cgroup with the limits of the sandbox.
+func newCgroup(sandbox *Sandbox) (*cgroup, error) {
+	parent := sandbox.CgroupParent
+	if parent == "" {
+		parent = DefaultCgroupParent
+	}
+	name := fmt.Sprintf("command-%d-%d", os.Getpid(), atomic.AddUint64(&cgroupSeq, 1))
+
+	c := &cgroup{}
+	var err error
+	if _, statErr := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); statErr == nil {
+		err = c.setupV2(parent, name, sandbox)
+	} else {
+		err = c.setupV1(parent, name, sandbox)
+	}
+	if err != nil {
+		_ = c.remove()
+		return nil, err
+	}
+	return c, nil
+}
+
+// setupV2 creates the cgroup in the unified hierarchy of cgroup v2.
+func (c *cgroup) setupV2(parent, name string, sandbox *Sandbox) error {
+	var controllers []string
+	if sandbox.CgroupCPULimit > 0 {
+		controllers = append(controllers, "+cpu")
+	}
+	if sandbox.CgroupMemoryLimit > 0 {
+		controllers = append(controllers, "+memory")
+	}
+
+	// The controllers must be enabled in every ancestor of the cgroup. They
+	// usually are already, so the errors are only reported when writing the
+	// limits.
+	dir := cgroupRoot
+	for _, elem := range append(splitCgroupPath(parent), name) {
+		_ = writeCgroupFile(dir, "cgroup.subtree_control", strings.Join(controllers, " "))
+		dir = filepath.Join(dir, elem)
+		if err := os.Mkdir(dir, 0755); err != nil && !os.IsExist(err) {
+			return err
+		}
+	}
+	c.dirs = append(c.dirs, dir)
+
+	if sandbox.CgroupCPULimit > 0 {
+		max := fmt.Sprintf("%d %d", cgroupCPUQuota(sandbox.CgroupCPULimit), cgroupCPUPeriod)
+		if err := writeCgroupFile(dir, "cpu.max", max); err != nil {
+			return err
+		}
+	}
+	if sandbox.CgroupMemoryLimit > 0 {
+		if err := writeCgroupFile(dir, "memory.max", strconv.FormatInt(sandbox.CgroupMemoryLimit, 10)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setupV1 creates the cgroup in the hierarchies of the cpu and memory
+// controllers of cgroup v1.
+func (c *cgroup) setupV1(parent, name string, sandbox *Sandbox) error {
+	if sandbox.CgroupCPULimit > 0 {
+		dir, err := c.mkdirV1("cpu", parent, name)
+		if err != nil {
+			return err
+		}
+		if err := writeCgroupFile(dir, "cpu.cfs_period_us", strconv.Itoa(cgroupCPUPeriod)); err != nil {
+			return err
+		}
+		quota := strconv.FormatInt(cgroupCPUQuota(sandbox.CgroupCPULimit), 10)
+		if err := writeCgroupFile(dir, "cpu.cfs_quota_us", quota); err != nil {
+			return err
+		}
+	}
+	if sandbox.CgroupMemoryLimit > 0 {
+		dir, err := c.mkdirV1("memory", parent, name)
+		if err != nil {
+			return err
+		}
+		if err := writeCgroupFile(dir, "memory.limit_in_bytes", strconv.FormatInt(sandbox.CgroupMemoryLimit, 10)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *cgroup) mkdirV1(controller, parent, name string) (string, error) {
+	elems := append([]string{cgroupRoot, controller}, splitCgroupPath(parent)...)
+	dir := filepath.Join(append(elems, name)...)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	c.dirs = append(c.dirs, dir)
+	return dir, nil
+}
+
+// wrap makes the shell wait until it is moved into the cgroup before running
+// the command.
+func (c *cgroup) wrap(command string) string {
+	return "read REPLY <&3 && exec 3<&- && " + command
+}
+
+// attach passes the pipe the shell waits on to the command, as file
+// descriptor 3.
+func (c *cgroup) attach(cmd *exec.Cmd) error {
+	if len(cmd.ExtraFiles) > 0 {
+		return fmt.Errorf("cgroup limits can't be applied to commands with extra files")
+	}
+	var err error
+	c.pipeR, c.pipeW, err = os.Pipe()
+	if err != nil {
+		return err
+	}
+	cmd.ExtraFiles = []*os.File{c.pipeR}
+	return nil
+}
+
+// start moves the started command into the cgroup, and lets its shell run
+// the command.
+func (c *cgroup) start(pid int) error {
+	_ = c.pipeR.Close()
+	defer c.pipeW.Close()
+	for _, dir := range c.dirs {
+		if err := writeCgroupFile(dir, "cgroup.procs", strconv.Itoa(pid)); err != nil {
+			return err
+		}
+	}
+	_, err := c.pipeW.Write([]byte("\n"))
+	return err
+}
+
+// remove removes the cgroup. It fails if processes of the command are still
+// running in the background.
+func (c *cgroup) remove() (err error) {
+	if c.pipeR != nil {
+		_ = c.pipeR.Close()
+		_ = c.pipeW.Close()
+	}
+	for i := len(c.dirs) - 1; i >= 0; i-- {
+		if e := os.Remove(c.dirs[i]); e != nil && !os.IsNotExist(e) && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// cgroupCPUQuota returns the CPU quota, in microseconds per period, of a CPU
+// limit in thousandths of a CPU core.
+func cgroupCPUQuota(limit int64) int64 {
+	quota := limit * cgroupCPUPeriod / 1000
+	if quota < cgroupMinCPUQuota {
+		quota = cgroupMinCPUQuota
+	}
+	return quota
+}
+
+func splitCgroupPath(path string) []string {
+	return strings.FieldsFunc(path, func(r rune) bool {
+		return r == '/'
+	})
+}
+
+func writeCgroupFile(dir, file, value string) error {
+	if err := ioutil.WriteFile(filepath.Join(dir, file), []byte(value), 0644); err != nil {
+		return fmt.Errorf("could not write %s to %s: %s", file, dir, err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package command
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withCgroupRoot(t *testing.T, v2 bool) (string, func()) {
+	root, err := ioutil.TempDir("", "cgroup")
+	require.NoError(t, err)
+	if v2 {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(root, "cgroup.controllers"), []byte("cpu memory"), 0644))
+	}
+	oldRoot := cgroupRoot
+	cgroupRoot = root
+	return root, func() {
+		cgroupRoot = oldRoot
+		_ = os.RemoveAll(root)
+	}
+}
+
+func readCgroupFile(t *testing.T, dir, file string) string {
+	b, err := ioutil.ReadFile(filepath.Join(dir, file))
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestNewCgroupV2(t *testing.T) {
+	root, cleanup := withCgroupRoot(t, true)
+	defer cleanup()
+
+	c, err := newCgroup(&Sandbox{
+		CgroupParent:      "system.slice/sensu-agent",
+		CgroupCPULimit:    500,
+		CgroupMemoryLimit: 1 << 20,
+	})
+	require.NoError(t, err)
+	require.Len(t, c.dirs, 1)
+
+	dir := c.dirs[0]
+	assert.Equal(t, filepath.Join(root, "system.slice", "sensu-agent"), filepath.Dir(dir))
+	assert.Equal(t, "50000 100000", readCgroupFile(t, dir, "cpu.max"))
+	assert.Equal(t, "1048576", readCgroupFile(t, dir, "memory.max"))
+	assert.Equal(t, "+cpu +memory", readCgroupFile(t, filepath.Dir(dir), "cgroup.subtree_control"))
+}
+
+func TestNewCgroupV1(t *testing.T) {
+	root, cleanup := withCgroupRoot(t, false)
+	defer cleanup()
+
+	c, err := newCgroup(&Sandbox{CgroupCPULimit: 2000})
+	require.NoError(t, err)
+	require.Len(t, c.dirs, 1)
+
+	dir := c.dirs[0]
+	assert.Equal(t, filepath.Join(root, "cpu", DefaultCgroupParent), filepath.Dir(dir))
+	assert.Equal(t, "100000", readCgroupFile(t, dir, "cpu.cfs_period_us"))
+	assert.Equal(t, "200000", readCgroupFile(t, dir, "cpu.cfs_quota_us"))
+	_, err = os.Stat(filepath.Join(root, "memory"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestCgroupCPUQuota(t *testing.T) {
+	assert.Equal(t, int64(100000), cgroupCPUQuota(1000))
+	assert.Equal(t, int64(250000), cgroupCPUQuota(2500))
+	assert.Equal(t, int64(cgroupMinCPUQuota), cgroupCPUQuota(1))
+}
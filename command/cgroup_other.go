@@ -0,0 +1,29 @@
+// +build !linux
+
+package command
+
+import "os/exec"
+
+// cgroup limits are only supported on Linux, and ignored on the other
+// platforms.
+type cgroup struct{}
+
+func newCgroup(sandbox *Sandbox) (*cgroup, error) {
+	return nil, nil
+}
+
+func (c *cgroup) wrap(command string) string {
+	return command
+}
+
+func (c *cgroup) attach(cmd *exec.Cmd) error {
+	return nil
+}
+
+func (c *cgroup) start(pid int) error {
+	return nil
+}
+
+func (c *cgroup) remove() error {
+	return nil
+}
@@ -2,8 +2,8 @@
 package command
 
 import (
-	"bytes"
 	"context"
+	"fmt"
 	"os/exec"
 	"strings"
 	"sync"
@@ -92,6 +92,10 @@ type ExecutionRequest struct {
 
 	// InProgressMu is the mutex for the InProgress map.
 	InProgressMu *sync.Mutex
+
+	// Sandbox optionally restricts the privileges and resources of the
+	// executed command.
+	Sandbox *Sandbox
 }
 
 // ExecutionResponse provides the response information of an ExecutionRequest.
@@ -135,8 +139,42 @@ func (e *ExecutionRequest) Execute(ctx context.Context, execution ExecutionReque
 	ctx, timeout := context.WithCancel(ctx)
 	defer timeout()
 
+	commandLine := execution.Command
+	if !execution.Sandbox.IsZero() {
+		commandLine = sandboxCommand(commandLine, execution.Sandbox)
+	}
+
+	// Limit the resources of the command and of its children with a cgroup
+	var cg *cgroup
+	if execution.Sandbox.hasCgroupLimits() {
+		var err error
+		if cg, err = newCgroup(execution.Sandbox); err != nil {
+			return resp, fmt.Errorf("could not create the cgroup of the command: %s", err)
+		}
+	}
+	if cg != nil {
+		commandLine = cg.wrap(commandLine)
+		defer func() {
+			if err := cg.remove(); err != nil {
+				logger.WithError(err).Warn("could not remove the cgroup of the command")
+			}
+		}()
+	}
+
 	// Taken from Sensu-Spawn (Sensu 1.x.x).
-	cmd = Command(ctx, execution.Command)
+	cmd = Command(ctx, commandLine)
+
+	if !execution.Sandbox.IsZero() {
+		if err := applySandbox(cmd, execution.Sandbox); err != nil {
+			return resp, err
+		}
+	}
+
+	if cg != nil {
+		if err := cg.attach(cmd); err != nil {
+			return resp, err
+		}
+	}
 
 	// Set the ENV for the command if it is set
 	if len(execution.Env) > 0 {
@@ -145,10 +183,13 @@ func (e *ExecutionRequest) Execute(ctx context.Context, execution ExecutionReque
 
 	// Share an output buffer between STDOUT/ERR, following the
 	// Nagios plugin spec.
-	var output bytes.Buffer
+	output := &limitedBuffer{}
+	if execution.Sandbox != nil {
+		output.limit = execution.Sandbox.MaxOutputSize
+	}
 
-	cmd.Stdout = &output
-	cmd.Stderr = &output
+	cmd.Stdout = output
+	cmd.Stderr = output
 
 	// If Input is specified, write to STDIN.
 	if execution.Input != "" {
@@ -180,6 +221,14 @@ func (e *ExecutionRequest) Execute(ctx context.Context, execution ExecutionReque
 		return resp, err
 	}
 
+	if cg != nil {
+		if err := cg.start(cmd.Process.Pid); err != nil {
+			_ = cmd.Process.Kill()
+			_ = cmd.Wait()
+			return resp, fmt.Errorf("could not move the command into its cgroup: %s", err)
+		}
+	}
+
 	err := cmd.Wait()
 	if timer != nil {
 		timer.Stop()
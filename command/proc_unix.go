@@ -4,7 +4,10 @@ package command
 
 import (
 	"context"
+	"fmt"
 	"os/exec"
+	"os/user"
+	"strconv"
 	"syscall"
 )
 
@@ -15,10 +18,102 @@ func Command(ctx context.Context, command string) *exec.Cmd {
 
 // SetProcessGroup sets the process group of the command process
 func SetProcessGroup(cmd *exec.Cmd) {
-	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
 }
 
 // KillProcess kills the command process and any child processes
 func KillProcess(cmd *exec.Cmd) error {
 	return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
 }
+
+// sandboxCommand prefixes the command with the shell builtins required to
+// apply the resource limits of the sandbox.
+func sandboxCommand(command string, sandbox *Sandbox) string {
+	if sandbox.CPUTimeLimit > 0 {
+		command = fmt.Sprintf("ulimit -t %d && %s", sandbox.CPUTimeLimit, command)
+	}
+	if sandbox.MemoryLimit > 0 {
+		// ulimit -v expects kibibytes
+		kb := sandbox.MemoryLimit / 1024
+		if kb == 0 {
+			kb = 1
+		}
+		command = fmt.Sprintf("ulimit -v %d && %s", kb, command)
+	}
+	return command
+}
+
+// applySandbox configures the command process to run with the credentials
+// of the sandbox user and group.
+func applySandbox(cmd *exec.Cmd, sandbox *Sandbox) error {
+	if sandbox.User == "" && sandbox.Group == "" {
+		return nil
+	}
+	credential := &syscall.Credential{
+		Uid: uint32(syscall.Getuid()),
+		Gid: uint32(syscall.Getgid()),
+	}
+	if sandbox.User != "" {
+		u, err := lookupUser(sandbox.User)
+		if err != nil {
+			return err
+		}
+		uid, err := strconv.ParseUint(u.Uid, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid uid for user %q: %s", sandbox.User, err)
+		}
+		gid, err := strconv.ParseUint(u.Gid, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid gid for user %q: %s", sandbox.User, err)
+		}
+		credential.Uid = uint32(uid)
+		credential.Gid = uint32(gid)
+	}
+	if sandbox.Group != "" {
+		g, err := lookupGroup(sandbox.Group)
+		if err != nil {
+			return err
+		}
+		gid, err := strconv.ParseUint(g.Gid, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid gid for group %q: %s", sandbox.Group, err)
+		}
+		credential.Gid = uint32(gid)
+	}
+	// Drop the supplementary groups of the parent process
+	credential.Groups = []uint32{}
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Credential = credential
+	return nil
+}
+
+func lookupUser(name string) (*user.User, error) {
+	u, err := user.Lookup(name)
+	if err == nil {
+		return u, nil
+	}
+	if _, perr := strconv.ParseUint(name, 10, 32); perr == nil {
+		if u, ierr := user.LookupId(name); ierr == nil {
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("couldn't find sandbox user %q: %s", name, err)
+}
+
+func lookupGroup(name string) (*user.Group, error) {
+	g, err := user.LookupGroup(name)
+	if err == nil {
+		return g, nil
+	}
+	if _, perr := strconv.ParseUint(name, 10, 32); perr == nil {
+		if g, ierr := user.LookupGroupId(name); ierr == nil {
+			return g, nil
+		}
+	}
+	return nil, fmt.Errorf("couldn't find sandbox group %q: %s", name, err)
+}
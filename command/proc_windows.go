@@ -4,6 +4,7 @@ package command
 
 import (
 	"context"
+	"errors"
 	"os/exec"
 	"strings"
 	"syscall"
@@ -30,3 +31,18 @@ func SetProcessGroup(cmd *exec.Cmd) {
 func KillProcess(cmd *exec.Cmd) error {
 	return cmd.Process.Kill()
 }
+
+// sandboxCommand returns the command unchanged, resource limits are not
+// supported on Windows.
+func sandboxCommand(command string, sandbox *Sandbox) string {
+	return command
+}
+
+// applySandbox returns an error if the sandbox requires features that are not
+// supported on Windows.
+func applySandbox(cmd *exec.Cmd, sandbox *Sandbox) error {
+	if sandbox.User != "" || sandbox.Group != "" || sandbox.CPUTimeLimit > 0 || sandbox.MemoryLimit > 0 {
+		return errors.New("sandbox user, group and resource limits are not supported on windows")
+	}
+	return nil
+}
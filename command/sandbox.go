@@ -0,0 +1,101 @@
+package command
+
+import (
+	"bytes"
+	"sync"
+)
+
+// TruncatedOutputNotice is appended to the output of a command whose output
+// exceeded the maximum output size of its sandbox.
+const TruncatedOutputNotice = "\n[output truncated]\n"
+
+// DefaultCgroupParent is the default cgroup under which the cgroups of the
+// commands with cgroup limits are created.
+const DefaultCgroupParent = "sensu"
+
+// Sandbox restricts the privileges and resources available to an executed
+// command. The zero value applies no restrictions.
+type Sandbox struct {
+	// User is the name or uid of the user the command is executed as.
+	User string
+
+	// Group is the name or gid of the group the command is executed as. If
+	// empty and User is set, the primary group of User is used.
+	Group string
+
+	// CPUTimeLimit is the maximum CPU time, in seconds, the command may
+	// consume. Zero means unlimited.
+	CPUTimeLimit int
+
+	// MemoryLimit is the maximum virtual memory, in bytes, the command may
+	// allocate. Zero means unlimited.
+	MemoryLimit int64
+
+	// MaxOutputSize is the maximum number of bytes of combined STDOUT/ERR
+	// retained from the command. Zero means unlimited.
+	MaxOutputSize int64
+
+	// CgroupParent is the cgroup, relative to the root of the cgroup
+	// hierarchy, under which a cgroup is created for the command when it has
+	// cgroup limits. DefaultCgroupParent is used if empty.
+	CgroupParent string
+
+	// CgroupCPULimit is the maximum CPU usage of the command and of its
+	// children, in thousandths of a CPU core. It is enforced with a cgroup on
+	// Linux, and ignored on the other platforms. Zero means unlimited.
+	CgroupCPULimit int64
+
+	// CgroupMemoryLimit is the maximum memory usage, in bytes, of the command
+	// and of its children. It is enforced with a cgroup on Linux, and ignored
+	// on the other platforms. Zero means unlimited.
+	CgroupMemoryLimit int64
+}
+
+// IsZero returns true if the sandbox applies no restrictions.
+func (s *Sandbox) IsZero() bool {
+	return s == nil || *s == Sandbox{}
+}
+
+// hasCgroupLimits returns true if the resources of the command are limited
+// with a cgroup.
+func (s *Sandbox) hasCgroupLimits() bool {
+	return s != nil && (s.CgroupCPULimit > 0 || s.CgroupMemoryLimit > 0)
+}
+
+// limitedBuffer is a bytes.Buffer that silently discards everything written
+// past its limit. Writes never fail, so the command is not killed by SIGPIPE
+// when it produces too much output.
+type limitedBuffer struct {
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	limit     int64
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	n := len(p)
+	if b.limit > 0 {
+		remaining := b.limit - int64(b.buf.Len())
+		if remaining <= 0 {
+			b.truncated = b.truncated || n > 0
+			return n, nil
+		}
+		if int64(n) > remaining {
+			p = p[:remaining]
+			b.truncated = true
+		}
+	}
+	_, _ = b.buf.Write(p)
+	return n, nil
+}
+
+func (b *limitedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.truncated {
+		return b.buf.String() + TruncatedOutputNotice
+	}
+	return b.buf.String()
+}
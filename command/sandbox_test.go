@@ -0,0 +1,34 @@
+package command
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxIsZero(t *testing.T) {
+	var nilSandbox *Sandbox
+	assert.True(t, nilSandbox.IsZero())
+	assert.True(t, (&Sandbox{}).IsZero())
+	assert.False(t, (&Sandbox{MaxOutputSize: 1}).IsZero())
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	buf := &limitedBuffer{limit: 5}
+
+	n, err := buf.Write([]byte("foo"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "foo", buf.String())
+
+	n, err = buf.Write([]byte("barbaz"))
+	assert.NoError(t, err)
+	assert.Equal(t, 6, n)
+	assert.Equal(t, "fooba"+TruncatedOutputNotice, buf.String())
+}
+
+func TestLimitedBufferUnlimited(t *testing.T) {
+	buf := &limitedBuffer{}
+	_, _ = buf.Write([]byte("foobar"))
+	assert.Equal(t, "foobar", buf.String())
+}
@@ -0,0 +1,103 @@
+// +build !windows
+
+package command
+
+import (
+	"context"
+	"os/user"
+	"strconv"
+	"testing"
+
+	"github.com/sensu/sensu-go/testing/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSandboxCommand(t *testing.T) {
+	assert.Equal(t, "foo", sandboxCommand("foo", &Sandbox{}))
+	assert.Equal(t, "ulimit -t 5 && foo", sandboxCommand("foo", &Sandbox{CPUTimeLimit: 5}))
+	assert.Equal(t, "ulimit -v 2048 && foo", sandboxCommand("foo", &Sandbox{MemoryLimit: 2 << 20}))
+	assert.Equal(t, "ulimit -v 1 && foo", sandboxCommand("foo", &Sandbox{MemoryLimit: 10}))
+	assert.Equal(t, "ulimit -v 1024 && ulimit -t 1 && foo", sandboxCommand("foo", &Sandbox{CPUTimeLimit: 1, MemoryLimit: 1 << 20}))
+}
+
+func TestExecuteSandboxLimits(t *testing.T) {
+	cpu := ExecutionRequest{Command: "ulimit -t", Sandbox: &Sandbox{CPUTimeLimit: 5}}
+	resp, err := cpu.Execute(context.Background(), cpu)
+	require.NoError(t, err)
+	assert.Equal(t, "5", testutil.CleanOutput(resp.Output))
+	assert.Equal(t, 0, resp.Status)
+
+	memory := ExecutionRequest{Command: "ulimit -v", Sandbox: &Sandbox{MemoryLimit: 1 << 30}}
+	resp, err = memory.Execute(context.Background(), memory)
+	require.NoError(t, err)
+	assert.Equal(t, "1048576", testutil.CleanOutput(resp.Output))
+	assert.Equal(t, 0, resp.Status)
+}
+
+func TestExecuteSandboxMaxOutputSize(t *testing.T) {
+	echo := FakeCommand("echo", "foo")
+	echo.Sandbox = &Sandbox{MaxOutputSize: 2}
+
+	resp, err := echo.Execute(context.Background(), echo)
+	require.NoError(t, err)
+	assert.Equal(t, "fo"+TruncatedOutputNotice, resp.Output)
+	assert.Equal(t, 0, resp.Status)
+}
+
+func TestApplySandbox(t *testing.T) {
+	current, err := user.Current()
+	require.NoError(t, err)
+	uid, err := strconv.ParseUint(current.Uid, 10, 32)
+	require.NoError(t, err)
+	gid, err := strconv.ParseUint(current.Gid, 10, 32)
+	require.NoError(t, err)
+
+	// Users and groups can be given by name or by id
+	for _, sandbox := range []*Sandbox{
+		{User: current.Username},
+		{User: current.Uid},
+		{User: current.Uid, Group: current.Gid},
+	} {
+		cmd := Command(context.Background(), "true")
+		require.NoError(t, applySandbox(cmd, sandbox))
+		require.NotNil(t, cmd.SysProcAttr)
+		require.NotNil(t, cmd.SysProcAttr.Credential)
+		assert.Equal(t, uint32(uid), cmd.SysProcAttr.Credential.Uid)
+		assert.Equal(t, uint32(gid), cmd.SysProcAttr.Credential.Gid)
+		// The supplementary groups of the parent process are dropped
+		assert.Empty(t, cmd.SysProcAttr.Credential.Groups)
+	}
+
+	// The credentials of the process are kept if neither a user nor a group
+	// is given
+	cmd := Command(context.Background(), "true")
+	require.NoError(t, applySandbox(cmd, &Sandbox{MaxOutputSize: 1}))
+	if cmd.SysProcAttr != nil {
+		assert.Nil(t, cmd.SysProcAttr.Credential)
+	}
+
+	// Setting the process group keeps the credentials
+	cmd = Command(context.Background(), "true")
+	require.NoError(t, applySandbox(cmd, &Sandbox{User: current.Uid}))
+	SetProcessGroup(cmd)
+	assert.True(t, cmd.SysProcAttr.Setpgid)
+	assert.Equal(t, uint32(uid), cmd.SysProcAttr.Credential.Uid)
+}
+
+func TestApplySandboxUnknownUser(t *testing.T) {
+	cmd := Command(context.Background(), "true")
+	assert.Error(t, applySandbox(cmd, &Sandbox{User: "sensu-sandbox-unknown-user"}))
+	assert.Error(t, applySandbox(cmd, &Sandbox{Group: "sensu-sandbox-unknown-group"}))
+	if cmd.SysProcAttr != nil {
+		assert.Nil(t, cmd.SysProcAttr.Credential)
+	}
+}
+
+func TestExecuteSandboxUnknownUser(t *testing.T) {
+	echo := FakeCommand("echo", "foo")
+	echo.Sandbox = &Sandbox{User: "sensu-sandbox-unknown-user"}
+
+	_, err := echo.Execute(context.Background(), echo)
+	assert.Error(t, err)
+}
@@ -63,6 +63,9 @@ func newOttoVM(assets JavascriptAssets) (*otto.Otto, error) {
 	if err := addTimeFuncs(vm); err != nil {
 		return nil, err
 	}
+	if err := addThresholdFuncs(vm); err != nil {
+		return nil, err
+	}
 	if assets != nil {
 		if err := addAssets(vm, assets); err != nil {
 			return nil, err
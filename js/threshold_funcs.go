@@ -0,0 +1,64 @@
+package js
+
+import (
+	"math"
+
+	"github.com/robertkrimen/otto"
+	"github.com/sensu/sensu-go/util/nagios"
+)
+
+// addThresholdFuncs adds the functions evaluating values against thresholds in
+// the Nagios range format, e.g. to filter events on their metrics. They throw
+// an error if the value isn't a number or if a range is invalid.
+func addThresholdFuncs(vm *otto.Otto) error {
+	funcs := map[string]interface{}{
+		// threshold_alert returns true if the value raises an alert for the
+		// range, e.g. threshold_alert(value, "~:90")
+		"threshold_alert": func(call otto.FunctionCall) otto.Value {
+			value := thresholdValue(call)
+			r, err := nagios.ParseRange(call.Argument(1).String())
+			if err != nil {
+				panic(call.Otto.MakeCustomError("RangeError", err.Error()))
+			}
+			result, _ := otto.ToValue(r.Alert(value))
+			return result
+		},
+		// threshold_status returns the check status of the value for the
+		// warning and critical ranges, e.g.
+		// threshold_status(value, "~:80", "~:95")
+		"threshold_status": func(call otto.FunctionCall) otto.Value {
+			value := thresholdValue(call)
+			t, err := nagios.ParseThreshold(optionalString(call.Argument(1)), optionalString(call.Argument(2)))
+			if err != nil {
+				panic(call.Otto.MakeCustomError("RangeError", err.Error()))
+			}
+			result, _ := otto.ToValue(t.Status(value))
+			return result
+		},
+	}
+	for k, v := range funcs {
+		if err := vm.Set(k, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// thresholdValue returns the value evaluated by a threshold function, its
+// first argument.
+func thresholdValue(call otto.FunctionCall) float64 {
+	value, err := call.Argument(0).ToFloat()
+	if err != nil || math.IsNaN(value) {
+		panic(call.Otto.MakeCustomError("TypeError", "the value of a threshold must be a number"))
+	}
+	return value
+}
+
+// optionalString returns the string of a value, or an empty string if the
+// value is undefined or null.
+func optionalString(value otto.Value) string {
+	if !value.IsDefined() || value.IsNull() {
+		return ""
+	}
+	return value.String()
+}
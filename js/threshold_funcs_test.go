@@ -0,0 +1,48 @@
+package js_test
+
+import (
+	"testing"
+
+	"github.com/sensu/sensu-go/js"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholdFuncs(t *testing.T) {
+	params := map[string]interface{}{
+		"cpu":  85.5,
+		"disk": "42",
+	}
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`threshold_alert(cpu, "~:80")`, true},
+		{`threshold_alert(cpu, "@80:90")`, true},
+		{`threshold_alert(cpu, "90")`, false},
+		{`threshold_alert(disk, "50:")`, true},
+		{`threshold_status(cpu, "~:80", "~:95") == 1`, true},
+		{`threshold_status(cpu, "~:80", "~:85") == 2`, true},
+		{`threshold_status(disk, "~:80", "~:95") == 0`, true},
+		{`threshold_status(disk, null, "~:40") == 2`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			result, err := js.Evaluate(tt.expr, params, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
+
+func TestThresholdFuncsErrors(t *testing.T) {
+	params := map[string]interface{}{"cpu": 85.5}
+	for _, expr := range []string{
+		`threshold_alert(cpu, "90:80")`,
+		`threshold_alert(memory, "~:80")`,
+		`threshold_status(cpu, "foo", "~:95") == 0`,
+	} {
+		_, err := js.Evaluate(expr, params, nil)
+		assert.Error(t, err, expr)
+	}
+}
@@ -0,0 +1,182 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// Cloud metadata keys, as stored in the labels of an entity.
+const (
+	CloudProviderKey         = "cloud_provider"
+	CloudInstanceIDKey       = "cloud_instance_id"
+	CloudInstanceTypeKey     = "cloud_instance_type"
+	CloudRegionKey           = "cloud_region"
+	CloudAvailabilityZoneKey = "cloud_availability_zone"
+)
+
+var (
+	ec2MetadataURL   = "http://169.254.169.254/latest"
+	gcpMetadataURL   = "http://metadata.google.internal/computeMetadata/v1"
+	azureMetadataURL = "http://169.254.169.254/metadata"
+)
+
+type ec2InstanceIdentity struct {
+	InstanceID       string `json:"instanceId"`
+	InstanceType     string `json:"instanceType"`
+	Region           string `json:"region"`
+	AvailabilityZone string `json:"availabilityZone"`
+}
+
+type gcpInstance struct {
+	ID          json.Number `json:"id"`
+	MachineType string      `json:"machineType"`
+	Zone        string      `json:"zone"`
+}
+
+type azureCompute struct {
+	VMID     string `json:"vmId"`
+	VMSize   string `json:"vmSize"`
+	Location string `json:"location"`
+	Zone     string `json:"zone"`
+}
+
+// GetCloudMetadata queries the metadata service of the given cloud provider,
+// as returned by GetCloudProvider, and returns the instance metadata keyed
+// by the Cloud*Key constants. Empty values are omitted.
+func GetCloudMetadata(ctx context.Context, provider string) (map[string]string, error) {
+	var (
+		metadata map[string]string
+		err      error
+	)
+	switch provider {
+	case "EC2":
+		metadata, err = getEC2Metadata(ctx)
+	case "GCP":
+		metadata, err = getGCPMetadata(ctx)
+	case "Azure":
+		metadata, err = getAzureMetadata(ctx)
+	default:
+		return nil, fmt.Errorf("cloud metadata not supported for provider %q", provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+	metadata[CloudProviderKey] = provider
+	for k, v := range metadata {
+		if v == "" {
+			delete(metadata, k)
+		}
+	}
+	return metadata, nil
+}
+
+func getEC2Metadata(ctx context.Context) (map[string]string, error) {
+	// Use an IMDSv2 session token if possible, instances that require IMDSv2
+	// refuse unauthenticated requests.
+	var token string
+	req, err := http.NewRequestWithContext(ctx, "PUT", ec2MetadataURL+"/api/token", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "60")
+	if b, err := doMetadataRequest(req); err == nil {
+		token = string(b)
+	} else {
+		logger.WithError(err).Debug("couldn't retrieve an IMDSv2 token")
+	}
+
+	req, err = http.NewRequestWithContext(ctx, "GET", ec2MetadataURL+"/dynamic/instance-identity/document", nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("X-aws-ec2-metadata-token", token)
+	}
+	b, err := doMetadataRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var identity ec2InstanceIdentity
+	if err := json.Unmarshal(b, &identity); err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		CloudInstanceIDKey:       identity.InstanceID,
+		CloudInstanceTypeKey:     identity.InstanceType,
+		CloudRegionKey:           identity.Region,
+		CloudAvailabilityZoneKey: identity.AvailabilityZone,
+	}, nil
+}
+
+func getGCPMetadata(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", gcpMetadataURL+"/instance/?recursive=true", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+	b, err := doMetadataRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var instance gcpInstance
+	if err := json.Unmarshal(b, &instance); err != nil {
+		return nil, err
+	}
+	// The machine type and zone are returned as resource paths, e.g.
+	// projects/123/zones/us-central1-a
+	zone := path.Base(instance.Zone)
+	region := ""
+	if i := strings.LastIndexByte(zone, '-'); i > 0 {
+		region = zone[:i]
+	}
+	return map[string]string{
+		CloudInstanceIDKey:       instance.ID.String(),
+		CloudInstanceTypeKey:     path.Base(instance.MachineType),
+		CloudRegionKey:           region,
+		CloudAvailabilityZoneKey: zone,
+	}, nil
+}
+
+func getAzureMetadata(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", azureMetadataURL+"/instance/compute?api-version=2019-06-01", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Metadata", "true")
+	b, err := doMetadataRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	var compute azureCompute
+	if err := json.Unmarshal(b, &compute); err != nil {
+		return nil, err
+	}
+	zone := compute.Zone
+	if zone != "" {
+		zone = compute.Location + "-" + zone
+	}
+	return map[string]string{
+		CloudInstanceIDKey:       compute.VMID,
+		CloudInstanceTypeKey:     compute.VMSize,
+		CloudRegionKey:           compute.Location,
+		CloudAvailabilityZoneKey: zone,
+	}, nil
+}
+
+func doMetadataRequest(req *http.Request) ([]byte, error) {
+	logger.Debug(req.Method, " ", req.URL.String())
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("metadata service returned status %d", resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
@@ -0,0 +1,64 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCloudMetadataEC2(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/token":
+			fmt.Fprint(w, "token")
+		case "/dynamic/instance-identity/document":
+			assert.Equal(t, "token", r.Header.Get("X-aws-ec2-metadata-token"))
+			fmt.Fprint(w, `{"instanceId":"i-1234","instanceType":"t3.micro","region":"us-east-1","availabilityZone":"us-east-1a"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	defer func(u string) { ec2MetadataURL = u }(ec2MetadataURL)
+	ec2MetadataURL = server.URL
+
+	metadata, err := GetCloudMetadata(context.Background(), "EC2")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		CloudProviderKey:         "EC2",
+		CloudInstanceIDKey:       "i-1234",
+		CloudInstanceTypeKey:     "t3.micro",
+		CloudRegionKey:           "us-east-1",
+		CloudAvailabilityZoneKey: "us-east-1a",
+	}, metadata)
+}
+
+func TestGetCloudMetadataGCP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Google", r.Header.Get("Metadata-Flavor"))
+		fmt.Fprint(w, `{"id":1234567890123,"machineType":"projects/42/machineTypes/n1-standard-1","zone":"projects/42/zones/us-central1-a"}`)
+	}))
+	defer server.Close()
+	defer func(u string) { gcpMetadataURL = u }(gcpMetadataURL)
+	gcpMetadataURL = server.URL
+
+	metadata, err := GetCloudMetadata(context.Background(), "GCP")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		CloudProviderKey:         "GCP",
+		CloudInstanceIDKey:       "1234567890123",
+		CloudInstanceTypeKey:     "n1-standard-1",
+		CloudRegionKey:           "us-central1",
+		CloudAvailabilityZoneKey: "us-central1-a",
+	}, metadata)
+}
+
+func TestGetCloudMetadataUnknownProvider(t *testing.T) {
+	_, err := GetCloudMetadata(context.Background(), "")
+	assert.Error(t, err)
+}
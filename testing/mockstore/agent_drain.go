@@ -0,0 +1,31 @@
+package mockstore
+
+import (
+	"context"
+
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// GetAgentDrain ...
+func (s *MockStore) GetAgentDrain(ctx context.Context, backend string) (bool, error) {
+	args := s.Called(ctx, backend)
+	return args.Bool(0), args.Error(1)
+}
+
+// GetAgentDrains ...
+func (s *MockStore) GetAgentDrains(ctx context.Context) ([]string, error) {
+	args := s.Called(ctx)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// UpdateAgentDrain ...
+func (s *MockStore) UpdateAgentDrain(ctx context.Context, backend string, draining bool) error {
+	args := s.Called(ctx, backend, draining)
+	return args.Error(0)
+}
+
+// GetAgentDrainWatcher ...
+func (s *MockStore) GetAgentDrainWatcher(ctx context.Context, backend string) <-chan store.WatchEventAgentDrain {
+	args := s.Called(ctx, backend)
+	return args.Get(0).(<-chan store.WatchEventAgentDrain)
+}
@@ -30,9 +30,3 @@ func (s *MockStore) UpdateCheckConfig(ctx context.Context, check *types.CheckCon
 	args := s.Called(ctx, check)
 	return args.Error(0)
 }
-
-// GetCheckConfigWatcher ...
-func (s *MockStore) GetCheckConfigWatcher(ctx context.Context) <-chan store.WatchEventCheckConfig {
-	args := s.Called(ctx)
-	return args.Get(0).(<-chan store.WatchEventCheckConfig)
-}
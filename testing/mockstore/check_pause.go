@@ -0,0 +1,19 @@
+package mockstore
+
+import (
+	"context"
+
+	"github.com/sensu/sensu-go/types"
+)
+
+// GetCheckPause ...
+func (s *MockStore) GetCheckPause(ctx context.Context, name string) (*types.CheckPause, error) {
+	args := s.Called(ctx, name)
+	return args.Get(0).(*types.CheckPause), args.Error(1)
+}
+
+// UpdateCheckPause ...
+func (s *MockStore) UpdateCheckPause(ctx context.Context, name string, pause *types.CheckPause) error {
+	args := s.Called(ctx, name, pause)
+	return args.Error(0)
+}
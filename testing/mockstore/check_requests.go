@@ -0,0 +1,25 @@
+package mockstore
+
+import (
+	"context"
+
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// RecordCheckRequests ...
+func (s *MockStore) RecordCheckRequests(ctx context.Context, requests []*store.SubscriptionCheckRequest, ttl int64) error {
+	args := s.Called(ctx, requests, ttl)
+	return args.Error(0)
+}
+
+// GetCheckRequests ...
+func (s *MockStore) GetCheckRequests(ctx context.Context, subscriptions []string) ([]*store.SubscriptionCheckRequest, error) {
+	args := s.Called(ctx, subscriptions)
+	return args.Get(0).([]*store.SubscriptionCheckRequest), args.Error(1)
+}
+
+// DeleteCheckRequests ...
+func (s *MockStore) DeleteCheckRequests(ctx context.Context, subscription, check string) error {
+	args := s.Called(ctx, subscription, check)
+	return args.Error(0)
+}
@@ -0,0 +1,26 @@
+package mockstore
+
+import (
+	"context"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// GetClusterConfig ...
+func (s *MockStore) GetClusterConfig(ctx context.Context) (*corev2.ClusterConfig, error) {
+	args := s.Called(ctx)
+	return args.Get(0).(*corev2.ClusterConfig), args.Error(1)
+}
+
+// UpdateClusterConfig ...
+func (s *MockStore) UpdateClusterConfig(ctx context.Context, config *corev2.ClusterConfig) error {
+	args := s.Called(ctx, config)
+	return args.Error(0)
+}
+
+// GetClusterConfigWatcher ...
+func (s *MockStore) GetClusterConfigWatcher(ctx context.Context) <-chan store.WatchEventClusterConfig {
+	args := s.Called(ctx)
+	return args.Get(0).(<-chan store.WatchEventClusterConfig)
+}
@@ -0,0 +1,34 @@
+package mockstore
+
+import (
+	"context"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+)
+
+// InitializeCompositeCheckStates ...
+func (s *MockStore) InitializeCompositeCheckStates(ctx context.Context, composite *corev2.CompositeCheck) (map[corev2.CompositeMember]uint32, error) {
+	args := s.Called(ctx, composite)
+	states, _ := args.Get(0).(map[corev2.CompositeMember]uint32)
+	return states, args.Error(1)
+}
+
+// UpdateCompositeCheckState ...
+func (s *MockStore) UpdateCompositeCheckState(ctx context.Context, composite *corev2.CompositeCheck, member corev2.CompositeMember, status uint32) (map[corev2.CompositeMember]uint32, error) {
+	args := s.Called(ctx, composite, member, status)
+	states, _ := args.Get(0).(map[corev2.CompositeMember]uint32)
+	return states, args.Error(1)
+}
+
+// DeleteCompositeCheckState ...
+func (s *MockStore) DeleteCompositeCheckState(ctx context.Context, composite *corev2.CompositeCheck, member corev2.CompositeMember) (map[corev2.CompositeMember]uint32, error) {
+	args := s.Called(ctx, composite, member)
+	states, _ := args.Get(0).(map[corev2.CompositeMember]uint32)
+	return states, args.Error(1)
+}
+
+// DeleteCompositeCheckStates ...
+func (s *MockStore) DeleteCompositeCheckStates(ctx context.Context, composite *corev2.CompositeCheck) error {
+	args := s.Called(ctx, composite)
+	return args.Error(0)
+}
@@ -0,0 +1,20 @@
+package mockstore
+
+import (
+	"context"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// GetHandlerResults ...
+func (s *MockStore) GetHandlerResults(ctx context.Context, entityName, checkName string) ([]*corev2.HandlerResult, error) {
+	args := s.Called(ctx, entityName, checkName)
+	return args.Get(0).([]*corev2.HandlerResult), args.Error(1)
+}
+
+// UpdateHandlerResults ...
+func (s *MockStore) UpdateHandlerResults(ctx context.Context, results []*store.EventHandlerResult) error {
+	args := s.Called(ctx, results)
+	return args.Error(0)
+}
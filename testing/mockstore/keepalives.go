@@ -18,8 +18,32 @@ func (s *MockStore) GetFailingKeepalives(ctx context.Context) ([]*types.Keepaliv
 	return args.Get(0).([]*types.KeepaliveRecord), args.Error(1)
 }
 
+// GetFailingKeepalive ...
+func (s *MockStore) GetFailingKeepalive(ctx context.Context, entity *types.Entity) (*types.KeepaliveRecord, error) {
+	args := s.Called(ctx, entity)
+	return args.Get(0).(*types.KeepaliveRecord), args.Error(1)
+}
+
 // UpdateFailingKeepalive ...
 func (s *MockStore) UpdateFailingKeepalive(ctx context.Context, entity *types.Entity, expiration int64) error {
 	args := s.Called(ctx, entity, expiration)
 	return args.Error(0)
 }
+
+// RecordKeepaliveConflict ...
+func (s *MockStore) RecordKeepaliveConflict(ctx context.Context, entity *types.Entity, ttl int64) error {
+	args := s.Called(ctx, entity, ttl)
+	return args.Error(0)
+}
+
+// GetKeepaliveConflict ...
+func (s *MockStore) GetKeepaliveConflict(ctx context.Context, entity *types.Entity) (int64, error) {
+	args := s.Called(ctx, entity)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// DeleteKeepaliveConflict ...
+func (s *MockStore) DeleteKeepaliveConflict(ctx context.Context, entity *types.Entity) (bool, error) {
+	args := s.Called(ctx, entity)
+	return args.Bool(0), args.Error(1)
+}
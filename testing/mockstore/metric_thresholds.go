@@ -0,0 +1,17 @@
+package mockstore
+
+import (
+	"context"
+)
+
+// GetMetricThresholdBreaches ...
+func (s *MockStore) GetMetricThresholdBreaches(ctx context.Context, entity, check string) (map[string]int, error) {
+	args := s.Called(ctx, entity, check)
+	return args.Get(0).(map[string]int), args.Error(1)
+}
+
+// UpdateMetricThresholdBreaches ...
+func (s *MockStore) UpdateMetricThresholdBreaches(ctx context.Context, entity, check string, breaches map[string]int) error {
+	args := s.Called(ctx, entity, check, breaches)
+	return args.Error(0)
+}
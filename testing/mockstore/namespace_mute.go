@@ -0,0 +1,32 @@
+package mockstore
+
+import (
+	"context"
+
+	"github.com/sensu/sensu-go/backend/store"
+	"github.com/sensu/sensu-go/types"
+)
+
+// GetNamespaceMute ...
+func (s *MockStore) GetNamespaceMute(ctx context.Context, namespace string) (*types.NamespaceMute, error) {
+	args := s.Called(ctx, namespace)
+	return args.Get(0).(*types.NamespaceMute), args.Error(1)
+}
+
+// GetNamespaceMutes ...
+func (s *MockStore) GetNamespaceMutes(ctx context.Context) (map[string]*types.NamespaceMute, error) {
+	args := s.Called(ctx)
+	return args.Get(0).(map[string]*types.NamespaceMute), args.Error(1)
+}
+
+// GetNamespaceMuteWatcher ...
+func (s *MockStore) GetNamespaceMuteWatcher(ctx context.Context) <-chan store.WatchEventNamespaceMute {
+	args := s.Called(ctx)
+	return args.Get(0).(<-chan store.WatchEventNamespaceMute)
+}
+
+// UpdateNamespaceMute ...
+func (s *MockStore) UpdateNamespaceMute(ctx context.Context, namespace string, mute *types.NamespaceMute) error {
+	args := s.Called(ctx, namespace, mute)
+	return args.Error(0)
+}
@@ -36,3 +36,21 @@ func (s *MockStore) ListResources(ctx context.Context, kind string, list interfa
 	args := s.Called(ctx, kind, list, pred)
 	return args.Error(0)
 }
+
+// CommitResources ...
+func (s *MockStore) CommitResources(ctx context.Context, ops []store.ResourceOp) error {
+	args := s.Called(ctx, ops)
+	return args.Error(0)
+}
+
+// GetVersionedResource ...
+func (s *MockStore) GetVersionedResource(ctx context.Context, name string, resource corev2.Resource) (int64, error) {
+	args := s.Called(ctx, name, resource)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// GetResourceVersion ...
+func (s *MockStore) GetResourceVersion(ctx context.Context, name string, resource corev2.Resource) (int64, error) {
+	args := s.Called(ctx, name, resource)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -51,3 +51,9 @@ func (s *MockStore) UpdateUser(user *types.User) error {
 	args := s.Called(user)
 	return args.Error(0)
 }
+
+// ResetUserPassword ...
+func (s *MockStore) ResetUserPassword(ctx context.Context, username, previous, password string) error {
+	args := s.Called(ctx, username, previous, password)
+	return args.Error(0)
+}
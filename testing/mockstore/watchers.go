@@ -0,0 +1,44 @@
+package mockstore
+
+import (
+	"context"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sensu/sensu-go/backend/store"
+)
+
+// WatchChecks ...
+func (s *MockStore) WatchChecks(ctx context.Context) <-chan store.WatchEventCheckConfig {
+	args := s.Called(ctx)
+	return args.Get(0).(<-chan store.WatchEventCheckConfig)
+}
+
+// WatchAssets ...
+func (s *MockStore) WatchAssets(ctx context.Context) <-chan store.WatchEventAsset {
+	args := s.Called(ctx)
+	return args.Get(0).(<-chan store.WatchEventAsset)
+}
+
+// WatchHooks ...
+func (s *MockStore) WatchHooks(ctx context.Context) <-chan store.WatchEventHookConfig {
+	args := s.Called(ctx)
+	return args.Get(0).(<-chan store.WatchEventHookConfig)
+}
+
+// WatchSilenced ...
+func (s *MockStore) WatchSilenced(ctx context.Context) <-chan store.WatchEventSilenced {
+	args := s.Called(ctx)
+	return args.Get(0).(<-chan store.WatchEventSilenced)
+}
+
+// WatchResources ...
+func (s *MockStore) WatchResources(ctx context.Context, resource corev2.Resource) <-chan store.WatchEventResource {
+	args := s.Called(ctx, resource)
+	return args.Get(0).(<-chan store.WatchEventResource)
+}
+
+// WatchDeletedEvents ...
+func (s *MockStore) WatchDeletedEvents(ctx context.Context) <-chan store.WatchEventResource {
+	args := s.Called(ctx)
+	return args.Get(0).(<-chan store.WatchEventResource)
+}
@@ -0,0 +1,109 @@
+package transport
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Check result schema versions. The agents advertise the versions they support
+// when they connect, and the backend picks the highest version supported by
+// both. Agents and backends predating the negotiation use version 1.
+const (
+	// CheckResultV1 is the original schema: the events are sent as is in
+	// event messages.
+	CheckResultV1 = 1
+
+	// CheckResultV2 sends the events in check_result messages, prefixed with
+	// their schema version. It has the same fields as version 1, but tells
+	// the backend the schema each result was encoded with, so that new fields
+	// can be introduced by later versions, only used with the agents and
+	// backends supporting them.
+	CheckResultV2 = 2
+)
+
+const (
+	// MessageTypeCheckResult is the message type string for the check
+	// results encoded with a check result schema of version 2 or later.
+	MessageTypeCheckResult = "check_result"
+
+	// HeaderKeyCheckResultVersions is the HTTP request header specifying the
+	// check result schema versions supported by the agent.
+	HeaderKeyCheckResultVersions = "Sensu-Check-Result-Versions"
+
+	// HeaderKeyCheckResultVersion is the HTTP response header specifying the
+	// check result schema version chosen by the backend.
+	HeaderKeyCheckResultVersion = "Sensu-Check-Result-Version"
+)
+
+// SupportedCheckResultVersions are the check result schema versions supported
+// by this version of Sensu.
+var SupportedCheckResultVersions = []int{CheckResultV1, CheckResultV2}
+
+// FormatCheckResultVersions formats versions as a header value.
+func FormatCheckResultVersions(versions []int) string {
+	values := make([]string, 0, len(versions))
+	for _, v := range versions {
+		values = append(values, strconv.Itoa(v))
+	}
+	return strings.Join(values, ",")
+}
+
+// NegotiateCheckResultVersion returns the highest version of supported that
+// is also part of offered, the value of the HeaderKeyCheckResultVersions
+// header sent by an agent. CheckResultV1 is returned if there is none.
+func NegotiateCheckResultVersion(offered string, supported []int) int {
+	version := CheckResultV1
+	for _, value := range strings.Split(offered, ",") {
+		v, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || v <= version {
+			continue
+		}
+		for _, s := range supported {
+			if s == v {
+				version = v
+				break
+			}
+		}
+	}
+	return version
+}
+
+// ParseCheckResultVersion parses the value of the HeaderKeyCheckResultVersion
+// header sent by a backend. CheckResultV1 is returned if the backend didn't
+// negotiate the version, or if the version isn't one of supported.
+func ParseCheckResultVersion(header string, supported []int) int {
+	v, err := strconv.Atoi(strings.TrimSpace(header))
+	if err != nil {
+		return CheckResultV1
+	}
+	for _, s := range supported {
+		if s == v {
+			return v
+		}
+	}
+	return CheckResultV1
+}
+
+// EncodeCheckResult prefixes an encoded event with its check result schema
+// version, to be sent in a check_result message.
+func EncodeCheckResult(version int, event []byte) []byte {
+	buf := []byte(strconv.Itoa(version) + "\n")
+	return append(buf, event...)
+}
+
+// DecodeCheckResult returns the check result schema version and the encoded
+// event of the payload of a check_result message.
+func DecodeCheckResult(payload []byte) (int, []byte, error) {
+	nl := bytes.Index(payload, sep)
+	if nl < 0 {
+		return 0, nil, errors.New("invalid check result")
+	}
+	version, err := strconv.Atoi(string(payload[:nl]))
+	if err != nil || version < CheckResultV2 {
+		return 0, nil, fmt.Errorf("invalid check result schema version %q", payload[:nl])
+	}
+	return version, payload[nl+1:], nil
+}
@@ -0,0 +1,98 @@
+package transport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckResultVersionNegotiation(t *testing.T) {
+	tests := []struct {
+		name      string
+		agent     []int
+		backend   []int
+		wantAgent int
+	}{
+		{
+			name:      "agent and backend predating the negotiation",
+			agent:     nil,
+			backend:   nil,
+			wantAgent: CheckResultV1,
+		},
+		{
+			name:      "agent predating the negotiation",
+			agent:     nil,
+			backend:   SupportedCheckResultVersions,
+			wantAgent: CheckResultV1,
+		},
+		{
+			name:      "backend predating the negotiation",
+			agent:     SupportedCheckResultVersions,
+			backend:   nil,
+			wantAgent: CheckResultV1,
+		},
+		{
+			name:      "v1 backend",
+			agent:     SupportedCheckResultVersions,
+			backend:   []int{CheckResultV1},
+			wantAgent: CheckResultV1,
+		},
+		{
+			name:      "v2 agent and backend",
+			agent:     SupportedCheckResultVersions,
+			backend:   SupportedCheckResultVersions,
+			wantAgent: CheckResultV2,
+		},
+		{
+			name:      "newer agent",
+			agent:     []int{CheckResultV1, CheckResultV2, 3},
+			backend:   SupportedCheckResultVersions,
+			wantAgent: CheckResultV2,
+		},
+		{
+			name:      "newer backend",
+			agent:     SupportedCheckResultVersions,
+			backend:   []int{CheckResultV1, CheckResultV2, 3},
+			wantAgent: CheckResultV2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The request header is only sent by agents supporting the
+			// negotiation, and the response header only by such backends
+			var offered, chosen string
+			if tt.agent != nil {
+				offered = FormatCheckResultVersions(tt.agent)
+			}
+			if tt.backend != nil {
+				version := NegotiateCheckResultVersion(offered, tt.backend)
+				chosen = FormatCheckResultVersions([]int{version})
+			}
+			agentVersions := tt.agent
+			if agentVersions == nil {
+				agentVersions = []int{CheckResultV1}
+			}
+			assert.Equal(t, tt.wantAgent, ParseCheckResultVersion(chosen, agentVersions))
+		})
+	}
+}
+
+func TestNegotiateCheckResultVersionInvalid(t *testing.T) {
+	assert.Equal(t, CheckResultV1, NegotiateCheckResultVersion("foo, -1,,0", SupportedCheckResultVersions))
+	assert.Equal(t, CheckResultV2, NegotiateCheckResultVersion(" 2 , foo", SupportedCheckResultVersions))
+	assert.Equal(t, CheckResultV1, ParseCheckResultVersion("3", SupportedCheckResultVersions))
+}
+
+func TestEncodeDecodeCheckResult(t *testing.T) {
+	event := []byte("{\"check\":{\"output\":\"line 1\\nline 2\\n\"}}")
+	version, decoded, err := DecodeCheckResult(EncodeCheckResult(CheckResultV2, event))
+	require.NoError(t, err)
+	assert.Equal(t, CheckResultV2, version)
+	assert.Equal(t, event, decoded)
+
+	for _, payload := range []string{"", "2", "foo\n{}", "1\n{}"} {
+		_, _, err := DecodeCheckResult([]byte(payload))
+		assert.Error(t, err, payload)
+	}
+}
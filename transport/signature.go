@@ -0,0 +1,216 @@
+package transport
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+)
+
+const (
+	// MessageTypeSigned is the message type string for the messages signed by
+	// the agent. Their payload is a header line holding the algorithm, the
+	// sequence number and the signature, followed by the encoded signed
+	// message.
+	MessageTypeSigned = "signed"
+
+	// HeaderKeySignedMessages is the HTTP response header telling the agent
+	// that the backend accepts signed messages. Its value is the nonce of the
+	// session, which the signatures cover so they can't be replayed in
+	// another session.
+	HeaderKeySignedMessages = "Sensu-Signed-Messages"
+
+	// SignatureHMACSHA256 signs the messages with an HMAC-SHA256 of a secret
+	// shared by the agents and the backends.
+	SignatureHMACSHA256 = "hmac-sha256"
+
+	// SignatureEd25519 signs the messages with the ed25519 private key of the
+	// agent, verified with its public key by the backends.
+	SignatureEd25519 = "ed25519"
+)
+
+// ErrInvalidSignature is returned when a signed message can't be verified
+// with any of the verification keys of its agent.
+var ErrInvalidSignature = errors.New("invalid message signature")
+
+// SigningKey is the key an agent signs its messages with.
+type SigningKey struct {
+	algorithm  string
+	secret     []byte
+	privateKey ed25519.PrivateKey
+}
+
+// VerificationKey is a key a backend verifies the signed messages of an agent
+// with.
+type VerificationKey struct {
+	agent     string
+	algorithm string
+	secret    []byte
+	publicKey ed25519.PublicKey
+}
+
+// LoadSigningKey reads the signing key of an agent from a file, containing
+// either an ed25519 private key in PKCS #8, PEM format, or an HMAC secret.
+func LoadSigningKey(path string) (*SigningKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signing key %s: %s", path, err)
+		}
+		privateKey, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("invalid signing key %s: only ed25519 keys are supported", path)
+		}
+		return &SigningKey{algorithm: SignatureEd25519, privateKey: privateKey}, nil
+	}
+	secret, err := hmacSecret(path, data)
+	if err != nil {
+		return nil, err
+	}
+	return &SigningKey{algorithm: SignatureHMACSHA256, secret: secret}, nil
+}
+
+// LoadVerificationKey reads the key verifying the messages of the named agent
+// from a file, containing either an ed25519 public key in PKIX, PEM format, or
+// an HMAC secret.
+func LoadVerificationKey(agent, path string) (*VerificationKey, error) {
+	if agent == "" {
+		return nil, fmt.Errorf("no agent name for the verification key %s", path)
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid verification key %s: %s", path, err)
+		}
+		publicKey, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("invalid verification key %s: only ed25519 keys are supported", path)
+		}
+		return &VerificationKey{agent: agent, algorithm: SignatureEd25519, publicKey: publicKey}, nil
+	}
+	secret, err := hmacSecret(path, data)
+	if err != nil {
+		return nil, err
+	}
+	return &VerificationKey{agent: agent, algorithm: SignatureHMACSHA256, secret: secret}, nil
+}
+
+func hmacSecret(path string, data []byte) ([]byte, error) {
+	secret := bytes.TrimSpace(data)
+	if len(secret) == 0 {
+		return nil, fmt.Errorf("empty HMAC secret in %s", path)
+	}
+	return secret, nil
+}
+
+func hmacSHA256(secret, data []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	_, _ = mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// signedData returns the data covered by the signature of a message: the
+// nonce of the session, the sequence number of the message and the encoded
+// message.
+func signedData(nonce string, sequence uint64, encoded []byte) []byte {
+	data := make([]byte, 0, len(nonce)+len(encoded)+22)
+	data = append(data, nonce...)
+	data = append(data, '\n')
+	data = strconv.AppendUint(data, sequence, 10)
+	data = append(data, '\n')
+	return append(data, encoded...)
+}
+
+// Sign wraps the message in a signed message. The signature covers the type
+// and the payload of the message, along with the nonce of the session and the
+// sequence number of the message, which must increase with each message of
+// the session.
+func (k *SigningKey) Sign(msg *Message, nonce string, sequence uint64) *Message {
+	encoded := Encode(msg.Type, msg.Payload)
+	data := signedData(nonce, sequence, encoded)
+	var signature []byte
+	switch k.algorithm {
+	case SignatureEd25519:
+		signature = ed25519.Sign(k.privateKey, data)
+	default:
+		signature = hmacSHA256(k.secret, data)
+	}
+	header := fmt.Sprintf("%s %d %s\n", k.algorithm, sequence, base64.StdEncoding.EncodeToString(signature))
+	return &Message{
+		Type:         MessageTypeSigned,
+		Payload:      append([]byte(header), encoded...),
+		SendCallback: msg.SendCallback,
+	}
+}
+
+// verify returns true if the signature of data is valid for the key.
+func (k *VerificationKey) verify(algorithm string, data, signature []byte) bool {
+	if k.algorithm != algorithm {
+		return false
+	}
+	switch k.algorithm {
+	case SignatureEd25519:
+		return ed25519.Verify(k.publicKey, data, signature)
+	default:
+		return hmac.Equal(hmacSHA256(k.secret, data), signature)
+	}
+}
+
+// VerifySignedMessage returns the type, the payload and the sequence number of
+// the message wrapped in the payload of a signed message, sent by the agent in
+// the session with the given nonce. ErrInvalidSignature is returned if the
+// signature isn't valid for any of the keys of the agent. The signature isn't
+// verified if keys is empty. The caller must reject the sequence numbers which
+// don't increase, so that the messages can't be replayed within the session.
+func VerifySignedMessage(payload []byte, agent, nonce string, keys []*VerificationKey) (string, []byte, uint64, error) {
+	nl := bytes.Index(payload, sep)
+	if nl < 0 {
+		return "", nil, 0, errors.New("invalid signed message")
+	}
+	header, encoded := payload[:nl], payload[nl+1:]
+	msgType, msg, err := Decode(encoded)
+	if err != nil {
+		return "", nil, 0, err
+	}
+	if msgType == MessageTypeSigned {
+		return "", nil, 0, errors.New("invalid signed message: nested signed message")
+	}
+	if len(keys) == 0 {
+		return msgType, msg, 0, nil
+	}
+
+	fields := bytes.SplitN(header, []byte(" "), 3)
+	if len(fields) != 3 {
+		return msgType, nil, 0, ErrInvalidSignature
+	}
+	sequence, err := strconv.ParseUint(string(fields[1]), 10, 64)
+	if err != nil {
+		return msgType, nil, 0, ErrInvalidSignature
+	}
+	signature, err := base64.StdEncoding.DecodeString(string(fields[2]))
+	if err != nil {
+		return msgType, nil, 0, ErrInvalidSignature
+	}
+	data := signedData(nonce, sequence, encoded)
+	for _, key := range keys {
+		if key.agent == agent && key.verify(string(fields[0]), data, signature) {
+			return msgType, msg, sequence, nil
+		}
+	}
+	return msgType, nil, 0, ErrInvalidSignature
+}
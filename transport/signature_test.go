@@ -0,0 +1,123 @@
+package transport
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKeyFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+	return path
+}
+
+func TestSignedMessages(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signature")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+	privatePath := writeKeyFile(t, dir, "agent.key", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	der, err = x509.MarshalPKIXPublicKey(publicKey)
+	require.NoError(t, err)
+	publicPath := writeKeyFile(t, dir, "agent.pub", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	secretPath := writeKeyFile(t, dir, "secret", []byte("s3cr3t\n"))
+	otherSecretPath := writeKeyFile(t, dir, "other", []byte("other"))
+
+	tests := []struct {
+		name        string
+		signingKey  string
+		verifyKeys  map[string]string
+		agent       string
+		nonce       string
+		wantErr     error
+		wantPayload bool
+	}{
+		{"hmac", secretPath, map[string]string{secretPath: "agent1"}, "agent1", "nonce", nil, true},
+		{"ed25519", privatePath, map[string]string{otherSecretPath: "agent1", publicPath: "agent1"}, "agent1", "nonce", nil, true},
+		{"wrong secret", secretPath, map[string]string{otherSecretPath: "agent1"}, "agent1", "nonce", ErrInvalidSignature, false},
+		{"wrong algorithm", privatePath, map[string]string{secretPath: "agent1"}, "agent1", "nonce", ErrInvalidSignature, false},
+		{"key of another agent", secretPath, map[string]string{secretPath: "agent2"}, "agent1", "nonce", ErrInvalidSignature, false},
+		{"another session", secretPath, map[string]string{secretPath: "agent1"}, "agent1", "other", ErrInvalidSignature, false},
+		{"no verification keys", secretPath, nil, "agent1", "nonce", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signingKey, err := LoadSigningKey(tt.signingKey)
+			require.NoError(t, err)
+			var keys []*VerificationKey
+			for path, agent := range tt.verifyKeys {
+				key, err := LoadVerificationKey(agent, path)
+				require.NoError(t, err)
+				keys = append(keys, key)
+			}
+
+			signed := signingKey.Sign(NewMessage(MessageTypeEvent, []byte(`{"check":{}}`)), "nonce", 42)
+			assert.Equal(t, MessageTypeSigned, signed.Type)
+
+			msgType, payload, sequence, err := VerifySignedMessage(signed.Payload, tt.agent, tt.nonce, keys)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, MessageTypeEvent, msgType)
+			if tt.wantPayload {
+				assert.Equal(t, `{"check":{}}`, string(payload))
+			}
+			if tt.wantPayload && len(keys) > 0 {
+				assert.Equal(t, uint64(42), sequence)
+			}
+		})
+	}
+}
+
+func TestSignedMessagesTampering(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signature")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := writeKeyFile(t, dir, "secret", []byte("s3cr3t"))
+	signingKey, err := LoadSigningKey(path)
+	require.NoError(t, err)
+	verificationKey, err := LoadVerificationKey("agent1", path)
+	require.NoError(t, err)
+	keys := []*VerificationKey{verificationKey}
+
+	signed := signingKey.Sign(NewMessage(MessageTypeEvent, []byte(`{"check":{"status":2}}`)), "nonce", 1)
+	tampered := []byte(string(signed.Payload[:len(signed.Payload)-3]) + "0}}")
+	_, _, _, err = VerifySignedMessage(tampered, "agent1", "nonce", keys)
+	assert.Equal(t, ErrInvalidSignature, err)
+
+	// The sequence number is covered by the signature
+	resequenced := []byte(strings.Replace(string(signed.Payload), " 1 ", " 2 ", 1))
+	_, _, _, err = VerifySignedMessage(resequenced, "agent1", "nonce", keys)
+	assert.Equal(t, ErrInvalidSignature, err)
+
+	_, _, _, err = VerifySignedMessage([]byte("garbage"), "agent1", "nonce", keys)
+	assert.Error(t, err)
+}
+
+func TestLoadSigningKeyErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "signature")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	_, err = LoadSigningKey(writeKeyFile(t, dir, "empty", []byte("\n")))
+	assert.Error(t, err)
+	_, err = LoadSigningKey(writeKeyFile(t, dir, "invalid.pem", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("foo")})))
+	assert.Error(t, err)
+	_, err = LoadVerificationKey("agent1", filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+	_, err = LoadVerificationKey("", writeKeyFile(t, dir, "secret", []byte("s3cr3t")))
+	assert.Error(t, err)
+}
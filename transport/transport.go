@@ -30,6 +30,14 @@ const (
 	// MessageTypeEvent is the message type string for events.
 	MessageTypeEvent = "event"
 
+	// MessageTypeRejection is the message type string for rejections of
+	// messages that the backend refused to accept, e.g. oversized events.
+	MessageTypeRejection = "rejection"
+
+	// MessageTypeDeregistration is the message type string for the last
+	// keepalive of ephemeral agents, sent when they shut down.
+	MessageTypeDeregistration = "deregistration"
+
 	// HeaderKeyAgentName is the HTTP request header specifying the Agent name
 	HeaderKeyAgentName = "Sensu-AgentName"
 
@@ -41,6 +49,10 @@ const (
 
 	// HeaderKeySubscriptions is the HTTP request header specifying the Agent Subscriptions
 	HeaderKeySubscriptions = "Sensu-Subscriptions"
+
+	// HeaderKeyEntityClass is the HTTP request header specifying the class of
+	// the Agent entity
+	HeaderKeyEntityClass = "Sensu-Entity-Class"
 )
 
 // A ClosedError is returned when Receive or Send is called on a closed
@@ -9,6 +9,7 @@ type (
 	Check               = v2.Check
 	CheckConfig         = v2.CheckConfig
 	CheckHistory        = v2.CheckHistory
+	CheckPause          = v2.CheckPause
 	CheckRequest        = v2.CheckRequest
 	Claims              = v2.Claims
 	ClusterHealth       = v2.ClusterHealth
@@ -20,6 +21,7 @@ type (
 	EventFilter         = v2.EventFilter
 	Extension           = v2.Extension
 	Handler             = v2.Handler
+	HandlerResult       = v2.HandlerResult
 	HandlerSocket       = v2.HandlerSocket
 	HealthResponse      = v2.HealthResponse
 	Hook                = v2.Hook
@@ -31,6 +33,7 @@ type (
 	Metrics             = v2.Metrics
 	Mutator             = v2.Mutator
 	Namespace           = v2.Namespace
+	NamespaceMute       = v2.NamespaceMute
 	Network             = v2.Network
 	NetworkInterface    = v2.NetworkInterface
 	ObjectMeta          = v2.ObjectMeta
@@ -41,6 +44,7 @@ type (
 	RoleRef             = v2.RoleRef
 	Rule                = v2.Rule
 	Silenced            = v2.Silenced
+	SilencedFilter      = v2.SilencedFilter
 	Subject             = v2.Subject
 	System              = v2.System
 	TLSOptions          = v2.TLSOptions
@@ -125,6 +129,23 @@ const (
 	// HandlerGRPCType is a special kind of handler that represents an extension
 	HandlerGRPCType = v2.HandlerGRPCType
 
+	// HandlerSlackType represents handlers that post event notifications to a
+	// Slack incoming webhook, without executing an external command
+	HandlerSlackType = v2.HandlerSlackType
+
+	// HandlerPagerDutyType represents handlers that trigger and resolve
+	// PagerDuty alerts with the Events API v2, without executing an external
+	// command
+	HandlerPagerDutyType = v2.HandlerPagerDutyType
+
+	// HandlerEmailType represents handlers that send event notifications by
+	// email, without executing an external command
+	HandlerEmailType = v2.HandlerEmailType
+
+	// HandlerWebhookType represents handlers that post event data to an HTTP
+	// endpoint, without executing an external command
+	HandlerWebhookType = v2.HandlerWebhookType
+
 	// EventFilterActionAllow is an action to allow events to pass through to the pipeline
 	EventFilterActionAllow = v2.EventFilterActionAllow
 
@@ -199,6 +220,7 @@ var (
 	FixtureMetricPoint        = v2.FixtureMetricPoint
 	FixtureMetricTag          = v2.FixtureMetricTag
 	FixtureHandler            = v2.FixtureHandler
+	FixtureHandlerResult      = v2.FixtureHandlerResult
 	FixtureSocketHandler      = v2.FixtureSocketHandler
 	FixtureSetHandler         = v2.FixtureSetHandler
 	FixtureUser               = v2.FixtureUser
@@ -0,0 +1,219 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// extendedAttributesField is the name of the struct field in which some
+// resources store arbitrary JSON-encoded attributes.
+const extendedAttributesField = "ExtendedAttributes"
+
+// jsonField describes how a struct field is represented in JSON.
+type jsonField struct {
+	Name      string
+	OmitEmpty bool
+}
+
+// parseJSONTag returns the JSON representation of the struct field, and false
+// if the field is never marshaled.
+func parseJSONTag(field reflect.StructField) (jsonField, bool) {
+	if field.PkgPath != "" {
+		// unexported
+		return jsonField{}, false
+	}
+	f := jsonField{Name: field.Name}
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		return f, true
+	}
+	if tag == "-" {
+		return jsonField{}, false
+	}
+	parts := strings.Split(tag, ",")
+	if parts[0] != "" {
+		f.Name = parts[0]
+	}
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			f.OmitEmpty = true
+		}
+	}
+	return f, true
+}
+
+// emitDefaults adds to m the zero value of every field of v that was omitted
+// from its JSON representation because of the omitempty option, so that zero
+// values and empty arrays survive an export and re-import. m is the JSON
+// representation of v, decoded into a map. Nil pointers and byte slices are
+// left out, since they have no meaningful zero value.
+func emitDefaults(v reflect.Value, m map[string]interface{}) {
+	v = reflect.Indirect(v)
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	typ := v.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		jf, ok := parseJSONTag(field)
+		if !ok {
+			continue
+		}
+		value := v.Field(i)
+		if field.Anonymous && reflect.Indirect(value).Kind() == reflect.Struct {
+			if _, tagged := field.Tag.Lookup("json"); !tagged {
+				// Embedded structs are flattened
+				emitDefaults(value, m)
+				continue
+			}
+		}
+		existing, found := m[jf.Name]
+		if !found {
+			if jf.OmitEmpty {
+				if zero, ok := zeroJSONValue(value.Type()); ok {
+					m[jf.Name] = zero
+				}
+			}
+			continue
+		}
+		emitNestedDefaults(value, existing)
+	}
+}
+
+// emitNestedDefaults calls emitDefaults on the structs found in value, whose
+// JSON representation is existing.
+func emitNestedDefaults(value reflect.Value, existing interface{}) {
+	switch reflect.Indirect(value).Kind() {
+	case reflect.Struct:
+		if nested, ok := existing.(map[string]interface{}); ok {
+			emitDefaults(value, nested)
+		}
+	case reflect.Slice, reflect.Array:
+		elems, ok := existing.([]interface{})
+		value = reflect.Indirect(value)
+		if !ok || len(elems) != value.Len() {
+			return
+		}
+		for i := range elems {
+			emitNestedDefaults(value.Index(i), elems[i])
+		}
+	}
+}
+
+// zeroJSONValue returns the JSON representation of the zero value of typ, as
+// decoded by toMap.
+func zeroJSONValue(typ reflect.Type) (interface{}, bool) {
+	switch typ.Kind() {
+	case reflect.String:
+		return "", true
+	case reflect.Bool:
+		return false, true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return json.Number("0"), true
+	case reflect.Slice:
+		if typ.Elem().Kind() == reflect.Uint8 {
+			// []byte are encoded as base64 strings, or by custom marshalers
+			return nil, false
+		}
+		return []interface{}{}, true
+	case reflect.Map:
+		return map[string]interface{}{}, true
+	}
+	return nil, false
+}
+
+// extendedAttributes returns the extended attributes of v, if it supports
+// them.
+func extendedAttributes(v interface{}) (reflect.Value, bool) {
+	val := reflect.Indirect(reflect.ValueOf(v))
+	if val.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	attrs := val.FieldByName(extendedAttributesField)
+	if !attrs.IsValid() || attrs.Kind() != reflect.Slice || attrs.Type().Elem().Kind() != reflect.Uint8 {
+		return reflect.Value{}, false
+	}
+	return attrs, true
+}
+
+// addExtendedAttributes adds the extended attributes of v to m, its JSON
+// representation decoded into a map. Extended attributes never override the
+// regular fields.
+func addExtendedAttributes(v interface{}, m map[string]interface{}) error {
+	attrs, ok := extendedAttributes(v)
+	if !ok || attrs.Len() == 0 {
+		return nil
+	}
+	extended := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(attrs.Bytes()))
+	dec.UseNumber()
+	if err := dec.Decode(&extended); err != nil {
+		return err
+	}
+	for k, v := range extended {
+		if _, ok := m[k]; !ok {
+			m[k] = v
+		}
+	}
+	return nil
+}
+
+// jsonFieldNames returns the names of the JSON fields of the struct type typ.
+func jsonFieldNames(typ reflect.Type, names map[string]struct{}) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		jf, ok := parseJSONTag(field)
+		if !ok {
+			continue
+		}
+		if _, tagged := field.Tag.Lookup("json"); field.Anonymous && !tagged {
+			jsonFieldNames(field.Type, names)
+			continue
+		}
+		names[jf.Name] = struct{}{}
+	}
+}
+
+// splitExtendedAttributes separates the fields of spec that are unknown to
+// resource, if it supports extended attributes. It returns the known fields,
+// and the unknown fields as a JSON object, or nil if there are none.
+func splitExtendedAttributes(resource interface{}, spec []byte) ([]byte, []byte, error) {
+	if _, ok := extendedAttributes(resource); !ok {
+		return spec, nil, nil
+	}
+	var fields map[string]*json.RawMessage
+	if err := json.Unmarshal(spec, &fields); err != nil {
+		return nil, nil, err
+	}
+	names := map[string]struct{}{}
+	jsonFieldNames(reflect.TypeOf(resource), names)
+	extended := map[string]*json.RawMessage{}
+	for k, v := range fields {
+		if _, ok := names[k]; !ok {
+			extended[k] = v
+			delete(fields, k)
+		}
+	}
+	if len(extended) == 0 {
+		return spec, nil, nil
+	}
+	known, err := json.Marshal(fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	attrs, err := json.Marshal(extended)
+	if err != nil {
+		return nil, nil, err
+	}
+	return known, attrs, nil
+}
@@ -0,0 +1,105 @@
+package types
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertSubset asserts that every field of want is found in got, with the
+// same value.
+func assertSubset(t *testing.T, want, got interface{}, path string) {
+	t.Helper()
+	switch want := want.(type) {
+	case map[string]interface{}:
+		gotMap, ok := got.(map[string]interface{})
+		if !ok {
+			t.Errorf("%s: want an object, got %v", path, got)
+			return
+		}
+		for k, v := range want {
+			g, ok := gotMap[k]
+			if !ok {
+				t.Errorf("%s.%s: missing field", path, k)
+				continue
+			}
+			assertSubset(t, v, g, path+"."+k)
+		}
+	case []interface{}:
+		gotSlice, ok := got.([]interface{})
+		if !ok || len(gotSlice) != len(want) {
+			t.Errorf("%s: want %v, got %v", path, want, got)
+			return
+		}
+		for i := range want {
+			assertSubset(t, want[i], gotSlice[i], path)
+		}
+	default:
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("%s: want %v, got %v", path, want, got)
+		}
+	}
+}
+
+func TestWrapperRoundTrip(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("testdata", "roundtrip", "*.json"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			golden, err := ioutil.ReadFile(file)
+			require.NoError(t, err)
+
+			var imported Wrapper
+			require.NoError(t, json.Unmarshal(golden, &imported))
+
+			exported, err := json.Marshal(imported)
+			require.NoError(t, err)
+
+			var want, got interface{}
+			require.NoError(t, json.Unmarshal(golden, &want))
+			require.NoError(t, json.Unmarshal(exported, &got))
+			assertSubset(t, want, got, "")
+
+			// Exporting the re-imported resource must not change anything
+			var reimported Wrapper
+			require.NoError(t, json.Unmarshal(exported, &reimported))
+			reexported, err := json.Marshal(reimported)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(exported), string(reexported))
+		})
+	}
+}
+
+func TestWrapperExtendedAttributes(t *testing.T) {
+	check := corev2.FixtureCheckConfig("check")
+	check.ExtendedAttributes = []byte(`{"team":"ops","command":"ignored"}`)
+
+	b, err := json.Marshal(WrapResource(check))
+	require.NoError(t, err)
+
+	var spec struct {
+		Spec map[string]interface{} `json:"spec"`
+	}
+	require.NoError(t, json.Unmarshal(b, &spec))
+	assert.Equal(t, "ops", spec.Spec["team"])
+	assert.Equal(t, check.Command, spec.Spec["command"])
+
+	var w Wrapper
+	require.NoError(t, json.Unmarshal(b, &w))
+	assert.JSONEq(t, `{"team":"ops"}`, string(w.Value.(*corev2.CheckConfig).ExtendedAttributes))
+}
+
+func TestWrapperUnknownFields(t *testing.T) {
+	// Resources without extended attributes still reject unknown fields
+	b := []byte(`{"type":"Handler","metadata":{"name":"foo"},"spec":{"type":"pipe","foo":"bar"}}`)
+	var w Wrapper
+	assert.Error(t, json.Unmarshal(b, &w))
+}
@@ -42,7 +42,9 @@ type lifter interface {
 
 // toMap produces a map from a struct by serializing it to JSON and then
 // deserializing the JSON into a map. This is done to preserve business logic
-// expressed in customer marshalers, and JSON struct tag semantics.
+// expressed in customer marshalers, and JSON struct tag semantics. Zero values
+// omitted by the JSON struct tags and extended attributes are added back, so
+// that the map can be re-imported without losing any field.
 func toMap(v interface{}) (map[string]interface{}, error) {
 	b, err := json.Marshal(v)
 	if err != nil {
@@ -51,20 +53,31 @@ func toMap(v interface{}) (map[string]interface{}, error) {
 	result := map[string]interface{}{}
 	dec := json.NewDecoder(bytes.NewReader(b))
 	dec.UseNumber()
-	err = dec.Decode(&result)
-	return result, err
+	if err := dec.Decode(&result); err != nil {
+		return nil, err
+	}
+	emitDefaults(reflect.ValueOf(v), result)
+	if err := addExtendedAttributes(v, result); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 // MarshalJSON implements json.Marshaler
 func (w Wrapper) MarshalJSON() ([]byte, error) {
 	wrapper := struct {
 		TypeMeta
-		ObjectMeta ObjectMeta             `json:"metadata"`
+		ObjectMeta map[string]interface{} `json:"metadata"`
 		Value      map[string]interface{} `json:"spec"`
 	}{
-		TypeMeta:   w.TypeMeta,
-		ObjectMeta: w.ObjectMeta,
+		TypeMeta: w.TypeMeta,
+	}
+
+	meta, err := toMap(w.ObjectMeta)
+	if err != nil {
+		return nil, err
 	}
+	wrapper.ObjectMeta = meta
 
 	// Remove the innerMeta
 	value, err := toMap(w.Value)
@@ -131,11 +144,21 @@ func (w *Wrapper) UnmarshalJSON(b []byte) error {
 	if wrapper.Value == nil {
 		return fmt.Errorf("no spec provided")
 	}
-	dec := json.NewDecoder(bytes.NewReader(*wrapper.Value))
+	// Fields unknown to resources that support extended attributes are kept
+	// as such, instead of being rejected
+	spec, attrs, err := splitExtendedAttributes(resource, *wrapper.Value)
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(spec))
 	dec.DisallowUnknownFields()
 	if err := dec.Decode(&resource); err != nil {
 		return err
 	}
+	if attrs != nil {
+		field, _ := extendedAttributes(resource)
+		field.SetBytes(attrs)
+	}
 
 	// Special case for the Namespace resource
 	if _, ok := resource.(*Namespace); ok {
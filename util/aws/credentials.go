@@ -0,0 +1,124 @@
+// Package aws provides the AWS credentials and the request signing shared by
+// the backend components calling AWS APIs, without depending on the AWS SDK.
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// instanceMetadataURL is the base URL of the EC2 instance metadata service.
+var instanceMetadataURL = "http://169.254.169.254/latest"
+
+// Credentials are the AWS credentials used to sign requests.
+type Credentials struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Expiration      time.Time
+}
+
+// CredentialsProvider retrieves AWS credentials.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context) (Credentials, error)
+}
+
+// DefaultCredentialsProvider retrieves credentials from the standard AWS
+// environment variables and falls back to the IAM role of the EC2 instance
+// the backend runs on.
+type DefaultCredentialsProvider struct {
+	mu     sync.Mutex
+	cached Credentials
+}
+
+// Credentials implements CredentialsProvider.
+func (p *DefaultCredentialsProvider) Credentials(ctx context.Context) (Credentials, error) {
+	if id, secret := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY"); id != "" && secret != "" {
+		return Credentials{
+			AccessKeyID:     id,
+			SecretAccessKey: secret,
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		}, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Refresh the instance role credentials a few minutes before they expire
+	if p.cached.AccessKeyID != "" && time.Now().Add(5*time.Minute).Before(p.cached.Expiration) {
+		return p.cached, nil
+	}
+	creds, err := instanceRoleCredentials(ctx)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("couldn't retrieve AWS credentials: %s", err)
+	}
+	p.cached = creds
+	return creds, nil
+}
+
+type instanceRoleResponse struct {
+	AccessKeyID     string    `json:"AccessKeyId"`
+	SecretAccessKey string    `json:"SecretAccessKey"`
+	Token           string    `json:"Token"`
+	Expiration      time.Time `json:"Expiration"`
+}
+
+func instanceRoleCredentials(ctx context.Context) (Credentials, error) {
+	// An IMDSv2 token is optional, unless the instance enforces it
+	token, _ := instanceMetadata(ctx, "PUT", "/api/token", "")
+
+	roles, err := instanceMetadata(ctx, "GET", "/meta-data/iam/security-credentials/", token)
+	if err != nil {
+		return Credentials{}, err
+	}
+	role := strings.TrimSpace(strings.SplitN(roles, "\n", 2)[0])
+	if role == "" {
+		return Credentials{}, errors.New("no IAM role attached to the instance")
+	}
+
+	body, err := instanceMetadata(ctx, "GET", "/meta-data/iam/security-credentials/"+role, token)
+	if err != nil {
+		return Credentials{}, err
+	}
+	var resp instanceRoleResponse
+	if err := json.Unmarshal([]byte(body), &resp); err != nil {
+		return Credentials{}, err
+	}
+	return Credentials{
+		AccessKeyID:     resp.AccessKeyID,
+		SecretAccessKey: resp.SecretAccessKey,
+		SessionToken:    resp.Token,
+		Expiration:      resp.Expiration,
+	}, nil
+}
+
+func instanceMetadata(ctx context.Context, method, path, token string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, method, instanceMetadataURL+path, nil)
+	if err != nil {
+		return "", err
+	}
+	if method == "PUT" {
+		req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "60")
+	}
+	if token != "" {
+		req.Header.Set("X-aws-ec2-metadata-token", token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("instance metadata service returned status %d", resp.StatusCode)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	return string(b), err
+}
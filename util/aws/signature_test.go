@@ -0,0 +1,37 @@
+package aws
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignRequest(t *testing.T) {
+	creds := Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}
+	now := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	sign := func(service string, creds Credentials) *http.Request {
+		req, err := http.NewRequest("PUT", "https://bucket.s3.us-east-1.amazonaws.com/events/default.ndjson", strings.NewReader("{}\n"))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		SignRequest(req, []byte("{}\n"), creds, "us-east-1", service, now)
+		return req
+	}
+
+	req := sign("sqs", creds)
+	assert.Equal(t, "20200102T030405Z", req.Header.Get("X-Amz-Date"))
+	assert.Empty(t, req.Header.Get("X-Amz-Content-Sha256"))
+	auth := req.Header.Get("Authorization")
+	assert.True(t, strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20200102/us-east-1/sqs/aws4_request, SignedHeaders=content-type;host;x-amz-date, Signature="))
+	assert.Equal(t, auth, sign("sqs", creds).Header.Get("Authorization"), "signatures must be deterministic")
+
+	creds.SessionToken = "token"
+	req = sign("s3", creds)
+	assert.Equal(t, "token", req.Header.Get("X-Amz-Security-Token"))
+	assert.Equal(t, "ca3d163bab055381827226140568f3bef7eaac187cebd76878e0b63e9e442356", req.Header.Get("X-Amz-Content-Sha256"))
+	assert.Contains(t, req.Header.Get("Authorization"), "SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date;x-amz-security-token,")
+}
@@ -0,0 +1,87 @@
+// Package httpclient creates HTTP clients configured with an explicit proxy and
+// certificate authorities, for the networks that only reach the internet
+// through a proxy.
+package httpclient
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Config configures the proxy and the certificate authorities of HTTP
+// clients. The zero value uses the proxy of the environment and the system
+// certificate authorities, like the default HTTP client.
+type Config struct {
+	// ProxyURL is the URL of the proxy of the HTTP and HTTPS requests. The
+	// HTTP_PROXY and HTTPS_PROXY environment variables are used if it's empty.
+	ProxyURL string
+
+	// NoProxy lists the hosts, domains, IP addresses and CIDR blocks that are
+	// reached without the proxy, like the NO_PROXY environment variable,
+	// which is used if it's empty.
+	NoProxy []string
+
+	// TrustedCAFile is the path of a bundle of CA certificates in PEM format,
+	// trusted instead of the system certificate authorities.
+	TrustedCAFile string
+}
+
+// Validate returns an error if the proxy URL is invalid.
+func (c Config) Validate() error {
+	if c.ProxyURL == "" {
+		return nil
+	}
+	u, err := url.Parse(c.ProxyURL)
+	if err != nil {
+		return fmt.Errorf("invalid proxy URL %q: %s", c.ProxyURL, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("invalid proxy URL %q: the scheme and host are required", c.ProxyURL)
+	}
+	return nil
+}
+
+// Proxy returns the function selecting the proxy of the requests, which is
+// meant to be used as the Proxy of an http.Transport.
+func (c Config) Proxy() func(*http.Request) (*url.URL, error) {
+	proxy := httpproxy.FromEnvironment()
+	if c.ProxyURL != "" {
+		proxy.HTTPProxy = c.ProxyURL
+		proxy.HTTPSProxy = c.ProxyURL
+	}
+	if len(c.NoProxy) > 0 {
+		proxy.NoProxy = strings.Join(c.NoProxy, ",")
+	}
+	proxyFunc := proxy.ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+}
+
+// NewClient returns an HTTP client with the given timeout, zero meaning no
+// timeout, using the proxy and the certificate authorities of the
+// configuration.
+func (c Config) NewClient(timeout time.Duration) (*http.Client, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = c.Proxy()
+	if c.TrustedCAFile != "" {
+		pool, err := corev2.LoadCACerts(c.TrustedCAFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
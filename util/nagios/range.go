@@ -0,0 +1,157 @@
+// Package nagios implements the range and threshold syntax of the Nagios
+// plugin development guidelines, so that thresholds evaluated by Sensu
+// behave like the ones of the plugins. The ranges are evaluated by the backend,
+// in the threshold filter functions; the agent doesn't evaluate thresholds.
+package nagios
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Check statuses returned by Threshold.Status
+const (
+	StatusOK       uint32 = 0
+	StatusWarning  uint32 = 1
+	StatusCritical uint32 = 2
+)
+
+// Range is a range of values, in the [@]start:end format, raising an alert
+// for the values outside of it, or for the values inside of it when it is
+// inverted with @. The bounds are inclusive.
+//
+//	10      alerts if value < 0 or value > 10
+//	10:     alerts if value < 10
+//	~:10    alerts if value > 10
+//	10:20   alerts if value < 10 or value > 20
+//	@10:20  alerts if 10 <= value <= 20
+type Range struct {
+	// Start is the lower bound of the range, -Inf if unbounded.
+	Start float64
+
+	// End is the upper bound of the range, +Inf if unbounded.
+	End float64
+
+	// Inside inverts the range, raising an alert for the values inside it.
+	Inside bool
+}
+
+// ParseRange parses a range in the Nagios format.
+func ParseRange(s string) (*Range, error) {
+	spec := strings.TrimSpace(s)
+	r := &Range{}
+	if strings.HasPrefix(spec, "@") {
+		r.Inside = true
+		spec = spec[1:]
+	}
+	if spec == "" {
+		return nil, fmt.Errorf("invalid range %q: range is empty", s)
+	}
+
+	start, end := "", spec
+	if i := strings.Index(spec, ":"); i >= 0 {
+		start, end = spec[:i], spec[i+1:]
+	}
+
+	switch start {
+	case "":
+		r.Start = 0
+	case "~":
+		r.Start = math.Inf(-1)
+	default:
+		v, err := strconv.ParseFloat(start, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid range %q: invalid start %q", s, start)
+		}
+		r.Start = v
+	}
+
+	if end == "" {
+		r.End = math.Inf(1)
+	} else {
+		v, err := strconv.ParseFloat(end, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid range %q: invalid end %q", s, end)
+		}
+		r.End = v
+	}
+
+	if r.Start > r.End {
+		return nil, fmt.Errorf("invalid range %q: start is greater than end", s)
+	}
+	return r, nil
+}
+
+// Alert returns true if the value raises an alert.
+func (r *Range) Alert(value float64) bool {
+	inside := value >= r.Start && value <= r.End
+	if r.Inside {
+		return inside
+	}
+	return !inside
+}
+
+// String returns the range in the Nagios format.
+func (r *Range) String() string {
+	var b strings.Builder
+	if r.Inside {
+		b.WriteString("@")
+	}
+	if math.IsInf(r.Start, -1) {
+		b.WriteString("~:")
+	} else if r.Start != 0 {
+		b.WriteString(formatFloat(r.Start))
+		b.WriteString(":")
+	}
+	if !math.IsInf(r.End, 1) {
+		b.WriteString(formatFloat(r.End))
+	} else if r.Start == 0 {
+		// An explicit start is required for unbounded ranges
+		b.WriteString("0:")
+	}
+	return b.String()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// Threshold combines the warning and critical ranges of a value. Either range
+// may be nil.
+type Threshold struct {
+	Warning  *Range
+	Critical *Range
+}
+
+// ParseThreshold parses the warning and critical ranges of a threshold. An
+// empty range never raises an alert.
+func ParseThreshold(warning, critical string) (*Threshold, error) {
+	t := &Threshold{}
+	var err error
+	if strings.TrimSpace(warning) != "" {
+		if t.Warning, err = ParseRange(warning); err != nil {
+			return nil, fmt.Errorf("warning threshold: %s", err)
+		}
+	}
+	if strings.TrimSpace(critical) != "" {
+		if t.Critical, err = ParseRange(critical); err != nil {
+			return nil, fmt.Errorf("critical threshold: %s", err)
+		}
+	}
+	return t, nil
+}
+
+// Status returns the check status of the value, StatusCritical if it raises
+// a critical alert, StatusWarning if it raises a warning alert, and StatusOK
+// otherwise.
+func (t *Threshold) Status(value float64) uint32 {
+	if t.Critical != nil && t.Critical.Alert(value) {
+		return StatusCritical
+	}
+	if t.Warning != nil && t.Warning.Alert(value) {
+		return StatusWarning
+	}
+	return StatusOK
+}
@@ -0,0 +1,93 @@
+package nagios
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    *Range
+		wantErr bool
+	}{
+		{spec: "10", want: &Range{Start: 0, End: 10}},
+		{spec: "10:", want: &Range{Start: 10, End: math.Inf(1)}},
+		{spec: "~:10", want: &Range{Start: math.Inf(-1), End: 10}},
+		{spec: "10:20", want: &Range{Start: 10, End: 20}},
+		{spec: "@10:20", want: &Range{Start: 10, End: 20, Inside: true}},
+		{spec: "-5.5:0.5", want: &Range{Start: -5.5, End: 0.5}},
+		{spec: " 95 ", want: &Range{Start: 0, End: 95}},
+		{spec: "", wantErr: true},
+		{spec: "@", wantErr: true},
+		{spec: "20:10", wantErr: true},
+		{spec: "foo", wantErr: true},
+		{spec: "1:bar", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseRange(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRangeAlert(t *testing.T) {
+	tests := []struct {
+		spec  string
+		value float64
+		want  bool
+	}{
+		{"10", -1, true},
+		{"10", 0, false},
+		{"10", 10, false},
+		{"10", 10.1, true},
+		{"10:", 9, true},
+		{"10:", 1e9, false},
+		{"~:10", -1e9, false},
+		{"~:10", 11, true},
+		{"10:20", 15, false},
+		{"10:20", 21, true},
+		{"@10:20", 10, true},
+		{"@10:20", 20, true},
+		{"@10:20", 21, false},
+	}
+	for _, tt := range tests {
+		r, err := ParseRange(tt.spec)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, r.Alert(tt.value), "%s with %v", tt.spec, tt.value)
+	}
+}
+
+func TestRangeString(t *testing.T) {
+	for _, spec := range []string{"10", "10:", "~:10", "~:", "0:", "10:20", "@10:20", "-5.5:0.5"} {
+		r, err := ParseRange(spec)
+		require.NoError(t, err)
+		assert.Equal(t, spec, r.String())
+	}
+}
+
+func TestThresholdStatus(t *testing.T) {
+	threshold, err := ParseThreshold("~:80", "~:95")
+	require.NoError(t, err)
+	assert.Equal(t, StatusOK, threshold.Status(50))
+	assert.Equal(t, StatusWarning, threshold.Status(85))
+	assert.Equal(t, StatusCritical, threshold.Status(99))
+
+	threshold, err = ParseThreshold("", "@0")
+	require.NoError(t, err)
+	assert.Nil(t, threshold.Warning)
+	assert.Equal(t, StatusCritical, threshold.Status(0))
+	assert.Equal(t, StatusOK, threshold.Status(1))
+
+	_, err = ParseThreshold("10:5", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+// Package systemd notifies systemd of the state of the services started with
+// Type=notify, and pings the systemd watchdog of the services configured with
+// WatchdogSec. Both do nothing when the service was not started by systemd.
+package systemd
+
+import (
+	"context"
+	"time"
+
+	"github.com/coreos/go-systemd/daemon"
+	"github.com/sirupsen/logrus"
+)
+
+// States of the service
+const (
+	// Ready tells systemd that the service is started and serving
+	Ready = daemon.SdNotifyReady
+
+	// Reloading tells systemd that the service is restarting its components,
+	// until it's Ready again
+	Reloading = daemon.SdNotifyReloading
+
+	// Stopping tells systemd that the service is shutting down
+	Stopping = daemon.SdNotifyStopping
+)
+
+var logger = logrus.WithFields(logrus.Fields{
+	"component": "systemd",
+})
+
+// Notify notifies systemd of the state of the service.
+func Notify(state string) {
+	sent, err := daemon.SdNotify(false, state)
+	if err != nil {
+		logger.WithError(err).Warnf("couldn't notify systemd: %s", state)
+		return
+	}
+	if sent {
+		logger.Debugf("notified systemd: %s", state)
+	}
+}
+
+// Watchdog pings the systemd watchdog at half of its interval until ctx is
+// done, as long as the service passes the health check, so systemd restarts
+// the service when it hangs. The health check is given half of the interval
+// to complete, and is skipped if nil. Watchdog returns right away if the
+// watchdog is not enabled.
+func Watchdog(ctx context.Context, check func(context.Context) error) {
+	interval, err := daemon.SdWatchdogEnabled(false)
+	if err != nil {
+		logger.WithError(err).Warn("invalid systemd watchdog configuration")
+		return
+	}
+	if interval == 0 {
+		return
+	}
+	logger.Infof("pinging the systemd watchdog every %s", interval/2)
+
+	ticker := time.NewTicker(interval / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if check != nil {
+			tctx, cancel := context.WithTimeout(ctx, interval/2)
+			err := check(tctx)
+			cancel()
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.WithError(err).Error("health check failed, not pinging the systemd watchdog")
+				}
+				continue
+			}
+		}
+		Notify(daemon.SdNotifyWatchdog)
+	}
+}
@@ -0,0 +1,99 @@
+package systemd
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// notifySocket listens on a systemd notification socket, set as NOTIFY_SOCKET
+// until the returned function is called
+func notifySocket(t *testing.T) (*net.UnixConn, func()) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "systemd")
+	require.NoError(t, err)
+	path := filepath.Join(dir, "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	require.NoError(t, os.Setenv("NOTIFY_SOCKET", path))
+
+	return conn, func() {
+		_ = os.Unsetenv("NOTIFY_SOCKET")
+		_ = conn.Close()
+		_ = os.RemoveAll(dir)
+	}
+}
+
+func readNotification(t *testing.T, conn *net.UnixConn, timeout time.Duration) (string, error) {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(timeout)))
+	buf := make([]byte, 64)
+	n, err := conn.Read(buf)
+	return string(buf[:n]), err
+}
+
+func TestNotify(t *testing.T) {
+	// Nothing happens without systemd
+	Notify(Ready)
+
+	conn, cleanup := notifySocket(t)
+	defer cleanup()
+
+	Notify(Ready)
+	state, err := readNotification(t, conn, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "READY=1", state)
+
+	Notify(Stopping)
+	state, err = readNotification(t, conn, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "STOPPING=1", state)
+}
+
+func TestWatchdog(t *testing.T) {
+	conn, cleanup := notifySocket(t)
+	defer cleanup()
+
+	// The watchdog is disabled
+	Watchdog(context.Background(), nil)
+
+	require.NoError(t, os.Setenv("WATCHDOG_USEC", "100000"))
+	defer func() { _ = os.Unsetenv("WATCHDOG_USEC") }()
+
+	healthy := make(chan bool, 1)
+	healthy <- true
+	check := func(context.Context) error {
+		if <-healthy {
+			return nil
+		}
+		return errors.New("unhealthy")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		Watchdog(ctx, check)
+		close(done)
+	}()
+
+	state, err := readNotification(t, conn, time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, "WATCHDOG=1", state)
+
+	// The watchdog is not pinged when the health check fails
+	healthy <- false
+	_, err = readNotification(t, conn, 200*time.Millisecond)
+	assert.Error(t, err)
+
+	cancel()
+	healthy <- true
+	<-done
+}
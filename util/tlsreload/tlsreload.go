@@ -0,0 +1,168 @@
+// Package tlsreload keeps the certificates of TLS servers up to date with their
+// files, so that rotated certificates are used without restarting the server.
+package tlsreload
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	corev2 "github.com/sensu/sensu-go/api/core/v2"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultInterval is the default interval at which the files are checked for
+// changes.
+const DefaultInterval = 30 * time.Second
+
+var logger = logrus.WithFields(logrus.Fields{
+	"component": "tls",
+})
+
+// Reloader holds a certificate, and optionally a pool of client CAs, and
+// reloads them from their files when the files change or when the process
+// receives SIGHUP.
+type Reloader struct {
+	certFile string
+	keyFile  string
+	caFile   string
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTime   time.Time
+}
+
+// New returns a Reloader for the given certificate and key files, and client
+// CA file. caFile can be empty.
+func New(certFile, keyFile, caFile string) (*Reloader, error) {
+	r := &Reloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		caFile:   caFile,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload loads the certificate and the client CAs from their files. The
+// previous ones are kept if they can't be loaded.
+func (r *Reloader) Reload() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("error loading tls server certificate: %s", err)
+	}
+	var clientCAs *x509.CertPool
+	if r.caFile != "" {
+		clientCAs, err = corev2.LoadCACerts(r.caFile)
+		if err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTime = modTime
+	return nil
+}
+
+// GetCertificate returns the current certificate. It can be used as the
+// GetCertificate function of a tls.Config.
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// Configure makes cfg use the current certificate, and the current client CAs
+// if the Reloader has a client CA file, instead of the ones loaded when cfg
+// was created.
+func (r *Reloader) Configure(cfg *tls.Config) {
+	cfg.Certificates = nil
+	cfg.NameToCertificate = nil
+	cfg.GetCertificate = r.GetCertificate
+	if r.caFile == "" {
+		return
+	}
+	base := cfg.Clone()
+	cfg.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		clientCfg := base.Clone()
+		clientCfg.ClientCAs = r.clientCAs
+		return clientCfg, nil
+	}
+}
+
+// Watch reloads the certificate when the process receives SIGHUP, or when
+// the files are modified, which is checked at the given interval. It returns
+// when stop is closed.
+func (r *Reloader) Watch(stop <-chan struct{}, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-hup:
+			r.reload("received SIGHUP")
+		case <-ticker.C:
+			modTime, err := r.latestModTime()
+			if err != nil {
+				logger.WithError(err).Warn("unable to check tls certificate files")
+				continue
+			}
+			r.mu.RLock()
+			changed := modTime.After(r.modTime)
+			r.mu.RUnlock()
+			if changed {
+				r.reload("tls certificate files changed")
+			}
+		}
+	}
+}
+
+func (r *Reloader) reload(reason string) {
+	fields := logrus.Fields{"cert_file": r.certFile, "reason": reason}
+	if err := r.Reload(); err != nil {
+		logger.WithFields(fields).WithError(err).Error("unable to reload tls certificate, keeping the previous one")
+		return
+	}
+	logger.WithFields(fields).Info("reloaded tls certificate")
+}
+
+// latestModTime returns the latest modification time of the files.
+func (r *Reloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{r.certFile, r.keyFile, r.caFile} {
+		if file == "" {
+			continue
+		}
+		info, err := os.Stat(file)
+		if err != nil {
+			return latest, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
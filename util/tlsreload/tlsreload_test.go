@@ -0,0 +1,112 @@
+package tlsreload
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// copyCerts copies the test certificates into a temporary directory, so that
+// they can be modified.
+func copyCerts(t *testing.T) (dir, certFile, keyFile, caFile string) {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "tlsreload")
+	require.NoError(t, err)
+
+	for _, name := range []string{"etcd1.pem", "etcd1-key.pem", "ca.pem"} {
+		b, err := ioutil.ReadFile(filepath.Join("..", "ssl", name))
+		require.NoError(t, err)
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), b, 0600))
+	}
+	return dir, filepath.Join(dir, "etcd1.pem"), filepath.Join(dir, "etcd1-key.pem"), filepath.Join(dir, "ca.pem")
+}
+
+func TestReload(t *testing.T) {
+	dir, certFile, keyFile, caFile := copyCerts(t)
+	defer os.RemoveAll(dir)
+
+	r, err := New(certFile, keyFile, caFile)
+	require.NoError(t, err)
+
+	cert, err := r.GetCertificate(nil)
+	require.NoError(t, err)
+	require.NotNil(t, cert)
+
+	// The certificate is replaced when it can be loaded
+	require.NoError(t, r.Reload())
+	reloaded, err := r.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.False(t, cert == reloaded)
+
+	// The previous certificate is kept when the new one can't be loaded
+	require.NoError(t, ioutil.WriteFile(certFile, []byte("garbage"), 0600))
+	assert.Error(t, r.Reload())
+	current, err := r.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.True(t, reloaded == current)
+}
+
+func TestNewInvalidFiles(t *testing.T) {
+	_, err := New("missing.pem", "missing-key.pem", "")
+	assert.Error(t, err)
+}
+
+func TestConfigure(t *testing.T) {
+	dir, certFile, keyFile, caFile := copyCerts(t)
+	defer os.RemoveAll(dir)
+
+	r, err := New(certFile, keyFile, caFile)
+	require.NoError(t, err)
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{{}},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	}
+	r.Configure(cfg)
+	assert.Empty(t, cfg.Certificates)
+	require.NotNil(t, cfg.GetCertificate)
+	require.NotNil(t, cfg.GetConfigForClient)
+
+	clientCfg, err := cfg.GetConfigForClient(nil)
+	require.NoError(t, err)
+	assert.NotNil(t, clientCfg.ClientCAs)
+	assert.Nil(t, clientCfg.GetConfigForClient)
+	assert.Equal(t, tls.RequireAndVerifyClientCert, clientCfg.ClientAuth)
+}
+
+func TestWatch(t *testing.T) {
+	dir, certFile, keyFile, caFile := copyCerts(t)
+	defer os.RemoveAll(dir)
+
+	r, err := New(certFile, keyFile, caFile)
+	require.NoError(t, err)
+	cert, _ := r.GetCertificate(nil)
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.Watch(stop, 10*time.Millisecond)
+	}()
+
+	// Touch the certificate, as cert-manager would when rotating it
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, future, future))
+
+	reloaded := false
+	for i := 0; i < 100 && !reloaded; i++ {
+		time.Sleep(10 * time.Millisecond)
+		current, _ := r.GetCertificate(nil)
+		reloaded = current != cert
+	}
+	assert.True(t, reloaded)
+
+	close(stop)
+	<-done
+}